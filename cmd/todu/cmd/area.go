@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var areaCmd = &cobra.Command{
+	Use:   "area",
+	Short: "Group projects into areas such as work, home, or health",
+	Long: `Group projects into areas of life such as work, home, or health.
+
+Areas are kept in the config file under "areas", mapping each area to the
+names of its projects. A project belongs to at most one area.
+
+Use an area to narrow task lists or daily review sections:
+  todu task list --area work
+
+  review:
+    daily:
+      custom:
+        - name: Home
+          area: home`,
+}
+
+var areaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List areas and their projects",
+	Args:  cobra.NoArgs,
+	RunE:  runAreaList,
+}
+
+var areaAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add an area",
+	Long: `Add an empty area. Use area assign to add projects to it.
+
+Example:
+  todu area add health`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAreaAdd,
+}
+
+var areaAssignCmd = &cobra.Command{
+	Use:   "assign <project> <area>",
+	Short: "Assign a project to an area",
+	Long: `Assign a project (ID or name) to an area, moving it out of any area it
+was in before. The area is added if it doesn't exist yet.
+
+Example:
+  todu area assign todu.sh work`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAreaAssign,
+}
+
+func init() {
+	rootCmd.AddCommand(areaCmd)
+	areaCmd.AddCommand(areaListCmd)
+	areaCmd.AddCommand(areaAddCmd)
+	areaCmd.AddCommand(areaAssignCmd)
+}
+
+func runAreaList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	names := make([]string, 0, len(cfg.Areas))
+	for name := range cfg.Areas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if GetOutputFormat() == "json" {
+		areas := make(map[string][]string, len(cfg.Areas))
+		for name, projects := range cfg.Areas {
+			areas[name] = append([]string{}, projects...)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(areas)
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No areas defined. Add one with: todu area add <name>")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AREA\tPROJECTS")
+	for _, name := range names {
+		projects := strings.Join(cfg.Areas[name], ", ")
+		if projects == "" {
+			projects = "(none)"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, projects)
+	}
+	return w.Flush()
+}
+
+func runAreaAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name, err := normalizeAreaName(args[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.AreaProjects(name); ok {
+		return fmt.Errorf("area %q already exists", name)
+	}
+
+	areas := cloneAreas(cfg.Areas)
+	areas[name] = []string{}
+	if err := config.SetAreas(GetConfigFile(), areas); err != nil {
+		return fmt.Errorf("failed to save areas: %w", err)
+	}
+
+	fmt.Printf("Added area %s\n", name)
+	return nil
+}
+
+func runAreaAssign(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	area, err := normalizeAreaName(args[1])
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	projectID, err := resolveProjectID(ctx, apiClient, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	project, err := apiClient.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	areas := assignArea(cfg.Areas, project.Name, area)
+	if err := config.SetAreas(GetConfigFile(), areas); err != nil {
+		return fmt.Errorf("failed to save areas: %w", err)
+	}
+
+	fmt.Printf("Assigned project %s to area %s\n", project.Name, area)
+	return nil
+}
+
+// normalizeAreaName lowercases an area name, as the config file's keys are
+// case-insensitive.
+func normalizeAreaName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("area name cannot be empty")
+	}
+	return name, nil
+}
+
+// cloneAreas returns a copy of areas that can be changed without touching
+// the loaded config.
+func cloneAreas(areas map[string][]string) map[string][]string {
+	clone := make(map[string][]string, len(areas))
+	for name, projects := range areas {
+		clone[name] = append([]string{}, projects...)
+	}
+	return clone
+}
+
+// assignArea returns a copy of areas with project moved into area, creating
+// the area if needed. Project names compare case-insensitively.
+func assignArea(areas map[string][]string, project, area string) map[string][]string {
+	result := cloneAreas(areas)
+	for name, projects := range result {
+		result[name] = slices.DeleteFunc(projects, func(p string) bool {
+			return strings.EqualFold(p, project)
+		})
+	}
+	result[area] = append(result[area], project)
+	return result
+}
+
+// resolveAreaProjectIDs returns the IDs of the projects in an area.
+// Project names in the config that no longer exist are ignored.
+func resolveAreaProjectIDs(ctx context.Context, apiClient *api.Client, cfg *config.Config, area string) (map[int]bool, error) {
+	names, ok := cfg.AreaProjects(area)
+	if !ok {
+		return nil, fmt.Errorf("unknown area %q (see 'todu area list')", area)
+	}
+
+	projects, err := apiClient.ListProjects(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	ids := make(map[int]bool)
+	for _, p := range projects {
+		for _, name := range names {
+			if strings.EqualFold(p.Name, name) {
+				ids[p.ID] = true
+			}
+		}
+	}
+	return ids, nil
+}
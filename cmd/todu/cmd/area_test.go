@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAssignArea(t *testing.T) {
+	areas := map[string][]string{
+		"work": {"todu.sh", "Website"},
+		"home": {"Garden"},
+	}
+
+	result := assignArea(areas, "website", "home")
+
+	if !slices.Equal(result["work"], []string{"todu.sh"}) {
+		t.Errorf("Expected project removed from its old area, got %v", result["work"])
+	}
+	if !slices.Equal(result["home"], []string{"Garden", "website"}) {
+		t.Errorf("Expected project added to the new area, got %v", result["home"])
+	}
+	if len(areas["work"]) != 2 {
+		t.Errorf("Expected the original areas unchanged, got %v", areas["work"])
+	}
+
+	result = assignArea(nil, "Gym", "health")
+	if !slices.Equal(result["health"], []string{"Gym"}) {
+		t.Errorf("Expected a new area, got %v", result)
+	}
+}
+
+func TestNormalizeAreaName(t *testing.T) {
+	if name, err := normalizeAreaName(" Work "); err != nil || name != "work" {
+		t.Errorf("Expected work, got %q (%v)", name, err)
+	}
+	if _, err := normalizeAreaName("  "); err == nil {
+		t.Error("Expected error for an empty area name")
+	}
+}
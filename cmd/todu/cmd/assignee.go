@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var assigneeCmd = &cobra.Command{
+	Use:   "assignee",
+	Short: "List, rename, and merge assignees",
+	Long: `Manage the assignee names used by tasks and templates.
+
+Synced systems bring in assignees as they are named there, so the same
+person can end up under several names (a renamed account, a bot, a
+display name). Rename and merge update every task and template that uses
+the old names.
+
+A task synced from an external system gets its assignees from there again
+when it next changes externally, so rename the account there too.`,
+}
+
+var assigneeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List assignees and how many tasks and templates use them",
+	Args:  cobra.NoArgs,
+	RunE:  runAssigneeList,
+}
+
+var assigneeRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename an assignee on every task and template",
+	Long: `Rename an assignee on every task and template that uses it.
+
+The new name must not be in use yet; use assignee merge to combine two
+assignees.
+
+Example:
+  todu assignee rename jdoe jane`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAssigneeRename,
+}
+
+var assigneeMergeCmd = &cobra.Command{
+	Use:   "merge <name>... --into <name>",
+	Short: "Merge assignees into one",
+	Long: `Replace one or more assignees with another on every task and template.
+Tasks that had several of the merged names get the target once.
+
+Examples:
+  todu assignee merge jdoe jane-doe --into jane
+  todu assignee merge dependabot[bot] renovate[bot] --into bots --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAssigneeMerge,
+}
+
+var (
+	// Merge flags
+	assigneeMergeInto string
+	assigneeMergeYes  bool
+
+	// Rename flags
+	assigneeRenameYes bool
+)
+
+func init() {
+	rootCmd.AddCommand(assigneeCmd)
+	assigneeCmd.AddCommand(assigneeListCmd)
+	assigneeCmd.AddCommand(assigneeRenameCmd)
+	assigneeCmd.AddCommand(assigneeMergeCmd)
+
+	addYesFlag(assigneeRenameCmd, &assigneeRenameYes)
+
+	assigneeMergeCmd.Flags().StringVar(&assigneeMergeInto, "into", "", "Assignee to merge into (required)")
+	_ = assigneeMergeCmd.MarkFlagRequired("into")
+	addYesFlag(assigneeMergeCmd, &assigneeMergeYes)
+}
+
+// assigneeUsage counts the tasks and templates using an assignee.
+type assigneeUsage struct {
+	Name      string `json:"name"`
+	Tasks     int    `json:"tasks"`
+	Templates int    `json:"templates"`
+}
+
+// countAssignees returns each assignee used by tasks or templates, sorted
+// by name.
+func countAssignees(tasks []*types.Task, templates []*types.RecurringTaskTemplate) []assigneeUsage {
+	usage := make(map[string]*assigneeUsage)
+	get := func(name string) *assigneeUsage {
+		if usage[name] == nil {
+			usage[name] = &assigneeUsage{Name: name}
+		}
+		return usage[name]
+	}
+	for _, task := range tasks {
+		for _, a := range task.Assignees {
+			get(a.Name).Tasks++
+		}
+	}
+	for _, tmpl := range templates {
+		for _, a := range tmpl.Assignees {
+			get(a.Name).Templates++
+		}
+	}
+
+	result := make([]assigneeUsage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// replaceAssignees returns names with every name in from replaced by to,
+// keeping to only once. Reports whether anything was replaced.
+func replaceAssignees(names, from []string, to string) ([]string, bool) {
+	var result []string
+	replaced := false
+	for _, name := range names {
+		if slices.Contains(from, name) {
+			replaced = true
+			name = to
+		}
+		if !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result, replaced
+}
+
+// assigneeNames returns the names of assignees.
+func assigneeNames(assignees []types.Assignee) []string {
+	names := make([]string, len(assignees))
+	for i, a := range assignees {
+		names[i] = a.Name
+	}
+	return names
+}
+
+// listAssigneeUsers fetches every task and template, the only places
+// assignees are recorded.
+func listAssigneeUsers(ctx context.Context, apiClient *api.Client) ([]*types.Task, []*types.RecurringTaskTemplate, error) {
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	templates, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{Limit: 1000})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return tasks, templates, nil
+}
+
+func runAssigneeList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, templates, err := listAssigneeUsers(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+	usage := countAssignees(tasks, templates)
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(usage)
+	}
+
+	if len(usage) == 0 {
+		fmt.Println("No assignees found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSIGNEE\tTASKS\tTEMPLATES")
+	for _, u := range usage {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", u.Name, u.Tasks, u.Templates)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nTotal: %d assignees\n", len(usage))
+	return nil
+}
+
+func runAssigneeRename(cmd *cobra.Command, args []string) error {
+	return mergeAssignees(args[:1], args[1], true, assigneeRenameYes)
+}
+
+func runAssigneeMerge(cmd *cobra.Command, args []string) error {
+	return mergeAssignees(args, assigneeMergeInto, false, assigneeMergeYes)
+}
+
+// mergeAssignees replaces the from assignees with into on every task and
+// template. For a rename, into must not be in use yet.
+func mergeAssignees(from []string, into string, rename, yes bool) error {
+	if into == "" {
+		return fmt.Errorf("assignee name cannot be empty")
+	}
+	if slices.Contains(from, into) {
+		return fmt.Errorf("cannot merge %q into itself", into)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, templates, err := listAssigneeUsers(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, u := range countAssignees(tasks, templates) {
+		used[u.Name] = true
+	}
+	for _, name := range from {
+		if !used[name] {
+			return fmt.Errorf("assignee %q not found", name)
+		}
+	}
+	if rename && used[into] {
+		return fmt.Errorf("assignee %q already exists; use 'todu assignee merge %s --into %s' to combine them", into, from[0], into)
+	}
+
+	type taskChange struct {
+		task  *types.Task
+		names []string
+	}
+	type templateChange struct {
+		template *types.RecurringTaskTemplate
+		names    []string
+	}
+	var taskChanges []taskChange
+	var templateChanges []templateChange
+	for _, task := range tasks {
+		if names, ok := replaceAssignees(assigneeNames(task.Assignees), from, into); ok {
+			taskChanges = append(taskChanges, taskChange{task, names})
+		}
+	}
+	for _, tmpl := range templates {
+		if names, ok := replaceAssignees(assigneeNames(tmpl.Assignees), from, into); ok {
+			templateChanges = append(templateChanges, templateChange{tmpl, names})
+		}
+	}
+
+	question := fmt.Sprintf("Replace with %q on %d tasks and %d templates?", into, len(taskChanges), len(templateChanges))
+	ok, err := confirmAction(cfg, yes, question, from...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	for i, c := range taskChanges {
+		if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, c.task.ID, c.task, &types.TaskUpdate{Assignees: c.names}, ""); err != nil {
+			return fmt.Errorf("failed to update task #%d (%d of %d tasks updated): %w", c.task.ID, i, len(taskChanges), err)
+		}
+	}
+	for i, c := range templateChanges {
+		if _, err := apiClient.UpdateTemplate(ctx, c.template.ID, &types.RecurringTaskTemplateUpdate{Assignees: c.names}); err != nil {
+			return fmt.Errorf("failed to update template #%d (%d of %d templates updated): %w", c.template.ID, i, len(templateChanges), err)
+		}
+	}
+
+	fmt.Printf("Updated %d tasks and %d templates to use %q\n", len(taskChanges), len(templateChanges), into)
+	return nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestCountAssignees(t *testing.T) {
+	tasks := []*types.Task{
+		{ID: 1, Assignees: []types.Assignee{{Name: "jane"}, {Name: "bot"}}},
+		{ID: 2, Assignees: []types.Assignee{{Name: "jane"}}},
+		{ID: 3},
+	}
+	templates := []*types.RecurringTaskTemplate{
+		{ID: 1, Assignees: []types.Assignee{{Name: "jdoe"}}},
+	}
+
+	got := countAssignees(tasks, templates)
+	want := []assigneeUsage{
+		{Name: "bot", Tasks: 1},
+		{Name: "jane", Tasks: 2},
+		{Name: "jdoe", Templates: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countAssignees() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReplaceAssignees(t *testing.T) {
+	tests := []struct {
+		names    []string
+		want     []string
+		replaced bool
+	}{
+		{[]string{"jdoe", "bob"}, []string{"jane", "bob"}, true},
+		{[]string{"jane", "jdoe", "jane-doe"}, []string{"jane"}, true},
+		{[]string{"bob"}, []string{"bob"}, false},
+		{nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		got, replaced := replaceAssignees(tt.names, []string{"jdoe", "jane-doe"}, "jane")
+		if replaced != tt.replaced || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("replaceAssignees(%v) = %v, %v, want %v, %v", tt.names, got, replaced, tt.want, tt.replaced)
+		}
+	}
+}
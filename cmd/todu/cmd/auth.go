@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"bufio"
-	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -28,9 +28,72 @@ Use --no-browser to print the URL instead of opening it automatically.`,
 	RunE: runAuth,
 }
 
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what the configured API key can do",
+	Long: `Show the scopes of the configured API key, as reported by the server.
+
+A key with only the read scope puts todu in read-only mode: commands that
+would change data fail before doing anything, and read commands still
+work. This is useful for a dashboard-only key shared with other devices.
+Servers that don't report scopes are assumed to allow everything.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthStatus,
+}
+
 func init() {
 	authCmd.Flags().BoolVar(&noBrowser, "no-browser", false, "print the URL instead of opening the browser")
 	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+	if cfg.APIKey == "" {
+		return fmt.Errorf("no API key configured; run 'todu auth' to create one")
+	}
+
+	info, err := api.NewClient(cfg.APIURL, cfg.APIKey).GetKeyInfo(commandContext())
+	if err != nil {
+		return fmt.Errorf("failed to get API key info: %w", err)
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]any{
+			"api_url":   cfg.APIURL,
+			"name":      info.Name,
+			"scopes":    info.Scopes,
+			"read_only": info.ReadOnly(),
+		})
+	}
+
+	fmt.Printf("API URL: %s\n", cfg.APIURL)
+	if info.Name != "" {
+		fmt.Printf("Key:     %s\n", info.Name)
+	}
+	fmt.Printf("Access:  %s\n", keyAccess(info))
+	return nil
+}
+
+// keyAccess describes what a key's scopes allow.
+func keyAccess(info *api.KeyInfo) string {
+	switch {
+	case len(info.Scopes) == 0:
+		return "full (server does not report scopes)"
+	case info.ReadOnly():
+		return "read-only (" + strings.Join(info.Scopes, ", ") + ")"
+	default:
+		return "read and write (" + strings.Join(info.Scopes, ", ") + ")"
+	}
 }
 
 func runAuth(cmd *cobra.Command, args []string) error {
@@ -38,6 +101,9 @@ func runAuth(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if err := requireInteractive("todu auth", "set api_key in the config file or $TODU_API_KEY"); err != nil {
+		return err
+	}
 
 	loginURL := buildLoginURL(cfg.APIURL)
 
@@ -87,6 +153,9 @@ func runAuth(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		fmt.Println("✓ API key verified successfully.")
+		if info, err := api.NewClient(cfg.APIURL, apiKey).GetKeyInfo(commandContext()); err == nil && info.ReadOnly() {
+			fmt.Println("ℹ This key is read-only: commands that change data will be refused.")
+		}
 	}
 
 	// Save the key
@@ -130,7 +199,7 @@ func isValidKeyFormat(key string) bool {
 // verifyAPIKey checks that the given API key works against the API.
 func verifyAPIKey(apiURL, apiKey string) error {
 	client := api.NewClient(apiURL, apiKey)
-	_, err := client.ListProjects(context.Background(), nil)
+	_, err := client.ListProjects(commandContext(), nil)
 	return err
 }
 
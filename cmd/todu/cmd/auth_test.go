@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/evcraddock/todu.sh/internal/api"
 	"github.com/spf13/cobra"
 )
 
@@ -188,3 +189,19 @@ func TestPromptYesNo(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyAccess(t *testing.T) {
+	tests := []struct {
+		scopes []string
+		want   string
+	}{
+		{nil, "full (server does not report scopes)"},
+		{[]string{"read"}, "read-only (read)"},
+		{[]string{"read", "write"}, "read and write (read, write)"},
+	}
+	for _, tt := range tests {
+		if got := keyAccess(&api.KeyInfo{Scopes: tt.scopes}); got != tt.want {
+			t.Errorf("keyAccess(%v) = %q, want %q", tt.scopes, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// defaultTaskColumns are the columns shown by "task list" when neither
+// --columns nor output.task_columns is set.
+var defaultTaskColumns = []string{"id", "title", "status", "priority", "project", "due"}
+
+// taskColumn describes a column that can be shown in task tables.
+type taskColumn struct {
+	header string
+	value  func(task *types.Task, data *taskTableData) string
+}
+
+// taskTableData holds values looked up once for a whole task table.
+type taskTableData struct {
+	// projectNames maps project IDs to names for the project column.
+	projectNames map[int]string
+
+	// syncStates maps task IDs to sync states for the sync column.
+	syncStates map[int]sync.State
+}
+
+// taskColumns lists the available task table columns by name.
+var taskColumns = map[string]taskColumn{
+	"id": {"ID", func(t *types.Task, _ *taskTableData) string {
+		return fmt.Sprintf("%d", t.ID)
+	}},
+	"title": {"TITLE", func(t *types.Task, _ *taskTableData) string {
+		return t.Title
+	}},
+	"status": {"STATUS", func(t *types.Task, _ *taskTableData) string {
+		return t.Status
+	}},
+	"priority": {"PRIORITY", func(t *types.Task, _ *taskTableData) string {
+		if t.Priority == nil {
+			return ""
+		}
+		return *t.Priority
+	}},
+	"project": {"PROJECT", func(t *types.Task, data *taskTableData) string {
+		// Use project name if available, otherwise fall back to ID
+		if name := data.projectNames[t.ProjectID]; name != "" {
+			return name
+		}
+		return fmt.Sprintf("%d", t.ProjectID)
+	}},
+	"due": {"DUE DATE", func(t *types.Task, _ *taskTableData) string {
+		return formatDateOnly(t.DueDate)
+	}},
+	"scheduled": {"SCHEDULED", func(t *types.Task, _ *taskTableData) string {
+		return formatDateOnly(t.ScheduledDate)
+	}},
+	"labels": {"LABELS", func(t *types.Task, _ *taskTableData) string {
+		names := make([]string, len(t.Labels))
+		for i, label := range t.Labels {
+			names[i] = label.DisplayName()
+		}
+		return strings.Join(names, ",")
+	}},
+	"assignees": {"ASSIGNEES", func(t *types.Task, _ *taskTableData) string {
+		names := make([]string, len(t.Assignees))
+		for i, assignee := range t.Assignees {
+			names[i] = assignee.Name
+		}
+		return strings.Join(names, ",")
+	}},
+	"external_id": {"EXTERNAL ID", func(t *types.Task, _ *taskTableData) string {
+		return t.ExternalID
+	}},
+	"template": {"TEMPLATE", func(t *types.Task, _ *taskTableData) string {
+		if t.TemplateID == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", *t.TemplateID)
+	}},
+	"created": {"CREATED", func(t *types.Task, _ *taskTableData) string {
+		return t.CreatedAt.Local().Format("2006-01-02")
+	}},
+	"updated": {"UPDATED", func(t *types.Task, _ *taskTableData) string {
+		return t.UpdatedAt.Local().Format("2006-01-02")
+	}},
+	"sync": {"SYNC", func(t *types.Task, data *taskTableData) string {
+		return string(data.syncStates[t.ID])
+	}},
+}
+
+// taskColumnNames returns the available column names in display order.
+func taskColumnNames() []string {
+	return []string{"id", "title", "status", "priority", "project", "due", "scheduled",
+		"labels", "assignees", "external_id", "template", "created", "updated", "sync"}
+}
+
+// parseTaskColumns validates a list of column names, accepting both
+// repeated and comma-separated values. Returns the defaults if names is empty.
+func parseTaskColumns(names []string) ([]string, error) {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, entry := range names {
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" || seen[name] {
+				continue
+			}
+			if _, ok := taskColumns[name]; !ok {
+				return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(taskColumnNames(), ", "))
+			}
+			seen[name] = true
+			columns = append(columns, name)
+		}
+	}
+
+	if len(columns) == 0 {
+		return defaultTaskColumns, nil
+	}
+	return columns, nil
+}
+
+// tableMode controls how task tables fit long titles.
+type tableMode int
+
+const (
+	// tableNormal truncates titles to fit the terminal.
+	tableNormal tableMode = iota
+
+	// tableWide never truncates titles, wrapping them onto extra lines.
+	tableWide
+
+	// tableCompact prints one dense line per task without headers.
+	tableCompact
+)
+
+const (
+	// defaultTitleWidth is the title width used when the terminal width is unknown.
+	defaultTitleWidth = 40
+
+	// minTitleWidth is the narrowest title column used on small terminals.
+	minTitleWidth = 10
+
+	// columnPadding matches the tabwriter padding between columns.
+	columnPadding = 2
+)
+
+// taskTableLayout describes how to render a task table.
+type taskTableLayout struct {
+	mode tableMode
+
+	// width is the terminal width in columns, or 0 if unknown.
+	width int
+}
+
+// writeTaskTable writes tasks as a table with the given columns.
+// data may be nil if no column needs it.
+func writeTaskTable(out io.Writer, tasks []*types.Task, columns []string, data *taskTableData, layout taskTableLayout) {
+	if data == nil {
+		data = &taskTableData{}
+	}
+
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		rows[i] = make([]string, len(columns))
+		for j, name := range columns {
+			rows[i][j] = taskColumns[name].value(task, data)
+		}
+	}
+
+	if layout.mode == tableCompact {
+		writeCompactRows(out, rows, layout.width)
+		return
+	}
+
+	titleIndex := slices.Index(columns, "title")
+	titleWidth := availableTitleWidth(columns, rows, titleIndex, layout.width)
+
+	w := tabwriter.NewWriter(out, 0, 0, columnPadding, ' ', 0)
+
+	headers := make([]string, len(columns))
+	underlines := make([]string, len(columns))
+	for i, name := range columns {
+		headers[i] = taskColumns[name].header
+		underlines[i] = strings.Repeat("-", len(headers[i]))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(underlines, "\t"))
+
+	for _, row := range rows {
+		if titleIndex < 0 {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+			continue
+		}
+
+		switch {
+		case layout.mode == tableWide && titleWidth > 0:
+			// Continuation lines only carry the wrapped title
+			for i, line := range wrapText(row[titleIndex], titleWidth) {
+				if i > 0 {
+					row = make([]string, len(columns))
+				}
+				row[titleIndex] = line
+				fmt.Fprintln(w, strings.Join(row, "\t"))
+			}
+		case layout.mode == tableWide:
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		default:
+			row[titleIndex] = fitWidth(row[titleIndex], titleWidth)
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	}
+
+	w.Flush()
+}
+
+// availableTitleWidth returns how wide the title column can be so the table
+// fits the terminal. Returns defaultTitleWidth if the terminal width is
+// unknown, and 0 if there is no title column.
+func availableTitleWidth(columns []string, rows [][]string, titleIndex, termWidth int) int {
+	if titleIndex < 0 {
+		return 0
+	}
+	if termWidth <= 0 {
+		return defaultTitleWidth
+	}
+
+	used := 0
+	for i, name := range columns {
+		if i == titleIndex {
+			continue
+		}
+		width := utf8.RuneCountInString(taskColumns[name].header)
+		for _, row := range rows {
+			width = max(width, utf8.RuneCountInString(row[i]))
+		}
+		used += width + columnPadding
+	}
+
+	return max(termWidth-used, minTitleWidth)
+}
+
+// writeCompactRows writes each row on a single line, skipping empty values
+// and cutting lines at the terminal width.
+func writeCompactRows(out io.Writer, rows [][]string, termWidth int) {
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+		for _, value := range row {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+		line := strings.Join(values, " ")
+		if termWidth > 0 {
+			line = fitWidth(line, termWidth)
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+// fitWidth truncates s to at most width runes, marking truncation with "...".
+func fitWidth(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// wrapText splits s into lines of at most width runes, breaking at spaces
+// where possible.
+func wrapText(s string, width int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(s) {
+		wordRunes := []rune(word)
+
+		// Break words longer than a whole line
+		for len(wordRunes) > width {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(wordRunes[:width]))
+			wordRunes = wordRunes[width:]
+		}
+
+		switch {
+		case len(line) == 0:
+			line = wordRunes
+		case len(line)+1+len(wordRunes) <= width:
+			line = append(append(line, ' '), wordRunes...)
+		default:
+			lines = append(lines, string(line))
+			line = wordRunes
+		}
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// formatDateOnly formats a date-only field, or returns empty if unset.
+func formatDateOnly(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	// Use UTC for date-only fields to preserve the stored date
+	// (Local() would shift midnight UTC to previous day in western timezones)
+	return t.UTC().Format("2006-01-02")
+}
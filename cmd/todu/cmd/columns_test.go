@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParseTaskColumns(t *testing.T) {
+	columns, err := parseTaskColumns(nil)
+	if err != nil {
+		t.Fatalf("parseTaskColumns failed: %v", err)
+	}
+	if strings.Join(columns, ",") != strings.Join(defaultTaskColumns, ",") {
+		t.Errorf("Expected default columns, got %v", columns)
+	}
+
+	columns, err = parseTaskColumns([]string{"id,Title", " labels ", "id"})
+	if err != nil {
+		t.Fatalf("parseTaskColumns failed: %v", err)
+	}
+	if strings.Join(columns, ",") != "id,title,labels" {
+		t.Errorf("Expected normalized, deduplicated columns, got %v", columns)
+	}
+
+	if _, err := parseTaskColumns([]string{"id,bogus"}); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+func TestTaskColumnNamesMatchColumns(t *testing.T) {
+	names := taskColumnNames()
+	if len(names) != len(taskColumns) {
+		t.Fatalf("Expected %d column names, got %d", len(taskColumns), len(names))
+	}
+	for _, name := range names {
+		if _, ok := taskColumns[name]; !ok {
+			t.Errorf("Column %q has no definition", name)
+		}
+	}
+}
+
+func TestWriteTaskTable(t *testing.T) {
+	scheduled := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	templateID := 9
+	tasks := []*types.Task{{
+		ID:            12,
+		Title:         "Renew passport",
+		ProjectID:     3,
+		ExternalID:    "gh-42",
+		ScheduledDate: &scheduled,
+		TemplateID:    &templateID,
+		Labels:        []types.Label{{Name: "errand"}, {Name: "admin"}},
+		Assignees:     []types.Assignee{{Name: "sam"}},
+	}}
+
+	var buf bytes.Buffer
+	writeTaskTable(&buf, tasks, []string{"id", "project", "scheduled", "labels", "assignees", "external_id", "template"}, &taskTableData{projectNames: map[int]string{3: "Home"}}, taskTableLayout{})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header, underline, and one row, got:\n%s", buf.String())
+	}
+	if fields := strings.Fields(lines[0]); fields[0] != "ID" || fields[1] != "PROJECT" || fields[2] != "SCHEDULED" {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	for _, want := range []string{"12", "Home", "2024-03-04", "errand,admin", "sam", "gh-42", "9"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("Expected row to contain %q, got %q", want, lines[2])
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"abcdefghijkl xy", 5, []string{"abcde", "fghij", "kl xy"}},
+		{"", 10, []string{""}},
+	}
+
+	for _, tt := range tests {
+		got := wrapText(tt.text, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("wrapText(%q, %d) = %q, expected %q", tt.text, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	if got := fitWidth("hello world", 8); got != "hello..." {
+		t.Errorf("Expected truncated text, got %q", got)
+	}
+	if got := fitWidth("héllo", 5); got != "héllo" {
+		t.Errorf("Expected multi-byte text to fit by runes, got %q", got)
+	}
+	if got := fitWidth("hello", 2); got != "he" {
+		t.Errorf("Expected hard cut for tiny widths, got %q", got)
+	}
+}
+
+func TestWriteTaskTableLayouts(t *testing.T) {
+	tasks := []*types.Task{{ID: 1, Title: "Write the quarterly planning document for the team", Status: "active"}}
+	columns := []string{"id", "title", "status"}
+
+	// Unknown width keeps the historical fixed truncation
+	var buf bytes.Buffer
+	writeTaskTable(&buf, tasks, columns, nil, taskTableLayout{mode: tableNormal})
+	if !strings.Contains(buf.String(), "Write the quarterly planning document...") {
+		t.Errorf("Expected 40-char truncation, got:\n%s", buf.String())
+	}
+
+	// Narrow terminal shrinks the title to fit
+	buf.Reset()
+	writeTaskTable(&buf, tasks, columns, nil, taskTableLayout{mode: tableNormal, width: 30})
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		if len(strings.TrimRight(line, " ")) > 30 {
+			t.Errorf("Expected lines to fit 30 columns, got %q", line)
+		}
+	}
+
+	// Wide mode wraps instead of truncating
+	buf.Reset()
+	writeTaskTable(&buf, tasks, columns, nil, taskTableLayout{mode: tableWide, width: 40})
+	if strings.Contains(buf.String(), "...") {
+		t.Errorf("Expected no truncation in wide mode, got:\n%s", buf.String())
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) < 4 {
+		t.Errorf("Expected wrapped title on extra lines, got:\n%s", buf.String())
+	}
+
+	// Compact mode has no headers and one line per task
+	buf.Reset()
+	writeTaskTable(&buf, tasks, columns, nil, taskTableLayout{mode: tableCompact})
+	if got := strings.TrimSpace(buf.String()); got != "1 Write the quarterly planning document for the team active" {
+		t.Errorf("Unexpected compact output: %q", got)
+	}
+}
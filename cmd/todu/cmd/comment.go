@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Work with task comments and journal entries",
+}
+
+var commentReactCmd = &cobra.Command{
+	Use:   "react <id> <emoji>",
+	Short: "React to a comment with an emoji",
+	Long: `Add an emoji reaction to a task comment or journal entry, or remove it
+with --remove. Comment IDs are shown by 'todu task show'.
+
+The reaction can be an emoji or one of GitHub's reaction names: +1, -1,
+laugh, confused, heart, hooray, rocket, or eyes. Reactions on comments
+synced to GitHub are added there on the next sync; other emoji and removed
+reactions stay in Todu.
+
+Examples:
+  todu comment react 42 👍
+  todu comment react 42 rocket
+  todu comment react 42 👍 --remove`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCommentReact,
+}
+
+var (
+	// React flags
+	commentReactRemove bool
+	commentReactAuthor string
+)
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+	commentCmd.AddCommand(commentReactCmd)
+
+	commentReactCmd.Flags().BoolVar(&commentReactRemove, "remove", false, "Remove the reaction instead of adding it")
+	commentReactCmd.Flags().StringVar(&commentReactAuthor, "author", "", "Author of the reaction (default: from config or git)")
+}
+
+func runCommentReact(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	commentID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	emoji, err := types.ParseReaction(args[1])
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	comment, err := apiClient.GetComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	author := getAuthor(commentReactAuthor, cfg)
+	reactions, changed := setReaction(comment.Reactions, emoji, author, !commentReactRemove)
+	if changed {
+		comment, err = apiClient.UpdateComment(ctx, commentID, &types.CommentUpdate{Reactions: &reactions})
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		if comment.HasReaction(emoji, author) == commentReactRemove {
+			return fmt.Errorf("reaction was not saved; the server may not support comment reactions")
+		}
+	}
+
+	if GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(comment, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	switch {
+	case !changed && commentReactRemove:
+		fmt.Printf("%s had not reacted %s to comment #%d\n", author, emoji, commentID)
+	case !changed:
+		fmt.Printf("%s already reacted %s to comment #%d\n", author, emoji, commentID)
+	case commentReactRemove:
+		fmt.Printf("Removed %s from comment #%d\n", emoji, commentID)
+	default:
+		fmt.Printf("Reacted %s to comment #%d\n", emoji, commentID)
+	}
+	if summary := comment.ReactionSummary(); summary != "" {
+		fmt.Printf("Reactions: %s\n", summary)
+	}
+	return nil
+}
+
+// setReaction adds author's emoji reaction to reactions, or removes it if
+// add is false. Reports whether reactions changed.
+func setReaction(reactions []types.Reaction, emoji, author string, add bool) ([]types.Reaction, bool) {
+	result := make([]types.Reaction, 0, len(reactions)+1)
+	found := false
+	for _, r := range reactions {
+		if r.Emoji == emoji && r.Author == author {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		result = append(result, r)
+	}
+	if add && !found {
+		result = append(result, types.Reaction{Emoji: emoji, Author: author})
+	}
+	return result, found != add
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestSetReaction(t *testing.T) {
+	reactions := []types.Reaction{{Emoji: "👍", Author: "bob"}}
+
+	added, changed := setReaction(reactions, "👍", "alice", true)
+	if !changed || len(added) != 2 || added[1] != (types.Reaction{Emoji: "👍", Author: "alice"}) {
+		t.Fatalf("Expected alice's 👍 to be added, got %v (changed %v)", added, changed)
+	}
+
+	if again, changed := setReaction(added, "👍", "alice", true); changed || len(again) != 2 {
+		t.Errorf("Expected adding twice to change nothing, got %v (changed %v)", again, changed)
+	}
+
+	removed, changed := setReaction(added, "👍", "bob", false)
+	if !changed || len(removed) != 1 || removed[0].Author != "alice" {
+		t.Errorf("Expected bob's 👍 to be removed, got %v (changed %v)", removed, changed)
+	}
+
+	if _, changed := setReaction(reactions, "🎉", "bob", false); changed {
+		t.Error("Expected removing a missing reaction to change nothing")
+	}
+}
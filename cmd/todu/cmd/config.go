@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/hooks"
 	"github.com/spf13/cobra"
 )
 
@@ -64,10 +67,135 @@ This shows the effective configuration after merging:
 		}
 		fmt.Println()
 
+		// Sync Configuration
+		fmt.Println("Sync:")
+		if len(cfg.Sync.CommentUpdates) > 0 {
+			projectIDs := make([]string, len(cfg.Sync.CommentUpdates))
+			for i, id := range cfg.Sync.CommentUpdates {
+				projectIDs[i] = fmt.Sprintf("%d", id)
+			}
+			fmt.Printf("  Comment Updates: [%s]\n", strings.Join(projectIDs, ", "))
+		} else {
+			fmt.Println("  Comment Updates: [] (disabled)")
+		}
+		fmt.Printf("  Duplicates: %s\n", cfg.Sync.Duplicates)
+		fmt.Println()
+
+		// Recurring Tasks Configuration
+		fmt.Println("Recurring Tasks:")
+		fmt.Printf("  Enabled:  %t\n", cfg.RecurringTasks.Enabled)
+		fmt.Printf("  Catch-up: %s\n", cfg.RecurringTasks.Catchup)
+		fmt.Printf("  Timezone: %s\n", cfg.RecurringTasks.Timezone)
+		fmt.Println()
+
+		// Review Configuration
+		fmt.Println("Review:")
+		if len(cfg.Review.Daily.Sections) > 0 {
+			fmt.Printf("  Daily Sections: %s\n", strings.Join(cfg.Review.Daily.Sections, ", "))
+		} else {
+			fmt.Println("  Daily Sections: (default)")
+		}
+		fmt.Printf("  Daily Soon Days: %d\n", cfg.Review.Daily.SoonDays)
+		fmt.Printf("  Daily Statuses: %s\n", strings.Join(cfg.Review.Daily.Statuses, ", "))
+		fmt.Printf("  Daily Carryover: %t\n", cfg.Review.Daily.Carryover)
+		fmt.Printf("  Daily Journal: %t\n", cfg.Review.Daily.Journal)
+		fmt.Printf("  Daily Remind At: %s\n", cfg.Review.Daily.RemindAt)
+		fmt.Printf("  Weekly Remind After: %d days\n", cfg.Review.Weekly.RemindAfterDays)
+		for _, c := range cfg.Review.Daily.Custom {
+			fmt.Printf("  Custom Section: %s\n", c.Name)
+		}
+		fmt.Println()
+
+		// Reports Configuration
+		fmt.Println("Reports:")
+		if cfg.Reports.OlderThan != "" {
+			fmt.Printf("  Prune Older Than: %s\n", cfg.Reports.OlderThan)
+		} else {
+			fmt.Println("  Prune Older Than: (not set)")
+		}
+		fmt.Printf("  Archive: %t\n", cfg.Reports.Archive)
+		fmt.Println()
+
+		// Holidays Configuration
+		fmt.Println("Holidays:")
+		if cfg.Holidays.Country != "" {
+			fmt.Printf("  Country: %s\n", cfg.Holidays.Country)
+		} else {
+			fmt.Println("  Country: (not set)")
+		}
+		if cfg.Holidays.ICS != "" {
+			fmt.Printf("  ICS: %s\n", cfg.Holidays.ICS)
+		} else {
+			fmt.Println("  ICS: (not set)")
+		}
+		fmt.Println()
+
+		// Suggest Configuration
+		fmt.Println("Suggest:")
+		fmt.Printf("  Capacity: %s\n", cfg.Suggest.Capacity)
+		fmt.Printf("  Default Estimate: %s\n", cfg.Suggest.DefaultEstimate)
+		fmt.Println()
+
+		// Mentions Configuration
+		fmt.Println("Mentions:")
+		if len(cfg.Mentions.Names) > 0 {
+			fmt.Printf("  Names: %s\n", strings.Join(cfg.Mentions.Names, ", "))
+		} else {
+			fmt.Println("  Names: (author only)")
+		}
+		fmt.Println()
+
+		// Export Configuration
+		fmt.Println("Export:")
+		if cfg.Export.Obsidian.Vault != "" {
+			fmt.Printf("  Obsidian Vault: %s\n", cfg.Export.Obsidian.Vault)
+			fmt.Printf("  Obsidian Folder: %s\n", cfg.Export.Obsidian.Folder)
+		} else {
+			fmt.Println("  Obsidian Vault: (not set)")
+		}
+		if cfg.Export.Webhook.URL != "" {
+			fmt.Printf("  Webhook URL: %s\n", cfg.Export.Webhook.URL)
+		} else {
+			fmt.Println("  Webhook URL: (not set)")
+		}
+		switch {
+		case cfg.Export.Email.SMTP.Host != "":
+			fmt.Printf("  Email: %s via %s:%d\n", strings.Join(cfg.Export.Email.To, ", "), cfg.Export.Email.SMTP.Host, cfg.Export.Email.SMTP.Port)
+		case cfg.Export.Email.Sendmail != "":
+			fmt.Printf("  Email: %s via %s\n", strings.Join(cfg.Export.Email.To, ", "), cfg.Export.Email.Sendmail)
+		default:
+			fmt.Println("  Email: (not set)")
+		}
+		fmt.Println()
+
+		// Wellness Configuration
+		fmt.Println("Wellness:")
+		if cfg.Wellness.MaxNext > 0 {
+			fmt.Printf("  Max Next: %d\n", cfg.Wellness.MaxNext)
+		} else {
+			fmt.Println("  Max Next: (no limit)")
+		}
+		if len(cfg.Wellness.Milestones) > 0 {
+			milestones := make([]string, len(cfg.Wellness.Milestones))
+			for i, m := range cfg.Wellness.Milestones {
+				milestones[i] = strconv.Itoa(m)
+			}
+			fmt.Printf("  Milestones: %s\n", strings.Join(milestones, ", "))
+		} else {
+			fmt.Println("  Milestones: (none)")
+		}
+		fmt.Printf("  Streak Freeze: %d\n", cfg.Wellness.StreakFreeze)
+		fmt.Println()
+
 		// Output Configuration
 		fmt.Println("Output:")
 		fmt.Printf("  Format: %s\n", cfg.Output.Format)
 		fmt.Printf("  Color:  %t\n", cfg.Output.Color)
+		if len(cfg.Output.TaskColumns) > 0 {
+			fmt.Printf("  Task Columns: %s\n", strings.Join(cfg.Output.TaskColumns, ", "))
+		} else {
+			fmt.Printf("  Task Columns: %s (default)\n", strings.Join(defaultTaskColumns, ", "))
+		}
 		fmt.Println()
 
 		// Defaults Configuration
@@ -82,6 +210,9 @@ This shows the effective configuration after merging:
 		} else {
 			fmt.Println("  Project: (not set)")
 		}
+		fmt.Printf("  Confirmations: %t\n", cfg.Confirmations)
+		fmt.Printf("  Day Start: %s\n", cfg.DayStart)
+		fmt.Printf("  Priorities: %s\n", strings.Join(cfg.Priorities, ", "))
 		fmt.Println()
 
 		// Paths Configuration
@@ -91,6 +222,64 @@ This shows the effective configuration after merging:
 		} else {
 			fmt.Println("  Local Reports: (not set)")
 		}
+		if cfg.RulesFile != "" {
+			fmt.Printf("  Rules File:    %s\n", cfg.RulesFile)
+		} else {
+			fmt.Println("  Rules File:    (default)")
+		}
+
+		// Hooks Configuration
+		if len(cfg.Hooks) > 0 {
+			fmt.Println()
+			fmt.Println("Hooks:")
+			for _, event := range hooks.Events {
+				if command, ok := cfg.Hooks[event]; ok {
+					fmt.Printf("  %s: %s\n", event, command)
+				}
+			}
+		}
+
+		// Custom Fields Configuration
+		if len(cfg.Fields) > 0 {
+			fmt.Println()
+			fmt.Println("Fields:")
+			projects := make([]string, 0, len(cfg.Fields))
+			for project := range cfg.Fields {
+				projects = append(projects, project)
+			}
+			sort.Strings(projects)
+			for _, project := range projects {
+				fmt.Printf("  %s: %s\n", project, strings.Join(cfg.Fields[project], ", "))
+			}
+		}
+
+		// Views Configuration
+		if len(cfg.Views) > 0 {
+			fmt.Println()
+			fmt.Println("Views:")
+			names := make([]string, 0, len(cfg.Views))
+			for name := range cfg.Views {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("  %s: %s\n", name, describeView(cfg.Views[name]))
+			}
+		}
+
+		// SLA Configuration
+		if len(cfg.SLA) > 0 {
+			fmt.Println()
+			fmt.Println("SLA:")
+			labels := make([]string, 0, len(cfg.SLA))
+			for label := range cfg.SLA {
+				labels = append(labels, label)
+			}
+			sort.Strings(labels)
+			for _, label := range labels {
+				fmt.Printf("  %s: %s\n", label, cfg.SLA[label])
+			}
+		}
 
 		return nil
 	},
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// errNoTerminal is returned when a confirmation prompt has no terminal to
+// read the answer from.
+var errNoTerminal = errors.New("no terminal available")
+
+// promptTerminal opens the terminal that prompt answers are read from.
+// Tests replace it to supply answers.
+var promptTerminal = openTerminal
+
+// confirmAction asks the user to confirm a destructive action, listing the
+// affected items first. Answers are read from the terminal rather than
+// stdin, so commands still prompt when their input is piped.
+//
+// Returns true without asking if yes is set (--yes) or confirmations are
+// turned off in the config. In non-interactive mode, or without a terminal
+// to ask on, returns an error suggesting --yes.
+func confirmAction(cfg *config.Config, yes bool, question string, affected ...string) (bool, error) {
+	if yes || (cfg != nil && !cfg.Confirmations) {
+		return true, nil
+	}
+	if err := requireInteractive("confirmation", "use --yes to proceed without asking"); err != nil {
+		return false, err
+	}
+
+	tty, err := promptTerminal()
+	if err != nil {
+		return false, fmt.Errorf("cannot ask for confirmation (%v); use --yes to proceed without asking", err)
+	}
+	defer tty.Close()
+
+	for _, line := range affected {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	return readConfirmation(tty)
+}
+
+// readConfirmation reads a yes/no answer. Anything but "y" or "yes" is no.
+func readConfirmation(r io.Reader) (bool, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && line == "" {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// addYesFlag registers --yes/-y on a command that asks for confirmation.
+func addYesFlag(cmd *cobra.Command, yes *bool) {
+	cmd.Flags().BoolVarP(yes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+// addForceAlias keeps a command's old --force flag working as a deprecated
+// alias of --yes.
+func addForceAlias(cmd *cobra.Command, yes *bool, shorthand string) {
+	cmd.Flags().BoolVarP(yes, "force", shorthand, false, "Skip the confirmation prompt")
+	_ = cmd.Flags().MarkDeprecated("force", "use --yes instead")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+)
+
+func TestConfirmAction(t *testing.T) {
+	defer func(orig func() (io.ReadCloser, error)) { promptTerminal = orig }(promptTerminal)
+	simulateTerminal(t)
+
+	answer := func(input string) func() (io.ReadCloser, error) {
+		return func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(input)), nil }
+	}
+	enabled := &config.Config{Confirmations: true}
+
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		yes      bool
+		terminal func() (io.ReadCloser, error)
+		want     bool
+		wantErr  bool
+	}{
+		{name: "yes answer", cfg: enabled, terminal: answer("y\n"), want: true},
+		{name: "full yes answer", cfg: enabled, terminal: answer(" YES \n"), want: true},
+		{name: "empty answer", cfg: enabled, terminal: answer("\n"), want: false},
+		{name: "end of input", cfg: enabled, terminal: answer(""), want: false},
+		{name: "yes flag skips prompt", cfg: enabled, yes: true, terminal: answer("n\n"), want: true},
+		{name: "confirmations off", cfg: &config.Config{Confirmations: false}, terminal: answer("n\n"), want: true},
+		{name: "no config prompts", terminal: answer("y\n"), want: true},
+		{
+			name: "no terminal",
+			cfg:  enabled,
+			terminal: func() (io.ReadCloser, error) {
+				return nil, errNoTerminal
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptTerminal = tt.terminal
+			got, err := confirmAction(tt.cfg, tt.yes, "Delete?", "Task #1: Test")
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "--yes") {
+					t.Errorf("Expected error suggesting --yes, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("confirmAction failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfirmActionNonInteractive(t *testing.T) {
+	defer func(orig func() (io.ReadCloser, error)) { promptTerminal = orig }(promptTerminal)
+	simulateTerminal(t)
+	promptTerminal = func() (io.ReadCloser, error) {
+		t.Fatal("Expected no prompt in non-interactive mode")
+		return nil, nil
+	}
+	nonInteractive = true
+	defer func() { nonInteractive = false }()
+
+	_, err := confirmAction(&config.Config{Confirmations: true}, false, "Delete?")
+	var promptErr *promptRequiredError
+	if !errors.As(err, &promptErr) || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("Expected prompt-required error suggesting --yes, got %v", err)
+	}
+
+	if ok, err := confirmAction(&config.Config{Confirmations: true}, true, "Delete?"); err != nil || !ok {
+		t.Errorf("Expected --yes to proceed in non-interactive mode, got %t, %v", ok, err)
+	}
+}
+
+func TestReadConfirmationError(t *testing.T) {
+	if _, err := readConfirmation(errReader{}); err == nil {
+		t.Error("Expected read error to be returned")
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
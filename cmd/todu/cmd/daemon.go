@@ -31,7 +31,10 @@ var daemonStartCmd = &cobra.Command{
 	Long: `Start the sync daemon in foreground mode.
 
 The daemon will run until interrupted (Ctrl+C) and log to stdout.
-For background operation, use 'daemon install' to install as a service.`,
+For background operation, use 'daemon install' to install as a service.
+
+While running, the daemon also answers read commands run with --via-daemon
+over ~/.config/todu/daemon.sock.`,
 	RunE: runDaemonStart,
 }
 
@@ -130,18 +133,47 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	if err := requireWritable(commandContext(), apiClient, "daemon"); err != nil {
+		return err
+	}
 
 	// Use the global plugin registry (with plugins already registered)
 	pluginRegistry := registry.Default
 
 	// Create sync engine
 	syncEngine := sync.NewEngine(apiClient, pluginRegistry)
+	if snapshotDir, err := sync.DefaultSnapshotDir(); err == nil {
+		syncEngine.WithSnapshots(sync.NewFileSnapshotStore(snapshotDir))
+	}
+	if runDir, err := sync.DefaultRunDir(); err == nil {
+		syncEngine.WithRuns(sync.NewRunStore(runDir))
+	}
 
 	// Create daemon
 	d := daemon.New(syncEngine, apiClient, cfg)
 
+	ctx, cancel := context.WithCancel(commandContext())
+
+	// Answer read commands run with --via-daemon, sparing them a process
+	// of their own
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		path, err := daemon.SocketPath()
+		if err == nil {
+			err = daemon.Serve(ctx, path, runDaemonRequest)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: --via-daemon is unavailable: %v\n", err)
+		}
+	}()
+	// Wait for the socket to be removed before exiting
+	defer func() {
+		cancel()
+		<-served
+	}()
+
 	// Start daemon (blocks until stopped)
-	ctx := context.Background()
 	if err := d.Start(ctx); err != nil {
 		return fmt.Errorf("daemon failed: %w", err)
 	}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/daemon"
+)
+
+// viaDaemonFlag asks for a read command to be answered by the running daemon
+const viaDaemonFlag = "--via-daemon"
+
+// daemonReadCommands are the commands the daemon runs for --via-daemon.
+// They only read, so running them in the daemon's process is safe.
+var daemonReadCommands = []string{
+	"todu project list",
+	"todu project show",
+	"todu show",
+	"todu system list",
+	"todu task list",
+	"todu task show",
+	"todu template list",
+	"todu view list",
+	"todu view show",
+}
+
+// daemonLocalFlags make a read command interactive or long-running, or
+// record, replay or count its traffic for the whole process, so it runs
+// locally instead
+var daemonLocalFlags = []string{"-i", "--interactive", "--watch", "--record", "--replay", "--show-api-stats"}
+
+// runViaDaemon runs the command in args through the daemon if --via-daemon
+// is among them, the command is a read command, and a daemon is listening.
+// Returns false to run the command locally instead.
+func runViaDaemon(args []string) (int, bool) {
+	var rest []string
+	found := false
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if arg == viaDaemonFlag || arg == viaDaemonFlag+"=true" {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	if !found || !daemonCanRun(rest) {
+		return 0, false
+	}
+
+	path, err := daemon.SocketPath()
+	if err != nil {
+		return 0, false
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return 0, false
+	}
+	resp, err := daemon.Call(path, daemon.Request{
+		Args:     rest,
+		Dir:      dir,
+		Columns:  terminalWidth(),
+		Terminal: stdoutIsTerminal(),
+	})
+	if err != nil {
+		// No daemon, or it went away; the command still works locally
+		return 0, false
+	}
+
+	fmt.Fprint(os.Stdout, resp.Stdout)
+	fmt.Fprint(os.Stderr, resp.Stderr)
+	return resp.ExitCode, true
+}
+
+// daemonCanRun reports whether args run a read command the daemon answers
+func daemonCanRun(args []string) bool {
+	target, flags, err := rootCmd.Find(args)
+	if err != nil || !slices.Contains(daemonReadCommands, target.CommandPath()) {
+		return false
+	}
+	for _, arg := range flags {
+		name, _, _ := strings.Cut(arg, "=")
+		if slices.Contains(daemonLocalFlags, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// runDaemonRequest runs a read command in the daemon for a --via-daemon
+// caller, as if it ran in the caller's directory and terminal, and returns
+// its output. The command gets the caller's directory, width and output
+// through its context rather than the process's, which the daemon's sync
+// loop uses at the same time.
+func runDaemonRequest(ctx context.Context, req daemon.Request) (resp daemon.Response) {
+	if !daemonCanRun(req.Args) {
+		return daemon.Response{Stderr: "Error: the daemon only runs read commands\n", ExitCode: 1}
+	}
+
+	var stdout, stderr bytes.Buffer
+	ctx = withInvocation(ctx, &invocation{
+		dir:      req.Dir,
+		columns:  req.Columns,
+		terminal: req.Terminal,
+	})
+
+	// Commands write to their writers, which subcommands inherit
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+				fmt.Fprintf(&stderr, "Error: %v\n", err)
+			}
+		}()
+		// There's no terminal to prompt on
+		err = executeCommand(ctx, rootCmd, req.Args, map[string]string{"non-interactive": "true"})
+	}()
+
+	resp.Stdout, resp.Stderr = stdout.String(), stderr.String()
+	if err != nil {
+		resp.ExitCode = 1
+		var promptErr *promptRequiredError
+		if errors.As(err, &promptErr) {
+			resp.ExitCode = exitPromptRequired
+		}
+		if ctx.Err() != nil {
+			resp.ExitCode = exitInterrupted
+		}
+	}
+	return resp
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/daemon"
+)
+
+func TestRunDaemonRequest(t *testing.T) {
+	newGoldenServer(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"project", "list"},
+		{"task", "list"},
+		{"task", "list", "--format", "json", "--label", "writing"},
+		{"task", "show", "1"},
+		{"show", "#1"},
+		{"system", "list"},
+		{"template", "list"},
+		{"view", "list"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			want := runGolden(t, args...)
+
+			// The config is found in the caller's directory, the home
+			// directory newGoldenServer wrote it to
+			var resp daemon.Response
+			stdout, stderr := captureOutput(func() {
+				resp = runDaemonRequest(context.Background(), daemon.Request{
+					Args:    args,
+					Dir:     os.Getenv("HOME"),
+					Columns: 100,
+				})
+			})
+
+			if got := resp.Stdout + resp.Stderr; got != want {
+				t.Errorf("Expected the output of a local run\n--- got ---\n%s\n--- want ---\n%s", got, want)
+			}
+			if stdout != "" || stderr != "" {
+				t.Errorf("Expected nothing written to the process's output, got %q and %q", stdout, stderr)
+			}
+			if dir, _ := os.Getwd(); dir != wd {
+				t.Errorf("Expected the working directory left at %s, got %s", wd, dir)
+			}
+		})
+	}
+}
+
+func TestRunDaemonRequestErrors(t *testing.T) {
+	newGoldenServer(t)
+	dir := os.Getenv("HOME")
+
+	resp := runDaemonRequest(context.Background(), daemon.Request{Args: []string{"task", "show", "99"}, Dir: dir})
+	if resp.ExitCode != 1 || !strings.Contains(resp.Stderr, "Error: failed to get task") {
+		t.Errorf("Expected the missing task reported, got %+v", resp)
+	}
+
+	resp = runDaemonRequest(context.Background(), daemon.Request{Args: []string{"task", "close", "1"}, Dir: dir})
+	if resp.ExitCode != 1 || !strings.Contains(resp.Stderr, "only runs read commands") {
+		t.Errorf("Expected the write command refused, got %+v", resp)
+	}
+}
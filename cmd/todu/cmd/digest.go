@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/export"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Email review digests",
+	Long:  `Email the daily or weekly review as a digest.`,
+}
+
+var digestSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Email the daily or weekly review",
+	Long: `Render the daily or weekly review and email it, with the markdown as the
+plaintext part and an HTML rendering as the alternative. Meant to be run
+from cron.
+
+Mail goes through the SMTP server in export.email.smtp, or, when no host
+is set, is piped to the export.email.sendmail command. Set the SMTP
+password with $TODU_EXPORT_EMAIL_SMTP_PASSWORD to keep it out of the
+config file.
+
+Examples:
+  todu digest send --daily
+  todu digest send --weekly --recipient me@example.com
+  todu digest send --daily --dry-run    # Print the message instead
+
+Cron example (weekdays at 7:00, Sundays at 18:00):
+  0 7 * * 1-5  todu digest send --daily
+  0 18 * * 0   todu digest send --weekly`,
+	Args: cobra.NoArgs,
+	RunE: runDigestSend,
+}
+
+var (
+	// Send flags
+	digestSendDaily      bool
+	digestSendWeekly     bool
+	digestSendRecipients []string
+	digestSendDryRun     bool
+)
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+	digestCmd.AddCommand(digestSendCmd)
+
+	digestSendCmd.Flags().BoolVar(&digestSendDaily, "daily", false, "Send the daily review")
+	digestSendCmd.Flags().BoolVar(&digestSendWeekly, "weekly", false, "Send the weekly review")
+	digestSendCmd.Flags().StringSliceVar(&digestSendRecipients, "recipient", []string{}, "Send to this address instead of export.email.to (repeatable)")
+	digestSendCmd.Flags().BoolVar(&digestSendDryRun, "dry-run", false, "Print the email instead of sending it")
+	digestSendCmd.MarkFlagsOneRequired("daily", "weekly")
+	digestSendCmd.MarkFlagsMutuallyExclusive("daily", "weekly")
+}
+
+func runDigestSend(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	email := emailSettings(cfg)
+	if len(digestSendRecipients) > 0 {
+		email.To = digestSendRecipients
+	}
+	adapter, err := export.New("email", export.Options{Email: email})
+	if err != nil && !digestSendDryRun {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	doc, err := digestDocument(ctx, apiClient, cfg, digestSendWeekly)
+	if err != nil {
+		return err
+	}
+
+	if digestSendDryRun {
+		message, err := email.Message(doc, time.Now())
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(message)
+		return err
+	}
+
+	sentTo, err := adapter.Export(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+	fmt.Printf("%s sent to: %s\n", doc.Title, sentTo)
+	return nil
+}
+
+// digestDocument renders the daily review, or the weekly review if weekly
+// is set, for today.
+func digestDocument(ctx context.Context, client *api.Client, cfg *config.Config, weekly bool) (*export.Document, error) {
+	if weekly {
+		weeklyOpts, err := weeklyReviewOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		markdown, err := review.WeeklyReport(ctx, client, now, weeklyOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate weekly review: %w", err)
+		}
+		start, end := review.WeekBoundaries(now)
+		return &export.Document{
+			Kind:     export.KindWeeklyReview,
+			Title:    fmt.Sprintf("Weekly Review: %s to %s", start.Format("01-02-2006"), end.Format("01-02-2006")),
+			Date:     end,
+			Markdown: markdown,
+		}, nil
+	}
+
+	opts, err := dailyReviewOptions(cfg, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	targetDate := daystart.Today(opts.DayStart)
+	markdown, err := review.DailyReport(ctx, client, targetDate, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate daily review: %w", err)
+	}
+	return &export.Document{
+		Kind:     export.KindDailyReview,
+		Title:    "Daily Review: " + targetDate.Format("01-02-2006"),
+		Date:     targetDate,
+		Markdown: markdown,
+	}, nil
+}
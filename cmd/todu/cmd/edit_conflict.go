@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/sync"
+)
+
+// resolveEditConflict handles an edit whose subject changed on the server
+// while the editor was open. It warns, shows how saving the edit would
+// change the current version, and asks whether to overwrite it. Returns nil
+// if the user chooses to overwrite; otherwise the edit is kept in a file,
+// named in the returned error, so it isn't lost.
+func resolveEditConflict(what, current, edited string, fields []string) error {
+	fmt.Fprintf(os.Stderr, "Warning: %s changed while you were editing", what)
+	if len(fields) > 0 {
+		fmt.Fprintf(os.Stderr, " (%s)", strings.Join(fields, ", "))
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprint(os.Stderr, sync.UnifiedDiff(current, edited, what+" (current)", what+" (your edit)"))
+	fmt.Fprintln(os.Stderr)
+
+	overwrite, err := askOverwrite()
+	if err == nil && overwrite {
+		return nil
+	}
+
+	path, keepErr := keepEdit(edited)
+	if keepErr != nil {
+		return fmt.Errorf("%s changed while you were editing; edit not saved: %w", what, keepErr)
+	}
+	if err != nil {
+		return fmt.Errorf("%s changed while you were editing (%v); your edit was not saved and is kept in %s", what, err, path)
+	}
+	return fmt.Errorf("%s changed while you were editing; your edit was not saved and is kept in %s", what, path)
+}
+
+// askOverwrite asks on the terminal whether to overwrite the other changes
+func askOverwrite() (bool, error) {
+	tty, err := promptTerminal()
+	if err != nil {
+		return false, errNoTerminal
+	}
+	defer tty.Close()
+
+	fmt.Fprint(os.Stderr, "Overwrite the current version with your edit? [y/N]: ")
+	return readConfirmation(tty)
+}
+
+// keepEdit writes an unsaved edit to a file and returns its path
+func keepEdit(content string) (string, error) {
+	file, err := os.CreateTemp("", "todu-edit-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content + "\n"); err != nil {
+		return "", fmt.Errorf("failed to write edit: %w", err)
+	}
+	return file.Name(), nil
+}
@@ -10,6 +10,9 @@ import (
 // openEditor opens the user's preferred editor with optional initial content
 // Returns the edited content or an error
 func openEditor(initialContent string) (string, error) {
+	if err := requireInteractive("the editor", "pass the text on the command line instead"); err != nil {
+		return "", err
+	}
 	editor := getEditor()
 
 	// Create a temporary file with .md extension for syntax highlighting
@@ -50,6 +53,22 @@ func openEditor(initialContent string) (string, error) {
 	return result, nil
 }
 
+// openFileInEditor opens an existing file in the user's preferred editor
+func openFileInEditor(path string) error {
+	if err := requireInteractive("the editor", ""); err != nil {
+		return err
+	}
+	cmd := exec.Command(getEditor(), path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	return nil
+}
+
 // getEditor returns the user's preferred editor
 // Priority: $VISUAL > $EDITOR > vim > vi > nano
 func getEditor() string {
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// addExportFlags adds the --to export target flag, and the --url flag for
+// the webhook target, to a command.
+func addExportFlags(cmd *cobra.Command, target, url *string, defaultTarget string) {
+	cmd.Flags().StringVar(target, "to", defaultTarget, "Export target ("+strings.Join(export.Targets, ", ")+")")
+	cmd.Flags().StringVar(url, "url", "", "Webhook URL for --to webhook (defaults to export.webhook.url)")
+}
+
+// exportAdapter returns the adapter for an export target, configured from
+// the export settings. url overrides the configured webhook URL.
+func exportAdapter(cfg *config.Config, target, url string) (export.Adapter, error) {
+	if url != "" && target != "webhook" {
+		return nil, fmt.Errorf("--url only works with --to webhook")
+	}
+	if url == "" {
+		url = cfg.Export.Webhook.URL
+	}
+	return export.New(target, export.Options{
+		ObsidianVault:  cfg.Export.Obsidian.Vault,
+		ObsidianFolder: cfg.Export.Obsidian.Folder,
+		WebhookURL:     url,
+		WebhookHeaders: cfg.Export.Webhook.Headers,
+		Email:          emailSettings(cfg),
+	})
+}
+
+// emailSettings returns the email export settings from the config
+func emailSettings(cfg *config.Config) export.Email {
+	email := cfg.Export.Email
+	return export.Email{
+		From:     email.From,
+		To:       email.To,
+		Host:     email.SMTP.Host,
+		Port:     email.SMTP.Port,
+		Username: email.SMTP.Username,
+		Password: email.SMTP.Password,
+		Sendmail: email.Sendmail,
+	}
+}
+
+// exportDocument exports doc and prints where it went, such as "Daily
+// review exported to: <path>". Nothing is printed for stdout, so the
+// output can be piped.
+func exportDocument(ctx context.Context, adapter export.Adapter, doc *export.Document, name string) error {
+	location, err := adapter.Export(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", strings.ToLower(name), err)
+	}
+	if location != "" {
+		fmt.Printf("%s exported to: %s\n", name, location)
+	}
+	return nil
+}
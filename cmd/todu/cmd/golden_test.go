@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenConfig is the config file runGolden passes to commands
+var goldenConfig string
+
+// newGoldenServer starts a fake API seeded with a small, fixed set of
+// systems, projects, tasks, comments, and templates, and points commands
+// run by runGolden at it.
+func newGoldenServer(t *testing.T) *testsupport.Server {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("COLUMNS", "100")
+	t.Setenv("CI", "true")
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	server := testsupport.NewServer(t)
+	config := filepath.Join(home, "config.yaml")
+	data := "api_url: " + server.URL + "\nauthor: tester\noutput:\n  color: false\n"
+	if err := os.WriteFile(config, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	goldenConfig = config
+
+	high, low := "high", "low"
+	description := "Numbers for the first quarter.\n\n- [x] Gather data\n- [ ] Write summary"
+	system := server.AddSystem(types.System{Identifier: "local", Name: "Local"})
+	work := server.AddProject(types.Project{Name: "Work", SystemID: system.ID, Priority: &high})
+	personal := server.AddProject(types.Project{Name: "Home", SystemID: system.ID})
+	report := server.AddTask(types.Task{Title: "Write quarterly report", ProjectID: work.ID, Priority: &high, Description: &description, Labels: []types.Label{{Name: "writing"}}})
+	server.AddTask(types.Task{Title: "Review pull request", ProjectID: work.ID, Status: "inprogress", Assignees: []types.Assignee{{Name: "sam"}}})
+	server.AddTask(types.Task{Title: "Fix the fence", ProjectID: personal.ID, Priority: &low, Status: "waiting"})
+	server.AddTask(types.Task{Title: "Renew passport", ProjectID: personal.ID, Status: "done"})
+	server.AddComment(types.Comment{TaskID: &report.ID, Content: "Draft is in the shared folder", Author: "tester"})
+	server.AddComment(types.Comment{Content: "Planned the week", Author: "tester"})
+	server.AddTemplate(types.RecurringTaskTemplate{
+		ProjectID:      personal.ID,
+		Title:          "Water plants",
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO",
+		StartDate:      time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		IsActive:       true,
+	})
+	return server
+}
+
+// runGolden runs a todu command against the server from newGoldenServer,
+// as the REPL and daemon do, and returns what it printed. Errors are
+// printed too, as they would be on the terminal.
+func runGolden(t *testing.T, args ...string) string {
+	t.Helper()
+	stdout, stderr := captureOutput(func() {
+		_ = executeREPLCommand(rootCmd, args, map[string]string{"config": goldenConfig})
+	})
+	return stdout + stderr
+}
+
+// checkGolden compares output with testdata/golden/<name>.golden, or
+// rewrites the file when the tests are run with -update.
+func checkGolden(t *testing.T, name, output string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			t.Fatalf("Failed to write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+	}
+	if output != string(want) {
+		t.Errorf("Output of %s differs from %s (run with -update to accept it)\n--- got ---\n%s\n--- want ---\n%s", name, path, output, want)
+	}
+}
+
+func TestGoldenCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"project_list", []string{"project", "list"}},
+		{"task_list", []string{"task", "list"}},
+		{"task_list_filtered", []string{"task", "list", "--project", "Work", "--status", "inprogress"}},
+		{"task_list_json", []string{"task", "list", "--format", "json", "--label", "writing"}},
+		{"task_show", []string{"task", "show", "1"}},
+		{"template_list", []string{"template", "list"}},
+		{"journal_list", []string{"journal", "list"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newGoldenServer(t)
+			checkGolden(t, tt.name, runGolden(t, tt.args...))
+		})
+	}
+}
+
+func TestGoldenTaskLifecycle(t *testing.T) {
+	server := newGoldenServer(t)
+
+	var steps []string
+	for _, args := range [][]string{
+		{"task", "create", "--title", "Book flights", "--project", "Home", "--priority", "high", "--label", "travel"},
+		{"task", "comment", "5", "Found a good fare"},
+		{"task", "close", "5"},
+		{"task", "list", "--status", "done"},
+	} {
+		steps = append(steps, "$ todu "+strings.Join(args, " ")+"\n"+runGolden(t, args...))
+	}
+	checkGolden(t, "task_lifecycle", strings.Join(steps, "\n"))
+
+	if task := server.Task(5); task == nil || task.Status != "done" || !task.HasLabel("travel") {
+		t.Errorf("Expected task 5 created and closed, got %+v", task)
+	}
+}
+
+// captureOutput runs fn with os.Stdout and os.Stderr redirected, and
+// returns what it wrote to each
+func captureOutput(fn func()) (string, string) {
+	stdout, stderr := os.Stdout, os.Stderr
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		fn()
+		return "", ""
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		fn()
+		return "", ""
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	done := make(chan struct{}, 2)
+	go func() { _, _ = io.Copy(&outBuf, outR); done <- struct{}{} }()
+	go func() { _, _ = io.Copy(&errBuf, errR); done <- struct{}{} }()
+
+	os.Stdout, os.Stderr = outW, errW
+	func() {
+		defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+		fn()
+	}()
+
+	outW.Close()
+	errW.Close()
+	<-done
+	<-done
+	outR.Close()
+	errR.Close()
+	return outBuf.String(), errBuf.String()
+}
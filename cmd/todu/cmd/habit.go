@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var habitCmd = &cobra.Command{
+	Use:   "habit",
+	Short: "Work with habits",
+	Long: `Work with habits: recurring templates of type habit.
+
+Create habits with "todu template create --type habit"; their streaks are
+shown by "todu template show" and the weekly review.`,
+}
+
+var habitSkipCmd = &cobra.Command{
+	Use:   "skip <habit>",
+	Short: "Skip a habit for a day with a reason",
+	Long: `Skip a habit's occurrence for a day, recording why.
+
+A skipped occurrence is an excused miss rather than a failure: it neither
+counts toward nor breaks the habit's streak. The daily review shows it as
+"–" with the reason instead of "false", and the weekly review leaves it out
+of the habits completed.
+
+The habit is given by template ID or title (case-insensitive). The
+occurrence is canceled and given a "` + types.SkippedLabelPrefix + `<reason>" label, so
+it syncs like any other label. Use --undo to take a skip back; reopening
+the occurrence any other way does too.
+
+Examples:
+  todu habit skip exercise --reason "injured"
+  todu habit skip 12 --date 2026-03-02 --reason "travelling"
+  todu habit skip exercise --undo`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHabitSkip,
+}
+
+var (
+	// Habit skip flags
+	habitSkipReason string
+	habitSkipDate   string
+	habitSkipUndo   bool
+)
+
+func init() {
+	rootCmd.AddCommand(habitCmd)
+	habitCmd.AddCommand(habitSkipCmd)
+	habitSkipCmd.Flags().StringVar(&habitSkipReason, "reason", "", "Why the habit is skipped")
+	habitSkipCmd.Flags().StringVar(&habitSkipDate, "date", "", "Day to skip (YYYY-MM-DD, default today)")
+	habitSkipCmd.Flags().BoolVar(&habitSkipUndo, "undo", false, "Take back a skip, reopening the occurrence")
+}
+
+func runHabitSkip(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	date := habitSkipDate
+	if date == "" {
+		dayStart, err := configDayStart(cfg)
+		if err != nil {
+			return err
+		}
+		date = daystart.Today(dayStart).Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid --date %q: must be YYYY-MM-DD", date)
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	habit, err := resolveHabit(ctx, apiClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	task, err := findHabitOccurrence(ctx, apiClient, habit, date)
+	if err != nil {
+		return err
+	}
+
+	if habitSkipUndo {
+		if _, skipped := task.Skipped(); !skipped {
+			fmt.Printf("%s was not skipped on %s\n", habit.Title, date)
+			return nil
+		}
+		status := "active"
+		if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, &types.TaskUpdate{
+			Status: &status,
+			Labels: skippedLabels(task, ""),
+		}, ""); err != nil {
+			return err
+		}
+		fmt.Printf("%s is no longer skipped on %s (#%d)\n", habit.Title, date, task.ID)
+		return nil
+	}
+
+	if task.Status == "done" {
+		return fmt.Errorf("%s is already done on %s (#%d)", habit.Title, date, task.ID)
+	}
+
+	status := "canceled"
+	label := types.SkippedLabelFor(habitSkipReason)
+	if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, &types.TaskUpdate{
+		Status: &status,
+		Labels: skippedLabels(task, label),
+	}, ""); err != nil {
+		return err
+	}
+
+	if reason := strings.TrimSpace(habitSkipReason); reason != "" {
+		fmt.Printf("Skipped %s on %s (#%d): %s\n", habit.Title, date, task.ID, reason)
+	} else {
+		fmt.Printf("Skipped %s on %s (#%d)\n", habit.Title, date, task.ID)
+	}
+	return nil
+}
+
+// resolveHabit finds a habit template by ID or by title (case-insensitive).
+func resolveHabit(ctx context.Context, apiClient *api.Client, identifier string) (*types.RecurringTaskTemplate, error) {
+	if id, err := strconv.Atoi(identifier); err == nil {
+		template, err := apiClient.GetTemplate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+		if template.TemplateType != "habit" {
+			return nil, fmt.Errorf("template #%d is a %s template, not a habit", id, template.TemplateType)
+		}
+		return template, nil
+	}
+
+	templates, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{TemplateType: "habit"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	for _, tmpl := range templates {
+		if tmpl.TemplateType == "habit" && strings.EqualFold(tmpl.Title, identifier) {
+			return tmpl, nil
+		}
+	}
+	return nil, fmt.Errorf("habit %q not found", identifier)
+}
+
+// findHabitOccurrence returns the habit's task scheduled on date.
+func findHabitOccurrence(ctx context.Context, apiClient *api.Client, habit *types.RecurringTaskTemplate, date string) (*types.Task, error) {
+	tasks, err := apiClient.ListTasks(ctx, &api.TaskListOptions{
+		TemplateID:    &habit.ID,
+		ScheduledDate: date,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	for _, task := range tasks {
+		// Use UTC for date-only fields to preserve the stored date
+		if task.TemplateID != nil && *task.TemplateID == habit.ID &&
+			task.ScheduledDate != nil && task.ScheduledDate.UTC().Format("2006-01-02") == date {
+			return task, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no occurrence scheduled on %s", habit.Title, date)
+}
+
+// skippedLabels returns the task's labels without any skipped label, plus
+// label when it isn't empty.
+func skippedLabels(task *types.Task, label string) []string {
+	labels := []string{}
+	for _, l := range task.Labels {
+		if l.Name != types.SkippedLabel && !strings.HasPrefix(l.Name, types.SkippedLabelPrefix) {
+			labels = append(labels, l.Name)
+		}
+	}
+	if label != "" {
+		labels = append(labels, label)
+	}
+	return labels
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestSkippedLabels(t *testing.T) {
+	task := &types.Task{Labels: []types.Label{{Name: "health"}, {Name: "skipped:sick"}, {Name: "skipped"}}}
+
+	if got := skippedLabels(task, "skipped:injured"); !slices.Equal(got, []string{"health", "skipped:injured"}) {
+		t.Errorf("skippedLabels() = %v, want [health skipped:injured]", got)
+	}
+	if got := skippedLabels(task, ""); !slices.Equal(got, []string{"health"}) {
+		t.Errorf("skippedLabels() = %v, want [health]", got)
+	}
+}
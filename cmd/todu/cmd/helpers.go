@@ -3,51 +3,60 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
 	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/google/uuid"
 )
 
 // parseDateToUTCStart parses a date string (YYYY-MM-DD) in local timezone
-// and returns the start of that day (00:00:00) converted to UTC as RFC3339.
+// and returns the start of that day converted to UTC as RFC3339.
 // This is useful for "after" filters where we want everything from the start of the local day.
 func parseDateToUTCStart(dateStr string) (string, error) {
-	// Parse the date in local timezone
-	loc := time.Local
-	t, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	// Parse the date in local timezone (or --tz)
+	loc, err := dateLocation()
+	if err != nil {
+		return "", err
+	}
+	start, _, err := localDay(dateStr, loc)
 	if err != nil {
 		return "", fmt.Errorf("invalid date format %q, expected YYYY-MM-DD: %w", dateStr, err)
 	}
 	// Convert to UTC and format as RFC3339
-	return t.UTC().Format(time.RFC3339), nil
+	return start.UTC().Format(time.RFC3339), nil
 }
 
 // parseDateToUTCEnd parses a date string (YYYY-MM-DD) in local timezone
 // and returns the end of that day (23:59:59.999999999) converted to UTC as RFC3339.
 // This is useful for "before" filters where we want everything up to the end of the local day.
 func parseDateToUTCEnd(dateStr string) (string, error) {
-	// Parse the date in local timezone
-	loc := time.Local
-	t, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	// Parse the date in local timezone (or --tz)
+	loc, err := dateLocation()
+	if err != nil {
+		return "", err
+	}
+	_, end, err := localDay(dateStr, loc)
 	if err != nil {
 		return "", fmt.Errorf("invalid date format %q, expected YYYY-MM-DD: %w", dateStr, err)
 	}
-	// Add 1 day and subtract 1 nanosecond to get end of day
-	endOfDay := t.AddDate(0, 0, 1).Add(-time.Nanosecond)
 	// Convert to UTC and format as RFC3339
-	return endOfDay.UTC().Format(time.RFC3339), nil
+	return end.UTC().Format(time.RFC3339), nil
 }
 
 // ensureLocalSystem checks if the "local" system exists and creates it if not.
 // This allows local-only projects to be created without manual system setup.
 // Returns the system ID of the local system.
 func ensureLocalSystem(client *api.Client) (int, error) {
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Check if local system already exists
 	systems, err := client.ListSystems(ctx)
@@ -93,7 +102,7 @@ func resolveSystemID(client *api.Client, systemArg string) (int, error) {
 	}
 
 	// Otherwise, look up by identifier
-	systems, err := client.ListSystems(context.Background())
+	systems, err := client.ListSystems(commandContext())
 	if err != nil {
 		return 0, fmt.Errorf("failed to list systems: %w", err)
 	}
@@ -107,9 +116,99 @@ func resolveSystemID(client *api.Client, systemArg string) (int, error) {
 	return 0, fmt.Errorf("system %q not found", systemArg)
 }
 
-// loadConfig loads the configuration using the global --config flag if set
+// requireWritable fails if the API key is read-only, for commands that
+// would otherwise fail partway through. Servers that don't report key
+// scopes are assumed to allow writes.
+func requireWritable(ctx context.Context, client *api.Client, command string) error {
+	info, err := client.GetKeyInfo(ctx)
+	if err != nil {
+		return nil
+	}
+	if info.ReadOnly() {
+		return fmt.Errorf("todu %s needs write access, but the API key is read-only (see 'todu auth status')", command)
+	}
+	return nil
+}
+
+// loadedConfig caches the last config loaded, so a command that needs the
+// config in several places, or a session running many commands, parses it
+// once. It is reloaded when its key changes.
+var loadedConfig struct {
+	key string
+	cfg *config.Config
+}
+
+// loadConfig loads the configuration using the global --config flag if set,
+// and applies its priority levels
 func loadConfig() (*config.Config, error) {
-	return config.Load(GetConfigFile())
+	key := configCacheKey()
+	cfg := loadedConfig.cfg
+	if cfg == nil || key != loadedConfig.key {
+		var err error
+		dir := ""
+		if inv := currentInvocation(); inv != nil {
+			dir = inv.dir
+		}
+		cfg, err = config.LoadFrom(dir, GetConfigFile())
+		if err != nil {
+			return nil, err
+		}
+		loadedConfig.key, loadedConfig.cfg = key, cfg
+	}
+	if err := types.SetPriorities(cfg.Priorities); err != nil {
+		return nil, fmt.Errorf("invalid priorities in config: %w", err)
+	}
+	// Callers may change fields, so each gets its own copy
+	copied := *cfg
+	return &copied, nil
+}
+
+// configCacheKey identifies what the loaded config depends on: the config
+// files that could be read, when they last changed, the working directory,
+// and TODU_ environment variables.
+func configCacheKey() string {
+	wd, _ := workingDir()
+	paths := []string{GetConfigFile()}
+	if paths[0] == "" {
+		paths = []string{"config.yaml"}
+		if home, err := os.UserHomeDir(); err == nil {
+			paths = append(paths, filepath.Join(home, ".config", "todu", "config.yaml"), filepath.Join(home, ".todu", "config.yaml"))
+		}
+	}
+	if !filepath.IsAbs(paths[0]) {
+		paths[0] = filepath.Join(wd, paths[0])
+	}
+
+	var key strings.Builder
+	key.WriteString(wd)
+	for _, path := range paths {
+		key.WriteString("\x00" + path)
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&key, "@%d/%d", info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, "TODU_") {
+			key.WriteString("\x00" + env)
+		}
+	}
+	return key.String()
+}
+
+// newHookRunner creates a runner for the hooks configured in cfg
+func newHookRunner(cfg *config.Config) (*hooks.Runner, error) {
+	if err := hooks.Validate(cfg.Hooks); err != nil {
+		return nil, err
+	}
+	return hooks.NewRunner(cfg.Hooks), nil
+}
+
+// runPostHook runs a post- hook. The command already succeeded, so a
+// failing hook is reported as a warning rather than an error.
+func runPostHook(ctx context.Context, runner *hooks.Runner, event string, data any) {
+	if err := runner.Run(ctx, event, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 }
 
 // ensureDefaultProject ensures the default project exists, creating it if needed.
@@ -154,3 +253,32 @@ func ensureDefaultProject(ctx context.Context, client *api.Client, projectName s
 	fmt.Printf("Auto-created default project %q (ID: %d)\n", project.Name, project.ID)
 	return project.ID, nil
 }
+
+// createProjectPlugin creates and validates the plugin for a project's system.
+func createProjectPlugin(ctx context.Context, client *api.Client, project *types.Project) (plugin.Plugin, error) {
+	system, err := client.GetSystem(ctx, project.SystemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+
+	return createSystemPlugin(system)
+}
+
+// createSystemPlugin creates and validates the plugin for a system.
+func createSystemPlugin(system *types.System) (plugin.Plugin, error) {
+	pluginConfig, err := registry.LoadPluginConfig(system.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugin config: %w", err)
+	}
+
+	p, err := registry.Create(system.Identifier, pluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin: %w", err)
+	}
+
+	if err := p.ValidateConfig(); err != nil {
+		return nil, fmt.Errorf("plugin %s is not configured (run 'todu system config %s'): %w", system.Identifier, system.Identifier, err)
+	}
+
+	return p, nil
+}
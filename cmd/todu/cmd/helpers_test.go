@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/evcraddock/todu.sh/internal/api"
@@ -270,3 +272,31 @@ func TestEnsureDefaultProject_CreatesLocalSystem(t *testing.T) {
 
 // Ensure context is used (compile-time check)
 var _ = context.Background()
+
+func TestLoadConfigCache(t *testing.T) {
+	defer func(orig string) { configFile = orig }(configFile)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("api_url: http://one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configFile = path
+
+	cfg, err := loadConfig()
+	if err != nil || cfg.APIURL != "http://one" {
+		t.Fatalf("loadConfig() = %v, %v", cfg, err)
+	}
+
+	// Changes to a returned config don't leak into the next load
+	cfg.APIURL = "changed"
+	if cfg, _ := loadConfig(); cfg.APIURL != "http://one" {
+		t.Errorf("Expected a fresh copy, got %q", cfg.APIURL)
+	}
+
+	// Editing the file is picked up
+	if err := os.WriteFile(path, []byte("api_url: http://two/longer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ := loadConfig(); cfg.APIURL != "http://two/longer" {
+		t.Errorf("Expected the edited config, got %q", cfg.APIURL)
+	}
+}
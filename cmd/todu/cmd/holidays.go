@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/internal/review"
+)
+
+// loadHolidayCalendar loads the holiday calendar from the config. Skipping
+// holidays requires one.
+func loadHolidayCalendar(cfg *config.Config, skip holidays.Skip) (*holidays.Calendar, error) {
+	icsPath := ""
+	if cfg.Holidays.ICS != "" {
+		icsPath = review.ExpandPath(cfg.Holidays.ICS)
+	}
+	cal, err := holidays.Load(cfg.Holidays.Country, icsPath)
+	if err != nil {
+		return nil, err
+	}
+	if skip.Holidays && cal.Empty() {
+		return nil, fmt.Errorf("skipping holidays needs a holiday calendar (set holidays.country or holidays.ics in the config)")
+	}
+	return cal, nil
+}
+
+// setLabel returns labels with name added (once) or removed.
+func setLabel(labels []string, name string, present bool) []string {
+	var result []string
+	for _, label := range labels {
+		if label != name {
+			result = append(result, label)
+		}
+	}
+	if present {
+		result = append(result, name)
+	}
+	return result
+}
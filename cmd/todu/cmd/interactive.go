@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+// exitPromptRequired is the exit status when a command stops because it
+// needs an answer it is not allowed to ask for in non-interactive mode.
+const exitPromptRequired = 3
+
+// stdoutIsTerminal reports whether stdout is a terminal, or for a command
+// run by the daemon, its caller's. Tests replace it to simulate an
+// interactive session.
+var stdoutIsTerminal = func() bool {
+	if inv := currentInvocation(); inv != nil {
+		return inv.terminal
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// promptRequiredError is returned when a command would prompt for input in
+// non-interactive mode.
+type promptRequiredError struct {
+	prompt string
+	hint   string
+}
+
+func (e *promptRequiredError) Error() string {
+	msg := fmt.Sprintf("%s requires input, but prompts are disabled in non-interactive mode", e.prompt)
+	if e.hint != "" {
+		msg += "; " + e.hint
+	}
+	return msg
+}
+
+// isNonInteractive reports whether prompts are disabled: --non-interactive
+// was given, $CI is true, or stdout is not a terminal.
+func isNonInteractive() bool {
+	if nonInteractive {
+		return true
+	}
+	if ci, err := strconv.ParseBool(os.Getenv("CI")); err == nil && ci {
+		return true
+	}
+	return !stdoutIsTerminal()
+}
+
+// requireInteractive returns a promptRequiredError naming the prompt and how
+// to avoid it if prompts are disabled, and nil otherwise.
+func requireInteractive(prompt, hint string) error {
+	if !isNonInteractive() {
+		return nil
+	}
+	return &promptRequiredError{prompt: prompt, hint: hint}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+)
+
+// simulateTerminal makes stdout look like a terminal with $CI unset, so
+// prompts are allowed for the rest of the test.
+func simulateTerminal(t *testing.T) {
+	t.Helper()
+	orig := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return true }
+	t.Cleanup(func() { stdoutIsTerminal = orig })
+	t.Setenv("CI", "")
+}
+
+func TestIsNonInteractive(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     bool
+		ci       string
+		terminal bool
+		want     bool
+	}{
+		{name: "terminal", terminal: true, want: false},
+		{name: "flag", flag: true, terminal: true, want: true},
+		{name: "CI true", ci: "true", terminal: true, want: true},
+		{name: "CI false", ci: "false", terminal: true, want: false},
+		{name: "stdout not a terminal", terminal: false, want: true},
+	}
+
+	origTerminal := stdoutIsTerminal
+	defer func() {
+		stdoutIsTerminal = origTerminal
+		nonInteractive = false
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonInteractive = tt.flag
+			t.Setenv("CI", tt.ci)
+			stdoutIsTerminal = func() bool { return tt.terminal }
+
+			if got := isNonInteractive(); got != tt.want {
+				t.Errorf("Expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRequireInteractive(t *testing.T) {
+	simulateTerminal(t)
+	if err := requireInteractive("the editor", ""); err != nil {
+		t.Fatalf("Expected no error on a terminal, got %v", err)
+	}
+
+	t.Setenv("CI", "true")
+	err := requireInteractive("the editor", "pass the text on the command line instead")
+	want := "the editor requires input, but prompts are disabled in non-interactive mode; pass the text on the command line instead"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+}
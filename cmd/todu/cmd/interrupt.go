@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// exitInterrupted is the exit status when a command stops because it was
+// interrupted with Ctrl-C or SIGTERM, as shells report for SIGINT
+const exitInterrupted = 130
+
+// interruptNotice is how long an interrupted command may take to stop
+// before the user is told how to quit at once
+const interruptNotice = time.Second
+
+// interruptDepth counts the withInterrupt calls in progress; a REPL command
+// runs inside the REPL's own
+var interruptDepth atomic.Int32
+
+func init() {
+	// Usage isn't shown for a command that failed because it was interrupted
+	usage := rootCmd.UsageFunc()
+	rootCmd.SetUsageFunc(func(cmd *cobra.Command) error {
+		if commandContext().Err() != nil {
+			return nil
+		}
+		return usage(cmd)
+	})
+}
+
+// interruptedError is returned by withInterrupt for a command that failed
+// because it was interrupted
+type interruptedError struct {
+	err error
+}
+
+func (e *interruptedError) Error() string { return e.err.Error() }
+func (e *interruptedError) Unwrap() error { return e.err }
+
+// withInterrupt runs fn with a context that is canceled by Ctrl-C or
+// SIGTERM, so the command can stop between steps and report what it did.
+// A second Ctrl-C quits at once. If fn fails after being interrupted, the
+// error is an *interruptedError.
+func withInterrupt(fn func(ctx context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	depth := interruptDepth.Add(1)
+	defer interruptDepth.Add(-1)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		// The next signal gets the default behavior and ends the process
+		stop()
+		select {
+		case <-time.After(interruptNotice):
+			if interruptDepth.Load() == depth {
+				fmt.Fprintln(os.Stderr, "\nStopping... press Ctrl-C again to quit now")
+			}
+		case <-done:
+		}
+	}()
+
+	err := fn(ctx)
+	if err != nil && ctx.Err() != nil {
+		return &interruptedError{err: err}
+	}
+	return err
+}
+
+// isInterrupted reports whether err is from an interrupted command
+func isInterrupted(err error) bool {
+	var interrupted *interruptedError
+	return errors.As(err, &interrupted)
+}
+
+// commandContext returns the context of the running command, which is
+// canceled if the command is interrupted
+func commandContext() context.Context {
+	if ctx := rootCmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
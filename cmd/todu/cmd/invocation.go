@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"os"
+)
+
+// invocation is what a command run by the daemon for a --via-daemon caller
+// runs with in place of the process's own: the caller's working directory
+// and terminal. The daemon's sync loop goes on using the process's, so a
+// request can't change them. Output goes to the command's writers, which
+// the daemon sets per request.
+type invocation struct {
+	dir      string
+	columns  int
+	terminal bool
+}
+
+// invocationKey is the context key of the running command's invocation
+type invocationKey struct{}
+
+// withInvocation returns a context that runs commands with inv
+func withInvocation(ctx context.Context, inv *invocation) context.Context {
+	return context.WithValue(ctx, invocationKey{}, inv)
+}
+
+// currentInvocation returns the invocation of the running command, or nil
+// when it runs with the process's directory and terminal
+func currentInvocation() *invocation {
+	inv, _ := commandContext().Value(invocationKey{}).(*invocation)
+	return inv
+}
+
+// workingDir returns the directory the running command runs in
+func workingDir() (string, error) {
+	if inv := currentInvocation(); inv != nil && inv.dir != "" {
+		return inv.dir, nil
+	}
+	return os.Getwd()
+}
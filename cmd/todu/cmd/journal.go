@@ -1,9 +1,10 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"os/exec"
 	"strconv"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/evcraddock/todu.sh/internal/api"
 	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/export"
 	"github.com/evcraddock/todu.sh/internal/journal"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/spf13/cobra"
@@ -33,7 +36,22 @@ var journalAddCmd = &cobra.Command{
 	Long: `Add a journal entry.
 
 If text is provided, creates the entry directly.
-If no text is provided, opens your default editor ($VISUAL or $EDITOR).`,
+If no text is provided, opens your default editor ($VISUAL or $EDITOR).
+
+With --prompt, the editor opens pre-filled with a journal template's
+prompt, such as weekly retrospective questions. See "todu journal prompts".
+
+With --task, the entry is linked to a task: it is also added to the task as
+a comment, and "journal export" groups it under the task's heading.
+
+Mood and energy can be rated from 1 to 5 with --mood and --energy, or by
+writing "mood:4" or "energy:3" in the entry. See "todu journal trends".
+
+Examples:
+  todu journal add "Finished the quarterly report"
+  todu journal add --task 42 "spent 2h debugging"
+  todu journal add --mood 4 --energy 3 "Good focus today"
+  todu journal add --prompt "Weekly retrospective"`,
 	RunE: runJournalAdd,
 }
 
@@ -60,9 +78,12 @@ var journalShowCmd = &cobra.Command{
 var journalEditCmd = &cobra.Command{
 	Use:   "edit <id>",
 	Short: "Edit a journal entry",
-	Long:  `Edit an existing journal entry in your default editor.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runJournalEdit,
+	Long: `Edit an existing journal entry in your default editor.
+
+If the entry changes while the editor is open, the difference is shown and
+you're asked before overwriting it; otherwise your edit is kept in a file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJournalEdit,
 }
 
 var journalDeleteCmd = &cobra.Command{
@@ -81,6 +102,34 @@ var journalSearchCmd = &cobra.Command{
 	RunE:  runJournalSearch,
 }
 
+var journalAttachCmd = &cobra.Command{
+	Use:   "attach <entry-id> <file>",
+	Short: "Attach a file to a journal entry",
+	Long: `Attach a file, such as a screenshot or PDF, to a journal entry.
+
+The file is copied into an attachments directory next to the entry's day in
+the local_reports export tree, and a link to it is added to the end of the
+entry, so the export for that day links to the attachment.
+
+Example:
+  todu journal attach 12 ~/Desktop/screenshot.png`,
+	Args: cobra.ExactArgs(2),
+	RunE: runJournalAttach,
+}
+
+var journalTrendsCmd = &cobra.Command{
+	Use:   "trends",
+	Short: "Show weekly mood and energy averages",
+	Long: `Show weekly averages of the mood and energy ratings recorded in journal
+entries (with --mood/--energy or "mood:N"/"energy:N" in the text).
+
+Example:
+  todu journal trends            # Last 8 weeks
+  todu journal trends --weeks 12`,
+	Args: cobra.NoArgs,
+	RunE: runJournalTrends,
+}
+
 var journalExportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export daily journal to markdown file",
@@ -88,15 +137,48 @@ var journalExportCmd = &cobra.Command{
 
 The file is saved to {local_reports}/YYYY/MM-Monthname/MM-DD-YYYY-journal.md
 
+Use --to to export somewhere else: obsidian (into the vault set by
+export.obsidian.vault), html (an HTML page next to the markdown file),
+stdout, or webhook (POSTed as JSON with the markdown, kind, title, and
+date to --url or export.webhook.url).
+
+With --encrypt, the file is encrypted with a passphrase in the age format
+and saved with a .age extension; the plaintext is not written. Read it back
+with "todu journal decrypt" or the age command-line tool. The passphrase is
+prompted for, or read from $TODU_JOURNAL_PASSPHRASE.
+
 Example:
   todu journal export              # Export today's journal
-  todu journal export --date 2025-12-11  # Export specific date`,
+  todu journal export --date 2025-12-11  # Export specific date
+  todu journal export --encrypt    # Export an encrypted copy
+  todu journal export --to obsidian  # Export into an Obsidian vault
+  todu journal export --to html    # Export an HTML page next to the markdown
+  todu journal export --to webhook --url https://hooks.example.com/journal`,
 	RunE: runJournalExport,
 }
 
+var journalDecryptCmd = &cobra.Command{
+	Use:   "decrypt <file>",
+	Short: "Decrypt an encrypted journal export",
+	Long: `Decrypt a journal export created with "todu journal export --encrypt" and
+print it, or save it with --out.
+
+The passphrase is prompted for, or read from $TODU_JOURNAL_PASSPHRASE.
+
+Example:
+  todu journal decrypt ~/reports/reviews/2025/12-December/12-11-2025-journal.md.age
+  todu journal decrypt export.md.age --out export.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJournalDecrypt,
+}
+
 var (
 	// Add flags
 	journalAddAuthor string
+	journalAddTask   int
+	journalAddMood   int
+	journalAddEnergy int
+	journalAddPrompt string
 
 	// List flags
 	journalListToday bool
@@ -106,10 +188,19 @@ var (
 	journalListType  string
 
 	// Delete flags
-	journalDeleteForce bool
+	journalDeleteYes bool
+
+	// Trends flags
+	journalTrendsWeeks int
 
 	// Export flags
-	journalExportDate string
+	journalExportDate    string
+	journalExportEncrypt bool
+	journalExportTo      string
+	journalExportURL     string
+
+	// Decrypt flags
+	journalDecryptOut string
 )
 
 func init() {
@@ -121,9 +212,20 @@ func init() {
 	journalCmd.AddCommand(journalDeleteCmd)
 	journalCmd.AddCommand(journalSearchCmd)
 	journalCmd.AddCommand(journalExportCmd)
+	journalCmd.AddCommand(journalTrendsCmd)
+	journalCmd.AddCommand(journalAttachCmd)
+	journalCmd.AddCommand(journalDecryptCmd)
+	journalCmd.AddCommand(journalPromptsCmd)
 
 	// Add flags
-	journalAddCmd.Flags().StringVar(&journalAddAuthor, "author", "", "Entry author (defaults to config/git user)")
+	journalAddCmd.Flags().StringVar(&journalAddAuthor, "author", "", "Entry author (defaults to config, API, or git user)")
+	journalAddCmd.Flags().IntVar(&journalAddTask, "task", 0, "Link the entry to a task and add it as a task comment")
+	journalAddCmd.Flags().IntVar(&journalAddMood, "mood", 0, "Mood rating (1-5)")
+	journalAddCmd.Flags().IntVar(&journalAddEnergy, "energy", 0, "Energy rating (1-5)")
+	journalAddCmd.Flags().StringVar(&journalAddPrompt, "prompt", "", "Journal template (ID or title) whose prompt seeds the editor")
+
+	// Trends flags
+	journalTrendsCmd.Flags().IntVar(&journalTrendsWeeks, "weeks", 8, "Number of weeks to show")
 
 	// List flags
 	journalListCmd.Flags().BoolVar(&journalListToday, "today", false, "Show only today's entries")
@@ -131,12 +233,19 @@ func init() {
 	journalListCmd.Flags().StringVar(&journalListSince, "since", "", "Show entries since date (YYYY-MM-DD)")
 	journalListCmd.Flags().StringVar(&journalListUntil, "until", "", "Show entries until date (YYYY-MM-DD)")
 	journalListCmd.Flags().StringVar(&journalListType, "type", "journal", "Filter by type: 'journal' (journal entries), 'comment' (task comments), or 'all'")
+	addTZFlag(journalListCmd)
 
 	// Delete flags
-	journalDeleteCmd.Flags().BoolVarP(&journalDeleteForce, "force", "f", false, "Skip confirmation")
+	addYesFlag(journalDeleteCmd, &journalDeleteYes)
+	addForceAlias(journalDeleteCmd, &journalDeleteYes, "f")
 
 	// Export flags
 	journalExportCmd.Flags().StringVar(&journalExportDate, "date", "", "Date to export (YYYY-MM-DD, defaults to today)")
+	journalExportCmd.Flags().BoolVar(&journalExportEncrypt, "encrypt", false, "Encrypt the export with a passphrase")
+	addExportFlags(journalExportCmd, &journalExportTo, &journalExportURL, "file")
+
+	// Decrypt flags
+	journalDecryptCmd.Flags().StringVarP(&journalDecryptOut, "out", "o", "", "Save the decrypted journal to this file instead of printing it")
 }
 
 func runJournalAdd(cmd *cobra.Command, args []string) error {
@@ -149,18 +258,46 @@ func runJournalAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("API URL not configured")
 	}
 
+	if err := journal.ValidateRating("mood", journalAddMood); err != nil {
+		return err
+	}
+	if err := journal.ValidateRating("energy", journalAddEnergy); err != nil {
+		return err
+	}
+
+	if journalAddPrompt != "" && len(args) > 0 {
+		return fmt.Errorf("--prompt opens the editor and cannot be combined with entry text")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
 	var content string
 
 	// If text provided as argument, use it; otherwise open editor
 	if len(args) > 0 {
 		content = strings.Join(args, " ")
 	} else {
-		// Open editor with blank content
-		editedContent, err := openEditor("")
+		// Open editor with blank content, or the template's prompt
+		initial := ""
+		if journalAddPrompt != "" {
+			template, err := resolveJournalTemplate(ctx, apiClient, journalAddPrompt)
+			if err != nil {
+				return err
+			}
+			initial = journalPromptText(template)
+		}
+
+		editedContent, err := openEditor(initial)
 		if err != nil {
 			return fmt.Errorf("failed to open editor: %w", err)
 		}
 		content = editedContent
+
+		// An untouched prompt is not an entry
+		if initial != "" && content == strings.TrimSpace(initial) {
+			content = ""
+		}
 	}
 
 	// Check if content is empty
@@ -172,13 +309,19 @@ func runJournalAdd(cmd *cobra.Command, args []string) error {
 	// Get author (from flag, config, git, or default)
 	author := getAuthor(journalAddAuthor, cfg)
 
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	// Check the linked task exists before creating anything
+	entryContent := journal.AppendMetadata(content, journal.Metadata{Mood: journalAddMood, Energy: journalAddEnergy})
+	if journalAddTask != 0 {
+		if _, err := apiClient.GetTask(ctx, journalAddTask); err != nil {
+			return fmt.Errorf("failed to get task %d: %w", journalAddTask, err)
+		}
+		entryContent = journal.FormatTaskEntry(journalAddTask, entryContent)
+	}
 
 	// Create journal entry (TaskID is nil)
 	entryCreate := &types.CommentCreate{
 		TaskID:  nil, // nil for journal entries
-		Content: content,
+		Content: entryContent,
 		Author:  author,
 	}
 
@@ -188,6 +331,20 @@ func runJournalAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Journal entry created (ID: %d)\n", entry.ID)
+
+	// Attach the entry to the task as a comment
+	if journalAddTask != 0 {
+		comment, err := apiClient.CreateComment(ctx, &types.CommentCreate{
+			TaskID:  &journalAddTask,
+			Content: content,
+			Author:  author,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add comment to task %d: %w", journalAddTask, err)
+		}
+		fmt.Printf("Added to task #%d as comment (ID: %d)\n", journalAddTask, comment.ID)
+	}
+
 	fmt.Printf("[%s] %s:\n", entry.CreatedAt.Local().Format("2006-01-02 15:04"), entry.Author)
 	fmt.Println(entry.Content)
 	return nil
@@ -204,7 +361,7 @@ func runJournalList(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Validate type parameter
 	if journalListType != "all" && journalListType != "journal" && journalListType != "comment" {
@@ -216,17 +373,28 @@ func runJournalList(cmd *cobra.Command, args []string) error {
 		Type: journalListType,
 	}
 
-	// Handle --today flag
+	// Dates are read in the machine's timezone, or --tz
+	loc, err := dateLocation()
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(loc)
+
+	// Handle --today flag. Before day_start, today is still the previous
+	// day, so the range is padded and entries are filtered below.
+	dayStart, err := configDayStart(cfg)
+	if err != nil {
+		return err
+	}
+	today := daystart.Day(now, dayStart)
 	if journalListToday {
-		today := time.Now().Format("2006-01-02")
-		tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
-		opts.CreatedAfter = today
-		opts.CreatedBefore = tomorrow
+		opts.CreatedAfter = today.Format("2006-01-02")
+		opts.CreatedBefore = today.AddDate(0, 0, 2).Format("2006-01-02")
 	}
 
 	// Handle --last N days flag
 	if journalListLast > 0 {
-		cutoff := time.Now().AddDate(0, 0, -journalListLast).Format("2006-01-02")
+		cutoff := now.AddDate(0, 0, -journalListLast).Format("2006-01-02")
 		opts.CreatedAfter = cutoff
 	}
 
@@ -237,11 +405,14 @@ func runJournalList(cmd *cobra.Command, args []string) error {
 
 	// Handle --until flag (add one day to make it inclusive)
 	if journalListUntil != "" {
-		untilDate, err := time.ParseInLocation("2006-01-02", journalListUntil, time.Local)
+		untilDate, err := time.ParseInLocation("2006-01-02", journalListUntil, loc)
 		if err == nil {
 			opts.CreatedBefore = untilDate.AddDate(0, 0, 1).Format("2006-01-02")
 		}
 	}
+	if warning := dateRangeWarning(journalListSince, journalListUntil, loc); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 
 	// Fetch entries with server-side filtering
 	entries, err := apiClient.ListCommentsFiltered(ctx, opts)
@@ -249,6 +420,16 @@ func runJournalList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list entries: %w", err)
 	}
 
+	if journalListToday {
+		var todays []*types.Comment
+		for _, entry := range entries {
+			if daystart.SameDay(entry.CreatedAt.In(loc), today, dayStart) {
+				todays = append(todays, entry)
+			}
+		}
+		entries = todays
+	}
+
 	if len(entries) == 0 {
 		fmt.Println("No entries found")
 		return nil
@@ -317,7 +498,7 @@ func runJournalShow(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	entry, err := apiClient.GetComment(ctx, entryID)
 	if err != nil {
@@ -329,30 +510,35 @@ func runJournalShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("entry %d is a task comment, not a journal entry", entryID)
 	}
 
+	out := cmd.OutOrStdout()
 	// Display results
 	if GetOutputFormat() == "json" {
 		data, err := json.MarshalIndent(entry, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %w", err)
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(out, string(data))
 		return nil
 	}
 
-	displayJournalEntry(entry)
+	displayJournalEntry(out, entry)
 	return nil
 }
 
-func displayJournalEntry(entry *types.Comment) {
-	fmt.Printf("Journal Entry #%d\n", entry.ID)
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println()
+func displayJournalEntry(out io.Writer, entry *types.Comment) {
+	fmt.Fprintf(out, "Journal Entry #%d\n", entry.ID)
+	fmt.Fprintln(out, strings.Repeat("=", 60))
+	fmt.Fprintln(out)
 
-	fmt.Printf("Author:  %s\n", entry.Author)
-	fmt.Printf("Created: %s\n", entry.CreatedAt.Local().Format("2006-01-02 15:04:05"))
-	fmt.Printf("Updated: %s\n", entry.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
-	fmt.Println()
-	fmt.Println(entry.Content)
+	fmt.Fprintf(out, "Author:  %s\n", entry.Author)
+	fmt.Fprintf(out, "Created: %s\n", entry.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "Updated: %s\n", entry.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, entry.Content)
+	if summary := entry.ReactionSummary(); summary != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, summary)
+	}
 }
 
 func runJournalEdit(cmd *cobra.Command, args []string) error {
@@ -371,7 +557,7 @@ func runJournalEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Get current entry
 	entry, err := apiClient.GetComment(ctx, entryID)
@@ -402,6 +588,18 @@ func runJournalEdit(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Re-fetch so changes made while the editor was open aren't silently
+	// overwritten
+	latest, err := apiClient.GetComment(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to get journal entry: %w", err)
+	}
+	if latest.Content != entry.Content && latest.Content != editedContent {
+		if err := resolveEditConflict(fmt.Sprintf("journal entry #%d", entryID), latest.Content, editedContent, nil); err != nil {
+			return err
+		}
+	}
+
 	// Update entry
 	update := &types.CommentUpdate{
 		Content: &editedContent,
@@ -431,19 +629,25 @@ func runJournalDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid entry ID: %s", args[0])
 	}
 
-	// Confirm deletion unless --force
-	if !journalDeleteForce {
-		fmt.Printf("Are you sure you want to delete journal entry #%d? (y/N): ", entryID)
-		var response string
-		_, _ = fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" {
-			fmt.Println("Deletion cancelled")
-			return nil
-		}
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	entry, err := apiClient.GetComment(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to get journal entry: %w", err)
 	}
 
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	// Confirm deletion unless --yes
+	firstLine, _, _ := strings.Cut(entry.Content, "\n")
+	affected := fmt.Sprintf("Entry #%d (%s): %s", entry.ID, entry.CreatedAt.Local().Format("2006-01-02 15:04"), truncate(firstLine, 60))
+	confirmed, err := confirmAction(cfg, journalDeleteYes, fmt.Sprintf("Delete journal entry #%d?", entryID), affected)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
 
 	err = apiClient.DeleteComment(ctx, entryID)
 	if err != nil {
@@ -467,10 +671,14 @@ func runJournalSearch(cmd *cobra.Command, args []string) error {
 	query := strings.ToLower(args[0])
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
-
-	// Fetch all journal entries (API max limit is 100)
-	entries, err := apiClient.ListJournals(ctx, 0, 100)
+	ctx := commandContext()
+
+	// Fetch every page of journal entries, narrowed server-side when the
+	// API supports search
+	entries, err := apiClient.ListCommentsFiltered(ctx, &api.CommentListOptions{
+		Type:   "journal",
+		Search: args[0],
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list journal entries: %w", err)
 	}
@@ -497,16 +705,17 @@ func runJournalSearch(cmd *cobra.Command, args []string) error {
 	return displayJournalsTable(matches)
 }
 
-// getAuthor returns the author name from flag, config, git, or default
+// getAuthor returns the author name from flag, config, API identity, git,
+// or default
 func getAuthor(flagValue string, cfg *config.Config) string {
 	// 1. From flag
 	if flagValue != "" {
 		return flagValue
 	}
 
-	// 2. From config
-	if cfg != nil && cfg.Author != "" {
-		return cfg.Author
+	// 2. From config or the API key's user
+	if user := currentUser(cfg); user != "" {
+		return user
 	}
 
 	// 3. From git config
@@ -528,8 +737,7 @@ func getGitUser() string {
 	return strings.TrimSpace(string(output))
 }
 
-func runJournalExport(cmd *cobra.Command, args []string) error {
-	// 1. Load and validate config
+func runJournalAttach(cmd *cobra.Command, args []string) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -543,9 +751,141 @@ func runJournalExport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("local_reports path not configured")
 	}
 
-	// 2. Parse target date (always use midnight local time)
+	entryID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid entry ID: %s", args[0])
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	entry, err := apiClient.GetComment(ctx, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to get journal entry: %w", err)
+	}
+
+	if entry.TaskID != nil {
+		return fmt.Errorf("entry %d is a task comment, not a journal entry", entryID)
+	}
+
+	link, err := journal.Attach(cfg.LocalReports, entry.ID, entry.CreatedAt, args[1])
+	if err != nil {
+		return err
+	}
+
+	content := strings.TrimRight(entry.Content, "\n") + "\n\n" + link
+	if _, err := apiClient.UpdateComment(ctx, entryID, &types.CommentUpdate{Content: &content}); err != nil {
+		return fmt.Errorf("failed to update journal entry: %w", err)
+	}
+
+	fmt.Printf("Attached %s to journal entry #%d\n", args[1], entryID)
+	return nil
+}
+
+func runJournalTrends(cmd *cobra.Command, args []string) error {
+	if journalTrendsWeeks < 1 {
+		return fmt.Errorf("--weeks must be at least 1")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
 	now := time.Now()
-	targetDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	since := journal.WeekStart(now).AddDate(0, 0, -7*(journalTrendsWeeks-1))
+	entries, err := apiClient.ListCommentsFiltered(ctx, &api.CommentListOptions{
+		Type:         "journal",
+		CreatedAfter: since.Format("2006-01-02"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list journal entries: %w", err)
+	}
+
+	trends := journal.WeeklyTrends(entries, now, journalTrendsWeeks)
+
+	if GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(trends, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayJournalTrends(os.Stdout, trends)
+	return nil
+}
+
+// displayJournalTrends prints weekly averages with a bar for each rating.
+func displayJournalTrends(out io.Writer, trends []journal.WeekTrend) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WEEK\tENTRIES\tMOOD\t\tENERGY\t")
+	fmt.Fprintln(w, "----\t-------\t----\t\t------\t")
+	for _, t := range trends {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			t.WeekStart.Format("2006-01-02"),
+			t.Entries,
+			formatRating(t.Mood, t.MoodCount),
+			ratingBar(t.Mood),
+			formatRating(t.Energy, t.EnergyCount),
+			ratingBar(t.Energy),
+		)
+	}
+	w.Flush()
+}
+
+// formatRating formats an average rating, or "-" if nothing was rated.
+func formatRating(avg float64, count int) string {
+	if count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", avg)
+}
+
+// ratingBar draws an average rating as a bar, two blocks per point.
+func ratingBar(avg float64) string {
+	return strings.Repeat("█", int(math.Round(avg*2)))
+}
+
+func runJournalExport(cmd *cobra.Command, args []string) error {
+	// 1. Load and validate config
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	if cfg.LocalReports == "" && export.NeedsPath(journalExportTo) {
+		return fmt.Errorf("local_reports path not configured")
+	}
+
+	if journalExportEncrypt && journalExportTo != "file" {
+		return fmt.Errorf("--encrypt only works with --to file")
+	}
+
+	adapter, err := exportAdapter(cfg, journalExportTo, journalExportURL)
+	if err != nil {
+		return err
+	}
+
+	// 2. Parse target date (always use midnight local time). Before
+	// day_start, today is still the previous day.
+	dayStart, err := configDayStart(cfg)
+	if err != nil {
+		return err
+	}
+	targetDate := daystart.Today(dayStart)
 	if journalExportDate != "" {
 		parsed, err := time.ParseInLocation("2006-01-02", journalExportDate, time.Local)
 		if err != nil {
@@ -556,13 +896,52 @@ func runJournalExport(cmd *cobra.Command, args []string) error {
 
 	// 3. Export using the shared journal package
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
+
+	if journalExportEncrypt {
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		outputPath, err := journal.ExportEncrypted(ctx, apiClient, targetDate, dayStart, cfg.LocalReports, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Journal exported to: %s\n", outputPath)
+		return nil
+	}
+
+	doc, err := journal.Document(ctx, apiClient, targetDate, dayStart, cfg.LocalReports)
+	if err != nil {
+		return err
+	}
+	return exportDocument(ctx, adapter, doc, "Journal")
+}
+
+func runJournalDecrypt(cmd *cobra.Command, args []string) error {
+	content, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		return err
+	}
 
-	outputPath, err := journal.Export(ctx, apiClient, targetDate, cfg.LocalReports)
+	plaintext, err := journal.Decrypt(content, passphrase)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Journal exported to: %s\n", outputPath)
+	if journalDecryptOut == "" {
+		fmt.Print(string(plaintext))
+		return nil
+	}
+
+	if err := os.WriteFile(journalDecryptOut, plaintext, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", journalDecryptOut, err)
+	}
+	fmt.Printf("Journal decrypted to: %s\n", journalDecryptOut)
 	return nil
 }
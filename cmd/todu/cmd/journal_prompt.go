@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var journalPromptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "List journal prompts due today",
+	Long: `List journal prompts due today.
+
+Journal prompts are templates of type "journal": instead of creating a
+task, each occurrence is a prompt to answer in the journal, such as weekly
+retrospective questions every Friday. The template description holds the
+prompt.
+
+Answer a prompt with "todu journal add --prompt <id>", which opens the
+editor pre-filled with it.
+
+Example:
+  todu template create --type journal --title "Weekly retrospective" --recurrence "FREQ=WEEKLY;BYDAY=FR" --start-date 2026-01-02 --project personal --description "What went well? What didn't? What will I change next week?"
+  todu journal prompts
+  todu journal add --prompt "Weekly retrospective"`,
+	Args: cobra.NoArgs,
+	RunE: runJournalPrompts,
+}
+
+var (
+	// Prompts flags
+	journalPromptsAll bool
+)
+
+func init() {
+	journalPromptsCmd.Flags().BoolVar(&journalPromptsAll, "all", false, "List every active journal prompt, not only those due today")
+}
+
+func runJournalPrompts(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	templates, err := listJournalTemplates(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+
+	if !journalPromptsAll {
+		templates = journalPromptsDue(templates, localToday())
+	}
+
+	if len(templates) == 0 {
+		if journalPromptsAll {
+			fmt.Println("No journal prompts found")
+		} else {
+			fmt.Println("No journal prompts due today")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tRECURRENCE")
+	fmt.Fprintln(w, "--\t-----\t----------")
+	for _, tmpl := range templates {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", tmpl.ID, truncate(tmpl.Title, 40), truncate(rruleToHuman(tmpl.RecurrenceRule), 25))
+	}
+	w.Flush()
+
+	fmt.Printf("\nAnswer one with: todu journal add --prompt %d\n", templates[0].ID)
+	return nil
+}
+
+// listJournalTemplates returns the active journal templates.
+func listJournalTemplates(ctx context.Context, apiClient *api.Client) ([]*types.RecurringTaskTemplate, error) {
+	active := true
+	templates, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{
+		Active:       &active,
+		TemplateType: journalTemplateType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+// journalPromptsDue returns the templates with an occurrence on day.
+// Templates with a recurrence rule that cannot be parsed are skipped.
+func journalPromptsDue(templates []*types.RecurringTaskTemplate, day time.Time) []*types.RecurringTaskTemplate {
+	var due []*types.RecurringTaskTemplate
+	for _, tmpl := range templates {
+		occurrences, err := recurring.Occurrences(tmpl, day, day)
+		if err == nil && len(occurrences) > 0 {
+			due = append(due, tmpl)
+		}
+	}
+	return due
+}
+
+// resolveJournalTemplate finds a journal template by ID or by title
+// (case-insensitive).
+func resolveJournalTemplate(ctx context.Context, apiClient *api.Client, identifier string) (*types.RecurringTaskTemplate, error) {
+	if id, err := strconv.Atoi(identifier); err == nil {
+		template, err := apiClient.GetTemplate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+		if template.TemplateType != journalTemplateType {
+			return nil, fmt.Errorf("template #%d is a %s template, not a journal prompt", id, template.TemplateType)
+		}
+		return template, nil
+	}
+
+	templates, err := listJournalTemplates(ctx, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range templates {
+		if strings.EqualFold(tmpl.Title, identifier) {
+			return tmpl, nil
+		}
+	}
+	return nil, fmt.Errorf("journal prompt %q not found", identifier)
+}
+
+// journalPromptText is the editor content seeded from a journal template:
+// its title as a heading, followed by the prompt.
+func journalPromptText(template *types.RecurringTaskTemplate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", template.Title)
+	if template.Description != nil && strings.TrimSpace(*template.Description) != "" {
+		b.WriteString("\n")
+		b.WriteString(strings.TrimSpace(*template.Description))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestJournalPromptsDue(t *testing.T) {
+	fridays := &types.RecurringTaskTemplate{
+		ID:             1,
+		Title:          "Weekly retrospective",
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=FR",
+		StartDate:      time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		TemplateType:   "journal",
+	}
+	daily := &types.RecurringTaskTemplate{
+		ID:             2,
+		Title:          "Gratitude",
+		RecurrenceRule: "FREQ=DAILY",
+		StartDate:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TemplateType:   "journal",
+	}
+	broken := &types.RecurringTaskTemplate{
+		ID:             3,
+		Title:          "Broken",
+		RecurrenceRule: "FREQ=SOMETIMES",
+		StartDate:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TemplateType:   "journal",
+	}
+	templates := []*types.RecurringTaskTemplate{fridays, daily, broken}
+
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	due := journalPromptsDue(templates, friday)
+	if len(due) != 2 || due[0].ID != 1 || due[1].ID != 2 {
+		t.Errorf("on a Friday got %v, want templates 1 and 2", templateIDs(due))
+	}
+
+	saturday := friday.AddDate(0, 0, 1)
+	due = journalPromptsDue(templates, saturday)
+	if len(due) != 1 || due[0].ID != 2 {
+		t.Errorf("on a Saturday got %v, want template 2", templateIDs(due))
+	}
+
+	beforeStart := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	if due := journalPromptsDue(templates, beforeStart); len(due) != 0 {
+		t.Errorf("before the start date got %v, want none", templateIDs(due))
+	}
+}
+
+func TestJournalPromptText(t *testing.T) {
+	description := "What went well?\nWhat didn't?\n"
+	template := &types.RecurringTaskTemplate{Title: "Weekly retrospective", Description: &description}
+
+	want := "# Weekly retrospective\n\nWhat went well?\nWhat didn't?\n\n"
+	if got := journalPromptText(template); got != want {
+		t.Errorf("journalPromptText() = %q, want %q", got, want)
+	}
+
+	template.Description = nil
+	want = "# Weekly retrospective\n\n"
+	if got := journalPromptText(template); got != want {
+		t.Errorf("journalPromptText() without description = %q, want %q", got, want)
+	}
+}
+
+func templateIDs(templates []*types.RecurringTaskTemplate) []int {
+	ids := make([]int, len(templates))
+	for i, tmpl := range templates {
+		ids[i] = tmpl.ID
+	}
+	return ids
+}
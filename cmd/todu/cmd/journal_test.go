@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/journal"
 )
 
 func TestBuildCommentListOptions_Until(t *testing.T) {
@@ -91,3 +94,24 @@ func TestBuildCommentListOptions_SinceAndUntil(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayJournalTrends(t *testing.T) {
+	trends := []journal.WeekTrend{
+		{WeekStart: time.Date(2025, 12, 8, 0, 0, 0, 0, time.Local)},
+		{WeekStart: time.Date(2025, 12, 15, 0, 0, 0, 0, time.Local), Summary: journal.Summary{Entries: 2, Mood: 3.5, MoodCount: 2}},
+	}
+
+	var out bytes.Buffer
+	displayJournalTrends(&out, trends)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header and 2 rows, got:\n%s", out.String())
+	}
+	if !strings.Contains(lines[2], "2025-12-08") || !strings.Contains(lines[2], "-") {
+		t.Errorf("Expected empty week row, got %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "3.5") || !strings.Contains(lines[3], strings.Repeat("█", 7)) {
+		t.Errorf("Expected mood average and bar, got %q", lines[3])
+	}
+}
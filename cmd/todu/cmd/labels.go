@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Manage labels",
+	Long:  `Manage label colors and emoji, and the labels of projects synced with external systems.`,
+}
+
+var labelsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List labels with their colors and emoji",
+	Args:  cobra.NoArgs,
+	RunE:  runLabelsList,
+}
+
+var labelsSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Set a label's color or emoji",
+	Long: `Set the color or emoji of a label. Pass an empty value to clear one.
+
+Colors are shown as chips in 'todu task show' and used when sync creates
+the label in GitHub or Forgejo. Labels without a color take the color of
+their GitHub or Forgejo label on the next pull.
+
+Examples:
+  todu labels set bug --color "#d73a4a" --emoji 🐛
+  todu labels set bug --emoji ""`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLabelsSet,
+}
+
+var labelsPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Create a project's labels in its external system",
+	Long: `Create every label used by a project's tasks and templates in the
+project's external system (GitHub or Forgejo) in one pass, before a sync
+needs them. Priorities are included as the labels sync writes for them:
+priority:<level>, or the label set in the plugin's priority_labels.
+
+Labels are created one at a time, --interval apart, to stay under the
+system's rate limits. A label that can't be created is reported and the
+rest are still created, so a large sync doesn't stop partway through.
+
+Existing labels whose name differs only in case, or whose color differs
+from the label's todu color (or the one todu would generate), are reported
+as drift.
+
+Examples:
+  todu labels push --project website --dry-run
+  todu labels push --project website --interval 1s`,
+	Args: cobra.NoArgs,
+	RunE: runLabelsPush,
+}
+
+var (
+	// Set flags
+	labelsSetColor string
+	labelsSetEmoji string
+
+	// Push flags
+	labelsPushProject  string
+	labelsPushDryRun   bool
+	labelsPushInterval time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(labelsCmd)
+	labelsCmd.AddCommand(labelsListCmd)
+	labelsCmd.AddCommand(labelsSetCmd)
+	labelsCmd.AddCommand(labelsPushCmd)
+
+	labelsSetCmd.Flags().StringVar(&labelsSetColor, "color", "", "Hex color such as #d73a4a")
+	labelsSetCmd.Flags().StringVar(&labelsSetEmoji, "emoji", "", "Emoji shown before the label name")
+
+	labelsPushCmd.Flags().StringVarP(&labelsPushProject, "project", "p", "", "Project ID or name (required)")
+	labelsPushCmd.Flags().BoolVar(&labelsPushDryRun, "dry-run", false, "Show what would be created without creating labels")
+	labelsPushCmd.Flags().DurationVar(&labelsPushInterval, "interval", 250*time.Millisecond, "Pause between label creations")
+	_ = labelsPushCmd.MarkFlagRequired("project")
+}
+
+func runLabelsList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	labels, err := apiClient.ListLabels(commandContext())
+	if err != nil {
+		return fmt.Errorf("failed to list labels: %w", err)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(labels)
+	}
+
+	if len(labels) == 0 {
+		fmt.Println("No labels found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCOLOR\tEMOJI")
+	for _, label := range labels {
+		color := ""
+		if label.Color != "" {
+			color = "#" + label.Color
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", label.ID, label.Name, color, label.Emoji)
+	}
+	return w.Flush()
+}
+
+func runLabelsSet(cmd *cobra.Command, args []string) error {
+	colorSet := cmd.Flags().Changed("color")
+	emojiSet := cmd.Flags().Changed("emoji")
+	if !colorSet && !emojiSet {
+		return fmt.Errorf("nothing to set; use --color or --emoji")
+	}
+
+	update := &types.LabelUpdate{}
+	if colorSet {
+		color, err := types.NormalizeLabelColor(labelsSetColor)
+		if err != nil {
+			return err
+		}
+		update.Color = &color
+	}
+	if emojiSet {
+		emoji := strings.TrimSpace(labelsSetEmoji)
+		update.Emoji = &emoji
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	labels, err := apiClient.ListLabels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list labels: %w", err)
+	}
+	var label *types.Label
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, args[0]) {
+			label = l
+			break
+		}
+	}
+	if label == nil {
+		return fmt.Errorf("label %q not found", args[0])
+	}
+
+	updated, err := apiClient.UpdateLabel(ctx, label.ID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update label: %w", err)
+	}
+
+	fmt.Printf("Updated label %s\n", formatLabels([]types.Label{*updated}, labelColorsEnabled()))
+	return nil
+}
+
+func runLabelsPush(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	projectID, err := resolveProjectID(ctx, apiClient, labelsPushProject)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	project, err := apiClient.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	p, err := createProjectPlugin(ctx, apiClient, project)
+	if err != nil {
+		return err
+	}
+	pusher, ok := p.(plugin.LabelPusher)
+	if !ok {
+		return fmt.Errorf("plugin %s does not support pushing labels", p.Name())
+	}
+
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{ProjectID: &projectID})
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	templates, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{ProjectID: &projectID, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	labels, colors := projectLabels(tasks, templates)
+	result, err := pusher.PushLabels(ctx, &project.ExternalID, labels, plugin.LabelPushOptions{
+		DryRun:   labelsPushDryRun,
+		Interval: labelsPushInterval,
+		Colors:   colors,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push labels: %w", err)
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		displayLabelPush(os.Stdout, result, labelsPushDryRun)
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d labels could not be created", len(result.Failed))
+	}
+	return nil
+}
+
+// projectLabels returns the labels used by tasks and templates, and the
+// priority labels of their priorities, sorted, together with the colors of
+// those that have one.
+func projectLabels(tasks []*types.Task, templates []*types.RecurringTaskTemplate) ([]string, map[string]string) {
+	seen := make(map[string]bool)
+	colors := make(map[string]string)
+	add := func(labels []types.Label, priority *string) {
+		for _, label := range labels {
+			seen[label.Name] = true
+			if label.Color != "" {
+				colors[label.Name] = label.Color
+			}
+		}
+		if priority != nil && *priority != "" {
+			seen[plugin.PriorityLabelPrefix+*priority] = true
+		}
+	}
+	for _, task := range tasks {
+		add(task.Labels, task.Priority)
+	}
+	for _, tmpl := range templates {
+		add(tmpl.Labels, tmpl.Priority)
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, colors
+}
+
+// displayLabelPush prints the labels created, drifted, and failed by a push.
+func displayLabelPush(out io.Writer, result *plugin.LabelPushResult, dryRun bool) {
+	created := "Created"
+	if dryRun {
+		created = "Would create"
+	}
+	if len(result.Created) > 0 {
+		fmt.Fprintf(out, "%s: %s\n", created, strings.Join(result.Created, ", "))
+	}
+
+	if len(result.Drift) > 0 {
+		fmt.Fprintln(out, "\nDrift:")
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LABEL\tREMOTE NAME\tCOLOR\tEXPECTED")
+		for _, drift := range result.Drift {
+			fmt.Fprintf(w, "%s\t%s\t#%s\t#%s\n", drift.Name, drift.RemoteName, drift.Color, drift.ExpectedColor)
+		}
+		w.Flush()
+	}
+
+	if len(result.Failed) > 0 {
+		fmt.Fprintln(out, "\nFailed:")
+		names := make([]string, 0, len(result.Failed))
+		for name := range result.Failed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(out, "  %s: %s\n", name, result.Failed[name])
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d existing, %d %s, %d drifted, %d failed\n",
+		len(result.Existing), len(result.Created), strings.ToLower(created), len(result.Drift), len(result.Failed))
+}
+
+// labelColorsEnabled reports whether labels are shown as colored chips:
+// stdout is a terminal and $NO_COLOR is not set.
+func labelColorsEnabled() bool {
+	return os.Getenv("NO_COLOR") == "" && stdoutIsTerminal()
+}
+
+// formatLabels returns labels for display: colored chips separated by
+// spaces if color is true, and a comma-separated list otherwise. Emoji are
+// shown either way.
+func formatLabels(labels []types.Label, color bool) string {
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = label.DisplayName()
+		if color && label.Color != "" {
+			parts[i] = labelChip(parts[i], label.Color)
+		}
+	}
+	if color {
+		return strings.Join(parts, " ")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// labelChip returns text on a background of the given hex color, in black
+// or white text depending on which is easier to read.
+func labelChip(text, color string) string {
+	var r, g, b int
+	if _, err := fmt.Sscanf(color, "%02x%02x%02x", &r, &g, &b); err != nil {
+		return text
+	}
+	fg := "38;2;255;255;255"
+	if r*299+g*587+b*114 > 128*1000 {
+		fg = "38;2;0;0;0"
+	}
+	return fmt.Sprintf("\033[48;2;%d;%d;%d;%sm %s \033[0m", r, g, b, fg, text)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestProjectLabels(t *testing.T) {
+	high := "high"
+	tasks := []*types.Task{
+		{Labels: []types.Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs"}}, Priority: &high},
+		{Labels: []types.Label{{Name: "bug"}}},
+	}
+	templates := []*types.RecurringTaskTemplate{
+		{Labels: []types.Label{{Name: "chore"}}},
+	}
+
+	got, colors := projectLabels(tasks, templates)
+	want := []string{"bug", "chore", "docs", "priority:high"}
+	if !slices.Equal(got, want) {
+		t.Errorf("projectLabels() = %v, want %v", got, want)
+	}
+	if len(colors) != 1 || colors["bug"] != "d73a4a" {
+		t.Errorf("Expected only bug's color, got %v", colors)
+	}
+}
+
+func TestDisplayLabelPush(t *testing.T) {
+	result := &plugin.LabelPushResult{
+		Created:  []string{"docs"},
+		Existing: []string{"bug"},
+		Drift:    []plugin.LabelDrift{{Name: "bug", RemoteName: "Bug", Color: "ee0701", ExpectedColor: "c0ffee"}},
+		Failed:   map[string]string{"bad": "invalid name"},
+	}
+
+	var out bytes.Buffer
+	displayLabelPush(&out, result, true)
+	for _, want := range []string{"Would create: docs", "Bug", "#ee0701", "bad: invalid name", "1 existing, 1 would create, 1 drifted, 1 failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	labels := []types.Label{{Name: "bug", Color: "d73a4a", Emoji: "🐛"}, {Name: "docs"}}
+
+	if got := formatLabels(labels, false); got != "🐛 bug, docs" {
+		t.Errorf("Expected plain labels, got %q", got)
+	}
+
+	got := formatLabels(labels, true)
+	if !strings.Contains(got, "\033[48;2;215;58;74;38;2;255;255;255m 🐛 bug \033[0m") || !strings.HasSuffix(got, " docs") {
+		t.Errorf("Expected a white-on-red chip for bug and plain docs, got %q", got)
+	}
+	if chip := labelChip("x", "ffffff"); !strings.Contains(chip, "38;2;0;0;0") {
+		t.Errorf("Expected black text on a light chip, got %q", chip)
+	}
+}
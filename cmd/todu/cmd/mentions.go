@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/mentions"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var mentionsCmd = &cobra.Command{
+	Use:   "mentions",
+	Short: "List unread @mentions of you in comments and journal entries",
+	Long: `List task comments and journal entries that mention you as @name,
+including comments pulled from external systems by sync.
+
+You are mentioned by your author name and by any of mentions.names in the
+config, such as your GitHub login. Your own comments are not listed.
+
+Mentions stay unread until you run with --mark-read. Use --all to include
+mentions already read. "todu notify --mentions" alerts on new ones.
+
+Examples:
+  todu mentions
+  todu mentions --mark-read
+  todu mentions --all --since 2024-06-01`,
+	Args: cobra.NoArgs,
+	RunE: runMentions,
+}
+
+var (
+	// Mentions flags
+	mentionsAll      bool
+	mentionsMarkRead bool
+	mentionsSince    string
+)
+
+func init() {
+	rootCmd.AddCommand(mentionsCmd)
+	mentionsCmd.Flags().BoolVar(&mentionsAll, "all", false, "Include mentions already read")
+	mentionsCmd.Flags().BoolVar(&mentionsMarkRead, "mark-read", false, "Mark the listed mentions read")
+	mentionsCmd.Flags().StringVar(&mentionsSince, "since", "", "Only check comments created on or after this date (YYYY-MM-DD)")
+}
+
+func runMentions(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	names := mentionNames(cfg)
+	if len(names) == 0 {
+		return fmt.Errorf("no identity to find mentions of; set author or mentions.names in the config")
+	}
+
+	if mentionsSince != "" {
+		if _, err := time.Parse("2006-01-02", mentionsSince); err != nil {
+			return fmt.Errorf("invalid --since date %q (use YYYY-MM-DD)", mentionsSince)
+		}
+	}
+
+	statePath, err := mentions.DefaultStatePath()
+	if err != nil {
+		return err
+	}
+	state, err := mentions.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	found, err := findMentions(ctx, apiClient, names, mentionsSince)
+	if err != nil {
+		return err
+	}
+	if !mentionsAll {
+		found = state.Unread(found)
+	}
+
+	if GetOutputFormat() == "json" {
+		if found == nil {
+			found = []*types.Comment{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(found); err != nil {
+			return err
+		}
+	} else {
+		displayMentions(os.Stdout, found, state)
+	}
+
+	if mentionsMarkRead && len(found) > 0 {
+		state.MarkRead(found, time.Now())
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+		if GetOutputFormat() != "json" {
+			fmt.Printf("\nMarked %d mentions read\n", len(found))
+		}
+	}
+	return nil
+}
+
+// mentionNames returns the names that mention the user: the author name
+// (or the API key's user) and mentions.names, without duplicates.
+func mentionNames(cfg *config.Config) []string {
+	var names []string
+	for _, name := range append([]string{currentUser(cfg)}, cfg.Mentions.Names...) {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// findMentions returns the comments and journal entries created since the
+// given date (all if empty) that mention any of names, newest first.
+func findMentions(ctx context.Context, apiClient *api.Client, names []string, since string) ([]*types.Comment, error) {
+	comments, err := apiClient.ListCommentsFiltered(ctx, &api.CommentListOptions{
+		Type:         "all",
+		CreatedAfter: since,
+		Search:       "@",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	return mentions.Find(comments, names), nil
+}
+
+// displayMentions prints mentions as a table, marking read ones.
+func displayMentions(out io.Writer, found []*types.Comment, state *mentions.State) {
+	if len(found) == 0 {
+		fmt.Fprintln(out, "No mentions found")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tAUTHOR\tON\tCOMMENT")
+	for _, comment := range found {
+		on := "journal"
+		if comment.TaskID != nil {
+			on = fmt.Sprintf("task #%d", *comment.TaskID)
+		}
+		text := truncate(firstMentionLine(comment.Content), 60)
+		if _, read := state.Read[comment.ID]; read {
+			text += " (read)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", comment.ID, comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.Author, on, text)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nTotal: %d mentions\n", len(found))
+}
+
+// firstMentionLine returns the first line of content that mentions anyone,
+// or the first line if none does.
+func firstMentionLine(content string) string {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for _, line := range lines {
+		if len(mentions.Parse(line)) > 0 {
+			return strings.TrimSpace(line)
+		}
+	}
+	return strings.TrimSpace(lines[0])
+}
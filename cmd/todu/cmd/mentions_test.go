@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/mentions"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestMentionNames(t *testing.T) {
+	cfg := &config.Config{Author: "jane"}
+	cfg.Mentions.Names = []string{"@jdoe", "jane", " "}
+
+	if got := mentionNames(cfg); !slices.Equal(got, []string{"jane", "jdoe"}) {
+		t.Errorf("mentionNames() = %v, want [jane jdoe]", got)
+	}
+	if got := mentionNames(&config.Config{}); len(got) != 0 {
+		t.Errorf("Expected no names without author, got %v", got)
+	}
+}
+
+func TestDisplayMentions(t *testing.T) {
+	taskID := 12
+	found := []*types.Comment{
+		{ID: 7, TaskID: &taskID, Author: "bob", Content: "Context first\n@jane can you review?", CreatedAt: time.Now()},
+		{ID: 3, Author: "carol", Content: "@jane lunch?", CreatedAt: time.Now()},
+	}
+	state := &mentions.State{Read: map[int]time.Time{3: time.Now()}}
+
+	var out bytes.Buffer
+	displayMentions(&out, found, state)
+	got := out.String()
+	for _, want := range []string{"task #12", "@jane can you review?", "journal", "@jane lunch? (read)", "Total: 2 mentions"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestMentionReminders(t *testing.T) {
+	taskID := 12
+	found := []*types.Comment{{ID: 7, TaskID: &taskID, Author: "bob", Content: "@jane ping"}}
+
+	reminders := mentionReminders(found)
+	if len(reminders) != 1 || reminders[0].title != "Mentioned by bob on task #12" || reminders[0].message != "@jane ping" {
+		t.Errorf("Unexpected reminders: %+v", reminders)
+	}
+
+	many := slices.Repeat(found, maxMentionReminders+1)
+	if reminders := mentionReminders(many); len(reminders) != 1 || reminders[0].title != "4 new mentions" {
+		t.Errorf("Expected a single summary reminder, got %+v", reminders)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/evcraddock/todu.sh/internal/meta"
+	"github.com/spf13/cobra"
+)
+
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "Describe todu's data types and commands for tooling",
+	Long: `Machine-readable descriptions of todu's JSON output and command line,
+for scripts and agents that drive the CLI.`,
+}
+
+var metaSchemaCmd = &cobra.Command{
+	Use:   "schema [task|project|template|comment]",
+	Short: "Print JSON Schemas for todu's data types",
+	Long: `Print the JSON Schema for a data type as it appears in --format json
+output. With no argument, prints an object with the schemas for all types,
+keyed by name.
+
+Examples:
+  todu meta schema
+  todu meta schema task`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMetaSchema,
+}
+
+var metaCommandsCmd = &cobra.Command{
+	Use:   "commands",
+	Short: "List all commands with their flags and examples",
+	Long: `List every todu command. With --json (or --format json), prints a
+catalog of all commands with their usage, flags, defaults, and examples.
+
+Examples:
+  todu meta commands
+  todu meta commands --json`,
+	Args: cobra.NoArgs,
+	RunE: runMetaCommands,
+}
+
+var (
+	// Meta flags
+	metaCommandsJSON bool
+)
+
+func init() {
+	rootCmd.AddCommand(metaCmd)
+	metaCmd.AddCommand(metaSchemaCmd)
+	metaCmd.AddCommand(metaCommandsCmd)
+
+	metaCommandsCmd.Flags().BoolVar(&metaCommandsJSON, "json", false, "Print the full command catalog as JSON")
+}
+
+func runMetaSchema(cmd *cobra.Command, args []string) error {
+	var output any
+	if len(args) == 0 {
+		output = meta.AllSchemas()
+	} else {
+		schema, err := meta.SchemaFor(args[0])
+		if err != nil {
+			return err
+		}
+		output = schema
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format schema: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func runMetaCommands(cmd *cobra.Command, args []string) error {
+	catalog := meta.DescribeCommands(rootCmd)
+
+	if metaCommandsJSON || GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(catalog, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format command catalog: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayCommandList(os.Stdout, catalog.Subcommands)
+	return nil
+}
+
+// displayCommandList prints each runnable command's path and summary
+func displayCommandList(out io.Writer, commands []meta.Command) {
+	for _, c := range commands {
+		if c.Runnable {
+			fmt.Fprintf(out, "%-32s %s\n", c.Path, c.Short)
+		}
+		displayCommandList(out, c.Subcommands)
+	}
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/mentions"
+	"github.com/evcraddock/todu.sh/internal/reminders"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Show reminders that need attention",
+	Long: `Show reminders that need attention: task reminders set with
+--remind on "todu task create" or "todu task update", and an overdue daily
+or weekly review (see "todu review status").
+
+Task reminders are sent once each, at their offset before the task is due.
+A reminder more than a day late, because notify wasn't run in time, is
+skipped. The daemon sends task reminders too.
+
+With --desktop, each reminder is also sent as a desktop notification
+(notify-send on Linux, osascript on macOS), so notify can run from cron or
+a scheduler. Nothing is printed or sent when there are no reminders.
+
+Review reminders need local_reports to be configured.
+
+With --mentions, new unread @mentions of you (see "todu mentions"),
+including ones pulled in by sync, are reported too. Each mention is only
+reported once.
+
+Example:
+  todu notify
+  todu notify --desktop
+  todu notify --desktop --mentions
+
+  # crontab: check every 30 minutes during the day
+  */30 8-18 * * * todu notify --desktop`,
+	Args: cobra.NoArgs,
+	RunE: runNotify,
+}
+
+var (
+	// Notify flags
+	notifyDesktop  bool
+	notifyMentions bool
+)
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().BoolVar(&notifyDesktop, "desktop", false, "Also send reminders as desktop notifications")
+	notifyCmd.Flags().BoolVar(&notifyMentions, "mentions", false, "Also report new @mentions of you")
+}
+
+// reminder is something todu notify tells the user about.
+type reminder struct {
+	title   string
+	message string
+}
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	now := time.Now()
+	due, markTasksNotified, err := newTaskReminders(cfg, now)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := reviewReminders(cfg, now)
+	if err != nil {
+		return err
+	}
+	due = append(due, reviews...)
+
+	var markNotified func() error
+	if notifyMentions {
+		var mentionReminders []reminder
+		mentionReminders, markNotified, err = newMentionReminders(cfg, now)
+		if err != nil {
+			return err
+		}
+		due = append(due, mentionReminders...)
+	}
+
+	for _, r := range due {
+		fmt.Printf("%s: %s\n", r.title, r.message)
+		if notifyDesktop {
+			if err := reminders.SendDesktop(r.title, r.message); err != nil {
+				return fmt.Errorf("failed to send notification: %w", err)
+			}
+		}
+	}
+	if markTasksNotified != nil {
+		if err := markTasksNotified(); err != nil {
+			return err
+		}
+	}
+	if markNotified != nil {
+		return markNotified()
+	}
+	return nil
+}
+
+// newTaskReminders returns a reminder for each task reminder due to be
+// sent, and a function that records them as sent.
+func newTaskReminders(cfg *config.Config, now time.Time) ([]reminder, func() error, error) {
+	if cfg.APIURL == "" {
+		return nil, nil, nil
+	}
+
+	statePath, err := reminders.DefaultStatePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	state, err := reminders.LoadState(statePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	tasks, err := apiClient.ListAllTasks(commandContext(), &api.TaskListOptions{
+		DueAfter: now.Add(-reminders.MaxLate).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	pending := reminders.Pending(tasks, now, state)
+	if len(pending) == 0 {
+		return nil, nil, nil
+	}
+
+	result := make([]reminder, len(pending))
+	for i, r := range pending {
+		result[i] = reminder{
+			title:   fmt.Sprintf("Task #%d: %s", r.TaskID, r.Title),
+			message: r.Message(now),
+		}
+	}
+	markNotified := func() error {
+		state.MarkNotified(pending, now)
+		return state.Save(statePath)
+	}
+	return result, markNotified, nil
+}
+
+// maxMentionReminders is how many new mentions are reported one by one;
+// more are summarized in a single reminder.
+const maxMentionReminders = 3
+
+// newMentionReminders returns a reminder for the unread mentions not
+// reported yet, and a function that records them as reported.
+func newMentionReminders(cfg *config.Config, now time.Time) ([]reminder, func() error, error) {
+	if cfg.APIURL == "" {
+		return nil, nil, fmt.Errorf("API URL not configured")
+	}
+	names := mentionNames(cfg)
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("no identity to find mentions of; set author or mentions.names in the config")
+	}
+
+	statePath, err := mentions.DefaultStatePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	state, err := mentions.LoadState(statePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	found, err := findMentions(commandContext(), apiClient, names, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	found = state.Unnotified(found)
+	if len(found) == 0 {
+		return nil, nil, nil
+	}
+
+	markNotified := func() error {
+		state.MarkNotified(found, now)
+		return state.Save(statePath)
+	}
+	return mentionReminders(found), markNotified, nil
+}
+
+// mentionReminders turns new mentions into reminders, one per mention up to
+// maxMentionReminders, or a single summary beyond that.
+func mentionReminders(found []*types.Comment) []reminder {
+	if len(found) > maxMentionReminders {
+		return []reminder{{
+			title:   fmt.Sprintf("%d new mentions", len(found)),
+			message: "run 'todu mentions' to read them",
+		}}
+	}
+
+	reminders := make([]reminder, 0, len(found))
+	for _, comment := range found {
+		on := "a journal entry"
+		if comment.TaskID != nil {
+			on = fmt.Sprintf("task #%d", *comment.TaskID)
+		}
+		reminders = append(reminders, reminder{
+			title:   fmt.Sprintf("Mentioned by %s on %s", comment.Author, on),
+			message: truncate(firstMentionLine(comment.Content), 100),
+		})
+	}
+	return reminders
+}
+
+// reviewReminders returns a reminder for each overdue review. Without
+// local_reports there is nothing to check.
+func reviewReminders(cfg *config.Config, now time.Time) ([]reminder, error) {
+	if cfg.LocalReports == "" {
+		return nil, nil
+	}
+
+	statuses, err := reviewStatuses(cfg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var reminders []reminder
+	for _, status := range statuses {
+		if !status.Overdue {
+			continue
+		}
+		reminders = append(reminders, reminder{
+			title:   fmt.Sprintf("%s review overdue", capitalize(status.Review)),
+			message: reviewReminderMessage(status, now),
+		})
+	}
+	return reminders, nil
+}
+
+// reviewReminderMessage says how long ago a review was last generated and
+// how to generate the next one.
+func reviewReminderMessage(status *review.Status, now time.Time) string {
+	last := "never generated"
+	if !status.Never() {
+		last = "last generated " + formatAgo(now.Sub(status.LastGenerated))
+	}
+	return fmt.Sprintf("%s; run 'todu review %s --save'", last, status.Review)
+}
+
+// formatAgo renders a duration as a rough "N days ago" phrase.
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "less than an hour ago"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h ago"
+	default:
+		days := int(d.Hours() / 24)
+		if days == 1 {
+			return "1 day ago"
+		}
+		return strconv.Itoa(days) + " days ago"
+	}
+}
+
+// capitalize upper-cases the first letter of an ASCII word.
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/review"
+)
+
+func TestReviewReminders(t *testing.T) {
+	cfg := &config.Config{LocalReports: t.TempDir()}
+	cfg.Review.Daily.RemindAt = "09:00"
+	cfg.Review.Weekly.RemindAfterDays = 7
+	now := time.Date(2025, 12, 16, 10, 0, 0, 0, time.Local)
+
+	reminders, err := reviewReminders(cfg, now)
+	if err != nil {
+		t.Fatalf("reviewReminders() error = %v", err)
+	}
+	if len(reminders) != 2 {
+		t.Fatalf("got %d reminders, want daily and weekly", len(reminders))
+	}
+	if reminders[0].title != "Daily review overdue" || !strings.Contains(reminders[0].message, "never generated") {
+		t.Errorf("daily reminder = %+v", reminders[0])
+	}
+
+	if err := review.SaveDailyReport("# Daily Review\n\nGenerated: 2025-12-16 09:30\n", review.DefaultDailyReportPath(cfg.LocalReports)); err != nil {
+		t.Fatal(err)
+	}
+	reminders, _ = reviewReminders(cfg, now)
+	if len(reminders) != 1 || reminders[0].title != "Weekly review overdue" {
+		t.Errorf("after today's daily review got %+v, want only the weekly reminder", reminders)
+	}
+
+	// Without local_reports there is nothing to check
+	reminders, err = reviewReminders(&config.Config{}, now)
+	if err != nil || len(reminders) != 0 {
+		t.Errorf("without local_reports got %+v, %v; want none", reminders, err)
+	}
+}
+
+func TestReviewReminderMessage(t *testing.T) {
+	now := time.Date(2025, 12, 16, 10, 0, 0, 0, time.Local)
+	status := &review.Status{Review: "weekly", LastGenerated: now.AddDate(0, 0, -9)}
+
+	want := "last generated 9 days ago; run 'todu review weekly --save'"
+	if got := reviewReminderMessage(status, now); got != want {
+		t.Errorf("reviewReminderMessage() = %q, want %q", got, want)
+	}
+}
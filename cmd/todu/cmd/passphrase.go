@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// passphraseEnv names the environment variable that supplies the journal
+// encryption passphrase without prompting (e.g., for scripts).
+const passphraseEnv = "TODU_JOURNAL_PASSPHRASE"
+
+// readPassphrase returns the journal passphrase from $TODU_JOURNAL_PASSPHRASE
+// or by prompting on the terminal without echo. If confirm is set, the
+// passphrase is asked for twice.
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	input := bufio.NewReader(os.Stdin)
+	passphrase, err := promptPassphrase(input, "Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase is required")
+	}
+
+	if confirm {
+		again, err := promptPassphrase(input, "Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+// promptPassphrase reads a line from stdin, hiding input on a terminal.
+// Piped input is still read in non-interactive mode, through input, which
+// is shared between prompts so lines buffered by an earlier one aren't lost.
+func promptPassphrase(input *bufio.Reader, prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		if err := requireInteractive("the passphrase", "set $"+passphraseEnv); err != nil {
+			return "", err
+		}
+	}
+	fmt.Fprint(os.Stderr, prompt)
+
+	if term.IsTerminal(fd) {
+		value, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(value), nil
+	}
+
+	line, err := input.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
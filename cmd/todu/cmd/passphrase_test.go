@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// pipeStdin replaces stdin with a pipe holding input for the rest of the test.
+func pipeStdin(t *testing.T, input string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatalf("Failed to write to pipe: %v", err)
+	}
+	_ = w.Close()
+
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		_ = r.Close()
+	})
+}
+
+func TestReadPassphrasePiped(t *testing.T) {
+	t.Setenv(passphraseEnv, "")
+
+	pipeStdin(t, "secret\nsecret\n")
+	if got, err := readPassphrase(true); err != nil || got != "secret" {
+		t.Errorf("readPassphrase(true) = %q, %v, want secret", got, err)
+	}
+
+	pipeStdin(t, "secret\r\n")
+	if got, err := readPassphrase(false); err != nil || got != "secret" {
+		t.Errorf("readPassphrase(false) = %q, %v, want secret", got, err)
+	}
+
+	pipeStdin(t, "secret\nother\n")
+	if _, err := readPassphrase(true); err == nil || err.Error() != "passphrases do not match" {
+		t.Errorf("Expected mismatched passphrases refused, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pickerPageSize is the number of matches shown at once by the picker.
+const pickerPageSize = 10
+
+// pickerItem is a selectable entry in the fuzzy picker.
+type pickerItem struct {
+	// label is shown to the user and matched against the query.
+	label string
+
+	// id identifies the item to the caller.
+	id int
+}
+
+// fuzzyScore matches query against text fzf-style: every space-separated term
+// must appear in text as a case-insensitive subsequence. Consecutive matches
+// and matches at word starts score higher. Returns false if any term fails to
+// match.
+func fuzzyScore(query, text string) (int, bool) {
+	textRunes := []rune(strings.ToLower(text))
+	total := 0
+
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		score, ok := fuzzyTermScore([]rune(term), textRunes)
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+
+	return total, true
+}
+
+// fuzzyTermScore scores a single query term against lowercased text.
+func fuzzyTermScore(term, text []rune) (int, bool) {
+	score := 0
+	ti := 0
+	last := -1
+
+	for _, r := range term {
+		found := false
+		for ; ti < len(text); ti++ {
+			if text[ti] != r {
+				continue
+			}
+
+			score++
+			if last >= 0 && ti == last+1 {
+				score += 5
+			}
+			if ti == 0 || !unicode.IsLetter(text[ti-1]) && !unicode.IsDigit(text[ti-1]) {
+				score += 8
+			}
+			if last >= 0 {
+				// Penalize gaps between matched characters, capped
+				score -= min(ti-last-1, 3)
+			}
+
+			last = ti
+			ti++
+			found = true
+			break
+		}
+		if !found {
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+// filterPickerItems returns the items matching query, best matches first.
+// Items with equal scores keep their original order.
+func filterPickerItems(items []pickerItem, query string) []pickerItem {
+	if strings.TrimSpace(query) == "" {
+		return items
+	}
+
+	type scored struct {
+		item  pickerItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, ok := fuzzyScore(query, item.label); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]pickerItem, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+	return result
+}
+
+// runPicker interactively narrows items by fuzzy query until the user selects
+// one by its list number. Typing anything else replaces the query. Returns
+// false if the user cancels with an empty line or input ends.
+func runPicker(in io.Reader, out io.Writer, items []pickerItem) (pickerItem, bool) {
+	reader := bufio.NewReader(in)
+	query := ""
+
+	for {
+		matches := filterPickerItems(items, query)
+		shown := matches[:min(len(matches), pickerPageSize)]
+
+		fmt.Fprintln(out)
+		if len(shown) == 0 {
+			fmt.Fprintf(out, "No matches for %q\n", query)
+		}
+		for i, item := range shown {
+			fmt.Fprintf(out, "%2d) %s\n", i+1, item.label)
+		}
+		if len(matches) > len(shown) {
+			fmt.Fprintf(out, "    ...and %d more, type to narrow\n", len(matches)-len(shown))
+		}
+
+		fmt.Fprint(out, "Filter, or number to select (empty to cancel): ")
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return pickerItem{}, false
+		}
+
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= len(shown) {
+			return shown[n-1], true
+		}
+		query = line
+
+		if err != nil {
+			return pickerItem{}, false
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		match bool
+	}{
+		{"rnw", "Renew passport", true},
+		{"RENEW", "renew passport", true},
+		{"pass renew", "Renew passport", true},
+		{"xyz", "Renew passport", false},
+		{"renew car", "Renew passport", false},
+		{"", "anything", true},
+	}
+
+	for _, tt := range tests {
+		_, ok := fuzzyScore(tt.query, tt.text)
+		if ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) match = %v, expected %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+}
+
+func TestFuzzyScorePrefersConsecutiveAndWordStart(t *testing.T) {
+	tight, _ := fuzzyScore("pass", "Renew passport")
+	loose, _ := fuzzyScore("pass", "prepare a sales sheet")
+	if tight <= loose {
+		t.Errorf("Expected consecutive word-start match to score higher: %d <= %d", tight, loose)
+	}
+}
+
+func TestFilterPickerItems(t *testing.T) {
+	items := []pickerItem{
+		{id: 1, label: "#1 Prepare a sales sheet [work]"},
+		{id: 2, label: "#2 Renew passport [home]"},
+		{id: 3, label: "#3 Buy milk [home]"},
+	}
+
+	matches := filterPickerItems(items, "pass")
+	if len(matches) != 2 || matches[0].id != 2 {
+		t.Errorf("Expected passport first, got %+v", matches)
+	}
+
+	if got := filterPickerItems(items, "  "); len(got) != 3 {
+		t.Errorf("Expected all items for empty query, got %d", len(got))
+	}
+}
+
+func TestRunPicker(t *testing.T) {
+	items := []pickerItem{
+		{id: 1, label: "#1 Prepare a sales sheet [work]"},
+		{id: 2, label: "#2 Renew passport [home]"},
+		{id: 3, label: "#3 Buy milk [home]"},
+	}
+
+	var out bytes.Buffer
+	picked, ok := runPicker(strings.NewReader("home\n2\n"), &out, items)
+	if !ok || picked.id != 3 {
+		t.Errorf("Expected to pick task 3 after filtering, got %+v (ok=%v)\n%s", picked, ok, out.String())
+	}
+
+	if _, ok := runPicker(strings.NewReader("\n"), &out, items); ok {
+		t.Error("Expected empty input to cancel")
+	}
+
+	if _, ok := runPicker(strings.NewReader("nothing"), &out, items); ok {
+		t.Error("Expected end of input to cancel")
+	}
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan your day by walking through candidate tasks",
+	Long: `Walk through the tasks worth doing today and decide on each one.
+
+Candidates are the daily review's Coming up Soon and Next tasks: tasks due
+soon, high priority tasks, and tasks in the default project. Tasks already
+scheduled for today are skipped.
+
+For each task, choose:
+  a  accept  Schedule the task for today
+  s  skip    Leave the task as it is
+  z  snooze  Schedule the task for later (--snooze, default tomorrow)
+  q  quit    Stop planning; remaining tasks are left as they are
+
+When done, the daily review is printed.
+
+Examples:
+  todu plan
+  todu plan --snooze +3d
+  todu plan --no-review`,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+var (
+	// Plan flags
+	planSnooze   string
+	planNoReview bool
+)
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.Flags().StringVar(&planSnooze, "snooze", "tomorrow", "When snoozed tasks are scheduled (YYYY-MM-DD, tomorrow, next-week, or +Nd/w/m)")
+	planCmd.Flags().BoolVar(&planNoReview, "no-review", false, "Don't print the daily review after planning")
+}
+
+// planAction is the decision made for a candidate task.
+type planAction int
+
+const (
+	planSkip planAction = iota
+	planAccept
+	planSnoozed
+)
+
+// planDecision pairs a candidate task with the decision made for it.
+type planDecision struct {
+	task   *types.Task
+	action planAction
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	opts, err := dailyReviewOptions(cfg, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// Before day_start, planning is still for the previous day
+	targetDate := daystart.Today(opts.DayStart)
+	today := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, time.UTC)
+	shift, err := parseDateShift(planSnooze, today)
+	if err != nil {
+		return fmt.Errorf("invalid --snooze value: %w", err)
+	}
+	snoozeDate := shift.apply(today)
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	candidates, projectNames, err := review.PlanCandidates(ctx, apiClient, targetDate, opts)
+	if err != nil {
+		return fmt.Errorf("failed to find tasks to plan: %w", err)
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("Nothing to plan: no candidate tasks.")
+	} else {
+		if err := requireInteractive("todu plan", ""); err != nil {
+			return err
+		}
+		fmt.Printf("Planning %s: %d candidate tasks\n\n", today.Format("Mon 2006-01-02"), len(candidates))
+		decisions, err := promptPlan(os.Stdin, os.Stdout, candidates, projectNames)
+		if err != nil {
+			return err
+		}
+
+		accepted, snoozed, err := applyPlan(ctx, apiClient, hookRunner, ruleEngine, decisions, today, snoozeDate)
+		fmt.Printf("\nPlanned %d tasks for today, snoozed %d to %s\n", accepted, snoozed, snoozeDate.Format("Mon 2006-01-02"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if planNoReview {
+		return nil
+	}
+
+	markdown, err := review.DailyReport(ctx, apiClient, targetDate, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate daily review: %w", err)
+	}
+	fmt.Println()
+	fmt.Print(markdown)
+	return nil
+}
+
+// promptPlan asks for a decision on each candidate task in turn. Input
+// ending early is treated as quitting.
+func promptPlan(in io.Reader, out io.Writer, tasks []*types.Task, projectNames map[int]string) ([]planDecision, error) {
+	reader := bufio.NewReader(in)
+	var decisions []planDecision
+
+	for i := 0; i < len(tasks); i++ {
+		task := tasks[i]
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(tasks), planTaskLine(task, projectNames))
+		fmt.Fprint(out, "Accept, skip, snooze, or quit? [a/s/z/q]: ")
+
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		if err == io.EOF && line == "" {
+			fmt.Fprintln(out)
+			return decisions, nil
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "a", "accept":
+			decisions = append(decisions, planDecision{task: task, action: planAccept})
+		case "s", "skip", "":
+			decisions = append(decisions, planDecision{task: task, action: planSkip})
+		case "z", "snooze":
+			decisions = append(decisions, planDecision{task: task, action: planSnoozed})
+		case "q", "quit":
+			return decisions, nil
+		default:
+			fmt.Fprintln(out, "Please enter a, s, z, or q.")
+			i--
+		}
+	}
+
+	return decisions, nil
+}
+
+// planTaskLine describes a candidate task for the plan prompt.
+func planTaskLine(task *types.Task, projectNames map[int]string) string {
+	line := fmt.Sprintf("#%d %s (%s)", task.ID, task.Title, projectNames[task.ProjectID])
+	if task.Priority != nil && *task.Priority != "" {
+		line += " [" + *task.Priority + "]"
+	}
+	if task.DueDate != nil {
+		line += " - Due: " + task.DueDate.UTC().Format("2006-01-02")
+	}
+	return line
+}
+
+// applyPlan schedules accepted tasks for today and snoozed tasks for
+// snoozeDate. Returns how many tasks were accepted and snoozed.
+func applyPlan(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, decisions []planDecision, today, snoozeDate time.Time) (int, int, error) {
+	accepted, snoozed := 0, 0
+	for _, d := range decisions {
+		var scheduled time.Time
+		switch d.action {
+		case planAccept:
+			scheduled = today
+		case planSnoozed:
+			scheduled = snoozeDate
+		default:
+			continue
+		}
+
+		if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, d.task.ID, d.task, &types.TaskUpdate{ScheduledDate: &scheduled}, ""); err != nil {
+			return accepted, snoozed, fmt.Errorf("failed to schedule task #%d: %w", d.task.ID, err)
+		}
+		if d.action == planAccept {
+			accepted++
+		} else {
+			snoozed++
+		}
+	}
+	return accepted, snoozed, nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestPromptPlan(t *testing.T) {
+	tasks := []*types.Task{
+		{ID: 1, Title: "First", ProjectID: 1},
+		{ID: 2, Title: "Second", ProjectID: 1},
+		{ID: 3, Title: "Third", ProjectID: 1},
+		{ID: 4, Title: "Fourth", ProjectID: 1},
+	}
+	var out bytes.Buffer
+
+	decisions, err := promptPlan(strings.NewReader("a\nwhat\nz\n\nq\n"), &out, tasks, map[int]string{1: "Work"})
+	if err != nil {
+		t.Fatalf("promptPlan failed: %v", err)
+	}
+
+	expected := []planAction{planAccept, planSnoozed, planSkip}
+	if len(decisions) != len(expected) {
+		t.Fatalf("Expected %d decisions, got %d", len(expected), len(decisions))
+	}
+	for i, action := range expected {
+		if decisions[i].action != action || decisions[i].task.ID != i+1 {
+			t.Errorf("Decision %d = task #%d action %d, expected task #%d action %d", i, decisions[i].task.ID, decisions[i].action, i+1, action)
+		}
+	}
+	if !strings.Contains(out.String(), "Please enter a, s, z, or q.") {
+		t.Errorf("Expected invalid input to be rejected, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "[1/4] #1 First (Work)") {
+		t.Errorf("Expected task prompt, got:\n%s", out.String())
+	}
+}
+
+func TestPromptPlanEndOfInput(t *testing.T) {
+	tasks := []*types.Task{{ID: 1, Title: "First"}, {ID: 2, Title: "Second"}}
+
+	decisions, err := promptPlan(strings.NewReader("a"), &bytes.Buffer{}, tasks, nil)
+	if err != nil {
+		t.Fatalf("promptPlan failed: %v", err)
+	}
+	if len(decisions) != 1 || decisions[0].action != planAccept {
+		t.Errorf("Expected only the first task to be accepted, got %+v", decisions)
+	}
+}
+
+func TestPlanTaskLine(t *testing.T) {
+	priority := "high"
+	due := mustDate("2024-06-01")
+	task := &types.Task{ID: 7, Title: "Ship it", ProjectID: 2, Priority: &priority, DueDate: &due}
+
+	got := planTaskLine(task, map[int]string{2: "Work"})
+	if got != "#7 Ship it (Work) [high] - Due: 2024-06-01" {
+		t.Errorf("planTaskLine() = %q", got)
+	}
+}
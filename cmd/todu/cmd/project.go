@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,7 +9,10 @@ import (
 	"text/tabwriter"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/plugincache"
 	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -46,16 +48,31 @@ with the external system.
 For local-only projects (no external sync), you can simply use:
   todu project add --name "My Project"
 
-This will auto-register the local system and generate an external ID.`,
+This will auto-register the local system and generate an external ID.
+
+Inside a git repository, --from-git registers the repository's project in
+one step: the origin remote picks the system (github.com uses github,
+other hosts use a system whose URL matches, or forgejo), owner/repo
+becomes the external ID, and the repository name becomes the project name.
+A .todu.yaml file is written at the repository root to pin the mapping
+for teammates.
+
+Examples:
+  todu project add --name "My Project"
+  todu project add --system github --external-id owner/repo --name repo
+  todu project add --from-git
+  todu project add --from-git --remote upstream --no-repo-file`,
 	RunE: runProjectAdd,
 }
 
 var projectShowCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show project details",
-	Long:  `Display detailed information about a specific project.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProjectShow,
+	Long: `Display detailed information about a specific project.
+
+Use --notes to also show the project's notes (see 'todu project notes').`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectShow,
 }
 
 var projectUpdateCmd = &cobra.Command{
@@ -82,7 +99,18 @@ var projectDiscoverCmd = &cobra.Command{
 	Long: `Discover available projects from an external system.
 
 This queries the external system (via its plugin) for all accessible
-projects and shows which ones are already registered in todu.`,
+projects and shows which ones are already registered in todu.
+
+Use --query to list only the projects matching a search in the external
+system's own syntax, for plugins that support search (GitHub and Forgejo).
+
+The list of projects is cached for an hour (set TODU_PLUGIN_<NAME>_CACHE_TTL
+to change it, or "0" to disable caching). Use --refresh to fetch it again.
+
+Examples:
+  todu project discover --system github
+  todu project discover --system github --query "org:myorg topic:active"
+  todu project discover --system github --query "org:myorg" --auto-import`,
 	RunE: runProjectDiscover,
 }
 
@@ -98,15 +126,20 @@ var (
 	projectAddStatus          string
 	projectAddPriority        string
 	projectAddSyncStrategy    string
+	projectAddFromGit         bool
+	projectAddRemote          string
+	projectAddNoRepoFile      bool
 	projectUpdateName         string
 	projectUpdateDescription  string
 	projectUpdateStatus       string
 	projectUpdatePriority     string
 	projectUpdateSyncStrategy string
-	projectRemoveForce        bool
+	projectRemoveYes          bool
 	projectRemoveCascade      bool
 	projectDiscoverSystem     string
 	projectDiscoverAutoImport bool
+	projectDiscoverRefresh    bool
+	projectDiscoverQuery      string
 )
 
 func init() {
@@ -120,7 +153,7 @@ func init() {
 
 	// project list flags
 	projectListCmd.Flags().StringVar(&projectListSystem, "system", "", "Filter by system ID or name")
-	projectListCmd.Flags().StringSliceVar(&projectListPriority, "priority", nil, "Filter by priority (low, medium, high) - can be specified multiple times")
+	projectListCmd.Flags().StringSliceVar(&projectListPriority, "priority", nil, "Filter by priority (a level from priorities in config) - can be specified multiple times")
 	projectListCmd.Flags().StringVar(&projectListStatus, "status", "active", "Filter by status (active, done, cancelled)")
 	projectListCmd.Flags().BoolVar(&projectListAll, "all", false, "Show all projects regardless of status")
 
@@ -130,24 +163,30 @@ func init() {
 	projectAddCmd.Flags().StringVar(&projectAddName, "name", "", "Project name (required)")
 	projectAddCmd.Flags().StringVar(&projectAddDescription, "description", "", "Project description")
 	projectAddCmd.Flags().StringVar(&projectAddStatus, "status", "active", "Project status")
-	projectAddCmd.Flags().StringVar(&projectAddPriority, "priority", "", "Project priority (low, medium, high)")
+	projectAddCmd.Flags().StringVar(&projectAddPriority, "priority", "", "Project priority (a level from priorities in config)")
 	projectAddCmd.Flags().StringVar(&projectAddSyncStrategy, "sync-strategy", "bidirectional", "Sync strategy (pull, push, or bidirectional)")
+	projectAddCmd.Flags().BoolVar(&projectAddFromGit, "from-git", false, "Register the current git repository's project from its remote")
+	projectAddCmd.Flags().StringVar(&projectAddRemote, "remote", "origin", "Git remote used by --from-git")
+	projectAddCmd.Flags().BoolVar(&projectAddNoRepoFile, "no-repo-file", false, "Don't write .todu.yaml with --from-git")
 	_ = projectAddCmd.MarkFlagRequired("name")
 
 	// project update flags
 	projectUpdateCmd.Flags().StringVar(&projectUpdateName, "name", "", "Project name")
 	projectUpdateCmd.Flags().StringVar(&projectUpdateDescription, "description", "", "Project description")
 	projectUpdateCmd.Flags().StringVar(&projectUpdateStatus, "status", "", "Project status")
-	projectUpdateCmd.Flags().StringVar(&projectUpdatePriority, "priority", "", "Project priority (low, medium, high)")
+	projectUpdateCmd.Flags().StringVar(&projectUpdatePriority, "priority", "", "Project priority (a level from priorities in config)")
 	projectUpdateCmd.Flags().StringVar(&projectUpdateSyncStrategy, "sync-strategy", "", "Sync strategy (pull, push, or bidirectional)")
 
 	// project remove flags
-	projectRemoveCmd.Flags().BoolVar(&projectRemoveForce, "force", false, "Skip confirmation prompt")
+	addYesFlag(projectRemoveCmd, &projectRemoveYes)
+	addForceAlias(projectRemoveCmd, &projectRemoveYes, "")
 	projectRemoveCmd.Flags().BoolVar(&projectRemoveCascade, "cascade", false, "Delete associated tasks as well")
 
 	// project discover flags
 	projectDiscoverCmd.Flags().StringVar(&projectDiscoverSystem, "system", "", "System ID or name (required)")
 	projectDiscoverCmd.Flags().BoolVar(&projectDiscoverAutoImport, "auto-import", false, "Automatically import all discovered projects")
+	projectDiscoverCmd.Flags().BoolVar(&projectDiscoverRefresh, "refresh", false, "Refetch projects instead of using the cache")
+	projectDiscoverCmd.Flags().StringVar(&projectDiscoverQuery, "query", "", "Only discover projects matching a search query in the system's syntax")
 	_ = projectDiscoverCmd.MarkFlagRequired("system")
 }
 
@@ -175,16 +214,16 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 		opts.Status = []string{projectListStatus}
 	}
 
-	projects, err := client.ListProjects(context.Background(), opts)
+	projects, err := client.ListProjects(commandContext(), opts)
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
-	// Sort by priority (high > medium > low > nil), then by status (active first)
+	// Sort by priority (highest level first, nil last), then by status (active first)
 	sortProjectsByPriorityAndStatus(projects)
 
 	// Fetch all systems to map IDs to names
-	systems, err := client.ListSystems(context.Background())
+	systems, err := client.ListSystems(commandContext())
 	if err != nil {
 		return fmt.Errorf("failed to list systems: %w", err)
 	}
@@ -194,14 +233,15 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 		systemNames[sys.ID] = sys.Identifier
 	}
 
+	out := cmd.OutOrStdout()
 	if GetOutputFormat() == "json" {
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(projects)
 	}
 
 	// Table output
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ID\tNAME\tSYSTEM\tSTATUS\tPRIORITY\tLAST SYNCED")
 
 	for _, project := range projects {
@@ -245,21 +285,24 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid sync strategy %q: must be pull, push, or bidirectional", projectAddSyncStrategy)
 	}
 
-	// Validate priority if provided
-	if projectAddPriority != "" {
-		validPriorities := map[string]bool{"low": true, "medium": true, "high": true}
-		if !validPriorities[projectAddPriority] {
-			return fmt.Errorf("invalid priority %q: must be low, medium, or high", projectAddPriority)
-		}
-	}
-
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Validate priority if provided
+	if projectAddPriority != "" {
+		if err := types.ValidatePriority(projectAddPriority); err != nil {
+			return err
+		}
+	}
+
 	client := api.NewClient(cfg.APIURL, cfg.APIKey)
 
+	if projectAddFromGit {
+		return runProjectAddFromGit(cmd, client)
+	}
+
 	var systemID int
 
 	// Handle local system auto-registration
@@ -281,7 +324,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		}
 
 		// Validate system exists
-		_, err = client.GetSystem(context.Background(), systemID)
+		_, err = client.GetSystem(commandContext(), systemID)
 		if err != nil {
 			return fmt.Errorf("system %q not found: %w", projectAddSystem, err)
 		}
@@ -312,11 +355,17 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		SyncStrategy: projectAddSyncStrategy,
 	}
 
-	project, err := client.CreateProject(context.Background(), projectCreate)
+	project, err := client.CreateProject(commandContext(), projectCreate)
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
 	}
 
+	printCreatedProject(project)
+	return nil
+}
+
+// printCreatedProject prints the summary shown after adding a project
+func printCreatedProject(project *types.Project) {
 	fmt.Printf("Created project %d: %s\n", project.ID, project.Name)
 	fmt.Printf("  System: %d\n", project.SystemID)
 	fmt.Printf("  External ID: %s\n", project.ExternalID)
@@ -325,8 +374,6 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Priority: %s\n", *project.Priority)
 	}
 	fmt.Printf("  Sync Strategy: %s\n", project.SyncStrategy)
-
-	return nil
 }
 
 func runProjectShow(cmd *cobra.Command, args []string) error {
@@ -336,7 +383,7 @@ func runProjectShow(cmd *cobra.Command, args []string) error {
 	}
 
 	client := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Resolve project ID from name or ID
 	projectID, err := resolveProjectID(ctx, client, args[0])
@@ -355,36 +402,61 @@ func runProjectShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get system: %w", err)
 	}
 
+	var notes string
+	if projectShowNotes {
+		if err := requireProjectNotes(cfg); err != nil {
+			return err
+		}
+		notes, err = review.LoadProjectNotes(cfg.LocalReports, project.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
 	// Display results
 	if GetOutputFormat() == "json" {
 		output := map[string]interface{}{
 			"project": project,
 			"system":  system,
 		}
+		if projectShowNotes {
+			output["notes"] = notes
+		}
 		data, err := json.MarshalIndent(output, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %w", err)
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(out, string(data))
 		return nil
 	}
 
-	fmt.Printf("Project ID: %d\n", project.ID)
-	fmt.Printf("Name: %s\n", project.Name)
+	fmt.Fprintf(out, "Project ID: %d\n", project.ID)
+	fmt.Fprintf(out, "Name: %s\n", project.Name)
 
 	if project.Description != nil {
-		fmt.Printf("Description: %s\n", *project.Description)
+		fmt.Fprintf(out, "Description: %s\n", *project.Description)
 	}
 
-	fmt.Printf("System: %s (ID: %d)\n", system.Identifier, system.ID)
-	fmt.Printf("External ID: %s\n", project.ExternalID)
-	fmt.Printf("Status: %s\n", project.Status)
+	fmt.Fprintf(out, "System: %s (ID: %d)\n", system.Identifier, system.ID)
+	fmt.Fprintf(out, "External ID: %s\n", project.ExternalID)
+	fmt.Fprintf(out, "Status: %s\n", project.Status)
 	if project.Priority != nil {
-		fmt.Printf("Priority: %s\n", *project.Priority)
+		fmt.Fprintf(out, "Priority: %s\n", *project.Priority)
+	}
+	fmt.Fprintf(out, "Sync Strategy: %s\n", project.SyncStrategy)
+	fmt.Fprintf(out, "\nCreated: %s\n", project.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "Updated: %s\n", project.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+
+	if projectShowNotes {
+		fmt.Fprintln(out, "\nNotes:")
+		fmt.Fprintln(out, strings.Repeat("-", 60))
+		if notes == "" {
+			fmt.Fprintln(out, "(no notes; add them with 'todu project notes')")
+		} else {
+			fmt.Fprintln(out, notes)
+		}
 	}
-	fmt.Printf("Sync Strategy: %s\n", project.SyncStrategy)
-	fmt.Printf("\nCreated: %s\n", project.CreatedAt.Local().Format("2006-01-02 15:04:05"))
-	fmt.Printf("Updated: %s\n", project.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
 
 	return nil
 }
@@ -402,21 +474,20 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Validate priority if provided
-	if projectUpdatePriority != "" {
-		validPriorities := map[string]bool{"low": true, "medium": true, "high": true}
-		if !validPriorities[projectUpdatePriority] {
-			return fmt.Errorf("invalid priority %q: must be low, medium, or high", projectUpdatePriority)
-		}
-	}
-
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Validate priority if provided
+	if projectUpdatePriority != "" {
+		if err := types.ValidatePriority(projectUpdatePriority); err != nil {
+			return err
+		}
+	}
+
 	client := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Resolve project ID from name or ID
 	projectID, err := resolveProjectID(ctx, client, args[0])
@@ -464,7 +535,7 @@ func runProjectRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	client := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Resolve project ID from name or ID
 	projectID, err := resolveProjectID(ctx, client, args[0])
@@ -478,13 +549,23 @@ func runProjectRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Confirm deletion unless --force
-	if !projectRemoveForce {
-		fmt.Printf("Are you sure you want to remove project %d (%s)? [y/N]: ", projectID, project.Name)
-		var response string
-		_, _ = fmt.Scanln(&response)
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
+	// Confirm deletion unless --yes
+	if !projectRemoveYes && cfg.Confirmations {
+		tasks, err := client.ListTasks(ctx, &api.TaskListOptions{ProjectID: &projectID})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		affected := []string{fmt.Sprintf("Project %d: %s", projectID, project.Name)}
+		if projectRemoveCascade {
+			affected = append(affected, fmt.Sprintf("%d task(s) in the project are deleted too", len(tasks)))
+		} else if len(tasks) > 0 {
+			affected = append(affected, fmt.Sprintf("The project has %d task(s); use --cascade to delete them too", len(tasks)))
+		}
+		confirmed, err := confirmAction(cfg, false, fmt.Sprintf("Remove project %d?", projectID), affected...)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			fmt.Println("Cancelled")
 			return nil
 		}
@@ -509,13 +590,10 @@ func runProjectRemove(cmd *cobra.Command, args []string) error {
 			fmt.Fprintln(os.Stderr, "  2. Delete project and all associated tasks:")
 			fmt.Fprintf(os.Stderr, "     todu project remove %d --cascade\n", projectID)
 
-			// If not using --force, offer to cascade delete now
-			if !projectRemoveForce && !projectRemoveCascade {
-				fmt.Fprintf(os.Stderr, "\nWould you like to delete the project and all its tasks now? [y/N]: ")
-				var response string
-				_, _ = fmt.Scanln(&response)
-				response = strings.ToLower(strings.TrimSpace(response))
-				if response == "y" || response == "yes" {
+			// If not using --yes, offer to cascade delete now
+			if !projectRemoveYes && !projectRemoveCascade && cfg.Confirmations {
+				fmt.Fprintln(os.Stderr)
+				if cascade, _ := confirmAction(cfg, false, "Would you like to delete the project and all its tasks now?"); cascade {
 					// Retry with cascade
 					err = client.DeleteProject(ctx, projectID, true)
 					if err != nil {
@@ -554,7 +632,7 @@ func runProjectDiscover(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get system details
-	system, err := client.GetSystem(context.Background(), systemID)
+	system, err := client.GetSystem(commandContext(), systemID)
 	if err != nil {
 		return fmt.Errorf("failed to get system: %w", err)
 	}
@@ -566,29 +644,54 @@ func runProjectDiscover(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get plugin instance (creates and configures it)
-	plugin, err := registry.Create(system.Identifier, pluginConfig)
+	p, err := registry.Create(system.Identifier, pluginConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create plugin: %w", err)
 	}
 
 	// Validate configuration
-	if err := plugin.ValidateConfig(); err != nil {
+	if err := p.ValidateConfig(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: Plugin %s is not properly configured\n\n", system.Identifier)
 		fmt.Fprintf(os.Stderr, "Please configure the plugin:\n")
 		fmt.Fprintf(os.Stderr, "  todu system config %s\n", system.Identifier)
 		return err
 	}
 
+	var searcher plugin.Searcher
+	if projectDiscoverQuery != "" {
+		var ok bool
+		if searcher, ok = p.(plugin.Searcher); !ok {
+			return fmt.Errorf("plugin %s does not support search", system.Identifier)
+		}
+	}
+
 	fmt.Printf("Discovering projects from %s...\n\n", system.Name)
 
-	// Fetch projects from plugin
-	externalProjects, err := plugin.FetchProjects(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to fetch projects from plugin: %w", err)
+	// Fetch projects from plugin, using the cache unless refreshing
+	plugincache.SetRefresh(projectDiscoverRefresh)
+	cache := plugincache.ForPlugin(pluginConfig)
+	cacheKey := plugincache.Key(system.Identifier, pluginConfig["url"], "projects")
+	if searcher != nil {
+		cacheKey = plugincache.Key(system.Identifier, pluginConfig["url"], "projects", "search", projectDiscoverQuery)
+	}
+
+	var externalProjects []*types.Project
+	if !cache.Get(cacheKey, &externalProjects) {
+		if searcher != nil {
+			externalProjects, err = searcher.SearchProjects(commandContext(), projectDiscoverQuery)
+		} else {
+			externalProjects, err = p.FetchProjects(commandContext())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch projects from plugin: %w", err)
+		}
+		if err := cache.Put(cacheKey, externalProjects); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	}
 
 	// Get existing projects for this system
-	existingProjects, err := client.ListProjects(context.Background(), &api.ProjectListOptions{SystemID: &systemID})
+	existingProjects, err := client.ListProjects(commandContext(), &api.ProjectListOptions{SystemID: &systemID})
 	if err != nil {
 		return fmt.Errorf("failed to list existing projects: %w", err)
 	}
@@ -640,7 +743,7 @@ func runProjectDiscover(cmd *cobra.Command, args []string) error {
 				SyncStrategy: "bidirectional",
 			}
 
-			created, err := client.CreateProject(context.Background(), projectCreate)
+			created, err := client.CreateProject(commandContext(), projectCreate)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to import %s: %v\n", project.ExternalID, err)
 				continue
@@ -679,7 +782,7 @@ func statusValue(status string) int {
 	}
 }
 
-// sortProjectsByPriorityAndStatus sorts projects by priority (high > medium > low > nil),
+// sortProjectsByPriorityAndStatus sorts projects by priority (highest level first, nil last),
 // then by status (active > done > cancelled), then by ID for consistent ordering
 func sortProjectsByPriorityAndStatus(projects []*types.Project) {
 	sort.Slice(projects, func(i, j int) bool {
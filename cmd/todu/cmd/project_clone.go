@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var projectCloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Copy a project's structure into a new local project",
+	Long: `Create a new local project with the same description, priority, and
+sync strategy as an existing one, for repeating structures like sprint
+boards or quarterly plans.
+
+The new project also gets:
+  - a copy of each recurring template, starting today unless --start-date
+    is given so they don't catch up on occurrences from before the clone
+  - the areas the project belongs to
+  - a copy of the project's notes, if local_reports is configured
+
+Tasks are not copied unless --with-tasks is given, which copies the open
+tasks (not done or canceled) with their status, priority, dates, labels,
+and assignees.
+
+Examples:
+  todu project clone "Sprint 12" --name "Sprint 13"
+  todu project clone 4 --name "Q3 plan" --with-tasks`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectClone,
+}
+
+var (
+	// Clone flags
+	projectCloneName      string
+	projectCloneWithTasks bool
+	projectCloneStartDate string
+)
+
+func init() {
+	projectCmd.AddCommand(projectCloneCmd)
+	projectCloneCmd.Flags().StringVar(&projectCloneName, "name", "", "Name of the new project (required)")
+	projectCloneCmd.Flags().BoolVar(&projectCloneWithTasks, "with-tasks", false, "Also copy the project's open tasks")
+	projectCloneCmd.Flags().StringVar(&projectCloneStartDate, "start-date", "", "Start date (YYYY-MM-DD) for the copied templates (default: today)")
+	_ = projectCloneCmd.MarkFlagRequired("name")
+}
+
+func runProjectClone(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	name := strings.TrimSpace(projectCloneName)
+	if name == "" {
+		return fmt.Errorf("--name must not be empty")
+	}
+
+	startDate := projectCloneStartDate
+	if startDate == "" {
+		startDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", startDate); err != nil {
+		return fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	sourceID, err := resolveProjectID(ctx, apiClient, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	source, err := apiClient.GetProject(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	projects, err := apiClient.ListProjects(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return fmt.Errorf("project %q already exists (ID: %d)", p.Name, p.ID)
+		}
+	}
+
+	templates, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{ProjectID: &sourceID, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	var tasks []*types.Task
+	if projectCloneWithTasks {
+		tasks, err = apiClient.ListAllTasks(ctx, &api.TaskListOptions{ProjectID: &sourceID})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		tasks = slices.DeleteFunc(tasks, func(t *types.Task) bool {
+			return t.Status == "done" || t.Status == "canceled"
+		})
+	}
+
+	systemID, err := ensureLocalSystem(apiClient)
+	if err != nil {
+		return err
+	}
+
+	project, err := apiClient.CreateProject(ctx, cloneProjectCreate(source, name, systemID))
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+	fmt.Printf("Created project %d: %s (cloned from %s)\n", project.ID, project.Name, source.Name)
+
+	// Tasks generated by a copied template point at the copy
+	templateIDs := make(map[int]int)
+	for _, tmpl := range templates {
+		created, err := apiClient.CreateTemplate(ctx, cloneTemplateCreate(tmpl, project.ID, startDate))
+		if err != nil {
+			return fmt.Errorf("failed to copy template #%d: %w", tmpl.ID, err)
+		}
+		templateIDs[tmpl.ID] = created.ID
+	}
+	fmt.Printf("  Templates: %d copied\n", len(templates))
+
+	if projectCloneWithTasks {
+		for _, task := range tasks {
+			if _, err := apiClient.CreateTask(ctx, cloneTaskCreate(task, project.ID, templateIDs)); err != nil {
+				return fmt.Errorf("failed to copy task #%d: %w", task.ID, err)
+			}
+		}
+		fmt.Printf("  Tasks: %d copied\n", len(tasks))
+	}
+
+	if areas, added := cloneProjectAreas(cfg.Areas, source.Name, project.Name); len(added) > 0 {
+		if err := config.SetAreas(GetConfigFile(), areas); err != nil {
+			return fmt.Errorf("failed to save areas: %w", err)
+		}
+		fmt.Printf("  Areas: %s\n", strings.Join(added, ", "))
+	}
+
+	if cfg.LocalReports != "" {
+		notes, err := review.LoadProjectNotes(cfg.LocalReports, source.ID)
+		if err != nil {
+			return err
+		}
+		if notes != "" {
+			path := review.ProjectNotesPath(cfg.LocalReports, project.ID)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return fmt.Errorf("failed to create notes directory: %w", err)
+			}
+			if err := appendProjectNotes(path, notes); err != nil {
+				return err
+			}
+			fmt.Println("  Notes: copied")
+		}
+	}
+
+	return nil
+}
+
+// cloneProjectCreate returns the request to create a local copy of a
+// project under a new name.
+func cloneProjectCreate(source *types.Project, name string, systemID int) *types.ProjectCreate {
+	return &types.ProjectCreate{
+		Name:         name,
+		Description:  source.Description,
+		SystemID:     systemID,
+		ExternalID:   uuid.New().String(),
+		Status:       "active",
+		Priority:     source.Priority,
+		SyncStrategy: source.SyncStrategy,
+	}
+}
+
+// cloneTemplateCreate returns the request to copy a template into a
+// project, starting on startDate (YYYY-MM-DD).
+func cloneTemplateCreate(tmpl *types.RecurringTaskTemplate, projectID int, startDate string) *types.RecurringTaskTemplateCreate {
+	create := &types.RecurringTaskTemplateCreate{
+		ProjectID:      projectID,
+		Title:          tmpl.Title,
+		Description:    tmpl.Description,
+		Priority:       tmpl.Priority,
+		RecurrenceRule: tmpl.RecurrenceRule,
+		StartDate:      startDate,
+		Timezone:       tmpl.Timezone,
+		TemplateType:   tmpl.TemplateType,
+		IsActive:       tmpl.IsActive,
+	}
+	if tmpl.EndDate != nil {
+		endDate := tmpl.EndDate.UTC().Format("2006-01-02")
+		create.EndDate = &endDate
+	}
+	for _, label := range tmpl.Labels {
+		create.Labels = append(create.Labels, label.Name)
+	}
+	for _, assignee := range tmpl.Assignees {
+		create.Assignees = append(create.Assignees, assignee.Name)
+	}
+	return create
+}
+
+// cloneTaskCreate returns the request to copy a task into a project.
+// templateIDs maps the source project's template IDs to their copies.
+func cloneTaskCreate(task *types.Task, projectID int, templateIDs map[int]int) *types.TaskCreate {
+	create := &types.TaskCreate{
+		Title:         task.Title,
+		Description:   task.Description,
+		ProjectID:     projectID,
+		Status:        task.Status,
+		Priority:      task.Priority,
+		DueDate:       task.DueDate,
+		ScheduledDate: task.ScheduledDate,
+	}
+	if task.TemplateID != nil {
+		if id, ok := templateIDs[*task.TemplateID]; ok {
+			create.TemplateID = &id
+		}
+	}
+	for _, label := range task.Labels {
+		create.Labels = append(create.Labels, label.Name)
+	}
+	for _, assignee := range task.Assignees {
+		create.Assignees = append(create.Assignees, assignee.Name)
+	}
+	return create
+}
+
+// cloneProjectAreas returns a copy of areas with project added to every
+// area source is in, and the names of those areas in sorted order.
+func cloneProjectAreas(areas map[string][]string, source, project string) (map[string][]string, []string) {
+	result := cloneAreas(areas)
+	var added []string
+	for name, projects := range result {
+		if slices.ContainsFunc(projects, func(p string) bool { return strings.EqualFold(p, source) }) {
+			result[name] = append(projects, project)
+			added = append(added, name)
+		}
+	}
+	slices.Sort(added)
+	return result, added
+}
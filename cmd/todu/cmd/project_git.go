@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/repoconfig"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// runProjectAddFromGit registers the project for the git repository in the
+// current directory and writes .todu.yaml at its root.
+func runProjectAddFromGit(cmd *cobra.Command, client *api.Client) error {
+	ctx := commandContext()
+
+	cwd, err := workingDir()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	root, err := repoconfig.GitRoot(cwd)
+	if err != nil {
+		return err
+	}
+	remoteURL, err := repoconfig.RemoteURL(root, projectAddRemote)
+	if err != nil {
+		return err
+	}
+	remote, err := repoconfig.ParseRemote(remoteURL)
+	if err != nil {
+		return err
+	}
+
+	systems, err := client.ListSystems(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list systems: %w", err)
+	}
+
+	var system *types.System
+	if cmd.Flags().Changed("system") {
+		systemID, err := resolveSystemID(client, projectAddSystem)
+		if err != nil {
+			return err
+		}
+		for _, s := range systems {
+			if s.ID == systemID {
+				system = s
+			}
+		}
+		if system == nil {
+			return fmt.Errorf("system %q not found", projectAddSystem)
+		}
+	} else {
+		system, err = systemForRemote(systems, remote.Host, pluginURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	externalID := remote.ExternalID()
+	if projectAddExternalID != "" {
+		externalID = projectAddExternalID
+	}
+
+	// Reuse the project if this repository is already registered
+	project, err := findProjectByExternalID(ctx, client, system.ID, externalID)
+	if err != nil {
+		return err
+	}
+	if project != nil {
+		fmt.Printf("Project %d (%s) is already registered for %s on %s\n", project.ID, project.Name, externalID, system.Identifier)
+	} else {
+		name := projectAddName
+		if name == "" {
+			name = remote.Repo
+		}
+		var descPtr, priorityPtr *string
+		if projectAddDescription != "" {
+			descPtr = &projectAddDescription
+		}
+		if projectAddPriority != "" {
+			priorityPtr = &projectAddPriority
+		}
+
+		project, err = client.CreateProject(ctx, &types.ProjectCreate{
+			Name:         name,
+			Description:  descPtr,
+			SystemID:     system.ID,
+			ExternalID:   externalID,
+			Status:       projectAddStatus,
+			Priority:     priorityPtr,
+			SyncStrategy: projectAddSyncStrategy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+		printCreatedProject(project)
+	}
+
+	if projectAddNoRepoFile {
+		return nil
+	}
+
+	path := filepath.Join(root, repoconfig.FileName)
+	repoFile := &repoconfig.File{
+		Project:    project.Name,
+		System:     system.Identifier,
+		ExternalID: project.ExternalID,
+	}
+	if err := repoconfig.Save(path, repoFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s not written: %v\n", repoconfig.FileName, err)
+		return nil
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
+
+// pluginURL returns the url setting configured for a plugin, if any
+func pluginURL(identifier string) string {
+	cfg, err := registry.LoadPluginConfig(identifier)
+	if err != nil {
+		return ""
+	}
+	return cfg["url"]
+}
+
+// systemForRemote picks the system that hosts a git remote. github.com
+// maps to the github system; other hosts map to a system whose URL (or
+// plugin url setting) has the same host, falling back to forgejo.
+func systemForRemote(systems []*types.System, host string, configuredURL func(string) string) (*types.System, error) {
+	byIdentifier := make(map[string]*types.System, len(systems))
+	for _, s := range systems {
+		byIdentifier[s.Identifier] = s
+	}
+
+	if host == "github.com" {
+		if s, ok := byIdentifier["github"]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("no github system registered; run 'todu system add --identifier github' first")
+	}
+
+	for _, s := range systems {
+		systemURL := ""
+		if s.URL != nil {
+			systemURL = *s.URL
+		}
+		if systemURL == "" {
+			systemURL = configuredURL(s.Identifier)
+		}
+		if urlHost(systemURL) == host {
+			return s, nil
+		}
+	}
+
+	if s, ok := byIdentifier["forgejo"]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("no system registered for %s; use --system to choose one", host)
+}
+
+// urlHost returns the lowercased host of a URL, or "" if it has none
+func urlHost(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// findProjectByExternalID returns the project with externalID on a system,
+// or nil if there is none
+func findProjectByExternalID(ctx context.Context, client *api.Client, systemID int, externalID string) (*types.Project, error) {
+	projects, err := client.ListProjects(ctx, &api.ProjectListOptions{SystemID: &systemID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		if p.SystemID == systemID && strings.EqualFold(p.ExternalID, externalID) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var projectNotesCmd = &cobra.Command{
+	Use:   "notes <id>",
+	Short: "Edit a project's notes",
+	Long: `Open a project's notes in your editor.
+
+Notes are a markdown document kept with the project for goals, decisions,
+links, and anything else that isn't a task. They are stored in
+{local_reports}/projects/, shown by 'todu project show --notes', and
+included in the weekly review while the project is open.
+
+Use --append to add a line without opening the editor, and --print to
+show the notes.
+
+Examples:
+  todu project notes website
+  todu project notes 3 --append "Decided to drop IE support"
+  todu project notes 3 --print`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectNotes,
+}
+
+var (
+	// Notes flags
+	projectNotesAppend string
+	projectNotesPrint  bool
+
+	// Show flags
+	projectShowNotes bool
+)
+
+func init() {
+	projectCmd.AddCommand(projectNotesCmd)
+	projectNotesCmd.Flags().StringVar(&projectNotesAppend, "append", "", "Append text to the notes instead of opening the editor")
+	projectNotesCmd.Flags().BoolVar(&projectNotesPrint, "print", false, "Print the notes instead of opening the editor")
+
+	projectShowCmd.Flags().BoolVar(&projectShowNotes, "notes", false, "Also show the project's notes")
+}
+
+func runProjectNotes(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+	if err := requireProjectNotes(cfg); err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	projectID, err := resolveProjectID(ctx, apiClient, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	if projectNotesPrint {
+		notes, err := review.LoadProjectNotes(cfg.LocalReports, projectID)
+		if err != nil {
+			return err
+		}
+		if notes == "" {
+			fmt.Println("No notes")
+			return nil
+		}
+		fmt.Println(notes)
+		return nil
+	}
+
+	path := review.ProjectNotesPath(cfg.LocalReports, projectID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+
+	if projectNotesAppend != "" {
+		if err := appendProjectNotes(path, projectNotesAppend); err != nil {
+			return err
+		}
+		fmt.Printf("Added to notes of project #%d\n", projectID)
+		return nil
+	}
+
+	return openFileInEditor(path)
+}
+
+// requireProjectNotes returns an error if project notes can't be stored
+// because local_reports isn't configured.
+func requireProjectNotes(cfg *config.Config) error {
+	if cfg.LocalReports == "" {
+		return fmt.Errorf("local_reports not configured; project notes are stored there")
+	}
+	return nil
+}
+
+// appendProjectNotes appends text as a new paragraph of the notes at path,
+// creating the file if needed.
+func appendProjectNotes(path, text string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read project notes: %w", err)
+	}
+
+	content := strings.TrimRight(string(existing), "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	content += strings.TrimSpace(text) + "\n"
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write project notes: %w", err)
+	}
+	return nil
+}
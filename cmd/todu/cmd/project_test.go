@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/evcraddock/todu.sh/pkg/types"
@@ -218,3 +220,127 @@ func TestSortProjectsByPriorityAndStatus_EmptySlice(t *testing.T) {
 		t.Errorf("expected empty slice, got %d projects", len(projects))
 	}
 }
+
+func TestSystemForRemote(t *testing.T) {
+	forgejoURL := "https://code.example.com"
+	systems := []*types.System{
+		{ID: 1, Identifier: "github"},
+		{ID: 2, Identifier: "forgejo"},
+		{ID: 3, Identifier: "gitea", URL: &forgejoURL},
+	}
+	noURL := func(string) string { return "" }
+
+	tests := []struct {
+		name   string
+		host   string
+		config func(string) string
+		want   int
+	}{
+		{"github", "github.com", noURL, 1},
+		{"system URL host", "code.example.com", noURL, 3},
+		{"plugin url setting", "git.other.org", func(id string) string {
+			if id == "forgejo" {
+				return "https://git.other.org/"
+			}
+			return ""
+		}, 2},
+		{"forgejo fallback", "unknown.example.org", noURL, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := systemForRemote(systems, tt.host, tt.config)
+			if err != nil {
+				t.Fatalf("systemForRemote() error = %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("systemForRemote() = %d, want %d", got.ID, tt.want)
+			}
+		})
+	}
+
+	if _, err := systemForRemote([]*types.System{{ID: 2, Identifier: "forgejo"}}, "github.com", noURL); err == nil {
+		t.Error("expected error with no github system")
+	}
+	if _, err := systemForRemote([]*types.System{{ID: 1, Identifier: "github"}}, "code.example.com", noURL); err == nil {
+		t.Error("expected error with no matching system")
+	}
+}
+
+func TestAppendProjectNotes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+
+	if err := appendProjectNotes(path, "First decision"); err != nil {
+		t.Fatalf("appendProjectNotes failed: %v", err)
+	}
+	if err := appendProjectNotes(path, "  Second decision\n"); err != nil {
+		t.Fatalf("appendProjectNotes failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "First decision\n\nSecond decision\n"; string(data) != want {
+		t.Errorf("notes = %q, want %q", data, want)
+	}
+}
+
+func TestCloneTemplateCreate(t *testing.T) {
+	priority := "high"
+	tmpl := &types.RecurringTaskTemplate{
+		ID:             3,
+		ProjectID:      1,
+		Title:          "Sprint planning",
+		Priority:       &priority,
+		RecurrenceRule: "FREQ=WEEKLY;INTERVAL=2",
+		Timezone:       "UTC",
+		TemplateType:   "task",
+		IsActive:       true,
+		Labels:         []types.Label{{Name: "meeting"}},
+		Assignees:      []types.Assignee{{Name: "jane"}},
+	}
+
+	create := cloneTemplateCreate(tmpl, 9, "2025-07-01")
+	if create.ProjectID != 9 || create.StartDate != "2025-07-01" || create.Title != tmpl.Title ||
+		*create.Priority != "high" || create.RecurrenceRule != tmpl.RecurrenceRule || !create.IsActive {
+		t.Errorf("Unexpected template create: %+v", create)
+	}
+	if len(create.Labels) != 1 || create.Labels[0] != "meeting" || len(create.Assignees) != 1 || create.Assignees[0] != "jane" {
+		t.Errorf("Expected labels and assignees copied, got %v and %v", create.Labels, create.Assignees)
+	}
+}
+
+func TestCloneTaskCreate(t *testing.T) {
+	generated, other := 3, 4
+	task := &types.Task{ID: 10, Title: "Retro", Status: "inprogress", TemplateID: &generated}
+
+	create := cloneTaskCreate(task, 9, map[int]int{3: 30})
+	if create.ProjectID != 9 || create.Status != "inprogress" || create.TemplateID == nil || *create.TemplateID != 30 {
+		t.Errorf("Unexpected task create: %+v", create)
+	}
+
+	task.TemplateID = &other
+	if create := cloneTaskCreate(task, 9, map[int]int{3: 30}); create.TemplateID != nil {
+		t.Errorf("Expected no template for an uncopied template, got %d", *create.TemplateID)
+	}
+}
+
+func TestCloneProjectAreas(t *testing.T) {
+	areas := map[string][]string{
+		"work":  {"Sprint 12", "Hiring"},
+		"team":  {"sprint 12"},
+		"home":  {"Garden"},
+		"empty": {},
+	}
+
+	result, added := cloneProjectAreas(areas, "Sprint 12", "Sprint 13")
+	if len(added) != 2 || added[0] != "team" || added[1] != "work" {
+		t.Errorf("Expected areas team and work, got %v", added)
+	}
+	if len(result["work"]) != 3 || result["work"][2] != "Sprint 13" || len(result["home"]) != 1 {
+		t.Errorf("Unexpected areas: %v", result)
+	}
+	if len(areas["work"]) != 2 {
+		t.Errorf("Expected the original areas unchanged, got %v", areas)
+	}
+}
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Interactive session for running many commands",
+	Long: `Start an interactive session that runs todu commands without the "todu"
+prefix, in one process, so each command skips startup and reuses the API
+connection.
+
+Use the up and down arrows for earlier commands. "use <project>" sets a
+current project, shown in the prompt, that is passed as --project to
+commands that take it, unless the command names a project, system, area,
+or view itself. "use" alone clears it.
+
+` + replHelp + `
+
+Example session:
+  todu> use inbox
+  todu:inbox> ls --status active
+  todu:inbox> add "Call the dentist"
+  todu:inbox> done 12 14
+  todu:inbox> c "Cleared the inbox before lunch"`,
+	Args: cobra.NoArgs,
+	RunE: runREPL,
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+}
+
+// replHelp lists what can be typed in a session besides todu commands
+const replHelp = `Abbreviations:
+  ls [flags]           task list [flags]
+  add <title>          task create --title <title>
+  done <id>...         task close <id>, for each ID
+  e <id>               task edit <id>
+  c <text>             journal add <text>
+  c <id> <text>        task comment <id> <text>
+
+Session commands:
+  use [project]        set or clear the current project
+  help                 show this list
+  exit, quit, Ctrl-D   leave the session`
+
+// replAbbreviations expand a session's first word into a command
+var replAbbreviations = map[string][]string{
+	"ls":   {"task", "list"},
+	"add":  {"task", "create", "--title"},
+	"done": {"task", "close"},
+	"e":    {"task", "edit"},
+}
+
+// replSession is the state kept between commands in a session
+type replSession struct {
+	project string // current project name, or "" for none
+
+	// globals are the global flags the session was started with, such as
+	// --config, which apply to every command in it
+	globals map[string]string
+}
+
+func runREPL(cmd *cobra.Command, args []string) error {
+	session := &replSession{globals: map[string]string{}}
+	rootCmd.PersistentFlags().Visit(func(flag *pflag.Flag) {
+		session.globals[flag.Name] = flag.Value.String()
+	})
+
+	interactive := term.IsTerminal(int(os.Stdin.Fd())) && !isNonInteractive()
+	var terminal *term.Terminal
+	var lines *bufio.Scanner
+	if interactive {
+		terminal = term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, os.Stdout}, "")
+		fmt.Println(`todu interactive session. Type "help" for abbreviations, "exit" to leave.`)
+	} else {
+		// Commands piped in run one per line, without prompts
+		lines = bufio.NewScanner(os.Stdin)
+	}
+
+	for {
+		var line string
+		var err error
+		if interactive {
+			line, err = readREPLLine(terminal, session.prompt())
+		} else if lines.Scan() {
+			line = lines.Text()
+		} else {
+			err = lines.Err()
+			if err == nil {
+				err = io.EOF
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if interactive {
+				fmt.Println()
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read command: %w", err)
+		}
+
+		quit, err := session.run(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// readREPLLine reads a line with editing and history, putting the terminal
+// in raw mode only while reading so commands can prompt and open editors
+func readREPLLine(terminal *term.Terminal, prompt string) (string, error) {
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = term.Restore(int(os.Stdin.Fd()), state) }()
+
+	if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		_ = terminal.SetSize(width, height)
+	}
+	terminal.SetPrompt(prompt)
+	line, err := terminal.ReadLine()
+	if errors.Is(err, term.ErrPasteIndicator) {
+		err = nil
+	}
+	return line, err
+}
+
+// prompt returns the session prompt, naming the current project
+func (s *replSession) prompt() string {
+	if s.project != "" {
+		return "todu:" + s.project + "> "
+	}
+	return "todu> "
+}
+
+// run runs a line typed in the session. Returns true if it ends the
+// session. Command errors have already been printed by the command.
+func (s *replSession) run(line string) (bool, error) {
+	args, err := splitREPLLine(line)
+	if err != nil {
+		return false, err
+	}
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return true, nil
+	case "help", "?":
+		fmt.Println(replHelp)
+		return false, nil
+	case "use":
+		return false, withInterrupt(func(ctx context.Context) error {
+			return s.use(ctx, args[1:])
+		})
+	case "repl":
+		return false, fmt.Errorf("already in a session")
+	}
+
+	commands, err := expandREPLCommand(args)
+	if err != nil {
+		return false, err
+	}
+	for _, command := range commands {
+		if s.project != "" {
+			command = withREPLProject(rootCmd, command, s.project)
+		}
+		// Errors are printed by cobra; a failed command doesn't end the session
+		_ = executeREPLCommand(rootCmd, command, s.globals)
+	}
+	return false, nil
+}
+
+// use sets or clears the session's current project
+func (s *replSession) use(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		s.project = ""
+		return nil
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("usage: use [project]")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+
+	projectID, err := resolveProjectID(ctx, apiClient, args[0])
+	if err != nil {
+		return err
+	}
+	project, err := apiClient.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	s.project = project.Name
+	return nil
+}
+
+// expandREPLCommand expands an abbreviation into the commands to run. Lines
+// that aren't abbreviations are run as they are.
+func expandREPLCommand(args []string) ([][]string, error) {
+	name, rest := args[0], args[1:]
+
+	switch name {
+	case "add":
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("usage: add <title> [flags]")
+		}
+		// Unquoted words before the first flag make up the title
+		title := rest
+		if i := slices.IndexFunc(rest, func(arg string) bool { return strings.HasPrefix(arg, "-") }); i >= 0 {
+			title, rest = rest[:i], rest[i:]
+		} else {
+			rest = nil
+		}
+		if len(title) == 0 {
+			return nil, fmt.Errorf("usage: add <title> [flags]")
+		}
+		return [][]string{append(slices.Clone(replAbbreviations[name]), append([]string{strings.Join(title, " ")}, rest...)...)}, nil
+	case "done":
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("usage: done <id>...")
+		}
+		var commands [][]string
+		for _, id := range rest {
+			commands = append(commands, append(slices.Clone(replAbbreviations[name]), strings.TrimPrefix(id, "#")))
+		}
+		return commands, nil
+	case "c":
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("usage: c [task-id] <text>")
+		}
+		if len(rest) > 1 && isTaskID(rest[0]) {
+			return [][]string{{"task", "comment", strings.TrimPrefix(rest[0], "#"), strings.Join(rest[1:], " ")}}, nil
+		}
+		return [][]string{{"journal", "add", strings.Join(rest, " ")}}, nil
+	}
+
+	if expansion, ok := replAbbreviations[name]; ok {
+		return [][]string{append(slices.Clone(expansion), rest...)}, nil
+	}
+	return [][]string{args}, nil
+}
+
+// isTaskID reports whether s is a task ID, with or without a leading #
+func isTaskID(s string) bool {
+	s = strings.TrimPrefix(s, "#")
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// withREPLProject adds --project for the session's current project to a
+// command that takes an optional --project flag and doesn't already scope
+// itself with one of the flags below
+func withREPLProject(root *cobra.Command, args []string, project string) []string {
+	target, _, err := root.Find(args)
+	if err != nil || target == root {
+		return args
+	}
+	flag := target.Flags().Lookup("project")
+	if flag == nil {
+		return args
+	}
+	// A required --project names a target, like task move's destination
+	if _, required := flag.Annotations[cobra.BashCompOneRequiredFlag]; required {
+		return args
+	}
+
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		for _, scope := range []string{"--project", "-p", "--system", "--area", "--view", "--all"} {
+			if arg == scope || strings.HasPrefix(arg, scope+"=") || (scope == "-p" && strings.HasPrefix(arg, "-p")) {
+				return args
+			}
+		}
+	}
+	return append(slices.Clone(args), "--project", project)
+}
+
+// executeREPLCommand runs a command line through root, first resetting
+// every flag to its default, or to globals for root's persistent flags, so
+// nothing carries over from the last command
+func executeREPLCommand(root *cobra.Command, args []string, globals map[string]string) error {
+	// Ctrl-C stops the command, not the session
+	return withInterrupt(func(ctx context.Context) error {
+		return executeCommand(ctx, root, args, globals)
+	})
+}
+
+// executeCommand runs a command line through root with ctx, like
+// executeREPLCommand but without handling Ctrl-C
+func executeCommand(ctx context.Context, root *cobra.Command, args []string, globals map[string]string) error {
+	resetFlags(root)
+	for name, value := range globals {
+		_ = root.PersistentFlags().Set(name, value)
+	}
+	taskListRepoContext = nil
+
+	previous := root.Context()
+	defer root.SetContext(previous)
+	root.SetArgs(args)
+	return root.ExecuteContext(ctx)
+}
+
+// resetFlags sets every flag of cmd and its subcommands back to its default
+// value and marks it unset
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(flag.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			_ = slice.Replace(values)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// splitREPLLine splits a line into words like a shell: words are separated
+// by spaces, quotes group words, and a backslash escapes the next character
+func splitREPLLine(line string) ([]string, error) {
+	var args []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("line ends with a backslash")
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
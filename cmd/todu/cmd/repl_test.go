@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSplitREPLLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "", want: nil},
+		{line: "  ls   --status active ", want: []string{"ls", "--status", "active"}},
+		{line: `add "Call the dentist" --priority high`, want: []string{"add", "Call the dentist", "--priority", "high"}},
+		{line: `c it\'s "a \"b\"" ''`, want: []string{"c", "it's", `a "b"`, ""}},
+		{line: `c 'it\'s'`, wantErr: true}, // no escapes in single quotes
+		{line: `add "unterminated`, wantErr: true},
+		{line: `ls \`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := splitREPLLine(tt.line)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("splitREPLLine(%q): expected error, got %q", tt.line, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitREPLLine(%q) = %q, %v, want %q", tt.line, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandREPLCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want [][]string
+	}{
+		{args: []string{"ls", "--status", "active"}, want: [][]string{{"task", "list", "--status", "active"}}},
+		{args: []string{"add", "Call", "the", "dentist", "--priority", "high"}, want: [][]string{{"task", "create", "--title", "Call the dentist", "--priority", "high"}}},
+		{args: []string{"done", "12", "#14"}, want: [][]string{{"task", "close", "12"}, {"task", "close", "14"}}},
+		{args: []string{"c", "Cleared the inbox"}, want: [][]string{{"journal", "add", "Cleared the inbox"}}},
+		{args: []string{"c", "#12", "looks", "good"}, want: [][]string{{"task", "comment", "12", "looks good"}}},
+		{args: []string{"c", "12"}, want: [][]string{{"journal", "add", "12"}}},
+		{args: []string{"task", "show", "12"}, want: [][]string{{"task", "show", "12"}}},
+	}
+	for _, tt := range tests {
+		got, err := expandREPLCommand(tt.args)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandREPLCommand(%q) = %q, %v, want %q", tt.args, got, err, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{{"add"}, {"add", "--priority", "high"}, {"done"}, {"c"}} {
+		if _, err := expandREPLCommand(args); err == nil {
+			t.Errorf("expandREPLCommand(%q): expected error", args)
+		}
+	}
+}
+
+func TestWithREPLProject(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"task", "list"}, want: []string{"task", "list", "--project", "inbox"}},
+		{args: []string{"task", "list", "-p", "work"}, want: []string{"task", "list", "-p", "work"}},
+		{args: []string{"task", "list", "--project=work"}, want: []string{"task", "list", "--project=work"}},
+		{args: []string{"sync", "--system", "github"}, want: []string{"sync", "--system", "github"}},
+		{args: []string{"task", "show", "12"}, want: []string{"task", "show", "12"}},
+		{args: []string{"task", "move", "12", "--project", "work"}, want: []string{"task", "move", "12", "--project", "work"}},
+		{args: []string{"task", "move", "12"}, want: []string{"task", "move", "12"}},
+		{args: []string{"bogus"}, want: []string{"bogus"}},
+	}
+	for _, tt := range tests {
+		if got := withREPLProject(rootCmd, tt.args, "inbox"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("withREPLProject(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestResetFlags(t *testing.T) {
+	var name string
+	var labels []string
+	var all bool
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{Use: "sub", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(sub)
+	root.PersistentFlags().StringVar(&name, "name", "default", "")
+	sub.Flags().StringSliceVar(&labels, "label", []string{"a"}, "")
+	sub.Flags().BoolVar(&all, "all", false, "")
+
+	root.SetArgs([]string{"sub", "--name", "x", "--label", "b,c", "--all"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if name != "x" || !reflect.DeepEqual(labels, []string{"b", "c"}) || !all {
+		t.Fatalf("Flags not parsed: %q %q %t", name, labels, all)
+	}
+
+	resetFlags(root)
+	if name != "default" || !reflect.DeepEqual(labels, []string{"a"}) || all {
+		t.Errorf("Expected defaults after reset, got %q %q %t", name, labels, all)
+	}
+	if sub.Flags().Changed("all") {
+		t.Error("Expected flags to be marked unset")
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/plugincache"
+	"github.com/evcraddock/todu.sh/internal/replay"
+)
+
+var (
+	recordFile string
+	replayFile string
+)
+
+// replayStarted is set once --record or --replay is set up, so commands run
+// in a REPL session share one recording
+var replayStarted bool
+
+// replayRecorder records the command's traffic for --record
+var replayRecorder *replay.Recorder
+
+// startReplay sets up --record or --replay. Every request to the API and to
+// plugins' servers goes through the recorder or player, and the plugin
+// cache is turned off so that none are answered from disk instead.
+func startReplay() error {
+	if replayStarted {
+		return nil
+	}
+	replayStarted = true
+
+	switch {
+	case recordFile != "" && replayFile != "":
+		return fmt.Errorf("--record and --replay can't be used together")
+	case recordFile != "":
+		replayRecorder = replay.NewRecorder()
+		api.SetTransport(replayRecorder.Wrap(api.Transport()))
+		http.DefaultTransport = replayRecorder.Wrap(http.DefaultTransport)
+	case replayFile != "":
+		session, err := replay.Load(replayFile)
+		if err != nil {
+			return err
+		}
+		player := replay.NewPlayer(session)
+		api.SetTransport(player)
+		http.DefaultTransport = player
+	default:
+		return nil
+	}
+
+	plugincache.SetDisabled(true)
+	return nil
+}
+
+// saveRecording writes the traffic recorded for --record, whether or not
+// the command succeeded, since failures are what bug reports are about
+func saveRecording() {
+	if replayRecorder == nil {
+		return
+	}
+	session := replayRecorder.Session()
+	if err := session.Save(recordFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save recording: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Recorded %d request(s) to %s\n", len(session.Interactions), recordFile)
+}
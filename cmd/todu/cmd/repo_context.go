@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/repoconfig"
+)
+
+// repoContext returns the .todu.yaml for the git repository containing the
+// current directory, or nil if there is none.
+func repoContext() (*repoconfig.File, error) {
+	cwd, err := workingDir()
+	if err != nil {
+		return nil, nil
+	}
+	path, err := repoconfig.Find(cwd)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return repoconfig.Load(path)
+}
+
+// resolveRepoProject returns the ID of the project a .todu.yaml pins,
+// matching on system and external ID when given, else on project name.
+func resolveRepoProject(ctx context.Context, apiClient *api.Client, file *repoconfig.File) (int, error) {
+	if file.System != "" && file.ExternalID != "" {
+		systemID, err := resolveSystemID(apiClient, file.System)
+		if err != nil {
+			return 0, fmt.Errorf("failed to resolve system from %s: %w", repoconfig.FileName, err)
+		}
+		project, err := findProjectByExternalID(ctx, apiClient, systemID, file.ExternalID)
+		if err != nil {
+			return 0, err
+		}
+		if project != nil {
+			return project.ID, nil
+		}
+		if file.Project == "" {
+			return 0, fmt.Errorf("project %s on %s from %s is not registered; run 'todu project add --from-git'", file.ExternalID, file.System, repoconfig.FileName)
+		}
+	}
+
+	if file.Project == "" {
+		return 0, fmt.Errorf("%s does not name a project", repoconfig.FileName)
+	}
+	projectID, err := resolveProjectID(ctx, apiClient, file.Project)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve project from %s: %w", repoconfig.FileName, err)
+	}
+	return projectID, nil
+}
+
+// applyRepoFilters fills in task list filters that weren't given on the
+// command line from the repository's defaults
+func applyRepoFilters(filters repoconfig.Filters) {
+	if taskListStatus == "" {
+		taskListStatus = filters.Status
+	}
+	if taskListPriority == "" {
+		taskListPriority = filters.Priority
+	}
+	if len(taskListLabels) == 0 {
+		taskListLabels = filters.Labels
+	}
+	if taskListAssignee == "" {
+		taskListAssignee = filters.Assignee
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/repoconfig"
+)
+
+func TestRepoContext(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "internal")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(sub)
+	file, err := repoContext()
+	if err != nil || file != nil {
+		t.Fatalf("repoContext() = %+v, %v; want none", file, err)
+	}
+
+	content := "project: todu.sh\nsystem: github\nexternal_id: evcraddock/todu.sh\n"
+	if err := os.WriteFile(filepath.Join(repo, repoconfig.FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err = repoContext()
+	if err != nil {
+		t.Fatalf("repoContext() error = %v", err)
+	}
+	if file == nil || file.Project != "todu.sh" || file.ExternalID != "evcraddock/todu.sh" {
+		t.Errorf("repoContext() = %+v", file)
+	}
+}
+
+func TestApplyRepoFilters(t *testing.T) {
+	defer func() {
+		taskListStatus, taskListPriority, taskListLabels, taskListAssignee = "", "", nil, ""
+	}()
+
+	// Flags given on the command line win
+	taskListStatus = "done"
+	taskListPriority = ""
+	taskListLabels = nil
+	taskListAssignee = ""
+
+	applyRepoFilters(repoconfig.Filters{
+		Status:   "active",
+		Priority: "high",
+		Labels:   []string{"bug"},
+		Assignee: "alice",
+	})
+
+	if taskListStatus != "done" {
+		t.Errorf("status = %q, want flag value done", taskListStatus)
+	}
+	if taskListPriority != "high" || taskListAssignee != "alice" || !reflect.DeepEqual(taskListLabels, []string{"bug"}) {
+		t.Errorf("repo filters not applied: priority=%q assignee=%q labels=%v", taskListPriority, taskListAssignee, taskListLabels)
+	}
+}
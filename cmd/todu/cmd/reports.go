@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/reports"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var reportsCmd = &cobra.Command{
+	Use:   "reports",
+	Short: "Manage files in the local_reports tree",
+}
+
+var reportsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove or archive old journal exports and reviews",
+	Long: `Remove or archive old journal exports, weekly reviews, and attachments.
+
+Dated reports are kept in month directories under
+{local_reports}/reviews/YYYY/MM-Monthname/. Prune removes every month that
+ended more than --older-than ago (such as 90d, 6w, 6m, or 1y).
+
+With --archive, the months are rolled up into one archive per year,
+{local_reports}/reviews/archive/YYYY.tar.gz, before they are removed. An
+existing archive is extended, not replaced, and a month is only removed
+once its archive has been written and read back.
+
+The defaults for --older-than and --archive come from reports.older_than
+and reports.archive in the config.
+
+Examples:
+  todu reports prune --older-than 1y --archive
+  todu reports prune --older-than 6m --dry-run
+  todu reports prune --older-than 2y --yes`,
+	Args: cobra.NoArgs,
+	RunE: runReportsPrune,
+}
+
+var (
+	// Prune flags
+	reportsPruneOlderThan string
+	reportsPruneArchive   bool
+	reportsPruneDryRun    bool
+	reportsPruneYes       bool
+)
+
+func init() {
+	rootCmd.AddCommand(reportsCmd)
+	reportsCmd.AddCommand(reportsPruneCmd)
+
+	reportsPruneCmd.Flags().StringVar(&reportsPruneOlderThan, "older-than", "", "Prune months older than this age (e.g. 90d, 6m, 1y)")
+	reportsPruneCmd.Flags().BoolVar(&reportsPruneArchive, "archive", false, "Roll pruned months into yearly archives instead of deleting them")
+	reportsPruneCmd.Flags().BoolVar(&reportsPruneDryRun, "dry-run", false, "Show what would be pruned without changing anything")
+	addYesFlag(reportsPruneCmd, &reportsPruneYes)
+}
+
+func runReportsPrune(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.LocalReports == "" {
+		return fmt.Errorf("local_reports path not configured")
+	}
+
+	olderThan := cfg.Reports.OlderThan
+	if cmd.Flags().Changed("older-than") {
+		olderThan = reportsPruneOlderThan
+	}
+	if olderThan == "" {
+		return fmt.Errorf("--older-than is required (or set reports.older_than in the config)")
+	}
+	archive := cfg.Reports.Archive
+	if cmd.Flags().Changed("archive") {
+		archive = reportsPruneArchive
+	}
+
+	cutoff, err := reports.ParseAge(olderThan, time.Now())
+	if err != nil {
+		return err
+	}
+
+	localReports := review.ExpandPath(cfg.LocalReports)
+	months, err := reports.FindMonths(localReports)
+	if err != nil {
+		return err
+	}
+	months = reports.OlderThan(months, cutoff)
+	if len(months) == 0 {
+		fmt.Printf("No reports older than %s\n", olderThan)
+		return nil
+	}
+
+	action := "delete"
+	if archive {
+		action = "archive"
+	}
+	affected := make([]string, len(months))
+	for i, m := range months {
+		affected[i] = fmt.Sprintf("%s (%s)", m.Name(), m.Dir)
+	}
+
+	if reportsPruneDryRun {
+		fmt.Printf("Would %s %d month(s) of reports:\n", action, len(months))
+		for _, line := range affected {
+			fmt.Printf("  %s\n", line)
+		}
+		return nil
+	}
+
+	confirmed, err := confirmAction(cfg, reportsPruneYes, fmt.Sprintf("%s %d month(s) of reports?", capitalize(action), len(months)), affected...)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Prune cancelled")
+		return nil
+	}
+
+	if !archive {
+		if err := reports.Remove(months); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d month(s) of reports\n", len(months))
+		return nil
+	}
+
+	written, err := reports.Archive(localReports, months)
+	for _, path := range written {
+		fmt.Printf("Archived to %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Archived %d month(s) of reports\n", len(months))
+	return nil
+}
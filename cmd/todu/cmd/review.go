@@ -1,12 +1,16 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/export"
 	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/internal/sla"
 	"github.com/spf13/cobra"
 )
 
@@ -25,19 +29,44 @@ var reviewDailyCmd = &cobra.Command{
 	Short: "Generate daily review report",
 	Long: `Generate a daily review report with tasks and habits.
 
-The report includes:
-  - In Progress: Tasks currently being worked on
-  - Daily Goals: Habit completion status for the day
-  - Coming up Soon: Tasks due within the next 3 days
-  - Next: High priority, scheduled, and default project tasks
-  - Waiting: Tasks in waiting status
-  - Done Today: Tasks completed today
+The report includes these sections:
+  in_progress     Tasks currently being worked on
+  daily_goals     Habit completion status for the day
+  coming_up_soon  Tasks due within the next few days (review.daily.soon_days)
+  next            High priority, scheduled, and default project tasks
+  waiting         Tasks in waiting status
+  done_today      Tasks completed today
+
+Optional sections, enabled with review.daily.carryover and
+review.daily.journal or by listing them in review.daily.sections:
+  carried_over    Tasks in yesterday's Next section that are still open
+  journal         Journal entries written on the review date
+
+Custom sections, such as a "Blocked" list, can be defined under
+review.daily.custom in the config.
+
+Section order comes from review.daily.sections in the config, and
+review.daily.limits caps how many items each section shows. Use --include
+and --exclude to pick sections for a single run.
+
+Use --to to export the review somewhere other than stdout or a file:
+obsidian, html, or webhook (see "todu journal export --help"). File
+targets write to the --save or --out path.
 
 Example:
   todu review daily                        # Display review to stdout
   todu review daily --save                 # Save to default location
   todu review daily --save=./review.md     # Save to specific path (use = for path)
-  todu review daily --date 2025-12-15      # Generate review for specific date`,
+  todu review daily --out ./review.md      # Save to specific path
+  todu review daily --out ./review.md --stdout   # Save and print
+  todu review daily --save --open          # Save and open in your editor
+  todu review daily --include next,waiting # Only show some sections
+  todu review daily --exclude daily_goals  # Hide a section
+  todu review daily --include carried_over,journal   # Only carryover and journal
+  todu review daily --date 2025-12-15      # Generate review for specific date
+  todu review daily --watch=5m             # Redraw every 5 minutes
+  todu review daily --to webhook           # POST to export.webhook.url
+  todu review daily --to webhook --url https://hooks.example.com/daily`,
 	RunE: runReviewDaily,
 }
 
@@ -50,22 +79,37 @@ The week starts from the specified date (or today) and covers 7 days.
 
 The report includes:
   - Projects Worked On: Completed tasks grouped by project
+  - Project Notes: Notes of open projects (see "todu project notes")
   - Habits Summary: Table showing habit completion for each day
   - Weekly Stats: Total tasks completed and habit completion rate
 
+Use --to to export the review to obsidian, html, or webhook instead (see
+"todu journal export --help").
+
 Example:
   todu review weekly                        # Review from today
   todu review weekly --date 2025-12-21      # Review from specific date
   todu review weekly --save                 # Save to dated file
-  todu review weekly --save=./review.md     # Save to specific path (use = for path)`,
+  todu review weekly --save=./review.md     # Save to specific path (use = for path)
+  todu review weekly --to obsidian          # Save into the Obsidian vault`,
 	RunE: runReviewWeekly,
 }
 
 var (
-	reviewDailyDate  string
-	reviewDailySave  string
-	reviewWeeklyDate string
-	reviewWeeklySave string
+	reviewDailyDate    string
+	reviewDailySave    string
+	reviewDailyWatch   string
+	reviewDailyOut     string
+	reviewDailyStdout  bool
+	reviewDailyOpen    bool
+	reviewDailyInclude []string
+	reviewDailyExclude []string
+	reviewDailyTo      string
+	reviewDailyURL     string
+	reviewWeeklyDate   string
+	reviewWeeklySave   string
+	reviewWeeklyTo     string
+	reviewWeeklyURL    string
 )
 
 func init() {
@@ -76,13 +120,42 @@ func init() {
 	reviewDailyCmd.Flags().StringVar(&reviewDailyDate, "date", "", "Target date (YYYY-MM-DD, defaults to today)")
 	reviewDailyCmd.Flags().StringVar(&reviewDailySave, "save", "", "Save to file (optional path, defaults to {local_reports}/daily-review.md)")
 	reviewDailyCmd.Flags().Lookup("save").NoOptDefVal = "default"
+	reviewDailyCmd.Flags().StringVar(&reviewDailyWatch, "watch", "", "Redraw every interval (e.g., --watch=5m, default "+watchDefaultInterval+")")
+	reviewDailyCmd.Flags().Lookup("watch").NoOptDefVal = watchDefaultInterval
+	reviewDailyCmd.Flags().StringVar(&reviewDailyOut, "out", "", "Save to this file path")
+	reviewDailyCmd.Flags().BoolVar(&reviewDailyStdout, "stdout", false, "Also print the review when saving it")
+	reviewDailyCmd.Flags().BoolVar(&reviewDailyOpen, "open", false, "Open the saved review in your editor")
+	reviewDailyCmd.Flags().StringSliceVar(&reviewDailyInclude, "include", []string{}, "Only show these sections (comma-separated)")
+	reviewDailyCmd.Flags().StringSliceVar(&reviewDailyExclude, "exclude", []string{}, "Hide these sections (comma-separated)")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("save", "out")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("save", "watch")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("out", "watch")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("open", "watch")
+	addExportFlags(reviewDailyCmd, &reviewDailyTo, &reviewDailyURL, "")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("to", "watch")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("to", "stdout")
+	reviewDailyCmd.MarkFlagsMutuallyExclusive("to", "open")
 
 	reviewWeeklyCmd.Flags().StringVar(&reviewWeeklyDate, "date", "", "Start date (YYYY-MM-DD, defaults to today)")
 	reviewWeeklyCmd.Flags().StringVar(&reviewWeeklySave, "save", "", "Save to file (optional path, defaults to dated file in {local_reports}/reviews/)")
 	reviewWeeklyCmd.Flags().Lookup("save").NoOptDefVal = "default"
+	addExportFlags(reviewWeeklyCmd, &reviewWeeklyTo, &reviewWeeklyURL, "")
 }
 
 func runReviewDaily(cmd *cobra.Command, args []string) error {
+	if reviewDailyWatch == "" {
+		return generateDailyReview()
+	}
+
+	interval, err := parseWatchInterval(reviewDailyWatch)
+	if err != nil {
+		return err
+	}
+	return watch(interval, watchTitle(), generateDailyReview)
+}
+
+// generateDailyReview generates the daily review and prints or saves it.
+func generateDailyReview() error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -92,8 +165,16 @@ func runReviewDaily(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("API URL not configured")
 	}
 
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	opts, err := dailyReviewOptions(cfg, reviewDailyInclude, reviewDailyExclude)
+	if err != nil {
+		return err
+	}
+
 	// Parse target date
-	targetDate := time.Now()
+	targetDate := daystart.Today(opts.DayStart)
 	if reviewDailyDate != "" {
 		parsed, err := time.ParseInLocation("2006-01-02", reviewDailyDate, time.Local)
 		if err != nil {
@@ -102,41 +183,146 @@ func runReviewDaily(cmd *cobra.Command, args []string) error {
 		targetDate = parsed
 	}
 
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	// Decide where to save: --out, --save, or the default path for --open
+	var outputPath string
+	switch {
+	case reviewDailyOut != "":
+		outputPath = reviewDailyOut
+	case reviewDailySave != "" && reviewDailySave != "default":
+		outputPath = reviewDailySave
+	case reviewDailySave == "default" || reviewDailyOpen || export.NeedsPath(reviewDailyTo):
+		if cfg.LocalReports == "" {
+			return fmt.Errorf("local_reports path not configured. Set it in your config file or specify a path: --out ./review.md")
+		}
+		outputPath = review.DefaultDailyReportPath(cfg.LocalReports)
+	}
+
+	var adapter export.Adapter
+	if reviewDailyTo != "" || reviewDailyURL != "" {
+		if adapter, err = exportAdapter(cfg, reviewDailyTo, reviewDailyURL); err != nil {
+			return err
+		}
+	}
+
+	// Yesterday's saved review tells the carried_over section what was planned
+	if outputPath != "" && (reviewDailyTo == "" || reviewDailyTo == "file") {
+		opts.PreviousReport = outputPath
+	}
 
 	// Generate the report
-	markdown, err := review.DailyReport(ctx, apiClient, targetDate, cfg.Defaults.Project)
+	markdown, err := review.DailyReport(ctx, apiClient, targetDate, opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate daily review: %w", err)
 	}
 
-	// If --save flag is provided, save to file
-	if reviewDailySave != "" {
-		var outputPath string
-		if reviewDailySave == "default" {
-			// Use default location
-			if cfg.LocalReports == "" {
-				return fmt.Errorf("local_reports path not configured. Set it in your config file or specify a path: --save ./review.md")
+	if adapter != nil {
+		if reviewDailyTo == "file" {
+			if err := review.KeepPreviousReport(markdown, outputPath); err != nil {
+				return err
 			}
-			outputPath = review.DefaultDailyReportPath(cfg.LocalReports)
-		} else {
-			// Use provided path
-			outputPath = reviewDailySave
 		}
-
-		if err := review.SaveDailyReport(markdown, outputPath); err != nil {
-			return fmt.Errorf("failed to save daily review: %w", err)
+		doc := &export.Document{
+			Kind:     export.KindDailyReview,
+			Title:    "Daily Review: " + targetDate.Format("01-02-2006"),
+			Date:     targetDate,
+			Markdown: markdown,
+			Path:     outputPath,
 		}
-		fmt.Printf("Daily review saved to: %s\n", outputPath)
-		return nil
+		return exportDocument(ctx, adapter, doc, "Daily review")
 	}
 
 	// Default: print to stdout
-	fmt.Print(markdown)
+	if outputPath == "" {
+		fmt.Print(markdown)
+		return nil
+	}
+
+	if err := review.SaveDailyReport(markdown, outputPath); err != nil {
+		return fmt.Errorf("failed to save daily review: %w", err)
+	}
+
+	if reviewDailyStdout {
+		fmt.Print(markdown)
+		// Keep stdout clean for piping
+		fmt.Fprintf(os.Stderr, "Daily review saved to: %s\n", outputPath)
+	} else {
+		fmt.Printf("Daily review saved to: %s\n", outputPath)
+	}
+
+	if reviewDailyOpen {
+		return openFileInEditor(review.ExpandPath(outputPath))
+	}
 	return nil
 }
 
+// weeklyReviewOptions builds the weekly review options from the config.
+func weeklyReviewOptions(cfg *config.Config) (review.WeeklyOptions, error) {
+	slaRules, err := sla.ParseRules(cfg.SLA)
+	if err != nil {
+		return review.WeeklyOptions{}, err
+	}
+	return review.WeeklyOptions{
+		LocalReports: cfg.LocalReports,
+		SLA:          slaRules,
+		StreakFreeze: cfg.Wellness.StreakFreeze,
+	}, nil
+}
+
+// dailyReviewOptions builds the daily review options from the config,
+// narrowing the configured sections with include and exclude.
+func dailyReviewOptions(cfg *config.Config, include, exclude []string) (review.DailyOptions, error) {
+	dailyCfg := cfg.Review.Daily
+	custom := make([]review.CustomSection, len(dailyCfg.Custom))
+	for i, c := range dailyCfg.Custom {
+		custom[i] = review.CustomSection{
+			Name:          c.Name,
+			Statuses:      c.Statuses,
+			Priority:      c.Priority,
+			Labels:        c.Labels,
+			Project:       c.Project,
+			DueWithinDays: c.DueWithinDays,
+		}
+		if c.Area != "" {
+			projects, ok := cfg.AreaProjects(c.Area)
+			if !ok {
+				return review.DailyOptions{}, fmt.Errorf("review section %q: unknown area %q", c.Name, c.Area)
+			}
+			// Non-nil even when empty, so an area without projects matches nothing
+			custom[i].Projects = append([]string{}, projects...)
+		}
+	}
+
+	order := dailyCfg.Sections
+	if len(order) == 0 {
+		order = review.DefaultSectionOrder(custom, dailyCfg.Carryover, dailyCfg.Journal)
+	}
+	sections, err := review.ResolveSections(order, include, exclude, custom)
+	if err != nil {
+		return review.DailyOptions{}, err
+	}
+
+	dayStart, err := configDayStart(cfg)
+	if err != nil {
+		return review.DailyOptions{}, err
+	}
+
+	opts := review.DailyOptions{
+		DefaultProject: cfg.Defaults.Project,
+		Sections:       sections,
+		SoonDays:       dailyCfg.SoonDays,
+		Statuses:       dailyCfg.Statuses,
+		Limits:         dailyCfg.Limits,
+		Custom:         custom,
+		DayStart:       dayStart,
+		MaxNext:        cfg.Wellness.MaxNext,
+		Milestones:     cfg.Wellness.Milestones,
+	}
+	if cfg.LocalReports != "" {
+		opts.PreviousReport = review.DefaultDailyReportPath(cfg.LocalReports)
+	}
+	return opts, nil
+}
+
 func runReviewWeekly(cmd *cobra.Command, args []string) error {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -157,29 +343,52 @@ func runReviewWeekly(cmd *cobra.Command, args []string) error {
 		startDate = parsed
 	}
 
+	weeklyOpts, err := weeklyReviewOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	var adapter export.Adapter
+	if reviewWeeklyTo != "" || reviewWeeklyURL != "" {
+		if adapter, err = exportAdapter(cfg, reviewWeeklyTo, reviewWeeklyURL); err != nil {
+			return err
+		}
+	}
+
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Generate the report
-	markdown, err := review.WeeklyReport(ctx, apiClient, startDate)
+	markdown, err := review.WeeklyReport(ctx, apiClient, startDate, weeklyOpts)
 	if err != nil {
 		return fmt.Errorf("failed to generate weekly review: %w", err)
 	}
 
-	// If --save flag is provided, save to file
-	if reviewWeeklySave != "" {
-		var outputPath string
-		if reviewWeeklySave == "default" {
-			// Use default location with dated path
-			if cfg.LocalReports == "" {
-				return fmt.Errorf("local_reports path not configured. Set it in your config file or specify a path: --save ./review.md")
-			}
-			outputPath = review.BuildWeeklyReportPath(cfg.LocalReports, startDate)
-		} else {
-			// Use provided path
-			outputPath = reviewWeeklySave
+	// Decide where to save: the --save path, or the dated default
+	var outputPath string
+	if reviewWeeklySave != "" && reviewWeeklySave != "default" {
+		outputPath = reviewWeeklySave
+	} else if reviewWeeklySave == "default" || export.NeedsPath(reviewWeeklyTo) {
+		if cfg.LocalReports == "" {
+			return fmt.Errorf("local_reports path not configured. Set it in your config file or specify a path: --save ./review.md")
 		}
+		outputPath = review.BuildWeeklyReportPath(cfg.LocalReports, startDate)
+	}
 
+	if adapter != nil {
+		start, end := review.WeekBoundaries(startDate)
+		doc := &export.Document{
+			Kind:     export.KindWeeklyReview,
+			Title:    fmt.Sprintf("Weekly Review: %s to %s", start.Format("01-02-2006"), end.Format("01-02-2006")),
+			Date:     end,
+			Markdown: markdown,
+			Path:     outputPath,
+		}
+		return exportDocument(ctx, adapter, doc, "Weekly review")
+	}
+
+	// If --save flag is provided, save to file
+	if outputPath != "" {
 		if err := review.SaveReport(markdown, outputPath); err != nil {
 			return fmt.Errorf("failed to save weekly review: %w", err)
 		}
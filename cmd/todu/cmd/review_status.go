@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var reviewStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show when reviews were last generated",
+	Long: `Show when the daily and weekly reviews were last saved to the
+local_reports tree, and when the next ones are due.
+
+A daily review is due each day at review.daily.remind_at (default 09:00).
+A weekly review is due review.weekly.remind_after_days (default 7) after
+the last one. Overdue reviews are also reported by "todu notify".
+
+Only reviews saved to the default locations count: {local_reports}/daily-review.md
+and the weekly reviews under {local_reports}/reviews/.
+
+Example:
+  todu review status
+  todu review status --format json`,
+	Args: cobra.NoArgs,
+	RunE: runReviewStatus,
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewStatusCmd)
+}
+
+func runReviewStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.LocalReports == "" {
+		return fmt.Errorf("local_reports path not configured")
+	}
+
+	statuses, err := reviewStatuses(cfg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REVIEW\tLAST GENERATED\tDUE\tSTATUS")
+	fmt.Fprintln(w, "------\t--------------\t---\t------")
+	for _, status := range statuses {
+		last := "never"
+		if !status.Never() {
+			last = status.LastGenerated.Format("2006-01-02 15:04")
+		}
+		state := "ok"
+		if status.Overdue {
+			state = "overdue"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Review, last, status.Due.Format("2006-01-02 15:04"), state)
+	}
+	w.Flush()
+	return nil
+}
+
+// reviewStatuses returns the daily and weekly review status read from the
+// local_reports tree.
+func reviewStatuses(cfg *config.Config, now time.Time) ([]*review.Status, error) {
+	daily, err := review.DailyStatus(cfg.LocalReports, cfg.Review.Daily.RemindAt, now)
+	if err != nil {
+		return nil, err
+	}
+	weekly, err := review.WeeklyStatus(cfg.LocalReports, cfg.Review.Weekly.RemindAfterDays, now)
+	if err != nil {
+		return nil, err
+	}
+	return []*review.Status{daily, weekly}, nil
+}
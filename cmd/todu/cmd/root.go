@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
+	"github.com/evcraddock/todu.sh/internal/api"
 	"github.com/spf13/cobra"
 )
 
 var (
-	configFile   string
-	outputFormat string
+	configFile     string
+	outputFormat   string
+	nonInteractive bool
+	showAPIStats   bool
+	viaDaemon      bool
 )
 
+// apiStatsSlowest is how many of the slowest calls --show-api-stats lists
+const apiStatsSlowest = 5
+
 var rootCmd = &cobra.Command{
 	Use:   "todu",
 	Short: "Task management across multiple systems",
@@ -21,8 +29,33 @@ multiple external task management systems via a plugin architecture.`,
 }
 
 func Execute() {
-	err := rootCmd.Execute()
+	// Read commands answered by the daemon skip loading config and plugins
+	if code, ok := runViaDaemon(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
+	var stats *api.Recorder
+	cobra.OnInitialize(func() {
+		if showAPIStats {
+			stats = &api.Recorder{}
+			api.SetRecorder(stats)
+		}
+		cobra.CheckErr(startReplay())
+	})
+
+	err := withInterrupt(rootCmd.ExecuteContext)
+	saveRecording()
+	if stats != nil {
+		stats.WriteSummary(os.Stderr, apiStatsSlowest)
+	}
 	if err != nil {
+		var promptErr *promptRequiredError
+		if errors.As(err, &promptErr) {
+			os.Exit(exitPromptRequired)
+		}
+		if isInterrupted(err) {
+			os.Exit(exitInterrupted)
+		}
 		os.Exit(1)
 	}
 }
@@ -30,6 +63,11 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file path (default: ./config.yaml, ~/.config/todu/config.yaml, or ~/.todu/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "text", "output format (text|json)")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "fail instead of prompting for input (default when stdout is not a terminal or CI=true)")
+	rootCmd.PersistentFlags().BoolVar(&showAPIStats, "show-api-stats", false, "print the number of API calls, their total time, and the slowest calls to stderr")
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "record the command's requests to the API and plugins' servers to a session file")
+	rootCmd.PersistentFlags().StringVar(&replayFile, "replay", "", "answer the command's requests from a session file recorded with --record, without contacting any server")
+	rootCmd.PersistentFlags().BoolVar(&viaDaemon, "via-daemon", false, "answer read commands (task list, task show, ...) from the running daemon when possible")
 }
 
 // GetConfigFile returns the config file path from the --config flag
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage rules for automatic task actions",
+	Long: `Rules act on tasks automatically when they are created, updated, or
+synced. They are read from ~/.config/todu/rules.yaml, or the file set by
+rules_file in config:
+
+  rules:
+    - name: triage high priority bugs
+      when: label==bug and priority==high
+      then:
+        - assign me
+        - add label triaged
+        - comment "auto-triaged"
+      on: [create, sync]   # Optional; default is create, update, and sync
+
+Conditions compare label, assignee, priority, status, project, title,
+description, or external_id using == (equals), != (not equal), or ~=
+(contains), combined with and, or, not, and parentheses.
+
+Actions: assign NAME, unassign NAME, add label NAME, remove label NAME,
+set priority LEVEL, set status STATUS, comment "TEXT".
+"me" means the author from config, or the API key's user (see 'todu whoami').
+
+A rule only applies when it would change something, so a rule that has
+already acted on a task does nothing the next time.`,
+}
+
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured rules",
+	Args:  cobra.NoArgs,
+	RunE:  runRulesList,
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test [task-id...]",
+	Short: "Show what rules would do to existing tasks",
+	Long: `Dry-run rules against existing tasks and show what would change.
+Nothing is modified.
+
+With no task IDs, every task is checked.
+
+Examples:
+  todu rules test
+  todu rules test 12 15
+  todu rules test --on sync`,
+	RunE: runRulesTest,
+}
+
+var (
+	// Rules test flags
+	rulesTestOn     string
+	rulesTestStatus string
+)
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+
+	rulesTestCmd.Flags().StringVar(&rulesTestOn, "on", "", "Only test rules run on this event (create, update, sync)")
+	rulesTestCmd.Flags().StringVar(&rulesTestStatus, "status", "", "Only test tasks with this status")
+}
+
+// loadRuleEngine loads the rules file configured in cfg
+func loadRuleEngine(cfg *config.Config) (*rules.Engine, error) {
+	path, err := rules.ResolvePath(cfg.RulesFile)
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := rules.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return rules.NewEngine(loaded, currentUser(cfg)), nil
+}
+
+// applyTaskRules applies the rules run for trigger to a task that was just
+// created or updated. The task has already been saved, so rule failures
+// are reported as warnings. Returns the task as it is after the rules ran.
+func applyTaskRules(ctx context.Context, apiClient *api.Client, engine *rules.Engine, task *types.Task, trigger rules.Trigger) *types.Task {
+	updated, applied, err := runTaskRules(ctx, apiClient, engine, task, trigger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if len(applied) > 0 {
+		// Keep JSON output parseable
+		out := os.Stdout
+		if GetOutputFormat() == "json" {
+			out = os.Stderr
+		}
+		fmt.Fprintf(out, "Rules applied: %s\n", strings.Join(applied, ", "))
+	}
+	return updated
+}
+
+// runTaskRules applies the rules run for trigger to a saved task without
+// printing anything. Returns the task as it is after the rules ran and the
+// names of the rules that changed it.
+func runTaskRules(ctx context.Context, apiClient *api.Client, engine *rules.Engine, task *types.Task, trigger rules.Trigger) (*types.Task, []string, error) {
+	if len(engine.Rules()) == 0 {
+		return task, nil, nil
+	}
+
+	project := ""
+	if p, err := apiClient.GetProject(ctx, task.ProjectID); err == nil {
+		project = p.Name
+	}
+
+	outcome, err := engine.Apply(ctx, apiClient, rules.Subject{Task: task, Project: project}, trigger)
+	if err != nil {
+		return task, nil, fmt.Errorf("failed to apply rules: %w", err)
+	}
+	if !outcome.Changed() {
+		return task, nil, nil
+	}
+
+	updated, err := apiClient.GetTask(ctx, task.ID)
+	if err != nil {
+		return task, outcome.Rules, nil
+	}
+	return updated, outcome.Rules, nil
+}
+
+func runRulesList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	engine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		type ruleJSON struct {
+			Name string   `json:"name"`
+			When string   `json:"when"`
+			Then []string `json:"then"`
+			On   []string `json:"on"`
+		}
+		output := make([]ruleJSON, 0, len(engine.Rules()))
+		for _, r := range engine.Rules() {
+			rj := ruleJSON{Name: r.Name, When: r.When}
+			for _, a := range r.Actions {
+				rj.Then = append(rj.Then, a.String())
+			}
+			for _, t := range r.Triggers() {
+				rj.On = append(rj.On, string(t))
+			}
+			output = append(output, rj)
+		}
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(engine.Rules()) == 0 {
+		path, _ := rules.ResolvePath(cfg.RulesFile)
+		fmt.Printf("No rules configured (%s)\n", path)
+		return nil
+	}
+
+	displayRules(os.Stdout, engine.Rules())
+	return nil
+}
+
+// displayRules prints each rule's condition, actions, and triggers
+func displayRules(out io.Writer, list []*rules.Rule) {
+	for i, r := range list {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		var on []string
+		for _, t := range r.Triggers() {
+			on = append(on, string(t))
+		}
+		fmt.Fprintf(out, "%s (on %s)\n", r.Name, strings.Join(on, ", "))
+		fmt.Fprintf(out, "  when: %s\n", r.When)
+		for _, a := range r.Actions {
+			fmt.Fprintf(out, "  then: %s\n", a)
+		}
+	}
+}
+
+func runRulesTest(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	trigger := rules.Trigger(strings.ToLower(rulesTestOn))
+	if trigger != "" && trigger != rules.TriggerCreate && trigger != rules.TriggerUpdate && trigger != rules.TriggerSync {
+		return fmt.Errorf("invalid --on %q: must be create, update, or sync", rulesTestOn)
+	}
+
+	engine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+	if len(engine.Rules()) == 0 {
+		fmt.Println("No rules configured")
+		return nil
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	var tasks []*types.Task
+	if len(args) > 0 {
+		for _, arg := range args {
+			id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %s", arg)
+			}
+			task, err := apiClient.GetTask(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get task %d: %w", id, err)
+			}
+			tasks = append(tasks, task)
+		}
+	} else {
+		tasks, err = apiClient.ListTasks(ctx, &api.TaskListOptions{Status: rulesTestStatus})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+	}
+
+	projectNames, err := rules.ProjectNames(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+
+	var outcomes []*rules.Outcome
+	for _, task := range tasks {
+		subject := rules.Subject{Task: task, Project: projectNames[task.ProjectID]}
+		outcome, err := engine.Preview(ctx, apiClient, subject, trigger)
+		if err != nil {
+			return err
+		}
+		if outcome.Changed() {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+
+	if GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(outcomes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayRuleOutcomes(os.Stdout, outcomes, tasks)
+	return nil
+}
+
+// displayRuleOutcomes prints the changes rules would make to each task
+func displayRuleOutcomes(out io.Writer, outcomes []*rules.Outcome, tasks []*types.Task) {
+	titles := make(map[int]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+
+	for _, o := range outcomes {
+		fmt.Fprintf(out, "#%d %s\n", o.TaskID, titles[o.TaskID])
+		fmt.Fprintf(out, "  rules: %s\n", strings.Join(o.Rules, ", "))
+		for _, change := range describeTaskUpdate(o.Update) {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+		for _, c := range o.Comments {
+			fmt.Fprintf(out, "  comment: %s\n", c)
+		}
+	}
+	fmt.Fprintf(out, "\n%d of %d tasks would change\n", len(outcomes), len(tasks))
+}
+
+// describeTaskUpdate lists the fields a rule update sets
+func describeTaskUpdate(update *types.TaskUpdate) []string {
+	if update == nil {
+		return nil
+	}
+	var lines []string
+	if update.Status != nil {
+		lines = append(lines, "status: "+*update.Status)
+	}
+	if update.Priority != nil {
+		lines = append(lines, "priority: "+*update.Priority)
+	}
+	if update.Labels != nil {
+		lines = append(lines, "labels: "+strings.Join(update.Labels, ", "))
+	}
+	if update.Assignees != nil {
+		lines = append(lines, "assignees: "+strings.Join(update.Assignees, ", "))
+	}
+	return lines
+}
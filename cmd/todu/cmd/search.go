@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/reports"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search --local <query>",
+	Short: "Search exported journals and reviews",
+	Long: `Search the markdown files in the local_reports tree.
+
+With --local, every journal export, weekly review, and daily review under
+local_reports is searched, including months rolled into yearly archives by
+"todu reports prune --archive". This reaches journal history that "todu
+journal search" cannot. A line matches if it contains every word of the
+query, ignoring case. Matches are listed newest report first.
+
+Encrypted exports (.age) are not searched.
+
+Examples:
+  todu search --local "quarterly report"
+  todu search --local retro --limit 10
+  todu search --local offsite --format json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+var (
+	// Search flags
+	searchLocal bool
+	searchLimit int
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().BoolVar(&searchLocal, "local", false, "Search the local_reports tree")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Maximum number of matches to show (0 for all)")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	if !searchLocal {
+		return fmt.Errorf("only --local search is supported; use 'todu task list --search' or 'todu journal search' to search the API")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.LocalReports == "" {
+		return fmt.Errorf("local_reports path not configured")
+	}
+
+	query := strings.Join(args, " ")
+	matches, err := reports.Search(review.ExpandPath(cfg.LocalReports), query)
+	if err != nil {
+		return err
+	}
+
+	total := len(matches)
+	if searchLimit > 0 && total > searchLimit {
+		matches = matches[:searchLimit]
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(matches)
+	}
+
+	if total == 0 {
+		fmt.Printf("No reports found matching '%s'\n", query)
+		return nil
+	}
+
+	lastPath := ""
+	for _, m := range matches {
+		if m.Path != lastPath {
+			if lastPath != "" {
+				fmt.Println()
+			}
+			fmt.Println(m.Path)
+			lastPath = m.Path
+		}
+		fmt.Printf("  %d: %s\n", m.Line, m.Text)
+	}
+
+	if len(matches) < total {
+		fmt.Printf("\nShowing %d of %d matches (use --limit to see more)\n", len(matches), total)
+	}
+	return nil
+}
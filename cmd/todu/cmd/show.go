@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <ref>",
+	Short: "Show a task, project, template, or comment by reference",
+	Long: `Show any entity by a typed reference or a bare ID.
+
+References:
+  task:12      Task 12 (also t:12)
+  project:3    Project 3 (also p:3)
+  template:7   Recurring task template 7 (also tpl:7)
+  comment:88   Comment or journal entry 88 (also c:88, journal:88)
+
+A bare number is looked up as a task, then a project, template, and
+comment, and the first match is shown. "#12" is treated like "12".
+
+Examples:
+  todu show task:12
+  todu show project:3
+  todu show 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+}
+
+// Entity kinds accepted by "todu show".
+const (
+	entityTask     = "task"
+	entityProject  = "project"
+	entityTemplate = "template"
+	entityComment  = "comment"
+)
+
+// entityAliases maps reference prefixes to entity kinds.
+var entityAliases = map[string]string{
+	"task":     entityTask,
+	"t":        entityTask,
+	"project":  entityProject,
+	"p":        entityProject,
+	"template": entityTemplate,
+	"tpl":      entityTemplate,
+	"comment":  entityComment,
+	"c":        entityComment,
+	"journal":  entityComment,
+}
+
+// parseEntityRef splits a reference like "task:12" into its kind and ID.
+// Returns an empty kind for a bare ID.
+func parseEntityRef(ref string) (string, int, error) {
+	kind := ""
+	idStr := strings.TrimPrefix(strings.TrimSpace(ref), "#")
+	if prefix, rest, ok := strings.Cut(idStr, ":"); ok {
+		kind, ok = entityAliases[strings.ToLower(prefix)]
+		if !ok {
+			return "", 0, fmt.Errorf("unknown reference type %q (use task, project, template, or comment)", prefix)
+		}
+		idStr = strings.TrimPrefix(rest, "#")
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid reference: %s", ref)
+	}
+	return kind, id, nil
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	kind, id, err := parseEntityRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	if kind == "" {
+		kind, err = inferEntityKind(id)
+		if err != nil {
+			return err
+		}
+		if GetOutputFormat() != "json" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Showing %s %d\n\n", kind, id)
+		}
+	}
+
+	idArgs := []string{strconv.Itoa(id)}
+	switch kind {
+	case entityProject:
+		return runProjectShow(cmd, idArgs)
+	case entityTemplate:
+		return runTemplateShow(cmd, idArgs)
+	case entityComment:
+		return runJournalShow(cmd, idArgs)
+	default:
+		return runTaskShow(cmd, idArgs)
+	}
+}
+
+// inferEntityKind finds the first kind of entity with the given ID, trying
+// tasks, projects, templates, then comments.
+func inferEntityKind(id int) (string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return "", fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	lookups := []struct {
+		kind string
+		get  func() error
+	}{
+		{entityTask, func() error { _, err := apiClient.GetTask(ctx, id); return err }},
+		{entityProject, func() error { _, err := apiClient.GetProject(ctx, id); return err }},
+		{entityTemplate, func() error { _, err := apiClient.GetTemplate(ctx, id); return err }},
+		{entityComment, func() error { _, err := apiClient.GetComment(ctx, id); return err }},
+	}
+
+	for _, lookup := range lookups {
+		err := lookup.get()
+		if err == nil {
+			return lookup.kind, nil
+		}
+		if !api.IsNotFound(err) {
+			return "", fmt.Errorf("failed to look up %s %d: %w", lookup.kind, id, err)
+		}
+	}
+
+	return "", fmt.Errorf("no task, project, template, or comment with ID %d", id)
+}
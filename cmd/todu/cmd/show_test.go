@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+func TestParseEntityRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		kind    string
+		id      int
+		wantErr bool
+	}{
+		{ref: "task:12", kind: entityTask, id: 12},
+		{ref: "T:12", kind: entityTask, id: 12},
+		{ref: "project:3", kind: entityProject, id: 3},
+		{ref: "tpl:7", kind: entityTemplate, id: 7},
+		{ref: "journal:88", kind: entityComment, id: 88},
+		{ref: "task:#5", kind: entityTask, id: 5},
+		{ref: "42", kind: "", id: 42},
+		{ref: "#42", kind: "", id: 42},
+		{ref: "label:4", wantErr: true},
+		{ref: "task:abc", wantErr: true},
+		{ref: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		kind, id, err := parseEntityRef(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseEntityRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (kind != tt.kind || id != tt.id) {
+			t.Errorf("parseEntityRef(%q) = %q, %d; expected %q, %d", tt.ref, kind, id, tt.kind, tt.id)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/sla"
+	"github.com/spf13/cobra"
+)
+
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Track open tasks against per-label SLAs",
+	Long: `Track how long open tasks have been open against per-label SLAs, set
+with the sla config setting:
+
+  sla:
+    bug: 14d
+    security: 3d
+
+A task's age is the time since it was created. When a task has more than
+one label with an SLA, the strictest applies. Tasks past their SLA get the
+sla-breach label from 'todu sla check' or the daemon, which also remove it
+once a task is no longer breaching. Weekly reviews count breaches by label.`,
+}
+
+var slaReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "List open tasks with an SLA, breaching first",
+	Long: `List open tasks with an SLA, with their age and the time left before
+they breach it. Tasks past their SLA come first, longest overdue first.
+
+Examples:
+  todu sla report
+  todu sla report --breached
+  todu sla report --format json`,
+	Args: cobra.NoArgs,
+	RunE: runSLAReport,
+}
+
+var slaCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Update sla-breach labels",
+	Long: `Add the sla-breach label to open tasks past their SLA, and remove it
+from open tasks no longer breaching, such as after their SLA was raised.
+Closed tasks keep the label as a record. The daemon does this after each
+sync.
+
+Examples:
+  todu sla check --dry-run
+  todu sla check`,
+	Args: cobra.NoArgs,
+	RunE: runSLACheck,
+}
+
+var (
+	// Report flags
+	slaReportBreached bool
+
+	// Check flags
+	slaCheckDryRun bool
+)
+
+func init() {
+	rootCmd.AddCommand(slaCmd)
+	slaCmd.AddCommand(slaReportCmd)
+	slaCmd.AddCommand(slaCheckCmd)
+
+	slaReportCmd.Flags().BoolVar(&slaReportBreached, "breached", false, "Only list tasks past their SLA")
+	slaCheckCmd.Flags().BoolVar(&slaCheckDryRun, "dry-run", false, "Show the label changes without making them")
+}
+
+// loadSLA loads the config and its SLA rules, failing if none are set.
+func loadSLA() (*config.Config, []sla.Rule, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return nil, nil, fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	rules, err := sla.ParseRules(cfg.SLA)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil, fmt.Errorf("no SLA rules configured; add an sla section to the config, such as 'sla: {bug: 14d}'")
+	}
+	return cfg, rules, nil
+}
+
+// slaReportEntry is a task's SLA status in JSON output
+type slaReportEntry struct {
+	TaskID   int       `json:"task_id"`
+	Title    string    `json:"title"`
+	Label    string    `json:"label"`
+	SLA      string    `json:"sla"`
+	Age      string    `json:"age"`
+	Deadline time.Time `json:"deadline"`
+	Breached bool      `json:"breached"`
+}
+
+func runSLAReport(cmd *cobra.Command, args []string) error {
+	cfg, rules, err := loadSLA()
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, err := sla.ListTasks(ctx, apiClient, rules)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var statuses []sla.Status
+	for _, status := range sla.Evaluate(tasks, rules, now) {
+		if status.Breached || !slaReportBreached {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if GetOutputFormat() == "json" {
+		entries := make([]slaReportEntry, 0, len(statuses))
+		for _, status := range statuses {
+			entries = append(entries, slaReportEntry{
+				TaskID:   status.Task.ID,
+				Title:    status.Task.Title,
+				Label:    status.Rule.Label,
+				SLA:      sla.FormatDuration(status.Rule.Within),
+				Age:      sla.FormatDuration(status.Age),
+				Deadline: status.Deadline,
+				Breached: status.Breached,
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(statuses) == 0 {
+		if slaReportBreached {
+			fmt.Println("No tasks past their SLA")
+		} else {
+			fmt.Println("No open tasks with an SLA")
+		}
+		return nil
+	}
+
+	breaching := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tLABEL\tSLA\tAGE\tSTATUS")
+	for _, status := range statuses {
+		state := sla.FormatDuration(status.Left(now)) + " left"
+		if status.Breached {
+			state = sla.FormatDuration(status.Left(now)) + " overdue"
+			breaching++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			status.Task.ID, truncate(status.Task.Title, 50), status.Rule.Label,
+			sla.FormatDuration(status.Rule.Within), sla.FormatDuration(status.Age), state)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d of %d open task(s) past their SLA\n", breaching, len(statuses))
+	return nil
+}
+
+func runSLACheck(cmd *cobra.Command, args []string) error {
+	cfg, rules, err := loadSLA()
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	if !slaCheckDryRun {
+		if err := requireWritable(ctx, apiClient, "sla check"); err != nil {
+			return err
+		}
+	}
+
+	tasks, err := sla.ListTasks(ctx, apiClient, rules)
+	if err != nil {
+		return err
+	}
+
+	changes := sla.Changes(tasks, rules, time.Now())
+	if len(changes) == 0 {
+		fmt.Println("SLA breach labels are up to date")
+		return nil
+	}
+
+	for _, change := range changes {
+		action := "remove"
+		if change.Add {
+			action = "add"
+		}
+		if slaCheckDryRun {
+			action = "would " + action
+		}
+		fmt.Printf("#%d %s: %s %s\n", change.Task.ID, change.Task.Title, action, sla.BreachLabel)
+	}
+	if slaCheckDryRun {
+		return nil
+	}
+
+	applied, err := sla.Apply(ctx, apiClient, changes)
+	fmt.Printf("\nUpdated %d task(s)\n", applied)
+	return err
+}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// somedayReviewInterval is how long a kept someday task stays out of
+// someday review before it resurfaces.
+const somedayReviewInterval = 30 * 24 * time.Hour
+
+var taskSomedayCmd = &cobra.Command{
+	Use:   "someday <id>",
+	Short: "Park a task in the someday/maybe backlog",
+	Long: `Park a task in the someday/maybe backlog.
+
+Someday tasks keep their status but are left out of task list, task pick,
+todu plan, and the daily review. They come back once a month in
+"todu someday review" for re-triage. A task is parked by adding the
+"` + types.SomedayLabel + `" label, so it syncs like any other label.
+
+Use task list --someday to list the backlog, and --undo to bring a task
+back.
+
+Examples:
+  todu task someday 42
+  todu task someday 42 --undo`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskSomeday,
+}
+
+var somedayCmd = &cobra.Command{
+	Use:   "someday",
+	Short: "Work with the someday/maybe backlog",
+}
+
+var somedayReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Re-triage someday/maybe tasks",
+	Long: `Walk through the someday/maybe backlog and decide on each task.
+
+Only tasks not reviewed in the last 30 days are shown, so running this
+monthly resurfaces each parked task once a month. Use --all to review the
+whole backlog.
+
+For each task, choose:
+  k  keep      Leave it in the backlog until next month
+  a  activate  Bring it back into lists and reviews
+  d  drop      Cancel the task
+  s  skip      Decide next time
+  q  quit      Stop reviewing; remaining tasks are left as they are`,
+	Args: cobra.NoArgs,
+	RunE: runSomedayReview,
+}
+
+var (
+	// Someday flags
+	taskSomedayUndo  bool
+	somedayReviewAll bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskSomedayCmd)
+	taskSomedayCmd.Flags().BoolVar(&taskSomedayUndo, "undo", false, "Take the task out of the someday/maybe backlog")
+
+	rootCmd.AddCommand(somedayCmd)
+	somedayCmd.AddCommand(somedayReviewCmd)
+	somedayReviewCmd.Flags().BoolVar(&somedayReviewAll, "all", false, "Review every someday task, including ones reviewed recently")
+}
+
+// somedayAction is the decision made for a someday task.
+type somedayAction int
+
+const (
+	somedaySkip somedayAction = iota
+	somedayKeep
+	somedayActivate
+	somedayDrop
+)
+
+// somedayDecision pairs a someday task with the decision made for it.
+type somedayDecision struct {
+	task   *types.Task
+	action somedayAction
+}
+
+func runTaskSomeday(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	parked := !taskSomedayUndo
+	if task.IsSomeday() == parked {
+		if parked {
+			fmt.Printf("Task #%d is already in the someday backlog\n", taskID)
+		} else {
+			fmt.Printf("Task #%d is not in the someday backlog\n", taskID)
+		}
+		return nil
+	}
+
+	if _, err := updateTaskLabel(ctx, apiClient, hookRunner, ruleEngine, task, types.SomedayLabel, parked); err != nil {
+		return err
+	}
+
+	if parked {
+		fmt.Printf("Task #%d moved to someday: %s\n", taskID, task.Title)
+	} else {
+		fmt.Printf("Task #%d is back from someday: %s\n", taskID, task.Title)
+	}
+	return nil
+}
+
+func runSomedayReview(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	statePath, err := somedayReviewsPath()
+	if err != nil {
+		return err
+	}
+	reviewed, err := loadSomedayReviews(statePath)
+	if err != nil {
+		return err
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, err := apiClient.ListTasks(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	now := time.Now()
+	backlog := 0
+	var due []*types.Task
+	for _, task := range tasks {
+		if !task.IsSomeday() || task.Status == "done" || task.Status == "canceled" {
+			continue
+		}
+		backlog++
+		if somedayReviewAll || somedayReviewDue(reviewed, task.ID, now) {
+			due = append(due, task)
+		}
+	}
+
+	if len(due) == 0 {
+		if backlog == 0 {
+			fmt.Println("The someday backlog is empty.")
+		} else {
+			fmt.Printf("Nothing to review: all %d someday task(s) were reviewed in the last 30 days. Use --all to review them anyway.\n", backlog)
+		}
+		return nil
+	}
+
+	if err := requireInteractive("someday review", ""); err != nil {
+		return err
+	}
+
+	projectNames := make(map[int]string)
+	if projects, err := apiClient.ListProjects(ctx, nil); err == nil {
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+
+	fmt.Printf("Reviewing %d of %d someday task(s)\n\n", len(due), backlog)
+	decisions, err := promptSomedayReview(os.Stdin, os.Stdout, due, projectNames)
+	if err != nil {
+		return err
+	}
+
+	kept, activated, dropped, applyErr := applySomedayReview(ctx, apiClient, hookRunner, ruleEngine, decisions, reviewed, now)
+	if err := saveSomedayReviews(statePath, reviewed); err != nil {
+		return err
+	}
+	if applyErr != nil {
+		return applyErr
+	}
+
+	fmt.Printf("\nKept %d, activated %d, dropped %d\n", kept, activated, dropped)
+	return nil
+}
+
+// promptSomedayReview asks for a decision on each someday task in turn.
+// Input ending early is treated as quitting.
+func promptSomedayReview(in io.Reader, out io.Writer, tasks []*types.Task, projectNames map[int]string) ([]somedayDecision, error) {
+	reader := bufio.NewReader(in)
+	var decisions []somedayDecision
+
+	for i := 0; i < len(tasks); i++ {
+		task := tasks[i]
+		fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(tasks), planTaskLine(task, projectNames))
+		fmt.Fprint(out, "Keep, activate, drop, skip, or quit? [k/a/d/s/q]: ")
+
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		if err == io.EOF && line == "" {
+			fmt.Fprintln(out)
+			return decisions, nil
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "k", "keep":
+			decisions = append(decisions, somedayDecision{task: task, action: somedayKeep})
+		case "a", "activate":
+			decisions = append(decisions, somedayDecision{task: task, action: somedayActivate})
+		case "d", "drop":
+			decisions = append(decisions, somedayDecision{task: task, action: somedayDrop})
+		case "s", "skip", "":
+			decisions = append(decisions, somedayDecision{task: task, action: somedaySkip})
+		case "q", "quit":
+			return decisions, nil
+		default:
+			fmt.Fprintln(out, "Please enter k, a, d, s, or q.")
+			i--
+		}
+	}
+
+	return decisions, nil
+}
+
+// applySomedayReview carries out the decisions, recording when kept tasks
+// were reviewed. Activated tasks are updated through the task update hooks
+// and rules, and dropped tasks are canceled through the task close hooks.
+// Returns how many tasks were kept, activated, and dropped.
+func applySomedayReview(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, decisions []somedayDecision, reviewed map[int]time.Time, now time.Time) (int, int, int, error) {
+	kept, activated, dropped := 0, 0, 0
+	for _, d := range decisions {
+		switch d.action {
+		case somedayKeep:
+			reviewed[d.task.ID] = now
+			kept++
+		case somedayActivate:
+			if _, err := updateTaskLabel(ctx, apiClient, hookRunner, ruleEngine, d.task, types.SomedayLabel, false); err != nil {
+				return kept, activated, dropped, fmt.Errorf("failed to activate task #%d: %w", d.task.ID, err)
+			}
+			delete(reviewed, d.task.ID)
+			activated++
+		case somedayDrop:
+			if _, err := closeTaskWithHooks(ctx, apiClient, hookRunner, d.task.ID, d.task, "canceled"); err != nil {
+				return kept, activated, dropped, fmt.Errorf("failed to drop task #%d: %w", d.task.ID, err)
+			}
+			delete(reviewed, d.task.ID)
+			dropped++
+		}
+	}
+	return kept, activated, dropped, nil
+}
+
+// somedayReviewDue reports whether a someday task is due for review: it was
+// never reviewed, or last reviewed at least somedayReviewInterval ago.
+func somedayReviewDue(reviewed map[int]time.Time, taskID int, now time.Time) bool {
+	last, ok := reviewed[taskID]
+	return !ok || now.Sub(last) >= somedayReviewInterval
+}
+
+// somedayReviewsPath returns the file recording when someday tasks were
+// last reviewed (~/.config/todu/someday-reviews.json).
+func somedayReviewsPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "someday-reviews.json"), nil
+}
+
+// loadSomedayReviews reads the last review time of each someday task, by
+// task ID. A missing file means nothing was reviewed yet.
+func loadSomedayReviews(path string) (map[int]time.Time, error) {
+	reviewed := make(map[int]time.Time)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return reviewed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read someday reviews: %w", err)
+	}
+	if err := json.Unmarshal(data, &reviewed); err != nil {
+		return nil, fmt.Errorf("failed to parse someday reviews %s: %w", path, err)
+	}
+	return reviewed, nil
+}
+
+// saveSomedayReviews writes the last review time of each someday task.
+func saveSomedayReviews(path string, reviewed map[int]time.Time) error {
+	data, err := json.MarshalIndent(reviewed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode someday reviews: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write someday reviews: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestPromptSomedayReview(t *testing.T) {
+	tasks := []*types.Task{
+		{ID: 1, Title: "Learn piano", ProjectID: 1},
+		{ID: 2, Title: "Write a novel", ProjectID: 1},
+		{ID: 3, Title: "Build a boat", ProjectID: 1},
+		{ID: 4, Title: "Visit Iceland", ProjectID: 1},
+	}
+
+	var out bytes.Buffer
+	decisions, err := promptSomedayReview(strings.NewReader("k\nx\na\nd\nq\n"), &out, tasks, map[int]string{1: "Home"})
+	if err != nil {
+		t.Fatalf("promptSomedayReview failed: %v", err)
+	}
+
+	want := []somedayAction{somedayKeep, somedayActivate, somedayDrop}
+	if len(decisions) != len(want) {
+		t.Fatalf("Expected %d decisions, got %d", len(want), len(decisions))
+	}
+	for i, d := range decisions {
+		if d.action != want[i] || d.task.ID != i+1 {
+			t.Errorf("Decision %d: expected action %d for task #%d, got %d for #%d", i, want[i], i+1, d.action, d.task.ID)
+		}
+	}
+	if !strings.Contains(out.String(), "Please enter k, a, d, s, or q.") {
+		t.Errorf("Expected a hint after invalid input, got:\n%s", out.String())
+	}
+}
+
+func TestSomedayReviewDue(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	reviewed := map[int]time.Time{
+		1: now.AddDate(0, 0, -10),
+		2: now.AddDate(0, 0, -31),
+	}
+
+	if somedayReviewDue(reviewed, 1, now) {
+		t.Error("Expected a task reviewed 10 days ago to stay hidden")
+	}
+	if !somedayReviewDue(reviewed, 2, now) {
+		t.Error("Expected a task reviewed 31 days ago to resurface")
+	}
+	if !somedayReviewDue(reviewed, 3, now) {
+		t.Error("Expected a never-reviewed task to be due")
+	}
+}
+
+func TestSomedayReviewsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todu", "someday-reviews.json")
+
+	reviewed, err := loadSomedayReviews(path)
+	if err != nil || len(reviewed) != 0 {
+		t.Fatalf("Expected no reviews from a missing file, got %v (%v)", reviewed, err)
+	}
+
+	when := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	reviewed[42] = when
+	if err := saveSomedayReviews(path, reviewed); err != nil {
+		t.Fatalf("saveSomedayReviews failed: %v", err)
+	}
+
+	loaded, err := loadSomedayReviews(path)
+	if err != nil {
+		t.Fatalf("loadSomedayReviews failed: %v", err)
+	}
+	if !loaded[42].Equal(when) {
+		t.Errorf("Expected review time %v for task 42, got %v", when, loaded[42])
+	}
+}
+
+func TestTaskSomedayUndoLastLabel(t *testing.T) {
+	server := newGoldenServer(t)
+
+	runGolden(t, "task", "someday", "2")
+	if !server.Task(2).IsSomeday() {
+		t.Fatal("Expected task #2 moved to someday")
+	}
+	if output := runGolden(t, "task", "someday", "2", "--undo"); !strings.Contains(output, "Task #2 is back from someday") || len(server.Task(2).Labels) != 0 {
+		t.Errorf("Expected task #2 back from someday with no labels, got %q with %v", output, server.Task(2).Labels)
+	}
+}
+
+func TestApplySomedayReview(t *testing.T) {
+	server := newGoldenServer(t)
+	someday := []types.Label{{Name: types.SomedayLabel}}
+	piano := server.AddTask(types.Task{Title: "Learn piano", ProjectID: 1, Labels: someday})
+	novel := server.AddTask(types.Task{Title: "Write a novel", ProjectID: 1, Labels: someday})
+
+	events := filepath.Join(t.TempDir(), "events")
+	runner := hooks.NewRunner(map[string]string{
+		hooks.PreTaskUpdate:  "echo \"$TODU_HOOK_EVENT\" >> " + events,
+		hooks.PostTaskUpdate: "echo \"$TODU_HOOK_EVENT\" >> " + events,
+		hooks.PreTaskClose:   "echo \"$TODU_HOOK_EVENT\" >> " + events,
+		hooks.PostTaskClose:  "echo \"$TODU_HOOK_EVENT\" >> " + events,
+	})
+	decisions := []somedayDecision{{task: piano, action: somedayActivate}, {task: novel, action: somedayDrop}}
+	reviewed := map[int]time.Time{}
+	_, activated, dropped, err := applySomedayReview(context.Background(), server.Client(), runner, rules.NewEngine(nil, ""), decisions, reviewed, server.Now())
+	if err != nil {
+		t.Fatalf("applySomedayReview failed: %v", err)
+	}
+	if activated != 1 || dropped != 1 {
+		t.Errorf("Expected 1 activated and 1 dropped, got %d and %d", activated, dropped)
+	}
+
+	if len(server.Task(piano.ID).Labels) != 0 {
+		t.Errorf("Expected the activated task's only label removed, got %v", server.Task(piano.ID).Labels)
+	}
+	if server.Task(novel.ID).Status != "canceled" {
+		t.Errorf("Expected the dropped task canceled, got %q", server.Task(novel.ID).Status)
+	}
+	data, err := os.ReadFile(events)
+	if err != nil {
+		t.Fatalf("Expected the hooks to run: %v", err)
+	}
+	want := []string{hooks.PreTaskUpdate, hooks.PostTaskUpdate, hooks.PreTaskClose, hooks.PostTaskClose}
+	if got := strings.Fields(string(data)); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected the update hooks for the activated task and the close hooks for the dropped task, got %v", got)
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest a realistic set of tasks for a day",
+	Long: `Propose tasks for a day that fit its capacity.
+
+Tasks already scheduled for the day count first. The rest of the capacity
+is filled from open tasks: tasks due on or before the day are always
+included, then starred tasks, then tasks by priority and due date, as long
+as their estimates fit. Tasks scheduled for a later day are left alone.
+
+Estimates come from a task's estimate label, set with
+'todu task update <id> --estimate 1h30m'. Tasks without one count as
+suggest.default_estimate (default 1h). The day's capacity is
+suggest.capacity (default 6h) unless --capacity is given.
+
+Use --accept to schedule the suggested tasks for the day.
+
+Examples:
+  todu suggest
+  todu suggest --day today --capacity 4h
+  todu suggest --day 2024-06-03 --accept`,
+	Args: cobra.NoArgs,
+	RunE: runSuggest,
+}
+
+var (
+	// Suggest flags
+	suggestDay      string
+	suggestCapacity string
+	suggestAccept   bool
+	suggestYes      bool
+)
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+	suggestCmd.Flags().StringVar(&suggestDay, "day", "tomorrow", "Day to plan (today, tomorrow, next-week, +Nd, or YYYY-MM-DD)")
+	suggestCmd.Flags().StringVar(&suggestCapacity, "capacity", "", "Estimated work that fits in the day (default: suggest.capacity)")
+	suggestCmd.Flags().BoolVar(&suggestAccept, "accept", false, "Schedule the suggested tasks for the day")
+	addYesFlag(suggestCmd, &suggestYes)
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	dailyOpts, err := dailyReviewOptions(cfg, nil, nil)
+	if err != nil {
+		return err
+	}
+	opts, err := suggestOptions(cfg, suggestCapacity)
+	if err != nil {
+		return err
+	}
+
+	today := daystart.Today(dailyOpts.DayStart)
+	day, err := parseSuggestDay(suggestDay, time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return err
+	}
+	// The review treats the target date as a local day
+	targetDate := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	suggestion, projectNames, err := review.SuggestDay(ctx, apiClient, targetDate, dailyOpts, opts)
+	if err != nil {
+		return fmt.Errorf("failed to suggest tasks: %w", err)
+	}
+
+	if GetOutputFormat() == "json" {
+		if err := displaySuggestionJSON(suggestion, projectNames); err != nil {
+			return err
+		}
+	} else {
+		displaySuggestion(os.Stdout, suggestion, projectNames, opts.DefaultEstimate)
+	}
+
+	if !suggestAccept {
+		if len(suggestion.Suggested) > 0 && GetOutputFormat() != "json" {
+			fmt.Println("\nRun with --accept to schedule the suggested tasks.")
+		}
+		return nil
+	}
+	if len(suggestion.Suggested) == 0 {
+		return nil
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	var affected []string
+	var decisions []planDecision
+	for _, st := range suggestion.Suggested {
+		affected = append(affected, fmt.Sprintf("#%d %s", st.Task.ID, st.Task.Title))
+		decisions = append(decisions, planDecision{task: st.Task, action: planAccept})
+	}
+	question := fmt.Sprintf("Schedule %d tasks for %s?", len(decisions), day.Format("Mon 2006-01-02"))
+	ok, err := confirmAction(cfg, suggestYes, question, affected...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	accepted, _, err := applyPlan(ctx, apiClient, hookRunner, ruleEngine, decisions, day, day)
+	if GetOutputFormat() != "json" {
+		fmt.Printf("Scheduled %d tasks for %s\n", accepted, day.Format("Mon 2006-01-02"))
+	}
+	return err
+}
+
+// suggestOptions reads the suggestion capacity and default estimate from
+// the config, with capacity overriding the configured capacity if set.
+func suggestOptions(cfg *config.Config, capacity string) (review.SuggestOptions, error) {
+	if capacity == "" {
+		capacity = cfg.Suggest.Capacity
+	}
+	capacityDuration, err := types.ParseEstimate(capacity)
+	if err != nil {
+		return review.SuggestOptions{}, fmt.Errorf("invalid capacity: %w", err)
+	}
+	defaultEstimate, err := types.ParseEstimate(cfg.Suggest.DefaultEstimate)
+	if err != nil {
+		return review.SuggestOptions{}, fmt.Errorf("invalid suggest.default_estimate in config: %w", err)
+	}
+	return review.SuggestOptions{Capacity: capacityDuration, DefaultEstimate: defaultEstimate}, nil
+}
+
+// parseSuggestDay resolves --day against today, a UTC midnight. Besides
+// "today" it accepts what --snooze does in todu plan.
+func parseSuggestDay(value string, today time.Time) (time.Time, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "today") {
+		return today, nil
+	}
+	shift, err := parseDateShift(value, today)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --day value: %w", err)
+	}
+	return shift.apply(today), nil
+}
+
+// displaySuggestion prints the day's scheduled and suggested tasks and how
+// much of the capacity they use.
+func displaySuggestion(out io.Writer, s *review.Suggestion, projectNames map[int]string, defaultEstimate time.Duration) {
+	fmt.Fprintf(out, "Suggested plan for %s (capacity %s)\n", s.Date.Format("Mon 2006-01-02"), types.FormatEstimate(s.Capacity))
+
+	guessed := false
+	section := func(title string, tasks []review.SuggestedTask) {
+		fmt.Fprintln(out)
+		if len(tasks) == 0 {
+			fmt.Fprintf(out, "%s: none\n", title)
+			return
+		}
+		var total time.Duration
+		for _, st := range tasks {
+			total += st.Estimate
+		}
+		fmt.Fprintf(out, "%s (%s):\n", title, types.FormatEstimate(total))
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, st := range tasks {
+			estimate := types.FormatEstimate(st.Estimate)
+			if !st.Estimated {
+				estimate += "*"
+				guessed = true
+			}
+			fmt.Fprintf(w, "  #%d\t%s\t%s\t%s\t%s\n", st.Task.ID, estimate, st.Task.Title, projectNames[st.Task.ProjectID], st.Reason)
+		}
+		w.Flush()
+	}
+	section("Already scheduled", s.Scheduled)
+	section("Suggested", s.Suggested)
+
+	fmt.Fprintf(out, "\nTotal: %s of %s", types.FormatEstimate(s.Load()), types.FormatEstimate(s.Capacity))
+	if s.Load() > s.Capacity {
+		fmt.Fprintf(out, " (over by %s)", types.FormatEstimate(s.Load()-s.Capacity))
+	}
+	fmt.Fprintln(out)
+	if guessed {
+		fmt.Fprintf(out, "* no estimate, counted as %s\n", types.FormatEstimate(defaultEstimate))
+	}
+}
+
+// displaySuggestionJSON prints the suggestion as JSON, with estimates in
+// minutes.
+func displaySuggestionJSON(s *review.Suggestion, projectNames map[int]string) error {
+	tasks := func(list []review.SuggestedTask) []map[string]interface{} {
+		result := make([]map[string]interface{}, 0, len(list))
+		for _, st := range list {
+			result = append(result, map[string]interface{}{
+				"id":               st.Task.ID,
+				"title":            st.Task.Title,
+				"project":          projectNames[st.Task.ProjectID],
+				"estimate_minutes": int(st.Estimate / time.Minute),
+				"estimated":        st.Estimated,
+				"reason":           st.Reason,
+			})
+		}
+		return result
+	}
+
+	output := map[string]interface{}{
+		"date":             s.Date.Format("2006-01-02"),
+		"capacity_minutes": int(s.Capacity / time.Minute),
+		"total_minutes":    int(s.Load() / time.Minute),
+		"scheduled":        tasks(s.Scheduled),
+		"suggested":        tasks(s.Suggested),
+	}
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParseSuggestDay(t *testing.T) {
+	today := mustDate("2024-06-05") // Wednesday
+
+	tests := map[string]string{
+		"today":      "2024-06-05",
+		"tomorrow":   "2024-06-06",
+		"next-week":  "2024-06-10",
+		"+3d":        "2024-06-08",
+		"2024-07-01": "2024-07-01",
+	}
+	for value, want := range tests {
+		got, err := parseSuggestDay(value, today)
+		if err != nil {
+			t.Errorf("parseSuggestDay(%q) failed: %v", value, err)
+			continue
+		}
+		if got.Format("2006-01-02") != want {
+			t.Errorf("parseSuggestDay(%q) = %s, want %s", value, got.Format("2006-01-02"), want)
+		}
+	}
+
+	if _, err := parseSuggestDay("someday", today); err == nil {
+		t.Error("Expected error for invalid day")
+	}
+}
+
+func TestDisplaySuggestion(t *testing.T) {
+	s := &review.Suggestion{
+		Date:     time.Date(2024, 6, 6, 0, 0, 0, 0, time.Local),
+		Capacity: 2 * time.Hour,
+		Scheduled: []review.SuggestedTask{
+			{Task: &types.Task{ID: 1, Title: "Standup notes", ProjectID: 1}, Estimate: 30 * time.Minute, Estimated: true, Reason: "scheduled"},
+		},
+		Suggested: []review.SuggestedTask{
+			{Task: &types.Task{ID: 2, Title: "Fix login", ProjectID: 1}, Estimate: 2 * time.Hour, Reason: "overdue"},
+		},
+	}
+
+	var out bytes.Buffer
+	displaySuggestion(&out, s, map[int]string{1: "Work"}, 2*time.Hour)
+	got := out.String()
+
+	for _, want := range []string{
+		"Suggested plan for Thu 2024-06-06 (capacity 2h)",
+		"Already scheduled (30m):",
+		"#2  2h*  Fix login",
+		"Total: 2h30m of 2h (over by 30m)",
+		"* no estimate, counted as 2h",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -2,15 +2,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/plugincache"
 	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/rules"
 	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var syncCmd = &cobra.Command{
@@ -19,10 +28,63 @@ var syncCmd = &cobra.Command{
 	Long: `Synchronize tasks between todu and external task management systems.
 
 Supports bidirectional sync, allowing changes in external systems to be
-pulled into todu, and changes in todu to be pushed to external systems.`,
+pulled into todu, and changes in todu to be pushed to external systems.
+
+Use --report to emit the results as JSON or markdown for automation:
+  todu sync --report json
+  todu sync --report markdown --out sync-report.md
+
+Use --label, --status, and --updated-after to sync just a slice of a project:
+  todu sync --project big-repo --label release-blocker
+  todu sync --status active --updated-after 2025-06-01
+
+A filtered sync does not update the project's last sync time, so the next
+full sync still picks up every change.
+
+Every sync records the changes it makes to todu under a run ID. If a sync
+goes wrong, such as one run with a token for the wrong repository, undo
+its changes with 'todu sync rollback <run-id>' (see 'todu sync runs').
+
+The first sync of a project pulls every external task. If it is interrupted,
+the next sync resumes where it left off. On a terminal, large pulls show a
+progress bar with an estimated time remaining.
+
+Plugins cache slow-changing data such as label IDs for an hour. Use
+--refresh to refetch it:
+  todu sync --refresh`,
 	RunE: runSync,
 }
 
+var syncPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Preview sync changes and save them as a plan",
+	Long: `Compute what a sync would create, update, and close on each side, and
+save it as a plan file without making any changes.
+
+Review the plan, then run 'todu sync apply <plan>' to execute exactly those
+changes. Apply refuses to run if any external task changed in between.
+Comments are not part of plans; run a regular 'todu sync' for those.
+
+Accepts the same project, system, strategy, and task filters as 'todu sync'.
+
+Examples:
+  todu sync plan --project big-repo
+  todu sync plan --strategy push --out push-plan.json`,
+	RunE: runSyncPlan,
+}
+
+var syncApplyCmd = &cobra.Command{
+	Use:   "apply <plan>",
+	Short: "Apply a saved sync plan",
+	Long: `Execute exactly the changes in a plan file created by 'todu sync plan'.
+
+Every external task the plan depends on is re-fetched first. If any of them
+changed since the plan was created, nothing is applied; create a new plan
+and review it again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSyncApply,
+}
+
 var syncStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show sync status for projects",
@@ -40,90 +102,300 @@ var (
 	syncStrategy     string
 	syncDryRun       bool
 	syncForce        bool
+	syncReport       string
+	syncReportOut    string
+	syncLabels       []string
+	syncView         string
+	syncTaskStatus   string
+	syncUpdatedAfter string
+	syncRefresh      bool
 	syncStatusSystem string
+	syncPlanOut      string
 )
 
+// errSyncInterrupted is returned by a sync stopped with Ctrl-C after its
+// partial results are shown
+var errSyncInterrupted = errors.New("sync interrupted before it finished")
+
 func init() {
 	rootCmd.AddCommand(syncCmd)
 	syncCmd.AddCommand(syncStatusCmd)
+	syncCmd.AddCommand(syncPlanCmd)
+	syncCmd.AddCommand(syncApplyCmd)
 
 	// Sync flags
-	syncCmd.Flags().StringVarP(&syncProject, "project", "p", "", "Sync specific project by ID or name")
-	syncCmd.Flags().StringVarP(&syncSystem, "system", "s", "", "Sync all projects for a system (ID or name)")
-	syncCmd.Flags().BoolVarP(&syncAll, "all", "a", false, "Sync all projects (default if no filters)")
-	syncCmd.Flags().StringVar(&syncStrategy, "strategy", "", "Override sync strategy (pull/push/bidirectional)")
+	addSyncSelectionFlags(syncCmd)
 	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Preview changes without making them")
-	syncCmd.Flags().BoolVar(&syncForce, "force", false, "Force push all tasks, ignoring last_pushed_at (use to re-sync missing data)")
+	syncCmd.Flags().StringVar(&syncReport, "report", "", "Emit a sync report (json|markdown)")
+	syncCmd.Flags().StringVar(&syncReportOut, "out", "", "Write the sync report to a file instead of stdout")
+
+	// Sync plan flags
+	addSyncSelectionFlags(syncPlanCmd)
+	syncPlanCmd.Flags().StringVarP(&syncPlanOut, "out", "o", "sync-plan.json", "Plan file to write")
 
 	// Sync status flags
 	syncStatusCmd.Flags().StringVarP(&syncStatusSystem, "system", "s", "", "Filter by system ID or name")
 }
 
-func runSync(cmd *cobra.Command, args []string) error {
-	cfg, err := loadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if cfg.APIURL == "" {
-		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
-	}
+// addSyncSelectionFlags registers the flags that choose which projects and
+// tasks take part in a sync.
+func addSyncSelectionFlags(cmd *cobra.Command) {
+	addSyncScopeFlags(cmd)
+	cmd.Flags().StringVar(&syncStrategy, "strategy", "", "Override sync strategy (pull/push/bidirectional)")
+	cmd.Flags().BoolVar(&syncForce, "force", false, "Force push all tasks, ignoring last_pushed_at (use to re-sync missing data)")
+}
 
-	// Create API client
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+// addSyncScopeFlags registers the project and task filters shared by sync
+// and the read-only sync commands.
+func addSyncScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&syncProject, "project", "p", "", "Sync specific project by ID or name")
+	cmd.Flags().StringVarP(&syncSystem, "system", "s", "", "Sync all projects for a system (ID or name)")
+	cmd.Flags().BoolVarP(&syncAll, "all", "a", false, "Sync all projects (default if no filters)")
+	cmd.Flags().StringSliceVar(&syncLabels, "label", []string{}, "Only sync tasks with this label (repeatable)")
+	cmd.Flags().StringVar(&syncTaskStatus, "status", "", "Only sync tasks with this status")
+	cmd.Flags().StringVar(&syncUpdatedAfter, "updated-after", "", "Only sync tasks updated after date (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&syncRefresh, "refresh", false, "Refetch cached plugin data such as label IDs")
+	cmd.Flags().StringVar(&syncView, "view", "", "Only sync a saved search's projects and tasks (see 'todu view list')")
+	cmd.MarkFlagsMutuallyExclusive("view", "project")
+	cmd.MarkFlagsMutuallyExclusive("view", "system")
+}
 
-	// Create sync engine
+// newSyncEngine creates a sync engine with the default snapshot and run
+// stores.
+func newSyncEngine(apiClient *api.Client) *sync.Engine {
 	engine := sync.NewEngine(apiClient, registry.Default)
+	if snapshotDir, err := sync.DefaultSnapshotDir(); err == nil {
+		engine.WithSnapshots(sync.NewFileSnapshotStore(snapshotDir))
+	}
+	if runDir, err := sync.DefaultRunDir(); err == nil {
+		engine.WithRuns(sync.NewRunStore(runDir))
+	}
+	return engine
+}
 
-	// Build sync options
+// buildSyncOptions builds sync options from the selection flags.
+func buildSyncOptions(ctx context.Context, apiClient *api.Client) (sync.Options, error) {
 	options := sync.Options{
 		DryRun: syncDryRun,
 		Force:  syncForce,
+		Filter: sync.TaskFilter{
+			Labels: syncLabels,
+			Status: syncTaskStatus,
+		},
+	}
+
+	if syncUpdatedAfter != "" {
+		updatedAfter, err := time.ParseInLocation("2006-01-02", syncUpdatedAfter, time.Local)
+		if err != nil {
+			return options, fmt.Errorf("invalid --updated-after date format, use YYYY-MM-DD: %w", err)
+		}
+		options.Filter.UpdatedAfter = &updatedAfter
 	}
 
 	// Handle strategy override
 	if syncStrategy != "" {
 		strategy := sync.Strategy(syncStrategy)
 		if !strategy.IsValid() {
-			return fmt.Errorf("invalid strategy %q. Must be: pull, push, or bidirectional", syncStrategy)
+			return options, fmt.Errorf("invalid strategy %q. Must be: pull, push, or bidirectional", syncStrategy)
 		}
 		options.StrategyOverride = &strategy
 	}
 
+	// A view chooses the projects and adds its task filters
+	if syncView != "" {
+		if err := applySyncView(ctx, apiClient, &options); err != nil {
+			return options, err
+		}
+		return options, nil
+	}
+
 	// Handle project/system filters
 	if syncProject != "" {
 		// Resolve project ID from name or ID
 		projectID, err := resolveProjectID(ctx, apiClient, syncProject)
 		if err != nil {
-			return fmt.Errorf("failed to resolve project: %w", err)
+			return options, fmt.Errorf("failed to resolve project: %w", err)
 		}
 		options.ProjectIDs = []int{projectID}
 	} else if syncSystem != "" {
 		systemID, err := resolveSystemID(apiClient, syncSystem)
 		if err != nil {
-			return err
+			return options, err
 		}
 		options.SystemID = &systemID
 	}
 	// If neither project nor system is specified, sync all (default behavior)
 
+	return options, nil
+}
+
+// applySyncView narrows sync options to a view: its project, area, or
+// system, and the tasks its filters match.
+func applySyncView(ctx context.Context, apiClient *api.Client, options *sync.Options) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	view, ok := cfg.View(syncView)
+	if !ok {
+		return fmt.Errorf("unknown view %q (see 'todu view list')", syncView)
+	}
+	if view.Search != "" {
+		return fmt.Errorf("view %s uses a full-text search, which sync can't filter on", syncView)
+	}
+
+	switch {
+	case view.Project != "":
+		projectID, err := resolveProjectID(ctx, apiClient, view.Project)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+		options.ProjectIDs = []int{projectID}
+	case view.Area != "":
+		ids, err := resolveAreaProjectIDs(ctx, apiClient, cfg, view.Area)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("area %s has no projects to sync", view.Area)
+		}
+		for id := range ids {
+			options.ProjectIDs = append(options.ProjectIDs, id)
+		}
+		slices.Sort(options.ProjectIDs)
+	case view.System != "":
+		systemID, err := resolveSystemID(apiClient, view.System)
+		if err != nil {
+			return err
+		}
+		options.SystemID = &systemID
+	}
+
+	if options.Filter.Status == "" {
+		options.Filter.Status = view.Status
+	}
+	options.Filter.Priority = view.Priority
+	options.Filter.Labels = append(options.Filter.Labels, view.Labels...)
+	if view.Starred {
+		options.Filter.Labels = append(options.Filter.Labels, types.StarredLabel)
+	}
+	for _, assignment := range view.Fields {
+		key, value, err := types.ParseField(assignment)
+		if err != nil {
+			return err
+		}
+		options.Filter.Labels = append(options.Filter.Labels, types.FieldLabel(key, value))
+	}
+	options.Filter.Assignee = view.Assignee
+	if view.Mine {
+		options.Filter.Assignee = currentUser(cfg)
+		if options.Filter.Assignee == "" {
+			return fmt.Errorf("no identity for view %s; set author in the config or use an API key whose user the server reports", syncView)
+		}
+	}
+	return nil
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	plugincache.SetRefresh(syncRefresh)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	if syncReport != "" && syncReport != "json" && syncReport != "markdown" {
+		return fmt.Errorf("invalid report format %q. Must be: json or markdown", syncReport)
+	}
+	if syncReportOut != "" && syncReport == "" {
+		return fmt.Errorf("--out requires --report")
+	}
+	// A report written to stdout replaces the normal text output
+	reportToStdout := syncReport != "" && syncReportOut == ""
+
+	// Create API client
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	// A dry run only reads, so it works with a read-only key
+	if !syncDryRun {
+		if err := requireWritable(ctx, apiClient, "sync"); err != nil {
+			return err
+		}
+	}
+
+	// Create sync engine
+	engine := newSyncEngine(apiClient)
+
+	// Build sync options
+	options, err := buildSyncOptions(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+	options.CommentUpdateProjectIDs = cfg.Sync.CommentUpdates
+	options.DuplicateMatch = sync.DuplicateMatch(cfg.Sync.Duplicates)
+	if !options.DuplicateMatch.IsValid() {
+		return fmt.Errorf("invalid sync.duplicates %q. Must be: url, title, or off", cfg.Sync.Duplicates)
+	}
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		options.Progress = newSyncProgressBar(os.Stderr)
+	}
+
 	// Display dry run notice
-	if syncDryRun {
+	if syncDryRun && !reportToStdout {
 		fmt.Println("=== DRY RUN MODE ===")
 		fmt.Println("No changes will be made")
 		fmt.Println()
 	}
 
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+	if err := hookRunner.Run(ctx, hooks.PreSync, map[string]any{"dry_run": syncDryRun, "project_ids": options.ProjectIDs}); err != nil {
+		return err
+	}
+
 	// Run sync
+	started := time.Now()
 	result, err := engine.Sync(ctx, options)
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
 
+	// After an interruption, rules and hooks still run for what was synced
+	ctx = context.WithoutCancel(ctx)
+	if !syncDryRun {
+		applySyncRules(ctx, apiClient, ruleEngine, started, !reportToStdout)
+	}
+
+	runPostHook(ctx, hookRunner, hooks.PostSync, sync.NewReport(result, syncDryRun))
+
 	// Display results
-	fmt.Println()
-	displaySyncResults(result, syncDryRun)
+	if syncReport != "" {
+		if err := writeSyncReport(result, syncDryRun, syncReport, syncReportOut); err != nil {
+			return err
+		}
+	}
+	if !reportToStdout {
+		fmt.Println()
+		displaySyncResults(result, syncDryRun)
+		if syncReportOut != "" {
+			fmt.Printf("Report written to: %s\n", syncReportOut)
+		}
+	}
+
+	if result.Interrupted {
+		return errSyncInterrupted
+	}
 
 	// Exit with error code if there were errors
 	if result.HasErrors() {
@@ -133,6 +405,176 @@ func runSync(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSyncPlan(cmd *cobra.Command, args []string) error {
+	plugincache.SetRefresh(syncRefresh)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	options, err := buildSyncOptions(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+
+	plan, err := newSyncEngine(apiClient).Plan(ctx, options)
+	if err != nil {
+		return fmt.Errorf("failed to create sync plan: %w", err)
+	}
+
+	if err := plan.Save(syncPlanOut); err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displaySyncPlan(plan)
+	if plan.ActionCount() > 0 {
+		fmt.Printf("\nPlan written to: %s\n", syncPlanOut)
+		fmt.Printf("Run 'todu sync apply %s' to apply these changes.\n", syncPlanOut)
+	}
+	return nil
+}
+
+func runSyncApply(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	plan, err := sync.LoadPlan(args[0])
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	if err := requireWritable(ctx, apiClient, "sync apply"); err != nil {
+		return err
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+	if err := hookRunner.Run(ctx, hooks.PreSync, map[string]any{"dry_run": false, "plan": args[0]}); err != nil {
+		return err
+	}
+
+	started := time.Now()
+	result, err := newSyncEngine(apiClient).Apply(ctx, plan)
+	if err != nil {
+		if errors.Is(err, sync.ErrPlanDrifted) {
+			return fmt.Errorf("%w\nRun 'todu sync plan' again and review the new plan", err)
+		}
+		return fmt.Errorf("failed to apply sync plan: %w", err)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	applySyncRules(ctx, apiClient, ruleEngine, started, true)
+	runPostHook(ctx, hookRunner, hooks.PostSync, sync.NewReport(result, false))
+
+	displaySyncResults(result, false)
+
+	if result.Interrupted {
+		return errSyncInterrupted
+	}
+
+	if result.HasErrors() {
+		return fmt.Errorf("apply completed with %d error(s)", result.TotalErrors)
+	}
+
+	return nil
+}
+
+// applySyncRules applies sync rules to the tasks a sync run touched. The
+// sync has already finished, so failures are reported as warnings.
+func applySyncRules(ctx context.Context, apiClient *api.Client, engine *rules.Engine, started time.Time, verbose bool) {
+	outcomes, err := engine.ApplyUpdatedSince(ctx, apiClient, started)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to apply rules: %v\n", err)
+	}
+	if verbose && len(outcomes) > 0 {
+		fmt.Printf("Rules applied to %d task(s)\n", len(outcomes))
+	}
+}
+
+// displaySyncPlan prints the actions in a sync plan as a table.
+func displaySyncPlan(plan *sync.Plan) {
+	if plan.ActionCount() == 0 {
+		fmt.Println("No changes. Everything is in sync.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tACTION\tTASK\tCHANGES")
+	fmt.Fprintln(w, "-------\t------\t----\t-------")
+
+	for _, pp := range plan.Projects {
+		for _, action := range pp.Actions {
+			fields := make([]string, len(action.Changes))
+			for i, change := range action.Changes {
+				fields[i] = change.Field
+			}
+			changes := strings.Join(fields, ", ")
+			if changes == "" {
+				changes = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				pp.ProjectName,
+				syncActionLabel(action.Type),
+				action.Title,
+				changes,
+			)
+		}
+	}
+
+	w.Flush()
+	fmt.Printf("\nPlan: %d change(s)\n", plan.ActionCount())
+}
+
+// syncActionLabel returns a human-readable label for a plan action type.
+func syncActionLabel(actionType sync.ActionType) string {
+	switch actionType {
+	case sync.ActionCreateLocal:
+		return "create in todu"
+	case sync.ActionUpdateLocal:
+		return "update in todu"
+	case sync.ActionCreateRemote:
+		return "create external"
+	case sync.ActionUpdateRemote:
+		return "update external"
+	case sync.ActionCloseRemote:
+		return "close external"
+	default:
+		return string(actionType)
+	}
+}
+
 func runSyncStatus(cmd *cobra.Command, args []string) error {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -147,7 +589,7 @@ func runSyncStatus(cmd *cobra.Command, args []string) error {
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
 
 	// Get projects
-	ctx := context.Background()
+	ctx := commandContext()
 	opts := &api.ProjectListOptions{}
 	if syncStatusSystem != "" {
 		systemID, err := resolveSystemID(apiClient, syncStatusSystem)
@@ -219,23 +661,36 @@ func displaySyncResults(result *sync.Result, dryRun bool) {
 	// Display per-project results
 	if len(result.ProjectResults) > 0 {
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "PROJECT\tCREATED\tUPDATED\tSKIPPED\tERRORS")
-		fmt.Fprintln(w, "-------\t-------\t-------\t-------\t------")
+		fmt.Fprintln(w, "PROJECT\tCREATED\tUPDATED\tSKIPPED\tERRORS\tDURATION")
+		fmt.Fprintln(w, "-------\t-------\t-------\t-------\t------\t--------")
 
 		for _, pr := range result.ProjectResults {
 			errCount := len(pr.Errors)
-			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n",
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n",
 				pr.ProjectName,
 				pr.Created,
 				pr.Updated,
 				pr.Skipped,
 				errCount,
+				pr.Duration.Round(time.Millisecond),
 			)
 
-			// Show errors for this project
+			if pr.Resumed > 0 {
+				fmt.Fprintf(w, "  └─ Resumed interrupted first sync: %d task(s) already pulled\n", pr.Resumed)
+			}
+
+			// Show the top errors for this project
 			if errCount > 0 {
-				for _, err := range pr.Errors {
-					fmt.Fprintf(w, "  └─ Error: %v\n", err)
+				messages := make([]string, errCount)
+				for i, err := range pr.Errors {
+					messages[i] = err.Error()
+				}
+				shown, remaining := sync.TopErrors(messages)
+				for _, msg := range shown {
+					fmt.Fprintf(w, "  └─ Error: %s\n", msg)
+				}
+				if remaining > 0 {
+					fmt.Fprintf(w, "  └─ ...and %d more error(s) (use --report json for all)\n", remaining)
 				}
 			}
 		}
@@ -251,6 +706,10 @@ func displaySyncResults(result *sync.Result, dryRun bool) {
 		result.TotalSkipped,
 	)
 
+	if result.TotalLinked > 0 {
+		fmt.Printf(", %d linked to existing tasks", result.TotalLinked)
+	}
+
 	if result.TotalErrors > 0 {
 		fmt.Printf(", %d errors", result.TotalErrors)
 	}
@@ -261,6 +720,41 @@ func displaySyncResults(result *sync.Result, dryRun bool) {
 	if dryRun {
 		fmt.Println("\nNo changes were made (dry run)")
 	}
+	if result.Interrupted {
+		fmt.Println("\nInterrupted: the results above are what was synced before stopping.")
+		fmt.Println("Run the sync again to finish; projects not listed were not synced.")
+	}
+	if result.RunID != "" {
+		fmt.Printf("Run ID: %s (undo with 'todu sync rollback %s')\n", result.RunID, result.RunID)
+	}
+}
+
+// writeSyncReport renders a sync report in the given format and writes it to
+// outPath, or to stdout if outPath is empty.
+func writeSyncReport(result *sync.Result, dryRun bool, format, outPath string) error {
+	report := sync.NewReport(result, dryRun)
+
+	var data []byte
+	switch format {
+	case "json":
+		jsonData, err := report.JSON()
+		if err != nil {
+			return err
+		}
+		data = append(jsonData, '\n')
+	case "markdown":
+		data = []byte(report.Markdown())
+	}
+
+	if outPath == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync report: %w", err)
+	}
+	return nil
 }
 
 func formatDuration(d time.Duration) string {
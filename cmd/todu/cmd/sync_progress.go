@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/sync"
+)
+
+const (
+	// progressMinTasks is the smallest pull that shows a progress bar, so
+	// routine incremental syncs stay quiet.
+	progressMinTasks = 50
+
+	// progressBarWidth is the number of cells in the progress bar.
+	progressBarWidth = 30
+
+	// progressRedrawInterval limits how often the progress bar is redrawn.
+	progressRedrawInterval = 100 * time.Millisecond
+)
+
+// syncProgressBar draws pull progress on a single terminal line.
+type syncProgressBar struct {
+	w        io.Writer
+	lastDraw time.Time
+}
+
+// newSyncProgressBar returns an Options.Progress callback that draws a
+// progress bar with an ETA to w for pulls of at least progressMinTasks tasks.
+func newSyncProgressBar(w io.Writer) func(sync.Progress) {
+	bar := &syncProgressBar{w: w}
+	return bar.update
+}
+
+func (b *syncProgressBar) update(p sync.Progress) {
+	if p.Total < progressMinTasks {
+		return
+	}
+
+	finished := p.Done >= p.Total
+	if !finished && time.Since(b.lastDraw) < progressRedrawInterval {
+		return
+	}
+	b.lastDraw = time.Now()
+
+	fmt.Fprintf(b.w, "\r\033[K%s", formatSyncProgress(p))
+	if finished {
+		// Clear the bar so the results start on a clean line
+		fmt.Fprint(b.w, "\r\033[K")
+	}
+}
+
+// formatSyncProgress renders a progress line, e.g.
+// "big-repo [=========>          ] 1500/5000  30%  ETA 4m10s".
+func formatSyncProgress(p sync.Progress) string {
+	filled := progressBarWidth * p.Done / p.Total
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	line := fmt.Sprintf("%s [%s] %d/%d %3d%%", p.ProjectName, bar, p.Done, p.Total, 100*p.Done/p.Total)
+	if eta := p.ETA(); eta > 0 {
+		line += "  ETA " + eta.Round(time.Second).String()
+	}
+	return line
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/sync"
+)
+
+func TestFormatSyncProgress(t *testing.T) {
+	p := sync.Progress{ProjectName: "big-repo", Done: 1500, Total: 5000, Started: time.Now().Add(-time.Minute)}
+	line := formatSyncProgress(p)
+
+	if !strings.HasPrefix(line, "big-repo [=========>") {
+		t.Errorf("Expected bar 30%% filled, got %q", line)
+	}
+	if !strings.Contains(line, "1500/5000  30%") {
+		t.Errorf("Expected counts and percentage, got %q", line)
+	}
+	if !strings.Contains(line, "ETA 2m20s") {
+		t.Errorf("Expected ETA, got %q", line)
+	}
+}
+
+func TestSyncProgressBarSkipsSmallPulls(t *testing.T) {
+	var buf bytes.Buffer
+	update := newSyncProgressBar(&buf)
+
+	update(sync.Progress{ProjectName: "small", Done: 1, Total: 3, Started: time.Now()})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no progress for a small pull, got %q", buf.String())
+	}
+
+	update(sync.Progress{ProjectName: "big", Done: 100, Total: 100, Started: time.Now()})
+	if !strings.HasSuffix(buf.String(), "\r\033[K") {
+		t.Errorf("Expected the finished bar cleared, got %q", buf.String())
+	}
+}
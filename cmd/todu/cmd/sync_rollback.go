@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var syncRunsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List recorded sync runs",
+	Long: `List the sync runs that changed todu, newest first, with the number of
+tasks and comments each created and updated. The last 100 runs are kept.
+
+Roll a run back with 'todu sync rollback <run-id>'.`,
+	Args: cobra.NoArgs,
+	RunE: runSyncRuns,
+}
+
+var syncRollbackCmd = &cobra.Command{
+	Use:   "rollback <run-id>",
+	Short: "Undo the changes a sync run made to todu",
+	Long: `Undo the changes a sync run made to todu, such as after a sync with a
+token pointed at the wrong repository. Tasks and comments the run created
+are deleted, and those it updated are put back as they were before it.
+
+Tasks and comments changed again since the sync are skipped, so later work
+isn't lost; use --force to roll them back anyway. Fields a task didn't have
+before the sync, such as a due date the sync added, can't be cleared and
+are left as they are. Changes the sync pushed to external systems are not
+undone.
+
+Examples:
+  todu sync runs
+  todu sync rollback 20250610-091500-3fa2 --dry-run
+  todu sync rollback 20250610-091500-3fa2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSyncRollback,
+}
+
+var (
+	// Rollback flags
+	syncRollbackDryRun bool
+	syncRollbackForce  bool
+	syncRollbackYes    bool
+)
+
+func init() {
+	syncCmd.AddCommand(syncRunsCmd)
+	syncCmd.AddCommand(syncRollbackCmd)
+
+	syncRollbackCmd.Flags().BoolVar(&syncRollbackDryRun, "dry-run", false, "Show what would be rolled back without changing anything")
+	syncRollbackCmd.Flags().BoolVar(&syncRollbackForce, "force", false, "Roll back tasks and comments changed since the sync")
+	syncRollbackCmd.Flags().BoolVarP(&syncRollbackYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+// newRunStore returns the default sync run store.
+func newRunStore() (*sync.RunStore, error) {
+	dir, err := sync.DefaultRunDir()
+	if err != nil {
+		return nil, err
+	}
+	return sync.NewRunStore(dir), nil
+}
+
+func runSyncRuns(cmd *cobra.Command, args []string) error {
+	store, err := newRunStore()
+	if err != nil {
+		return err
+	}
+	runs, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("No sync runs recorded")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN ID\tSTARTED\tTASKS CREATED\tTASKS UPDATED\tCOMMENTS\tROLLED BACK")
+	for _, run := range runs {
+		created, updated := 0, 0
+		for _, change := range run.Tasks {
+			if change.Created {
+				created++
+			} else {
+				updated++
+			}
+		}
+		rolledBack := ""
+		if run.RolledBackAt != nil {
+			rolledBack = run.RolledBackAt.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n",
+			run.ID, run.StartedAt.Local().Format("2006-01-02 15:04"), created, updated, len(run.Comments), rolledBack)
+	}
+	return w.Flush()
+}
+
+func runSyncRollback(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	store, err := newRunStore()
+	if err != nil {
+		return err
+	}
+	run, err := store.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	if !syncRollbackDryRun {
+		if err := requireWritable(ctx, apiClient, "sync rollback"); err != nil {
+			return err
+		}
+		question := fmt.Sprintf("Roll back %d task and %d comment change(s) from sync run %s?", len(run.Tasks), len(run.Comments), run.ID)
+		ok, err := confirmAction(cfg, syncRollbackYes, question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	result, err := sync.Rollback(ctx, apiClient, store, run, sync.RollbackOptions{
+		DryRun: syncRollbackDryRun,
+		Force:  syncRollbackForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		displayRollback(result, syncRollbackDryRun)
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d change(s) could not be rolled back", len(result.Failed))
+	}
+	return nil
+}
+
+// displayRollback prints what a rollback did, or would do on a dry run.
+func displayRollback(result *sync.RollbackResult, dryRun bool) {
+	restored, deleted := "Restored", "Deleted"
+	if dryRun {
+		restored, deleted = "Would restore", "Would delete"
+	}
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{restored, result.Restored},
+		{deleted, result.Deleted},
+		{"Skipped", result.Skipped},
+		{"Failed", result.Failed},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", section.title)
+		for _, item := range section.items {
+			fmt.Printf("  %s\n", item)
+		}
+	}
+	fmt.Printf("\n%d restored, %d deleted, %d skipped, %d failed\n",
+		len(result.Restored), len(result.Deleted), len(result.Skipped), len(result.Failed))
+}
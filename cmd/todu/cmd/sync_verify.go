@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/plugincache"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var syncVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check synced tasks against their external counterparts",
+	Long: `Compare every synced and linked task with its external counterpart and
+report the ones that differ, without changing anything.
+
+Use it after an API outage, a failed sync, or manual edits on either side to
+find tasks that sync will not reconcile on its own. Each divergent task shows
+the fields that differ and, when a sync snapshot exists, which side changed:
+
+  local-ahead    changed in todu; the next push updates the external task
+  remote-ahead   changed externally; the next pull updates the todu task
+  conflict       changed on both sides
+  unknown        no snapshot to tell which side changed
+
+Tasks whose external task no longer exists are reported as missing.
+Run 'todu sync --force' to push todu's version of every task.
+
+Exits with an error if any task diverges, so it can be used in scripts.
+
+Examples:
+  todu sync verify
+  todu sync verify --project big-repo
+  todu sync verify --system github --format json`,
+	RunE: runSyncVerify,
+}
+
+func init() {
+	syncCmd.AddCommand(syncVerifyCmd)
+	addSyncScopeFlags(syncVerifyCmd)
+}
+
+func runSyncVerify(cmd *cobra.Command, args []string) error {
+	plugincache.SetRefresh(syncRefresh)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured. Run 'todu config show' to see configuration")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	options, err := buildSyncOptions(ctx, apiClient)
+	if err != nil {
+		return err
+	}
+
+	verification, err := newSyncEngine(apiClient).Verify(ctx, options)
+	if err != nil {
+		return fmt.Errorf("verify failed: %w", err)
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(verification); err != nil {
+			return err
+		}
+	} else {
+		displayVerification(verification)
+	}
+
+	if count := verification.DivergenceCount(); count > 0 {
+		return fmt.Errorf("%d task(s) out of sync", count)
+	}
+	if count := verification.ErrorCount(); count > 0 {
+		return fmt.Errorf("verify completed with %d error(s)", count)
+	}
+
+	return nil
+}
+
+// displayVerification prints divergent tasks as a table followed by any
+// errors and a summary.
+func displayVerification(v *sync.Verification) {
+	checked := 0
+	for _, pv := range v.Projects {
+		checked += pv.Checked
+	}
+
+	if v.DivergenceCount() > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROJECT\tTASK\tEXTERNAL ID\tSTATE\tDIFFERS")
+		fmt.Fprintln(w, "-------\t----\t-----------\t-----\t-------")
+
+		for _, pv := range v.Projects {
+			for _, d := range pv.Divergences {
+				externalID := d.ExternalID
+				if d.Linked {
+					externalID += " (linked)"
+				}
+				fmt.Fprintf(w, "%s\t#%d %s\t%s\t%s\t%s\n",
+					pv.ProjectName,
+					d.TaskID,
+					truncate(d.Title, 40),
+					externalID,
+					divergenceState(d),
+					divergenceFields(d),
+				)
+			}
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	for _, pv := range v.Projects {
+		for _, msg := range pv.Errors {
+			fmt.Printf("Error: %s: %s\n", pv.ProjectName, msg)
+		}
+	}
+
+	fmt.Printf("Checked %d task(s) in %d project(s): %d out of sync\n", checked, len(v.Projects), v.DivergenceCount())
+}
+
+// divergenceState returns the STATE column value for a divergent task.
+func divergenceState(d sync.Divergence) string {
+	if d.Kind == sync.DivergenceMissing {
+		return "missing"
+	}
+	return string(d.State)
+}
+
+// divergenceFields returns the names of the fields that differ.
+func divergenceFields(d sync.Divergence) string {
+	if len(d.Changes) == 0 {
+		return "-"
+	}
+	fields := make([]string, len(d.Changes))
+	for i, change := range d.Changes {
+		fields[i] = change.Field
+	}
+	return strings.Join(fields, ", ")
+}
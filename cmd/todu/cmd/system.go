@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -80,7 +79,7 @@ var (
 	systemAddName       string
 	systemAddURL        string
 	systemAddMetadata   []string
-	systemRemoveForce   bool
+	systemRemoveYes     bool
 )
 
 func init() {
@@ -100,7 +99,8 @@ func init() {
 	_ = systemAddCmd.MarkFlagRequired("name")
 
 	// system remove flags
-	systemRemoveCmd.Flags().BoolVar(&systemRemoveForce, "force", false, "Skip confirmation prompt")
+	addYesFlag(systemRemoveCmd, &systemRemoveYes)
+	addForceAlias(systemRemoveCmd, &systemRemoveYes, "")
 }
 
 func runSystemList(cmd *cobra.Command, args []string) error {
@@ -110,19 +110,20 @@ func runSystemList(cmd *cobra.Command, args []string) error {
 	}
 
 	client := api.NewClient(cfg.APIURL, cfg.APIKey)
-	systems, err := client.ListSystems(context.Background())
+	systems, err := client.ListSystems(commandContext())
 	if err != nil {
 		return fmt.Errorf("failed to list systems: %w", err)
 	}
 
+	out := cmd.OutOrStdout()
 	if GetOutputFormat() == "json" {
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(systems)
 	}
 
 	// Table output
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ID\tIDENTIFIER\tNAME\tURL\tCONFIGURED")
 
 	for _, system := range systems {
@@ -201,7 +202,7 @@ func runSystemAdd(cmd *cobra.Command, args []string) error {
 		Metadata:   metadata,
 	}
 
-	system, err := client.CreateSystem(context.Background(), systemCreate)
+	system, err := client.CreateSystem(commandContext(), systemCreate)
 	if err != nil {
 		return fmt.Errorf("failed to create system: %w", err)
 	}
@@ -227,14 +228,14 @@ func runSystemShow(cmd *cobra.Command, args []string) error {
 	var system *types.System
 	var numID int
 	if _, err := fmt.Sscanf(args[0], "%d", &numID); err == nil {
-		system, err = client.GetSystem(context.Background(), numID)
+		system, err = client.GetSystem(commandContext(), numID)
 		if err != nil {
 			return fmt.Errorf("failed to get system: %w", err)
 		}
 	} else {
 		// Treat as identifier - search through all systems
 		identifier := args[0]
-		systems, err := client.ListSystems(context.Background())
+		systems, err := client.ListSystems(commandContext())
 		if err != nil {
 			return fmt.Errorf("failed to list systems: %w", err)
 		}
@@ -354,14 +355,14 @@ func runSystemRemove(cmd *cobra.Command, args []string) error {
 	var id int
 	if _, err := fmt.Sscanf(args[0], "%d", &id); err == nil {
 		// It's a numeric ID
-		system, err = client.GetSystem(context.Background(), id)
+		system, err = client.GetSystem(commandContext(), id)
 		if err != nil {
 			return fmt.Errorf("failed to get system: %w", err)
 		}
 	} else {
 		// Treat as identifier - search through all systems
 		identifier := args[0]
-		systems, err := client.ListSystems(context.Background())
+		systems, err := client.ListSystems(commandContext())
 		if err != nil {
 			return fmt.Errorf("failed to list systems: %w", err)
 		}
@@ -379,20 +380,19 @@ func runSystemRemove(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Confirm deletion unless --force
-	if !systemRemoveForce {
-		fmt.Printf("Are you sure you want to remove system %d (%s)? [y/N]: ", id, system.Name)
-		var response string
-		_, _ = fmt.Scanln(&response)
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Cancelled")
-			return nil
-		}
+	// Confirm deletion unless --yes
+	affected := fmt.Sprintf("System %d: %s (%s)", id, system.Name, system.Identifier)
+	confirmed, err := confirmAction(cfg, systemRemoveYes, fmt.Sprintf("Remove system %d?", id), affected)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Cancelled")
+		return nil
 	}
 
 	// Delete system
-	err = client.DeleteSystem(context.Background(), id)
+	err = client.DeleteSystem(commandContext(), id)
 	if err != nil {
 		// Check if it's because of associated projects
 		if strings.Contains(err.Error(), "projects") || strings.Contains(err.Error(), "associated") {
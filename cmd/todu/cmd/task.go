@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,6 +14,11 @@ import (
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/repoconfig"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/internal/sync"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -31,16 +38,29 @@ var taskListCmd = &cobra.Command{
 	Long: `List tasks from todu with optional filtering.
 
 Displays tasks in a table format with key information. Use filters to
-narrow down results to specific projects, statuses, or other criteria.`,
+narrow down results to specific projects, statuses, or other criteria.
+
+Use --watch to redraw the list on an interval, e.g. as a live dashboard:
+  todu task list --status inprogress --watch=30s
+
+Use -i to triage in batches: toggle tasks by their list numbers (e.g.
+"1 3 5-7"), then close, delete, label, move, or set the due date of all
+selected tasks at once:
+  todu task list -i --project inbox`,
 	RunE: runTaskList,
 }
 
 var taskShowCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show task details",
-	Long:  `Display detailed information about a specific task including comments.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTaskShow,
+	Long: `Display detailed information about a specific task including comments.
+
+The sync state compares the task with the snapshot taken at its last sync:
+in-sync, local-ahead (edited in todu), remote-ahead (edited externally), or
+conflict (edited on both sides). External edits are only detected with
+--remote, which fetches the task from its external system.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskShow,
 }
 
 var taskCreateCmd = &cobra.Command{
@@ -50,7 +70,15 @@ var taskCreateCmd = &cobra.Command{
 
 Tasks must belong to a project. Use --project to specify which project
 the task belongs to, or configure defaults.project in your config file
-to use a default project when --project is not specified.`,
+to use a default project when --project is not specified.
+
+--remind sets reminders at offsets before the due date, such as 1d,1h.
+"todu notify" and the daemon send them. They are kept as remind:<offset>
+labels.
+
+--parent and --depends-on record the tasks this one depends on, kept as
+parent:<id> and depends-on:<id> labels. Sync creates parents and blockers
+in the external system first and refers to them by their external numbers.`,
 	RunE: runTaskCreate,
 }
 
@@ -100,6 +128,25 @@ comments to both tasks, and cancels the original task.`,
 	RunE: runTaskMove,
 }
 
+var taskDiffCmd = &cobra.Command{
+	Use:   "diff <id>",
+	Short: "Show differences between a task and its external copy",
+	Long: `Show field differences between a task in todu and its copy in the
+external system it is synced with.
+
+Compares title, status, priority, due date, labels, assignees, and
+description. Description changes are shown as a unified diff.
+
+Use --against-snapshot to compare against the task as it was at the last
+successful sync instead, showing what changed locally since then.
+
+Example:
+  todu task diff 42 --against-remote
+  todu task diff 42 --against-snapshot`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskDiff,
+}
+
 var (
 	// List flags
 	taskListStatus          string
@@ -109,6 +156,7 @@ var (
 	taskListProjectStatus   string
 	taskListProjectPriority string
 	taskListAssignee        string
+	taskListMine            bool
 	taskListLabels          []string
 	taskListSearch          string
 	taskListDueBefore       string
@@ -117,7 +165,24 @@ var (
 	taskListUpdatedAfter    string
 	taskListTemplateID      int
 	taskListScheduledDate   string
+	taskListScheduledBefore string
+	taskListScheduledAfter  string
+	taskListShowScheduled   bool
+	taskListColumns         []string
+	taskListWide            bool
+	taskListCompact         bool
+	taskListWatch           string
 	taskListLimit           int
+	taskListNoContext       bool
+	taskListInteractive     bool
+	taskListStarred         bool
+	taskListSomeday         bool
+	taskListArea            string
+	taskListFields          []string
+	taskListView            string
+
+	// taskListRepoContext is the .todu.yaml scoping task list, if any
+	taskListRepoContext *repoconfig.File
 
 	// Create flags
 	taskCreateTitle         string
@@ -128,9 +193,15 @@ var (
 	taskCreateDue           string
 	taskCreateLabels        []string
 	taskCreateAssignees     []string
+	taskCreateEstimate      string
+	taskCreateRemind        string
+	taskCreateParent        int
+	taskCreateDependsOn     string
+	taskCreateFields        []string
 	taskCreateExternalID    string
 	taskCreateTemplate      int
 	taskCreateScheduledDate string
+	taskCreateNoContext     bool
 
 	// Update flags
 	taskUpdateTitle           string
@@ -142,16 +213,28 @@ var (
 	taskUpdateRemoveLabels    []string
 	taskUpdateAddAssignees    []string
 	taskUpdateRemoveAssignees []string
+	taskUpdateEstimate        string
+	taskUpdateRemind          string
+	taskUpdateParent          string
+	taskUpdateDependsOn       string
+	taskUpdateFields          []string
+
+	// Show flags
+	taskShowRemote bool
 
 	// Comment flags
 	taskCommentMessage string
 	taskCommentAuthor  string
 
 	// Delete flags
-	taskDeleteForce bool
+	taskDeleteYes bool
 
 	// Move flags
 	taskMoveProject string
+
+	// Diff flags
+	taskDiffAgainstRemote   bool
+	taskDiffAgainstSnapshot bool
 )
 
 func init() {
@@ -164,15 +247,24 @@ func init() {
 	taskCmd.AddCommand(taskCommentCmd)
 	taskCmd.AddCommand(taskDeleteCmd)
 
+	// Show flags
+	taskShowCmd.Flags().BoolVar(&taskShowRemote, "remote", false, "Fetch the external task to detect external changes in the sync state")
+
 	// List flags
 	taskListCmd.Flags().StringVar(&taskListStatus, "status", "", "Filter by status")
 	taskListCmd.Flags().StringVar(&taskListPriority, "priority", "", "Filter by priority")
 	taskListCmd.Flags().StringVarP(&taskListProject, "project", "p", "", "Filter by project ID or name")
 	taskListCmd.Flags().StringVar(&taskListSystem, "system", "", "Filter by system ID or name")
+	taskListCmd.Flags().StringVar(&taskListView, "view", "", "List a saved search's tasks (see 'todu view list')")
+	taskListCmd.Flags().StringVar(&taskListArea, "area", "", "Filter by area (see 'todu area list')")
 	taskListCmd.Flags().StringVar(&taskListProjectStatus, "project-status", "", "Filter by project status (comma-separated: active, done, canceled)")
-	taskListCmd.Flags().StringVar(&taskListProjectPriority, "project-priority", "", "Filter by project priority (comma-separated: low, medium, high)")
+	taskListCmd.Flags().StringVar(&taskListProjectPriority, "project-priority", "", "Filter by project priority (comma-separated levels, such as high,urgent)")
 	taskListCmd.Flags().StringVar(&taskListAssignee, "assignee", "", "Filter by assignee")
+	taskListCmd.Flags().BoolVar(&taskListMine, "mine", false, "Only show tasks assigned to you (see 'todu whoami')")
 	taskListCmd.Flags().StringSliceVar(&taskListLabels, "label", []string{}, "Filter by label (repeatable)")
+	taskListCmd.Flags().StringArrayVar(&taskListFields, "field", []string{}, "Filter by custom field, as key=value (repeatable)")
+	taskListCmd.Flags().BoolVar(&taskListStarred, "starred", false, "Only show starred tasks")
+	taskListCmd.Flags().BoolVar(&taskListSomeday, "someday", false, "Only show someday/maybe tasks (hidden otherwise)")
 	taskListCmd.Flags().StringVar(&taskListSearch, "search", "", "Full-text search")
 	taskListCmd.Flags().StringVar(&taskListDueBefore, "due-before", "", "Due before date (YYYY-MM-DD)")
 	taskListCmd.Flags().StringVar(&taskListDueAfter, "due-after", "", "Due after date (YYYY-MM-DD)")
@@ -180,17 +272,36 @@ func init() {
 	taskListCmd.Flags().StringVar(&taskListUpdatedAfter, "updated-after", "", "Updated after date (YYYY-MM-DD)")
 	taskListCmd.Flags().IntVar(&taskListTemplateID, "template-id", 0, "Filter by recurring template ID")
 	taskListCmd.Flags().StringVar(&taskListScheduledDate, "scheduled-date", "", "Filter by scheduled date (YYYY-MM-DD)")
+	taskListCmd.Flags().StringVar(&taskListScheduledBefore, "scheduled-before", "", "Scheduled on or before date (YYYY-MM-DD)")
+	taskListCmd.Flags().StringVar(&taskListScheduledAfter, "scheduled-after", "", "Scheduled on or after date (YYYY-MM-DD)")
+	addTZFlag(taskListCmd)
+	taskListCmd.Flags().BoolVar(&taskListShowScheduled, "show-scheduled", false, "Show the scheduled date column")
+	taskListCmd.Flags().BoolVar(&taskListWide, "wide", false, "Show full titles, wrapping long ones")
+	taskListCmd.Flags().BoolVar(&taskListCompact, "compact", false, "Show one dense line per task without headers")
+	taskListCmd.MarkFlagsMutuallyExclusive("wide", "compact")
+	taskListCmd.Flags().StringVar(&taskListWatch, "watch", "", "Redraw every interval (e.g., --watch=30s, default "+watchDefaultInterval+")")
+	taskListCmd.Flags().Lookup("watch").NoOptDefVal = watchDefaultInterval
+	taskListCmd.Flags().StringSliceVar(&taskListColumns, "columns", []string{}, "Columns to show (comma-separated: "+strings.Join(taskColumnNames(), ", ")+")")
 	taskListCmd.Flags().IntVar(&taskListLimit, "limit", 0, "Limit number of results (0 = no limit)")
+	taskListCmd.Flags().BoolVar(&taskListNoContext, "no-context", false, "Ignore the current repository's .todu.yaml")
+	taskListCmd.Flags().BoolVarP(&taskListInteractive, "interactive", "i", false, "Select tasks and apply an action (close, delete, label, move, due) to them")
+	taskListCmd.MarkFlagsMutuallyExclusive("interactive", "watch")
 
 	// Create flags
 	taskCreateCmd.Flags().StringVar(&taskCreateTitle, "title", "", "Task title (required)")
-	taskCreateCmd.Flags().StringVarP(&taskCreateProject, "project", "p", "", "Project ID or name (uses defaults.project if not specified)")
+	taskCreateCmd.Flags().StringVarP(&taskCreateProject, "project", "p", "", "Project ID or name (uses .todu.yaml or defaults.project if not specified)")
+	taskCreateCmd.Flags().BoolVar(&taskCreateNoContext, "no-context", false, "Ignore the current repository's .todu.yaml")
 	taskCreateCmd.Flags().StringVar(&taskCreateDescription, "description", "", "Task description")
 	taskCreateCmd.Flags().StringVar(&taskCreateStatus, "status", "active", "Task status")
-	taskCreateCmd.Flags().StringVar(&taskCreatePriority, "priority", "medium", "Task priority")
+	taskCreateCmd.Flags().StringVar(&taskCreatePriority, "priority", "", "Task priority (default: medium, or the middle configured level)")
 	taskCreateCmd.Flags().StringVar(&taskCreateDue, "due", "", "Due date (YYYY-MM-DD)")
 	taskCreateCmd.Flags().StringSliceVar(&taskCreateLabels, "label", []string{}, "Task label (repeatable)")
 	taskCreateCmd.Flags().StringSliceVar(&taskCreateAssignees, "assignee", []string{}, "Task assignee (repeatable)")
+	taskCreateCmd.Flags().StringVar(&taskCreateEstimate, "estimate", "", "Time estimate (e.g., 45m, 2h, 1h30m)")
+	taskCreateCmd.Flags().StringVar(&taskCreateRemind, "remind", "", "Reminders before the due date (comma-separated, e.g., 1d,1h)")
+	taskCreateCmd.Flags().IntVar(&taskCreateParent, "parent", 0, "Parent task ID")
+	taskCreateCmd.Flags().StringVar(&taskCreateDependsOn, "depends-on", "", "IDs of tasks blocking this one (comma-separated)")
+	taskCreateCmd.Flags().StringArrayVar(&taskCreateFields, "field", []string{}, "Custom field, as key=value (repeatable)")
 	taskCreateCmd.Flags().StringVar(&taskCreateExternalID, "external-id", "", "External ID")
 	taskCreateCmd.Flags().IntVar(&taskCreateTemplate, "template", 0, "Link task to recurring template ID")
 	taskCreateCmd.Flags().StringVar(&taskCreateScheduledDate, "scheduled-date", "", "Scheduled date for recurring task (YYYY-MM-DD)")
@@ -205,21 +316,109 @@ func init() {
 	taskUpdateCmd.Flags().StringSliceVar(&taskUpdateRemoveLabels, "remove-label", []string{}, "Remove label (repeatable)")
 	taskUpdateCmd.Flags().StringSliceVar(&taskUpdateAddAssignees, "add-assignee", []string{}, "Add assignee (repeatable)")
 	taskUpdateCmd.Flags().StringSliceVar(&taskUpdateRemoveAssignees, "remove-assignee", []string{}, "Remove assignee (repeatable)")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateEstimate, "estimate", "", "Set time estimate (e.g., 45m, 2h, 1h30m; none to clear)")
+	taskUpdateCmd.Flags().StringArrayVar(&taskUpdateFields, "field", []string{}, "Set custom field, as key=value (repeatable; key= to clear)")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateRemind, "remind", "", "Set reminders before the due date (comma-separated, e.g., 1d,1h; none to clear)")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateParent, "parent", "", "Set parent task ID (none to clear)")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateDependsOn, "depends-on", "", "Set IDs of tasks blocking this one (comma-separated; none to clear)")
 
 	// Comment flags
 	taskCommentCmd.Flags().StringVarP(&taskCommentMessage, "message", "m", "", "Comment message")
-	taskCommentCmd.Flags().StringVar(&taskCommentAuthor, "author", "user", "Comment author")
+	taskCommentCmd.Flags().StringVar(&taskCommentAuthor, "author", "", "Comment author (default: from config, API, or git)")
 
 	// Delete flags
-	taskDeleteCmd.Flags().BoolVarP(&taskDeleteForce, "force", "f", false, "Skip confirmation")
+	addYesFlag(taskDeleteCmd, &taskDeleteYes)
+	addForceAlias(taskDeleteCmd, &taskDeleteYes, "f")
 
 	// Move command and flags
 	taskCmd.AddCommand(taskMoveCmd)
 	taskMoveCmd.Flags().StringVarP(&taskMoveProject, "project", "p", "", "Target project ID or name (required)")
 	_ = taskMoveCmd.MarkFlagRequired("project")
+
+	// Diff command and flags
+	taskCmd.AddCommand(taskDiffCmd)
+	taskDiffCmd.Flags().BoolVar(&taskDiffAgainstRemote, "against-remote", false, "Compare against the task in the external system")
+	taskDiffCmd.Flags().BoolVar(&taskDiffAgainstSnapshot, "against-snapshot", false, "Compare against the task as of the last successful sync")
+	taskDiffCmd.MarkFlagsMutuallyExclusive("against-remote", "against-snapshot")
 }
 
 func runTaskList(cmd *cobra.Command, args []string) error {
+	if err := prepareTaskListFilters(); err != nil {
+		return err
+	}
+
+	if taskListInteractive {
+		return runTaskListInteractive(cmd)
+	}
+	if taskListWatch == "" {
+		return listTasks(cmd.OutOrStdout())
+	}
+
+	interval, err := parseWatchInterval(taskListWatch)
+	if err != nil {
+		return err
+	}
+	return watch(interval, watchTitle(), func() error { return listTasks(os.Stdout) })
+}
+
+// prepareTaskListFilters fills in the task list filters from --view, the
+// repository's .todu.yaml, --mine, and --field.
+func prepareTaskListFilters() error {
+	if taskListView != "" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		view, ok := cfg.View(taskListView)
+		if !ok {
+			return fmt.Errorf("unknown view %q (see 'todu view list')", taskListView)
+		}
+		applyView(view)
+	}
+
+	// A repository's .todu.yaml applies unless the project or system is
+	// chosen explicitly
+	if !taskListNoContext && taskListProject == "" && taskListSystem == "" {
+		file, err := repoContext()
+		if err != nil {
+			return err
+		}
+		if file != nil {
+			taskListRepoContext = file
+			applyRepoFilters(file.Filters)
+		}
+	}
+
+	if taskListMine {
+		if taskListAssignee != "" {
+			return fmt.Errorf("--mine and --assignee cannot be used together")
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		taskListAssignee = currentUser(cfg)
+		if taskListAssignee == "" {
+			return fmt.Errorf("no identity for --mine; set author in the config or use an API key whose user the server reports")
+		}
+	}
+
+	// Custom field filters are label filters on the fields' labels
+	for _, assignment := range taskListFields {
+		key, value, err := types.ParseField(assignment)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return fmt.Errorf("invalid field filter %q: use key=value", assignment)
+		}
+		taskListLabels = append(taskListLabels, types.FieldLabel(key, value))
+	}
+	return nil
+}
+
+// listTasks fetches tasks using the list flags and writes them to w.
+func listTasks(w io.Writer) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -229,21 +428,70 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("API URL not configured")
 	}
 
+	// Columns from flag, then config, then defaults
+	columnNames := taskListColumns
+	if len(columnNames) == 0 {
+		columnNames = cfg.Output.TaskColumns
+	}
+	columns, err := parseTaskColumns(columnNames)
+	if err != nil {
+		return err
+	}
+
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
+
+	tasks, err := fetchListTasks(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Display results
+	if GetOutputFormat() == "json" {
+		return displayTasksJSON(w, tasks)
+	}
+
+	// Show scheduled dates when asked for or when filtering on them
+	showScheduled := taskListShowScheduled || taskListScheduledDate != "" ||
+		taskListScheduledAfter != "" || taskListScheduledBefore != ""
+	if showScheduled && !slices.Contains(columns, "scheduled") {
+		columns = append(slices.Clone(columns), "scheduled")
+	}
+
+	return displayTasksTable(ctx, w, apiClient, tasks, columns)
+}
+
+// fetchListTasks fetches the tasks matching the list flags, sorted by
+// priority and limited to --limit.
+func fetchListTasks(ctx context.Context, apiClient *api.Client, cfg *config.Config) ([]*types.Task, error) {
+	// Date filters are read in the machine's timezone, or --tz
+	loc, err := dateLocation()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range [][2]string{
+		{taskListScheduledAfter, taskListScheduledBefore},
+		{taskListDueAfter, taskListDueBefore},
+		{taskListUpdatedAfter, taskListUpdatedBefore},
+	} {
+		if warning := dateRangeWarning(r[0], r[1], loc); warning != "" {
+			fmt.Fprintf(rootCmd.ErrOrStderr(), "Warning: %s\n", warning)
+			break
+		}
+	}
 
 	// Resolve system ID if provided (for filtering)
 	var systemProjectIDs map[int]bool
 	if taskListSystem != "" {
 		systemID, err := resolveSystemID(apiClient, taskListSystem)
 		if err != nil {
-			return fmt.Errorf("failed to resolve system: %w", err)
+			return nil, fmt.Errorf("failed to resolve system: %w", err)
 		}
 
 		// Get all projects for this system
 		projects, err := apiClient.ListProjects(ctx, &api.ProjectListOptions{SystemID: &systemID})
 		if err != nil {
-			return fmt.Errorf("failed to list projects for system: %w", err)
+			return nil, fmt.Errorf("failed to list projects for system: %w", err)
 		}
 
 		systemProjectIDs = make(map[int]bool)
@@ -252,6 +500,16 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Resolve area to its projects if provided (for filtering)
+	var areaProjectIDs map[int]bool
+	if taskListArea != "" {
+		var err error
+		areaProjectIDs, err = resolveAreaProjectIDs(ctx, apiClient, cfg, taskListArea)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Build API options with filters
 	opts := &api.TaskListOptions{
 		Status:   taskListStatus,
@@ -281,7 +539,14 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	if taskListProject != "" {
 		projectID, err := resolveProjectID(ctx, apiClient, taskListProject)
 		if err != nil {
-			return fmt.Errorf("failed to resolve project: %w", err)
+			return nil, fmt.Errorf("failed to resolve project: %w", err)
+		}
+		opts.ProjectID = &projectID
+	} else if taskListRepoContext != nil {
+		// Inside a repository with .todu.yaml, scope to its project
+		projectID, err := resolveRepoProject(ctx, apiClient, taskListRepoContext)
+		if err != nil {
+			return nil, err
 		}
 		opts.ProjectID = &projectID
 	}
@@ -291,30 +556,64 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 		opts.TemplateID = &taskListTemplateID
 	}
 
-	// Set scheduled date filter
+	// Set scheduled date filters (scheduled-before includes the whole local day)
 	if taskListScheduledDate != "" {
 		opts.ScheduledDate = taskListScheduledDate
 	}
+	if taskListScheduledAfter != "" {
+		if _, err := time.Parse("2006-01-02", taskListScheduledAfter); err != nil {
+			return nil, fmt.Errorf("invalid --scheduled-after date format (use YYYY-MM-DD): %w", err)
+		}
+		opts.ScheduledAfter = taskListScheduledAfter
+	}
+	if taskListScheduledBefore != "" {
+		utcDate, err := parseDateToUTCEnd(taskListScheduledBefore)
+		if err != nil {
+			return nil, err
+		}
+		opts.ScheduledBefore = utcDate
+	}
 
 	// Set updated date filters (convert local timezone to UTC)
 	if taskListUpdatedAfter != "" {
 		utcDate, err := parseDateToUTCStart(taskListUpdatedAfter)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		opts.UpdatedAfter = utcDate
 	}
 	if taskListUpdatedBefore != "" {
 		utcDate, err := parseDateToUTCEnd(taskListUpdatedBefore)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		opts.UpdatedBefore = utcDate
 	}
 
+	// Due date, label, assignee, and search filters are sent to the API so
+	// it can apply them before the result limit, and applied again below
+	// for servers that don't support them
+	if taskListDueAfter != "" {
+		utcDate, err := parseDateToUTCStart(taskListDueAfter)
+		if err != nil {
+			return nil, err
+		}
+		opts.DueAfter = utcDate
+	}
+	if taskListDueBefore != "" {
+		utcDate, err := parseDateToUTCEnd(taskListDueBefore)
+		if err != nil {
+			return nil, err
+		}
+		opts.DueBefore = utcDate
+	}
+	opts.Labels = taskListLabels
+	opts.Assignee = taskListAssignee
+	opts.Search = taskListSearch
+
 	tasks, err := apiClient.ListTasks(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("failed to list tasks: %w", err)
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 
 	// Filter by system if specified
@@ -328,10 +627,21 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 		tasks = filteredTasks
 	}
 
+	// Filter by area if specified
+	if areaProjectIDs != nil {
+		var filteredTasks []*types.Task
+		for _, task := range tasks {
+			if areaProjectIDs[task.ProjectID] {
+				filteredTasks = append(filteredTasks, task)
+			}
+		}
+		tasks = filteredTasks
+	}
+
 	// Apply filters
-	tasks = filterTasks(tasks)
+	tasks = filterTasks(tasks, loc)
 
-	// Sort by priority (high > medium > low > nil)
+	// Sort by priority (highest level first, nil last)
 	sortTasksByPriority(tasks)
 
 	// Limit results
@@ -339,15 +649,10 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 		tasks = tasks[:taskListLimit]
 	}
 
-	// Display results
-	if GetOutputFormat() == "json" {
-		return displayTasksJSON(tasks)
-	}
-
-	return displayTasksTable(ctx, apiClient, tasks)
+	return tasks, nil
 }
 
-func filterTasks(tasks []*types.Task) []*types.Task {
+func filterTasks(tasks []*types.Task, loc *time.Location) []*types.Task {
 	var filtered []*types.Task
 
 	for _, task := range tasks {
@@ -367,6 +672,15 @@ func filterTasks(tasks []*types.Task) []*types.Task {
 			}
 		}
 
+		if taskListStarred && !task.IsStarred() {
+			continue
+		}
+
+		// Someday tasks are hidden unless asked for
+		if task.IsSomeday() != (taskListSomeday || slices.Contains(taskListLabels, types.SomedayLabel)) {
+			continue
+		}
+
 		// Label filter
 		if len(taskListLabels) > 0 {
 			hasAllLabels := true
@@ -402,21 +716,18 @@ func filterTasks(tasks []*types.Task) []*types.Task {
 			}
 		}
 
-		// Due date filters (user input is local timezone, task.DueDate is UTC)
+		// Due date filters (user input is local timezone or --tz, task.DueDate is UTC)
 		if taskListDueBefore != "" && task.DueDate != nil {
 			// Parse user's date in local timezone, get end of that day, convert to UTC for comparison
-			beforeDate, err := time.ParseInLocation("2006-01-02", taskListDueBefore, time.Local)
-			if err == nil {
-				endOfDay := beforeDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
-				if task.DueDate.After(endOfDay) {
-					continue
-				}
+			_, endOfDay, err := localDay(taskListDueBefore, loc)
+			if err == nil && task.DueDate.After(endOfDay) {
+				continue
 			}
 		}
 
 		if taskListDueAfter != "" && task.DueDate != nil {
 			// Parse user's date in local timezone, get start of that day for comparison
-			afterDate, err := time.ParseInLocation("2006-01-02", taskListDueAfter, time.Local)
+			afterDate, _, err := localDay(taskListDueAfter, loc)
 			if err == nil && task.DueDate.Before(afterDate) {
 				continue
 			}
@@ -428,27 +739,138 @@ func filterTasks(tasks []*types.Task) []*types.Task {
 	return filtered
 }
 
+// setReminderLabels replaces any reminder labels in labels with ones for
+// the comma-separated offsets in remind. A remind of "none" only removes
+// them.
+func setReminderLabels(labels []string, remind string) ([]string, error) {
+	var result []string
+	for _, name := range labels {
+		if !strings.HasPrefix(name, types.ReminderLabelPrefix) {
+			result = append(result, name)
+		}
+	}
+	if remind == "none" {
+		return result, nil
+	}
+	for _, value := range strings.Split(remind, ",") {
+		offset, err := types.ParseReminderOffset(value)
+		if err != nil {
+			return nil, err
+		}
+		if label := types.ReminderLabel(offset); !slices.Contains(result, label) {
+			result = append(result, label)
+		}
+	}
+	return result, nil
+}
+
+// setEstimateLabel replaces any estimate label in labels with one for
+// estimate. An estimate of "none" only removes it.
+func setEstimateLabel(labels []string, estimate string) ([]string, error) {
+	var result []string
+	for _, name := range labels {
+		if !strings.HasPrefix(name, types.EstimateLabelPrefix) {
+			result = append(result, name)
+		}
+	}
+	if estimate == "none" {
+		return result, nil
+	}
+	d, err := types.ParseEstimate(estimate)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, types.EstimateLabel(d)), nil
+}
+
+// setTaskRefLabels replaces any labels starting with prefix in labels with
+// one for each comma-separated task ID in refs. A refs of "none" only
+// removes them, and a task can't refer to itself.
+func setTaskRefLabels(labels []string, prefix, refs string, self int) ([]string, error) {
+	var result []string
+	for _, name := range labels {
+		if !strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+	if refs == "none" {
+		return result, nil
+	}
+	for _, value := range strings.Split(refs, ",") {
+		id, err := types.ParseTaskRef(value)
+		if err != nil {
+			return nil, err
+		}
+		if id == self {
+			return nil, fmt.Errorf("task %d can't depend on itself", id)
+		}
+		if label := prefix + strconv.Itoa(id); !slices.Contains(result, label) {
+			result = append(result, label)
+		}
+	}
+	return result, nil
+}
+
+// setFieldLabels replaces the field labels in labels for each key=value
+// assignment. An empty value only removes the field.
+func setFieldLabels(labels []string, assignments []string) ([]string, error) {
+	result := slices.Clone(labels)
+	for _, assignment := range assignments {
+		key, value, err := types.ParseField(assignment)
+		if err != nil {
+			return nil, err
+		}
+		result = slices.DeleteFunc(result, func(name string) bool {
+			k, _, ok := types.ParseFieldLabel(name)
+			return ok && k == key
+		})
+		if value != "" {
+			result = append(result, types.FieldLabel(key, value))
+		}
+	}
+	return result, nil
+}
+
+// checkProjectFields checks the keys of key=value assignments against the
+// fields configured for the project, if any.
+func checkProjectFields(ctx context.Context, apiClient *api.Client, cfg *config.Config, projectID int, assignments []string) error {
+	if len(cfg.Fields) == 0 {
+		return nil
+	}
+	project, err := apiClient.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	allowed, ok := cfg.ProjectFields(project.Name)
+	if !ok {
+		return nil
+	}
+	for _, assignment := range assignments {
+		key, _, err := types.ParseField(assignment)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(allowed, key) {
+			return fmt.Errorf("project %s has no field %q (fields: %s)", project.Name, key, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
 // priorityValue returns a numeric value for sorting (higher = more important)
 func priorityValue(p *string) int {
-	if p == nil {
-		return 0
-	}
-	switch *p {
-	case "high":
-		return 3
-	case "medium":
-		return 2
-	case "low":
-		return 1
-	default:
-		return 0
-	}
+	return types.PriorityRank(p)
 }
 
-// sortTasksByPriority sorts tasks by priority (high > medium > low > nil)
-// Tasks with same priority are sorted by ID for consistent ordering
+// sortTasksByPriority sorts starred tasks first, then by priority, highest
+// configured level first and nil last. Tasks with same priority are sorted by ID
+// for consistent ordering
 func sortTasksByPriority(tasks []*types.Task) {
 	sort.Slice(tasks, func(i, j int) bool {
+		// Starred tasks come first regardless of priority
+		if si, sj := tasks[i].IsStarred(), tasks[j].IsStarred(); si != sj {
+			return si
+		}
 		pi := priorityValue(tasks[i].Priority)
 		pj := priorityValue(tasks[j].Priority)
 		if pi != pj {
@@ -458,66 +880,49 @@ func sortTasksByPriority(tasks []*types.Task) {
 	})
 }
 
-func displayTasksTable(ctx context.Context, apiClient *api.Client, tasks []*types.Task) error {
+func displayTasksTable(ctx context.Context, w io.Writer, apiClient *api.Client, tasks []*types.Task, columns []string) error {
 	if len(tasks) == 0 {
-		fmt.Println("No tasks found")
+		fmt.Fprintln(w, "No tasks found")
 		return nil
 	}
 
 	// Fetch projects for name lookup
-	projectNames := make(map[int]string)
-	projects, err := apiClient.ListProjects(ctx, nil) // nil opts fetches all projects
-	if err == nil {
-		for _, p := range projects {
-			projectNames[p.ID] = p.Name
+	data := &taskTableData{projectNames: make(map[int]string)}
+	if slices.Contains(columns, "project") {
+		projects, err := apiClient.ListProjects(ctx, nil) // nil opts fetches all projects
+		if err == nil {
+			for _, p := range projects {
+				data.projectNames[p.ID] = p.Name
+			}
 		}
+		// If fetch fails, we'll fall back to showing IDs
 	}
-	// If fetch fails, we'll fall back to showing IDs
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tPRIORITY\tPROJECT\tDUE DATE")
-	fmt.Fprintln(w, "--\t-----\t------\t--------\t-------\t--------")
-
-	for _, task := range tasks {
-		priority := ""
-		if task.Priority != nil {
-			priority = *task.Priority
-		}
-
-		dueDate := ""
-		if task.DueDate != nil {
-			// Use UTC for date-only fields to preserve the stored date
-			// (Local() would shift midnight UTC to previous day in western timezones)
-			dueDate = task.DueDate.UTC().Format("2006-01-02")
-		}
-
-		// Use project name if available, otherwise fall back to ID
-		projectDisplay := projectNames[task.ProjectID]
-		if projectDisplay == "" {
-			projectDisplay = fmt.Sprintf("%d", task.ProjectID)
-		}
+	if slices.Contains(columns, "sync") {
+		data.syncStates = taskSyncStates(tasks)
+	}
 
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
-			task.ID,
-			truncate(task.Title, 40),
-			task.Status,
-			priority,
-			projectDisplay,
-			dueDate,
-		)
+	layout := taskTableLayout{mode: tableNormal, width: terminalWidth()}
+	switch {
+	case taskListWide:
+		layout.mode = tableWide
+	case taskListCompact:
+		layout.mode = tableCompact
 	}
 
-	w.Flush()
-	fmt.Printf("\nTotal: %d tasks\n", len(tasks))
+	writeTaskTable(w, tasks, columns, data, layout)
+	if layout.mode != tableCompact {
+		fmt.Fprintf(w, "\nTotal: %d tasks\n", len(tasks))
+	}
 	return nil
 }
 
-func displayTasksJSON(tasks []*types.Task) error {
+func displayTasksJSON(w io.Writer, tasks []*types.Task) error {
 	data, err := json.MarshalIndent(tasks, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(w, string(data))
 	return nil
 }
 
@@ -537,7 +942,7 @@ func runTaskShow(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	task, err := apiClient.GetTask(ctx, taskID)
 	if err != nil {
@@ -551,95 +956,137 @@ func runTaskShow(cmd *cobra.Command, args []string) error {
 		comments = []*types.Comment{}
 	}
 
+	links := loadTaskLinks(ctx, apiClient, taskID)
+	syncInfo := loadTaskSyncInfo(ctx, apiClient, task, taskShowRemote)
+
 	// Display results
 	if GetOutputFormat() == "json" {
-		return displayTaskJSON(task, comments)
+		return displayTaskJSON(cmd.OutOrStdout(), task, syncInfo, links, comments)
 	}
 
-	displayTask(task, comments)
+	displayTask(cmd.OutOrStdout(), task, syncInfo, links, comments)
 	return nil
 }
 
-func displayTaskJSON(task *types.Task, comments []*types.Comment) error {
+func displayTaskJSON(w io.Writer, task *types.Task, syncInfo *taskSyncInfo, links []*externalLink, comments []*types.Comment) error {
 	output := map[string]interface{}{
 		"task":     task,
+		"sync":     syncInfo,
 		"comments": comments,
 	}
+	if fields := task.Fields(); len(fields) > 0 {
+		output["fields"] = fields
+	}
+	if len(links) > 0 {
+		output["links"] = links
+	}
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(w, string(data))
 	return nil
 }
 
-func displayTask(task *types.Task, comments []*types.Comment) {
-	fmt.Printf("Task #%d: %s\n", task.ID, task.Title)
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println()
+func displayTask(w io.Writer, task *types.Task, syncInfo *taskSyncInfo, links []*externalLink, comments []*types.Comment) {
+	fmt.Fprintf(w, "Task #%d: %s\n", task.ID, task.Title)
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+	fmt.Fprintln(w)
 
-	fmt.Printf("Status:      %s\n", task.Status)
+	fmt.Fprintf(w, "Status:      %s\n", task.Status)
 	if task.Priority != nil {
-		fmt.Printf("Priority:    %s\n", *task.Priority)
+		fmt.Fprintf(w, "Priority:    %s\n", *task.Priority)
+	}
+	fmt.Fprintf(w, "Project ID:  %d\n", task.ProjectID)
+	fmt.Fprintf(w, "External ID: %s\n", task.ExternalID)
+	if syncInfo != nil {
+		if syncInfo.System != "" {
+			fmt.Fprintf(w, "System:      %s\n", syncInfo.System)
+		}
+		if syncInfo.LastPushedAt != nil {
+			fmt.Fprintf(w, "Last Pushed: %s\n", syncInfo.LastPushedAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		if syncInfo.RemoteChecked || syncInfo.State == sync.StateNotSynced {
+			fmt.Fprintf(w, "Sync State:  %s\n", syncInfo.State)
+		} else {
+			fmt.Fprintf(w, "Sync State:  %s (use --remote to check for external changes)\n", syncInfo.State)
+		}
 	}
-	fmt.Printf("Project ID:  %d\n", task.ProjectID)
-	fmt.Printf("External ID: %s\n", task.ExternalID)
 
 	if task.SourceURL != nil {
-		fmt.Printf("Source URL:  %s\n", *task.SourceURL)
+		fmt.Fprintf(w, "Source URL:  %s\n", *task.SourceURL)
 	}
 
-	if task.DueDate != nil {
-		// Use UTC for date-only fields to preserve the stored date
-		fmt.Printf("Due Date:    %s\n", task.DueDate.UTC().Format("2006-01-02"))
+	for _, link := range links {
+		fmt.Fprintf(w, "Linked:      %s#%s (project %d)\n", link.ProjectExternalID, link.ExternalID, link.ProjectID)
 	}
 
-	if task.TemplateID != nil {
-		fmt.Printf("Template ID: %d\n", *task.TemplateID)
-		fmt.Println("             (Next occurrence will be generated when marked done)")
+	if task.DueDate != nil {
+		// Use UTC for date-only fields to preserve the stored date
+		fmt.Fprintf(w, "Due Date:    %s (deadline)\n", task.DueDate.UTC().Format("2006-01-02"))
 	}
 
 	if task.ScheduledDate != nil {
 		// Use UTC for date-only fields to preserve the stored date
-		fmt.Printf("Scheduled:   %s\n", task.ScheduledDate.UTC().Format("2006-01-02"))
+		fmt.Fprintf(w, "Scheduled:   %s (planned work day)\n", task.ScheduledDate.UTC().Format("2006-01-02"))
 	}
 
-	fmt.Printf("Created:     %s\n", task.CreatedAt.Local().Format("2006-01-02 15:04:05"))
-	fmt.Printf("Updated:     %s\n", task.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+	if task.TemplateID != nil {
+		fmt.Fprintf(w, "Template ID: %d\n", *task.TemplateID)
+		fmt.Fprintln(w, "             (Next occurrence will be generated when marked done)")
+	}
+
+	fmt.Fprintf(w, "Created:     %s\n", task.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "Updated:     %s\n", task.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
 
 	if task.Description != nil && *task.Description != "" {
-		fmt.Println()
-		fmt.Println("Description:")
-		fmt.Println(*task.Description)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Description:")
+		fmt.Fprintln(w, *task.Description)
 	}
 
-	if len(task.Labels) > 0 {
-		fmt.Println()
-		fmt.Print("Labels: ")
-		labelNames := make([]string, len(task.Labels))
-		for i, label := range task.Labels {
-			labelNames[i] = label.Name
+	if checklist := task.Checklist(); len(checklist) > 0 {
+		fmt.Fprintln(w)
+		displayChecklist(w, checklist)
+	}
+
+	if fields := task.Fields(); len(fields) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Fields:")
+		for _, key := range types.FieldKeys(fields) {
+			fmt.Fprintf(w, "  %s: %s\n", key, fields[key])
 		}
-		fmt.Println(strings.Join(labelNames, ", "))
+	}
+
+	labels := slices.DeleteFunc(slices.Clone(task.Labels), func(label types.Label) bool {
+		_, _, ok := types.ParseFieldLabel(label.Name)
+		return ok
+	})
+	if len(labels) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Labels: "+formatLabels(labels, labelColorsEnabled()))
 	}
 
 	if len(task.Assignees) > 0 {
-		fmt.Println()
-		fmt.Print("Assignees: ")
+		fmt.Fprintln(w)
+		fmt.Fprint(w, "Assignees: ")
 		assigneeNames := make([]string, len(task.Assignees))
 		for i, assignee := range task.Assignees {
 			assigneeNames[i] = assignee.Name
 		}
-		fmt.Println(strings.Join(assigneeNames, ", "))
+		fmt.Fprintln(w, strings.Join(assigneeNames, ", "))
 	}
 
 	if len(comments) > 0 {
-		fmt.Println()
-		fmt.Printf("Comments (%d):\n", len(comments))
-		fmt.Println(strings.Repeat("-", 60))
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Comments (%d):\n", len(comments))
+		fmt.Fprintln(w, strings.Repeat("-", 60))
 		for _, comment := range comments {
-			fmt.Printf("\n[%s] %s:\n", comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.Author)
-			fmt.Println(comment.Content)
+			fmt.Fprintf(w, "\n[%s] %s (#%d):\n", comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.Author, comment.ID)
+			fmt.Fprintln(w, comment.Content)
+			if summary := comment.ReactionSummary(); summary != "" {
+				fmt.Fprintln(w, summary)
+			}
 		}
 	}
 }
@@ -659,10 +1106,27 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--title is required")
 	}
 
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
-	// Resolve project ID from flag, config default, or error
+	var repoFile *repoconfig.File
+	if taskCreateProject == "" && !taskCreateNoContext {
+		repoFile, err = repoContext()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve project ID from flag, repository, config default, or error
 	var projectID int
 	if taskCreateProject != "" {
 		// Use the project from flag
@@ -670,6 +1134,12 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to resolve project: %w", err)
 		}
+	} else if repoFile != nil {
+		// Use the project pinned by the current repository's .todu.yaml
+		projectID, err = resolveRepoProject(ctx, apiClient, repoFile)
+		if err != nil {
+			return err
+		}
 	} else if cfg.Defaults.Project != "" {
 		// Use the default project from config (auto-create if needed)
 		projectID, err = ensureDefaultProject(ctx, apiClient, cfg.Defaults.Project)
@@ -695,9 +1165,12 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		taskCreate.Description = &taskCreateDescription
 	}
 
-	if taskCreatePriority != "" {
-		taskCreate.Priority = &taskCreatePriority
+	if taskCreatePriority == "" {
+		taskCreatePriority = types.DefaultPriority()
+	} else if err := types.ValidatePriority(taskCreatePriority); err != nil {
+		return err
 	}
+	taskCreate.Priority = &taskCreatePriority
 
 	if taskCreateDue != "" {
 		dueDate, err := time.Parse("2006-01-02", taskCreateDue)
@@ -711,6 +1184,47 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 	if len(taskCreateLabels) > 0 {
 		taskCreate.Labels = taskCreateLabels
 	}
+	if taskCreateEstimate != "" {
+		labels, err := setEstimateLabel(taskCreate.Labels, taskCreateEstimate)
+		if err != nil {
+			return err
+		}
+		taskCreate.Labels = labels
+	}
+	if len(taskCreateFields) > 0 {
+		if err := checkProjectFields(ctx, apiClient, cfg, projectID, taskCreateFields); err != nil {
+			return err
+		}
+		labels, err := setFieldLabels(taskCreate.Labels, taskCreateFields)
+		if err != nil {
+			return err
+		}
+		taskCreate.Labels = labels
+	}
+	if taskCreateParent != 0 {
+		labels, err := setTaskRefLabels(taskCreate.Labels, types.ParentLabelPrefix, strconv.Itoa(taskCreateParent), 0)
+		if err != nil {
+			return err
+		}
+		taskCreate.Labels = labels
+	}
+	if taskCreateDependsOn != "" {
+		labels, err := setTaskRefLabels(taskCreate.Labels, types.DependsOnLabelPrefix, taskCreateDependsOn, 0)
+		if err != nil {
+			return err
+		}
+		taskCreate.Labels = labels
+	}
+	if taskCreateRemind != "" {
+		if taskCreate.DueDate == nil {
+			return fmt.Errorf("--remind needs a due date (--due)")
+		}
+		labels, err := setReminderLabels(taskCreate.Labels, taskCreateRemind)
+		if err != nil {
+			return err
+		}
+		taskCreate.Labels = labels
+	}
 
 	// Add assignees
 	if len(taskCreateAssignees) > 0 {
@@ -731,13 +1245,20 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		taskCreate.ScheduledDate = &scheduledDate
 	}
 
+	if err := hookRunner.Run(ctx, hooks.PreTaskCreate, map[string]any{"task": taskCreate}); err != nil {
+		return err
+	}
+
 	task, err := apiClient.CreateTask(ctx, taskCreate)
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 
+	task = applyTaskRules(ctx, apiClient, ruleEngine, task, rules.TriggerCreate)
+	runPostHook(ctx, hookRunner, hooks.PostTaskCreate, map[string]any{"task": task})
+
 	fmt.Println("Task created successfully:")
-	displayTask(task, []*types.Comment{})
+	displayTask(os.Stdout, task, nil, nil, []*types.Comment{})
 	return nil
 }
 
@@ -757,7 +1278,16 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Get current task to merge labels/assignees
 	currentTask, err := apiClient.GetTask(ctx, taskID)
@@ -781,6 +1311,9 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if taskUpdatePriority != "" {
+		if err := types.ValidatePriority(taskUpdatePriority); err != nil {
+			return err
+		}
 		taskUpdate.Priority = &taskUpdatePriority
 	}
 
@@ -817,7 +1350,7 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 
 		// Remove labels
 		for _, name := range taskUpdateRemoveLabels {
-			var newLabels []string
+			newLabels := []string{}
 			for _, labelName := range labelNames {
 				if labelName != name {
 					newLabels = append(newLabels, labelName)
@@ -826,6 +1359,87 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 			labelNames = newLabels
 		}
 
+		// Removing the last label sends an empty label set
+		taskUpdate.Labels = labelNames
+		taskUpdate.ClearLabels = true
+	}
+
+	// Replace the estimate label
+	if taskUpdateEstimate != "" {
+		labelNames := taskUpdate.Labels
+		if labelNames == nil {
+			for _, label := range currentTask.Labels {
+				labelNames = append(labelNames, label.Name)
+			}
+		}
+		labels, err := setEstimateLabel(labelNames, taskUpdateEstimate)
+		if err != nil {
+			return err
+		}
+		taskUpdate.Labels = labels
+	}
+
+	// Replace the custom field labels
+	if len(taskUpdateFields) > 0 {
+		if err := checkProjectFields(ctx, apiClient, cfg, currentTask.ProjectID, taskUpdateFields); err != nil {
+			return err
+		}
+		labelNames := taskUpdate.Labels
+		if labelNames == nil {
+			for _, label := range currentTask.Labels {
+				labelNames = append(labelNames, label.Name)
+			}
+		}
+		labels, err := setFieldLabels(labelNames, taskUpdateFields)
+		if err != nil {
+			return err
+		}
+		taskUpdate.Labels = labels
+	}
+
+	// Replace the reminder labels
+	if taskUpdateRemind != "" {
+		if taskUpdateRemind != "none" && taskUpdate.DueDate == nil && currentTask.DueDate == nil {
+			return fmt.Errorf("--remind needs a due date (--due)")
+		}
+		labelNames := taskUpdate.Labels
+		if labelNames == nil {
+			for _, label := range currentTask.Labels {
+				labelNames = append(labelNames, label.Name)
+			}
+		}
+		labels, err := setReminderLabels(labelNames, taskUpdateRemind)
+		if err != nil {
+			return err
+		}
+		taskUpdate.Labels = labels
+	}
+
+	// Replace the parent and depends-on labels
+	if taskUpdateParent != "" || taskUpdateDependsOn != "" {
+		labelNames := taskUpdate.Labels
+		if labelNames == nil {
+			for _, label := range currentTask.Labels {
+				labelNames = append(labelNames, label.Name)
+			}
+		}
+		if strings.Contains(taskUpdateParent, ",") {
+			return fmt.Errorf("a task has only one parent")
+		}
+		if taskUpdateParent != "" {
+			labels, err := setTaskRefLabels(labelNames, types.ParentLabelPrefix, taskUpdateParent, currentTask.ID)
+			if err != nil {
+				return err
+			}
+			labelNames = labels
+		}
+		if taskUpdateDependsOn != "" {
+			labels, err := setTaskRefLabels(labelNames, types.DependsOnLabelPrefix, taskUpdateDependsOn, currentTask.ID)
+			if err != nil {
+				return err
+			}
+			labelNames = labels
+		}
 		taskUpdate.Labels = labelNames
 	}
 
@@ -865,13 +1479,69 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 		taskUpdate.Assignees = assigneeNames
 	}
 
+	return saveTaskUpdate(ctx, apiClient, hookRunner, ruleEngine, taskID, currentTask, taskUpdate)
+}
+
+// saveTaskUpdate applies an update to a task, running the task update hooks
+// and rules, and prints the description diff if the description changed.
+func saveTaskUpdate(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, taskID int, currentTask *types.Task, taskUpdate *types.TaskUpdate) error {
+	if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, taskID, currentTask, taskUpdate, fmt.Sprintf("Task #%d updated successfully", taskID)); err != nil {
+		return err
+	}
+
+	// Show what changed in the description so overwrites are auditable
+	if taskUpdate.Description != nil {
+		oldDesc := ""
+		if currentTask.Description != nil {
+			oldDesc = *currentTask.Description
+		}
+		if diff := sync.UnifiedDiff(oldDesc, *taskUpdate.Description, "description (before)", "description (after)"); diff != "" {
+			fmt.Println()
+			fmt.Print(diff)
+		}
+	}
+	return nil
+}
+
+// updateTaskWithHooks applies an update to a task, running the task update
+// hooks and rules, and returns the task as the rules left it. An update
+// that closes the task, setting it done or canceled, runs the task close
+// hooks instead. A non-empty message is printed once the update is saved,
+// before the rules run.
+func updateTaskWithHooks(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, taskID int, currentTask *types.Task, taskUpdate *types.TaskUpdate, message string) (*types.Task, error) {
+	closing := taskUpdate.Status != nil && isClosedStatus(*taskUpdate.Status) && !isClosedStatus(currentTask.Status)
+
+	var err error
+	if closing {
+		err = hookRunner.Run(ctx, hooks.PreTaskClose, map[string]any{"task": currentTask})
+	} else {
+		err = hookRunner.Run(ctx, hooks.PreTaskUpdate, map[string]any{"task": currentTask, "update": taskUpdate})
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	task, err := apiClient.UpdateTask(ctx, taskID, taskUpdate)
 	if err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
-	fmt.Printf("Task #%d updated successfully\n", task.ID)
-	return nil
+	if message != "" {
+		fmt.Println(message)
+	}
+
+	task = applyTaskRules(ctx, apiClient, ruleEngine, task, rules.TriggerUpdate)
+	if closing {
+		runPostHook(ctx, hookRunner, hooks.PostTaskClose, map[string]any{"task": task})
+	} else {
+		runPostHook(ctx, hookRunner, hooks.PostTaskUpdate, map[string]any{"task": task, "previous": currentTask})
+	}
+	return task, nil
+}
+
+// isClosedStatus reports whether a task status is done or canceled
+func isClosedStatus(status string) bool {
+	return status == "done" || status == "canceled"
 }
 
 func runTaskClose(cmd *cobra.Command, args []string) error {
@@ -890,22 +1560,49 @@ func runTaskClose(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
-	status := "done"
-	taskUpdate := &types.TaskUpdate{
-		Status: &status,
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
 	}
 
-	task, err := apiClient.UpdateTask(ctx, taskID, taskUpdate)
+	task, err := closeTaskWithHooks(ctx, apiClient, hookRunner, taskID, nil, "done")
 	if err != nil {
-		return fmt.Errorf("failed to close task: %w", err)
+		return err
 	}
 
 	fmt.Printf("Task #%d closed successfully\n", task.ID)
 	return nil
 }
 
+// closeTaskWithHooks sets a task's status to done or canceled, running the
+// task close hooks. current is the task before closing, or nil to fetch it
+// when a pre-close hook needs it.
+func closeTaskWithHooks(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, taskID int, current *types.Task, status string) (*types.Task, error) {
+	// Pre-close hooks see the task as it was before closing
+	if hookRunner.Has(hooks.PreTaskClose) {
+		if current == nil {
+			var err error
+			current, err = apiClient.GetTask(ctx, taskID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get task: %w", err)
+			}
+		}
+		if err := hookRunner.Run(ctx, hooks.PreTaskClose, map[string]any{"task": current}); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := apiClient.UpdateTask(ctx, taskID, &types.TaskUpdate{Status: &status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to close task: %w", err)
+	}
+
+	runPostHook(ctx, hookRunner, hooks.PostTaskClose, map[string]any{"task": task})
+	return task, nil
+}
+
 func runTaskComment(cmd *cobra.Command, args []string) error {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -943,12 +1640,12 @@ func runTaskComment(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	commentCreate := &types.CommentCreate{
 		TaskID:  &taskID,
 		Content: commentText,
-		Author:  taskCommentAuthor,
+		Author:  getAuthor(taskCommentAuthor, cfg),
 	}
 
 	comment, err := apiClient.CreateComment(ctx, commentCreate)
@@ -977,25 +1674,45 @@ func runTaskDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid task ID: %s", args[0])
 	}
 
-	// Confirm deletion unless --force
-	if !taskDeleteForce {
-		fmt.Printf("Are you sure you want to delete task #%d? (y/N): ", taskID)
-		var response string
-		_, _ = fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" {
-			fmt.Println("Deletion cancelled")
-			return nil
-		}
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
 	}
 
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	// The prompt and delete hooks get the task as it was, since it won't
+	// exist afterwards
+	deleted, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Confirm deletion unless --yes
+	affected := []string{fmt.Sprintf("Task #%d: %s (%s)", deleted.ID, deleted.Title, deleted.Status)}
+	if deleted.ExternalID != "" {
+		affected = append(affected, fmt.Sprintf("Synced with external task %s, which is not deleted", deleted.ExternalID))
+	}
+	confirmed, err := confirmAction(cfg, taskDeleteYes, fmt.Sprintf("Delete task #%d?", taskID), affected...)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+	if err := hookRunner.Run(ctx, hooks.PreTaskDelete, map[string]any{"task": deleted}); err != nil {
+		return err
+	}
 
 	err = apiClient.DeleteTask(ctx, taskID)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
+	runPostHook(ctx, hookRunner, hooks.PostTaskDelete, map[string]any{"task": deleted})
+
 	fmt.Printf("Task #%d deleted successfully\n", taskID)
 	return nil
 }
@@ -1065,7 +1782,7 @@ func runTaskMove(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Get the source task
 	sourceTask, err := apiClient.GetTask(ctx, taskID)
@@ -1215,3 +1932,109 @@ func runTaskMove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runTaskDiff(cmd *cobra.Command, args []string) error {
+	if !taskDiffAgainstRemote && !taskDiffAgainstSnapshot {
+		return fmt.Errorf("nothing to compare against: use --against-remote or --against-snapshot")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.ExternalID == "" {
+		return fmt.Errorf("task #%d has not been synced to an external system", taskID)
+	}
+
+	var otherTask *types.Task
+	otherName := "remote"
+	if taskDiffAgainstSnapshot {
+		otherName = "snapshot"
+		snapshotDir, err := sync.DefaultSnapshotDir()
+		if err != nil {
+			return err
+		}
+		otherTask, err = sync.NewFileSnapshotStore(snapshotDir).Get(task.ProjectID, task.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to load sync snapshot: %w", err)
+		}
+		if otherTask == nil {
+			return fmt.Errorf("no sync snapshot recorded for task #%d", taskID)
+		}
+	} else {
+		project, err := apiClient.GetProject(ctx, task.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+
+		p, err := createProjectPlugin(ctx, apiClient, project)
+		if err != nil {
+			return err
+		}
+
+		otherTask, err = p.FetchTask(ctx, &project.ExternalID, task.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch external task: %w", err)
+		}
+	}
+
+	diffs := sync.DiffTasks(task, otherTask)
+
+	if GetOutputFormat() == "json" {
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayTaskDiffs(task, diffs, otherName)
+	return nil
+}
+
+func displayTaskDiffs(task *types.Task, diffs []sync.FieldDiff, otherName string) {
+	if len(diffs) == 0 {
+		fmt.Printf("Task #%d matches %s of external task %s\n", task.ID, otherName, task.ExternalID)
+		return
+	}
+
+	fmt.Printf("Task #%d differs from %s of external task %s:\n\n", task.ID, otherName, task.ExternalID)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "FIELD\tLOCAL\t%s\n", strings.ToUpper(otherName))
+	fmt.Fprintf(w, "-----\t-----\t%s\n", strings.Repeat("-", len(otherName)))
+	var descDiff string
+	for _, d := range diffs {
+		if d.Diff != "" {
+			descDiff = d.Diff
+			fmt.Fprintf(w, "%s\t%s\t%s\n", d.Field, "(see below)", "(see below)")
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.Field, d.Local, d.Remote)
+	}
+	w.Flush()
+
+	if descDiff != "" {
+		fmt.Println()
+		fmt.Print(descDiff)
+	}
+}
@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskBumpCmd = &cobra.Command{
+	Use:   "bump <id> [offset]",
+	Short: "Postpone a task's due and scheduled dates",
+	Long: `Move a task's due and scheduled dates.
+
+The offset is applied to each date the task already has. If the task has
+neither, the due date is set relative to today. The default offset is +1d.
+
+Offsets:
+  +3d, -1d        Shift by days
+  +1w, +2w        Shift by weeks
+  +1m             Shift by months
+  tomorrow        Set to tomorrow
+  next-week       Set to next Monday
+  YYYY-MM-DD      Set to a specific date
+
+Negative offsets look like flags, so put them after --, as in
+"todu task bump 42 -- -1d".
+
+Use --overdue to bump every open task whose due date has passed. Each task
+is confirmed individually unless --yes is given.
+
+With --skip-weekends or --skip-holidays, a date that lands on a weekend or
+a holiday (from the holidays calendar in the config) moves to the next
+business day.
+
+Examples:
+  todu task bump 42
+  todu task bump 42 +3d
+  todu task bump 42 next-week
+  todu task bump 42 2024-06-01
+  todu task bump 42 -- -2d
+  todu task bump --overdue +1w
+  todu task bump --overdue +1d --yes
+  todu task bump 42 +1w --skip-weekends --skip-holidays`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if taskBumpOverdue {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
+	RunE: runTaskBump,
+}
+
+var (
+	// Bump flags
+	taskBumpOverdue      bool
+	taskBumpYes          bool
+	taskBumpSkipWeekends bool
+	taskBumpSkipHolidays bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskBumpCmd)
+	taskBumpCmd.Flags().BoolVar(&taskBumpOverdue, "overdue", false, "Bump all open tasks that are past their due date")
+	taskBumpCmd.Flags().BoolVar(&taskBumpSkipWeekends, "skip-weekends", false, "Move dates that land on a weekend to the next weekday")
+	taskBumpCmd.Flags().BoolVar(&taskBumpSkipHolidays, "skip-holidays", false, "Move dates that land on a holiday to the next business day")
+	addYesFlag(taskBumpCmd, &taskBumpYes)
+	taskBumpCmd.SetFlagErrorFunc(bumpFlagError)
+}
+
+// bumpFlagError explains how to pass a negative offset when one was taken
+// for a flag, such as "-1d" in "todu task bump 42 -1d".
+func bumpFlagError(cmd *cobra.Command, err error) error {
+	if _, arg, ok := strings.Cut(err.Error(), " in "); ok {
+		if _, parseErr := parseDateShift(arg, time.Time{}); parseErr == nil {
+			return fmt.Errorf("%w\nPut negative offsets after --, as in: todu task bump 42 -- %s", err, arg)
+		}
+	}
+	return err
+}
+
+// dateShift describes how to move a date: either by a relative offset
+// or to an absolute date, then off any days in skip.
+type dateShift struct {
+	days     int
+	months   int
+	absolute *time.Time
+
+	skip     holidays.Skip
+	calendar *holidays.Calendar
+}
+
+// parseDateShift parses a bump offset such as "+3d", "-1w", "+1m",
+// "tomorrow", "next-week", or "YYYY-MM-DD". today is the reference for
+// named offsets and must be a UTC midnight date.
+func parseDateShift(offset string, today time.Time) (dateShift, error) {
+	value := strings.ToLower(strings.TrimSpace(offset))
+
+	switch value {
+	case "tomorrow":
+		date := today.AddDate(0, 0, 1)
+		return dateShift{absolute: &date}, nil
+	case "next-week":
+		// Next Monday, never today
+		daysUntil := (int(time.Monday) - int(today.Weekday()) + 7) % 7
+		if daysUntil == 0 {
+			daysUntil = 7
+		}
+		date := today.AddDate(0, 0, daysUntil)
+		return dateShift{absolute: &date}, nil
+	}
+
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return dateShift{absolute: &date}, nil
+	}
+
+	if len(value) < 3 || (value[0] != '+' && value[0] != '-') {
+		return dateShift{}, fmt.Errorf("invalid offset %q (use +3d, +1w, +1m, tomorrow, next-week, or YYYY-MM-DD)", offset)
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil {
+		return dateShift{}, fmt.Errorf("invalid offset %q (use +3d, +1w, +1m, tomorrow, next-week, or YYYY-MM-DD)", offset)
+	}
+
+	switch value[len(value)-1] {
+	case 'd':
+		return dateShift{days: n}, nil
+	case 'w':
+		return dateShift{days: n * 7}, nil
+	case 'm':
+		return dateShift{months: n}, nil
+	default:
+		return dateShift{}, fmt.Errorf("invalid offset unit in %q (use d, w, or m)", offset)
+	}
+}
+
+// apply returns the shifted date. Relative shifts are applied to from.
+func (s dateShift) apply(from time.Time) time.Time {
+	date := from.AddDate(0, s.months, s.days)
+	if s.absolute != nil {
+		date = *s.absolute
+	}
+	if s.skip.Any() {
+		// Keep the time of day while moving to the next allowed day
+		day := date.UTC().Truncate(24 * time.Hour)
+		date = date.Add(s.calendar.Next(date, s.skip).Sub(day))
+	}
+	return date
+}
+
+// bumpTask builds the update that moves a task's dates by shift.
+// Each existing date is shifted independently. A task with no dates gets a
+// due date relative to today.
+func bumpTask(task *types.Task, shift dateShift, today time.Time) *types.TaskUpdate {
+	update := &types.TaskUpdate{}
+
+	if task.DueDate != nil {
+		due := shift.apply(task.DueDate.UTC())
+		update.DueDate = &due
+	}
+	if task.ScheduledDate != nil {
+		scheduled := shift.apply(task.ScheduledDate.UTC())
+		update.ScheduledDate = &scheduled
+	}
+	if update.DueDate == nil && update.ScheduledDate == nil {
+		due := shift.apply(today)
+		update.DueDate = &due
+	}
+
+	return update
+}
+
+// isOverdue reports whether an open task's due date is before today.
+func isOverdue(task *types.Task, today time.Time) bool {
+	if task.DueDate == nil || task.Status == "done" || task.Status == "canceled" {
+		return false
+	}
+	// Use UTC for date-only fields to preserve the stored date
+	return task.DueDate.UTC().Before(today)
+}
+
+// localToday returns today's local date as a UTC midnight, matching how
+// date-only fields are stored.
+func localToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func runTaskBump(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	offset := "+1d"
+	var taskArg string
+	if taskBumpOverdue {
+		if len(args) == 1 {
+			offset = args[0]
+		}
+	} else {
+		taskArg = args[0]
+		if len(args) == 2 {
+			offset = args[1]
+		}
+	}
+
+	today := localToday()
+	shift, err := parseDateShift(offset, today)
+	if err != nil {
+		return err
+	}
+	shift.skip = holidays.Skip{Weekends: taskBumpSkipWeekends, Holidays: taskBumpSkipHolidays}
+	if shift.skip.Holidays {
+		if shift.calendar, err = loadHolidayCalendar(cfg, shift.skip); err != nil {
+			return err
+		}
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	if !taskBumpOverdue {
+		taskID, err := strconv.Atoi(taskArg)
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %s", taskArg)
+		}
+
+		task, err := apiClient.GetTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+
+		_, err = applyTaskBump(ctx, apiClient, hookRunner, ruleEngine, task, shift, today, true)
+		return err
+	}
+
+	overdue, err := listOverdueTasks(ctx, apiClient, today)
+	if err != nil {
+		return err
+	}
+
+	if len(overdue) == 0 {
+		fmt.Println("No overdue tasks")
+		return nil
+	}
+
+	bumped := 0
+	for _, task := range overdue {
+		ok, err := applyTaskBump(ctx, apiClient, hookRunner, ruleEngine, task, shift, today, taskBumpYes || !cfg.Confirmations)
+		if err != nil {
+			return err
+		}
+		if ok {
+			bumped++
+		}
+	}
+
+	fmt.Printf("\nBumped %d of %d overdue tasks\n", bumped, len(overdue))
+	return nil
+}
+
+// listOverdueTasks returns every open task due before today. The API
+// filters by status and due date, and all pages are fetched, so a backlog
+// larger than a page is bumped in full.
+func listOverdueTasks(ctx context.Context, apiClient *api.Client, today time.Time) ([]*types.Task, error) {
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{
+		Status:    strings.Join(uiOpenStatuses, ","),
+		DueBefore: today.Add(-time.Second).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	// Checked again for servers that don't support the filters
+	var overdue []*types.Task
+	for _, task := range tasks {
+		if isOverdue(task, today) {
+			overdue = append(overdue, task)
+		}
+	}
+	return overdue, nil
+}
+
+// applyTaskBump updates a single task's dates, asking for confirmation
+// unless confirmed is true. Returns whether the task was updated.
+func applyTaskBump(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, task *types.Task, shift dateShift, today time.Time, confirmed bool) (bool, error) {
+	update := bumpTask(task, shift, today)
+	summary := describeBump(task, update)
+
+	ok, err := confirmAction(nil, confirmed, fmt.Sprintf("Bump task #%d %q (%s)?", task.ID, truncate(task.Title, 40), summary))
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Printf("Skipped task #%d\n", task.ID)
+		return false, nil
+	}
+
+	message := fmt.Sprintf("Task #%d bumped: %s", task.ID, summary)
+	if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, update, message); err != nil {
+		return false, fmt.Errorf("failed to bump task #%d: %w", task.ID, err)
+	}
+	return true, nil
+}
+
+// describeBump summarizes the date changes in a bump update.
+func describeBump(task *types.Task, update *types.TaskUpdate) string {
+	var parts []string
+	if update.DueDate != nil {
+		parts = append(parts, "due "+formatBumpChange(task.DueDate, *update.DueDate))
+	}
+	if update.ScheduledDate != nil {
+		parts = append(parts, "scheduled "+formatBumpChange(task.ScheduledDate, *update.ScheduledDate))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatBumpChange(from *time.Time, to time.Time) string {
+	// Use UTC for date-only fields to preserve the stored date
+	if from == nil {
+		return to.UTC().Format("2006-01-02")
+	}
+	return from.UTC().Format("2006-01-02") + " -> " + to.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func mustDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestParseDateShift(t *testing.T) {
+	// 2024-03-13 is a Wednesday
+	today := mustDate("2024-03-13")
+	from := mustDate("2024-01-31")
+
+	tests := []struct {
+		offset   string
+		expected string
+		wantErr  bool
+	}{
+		{offset: "+3d", expected: "2024-02-03"},
+		{offset: "-1d", expected: "2024-01-30"},
+		{offset: "+2w", expected: "2024-02-14"},
+		{offset: "+1m", expected: "2024-03-02"},
+		{offset: "tomorrow", expected: "2024-03-14"},
+		{offset: "next-week", expected: "2024-03-18"},
+		{offset: "2024-06-01", expected: "2024-06-01"},
+		{offset: "3d", wantErr: true},
+		{offset: "+3y", wantErr: true},
+		{offset: "+d", wantErr: true},
+		{offset: "later", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.offset, func(t *testing.T) {
+			shift, err := parseDateShift(tt.offset, today)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateShift(%q) error = %v, wantErr %v", tt.offset, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := shift.apply(from).Format("2006-01-02"); got != tt.expected {
+				t.Errorf("parseDateShift(%q).apply = %s, expected %s", tt.offset, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDateShiftNextWeekOnMonday(t *testing.T) {
+	shift, err := parseDateShift("next-week", mustDate("2024-03-18"))
+	if err != nil {
+		t.Fatalf("parseDateShift failed: %v", err)
+	}
+	if got := shift.apply(time.Time{}).Format("2006-01-02"); got != "2024-03-25" {
+		t.Errorf("Expected the following Monday, got %s", got)
+	}
+}
+
+func TestBumpTask(t *testing.T) {
+	today := mustDate("2024-03-13")
+	shift := dateShift{days: 7}
+
+	due := mustDate("2024-03-10")
+	scheduled := mustDate("2024-03-08")
+	update := bumpTask(&types.Task{DueDate: &due, ScheduledDate: &scheduled}, shift, today)
+	if update.DueDate == nil || update.DueDate.Format("2006-01-02") != "2024-03-17" {
+		t.Errorf("Expected due date shifted, got %v", update.DueDate)
+	}
+	if update.ScheduledDate == nil || update.ScheduledDate.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("Expected scheduled date shifted, got %v", update.ScheduledDate)
+	}
+
+	update = bumpTask(&types.Task{ScheduledDate: &scheduled}, shift, today)
+	if update.DueDate != nil {
+		t.Error("Expected due date to stay unset when only scheduled date exists")
+	}
+
+	update = bumpTask(&types.Task{}, shift, today)
+	if update.DueDate == nil || update.DueDate.Format("2006-01-02") != "2024-03-20" {
+		t.Errorf("Expected due date relative to today, got %v", update.DueDate)
+	}
+}
+
+func TestBumpTaskSkipsWeekendsAndHolidays(t *testing.T) {
+	cal, err := holidays.Load("US", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := mustDate("2024-06-26")
+	shift := dateShift{days: 7, skip: holidays.Skip{Weekends: true, Holidays: true}, calendar: cal}
+
+	// A week after 2024-06-27 is Independence Day, after 2024-06-29 a Saturday
+	due := time.Date(2024, 6, 27, 17, 0, 0, 0, time.UTC)
+	scheduled := mustDate("2024-06-29")
+	update := bumpTask(&types.Task{DueDate: &due, ScheduledDate: &scheduled}, shift, today)
+	if want := time.Date(2024, 7, 5, 17, 0, 0, 0, time.UTC); !update.DueDate.Equal(want) {
+		t.Errorf("Expected due date %v, got %v", want, update.DueDate)
+	}
+	if got := update.ScheduledDate.Format("2006-01-02"); got != "2024-07-08" {
+		t.Errorf("Expected scheduled date 2024-07-08, got %s", got)
+	}
+
+	// Absolute dates move too, and weekends are skipped without a calendar
+	saturday := mustDate("2024-07-13")
+	update = bumpTask(&types.Task{}, dateShift{absolute: &saturday, skip: holidays.Skip{Weekends: true}}, today)
+	if got := update.DueDate.Format("2006-01-02"); got != "2024-07-15" {
+		t.Errorf("Expected due date 2024-07-15, got %s", got)
+	}
+}
+
+func TestIsOverdue(t *testing.T) {
+	today := mustDate("2024-03-13")
+	past := mustDate("2024-03-12")
+	current := mustDate("2024-03-13")
+
+	tests := []struct {
+		name     string
+		task     *types.Task
+		expected bool
+	}{
+		{"past due", &types.Task{Status: "active", DueDate: &past}, true},
+		{"due today", &types.Task{Status: "active", DueDate: &current}, false},
+		{"no due date", &types.Task{Status: "active"}, false},
+		{"done", &types.Task{Status: "done", DueDate: &past}, false},
+		{"canceled", &types.Task{Status: "canceled", DueDate: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOverdue(tt.task, today); got != tt.expected {
+				t.Errorf("isOverdue() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTaskBumpNegativeOffset(t *testing.T) {
+	server := newGoldenServer(t)
+	due := mustDate("2025-03-10")
+	task := server.AddTask(types.Task{Title: "File taxes", ProjectID: 1, DueDate: &due})
+	id := strconv.Itoa(task.ID)
+
+	output := runGolden(t, "task", "bump", id, "-2d")
+	if !strings.Contains(output, "Put negative offsets after --, as in: todu task bump 42 -- -2d") {
+		t.Errorf("Expected a hint to use --, got: %s", output)
+	}
+
+	runGolden(t, "task", "bump", id, "--", "-2d")
+	if got := server.Task(task.ID).DueDate; got == nil || got.Format("2006-01-02") != "2025-03-08" {
+		t.Errorf("Expected due date moved back to 2025-03-08, got %v", got)
+	}
+}
+
+func TestTaskBumpOverdueFetchesEveryPage(t *testing.T) {
+	server := newGoldenServer(t)
+	today := localToday()
+	due := today.AddDate(0, 0, -3)
+	for i := 0; i < testsupport.MaxTaskPageSize+20; i++ {
+		server.AddTask(types.Task{Title: fmt.Sprintf("Overdue %d", i), ProjectID: 1, DueDate: &due})
+	}
+	closed := server.AddTask(types.Task{Title: "Closed", ProjectID: 1, Status: "done", DueDate: &due})
+	future := today.AddDate(0, 0, 2)
+	notDue := server.AddTask(types.Task{Title: "Not due yet", ProjectID: 1, DueDate: &future})
+
+	want := 0
+	for _, task := range server.Tasks() {
+		if isOverdue(task, today) {
+			want++
+		}
+	}
+
+	output := runGolden(t, "task", "bump", "--overdue", "+1w", "--yes")
+	if summary := fmt.Sprintf("Bumped %d of %d overdue tasks", want, want); !strings.Contains(output, summary) {
+		t.Errorf("Expected %q, got %q", summary, output[max(0, len(output)-200):])
+	}
+	for _, task := range server.Tasks() {
+		if isOverdue(task, today) {
+			t.Fatalf("Expected every overdue task bumped, task #%d is still due %v", task.ID, task.DueDate)
+		}
+	}
+	if !server.Task(closed.ID).DueDate.Equal(due) || !server.Task(notDue.ID).DueDate.Equal(future) {
+		t.Error("Expected closed tasks and tasks not yet due left alone")
+	}
+
+	filtered := false
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request, "GET /api/v1/tasks/?") && strings.Contains(request, "due_before=") && strings.Contains(request, "status=") {
+			filtered = true
+		}
+	}
+	if !filtered {
+		t.Errorf("Expected the overdue tasks filtered by the API, got %v", server.Requests())
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskCheckCmd = &cobra.Command{
+	Use:   "check <id>",
+	Short: "Show or edit a task's checklist",
+	Long: `Show or edit the checklist of a task.
+
+The checklist is kept in the task description as a markdown task list
+("- [ ] item" and "- [x] item"), so it syncs to GitHub and Forgejo issue
+bodies, which render it as checkboxes, and edits made there come back.
+Items are numbered from 1 in the order they appear; task show lists them
+with their numbers.
+
+Without flags, the checklist is shown.
+
+Examples:
+  todu task check 42
+  todu task check 42 --add "write tests" --add "update docs"
+  todu task check 42 --toggle 2
+  todu task check 42 --remove 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskCheck,
+}
+
+var (
+	// Check flags
+	taskCheckAdd    []string
+	taskCheckToggle []int
+	taskCheckRemove []int
+)
+
+func init() {
+	taskCmd.AddCommand(taskCheckCmd)
+	taskCheckCmd.Flags().StringArrayVar(&taskCheckAdd, "add", nil, "Add an unchecked item (can be specified multiple times)")
+	taskCheckCmd.Flags().IntSliceVar(&taskCheckToggle, "toggle", nil, "Check or uncheck item N (can be specified multiple times)")
+	taskCheckCmd.Flags().IntSliceVar(&taskCheckRemove, "remove", nil, "Remove item N (can be specified multiple times)")
+}
+
+func runTaskCheck(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if len(taskCheckAdd) > 0 || len(taskCheckToggle) > 0 || len(taskCheckRemove) > 0 {
+		description := ""
+		if task.Description != nil {
+			description = *task.Description
+		}
+		description, err = editChecklist(description, taskCheckToggle, taskCheckRemove, taskCheckAdd)
+		if err != nil {
+			return err
+		}
+		task, err = updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, &types.TaskUpdate{Description: &description}, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if GetOutputFormat() == "json" {
+		items := task.Checklist()
+		if items == nil {
+			items = []types.ChecklistItem{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(items)
+	}
+
+	fmt.Printf("Task #%d: %s\n\n", task.ID, task.Title)
+	displayChecklist(os.Stdout, task.Checklist())
+	return nil
+}
+
+// editChecklist applies checklist edits to a description: toggles first,
+// then removals, then additions, so item numbers refer to the checklist as
+// it was shown.
+func editChecklist(description string, toggle, remove []int, add []string) (string, error) {
+	var err error
+	for _, n := range toggle {
+		if description, err = types.ToggleChecklistItem(description, n); err != nil {
+			return "", err
+		}
+	}
+
+	// Remove from the end so earlier numbers stay valid
+	sorted := append([]int(nil), remove...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	for i, n := range sorted {
+		if i > 0 && n == sorted[i-1] {
+			continue
+		}
+		if description, err = types.RemoveChecklistItem(description, n); err != nil {
+			return "", err
+		}
+	}
+
+	for _, text := range add {
+		if description, err = types.AddChecklistItem(description, text); err != nil {
+			return "", err
+		}
+	}
+	return description, nil
+}
+
+// displayChecklist prints a checklist with its progress and numbered items.
+func displayChecklist(out io.Writer, items []types.ChecklistItem) {
+	if len(items) == 0 {
+		fmt.Fprintln(out, "No checklist items")
+		return
+	}
+
+	fmt.Fprintf(out, "Checklist: %s\n", formatChecklistProgress(items))
+	for i, item := range items {
+		mark := " "
+		if item.Done {
+			mark = "x"
+		}
+		fmt.Fprintf(out, "  %d. [%s] %s\n", i+1, mark, item.Text)
+	}
+}
+
+// formatChecklistProgress renders checklist progress as a bar with counts,
+// such as "[####------] 2/5".
+func formatChecklistProgress(items []types.ChecklistItem) string {
+	const width = 10
+	done, total := types.ChecklistProgress(items)
+	filled := done * width / total
+	return fmt.Sprintf("[%s%s] %d/%d", strings.Repeat("#", filled), strings.Repeat("-", width-filled), done, total)
+}
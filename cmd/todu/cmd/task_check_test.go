@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestEditChecklist(t *testing.T) {
+	description := "Steps:\n- [ ] one\n- [ ] two\n- [ ] three"
+
+	got, err := editChecklist(description, []int{1}, []int{2, 3, 2}, []string{"four"})
+	if err != nil {
+		t.Fatalf("editChecklist failed: %v", err)
+	}
+	if want := "Steps:\n- [x] one\n- [ ] four"; got != want {
+		t.Errorf("editChecklist() = %q, want %q", got, want)
+	}
+
+	if _, err := editChecklist(description, []int{4}, nil, nil); err == nil {
+		t.Error("Expected error toggling a missing item")
+	}
+}
+
+func TestDisplayChecklist(t *testing.T) {
+	items := []types.ChecklistItem{{Text: "write tests", Done: true}, {Text: "update docs"}}
+
+	var out bytes.Buffer
+	displayChecklist(&out, items)
+	got := out.String()
+	for _, want := range []string{"Checklist: [#####-----] 1/2", "  1. [x] write tests", "  2. [ ] update docs"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
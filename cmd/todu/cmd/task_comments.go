@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/export"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// commentExportSlugLength caps the part of an export file name taken from
+// the task title.
+const commentExportSlugLength = 50
+
+var taskCommentsCmd = &cobra.Command{
+	Use:   "comments",
+	Short: "Work with the comments of a project's tasks",
+}
+
+var taskCommentsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export each task's comment history to a markdown file",
+	Long: `Write one markdown file per task in a project to a directory, holding
+the task's full comment history with each comment's author and timestamps.
+Useful for archiving discussions before archiving or deleting a project.
+
+Tasks of every status are exported. Files are named after the task ID and
+title, such as 42-fix-login-bug.md, and an existing file is replaced, so
+exporting again brings the files up to date. Tasks without comments are
+skipped unless --all is given.
+
+Examples:
+  todu task comments export --project myproject --out comments/
+  todu task comments export --project 3 --out archive/comments --all`,
+	Args: cobra.NoArgs,
+	RunE: runTaskCommentsExport,
+}
+
+var (
+	// Comments export flags
+	taskCommentsExportProject string
+	taskCommentsExportOut     string
+	taskCommentsExportAll     bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskCommentsCmd)
+	taskCommentsCmd.AddCommand(taskCommentsExportCmd)
+
+	taskCommentsExportCmd.Flags().StringVarP(&taskCommentsExportProject, "project", "p", "", "Project ID or name (required)")
+	taskCommentsExportCmd.Flags().StringVar(&taskCommentsExportOut, "out", "", "Directory to write the files to (required)")
+	taskCommentsExportCmd.Flags().BoolVar(&taskCommentsExportAll, "all", false, "Also export tasks without comments")
+}
+
+func runTaskCommentsExport(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	if taskCommentsExportProject == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if taskCommentsExportOut == "" {
+		return fmt.Errorf("--out is required")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	projectID, err := resolveProjectID(ctx, apiClient, taskCommentsExportProject)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	project, err := apiClient.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{ProjectID: &projectID})
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	dir := export.ExpandPath(taskCommentsExportOut)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	exported, comments := 0, 0
+	for _, task := range tasks {
+		if task.ProjectID != projectID {
+			continue
+		}
+		taskComments, err := apiClient.ListComments(ctx, task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list comments of task #%d: %w", task.ID, err)
+		}
+		if len(taskComments) == 0 && !taskCommentsExportAll {
+			continue
+		}
+
+		path := filepath.Join(dir, commentExportFilename(task))
+		content := renderCommentHistory(task, project.Name, taskComments)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write comments of task #%d: %w", task.ID, err)
+		}
+		exported++
+		comments += len(taskComments)
+	}
+
+	fmt.Printf("Exported %d comment(s) from %d task(s) of %s to %s\n", comments, exported, project.Name, dir)
+	return nil
+}
+
+// commentExportFilename returns the export file name for a task's comments:
+// its ID and a slug of its title, such as 42-fix-login-bug.md. Long titles
+// are cut at the last whole word that fits commentExportSlugLength.
+func commentExportFilename(task *types.Task) string {
+	words := strings.FieldsFunc(strings.ToLower(task.Title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	slug := ""
+	for _, word := range words {
+		next := word
+		if slug != "" {
+			next = slug + "-" + word
+		}
+		if utf8.RuneCountInString(next) > commentExportSlugLength {
+			if slug == "" {
+				slug = string([]rune(word)[:commentExportSlugLength])
+			}
+			break
+		}
+		slug = next
+	}
+	if slug == "" {
+		return fmt.Sprintf("%d.md", task.ID)
+	}
+	return fmt.Sprintf("%d-%s.md", task.ID, slug)
+}
+
+// renderCommentHistory renders a task's comments, oldest first, as a
+// markdown document headed by the task's details.
+func renderCommentHistory(task *types.Task, projectName string, comments []*types.Comment) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", task.Title)
+	fmt.Fprintf(&sb, "- **Task**: #%d\n", task.ID)
+	fmt.Fprintf(&sb, "- **Project**: %s\n", projectName)
+	fmt.Fprintf(&sb, "- **Status**: %s\n", task.Status)
+	if task.SourceURL != nil && *task.SourceURL != "" {
+		fmt.Fprintf(&sb, "- **Source**: %s\n", *task.SourceURL)
+	}
+	fmt.Fprintf(&sb, "- **Created**: %s\n", task.CreatedAt.Local().Format("2006-01-02 15:04"))
+
+	sorted := append([]*types.Comment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	fmt.Fprintf(&sb, "\n## Comments (%d)\n", len(sorted))
+	if len(sorted) == 0 {
+		sb.WriteString("\nNo comments.\n")
+	}
+	for _, comment := range sorted {
+		fmt.Fprintf(&sb, "\n### %s — %s (#%d)\n\n", comment.Author, comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.ID)
+		if comment.UpdatedAt.Sub(comment.CreatedAt) >= time.Minute {
+			fmt.Fprintf(&sb, "_Edited %s_\n\n", comment.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		sb.WriteString(strings.TrimSpace(comment.Content) + "\n")
+		if summary := comment.ReactionSummary(); summary != "" {
+			fmt.Fprintf(&sb, "\nReactions: %s\n", summary)
+		}
+	}
+	return sb.String()
+}
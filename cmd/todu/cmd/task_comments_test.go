@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestCommentExportFilename(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Fix login bug", "42-fix-login-bug.md"},
+		{"  Q1 report: draft/final?! ", "42-q1-report-draft-final.md"},
+		{"Café menü", "42-café-menü.md"},
+		{"???", "42.md"},
+		{strings.Repeat("word ", 20), "42-" + strings.TrimSuffix(strings.Repeat("word-", 10), "-") + ".md"},
+	}
+	for _, tt := range tests {
+		if got := commentExportFilename(&types.Task{ID: 42, Title: tt.title}); got != tt.want {
+			t.Errorf("commentExportFilename(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestRenderCommentHistory(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	created := time.Date(2025, 6, 9, 8, 30, 0, 0, time.UTC)
+	url := "https://github.com/acme/web/issues/7"
+	task := &types.Task{ID: 42, Title: "Fix login bug", Status: "done", SourceURL: &url, CreatedAt: created}
+	comments := []*types.Comment{
+		{ID: 8, Author: "sam", Content: "Fixed in #12\n", CreatedAt: created.Add(2 * time.Hour), UpdatedAt: created.Add(3 * time.Hour)},
+		{ID: 7, Author: "erik", Content: "Can reproduce", CreatedAt: created.Add(time.Hour), UpdatedAt: created.Add(time.Hour),
+			Reactions: []types.Reaction{{Emoji: "👍", Author: "sam"}}},
+	}
+
+	want := `# Fix login bug
+
+- **Task**: #42
+- **Project**: Web
+- **Status**: done
+- **Source**: https://github.com/acme/web/issues/7
+- **Created**: 2025-06-09 08:30
+
+## Comments (2)
+
+### erik — 2025-06-09 09:30 (#7)
+
+Can reproduce
+
+Reactions: 👍 1
+
+### sam — 2025-06-09 10:30 (#8)
+
+_Edited 2025-06-09 11:30_
+
+Fixed in #12
+`
+	if got := renderCommentHistory(task, "Web", comments); got != want {
+		t.Errorf("renderCommentHistory() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTaskCommentsExport(t *testing.T) {
+	newGoldenServer(t)
+	out := filepath.Join(t.TempDir(), "comments")
+
+	output := runGolden(t, "task", "comments", "export", "--project", "Work", "--out", out)
+	if !strings.Contains(output, "Exported 1 comment(s) from 1 task(s) of Work") {
+		t.Errorf("Unexpected output: %s", output)
+	}
+
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatalf("Failed to read output directory: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "1-write-quarterly-report.md" {
+		t.Fatalf("Expected only the commented task exported, got %v", entries)
+	}
+	data, err := os.ReadFile(filepath.Join(out, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	if !strings.Contains(string(data), "### tester — ") || !strings.Contains(string(data), "Draft is in the shared folder") {
+		t.Errorf("Expected the comment with its author, got:\n%s", data)
+	}
+
+	runGolden(t, "task", "comments", "export", "--project", "Work", "--out", out, "--all")
+	if entries, _ := os.ReadDir(out); len(entries) != 2 {
+		t.Errorf("Expected --all to export both Work tasks, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edit a task's fields and description in your editor",
+	Long: `Edit a task in your editor, like kubectl edit.
+
+The task opens as markdown with YAML front matter holding its title,
+status, priority, labels, and due date, followed by the description.
+When the editor closes, the changed fields are saved as a single update.
+Closing the editor without changes leaves the task untouched.
+
+If the edited task cannot be parsed, the editor reopens with the error at
+the top. Close it again without changes to give up.
+
+If someone else changes the task while the editor is open, their changes
+to other fields are kept. If they changed a field you edited too, the
+difference is shown and you're asked before overwriting it; otherwise
+your edit is kept in a file.
+
+Example:
+  ---
+  title: Fix login redirect
+  status: active
+  priority: high
+  labels: [bug, auth]
+  due: "2026-11-01"
+  ---
+  Users land on / after logging in instead of the page they asked for.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskEdit,
+}
+
+func init() {
+	taskCmd.AddCommand(taskEditCmd)
+}
+
+// taskEditHeader explains the edit buffer. Comment lines in the front
+// matter are ignored when it is parsed.
+const taskEditHeader = `# Edit the task below and close the editor to save.
+# Only changed fields are updated. Close without changes to cancel.
+`
+
+// taskEditFields are the task fields editable in the front matter.
+type taskEditFields struct {
+	Title    string   `yaml:"title"`
+	Status   string   `yaml:"status"`
+	Priority string   `yaml:"priority"`
+	Labels   []string `yaml:"labels,flow"`
+	Due      string   `yaml:"due"`
+}
+
+func runTaskEdit(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	currentTask, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	original, err := formatTaskEdit(currentTask)
+	if err != nil {
+		return err
+	}
+
+	content := original
+	for {
+		edited, err := openEditor(content)
+		if err != nil {
+			return fmt.Errorf("failed to open editor: %w", err)
+		}
+		if edited == strings.TrimSpace(content) {
+			if content != original {
+				return fmt.Errorf("edit cancelled; no changes saved")
+			}
+			fmt.Println("No changes made")
+			return nil
+		}
+
+		taskUpdate, err := parseTaskEdit(currentTask, edited)
+		if err != nil {
+			// Reopen with the error on top, like kubectl edit
+			content = fmt.Sprintf("# Error: %v\n#\n%s\n", err, stripEditError(edited))
+			continue
+		}
+		if taskUpdate == nil {
+			fmt.Println("No changes made")
+			return nil
+		}
+
+		// Re-fetch so changes made while the editor was open aren't
+		// silently overwritten
+		latest, err := apiClient.GetTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		theirs, conflicts := taskEditConflicts(currentTask, latest, taskUpdate)
+		if len(conflicts) > 0 {
+			current, err := formatTaskEdit(latest)
+			if err != nil {
+				return err
+			}
+			yours, err := formatTaskEdit(applyTaskEdit(currentTask, taskUpdate))
+			if err != nil {
+				return err
+			}
+			if err := resolveEditConflict(fmt.Sprintf("task #%d", taskID), current, yours, conflicts); err != nil {
+				return err
+			}
+		} else if len(theirs) > 0 {
+			fmt.Printf("Task #%d also changed while you were editing (%s); those changes are kept\n", taskID, strings.Join(theirs, ", "))
+		}
+
+		return saveTaskUpdate(ctx, apiClient, hookRunner, ruleEngine, taskID, currentTask, taskUpdate)
+	}
+}
+
+// formatTaskEdit renders a task as front matter followed by its description.
+func formatTaskEdit(task *types.Task) (string, error) {
+	fields := taskEditFields{
+		Title:  task.Title,
+		Status: task.Status,
+		Labels: make([]string, len(task.Labels)),
+	}
+	if task.Priority != nil {
+		fields.Priority = *task.Priority
+	}
+	for i, label := range task.Labels {
+		fields.Labels[i] = label.Name
+	}
+	if task.DueDate != nil {
+		fields.Due = task.DueDate.Format("2006-01-02")
+	}
+
+	frontMatter, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to format task: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString(taskEditHeader)
+	b.Write(frontMatter)
+	b.WriteString("---\n")
+	if task.Description != nil && *task.Description != "" {
+		b.WriteString(*task.Description)
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// parseTaskEdit parses an edited buffer and returns an update holding the
+// fields that differ from task, or nil if nothing changed.
+func parseTaskEdit(task *types.Task, content string) (*types.TaskUpdate, error) {
+	frontMatter, description, err := splitFrontMatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields taskEditFields
+	if err := yaml.Unmarshal([]byte(frontMatter), &fields); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	update := &types.TaskUpdate{}
+	changed := false
+
+	title := strings.TrimSpace(fields.Title)
+	if title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+	if title != task.Title {
+		update.Title = &title
+		changed = true
+	}
+
+	status := strings.TrimSpace(fields.Status)
+	if status == "" {
+		return nil, fmt.Errorf("status cannot be empty")
+	}
+	if status != task.Status {
+		update.Status = &status
+		changed = true
+	}
+
+	priority := strings.TrimSpace(fields.Priority)
+	currentPriority := ""
+	if task.Priority != nil {
+		currentPriority = *task.Priority
+	}
+	if priority != currentPriority {
+		if priority == "" {
+			return nil, fmt.Errorf("priority cannot be removed; set one of %s", strings.Join(types.Priorities(), ", "))
+		}
+		if err := types.ValidatePriority(priority); err != nil {
+			return nil, err
+		}
+		update.Priority = &priority
+		changed = true
+	}
+
+	labels := make([]string, 0, len(fields.Labels))
+	for _, name := range fields.Labels {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(labels, name) {
+			labels = append(labels, name)
+		}
+	}
+	currentLabels := make([]string, len(task.Labels))
+	for i, label := range task.Labels {
+		currentLabels[i] = label.Name
+	}
+	if !sameLabels(labels, currentLabels) {
+		if len(labels) == 0 {
+			return nil, fmt.Errorf("labels cannot all be removed here; use 'todu task update --remove-label'")
+		}
+		update.Labels = labels
+		changed = true
+	}
+
+	due := strings.TrimSpace(fields.Due)
+	currentDue := ""
+	if task.DueDate != nil {
+		currentDue = task.DueDate.Format("2006-01-02")
+	}
+	if due != currentDue {
+		if due == "" {
+			return nil, fmt.Errorf("due date cannot be removed")
+		}
+		dueDate, err := time.Parse("2006-01-02", due)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date %q (use YYYY-MM-DD)", due)
+		}
+		update.DueDate = &dueDate
+		changed = true
+	}
+
+	currentDescription := ""
+	if task.Description != nil {
+		currentDescription = strings.TrimSpace(*task.Description)
+	}
+	if description != currentDescription {
+		update.Description = &description
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return update, nil
+}
+
+// taskEditChanges returns the names of the editable fields that differ
+// between two versions of a task.
+func taskEditChanges(a, b *types.Task) []string {
+	var changed []string
+	if a.Title != b.Title {
+		changed = append(changed, "title")
+	}
+	if a.Status != b.Status {
+		changed = append(changed, "status")
+	}
+	if derefString(a.Priority) != derefString(b.Priority) {
+		changed = append(changed, "priority")
+	}
+	aLabels := make([]string, len(a.Labels))
+	for i, label := range a.Labels {
+		aLabels[i] = label.Name
+	}
+	bLabels := make([]string, len(b.Labels))
+	for i, label := range b.Labels {
+		bLabels[i] = label.Name
+	}
+	if !sameLabels(aLabels, bLabels) {
+		changed = append(changed, "labels")
+	}
+	aDue, bDue := "", ""
+	if a.DueDate != nil {
+		aDue = a.DueDate.Format("2006-01-02")
+	}
+	if b.DueDate != nil {
+		bDue = b.DueDate.Format("2006-01-02")
+	}
+	if aDue != bDue {
+		changed = append(changed, "due")
+	}
+	if strings.TrimSpace(derefString(a.Description)) != strings.TrimSpace(derefString(b.Description)) {
+		changed = append(changed, "description")
+	}
+	return changed
+}
+
+// taskEditConflicts compares the task as it was when the editor opened with
+// the latest version. It returns the fields changed by someone else since,
+// and those of them the update would overwrite with a different value.
+func taskEditConflicts(original, latest *types.Task, update *types.TaskUpdate) (theirs, conflicts []string) {
+	theirs = taskEditChanges(original, latest)
+	overwritten := taskEditChanges(latest, applyTaskEdit(latest, update))
+	for _, field := range theirs {
+		if slices.Contains(overwritten, field) {
+			conflicts = append(conflicts, field)
+		}
+	}
+	return theirs, conflicts
+}
+
+// applyTaskEdit returns a copy of task with the edited fields of update
+// applied.
+func applyTaskEdit(task *types.Task, update *types.TaskUpdate) *types.Task {
+	edited := *task
+	if update.Title != nil {
+		edited.Title = *update.Title
+	}
+	if update.Status != nil {
+		edited.Status = *update.Status
+	}
+	if update.Priority != nil {
+		edited.Priority = update.Priority
+	}
+	if update.Labels != nil {
+		edited.Labels = make([]types.Label, len(update.Labels))
+		for i, name := range update.Labels {
+			edited.Labels[i] = types.Label{Name: name}
+		}
+	}
+	if update.DueDate != nil {
+		edited.DueDate = update.DueDate
+	}
+	if update.Description != nil {
+		edited.Description = update.Description
+	}
+	return &edited
+}
+
+// splitFrontMatter splits a buffer into its front matter and the trimmed
+// markdown body after it.
+func splitFrontMatter(content string) (string, string, error) {
+	content = stripEditError(content)
+	if !strings.HasPrefix(content, "---\n") {
+		return "", "", fmt.Errorf("missing front matter: the task must start with a --- line")
+	}
+
+	rest := content[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", "", fmt.Errorf("unterminated front matter: add a --- line after the fields")
+	}
+
+	body := rest[end+len("\n---"):]
+	if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+		body = body[nl+1:]
+	} else {
+		body = ""
+	}
+	return rest[:end], strings.TrimSpace(body), nil
+}
+
+// stripEditError drops the comment lines added above the front matter when
+// the editor is reopened after an error.
+func stripEditError(content string) string {
+	for strings.HasPrefix(content, "#") {
+		nl := strings.IndexByte(content, '\n')
+		if nl < 0 {
+			return ""
+		}
+		content = content[nl+1:]
+	}
+	return content
+}
+
+// sameLabels reports whether two label lists hold the same names in any
+// order.
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, name := range a {
+		if !slices.Contains(b, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// derefString returns the string s points to, or "" if it is nil.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func editTestTask() *types.Task {
+	priority := "medium"
+	description := "Users land on / after logging in."
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	return &types.Task{
+		ID:          7,
+		Title:       "Fix login: redirect",
+		Status:      "active",
+		Priority:    &priority,
+		Description: &description,
+		DueDate:     &due,
+		Labels:      []types.Label{{Name: "bug"}, {Name: "auth"}},
+	}
+}
+
+func TestTaskEditRoundTrip(t *testing.T) {
+	task := editTestTask()
+	content, err := formatTaskEdit(task)
+	if err != nil {
+		t.Fatalf("formatTaskEdit failed: %v", err)
+	}
+
+	update, err := parseTaskEdit(task, content)
+	if err != nil {
+		t.Fatalf("parseTaskEdit failed: %v", err)
+	}
+	if update != nil {
+		t.Errorf("Expected no changes for an unedited task, got %+v", update)
+	}
+}
+
+func TestParseTaskEdit(t *testing.T) {
+	task := editTestTask()
+	content, err := formatTaskEdit(task)
+	if err != nil {
+		t.Fatalf("formatTaskEdit failed: %v", err)
+	}
+
+	edited := strings.NewReplacer(
+		"status: active", "status: inprogress",
+		"labels: [bug, auth]", "labels: [auth, bug, ui]",
+		"Users land on / after logging in.", "Keep the requested page after login.",
+	).Replace(content)
+
+	update, err := parseTaskEdit(task, edited)
+	if err != nil {
+		t.Fatalf("parseTaskEdit failed: %v", err)
+	}
+	if update == nil {
+		t.Fatal("Expected an update")
+	}
+	if update.Status == nil || *update.Status != "inprogress" {
+		t.Errorf("Expected status inprogress, got %v", update.Status)
+	}
+	if strings.Join(update.Labels, ",") != "auth,bug,ui" {
+		t.Errorf("Expected labels auth,bug,ui, got %v", update.Labels)
+	}
+	if update.Description == nil || *update.Description != "Keep the requested page after login." {
+		t.Errorf("Expected new description, got %v", update.Description)
+	}
+	if update.Title != nil || update.Priority != nil || update.DueDate != nil {
+		t.Errorf("Expected unchanged fields left out, got %+v", update)
+	}
+}
+
+func TestParseTaskEditErrors(t *testing.T) {
+	task := editTestTask()
+	content, err := formatTaskEdit(task)
+	if err != nil {
+		t.Fatalf("formatTaskEdit failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing front matter", content: "just text", wantErr: "missing front matter"},
+		{name: "unterminated", content: "---\ntitle: x\n", wantErr: "unterminated front matter"},
+		{name: "empty title", content: strings.Replace(content, "title: 'Fix login: redirect'", "title: ''", 1), wantErr: "title cannot be empty"},
+		{name: "bad priority", content: strings.Replace(content, "priority: medium", "priority: urgent", 1), wantErr: "invalid priority"},
+		{name: "bad due", content: strings.Replace(content, `due: "2026-11-01"`, "due: tomorrow", 1), wantErr: "invalid due date"},
+		{name: "cleared due", content: strings.Replace(content, `due: "2026-11-01"`, "due: ''", 1), wantErr: "due date cannot be removed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTaskEdit(task, tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseTaskEditAfterError(t *testing.T) {
+	task := editTestTask()
+	content, err := formatTaskEdit(task)
+	if err != nil {
+		t.Fatalf("formatTaskEdit failed: %v", err)
+	}
+
+	reopened := "# Error: invalid priority\n#\n" + strings.Replace(content, "priority: medium", "priority: high", 1)
+	update, err := parseTaskEdit(task, reopened)
+	if err != nil {
+		t.Fatalf("parseTaskEdit failed: %v", err)
+	}
+	if update == nil || update.Priority == nil || *update.Priority != "high" {
+		t.Errorf("Expected priority high, got %+v", update)
+	}
+}
+
+func TestTaskEditConflicts(t *testing.T) {
+	original := editTestTask()
+
+	// Someone else changed the status and the title while the editor was open
+	latest := editTestTask()
+	latest.Status = "inprogress"
+	latest.Title = "Fix login redirect"
+
+	newTitle := "Fix login redirect after SSO"
+	high := "high"
+	tests := []struct {
+		name          string
+		update        *types.TaskUpdate
+		wantConflicts []string
+	}{
+		{name: "other fields", update: &types.TaskUpdate{Priority: &high}},
+		{name: "same change", update: &types.TaskUpdate{Title: &latest.Title}},
+		{name: "same field", update: &types.TaskUpdate{Title: &newTitle, Priority: &high}, wantConflicts: []string{"title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theirs, conflicts := taskEditConflicts(original, latest, tt.update)
+			if strings.Join(theirs, ",") != "title,status" {
+				t.Errorf("Expected their changes title,status, got %v", theirs)
+			}
+			if strings.Join(conflicts, ",") != strings.Join(tt.wantConflicts, ",") {
+				t.Errorf("Expected conflicts %v, got %v", tt.wantConflicts, conflicts)
+			}
+		})
+	}
+
+	if _, conflicts := taskEditConflicts(original, editTestTask(), &types.TaskUpdate{Title: &newTitle}); len(conflicts) != 0 {
+		t.Errorf("Expected no conflicts for an unchanged task, got %v", conflicts)
+	}
+}
+
+func TestResolveEditConflict(t *testing.T) {
+	defer func(orig func() (io.ReadCloser, error)) { promptTerminal = orig }(promptTerminal)
+	t.Setenv("TMPDIR", t.TempDir())
+
+	promptTerminal = func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("y\n")), nil }
+	if err := resolveEditConflict("task #7", "old", "new", []string{"title"}); err != nil {
+		t.Errorf("Expected overwrite on yes, got %v", err)
+	}
+
+	promptTerminal = func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("\n")), nil }
+	err := resolveEditConflict("task #7", "old", "my edit", []string{"title"})
+	if err == nil || !strings.Contains(err.Error(), "kept in ") {
+		t.Fatalf("Expected the edit to be kept, got %v", err)
+	}
+	path := err.Error()[strings.LastIndex(err.Error(), " ")+1:]
+	if kept, err := os.ReadFile(path); err != nil || string(kept) != "my edit\n" {
+		t.Errorf("Expected the edit in %s, got %q, %v", path, kept, err)
+	}
+}
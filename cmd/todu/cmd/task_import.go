@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks matching an external search",
+	Long: `Import the tasks matching a search in an external system.
+
+The query uses the external system's own search syntax, so a curated subset
+can be imported instead of syncing every task in a project. Search is
+supported by the GitHub and Forgejo plugins.
+
+Projects that aren't registered yet are created with the --sync-strategy
+given (push by default, so the next sync doesn't pull everything else in
+the project). Tasks that were imported before are updated, so running the
+same import again refreshes them. Comments are not imported.
+
+Examples:
+  todu task import --system github --query "is:open label:help-wanted repo:x/y"
+  todu task import --system github --query "org:myorg is:open assignee:@me"
+  todu task import --system forgejo --query "is:open label:bug" --dry-run
+  todu task import --system github --query "repo:x/y" --sync-strategy bidirectional`,
+	Args: cobra.NoArgs,
+	RunE: runTaskImport,
+}
+
+var (
+	// Import flags
+	taskImportSystem       string
+	taskImportQuery        string
+	taskImportSyncStrategy string
+	taskImportDryRun       bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskImportCmd)
+	taskImportCmd.Flags().StringVar(&taskImportSystem, "system", "", "System ID or name (required)")
+	taskImportCmd.Flags().StringVar(&taskImportQuery, "query", "", "Search query in the system's syntax (required)")
+	taskImportCmd.Flags().StringVar(&taskImportSyncStrategy, "sync-strategy", "push", "Sync strategy for created projects (pull, push, or bidirectional)")
+	taskImportCmd.Flags().BoolVar(&taskImportDryRun, "dry-run", false, "Show what would be imported without making changes")
+	_ = taskImportCmd.MarkFlagRequired("system")
+	_ = taskImportCmd.MarkFlagRequired("query")
+}
+
+// importedTask describes one task handled by an import.
+type importedTask struct {
+	Action            string `json:"action"` // "create" or "update"
+	TaskID            int    `json:"task_id,omitempty"`
+	ProjectExternalID string `json:"project_external_id"`
+	ExternalID        string `json:"external_id"`
+	Title             string `json:"title"`
+}
+
+// importReport is the result of an import.
+type importReport struct {
+	CreatedProjects []*types.Project `json:"created_projects"`
+	Tasks           []*importedTask  `json:"tasks"`
+}
+
+func runTaskImport(cmd *cobra.Command, args []string) error {
+	validStrategies := map[string]bool{
+		"pull":          true,
+		"push":          true,
+		"bidirectional": true,
+	}
+	if !validStrategies[taskImportSyncStrategy] {
+		return fmt.Errorf("invalid sync strategy %q: must be pull, push, or bidirectional", taskImportSyncStrategy)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	systemID, err := resolveSystemID(client, taskImportSystem)
+	if err != nil {
+		return err
+	}
+
+	system, err := client.GetSystem(ctx, systemID)
+	if err != nil {
+		return fmt.Errorf("failed to get system: %w", err)
+	}
+
+	p, err := createSystemPlugin(system)
+	if err != nil {
+		return err
+	}
+
+	searcher, ok := p.(plugin.Searcher)
+	if !ok {
+		return fmt.Errorf("plugin %s does not support search", system.Identifier)
+	}
+
+	results, err := searcher.SearchTasks(ctx, taskImportQuery)
+	if err != nil {
+		return fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	// Record imported tasks as synced so the next sync merges against them
+	var snapshots *sync.FileSnapshotStore
+	if !taskImportDryRun {
+		if snapshotDir, err := sync.DefaultSnapshotDir(); err == nil {
+			snapshots = sync.NewFileSnapshotStore(snapshotDir)
+		}
+	}
+
+	report, err := importSearchResults(ctx, client, hookRunner, ruleEngine, systemID, results, taskImportSyncStrategy, taskImportDryRun, snapshots)
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	if len(report.Tasks) == 0 {
+		fmt.Println("No tasks matched the query")
+		return nil
+	}
+
+	if taskImportDryRun {
+		fmt.Println("Dry run: no changes made")
+		fmt.Println()
+	}
+
+	for _, project := range report.CreatedProjects {
+		if taskImportDryRun {
+			fmt.Printf("Would create project %s (%s)\n", project.Name, project.ExternalID)
+		} else {
+			fmt.Printf("Created project %s (ID: %d, sync strategy: %s)\n", project.Name, project.ID, project.SyncStrategy)
+		}
+	}
+	if len(report.CreatedProjects) > 0 {
+		fmt.Println()
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tID\tPROJECT\tEXTERNAL ID\tTITLE")
+	for _, task := range report.Tasks {
+		id := "-"
+		if task.TaskID != 0 {
+			id = fmt.Sprintf("%d", task.TaskID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			task.Action,
+			id,
+			truncateString(task.ProjectExternalID, 30),
+			task.ExternalID,
+			truncateString(task.Title, 50),
+		)
+	}
+	w.Flush()
+
+	created, updated := 0, 0
+	for _, task := range report.Tasks {
+		if task.Action == "create" {
+			created++
+		} else {
+			updated++
+		}
+	}
+	fmt.Printf("\n%d created, %d updated\n", created, updated)
+
+	return nil
+}
+
+// importSearchResults creates or updates a task for each search result,
+// creating projects that aren't registered for the system yet. Tasks are
+// matched to existing ones by external ID, and updated through the task update
+// hooks and rules. With dryRun set, nothing is written and the report shows
+// what would change. snapshots may be nil.
+func importSearchResults(ctx context.Context, client *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, systemID int, results []*plugin.SearchResult, syncStrategy string, dryRun bool, snapshots *sync.FileSnapshotStore) (*importReport, error) {
+	report := &importReport{
+		CreatedProjects: []*types.Project{},
+		Tasks:           []*importedTask{},
+	}
+
+	existingProjects, err := client.ListProjects(ctx, &api.ProjectListOptions{SystemID: &systemID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projects := make(map[string]*types.Project)
+	for _, project := range existingProjects {
+		projects[project.ExternalID] = project
+	}
+
+	// Existing tasks by external ID, loaded once per project
+	projectTasks := make(map[string]map[string]*types.Task)
+
+	for _, result := range results {
+		project, ok := projects[result.ProjectExternalID]
+		if !ok {
+			project, err = importProject(ctx, client, systemID, result.ProjectExternalID, syncStrategy, dryRun)
+			if err != nil {
+				return nil, err
+			}
+			projects[result.ProjectExternalID] = project
+			report.CreatedProjects = append(report.CreatedProjects, project)
+		}
+
+		existing, ok := projectTasks[result.ProjectExternalID]
+		if !ok {
+			existing = make(map[string]*types.Task)
+			if project.ID != 0 {
+				tasks, err := client.ListTasks(ctx, &api.TaskListOptions{ProjectID: &project.ID})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list tasks for project %s: %w", project.Name, err)
+				}
+				for _, task := range tasks {
+					if task.ExternalID != "" {
+						existing[task.ExternalID] = task
+					}
+				}
+			}
+			projectTasks[result.ProjectExternalID] = existing
+		}
+
+		external := result.Task
+		imported := &importedTask{
+			Action:            "create",
+			ProjectExternalID: result.ProjectExternalID,
+			ExternalID:        external.ExternalID,
+			Title:             external.Title,
+		}
+
+		if toduTask, ok := existing[external.ExternalID]; ok {
+			imported.Action = "update"
+			imported.TaskID = toduTask.ID
+			if !dryRun {
+				taskUpdate := &types.TaskUpdate{
+					Title:       &external.Title,
+					Description: external.Description,
+					Status:      &external.Status,
+					Priority:    external.Priority,
+					DueDate:     external.DueDate,
+					Labels:      importLabelNames(external.Labels),
+					Assignees:   importAssigneeNames(external.Assignees),
+				}
+				if _, err := updateTaskWithHooks(ctx, client, hookRunner, ruleEngine, toduTask.ID, toduTask, taskUpdate, ""); err != nil {
+					return nil, fmt.Errorf("failed to import task %q: %w", external.Title, err)
+				}
+			}
+		} else if !dryRun {
+			taskCreate := &types.TaskCreate{
+				ExternalID:  external.ExternalID,
+				SourceURL:   external.SourceURL,
+				Title:       external.Title,
+				Description: external.Description,
+				ProjectID:   project.ID,
+				Status:      external.Status,
+				Priority:    external.Priority,
+				DueDate:     external.DueDate,
+				Labels:      importLabelNames(external.Labels),
+				Assignees:   importAssigneeNames(external.Assignees),
+			}
+			created, err := client.CreateTask(ctx, taskCreate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create task %q: %w", external.Title, err)
+			}
+			imported.TaskID = created.ID
+			existing[external.ExternalID] = created
+		}
+
+		if snapshots != nil && !dryRun {
+			if err := snapshots.Put(project.ID, external); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+
+		report.Tasks = append(report.Tasks, imported)
+	}
+
+	return report, nil
+}
+
+// importProject creates the project for an external ID found by a search.
+// With dryRun set, the project is returned without being created.
+func importProject(ctx context.Context, client *api.Client, systemID int, externalID, syncStrategy string, dryRun bool) (*types.Project, error) {
+	name := externalID
+	if idx := strings.LastIndex(externalID, "/"); idx >= 0 {
+		name = externalID[idx+1:]
+	}
+
+	projectCreate := &types.ProjectCreate{
+		Name:         name,
+		SystemID:     systemID,
+		ExternalID:   externalID,
+		Status:       "active",
+		SyncStrategy: syncStrategy,
+	}
+
+	if dryRun {
+		return &types.Project{
+			Name:         projectCreate.Name,
+			SystemID:     systemID,
+			ExternalID:   externalID,
+			Status:       projectCreate.Status,
+			SyncStrategy: syncStrategy,
+		}, nil
+	}
+
+	project, err := client.CreateProject(ctx, projectCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project %s: %w", externalID, err)
+	}
+
+	return project, nil
+}
+
+// importLabelNames returns the names of an external task's labels.
+func importLabelNames(labels []types.Label) []string {
+	if labels == nil {
+		return nil
+	}
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label.Name != "" {
+			names = append(names, label.Name)
+		}
+	}
+	return names
+}
+
+// importAssigneeNames returns the names of an external task's assignees.
+func importAssigneeNames(assignees []types.Assignee) []string {
+	if assignees == nil {
+		return nil
+	}
+	names := make([]string, 0, len(assignees))
+	for _, assignee := range assignees {
+		if assignee.Name != "" {
+			names = append(names, assignee.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestImportSearchResults(t *testing.T) {
+	var createdProjects []types.ProjectCreate
+	var createdTasks []types.TaskCreate
+	var updatedTaskIDs []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("system_id") != "3" {
+			t.Errorf("Expected projects filtered by system 3, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id": 7, "name": "known", "system_id": 3, "external_id": "acme/known"}]`))
+	})
+	mux.HandleFunc("POST /api/v1/projects/", func(w http.ResponseWriter, r *http.Request) {
+		var create types.ProjectCreate
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		createdProjects = append(createdProjects, create)
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 8, Name: create.Name, ExternalID: create.ExternalID, SyncStrategy: create.SyncStrategy})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project_id") == "7" {
+			_, _ = w.Write([]byte(`{"items": [{"id": 70, "project_id": 7, "external_id": "1", "title": "Old title"}], "total": 1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [], "total": 0}`))
+	})
+	mux.HandleFunc("POST /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		var create types.TaskCreate
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		createdTasks = append(createdTasks, create)
+		_ = json.NewEncoder(w).Encode(&types.Task{ID: 80 + len(createdTasks), ProjectID: create.ProjectID, ExternalID: create.ExternalID})
+	})
+	mux.HandleFunc("PUT /api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		updatedTaskIDs = append(updatedTaskIDs, r.PathValue("id"))
+		_, _ = w.Write([]byte(`{"id": 70}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := []*plugin.SearchResult{
+		{ProjectExternalID: "acme/known", Task: &types.Task{ExternalID: "1", Title: "Known issue", Status: "active"}},
+		{ProjectExternalID: "acme/new", Task: &types.Task{ExternalID: "2", Title: "New issue", Status: "active", Labels: []types.Label{{Name: "help-wanted"}}}},
+		{ProjectExternalID: "acme/new", Task: &types.Task{ExternalID: "3", Title: "Another", Status: "done"}},
+	}
+
+	client := api.NewClient(server.URL, "")
+	snapshots := sync.NewFileSnapshotStore(t.TempDir())
+	events := filepath.Join(t.TempDir(), "events")
+	runner := hooks.NewRunner(map[string]string{hooks.PostTaskUpdate: "echo \"$TODU_HOOK_EVENT\" >> " + events})
+
+	report, err := importSearchResults(context.Background(), client, runner, rules.NewEngine(nil, ""), 3, results, "push", false, snapshots)
+	if err != nil {
+		t.Fatalf("importSearchResults failed: %v", err)
+	}
+
+	if len(createdProjects) != 1 || createdProjects[0].Name != "new" || createdProjects[0].ExternalID != "acme/new" || createdProjects[0].SyncStrategy != "push" {
+		t.Errorf("Expected project acme/new created with push strategy, got %+v", createdProjects)
+	}
+	if len(updatedTaskIDs) != 1 || updatedTaskIDs[0] != "70" {
+		t.Errorf("Expected task 70 updated, got %v", updatedTaskIDs)
+	}
+	data, _ := os.ReadFile(events)
+	if got := strings.Fields(string(data)); len(got) != 1 || got[0] != hooks.PostTaskUpdate {
+		t.Errorf("Expected the update hook run for the updated task only, got %v", got)
+	}
+	if len(createdTasks) != 2 || createdTasks[0].ProjectID != 8 || len(createdTasks[0].Labels) != 1 {
+		t.Errorf("Unexpected created tasks: %+v", createdTasks)
+	}
+
+	actions := []string{"update", "create", "create"}
+	if len(report.Tasks) != len(actions) {
+		t.Fatalf("Expected %d report rows, got %d", len(actions), len(report.Tasks))
+	}
+	for i, action := range actions {
+		if report.Tasks[i].Action != action {
+			t.Errorf("Row %d: expected %s, got %s", i, action, report.Tasks[i].Action)
+		}
+	}
+
+	if snapshot, err := snapshots.Get(8, "2"); err != nil || snapshot == nil || snapshot.Title != "New issue" {
+		t.Errorf("Expected snapshot for imported task, got %+v (%v)", snapshot, err)
+	}
+}
+
+func TestImportSearchResults_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Unexpected %s %s during dry run", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	results := []*plugin.SearchResult{
+		{ProjectExternalID: "acme/new", Task: &types.Task{ExternalID: "2", Title: "New issue"}},
+	}
+
+	report, err := importSearchResults(context.Background(), api.NewClient(server.URL, ""), hooks.NewRunner(nil), rules.NewEngine(nil, ""), 3, results, "push", true, nil)
+	if err != nil {
+		t.Fatalf("importSearchResults failed: %v", err)
+	}
+	if len(report.CreatedProjects) != 1 || report.CreatedProjects[0].ID != 0 {
+		t.Errorf("Expected an uncreated project in the report, got %+v", report.CreatedProjects)
+	}
+	if len(report.Tasks) != 1 || report.Tasks[0].Action != "create" || report.Tasks[0].TaskID != 0 {
+		t.Errorf("Unexpected report rows: %+v", report.Tasks)
+	}
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var taskLinkExternalCmd = &cobra.Command{
+	Use:   "link-external <id>",
+	Short: "Link a task to an issue in another system",
+	Long: `Link a task to an issue in another external system.
+
+A task's own external ID ties it to an issue in its project's system. Links
+tie the same task to issues in other systems too (e.g., mirrored GitHub and
+Forgejo repositories), and sync keeps each of them up to date: syncing the
+linked issue's project pulls its changes into the task and pushes the task's
+changes to it. Comments are only synced with the task's own issue.
+
+Sync also links duplicates it detects on its own, controlled by the
+sync.duplicates setting (url, title, or off). Use this command when
+detection misses a match. 'todu task show' lists a task's links.
+
+The external ID is the repository (or project) and the issue number,
+separated by "#". The repository must be registered as a project.
+
+Examples:
+  todu task link-external 42 --system forgejo --external-id owner/repo#5
+  todu task link-external 42 --system github --external-id acme/api#118
+  todu task link-external 42 --system forgejo --external-id owner/repo#5 --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskLinkExternal,
+}
+
+var (
+	// Link flags
+	taskLinkSystem     string
+	taskLinkExternalID string
+	taskLinkRemove     bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskLinkExternalCmd)
+	taskLinkExternalCmd.Flags().StringVar(&taskLinkSystem, "system", "", "System ID or name (required)")
+	taskLinkExternalCmd.Flags().StringVar(&taskLinkExternalID, "external-id", "", "External ID as <project>#<number> (required)")
+	taskLinkExternalCmd.Flags().BoolVar(&taskLinkRemove, "remove", false, "Remove the link instead of adding it")
+	_ = taskLinkExternalCmd.MarkFlagRequired("system")
+	_ = taskLinkExternalCmd.MarkFlagRequired("external-id")
+}
+
+// externalLink describes an external task linked to a task.
+type externalLink struct {
+	TaskID            int    `json:"task_id"`
+	ProjectID         int    `json:"project_id"`
+	ProjectExternalID string `json:"project_external_id"`
+	ExternalID        string `json:"external_id"`
+
+	// DuplicateTaskID is a task already created for the external issue,
+	// which sync will keep updating until it is deleted.
+	DuplicateTaskID int `json:"duplicate_task_id,omitempty"`
+}
+
+func runTaskLinkExternal(cmd *cobra.Command, args []string) error {
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	client := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	systemID, err := resolveSystemID(client, taskLinkSystem)
+	if err != nil {
+		return err
+	}
+
+	snapshotDir, err := sync.DefaultSnapshotDir()
+	if err != nil {
+		return err
+	}
+
+	store := sync.NewFileSnapshotStore(snapshotDir)
+
+	if taskLinkRemove {
+		link, err := unlinkExternalTask(ctx, client, store, taskID, systemID, taskLinkExternalID)
+		if err != nil {
+			return err
+		}
+		if GetOutputFormat() == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(link)
+		}
+		fmt.Printf("Unlinked task #%d from %s#%s (%s)\n", link.TaskID, link.ProjectExternalID, link.ExternalID, taskLinkSystem)
+		return nil
+	}
+
+	link, err := linkExternalTask(ctx, client, store, taskID, systemID, taskLinkExternalID)
+	if err != nil {
+		return err
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(link)
+	}
+
+	fmt.Printf("Linked task #%d to %s#%s (%s)\n", link.TaskID, link.ProjectExternalID, link.ExternalID, taskLinkSystem)
+	if link.DuplicateTaskID != 0 {
+		fmt.Printf("Task #%d was already created for this issue; delete it with 'todu task delete %d'\n", link.DuplicateTaskID, link.DuplicateTaskID)
+	}
+
+	return nil
+}
+
+// linkExternalTask links the issue named by ref ("<project>#<number>") in a
+// system to a task, so syncing the issue's project syncs it with the task
+// instead of creating a duplicate.
+func linkExternalTask(ctx context.Context, client *api.Client, store sync.LinkStore, taskID, systemID int, ref string) (*externalLink, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	link, err := resolveExternalLink(ctx, client, taskID, systemID, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ProjectID == link.ProjectID {
+		if task.ExternalID == link.ExternalID {
+			return nil, fmt.Errorf("task #%d is already synced with %s", task.ID, ref)
+		}
+		return nil, fmt.Errorf("task #%d already belongs to project %s", task.ID, link.ProjectExternalID)
+	}
+
+	if linkedID, err := store.LinkedTask(link.ProjectID, link.ExternalID); err != nil {
+		return nil, fmt.Errorf("failed to load links: %w", err)
+	} else if linkedID != 0 && linkedID != task.ID {
+		return nil, fmt.Errorf("%s is already linked to task #%d", ref, linkedID)
+	}
+
+	existing, err := client.ListTasks(ctx, &api.TaskListOptions{ProjectID: &link.ProjectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	for _, t := range existing {
+		if t.ExternalID == link.ExternalID {
+			link.DuplicateTaskID = t.ID
+			break
+		}
+	}
+
+	if err := store.Link(link.ProjectID, link.ExternalID, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to save link: %w", err)
+	}
+
+	return link, nil
+}
+
+// unlinkExternalTask removes the link between a task and the issue named by
+// ref. The issue is created as a task of its own on the next pull.
+func unlinkExternalTask(ctx context.Context, client *api.Client, store sync.LinkStore, taskID, systemID int, ref string) (*externalLink, error) {
+	link, err := resolveExternalLink(ctx, client, taskID, systemID, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedID, err := store.LinkedTask(link.ProjectID, link.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load links: %w", err)
+	}
+	if linkedID != taskID {
+		return nil, fmt.Errorf("task #%d is not linked to %s", taskID, ref)
+	}
+
+	if err := store.Unlink(link.ProjectID, link.ExternalID); err != nil {
+		return nil, fmt.Errorf("failed to remove link: %w", err)
+	}
+
+	return link, nil
+}
+
+// resolveExternalLink parses ref ("<project>#<number>") and finds the
+// system's project it names.
+func resolveExternalLink(ctx context.Context, client *api.Client, taskID, systemID int, ref string) (*externalLink, error) {
+	idx := strings.LastIndex(ref, "#")
+	if idx <= 0 || idx == len(ref)-1 {
+		return nil, fmt.Errorf("invalid external ID %q: expected <project>#<number>", ref)
+	}
+	link := &externalLink{TaskID: taskID, ProjectExternalID: ref[:idx], ExternalID: ref[idx+1:]}
+
+	projects, err := client.ListProjects(ctx, &api.ProjectListOptions{SystemID: &systemID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, project := range projects {
+		if project.ExternalID == link.ProjectExternalID {
+			link.ProjectID = project.ID
+			return link, nil
+		}
+	}
+
+	return nil, fmt.Errorf("project %s is not registered for this system (add it with 'todu project add')", link.ProjectExternalID)
+}
+
+// loadTaskLinks returns the external tasks linked to a task. Links are
+// best-effort extra detail, so failures return nil.
+func loadTaskLinks(ctx context.Context, client *api.Client, taskID int) []*externalLink {
+	snapshotDir, err := sync.DefaultSnapshotDir()
+	if err != nil {
+		return nil
+	}
+	taskLinks, err := sync.NewFileSnapshotStore(snapshotDir).TaskLinks(taskID)
+	if err != nil {
+		return nil
+	}
+
+	links := make([]*externalLink, 0, len(taskLinks))
+	for _, l := range taskLinks {
+		link := &externalLink{TaskID: taskID, ProjectID: l.ProjectID, ExternalID: l.ExternalID}
+		if project, err := client.GetProject(ctx, l.ProjectID); err == nil {
+			link.ProjectExternalID = project.ExternalID
+		}
+		links = append(links, link)
+	}
+	return links
+}
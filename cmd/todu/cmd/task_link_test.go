@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/sync"
+)
+
+func TestLinkExternalTask(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"id": %s, "project_id": 1, "external_id": "5", "title": "Fix login"}`, r.PathValue("id"))
+	})
+	mux.HandleFunc("GET /api/v1/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("system_id") != "2" {
+			t.Errorf("Expected projects filtered by system 2, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id": 7, "name": "repo", "system_id": 2, "external_id": "owner/repo"}]`))
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [{"id": 70, "project_id": 7, "external_id": "5", "title": "Fix login"}], "total": 1}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "")
+	store := sync.NewFileSnapshotStore(t.TempDir())
+
+	link, err := linkExternalTask(context.Background(), client, store, 42, 2, "owner/repo#5")
+	if err != nil {
+		t.Fatalf("linkExternalTask failed: %v", err)
+	}
+	if link.ProjectID != 7 || link.ExternalID != "5" || link.DuplicateTaskID != 70 {
+		t.Errorf("Unexpected link: %+v", link)
+	}
+	if taskID, err := store.LinkedTask(7, "5"); err != nil || taskID != 42 {
+		t.Errorf("Expected link to task 42 stored, got %d (%v)", taskID, err)
+	}
+
+	if _, err := linkExternalTask(context.Background(), client, store, 43, 2, "owner/repo#5"); err == nil || !strings.Contains(err.Error(), "already linked to task #42") {
+		t.Errorf("Expected error for issue linked to another task, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{
+		{name: "missing number", ref: "owner/repo", wantErr: "expected <project>#<number>"},
+		{name: "empty number", ref: "owner/repo#", wantErr: "expected <project>#<number>"},
+		{name: "unknown project", ref: "owner/other#5", wantErr: "not registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := linkExternalTask(context.Background(), client, store, 42, 2, tt.ref)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnlinkExternalTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 7, "name": "repo", "system_id": 2, "external_id": "owner/repo"}]`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "")
+	store := sync.NewFileSnapshotStore(t.TempDir())
+	if err := store.Link(7, "5", 42); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+
+	if _, err := unlinkExternalTask(context.Background(), client, store, 43, 2, "owner/repo#5"); err == nil {
+		t.Error("Expected error unlinking an issue linked to another task")
+	}
+
+	if _, err := unlinkExternalTask(context.Background(), client, store, 42, 2, "owner/repo#5"); err != nil {
+		t.Fatalf("unlinkExternalTask failed: %v", err)
+	}
+	if taskID, _ := store.LinkedTask(7, "5"); taskID != 0 {
+		t.Errorf("Expected link removed, got task %d", taskID)
+	}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// Batch actions offered by task list -i
+const (
+	batchClose  = "close"
+	batchDelete = "delete"
+	batchLabel  = "label"
+	batchMove   = "move"
+	batchDue    = "due"
+)
+
+// taskBatch is an action chosen for a selection of tasks.
+type taskBatch struct {
+	tasks  []*types.Task
+	action string
+
+	// value is the label, target project, or due date for the action.
+	value string
+}
+
+// runTaskListInteractive lists the tasks matching the list flags for
+// selection and applies a batch action to the selected ones.
+func runTaskListInteractive(cmd *cobra.Command) error {
+	if err := requireInteractive("task list -i", "use task update, close, or delete with task IDs"); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, err := fetchListTasks(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found")
+		return nil
+	}
+
+	projectNames := make(map[int]string)
+	if projects, err := apiClient.ListProjects(ctx, nil); err == nil {
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+
+	batch, err := promptTaskBatch(os.Stdin, os.Stdout, tasks, projectNames)
+	if err != nil {
+		return err
+	}
+	if batch == nil {
+		fmt.Println("No changes made")
+		return nil
+	}
+	fmt.Println()
+
+	return applyTaskBatch(cmd, cfg, batch)
+}
+
+// promptTaskBatch lets the user toggle tasks by list number, then choose an
+// action for the selection. Returns nil if the user quits or input ends.
+func promptTaskBatch(in io.Reader, out io.Writer, tasks []*types.Task, projectNames map[int]string) (*taskBatch, error) {
+	reader := bufio.NewReader(in)
+	selected := make([]bool, len(tasks))
+
+	for {
+		fmt.Fprintln(out)
+		count := 0
+		for i, task := range tasks {
+			mark := " "
+			if selected[i] {
+				mark = "x"
+				count++
+			}
+			fmt.Fprintf(out, "%2d) [%s] %s\n", i+1, mark, taskPickerLabel(task, projectNames))
+		}
+		fmt.Fprintf(out, "%d selected. Numbers or ranges to toggle (e.g. 1 3 5-7), a for all, enter for actions, q to quit: ", count)
+
+		line, ok := readPromptLine(reader)
+		if !ok {
+			fmt.Fprintln(out)
+			return nil, nil
+		}
+
+		switch strings.ToLower(line) {
+		case "q", "quit":
+			return nil, nil
+		case "a", "all":
+			all := count < len(tasks)
+			for i := range selected {
+				selected[i] = all
+			}
+			continue
+		case "":
+			if count == 0 {
+				fmt.Fprintln(out, "Select at least one task first.")
+				continue
+			}
+		default:
+			indexes, err := parseSelection(line, len(tasks))
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			for _, i := range indexes {
+				selected[i] = !selected[i]
+			}
+			continue
+		}
+
+		var picked []*types.Task
+		for i, task := range tasks {
+			if selected[i] {
+				picked = append(picked, task)
+			}
+		}
+
+		batch, back, err := promptBatchAction(reader, out, picked)
+		if err != nil || !back {
+			return batch, err
+		}
+	}
+}
+
+// promptBatchAction asks which action to apply to the selected tasks and
+// the value it needs. Returns back=true if the user wants to change the
+// selection instead.
+func promptBatchAction(reader *bufio.Reader, out io.Writer, tasks []*types.Task) (*taskBatch, bool, error) {
+	for {
+		fmt.Fprintf(out, "Action for %d task(s): [c]lose, [d]elete, [l]abel, [m]ove, d[u]e, [b]ack, [q]uit: ", len(tasks))
+		line, ok := readPromptLine(reader)
+		if !ok {
+			fmt.Fprintln(out)
+			return nil, false, nil
+		}
+
+		batch := &taskBatch{tasks: tasks}
+		switch strings.ToLower(line) {
+		case "c", batchClose:
+			batch.action = batchClose
+		case "d", batchDelete:
+			batch.action = batchDelete
+		case "l", batchLabel:
+			batch.action = batchLabel
+		case "m", batchMove:
+			batch.action = batchMove
+		case "u", batchDue:
+			batch.action = batchDue
+		case "b", "back", "":
+			return nil, true, nil
+		case "q", "quit":
+			return nil, false, nil
+		default:
+			fmt.Fprintln(out, "Please enter c, d, l, m, u, b, or q.")
+			continue
+		}
+
+		var prompt string
+		switch batch.action {
+		case batchLabel:
+			prompt = "Label to add: "
+		case batchMove:
+			prompt = "Target project (ID or name): "
+		case batchDue:
+			prompt = "Due date (YYYY-MM-DD): "
+		default:
+			return batch, false, nil
+		}
+
+		fmt.Fprint(out, prompt)
+		value, ok := readPromptLine(reader)
+		if !ok {
+			fmt.Fprintln(out)
+			return nil, false, nil
+		}
+		if value == "" {
+			continue
+		}
+		if batch.action == batchDue {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				fmt.Fprintf(out, "Invalid date %q (use YYYY-MM-DD).\n", value)
+				continue
+			}
+		}
+		batch.value = value
+		return batch, false, nil
+	}
+}
+
+// readPromptLine reads a trimmed line. Returns false when input ends
+// without one.
+func readPromptLine(reader *bufio.Reader) (string, bool) {
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+// parseSelection parses space- or comma-separated list numbers and ranges
+// like "1 3 5-7" into zero-based indexes of a list of n items.
+func parseSelection(input string, n int) ([]int, error) {
+	var indexes []int
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' })
+	for _, field := range fields {
+		first, last, isRange := strings.Cut(field, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", field)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil {
+				return nil, fmt.Errorf("invalid selection %q", field)
+			}
+		}
+		if start < 1 || end > n || start > end {
+			return nil, fmt.Errorf("selection %q is out of range 1-%d", field, n)
+		}
+		for i := start; i <= end; i++ {
+			indexes = append(indexes, i-1)
+		}
+	}
+	return indexes, nil
+}
+
+// applyTaskBatch runs the chosen action on each selected task through the
+// single-task commands, so hooks and rules apply as usual. Failures are
+// reported per task and the rest still run.
+func applyTaskBatch(cmd *cobra.Command, cfg *config.Config, batch *taskBatch) error {
+	var run func(*cobra.Command, []string) error
+	switch batch.action {
+	case batchClose:
+		run = runTaskClose
+	case batchDelete:
+		affected := make([]string, len(batch.tasks))
+		for i, task := range batch.tasks {
+			affected[i] = fmt.Sprintf("Task #%d: %s (%s)", task.ID, task.Title, task.Status)
+		}
+		confirmed, err := confirmAction(cfg, false, fmt.Sprintf("Delete %d task(s)?", len(batch.tasks)), affected...)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Deletion cancelled")
+			return nil
+		}
+		taskDeleteYes = true
+		run = runTaskDelete
+	case batchLabel:
+		taskUpdateAddLabels = []string{batch.value}
+		run = runTaskUpdate
+	case batchMove:
+		taskMoveProject = batch.value
+		run = runTaskMove
+	case batchDue:
+		taskUpdateDue = batch.value
+		run = runTaskUpdate
+	default:
+		return fmt.Errorf("unknown batch action %q", batch.action)
+	}
+
+	failed := 0
+	for _, task := range batch.tasks {
+		if err := run(cmd, []string{strconv.Itoa(task.ID)}); err != nil {
+			fmt.Fprintf(os.Stderr, "Task #%d: %v\n", task.ID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s failed for %d of %d task(s)", batch.action, failed, len(batch.tasks))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{input: "1", want: []int{0}},
+		{input: "1 3", want: []int{0, 2}},
+		{input: "2-4,6", want: []int{1, 2, 3, 5}},
+		{input: "0", wantErr: true},
+		{input: "5-9", wantErr: true},
+		{input: "3-2", wantErr: true},
+		{input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSelection(tt.input, 6)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSelection failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func batchTestTasks() []*types.Task {
+	return []*types.Task{
+		{ID: 11, Title: "First", ProjectID: 1},
+		{ID: 12, Title: "Second", ProjectID: 1},
+		{ID: 13, Title: "Third", ProjectID: 1},
+	}
+}
+
+func TestPromptTaskBatch(t *testing.T) {
+	tasks := batchTestTasks()
+	names := map[int]string{1: "inbox"}
+
+	// Select 1-3, deselect 2, try an invalid date, then set a due date
+	in := strings.NewReader("1-3\n2\n\nu\nsoon\nu\n2026-11-01\n")
+	var out bytes.Buffer
+	batch, err := promptTaskBatch(in, &out, tasks, names)
+	if err != nil {
+		t.Fatalf("promptTaskBatch failed: %v", err)
+	}
+	if batch == nil || batch.action != batchDue || batch.value != "2026-11-01" {
+		t.Fatalf("Expected due batch, got %+v", batch)
+	}
+	if len(batch.tasks) != 2 || batch.tasks[0].ID != 11 || batch.tasks[1].ID != 13 {
+		t.Errorf("Expected tasks #11 and #13, got %v", batch.tasks)
+	}
+	if !strings.Contains(out.String(), " 2) [ ] #12 Second [inbox]") {
+		t.Errorf("Expected deselected task shown, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `Invalid date "soon"`) {
+		t.Errorf("Expected invalid date message, got:\n%s", out.String())
+	}
+}
+
+func TestPromptTaskBatchBackAndQuit(t *testing.T) {
+	tasks := batchTestTasks()
+
+	// Going back keeps the selection; close applies to all
+	batch, err := promptTaskBatch(strings.NewReader("1\n\nb\na\n\nc\n"), &bytes.Buffer{}, tasks, nil)
+	if err != nil {
+		t.Fatalf("promptTaskBatch failed: %v", err)
+	}
+	if batch == nil || batch.action != batchClose || len(batch.tasks) != 3 {
+		t.Fatalf("Expected close batch of 3 tasks, got %+v", batch)
+	}
+
+	for _, input := range []string{"q\n", "1\n", "1\n\nq\n"} {
+		batch, err := promptTaskBatch(strings.NewReader(input), &bytes.Buffer{}, tasks, nil)
+		if err != nil || batch != nil {
+			t.Errorf("Expected no batch for %q, got %+v, %v", input, batch, err)
+		}
+	}
+}
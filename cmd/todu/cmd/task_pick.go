@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskPickCmd = &cobra.Command{
+	Use:   "pick",
+	Short: "Find a task by fuzzy search and act on it",
+	Long: `Interactively pick a task by fuzzy search instead of remembering its ID.
+
+Type part of a task's title, project, labels, or ID to narrow the list, then
+enter the number of the task you want. Space-separated words must all match.
+
+The chosen task is then passed to the --then action:
+  show     Show task details (default)
+  close    Mark the task as done
+  edit     Edit the task description in your editor
+  comment  Add a comment in your editor
+
+Only open tasks outside the someday backlog are listed unless --status is
+given.
+
+Examples:
+  todu task pick
+  todu task pick --then close
+  todu task pick --project work --then comment`,
+	Args: cobra.NoArgs,
+	RunE: runTaskPick,
+}
+
+var (
+	// Pick flags
+	taskPickThen    string
+	taskPickProject string
+	taskPickStatus  string
+)
+
+func init() {
+	taskCmd.AddCommand(taskPickCmd)
+	taskPickCmd.Flags().StringVar(&taskPickThen, "then", "show", "Action for the picked task (show/close/edit/comment)")
+	taskPickCmd.Flags().StringVarP(&taskPickProject, "project", "p", "", "Only pick from this project (ID or name)")
+	taskPickCmd.Flags().StringVar(&taskPickStatus, "status", "", "Only pick tasks with this status")
+}
+
+func runTaskPick(cmd *cobra.Command, args []string) error {
+	switch taskPickThen {
+	case "show", "close", "edit", "comment":
+	default:
+		return fmt.Errorf("invalid --then value: must be 'show', 'close', 'edit', or 'comment'")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	opts := &api.TaskListOptions{Status: taskPickStatus}
+	if taskPickProject != "" {
+		projectID, err := resolveProjectID(ctx, apiClient, taskPickProject)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+		opts.ProjectID = &projectID
+	}
+
+	tasks, err := apiClient.ListTasks(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	if taskPickStatus == "" {
+		var open []*types.Task
+		for _, task := range tasks {
+			if task.Status != "done" && task.Status != "canceled" && !task.IsSomeday() {
+				open = append(open, task)
+			}
+		}
+		tasks = open
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found")
+		return nil
+	}
+
+	sortTasksByPriority(tasks)
+
+	projectNames := make(map[int]string)
+	if projects, err := apiClient.ListProjects(ctx, nil); err == nil {
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+
+	items := make([]pickerItem, len(tasks))
+	for i, task := range tasks {
+		items[i] = pickerItem{id: task.ID, label: taskPickerLabel(task, projectNames)}
+	}
+
+	if err := requireInteractive("the task picker", "use 'todu task list' to find the task ID"); err != nil {
+		return err
+	}
+	picked, ok := runPicker(os.Stdin, os.Stdout, items)
+	if !ok {
+		fmt.Println("No task selected")
+		return nil
+	}
+	fmt.Println()
+
+	taskArgs := []string{strconv.Itoa(picked.id)}
+	switch taskPickThen {
+	case "close":
+		return runTaskClose(cmd, taskArgs)
+	case "edit":
+		hookRunner, err := newHookRunner(cfg)
+		if err != nil {
+			return err
+		}
+		ruleEngine, err := loadRuleEngine(cfg)
+		if err != nil {
+			return err
+		}
+		return editTaskDescription(ctx, apiClient, hookRunner, ruleEngine, picked.id)
+	case "comment":
+		return runTaskComment(cmd, taskArgs)
+	default:
+		return runTaskShow(cmd, taskArgs)
+	}
+}
+
+// taskPickerLabel builds the searchable picker line for a task.
+func taskPickerLabel(task *types.Task, projectNames map[int]string) string {
+	project := projectNames[task.ProjectID]
+	if project == "" {
+		project = strconv.Itoa(task.ProjectID)
+	}
+
+	label := fmt.Sprintf("#%d %s [%s]", task.ID, task.Title, project)
+	if len(task.Labels) > 0 {
+		names := make([]string, len(task.Labels))
+		for i, l := range task.Labels {
+			names[i] = l.Name
+		}
+		label += " " + strings.Join(names, ",")
+	}
+	return label
+}
+
+// editTaskDescription opens a task's description in the editor and saves it
+// if it changed.
+func editTaskDescription(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, taskID int) error {
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	current := ""
+	if task.Description != nil {
+		current = *task.Description
+	}
+
+	edited, err := openEditor(current)
+	if err != nil {
+		return fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	if edited == strings.TrimSpace(current) {
+		fmt.Println("No changes made")
+		return nil
+	}
+
+	message := fmt.Sprintf("Task #%d description updated", taskID)
+	_, err = updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, taskID, task, &types.TaskUpdate{Description: &edited}, message)
+	return err
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskScheduleCmd = &cobra.Command{
+	Use:   "schedule <id> <date>",
+	Short: "Set the day you plan to work on a task",
+	Long: `Set a task's scheduled date.
+
+The scheduled date is the day you plan to work on a task. It is separate
+from the due date, which is the deadline; use "todu task update --due" to
+change that.
+
+The date can be YYYY-MM-DD, tomorrow, next-week (next Monday), or an offset
+from today such as +3d or +1w.
+
+Examples:
+  todu task schedule 42 2024-06-01
+  todu task schedule 42 tomorrow
+  todu task schedule 42 +3d`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTaskSchedule,
+}
+
+func init() {
+	taskCmd.AddCommand(taskScheduleCmd)
+}
+
+func runTaskSchedule(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	today := localToday()
+	shift, err := parseDateShift(args[1], today)
+	if err != nil {
+		return err
+	}
+	scheduled := shift.apply(today)
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	message := fmt.Sprintf("Task #%d scheduled for %s", task.ID, scheduled.Format("Mon 2006-01-02"))
+	if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, taskID, task, &types.TaskUpdate{ScheduledDate: &scheduled}, message); err != nil {
+		return fmt.Errorf("failed to schedule task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestTaskSchedule(t *testing.T) {
+	server := newGoldenServer(t)
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	task := server.AddTask(types.Task{Title: "File taxes", ProjectID: 2, DueDate: &due})
+
+	output := runGolden(t, "task", "schedule", "5", "2025-02-03")
+	if !strings.Contains(output, "Task #5 scheduled for Mon 2025-02-03") {
+		t.Errorf("Expected the new scheduled date printed, got %q", output)
+	}
+	got := server.Task(task.ID)
+	if got.ScheduledDate == nil || !got.ScheduledDate.Equal(time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected task #5 scheduled for 2025-02-03, got %v", got.ScheduledDate)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(due) {
+		t.Errorf("Expected the due date left at %v, got %v", due, got.DueDate)
+	}
+
+	runGolden(t, "task", "schedule", "5", "+3d")
+	if want := localToday().AddDate(0, 0, 3); !server.Task(task.ID).ScheduledDate.Equal(want) {
+		t.Errorf("Expected task #5 scheduled for %v, got %v", want, server.Task(task.ID).ScheduledDate)
+	}
+}
+
+func TestTaskScheduleErrors(t *testing.T) {
+	server := newGoldenServer(t)
+
+	if output := runGolden(t, "task", "schedule", "1", "someday-soon"); !strings.Contains(output, "Error:") || server.Task(1).ScheduledDate != nil {
+		t.Errorf("Expected an invalid date refused, got %q", output)
+	}
+	if output := runGolden(t, "task", "schedule", "99", "tomorrow"); !strings.Contains(output, "failed to get task") {
+		t.Errorf("Expected the missing task reported, got %q", output)
+	}
+}
+
+func TestTaskListScheduledFilters(t *testing.T) {
+	server := newGoldenServer(t)
+	for i, title := range []string{"Plan sprint", "Book venue", "Send invites"} {
+		scheduled := time.Date(2025, 1, 14+i, 0, 0, 0, 0, time.UTC)
+		server.AddTask(types.Task{Title: title, ProjectID: 1, ScheduledDate: &scheduled})
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--scheduled-before", "2025-01-15"}, []string{"Plan sprint", "Book venue"}},
+		{[]string{"--scheduled-after", "2025-01-15"}, []string{"Book venue", "Send invites"}},
+		{[]string{"--scheduled-after", "2025-01-15", "--scheduled-before", "2025-01-15"}, []string{"Book venue"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := runGolden(t, append([]string{"task", "list", "--tz", "UTC"}, tt.args...)...)
+			for _, title := range []string{"Plan sprint", "Book venue", "Send invites"} {
+				if listed, want := strings.Contains(output, title), slices.Contains(tt.want, title); listed != want {
+					t.Errorf("Expected %q listed: %v, got output:\n%s", title, want, output)
+				}
+			}
+			if strings.Contains(output, "Write quarterly report") {
+				t.Errorf("Expected unscheduled tasks left out, got output:\n%s", output)
+			}
+		})
+	}
+}
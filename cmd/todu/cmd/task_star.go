@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var taskStarCmd = &cobra.Command{
+	Use:   "star <id>",
+	Short: "Star a task so it is listed first",
+	Long: `Star a task to pin it to the top.
+
+Starred tasks are listed before all other tasks in task list and task
+pick, and always appear at the top of the daily review's Next section.
+A star is stored as the "` + types.StarredLabel + `" label, so it syncs like any other label.
+
+Use task list --starred to show only starred tasks.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskStar,
+}
+
+var taskUnstarCmd = &cobra.Command{
+	Use:   "unstar <id>",
+	Short: "Remove the star from a task",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTaskUnstar,
+}
+
+func init() {
+	taskCmd.AddCommand(taskStarCmd)
+	taskCmd.AddCommand(taskUnstarCmd)
+}
+
+func runTaskStar(cmd *cobra.Command, args []string) error {
+	return setTaskStarred(args[0], true)
+}
+
+func runTaskUnstar(cmd *cobra.Command, args []string) error {
+	return setTaskStarred(args[0], false)
+}
+
+// setTaskStarred adds or removes the starred label on a task.
+func setTaskStarred(arg string, starred bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", arg)
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.IsStarred() == starred {
+		if starred {
+			fmt.Printf("Task #%d is already starred\n", taskID)
+		} else {
+			fmt.Printf("Task #%d is not starred\n", taskID)
+		}
+		return nil
+	}
+
+	if _, err := updateTaskLabel(ctx, apiClient, hookRunner, ruleEngine, task, types.StarredLabel, starred); err != nil {
+		return err
+	}
+
+	if starred {
+		fmt.Printf("Task #%d starred: %s\n", taskID, task.Title)
+	} else {
+		fmt.Printf("Task #%d unstarred: %s\n", taskID, task.Title)
+	}
+	return nil
+}
+
+// updateTaskLabel saves the task's labels with label added or removed,
+// running the task update hooks and rules.
+func updateTaskLabel(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, task *types.Task, label string, present bool) (*types.Task, error) {
+	var labels []string
+	for _, l := range task.Labels {
+		if l.Name != label {
+			labels = append(labels, l.Name)
+		}
+	}
+	if present {
+		labels = append(labels, label)
+	}
+
+	// Removing the last label sends an empty label set
+	updated, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, &types.TaskUpdate{Labels: labels, ClearLabels: true}, "")
+	if err != nil {
+		return nil, err
+	}
+	if updated.HasLabel(label) != present {
+		return nil, fmt.Errorf("task #%d was not updated: the API did not change its labels", task.ID)
+	}
+	return updated, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskStarUnstar(t *testing.T) {
+	server := newGoldenServer(t)
+
+	labels := func(id int) string {
+		var names []string
+		for _, label := range server.Task(id).Labels {
+			names = append(names, label.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	if output := runGolden(t, "task", "star", "1"); !strings.Contains(output, "Task #1 starred") || labels(1) != "writing,starred" {
+		t.Errorf("Expected task #1 starred, got %q with labels %q", output, labels(1))
+	}
+	if output := runGolden(t, "task", "star", "1"); !strings.Contains(output, "Task #1 is already starred") {
+		t.Errorf("Expected task #1 already starred, got %q", output)
+	}
+	if output := runGolden(t, "task", "unstar", "1"); !strings.Contains(output, "Task #1 unstarred") || labels(1) != "writing" {
+		t.Errorf("Expected task #1 unstarred, got %q with labels %q", output, labels(1))
+	}
+	if output := runGolden(t, "task", "unstar", "1"); !strings.Contains(output, "Task #1 is not starred") {
+		t.Errorf("Expected task #1 not starred, got %q", output)
+	}
+
+	// Removing the star when it is the only label clears the labels
+	runGolden(t, "task", "star", "2")
+	if output := runGolden(t, "task", "unstar", "2"); !strings.Contains(output, "Task #2 unstarred") || labels(2) != "" {
+		t.Errorf("Expected task #2 unstarred, got %q with labels %q", output, labels(2))
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// taskSyncInfo describes a task's sync health for "task show".
+type taskSyncInfo struct {
+	System       string     `json:"system,omitempty"`
+	ExternalID   string     `json:"external_id,omitempty"`
+	LastPushedAt *time.Time `json:"last_pushed_at,omitempty"`
+	State        sync.State `json:"state"`
+
+	// RemoteChecked is true if the external task was fetched, so remote
+	// changes are reflected in State.
+	RemoteChecked bool `json:"remote_checked"`
+}
+
+// loadTaskSyncInfo computes a task's sync state from its last-synced
+// snapshot. With checkRemote set, the external task is fetched so remote
+// changes are detected too; a failed fetch is reported as a warning.
+func loadTaskSyncInfo(ctx context.Context, client *api.Client, task *types.Task, checkRemote bool) *taskSyncInfo {
+	info := &taskSyncInfo{
+		ExternalID:   task.ExternalID,
+		LastPushedAt: task.LastPushedAt,
+	}
+
+	project, err := client.GetProject(ctx, task.ProjectID)
+	if err == nil {
+		if system, err := client.GetSystem(ctx, project.SystemID); err == nil {
+			info.System = system.Identifier
+		}
+	}
+
+	var base *types.Task
+	if task.ExternalID != "" {
+		if snapshotDir, err := sync.DefaultSnapshotDir(); err == nil {
+			base, _ = sync.NewFileSnapshotStore(snapshotDir).Get(task.ProjectID, task.ExternalID)
+		}
+	}
+
+	var remote *types.Task
+	if checkRemote && task.ExternalID != "" && project != nil {
+		remote, err = fetchRemoteTask(ctx, client, project, task.ExternalID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			info.RemoteChecked = true
+		}
+	}
+
+	info.State = sync.TaskState(task, base, remote)
+	return info
+}
+
+// fetchRemoteTask fetches a task from its project's external system.
+func fetchRemoteTask(ctx context.Context, client *api.Client, project *types.Project, externalID string) (*types.Task, error) {
+	p, err := createProjectPlugin(ctx, client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := p.FetchTask(ctx, &project.ExternalID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch external task: %w", err)
+	}
+	return remote, nil
+}
+
+// taskSyncStates computes the sync state of listed tasks from their
+// last-synced snapshots. Changes in external systems aren't detected.
+func taskSyncStates(tasks []*types.Task) map[int]sync.State {
+	var store *sync.FileSnapshotStore
+	if snapshotDir, err := sync.DefaultSnapshotDir(); err == nil {
+		store = sync.NewFileSnapshotStore(snapshotDir)
+	}
+
+	states := make(map[int]sync.State, len(tasks))
+	for _, task := range tasks {
+		var base *types.Task
+		if store != nil && task.ExternalID != "" {
+			base, _ = store.Get(task.ProjectID, task.ExternalID)
+		}
+		if base != nil {
+			// Task lists don't include descriptions, so they aren't compared
+			compared := *base
+			compared.Description = task.Description
+			base = &compared
+		}
+		states[task.ID] = sync.TaskState(task, base, nil)
+	}
+	return states
+}
@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
 	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
@@ -51,6 +59,25 @@ func TestSortTasksByPriority(t *testing.T) {
 	}
 }
 
+func TestSortTasksByPriority_StarredFirst(t *testing.T) {
+	starred := []types.Label{{Name: types.StarredLabel}}
+	tasks := []*types.Task{
+		{ID: 1, Title: "High priority task", Priority: strPtr("high")},
+		{ID: 2, Title: "Starred low task", Priority: strPtr("low"), Labels: starred},
+		{ID: 3, Title: "Starred medium task", Priority: strPtr("medium"), Labels: starred},
+	}
+
+	sortTasksByPriority(tasks)
+
+	// Starred by priority (3, 2), then the rest (1)
+	expectedOrder := []int{3, 2, 1}
+	for i, expectedID := range expectedOrder {
+		if tasks[i].ID != expectedID {
+			t.Errorf("position %d: got ID %d, want %d", i, tasks[i].ID, expectedID)
+		}
+	}
+}
+
 func TestSortTasksByPriority_SamePrioritySortsByID(t *testing.T) {
 	// All tasks have the same priority - should sort by ID
 	tasks := []*types.Task{
@@ -109,3 +136,187 @@ func TestSortTasksByPriority_SingleTask(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestSetReminderLabels(t *testing.T) {
+	labels, err := setReminderLabels([]string{"bug", "remind:2d"}, "1d, 60m,1h")
+	if err != nil {
+		t.Fatalf("setReminderLabels failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"bug", "remind:1d", "remind:1h"}) {
+		t.Errorf("Expected old reminders replaced and duplicates dropped, got %v", labels)
+	}
+
+	if labels, _ := setReminderLabels(labels, "none"); !reflect.DeepEqual(labels, []string{"bug"}) {
+		t.Errorf("Expected none to remove reminders, got %v", labels)
+	}
+	if _, err := setReminderLabels(nil, "soon"); err == nil {
+		t.Error("Expected error for an invalid offset")
+	}
+}
+
+func TestSetTaskRefLabels(t *testing.T) {
+	labels, err := setTaskRefLabels([]string{"bug", "depends-on:3"}, types.DependsOnLabelPrefix, "4, 5,4", 7)
+	if err != nil {
+		t.Fatalf("setTaskRefLabels failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"bug", "depends-on:4", "depends-on:5"}) {
+		t.Errorf("Expected old blockers replaced and duplicates dropped, got %v", labels)
+	}
+
+	if labels, _ := setTaskRefLabels(labels, types.DependsOnLabelPrefix, "none", 7); !reflect.DeepEqual(labels, []string{"bug"}) {
+		t.Errorf("Expected none to remove blockers, got %v", labels)
+	}
+	if _, err := setTaskRefLabels(nil, types.ParentLabelPrefix, "7", 7); err == nil {
+		t.Error("Expected error for a task depending on itself")
+	}
+	if _, err := setTaskRefLabels(nil, types.ParentLabelPrefix, "soon", 7); err == nil {
+		t.Error("Expected error for an invalid task ID")
+	}
+}
+
+func TestSetFieldLabels(t *testing.T) {
+	labels, err := setFieldLabels([]string{"bug", "field:customer=initech"}, []string{"customer=acme", "story-points=3"})
+	if err != nil {
+		t.Fatalf("setFieldLabels failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"bug", "field:customer=acme", "field:story-points=3"}) {
+		t.Errorf("Expected the customer field replaced and story-points added, got %v", labels)
+	}
+
+	if labels, _ := setFieldLabels(labels, []string{"customer="}); !reflect.DeepEqual(labels, []string{"bug", "field:story-points=3"}) {
+		t.Errorf("Expected an empty value to remove the field, got %v", labels)
+	}
+	if _, err := setFieldLabels(nil, []string{"Customer=acme"}); err == nil {
+		t.Error("Expected error for an invalid field name")
+	}
+}
+
+// appendGoldenConfig adds settings to the config file runGolden uses.
+func appendGoldenConfig(t *testing.T, settings string) {
+	t.Helper()
+	f, err := os.OpenFile(goldenConfig, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("Failed to open config: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(settings); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestDateCommandsRunUpdateHooks(t *testing.T) {
+	server := newGoldenServer(t)
+	events := filepath.Join(t.TempDir(), "events")
+	appendGoldenConfig(t, "hooks:\n  post-task-update: echo \"$TODU_HOOK_EVENT\" >> "+events+"\n")
+
+	runGolden(t, "task", "bump", "1", "+1d")
+	runGolden(t, "task", "schedule", "2", "tomorrow")
+
+	runner := hooks.NewRunner(map[string]string{hooks.PostTaskUpdate: "echo \"$TODU_HOOK_EVENT\" >> " + events})
+	today := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	decisions := []planDecision{{task: server.Task(3), action: planAccept}}
+	if _, _, err := applyPlan(context.Background(), server.Client(), runner, rules.NewEngine(nil, ""), decisions, today, today); err != nil {
+		t.Fatalf("applyPlan failed: %v", err)
+	}
+
+	data, err := os.ReadFile(events)
+	if err != nil {
+		t.Fatalf("Expected the hooks to run: %v", err)
+	}
+	if got := strings.Fields(string(data)); len(got) != 3 {
+		t.Errorf("Expected a post-task-update hook per command, got %v", got)
+	}
+
+	// A failing pre- hook stops the update
+	appendGoldenConfig(t, "  pre-task-update: exit 1\n")
+	output := runGolden(t, "task", "schedule", "4", "tomorrow")
+	if !strings.Contains(output, "pre-task-update hook failed") || server.Task(4).ScheduledDate != nil {
+		t.Errorf("Expected the schedule aborted by the pre- hook, got %q", output)
+	}
+}
+
+func TestEditCommandsRunUpdateHooks(t *testing.T) {
+	server := newGoldenServer(t)
+	events := filepath.Join(t.TempDir(), "events")
+	hook := "echo \"$TODU_HOOK_EVENT\" >> " + events + "\n"
+	appendGoldenConfig(t, "hooks:\n  post-task-update: "+hook+"  post-task-close: "+hook)
+
+	habit := server.AddTemplate(types.RecurringTaskTemplate{
+		ProjectID:      2,
+		Title:          "Stretch",
+		TemplateType:   "habit",
+		RecurrenceRule: "FREQ=DAILY",
+		StartDate:      time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		IsActive:       true,
+	})
+	scheduled := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	server.AddTask(types.Task{Title: "Stretch", ProjectID: 2, TemplateID: &habit.ID, ScheduledDate: &scheduled})
+
+	for _, args := range [][]string{
+		{"task", "star", "1"},
+		{"task", "check", "1", "--add", "Outline"},
+		{"assignee", "rename", "sam", "alex", "--yes"},
+		{"template", "from-task", "3", "--every", "weekly"},
+		{"habit", "skip", "Stretch", "--date", "2025-01-15"},
+	} {
+		if output := runGolden(t, args...); strings.Contains(output, "Error") {
+			t.Fatalf("%s failed: %s", strings.Join(args, " "), output)
+		}
+	}
+
+	data, err := os.ReadFile(events)
+	if err != nil {
+		t.Fatalf("Expected the hooks to run: %v", err)
+	}
+	want := []string{hooks.PostTaskUpdate, hooks.PostTaskUpdate, hooks.PostTaskUpdate, hooks.PostTaskUpdate, hooks.PostTaskClose}
+	if got := strings.Fields(string(data)); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected an update hook per edit and a close hook for the skipped habit, got %v", got)
+	}
+}
+
+func TestDateCommandsRunUpdateRules(t *testing.T) {
+	server := newGoldenServer(t)
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	data := "rules:\n  - name: tag planned\n    when: status==active\n    then:\n      - add label planned\n    on: [update]\n"
+	if err := os.WriteFile(rulesFile, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	appendGoldenConfig(t, "rules_file: "+rulesFile+"\n")
+
+	hasLabel := func(id int) bool {
+		for _, label := range server.Task(id).Labels {
+			if label.Name == "planned" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if output := runGolden(t, "task", "bump", "1", "+1d"); !strings.Contains(output, "Rules applied: tag planned") || !hasLabel(1) {
+		t.Errorf("Expected the rule applied to the bumped task, got %q", output)
+	}
+
+	loaded, err := rules.Load(rulesFile)
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	engine := rules.NewEngine(loaded, "tester")
+	today := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	task := server.AddTask(types.Task{Title: "Call the bank", ProjectID: 2})
+	decisions := []planDecision{{task: task, action: planAccept}, {task: server.Task(3), action: planAccept}}
+	if _, _, err := applyPlan(context.Background(), server.Client(), nil, engine, decisions, today, today); err != nil {
+		t.Fatalf("applyPlan failed: %v", err)
+	}
+	if !hasLabel(task.ID) || hasLabel(3) {
+		t.Error("Expected the rule applied to the planned active task only")
+	}
+}
+
+func TestTaskUpdateRemovesLastLabel(t *testing.T) {
+	server := newGoldenServer(t)
+
+	runGolden(t, "task", "update", "1", "--remove-label", "writing")
+	if labels := server.Task(1).Labels; len(labels) != 0 {
+		t.Errorf("Expected the last label removed, got %v", labels)
+	}
+}
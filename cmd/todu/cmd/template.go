@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/internal/recurring"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/teambition/rrule-go"
@@ -28,7 +33,10 @@ is marked as done, a new task is automatically generated for the next occurrence
 
 Template types:
   - task: Standard recurring tasks
-  - habit: Habit tracking tasks`,
+  - habit: Habit tracking tasks
+  - journal: Journal prompts, such as weekly retrospective questions. The
+    description is the prompt; answer it with "todu journal add --prompt".
+    See "todu journal prompts" for the prompts due today.`,
 }
 
 var templateListCmd = &cobra.Command{
@@ -47,9 +55,17 @@ Examples:
 var templateShowCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show template details",
-	Long:  `Display detailed information about a specific recurring task template.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTemplateShow,
+	Long: `Display detailed information about a specific recurring task template,
+followed by the tasks generated from it, newest occurrence first, and how
+many of them were completed.
+
+Use --history to show only the last N occurrences.
+
+Examples:
+  todu template show 3
+  todu template show 3 --history 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateShow,
 }
 
 var templateCreateCmd = &cobra.Command{
@@ -109,6 +125,60 @@ var templateDeleteCmd = &cobra.Command{
 	RunE:  runTemplateDelete,
 }
 
+var templateFromTaskCmd = &cobra.Command{
+	Use:   "from-task <task-id>",
+	Short: "Convert an existing task into a recurring template",
+	Long: `Create a recurring task template from an existing task.
+
+The template copies the task's title, description, priority, labels, assignees,
+and project. The original task is linked to the new template as its first
+occurrence, so completing it generates the next one.
+
+--every accepts an RRULE or a simple phrase:
+  day, weekday, week, month, year
+  3 days, 2 weeks, 6 months
+  monday, mon,wed,fri
+
+The start date defaults to the task's scheduled date, then its due date,
+then today.
+
+Examples:
+  todu template from-task 42 --every week
+  todu template from-task 42 --every "2 weeks" --start-date 2024-01-05
+  todu template from-task 42 --every mon,wed,fri --type habit
+  todu template from-task 42 --every "FREQ=MONTHLY;BYMONTHDAY=1"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateFromTask,
+}
+
+var templateCatchupCmd = &cobra.Command{
+	Use:   "catchup <id>",
+	Short: "Create tasks for missed occurrences of a template",
+	Long: `Create tasks for occurrences of a template that were missed, for
+example after a vacation.
+
+Missed occurrences are those between --since and today that have no task
+linked to the template. --since defaults to the day after the template's
+most recent task, or its start date if it has none.
+
+The catch-up policy decides which missed occurrences get tasks:
+  all     Create a task for every missed occurrence
+  latest  Create a task only for the most recent one
+  none    Create no tasks
+
+The policy defaults to recurring_tasks.catchup in the config (latest if
+unset). The tasks to be created are previewed and must be confirmed unless
+--yes is given. Use --dry-run to only preview.
+
+Examples:
+  todu template catchup 3
+  todu template catchup 3 --since 2024-07-01 --policy all
+  todu template catchup 3 --policy latest --yes
+  todu template catchup 3 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateCatchup,
+}
+
 var (
 	// List flags
 	templateListActive  string
@@ -117,31 +187,55 @@ var (
 	templateListSkip    int
 	templateListLimit   int
 
+	// Show flags
+	templateShowHistory int
+
 	// Create flags
-	templateCreateTitle       string
-	templateCreateProject     string
-	templateCreateDescription string
-	templateCreatePriority    string
-	templateCreateRecurrence  string
-	templateCreateStartDate   string
-	templateCreateEndDate     string
-	templateCreateTimezone    string
-	templateCreateType        string
-	templateCreateLabels      []string
-	templateCreateAssignees   []string
+	templateCreateTitle        string
+	templateCreateProject      string
+	templateCreateDescription  string
+	templateCreatePriority     string
+	templateCreateRecurrence   string
+	templateCreateStartDate    string
+	templateCreateEndDate      string
+	templateCreateTimezone     string
+	templateCreateType         string
+	templateCreateLabels       []string
+	templateCreateAssignees    []string
+	templateCreateSkipWeekends bool
+	templateCreateSkipHolidays bool
+	templateCreateGrace        string
 
 	// Update flags
-	templateUpdateTitle       string
-	templateUpdateDescription string
-	templateUpdatePriority    string
-	templateUpdateRecurrence  string
-	templateUpdateEndDate     string
-	templateUpdateTimezone    string
-	templateUpdateLabels      []string
-	templateUpdateAssignees   []string
+	templateUpdateTitle        string
+	templateUpdateDescription  string
+	templateUpdatePriority     string
+	templateUpdateRecurrence   string
+	templateUpdateEndDate      string
+	templateUpdateTimezone     string
+	templateUpdateLabels       []string
+	templateUpdateAssignees    []string
+	templateUpdateSkipWeekends bool
+	templateUpdateSkipHolidays bool
+	templateUpdateGrace        string
 
 	// Delete flags
-	templateDeleteForce bool
+	templateDeleteYes bool
+
+	// Catchup flags
+	templateCatchupSince  string
+	templateCatchupPolicy string
+	templateCatchupDryRun bool
+	templateCatchupYes    bool
+
+	// From-task flags
+	templateFromTaskEvery        string
+	templateFromTaskStartDate    string
+	templateFromTaskTimezone     string
+	templateFromTaskType         string
+	templateFromTaskSkipWeekends bool
+	templateFromTaskSkipHolidays bool
+	templateFromTaskGrace        string
 )
 
 func init() {
@@ -153,26 +247,34 @@ func init() {
 	templateCmd.AddCommand(templateActivateCmd)
 	templateCmd.AddCommand(templateDeactivateCmd)
 	templateCmd.AddCommand(templateDeleteCmd)
+	templateCmd.AddCommand(templateFromTaskCmd)
+	templateCmd.AddCommand(templateCatchupCmd)
 
 	// List flags
 	templateListCmd.Flags().StringVar(&templateListActive, "active", "", "Filter by active status (true/false)")
-	templateListCmd.Flags().StringVar(&templateListType, "type", "", "Filter by template type (task/habit)")
+	templateListCmd.Flags().StringVar(&templateListType, "type", "", "Filter by template type (task/habit/journal)")
 	templateListCmd.Flags().StringVarP(&templateListProject, "project", "p", "", "Filter by project ID or name")
 	templateListCmd.Flags().IntVar(&templateListSkip, "skip", 0, "Number of items to skip (pagination)")
 	templateListCmd.Flags().IntVar(&templateListLimit, "limit", 50, "Limit number of results")
 
+	// Show flags
+	templateShowCmd.Flags().IntVar(&templateShowHistory, "history", 0, "Show only the last N generated occurrences (0 for all)")
+
 	// Create flags
 	templateCreateCmd.Flags().StringVar(&templateCreateTitle, "title", "", "Template title (required)")
 	templateCreateCmd.Flags().StringVarP(&templateCreateProject, "project", "p", "", "Project ID or name (required)")
 	templateCreateCmd.Flags().StringVar(&templateCreateDescription, "description", "", "Template description")
-	templateCreateCmd.Flags().StringVar(&templateCreatePriority, "priority", "", "Task priority (low/medium/high)")
+	templateCreateCmd.Flags().StringVar(&templateCreatePriority, "priority", "", "Task priority (a level from priorities in config)")
 	templateCreateCmd.Flags().StringVar(&templateCreateRecurrence, "recurrence", "", "Recurrence rule in RRULE format (required)")
 	templateCreateCmd.Flags().StringVar(&templateCreateStartDate, "start-date", "", "Start date (YYYY-MM-DD) (required)")
 	templateCreateCmd.Flags().StringVar(&templateCreateEndDate, "end-date", "", "End date (YYYY-MM-DD)")
-	templateCreateCmd.Flags().StringVar(&templateCreateTimezone, "timezone", "UTC", "IANA timezone (e.g., America/New_York, Europe/London)")
-	templateCreateCmd.Flags().StringVar(&templateCreateType, "type", "task", "Template type (task/habit)")
+	templateCreateCmd.Flags().StringVar(&templateCreateTimezone, "timezone", "", "IANA timezone (e.g., America/New_York, Europe/London; default: recurring_tasks.timezone)")
+	templateCreateCmd.Flags().StringVar(&templateCreateType, "type", "task", "Template type (task/habit/journal)")
 	templateCreateCmd.Flags().StringSliceVar(&templateCreateLabels, "label", []string{}, "Template label (repeatable)")
 	templateCreateCmd.Flags().StringSliceVar(&templateCreateAssignees, "assignee", []string{}, "Template assignee (repeatable)")
+	templateCreateCmd.Flags().BoolVar(&templateCreateSkipWeekends, "skip-weekends", false, "Move occurrences on a weekend to the next weekday")
+	templateCreateCmd.Flags().BoolVar(&templateCreateSkipHolidays, "skip-holidays", false, "Move occurrences on a holiday to the next business day")
+	templateCreateCmd.Flags().StringVar(&templateCreateGrace, "grace", "", "Completion window: days after its scheduled date an occurrence still counts (e.g., 2d)")
 
 	// Update flags
 	templateUpdateCmd.Flags().StringVar(&templateUpdateTitle, "title", "", "Update template title")
@@ -183,9 +285,28 @@ func init() {
 	templateUpdateCmd.Flags().StringVar(&templateUpdateTimezone, "timezone", "", "Update IANA timezone")
 	templateUpdateCmd.Flags().StringSliceVar(&templateUpdateLabels, "label", []string{}, "Replace labels (repeatable)")
 	templateUpdateCmd.Flags().StringSliceVar(&templateUpdateAssignees, "assignee", []string{}, "Replace assignees (repeatable)")
+	templateUpdateCmd.Flags().BoolVar(&templateUpdateSkipWeekends, "skip-weekends", false, "Move occurrences on a weekend to the next weekday (--skip-weekends=false to stop)")
+	templateUpdateCmd.Flags().BoolVar(&templateUpdateSkipHolidays, "skip-holidays", false, "Move occurrences on a holiday to the next business day (--skip-holidays=false to stop)")
+	templateUpdateCmd.Flags().StringVar(&templateUpdateGrace, "grace", "", "Completion window: days after its scheduled date an occurrence still counts (e.g., 2d, or none)")
 
 	// Delete flags
-	templateDeleteCmd.Flags().BoolVarP(&templateDeleteForce, "force", "f", false, "Skip confirmation")
+	addYesFlag(templateDeleteCmd, &templateDeleteYes)
+	addForceAlias(templateDeleteCmd, &templateDeleteYes, "f")
+
+	// Catchup flags
+	templateCatchupCmd.Flags().StringVar(&templateCatchupSince, "since", "", "Look for missed occurrences from this date (YYYY-MM-DD)")
+	templateCatchupCmd.Flags().StringVar(&templateCatchupPolicy, "policy", "", "Catch-up policy (all/latest/none), defaults to recurring_tasks.catchup")
+	templateCatchupCmd.Flags().BoolVar(&templateCatchupDryRun, "dry-run", false, "Preview without creating tasks")
+	addYesFlag(templateCatchupCmd, &templateCatchupYes)
+
+	// From-task flags
+	templateFromTaskCmd.Flags().StringVar(&templateFromTaskEvery, "every", "", "Recurrence as an RRULE or phrase like \"week\" or \"mon,fri\" (required)")
+	templateFromTaskCmd.Flags().StringVar(&templateFromTaskStartDate, "start-date", "", "Start date (YYYY-MM-DD), defaults to the task's scheduled or due date")
+	templateFromTaskCmd.Flags().StringVar(&templateFromTaskTimezone, "timezone", "", "IANA timezone (e.g., America/New_York, Europe/London; default: recurring_tasks.timezone)")
+	templateFromTaskCmd.Flags().StringVar(&templateFromTaskType, "type", "task", "Template type (task/habit/journal)")
+	templateFromTaskCmd.Flags().BoolVar(&templateFromTaskSkipWeekends, "skip-weekends", false, "Move occurrences on a weekend to the next weekday")
+	templateFromTaskCmd.Flags().BoolVar(&templateFromTaskSkipHolidays, "skip-holidays", false, "Move occurrences on a holiday to the next business day")
+	templateFromTaskCmd.Flags().StringVar(&templateFromTaskGrace, "grace", "", "Completion window: days after its scheduled date an occurrence still counts (e.g., 2d)")
 }
 
 func runTemplateList(cmd *cobra.Command, args []string) error {
@@ -199,7 +320,7 @@ func runTemplateList(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Build API options with filters
 	opts := &api.TemplateListOptions{
@@ -218,8 +339,8 @@ func runTemplateList(cmd *cobra.Command, args []string) error {
 
 	// Parse type filter
 	if templateListType != "" {
-		if templateListType != "task" && templateListType != "habit" {
-			return fmt.Errorf("invalid --type value: must be 'task' or 'habit'")
+		if err := validateTemplateType(templateListType); err != nil {
+			return err
 		}
 		opts.TemplateType = templateListType
 	}
@@ -240,15 +361,15 @@ func runTemplateList(cmd *cobra.Command, args []string) error {
 
 	// Display results
 	if GetOutputFormat() == "json" {
-		return displayTemplatesJSON(templates)
+		return displayTemplatesJSON(cmd.OutOrStdout(), templates)
 	}
 
-	return displayTemplatesTable(ctx, apiClient, templates)
+	return displayTemplatesTable(ctx, cmd.OutOrStdout(), apiClient, templates)
 }
 
-func displayTemplatesTable(ctx context.Context, apiClient *api.Client, templates []*types.RecurringTaskTemplate) error {
+func displayTemplatesTable(ctx context.Context, out io.Writer, apiClient *api.Client, templates []*types.RecurringTaskTemplate) error {
 	if len(templates) == 0 {
-		fmt.Println("No templates found")
+		fmt.Fprintln(out, "No templates found")
 		return nil
 	}
 
@@ -261,7 +382,7 @@ func displayTemplatesTable(ctx context.Context, apiClient *api.Client, templates
 		}
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ID\tTITLE\tRECURRENCE\tTYPE\tACTIVE\tPROJECT")
 	fmt.Fprintln(w, "--\t-----\t----------\t----\t------\t-------")
 
@@ -288,16 +409,16 @@ func displayTemplatesTable(ctx context.Context, apiClient *api.Client, templates
 	}
 
 	w.Flush()
-	fmt.Printf("\nTotal: %d templates\n", len(templates))
+	fmt.Fprintf(out, "\nTotal: %d templates\n", len(templates))
 	return nil
 }
 
-func displayTemplatesJSON(templates []*types.RecurringTaskTemplate) error {
+func displayTemplatesJSON(out io.Writer, templates []*types.RecurringTaskTemplate) error {
 	data, err := json.MarshalIndent(templates, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 	return nil
 }
 
@@ -317,136 +438,187 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	template, err := apiClient.GetTemplate(ctx, templateID)
 	if err != nil {
 		return fmt.Errorf("failed to get template: %w", err)
 	}
 
-	// Get tasks associated with this template
-	var associatedTasks []*types.Task
-	tasks, err := apiClient.ListTasks(ctx, &api.TaskListOptions{
-		ProjectID: &template.ProjectID,
-		Limit:     100,
+	// Get tasks associated with this template. The project filter keeps the
+	// scan bounded on servers that ignore template_id.
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{
+		ProjectID:  &template.ProjectID,
+		TemplateID: &templateID,
 	})
-	if err == nil {
-		for _, task := range tasks {
-			if task.TemplateID != nil && *task.TemplateID == templateID {
-				associatedTasks = append(associatedTasks, task)
-			}
+	if err != nil {
+		return fmt.Errorf("failed to list template tasks: %w", err)
+	}
+	var associatedTasks []*types.Task
+	for _, task := range tasks {
+		if task.TemplateID != nil && *task.TemplateID == templateID {
+			associatedTasks = append(associatedTasks, task)
+		}
+	}
+	history := recurring.History(associatedTasks, templateShowHistory)
+
+	// Habits get streak stats, with the wellness.streak_freeze allowance
+	var streak *recurring.Streak
+	if template.TemplateType == "habit" {
+		dayStart, err := configDayStart(cfg)
+		if err != nil {
+			return err
 		}
+		s := recurring.Streaks(associatedTasks, daystart.Today(dayStart), cfg.Wellness.StreakFreeze, recurring.TemplateWindow(template))
+		streak = &s
 	}
 
 	// Display results
+	out := cmd.OutOrStdout()
 	if GetOutputFormat() == "json" {
-		return displayTemplateWithTasksJSON(template, associatedTasks)
+		return displayTemplateWithTasksJSON(out, template, history, len(associatedTasks), streak)
 	}
 
-	displayTemplate(template)
-	displayAssociatedTasks(associatedTasks)
+	displayTemplate(out, template)
+	if streak != nil {
+		displayStreak(out, *streak)
+	}
+	displayAssociatedTasks(out, history, len(associatedTasks))
 	return nil
 }
 
-func displayTemplateWithTasksJSON(template *types.RecurringTaskTemplate, tasks []*types.Task) error {
+func displayTemplateWithTasksJSON(out io.Writer, template *types.RecurringTaskTemplate, tasks []*types.Task, total int, streak *recurring.Streak) error {
 	output := map[string]interface{}{
-		"template": template,
-		"tasks":    tasks,
+		"template":    template,
+		"tasks":       tasks,
+		"total_tasks": total,
+		"completed":   recurring.Completed(tasks),
+	}
+	if streak != nil {
+		output["streak"] = map[string]int{
+			"current": streak.Current,
+			"longest": streak.Longest,
+			"frozen":  streak.Frozen,
+			"skipped": streak.Skipped,
+		}
 	}
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 	return nil
 }
 
-func displayAssociatedTasks(tasks []*types.Task) {
+// displayStreak prints a habit's current and longest streaks
+func displayStreak(out io.Writer, streak recurring.Streak) {
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Streak:       %d (longest %d)\n", streak.Current, streak.Longest)
+	if streak.Frozen > 0 {
+		fmt.Fprintf(out, "              %d missed occurrence(s) covered by streak_freeze\n", streak.Frozen)
+	}
+	if streak.Skipped > 0 {
+		fmt.Fprintf(out, "              %d occurrence(s) skipped\n", streak.Skipped)
+	}
+}
+
+func displayAssociatedTasks(out io.Writer, tasks []*types.Task, total int) {
 	if len(tasks) == 0 {
 		return
 	}
 
-	fmt.Println()
-	fmt.Printf("Associated Tasks (%d):\n", len(tasks))
-	fmt.Println(strings.Repeat("-", 40))
+	fmt.Fprintln(out)
+	if len(tasks) < total {
+		fmt.Fprintf(out, "Last %d of %d Occurrences (%d completed):\n", len(tasks), total, recurring.Completed(tasks))
+	} else {
+		fmt.Fprintf(out, "Associated Tasks (%d, %d completed):\n", len(tasks), recurring.Completed(tasks))
+	}
+	fmt.Fprintln(out, strings.Repeat("-", 40))
 	for _, task := range tasks {
 		scheduled := ""
 		if task.ScheduledDate != nil {
 			// Use UTC for date-only fields to preserve the stored date
 			scheduled = task.ScheduledDate.UTC().Format("2006-01-02")
 		}
-		fmt.Printf("  #%d: %s [%s] %s\n", task.ID, truncate(task.Title, 30), task.Status, scheduled)
+		status := task.Status
+		if reason, skipped := task.Skipped(); skipped {
+			status = "– skipped"
+			if reason != "" {
+				status += ": " + reason
+			}
+		}
+		fmt.Fprintf(out, "  #%d: %s [%s] %s\n", task.ID, truncate(task.Title, 30), status, scheduled)
 	}
 }
 
-func displayTemplate(tmpl *types.RecurringTaskTemplate) {
-	fmt.Printf("Template #%d: %s\n", tmpl.ID, tmpl.Title)
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println()
+func displayTemplate(out io.Writer, tmpl *types.RecurringTaskTemplate) {
+	fmt.Fprintf(out, "Template #%d: %s\n", tmpl.ID, tmpl.Title)
+	fmt.Fprintln(out, strings.Repeat("=", 60))
+	fmt.Fprintln(out)
 
 	activeStr := "inactive"
 	if tmpl.IsActive {
 		activeStr = "active"
 	}
-	fmt.Printf("Status:       %s\n", activeStr)
-	fmt.Printf("Type:         %s\n", tmpl.TemplateType)
-	fmt.Printf("Project ID:   %d\n", tmpl.ProjectID)
+	fmt.Fprintf(out, "Status:       %s\n", activeStr)
+	fmt.Fprintf(out, "Type:         %s\n", tmpl.TemplateType)
+	fmt.Fprintf(out, "Project ID:   %d\n", tmpl.ProjectID)
 
 	if tmpl.Priority != nil {
-		fmt.Printf("Priority:     %s\n", *tmpl.Priority)
+		fmt.Fprintf(out, "Priority:     %s\n", *tmpl.Priority)
 	}
 
-	fmt.Println()
-	fmt.Printf("Recurrence:   %s\n", tmpl.RecurrenceRule)
-	fmt.Printf("              (%s)\n", rruleToHuman(tmpl.RecurrenceRule))
-	fmt.Printf("Timezone:     %s\n", tmpl.Timezone)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Recurrence:   %s\n", tmpl.RecurrenceRule)
+	fmt.Fprintf(out, "              (%s)\n", rruleToHuman(tmpl.RecurrenceRule))
+	fmt.Fprintf(out, "Timezone:     %s\n", tmpl.Timezone)
 	// Use UTC for date-only fields to preserve the stored date
-	fmt.Printf("Start Date:   %s\n", tmpl.StartDate.UTC().Format("2006-01-02"))
+	fmt.Fprintf(out, "Start Date:   %s\n", tmpl.StartDate.UTC().Format("2006-01-02"))
 	if tmpl.EndDate != nil {
-		fmt.Printf("End Date:     %s\n", tmpl.EndDate.UTC().Format("2006-01-02"))
+		fmt.Fprintf(out, "End Date:     %s\n", tmpl.EndDate.UTC().Format("2006-01-02"))
 	}
 
-	fmt.Println()
-	fmt.Printf("Created:      %s\n", tmpl.CreatedAt.Local().Format("2006-01-02 15:04:05"))
-	fmt.Printf("Updated:      %s\n", tmpl.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Created:      %s\n", tmpl.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "Updated:      %s\n", tmpl.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
 
 	if tmpl.Description != nil && *tmpl.Description != "" {
-		fmt.Println()
-		fmt.Println("Description:")
-		fmt.Println(*tmpl.Description)
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Description:")
+		fmt.Fprintln(out, *tmpl.Description)
 	}
 
 	if len(tmpl.Labels) > 0 {
-		fmt.Println()
-		fmt.Print("Labels: ")
+		fmt.Fprintln(out)
+		fmt.Fprint(out, "Labels: ")
 		labelNames := make([]string, len(tmpl.Labels))
 		for i, label := range tmpl.Labels {
 			labelNames[i] = label.Name
 		}
-		fmt.Println(strings.Join(labelNames, ", "))
+		fmt.Fprintln(out, strings.Join(labelNames, ", "))
 	}
 
 	if len(tmpl.Assignees) > 0 {
-		fmt.Println()
-		fmt.Print("Assignees: ")
+		fmt.Fprintln(out)
+		fmt.Fprint(out, "Assignees: ")
 		assigneeNames := make([]string, len(tmpl.Assignees))
 		for i, assignee := range tmpl.Assignees {
 			assigneeNames[i] = assignee.Name
 		}
-		fmt.Println(strings.Join(assigneeNames, ", "))
+		fmt.Fprintln(out, strings.Join(assigneeNames, ", "))
 	}
 
 	// Show next due datetime
 	occurrences := getNextOccurrences(tmpl, 1)
 	if len(occurrences) > 0 {
-		fmt.Println()
+		fmt.Fprintln(out)
 		// Load the template's timezone for display
 		loc, err := time.LoadLocation(tmpl.Timezone)
 		if err != nil {
 			loc = time.UTC
 		}
 		nextDue := occurrences[0].In(loc)
-		fmt.Printf("Next Due:     %s\n", nextDue.Format("Mon, Jan 2, 2006 15:04:05 MST"))
+		fmt.Fprintf(out, "Next Due:     %s\n", nextDue.Format("Mon, Jan 2, 2006 15:04:05 MST"))
 	}
 }
 
@@ -482,17 +654,21 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate template type
-	if templateCreateType != "task" && templateCreateType != "habit" {
-		return fmt.Errorf("invalid --type value: must be 'task' or 'habit'")
+	if err := validateTemplateType(templateCreateType); err != nil {
+		return err
 	}
 
 	// Validate timezone
-	if err := validateTimezone(templateCreateTimezone); err != nil {
+	timezone := templateTimezone(cfg, templateCreateTimezone)
+	if err := validateTimezone(timezone); err != nil {
 		return fmt.Errorf("invalid timezone: %w", err)
 	}
+	if warning := timezoneMismatch(timezone, time.Local, time.Now()); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Resolve project ID from flag, config default, or error
 	var projectID int
@@ -516,7 +692,7 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 		Title:          templateCreateTitle,
 		RecurrenceRule: templateCreateRecurrence,
 		StartDate:      templateCreateStartDate,
-		Timezone:       templateCreateTimezone,
+		Timezone:       timezone,
 		TemplateType:   templateCreateType,
 		IsActive:       true,
 	}
@@ -526,6 +702,9 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	if templateCreatePriority != "" {
+		if err := types.ValidatePriority(templateCreatePriority); err != nil {
+			return err
+		}
 		templateCreate.Priority = &templateCreatePriority
 	}
 
@@ -540,6 +719,21 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	if len(templateCreateLabels) > 0 {
 		templateCreate.Labels = templateCreateLabels
 	}
+	if templateCreateSkipHolidays {
+		if _, err := loadHolidayCalendar(cfg, holidays.Skip{Holidays: true}); err != nil {
+			return err
+		}
+		templateCreate.Labels = setLabel(templateCreate.Labels, types.SkipHolidaysLabel, true)
+	}
+	if templateCreateSkipWeekends {
+		templateCreate.Labels = setLabel(templateCreate.Labels, types.SkipWeekendsLabel, true)
+	}
+	if templateCreateGrace != "" {
+		templateCreate.Labels, err = setGraceLabel(templateCreate.Labels, templateCreateGrace)
+		if err != nil {
+			return err
+		}
+	}
 
 	if len(templateCreateAssignees) > 0 {
 		templateCreate.Assignees = templateCreateAssignees
@@ -551,7 +745,7 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println("Template created successfully:")
-	displayTemplate(template)
+	displayTemplate(os.Stdout, template)
 	return nil
 }
 
@@ -571,7 +765,7 @@ func runTemplateUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	// Build update request
 	templateUpdate := &types.RecurringTaskTemplateUpdate{}
@@ -585,6 +779,9 @@ func runTemplateUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if templateUpdatePriority != "" {
+		if err := types.ValidatePriority(templateUpdatePriority); err != nil {
+			return err
+		}
 		templateUpdate.Priority = &templateUpdatePriority
 	}
 
@@ -607,6 +804,9 @@ func runTemplateUpdate(cmd *cobra.Command, args []string) error {
 		if err := validateTimezone(templateUpdateTimezone); err != nil {
 			return fmt.Errorf("invalid timezone: %w", err)
 		}
+		if warning := timezoneMismatch(templateUpdateTimezone, time.Local, time.Now()); warning != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
 		templateUpdate.Timezone = &templateUpdateTimezone
 	}
 
@@ -614,6 +814,41 @@ func runTemplateUpdate(cmd *cobra.Command, args []string) error {
 		templateUpdate.Labels = templateUpdateLabels
 	}
 
+	// Skip and grace flags add or remove labels, so start from the current
+	// ones unless --label replaces them
+	skipWeekends := cmd.Flags().Changed("skip-weekends")
+	skipHolidays := cmd.Flags().Changed("skip-holidays")
+	grace := cmd.Flags().Changed("grace")
+	if skipWeekends || skipHolidays || grace {
+		if templateUpdate.Labels == nil {
+			current, err := apiClient.GetTemplate(ctx, templateID)
+			if err != nil {
+				return fmt.Errorf("failed to get template: %w", err)
+			}
+			templateUpdate.Labels = []string{}
+			for _, label := range current.Labels {
+				templateUpdate.Labels = append(templateUpdate.Labels, label.Name)
+			}
+		}
+		if skipHolidays {
+			if templateUpdateSkipHolidays {
+				if _, err := loadHolidayCalendar(cfg, holidays.Skip{Holidays: true}); err != nil {
+					return err
+				}
+			}
+			templateUpdate.Labels = setLabel(templateUpdate.Labels, types.SkipHolidaysLabel, templateUpdateSkipHolidays)
+		}
+		if skipWeekends {
+			templateUpdate.Labels = setLabel(templateUpdate.Labels, types.SkipWeekendsLabel, templateUpdateSkipWeekends)
+		}
+		if grace {
+			templateUpdate.Labels, err = setGraceLabel(templateUpdate.Labels, templateUpdateGrace)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(templateUpdateAssignees) > 0 {
 		templateUpdate.Assignees = templateUpdateAssignees
 	}
@@ -643,7 +878,7 @@ func runTemplateActivate(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	active := true
 	templateUpdate := &types.RecurringTaskTemplateUpdate{
@@ -675,7 +910,7 @@ func runTemplateDeactivate(cmd *cobra.Command, args []string) error {
 	}
 
 	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	ctx := commandContext()
 
 	active := false
 	templateUpdate := &types.RecurringTaskTemplateUpdate{
@@ -706,19 +941,24 @@ func runTemplateDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid template ID: %s", args[0])
 	}
 
-	// Confirm deletion unless --force
-	if !templateDeleteForce {
-		fmt.Printf("Are you sure you want to delete template #%d? (y/N): ", templateID)
-		var response string
-		_, _ = fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" {
-			fmt.Println("Deletion cancelled")
-			return nil
-		}
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	template, err := apiClient.GetTemplate(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
 	}
 
-	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
-	ctx := context.Background()
+	// Confirm deletion unless --yes
+	affected := fmt.Sprintf("Template #%d: %s (%s)", template.ID, template.Title, template.RecurrenceRule)
+	confirmed, err := confirmAction(cfg, templateDeleteYes, fmt.Sprintf("Delete template #%d?", templateID), affected)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
 
 	err = apiClient.DeleteTemplate(ctx, templateID)
 	if err != nil {
@@ -729,39 +969,417 @@ func runTemplateDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTemplateFromTask(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	if templateFromTaskEvery == "" {
+		return fmt.Errorf("--every is required")
+	}
+
+	recurrence, err := parseEvery(templateFromTaskEvery)
+	if err != nil {
+		return fmt.Errorf("invalid --every value: %w", err)
+	}
+
+	if err := validateTemplateType(templateFromTaskType); err != nil {
+		return err
+	}
+
+	timezone := templateTimezone(cfg, templateFromTaskTimezone)
+	if err := validateTimezone(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	if warning := timezoneMismatch(timezone, time.Local, time.Now()); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if templateFromTaskStartDate != "" {
+		if _, err := time.Parse("2006-01-02", templateFromTaskStartDate); err != nil {
+			return fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+		}
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	task, err := apiClient.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.TemplateID != nil {
+		return fmt.Errorf("task #%d is already linked to template #%d", task.ID, *task.TemplateID)
+	}
+
+	templateCreate := templateCreateFromTask(task, recurrence, templateFromTaskStartDate, timezone, templateFromTaskType)
+	if templateFromTaskSkipHolidays {
+		if _, err := loadHolidayCalendar(cfg, holidays.Skip{Holidays: true}); err != nil {
+			return err
+		}
+		templateCreate.Labels = setLabel(templateCreate.Labels, types.SkipHolidaysLabel, true)
+	}
+	if templateFromTaskSkipWeekends {
+		templateCreate.Labels = setLabel(templateCreate.Labels, types.SkipWeekendsLabel, true)
+	}
+	if templateFromTaskGrace != "" {
+		templateCreate.Labels, err = setGraceLabel(templateCreate.Labels, templateFromTaskGrace)
+		if err != nil {
+			return err
+		}
+	}
+
+	template, err := apiClient.CreateTemplate(ctx, templateCreate)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	// Link the original task as the template's first occurrence
+	if _, err := updateTaskWithHooks(ctx, apiClient, hookRunner, ruleEngine, task.ID, task, &types.TaskUpdate{TemplateID: &template.ID}, ""); err != nil {
+		return fmt.Errorf("template #%d created but failed to link task #%d: %w", template.ID, task.ID, err)
+	}
+
+	fmt.Printf("Template created from task #%d:\n", task.ID)
+	displayTemplate(os.Stdout, template)
+	return nil
+}
+
+func runTemplateCatchup(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	templateID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid template ID: %s", args[0])
+	}
+
+	policyName := cfg.RecurringTasks.Catchup
+	if templateCatchupPolicy != "" {
+		policyName = templateCatchupPolicy
+	}
+	policy, err := recurring.ParseCatchupPolicy(policyName)
+	if err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	template, err := apiClient.GetTemplate(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+	if template.TemplateType == journalTemplateType {
+		return fmt.Errorf("template #%d is a journal prompt and has no tasks to catch up", templateID)
+	}
+
+	existing, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{TemplateID: &templateID})
+	if err != nil {
+		return fmt.Errorf("failed to list template tasks: %w", err)
+	}
+
+	// Default to the day after the most recent occurrence with a task
+	var since time.Time
+	if templateCatchupSince != "" {
+		since, err = time.Parse("2006-01-02", templateCatchupSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since date format (use YYYY-MM-DD): %w", err)
+		}
+	} else if last := recurring.LastScheduled(existing); last != nil {
+		since = last.AddDate(0, 0, 1)
+	} else {
+		since = template.StartDate
+	}
+
+	missed, err := recurring.Missed(template, existing, since, localToday())
+	if err != nil {
+		return err
+	}
+
+	// Move occurrences off weekends and holidays the template skips
+	if skip := recurring.TemplateSkip(template); skip.Any() {
+		cal, err := loadHolidayCalendar(cfg, skip)
+		if err != nil {
+			return err
+		}
+		missed = recurring.Adjust(missed, existing, func(date time.Time) time.Time {
+			return cal.Next(date, skip)
+		})
+	}
+
+	if len(missed) == 0 {
+		fmt.Printf("No missed occurrences for template #%d since %s\n", template.ID, since.UTC().Format("2006-01-02"))
+		return nil
+	}
+
+	toCreate := policy.Apply(missed)
+	selected := make(map[time.Time]bool, len(toCreate))
+	for _, date := range toCreate {
+		selected[date] = true
+	}
+
+	fmt.Printf("Template #%d: %s\n", template.ID, template.Title)
+	fmt.Printf("Missed occurrences since %s (policy: %s):\n", since.UTC().Format("2006-01-02"), policy)
+	for _, date := range missed {
+		action := "skip"
+		if selected[date] {
+			action = "create"
+		}
+		fmt.Printf("  %s  %s\n", date.Format("Mon 2006-01-02"), action)
+	}
+	fmt.Println()
+
+	if len(toCreate) == 0 {
+		fmt.Println("No tasks to create")
+		return nil
+	}
+
+	if templateCatchupDryRun {
+		fmt.Printf("Dry run: would create %d task(s)\n", len(toCreate))
+		return nil
+	}
+
+	confirmed, err := confirmAction(cfg, templateCatchupYes, fmt.Sprintf("Create %d task(s)?", len(toCreate)))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Catch-up cancelled")
+		return nil
+	}
+
+	for _, date := range toCreate {
+		task, err := apiClient.CreateTask(ctx, recurring.TaskCreate(template, date))
+		if err != nil {
+			return fmt.Errorf("failed to create task for %s: %w", date.Format("2006-01-02"), err)
+		}
+		fmt.Printf("Created task #%d for %s\n", task.ID, date.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+// templateCreateFromTask builds a template create request that copies a task's fields.
+// An empty startDate falls back to the task's scheduled date, then its due date, then today.
+func templateCreateFromTask(task *types.Task, recurrence, startDate, timezone, templateType string) *types.RecurringTaskTemplateCreate {
+	if startDate == "" {
+		switch {
+		case task.ScheduledDate != nil:
+			// Use UTC for date-only fields to preserve the stored date
+			startDate = task.ScheduledDate.UTC().Format("2006-01-02")
+		case task.DueDate != nil:
+			startDate = task.DueDate.UTC().Format("2006-01-02")
+		default:
+			startDate = time.Now().Format("2006-01-02")
+		}
+	}
+
+	templateCreate := &types.RecurringTaskTemplateCreate{
+		ProjectID:      task.ProjectID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Priority:       task.Priority,
+		RecurrenceRule: recurrence,
+		StartDate:      startDate,
+		Timezone:       timezone,
+		TemplateType:   templateType,
+		IsActive:       true,
+	}
+
+	for _, label := range task.Labels {
+		templateCreate.Labels = append(templateCreate.Labels, label.Name)
+	}
+
+	for _, assignee := range task.Assignees {
+		templateCreate.Assignees = append(templateCreate.Assignees, assignee.Name)
+	}
+
+	return templateCreate
+}
+
+// everyDays maps day names and abbreviations to RRULE BYDAY codes
+var everyDays = map[string]string{
+	"mon": "MO", "monday": "MO",
+	"tue": "TU", "tues": "TU", "tuesday": "TU",
+	"wed": "WE", "wednesday": "WE",
+	"thu": "TH", "thur": "TH", "thurs": "TH", "thursday": "TH",
+	"fri": "FR", "friday": "FR",
+	"sat": "SA", "saturday": "SA",
+	"sun": "SU", "sunday": "SU",
+}
+
+// everyUnits maps interval units to RRULE frequencies
+var everyUnits = map[string]string{
+	"day":   "DAILY",
+	"week":  "WEEKLY",
+	"month": "MONTHLY",
+	"year":  "YEARLY",
+}
+
+// parseEvery converts a recurrence phrase (e.g., "week", "2 weeks", "mon,fri")
+// into an RRULE. Values that are already RRULEs are validated and returned
+// in upper case, which the rrule library requires.
+func parseEvery(every string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(every))
+	if strings.HasPrefix(value, "freq=") {
+		rule := strings.ToUpper(strings.TrimSpace(every))
+		if err := validateRRule(rule); err != nil {
+			return "", err
+		}
+		return rule, nil
+	}
+
+	switch value {
+	case "":
+		return "", fmt.Errorf("recurrence is empty")
+	case "daily":
+		return "FREQ=DAILY", nil
+	case "weekly":
+		return "FREQ=WEEKLY", nil
+	case "monthly":
+		return "FREQ=MONTHLY", nil
+	case "yearly", "annually":
+		return "FREQ=YEARLY", nil
+	case "weekday", "weekdays":
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", nil
+	case "weekend", "weekends":
+		return "FREQ=WEEKLY;BYDAY=SA,SU", nil
+	}
+
+	// "<n> <unit>" or "<unit>"
+	fields := strings.Fields(value)
+	interval := 1
+	if len(fields) == 2 {
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			if n < 1 {
+				return "", fmt.Errorf("interval must be at least 1")
+			}
+			interval = n
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 1 {
+		if freq, ok := everyUnits[strings.TrimSuffix(fields[0], "s")]; ok {
+			if interval == 1 {
+				return "FREQ=" + freq, nil
+			}
+			return fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, interval), nil
+		}
+	}
+
+	// Day names separated by commas, spaces, or "and"
+	dayNames := strings.FieldsFunc(strings.ReplaceAll(value, " and ", ","), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	codes := make([]string, 0, len(dayNames))
+	seen := make(map[string]bool)
+	for _, name := range dayNames {
+		code, ok := everyDays[name]
+		if !ok {
+			return "", fmt.Errorf("unrecognized recurrence %q (use an RRULE or a phrase like \"week\", \"2 days\", or \"mon,fri\")", every)
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return "", fmt.Errorf("unrecognized recurrence %q (use an RRULE or a phrase like \"week\", \"2 days\", or \"mon,fri\")", every)
+	}
+
+	return "FREQ=WEEKLY;BYDAY=" + strings.Join(codes, ","), nil
+}
+
 // validateRRule performs basic validation of an RRULE string
-func validateRRule(rrule string) error {
+func validateRRule(rule string) error {
 	// Basic RRULE validation - must start with FREQ=
-	if !strings.HasPrefix(strings.ToUpper(rrule), "FREQ=") {
+	if !strings.HasPrefix(strings.ToUpper(rule), "FREQ=") {
 		return fmt.Errorf("RRULE must start with FREQ=")
 	}
 
 	// Check for valid frequency
 	validFreqs := []string{"DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
-	hasValidFreq := false
-	upperRRule := strings.ToUpper(rrule)
-	for _, freq := range validFreqs {
-		if strings.Contains(upperRRule, "FREQ="+freq) {
-			hasValidFreq = true
-			break
-		}
-	}
-	if !hasValidFreq {
+	upperRRule := strings.ToUpper(rule)
+	freq, _, _ := strings.Cut(strings.TrimPrefix(upperRRule, "FREQ="), ";")
+	if !slices.Contains(validFreqs, freq) {
 		return fmt.Errorf("FREQ must be one of: DAILY, WEEKLY, MONTHLY, YEARLY")
 	}
 
 	// Basic format check - should contain only valid RRULE parts
 	validParts := regexp.MustCompile(`^(FREQ|INTERVAL|BYDAY|BYMONTH|BYMONTHDAY|BYYEARDAY|BYWEEKNO|UNTIL|COUNT|WKST)=`)
-	parts := strings.Split(rrule, ";")
+	parts := strings.Split(rule, ";")
 	for _, part := range parts {
 		if !validParts.MatchString(strings.ToUpper(part)) {
 			return fmt.Errorf("invalid RRULE part: %s", part)
 		}
 	}
 
+	// Values, such as day names and numbers, are checked by parsing
+	if _, err := rrule.StrToROption(upperRRule); err != nil {
+		return fmt.Errorf("invalid RRULE: %w", err)
+	}
+
 	return nil
 }
 
+// journalTemplateType is the template type whose occurrences are journal
+// prompts rather than tasks.
+const journalTemplateType = "journal"
+
+// setGraceLabel replaces any grace label in labels with one for the
+// completion window grace. A grace of "none" only removes it.
+func setGraceLabel(labels []string, grace string) ([]string, error) {
+	var result []string
+	for _, name := range labels {
+		if !strings.HasPrefix(name, types.GraceLabelPrefix) {
+			result = append(result, name)
+		}
+	}
+	if grace == "none" {
+		return result, nil
+	}
+	days, err := types.ParseGrace(grace)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, types.GraceLabel(days)), nil
+}
+
+// validateTemplateType validates a --type value
+func validateTemplateType(templateType string) error {
+	switch templateType {
+	case "task", "habit", journalTemplateType:
+		return nil
+	}
+	return fmt.Errorf("invalid --type value: must be 'task', 'habit', or 'journal'")
+}
+
 // validateTimezone validates an IANA timezone string
 func validateTimezone(tz string) error {
 	_, err := time.LoadLocation(tz)
@@ -782,16 +1400,17 @@ func rruleToHuman(rrule string) string {
 	}
 
 	freq := parts["FREQ"]
-	interval := parts["INTERVAL"]
 	byday := parts["BYDAY"]
 	bymonthday := parts["BYMONTHDAY"]
 
-	// Parse interval
+	// Parse interval; a rule with an invalid one is shown as it is
 	intervalNum := 1
-	if interval != "" {
-		if n, err := strconv.Atoi(interval); err == nil {
-			intervalNum = n
+	if interval, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(interval)
+		if err != nil || n < 1 {
+			return rrule
 		}
+		intervalNum = n
 	}
 
 	var result string
@@ -866,40 +1485,12 @@ func formatDays(byday string) string {
 	return strings.Join(result, ", ")
 }
 
-// getNextOccurrences calculates the next n occurrences for a template
+// getNextOccurrences calculates the next n occurrences for a template, in
+// the template's timezone
 func getNextOccurrences(tmpl *types.RecurringTaskTemplate, count int) []time.Time {
-	// Load timezone
-	loc, err := time.LoadLocation(tmpl.Timezone)
-	if err != nil {
-		loc = time.UTC
-	}
-
-	// Parse the RRULE
-	ruleStr := "DTSTART:" + tmpl.StartDate.In(loc).Format("20060102T150405Z") + "\nRRULE:" + tmpl.RecurrenceRule
-	rule, err := rrule.StrToRRule(ruleStr)
+	occurrences, err := recurring.Next(tmpl, time.Now(), count)
 	if err != nil {
 		return nil
 	}
-
-	// Get occurrences starting from now
-	now := time.Now().In(loc)
-
-	// If there's an end date, use it
-	var endDate time.Time
-	if tmpl.EndDate != nil {
-		endDate = tmpl.EndDate.In(loc)
-	} else {
-		// Default to 1 year from now if no end date
-		endDate = now.AddDate(1, 0, 0)
-	}
-
-	// Get occurrences between now and end date
-	occurrences := rule.Between(now, endDate, true)
-
-	// Limit to requested count
-	if len(occurrences) > count {
-		occurrences = occurrences[:count]
-	}
-
 	return occurrences
 }
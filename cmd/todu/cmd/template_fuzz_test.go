@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+func FuzzParseEvery(f *testing.F) {
+	for _, seed := range []string{
+		"week", "2 weeks", "3 days", "month", "yearly", "weekdays", "weekend",
+		"mon,fri", "Mon and Wed", "tue thu", " sat ", "mon,mon",
+		"FREQ=DAILY", "freq=weekly;byday=mo", "FREQ=MONTHLY;BYMONTHDAY=1",
+		"0 days", "-1 weeks", "2", "fortnight", "", "FREQ=HOURLY",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, every string) {
+		rule, err := parseEvery(every)
+		if err != nil {
+			return
+		}
+		if err := validateRRule(rule); err != nil {
+			t.Fatalf("parseEvery(%q) = %q, which isn't valid: %v", every, rule, err)
+		}
+		if _, err := rrule.StrToRRule(rule); err != nil {
+			t.Fatalf("parseEvery(%q) = %q, which the rrule library rejects: %v", every, rule, err)
+		}
+		if again, err := parseEvery(rule); err != nil || again != rule {
+			t.Fatalf("parseEvery(%q) = %q, but parsing that gives %q, %v", every, rule, again, err)
+		}
+		if human := rruleToHuman(rule); human == "" || strings.Contains(human, "FREQ=") {
+			t.Fatalf("rruleToHuman(%q) = %q, want a description", rule, human)
+		}
+	})
+}
+
+func FuzzRRuleToHuman(f *testing.F) {
+	for _, seed := range []string{
+		"FREQ=DAILY", "FREQ=DAILY;INTERVAL=3", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		"FREQ=MONTHLY;BYMONTHDAY=15", "FREQ=YEARLY;INTERVAL=2", "freq=weekly;byday=sa",
+		"FREQ=WEEKLY;INTERVAL=0", "FREQ=DAILY;INTERVAL=-2", "FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=", "=;=", ";;", "INTERVAL=2", "FREQ=SECONDLY",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, rule string) {
+		human := rruleToHuman(rule)
+		if rule != "" && human == "" {
+			t.Fatalf("rruleToHuman(%q) is empty", rule)
+		}
+
+		parts := make(map[string]string)
+		for _, part := range strings.Split(rule, ";") {
+			if key, value, ok := strings.Cut(part, "="); ok {
+				parts[strings.ToUpper(key)] = value
+			}
+		}
+		switch strings.ToUpper(parts["FREQ"]) {
+		case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		default:
+			return
+		}
+		if interval, ok := parts["INTERVAL"]; ok {
+			if n, err := strconv.Atoi(interval); err != nil || n < 1 {
+				if human != rule {
+					t.Fatalf("rruleToHuman(%q) = %q, want the rule shown as it is for an invalid interval", rule, human)
+				}
+				return
+			}
+		}
+		if strings.Contains(human, "FREQ=") {
+			t.Fatalf("rruleToHuman(%q) = %q, want a description", rule, human)
+		}
+	})
+}
+
+// TestDateFiltersCoverLocalDay checks that the UTC bounds of a date filter
+// are the first and last second of the local day, for every day of twenty
+// years in timezones with DST changes at midnight and at odd offsets.
+func TestDateFiltersCoverLocalDay(t *testing.T) {
+	defer func() { dateTZ = "" }()
+
+	for _, zone := range []string{"UTC", "America/New_York", "Europe/London", "America/Havana", "America/Sao_Paulo", "Australia/Lord_Howe", "Pacific/Apia", "Asia/Kolkata"} {
+		dateTZ = zone
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			t.Fatalf("LoadLocation(%s) failed: %v", zone, err)
+		}
+
+		for day := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() < 2030; day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			if noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc); noon.Format("2006-01-02") != date {
+				continue // the timezone skipped this day
+			}
+
+			startValue, err := parseDateToUTCStart(date)
+			if err != nil {
+				t.Fatalf("parseDateToUTCStart(%s) in %s failed: %v", date, zone, err)
+			}
+			endValue, err := parseDateToUTCEnd(date)
+			if err != nil {
+				t.Fatalf("parseDateToUTCEnd(%s) in %s failed: %v", date, zone, err)
+			}
+			start, _ := time.Parse(time.RFC3339, startValue)
+			end, _ := time.Parse(time.RFC3339, endValue)
+
+			localDay := func(t time.Time) string { return t.In(loc).Format("2006-01-02") }
+			if localDay(start) != date || localDay(start.Add(-time.Second)) == date {
+				t.Errorf("%s in %s: start %s isn't the first second of the day", date, zone, start.In(loc))
+			}
+			if localDay(end) != date || localDay(end.Add(time.Second)) == date {
+				t.Errorf("%s in %s: end %s isn't the last second of the day", date, zone, end.In(loc))
+			}
+		}
+	}
+}
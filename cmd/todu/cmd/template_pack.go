@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var templateExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export templates as a shareable pack",
+	Long: `Export templates to a YAML pack that can be shared or imported into
+another todu instance with 'todu template import'.
+
+Packs keep each template's title, type, recurrence, description, priority,
+timezone, and labels. IDs, projects, assignees, and start dates are left
+out, since they belong to this instance.
+
+Examples:
+  todu template export --type habit --out habits.yaml
+  todu template export --project wellness --active > wellness.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateExport,
+}
+
+var templateImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import templates from a pack",
+	Long: `Create the templates in a YAML pack (see 'todu template export') in a
+project. Use - to read the pack from stdin.
+
+Imported templates start today unless --start-date is given, so they don't
+catch up on occurrences from before the import. Templates without a
+timezone in the pack use --timezone or recurring_tasks.timezone.
+
+A template whose title matches one already in the project (ignoring case)
+is handled by --on-conflict:
+
+  skip     leave the existing template alone (default)
+  replace  update the existing template with the pack's fields
+  rename   create it anyway, with " (2)" or the next free number added
+
+Examples:
+  todu template import habits.yaml --project wellness
+  todu template import habits.yaml --project wellness --on-conflict replace --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateImport,
+}
+
+var (
+	// Export flags
+	templateExportType    string
+	templateExportProject string
+	templateExportActive  bool
+	templateExportOut     string
+
+	// Import flags
+	templateImportProject    string
+	templateImportStartDate  string
+	templateImportTimezone   string
+	templateImportOnConflict string
+	templateImportDryRun     bool
+)
+
+func init() {
+	templateCmd.AddCommand(templateExportCmd)
+	templateCmd.AddCommand(templateImportCmd)
+
+	templateExportCmd.Flags().StringVar(&templateExportType, "type", "", "Only export templates of this type (task/habit/journal)")
+	templateExportCmd.Flags().StringVarP(&templateExportProject, "project", "p", "", "Only export templates of this project (ID or name)")
+	templateExportCmd.Flags().BoolVar(&templateExportActive, "active", false, "Only export active templates")
+	templateExportCmd.Flags().StringVarP(&templateExportOut, "out", "o", "", "Write the pack to this file instead of stdout")
+
+	templateImportCmd.Flags().StringVarP(&templateImportProject, "project", "p", "", "Project ID or name to create the templates in (default: defaults.project)")
+	templateImportCmd.Flags().StringVar(&templateImportStartDate, "start-date", "", "Start date (YYYY-MM-DD) for the imported templates (default: today)")
+	templateImportCmd.Flags().StringVar(&templateImportTimezone, "timezone", "", "IANA timezone for templates without one (default: recurring_tasks.timezone)")
+	templateImportCmd.Flags().StringVar(&templateImportOnConflict, "on-conflict", packConflictSkip, "What to do with a template whose title already exists (skip/replace/rename)")
+	templateImportCmd.Flags().BoolVar(&templateImportDryRun, "dry-run", false, "Show what would be imported without making changes")
+}
+
+// How a pack import handles a template whose title already exists
+const (
+	packConflictSkip    = "skip"
+	packConflictReplace = "replace"
+	packConflictRename  = "rename"
+)
+
+func runTemplateExport(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	if templateExportType != "" {
+		if err := validateTemplateType(templateExportType); err != nil {
+			return err
+		}
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	opts := &api.TemplateListOptions{TemplateType: templateExportType, Limit: 1000}
+	if templateExportProject != "" {
+		projectID, err := resolveProjectID(ctx, apiClient, templateExportProject)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+		opts.ProjectID = &projectID
+	}
+	if templateExportActive {
+		active := true
+		opts.Active = &active
+	}
+
+	templates, err := apiClient.ListTemplates(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	data, err := recurring.MarshalPack(recurring.NewPack(templates))
+	if err != nil {
+		return err
+	}
+
+	if templateExportOut == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(templateExportOut, data, 0644); err != nil {
+		return fmt.Errorf("failed to write template pack: %w", err)
+	}
+	fmt.Printf("Exported %d templates to %s\n", len(templates), templateExportOut)
+	return nil
+}
+
+// packImportAction is what a pack import does with one template.
+type packImportAction struct {
+	Action     string `json:"action"` // "create", "skip", "replace", or "rename"
+	Title      string `json:"title"`  // the title the template is created with
+	TemplateID int    `json:"template_id,omitempty"`
+
+	entry    *recurring.PackTemplate
+	existing *types.RecurringTaskTemplate
+}
+
+// planPackImport decides what to do with each template in a pack, given
+// the templates already in the target project. Templates earlier in the
+// pack count as existing for later ones.
+func planPackImport(pack *recurring.Pack, existing []*types.RecurringTaskTemplate, onConflict string) []*packImportAction {
+	byTitle := make(map[string]*types.RecurringTaskTemplate)
+	for _, tmpl := range existing {
+		byTitle[strings.ToLower(tmpl.Title)] = tmpl
+	}
+	planned := make(map[string]bool)
+
+	var actions []*packImportAction
+	for i := range pack.Templates {
+		entry := &pack.Templates[i]
+		key := strings.ToLower(entry.Title)
+		match := byTitle[key]
+		action := &packImportAction{Action: "create", Title: entry.Title, entry: entry, existing: match}
+
+		if match != nil || planned[key] {
+			switch onConflict {
+			case packConflictReplace:
+				if match != nil {
+					action.Action = packConflictReplace
+					action.TemplateID = match.ID
+				} else {
+					action.Action = packConflictSkip
+				}
+			case packConflictRename:
+				action.Action = packConflictRename
+				for n := 2; byTitle[strings.ToLower(action.Title)] != nil || planned[strings.ToLower(action.Title)]; n++ {
+					action.Title = fmt.Sprintf("%s (%d)", entry.Title, n)
+				}
+			default:
+				action.Action = packConflictSkip
+				if match != nil {
+					action.TemplateID = match.ID
+				}
+			}
+		}
+
+		planned[strings.ToLower(action.Title)] = true
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func runTemplateImport(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	switch templateImportOnConflict {
+	case packConflictSkip, packConflictReplace, packConflictRename:
+	default:
+		return fmt.Errorf("invalid --on-conflict value: must be 'skip', 'replace', or 'rename'")
+	}
+
+	timezone := templateTimezone(cfg, templateImportTimezone)
+	if err := validateTimezone(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	startDate := templateImportStartDate
+	if startDate == "" {
+		startDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", startDate); err != nil {
+		return fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+	}
+
+	var data []byte
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read template pack: %w", err)
+	}
+	pack, err := recurring.ParsePack(data)
+	if err != nil {
+		return err
+	}
+	for _, entry := range pack.Templates {
+		if entry.Timezone != "" {
+			if err := validateTimezone(entry.Timezone); err != nil {
+				return fmt.Errorf("template %q: %w", entry.Title, err)
+			}
+		}
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	var projectID int
+	if templateImportProject != "" {
+		projectID, err = resolveProjectID(ctx, apiClient, templateImportProject)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+	} else if cfg.Defaults.Project != "" {
+		projectID, err = ensureDefaultProject(ctx, apiClient, cfg.Defaults.Project)
+		if err != nil {
+			return fmt.Errorf("failed to ensure default project: %w", err)
+		}
+	} else {
+		return fmt.Errorf("--project is required (or configure defaults.project in config)")
+	}
+
+	existing, err := apiClient.ListTemplates(ctx, &api.TemplateListOptions{ProjectID: &projectID, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	actions := planPackImport(pack, existing, templateImportOnConflict)
+
+	if !templateImportDryRun {
+		for i, action := range actions {
+			switch action.Action {
+			case "create", packConflictRename:
+				create := action.entry.TemplateCreate(projectID, startDate, timezone)
+				create.Title = action.Title
+				created, err := apiClient.CreateTemplate(ctx, create)
+				if err != nil {
+					return fmt.Errorf("failed to create template %q (%d of %d imported): %w", action.Title, i, len(actions), err)
+				}
+				action.TemplateID = created.ID
+			case packConflictReplace:
+				if _, err := apiClient.UpdateTemplate(ctx, action.existing.ID, action.entry.TemplateUpdate(timezone)); err != nil {
+					return fmt.Errorf("failed to update template #%d (%d of %d imported): %w", action.existing.ID, i, len(actions), err)
+				}
+			}
+		}
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(actions)
+	}
+
+	displayPackImport(actions, templateImportDryRun)
+	return nil
+}
+
+// displayPackImport prints what an import did, or would do on a dry run.
+func displayPackImport(actions []*packImportAction, dryRun bool) {
+	if len(actions) == 0 {
+		fmt.Println("No templates in pack")
+		return
+	}
+
+	counts := make(map[string]int)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tID\tTITLE")
+	for _, action := range actions {
+		counts[action.Action]++
+		id := "-"
+		if action.TemplateID != 0 {
+			id = fmt.Sprintf("%d", action.TemplateID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", action.Action, id, action.Title)
+	}
+	w.Flush()
+
+	prefix := ""
+	if dryRun {
+		prefix = "Dry run: "
+	}
+	fmt.Printf("\n%s%d created, %d renamed, %d replaced, %d skipped\n", prefix, counts["create"], counts[packConflictRename], counts[packConflictReplace], counts[packConflictSkip])
+}
@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
 func TestValidateRRule(t *testing.T) {
@@ -240,3 +247,185 @@ func TestFormatDays(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEvery(t *testing.T) {
+	tests := []struct {
+		every    string
+		expected string
+		wantErr  bool
+	}{
+		{every: "day", expected: "FREQ=DAILY"},
+		{every: "Daily", expected: "FREQ=DAILY"},
+		{every: "3 days", expected: "FREQ=DAILY;INTERVAL=3"},
+		{every: "week", expected: "FREQ=WEEKLY"},
+		{every: "2 weeks", expected: "FREQ=WEEKLY;INTERVAL=2"},
+		{every: "6 months", expected: "FREQ=MONTHLY;INTERVAL=6"},
+		{every: "year", expected: "FREQ=YEARLY"},
+		{every: "weekdays", expected: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{every: "monday", expected: "FREQ=WEEKLY;BYDAY=MO"},
+		{every: "mon,wed,fri", expected: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{every: "tuesday and thursday", expected: "FREQ=WEEKLY;BYDAY=TU,TH"},
+		{every: "mon fri mon", expected: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{every: "FREQ=MONTHLY;BYMONTHDAY=1", expected: "FREQ=MONTHLY;BYMONTHDAY=1"},
+		{every: "FREQ=HOURLY", wantErr: true},
+		{every: "0 days", wantErr: true},
+		{every: "fortnight", wantErr: true},
+		{every: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.every, func(t *testing.T) {
+			result, err := parseEvery(tt.every)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEvery(%q) error = %v, wantErr %v", tt.every, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parseEvery(%q) = %q, expected %q", tt.every, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTemplateCreateFromTask(t *testing.T) {
+	description := "Check the backups"
+	priority := "high"
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	task := &types.Task{
+		ID:          42,
+		Title:       "Backup review",
+		Description: &description,
+		Priority:    &priority,
+		ProjectID:   7,
+		DueDate:     &due,
+		Labels:      []types.Label{{Name: "ops"}, {Name: "monthly"}},
+		Assignees:   []types.Assignee{{Name: "alice"}},
+	}
+
+	create := templateCreateFromTask(task, "FREQ=MONTHLY", "", "UTC", "task")
+
+	if create.ProjectID != 7 || create.Title != "Backup review" {
+		t.Errorf("Expected project and title copied, got %+v", create)
+	}
+	if create.Description == nil || *create.Description != description {
+		t.Error("Expected description copied")
+	}
+	if create.Priority == nil || *create.Priority != priority {
+		t.Error("Expected priority copied")
+	}
+	if create.StartDate != "2024-03-15" {
+		t.Errorf("Expected start date from due date, got %s", create.StartDate)
+	}
+	if len(create.Labels) != 2 || create.Labels[0] != "ops" {
+		t.Errorf("Expected labels copied, got %v", create.Labels)
+	}
+	if len(create.Assignees) != 1 || create.Assignees[0] != "alice" {
+		t.Errorf("Expected assignees copied, got %v", create.Assignees)
+	}
+	if !create.IsActive {
+		t.Error("Expected template to be active")
+	}
+
+	scheduled := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	task.ScheduledDate = &scheduled
+	if create := templateCreateFromTask(task, "FREQ=MONTHLY", "", "UTC", "task"); create.StartDate != "2024-03-10" {
+		t.Errorf("Expected scheduled date to take precedence, got %s", create.StartDate)
+	}
+	if create := templateCreateFromTask(task, "FREQ=MONTHLY", "2024-04-01", "UTC", "task"); create.StartDate != "2024-04-01" {
+		t.Errorf("Expected explicit start date, got %s", create.StartDate)
+	}
+}
+
+func TestValidateTemplateType(t *testing.T) {
+	for _, templateType := range []string{"task", "habit", "journal"} {
+		if err := validateTemplateType(templateType); err != nil {
+			t.Errorf("validateTemplateType(%q) error = %v", templateType, err)
+		}
+	}
+	if err := validateTemplateType("note"); err == nil {
+		t.Error("validateTemplateType(\"note\") expected error")
+	}
+}
+
+func TestSetGraceLabel(t *testing.T) {
+	labels, err := setGraceLabel([]string{"review", "grace:1d"}, "3d")
+	if err != nil {
+		t.Fatalf("setGraceLabel() error = %v", err)
+	}
+	if strings.Join(labels, ",") != "review,grace:3d" {
+		t.Errorf("setGraceLabel() = %v, want [review grace:3d]", labels)
+	}
+
+	labels, err = setGraceLabel(labels, "none")
+	if err != nil || strings.Join(labels, ",") != "review" {
+		t.Errorf("setGraceLabel(none) = %v, %v, want [review]", labels, err)
+	}
+
+	if _, err := setGraceLabel(nil, "2 days"); err == nil {
+		t.Error("setGraceLabel(\"2 days\") expected error")
+	}
+}
+
+func TestDisplayTemplateVerification(t *testing.T) {
+	tmpl := &types.RecurringTaskTemplate{ID: 3, Title: "Weekly sync", RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO"}
+	expected := mustDate("2024-03-11")
+	next := mustDate("2024-03-25")
+	v := &recurring.Verification{
+		Timezone: "America/Chicago",
+		From:     mustDate("2024-03-04"),
+		To:       mustDate("2024-03-12"),
+		Dates: []recurring.DateCheck{
+			{Date: mustDate("2024-03-04"), Occurrence: true, TaskIDs: []int{1}, Result: recurring.VerifyOK},
+			{Date: mustDate("2024-03-12"), TaskIDs: []int{2}, Result: recurring.VerifyShifted, Expected: &expected},
+		},
+		Next: &next,
+	}
+
+	var out bytes.Buffer
+	displayTemplateVerification(&out, tmpl, v, false)
+	got := out.String()
+
+	for _, want := range []string{
+		"Rule: FREQ=WEEKLY;BYDAY=MO (America/Chicago)",
+		"Tue 2024-03-12  no          #2     shifted (expected 2024-03-11)",
+		"Next occurrence: Mon 2024-03-25 (no task yet)",
+		"Checked 2 date(s): 1 mismatch(es)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "2024-03-04  yes") {
+		t.Errorf("Expected matching dates to be hidden without --all, got:\n%s", got)
+	}
+}
+
+func TestPlanPackImport(t *testing.T) {
+	pack := &recurring.Pack{Templates: []recurring.PackTemplate{
+		{Title: "Drink water"},
+		{Title: "Stretch"},
+		{Title: "stretch"},
+	}}
+	existing := []*types.RecurringTaskTemplate{
+		{ID: 4, Title: "drink water"},
+		{ID: 5, Title: "Drink water (2)"},
+	}
+
+	summarize := func(actions []*packImportAction) string {
+		var parts []string
+		for _, a := range actions {
+			parts = append(parts, fmt.Sprintf("%s:%s:%d", a.Action, a.Title, a.TemplateID))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := map[string]string{
+		packConflictSkip:    "skip:Drink water:4 create:Stretch:0 skip:stretch:0",
+		packConflictReplace: "replace:Drink water:4 create:Stretch:0 skip:stretch:0",
+		packConflictRename:  "rename:Drink water (3):0 create:Stretch:0 rename:stretch (2):0",
+	}
+	for onConflict, want := range tests {
+		if got := summarize(planPackImport(pack, existing, onConflict)); got != want {
+			t.Errorf("%s: got %q, want %q", onConflict, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var templateVerifyCmd = &cobra.Command{
+	Use:   "verify <id>",
+	Short: "Check a template's generated tasks against its schedule",
+	Long: `Compute a template's occurrences locally, in the template's timezone,
+and compare them with the scheduled dates of the tasks the server generated
+for it, from the first generated task to the last.
+
+Each date that disagrees is reported as:
+
+  missing      an occurrence without a task
+  unexpected   a task on a date that is not an occurrence
+  shifted      a task a day off an occurrence without a task, usually a
+               timezone disagreement between the server and this machine
+  duplicate    an occurrence with more than one task
+
+Occurrences of templates that skip weekends or holidays are expected on
+the next business day, where the daemon moves their tasks.
+
+Use --all to list matching dates too.
+
+Exits with an error if any date disagrees, so it can be used in scripts.
+
+Examples:
+  todu template verify 3
+  todu template verify 3 --all
+  todu template verify 3 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateVerify,
+}
+
+var (
+	// Verify flags
+	templateVerifyAll bool
+)
+
+func init() {
+	templateCmd.AddCommand(templateVerifyCmd)
+	templateVerifyCmd.Flags().BoolVar(&templateVerifyAll, "all", false, "List every checked date, not just mismatches")
+}
+
+func runTemplateVerify(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	templateID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid template ID: %s", args[0])
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	template, err := apiClient.GetTemplate(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+
+	tasks, err := apiClient.ListAllTasks(ctx, &api.TaskListOptions{
+		ProjectID:  &template.ProjectID,
+		TemplateID: &templateID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list template tasks: %w", err)
+	}
+	var associatedTasks []*types.Task
+	for _, task := range tasks {
+		if task.TemplateID != nil && *task.TemplateID == templateID {
+			associatedTasks = append(associatedTasks, task)
+		}
+	}
+
+	calendar, err := loadHolidayCalendar(cfg, recurring.TemplateSkip(template))
+	if err != nil {
+		return err
+	}
+
+	verification, err := recurring.Verify(template, associatedTasks, time.Now(), calendar)
+	if err != nil {
+		return fmt.Errorf("failed to compute occurrences: %w", err)
+	}
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(verification); err != nil {
+			return err
+		}
+	} else {
+		displayTemplateVerification(os.Stdout, template, verification, templateVerifyAll)
+	}
+
+	if count := verification.MismatchCount(); count > 0 {
+		return fmt.Errorf("%d date(s) differ between the local schedule and the server's tasks", count)
+	}
+	return nil
+}
+
+// displayTemplateVerification prints the checked dates as a table followed
+// by the next occurrence and a summary. Matching dates are only listed if
+// all is set.
+func displayTemplateVerification(out io.Writer, tmpl *types.RecurringTaskTemplate, v *recurring.Verification, all bool) {
+	fmt.Fprintf(out, "Template #%d: %s\n", tmpl.ID, tmpl.Title)
+	fmt.Fprintf(out, "Rule: %s (%s)\n", tmpl.RecurrenceRule, v.Timezone)
+	fmt.Fprintf(out, "Checked %s to %s\n\n", v.From.Format("2006-01-02"), v.To.Format("2006-01-02"))
+
+	var rows []recurring.DateCheck
+	for _, d := range v.Dates {
+		if all || d.Result != recurring.VerifyOK {
+			rows = append(rows, d)
+		}
+	}
+
+	if len(rows) > 0 {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tOCCURRENCE\tTASKS\tRESULT")
+		fmt.Fprintln(w, "----\t----------\t-----\t------")
+		for _, d := range rows {
+			occurrence := "no"
+			if d.Occurrence {
+				occurrence = "yes"
+			}
+			result := d.Result
+			if d.Expected != nil {
+				result += " (expected " + d.Expected.Format("2006-01-02") + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Date.Format("Mon 2006-01-02"), occurrence, formatTaskIDs(d.TaskIDs), result)
+		}
+		w.Flush()
+		fmt.Fprintln(out)
+	}
+
+	if v.Next != nil {
+		if v.NextTaskID != 0 {
+			fmt.Fprintf(out, "Next occurrence: %s (task #%d)\n", v.Next.Format("Mon 2006-01-02"), v.NextTaskID)
+		} else {
+			fmt.Fprintf(out, "Next occurrence: %s (no task yet)\n", v.Next.Format("Mon 2006-01-02"))
+		}
+	}
+	if v.Unscheduled > 0 {
+		fmt.Fprintf(out, "%d task(s) without a scheduled date were not checked\n", v.Unscheduled)
+	}
+	fmt.Fprintf(out, "Checked %d date(s): %d mismatch(es)\n", len(v.Dates), v.MismatchCount())
+}
+
+// formatTaskIDs formats task IDs as "#1, #2", or "-" if there are none.
+func formatTaskIDs(ids []int) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = "#" + strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+)
+
+// terminalWidth returns the width of the terminal attached to stdout in
+// columns, or 0 if stdout is not a terminal. $COLUMNS overrides detection.
+// A command run by the daemon gets the width of its caller's terminal.
+func terminalWidth() int {
+	if inv := currentInvocation(); inv != nil {
+		return inv.columns
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return stdoutTerminalWidth()
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// stdoutTerminalWidth is not supported on this platform; callers fall back
+// to $COLUMNS or fixed widths.
+func stdoutTerminalWidth() int {
+	return 0
+}
+
+// openTerminal returns stdin for reading prompt answers if it is a terminal.
+// There is no portable controlling terminal device on this platform.
+func openTerminal() (io.ReadCloser, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, errNoTerminal
+	}
+	return io.NopCloser(os.Stdin), nil
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// stdoutTerminalWidth queries the terminal size of stdout.
+func stdoutTerminalWidth() int {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(ws.Col)
+}
+
+// openTerminal opens the controlling terminal for reading prompt answers, so
+// prompts work even when stdin is piped.
+func openTerminal() (io.ReadCloser, error) {
+	return os.Open("/dev/tty")
+}
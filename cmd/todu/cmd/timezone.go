@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/spf13/cobra"
+)
+
+// dateTZ is the --tz flag of date-filtered commands
+var dateTZ string
+
+// addTZFlag registers --tz, the timezone date filters are read in.
+func addTZFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&dateTZ, "tz", "", "IANA timezone for date filters (default: this machine's timezone)")
+}
+
+// dateLocation returns the timezone date filters are read in: --tz if
+// given, otherwise the machine's timezone.
+func dateLocation() (*time.Location, error) {
+	if dateTZ == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(dateTZ)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --tz %q: must be an IANA timezone such as America/New_York", dateTZ)
+	}
+	return loc, nil
+}
+
+// localDay parses a YYYY-MM-DD date and returns the first and last moment
+// of that day in loc
+func localDay(date string, loc *time.Location) (start, end time.Time, err error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start = localDayStart(day, loc)
+	end = localDayStart(day.AddDate(0, 0, 1), loc).Add(-time.Nanosecond)
+	return start, end, nil
+}
+
+// localDayStart returns the first moment in loc of day, a UTC midnight.
+// That's usually midnight, but a day that begins with a DST change starts
+// at the change, and a day the timezone skipped starts with the next one.
+func localDayStart(day time.Time, loc *time.Location) time.Time {
+	var start time.Time
+	// Midnight is at the UTC offset of the day before or of the day itself,
+	// whichever gives the earliest moment that's on the day
+	for _, noon := range []time.Time{
+		time.Date(day.Year(), day.Month(), day.Day()-1, 12, 0, 0, 0, loc),
+		time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc),
+	} {
+		_, offset := noon.Zone()
+		candidate := day.Add(-time.Duration(offset) * time.Second).In(loc)
+		if candidate.Format("2006-01-02") == day.Format("2006-01-02") && (start.IsZero() || candidate.Before(start)) {
+			start = candidate
+		}
+	}
+	if start.IsZero() {
+		return localDayStart(day.AddDate(0, 0, 1), loc)
+	}
+	return start
+}
+
+// templateTimezone returns the timezone for a new template: the --timezone
+// flag if given, otherwise recurring_tasks.timezone from the config.
+func templateTimezone(cfg *config.Config, flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if cfg.RecurringTasks.Timezone != "" {
+		return cfg.RecurringTasks.Timezone
+	}
+	return "UTC"
+}
+
+// timezoneMismatch returns a warning if tz, a template's timezone, is
+// currently at a different UTC offset than local. Templates generate tasks
+// on dates in their own timezone, so a mismatch can put occurrences on a
+// different day than expected near midnight.
+func timezoneMismatch(tz string, local *time.Location, now time.Time) string {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return ""
+	}
+	_, templateOffset := now.In(loc).Zone()
+	_, localOffset := now.In(local).Zone()
+	if templateOffset == localOffset {
+		return ""
+	}
+	return fmt.Sprintf("template timezone %s (%s) differs from this machine's timezone %s (%s); occurrences are scheduled on %s dates",
+		tz, formatOffset(templateOffset), local, formatOffset(localOffset), tz)
+}
+
+// formatOffset formats a UTC offset in seconds as UTC±HH:MM.
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// dstChange returns the first day between from and to (inclusive dates) on
+// which loc's UTC offset changes, and false if it never does.
+func dstChange(loc *time.Location, from, to time.Time) (time.Time, bool) {
+	day := time.Date(from.Year(), from.Month(), from.Day(), 12, 0, 0, 0, loc)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 12, 0, 0, 0, loc)
+	_, offset := day.Zone()
+	for !day.After(last) {
+		if _, o := day.Zone(); o != offset {
+			return day, true
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+// dateRangeWarning returns a warning if filtering from after to before
+// (YYYY-MM-DD, either may be empty for today) crosses a daylight saving
+// change in loc. Timestamps near midnight can then land on a neighboring
+// day. Returns "" when both are empty or either doesn't parse.
+func dateRangeWarning(after, before string, loc *time.Location) string {
+	if after == "" && before == "" {
+		return ""
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from, to := today, today
+	var err error
+	if after != "" {
+		if from, err = time.ParseInLocation("2006-01-02", after, loc); err != nil {
+			return ""
+		}
+	}
+	if before != "" {
+		if to, err = time.ParseInLocation("2006-01-02", before, loc); err != nil {
+			return ""
+		}
+	}
+	if to.Before(from) {
+		from, to = to, from
+	}
+
+	change, ok := dstChange(loc, from, to)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s to %s crosses a daylight saving change in %s on %s; entries timestamped near midnight may fall on a neighboring day (use --tz to filter in another timezone)",
+		from.Format("2006-01-02"), to.Format("2006-01-02"), loc, change.Format("2006-01-02"))
+}
+
+// configDayStart returns how long after midnight the day starts, from
+// day_start in the config.
+func configDayStart(cfg *config.Config) (time.Duration, error) {
+	start, err := daystart.Parse(cfg.DayStart)
+	if err != nil {
+		return 0, fmt.Errorf("invalid day_start in config: %w", err)
+	}
+	return start, nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+)
+
+func TestDateLocation(t *testing.T) {
+	defer func() { dateTZ = "" }()
+
+	dateTZ = ""
+	if loc, err := dateLocation(); err != nil || loc != time.Local {
+		t.Errorf("Expected time.Local without --tz, got %v, %v", loc, err)
+	}
+
+	dateTZ = "Asia/Tokyo"
+	if loc, err := dateLocation(); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("Expected Asia/Tokyo, got %v, %v", loc, err)
+	}
+	start, err := parseDateToUTCStart("2024-06-01")
+	if err != nil || start != "2024-05-31T15:00:00Z" {
+		t.Errorf("parseDateToUTCStart with --tz = %q, %v", start, err)
+	}
+
+	dateTZ = "Mars/Olympus"
+	if _, err := dateLocation(); err == nil {
+		t.Error("Expected error for invalid --tz")
+	}
+}
+
+func TestTemplateTimezone(t *testing.T) {
+	cfg := &config.Config{}
+	if got := templateTimezone(cfg, ""); got != "UTC" {
+		t.Errorf("Expected UTC by default, got %q", got)
+	}
+
+	cfg.RecurringTasks.Timezone = "Europe/London"
+	if got := templateTimezone(cfg, ""); got != "Europe/London" {
+		t.Errorf("Expected config timezone, got %q", got)
+	}
+	if got := templateTimezone(cfg, "America/Chicago"); got != "America/Chicago" {
+		t.Errorf("Expected flag to override config, got %q", got)
+	}
+}
+
+func TestTimezoneMismatch(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	summer := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := timezoneMismatch("America/Chicago", chicago, summer); got != "" {
+		t.Errorf("Expected no warning for matching timezone, got %q", got)
+	}
+	// Same offset under a different name is not a mismatch
+	if got := timezoneMismatch("UTC", time.UTC, summer); got != "" {
+		t.Errorf("Expected no warning for UTC on a UTC machine, got %q", got)
+	}
+
+	got := timezoneMismatch("UTC", chicago, summer)
+	if !strings.Contains(got, "UTC (UTC+00:00)") || !strings.Contains(got, "America/Chicago (UTC-05:00)") {
+		t.Errorf("Unexpected mismatch warning: %q", got)
+	}
+}
+
+func TestDateRangeWarning(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	// US daylight saving started on 2024-03-10
+	got := dateRangeWarning("2024-03-01", "2024-03-31", chicago)
+	if !strings.Contains(got, "on 2024-03-10") {
+		t.Errorf("Expected warning about 2024-03-10, got %q", got)
+	}
+
+	// Reversed bounds are still checked
+	if got := dateRangeWarning("2024-03-31", "2024-03-01", chicago); got == "" {
+		t.Error("Expected warning for reversed range")
+	}
+
+	tests := []struct {
+		name          string
+		after, before string
+		loc           *time.Location
+	}{
+		{"no change", "2024-04-01", "2024-04-30", chicago},
+		{"no daylight saving", "2024-03-01", "2024-03-31", time.UTC},
+		{"no bounds", "", "", chicago},
+		{"invalid date", "2024-03-01", "March 31", chicago},
+	}
+	for _, tt := range tests {
+		if got := dateRangeWarning(tt.after, tt.before, tt.loc); got != "" {
+			t.Errorf("%s: expected no warning, got %q", tt.name, got)
+		}
+	}
+}
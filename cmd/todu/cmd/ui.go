@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/hooks"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Escape sequences that switch the terminal to and from the dashboard
+const (
+	uiEnterScreen = "\033[?1049h\033[?25l" // alternate screen, hidden cursor
+	uiLeaveScreen = "\033[?25h\033[?1049l"
+)
+
+// uiResizePoll is how often the dashboard checks for a resized terminal
+const uiResizePoll = 250 * time.Millisecond
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Full-screen dashboard for triaging tasks",
+	Long: `Open a full-screen dashboard of tasks grouped by status.
+
+Move with j/k or the arrow keys, press enter to see a task's details and
+comments, and change its status (s) or priority (p, +, -) in place. Updates
+run the same hooks and rules as "todu task update" and "todu task close".
+Press ? for all keys and q to quit.
+
+Open tasks are shown by default; --all includes done and canceled ones.
+
+Examples:
+  todu ui
+  todu ui --project inbox
+  todu ui --view hot`,
+	Args: cobra.NoArgs,
+	RunE: runUI,
+}
+
+// UI flags
+var (
+	uiProject string
+	uiView    string
+	uiAll     bool
+)
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+
+	uiCmd.Flags().StringVarP(&uiProject, "project", "p", "", "Only show tasks in this project (ID or name)")
+	uiCmd.Flags().StringVar(&uiView, "view", "", "Only show tasks matching this saved view")
+	uiCmd.Flags().BoolVar(&uiAll, "all", false, "Include done and canceled tasks")
+}
+
+func runUI(cmd *cobra.Command, args []string) error {
+	if err := requireInteractive("the dashboard", "use 'todu task list' instead"); err != nil {
+		return err
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("todu ui needs a terminal; use 'todu task list' instead")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	hookRunner, err := newHookRunner(cfg)
+	if err != nil {
+		return err
+	}
+	// Hook output would draw over the dashboard
+	hookRunner.WithOutput(io.Discard)
+
+	ruleEngine, err := loadRuleEngine(cfg)
+	if err != nil {
+		return err
+	}
+
+	taskListProject = uiProject
+	taskListView = uiView
+	if err := prepareTaskListFilters(); err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx, stop := signal.NotifyContext(commandContext(), syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+
+	load := func() ([]*types.Task, map[int]string, error) {
+		// Refreshing shows projects renamed or added since the last load
+		apiClient.ResetLookups()
+		tasks, err := fetchListTasks(ctx, apiClient, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		// A status filter from a view decides what's shown on its own
+		if !uiAll && taskListStatus == "" {
+			tasks = slices.DeleteFunc(tasks, func(task *types.Task) bool {
+				return !slices.Contains(uiOpenStatuses, task.Status)
+			})
+		}
+		projects, err := apiClient.ListProjects(ctx, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		projectNames := make(map[int]string, len(projects))
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+		return tasks, projectNames, nil
+	}
+
+	tasks, projectNames, err := load()
+	if err != nil {
+		return err
+	}
+	model := newUIModel(tasks, projectNames)
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	fmt.Print(uiEnterScreen)
+	defer func() {
+		fmt.Print(uiLeaveScreen)
+		_ = term.Restore(int(os.Stdin.Fd()), state)
+	}()
+
+	keys := make(chan []string)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- parseUIKeys(buf[:n])
+		}
+	}()
+
+	ticker := time.NewTicker(uiResizePoll)
+	defer ticker.Stop()
+
+	width, height := uiTerminalSize()
+	fmt.Print(model.render(width, height))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if w, h := uiTerminalSize(); w != width || h != height {
+				width, height = w, h
+				fmt.Print(model.render(width, height))
+			}
+			continue
+		case pressed, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range pressed {
+				action := model.handleKey(key)
+				if action == nil {
+					continue
+				}
+				if action.kind == uiActionQuit {
+					return nil
+				}
+				uiRun(ctx, apiClient, hookRunner, ruleEngine, model, action, load)
+			}
+		}
+		fmt.Print(model.render(width, height))
+	}
+}
+
+// uiRun carries out an action from the dashboard, reporting the outcome in
+// the model's footer message
+func uiRun(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, model *uiModel, action *uiAction, load func() ([]*types.Task, map[int]string, error)) {
+	switch action.kind {
+	case uiActionRefresh:
+		tasks, projectNames, err := load()
+		if err != nil {
+			model.message = "Error: " + err.Error()
+			return
+		}
+		model.projectNames = projectNames
+		model.setTasks(tasks)
+		model.commentsFor = 0
+		model.message = fmt.Sprintf("Reloaded %d task(s)", len(tasks))
+
+	case uiActionComments:
+		comments, err := apiClient.ListComments(ctx, action.task.ID)
+		if err != nil {
+			model.message = "Error: failed to list comments: " + err.Error()
+			return
+		}
+		model.comments = comments
+		model.commentsFor = action.task.ID
+
+	case uiActionSetStatus, uiActionSetPriority:
+		update := &types.TaskUpdate{}
+		change := "status"
+		if action.kind == uiActionSetStatus {
+			update.Status = &action.value
+		} else {
+			update.Priority = &action.value
+			change = "priority"
+		}
+
+		task, applied, err := uiUpdateTask(ctx, apiClient, hookRunner, ruleEngine, action.task, update)
+		if err != nil {
+			model.message = "Error: " + err.Error()
+			return
+		}
+		model.replaceTask(task)
+		model.message = fmt.Sprintf("Task #%d %s set to %s", task.ID, change, action.value)
+		if len(applied) > 0 {
+			model.message += "; rules applied: " + strings.Join(applied, ", ")
+		}
+	}
+}
+
+// uiUpdateTask saves a change made in the dashboard without printing,
+// running the close hooks when the task is marked done and the update
+// hooks and rules otherwise, as "todu task close" and "todu task update" do.
+// Returns the saved task and the rules that changed it.
+func uiUpdateTask(ctx context.Context, apiClient *api.Client, hookRunner *hooks.Runner, ruleEngine *rules.Engine, current *types.Task, update *types.TaskUpdate) (*types.Task, []string, error) {
+	closing := update.Status != nil && *update.Status == "done"
+
+	var err error
+	if closing {
+		err = hookRunner.Run(ctx, hooks.PreTaskClose, map[string]any{"task": current})
+	} else {
+		err = hookRunner.Run(ctx, hooks.PreTaskUpdate, map[string]any{"task": current, "update": update})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := apiClient.UpdateTask(ctx, current.ID, update)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if closing {
+		_ = hookRunner.Run(ctx, hooks.PostTaskClose, map[string]any{"task": task})
+		return task, nil, nil
+	}
+
+	// The task is saved, so rule and post-hook failures don't undo it
+	task, applied, _ := runTaskRules(ctx, apiClient, ruleEngine, task, rules.TriggerUpdate)
+	_ = hookRunner.Run(ctx, hooks.PostTaskUpdate, map[string]any{"task": task, "previous": current})
+	return task, applied, nil
+}
+
+// uiTerminalSize returns the size of the terminal on stdout, falling back
+// to 80x24
+func uiTerminalSize() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
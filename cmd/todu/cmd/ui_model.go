@@ -0,0 +1,612 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// uiStatuses are the statuses the dashboard groups tasks by, in order
+var uiStatuses = []struct{ status, title, key string }{
+	{"inprogress", "In Progress", "i"},
+	{"active", "Active", "a"},
+	{"waiting", "Waiting", "w"},
+	{"done", "Done", "d"},
+	{"canceled", "Canceled", "c"},
+}
+
+// uiOpenStatuses are shown unless the dashboard includes closed tasks
+var uiOpenStatuses = []string{"inprogress", "active", "waiting"}
+
+// uiDetailMinWidth is the narrowest terminal that shows the detail pane
+// beside the list; narrower ones show it in place of the list.
+const uiDetailMinWidth = 100
+
+// Escape sequences used to draw the dashboard
+const (
+	uiReverse   = "\033[7m"
+	uiBold      = "\033[1m"
+	uiReset     = "\033[0m"
+	uiHome      = "\033[H"
+	uiClearLine = "\033[K"
+	uiClearDown = "\033[J"
+)
+
+// Actions the dashboard asks its terminal loop to carry out
+const (
+	uiActionQuit        = "quit"
+	uiActionRefresh     = "refresh"
+	uiActionSetStatus   = "status"
+	uiActionSetPriority = "priority"
+	uiActionComments    = "comments"
+)
+
+// uiAction is work a key press asks for that needs the API.
+type uiAction struct {
+	kind  string
+	task  *types.Task
+	value string // the new status or priority
+}
+
+// Prompts shown in the footer while waiting for a second key
+const (
+	uiPromptNone     = ""
+	uiPromptStatus   = "status"
+	uiPromptPriority = "priority"
+)
+
+// uiModel is the dashboard's state. Keys update it through handleKey and
+// render draws it, so it can be tested without a terminal.
+type uiModel struct {
+	tasks        []*types.Task // grouped by status, in uiStatuses order
+	projectNames map[int]string
+
+	cursor int // index into tasks
+	offset int // first list line shown
+
+	detail      bool
+	comments    []*types.Comment
+	commentsFor int // ID of the task comments were loaded for
+
+	prompt  string
+	help    bool
+	message string
+}
+
+// newUIModel returns a dashboard for tasks, grouped by status with their
+// order kept within each status
+func newUIModel(tasks []*types.Task, projectNames map[int]string) *uiModel {
+	m := &uiModel{projectNames: projectNames}
+	m.setTasks(tasks)
+	return m
+}
+
+// uiStatusRank returns the position of status in uiStatuses, with unknown
+// statuses last
+func uiStatusRank(status string) int {
+	for i, s := range uiStatuses {
+		if s.status == status {
+			return i
+		}
+	}
+	return len(uiStatuses)
+}
+
+// uiStatusTitle returns the group heading for status
+func uiStatusTitle(status string) string {
+	if rank := uiStatusRank(status); rank < len(uiStatuses) {
+		return uiStatuses[rank].title
+	}
+	return status
+}
+
+// setTasks replaces the tasks, keeping the cursor on the same task if it's
+// still there
+func (m *uiModel) setTasks(tasks []*types.Task) {
+	selected := m.selected()
+	m.tasks = slices.Clone(tasks)
+	slices.SortStableFunc(m.tasks, func(a, b *types.Task) int {
+		return uiStatusRank(a.Status) - uiStatusRank(b.Status)
+	})
+
+	m.cursor = min(m.cursor, max(len(m.tasks)-1, 0))
+	if selected != nil {
+		for i, task := range m.tasks {
+			if task.ID == selected.ID {
+				m.cursor = i
+				break
+			}
+		}
+	}
+}
+
+// replaceTask swaps in an updated copy of a task, moving it to its status
+// group and keeping the cursor on it
+func (m *uiModel) replaceTask(updated *types.Task) {
+	tasks := slices.Clone(m.tasks)
+	for i, task := range tasks {
+		if task.ID == updated.ID {
+			tasks[i] = updated
+		}
+	}
+	m.setTasks(tasks)
+}
+
+// selected returns the task under the cursor, or nil if there are none
+func (m *uiModel) selected() *types.Task {
+	if m.cursor < 0 || m.cursor >= len(m.tasks) {
+		return nil
+	}
+	return m.tasks[m.cursor]
+}
+
+// handleKey updates the model for a key and returns the API work it needs,
+// or nil if a redraw is enough
+func (m *uiModel) handleKey(key string) *uiAction {
+	m.message = ""
+
+	if key == "ctrl+c" {
+		return &uiAction{kind: uiActionQuit}
+	}
+	if m.help {
+		m.help = false
+		return nil
+	}
+	if m.prompt != uiPromptNone {
+		return m.handlePromptKey(key)
+	}
+
+	task := m.selected()
+	switch key {
+	case "q":
+		return &uiAction{kind: uiActionQuit}
+	case "esc":
+		m.detail = false
+	case "?":
+		m.help = true
+	case "r":
+		return &uiAction{kind: uiActionRefresh}
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "pgdown", "ctrl+d":
+		m.move(10)
+	case "pgup", "ctrl+u":
+		m.move(-10)
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = max(len(m.tasks)-1, 0)
+	case "tab":
+		m.nextGroup()
+	case "enter", " ":
+		if task == nil {
+			return nil
+		}
+		m.detail = !m.detail
+		if m.detail && m.commentsFor != task.ID {
+			return &uiAction{kind: uiActionComments, task: task}
+		}
+	case "s":
+		if task != nil {
+			m.prompt = uiPromptStatus
+		}
+	case "p":
+		if task != nil {
+			m.prompt = uiPromptPriority
+		}
+	case "+", "=":
+		return m.shiftPriority(task, 1)
+	case "-":
+		return m.shiftPriority(task, -1)
+	case "x":
+		if task != nil && task.Status != "done" {
+			return &uiAction{kind: uiActionSetStatus, task: task, value: "done"}
+		}
+	}
+
+	// Moving to another task loads its comments if the detail pane is open
+	if task = m.selected(); m.detail && task != nil && m.commentsFor != task.ID {
+		return &uiAction{kind: uiActionComments, task: task}
+	}
+	return nil
+}
+
+// handlePromptKey handles the key answering a status or priority prompt
+func (m *uiModel) handlePromptKey(key string) *uiAction {
+	prompt := m.prompt
+	m.prompt = uiPromptNone
+	task := m.selected()
+	if task == nil || key == "esc" {
+		return nil
+	}
+
+	switch prompt {
+	case uiPromptStatus:
+		for _, s := range uiStatuses {
+			if key == s.key && s.status != task.Status {
+				return &uiAction{kind: uiActionSetStatus, task: task, value: s.status}
+			}
+		}
+	case uiPromptPriority:
+		levels := types.Priorities()
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(levels) {
+			if task.Priority == nil || *task.Priority != levels[n-1] {
+				return &uiAction{kind: uiActionSetPriority, task: task, value: levels[n-1]}
+			}
+		}
+	}
+	return nil
+}
+
+// shiftPriority returns the action raising (by 1) or lowering (by -1) a
+// task's priority, or nil if it's already at the end of the range
+func (m *uiModel) shiftPriority(task *types.Task, by int) *uiAction {
+	if task == nil {
+		return nil
+	}
+	levels := types.Priorities()
+	rank := types.PriorityRank(task.Priority) // 1-based, 0 for none
+	if rank == 0 {
+		rank = slices.Index(levels, types.DefaultPriority()) + 1 - by
+	}
+	next := rank - 1 + by
+	if next < 0 || next >= len(levels) {
+		return nil
+	}
+	return &uiAction{kind: uiActionSetPriority, task: task, value: levels[next]}
+}
+
+// move moves the cursor by n tasks, stopping at the ends
+func (m *uiModel) move(n int) {
+	m.cursor = max(0, min(m.cursor+n, len(m.tasks)-1))
+}
+
+// nextGroup moves the cursor to the first task of the next status group,
+// wrapping to the first group
+func (m *uiModel) nextGroup() {
+	task := m.selected()
+	if task == nil {
+		return
+	}
+	for i := m.cursor + 1; i < len(m.tasks); i++ {
+		if m.tasks[i].Status != task.Status {
+			m.cursor = i
+			return
+		}
+	}
+	m.cursor = 0
+}
+
+// uiLine is a line of the task list: a group heading, or a task row
+type uiLine struct {
+	text string
+	task int // index into tasks, or -1 for a heading
+}
+
+// listLines returns the task list's lines, with a heading per status group
+func (m *uiModel) listLines() []uiLine {
+	var lines []uiLine
+	for i, task := range m.tasks {
+		if i == 0 || task.Status != m.tasks[i-1].Status {
+			count := 0
+			for _, t := range m.tasks[i:] {
+				if t.Status != task.Status {
+					break
+				}
+				count++
+			}
+			if i > 0 {
+				lines = append(lines, uiLine{task: -1})
+			}
+			lines = append(lines, uiLine{text: fmt.Sprintf("%s (%d)", uiStatusTitle(task.Status), count), task: -1})
+		}
+
+		priority := ""
+		if task.Priority != nil {
+			priority = "[" + *task.Priority + "] "
+		}
+		star := " "
+		if task.IsStarred() {
+			star = "*"
+		}
+		project := m.projectNames[task.ProjectID]
+		if project == "" {
+			project = strconv.Itoa(task.ProjectID)
+		}
+		lines = append(lines, uiLine{
+			text: fmt.Sprintf("%s #%-5d %s%s  (%s)", star, task.ID, priority, task.Title, project),
+			task: i,
+		})
+	}
+	return lines
+}
+
+// render draws the dashboard for a terminal of width columns and height
+// rows, as escape sequences and CRLF-terminated lines for a raw terminal
+func (m *uiModel) render(width, height int) string {
+	width = max(width, 20)
+	height = max(height, 5)
+
+	header := fmt.Sprintf(" todu ui - %d task(s)", len(m.tasks))
+	body := height - 2 // header and footer
+
+	var rows []string
+	switch {
+	case m.help:
+		rows = uiFitLines(uiHelpLines(), width, body)
+	case m.detail && width < uiDetailMinWidth:
+		rows = uiFitLines(m.detailLines(width), width, body)
+	case m.detail:
+		listWidth := width * 55 / 100
+		list := m.listRows(listWidth-1, body)
+		detail := uiFitLines(m.detailLines(width-listWidth-2), width-listWidth-2, body)
+		for i := range list {
+			rows = append(rows, list[i]+" │"+detail[i])
+		}
+	default:
+		rows = m.listRows(width, body)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(uiHome)
+	sb.WriteString(uiReverse + uiPad(header, width) + uiReset + uiClearLine + "\r\n")
+	for _, row := range rows {
+		sb.WriteString(row + uiReset + uiClearLine + "\r\n")
+	}
+	sb.WriteString(uiReverse + uiPad(" "+m.footer(), width) + uiReset + uiClearLine)
+	sb.WriteString(uiClearDown)
+	return sb.String()
+}
+
+// listRows returns height rows of the task list, scrolled to keep the
+// cursor in view, each padded to width
+func (m *uiModel) listRows(width, height int) []string {
+	lines := m.listLines()
+	if len(lines) == 0 {
+		lines = []uiLine{{text: "No tasks", task: -1}}
+	}
+
+	cursorLine := 0
+	for i, line := range lines {
+		if line.task == m.cursor {
+			cursorLine = i
+			break
+		}
+	}
+	// Keep the cursor's group heading in view when scrolling up to it
+	if cursorLine > 0 && lines[cursorLine-1].task == -1 && lines[cursorLine-1].text != "" && cursorLine-1 < m.offset {
+		m.offset = cursorLine - 1
+	}
+	if cursorLine < m.offset {
+		m.offset = cursorLine
+	}
+	if cursorLine >= m.offset+height {
+		m.offset = cursorLine - height + 1
+	}
+	m.offset = max(0, min(m.offset, len(lines)-height))
+
+	rows := make([]string, height)
+	for i := range rows {
+		n := m.offset + i
+		switch {
+		case n >= len(lines):
+			rows[i] = uiPad("", width)
+		case lines[n].task == -1:
+			rows[i] = uiBold + uiPad(lines[n].text, width) + uiReset
+		case lines[n].task == m.cursor:
+			rows[i] = uiReverse + uiPad(lines[n].text, width) + uiReset
+		default:
+			rows[i] = uiPad(lines[n].text, width)
+		}
+	}
+	return rows
+}
+
+// detailLines returns the selected task's details and comments, wrapped to
+// width
+func (m *uiModel) detailLines(width int) []string {
+	task := m.selected()
+	if task == nil {
+		return nil
+	}
+	width = max(width, 10)
+
+	lines := uiWrap(fmt.Sprintf("#%d %s", task.ID, task.Title), width)
+	lines = append(lines, "")
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		// Keep the values aligned unless the line needs wrapping
+		line := fmt.Sprintf("%-10s %s", name+":", value)
+		if len([]rune(line)) <= width {
+			lines = append(lines, line)
+		} else {
+			lines = append(lines, uiWrap(line, width)...)
+		}
+	}
+	add("Status", task.Status)
+	if task.Priority != nil {
+		add("Priority", *task.Priority)
+	}
+	add("Project", m.projectNames[task.ProjectID])
+	if task.DueDate != nil {
+		add("Due", task.DueDate.UTC().Format("2006-01-02"))
+	}
+	if task.ScheduledDate != nil {
+		add("Scheduled", task.ScheduledDate.UTC().Format("2006-01-02"))
+	}
+	if len(task.Labels) > 0 {
+		names := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			names[i] = label.Name
+		}
+		add("Labels", strings.Join(names, ", "))
+	}
+	if len(task.Assignees) > 0 {
+		names := make([]string, len(task.Assignees))
+		for i, assignee := range task.Assignees {
+			names[i] = assignee.Name
+		}
+		add("Assignees", strings.Join(names, ", "))
+	}
+
+	if task.Description != nil && *task.Description != "" {
+		lines = append(lines, "")
+		for _, paragraph := range strings.Split(*task.Description, "\n") {
+			lines = append(lines, uiWrap(paragraph, width)...)
+		}
+	}
+
+	lines = append(lines, "")
+	switch {
+	case m.commentsFor != task.ID:
+		lines = append(lines, "Loading comments...")
+	case len(m.comments) == 0:
+		lines = append(lines, "No comments")
+	default:
+		lines = append(lines, fmt.Sprintf("Comments (%d):", len(m.comments)))
+		for _, comment := range m.comments {
+			lines = append(lines, "")
+			lines = append(lines, uiWrap(fmt.Sprintf("[%s] %s:", comment.CreatedAt.Local().Format("2006-01-02 15:04"), comment.Author), width)...)
+			for _, paragraph := range strings.Split(comment.Content, "\n") {
+				lines = append(lines, uiWrap(paragraph, width)...)
+			}
+		}
+	}
+	return lines
+}
+
+// footer returns the prompt, message, or key hints shown at the bottom
+func (m *uiModel) footer() string {
+	switch {
+	case m.prompt == uiPromptStatus:
+		var choices []string
+		for _, s := range uiStatuses {
+			choices = append(choices, fmt.Sprintf("[%s]%s", s.key, strings.TrimPrefix(s.status, s.key)))
+		}
+		return "Set status: " + strings.Join(choices, " ") + "  esc to cancel"
+	case m.prompt == uiPromptPriority:
+		var choices []string
+		for i, level := range types.Priorities() {
+			choices = append(choices, fmt.Sprintf("%d %s", i+1, level))
+		}
+		return "Set priority: " + strings.Join(choices, ", ") + "  esc to cancel"
+	case m.message != "":
+		return m.message
+	case m.help:
+		return "Press any key to go back"
+	default:
+		return "j/k move  enter details  s status  p priority  +/- raise/lower  x done  r refresh  ? help  q quit"
+	}
+}
+
+// uiHelpLines lists the dashboard's keys
+func uiHelpLines() []string {
+	return []string{
+		"Keys",
+		"",
+		"  j, down        Next task",
+		"  k, up          Previous task",
+		"  pgdn, ctrl+d   Down 10 tasks",
+		"  pgup, ctrl+u   Up 10 tasks",
+		"  g, G           First or last task",
+		"  tab            Next status group",
+		"  enter, space   Show or hide details and comments",
+		"  esc            Hide details",
+		"  s              Set status: a(ctive), i(nprogress), w(aiting), d(one), c(anceled)",
+		"  p              Set priority by number, lowest first",
+		"  +, -           Raise or lower priority",
+		"  x              Mark done",
+		"  r              Reload tasks",
+		"  q, ctrl+c      Quit",
+	}
+}
+
+// uiFitLines returns exactly height lines, each padded or cut to width
+func uiFitLines(lines []string, width, height int) []string {
+	rows := make([]string, height)
+	for i := range rows {
+		if i < len(lines) {
+			rows[i] = uiPad(lines[i], width)
+		} else {
+			rows[i] = uiPad("", width)
+		}
+	}
+	return rows
+}
+
+// uiPad pads s with spaces to width columns, or cuts it to fit with an
+// ellipsis
+func uiPad(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:max(width, 0)])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// uiWrap wraps s to width, keeping an empty line for an empty string
+func uiWrap(s string, width int) []string {
+	lines := wrapText(s, width)
+	if len(lines) == 0 {
+		return []string{""}
+	}
+	return lines
+}
+
+// parseUIKeys splits terminal input into key names: "up", "down", "enter",
+// "esc", "tab", "ctrl+c" and the like, or the typed character
+func parseUIKeys(input []byte) []string {
+	sequences := map[string]string{
+		"\033[A": "up", "\033[B": "down", "\033[C": "right", "\033[D": "left",
+		"\033OA": "up", "\033OB": "down",
+		"\033[H": "home", "\033[F": "end", "\033[1~": "home", "\033[4~": "end",
+		"\033[5~": "pgup", "\033[6~": "pgdown",
+	}
+
+	var keys []string
+	s := string(input)
+	for len(s) > 0 {
+		matched := false
+		for seq, name := range sequences {
+			if strings.HasPrefix(s, seq) {
+				keys = append(keys, name)
+				s = s[len(seq):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		r := []rune(s)[0]
+		s = s[len(string(r)):]
+		switch r {
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		case '\t':
+			keys = append(keys, "tab")
+		case 0x1b:
+			keys = append(keys, "esc")
+		case 0x03:
+			keys = append(keys, "ctrl+c")
+		case 0x04:
+			keys = append(keys, "ctrl+d")
+		case 0x15:
+			keys = append(keys, "ctrl+u")
+		default:
+			keys = append(keys, string(r))
+		}
+	}
+	return keys
+}
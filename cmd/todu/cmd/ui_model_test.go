@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func uiTestTasks() []*types.Task {
+	high := "high"
+	return []*types.Task{
+		{ID: 1, Title: "Write docs", ProjectID: 1, Status: "active"},
+		{ID: 2, Title: "Fix login", ProjectID: 1, Status: "inprogress", Priority: &high},
+		{ID: 3, Title: "Wait on review", ProjectID: 2, Status: "waiting"},
+		{ID: 4, Title: "Plan offsite", ProjectID: 2, Status: "active"},
+	}
+}
+
+func uiTaskIDs(m *uiModel) []int {
+	var ids []int
+	for _, task := range m.tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestUIModelGroupsByStatus(t *testing.T) {
+	m := newUIModel(uiTestTasks(), map[int]string{1: "todu.sh", 2: "Home"})
+	if got, want := uiTaskIDs(m), []int{2, 1, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected tasks in status order %v, got %v", want, got)
+	}
+
+	var headings []string
+	for _, line := range m.listLines() {
+		if line.task == -1 && line.text != "" {
+			headings = append(headings, line.text)
+		}
+	}
+	if want := []string{"In Progress (1)", "Active (2)", "Waiting (1)"}; !reflect.DeepEqual(headings, want) {
+		t.Errorf("headings = %v, want %v", headings, want)
+	}
+}
+
+func TestUIModelNavigation(t *testing.T) {
+	m := newUIModel(uiTestTasks(), nil)
+
+	m.handleKey("k")
+	if m.cursor != 0 {
+		t.Errorf("Expected the cursor to stop at the top, got %d", m.cursor)
+	}
+	m.handleKey("down")
+	m.handleKey("j")
+	if m.selected().ID != 4 {
+		t.Errorf("Expected task 4 after moving down twice, got %d", m.selected().ID)
+	}
+	m.handleKey("tab")
+	if m.selected().ID != 3 {
+		t.Errorf("Expected tab to move to the waiting group, got %d", m.selected().ID)
+	}
+	m.handleKey("tab")
+	if m.cursor != 0 {
+		t.Errorf("Expected tab to wrap to the first group, got %d", m.cursor)
+	}
+	m.handleKey("G")
+	if m.selected().ID != 3 {
+		t.Errorf("Expected G to move to the last task, got %d", m.selected().ID)
+	}
+}
+
+func TestUIModelStatusPrompt(t *testing.T) {
+	m := newUIModel(uiTestTasks(), nil)
+
+	if action := m.handleKey("s"); action != nil || m.prompt != uiPromptStatus {
+		t.Fatalf("Expected s to prompt for a status, got %+v", action)
+	}
+	action := m.handleKey("w")
+	if action == nil || action.kind != uiActionSetStatus || action.task.ID != 2 || action.value != "waiting" {
+		t.Fatalf("Unexpected action %+v", action)
+	}
+	if m.prompt != uiPromptNone {
+		t.Error("Expected the prompt to close")
+	}
+
+	// Choosing the current status or cancelling does nothing
+	m.handleKey("s")
+	if action := m.handleKey("i"); action != nil {
+		t.Errorf("Expected no action for the current status, got %+v", action)
+	}
+	m.handleKey("s")
+	if action := m.handleKey("esc"); action != nil || m.prompt != uiPromptNone {
+		t.Errorf("Expected esc to cancel, got %+v", action)
+	}
+}
+
+func TestUIModelPriority(t *testing.T) {
+	m := newUIModel(uiTestTasks(), nil)
+
+	// Task 2 is high, the top level
+	if action := m.handleKey("+"); action != nil {
+		t.Errorf("Expected no action raising the highest priority, got %+v", action)
+	}
+	if action := m.handleKey("-"); action == nil || action.value != "medium" {
+		t.Errorf("Expected - to lower to medium, got %+v", action)
+	}
+
+	// Task 1 has no priority, so either key sets the default
+	m.handleKey("j")
+	if action := m.handleKey("+"); action == nil || action.value != types.DefaultPriority() {
+		t.Errorf("Expected + to set the default priority, got %+v", action)
+	}
+
+	m.handleKey("p")
+	if action := m.handleKey("1"); action == nil || action.kind != uiActionSetPriority || action.value != "low" {
+		t.Errorf("Expected p 1 to set low, got %+v", action)
+	}
+}
+
+func TestUIModelReplaceTaskMovesGroup(t *testing.T) {
+	m := newUIModel(uiTestTasks(), nil)
+	m.handleKey("j") // task 1, active
+
+	updated := *m.selected()
+	updated.Status = "done"
+	m.replaceTask(&updated)
+
+	if got, want := uiTaskIDs(m), []int{2, 4, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the done task last, got %v", got)
+	}
+	if m.selected().ID != 1 {
+		t.Errorf("Expected the cursor to follow the task, got %d", m.selected().ID)
+	}
+}
+
+func TestUIModelDetailLoadsComments(t *testing.T) {
+	m := newUIModel(uiTestTasks(), map[int]string{1: "todu.sh"})
+
+	action := m.handleKey("enter")
+	if action == nil || action.kind != uiActionComments || action.task.ID != 2 {
+		t.Fatalf("Expected enter to load comments, got %+v", action)
+	}
+	m.comments = []*types.Comment{{Author: "erik", Content: "On it", CreatedAt: time.Date(2025, 6, 9, 8, 30, 0, 0, time.Local)}}
+	m.commentsFor = 2
+
+	detail := strings.Join(m.detailLines(60), "\n")
+	for _, want := range []string{"#2 Fix login", "Priority:  high", "Project:   todu.sh", "Comments (1):", "[2025-06-09 08:30] erik:\nOn it"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected detail to contain %q, got:\n%s", want, detail)
+		}
+	}
+
+	// Moving to another task loads its comments
+	if action := m.handleKey("j"); action == nil || action.kind != uiActionComments || action.task.ID != 1 {
+		t.Errorf("Expected comments for the next task, got %+v", action)
+	}
+	m.handleKey("esc")
+	if m.detail {
+		t.Error("Expected esc to close the detail pane")
+	}
+}
+
+func TestUIModelRender(t *testing.T) {
+	m := newUIModel(uiTestTasks(), map[int]string{1: "todu.sh", 2: "Home"})
+
+	screen := m.render(80, 10)
+	lines := strings.Split(screen, "\r\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 lines, got %d:\n%s", len(lines), screen)
+	}
+	if !strings.Contains(lines[2], uiReverse+"  #2     [high] Fix login  (todu.sh)") {
+		t.Errorf("Expected the selected task highlighted, got %q", lines[2])
+	}
+
+	// The list scrolls to keep the cursor in view
+	m.handleKey("G")
+	if screen := m.render(80, 6); !strings.Contains(screen, "Wait on review") || strings.Contains(screen, "Fix login") {
+		t.Errorf("Expected the list to scroll to the last task, got:\n%s", screen)
+	}
+}
+
+func TestUIPad(t *testing.T) {
+	if got := uiPad("abc", 5); got != "abc  " {
+		t.Errorf("uiPad() = %q", got)
+	}
+	if got := uiPad("héllo world", 6); got != "héllo…" {
+		t.Errorf("uiPad() = %q", got)
+	}
+}
+
+func TestParseUIKeys(t *testing.T) {
+	got := parseUIKeys([]byte("j\033[A\033[6~\r\t\033q\x03"))
+	want := []string{"j", "up", "pgdown", "enter", "tab", "esc", "q", "ctrl+c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUIKeys() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/export"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Saved searches used like projects",
+	Long: `Save task list filters under a name and use the result like a project.
+
+Views are kept in the config file under "views". They're evaluated by todu
+itself on top of the task list filters, so the server needs no support for
+them, and a view can span projects:
+
+  views:
+    hot:
+      priority: high
+      mine: true
+
+Use a view to list, sync, or export its tasks as a unit:
+  todu view show hot
+  todu task list --view hot --status active
+  todu sync --view hot
+  todu view export hot --to obsidian`,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List views and their filters",
+	Args:  cobra.NoArgs,
+	RunE:  runViewList,
+}
+
+var viewShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "List a view's tasks",
+	Long: `List the tasks a view matches, as "todu task list --view" does.
+
+Example:
+  todu view show hot`,
+	Args: cobra.ExactArgs(1),
+	RunE: runViewShow,
+}
+
+var viewAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Save a view",
+	Long: `Save task list filters as a view, replacing any view with the name.
+
+Examples:
+  todu view add hot --priority high --mine
+  todu view add acme --field customer=acme --status active
+  todu view add work-bugs --area work --label bug`,
+	Args: cobra.ExactArgs(1),
+	RunE: runViewAdd,
+}
+
+var viewRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewRemove,
+}
+
+var viewExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a view's tasks",
+	Long: `Export the tasks a view matches as a markdown list grouped by project,
+to any export target (see 'todu journal export --help').
+
+The file, html, and obsidian targets write <local_reports>/views/<name>.md,
+or its .html or Obsidian note counterpart.
+
+Examples:
+  todu view export hot --to stdout
+  todu view export hot --to obsidian
+  todu view export hot --to webhook --url https://hooks.example.com/todu`,
+	Args: cobra.ExactArgs(1),
+	RunE: runViewExport,
+}
+
+var (
+	// Add flags
+	viewAdd config.ViewConfig
+
+	// Export flags
+	viewExportTo  string
+	viewExportURL string
+)
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewShowCmd)
+	viewCmd.AddCommand(viewAddCmd)
+	viewCmd.AddCommand(viewRemoveCmd)
+	viewCmd.AddCommand(viewExportCmd)
+
+	viewAddCmd.Flags().StringVarP(&viewAdd.Project, "project", "p", "", "Only tasks in this project (ID or name)")
+	viewAddCmd.Flags().StringVar(&viewAdd.Area, "area", "", "Only tasks in this area's projects")
+	viewAddCmd.Flags().StringVar(&viewAdd.System, "system", "", "Only tasks in this system's projects")
+	viewAddCmd.Flags().StringVar(&viewAdd.Status, "status", "", "Only tasks with this status")
+	viewAddCmd.Flags().StringVar(&viewAdd.Priority, "priority", "", "Only tasks with this priority")
+	viewAddCmd.Flags().StringSliceVar(&viewAdd.Labels, "label", []string{}, "Only tasks with this label (repeatable)")
+	viewAddCmd.Flags().StringArrayVar(&viewAdd.Fields, "field", []string{}, "Only tasks with this custom field, as key=value (repeatable)")
+	viewAddCmd.Flags().StringVar(&viewAdd.Assignee, "assignee", "", "Only tasks assigned to this name")
+	viewAddCmd.Flags().BoolVar(&viewAdd.Mine, "mine", false, "Only tasks assigned to you")
+	viewAddCmd.Flags().BoolVar(&viewAdd.Starred, "starred", false, "Only starred tasks")
+	viewAddCmd.Flags().StringVar(&viewAdd.Search, "search", "", "Only tasks matching this full-text search")
+	viewAddCmd.MarkFlagsMutuallyExclusive("project", "area", "system")
+	viewAddCmd.MarkFlagsMutuallyExclusive("mine", "assignee")
+
+	addExportFlags(viewExportCmd, &viewExportTo, &viewExportURL, "stdout")
+}
+
+// applyView fills in task list filters that weren't given on the command
+// line from a view. Views ignore the repository's .todu.yaml.
+func applyView(view config.ViewConfig) {
+	if taskListProject == "" && taskListArea == "" && taskListSystem == "" {
+		taskListProject = view.Project
+		taskListArea = view.Area
+		taskListSystem = view.System
+	}
+	if taskListStatus == "" {
+		taskListStatus = view.Status
+	}
+	if taskListPriority == "" {
+		taskListPriority = view.Priority
+	}
+	if taskListAssignee == "" && !taskListMine {
+		taskListAssignee = view.Assignee
+		taskListMine = view.Mine
+	}
+	if taskListSearch == "" {
+		taskListSearch = view.Search
+	}
+	taskListLabels = append(taskListLabels, view.Labels...)
+	taskListFields = append(taskListFields, view.Fields...)
+	taskListStarred = taskListStarred || view.Starred
+	taskListNoContext = true
+}
+
+// describeView summarizes a view's filters as task list flags
+func describeView(view config.ViewConfig) string {
+	var parts []string
+	add := func(flag, value string) {
+		if value != "" {
+			parts = append(parts, fmt.Sprintf("--%s %s", flag, value))
+		}
+	}
+	add("project", view.Project)
+	add("area", view.Area)
+	add("system", view.System)
+	add("status", view.Status)
+	add("priority", view.Priority)
+	for _, label := range view.Labels {
+		add("label", label)
+	}
+	for _, field := range view.Fields {
+		add("field", field)
+	}
+	add("assignee", view.Assignee)
+	if view.Mine {
+		parts = append(parts, "--mine")
+	}
+	if view.Starred {
+		parts = append(parts, "--starred")
+	}
+	if view.Search != "" {
+		parts = append(parts, fmt.Sprintf("--search %q", view.Search))
+	}
+	if len(parts) == 0 {
+		return "(all tasks)"
+	}
+	return strings.Join(parts, " ")
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if GetOutputFormat() == "json" {
+		views := cfg.Views
+		if views == nil {
+			views = map[string]config.ViewConfig{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(views)
+	}
+
+	if len(cfg.Views) == 0 {
+		fmt.Fprintln(out, "No views defined. Add one with: todu view add <name> [filters]")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Views))
+	for name := range cfg.Views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VIEW\tFILTERS")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\n", name, describeView(cfg.Views[name]))
+	}
+	return w.Flush()
+}
+
+func runViewShow(cmd *cobra.Command, args []string) error {
+	taskListView = args[0]
+	if err := prepareTaskListFilters(); err != nil {
+		return err
+	}
+	return listTasks(cmd.OutOrStdout())
+}
+
+func runViewAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name, err := normalizeViewName(args[0])
+	if err != nil {
+		return err
+	}
+	if viewAdd.Priority != "" {
+		if err := types.ValidatePriority(viewAdd.Priority); err != nil {
+			return err
+		}
+	}
+	if viewAdd.Area != "" {
+		if _, ok := cfg.AreaProjects(viewAdd.Area); !ok {
+			return fmt.Errorf("unknown area %q (see 'todu area list')", viewAdd.Area)
+		}
+	}
+	for _, assignment := range viewAdd.Fields {
+		if _, value, err := types.ParseField(assignment); err != nil {
+			return err
+		} else if value == "" {
+			return fmt.Errorf("invalid field filter %q: use key=value", assignment)
+		}
+	}
+
+	views := make(map[string]config.ViewConfig, len(cfg.Views)+1)
+	for viewName, view := range cfg.Views {
+		if !strings.EqualFold(viewName, name) {
+			views[viewName] = view
+		}
+	}
+	views[name] = viewAdd
+	if err := config.SetViews(GetConfigFile(), views); err != nil {
+		return fmt.Errorf("failed to save views: %w", err)
+	}
+
+	fmt.Printf("Saved view %s: %s\n", name, describeView(viewAdd))
+	return nil
+}
+
+func runViewRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	views := make(map[string]config.ViewConfig, len(cfg.Views))
+	removed := ""
+	for viewName, view := range cfg.Views {
+		if strings.EqualFold(viewName, args[0]) {
+			removed = viewName
+			continue
+		}
+		views[viewName] = view
+	}
+	if removed == "" {
+		return fmt.Errorf("unknown view %q (see 'todu view list')", args[0])
+	}
+	if err := config.SetViews(GetConfigFile(), views); err != nil {
+		return fmt.Errorf("failed to save views: %w", err)
+	}
+
+	fmt.Printf("Removed view %s\n", removed)
+	return nil
+}
+
+func runViewExport(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	if cfg.LocalReports == "" && export.NeedsPath(viewExportTo) {
+		return fmt.Errorf("local_reports path not configured")
+	}
+
+	adapter, err := exportAdapter(cfg, viewExportTo, viewExportURL)
+	if err != nil {
+		return err
+	}
+
+	name, err := normalizeViewName(args[0])
+	if err != nil {
+		return err
+	}
+	taskListView = name
+	if err := prepareTaskListFilters(); err != nil {
+		return err
+	}
+
+	apiClient := api.NewClient(cfg.APIURL, cfg.APIKey)
+	ctx := commandContext()
+
+	tasks, err := fetchListTasks(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+	projects, err := apiClient.ListProjects(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	projectNames := make(map[int]string, len(projects))
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+	}
+
+	now := time.Now()
+	doc := &export.Document{
+		Kind:     export.KindView,
+		Title:    "View: " + name,
+		Date:     now,
+		Markdown: viewMarkdown(name, tasks, projectNames, now),
+	}
+	if cfg.LocalReports != "" {
+		doc.Path = filepath.Join(cfg.LocalReports, "views", name+".md")
+	}
+	return exportDocument(ctx, adapter, doc, "View")
+}
+
+// viewMarkdown renders a view's tasks as a checklist grouped by project, in
+// project name order
+func viewMarkdown(name string, tasks []*types.Task, projectNames map[int]string, now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# View: %s\n\n", name)
+	fmt.Fprintf(&sb, "Exported %s. %d task(s).\n", now.Format("01-02-2006 15:04"), len(tasks))
+
+	byProject := make(map[string][]*types.Task)
+	for _, task := range tasks {
+		project := projectNames[task.ProjectID]
+		if project == "" {
+			project = fmt.Sprintf("Project %d", task.ProjectID)
+		}
+		byProject[project] = append(byProject[project], task)
+	}
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		fmt.Fprintf(&sb, "\n## %s\n\n", project)
+		for _, task := range byProject[project] {
+			check := " "
+			if task.Status == "done" {
+				check = "x"
+			}
+			fmt.Fprintf(&sb, "- [%s] %s (#%d)", check, task.Title, task.ID)
+
+			var details []string
+			if task.Status != "active" && task.Status != "done" {
+				details = append(details, task.Status)
+			}
+			if task.Priority != nil {
+				details = append(details, *task.Priority)
+			}
+			if task.DueDate != nil {
+				details = append(details, "due "+task.DueDate.UTC().Format("2006-01-02"))
+			}
+			if len(details) > 0 {
+				fmt.Fprintf(&sb, " - %s", strings.Join(details, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// normalizeViewName lowercases a view name and checks it can be a file name
+func normalizeViewName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("view name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid view name %q", name)
+	}
+	return name, nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestApplyView(t *testing.T) {
+	defer func() {
+		taskListProject, taskListArea, taskListSystem = "", "", ""
+		taskListStatus, taskListPriority, taskListAssignee, taskListSearch = "", "", "", ""
+		taskListLabels, taskListFields = nil, nil
+		taskListMine, taskListStarred, taskListNoContext = false, false, false
+	}()
+
+	// Flags given on the command line win, and labels combine
+	taskListStatus = "done"
+	taskListLabels = []string{"bug"}
+
+	applyView(config.ViewConfig{
+		Area:     "work",
+		Status:   "active",
+		Priority: "high",
+		Labels:   []string{"backend"},
+		Fields:   []string{"customer=acme"},
+		Mine:     true,
+		Starred:  true,
+	})
+
+	if taskListStatus != "done" || taskListPriority != "high" || taskListArea != "work" {
+		t.Errorf("view filters not applied: status=%q priority=%q area=%q", taskListStatus, taskListPriority, taskListArea)
+	}
+	if !reflect.DeepEqual(taskListLabels, []string{"bug", "backend"}) || !reflect.DeepEqual(taskListFields, []string{"customer=acme"}) {
+		t.Errorf("labels=%v fields=%v", taskListLabels, taskListFields)
+	}
+	if !taskListMine || !taskListStarred || !taskListNoContext {
+		t.Errorf("mine=%t starred=%t no-context=%t", taskListMine, taskListStarred, taskListNoContext)
+	}
+}
+
+func TestApplyViewKeepsProjectFlag(t *testing.T) {
+	defer func() {
+		taskListProject, taskListArea, taskListNoContext = "", "", false
+	}()
+
+	taskListProject = "inbox"
+	applyView(config.ViewConfig{Area: "work"})
+	if taskListProject != "inbox" || taskListArea != "" {
+		t.Errorf("Expected --project to replace the view's area, got project=%q area=%q", taskListProject, taskListArea)
+	}
+}
+
+func TestDescribeView(t *testing.T) {
+	got := describeView(config.ViewConfig{Priority: "high", Labels: []string{"bug"}, Mine: true, Search: "login page"})
+	want := `--priority high --label bug --mine --search "login page"`
+	if got != want {
+		t.Errorf("describeView() = %q, want %q", got, want)
+	}
+	if got := describeView(config.ViewConfig{}); got != "(all tasks)" {
+		t.Errorf("describeView() of an empty view = %q", got)
+	}
+}
+
+func TestViewMarkdown(t *testing.T) {
+	high := "high"
+	due := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	tasks := []*types.Task{
+		{ID: 3, Title: "Fix login", ProjectID: 2, Status: "active", Priority: &high, DueDate: &due},
+		{ID: 1, Title: "Write docs", ProjectID: 1, Status: "done"},
+		{ID: 4, Title: "Plan offsite", ProjectID: 9, Status: "waiting"},
+	}
+	now := time.Date(2025, 6, 9, 8, 30, 0, 0, time.Local)
+
+	markdown := viewMarkdown("hot", tasks, map[int]string{1: "todu.sh", 2: "Website"}, now)
+
+	for _, want := range []string{
+		"# View: hot\n",
+		"Exported 06-09-2025 08:30. 3 task(s).",
+		"## Project 9\n\n- [ ] Plan offsite (#4) - waiting\n",
+		"## Website\n\n- [ ] Fix login (#3) - high, due 2025-06-10\n",
+		"## todu.sh\n\n- [x] Write docs (#1)\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+	if strings.Index(markdown, "## Project 9") > strings.Index(markdown, "## Website") {
+		t.Errorf("Expected projects in name order, got:\n%s", markdown)
+	}
+}
+
+func TestNormalizeViewName(t *testing.T) {
+	if name, err := normalizeViewName(" Hot "); err != nil || name != "hot" {
+		t.Errorf("normalizeViewName() = %q, %v", name, err)
+	}
+	for _, name := range []string{"", "a/b", ".."} {
+		if _, err := normalizeViewName(name); err == nil {
+			t.Errorf("normalizeViewName(%q): expected error", name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// watchDefaultInterval is used when --watch is given without a value.
+	watchDefaultInterval = "10s"
+
+	// watchMinInterval keeps watch mode from hammering the API.
+	watchMinInterval = time.Second
+
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\033[H\033[2J"
+)
+
+// parseWatchInterval parses a --watch value such as "30s" or "5m".
+func parseWatchInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --watch interval %q (use a duration like 30s or 5m): %w", value, err)
+	}
+	if interval < watchMinInterval {
+		return 0, fmt.Errorf("--watch interval must be at least %s", watchMinInterval)
+	}
+	return interval, nil
+}
+
+// watch clears the screen and calls render every interval until interrupted.
+// Render errors are shown in place of the output and don't stop watching.
+func watch(interval time.Duration, title string, render func() error) error {
+	ctx, stop := signal.NotifyContext(commandContext(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		redraw(os.Stdout, interval, title, render)
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// redraw renders a single watch frame.
+func redraw(out io.Writer, interval time.Duration, title string, render func() error) {
+	fmt.Fprint(out, clearScreen)
+	fmt.Fprintf(out, "Every %s: %s    %s\n\n", interval, title, time.Now().Format("2006-01-02 15:04:05"))
+	if err := render(); err != nil {
+		fmt.Fprintf(out, "Error: %v\n", err)
+	}
+}
+
+// watchTitle describes the watched command from its invocation.
+func watchTitle() string {
+	return "todu " + strings.Join(os.Args[1:], " ")
+}
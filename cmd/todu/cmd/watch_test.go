@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWatchInterval(t *testing.T) {
+	interval, err := parseWatchInterval(watchDefaultInterval)
+	if err != nil || interval != 10*time.Second {
+		t.Errorf("Expected default interval to parse, got %v (%v)", interval, err)
+	}
+
+	if _, err := parseWatchInterval("soon"); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+	if _, err := parseWatchInterval("100ms"); err == nil {
+		t.Error("Expected error for interval below minimum")
+	}
+}
+
+func TestRedraw(t *testing.T) {
+	var out bytes.Buffer
+	redraw(&out, 30*time.Second, "todu task list", func() error {
+		out.WriteString("rows\n")
+		return nil
+	})
+
+	got := out.String()
+	if !strings.HasPrefix(got, clearScreen) {
+		t.Error("Expected frame to start by clearing the screen")
+	}
+	if !strings.Contains(got, "Every 30s: todu task list") || !strings.HasSuffix(got, "rows\n") {
+		t.Errorf("Unexpected frame:\n%q", got)
+	}
+
+	out.Reset()
+	redraw(&out, time.Second, "x", func() error { return errors.New("connection refused") })
+	if !strings.Contains(out.String(), "Error: connection refused") {
+		t.Errorf("Expected render error in frame, got %q", out.String())
+	}
+}
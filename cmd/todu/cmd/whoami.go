@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/identity"
+	"github.com/spf13/cobra"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show who todu acts as",
+	Long: `Show the user the configured API key belongs to, as reported by the
+server, and the name todu uses for comments, journal entries, reactions,
+"todu task list --mine", and "me" in rules.
+
+That name is the author from the config if set, and otherwise the API
+key's user. The API key's user is fetched once and cached for a day in
+~/.config/todu/identity.json; use --refresh to fetch it again.`,
+	Args: cobra.NoArgs,
+	RunE: runWhoami,
+}
+
+// Whoami flags
+var whoamiRefresh bool
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+	whoamiCmd.Flags().BoolVar(&whoamiRefresh, "refresh", false, "Fetch the identity from the server instead of the cache")
+}
+
+func runWhoami(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIURL == "" {
+		return fmt.Errorf("API URL not configured")
+	}
+
+	var user identity.User
+	if cfg.APIKey != "" {
+		user, err = resolveIdentity(commandContext(), cfg, whoamiRefresh)
+		if err != nil {
+			return err
+		}
+	}
+	author := getAuthor("", cfg)
+
+	if GetOutputFormat() == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]any{
+			"api_url": cfg.APIURL,
+			"name":    user.Name,
+			"email":   user.Email,
+			"author":  author,
+		})
+	}
+
+	switch {
+	case user.Name != "":
+		fmt.Printf("User:    %s\n", user.Name)
+	case cfg.APIKey == "":
+		fmt.Println("User:    (no API key configured)")
+	default:
+		fmt.Println("User:    (not reported by the server)")
+	}
+	if user.Email != "" {
+		fmt.Printf("Email:   %s\n", user.Email)
+	}
+	fmt.Printf("Author:  %s\n", author)
+	return nil
+}
+
+// resolveIdentity returns the user the configured API key belongs to,
+// cached in the default identity cache.
+func resolveIdentity(ctx context.Context, cfg *config.Config, refresh bool) (identity.User, error) {
+	path, err := identity.DefaultCachePath()
+	if err != nil {
+		return identity.User{}, err
+	}
+	client := api.NewClient(cfg.APIURL, cfg.APIKey)
+	return identity.Resolve(ctx, client, identity.Key(cfg.APIURL, cfg.APIKey), path, refresh)
+}
+
+// currentUser returns the name of the user todu acts as: the author from
+// the config, or else the user the API key belongs to. It is empty if
+// neither is known.
+func currentUser(cfg *config.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	if cfg.Author != "" {
+		return cfg.Author
+	}
+	if cfg.APIURL == "" || cfg.APIKey == "" {
+		return ""
+	}
+	user, err := resolveIdentity(commandContext(), cfg, false)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
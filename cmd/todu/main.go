@@ -2,9 +2,13 @@ package main
 
 import (
 	"github.com/evcraddock/todu.sh/cmd/todu/cmd"
-	_ "github.com/evcraddock/todu.sh/plugins/forgejo" // Register Forgejo plugin
-	_ "github.com/evcraddock/todu.sh/plugins/github"  // Register GitHub plugin
-	_ "github.com/evcraddock/todu.sh/plugins/local"   // Register Local plugin
+	_ "github.com/evcraddock/todu.sh/plugins/azuredevops" // Register Azure DevOps plugin
+	_ "github.com/evcraddock/todu.sh/plugins/clickup"     // Register ClickUp plugin
+	_ "github.com/evcraddock/todu.sh/plugins/forgejo"     // Register Forgejo plugin
+	_ "github.com/evcraddock/todu.sh/plugins/generic"     // Register generic REST plugin
+	_ "github.com/evcraddock/todu.sh/plugins/github"      // Register GitHub plugin
+	_ "github.com/evcraddock/todu.sh/plugins/local"       // Register Local plugin
+	_ "github.com/evcraddock/todu.sh/plugins/todoist"     // Register Todoist plugin
 )
 
 func main() {
@@ -3,14 +3,21 @@ module github.com/evcraddock/todu.sh
 go 1.24.6
 
 require (
+	filippo.io/age v1.2.1
+	github.com/evcraddock/todu.sh/plugins/azuredevops v0.0.0-00010101000000-000000000000
+	github.com/evcraddock/todu.sh/plugins/clickup v0.0.0-00010101000000-000000000000
 	github.com/evcraddock/todu.sh/plugins/forgejo v0.0.0-00010101000000-000000000000
 	github.com/evcraddock/todu.sh/plugins/github v0.0.0-00010101000000-000000000000
+	github.com/evcraddock/todu.sh/plugins/todoist v0.0.0-00010101000000-000000000000
 	github.com/google/uuid v1.6.0
 	github.com/rs/zerolog v1.34.0
 	github.com/spf13/cobra v1.10.1
+	github.com/spf13/pflag v1.0.10
 	github.com/spf13/viper v1.21.0
 	github.com/teambition/rrule-go v1.8.2
 	golang.org/x/sync v0.19.0
+	golang.org/x/sys v0.29.0
+	golang.org/x/term v0.28.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -28,14 +35,19 @@ require (
 	github.com/sourcegraph/conc v0.3.1-0.20240121214520-5f936abd7ae8 // indirect
 	github.com/spf13/afero v1.15.0 // indirect
 	github.com/spf13/cast v1.10.0 // indirect
-	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
+	golang.org/x/crypto v0.24.0 // indirect
 	golang.org/x/oauth2 v0.33.0 // indirect
-	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 )
 
+replace github.com/evcraddock/todu.sh/plugins/azuredevops => ./plugins/azuredevops
+
+replace github.com/evcraddock/todu.sh/plugins/clickup => ./plugins/clickup
+
 replace github.com/evcraddock/todu.sh/plugins/forgejo => ./plugins/forgejo
 
 replace github.com/evcraddock/todu.sh/plugins/github => ./plugins/github
+
+replace github.com/evcraddock/todu.sh/plugins/todoist => ./plugins/todoist
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/evcraddock/todu.sh/pkg/types"
@@ -18,6 +22,43 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+
+	// readOnly is set once the key's scopes are checked, before the first
+	// request that would change data
+	keyChecked sync.Once
+	readOnly   bool
+
+	// lookups holds responses to system and project GETs, which commands
+	// repeat to resolve names; see getLookup
+	lookupsMu sync.Mutex
+	lookups   map[string][]byte
+}
+
+// transport is shared by every Client, so a command that creates several
+// clients, and long-running processes like the daemon, reuse kept-alive
+// connections to the API instead of opening one per request.
+var transport http.RoundTripper = newTransport()
+
+// Transport returns the transport clients send requests with.
+func Transport() http.RoundTripper {
+	return transport
+}
+
+// SetTransport replaces the transport of clients created afterwards, such
+// as to record or replay their requests.
+func SetTransport(rt http.RoundTripper) {
+	transport = rt
+}
+
+// maxIdleConnsPerHost is how many idle connections to the API are kept;
+// http.DefaultTransport keeps two, fewer than parallel syncs use.
+const maxIdleConnsPerHost = 16
+
+// newTransport returns the transport shared by clients
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	return t
 }
 
 // NewClient creates a new API client with the given base URL and API key
@@ -26,14 +67,23 @@ func NewClient(baseURL, apiKey string) *Client {
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
 	}
 }
 
 // doRequest executes an HTTP request to the API
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
-	url := c.baseURL + path
+	if err := c.checkWritable(ctx, method, path); err != nil {
+		return nil, err
+	}
+	if method != http.MethodGet {
+		// A change may make any remembered lookup out of date
+		c.ResetLookups()
+	}
+
+	reqURL := c.baseURL + path
 
 	var reqBody io.Reader
 	if body != nil {
@@ -44,7 +94,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -58,7 +108,15 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	if r := currentRecorder(); r != nil {
+		call := Call{Method: method, Path: path, Duration: time.Since(start)}
+		if resp != nil {
+			call.Status = resp.StatusCode
+		}
+		r.record(call)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -66,6 +124,75 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	return resp, nil
 }
 
+// getLookup gets a system or project path into dest, answering repeats of
+// the same GET from the response to the first. Clients live for one
+// command, so a command resolving the same names several times makes one
+// request each; long-lived clients call ResetLookups between runs.
+func (c *Client) getLookup(ctx context.Context, path string, dest interface{}) error {
+	c.lookupsMu.Lock()
+	data, ok := c.lookups[path]
+	c.lookupsMu.Unlock()
+	if ok {
+		// Decode afresh so callers can't change each other's results
+		if err := json.Unmarshal(data, dest); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	var raw json.RawMessage
+	if err := parseResponse(resp, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.lookupsMu.Lock()
+	if c.lookups == nil {
+		c.lookups = make(map[string][]byte)
+	}
+	c.lookups[path] = raw
+	c.lookupsMu.Unlock()
+	return nil
+}
+
+// ResetLookups forgets the system and project responses the client has
+// remembered, so the next lookups fetch them again
+func (c *Client) ResetLookups() {
+	c.lookupsMu.Lock()
+	defer c.lookupsMu.Unlock()
+	c.lookups = nil
+}
+
+// HTTPError is returned when the API responds with an error status code.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an API 404 response.
+func IsNotFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// withQuery appends encoded query parameters to an API path
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
 // parseResponse parses an HTTP response into the destination interface
 func parseResponse(resp *http.Response, dest interface{}) error {
 	defer resp.Body.Close()
@@ -77,7 +204,7 @@ func parseResponse(resp *http.Response, dest interface{}) error {
 	}
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bodyBytes))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	// Handle nil destination (e.g., for DELETE requests)
@@ -93,17 +220,96 @@ func parseResponse(resp *http.Response, dest interface{}) error {
 	return nil
 }
 
-// System Methods
+// API Key Methods
 
-// ListSystems retrieves all systems
-func (c *Client) ListSystems(ctx context.Context) ([]*types.System, error) {
-	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/systems/", nil)
+// Scopes an API key can have. Keys with only ScopeRead are read-only.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// ErrReadOnly is returned, without contacting the server, for requests
+// that would change data when the API key is read-only.
+var ErrReadOnly = errors.New("the API key is read-only")
+
+// KeyInfo describes the API key a client authenticates with
+type KeyInfo struct {
+	Name   string   `json:"name,omitempty"`
+	Scopes []string `json:"scopes"`
+}
+
+// ReadOnly reports whether the key can read but not change data. Keys
+// without scopes, from servers that don't report them, can do both.
+func (k *KeyInfo) ReadOnly() bool {
+	return len(k.Scopes) > 0 && !slices.Contains(k.Scopes, ScopeWrite)
+}
+
+// GetKeyInfo retrieves the scopes of the client's API key. Servers that
+// don't report scopes return a KeyInfo without any.
+func (c *Client) GetKeyInfo(ctx context.Context) (*KeyInfo, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/auth/key", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info KeyInfo
+	if err := parseResponse(resp, &info); err != nil {
+		if IsNotFound(err) {
+			return &KeyInfo{}, nil
+		}
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Identity is the user an API key belongs to
+type Identity struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// GetIdentity retrieves the user the client's API key belongs to. Servers
+// without a whoami endpoint return an Identity without a name.
+func (c *Client) GetIdentity(ctx context.Context) (*Identity, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/auth/whoami", nil)
 	if err != nil {
 		return nil, err
 	}
 
+	var identity Identity
+	if err := parseResponse(resp, &identity); err != nil {
+		if IsNotFound(err) {
+			return &Identity{}, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// checkWritable fails requests that would change data if the API key is
+// read-only, so mutating commands stop before doing anything. The key's
+// scopes are fetched once, before the first such request; if they can't
+// be, the server decides.
+func (c *Client) checkWritable(ctx context.Context, method, path string) error {
+	if method == http.MethodGet || method == http.MethodHead || c.apiKey == "" {
+		return nil
+	}
+	c.keyChecked.Do(func() {
+		info, err := c.GetKeyInfo(ctx)
+		c.readOnly = err == nil && info.ReadOnly()
+	})
+	if c.readOnly {
+		return fmt.Errorf("%w: %s %s is not allowed; read commands still work, use a key with the write scope to make changes", ErrReadOnly, method, path)
+	}
+	return nil
+}
+
+// System Methods
+
+// ListSystems retrieves all systems
+func (c *Client) ListSystems(ctx context.Context) ([]*types.System, error) {
 	var systems []*types.System
-	if err := parseResponse(resp, &systems); err != nil {
+	if err := c.getLookup(ctx, "/api/v1/systems/", &systems); err != nil {
 		return nil, err
 	}
 
@@ -113,13 +319,8 @@ func (c *Client) ListSystems(ctx context.Context) ([]*types.System, error) {
 // GetSystem retrieves a specific system by ID
 func (c *Client) GetSystem(ctx context.Context, id int) (*types.System, error) {
 	path := fmt.Sprintf("/api/v1/systems/%d", id)
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var system types.System
-	if err := parseResponse(resp, &system); err != nil {
+	if err := c.getLookup(ctx, path, &system); err != nil {
 		return nil, err
 	}
 
@@ -179,36 +380,22 @@ type ProjectListOptions struct {
 
 // ListProjects retrieves all projects, optionally filtered
 func (c *Client) ListProjects(ctx context.Context, opts *ProjectListOptions) ([]*types.Project, error) {
-	path := "/api/v1/projects/?"
-
+	query := url.Values{}
 	if opts != nil {
 		if opts.SystemID != nil {
-			path += fmt.Sprintf("system_id=%d&", *opts.SystemID)
+			query.Set("system_id", strconv.Itoa(*opts.SystemID))
 		}
-		if len(opts.Priority) > 0 {
-			for _, p := range opts.Priority {
-				path += fmt.Sprintf("priority=%s&", p)
-			}
+		for _, p := range opts.Priority {
+			query.Add("priority", p)
 		}
-		if len(opts.Status) > 0 {
-			for _, s := range opts.Status {
-				path += fmt.Sprintf("status=%s&", s)
-			}
+		for _, s := range opts.Status {
+			query.Add("status", s)
 		}
 	}
-
-	// Remove trailing & or ?
-	if path[len(path)-1] == '&' || path[len(path)-1] == '?' {
-		path = path[:len(path)-1]
-	}
-
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+	path := withQuery("/api/v1/projects/", query)
 
 	var projects []*types.Project
-	if err := parseResponse(resp, &projects); err != nil {
+	if err := c.getLookup(ctx, path, &projects); err != nil {
 		return nil, err
 	}
 
@@ -218,13 +405,8 @@ func (c *Client) ListProjects(ctx context.Context, opts *ProjectListOptions) ([]
 // GetProject retrieves a specific project by ID
 func (c *Client) GetProject(ctx context.Context, id int) (*types.Project, error) {
 	path := fmt.Sprintf("/api/v1/projects/%d", id)
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var project types.Project
-	if err := parseResponse(resp, &project); err != nil {
+	if err := c.getLookup(ctx, path, &project); err != nil {
 		return nil, err
 	}
 
@@ -264,10 +446,11 @@ func (c *Client) UpdateProject(ctx context.Context, id int, project *types.Proje
 
 // DeleteProject deletes a project
 func (c *Client) DeleteProject(ctx context.Context, id int, cascade bool) error {
-	path := fmt.Sprintf("/api/v1/projects/%d", id)
+	query := url.Values{}
 	if cascade {
-		path += "?cascade=true"
+		query.Set("cascade", "true")
 	}
+	path := withQuery(fmt.Sprintf("/api/v1/projects/%d", id), query)
 	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
@@ -299,62 +482,131 @@ type TaskListOptions struct {
 	ScheduledBefore string
 	UpdatedAfter    string
 	UpdatedBefore   string
-	Limit           int
+
+	// Due date, label, assignee, and search filters are applied by servers
+	// that support them. Others ignore them, so callers should still filter
+	// the results themselves.
+	DueAfter  string   // RFC3339 timestamp
+	DueBefore string   // RFC3339 timestamp
+	Labels    []string // tasks must have every label
+	Assignee  string
+	Search    string // text in the title or description
+
+	Skip  int
+	Limit int
 }
 
+// taskPageSize is the most tasks ListAllTasks requests at a time
+const taskPageSize = 500
+
 // ListTasks retrieves tasks with optional filters
 func (c *Client) ListTasks(ctx context.Context, opts *TaskListOptions) ([]*types.Task, error) {
-	path := "/api/v1/tasks/?"
+	page, err := c.listTaskPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// ListAllTasks retrieves every task matching the filters, taskPageSize at a
+// time. opts.Skip and opts.Limit are ignored.
+func (c *Client) ListAllTasks(ctx context.Context, opts *TaskListOptions) ([]*types.Task, error) {
+	pageOpts := TaskListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.Limit = taskPageSize
+
+	var tasks []*types.Task
+	seen := make(map[int]bool)
+	for {
+		pageOpts.Skip = len(tasks)
+		page, err := c.listTaskPage(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, task := range page.Items {
+			if !seen[task.ID] {
+				seen[task.ID] = true
+				tasks = append(tasks, task)
+				added++
+			}
+		}
+
+		// Stop at the total, on a short page, or if the server ignored
+		// skip and returned tasks we already have
+		if added == 0 || len(page.Items) < taskPageSize || len(tasks) >= page.Total {
+			break
+		}
+	}
+
+	return tasks, nil
+}
+
+// listTaskPage retrieves one page of tasks
+func (c *Client) listTaskPage(ctx context.Context, opts *TaskListOptions) (*TasksResponse, error) {
+	query := url.Values{}
+	query.Set("limit", "500")
 
 	if opts != nil {
 		if opts.ProjectID != nil {
-			path += fmt.Sprintf("project_id=%d&", *opts.ProjectID)
+			query.Set("project_id", strconv.Itoa(*opts.ProjectID))
 		}
 		if opts.Status != "" {
-			path += fmt.Sprintf("status=%s&", opts.Status)
+			query.Set("status", opts.Status)
 		}
 		if opts.Priority != "" {
-			path += fmt.Sprintf("priority=%s&", opts.Priority)
+			query.Set("priority", opts.Priority)
 		}
-		if len(opts.ProjectStatus) > 0 {
-			for _, ps := range opts.ProjectStatus {
-				path += fmt.Sprintf("project_status=%s&", ps)
-			}
+		for _, ps := range opts.ProjectStatus {
+			query.Add("project_status", ps)
 		}
-		if len(opts.ProjectPriority) > 0 {
-			for _, pp := range opts.ProjectPriority {
-				path += fmt.Sprintf("project_priority=%s&", pp)
-			}
+		for _, pp := range opts.ProjectPriority {
+			query.Add("project_priority", pp)
 		}
 		if opts.TemplateID != nil {
-			path += fmt.Sprintf("template_id=%d&", *opts.TemplateID)
+			query.Set("template_id", strconv.Itoa(*opts.TemplateID))
 		}
 		if opts.ScheduledDate != "" {
-			path += fmt.Sprintf("scheduled_date=%s&", opts.ScheduledDate)
+			query.Set("scheduled_date", opts.ScheduledDate)
 		}
 		if opts.ScheduledAfter != "" {
-			path += fmt.Sprintf("scheduled_after=%s&", opts.ScheduledAfter)
+			query.Set("scheduled_after", opts.ScheduledAfter)
 		}
 		if opts.ScheduledBefore != "" {
-			path += fmt.Sprintf("scheduled_before=%s&", opts.ScheduledBefore)
+			query.Set("scheduled_before", opts.ScheduledBefore)
 		}
 		if opts.UpdatedAfter != "" {
-			path += fmt.Sprintf("updated_after=%s&", opts.UpdatedAfter)
+			query.Set("updated_after", opts.UpdatedAfter)
 		}
 		if opts.UpdatedBefore != "" {
-			path += fmt.Sprintf("updated_before=%s&", opts.UpdatedBefore)
+			query.Set("updated_before", opts.UpdatedBefore)
+		}
+		if opts.DueAfter != "" {
+			query.Set("due_after", opts.DueAfter)
+		}
+		if opts.DueBefore != "" {
+			query.Set("due_before", opts.DueBefore)
+		}
+		for _, label := range opts.Labels {
+			query.Add("label", label)
+		}
+		if opts.Assignee != "" {
+			query.Set("assignee", opts.Assignee)
+		}
+		if opts.Search != "" {
+			query.Set("search", opts.Search)
+		}
+		if opts.Skip > 0 {
+			query.Set("skip", strconv.Itoa(opts.Skip))
 		}
 		if opts.Limit > 0 {
-			path += fmt.Sprintf("limit=%d&", opts.Limit)
-		} else {
-			path += "limit=500&"
+			query.Set("limit", strconv.Itoa(opts.Limit))
 		}
-	} else {
-		path += "limit=500&"
 	}
-
-	// Remove trailing & or ?
-	path = path[:len(path)-1]
+	path := withQuery("/api/v1/tasks/", query)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -366,7 +618,7 @@ func (c *Client) ListTasks(ctx context.Context, opts *TaskListOptions) ([]*types
 		return nil, err
 	}
 
-	return tasksResp.Items, nil
+	return &tasksResp, nil
 }
 
 // GetTask retrieves a specific task by ID
@@ -517,70 +769,120 @@ type CommentListOptions struct {
 	Type          string // "journal", "comment", or "all"
 	CreatedAfter  string // ISO date: YYYY-MM-DD or YYYY-MM-DDTHH:MM:SS
 	CreatedBefore string // ISO date: YYYY-MM-DD or YYYY-MM-DDTHH:MM:SS
+
+	// Search asks the server to match entries containing this text. Servers
+	// that don't support it return unfiltered entries, so callers should
+	// still filter the results themselves.
+	Search string
 }
 
-// ListCommentsFiltered retrieves comments/journals with optional filters
-func (c *Client) ListCommentsFiltered(ctx context.Context, opts *CommentListOptions) ([]*types.Comment, error) {
-	path := "/api/v1/comments?"
-	params := []string{"limit=10000"} // large limit to get all entries
+// commentPageSize is the most comments the API returns per request
+const commentPageSize = 100
 
+// ListCommentsFiltered retrieves all comments/journals matching the
+// filters, one page at a time
+func (c *Client) ListCommentsFiltered(ctx context.Context, opts *CommentListOptions) ([]*types.Comment, error) {
+	query := url.Values{}
 	if opts != nil {
 		if opts.Type != "" {
-			params = append(params, fmt.Sprintf("type=%s", opts.Type))
+			query.Set("type", opts.Type)
 		}
 		if opts.CreatedAfter != "" {
-			params = append(params, fmt.Sprintf("created_after=%s", opts.CreatedAfter))
+			query.Set("created_after", opts.CreatedAfter)
 		}
 		if opts.CreatedBefore != "" {
-			params = append(params, fmt.Sprintf("created_before=%s", opts.CreatedBefore))
+			query.Set("created_before", opts.CreatedBefore)
+		}
+		if opts.Search != "" {
+			query.Set("search", opts.Search)
 		}
 	}
 
-	path += strings.Join(params, "&")
+	return c.listCommentPages(ctx, query, 0, 0)
+}
 
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
+// ListJournals retrieves journal entries (comments without task_id),
+// starting at skip. A limit of 0 or less retrieves all of them.
+func (c *Client) ListJournals(ctx context.Context, skip, limit int) ([]*types.Comment, error) {
+	return c.listCommentPages(ctx, url.Values{"type": {"journal"}}, skip, limit)
+}
+
+// ListAllComments retrieves comments and journal entries with optional type
+// filter, starting at skip. A limit of 0 or less retrieves all of them.
+func (c *Client) ListAllComments(ctx context.Context, commentType string, skip, limit int) ([]*types.Comment, error) {
+	return c.listCommentPages(ctx, url.Values{"type": {commentType}}, skip, limit)
+}
 
+// listCommentPages retrieves up to limit comments (all if limit <= 0)
+// starting at skip, requesting commentPageSize at a time until a short
+// page shows there are no more.
+func (c *Client) listCommentPages(ctx context.Context, filters url.Values, skip, limit int) ([]*types.Comment, error) {
 	var comments []*types.Comment
-	if err := parseResponse(resp, &comments); err != nil {
-		return nil, err
+	for limit <= 0 || len(comments) < limit {
+		pageSize := commentPageSize
+		if limit > 0 && limit-len(comments) < pageSize {
+			pageSize = limit - len(comments)
+		}
+
+		query := url.Values{}
+		for key, values := range filters {
+			query[key] = values
+		}
+		query.Set("skip", strconv.Itoa(skip+len(comments)))
+		query.Set("limit", strconv.Itoa(pageSize))
+		resp, err := c.doRequest(ctx, http.MethodGet, withQuery("/api/v1/comments", query), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*types.Comment
+		if err := parseResponse(resp, &page); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page...)
+
+		// A short page is the last one; a long one means the server
+		// ignored the limit and returned everything
+		if len(page) != pageSize {
+			break
+		}
 	}
 
 	return comments, nil
 }
 
-// ListJournals retrieves all journal entries (comments without task_id)
-func (c *Client) ListJournals(ctx context.Context, skip, limit int) ([]*types.Comment, error) {
-	path := fmt.Sprintf("/api/v1/comments?type=journal&skip=%d&limit=%d", skip, limit)
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+// Label Methods
+
+// ListLabels retrieves all labels
+func (c *Client) ListLabels(ctx context.Context) ([]*types.Label, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/labels/", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var journals []*types.Comment
-	if err := parseResponse(resp, &journals); err != nil {
+	var labels []*types.Label
+	if err := parseResponse(resp, &labels); err != nil {
 		return nil, err
 	}
 
-	return journals, nil
+	return labels, nil
 }
 
-// ListAllComments retrieves all comments and journal entries with optional type filter
-func (c *Client) ListAllComments(ctx context.Context, commentType string, skip, limit int) ([]*types.Comment, error) {
-	path := fmt.Sprintf("/api/v1/comments?type=%s&skip=%d&limit=%d", commentType, skip, limit)
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+// UpdateLabel updates a label's color or emoji
+func (c *Client) UpdateLabel(ctx context.Context, id int, label *types.LabelUpdate) (*types.Label, error) {
+	path := fmt.Sprintf("/api/v1/labels/%d", id)
+	resp, err := c.doRequest(ctx, http.MethodPatch, path, label)
 	if err != nil {
 		return nil, err
 	}
 
-	var comments []*types.Comment
-	if err := parseResponse(resp, &comments); err != nil {
+	var updated types.Label
+	if err := parseResponse(resp, &updated); err != nil {
 		return nil, err
 	}
 
-	return comments, nil
+	return &updated, nil
 }
 
 // Recurring Task Template Methods
@@ -596,32 +898,27 @@ type TemplateListOptions struct {
 
 // ListTemplates retrieves recurring task templates with optional filters
 func (c *Client) ListTemplates(ctx context.Context, opts *TemplateListOptions) ([]*types.RecurringTaskTemplate, error) {
-	path := "/api/v1/recurring-templates/?"
+	query := url.Values{}
+	query.Set("limit", "100")
 
 	if opts != nil {
 		if opts.ProjectID != nil {
-			path += fmt.Sprintf("project_id=%d&", *opts.ProjectID)
+			query.Set("project_id", strconv.Itoa(*opts.ProjectID))
 		}
 		if opts.Active != nil {
-			path += fmt.Sprintf("is_active=%t&", *opts.Active)
+			query.Set("is_active", strconv.FormatBool(*opts.Active))
 		}
 		if opts.TemplateType != "" {
-			path += fmt.Sprintf("template_type=%s&", opts.TemplateType)
+			query.Set("template_type", opts.TemplateType)
 		}
 		if opts.Skip > 0 {
-			path += fmt.Sprintf("skip=%d&", opts.Skip)
+			query.Set("skip", strconv.Itoa(opts.Skip))
 		}
 		if opts.Limit > 0 {
-			path += fmt.Sprintf("limit=%d&", opts.Limit)
-		} else {
-			path += "limit=100&"
+			query.Set("limit", strconv.Itoa(opts.Limit))
 		}
-	} else {
-		path += "limit=100&"
 	}
-
-	// Remove trailing & or ?
-	path = path[:len(path)-1]
+	path := withQuery("/api/v1/recurring-templates/", query)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -2,11 +2,16 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
 func TestNewClient(t *testing.T) {
@@ -174,3 +179,110 @@ func TestAuth401ErrorMessage(t *testing.T) {
 		t.Errorf("Expected error to contain config set instructions, got '%s'", err.Error())
 	}
 }
+
+func TestClientsReuseConnections(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "Test"})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			connections++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		// A new client per call, as commands create them
+		if _, err := NewClient(server.URL, "").GetSystem(ctx, 1); err != nil {
+			t.Fatalf("GetSystem failed: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if connections != 1 {
+		t.Errorf("Expected one connection for sequential calls, got %d", connections)
+	}
+}
+
+func TestClientRemembersLookups(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	count := func(request string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[request]
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/projects/" {
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "name": "Test"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "Test"})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL, "")
+	for i := 0; i < 3; i++ {
+		projects, err := client.ListProjects(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListProjects failed: %v", err)
+		}
+		if len(projects) != 1 || projects[0].Name != "Test" {
+			t.Fatalf("Unexpected projects: %+v", projects)
+		}
+		// Changing a result doesn't change the next one
+		projects[0].Name = "Changed"
+	}
+	if count("GET /api/v1/projects/") != 1 {
+		t.Errorf("Expected 1 project list request, got %d", count("GET /api/v1/projects/"))
+	}
+
+	// A change, like a rename, is seen by the next lookup
+	if _, err := client.UpdateProject(ctx, 1, &types.ProjectUpdate{}); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+	if _, err := client.ListProjects(ctx, nil); err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if count("GET /api/v1/projects/") != 2 {
+		t.Errorf("Expected a new request after a change, got %d", count("GET /api/v1/projects/"))
+	}
+
+	// Tasks aren't remembered
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTask(ctx, 1); err != nil {
+			t.Fatalf("GetTask failed: %v", err)
+		}
+	}
+	if count("GET /api/v1/tasks/1") != 2 {
+		t.Errorf("Expected 2 task requests, got %d", count("GET /api/v1/tasks/1"))
+	}
+
+	client.ResetLookups()
+	if _, err := client.GetSystem(ctx, 1); err != nil {
+		t.Fatalf("GetSystem failed: %v", err)
+	}
+	if _, err := client.GetSystem(ctx, 1); err != nil {
+		t.Fatalf("GetSystem failed: %v", err)
+	}
+	client.ResetLookups()
+	if _, err := client.GetSystem(ctx, 1); err != nil {
+		t.Fatalf("GetSystem failed: %v", err)
+	}
+	if count("GET /api/v1/systems/1") != 2 {
+		t.Errorf("Expected 2 system requests around a reset, got %d", count("GET /api/v1/systems/1"))
+	}
+}
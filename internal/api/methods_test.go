@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +15,87 @@ import (
 	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
+// API Key Tests
+
+func TestReadOnlyKey(t *testing.T) {
+	var writes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/auth/key":
+			_, _ = w.Write([]byte(`{"name": "dashboard", "scopes": ["read"]}`))
+		case r.Method != http.MethodGet:
+			writes++
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		default:
+			_, _ = w.Write([]byte(`{"id": 1, "title": "Read me"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "sk_read")
+	if _, err := client.GetTask(context.Background(), 1); err != nil {
+		t.Fatalf("Expected reads to work, got %v", err)
+	}
+	_, err := client.CreateTask(context.Background(), &types.TaskCreate{Title: "t", ProjectID: 1})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	if writes != 0 {
+		t.Errorf("Expected no write to reach the server, got %d", writes)
+	}
+
+	info, err := client.GetKeyInfo(context.Background())
+	if err != nil || !info.ReadOnly() || info.Name != "dashboard" {
+		t.Errorf("Unexpected key info: %+v, %v", info, err)
+	}
+}
+
+func TestKeyWithoutScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/key" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "sk_old")
+	if _, err := client.CreateTask(context.Background(), &types.TaskCreate{Title: "t", ProjectID: 1}); err != nil {
+		t.Errorf("Expected writes to be allowed when the server doesn't report scopes, got %v", err)
+	}
+	if (&KeyInfo{Scopes: []string{ScopeRead, ScopeWrite}}).ReadOnly() {
+		t.Error("Expected a key with the write scope not to be read-only")
+	}
+}
+
+func TestGetIdentity(t *testing.T) {
+	found := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/whoami" || !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "jane", "email": "jane@example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "sk_test")
+	identity, err := client.GetIdentity(context.Background())
+	if err != nil || identity.Name != "jane" || identity.Email != "jane@example.com" {
+		t.Errorf("Unexpected identity: %+v, %v", identity, err)
+	}
+
+	found = false
+	identity, err = client.GetIdentity(context.Background())
+	if err != nil || identity.Name != "" {
+		t.Errorf("Expected an empty identity from servers without whoami, got %+v, %v", identity, err)
+	}
+}
+
 // System Tests
 
 func TestListSystems(t *testing.T) {
@@ -520,6 +604,111 @@ func TestListTasksWithFilter(t *testing.T) {
 	}
 }
 
+func TestListTasksSendsFiltersToServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("due_after"); got != "2025-01-01T00:00:00Z" {
+			t.Errorf("Expected due_after=2025-01-01T00:00:00Z, got %q", got)
+		}
+		if got := query.Get("due_before"); got != "2025-01-31T23:59:59Z" {
+			t.Errorf("Expected due_before=2025-01-31T23:59:59Z, got %q", got)
+		}
+		if got := query["label"]; len(got) != 2 || got[0] != "bug" || got[1] != "needs review" {
+			t.Errorf("Expected labels [bug, needs review], got %v", got)
+		}
+		if got := query.Get("assignee"); got != "alice" {
+			t.Errorf("Expected assignee=alice, got %q", got)
+		}
+		if got := query.Get("search"); got != "login & redirect" {
+			t.Errorf("Expected search='login & redirect', got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TasksResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	_, err := client.ListTasks(context.Background(), &TaskListOptions{
+		DueAfter:  "2025-01-01T00:00:00Z",
+		DueBefore: "2025-01-31T23:59:59Z",
+		Labels:    []string{"bug", "needs review"},
+		Assignee:  "alice",
+		Search:    "login & redirect",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestListAllTasksPaginates(t *testing.T) {
+	const total = 1203
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		query := r.URL.Query()
+		if got := query.Get("template_id"); got != "7" {
+			t.Errorf("Expected template_id=7, got %q", got)
+		}
+		skip, _ := strconv.Atoi(query.Get("skip"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		resp := TasksResponse{Total: total, Skip: skip, Limit: limit}
+		for id := skip + 1; id <= total && id <= skip+limit; id++ {
+			resp.Items = append(resp.Items, &types.Task{ID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	templateID := 7
+	tasks, err := client.ListAllTasks(context.Background(), &TaskListOptions{TemplateID: &templateID, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tasks) != total {
+		t.Errorf("Expected %d tasks, got %d", total, len(tasks))
+	}
+
+	want := []string{
+		"limit=500&template_id=7",
+		"limit=500&skip=500&template_id=7",
+		"limit=500&skip=1000&template_id=7",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, got %v", len(want), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Request %d: expected %q, got %q", i, want[i], requests[i])
+		}
+	}
+}
+
+func TestListAllTasksStopsWhenSkipIgnored(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		resp := TasksResponse{Total: 1000}
+		for id := 1; id <= 500; id++ {
+			resp.Items = append(resp.Items, &types.Task{ID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	tasks, err := client.ListAllTasks(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tasks) != 500 || requests != 2 {
+		t.Errorf("Expected 500 tasks from 2 requests, got %d from %d", len(tasks), requests)
+	}
+}
+
 func TestGetTask(t *testing.T) {
 	now := time.Now()
 	task := &types.Task{
@@ -675,6 +864,84 @@ func TestListComments(t *testing.T) {
 	}
 }
 
+// journalServer serves total journal entries, honoring skip and limit but
+// never returning more than 100 per request like the real API.
+func journalServer(total int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit > 100 {
+			limit = 100
+		}
+
+		page := []*types.Comment{}
+		for i := skip; i < total && len(page) < limit; i++ {
+			page = append(page, &types.Comment{ID: i + 1, Content: fmt.Sprintf("entry %d", i+1)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestListJournalsPaginates(t *testing.T) {
+	var requests []string
+	server := journalServer(250, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	result, err := client.ListJournals(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 250 || result[249].ID != 250 {
+		t.Errorf("Expected all 250 entries, got %d", len(result))
+	}
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 requests, got %d: %v", len(requests), requests)
+	}
+
+	// A limit stops early, and skip offsets every page
+	requests = nil
+	result, err = client.ListJournals(context.Background(), 50, 120)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 120 || result[0].ID != 51 || result[119].ID != 170 {
+		t.Errorf("Expected entries 51-170, got %d entries", len(result))
+	}
+	want := []string{"limit=100&skip=50&type=journal", "limit=20&skip=150&type=journal"}
+	if strings.Join(requests, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestListCommentsFilteredPaginates(t *testing.T) {
+	var requests []string
+	server := journalServer(100, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	result, err := client.ListCommentsFiltered(context.Background(), &CommentListOptions{
+		Type:   "journal",
+		Search: "quarterly report",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 100 {
+		t.Errorf("Expected 100 entries, got %d", len(result))
+	}
+
+	// A full page is followed by an empty one
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %v", requests)
+	}
+	if !strings.Contains(requests[0], "search=quarterly+report") {
+		t.Errorf("Expected search in query, got %s", requests[0])
+	}
+}
+
 func TestGetComment(t *testing.T) {
 	now := time.Now()
 	taskID := 1
@@ -1106,3 +1373,59 @@ func TestDeleteTemplate(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail":"Task not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	_, err := client.GetTask(context.Background(), 999)
+	if !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err.Error() != `HTTP 404: {"detail":"Task not found"}` {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+	if IsNotFound(fmt.Errorf("other")) {
+		t.Error("Expected plain error not to be a not found error")
+	}
+}
+
+// Label Tests
+
+func TestListAndUpdateLabels(t *testing.T) {
+	var update map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/labels/":
+			_ = json.NewEncoder(w).Encode([]*types.Label{{ID: 1, Name: "bug", Color: "d73a4a", Emoji: "🐛"}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/labels/1":
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			_ = json.NewEncoder(w).Encode(&types.Label{ID: 1, Name: "bug", Color: update["color"]})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	labels, err := client.ListLabels(context.Background())
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0].Color != "d73a4a" || labels[0].Emoji != "🐛" {
+		t.Errorf("Unexpected labels: %+v", labels)
+	}
+
+	color := "0e8a16"
+	updated, err := client.UpdateLabel(context.Background(), 1, &types.LabelUpdate{Color: &color})
+	if err != nil {
+		t.Fatalf("UpdateLabel failed: %v", err)
+	}
+	if _, sent := update["emoji"]; sent || updated.Color != "0e8a16" {
+		t.Errorf("Expected only the color to be sent, got %v", update)
+	}
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// tricky holds characters that corrupt a hand-built query string
+const tricky = "a&b=c #d+e?f%g"
+
+// captureQuery serves body for every request and records the query of the
+// last one.
+func captureQuery(t *testing.T, body string) (*httptest.Server, *url.Values) {
+	t.Helper()
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &got
+}
+
+func TestQueryEscaping(t *testing.T) {
+	projectID := 7
+	templateID := 9
+	active := true
+
+	tests := []struct {
+		name string
+		body string
+		call func(c *Client) error
+		want url.Values
+	}{
+		{
+			name: "ListProjects",
+			body: "[]",
+			call: func(c *Client) error {
+				_, err := c.ListProjects(context.Background(), &ProjectListOptions{
+					SystemID: &projectID,
+					Priority: []string{tricky, "high"},
+					Status:   []string{tricky},
+				})
+				return err
+			},
+			want: url.Values{
+				"system_id": {"7"},
+				"priority":  {tricky, "high"},
+				"status":    {tricky},
+			},
+		},
+		{
+			name: "ListTasks",
+			body: "{}",
+			call: func(c *Client) error {
+				_, err := c.ListTasks(context.Background(), &TaskListOptions{
+					ProjectID:       &projectID,
+					Status:          tricky,
+					Priority:        tricky,
+					ProjectStatus:   []string{tricky, "active"},
+					ProjectPriority: []string{tricky},
+					TemplateID:      &templateID,
+					ScheduledDate:   tricky,
+					ScheduledAfter:  tricky,
+					ScheduledBefore: tricky,
+					UpdatedAfter:    "2025-01-01T00:00:00+02:00",
+					UpdatedBefore:   tricky,
+					DueAfter:        "2025-01-01T00:00:00+02:00",
+					DueBefore:       tricky,
+					Labels:          []string{tricky, "needs review"},
+					Assignee:        tricky,
+					Search:          tricky,
+					Limit:           25,
+				})
+				return err
+			},
+			want: url.Values{
+				"project_id":       {"7"},
+				"status":           {tricky},
+				"priority":         {tricky},
+				"project_status":   {tricky, "active"},
+				"project_priority": {tricky},
+				"template_id":      {"9"},
+				"scheduled_date":   {tricky},
+				"scheduled_after":  {tricky},
+				"scheduled_before": {tricky},
+				"updated_after":    {"2025-01-01T00:00:00+02:00"},
+				"updated_before":   {tricky},
+				"due_after":        {"2025-01-01T00:00:00+02:00"},
+				"due_before":       {tricky},
+				"label":            {tricky, "needs review"},
+				"assignee":         {tricky},
+				"search":           {tricky},
+				"limit":            {"25"},
+			},
+		},
+		{
+			name: "ListCommentsFiltered",
+			body: "[]",
+			call: func(c *Client) error {
+				_, err := c.ListCommentsFiltered(context.Background(), &CommentListOptions{
+					Type:          tricky,
+					CreatedAfter:  "2025-01-01T00:00:00+02:00",
+					CreatedBefore: tricky,
+					Search:        tricky,
+				})
+				return err
+			},
+			want: url.Values{
+				"type":           {tricky},
+				"created_after":  {"2025-01-01T00:00:00+02:00"},
+				"created_before": {tricky},
+				"search":         {tricky},
+				"skip":           {"0"},
+				"limit":          {"100"},
+			},
+		},
+		{
+			name: "ListAllComments",
+			body: "[]",
+			call: func(c *Client) error {
+				_, err := c.ListAllComments(context.Background(), tricky, 5, 10)
+				return err
+			},
+			want: url.Values{
+				"type":  {tricky},
+				"skip":  {"5"},
+				"limit": {"10"},
+			},
+		},
+		{
+			name: "ListTemplates",
+			body: "[]",
+			call: func(c *Client) error {
+				_, err := c.ListTemplates(context.Background(), &TemplateListOptions{
+					ProjectID:    &projectID,
+					Active:       &active,
+					TemplateType: tricky,
+					Skip:         3,
+					Limit:        4,
+				})
+				return err
+			},
+			want: url.Values{
+				"project_id":    {"7"},
+				"is_active":     {"true"},
+				"template_type": {tricky},
+				"skip":          {"3"},
+				"limit":         {"4"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, got := captureQuery(t, tt.body)
+			if err := tt.call(NewClient(server.URL, "")); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Expected query %v, got %v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	if got := withQuery("/api/v1/tasks/", url.Values{}); got != "/api/v1/tasks/" {
+		t.Errorf("Expected no query string, got %q", got)
+	}
+	if got := withQuery("/api/v1/projects/1", url.Values{"cascade": {"true"}}); got != "/api/v1/projects/1?cascade=true" {
+		t.Errorf("Expected cascade query, got %q", got)
+	}
+}
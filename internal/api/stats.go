@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Call is one request made to the API.
+type Call struct {
+	Method   string
+	Path     string
+	Status   int // 0 if the request failed before a response
+	Duration time.Duration
+}
+
+// Endpoint returns the call's path without its query, with numeric IDs
+// replaced by {id}, so repeated calls to the same endpoint group together.
+func (c Call) Endpoint() string {
+	path, _, _ := strings.Cut(c.Path, "?")
+	return c.Method + " " + idSegment.ReplaceAllString(path, "/{id}")
+}
+
+var idSegment = regexp.MustCompile(`/\d+`)
+
+// Recorder collects the calls made by every Client while it is installed
+// with SetRecorder. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+var (
+	recorderMu sync.Mutex
+	recorder   *Recorder
+)
+
+// SetRecorder makes every Client record its requests into r. A nil r stops
+// recording.
+func SetRecorder(r *Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = r
+}
+
+// currentRecorder returns the installed Recorder, or nil.
+func currentRecorder() *Recorder {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	return recorder
+}
+
+func (r *Recorder) record(call Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns the recorded calls in the order they finished.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// WriteSummary writes the number of calls and their total time, the calls
+// per endpoint (to spot N+1 patterns), and the slowest calls.
+func (r *Recorder) WriteSummary(w io.Writer, slowest int) {
+	calls := r.Calls()
+
+	var total time.Duration
+	counts := make(map[string]int)
+	durations := make(map[string]time.Duration)
+	for _, call := range calls {
+		total += call.Duration
+		counts[call.Endpoint()]++
+		durations[call.Endpoint()] += call.Duration
+	}
+
+	fmt.Fprintf(w, "API calls: %d, total time %s\n", len(calls), roundDuration(total))
+	if len(calls) == 0 {
+		return
+	}
+
+	endpoints := make([]string, 0, len(counts))
+	for endpoint := range counts {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if counts[endpoints[i]] != counts[endpoints[j]] {
+			return counts[endpoints[i]] > counts[endpoints[j]]
+		}
+		return endpoints[i] < endpoints[j]
+	})
+
+	fmt.Fprintln(w, "By endpoint:")
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(w, "  %4d  %8s  %s\n", counts[endpoint], roundDuration(durations[endpoint]), endpoint)
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Duration > calls[j].Duration
+	})
+	if len(calls) > slowest {
+		calls = calls[:slowest]
+	}
+
+	fmt.Fprintln(w, "Slowest:")
+	for _, call := range calls {
+		status := "error"
+		if call.Status != 0 {
+			status = fmt.Sprintf("%d", call.Status)
+		}
+		fmt.Fprintf(w, "  %8s  %s  %s %s\n", roundDuration(call.Duration), status, call.Method, call.Path)
+	}
+}
+
+// roundDuration rounds to a readable precision.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorderRecordsCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/tasks/2" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	recorder := &Recorder{}
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	client := NewClient(server.URL, "")
+	_, _ = client.GetTask(context.Background(), 1)
+	_, _ = client.GetTask(context.Background(), 2)
+
+	calls := recorder.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Method != http.MethodGet || calls[0].Path != "/api/v1/tasks/1" || calls[0].Status != http.StatusOK {
+		t.Errorf("Unexpected first call: %+v", calls[0])
+	}
+	if calls[1].Status != http.StatusNotFound {
+		t.Errorf("Expected second call to record 404, got %d", calls[1].Status)
+	}
+
+	// Nothing is recorded once the recorder is removed
+	SetRecorder(nil)
+	_, _ = client.GetTask(context.Background(), 1)
+	if len(recorder.Calls()) != 2 {
+		t.Errorf("Expected no more calls after SetRecorder(nil), got %d", len(recorder.Calls()))
+	}
+}
+
+func TestCallEndpoint(t *testing.T) {
+	tests := []struct {
+		call Call
+		want string
+	}{
+		{Call{Method: "GET", Path: "/api/v1/tasks/42"}, "GET /api/v1/tasks/{id}"},
+		{Call{Method: "GET", Path: "/api/v1/tasks/42/comments"}, "GET /api/v1/tasks/{id}/comments"},
+		{Call{Method: "GET", Path: "/api/v1/tasks/?limit=500&project_id=3"}, "GET /api/v1/tasks/"},
+	}
+	for _, tt := range tests {
+		if got := tt.call.Endpoint(); got != tt.want {
+			t.Errorf("Endpoint(%q) = %q, want %q", tt.call.Path, got, tt.want)
+		}
+	}
+}
+
+func TestRecorderWriteSummary(t *testing.T) {
+	recorder := &Recorder{}
+	recorder.record(Call{Method: "GET", Path: "/api/v1/projects/", Status: 200, Duration: 20 * time.Millisecond})
+	for i := 1; i <= 3; i++ {
+		recorder.record(Call{Method: "GET", Path: "/api/v1/projects/1", Status: 200, Duration: time.Duration(i) * time.Millisecond})
+	}
+	recorder.record(Call{Method: "POST", Path: "/api/v1/tasks/", Duration: 50 * time.Millisecond})
+
+	var out strings.Builder
+	recorder.WriteSummary(&out, 2)
+	summary := out.String()
+
+	for _, want := range []string{
+		"API calls: 5, total time 76ms",
+		"     3       6ms  GET /api/v1/projects/{id}",
+		"Slowest:\n      50ms  error  POST /api/v1/tasks/\n      20ms  200  GET /api/v1/projects/\n",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+
+	var empty strings.Builder
+	(&Recorder{}).WriteSummary(&empty, 5)
+	if empty.String() != "API calls: 0, total time 0s\n" {
+		t.Errorf("Unexpected empty summary: %q", empty.String())
+	}
+}
@@ -17,9 +17,100 @@ type Config struct {
 	Author         string               `mapstructure:"author"`
 	LocalReports   string               `mapstructure:"local_reports"`
 	Daemon         DaemonConfig         `mapstructure:"daemon"`
+	Sync           SyncConfig           `mapstructure:"sync"`
 	Output         OutputConfig         `mapstructure:"output"`
 	Defaults       DefaultsConfig       `mapstructure:"defaults"`
 	RecurringTasks RecurringTasksConfig `mapstructure:"recurring_tasks"`
+	Review         ReviewConfig         `mapstructure:"review"`
+	Reports        ReportsConfig        `mapstructure:"reports"`
+	Holidays       HolidaysConfig       `mapstructure:"holidays"`
+	Suggest        SuggestConfig        `mapstructure:"suggest"`
+	Mentions       MentionsConfig       `mapstructure:"mentions"`
+	Export         ExportConfig         `mapstructure:"export"`
+	Wellness       WellnessConfig       `mapstructure:"wellness"`
+
+	// RulesFile is the rules file for automatic task actions
+	// (default: ~/.config/todu/rules.yaml)
+	RulesFile string `mapstructure:"rules_file"`
+
+	// Hooks maps hook event names (e.g. post-task-create) to shell commands
+	Hooks map[string]string `mapstructure:"hooks"`
+
+	// Confirmations controls whether destructive commands ask before
+	// deleting or changing data. When false, they behave as if --yes was given.
+	Confirmations bool `mapstructure:"confirmations"`
+
+	// Areas groups projects into areas of life such as work or home, mapping
+	// each area name to the names of its projects
+	Areas map[string][]string `mapstructure:"areas"`
+
+	// DayStart is the time (HH:MM) the day starts for --today filters, the
+	// daily review, and journal exports. Work done after midnight but
+	// before it counts toward the previous day.
+	DayStart string `mapstructure:"day_start"`
+
+	// Priorities lists the task and project priority levels, lowest first
+	Priorities []string `mapstructure:"priorities"`
+
+	// SLA maps labels to the time tasks with them may stay open, such as
+	// 14d for bug. Open tasks past it get the sla-breach label.
+	SLA map[string]string `mapstructure:"sla"`
+
+	// Fields maps project names to the custom fields their tasks may have.
+	// Projects not listed take any field.
+	Fields map[string][]string `mapstructure:"fields"`
+
+	// Views are saved searches by name, used like projects by task list,
+	// sync, and view export
+	Views map[string]ViewConfig `mapstructure:"views"`
+}
+
+// ViewConfig is a saved search: task list filters kept under a name
+type ViewConfig struct {
+	Project  string   `mapstructure:"project" yaml:"project,omitempty" json:"project,omitempty"`
+	Area     string   `mapstructure:"area" yaml:"area,omitempty" json:"area,omitempty"`
+	System   string   `mapstructure:"system" yaml:"system,omitempty" json:"system,omitempty"`
+	Status   string   `mapstructure:"status" yaml:"status,omitempty" json:"status,omitempty"`
+	Priority string   `mapstructure:"priority" yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels   []string `mapstructure:"labels" yaml:"labels,omitempty" json:"labels,omitempty"`
+	Fields   []string `mapstructure:"fields" yaml:"fields,omitempty" json:"fields,omitempty"` // key=value
+	Assignee string   `mapstructure:"assignee" yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Mine     bool     `mapstructure:"mine" yaml:"mine,omitempty" json:"mine,omitempty"`
+	Starred  bool     `mapstructure:"starred" yaml:"starred,omitempty" json:"starred,omitempty"`
+	Search   string   `mapstructure:"search" yaml:"search,omitempty" json:"search,omitempty"`
+}
+
+// AreaProjects returns the project names in an area (case-insensitive),
+// and false if the area is not defined.
+func (c *Config) AreaProjects(area string) ([]string, bool) {
+	for name, projects := range c.Areas {
+		if strings.EqualFold(name, area) {
+			return projects, true
+		}
+	}
+	return nil, false
+}
+
+// ProjectFields returns the custom fields allowed on a project's tasks
+// (case-insensitive project name), and false if the project takes any.
+func (c *Config) ProjectFields(project string) ([]string, bool) {
+	for name, fields := range c.Fields {
+		if strings.EqualFold(name, project) {
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// View returns the saved search with a name (case-insensitive), and false
+// if there is none.
+func (c *Config) View(name string) (ViewConfig, bool) {
+	for viewName, view := range c.Views {
+		if strings.EqualFold(viewName, name) {
+			return view, true
+		}
+	}
+	return ViewConfig{}, false
 }
 
 // DefaultsConfig contains default values for commands
@@ -37,26 +128,212 @@ type DaemonConfig struct {
 	LogMaxAgeDays int    `mapstructure:"log_max_age_days"`
 }
 
+// SyncConfig contains sync settings shared by the sync command and daemon
+type SyncConfig struct {
+	CommentUpdates []int `mapstructure:"comment_updates"`
+
+	// Duplicates selects how a pull detects work items already synced from
+	// another system: url, title, or off
+	Duplicates string `mapstructure:"duplicates"`
+}
+
 // RecurringTasksConfig contains recurring task processing settings
 type RecurringTasksConfig struct {
 	Enabled bool `mapstructure:"enabled"`
+
+	// Catchup is the default policy for missed occurrences used by
+	// "todu template catchup": all, latest, or none
+	Catchup string `mapstructure:"catchup"`
+
+	// Timezone is the default IANA timezone for new templates
+	Timezone string `mapstructure:"timezone"`
+}
+
+// ReviewConfig contains review report settings
+type ReviewConfig struct {
+	Daily  DailyReviewConfig  `mapstructure:"daily"`
+	Weekly WeeklyReviewConfig `mapstructure:"weekly"`
+}
+
+// DailyReviewConfig contains daily review settings
+type DailyReviewConfig struct {
+	// Sections lists the daily review sections to show, in order
+	Sections []string `mapstructure:"sections"`
+
+	// SoonDays is how many days ahead "Coming up Soon" looks
+	SoonDays int `mapstructure:"soon_days"`
+
+	// Statuses lists the task statuses shown in "Coming up Soon" and "Next"
+	Statuses []string `mapstructure:"statuses"`
+
+	// Limits caps the number of items shown per section, by section name
+	Limits map[string]int `mapstructure:"limits"`
+
+	// Custom defines additional sections listing tasks that match a filter
+	Custom []ReviewSectionConfig `mapstructure:"custom"`
+
+	// Carryover adds the "Carried over from Yesterday" section to the default order
+	Carryover bool `mapstructure:"carryover"`
+
+	// Journal adds the day's journal entries to the default order
+	Journal bool `mapstructure:"journal"`
+
+	// RemindAt is the local time (HH:MM) after which "todu notify" reminds
+	// that no daily review was generated today
+	RemindAt string `mapstructure:"remind_at"`
+}
+
+// WeeklyReviewConfig contains weekly review settings
+type WeeklyReviewConfig struct {
+	// RemindAfterDays is how many days after the last weekly review
+	// "todu notify" reminds that the next one is due
+	RemindAfterDays int `mapstructure:"remind_after_days"`
+}
+
+// ReviewSectionConfig defines a custom daily review section
+type ReviewSectionConfig struct {
+	Name          string   `mapstructure:"name"`
+	Statuses      []string `mapstructure:"statuses"`
+	Priority      string   `mapstructure:"priority"`
+	Labels        []string `mapstructure:"labels"`
+	Project       string   `mapstructure:"project"`
+	Area          string   `mapstructure:"area"`
+	DueWithinDays int      `mapstructure:"due_within_days"`
+}
+
+// ReportsConfig contains retention settings for the dated files in
+// local_reports, used by "todu reports prune"
+type ReportsConfig struct {
+	// OlderThan is the default age of months to prune, such as 1y
+	OlderThan string `mapstructure:"older_than"`
+
+	// Archive rolls pruned months into a yearly archive instead of
+	// deleting them
+	Archive bool `mapstructure:"archive"`
+}
+
+// HolidaysConfig sets the holiday calendar that --skip-holidays and
+// skip-holidays templates move tasks off. Holidays from both are combined.
+type HolidaysConfig struct {
+	// Country is a country code with built-in holidays, such as US or GB
+	Country string `mapstructure:"country"`
+
+	// ICS is the path to an ICS calendar whose all-day events are holidays
+	ICS string `mapstructure:"ics"`
+}
+
+// SuggestConfig contains todu suggest settings
+type SuggestConfig struct {
+	// Capacity is how much estimated work fits in a day, such as 6h
+	Capacity string `mapstructure:"capacity"`
+
+	// DefaultEstimate is assumed for tasks without an estimate label
+	DefaultEstimate string `mapstructure:"default_estimate"`
+}
+
+// MentionsConfig contains todu mentions settings
+type MentionsConfig struct {
+	// Names are the @names that mention you, such as your GitHub login.
+	// The author name is always included.
+	Names []string `mapstructure:"names"`
+}
+
+// WellnessConfig contains optional settings that ease the pressure of
+// long task lists and habit streaks
+type WellnessConfig struct {
+	// MaxNext caps the daily review's Next section, leaving the rest
+	// unmentioned; 0 shows everything
+	MaxNext int `mapstructure:"max_next"`
+
+	// Milestones are counts of tasks done in a day the daily review
+	// celebrates, such as [5, 10]
+	Milestones []int `mapstructure:"milestones"`
+
+	// StreakFreeze is how many missed occurrences in any 7 days a habit
+	// streak survives
+	StreakFreeze int `mapstructure:"streak_freeze"`
+}
+
+// ExportConfig contains settings for the --to export targets
+type ExportConfig struct {
+	Obsidian ObsidianExportConfig `mapstructure:"obsidian"`
+	Webhook  WebhookExportConfig  `mapstructure:"webhook"`
+	Email    EmailExportConfig    `mapstructure:"email"`
+}
+
+// ObsidianExportConfig contains the Obsidian export target settings
+type ObsidianExportConfig struct {
+	// Vault is the path to the Obsidian vault
+	Vault string `mapstructure:"vault"`
+
+	// Folder is the folder in the vault exports go in
+	Folder string `mapstructure:"folder"`
+}
+
+// WebhookExportConfig contains the webhook export target settings
+type WebhookExportConfig struct {
+	// URL receives exports as POST requests
+	URL string `mapstructure:"url"`
+
+	// Headers are added to each request, such as Authorization
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// EmailExportConfig contains the email export target settings, used by
+// "todu digest send"
+type EmailExportConfig struct {
+	From string   `mapstructure:"from"`
+	To   []string `mapstructure:"to"`
+
+	// SMTP is the mail server; when its host is empty, Sendmail is used
+	SMTP SMTPConfig `mapstructure:"smtp"`
+
+	// Sendmail is a command that reads the message on stdin, such as
+	// "sendmail -t -i"
+	Sendmail string `mapstructure:"sendmail"`
+}
+
+// SMTPConfig contains SMTP server settings
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
 }
 
 // OutputConfig contains output formatting settings
 type OutputConfig struct {
 	Format string `mapstructure:"format"`
 	Color  bool   `mapstructure:"color"`
+
+	// TaskColumns is the default column list for "task list"
+	TaskColumns []string `mapstructure:"task_columns"`
 }
 
 // Load loads configuration from file and environment variables
 // If configPath is provided, it will be used exclusively.
 // Otherwise, searches in order: ./config.yaml, ~/.config/todu/config.yaml, ~/.todu/config.yaml
 func Load(configPath string) (*Config, error) {
+	return LoadFrom("", configPath)
+}
+
+// LoadFrom loads configuration like Load, as if run in dir: a relative
+// configPath and the local ./config.yaml are looked up in dir. An empty dir
+// is the working directory.
+func LoadFrom(dir, configPath string) (*Config, error) {
 	// If a specific config path is provided, use it exclusively
 	if configPath != "" {
+		if !filepath.IsAbs(configPath) {
+			configPath = filepath.Join(dir, configPath)
+		}
 		return loadFromFile(configPath, true)
 	}
 
+	local := "."
+	if dir != "" {
+		local = dir
+	}
+
 	// Otherwise use the default search paths
 	homeDir, err := os.UserHomeDir()
 	var paths []string
@@ -64,12 +341,12 @@ func Load(configPath string) (*Config, error) {
 		// Search local config first, then global configs
 		// This allows local development config to override global config
 		paths = []string{
-			".",
+			local,
 			filepath.Join(homeDir, ".config", "todu"),
 			filepath.Join(homeDir, ".todu"),
 		}
 	} else {
-		paths = []string{"."}
+		paths = []string{local}
 	}
 	return loadFromPaths(paths, true)
 }
@@ -90,10 +367,45 @@ func loadFromFile(filePath string, enableEnv bool) (*Config, error) {
 	v.SetDefault("daemon.log_max_size_mb", 10)
 	v.SetDefault("daemon.log_max_backups", 5)
 	v.SetDefault("daemon.log_max_age_days", 7)
+	v.SetDefault("sync.comment_updates", []int{})
+	v.SetDefault("sync.duplicates", "url")
 	v.SetDefault("recurring_tasks.enabled", true)
+	v.SetDefault("recurring_tasks.catchup", "latest")
+	v.SetDefault("recurring_tasks.timezone", "UTC")
+	v.SetDefault("review.daily.sections", []string{})
+	v.SetDefault("review.daily.soon_days", 3)
+	v.SetDefault("review.daily.statuses", []string{"active"})
+	v.SetDefault("review.daily.carryover", false)
+	v.SetDefault("review.daily.journal", false)
+	v.SetDefault("review.daily.remind_at", "09:00")
+	v.SetDefault("review.weekly.remind_after_days", 7)
+	v.SetDefault("reports.older_than", "")
+	v.SetDefault("reports.archive", false)
+	v.SetDefault("holidays.country", "")
+	v.SetDefault("holidays.ics", "")
+	v.SetDefault("suggest.capacity", "6h")
+	v.SetDefault("suggest.default_estimate", "1h")
+	v.SetDefault("wellness.max_next", 0)
+	v.SetDefault("wellness.milestones", []int{})
+	v.SetDefault("wellness.streak_freeze", 0)
+	v.SetDefault("export.obsidian.vault", "")
+	v.SetDefault("export.obsidian.folder", "todu")
+	v.SetDefault("export.webhook.url", "")
+	v.SetDefault("export.email.from", "")
+	v.SetDefault("export.email.to", []string{})
+	v.SetDefault("export.email.sendmail", "")
+	v.SetDefault("export.email.smtp.host", "")
+	v.SetDefault("export.email.smtp.port", 587)
+	v.SetDefault("export.email.smtp.username", "")
+	v.SetDefault("export.email.smtp.password", "")
+	v.SetDefault("day_start", "00:00")
+	v.SetDefault("priorities", []string{"low", "medium", "high"})
 	v.SetDefault("output.format", "text")
 	v.SetDefault("output.color", true)
+	v.SetDefault("output.task_columns", []string{})
 	v.SetDefault("defaults.project", "")
+	v.SetDefault("rules_file", "")
+	v.SetDefault("confirmations", true)
 
 	// Enable environment variable support with TODU_ prefix
 	if enableEnv {
@@ -136,10 +448,45 @@ func loadFromPaths(paths []string, enableEnv bool) (*Config, error) {
 	v.SetDefault("daemon.log_max_size_mb", 10)
 	v.SetDefault("daemon.log_max_backups", 5)
 	v.SetDefault("daemon.log_max_age_days", 7)
+	v.SetDefault("sync.comment_updates", []int{})
+	v.SetDefault("sync.duplicates", "url")
 	v.SetDefault("recurring_tasks.enabled", true)
+	v.SetDefault("recurring_tasks.catchup", "latest")
+	v.SetDefault("recurring_tasks.timezone", "UTC")
+	v.SetDefault("review.daily.sections", []string{})
+	v.SetDefault("review.daily.soon_days", 3)
+	v.SetDefault("review.daily.statuses", []string{"active"})
+	v.SetDefault("review.daily.carryover", false)
+	v.SetDefault("review.daily.journal", false)
+	v.SetDefault("review.daily.remind_at", "09:00")
+	v.SetDefault("review.weekly.remind_after_days", 7)
+	v.SetDefault("reports.older_than", "")
+	v.SetDefault("reports.archive", false)
+	v.SetDefault("holidays.country", "")
+	v.SetDefault("holidays.ics", "")
+	v.SetDefault("suggest.capacity", "6h")
+	v.SetDefault("suggest.default_estimate", "1h")
+	v.SetDefault("wellness.max_next", 0)
+	v.SetDefault("wellness.milestones", []int{})
+	v.SetDefault("wellness.streak_freeze", 0)
+	v.SetDefault("export.obsidian.vault", "")
+	v.SetDefault("export.obsidian.folder", "todu")
+	v.SetDefault("export.webhook.url", "")
+	v.SetDefault("export.email.from", "")
+	v.SetDefault("export.email.to", []string{})
+	v.SetDefault("export.email.sendmail", "")
+	v.SetDefault("export.email.smtp.host", "")
+	v.SetDefault("export.email.smtp.port", 587)
+	v.SetDefault("export.email.smtp.username", "")
+	v.SetDefault("export.email.smtp.password", "")
+	v.SetDefault("day_start", "00:00")
+	v.SetDefault("priorities", []string{"low", "medium", "high"})
 	v.SetDefault("output.format", "text")
 	v.SetDefault("output.color", true)
+	v.SetDefault("output.task_columns", []string{})
 	v.SetDefault("defaults.project", "")
+	v.SetDefault("rules_file", "")
+	v.SetDefault("confirmations", true)
 
 	// Set config file name and type
 	v.SetConfigName("config")
@@ -199,6 +546,26 @@ func GetConfigPath() (string, error) {
 // If configPath is empty, uses the default config path.
 // It preserves other settings in the file.
 func SetAPIKey(configPath, apiKey string) error {
+	return setValue(configPath, "api_key", apiKey)
+}
+
+// SetAreas replaces the areas in the config file.
+// If configPath is empty, uses the default config path.
+// It preserves other settings in the file.
+func SetAreas(configPath string, areas map[string][]string) error {
+	return setValue(configPath, "areas", areas)
+}
+
+// SetViews replaces the saved searches in the config file.
+// If configPath is empty, uses the default config path.
+// It preserves other settings in the file.
+func SetViews(configPath string, views map[string]ViewConfig) error {
+	return setValue(configPath, "views", views)
+}
+
+// setValue sets a top-level key in the config file, preserving other
+// settings. If configPath is empty, uses the default config path.
+func setValue(configPath, key string, value interface{}) error {
 	var err error
 	if configPath == "" {
 		configPath, err = GetConfigPath()
@@ -220,8 +587,7 @@ func SetAPIKey(configPath, apiKey string) error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Update api_key
-	configData["api_key"] = apiKey
+	configData[key] = value
 
 	// Write back to file
 	newData, err := yaml.Marshal(configData)
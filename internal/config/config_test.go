@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -265,6 +266,35 @@ func TestLoadFromCustomPathNotFound(t *testing.T) {
 	}
 }
 
+func TestLoadFromDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("api_url: http://local.example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("api_url: http://other.example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	// The local config is found in dir, not the working directory
+	config, err := LoadFrom(dir, "")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if config.APIURL != "http://local.example.com" {
+		t.Errorf("Expected the config in dir, got APIURL %q", config.APIURL)
+	}
+
+	// A relative path is relative to dir
+	config, err = LoadFrom(dir, "other.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if config.APIURL != "http://other.example.com" {
+		t.Errorf("Expected other.yaml in dir, got APIURL %q", config.APIURL)
+	}
+}
+
 func TestLoadWithEmptyPath(t *testing.T) {
 	// Load with empty path should use default search paths
 	// This test will use the system's actual config if it exists, or defaults
@@ -355,3 +385,91 @@ func TestLoadDefaultsProjectFromEnv(t *testing.T) {
 		t.Errorf("Expected Defaults.Project from env to be 'EnvInbox', got '%s'", config.Defaults.Project)
 	}
 }
+
+func TestSetAreas(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("api_url: http://example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if err := SetAreas(configPath, map[string][]string{"work": {"todu.sh", "Website"}, "home": {}}); err != nil {
+		t.Fatalf("SetAreas failed: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.APIURL != "http://example.com" {
+		t.Errorf("Expected other settings preserved, got APIURL %q", config.APIURL)
+	}
+
+	projects, ok := config.AreaProjects("Work")
+	if !ok || len(projects) != 2 || projects[1] != "Website" {
+		t.Errorf("Expected work area projects, got %v (%t)", projects, ok)
+	}
+	if projects, ok := config.AreaProjects("home"); !ok || len(projects) != 0 {
+		t.Errorf("Expected empty home area, got %v (%t)", projects, ok)
+	}
+	if _, ok := config.AreaProjects("health"); ok {
+		t.Error("Expected unknown area to be reported")
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := "api_url: http://example.com\nfields:\n  Consulting: [customer, story-points]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	fields, ok := config.ProjectFields("consulting")
+	if !ok || len(fields) != 2 || fields[0] != "customer" {
+		t.Errorf("Expected consulting fields, got %v (%t)", fields, ok)
+	}
+	if _, ok := config.ProjectFields("Inbox"); ok {
+		t.Error("Expected a project without fields to take any")
+	}
+}
+
+func TestSetViews(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("api_url: http://example.com\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	views := map[string]ViewConfig{
+		"hot": {Priority: "high", Mine: true, Labels: []string{"bug"}},
+	}
+	if err := SetViews(configPath, views); err != nil {
+		t.Fatalf("SetViews failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if strings.Contains(string(data), "search:") {
+		t.Errorf("Expected unset filters to be left out, got:\n%s", data)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	view, ok := config.View("HOT")
+	if !ok || view.Priority != "high" || !view.Mine || len(view.Labels) != 1 || view.Labels[0] != "bug" {
+		t.Errorf("Expected the hot view, got %+v (%t)", view, ok)
+	}
+	if _, ok := config.View("cold"); ok {
+		t.Error("Expected unknown view to be reported")
+	}
+}
@@ -15,8 +15,17 @@ import (
 
 	"github.com/evcraddock/todu.sh/internal/api"
 	"github.com/evcraddock/todu.sh/internal/config"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/internal/hooks"
 	"github.com/evcraddock/todu.sh/internal/journal"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/internal/reminders"
+	"github.com/evcraddock/todu.sh/internal/review"
+	"github.com/evcraddock/todu.sh/internal/rules"
+	"github.com/evcraddock/todu.sh/internal/sla"
 	"github.com/evcraddock/todu.sh/internal/sync"
+	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
 // SyncEngine is an interface for sync operations (allows mocking in tests)
@@ -45,6 +54,7 @@ type Daemon struct {
 	apiClient     APIClient
 	fullAPIClient *api.Client
 	config        *config.Config
+	hooks         *hooks.Runner
 	logger        zerolog.Logger
 	stopChan      chan struct{}
 	doneChan      chan struct{}
@@ -72,6 +82,7 @@ func New(engine SyncEngine, apiClient APIClient, config *config.Config) *Daemon
 		engine:    engine,
 		apiClient: apiClient,
 		config:    config,
+		hooks:     hooks.NewRunner(config.Hooks),
 		logger:    logger,
 		stopChan:  make(chan struct{}),
 		doneChan:  make(chan struct{}),
@@ -194,6 +205,12 @@ func (d *Daemon) runSync(ctx context.Context) error {
 	d.status.LastSyncTime = time.Now()
 	d.status.LastSyncError = ""
 
+	// Look up projects and systems afresh, to pick up ones added since
+	// the last sync
+	if d.fullAPIClient != nil {
+		d.fullAPIClient.ResetLookups()
+	}
+
 	// Check if this is a new day - if so, export yesterday's journal
 	if d.isNewDay(previousSyncTime) {
 		d.exportYesterdayJournal(ctx)
@@ -201,10 +218,21 @@ func (d *Daemon) runSync(ctx context.Context) error {
 
 	// Build sync options
 	options := sync.Options{
-		ProjectIDs: d.config.Daemon.Projects,
+		ProjectIDs:              d.config.Daemon.Projects,
+		CommentUpdateProjectIDs: d.config.Sync.CommentUpdates,
+		DuplicateMatch:          sync.DuplicateMatch(d.config.Sync.Duplicates),
+	}
+
+	// A failing pre-sync hook skips this sync
+	if err := d.hooks.Run(ctx, hooks.PreSync, map[string]any{"dry_run": false, "project_ids": options.ProjectIDs}); err != nil {
+		d.status.LastSyncError = err.Error()
+		d.status.ErrorCount++
+		d.writeStatus()
+		return err
 	}
 
 	// Run sync
+	started := time.Now()
 	result, err := d.engine.Sync(ctx, options)
 	if err != nil {
 		d.status.LastSyncError = err.Error()
@@ -213,12 +241,19 @@ func (d *Daemon) runSync(ctx context.Context) error {
 		return err
 	}
 
+	d.applyRules(ctx, started)
+
+	if err := d.hooks.Run(ctx, hooks.PostSync, sync.NewReport(result, false)); err != nil {
+		d.logger.Warn().Err(err).Msg("Post-sync hook failed")
+	}
+
 	// Log results summary
 	d.logger.Info().
 		Int("created", result.TotalCreated).
 		Int("updated", result.TotalUpdated).
 		Int("skipped", result.TotalSkipped).
 		Int("errors", result.TotalErrors).
+		Str("run_id", result.RunID).
 		Msg("Sync completed")
 
 	// Process recurring task templates if enabled (independent of sync errors)
@@ -227,8 +262,12 @@ func (d *Daemon) runSync(ctx context.Context) error {
 			d.logger.Warn().Err(err).Msg("Failed to process recurring templates")
 			// Don't fail the entire sync - just log the error
 		}
+		d.rescheduleRecurringTasks(ctx)
 	}
 
+	d.sendTaskReminders(ctx)
+	d.applySLA(ctx)
+
 	if result.TotalErrors > 0 {
 		// Log detailed errors for each project (always log errors regardless of level)
 		for _, pr := range result.ProjectResults {
@@ -255,6 +294,34 @@ func (d *Daemon) runSync(ctx context.Context) error {
 	return nil
 }
 
+// applyRules applies sync rules to the tasks touched by the sync that
+// started at started. The rules file is reloaded each time so edits take
+// effect without restarting the daemon.
+func (d *Daemon) applyRules(ctx context.Context, started time.Time) {
+	if d.fullAPIClient == nil {
+		return
+	}
+
+	path, err := rules.ResolvePath(d.config.RulesFile)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to find rules file")
+		return
+	}
+	loaded, err := rules.Load(path)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to load rules")
+		return
+	}
+
+	outcomes, err := rules.NewEngine(loaded, d.config.Author).ApplyUpdatedSince(ctx, d.fullAPIClient, started)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to apply rules")
+	}
+	for _, o := range outcomes {
+		d.logger.Info().Int("task", o.TaskID).Strs("rules", o.Rules).Msg("Rules applied")
+	}
+}
+
 // processRecurringTasks processes due recurring task templates
 func (d *Daemon) processRecurringTasks(ctx context.Context) error {
 	d.logger.Debug().Msg("Processing recurring task templates...")
@@ -299,6 +366,132 @@ func (d *Daemon) processRecurringTasks(ctx context.Context) error {
 	return nil
 }
 
+// rescheduleRecurringTasks moves upcoming tasks generated from
+// skip-weekends or skip-holidays templates off the days they skip. The API
+// generates occurrences without knowing about weekends or holidays, both
+// here and when a task is completed.
+func (d *Daemon) rescheduleRecurringTasks(ctx context.Context) {
+	if d.fullAPIClient == nil {
+		return
+	}
+
+	icsPath := ""
+	if d.config.Holidays.ICS != "" {
+		icsPath = review.ExpandPath(d.config.Holidays.ICS)
+	}
+	cal, err := holidays.Load(d.config.Holidays.Country, icsPath)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to load holiday calendar")
+		return
+	}
+
+	now := time.Now()
+	tasks, err := d.fullAPIClient.ListAllTasks(ctx, &api.TaskListOptions{
+		Status:         "active",
+		ScheduledAfter: now.Format("2006-01-02"),
+	})
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to list tasks to reschedule")
+		return
+	}
+
+	for _, task := range tasks {
+		if task.Status != "active" {
+			continue
+		}
+		update := recurring.Reschedule(task, cal)
+		if update == nil {
+			continue
+		}
+		if _, err := d.fullAPIClient.UpdateTask(ctx, task.ID, update); err != nil {
+			d.logger.Warn().Err(err).Int("task", task.ID).Msg("Failed to reschedule recurring task")
+			continue
+		}
+		d.logger.Info().
+			Int("task", task.ID).
+			Str("from", task.ScheduledDate.UTC().Format("2006-01-02")).
+			Str("to", update.ScheduledDate.Format("2006-01-02")).
+			Msg("Moved recurring task off a skipped day")
+	}
+}
+
+// sendTaskReminders sends the task reminders that are due as desktop
+// notifications, sharing the record of sent reminders with "todu notify" so
+// each is only sent once.
+func (d *Daemon) sendTaskReminders(ctx context.Context) {
+	if d.fullAPIClient == nil {
+		return
+	}
+
+	statePath, err := reminders.DefaultStatePath()
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to find reminder state")
+		return
+	}
+	state, err := reminders.LoadState(statePath)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to load reminder state")
+		return
+	}
+
+	now := time.Now()
+	tasks, err := d.fullAPIClient.ListAllTasks(ctx, &api.TaskListOptions{
+		DueAfter: now.Add(-reminders.MaxLate).Format(time.RFC3339),
+	})
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to list tasks for reminders")
+		return
+	}
+
+	pending := reminders.Pending(tasks, now, state)
+	if len(pending) == 0 {
+		return
+	}
+	for _, r := range pending {
+		title := fmt.Sprintf("Task #%d: %s", r.TaskID, r.Title)
+		if err := reminders.SendDesktop(title, r.Message(now)); err != nil {
+			d.logger.Warn().Err(err).Int("task", r.TaskID).Msg("Failed to send task reminder")
+			continue
+		}
+		d.logger.Info().Int("task", r.TaskID).Str("offset", types.FormatReminderOffset(r.Offset)).Msg("Sent task reminder")
+	}
+
+	// Failed notifications aren't retried, so a missing notifier doesn't
+	// log the same reminders every sync
+	state.MarkNotified(pending, now)
+	if err := state.Save(statePath); err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to save reminder state")
+	}
+}
+
+// applySLA adds the sla-breach label to open tasks past their SLA and
+// removes it from those no longer breaching.
+func (d *Daemon) applySLA(ctx context.Context) {
+	if d.fullAPIClient == nil || len(d.config.SLA) == 0 {
+		return
+	}
+
+	rules, err := sla.ParseRules(d.config.SLA)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Invalid SLA configuration")
+		return
+	}
+	tasks, err := sla.ListTasks(ctx, d.fullAPIClient, rules)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to list tasks for SLA check")
+		return
+	}
+
+	changes := sla.Changes(tasks, rules, time.Now())
+	applied, err := sla.Apply(ctx, d.fullAPIClient, changes)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to update SLA breach labels")
+	}
+	if applied > 0 {
+		d.logger.Info().Int("tasks", applied).Msg("Updated SLA breach labels")
+	}
+}
+
 // writeStatus writes the current status to the status file
 func (d *Daemon) writeStatus() {
 	homeDir, err := os.UserHomeDir()
@@ -384,11 +577,19 @@ func (d *Daemon) isNewDay(previousSyncTime time.Time) bool {
 		return true
 	}
 
-	now := time.Now()
-	prevYear, prevMonth, prevDay := previousSyncTime.Local().Date()
-	nowYear, nowMonth, nowDay := now.Local().Date()
+	// Days begin at the configured day start, not midnight
+	return !daystart.SameDay(previousSyncTime.Local(), daystart.Today(d.dayStart()), d.dayStart())
+}
 
-	return prevYear != nowYear || prevMonth != nowMonth || prevDay != nowDay
+// dayStart returns how long after midnight the day starts, from day_start
+// in the config
+func (d *Daemon) dayStart() time.Duration {
+	start, err := daystart.Parse(d.config.DayStart)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Ignoring day_start")
+		return 0
+	}
+	return start
 }
 
 // exportYesterdayJournal exports the previous day's journal to a markdown file
@@ -404,11 +605,11 @@ func (d *Daemon) exportYesterdayJournal(ctx context.Context) {
 	}
 
 	// Use midnight local time for yesterday to match CLI behavior
-	now := time.Now()
-	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.Local)
+	dayStart := d.dayStart()
+	yesterday := daystart.Today(dayStart).AddDate(0, 0, -1)
 	d.logger.Info().Time("date", yesterday).Msg("Exporting previous day's journal")
 
-	outputPath, err := journal.Export(ctx, d.fullAPIClient, yesterday, d.config.LocalReports)
+	outputPath, err := journal.Export(ctx, d.fullAPIClient, yesterday, dayStart, d.config.LocalReports)
 	if err != nil {
 		d.logger.Warn().Err(err).Msg("Failed to export journal")
 		return
@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// socketTimeout bounds how long a request over the socket may take
+const socketTimeout = 30 * time.Second
+
+// Request asks the daemon to run a command and return its output
+type Request struct {
+	// Args are the command line, without the program name
+	Args []string `json:"args"`
+
+	// Dir is the caller's working directory
+	Dir string `json:"dir"`
+
+	// Columns is the width of the caller's terminal, or 0 if none
+	Columns int `json:"columns,omitempty"`
+
+	// Terminal reports whether the caller's stdout is a terminal
+	Terminal bool `json:"terminal,omitempty"`
+}
+
+// Response is the output of a command run by the daemon
+type Response struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// Handler runs a request. ctx is done when the daemon stops
+type Handler func(ctx context.Context, req Request) Response
+
+// SocketPath returns the path of the socket the daemon answers commands on
+func SocketPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "daemon.sock"), nil
+}
+
+// Serve answers requests on a Unix socket at path until ctx is done.
+// Requests are handled one at a time. A socket left behind by a daemon
+// that didn't shut down cleanly is replaced.
+func Serve(ctx context.Context, path string, handler Handler) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("another daemon is listening on %s", path)
+	}
+	_ = os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var mu sync.Mutex
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go func() {
+			defer conn.Close()
+			_ = conn.SetDeadline(time.Now().Add(socketTimeout))
+
+			var req Request
+			if err := json.NewDecoder(conn).Decode(&req); err != nil {
+				return
+			}
+			mu.Lock()
+			resp := handler(ctx, req)
+			mu.Unlock()
+			_ = json.NewEncoder(conn).Encode(resp)
+		}()
+	}
+}
+
+// Call sends a request to the daemon listening at path. It fails quickly
+// if no daemon is listening.
+func Call(path string, req Request) (*Response, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(socketTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeAndCall(t *testing.T) {
+	// Unix socket paths are limited to about 100 bytes, so avoid long
+	// test directory names
+	dir, err := os.MkdirTemp("", "todu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "daemon.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, path, func(_ context.Context, req Request) Response {
+			return Response{Stdout: strings.Join(req.Args, " ") + " in " + req.Dir, ExitCode: 3}
+		})
+	}()
+
+	var resp *Response
+	for i := 0; i < 50; i++ {
+		if resp, err = Call(path, Request{Args: []string{"task", "list"}, Dir: "/work"}); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if resp.Stdout != "task list in /work" || resp.ExitCode != 3 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a private socket, got %v, %v", info, err)
+	}
+
+	// A second daemon doesn't take over the socket
+	if err := Serve(ctx, path, nil); err == nil || !strings.Contains(err.Error(), "another daemon") {
+		t.Errorf("Expected an error for a socket in use, got %v", err)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket to be removed, got %v", err)
+	}
+	if _, err := Call(path, Request{}); err == nil {
+		t.Error("Expected Call to fail without a daemon")
+	}
+}
@@ -0,0 +1,45 @@
+// Package daystart maps times to the day they count toward when the day
+// starts at a configured time, such as 04:00, instead of midnight. Work done
+// after midnight but before the day start counts toward the previous day.
+package daystart
+
+import (
+	"fmt"
+	"time"
+)
+
+// Parse parses a day start in HH:MM form, between 00:00 and 23:59, and
+// returns how long after midnight the day starts. An empty string is
+// midnight.
+func Parse(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid day start %q (use HH:MM, e.g. 04:00)", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Day returns the date t counts toward, as a midnight in t's location.
+// Convert t with Local (or In) first to get the day in that timezone.
+func Day(t time.Time, start time.Duration) time.Time {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if t.Before(date.Add(start)) {
+		date = date.AddDate(0, 0, -1)
+	}
+	return date
+}
+
+// Today returns the local date, as a local midnight, that now counts toward.
+func Today(start time.Duration) time.Time {
+	return Day(time.Now(), start)
+}
+
+// SameDay reports whether t counts toward the date of day, in t's location.
+func SameDay(t, day time.Time, start time.Duration) bool {
+	y1, m1, d1 := Day(t, start).Date()
+	y2, m2, d2 := day.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
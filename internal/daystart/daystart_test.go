@@ -0,0 +1,66 @@
+package daystart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"00:00", 0, false},
+		{"04:00", 4 * time.Hour, false},
+		{"05:30", 5*time.Hour + 30*time.Minute, false},
+		{"4am", 0, true},
+		{"24:00", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDay(t *testing.T) {
+	start := 4 * time.Hour
+	local := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 6, day, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name  string
+		t     time.Time
+		start time.Duration
+		want  int
+	}{
+		{"late night counts toward previous day", local(11, 1, 30), start, 10},
+		{"just before day start", local(11, 3, 59), start, 10},
+		{"at day start", local(11, 4, 0), start, 11},
+		{"evening", local(11, 23, 0), start, 11},
+		{"midnight start", local(11, 1, 30), 0, 11},
+	}
+
+	for _, tt := range tests {
+		got := Day(tt.t, tt.start)
+		if got.Day() != tt.want || got.Hour() != 0 || got.Location() != time.Local {
+			t.Errorf("%s: Day() = %v, want June %d local midnight", tt.name, got, tt.want)
+		}
+	}
+
+	if !SameDay(local(11, 2, 0), local(10, 0, 0), start) {
+		t.Error("Expected 02:00 to count toward the previous day")
+	}
+	if SameDay(local(11, 2, 0), local(11, 0, 0), start) {
+		t.Error("Expected 02:00 not to count toward its calendar day")
+	}
+}
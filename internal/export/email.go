@@ -0,0 +1,216 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpsPort is the port for SMTP over implicit TLS; other ports use
+// STARTTLS when the server offers it
+const smtpsPort = 465
+
+// Email sends documents as email with plaintext (markdown) and HTML
+// alternatives, over SMTP or through a sendmail command.
+type Email struct {
+	From string
+	To   []string
+
+	// SMTP server settings; used when Host is set
+	Host     string
+	Port     int // default 587
+	Username string
+	Password string
+
+	// Sendmail is a command that reads the message on stdin, such as
+	// "sendmail -t -i"; used when Host isn't set
+	Sendmail string
+}
+
+// validate checks that the email settings can send mail
+func (e Email) validate() error {
+	if len(e.To) == 0 {
+		return fmt.Errorf("no email recipients. Set export.email.to in your config file")
+	}
+	if e.From == "" {
+		return fmt.Errorf("no email sender. Set export.email.from in your config file")
+	}
+	if e.Host == "" && e.Sendmail == "" {
+		return fmt.Errorf("email not configured. Set export.email.smtp.host or export.email.sendmail in your config file")
+	}
+	for _, address := range append([]string{e.From}, e.To...) {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid email address %q: %w", address, err)
+		}
+	}
+	return nil
+}
+
+// Export emails doc to the recipients, with its title as the subject
+func (e Email) Export(ctx context.Context, doc *Document) (string, error) {
+	if err := e.validate(); err != nil {
+		return "", err
+	}
+	message, err := e.Message(doc, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	if e.Host != "" {
+		err = e.sendSMTP(message)
+	} else {
+		err = e.sendmail(ctx, message)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(e.To, ", "), nil
+}
+
+// Message returns doc as a MIME message with plaintext and HTML parts
+func (e Email) Message(doc *Document, now time.Time) ([]byte, error) {
+	subject := doc.Title
+	if subject == "" {
+		subject = doc.Kind
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", doc.Markdown},
+		{"text/html; charset=utf-8", HTMLPage(doc)},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n", parts.Boundary())
+	fmt.Fprintf(&msg, "X-Todu-Kind: %s\r\n", doc.Kind)
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// sendSMTP sends message through the SMTP server, logging in if a username
+// is set
+func (e Email) sendSMTP(message []byte) error {
+	port := e.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+	from, to, err := e.envelope()
+	if err != nil {
+		return err
+	}
+
+	if port != smtpsPort {
+		if err := smtp.SendMail(addr, auth, from, to, message); err != nil {
+			return fmt.Errorf("failed to send email through %s: %w", addr, err)
+		}
+		return nil
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: e.Host})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	client, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to log in to %s: %w", addr, err)
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("failed to send email to %s: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	return client.Quit()
+}
+
+// envelope returns the bare sender and recipient addresses
+func (e Email) envelope() (string, []string, error) {
+	from, err := mail.ParseAddress(e.From)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid email address %q: %w", e.From, err)
+	}
+	to := make([]string, len(e.To))
+	for i, address := range e.To {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid email address %q: %w", address, err)
+		}
+		to[i] = parsed.Address
+	}
+	return from.Address, to, nil
+}
+
+// sendmail pipes message to the sendmail command
+func (e Email) sendmail(ctx context.Context, message []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", e.Sendmail)
+	cmd.Stdin = bytes.NewReader(message)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sendmail failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
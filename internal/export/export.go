@@ -0,0 +1,150 @@
+// Package export sends rendered reports, such as journal exports and
+// reviews, to a target: a markdown file, an Obsidian vault, an HTML file,
+// stdout, a webhook, or email. Commands pick the target with --to, so a new
+// target is a new adapter rather than a new command.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Document kinds
+const (
+	KindJournal      = "journal"
+	KindDailyReview  = "daily-review"
+	KindWeeklyReview = "weekly-review"
+	KindView         = "view"
+)
+
+// Targets lists the export targets, in the order they're shown in help
+var Targets = []string{"file", "obsidian", "html", "stdout", "webhook", "email"}
+
+// Document is a rendered report to export.
+type Document struct {
+	Kind     string // one of the Kind constants
+	Title    string
+	Date     time.Time
+	Markdown string
+
+	// Path is where file targets write the document. The HTML target
+	// replaces its extension with .html, and the Obsidian target uses only
+	// its file name.
+	Path string
+}
+
+// Adapter exports documents to one target.
+type Adapter interface {
+	// Export sends doc to the target and returns where it went, such as a
+	// file path or URL, or "" for stdout.
+	Export(ctx context.Context, doc *Document) (string, error)
+}
+
+// Options configures the adapters.
+type Options struct {
+	ObsidianVault  string
+	ObsidianFolder string // folder in the vault, default "todu"
+	WebhookURL     string
+	WebhookHeaders map[string]string
+	Email          Email
+	Stdout         io.Writer // default os.Stdout
+}
+
+// New returns the adapter for target.
+func New(target string, opts Options) (Adapter, error) {
+	switch target {
+	case "file":
+		return File{}, nil
+	case "obsidian":
+		if opts.ObsidianVault == "" {
+			return nil, fmt.Errorf("obsidian vault not configured. Set export.obsidian.vault in your config file")
+		}
+		return Obsidian{Vault: opts.ObsidianVault, Folder: opts.ObsidianFolder}, nil
+	case "html":
+		return HTML{}, nil
+	case "stdout":
+		out := opts.Stdout
+		if out == nil {
+			out = os.Stdout
+		}
+		return Stdout{Out: out}, nil
+	case "webhook":
+		if opts.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook URL not configured. Use --url or set export.webhook.url in your config file")
+		}
+		if u, err := url.Parse(opts.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid webhook URL %q: must be an http or https URL", opts.WebhookURL)
+		}
+		return Webhook{URL: opts.WebhookURL, Headers: opts.WebhookHeaders}, nil
+	case "email":
+		if err := opts.Email.validate(); err != nil {
+			return nil, err
+		}
+		return opts.Email, nil
+	default:
+		return nil, fmt.Errorf("unknown export target %q (valid: %s)", target, strings.Join(Targets, ", "))
+	}
+}
+
+// NeedsPath reports whether target writes to the document's Path
+func NeedsPath(target string) bool {
+	return target == "file" || target == "html" || target == "obsidian"
+}
+
+// File writes documents as markdown to their Path.
+type File struct{}
+
+// Export writes doc.Markdown to doc.Path
+func (File) Export(ctx context.Context, doc *Document) (string, error) {
+	if doc.Path == "" {
+		return "", fmt.Errorf("no file path to export %s to", doc.Kind)
+	}
+	path := ExpandPath(doc.Path)
+	if err := WriteFile(path, []byte(doc.Markdown), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Stdout prints documents.
+type Stdout struct {
+	Out io.Writer
+}
+
+// Export prints doc.Markdown
+func (s Stdout) Export(ctx context.Context, doc *Document) (string, error) {
+	if _, err := io.WriteString(s.Out, doc.Markdown); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", doc.Kind, err)
+	}
+	return "", nil
+}
+
+// WriteFile writes content to path, creating its directory if needed
+func WriteFile(path string, content []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ExpandPath expands ~ to the home directory
+func ExpandPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDocument(path string) *Document {
+	return &Document{
+		Kind:     KindDailyReview,
+		Title:    "Daily Review",
+		Date:     time.Date(2025, 6, 10, 0, 0, 0, 0, time.Local),
+		Markdown: "# Daily Review\n\n- [ ] #1 Task\n",
+		Path:     path,
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, target := range []string{"file", "html", "stdout"} {
+		if _, err := New(target, Options{}); err != nil {
+			t.Errorf("New(%q) failed: %v", target, err)
+		}
+	}
+	for _, target := range []string{"obsidian", "webhook", "fax"} {
+		if _, err := New(target, Options{}); err == nil {
+			t.Errorf("New(%q) should fail without its settings", target)
+		}
+	}
+	for _, url := range []string{"example.com/hook", "ftp://example.com/hook", "https://"} {
+		if _, err := New("webhook", Options{WebhookURL: url}); err == nil {
+			t.Errorf("New(webhook) should reject URL %q", url)
+		}
+	}
+}
+
+func TestFileAndStdout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews", "daily-review.md")
+	doc := testDocument(path)
+
+	got, err := File{}.Export(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	content, err := os.ReadFile(got)
+	if err != nil || string(content) != doc.Markdown {
+		t.Errorf("Expected the markdown at %s, got %q, %v", got, content, err)
+	}
+
+	var out bytes.Buffer
+	if _, err := (Stdout{Out: &out}).Export(context.Background(), doc); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if out.String() != doc.Markdown {
+		t.Errorf("Expected the markdown on stdout, got %q", out.String())
+	}
+}
+
+func TestObsidian(t *testing.T) {
+	vault := t.TempDir()
+	doc := testDocument("/reports/daily-review.md")
+
+	path, err := Obsidian{Vault: vault}.Export(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if path != filepath.Join(vault, "todu", "daily-review.md") {
+		t.Errorf("Unexpected path %s", path)
+	}
+	content, _ := os.ReadFile(path)
+	want := "---\ndate: 2025-06-10\ntype: daily-review\ntags: [todu, daily-review]\n---\n\n# Daily Review"
+	if !strings.HasPrefix(string(content), want) {
+		t.Errorf("Expected frontmatter, got:\n%s", content)
+	}
+}
+
+func TestHTMLExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily-review.md")
+	got, err := HTML{}.Export(context.Background(), testDocument(path))
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if filepath.Ext(got) != ".html" {
+		t.Errorf("Expected an .html file, got %s", got)
+	}
+	content, _ := os.ReadFile(got)
+	if !strings.Contains(string(content), "<title>Daily Review</title>") || !strings.Contains(string(content), "<h1>Daily Review</h1>") {
+		t.Errorf("Unexpected page:\n%s", content)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var payload WebhookPayload
+	var contentType, kind, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		contentType, kind, auth = r.Header.Get("Content-Type"), r.Header.Get("X-Todu-Kind"), r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	doc := testDocument("")
+	webhook := Webhook{URL: server.URL, Headers: map[string]string{"authorization": "Bearer secret"}}
+	if _, err := webhook.Export(context.Background(), doc); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if payload.Markdown != doc.Markdown || payload.Kind != KindDailyReview || payload.Title != "Daily Review" || payload.Date != "2025-06-10" {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+	if contentType != "application/json" || kind != KindDailyReview || auth != "Bearer secret" {
+		t.Errorf("Unexpected headers: content type %q, kind %q, authorization %q", contentType, kind, auth)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if _, err := (Webhook{URL: failing.URL}).Export(context.Background(), doc); err == nil {
+		t.Error("Expected a failing webhook to return an error")
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	email := Email{From: "todu <todu@example.com>", To: []string{"me@example.com"}, Sendmail: "cat"}
+	doc := testDocument("")
+	doc.Title = "Daily Review: 06-10-2025 ✓"
+
+	msg, err := email.Message(doc, time.Date(2025, 6, 10, 7, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Message failed: %v", err)
+	}
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if subject != doc.Title || parsed.Header.Get("To") != "me@example.com" {
+		t.Errorf("Unexpected headers: %v", parsed.Header)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Expected multipart/alternative, got %q, %v", mediaType, err)
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	var bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart failed: %v", err)
+		}
+		content, _ := io.ReadAll(part) // multipart decodes quoted-printable
+		types = append(types, strings.SplitN(part.Header.Get("Content-Type"), ";", 2)[0])
+		bodies = append(bodies, string(content))
+	}
+	if len(types) != 2 || types[0] != "text/plain" || types[1] != "text/html" {
+		t.Fatalf("Expected plaintext and HTML parts, got %v", types)
+	}
+	if strings.ReplaceAll(bodies[0], "\r\n", "\n") != doc.Markdown || !strings.Contains(bodies[1], "<h1>Daily Review</h1>") {
+		t.Errorf("Unexpected bodies: %q", bodies)
+	}
+}
+
+func TestEmailSendmail(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "sent.eml")
+	email := Email{From: "todu@example.com", To: []string{"me@example.com"}, Sendmail: "cat > " + out}
+	if _, err := email.Export(context.Background(), testDocument("")); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	sent, err := os.ReadFile(out)
+	if err != nil || !bytes.Contains(sent, []byte("Subject: Daily Review")) {
+		t.Errorf("Expected the message piped to sendmail, got %q, %v", sent, err)
+	}
+
+	if _, err := (Email{From: "todu@example.com", Sendmail: "cat"}).Export(context.Background(), testDocument("")); err == nil {
+		t.Error("Expected an error without recipients")
+	}
+}
@@ -0,0 +1,263 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+)
+
+// HTML writes documents as standalone HTML pages next to their Path.
+type HTML struct{}
+
+// Export writes doc as HTML to doc.Path with its extension replaced by .html
+func (HTML) Export(ctx context.Context, doc *Document) (string, error) {
+	if doc.Path == "" {
+		return "", fmt.Errorf("no file path to export %s to", doc.Kind)
+	}
+	path := ExpandPath(doc.Path)
+	path = strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+	if err := WriteFile(path, []byte(HTMLPage(doc)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// HTMLPage returns doc as a standalone HTML page
+func HTMLPage(doc *Document) string {
+	title := doc.Title
+	if title == "" {
+		title = doc.Kind
+	}
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString("</head>\n<body>\n")
+	sb.WriteString(RenderHTML(doc.Markdown))
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// RenderHTML converts the markdown todu generates to HTML. It handles
+// headings, rules, nested and task lists, tables, block quotes,
+// paragraphs, and inline code, emphasis, and links; anything else is
+// kept as text.
+func RenderHTML(markdown string) string {
+	r := &htmlRenderer{}
+	for _, line := range strings.Split(markdown, "\n") {
+		r.line(line)
+	}
+	r.closeBlocks()
+	return r.sb.String()
+}
+
+// htmlRenderer renders markdown line by line, tracking open blocks
+type htmlRenderer struct {
+	sb        strings.Builder
+	lists     []int // indents of open lists, innermost last
+	paragraph []string
+	table     bool
+	quote     bool
+}
+
+// line renders one line of markdown
+func (r *htmlRenderer) line(line string) {
+	trimmed := strings.TrimSpace(line)
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+	switch {
+	case trimmed == "":
+		r.closeBlocks()
+	case strings.HasPrefix(trimmed, "|"):
+		r.tableRow(trimmed)
+	case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+		r.listItem(indent, trimmed[2:])
+	case trimmed == "---" || trimmed == "***":
+		r.closeBlocks()
+		r.sb.WriteString("<hr>\n")
+	case strings.HasPrefix(trimmed, "#"):
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level > 6 || !strings.HasPrefix(trimmed[level:], " ") {
+			r.text(trimmed)
+			return
+		}
+		r.closeBlocks()
+		r.sb.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, inlineHTML(strings.TrimSpace(trimmed[level:])), level))
+	case strings.HasPrefix(trimmed, ">"):
+		if !r.quote {
+			r.closeBlocks()
+			r.sb.WriteString("<blockquote>\n")
+			r.quote = true
+		}
+		r.paragraph = append(r.paragraph, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+	default:
+		r.text(trimmed)
+	}
+}
+
+// text adds a line to the current paragraph
+func (r *htmlRenderer) text(line string) {
+	if len(r.lists) > 0 || r.table {
+		r.closeBlocks()
+	}
+	r.paragraph = append(r.paragraph, line)
+}
+
+// listItem renders a list item, opening and closing nested lists by indent
+func (r *htmlRenderer) listItem(indent int, content string) {
+	if r.table || r.quote || len(r.paragraph) > 0 {
+		r.closeBlocks()
+	}
+	for len(r.lists) > 0 && indent < r.lists[len(r.lists)-1] {
+		r.sb.WriteString("</li>\n</ul>\n")
+		r.lists = r.lists[:len(r.lists)-1]
+	}
+	switch {
+	case len(r.lists) == 0 || indent > r.lists[len(r.lists)-1]:
+		if len(r.lists) > 0 {
+			r.sb.WriteString("\n")
+		}
+		r.sb.WriteString("<ul>\n")
+		r.lists = append(r.lists, indent)
+	default:
+		r.sb.WriteString("</li>\n")
+	}
+
+	r.sb.WriteString("<li>")
+	lower := strings.ToLower(content)
+	switch {
+	case strings.HasPrefix(lower, "[x] "):
+		r.sb.WriteString(`<input type="checkbox" checked disabled> `)
+		content = content[4:]
+	case strings.HasPrefix(content, "[ ] "):
+		r.sb.WriteString(`<input type="checkbox" disabled> `)
+		content = content[4:]
+	}
+	r.sb.WriteString(inlineHTML(content))
+}
+
+// tableRow renders a table row; the first row is the header, and the
+// separator row under it is skipped
+func (r *htmlRenderer) tableRow(line string) {
+	cells := strings.Split(strings.Trim(line, "|"), "|")
+	if isSeparatorRow(cells) {
+		return
+	}
+	cell := "td"
+	if !r.table {
+		r.closeBlocks()
+		r.sb.WriteString("<table>\n")
+		r.table = true
+		cell = "th"
+	}
+	r.sb.WriteString("<tr>")
+	for _, c := range cells {
+		r.sb.WriteString(fmt.Sprintf("<%s>%s</%s>", cell, inlineHTML(strings.TrimSpace(c)), cell))
+	}
+	r.sb.WriteString("</tr>\n")
+}
+
+// isSeparatorRow reports whether cells are a table's header separator
+func isSeparatorRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.Trim(strings.TrimSpace(c), ":-") != "" || strings.TrimSpace(c) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// closeBlocks closes any open paragraph, list, table, or quote
+func (r *htmlRenderer) closeBlocks() {
+	if len(r.paragraph) > 0 {
+		r.sb.WriteString("<p>" + inlineHTML(strings.Join(r.paragraph, "\n")) + "</p>\n")
+		r.paragraph = nil
+	}
+	if r.quote {
+		r.sb.WriteString("</blockquote>\n")
+		r.quote = false
+	}
+	for range r.lists {
+		r.sb.WriteString("</li>\n</ul>\n")
+	}
+	r.lists = nil
+	if r.table {
+		r.sb.WriteString("</table>\n")
+		r.table = false
+	}
+}
+
+// inlineHTML escapes text and renders inline code, **bold**, *emphasis*,
+// [links](url), and backslash escapes
+func inlineHTML(text string) string {
+	var sb strings.Builder
+	bold, em := false, false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!|<>", text[i+1]) >= 0:
+			i++
+			sb.WriteString(html.EscapeString(text[i : i+1]))
+		case c == '`':
+			end := strings.IndexByte(text[i+1:], '`')
+			if end < 0 {
+				sb.WriteString("`")
+				continue
+			}
+			sb.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+			i += end + 1
+		case c == '*' && i+1 < len(text) && text[i+1] == '*':
+			if bold {
+				sb.WriteString("</strong>")
+			} else {
+				sb.WriteString("<strong>")
+			}
+			bold = !bold
+			i++
+		case c == '*':
+			if em {
+				sb.WriteString("</em>")
+			} else {
+				sb.WriteString("<em>")
+			}
+			em = !em
+		case c == '[':
+			label, url, n, ok := parseLink(text[i:])
+			if !ok {
+				sb.WriteString("[")
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(label)))
+			i += n - 1
+		default:
+			sb.WriteString(html.EscapeString(text[i : i+1]))
+		}
+	}
+	if em {
+		sb.WriteString("</em>")
+	}
+	if bold {
+		sb.WriteString("</strong>")
+	}
+	return sb.String()
+}
+
+// parseLink parses a [label](url) link at the start of text, returning
+// its length
+func parseLink(text string) (label, url string, n int, ok bool) {
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 0 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(text[closeLabel+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	label = text[1:closeLabel]
+	url = text[closeLabel+2 : closeLabel+2+closeURL]
+	if strings.ContainsAny(label, "[]") || strings.ContainsAny(url, " \t") {
+		return "", "", 0, false
+	}
+	return label, url, closeLabel + 3 + closeURL, true
+}
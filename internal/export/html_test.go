@@ -0,0 +1,44 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	markdown := `# Weekly Review
+
+Some **bold** and *em* text with ` + "`code`" + ` and a [link](https://example.com).
+
+---
+
+## Tasks
+
+- [x] #1 Done
+- [ ] #2 Open
+  - nested
+- plain \*escaped\*
+
+| Habit | Mon |
+|-------|-----|
+| Run | ✓ |
+
+> quoted <b>
+`
+	got := RenderHTML(markdown)
+	for _, want := range []string{
+		"<h1>Weekly Review</h1>\n",
+		"<p>Some <strong>bold</strong> and <em>em</em> text with <code>code</code> and a <a href=\"https://example.com\">link</a>.</p>\n",
+		"<hr>\n",
+		"<h2>Tasks</h2>\n",
+		"<li><input type=\"checkbox\" checked disabled> #1 Done</li>\n",
+		"<li><input type=\"checkbox\" disabled> #2 Open\n<ul>\n<li>nested</li>\n</ul>\n</li>\n",
+		"<li>plain *escaped*</li>\n</ul>\n",
+		"<table>\n<tr><th>Habit</th><th>Mon</th></tr>\n<tr><td>Run</td><td>✓</td></tr>\n</table>\n",
+		"<blockquote>\n<p>quoted &lt;b&gt;</p>\n</blockquote>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// defaultObsidianFolder is the vault folder documents go in by default
+const defaultObsidianFolder = "todu"
+
+// Obsidian writes documents into an Obsidian vault, with frontmatter
+// giving their date and kind so they can be found with tags and queries.
+type Obsidian struct {
+	Vault  string
+	Folder string // default "todu"
+}
+
+// Export writes doc to Folder in the vault, named after doc.Path
+func (o Obsidian) Export(ctx context.Context, doc *Document) (string, error) {
+	if doc.Path == "" {
+		return "", fmt.Errorf("no file name to export %s to", doc.Kind)
+	}
+	folder := o.Folder
+	if folder == "" {
+		folder = defaultObsidianFolder
+	}
+	path := filepath.Join(ExpandPath(o.Vault), folder, filepath.Base(doc.Path))
+	if err := WriteFile(path, []byte(obsidianNote(doc)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// obsidianNote returns doc's markdown with Obsidian frontmatter
+func obsidianNote(doc *Document) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	if !doc.Date.IsZero() {
+		sb.WriteString(fmt.Sprintf("date: %s\n", doc.Date.Format("2006-01-02")))
+	}
+	sb.WriteString(fmt.Sprintf("type: %s\n", doc.Kind))
+	sb.WriteString(fmt.Sprintf("tags: [todu, %s]\n", doc.Kind))
+	sb.WriteString("---\n\n")
+	sb.WriteString(doc.Markdown)
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// webhookTimeout is how long a webhook has to respond
+const webhookTimeout = 30 * time.Second
+
+// WebhookPayload is the JSON body documents are posted to webhooks with.
+type WebhookPayload struct {
+	Kind       string    `json:"kind"`
+	Title      string    `json:"title"`
+	Date       string    `json:"date,omitempty"` // YYYY-MM-DD
+	Markdown   string    `json:"markdown"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// Webhook posts documents to a URL.
+type Webhook struct {
+	URL string
+
+	// Headers are added to each request, such as an Authorization header
+	// for the endpoint
+	Headers map[string]string
+}
+
+// Export posts doc to the URL as a JSON WebhookPayload, with its kind and
+// date also in X-Todu-Kind and X-Todu-Date headers
+func (w Webhook) Export(ctx context.Context, doc *Document) (string, error) {
+	payload := WebhookPayload{
+		Kind:       doc.Kind,
+		Title:      doc.Title,
+		Markdown:   doc.Markdown,
+		ExportedAt: time.Now().UTC(),
+	}
+	if !doc.Date.IsZero() {
+		payload.Date = doc.Date.Format("2006-01-02")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for name, value := range w.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Todu-Kind", doc.Kind)
+	if payload.Date != "" {
+		req.Header.Set("X-Todu-Date", payload.Date)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to post %s to webhook: %w", doc.Kind, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return w.URL, nil
+}
@@ -0,0 +1,134 @@
+package holidays
+
+import (
+	"sort"
+	"time"
+)
+
+// holiday is a public holiday in a given year.
+type holiday struct {
+	date time.Time
+	name string
+}
+
+// countries maps country codes to the public holidays of a year.
+var countries = map[string]func(year int) []holiday{
+	"US": usHolidays,
+	"GB": gbHolidays,
+}
+
+// Countries returns the supported country codes.
+func Countries() []string {
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// usHolidays returns the US federal holidays. Holidays on a Saturday are
+// observed the Friday before, and on a Sunday the Monday after.
+func usHolidays(year int) []holiday {
+	observed := func(date time.Time) time.Time {
+		switch date.Weekday() {
+		case time.Saturday:
+			return date.AddDate(0, 0, -1)
+		case time.Sunday:
+			return date.AddDate(0, 0, 1)
+		}
+		return date
+	}
+
+	return []holiday{
+		{observed(day(year, time.January, 1)), "New Year's Day"},
+		{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+		{nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday"},
+		{lastWeekday(year, time.May, time.Monday), "Memorial Day"},
+		{observed(day(year, time.June, 19)), "Juneteenth"},
+		{observed(day(year, time.July, 4)), "Independence Day"},
+		{nthWeekday(year, time.September, time.Monday, 1), "Labor Day"},
+		{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day"},
+		{observed(day(year, time.November, 11)), "Veterans Day"},
+		{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day"},
+		{observed(day(year, time.December, 25)), "Christmas Day"},
+	}
+}
+
+// gbHolidays returns the bank holidays of England and Wales. Holidays on a
+// weekend are moved to the next weekday not already a holiday.
+func gbHolidays(year int) []holiday {
+	substitute := func(date time.Time, taken ...time.Time) time.Time {
+		for {
+			weekend := date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+			clash := false
+			for _, t := range taken {
+				if t.Equal(date) {
+					clash = true
+				}
+			}
+			if !weekend && !clash {
+				return date
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+	}
+
+	easter := easterSunday(year)
+	// Boxing Day keeps its date when it's a weekday, so a Sunday Christmas
+	// moves past it
+	boxingDay := day(year, time.December, 26)
+	var christmas time.Time
+	if boxingDay.Weekday() == time.Saturday || boxingDay.Weekday() == time.Sunday {
+		christmas = substitute(day(year, time.December, 25))
+	} else {
+		christmas = substitute(day(year, time.December, 25), boxingDay)
+	}
+	boxing := substitute(boxingDay, christmas)
+
+	return []holiday{
+		{substitute(day(year, time.January, 1)), "New Year's Day"},
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{nthWeekday(year, time.May, time.Monday, 1), "Early May bank holiday"},
+		{lastWeekday(year, time.May, time.Monday), "Spring bank holiday"},
+		{lastWeekday(year, time.August, time.Monday), "Summer bank holiday"},
+		{christmas, "Christmas Day"},
+		{boxing, "Boxing Day"},
+	}
+}
+
+// day returns a date as a UTC midnight.
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the nth weekday of a month, such as the third Monday.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := day(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last weekday of a month, such as the last Monday.
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := day(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easterSunday returns Easter Sunday of a year (anonymous Gregorian algorithm).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	dayOfMonth := (h+l-7*m+114)%31 + 1
+	return day(year, time.Month(month), dayOfMonth)
+}
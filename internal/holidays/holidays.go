@@ -0,0 +1,163 @@
+// Package holidays provides the holiday calendar used to move recurring
+// tasks and bumped dates off weekends and holidays.
+package holidays
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Calendar reports which dates are holidays. Dates are compared by their
+// UTC calendar date, matching how date-only fields are stored.
+type Calendar struct {
+	country string
+	dates   map[string]string // YYYY-MM-DD to holiday name
+}
+
+// Load builds a calendar from a country code (see Countries) and an ICS
+// file, either of which may be empty. Holidays from both are combined.
+func Load(country, icsPath string) (*Calendar, error) {
+	cal := &Calendar{dates: make(map[string]string)}
+
+	if country != "" {
+		code := strings.ToUpper(strings.TrimSpace(country))
+		if _, ok := countries[code]; !ok {
+			return nil, fmt.Errorf("unsupported holiday country %q (supported: %s; use an ICS file for others)", country, strings.Join(Countries(), ", "))
+		}
+		cal.country = code
+	}
+
+	if icsPath != "" {
+		if err := cal.loadICS(icsPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return cal, nil
+}
+
+// Empty reports whether the calendar has no holiday source.
+func (c *Calendar) Empty() bool {
+	return c == nil || (c.country == "" && len(c.dates) == 0)
+}
+
+// Holiday returns the name of the holiday on date, and false if it isn't one.
+func (c *Calendar) Holiday(date time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	date = dateOnly(date)
+	if name, ok := c.dates[date.Format("2006-01-02")]; ok {
+		return name, true
+	}
+	if c.country != "" {
+		for _, h := range countries[c.country](date.Year()) {
+			if h.date.Equal(date) {
+				return h.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Skip says which days tasks should not land on.
+type Skip struct {
+	Weekends bool
+	Holidays bool
+}
+
+// Any reports whether any days are skipped.
+func (s Skip) Any() bool {
+	return s.Weekends || s.Holidays
+}
+
+// Next returns date if it is a day skip allows, otherwise the first
+// following day that is. The result is a UTC midnight.
+func (c *Calendar) Next(date time.Time, skip Skip) time.Time {
+	date = dateOnly(date)
+	// A year of consecutive skipped days means a broken calendar
+	for i := 0; i < 366; i++ {
+		if !c.skipped(date, skip) {
+			return date
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// skipped reports whether skip rules out date.
+func (c *Calendar) skipped(date time.Time, skip Skip) bool {
+	if skip.Weekends && (date.Weekday() == time.Saturday || date.Weekday() == time.Sunday) {
+		return true
+	}
+	if skip.Holidays {
+		if _, ok := c.Holiday(date); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// loadICS adds the all-day events of an ICS file. Only DTSTART and
+// SUMMARY are read; recurring events are not expanded.
+func (c *Calendar) loadICS(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open holiday calendar: %w", err)
+	}
+	defer f.Close()
+
+	// Unfold continuation lines (RFC 5545 section 3.1)
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read holiday calendar: %w", err)
+	}
+
+	var start, summary string
+	inEvent := false
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as ;VALUE=DATE
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, summary = true, "", ""
+		case name == "END" && value == "VEVENT":
+			if inEvent && len(start) >= 8 {
+				date, err := time.Parse("20060102", start[:8])
+				if err != nil {
+					return fmt.Errorf("invalid DTSTART %q in %s", start, path)
+				}
+				c.dates[date.Format("2006-01-02")] = summary
+			}
+			inEvent = false
+		case inEvent && name == "DTSTART":
+			start = value
+		case inEvent && name == "SUMMARY":
+			summary = strings.ReplaceAll(value, `\,`, ",")
+		}
+	}
+	return nil
+}
+
+// dateOnly truncates t to a UTC midnight, keeping its UTC calendar date.
+func dateOnly(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,127 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCountryHolidays(t *testing.T) {
+	us, err := Load("us", "")
+	if err != nil {
+		t.Fatalf("Load(us) failed: %v", err)
+	}
+	gb, err := Load("GB", "")
+	if err != nil {
+		t.Fatalf("Load(GB) failed: %v", err)
+	}
+
+	tests := []struct {
+		cal  *Calendar
+		date string
+		want string
+	}{
+		{us, "2024-01-15", "Martin Luther King Jr. Day"},
+		{us, "2024-05-27", "Memorial Day"},
+		{us, "2024-11-28", "Thanksgiving Day"},
+		{us, "2021-07-05", "Independence Day"}, // July 4 was a Sunday
+		{us, "2021-12-24", "Christmas Day"},    // Dec 25 was a Saturday
+		{gb, "2024-03-29", "Good Friday"},
+		{gb, "2024-04-01", "Easter Monday"},
+		{gb, "2024-08-26", "Summer bank holiday"},
+		{gb, "2021-12-27", "Christmas Day"}, // Dec 25 was a Saturday
+		{gb, "2021-12-28", "Boxing Day"},
+		{gb, "2022-12-27", "Christmas Day"}, // Dec 25 was a Sunday, Boxing Day a Monday
+	}
+
+	for _, tt := range tests {
+		name, ok := tt.cal.Holiday(mustDate(tt.date))
+		if !ok || name != tt.want {
+			t.Errorf("Holiday(%s) = %q, %v, want %q", tt.date, name, ok, tt.want)
+		}
+	}
+
+	if name, ok := us.Holiday(mustDate("2024-07-05")); ok {
+		t.Errorf("Expected 2024-07-05 not to be a US holiday, got %q", name)
+	}
+}
+
+func TestLoadUnsupportedCountry(t *testing.T) {
+	if _, err := Load("XX", ""); err == nil {
+		t.Error("Expected error for unsupported country")
+	}
+}
+
+func TestLoadICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240812\r\n" +
+		"SUMMARY:Company\r\n" +
+		"  offsite\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20241231T000000Z\r\n" +
+		"SUMMARY:Office closed\\, year end\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(ics), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cal, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if name, ok := cal.Holiday(mustDate("2024-08-12")); !ok || name != "Company offsite" {
+		t.Errorf("Expected Company offsite, got %q, %v", name, ok)
+	}
+	if name, ok := cal.Holiday(mustDate("2024-12-31")); !ok || name != "Office closed, year end" {
+		t.Errorf("Expected Office closed, year end, got %q, %v", name, ok)
+	}
+
+	if _, err := Load("", filepath.Join(t.TempDir(), "missing.ics")); err == nil {
+		t.Error("Expected error for missing ICS file")
+	}
+}
+
+func TestNext(t *testing.T) {
+	cal, err := Load("US", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date string
+		skip Skip
+		want string
+	}{
+		{"2024-07-06", Skip{Weekends: true}, "2024-07-08"},                 // Saturday
+		{"2024-07-04", Skip{Holidays: true}, "2024-07-05"},                 // Independence Day
+		{"2024-07-04", Skip{Weekends: true}, "2024-07-04"},                 // Thursday
+		{"2024-08-31", Skip{Weekends: true, Holidays: true}, "2024-09-03"}, // Saturday before Labor Day
+		{"2024-08-31", Skip{}, "2024-08-31"},
+	}
+
+	for _, tt := range tests {
+		got := cal.Next(mustDate(tt.date), tt.skip).Format("2006-01-02")
+		if got != tt.want {
+			t.Errorf("Next(%s, %+v) = %s, want %s", tt.date, tt.skip, got, tt.want)
+		}
+	}
+
+	// Weekends can be skipped without a calendar
+	var none *Calendar
+	if got := none.Next(mustDate("2024-07-06"), Skip{Weekends: true}).Format("2006-01-02"); got != "2024-07-08" {
+		t.Errorf("Next without calendar = %s, want 2024-07-08", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Package hooks runs user-configured scripts before and after todu commands.
+//
+// A hook is a shell command configured under "hooks" for an event name. It
+// receives a JSON payload on stdin describing the event. A pre- hook that
+// exits non-zero aborts the command; post- hook failures are reported but
+// do not undo what the command already did.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hook events
+const (
+	PreTaskCreate  = "pre-task-create"
+	PostTaskCreate = "post-task-create"
+	PreTaskUpdate  = "pre-task-update"
+	PostTaskUpdate = "post-task-update"
+	PreTaskClose   = "pre-task-close"
+	PostTaskClose  = "post-task-close"
+	PreTaskDelete  = "pre-task-delete"
+	PostTaskDelete = "post-task-delete"
+	PreSync        = "pre-sync"
+	PostSync       = "post-sync"
+)
+
+// Events lists every hook event, in the order they are documented
+var Events = []string{
+	PreTaskCreate, PostTaskCreate,
+	PreTaskUpdate, PostTaskUpdate,
+	PreTaskClose, PostTaskClose,
+	PreTaskDelete, PostTaskDelete,
+	PreSync, PostSync,
+}
+
+// DefaultTimeout is how long a hook may run before it is killed
+const DefaultTimeout = 30 * time.Second
+
+// Payload is the JSON document written to a hook's stdin
+type Payload struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// Runner runs the hooks configured for each event
+type Runner struct {
+	hooks   map[string]string
+	timeout time.Duration
+	output  io.Writer
+}
+
+// NewRunner creates a Runner for the configured hooks, keyed by event name.
+// Hook output goes to stderr so it never mixes with command output.
+func NewRunner(hooks map[string]string) *Runner {
+	return &Runner{
+		hooks:   hooks,
+		timeout: DefaultTimeout,
+		output:  os.Stderr,
+	}
+}
+
+// WithTimeout sets how long a hook may run
+func (r *Runner) WithTimeout(timeout time.Duration) *Runner {
+	r.timeout = timeout
+	return r
+}
+
+// WithOutput sets where hook stdout and stderr are written
+func (r *Runner) WithOutput(w io.Writer) *Runner {
+	r.output = w
+	return r
+}
+
+// Has reports whether a hook is configured for event
+func (r *Runner) Has(event string) bool {
+	if r == nil {
+		return false
+	}
+	return strings.TrimSpace(r.hooks[event]) != ""
+}
+
+// Run runs the hook for event with data as the payload. Returns nil if no
+// hook is configured. The hook runs via "sh -c" with TODU_HOOK_EVENT set.
+func (r *Runner) Run(ctx context.Context, event string, data any) error {
+	if !r.Has(event) {
+		return nil
+	}
+
+	input, err := json.Marshal(Payload{Event: event, Timestamp: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s hook payload: %w", event, err)
+	}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", r.hooks[event])
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = r.output
+	cmd.Stderr = r.output
+	cmd.Env = append(os.Environ(), "TODU_HOOK_EVENT="+event)
+	// Don't wait on output from processes the hook left running after it was killed
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s hook timed out after %s", event, r.timeout)
+		}
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+	return nil
+}
+
+// Validate checks that every configured hook names a known event
+func Validate(hooks map[string]string) error {
+	known := make(map[string]struct{}, len(Events))
+	for _, e := range Events {
+		known[e] = struct{}{}
+	}
+
+	var unknown []string
+	for event := range hooks {
+		if _, ok := known[event]; !ok {
+			unknown = append(unknown, event)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown hook event(s): %s (valid: %s)", strings.Join(unknown, ", "), strings.Join(Events, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunWritesPayload(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "payload.json")
+
+	runner := NewRunner(map[string]string{
+		PostTaskCreate: "cat > " + out + "; echo $TODU_HOOK_EVENT",
+	})
+	var output bytes.Buffer
+	runner.WithOutput(&output)
+
+	data := map[string]any{"task": map[string]any{"id": 42}}
+	if err := runner.Run(context.Background(), PostTaskCreate, data); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if got := strings.TrimSpace(output.String()); got != PostTaskCreate {
+		t.Errorf("hook output = %q, want %q", got, PostTaskCreate)
+	}
+
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read payload: %v", err)
+	}
+	var payload struct {
+		Event string `json:"event"`
+		Data  struct {
+			Task struct {
+				ID int `json:"id"`
+			} `json:"task"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Event != PostTaskCreate || payload.Data.Task.ID != 42 {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestRunNoHook(t *testing.T) {
+	runner := NewRunner(nil)
+	if runner.Has(PreSync) {
+		t.Error("Has() = true with no hooks")
+	}
+	if err := runner.Run(context.Background(), PreSync, nil); err != nil {
+		t.Errorf("Run() error = %v, want nil", err)
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	runner := NewRunner(map[string]string{PreTaskClose: "echo nope >&2; exit 3"})
+	var output bytes.Buffer
+	runner.WithOutput(&output)
+
+	err := runner.Run(context.Background(), PreTaskClose, nil)
+	if err == nil || !strings.Contains(err.Error(), PreTaskClose) {
+		t.Fatalf("Run() error = %v, want %s hook failure", err, PreTaskClose)
+	}
+	if !strings.Contains(output.String(), "nope") {
+		t.Errorf("hook stderr not captured: %q", output.String())
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	runner := NewRunner(map[string]string{PostSync: "exec sleep 5"}).WithTimeout(50 * time.Millisecond).WithOutput(&bytes.Buffer{})
+	err := runner.Run(context.Background(), PostSync, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Run() error = %v, want timeout", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(map[string]string{PostTaskCreate: "true", PreSync: "true"}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	err := Validate(map[string]string{"post-task-creat": "true"})
+	if err == nil || !strings.Contains(err.Error(), "post-task-creat") {
+		t.Errorf("Validate() error = %v, want unknown event", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Package httpcache provides an HTTP transport that makes conditional GET
+// requests.
+//
+// Responses carrying an ETag or Last-Modified header are kept in memory. Later
+// GET requests for the same URL send If-None-Match or If-Modified-Since, and a
+// 304 Not Modified reply is answered from memory as if the server had sent the
+// full response again. GitHub doesn't count 304 replies against the rate
+// limit, and Forgejo skips rendering the body, so repeated syncs in one
+// process (such as the daemon) fetch unchanged resources cheaply.
+package httpcache
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// DefaultMaxEntries is the number of responses a Transport keeps by default.
+const DefaultMaxEntries = 1000
+
+// entry is a cached response.
+type entry struct {
+	etag         string
+	lastModified string
+	response     []byte
+}
+
+// Transport is an http.RoundTripper that makes GET requests conditional.
+// It is safe for concurrent use.
+type Transport struct {
+	// Base is the underlying transport. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// MaxEntries limits the number of cached responses. Once full, the
+	// oldest entry is evicted. Zero means DefaultMaxEntries.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	order   []string
+}
+
+// NewTransport returns a Transport wrapping base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip performs the request, adding validators from a cached response
+// and serving the cached response when the server replies 304 Not Modified.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base().RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached := t.get(key)
+
+	// Leave requests that already carry validators to the caller
+	if cached != nil && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	} else {
+		cached = nil
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return readResponse(cached.response, req)
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			dump, err := httputil.DumpResponse(resp, true)
+			if err != nil {
+				return nil, err
+			}
+			t.put(key, &entry{etag: etag, lastModified: lastModified, response: dump})
+			return readResponse(dump, req)
+		}
+	}
+
+	return resp, nil
+}
+
+// Len returns the number of cached responses.
+func (t *Transport) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
+
+// base returns the underlying transport.
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// get returns the cached entry for a URL, or nil.
+func (t *Transport) get(key string) *entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.entries[key]
+}
+
+// put caches an entry for a URL, evicting the oldest entry when full.
+func (t *Transport) put(key string, e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries == nil {
+		t.entries = make(map[string]*entry)
+	}
+	if _, exists := t.entries[key]; !exists {
+		t.order = append(t.order, key)
+	}
+	t.entries[key] = e
+
+	limit := t.MaxEntries
+	if limit <= 0 {
+		limit = DefaultMaxEntries
+	}
+	for len(t.entries) > limit {
+		oldest := t.order[0]
+		t.order = t.order[1:]
+		delete(t.entries, oldest)
+	}
+}
+
+// readResponse parses a dumped response as the reply to req.
+func readResponse(dump []byte, req *http.Request) (*http.Response, error) {
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
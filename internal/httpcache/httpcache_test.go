@@ -0,0 +1,107 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransportConditionalGet(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Page", "1")
+		io.WriteString(w, "hello")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/items")
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+			t.Errorf("request %d: got %d %q, want 200 \"hello\"", i, resp.StatusCode, body)
+		}
+		if resp.Header.Get("X-Page") != "1" {
+			t.Errorf("request %d: expected cached headers, got %v", i, resp.Header)
+		}
+	}
+
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected 2 requests with 1 revalidated, got %d and %d", requests, notModified)
+	}
+}
+
+func TestTransportSkipsNonGetAndUncacheable(t *testing.T) {
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			conditional++
+		}
+		if r.URL.Path == "/etag" {
+			w.Header().Set("ETag", `"v1"`)
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	transport := NewTransport(nil)
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/etag", "text/plain", nil)
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+
+		resp, err = client.Get(server.URL + "/plain")
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if conditional != 0 || transport.Len() != 0 {
+		t.Errorf("Expected no conditional requests or cached entries, got %d and %d", conditional, transport.Len())
+	}
+}
+
+func TestTransportEvictsOldest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		io.WriteString(w, r.URL.Path)
+	}))
+	defer server.Close()
+
+	transport := &Transport{MaxEntries: 2}
+	client := &http.Client{Transport: transport}
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	if transport.Len() != 2 {
+		t.Fatalf("Expected 2 cached entries, got %d", transport.Len())
+	}
+	if transport.get(server.URL+"/a") != nil {
+		t.Error("Expected oldest entry to be evicted")
+	}
+}
@@ -0,0 +1,125 @@
+// Package identity looks up the user an API key belongs to and caches it,
+// so commands can default comment authors and "mine" filters to that user
+// without asking the server every time.
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+)
+
+// MaxAge is how long a cached identity is used before it is fetched again
+const MaxAge = 24 * time.Hour
+
+// User is the cached identity of an API key. Name is empty if the server
+// doesn't report identities.
+type User struct {
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Cache holds identities by Key.
+type Cache struct {
+	Users map[string]User `json:"users"`
+}
+
+// Key identifies an API key on a server in the cache without storing the
+// key itself, so switching keys or servers looks the identity up again.
+func Key(apiURL, apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return apiURL + "#" + hex.EncodeToString(sum[:6])
+}
+
+// Lookup returns the cached identity for key if it was fetched less than
+// MaxAge before now.
+func (c *Cache) Lookup(key string, now time.Time) (User, bool) {
+	user, ok := c.Users[key]
+	if !ok || now.Sub(user.FetchedAt) > MaxAge {
+		return User{}, false
+	}
+	return user, true
+}
+
+// DefaultCachePath returns the default cache file
+// (~/.config/todu/identity.json).
+func DefaultCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "identity.json"), nil
+}
+
+// LoadCache reads the identity cache from path. A missing file is an empty
+// cache.
+func LoadCache(path string) (*Cache, error) {
+	cache := &Cache{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read identity cache: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cache); err != nil {
+			return nil, fmt.Errorf("failed to parse identity cache %s: %w", path, err)
+		}
+	}
+	if cache.Users == nil {
+		cache.Users = make(map[string]User)
+	}
+	return cache, nil
+}
+
+// Save writes the identity cache to path.
+func (c *Cache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode identity cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write identity cache: %w", err)
+	}
+	return nil
+}
+
+// Resolve returns the identity of the API key client authenticates with,
+// from the cache at path unless it is stale or refresh is set. Fetched
+// identities are saved to the cache. If the server can't be reached, a
+// stale cached identity is returned rather than an error.
+func Resolve(ctx context.Context, client *api.Client, key, path string, refresh bool) (User, error) {
+	cache, err := LoadCache(path)
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now()
+	if user, ok := cache.Lookup(key, now); ok && !refresh {
+		return user, nil
+	}
+
+	identity, err := client.GetIdentity(ctx)
+	if err != nil {
+		if user, ok := cache.Users[key]; ok && !refresh {
+			return user, nil
+		}
+		return User{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	user := User{Name: identity.Name, Email: identity.Email, FetchedAt: now}
+	cache.Users[key] = user
+	if err := cache.Save(path); err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
@@ -0,0 +1,84 @@
+package identity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+)
+
+func TestKey(t *testing.T) {
+	if Key("http://a", "sk_1") == Key("http://a", "sk_2") {
+		t.Error("Expected different keys to get different cache keys")
+	}
+	if Key("http://a", "sk_1") == Key("http://b", "sk_1") {
+		t.Error("Expected different servers to get different cache keys")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	now := time.Now()
+	cache := &Cache{Users: map[string]User{
+		"fresh": {Name: "jane", FetchedAt: now.Add(-time.Hour)},
+		"stale": {Name: "old", FetchedAt: now.Add(-MaxAge - time.Hour)},
+	}}
+	if user, ok := cache.Lookup("fresh", now); !ok || user.Name != "jane" {
+		t.Errorf("Lookup(fresh) = %+v, %v", user, ok)
+	}
+	if _, ok := cache.Lookup("stale", now); ok {
+		t.Error("Expected a stale identity to be looked up again")
+	}
+	if _, ok := cache.Lookup("missing", now); ok {
+		t.Error("Expected a missing identity not to be found")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var requests int
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			http.Error(w, "down", http.StatusInternalServerError)
+			return
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "jane"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "sk_test")
+	path := filepath.Join(t.TempDir(), "identity.json")
+	key := Key(server.URL, "sk_test")
+
+	for i := 0; i < 2; i++ {
+		user, err := Resolve(context.Background(), client, key, path, false)
+		if err != nil || user.Name != "jane" {
+			t.Fatalf("Resolve() = %+v, %v", user, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the identity to be fetched once and cached, got %d requests", requests)
+	}
+
+	if _, err := Resolve(context.Background(), client, key, path, true); err != nil || requests != 2 {
+		t.Errorf("Expected refresh to fetch again, got %d requests, %v", requests, err)
+	}
+
+	// A stale identity is used when the server is down
+	cache, _ := LoadCache(path)
+	user := cache.Users[key]
+	user.FetchedAt = time.Now().Add(-2 * MaxAge)
+	cache.Users[key] = user
+	if err := cache.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	up = false
+	if user, err := Resolve(context.Background(), client, key, path, false); err != nil || user.Name != "jane" {
+		t.Errorf("Expected the stale identity when the server is down, got %+v, %v", user, err)
+	}
+}
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// attachmentsDir is the directory, next to a day's export, that holds
+// files attached to that day's journal entries.
+const attachmentsDir = "attachments"
+
+// imageExtensions are attachment types linked as markdown images.
+var imageExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".gif":  true,
+	".webp": true,
+	".svg":  true,
+}
+
+// Attach copies a file into the attachments directory next to the export
+// for the day the entry was created, named "<entry-id>-<file name>", and
+// returns the markdown link to insert into the entry. The link is relative
+// to the export file so it keeps working when the export tree is moved.
+func Attach(localReportsPath string, entryID int, createdAt time.Time, srcPath string) (string, error) {
+	if localReportsPath == "" {
+		return "", fmt.Errorf("local_reports path not configured")
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", srcPath, err)
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", srcPath)
+	}
+
+	exportDir := filepath.Dir(buildExportPath(expandPath(localReportsPath), createdAt.Local()))
+	name := fmt.Sprintf("%d-%s", entryID, filepath.Base(srcPath))
+	destPath := filepath.Join(exportDir, attachmentsDir, name)
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", filepath.Dir(destPath), err)
+	}
+
+	dest, err := os.Create(destPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	if _, err := io.Copy(dest, src); err != nil {
+		dest.Close()
+		return "", fmt.Errorf("failed to copy %s: %w", srcPath, err)
+	}
+	if err := dest.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+
+	return AttachmentLink(filepath.Base(srcPath), attachmentsDir+"/"+name), nil
+}
+
+// AttachmentLink returns a markdown link to an attachment, as an image for
+// image files.
+func AttachmentLink(name, relPath string) string {
+	link := fmt.Sprintf("[%s](%s)", name, strings.ReplaceAll(relPath, " ", "%20"))
+	if imageExtensions[strings.ToLower(filepath.Ext(name))] {
+		return "!" + link
+	}
+	return link
+}
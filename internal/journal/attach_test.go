@@ -0,0 +1,56 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAttach(t *testing.T) {
+	reports := t.TempDir()
+	src := filepath.Join(t.TempDir(), "screen shot.png")
+	if err := os.WriteFile(src, []byte("image data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	createdAt := time.Date(2025, 12, 13, 10, 30, 0, 0, time.Local)
+
+	link, err := Attach(reports, 12, createdAt, src)
+	if err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+	if link != "![screen shot.png](attachments/12-screen%20shot.png)" {
+		t.Errorf("Attach() link = %q", link)
+	}
+
+	// The attachment sits next to the day's export so the relative link resolves
+	exportDir := filepath.Dir(buildExportPath(reports, createdAt))
+	data, err := os.ReadFile(filepath.Join(exportDir, "attachments", "12-screen shot.png"))
+	if err != nil {
+		t.Fatalf("Expected attachment to be copied: %v", err)
+	}
+	if string(data) != "image data" {
+		t.Errorf("Unexpected attachment content %q", data)
+	}
+}
+
+func TestAttachErrors(t *testing.T) {
+	if _, err := Attach("", 1, time.Now(), "file.txt"); err == nil {
+		t.Error("Expected error without local_reports")
+	}
+	if _, err := Attach(t.TempDir(), 1, time.Now(), filepath.Join(t.TempDir(), "missing.pdf")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := Attach(t.TempDir(), 1, time.Now(), t.TempDir()); err == nil {
+		t.Error("Expected error for a directory")
+	}
+}
+
+func TestAttachmentLink(t *testing.T) {
+	if got := AttachmentLink("report.pdf", "attachments/3-report.pdf"); got != "[report.pdf](attachments/3-report.pdf)" {
+		t.Errorf("AttachmentLink() = %q", got)
+	}
+	if got := AttachmentLink("Photo.JPG", "attachments/3-Photo.JPG"); got != "![Photo.JPG](attachments/3-Photo.JPG)" {
+		t.Errorf("AttachmentLink() = %q", got)
+	}
+}
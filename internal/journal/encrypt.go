@@ -0,0 +1,69 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// EncryptedExtension is appended to the path of encrypted journal exports.
+const EncryptedExtension = ".age"
+
+// Encrypt encrypts content with a passphrase in the age format, so exports
+// can also be decrypted with the age command-line tool. The output is
+// ASCII-armored.
+func Encrypt(content []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryption key: %w", err)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt journal: %w", err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to encrypt journal: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt journal: %w", err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt journal: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decrypt decrypts content produced by Encrypt (armored or binary age).
+func Decrypt(content []byte, passphrase string) ([]byte, error) {
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption key: %w", err)
+	}
+
+	var src io.Reader = bytes.NewReader(content)
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(content))
+	}
+
+	r, err := age.Decrypt(src, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt journal (wrong passphrase?): %w", err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt journal: %w", err)
+	}
+	return plaintext, nil
+}
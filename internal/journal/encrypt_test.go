@@ -0,0 +1,39 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	markdown := "# 12-13-2025 Journal\n\n- #### time: 10:30 CT\nPrivate entry\n"
+
+	encrypted, err := Encrypt([]byte(markdown), "correct horse")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if strings.Contains(string(encrypted), "Private entry") {
+		t.Fatal("Expected encrypted output not to contain the plaintext")
+	}
+	if !strings.HasPrefix(string(encrypted), "-----BEGIN AGE ENCRYPTED FILE-----") {
+		t.Errorf("Expected armored age output, got:\n%s", encrypted)
+	}
+
+	decrypted, err := Decrypt(encrypted, "correct horse")
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if string(decrypted) != markdown {
+		t.Errorf("Decrypt() = %q, expected %q", decrypted, markdown)
+	}
+
+	if _, err := Decrypt(encrypted, "wrong"); err == nil {
+		t.Error("Expected wrong passphrase to fail")
+	}
+}
+
+func TestEncryptRequiresPassphrase(t *testing.T) {
+	if _, err := Encrypt([]byte("x"), ""); err == nil {
+		t.Error("Expected empty passphrase to be rejected")
+	}
+}
@@ -9,13 +9,14 @@ import (
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/export"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"golang.org/x/sync/errgroup"
 )
 
 // Export constants
 const (
-	maxJournalLimit  = 100
 	maxTaskLimit     = 500
 	maxHabitLimit    = 100
 	exportAPITimeout = 30 * time.Second
@@ -35,6 +36,9 @@ type exportData struct {
 	habits         []*types.RecurringTaskTemplate
 	projectMap     map[int]string
 	habitTasks     map[int]habitTaskInfo
+
+	// taskTitles maps task IDs referenced by task-linked entries to titles
+	taskTitles map[int]string
 }
 
 // apiResults holds the raw results from all API calls
@@ -46,12 +50,71 @@ type apiResults struct {
 	scheduledTasks []*types.Task
 }
 
-// Export exports the journal for a specific date to a markdown file
-func Export(ctx context.Context, client *api.Client, targetDate time.Time, localReportsPath string) (string, error) {
+// Export exports the journal for a specific date to a markdown file.
+// dayStart is how long after midnight the day starts; entries and
+// completions before it count toward the previous day.
+func Export(ctx context.Context, client *api.Client, targetDate time.Time, dayStart time.Duration, localReportsPath string) (string, error) {
+	if localReportsPath == "" {
+		return "", fmt.Errorf("local_reports path not configured")
+	}
+
+	doc, err := Document(ctx, client, targetDate, dayStart, localReportsPath)
+	if err != nil {
+		return "", err
+	}
+
+	return export.File{}.Export(ctx, doc)
+}
+
+// ExportEncrypted exports the journal for a specific date to an
+// age-encrypted markdown file (the usual export path with ".age" appended),
+// protected by passphrase. The plaintext is never written to disk.
+func ExportEncrypted(ctx context.Context, client *api.Client, targetDate time.Time, dayStart time.Duration, localReportsPath, passphrase string) (string, error) {
 	if localReportsPath == "" {
 		return "", fmt.Errorf("local_reports path not configured")
 	}
 
+	markdown, err := renderExport(ctx, client, targetDate, dayStart)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := Encrypt([]byte(markdown), passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := buildExportPath(expandPath(localReportsPath), targetDate) + EncryptedExtension
+	if err := export.WriteFile(outputPath, encrypted, 0600); err != nil {
+		return "", err
+	}
+
+	return outputPath, nil
+}
+
+// Document renders the journal for a specific date as a document for any
+// export target. Its path is the usual export path under localReportsPath,
+// or empty if that isn't set.
+func Document(ctx context.Context, client *api.Client, targetDate time.Time, dayStart time.Duration, localReportsPath string) (*export.Document, error) {
+	markdown, err := renderExport(ctx, client, targetDate, dayStart)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &export.Document{
+		Kind:     export.KindJournal,
+		Title:    targetDate.Format("01-02-2006") + " Journal",
+		Date:     targetDate,
+		Markdown: markdown,
+	}
+	if localReportsPath != "" {
+		doc.Path = buildExportPath(expandPath(localReportsPath), targetDate)
+	}
+	return doc, nil
+}
+
+// renderExport fetches the day's data and generates the export markdown
+func renderExport(ctx context.Context, client *api.Client, targetDate time.Time, dayStart time.Duration) (string, error) {
 	// Fetch all data from API in parallel
 	dateStr := targetDate.Format("2006-01-02")
 	results, err := fetchData(ctx, client, targetDate, dateStr)
@@ -64,31 +127,19 @@ func Export(ctx context.Context, client *api.Client, targetDate time.Time, local
 	habitTemplateIDs := buildHabitTemplateSet(results.habits)
 	habitTasks := buildHabitTaskMap(results.scheduledTasks, habitTemplateIDs)
 
+	journals := filterJournalsByTargetDate(results.journals, targetDate, dayStart)
+
 	data := &exportData{
 		targetDate:     targetDate,
-		journals:       filterJournalsByTargetDate(results.journals, targetDate),
-		completedTasks: filterTasksByTargetDate(results.doneTasks, targetDate),
+		journals:       journals,
+		completedTasks: filterTasksByTargetDate(results.doneTasks, targetDate, dayStart),
 		habits:         results.habits,
 		projectMap:     projectMap,
 		habitTasks:     habitTasks,
+		taskTitles:     fetchLinkedTaskTitles(ctx, client, journals),
 	}
 
-	// Generate markdown
-	markdown := generateMarkdown(data)
-
-	// Write to file
-	outputPath := buildExportPath(expandPath(localReportsPath), targetDate)
-	outputDir := filepath.Dir(outputPath)
-
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create directory %s: %w", outputDir, err)
-	}
-
-	if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
-		return "", fmt.Errorf("failed to write file %s: %w", outputPath, err)
-	}
-
-	return outputPath, nil
+	return generateMarkdown(data), nil
 }
 
 // fetchData fetches all data needed for export in parallel
@@ -102,7 +153,7 @@ func fetchData(ctx context.Context, client *api.Client, targetDate time.Time, da
 	// 1. Fetch journals
 	g.Go(func() error {
 		var err error
-		results.journals, err = client.ListJournals(ctx, 0, maxJournalLimit)
+		results.journals, err = client.ListCommentsFiltered(ctx, DayListOptions(targetDate))
 		return err
 	})
 
@@ -153,6 +204,28 @@ func fetchData(ctx context.Context, client *api.Client, targetDate time.Time, da
 	return results, nil
 }
 
+// fetchLinkedTaskTitles looks up the titles of tasks referenced by
+// task-linked journal entries. Tasks that can't be fetched are left out.
+func fetchLinkedTaskTitles(ctx context.Context, client *api.Client, journals []*types.Comment) map[int]string {
+	titles := make(map[int]string)
+	for _, j := range journals {
+		taskID, _, ok := ParseTaskEntry(j.Content)
+		if !ok {
+			continue
+		}
+		if _, fetched := titles[taskID]; fetched {
+			continue
+		}
+		task, err := client.GetTask(ctx, taskID)
+		if err != nil {
+			titles[taskID] = ""
+			continue
+		}
+		titles[taskID] = task.Title
+	}
+	return titles
+}
+
 // buildProjectMap creates a map from project ID to project name
 func buildProjectMap(projects []*types.Project) map[int]string {
 	projectMap := make(map[int]string)
@@ -187,11 +260,23 @@ func buildHabitTaskMap(scheduledTasks []*types.Task, habitTemplateIDs map[int]st
 	return habitTasks
 }
 
+// DayListOptions returns options listing the journal entries that may have
+// been written on day. The API compares UTC timestamps, so the range is
+// padded by a day on each side and results still need filtering by local
+// date.
+func DayListOptions(day time.Time) *api.CommentListOptions {
+	return &api.CommentListOptions{
+		Type:          "journal",
+		CreatedAfter:  day.AddDate(0, 0, -1).Format("2006-01-02"),
+		CreatedBefore: day.AddDate(0, 0, 2).Format("2006-01-02"),
+	}
+}
+
 // filterJournalsByTargetDate filters journals to only those created on the target date
-func filterJournalsByTargetDate(journals []*types.Comment, targetDate time.Time) []*types.Comment {
+func filterJournalsByTargetDate(journals []*types.Comment, targetDate time.Time, dayStart time.Duration) []*types.Comment {
 	var filtered []*types.Comment
 	for _, j := range journals {
-		if isSameDay(j.CreatedAt.Local(), targetDate) {
+		if daystart.SameDay(j.CreatedAt.Local(), targetDate, dayStart) {
 			filtered = append(filtered, j)
 		}
 	}
@@ -199,23 +284,16 @@ func filterJournalsByTargetDate(journals []*types.Comment, targetDate time.Time)
 }
 
 // filterTasksByTargetDate filters tasks to only those updated on the target date
-func filterTasksByTargetDate(tasks []*types.Task, targetDate time.Time) []*types.Task {
+func filterTasksByTargetDate(tasks []*types.Task, targetDate time.Time, dayStart time.Duration) []*types.Task {
 	var filtered []*types.Task
 	for _, t := range tasks {
-		if isSameDay(t.UpdatedAt.Local(), targetDate) {
+		if daystart.SameDay(t.UpdatedAt.Local(), targetDate, dayStart) {
 			filtered = append(filtered, t)
 		}
 	}
 	return filtered
 }
 
-// isSameDay checks if two times are on the same day
-func isSameDay(t1, t2 time.Time) bool {
-	y1, m1, d1 := t1.Date()
-	y2, m2, d2 := t2.Date()
-	return y1 == y2 && m1 == m2 && d1 == d2
-}
-
 // buildExportPath constructs the output file path for the export
 func buildExportPath(localReports string, targetDate time.Time) string {
 	year := targetDate.Format("2006")
@@ -276,6 +354,15 @@ func formatTimezone(offset int) string {
 	return fmt.Sprintf("%+03d%02d", hours, minutes)
 }
 
+// writeJournalEntry writes a journal entry with its time heading
+func writeJournalEntry(sb *strings.Builder, createdAt time.Time, content string) {
+	_, offset := createdAt.Local().Zone()
+	tz := formatTimezone(offset)
+	timeStr := createdAt.Local().Format("15:04")
+	sb.WriteString(fmt.Sprintf("- #### time: %s %s\n", timeStr, tz))
+	sb.WriteString(fmt.Sprintf("%s\n\n", content))
+}
+
 // generateMarkdown generates the markdown content for the journal export
 func generateMarkdown(data *exportData) string {
 	var sb strings.Builder
@@ -283,13 +370,30 @@ func generateMarkdown(data *exportData) string {
 
 	sb.WriteString(fmt.Sprintf("# %s Journal\n\n", dateFormatted))
 
-	// Journal entries section
+	// Journal entries section; task-linked entries are grouped by task below
+	var taskIDs []int
+	taskEntries := make(map[int][]*types.Comment)
 	for _, j := range data.journals {
-		_, offset := j.CreatedAt.Local().Zone()
-		tz := formatTimezone(offset)
-		timeStr := j.CreatedAt.Local().Format("15:04")
-		sb.WriteString(fmt.Sprintf("- #### time: %s %s\n", timeStr, tz))
-		sb.WriteString(fmt.Sprintf("%s\n\n", j.Content))
+		if taskID, _, ok := ParseTaskEntry(j.Content); ok {
+			if _, seen := taskEntries[taskID]; !seen {
+				taskIDs = append(taskIDs, taskID)
+			}
+			taskEntries[taskID] = append(taskEntries[taskID], j)
+			continue
+		}
+		writeJournalEntry(&sb, j.CreatedAt, j.Content)
+	}
+
+	for _, taskID := range taskIDs {
+		heading := fmt.Sprintf("#%d", taskID)
+		if title := data.taskTitles[taskID]; title != "" {
+			heading += " " + escapeMarkdown(title)
+		}
+		sb.WriteString(fmt.Sprintf("### %s\n\n", heading))
+		for _, j := range taskEntries[taskID] {
+			_, content, _ := ParseTaskEntry(j.Content)
+			writeJournalEntry(&sb, j.CreatedAt, content)
+		}
 	}
 
 	// Completed Today section (exclude habit tasks)
@@ -309,7 +413,7 @@ func generateMarkdown(data *exportData) string {
 		}
 		hasCompletedTasks = true
 		projectName := data.projectMap[t.ProjectID]
-		priority := "medium"
+		priority := types.DefaultPriority()
 		if t.Priority != nil {
 			priority = *t.Priority
 		}
@@ -3,6 +3,7 @@ package journal
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -187,7 +188,7 @@ func TestFilterJournalsByTargetDate(t *testing.T) {
 		{ID: 4, CreatedAt: time.Date(2025, 12, 14, 10, 30, 0, 0, time.Local)}, // Next day
 	}
 
-	result := filterJournalsByTargetDate(journals, targetDate)
+	result := filterJournalsByTargetDate(journals, targetDate, 0)
 
 	if len(result) != 2 {
 		t.Errorf("Expected 2 journals, got %d", len(result))
@@ -210,7 +211,7 @@ func TestFilterTasksByTargetDate(t *testing.T) {
 		{ID: 3, UpdatedAt: time.Date(2025, 12, 13, 0, 1, 0, 0, time.Local)},   // Same day, early
 	}
 
-	result := filterTasksByTargetDate(tasks, targetDate)
+	result := filterTasksByTargetDate(tasks, targetDate, 0)
 
 	if len(result) != 2 {
 		t.Errorf("Expected 2 tasks, got %d", len(result))
@@ -223,6 +224,29 @@ func TestFilterTasksByTargetDate(t *testing.T) {
 	}
 }
 
+func TestFilterByTargetDateWithDayStart(t *testing.T) {
+	targetDate := time.Date(2025, 12, 13, 0, 0, 0, 0, time.Local)
+	dayStart := 4 * time.Hour
+
+	journals := []*types.Comment{
+		{ID: 1, CreatedAt: time.Date(2025, 12, 13, 2, 0, 0, 0, time.Local)},  // Before day start, counts toward the 12th
+		{ID: 2, CreatedAt: time.Date(2025, 12, 13, 4, 0, 0, 0, time.Local)},  // At day start
+		{ID: 3, CreatedAt: time.Date(2025, 12, 14, 1, 30, 0, 0, time.Local)}, // Late night, counts toward the 13th
+	}
+	result := filterJournalsByTargetDate(journals, targetDate, dayStart)
+	if len(result) != 2 || result[0].ID != 2 || result[1].ID != 3 {
+		t.Errorf("Expected journals 2 and 3, got %v", result)
+	}
+
+	tasks := []*types.Task{
+		{ID: 1, UpdatedAt: time.Date(2025, 12, 14, 3, 59, 0, 0, time.Local)},
+		{ID: 2, UpdatedAt: time.Date(2025, 12, 14, 4, 0, 0, 0, time.Local)},
+	}
+	if got := filterTasksByTargetDate(tasks, targetDate, dayStart); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("Expected task 1, got %v", got)
+	}
+}
+
 func TestBuildExportPath(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -386,3 +410,51 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestParseTaskEntry(t *testing.T) {
+	content := FormatTaskEntry(42, "spent 2h debugging")
+	if content != "[task #42] spent 2h debugging" {
+		t.Errorf("FormatTaskEntry() = %q", content)
+	}
+
+	taskID, body, ok := ParseTaskEntry(content)
+	if !ok || taskID != 42 || body != "spent 2h debugging" {
+		t.Errorf("ParseTaskEntry() = %d, %q, %t", taskID, body, ok)
+	}
+
+	if _, body, ok := ParseTaskEntry("plain entry [task #1]"); ok || body != "plain entry [task #1]" {
+		t.Errorf("Expected unlinked entry, got %q, %t", body, ok)
+	}
+}
+
+func TestGenerateMarkdown_GroupsTaskEntries(t *testing.T) {
+	at := time.Date(2025, 12, 13, 10, 30, 0, 0, time.Local)
+	data := &exportData{
+		targetDate: at,
+		journals: []*types.Comment{
+			{ID: 1, Content: FormatTaskEntry(42, "spent 2h debugging"), CreatedAt: at},
+			{ID: 2, Content: "General note", CreatedAt: at.Add(time.Hour)},
+			{ID: 3, Content: FormatTaskEntry(42, "found the bug"), CreatedAt: at.Add(2 * time.Hour)},
+			{ID: 4, Content: FormatTaskEntry(7, "reviewed"), CreatedAt: at.Add(3 * time.Hour)},
+		},
+		projectMap: make(map[int]string),
+		habitTasks: make(map[int]habitTaskInfo),
+		taskTitles: map[int]string{42: "Fix login"},
+	}
+
+	result := generateMarkdown(data)
+
+	general := strings.Index(result, "General note")
+	task42 := strings.Index(result, "### #42 Fix login\n\n")
+	task7 := strings.Index(result, "### #7\n\n")
+	if general < 0 || task42 < 0 || task7 < 0 || !(general < task42 && task42 < task7) {
+		t.Fatalf("Expected general entries then task groups, got:\n%s", result)
+	}
+	section := result[task42:task7]
+	if !strings.Contains(section, "spent 2h debugging") || !strings.Contains(section, "found the bug") {
+		t.Errorf("Expected both entries under task #42, got:\n%s", section)
+	}
+	if strings.Contains(result, "[task #") {
+		t.Errorf("Expected task references to be removed, got:\n%s", result)
+	}
+}
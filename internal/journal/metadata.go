@@ -0,0 +1,149 @@
+package journal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Ratings for mood and energy range from MinRating to MaxRating.
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// metadataPattern matches "mood:N" and "energy:N" tokens in entry content.
+var metadataPattern = regexp.MustCompile(`(?i)(?:^|\s)(mood|energy):(\d+)\b`)
+
+// Metadata is the structured data recorded in a journal entry.
+type Metadata struct {
+	// Mood is the mood rating, or 0 if not recorded.
+	Mood int
+
+	// Energy is the energy rating, or 0 if not recorded.
+	Energy int
+}
+
+// ParseMetadata reads "mood:N" and "energy:N" tokens from entry content.
+// Ratings outside MinRating to MaxRating are ignored; if a token appears
+// more than once, the last one wins.
+func ParseMetadata(content string) Metadata {
+	var meta Metadata
+	for _, match := range metadataPattern.FindAllStringSubmatch(content, -1) {
+		value, err := strconv.Atoi(match[2])
+		if err != nil || value < MinRating || value > MaxRating {
+			continue
+		}
+		switch strings.ToLower(match[1]) {
+		case "mood":
+			meta.Mood = value
+		case "energy":
+			meta.Energy = value
+		}
+	}
+	return meta
+}
+
+// AppendMetadata adds "mood:N" and "energy:N" tokens to entry content on
+// their own line. Zero ratings are left out.
+func AppendMetadata(content string, meta Metadata) string {
+	var tokens []string
+	if meta.Mood != 0 {
+		tokens = append(tokens, fmt.Sprintf("mood:%d", meta.Mood))
+	}
+	if meta.Energy != 0 {
+		tokens = append(tokens, fmt.Sprintf("energy:%d", meta.Energy))
+	}
+	if len(tokens) == 0 {
+		return content
+	}
+	return content + "\n\n" + strings.Join(tokens, " ")
+}
+
+// ValidateRating checks a mood or energy rating given on the command line.
+// Zero means not set and is allowed.
+func ValidateRating(name string, value int) error {
+	if value != 0 && (value < MinRating || value > MaxRating) {
+		return fmt.Errorf("invalid %s %d: must be between %d and %d", name, value, MinRating, MaxRating)
+	}
+	return nil
+}
+
+// Summary holds average mood and energy over a set of journal entries.
+type Summary struct {
+	Entries     int     `json:"entries"`
+	Mood        float64 `json:"mood,omitempty"`
+	MoodCount   int     `json:"mood_count"`
+	Energy      float64 `json:"energy,omitempty"`
+	EnergyCount int     `json:"energy_count"`
+}
+
+// HasRatings reports whether any entry recorded a mood or energy rating.
+func (s Summary) HasRatings() bool {
+	return s.MoodCount > 0 || s.EnergyCount > 0
+}
+
+// Summarize averages the mood and energy ratings recorded in entries.
+func Summarize(entries []*types.Comment) Summary {
+	var summary Summary
+	moodTotal, energyTotal := 0, 0
+	for _, e := range entries {
+		summary.Entries++
+		meta := ParseMetadata(e.Content)
+		if meta.Mood != 0 {
+			moodTotal += meta.Mood
+			summary.MoodCount++
+		}
+		if meta.Energy != 0 {
+			energyTotal += meta.Energy
+			summary.EnergyCount++
+		}
+	}
+	if summary.MoodCount > 0 {
+		summary.Mood = float64(moodTotal) / float64(summary.MoodCount)
+	}
+	if summary.EnergyCount > 0 {
+		summary.Energy = float64(energyTotal) / float64(summary.EnergyCount)
+	}
+	return summary
+}
+
+// WeekTrend is the mood and energy summary for one week.
+type WeekTrend struct {
+	WeekStart time.Time `json:"week_start"`
+	Summary
+}
+
+// WeeklyTrends groups entries into Monday-based weeks and summarizes each,
+// returning the given number of weeks ending with the week containing end.
+// Weeks without entries are included with zero counts.
+func WeeklyTrends(entries []*types.Comment, end time.Time, weeks int) []WeekTrend {
+	lastWeek := WeekStart(end)
+	firstWeek := lastWeek.AddDate(0, 0, -7*(weeks-1))
+
+	byWeek := make(map[string][]*types.Comment)
+	for _, e := range entries {
+		key := WeekStart(e.CreatedAt.Local()).Format("2006-01-02")
+		byWeek[key] = append(byWeek[key], e)
+	}
+
+	trends := make([]WeekTrend, 0, weeks)
+	for week := firstWeek; !week.After(lastWeek); week = week.AddDate(0, 0, 7) {
+		trends = append(trends, WeekTrend{
+			WeekStart: week,
+			Summary:   Summarize(byWeek[week.Format("2006-01-02")]),
+		})
+	}
+	return trends
+}
+
+// WeekStart returns local midnight on the Monday of t's week.
+func WeekStart(t time.Time) time.Time {
+	t = t.Local()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,91 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected Metadata
+	}{
+		{"Good day mood:4 energy:3", Metadata{Mood: 4, Energy: 3}},
+		{"Mood:2\nlong day", Metadata{Mood: 2}},
+		{"energy:9 is out of range", Metadata{}},
+		{"no ratings here", Metadata{}},
+		{"badmood:4 doesn't count", Metadata{}},
+		{"mood:2 then mood:5", Metadata{Mood: 5}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseMetadata(tt.content); got != tt.expected {
+			t.Errorf("ParseMetadata(%q) = %+v, expected %+v", tt.content, got, tt.expected)
+		}
+	}
+}
+
+func TestAppendMetadata(t *testing.T) {
+	got := AppendMetadata("Good focus", Metadata{Mood: 4, Energy: 3})
+	if got != "Good focus\n\nmood:4 energy:3" {
+		t.Errorf("AppendMetadata() = %q", got)
+	}
+	if got := AppendMetadata("Plain", Metadata{}); got != "Plain" {
+		t.Errorf("Expected content unchanged, got %q", got)
+	}
+	if ParseMetadata(AppendMetadata("x", Metadata{Energy: 2})) != (Metadata{Energy: 2}) {
+		t.Error("Expected appended metadata to parse back")
+	}
+}
+
+func TestValidateRating(t *testing.T) {
+	if err := ValidateRating("mood", 0); err != nil {
+		t.Errorf("Expected unset rating to be valid: %v", err)
+	}
+	if err := ValidateRating("mood", 5); err != nil {
+		t.Errorf("Expected 5 to be valid: %v", err)
+	}
+	if err := ValidateRating("energy", 6); err == nil {
+		t.Error("Expected 6 to be invalid")
+	}
+}
+
+func TestWeeklyTrends(t *testing.T) {
+	// Wednesday
+	end := time.Date(2025, 12, 17, 12, 0, 0, 0, time.Local)
+	entries := []*types.Comment{
+		{Content: "mood:4 energy:2", CreatedAt: time.Date(2025, 12, 15, 9, 0, 0, 0, time.Local)},
+		{Content: "mood:2", CreatedAt: time.Date(2025, 12, 17, 9, 0, 0, 0, time.Local)},
+		{Content: "no rating", CreatedAt: time.Date(2025, 12, 16, 9, 0, 0, 0, time.Local)},
+		{Content: "mood:5", CreatedAt: time.Date(2025, 12, 3, 9, 0, 0, 0, time.Local)},
+	}
+
+	trends := WeeklyTrends(entries, end, 3)
+	if len(trends) != 3 {
+		t.Fatalf("Expected 3 weeks, got %d", len(trends))
+	}
+	if got := trends[0].WeekStart.Format("2006-01-02"); got != "2025-12-01" {
+		t.Errorf("Expected first week to start 2025-12-01, got %s", got)
+	}
+	if trends[0].Mood != 5 || trends[1].Entries != 0 {
+		t.Errorf("Unexpected earlier weeks: %+v", trends[:2])
+	}
+
+	last := trends[2]
+	if last.Entries != 3 || last.MoodCount != 2 || last.Mood != 3 || last.EnergyCount != 1 || last.Energy != 2 {
+		t.Errorf("Unexpected current week summary: %+v", last)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2025, 12, 21, 23, 0, 0, 0, time.Local)
+	if got := WeekStart(sunday).Format("2006-01-02"); got != "2025-12-15" {
+		t.Errorf("WeekStart(Sunday) = %s, expected 2025-12-15", got)
+	}
+	monday := time.Date(2025, 12, 15, 0, 0, 0, 0, time.Local)
+	if got := WeekStart(monday).Format("2006-01-02"); got != "2025-12-15" {
+		t.Errorf("WeekStart(Monday) = %s, expected 2025-12-15", got)
+	}
+}
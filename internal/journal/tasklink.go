@@ -0,0 +1,33 @@
+package journal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// taskRefPattern matches the task reference that starts a task-linked
+// journal entry, e.g. "[task #42] spent 2h debugging".
+var taskRefPattern = regexp.MustCompile(`^\[task #(\d+)\]\s*`)
+
+// FormatTaskEntry returns the content of a journal entry linked to a task.
+// The entry starts with a "[task #N]" reference so it can be grouped with
+// the task in exports.
+func FormatTaskEntry(taskID int, content string) string {
+	return fmt.Sprintf("[task #%d] %s", taskID, content)
+}
+
+// ParseTaskEntry returns the task a journal entry is linked to and the entry
+// text without the reference. Returns false if the entry isn't linked.
+func ParseTaskEntry(content string) (int, string, bool) {
+	match := taskRefPattern.FindStringSubmatch(content)
+	if match == nil {
+		return 0, content, false
+	}
+	taskID, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, content, false
+	}
+	return taskID, strings.TrimPrefix(content, match[0]), true
+}
@@ -0,0 +1,174 @@
+// Package mentions finds @name mentions in comments and journal entries and
+// remembers which ones were read or already notified.
+package mentions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Parse returns the names mentioned in text as @name, in order of first
+// mention without duplicates (case-insensitive). Names are letters, digits,
+// and inner dots, dashes, and underscores, like GitHub logins. An @ after a
+// letter or digit, as in an email address, is not a mention.
+func Parse(text string) []string {
+	var names []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isNameRune(runes[i-1])) {
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && (isNameRune(runes[j]) || isNamePunct(runes[j])) {
+			j++
+		}
+		name := strings.TrimRightFunc(string(runes[i+1:j]), isNamePunct)
+		i = j - 1
+		if name == "" || isNamePunct(rune(name[0])) {
+			continue
+		}
+		if !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) }) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isNameRune reports whether r can start or end a mentioned name.
+func isNameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// isNamePunct reports whether r can appear inside a mentioned name.
+func isNamePunct(r rune) bool {
+	return r == '.' || r == '-' || r == '_'
+}
+
+// Mentions reports whether comment mentions any of names (case-insensitive).
+// Comments written by one of names don't count.
+func Mentions(comment *types.Comment, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(comment.Author, name) {
+			return false
+		}
+	}
+	for _, mentioned := range Parse(comment.Content) {
+		for _, name := range names {
+			if strings.EqualFold(mentioned, strings.TrimPrefix(name, "@")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Find returns the comments that mention any of names, newest first.
+func Find(comments []*types.Comment, names []string) []*types.Comment {
+	var result []*types.Comment
+	for _, comment := range comments {
+		if Mentions(comment, names) {
+			result = append(result, comment)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *types.Comment) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return result
+}
+
+// State records, by comment ID, when mentions were marked read and when
+// they were sent as notifications.
+type State struct {
+	Read     map[int]time.Time `json:"read"`
+	Notified map[int]time.Time `json:"notified"`
+}
+
+// Unread returns the mentions not marked read.
+func (s *State) Unread(mentions []*types.Comment) []*types.Comment {
+	var result []*types.Comment
+	for _, comment := range mentions {
+		if _, ok := s.Read[comment.ID]; !ok {
+			result = append(result, comment)
+		}
+	}
+	return result
+}
+
+// Unnotified returns the unread mentions not sent as notifications yet.
+func (s *State) Unnotified(mentions []*types.Comment) []*types.Comment {
+	var result []*types.Comment
+	for _, comment := range s.Unread(mentions) {
+		if _, ok := s.Notified[comment.ID]; !ok {
+			result = append(result, comment)
+		}
+	}
+	return result
+}
+
+// MarkRead marks mentions read at now.
+func (s *State) MarkRead(mentions []*types.Comment, now time.Time) {
+	for _, comment := range mentions {
+		s.Read[comment.ID] = now
+	}
+}
+
+// MarkNotified records that mentions were sent as notifications at now.
+func (s *State) MarkNotified(mentions []*types.Comment, now time.Time) {
+	for _, comment := range mentions {
+		s.Notified[comment.ID] = now
+	}
+}
+
+// DefaultStatePath returns the default state file
+// (~/.config/todu/mentions.json).
+func DefaultStatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "mentions.json"), nil
+}
+
+// LoadState reads the mention state from path. A missing file means
+// nothing was read or notified yet.
+func LoadState(path string) (*State, error) {
+	state := &State{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read mention state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("failed to parse mention state %s: %w", path, err)
+		}
+	}
+	if state.Read == nil {
+		state.Read = make(map[int]time.Time)
+	}
+	if state.Notified == nil {
+		state.Notified = make(map[int]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the mention state to path.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mention state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write mention state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package mentions
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@alice can you look?", []string{"alice"}},
+		{"cc @bob, @carol-d and (@dave_e).", []string{"bob", "carol-d", "dave_e"}},
+		{"thanks @Alice and @alice", []string{"Alice"}},
+		{"mail jane@example.com", nil},
+		{"@ alone, @-dash and @@", nil},
+		{"ask @jane.doe.", []string{"jane.doe"}},
+	}
+
+	for _, tt := range tests {
+		if got := Parse(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	now := time.Now()
+	comments := []*types.Comment{
+		{ID: 1, Author: "bob", Content: "@Jane please review", CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, Author: "jane", Content: "note to self @jane"},
+		{ID: 3, Author: "bob", Content: "@janet not you", CreatedAt: now},
+		{ID: 4, Author: "carol", Content: "ping @jdoe", CreatedAt: now},
+	}
+
+	got := Find(comments, []string{"jane", "@jdoe"})
+	if len(got) != 2 || got[0].ID != 4 || got[1].ID != 1 {
+		t.Errorf("Expected comments 4 and 1, got %v", got)
+	}
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mentions.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	mentions := []*types.Comment{{ID: 1}, {ID: 2}, {ID: 3}}
+	now := time.Now()
+	state.MarkRead(mentions[:1], now)
+	state.MarkNotified(mentions[1:2], now)
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	state, err = LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if unread := state.Unread(mentions); len(unread) != 2 || unread[0].ID != 2 {
+		t.Errorf("Expected mentions 2 and 3 unread, got %v", unread)
+	}
+	if unnotified := state.Unnotified(mentions); len(unnotified) != 1 || unnotified[0].ID != 3 {
+		t.Errorf("Expected mention 3 unnotified, got %v", unnotified)
+	}
+}
@@ -0,0 +1,116 @@
+package meta
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Command describes a CLI command, its flags, and its examples
+type Command struct {
+	Path        string    `json:"path"`
+	Use         string    `json:"use"`
+	Aliases     []string  `json:"aliases,omitempty"`
+	Short       string    `json:"short"`
+	Long        string    `json:"long,omitempty"`
+	Examples    []string  `json:"examples,omitempty"`
+	Runnable    bool      `json:"runnable"`
+	Flags       []Flag    `json:"flags,omitempty"`
+	Subcommands []Command `json:"subcommands,omitempty"`
+}
+
+// Flag describes a command-line flag
+type Flag struct {
+	Name       string `json:"name"`
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type"`
+	Default    string `json:"default,omitempty"`
+	Usage      string `json:"usage"`
+	Persistent bool   `json:"persistent,omitempty"`
+}
+
+// DescribeCommands returns the catalog of cmd and all its visible
+// subcommands. Hidden commands and the generated help command are left out.
+func DescribeCommands(cmd *cobra.Command) Command {
+	c := Command{
+		Path:     cmd.CommandPath(),
+		Use:      cmd.Use,
+		Aliases:  cmd.Aliases,
+		Short:    cmd.Short,
+		Long:     cmd.Long,
+		Examples: commandExamples(cmd),
+		Runnable: cmd.Runnable(),
+	}
+
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		c.Flags = append(c.Flags, describeFlag(f, cmd.PersistentFlags().Lookup(f.Name) != nil))
+	})
+
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden || sub.Name() == "help" {
+			continue
+		}
+		c.Subcommands = append(c.Subcommands, DescribeCommands(sub))
+	}
+	return c
+}
+
+func describeFlag(f *pflag.Flag, persistent bool) Flag {
+	flag := Flag{
+		Name:       f.Name,
+		Shorthand:  f.Shorthand,
+		Type:       f.Value.Type(),
+		Usage:      f.Usage,
+		Persistent: persistent,
+	}
+	if f.DefValue != "" && f.DefValue != "[]" {
+		flag.Default = f.DefValue
+	}
+	return flag
+}
+
+// commandExamples returns a command's example invocations, from its Example
+// field or, as most commands write them, the indented lines after an
+// "Example:" or "Examples:" heading in the long description.
+func commandExamples(cmd *cobra.Command) []string {
+	text := cmd.Example
+	if text == "" {
+		text = examplesSection(cmd.Long)
+	}
+
+	var examples []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		examples = append(examples, line)
+	}
+	return examples
+}
+
+// examplesSection returns the indented block following an "Example:" or
+// "Examples:" line in long help text.
+func examplesSection(long string) string {
+	var block []string
+	inSection := false
+	for _, line := range strings.Split(long, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "Example:" || trimmed == "Examples:" {
+			inSection = true
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if trimmed != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			break
+		}
+		block = append(block, line)
+	}
+	return strings.Join(block, "\n")
+}
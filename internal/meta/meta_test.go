@@ -0,0 +1,128 @@
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSchemaFor(t *testing.T) {
+	schema, err := SchemaFor("task")
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+
+	if schema["$schema"] != SchemaDialect || schema["title"] != "Task" {
+		t.Errorf("unexpected header: $schema=%v title=%v", schema["$schema"], schema["title"])
+	}
+
+	props := schema["properties"].(map[string]any)
+	tests := []struct {
+		name string
+		want map[string]any
+	}{
+		{"id", map[string]any{"type": "integer"}},
+		{"title", map[string]any{"type": "string"}},
+		{"priority", map[string]any{"type": []string{"string", "null"}}},
+		{"created_at", map[string]any{"type": "string", "format": "date-time"}},
+		{"due_date", map[string]any{"type": []string{"string", "null"}, "format": "date-time"}},
+	}
+	for _, tt := range tests {
+		if got := props[tt.name]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("property %q = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	labels := props["labels"].(map[string]any)
+	if labels["type"] != "array" {
+		t.Errorf("labels type = %v, want array", labels["type"])
+	}
+	item := labels["items"].(map[string]any)
+	if _, ok := item["properties"].(map[string]any)["name"]; !ok {
+		t.Errorf("labels items missing name property: %v", item)
+	}
+
+	required := schema["required"].([]string)
+	has := make(map[string]bool)
+	for _, r := range required {
+		has[r] = true
+	}
+	if !has["id"] || !has["title"] || has["priority"] || has["labels"] {
+		t.Errorf("required = %v", required)
+	}
+}
+
+func TestSchemaForUnknown(t *testing.T) {
+	if _, err := SchemaFor("widget"); err == nil {
+		t.Error("SchemaFor(widget) expected error")
+	}
+}
+
+func TestAllSchemas(t *testing.T) {
+	all := AllSchemas()
+	for _, name := range []string{"task", "project", "template", "comment"} {
+		if _, ok := all[name]; !ok {
+			t.Errorf("AllSchemas() missing %q", name)
+		}
+	}
+}
+
+func TestDescribeCommands(t *testing.T) {
+	root := &cobra.Command{Use: "todu"}
+	root.PersistentFlags().String("format", "text", "output format")
+
+	var verbose bool
+	list := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List things",
+		Long: `List things.
+
+Examples:
+  todu thing list
+  todu thing list --verbose  # More detail
+
+Notes here.`,
+		RunE: func(*cobra.Command, []string) error { return nil },
+	}
+	list.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show more")
+
+	hidden := &cobra.Command{Use: "secret", Hidden: true, RunE: func(*cobra.Command, []string) error { return nil }}
+	thing := &cobra.Command{Use: "thing", Short: "Things"}
+	thing.AddCommand(list, hidden)
+	root.AddCommand(thing)
+
+	catalog := DescribeCommands(root)
+
+	if len(catalog.Flags) != 1 || !catalog.Flags[0].Persistent || catalog.Flags[0].Default != "text" {
+		t.Errorf("root flags = %+v", catalog.Flags)
+	}
+	if len(catalog.Subcommands) != 1 || catalog.Subcommands[0].Runnable {
+		t.Fatalf("root subcommands = %+v", catalog.Subcommands)
+	}
+
+	subs := catalog.Subcommands[0].Subcommands
+	if len(subs) != 1 {
+		t.Fatalf("thing subcommands = %+v, want only list", subs)
+	}
+	got := subs[0]
+	if got.Path != "todu thing list" || !got.Runnable || !reflect.DeepEqual(got.Aliases, []string{"ls"}) {
+		t.Errorf("list = %+v", got)
+	}
+	wantExamples := []string{"todu thing list", "todu thing list --verbose  # More detail"}
+	if !reflect.DeepEqual(got.Examples, wantExamples) {
+		t.Errorf("examples = %q, want %q", got.Examples, wantExamples)
+	}
+	wantFlag := Flag{Name: "verbose", Shorthand: "v", Type: "bool", Default: "false", Usage: "Show more"}
+	if len(got.Flags) != 1 || got.Flags[0] != wantFlag {
+		t.Errorf("flags = %+v, want %+v", got.Flags, wantFlag)
+	}
+}
+
+func TestCommandExamplesPrefersExampleField(t *testing.T) {
+	c := &cobra.Command{Use: "x", Example: "  todu x\n  todu x --y", Long: "Examples:\n  other"}
+	if got := commandExamples(c); !reflect.DeepEqual(got, []string{"todu x", "todu x --y"}) {
+		t.Errorf("commandExamples() = %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+// Package meta describes todu's data types and commands in machine-readable
+// form, so scripts and agents can drive the CLI without parsing help text.
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// SchemaDialect is the JSON Schema version the generated schemas follow
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaTypes maps the entity names accepted by SchemaFor to their types
+var schemaTypes = map[string]reflect.Type{
+	"task":     reflect.TypeOf(types.Task{}),
+	"project":  reflect.TypeOf(types.Project{}),
+	"template": reflect.TypeOf(types.RecurringTaskTemplate{}),
+	"comment":  reflect.TypeOf(types.Comment{}),
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaNames returns the entity names that have schemas, sorted
+func SchemaNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaFor returns the JSON Schema for the named entity as it appears in
+// todu's JSON output.
+func SchemaFor(name string) (map[string]any, error) {
+	t, ok := schemaTypes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q (valid: %s)", name, strings.Join(SchemaNames(), ", "))
+	}
+	schema := typeSchema(t)
+	schema["$schema"] = SchemaDialect
+	schema["title"] = t.Name()
+	return schema, nil
+}
+
+// AllSchemas returns the schemas for every entity, keyed by name
+func AllSchemas() map[string]map[string]any {
+	all := make(map[string]map[string]any, len(schemaTypes))
+	for _, name := range SchemaNames() {
+		schema, _ := SchemaFor(name)
+		all[name] = schema
+	}
+	return all
+}
+
+// typeSchema builds the schema for a Go type from its JSON encoding
+func typeSchema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := typeSchema(t.Elem())
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []string{typ, "null"}
+		}
+		return schema
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema builds an object schema from a struct's exported fields.
+// Fields without omitempty are required.
+func structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
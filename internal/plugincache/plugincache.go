@@ -0,0 +1,183 @@
+// Package plugincache stores plugin responses on disk with a time-to-live.
+//
+// Listing every repository a token can access, or every label of a
+// repository, takes many requests and rarely changes. Commands cache these
+// responses so repeated `project discover` runs and syncs don't refetch them.
+// Each entry is a JSON file named after a hash of its key:
+//
+//	~/.config/todu/cache/{hash}.json
+package plugincache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTTL is how long cached responses are used before being refetched.
+const DefaultTTL = time.Hour
+
+// ConfigTTL is the plugin configuration key that overrides DefaultTTL.
+// The value is a Go duration such as "30m"; "0" disables caching.
+const ConfigTTL = "cache_ttl"
+
+// refresh makes every cache miss while still storing fresh responses.
+var refresh atomic.Bool
+
+// SetRefresh makes all caches bypass stored entries when enabled, so the
+// next lookups refetch and re-store their responses. Commands set it from
+// their --refresh flag.
+func SetRefresh(enabled bool) {
+	refresh.Store(enabled)
+}
+
+// disabled makes every cache miss and store nothing.
+var disabled atomic.Bool
+
+// SetDisabled turns all caches off when enabled, so every lookup goes to the
+// plugin's server and nothing is stored, such as while recording or
+// replaying a command's traffic.
+func SetDisabled(enabled bool) {
+	disabled.Store(enabled)
+}
+
+// entry is the on-disk form of a cached value.
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Cache is a directory of cached responses.
+// A nil Cache is valid and never stores anything.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// New returns a cache that keeps entries in dir for ttl.
+// A ttl of zero or less returns nil, which disables caching.
+func New(dir string, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// DefaultDir returns the default cache directory (~/.config/todu/cache).
+func DefaultDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "cache"), nil
+}
+
+// ForPlugin returns a cache in the default directory with the TTL from a
+// plugin's configuration. Returns nil (no caching) if the TTL is "0" or the
+// home directory can't be determined.
+func ForPlugin(config map[string]string) *Cache {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil
+	}
+	return New(dir, ParseTTL(config[ConfigTTL]))
+}
+
+// ParseTTL parses a cache_ttl value, returning DefaultTTL if it is empty or
+// invalid.
+func ParseTTL(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultTTL
+	}
+	if value == "0" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
+
+// Key joins parts into a cache key, e.g. Key("forgejo", url, "labels", "owner/repo").
+func Key(parts ...string) string {
+	return strings.Join(parts, "|")
+}
+
+// Get decodes the cached value for key into v. It reports false if there is
+// no entry, the entry has expired, or refresh is enabled.
+func (c *Cache) Get(key string, v any) bool {
+	if c == nil || refresh.Load() || disabled.Load() {
+		return false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return false
+	}
+	if c.now().Sub(e.StoredAt) > c.ttl {
+		return false
+	}
+
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Put stores v under key.
+func (c *Cache) Put(key string, v any) error {
+	if c == nil || disabled.Load() {
+		return nil
+	}
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	data, err := json.Marshal(&entry{Key: key, StoredAt: c.now(), Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	path := c.path(key)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the entry for key, if any.
+func (c *Cache) Delete(key string) {
+	if c == nil {
+		return
+	}
+	_ = os.Remove(c.path(key))
+}
+
+// path returns the file path for a key. Keys are hashed so that URLs and
+// repository names are safe to use and credentials never appear in file names.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
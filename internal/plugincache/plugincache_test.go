@@ -0,0 +1,115 @@
+package plugincache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheGetPut(t *testing.T) {
+	cache := New(t.TempDir(), time.Hour)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	var got []string
+	if cache.Get("projects", &got) {
+		t.Fatal("Expected miss on empty cache")
+	}
+
+	if err := cache.Put("projects", []string{"a/b", "c/d"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if !cache.Get("projects", &got) || len(got) != 2 || got[1] != "c/d" {
+		t.Errorf("Expected cached value, got %v", got)
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(2 * time.Hour)
+	if cache.Get("projects", &got) {
+		t.Error("Expected expired entry to miss")
+	}
+}
+
+func TestCacheRefreshAndDelete(t *testing.T) {
+	cache := New(t.TempDir(), time.Hour)
+	if err := cache.Put("labels", map[string]int64{"bug": 1}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	var got map[string]int64
+	SetRefresh(true)
+	hit := cache.Get("labels", &got)
+	SetRefresh(false)
+	if hit {
+		t.Error("Expected refresh to bypass the cache")
+	}
+
+	if !cache.Get("labels", &got) || got["bug"] != 1 {
+		t.Errorf("Expected cached labels, got %v", got)
+	}
+
+	cache.Delete("labels")
+	if cache.Get("labels", &got) {
+		t.Error("Expected deleted entry to miss")
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	cache := New(t.TempDir(), time.Hour)
+	if err := cache.Put("labels", map[string]int64{"bug": 1}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	SetDisabled(true)
+	var got map[string]int64
+	hit := cache.Get("labels", &got)
+	err := cache.Put("repos", []string{"a/b"})
+	SetDisabled(false)
+	if hit {
+		t.Error("Expected a disabled cache to miss")
+	}
+	if err != nil {
+		t.Errorf("Put on disabled cache failed: %v", err)
+	}
+
+	var repos []string
+	if cache.Get("repos", &repos) {
+		t.Errorf("Expected nothing stored while disabled, got %v", repos)
+	}
+	if !cache.Get("labels", &got) {
+		t.Error("Expected entries from before to be kept")
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var cache *Cache
+	if err := cache.Put("k", 1); err != nil {
+		t.Errorf("Put on nil cache failed: %v", err)
+	}
+	var v int
+	if cache.Get("k", &v) {
+		t.Error("Expected nil cache to miss")
+	}
+	cache.Delete("k")
+
+	if New(t.TempDir(), 0) != nil {
+		t.Error("Expected zero TTL to disable caching")
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultTTL},
+		{"0", 0},
+		{"30m", 30 * time.Minute},
+		{"bogus", DefaultTTL},
+		{"-1h", DefaultTTL},
+	}
+	for _, tt := range tests {
+		if got := ParseTTL(tt.value); got != tt.want {
+			t.Errorf("ParseTTL(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
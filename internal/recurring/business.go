@@ -0,0 +1,72 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// TemplateSkip returns the days a template's occurrences should not land
+// on, from its skip-weekends and skip-holidays labels.
+func TemplateSkip(tmpl *types.RecurringTaskTemplate) holidays.Skip {
+	return holidays.Skip{
+		Weekends: tmpl.HasLabel(types.SkipWeekendsLabel),
+		Holidays: tmpl.HasLabel(types.SkipHolidaysLabel),
+	}
+}
+
+// TaskSkip returns the days a generated task should not land on. Tasks
+// carry their template's labels.
+func TaskSkip(task *types.Task) holidays.Skip {
+	return holidays.Skip{
+		Weekends: task.HasLabel(types.SkipWeekendsLabel),
+		Holidays: task.HasLabel(types.SkipHolidaysLabel),
+	}
+}
+
+// Reschedule returns the update that moves a generated task off a skipped
+// day to the next allowed one, or nil if it doesn't need to move. A due
+// date moves by the same number of days as the scheduled date.
+func Reschedule(task *types.Task, cal *holidays.Calendar) *types.TaskUpdate {
+	skip := TaskSkip(task)
+	if task.TemplateID == nil || task.ScheduledDate == nil || !skip.Any() {
+		return nil
+	}
+
+	scheduled := dateOnly(*task.ScheduledDate)
+	next := cal.Next(scheduled, skip)
+	if next.Equal(scheduled) {
+		return nil
+	}
+
+	update := &types.TaskUpdate{ScheduledDate: &next}
+	if task.DueDate != nil {
+		due := task.DueDate.Add(next.Sub(scheduled))
+		update.DueDate = &due
+	}
+	return update
+}
+
+// Adjust moves each date with next, dropping dates that land on a day a
+// task is already scheduled for and collapsing dates that land together.
+// Used to move missed occurrences off skipped days.
+func Adjust(dates []time.Time, existing []*types.Task, next func(time.Time) time.Time) []time.Time {
+	taken := make(map[time.Time]bool)
+	for _, task := range existing {
+		if task.ScheduledDate != nil {
+			taken[dateOnly(*task.ScheduledDate)] = true
+		}
+	}
+
+	var adjusted []time.Time
+	for _, date := range dates {
+		moved := dateOnly(next(date))
+		if taken[moved] {
+			continue
+		}
+		taken[moved] = true
+		adjusted = append(adjusted, moved)
+	}
+	return adjusted
+}
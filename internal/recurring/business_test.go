@@ -0,0 +1,79 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestReschedule(t *testing.T) {
+	cal, err := holidays.Load("US", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templateID := 3
+	saturday := mustDate("2024-07-06")
+	holiday := mustDate("2024-07-04")
+	due := time.Date(2024, 7, 6, 17, 0, 0, 0, time.UTC)
+
+	task := &types.Task{
+		TemplateID:    &templateID,
+		ScheduledDate: &saturday,
+		DueDate:       &due,
+		Labels:        []types.Label{{Name: types.SkipWeekendsLabel}},
+	}
+	update := Reschedule(task, cal)
+	if update == nil || update.ScheduledDate.Format("2006-01-02") != "2024-07-08" {
+		t.Fatalf("Expected task moved to 2024-07-08, got %+v", update)
+	}
+	if want := time.Date(2024, 7, 8, 17, 0, 0, 0, time.UTC); !update.DueDate.Equal(want) {
+		t.Errorf("Expected due date moved to %v, got %v", want, update.DueDate)
+	}
+
+	// Holidays are only skipped with the skip-holidays label
+	task = &types.Task{TemplateID: &templateID, ScheduledDate: &holiday, Labels: task.Labels}
+	if update := Reschedule(task, cal); update != nil {
+		t.Errorf("Expected weekday holiday to stay without skip-holidays, got %+v", update)
+	}
+	task.Labels = []types.Label{{Name: types.SkipHolidaysLabel}}
+	if update := Reschedule(task, cal); update == nil || update.ScheduledDate.Format("2006-01-02") != "2024-07-05" {
+		t.Errorf("Expected holiday moved to 2024-07-05, got %+v", update)
+	}
+
+	// Tasks not generated from a template are left alone
+	task = &types.Task{ScheduledDate: &saturday, Labels: []types.Label{{Name: types.SkipWeekendsLabel}}}
+	if update := Reschedule(task, cal); update != nil {
+		t.Errorf("Expected task without template to stay, got %+v", update)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	skip := holidays.Skip{Weekends: true}
+	var cal *holidays.Calendar
+	next := func(d time.Time) time.Time { return cal.Next(d, skip) }
+
+	monday := mustDate("2024-07-08")
+	existing := []*types.Task{{ScheduledDate: &monday}}
+
+	// Friday stays, Saturday and Sunday collapse onto Monday which already
+	// has a task, Tuesday stays
+	dates := []time.Time{
+		mustDate("2024-07-05"),
+		mustDate("2024-07-06"),
+		mustDate("2024-07-07"),
+		mustDate("2024-07-09"),
+	}
+	got := formatDates(Adjust(dates, existing, next))
+	want := []string{"2024-07-05", "2024-07-09"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Adjust() = %v, want %v", got, want)
+	}
+
+	// Without an existing Monday task, the weekend becomes one Monday task
+	got = formatDates(Adjust(dates[1:3], nil, next))
+	if len(got) != 1 || got[0] != "2024-07-08" {
+		t.Errorf("Adjust() = %v, want [2024-07-08]", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Package recurring contains client-side helpers for recurring task templates.
+package recurring
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/teambition/rrule-go"
+)
+
+// CatchupPolicy controls which missed occurrences of a template get tasks.
+type CatchupPolicy string
+
+const (
+	// CatchupAll creates a task for every missed occurrence.
+	CatchupAll CatchupPolicy = "all"
+
+	// CatchupLatest creates a task only for the most recent missed occurrence.
+	CatchupLatest CatchupPolicy = "latest"
+
+	// CatchupNone creates no tasks for missed occurrences.
+	CatchupNone CatchupPolicy = "none"
+)
+
+// ParseCatchupPolicy parses a policy name. An empty name returns CatchupLatest.
+func ParseCatchupPolicy(name string) (CatchupPolicy, error) {
+	switch policy := CatchupPolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case "":
+		return CatchupLatest, nil
+	case CatchupAll, CatchupLatest, CatchupNone:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid catch-up policy %q: must be 'all', 'latest', or 'none'", name)
+	}
+}
+
+// Apply selects the occurrences to create from the missed ones, which must be
+// in chronological order.
+func (p CatchupPolicy) Apply(missed []time.Time) []time.Time {
+	switch p {
+	case CatchupAll:
+		return missed
+	case CatchupLatest:
+		if len(missed) == 0 {
+			return nil
+		}
+		return missed[len(missed)-1:]
+	default:
+		return nil
+	}
+}
+
+// Occurrences returns the dates of a template's occurrences between since and
+// until, inclusive. Dates are returned as UTC midnights, matching how
+// scheduled dates are stored. Occurrences after the template's end date are
+// excluded.
+func Occurrences(tmpl *types.RecurringTaskTemplate, since, until time.Time) ([]time.Time, error) {
+	start := dateOnly(tmpl.StartDate)
+	rule, err := rrule.StrToRRule("DTSTART:" + start.Format("20060102T150405Z") + "\nRRULE:" + tmpl.RecurrenceRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	until = dateOnly(until)
+	if tmpl.EndDate != nil && dateOnly(*tmpl.EndDate).Before(until) {
+		until = dateOnly(*tmpl.EndDate)
+	}
+
+	var dates []time.Time
+	for _, occurrence := range rule.Between(dateOnly(since), until, true) {
+		dates = append(dates, dateOnly(occurrence))
+	}
+	return dates, nil
+}
+
+// Missed returns the occurrences between since and until that have no task
+// among existing. existing should be the tasks linked to the template.
+func Missed(tmpl *types.RecurringTaskTemplate, existing []*types.Task, since, until time.Time) ([]time.Time, error) {
+	occurrences, err := Occurrences(tmpl, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make(map[string]bool)
+	for _, task := range existing {
+		if task.ScheduledDate != nil {
+			scheduled[dateOnly(*task.ScheduledDate).Format("2006-01-02")] = true
+		}
+	}
+
+	var missed []time.Time
+	for _, date := range occurrences {
+		if !scheduled[date.Format("2006-01-02")] {
+			missed = append(missed, date)
+		}
+	}
+	return missed, nil
+}
+
+// LastScheduled returns the latest scheduled date among tasks, or nil if none
+// have one.
+func LastScheduled(tasks []*types.Task) *time.Time {
+	var latest *time.Time
+	for _, task := range tasks {
+		if task.ScheduledDate == nil {
+			continue
+		}
+		date := dateOnly(*task.ScheduledDate)
+		if latest == nil || date.After(*latest) {
+			latest = &date
+		}
+	}
+	return latest
+}
+
+// TaskCreate builds the request for a template's task on the given date.
+func TaskCreate(tmpl *types.RecurringTaskTemplate, date time.Time) *types.TaskCreate {
+	templateID := tmpl.ID
+	scheduled := dateOnly(date)
+
+	create := &types.TaskCreate{
+		Title:         tmpl.Title,
+		Description:   tmpl.Description,
+		ProjectID:     tmpl.ProjectID,
+		Status:        "active",
+		Priority:      tmpl.Priority,
+		TemplateID:    &templateID,
+		ScheduledDate: &scheduled,
+	}
+
+	for _, label := range tmpl.Labels {
+		create.Labels = append(create.Labels, label.Name)
+	}
+	for _, assignee := range tmpl.Assignees {
+		create.Assignees = append(create.Assignees, assignee.Name)
+	}
+
+	return create
+}
+
+// dateOnly truncates t to a UTC midnight, keeping its UTC calendar date.
+// Date-only fields are stored as UTC midnights.
+func dateOnly(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
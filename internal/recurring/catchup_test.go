@@ -0,0 +1,164 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func formatDates(dates []time.Time) []string {
+	result := make([]string, len(dates))
+	for i, d := range dates {
+		result[i] = d.Format("2006-01-02")
+	}
+	return result
+}
+
+func TestParseCatchupPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected CatchupPolicy
+		wantErr  bool
+	}{
+		{"", CatchupLatest, false},
+		{"all", CatchupAll, false},
+		{"Latest", CatchupLatest, false},
+		{" none ", CatchupNone, false},
+		{"some", "", true},
+	}
+
+	for _, tt := range tests {
+		policy, err := ParseCatchupPolicy(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCatchupPolicy(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if policy != tt.expected {
+			t.Errorf("ParseCatchupPolicy(%q) = %q, expected %q", tt.name, policy, tt.expected)
+		}
+	}
+}
+
+func TestCatchupPolicyApply(t *testing.T) {
+	missed := []time.Time{mustDate("2024-03-01"), mustDate("2024-03-02"), mustDate("2024-03-03")}
+
+	if got := CatchupAll.Apply(missed); len(got) != 3 {
+		t.Errorf("Expected all occurrences, got %v", formatDates(got))
+	}
+	if got := CatchupLatest.Apply(missed); len(got) != 1 || !got[0].Equal(missed[2]) {
+		t.Errorf("Expected only the latest occurrence, got %v", formatDates(got))
+	}
+	if got := CatchupNone.Apply(missed); len(got) != 0 {
+		t.Errorf("Expected no occurrences, got %v", formatDates(got))
+	}
+	if got := CatchupLatest.Apply(nil); got != nil {
+		t.Errorf("Expected nil for no missed occurrences, got %v", formatDates(got))
+	}
+}
+
+func TestMissed(t *testing.T) {
+	end := mustDate("2024-03-08")
+	tmpl := &types.RecurringTaskTemplate{
+		ID:             5,
+		RecurrenceRule: "FREQ=DAILY",
+		StartDate:      mustDate("2024-03-01"),
+		EndDate:        &end,
+	}
+
+	done := mustDate("2024-03-05")
+	existing := []*types.Task{{ScheduledDate: &done}, {}}
+
+	missed, err := Missed(tmpl, existing, mustDate("2024-03-04"), mustDate("2024-03-10"))
+	if err != nil {
+		t.Fatalf("Missed failed: %v", err)
+	}
+
+	expected := []string{"2024-03-04", "2024-03-06", "2024-03-07", "2024-03-08"}
+	got := formatDates(missed)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+}
+
+func TestOccurrencesWeekly(t *testing.T) {
+	tmpl := &types.RecurringTaskTemplate{
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO,FR",
+		StartDate:      mustDate("2024-03-01"),
+	}
+
+	occurrences, err := Occurrences(tmpl, mustDate("2024-03-01"), mustDate("2024-03-12"))
+	if err != nil {
+		t.Fatalf("Occurrences failed: %v", err)
+	}
+
+	got := formatDates(occurrences)
+	expected := []string{"2024-03-01", "2024-03-04", "2024-03-08", "2024-03-11"}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+}
+
+func TestOccurrencesInvalidRule(t *testing.T) {
+	tmpl := &types.RecurringTaskTemplate{RecurrenceRule: "NOT A RULE", StartDate: mustDate("2024-03-01")}
+	if _, err := Occurrences(tmpl, mustDate("2024-03-01"), mustDate("2024-03-02")); err == nil {
+		t.Error("Expected error for invalid rule")
+	}
+}
+
+func TestLastScheduled(t *testing.T) {
+	if LastScheduled(nil) != nil {
+		t.Error("Expected nil for no tasks")
+	}
+
+	a := mustDate("2024-03-01")
+	b := mustDate("2024-03-09")
+	latest := LastScheduled([]*types.Task{{ScheduledDate: &a}, {}, {ScheduledDate: &b}})
+	if latest == nil || !latest.Equal(b) {
+		t.Errorf("Expected %v, got %v", b, latest)
+	}
+}
+
+func TestTaskCreate(t *testing.T) {
+	priority := "high"
+	tmpl := &types.RecurringTaskTemplate{
+		ID:        5,
+		ProjectID: 2,
+		Title:     "Water plants",
+		Priority:  &priority,
+		Labels:    []types.Label{{Name: "home"}},
+	}
+
+	create := TaskCreate(tmpl, mustDate("2024-03-04"))
+	if create.TemplateID == nil || *create.TemplateID != 5 {
+		t.Error("Expected task linked to template")
+	}
+	if create.ScheduledDate == nil || create.ScheduledDate.Format("2006-01-02") != "2024-03-04" {
+		t.Errorf("Expected scheduled date, got %v", create.ScheduledDate)
+	}
+	if create.ProjectID != 2 || create.Title != "Water plants" || create.Status != "active" {
+		t.Errorf("Unexpected task fields: %+v", create)
+	}
+	if len(create.Labels) != 1 || create.Labels[0] != "home" {
+		t.Errorf("Expected template labels, got %v", create.Labels)
+	}
+}
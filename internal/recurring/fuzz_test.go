@@ -0,0 +1,181 @@
+package recurring
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// fuzzZones are timezones with the transitions that have broken date math:
+// DST changes at midnight (Havana, Sao Paulo), half-hour DST (Lord Howe),
+// a skipped day (Apia, 2011-12-30), and offsets far from UTC both ways.
+var fuzzZones = []string{
+	"UTC",
+	"America/New_York",
+	"Europe/London",
+	"America/Havana",
+	"America/Sao_Paulo",
+	"Australia/Lord_Howe",
+	"Pacific/Apia",
+	"Pacific/Kiritimati",
+	"Pacific/Pago_Pago",
+	"Asia/Kolkata",
+}
+
+var fuzzFreqs = []string{"DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+var fuzzDays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// fuzzEpoch is the earliest date fuzzed start dates and times are offset
+// from.
+var fuzzEpoch = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fuzzRule builds a date-based RRULE, the kind templates use, from fuzzed
+// parts.
+func fuzzRule(freq, interval, days uint8, monthDay int8) string {
+	parts := []string{"FREQ=" + fuzzFreqs[int(freq)%len(fuzzFreqs)]}
+	if n := int(interval)%4 + 1; n > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", n))
+	}
+	var byDay []string
+	for i, day := range fuzzDays {
+		if days&(1<<i) != 0 {
+			byDay = append(byDay, day)
+		}
+	}
+	if len(byDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(byDay, ","))
+	}
+	if md := int(monthDay) % 32; md != 0 && len(byDay) == 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", md))
+	}
+	return strings.Join(parts, ";")
+}
+
+// FuzzNext checks that the next occurrences computed in a template's
+// timezone fall on the same calendar dates as the occurrences computed in
+// UTC, which is how scheduled dates are stored. Disagreement between the
+// two is how habit exports ended up a day off.
+func FuzzNext(f *testing.F) {
+	day := int64(24 * time.Hour / time.Second)
+	f.Add(uint8(0), uint8(0), uint8(0), int8(0), uint16(0), int64(0), uint8(0), uint8(5))
+	// Daily across New York's spring forward
+	f.Add(uint8(0), uint8(0), uint8(0), int8(0), uint16(5000), 5181*day+6*3600, uint8(1), uint8(10))
+	// Weekdays across Havana's midnight DST change
+	f.Add(uint8(1), uint8(0), uint8(0x1f), int8(0), uint16(4000), 5180*day, uint8(3), uint8(10))
+	// Daily across the day Apia skipped
+	f.Add(uint8(0), uint8(0), uint8(0), int8(0), uint16(600), 727*day, uint8(6), uint8(5))
+	// The 31st of every other month, from a time late in the UTC day
+	f.Add(uint8(2), uint8(1), uint8(0), int8(31), uint16(100), 3000*day+23*3600, uint8(8), uint8(12))
+	// Yearly on the 29th, from a leap day
+	f.Add(uint8(3), uint8(0), uint8(0), int8(29), uint16(789), 800*day, uint8(9), uint8(4))
+
+	f.Fuzz(func(t *testing.T, freq, interval, days uint8, monthDay int8, startDay uint16, nowOffset int64, zone, count uint8) {
+		tmpl := &types.RecurringTaskTemplate{
+			RecurrenceRule: fuzzRule(freq, interval, days, monthDay),
+			StartDate:      fuzzEpoch.AddDate(0, 0, int(startDay)%8000),
+			Timezone:       fuzzZones[int(zone)%len(fuzzZones)],
+		}
+		if nowOffset < 0 {
+			nowOffset = -nowOffset
+		}
+		now := fuzzEpoch.Add(time.Duration(nowOffset%(25*365*day)) * time.Second)
+		n := int(count) % 16
+
+		next, err := Next(tmpl, now, n)
+		if err != nil {
+			t.Fatalf("Next(%q) failed: %v", tmpl.RecurrenceRule, err)
+		}
+		if len(next) > n {
+			t.Fatalf("Next returned %d occurrences, asked for %d", len(next), n)
+		}
+
+		loc, _ := time.LoadLocation(tmpl.Timezone)
+		today := localDate(now.In(loc))
+		since := today
+		if start := dateOnly(tmpl.StartDate); start.After(since) {
+			since = start
+		}
+		// Enough time for n occurrences of the sparsest rule
+		until := since.AddDate(5*(n+1), 0, 0)
+		want, err := Occurrences(tmpl, since, until)
+		if err != nil {
+			t.Fatalf("Occurrences(%q) failed: %v", tmpl.RecurrenceRule, err)
+		}
+		// A day the timezone skipped has no occurrence
+		var kept []time.Time
+		for _, date := range want {
+			noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc)
+			if localDate(noon).Equal(date) {
+				kept = append(kept, date)
+			}
+		}
+		want = kept
+		if len(want) > n {
+			want = want[:n]
+		}
+
+		if len(next) != len(want) {
+			t.Fatalf("%s in %s from %s: Next returned %d dates, Occurrences %d", tmpl.RecurrenceRule, tmpl.Timezone, now, len(next), len(want))
+		}
+		for i, occurrence := range next {
+			got := localDate(occurrence.In(loc))
+			if !got.Equal(want[i]) {
+				t.Fatalf("%s in %s from %s: occurrence %d is %s, in UTC %s", tmpl.RecurrenceRule, tmpl.Timezone, now, i, got.Format("2006-01-02"), want[i].Format("2006-01-02"))
+			}
+			if got.Before(today) {
+				t.Fatalf("occurrence %s is before today, %s", got.Format("2006-01-02"), today.Format("2006-01-02"))
+			}
+			if i > 0 && !got.After(localDate(next[i-1].In(loc))) {
+				t.Fatalf("occurrences %d and %d aren't on increasing dates: %v", i-1, i, next)
+			}
+		}
+	})
+}
+
+// FuzzOccurrences checks that occurrences are UTC midnights in order,
+// inside the requested range, and never after the end date.
+func FuzzOccurrences(f *testing.F) {
+	f.Add(uint8(0), uint8(0), uint8(0), int8(0), uint16(0), uint16(0), uint16(30), uint16(0))
+	f.Add(uint8(1), uint8(2), uint8(0x41), int8(0), uint16(10), uint16(5), uint16(90), uint16(40))
+	f.Add(uint8(2), uint8(0), uint8(0), int8(-1), uint16(59), uint16(0), uint16(400), uint16(0))
+
+	f.Fuzz(func(t *testing.T, freq, interval, days uint8, monthDay int8, startDay, sinceDay, span, endDay uint16) {
+		tmpl := &types.RecurringTaskTemplate{
+			RecurrenceRule: fuzzRule(freq, interval, days, monthDay),
+			StartDate:      fuzzEpoch.AddDate(0, 0, int(startDay)%4000),
+		}
+		if endDay != 0 {
+			end := tmpl.StartDate.AddDate(0, 0, int(endDay)%2000)
+			tmpl.EndDate = &end
+		}
+		// Times of day are ignored
+		since := fuzzEpoch.AddDate(0, 0, int(sinceDay)%5000).Add(17 * time.Hour)
+		until := since.AddDate(0, 0, int(span)%1500).Add(3 * time.Hour)
+
+		dates, err := Occurrences(tmpl, since, until)
+		if err != nil {
+			t.Fatalf("Occurrences(%q) failed: %v", tmpl.RecurrenceRule, err)
+		}
+		for i, date := range dates {
+			if date.Location() != time.UTC || date.Hour() != 0 || date.Minute() != 0 || date.Second() != 0 {
+				t.Fatalf("occurrence %s is not a UTC midnight", date)
+			}
+			if date.Before(dateOnly(since)) || date.After(dateOnly(until)) {
+				t.Fatalf("occurrence %s is outside %s to %s", date, since, until)
+			}
+			if date.Before(dateOnly(tmpl.StartDate)) {
+				t.Fatalf("occurrence %s is before the start date %s", date, tmpl.StartDate)
+			}
+			if tmpl.EndDate != nil && date.After(dateOnly(*tmpl.EndDate)) {
+				t.Fatalf("occurrence %s is after the end date %s", date, tmpl.EndDate)
+			}
+			if i > 0 && !date.After(dates[i-1]) {
+				t.Fatalf("occurrences aren't in order: %v", dates)
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package recurring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// History returns the last n occurrences among a template's tasks, newest
+// first. Occurrences are ordered by scheduled date, or creation date for
+// tasks without one. An n of 0 or less returns them all.
+func History(tasks []*types.Task, n int) []*types.Task {
+	history := append([]*types.Task(nil), tasks...)
+	sort.SliceStable(history, func(i, j int) bool {
+		return occurrenceDate(history[i]).After(occurrenceDate(history[j]))
+	})
+	if n > 0 && len(history) > n {
+		history = history[:n]
+	}
+	return history
+}
+
+// Completed returns how many of tasks are done.
+func Completed(tasks []*types.Task) int {
+	count := 0
+	for _, task := range tasks {
+		if task.Status == "done" {
+			count++
+		}
+	}
+	return count
+}
+
+// occurrenceDate returns the date a task was generated for.
+func occurrenceDate(task *types.Task) time.Time {
+	if task.ScheduledDate != nil {
+		return dateOnly(*task.ScheduledDate)
+	}
+	return task.CreatedAt
+}
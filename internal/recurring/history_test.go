@@ -0,0 +1,55 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestHistory(t *testing.T) {
+	scheduled := func(s string) *time.Time {
+		d := mustDate(s)
+		return &d
+	}
+	tasks := []*types.Task{
+		{ID: 1, Status: "done", ScheduledDate: scheduled("2024-07-01")},
+		{ID: 2, Status: "active", ScheduledDate: scheduled("2024-07-15")},
+		{ID: 3, Status: "done", CreatedAt: mustDate("2024-07-10")},
+		{ID: 4, Status: "canceled", ScheduledDate: scheduled("2024-07-08")},
+	}
+
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{0, []int{2, 3, 4, 1}},
+		{2, []int{2, 3}},
+		{10, []int{2, 3, 4, 1}},
+	}
+
+	for _, tt := range tests {
+		history := History(tasks, tt.n)
+		var got []int
+		for _, task := range history {
+			got = append(got, task.ID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("History(n=%d) = %v, want %v", tt.n, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("History(n=%d) = %v, want %v", tt.n, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if tasks[0].ID != 1 {
+		t.Error("History should not reorder its input")
+	}
+	if got := Completed(tasks); got != 2 {
+		t.Errorf("Completed() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,140 @@
+package recurring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/teambition/rrule-go"
+	"gopkg.in/yaml.v3"
+)
+
+// PackVersion is the version of the template pack format written by
+// MarshalPack.
+const PackVersion = 1
+
+// Pack is a shareable set of templates, such as a curated set of habits.
+// Packs leave out everything tied to one todu instance: IDs, projects,
+// assignees, and start dates.
+type Pack struct {
+	Version   int            `yaml:"version"`
+	Templates []PackTemplate `yaml:"templates"`
+}
+
+// PackTemplate is a template in a Pack.
+type PackTemplate struct {
+	Title       string   `yaml:"title"`
+	Type        string   `yaml:"type"`
+	Recurrence  string   `yaml:"recurrence"`
+	Description string   `yaml:"description,omitempty"`
+	Priority    string   `yaml:"priority,omitempty"`
+	Timezone    string   `yaml:"timezone,omitempty"` // empty uses the importer's default
+	Labels      []string `yaml:"labels,omitempty"`
+	Inactive    bool     `yaml:"inactive,omitempty"`
+}
+
+// NewPack returns a pack of templates.
+func NewPack(templates []*types.RecurringTaskTemplate) *Pack {
+	pack := &Pack{Version: PackVersion, Templates: []PackTemplate{}}
+	for _, tmpl := range templates {
+		entry := PackTemplate{
+			Title:      tmpl.Title,
+			Type:       tmpl.TemplateType,
+			Recurrence: tmpl.RecurrenceRule,
+			Timezone:   tmpl.Timezone,
+			Inactive:   !tmpl.IsActive,
+		}
+		if tmpl.Description != nil {
+			entry.Description = *tmpl.Description
+		}
+		if tmpl.Priority != nil {
+			entry.Priority = *tmpl.Priority
+		}
+		for _, label := range tmpl.Labels {
+			entry.Labels = append(entry.Labels, label.Name)
+		}
+		pack.Templates = append(pack.Templates, entry)
+	}
+	return pack
+}
+
+// MarshalPack encodes a pack as YAML.
+func MarshalPack(pack *Pack) ([]byte, error) {
+	data, err := yaml.Marshal(pack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template pack: %w", err)
+	}
+	return data, nil
+}
+
+// ParsePack decodes and validates a YAML template pack.
+func ParsePack(data []byte) (*Pack, error) {
+	var pack Pack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("failed to parse template pack: %w", err)
+	}
+	if pack.Version > PackVersion {
+		return nil, fmt.Errorf("template pack version %d is newer than this todu supports (%d)", pack.Version, PackVersion)
+	}
+
+	for i := range pack.Templates {
+		entry := &pack.Templates[i]
+		entry.Title = strings.TrimSpace(entry.Title)
+		if entry.Title == "" {
+			return nil, fmt.Errorf("template %d in pack has no title", i+1)
+		}
+		if entry.Type == "" {
+			entry.Type = "task"
+		}
+		switch entry.Type {
+		case "task", "habit", "journal":
+		default:
+			return nil, fmt.Errorf("template %q has invalid type %q (must be task, habit, or journal)", entry.Title, entry.Type)
+		}
+		if _, err := rrule.StrToROption(entry.Recurrence); err != nil || entry.Recurrence == "" {
+			return nil, fmt.Errorf("template %q has invalid recurrence %q", entry.Title, entry.Recurrence)
+		}
+	}
+	return &pack, nil
+}
+
+// TemplateCreate returns the request to create entry in a project,
+// starting on startDate (YYYY-MM-DD). timezone is used if the entry has
+// none.
+func (entry *PackTemplate) TemplateCreate(projectID int, startDate, timezone string) *types.RecurringTaskTemplateCreate {
+	create := &types.RecurringTaskTemplateCreate{
+		ProjectID:      projectID,
+		Title:          entry.Title,
+		RecurrenceRule: entry.Recurrence,
+		StartDate:      startDate,
+		Timezone:       timezone,
+		TemplateType:   entry.Type,
+		IsActive:       !entry.Inactive,
+		Labels:         entry.Labels,
+	}
+	if entry.Timezone != "" {
+		create.Timezone = entry.Timezone
+	}
+	if entry.Description != "" {
+		create.Description = &entry.Description
+	}
+	if entry.Priority != "" {
+		create.Priority = &entry.Priority
+	}
+	return create
+}
+
+// TemplateUpdate returns the request to replace an existing template's
+// fields with entry's. timezone is used if the entry has none.
+func (entry *PackTemplate) TemplateUpdate(timezone string) *types.RecurringTaskTemplateUpdate {
+	create := entry.TemplateCreate(0, "", timezone)
+	return &types.RecurringTaskTemplateUpdate{
+		Title:          &create.Title,
+		Description:    create.Description,
+		Priority:       create.Priority,
+		RecurrenceRule: &create.RecurrenceRule,
+		Timezone:       &create.Timezone,
+		IsActive:       &create.IsActive,
+		Labels:         create.Labels,
+	}
+}
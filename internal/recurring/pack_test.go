@@ -0,0 +1,64 @@
+package recurring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestPackRoundTrip(t *testing.T) {
+	description := "8 glasses"
+	templates := []*types.RecurringTaskTemplate{
+		{
+			ID:             7,
+			ProjectID:      3,
+			Title:          "Drink water",
+			Description:    &description,
+			RecurrenceRule: "FREQ=DAILY",
+			Timezone:       "Europe/London",
+			TemplateType:   "habit",
+			IsActive:       true,
+			Labels:         []types.Label{{Name: types.SkipWeekendsLabel}},
+			Assignees:      []types.Assignee{{Name: "jane"}},
+		},
+	}
+
+	data, err := MarshalPack(NewPack(templates))
+	if err != nil {
+		t.Fatalf("MarshalPack failed: %v", err)
+	}
+	if strings.Contains(string(data), "jane") || strings.Contains(string(data), "project") {
+		t.Errorf("Expected pack without instance-specific fields, got:\n%s", data)
+	}
+
+	pack, err := ParsePack(data)
+	if err != nil {
+		t.Fatalf("ParsePack failed: %v", err)
+	}
+	create := pack.Templates[0].TemplateCreate(5, "2025-01-01", "UTC")
+	if create.ProjectID != 5 || create.Title != "Drink water" || create.Timezone != "Europe/London" ||
+		create.TemplateType != "habit" || !create.IsActive || *create.Description != description ||
+		len(create.Labels) != 1 || create.Labels[0] != types.SkipWeekendsLabel {
+		t.Errorf("Unexpected template create: %+v", create)
+	}
+}
+
+func TestParsePackErrors(t *testing.T) {
+	tests := map[string]string{
+		"no title":     "templates:\n  - recurrence: FREQ=DAILY\n",
+		"bad type":     "templates:\n  - title: A\n    type: chore\n    recurrence: FREQ=DAILY\n",
+		"bad rrule":    "templates:\n  - title: A\n    recurrence: FREQ=SOMETIMES\n",
+		"newer format": "version: 99\ntemplates: []\n",
+	}
+	for name, data := range tests {
+		if _, err := ParsePack([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	pack, err := ParsePack([]byte("templates:\n  - title: Stretch\n    recurrence: FREQ=WEEKLY;BYDAY=MO\n"))
+	if err != nil || pack.Templates[0].Type != "task" {
+		t.Errorf("Expected type to default to task, got %+v (err %v)", pack, err)
+	}
+}
@@ -0,0 +1,75 @@
+package recurring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// freezeWindow is the period a streak freeze allowance covers
+const freezeWindow = 7 * 24 * time.Hour
+
+// Streak is a habit's run of completed occurrences.
+type Streak struct {
+	Current int // completed occurrences in the current streak
+	Longest int // completed occurrences in the longest streak
+	Frozen  int // missed occurrences the current streak survived
+	Skipped int // occurrences skipped for a reason in the current streak
+}
+
+// Streaks works out a habit's streaks from its tasks, as of today. freeze
+// is how many missed occurrences in any 7 days a streak survives without
+// being reset; missed occurrences it survives don't count toward it.
+// An occurrence only counts once it's done, and with a completion window
+// only if it was done within it; until its window closes, or the day is
+// over without one, it isn't missed yet. Skipped occurrences are excused
+// misses and, like canceled ones, neither count nor break a streak.
+func Streaks(tasks []*types.Task, today time.Time, freeze int, window Window) Streak {
+	// Occurrence dates are UTC midnights; today is a local date
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	occurrences := append([]*types.Task(nil), tasks...)
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrenceDate(occurrences[i]).Before(occurrenceDate(occurrences[j]))
+	})
+
+	var streak Streak
+	var frozen []time.Time // missed occurrences the current streak survived
+	for _, task := range occurrences {
+		date := dateOnly(occurrenceDate(task))
+		if date.After(today) {
+			continue
+		}
+		if _, skipped := task.Skipped(); skipped {
+			streak.Skipped++
+			continue
+		}
+		if task.Status == "canceled" {
+			continue
+		}
+		if window.OnTime(task) {
+			streak.Current++
+			streak.Longest = max(streak.Longest, streak.Current)
+			continue
+		}
+		if task.Status != "done" && window.Open(date, today) {
+			continue // still time to do it
+		}
+
+		recent := 0
+		for _, f := range frozen {
+			if date.Sub(f) < freezeWindow {
+				recent++
+			}
+		}
+		if recent < freeze {
+			frozen = append(frozen, date)
+			continue
+		}
+		streak.Current = 0
+		streak.Skipped = 0
+		frozen = nil
+	}
+	streak.Frozen = len(frozen)
+	return streak
+}
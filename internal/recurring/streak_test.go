@@ -0,0 +1,65 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// habitDays returns daily habit tasks from start, one per character of
+// days: d done, m missed, c canceled, s skipped, l done three days late
+func habitDays(start string, days string) []*types.Task {
+	var tasks []*types.Task
+	date := mustDate(start)
+	for i, c := range days {
+		scheduled := date.AddDate(0, 0, i)
+		status := map[rune]string{'d': "done", 'm': "active", 'c': "canceled", 's': "canceled", 'l': "done"}[c]
+		task := &types.Task{ID: i + 1, Status: status, ScheduledDate: &scheduled, UpdatedAt: scheduled.Add(12 * time.Hour)}
+		if c == 'l' {
+			task.UpdatedAt = task.UpdatedAt.AddDate(0, 0, 3)
+		}
+		if c == 's' {
+			task.Labels = []types.Label{{Name: types.SkippedLabelFor("injured")}}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func TestStreaks(t *testing.T) {
+	today := time.Date(2024, 7, 10, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		days   string // 2024-07-01 through 2024-07-10
+		freeze int
+		window Window
+		want   Streak
+	}{
+		{"all done", "dddddddddd", 0, NoWindow, Streak{Current: 10, Longest: 10}},
+		{"today still open", "dddddddddm", 0, NoWindow, Streak{Current: 9, Longest: 9}},
+		{"missed day resets", "dddddmdddd", 0, NoWindow, Streak{Current: 4, Longest: 5}},
+		{"freeze survives a miss", "dddddmdddd", 1, NoWindow, Streak{Current: 9, Longest: 9, Frozen: 1}},
+		{"two misses in a week exceed one freeze", "dddmdmdddd", 1, NoWindow, Streak{Current: 4, Longest: 4}},
+		{"misses a week apart each use the freeze", "dmddddddmd", 1, NoWindow, Streak{Current: 8, Longest: 8, Frozen: 2}},
+		{"canceled is neutral", "dddddcdddd", 0, NoWindow, Streak{Current: 9, Longest: 9}},
+		{"skipped is excused", "ddsddsdddd", 0, NoWindow, Streak{Current: 8, Longest: 8, Skipped: 2}},
+		{"skips before a reset aren't counted", "dsdmdddddd", 0, NoWindow, Streak{Current: 6, Longest: 6}},
+		{"skipped today", "ddddddddds", 0, NoWindow, Streak{Current: 9, Longest: 9, Skipped: 1}},
+		{"late counts without a window", "ddddldddd", 0, NoWindow, Streak{Current: 9, Longest: 9}},
+		{"late is a miss outside the window", "dddddlddd", 0, 2, Streak{Current: 3, Longest: 5}},
+		{"late within the window", "dddddlddd", 0, 3, Streak{Current: 9, Longest: 9}},
+		{"freeze survives a late one", "dddddlddd", 1, 2, Streak{Current: 8, Longest: 8, Frozen: 1}},
+		{"open within the window", "dddddddmmd", 0, 2, Streak{Current: 8, Longest: 8}},
+		{"open past the window", "dddddddmmd", 0, 1, Streak{Current: 1, Longest: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Streaks(habitDays("2024-07-01", tt.days), today, tt.freeze, tt.window)
+			if got != tt.want {
+				t.Errorf("Streaks(%s, freeze=%d, window=%d) = %+v, want %+v", tt.days, tt.freeze, tt.window, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package recurring
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/holidays"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/teambition/rrule-go"
+)
+
+// Results of checking a date in a Verification.
+const (
+	// VerifyOK means the date is an occurrence with exactly one task.
+	VerifyOK = "ok"
+
+	// VerifyMissing means the date is an occurrence without a task.
+	VerifyMissing = "missing"
+
+	// VerifyUnexpected means the date has a task but is not an occurrence.
+	VerifyUnexpected = "unexpected"
+
+	// VerifyShifted means the date has a task but the occurrence it belongs
+	// to, a day earlier or later, does not. This is typically a timezone
+	// disagreement.
+	VerifyShifted = "shifted"
+
+	// VerifyDuplicate means the date is an occurrence with several tasks.
+	VerifyDuplicate = "duplicate"
+)
+
+// DateCheck compares one date between the locally computed occurrences and
+// the server's scheduled tasks.
+type DateCheck struct {
+	Date       time.Time  `json:"date"`
+	Occurrence bool       `json:"occurrence"`
+	TaskIDs    []int      `json:"task_ids,omitempty"`
+	Result     string     `json:"result"`
+	Expected   *time.Time `json:"expected,omitempty"` // the occurrence a shifted task belongs to
+}
+
+// Verification compares a template's locally computed occurrences with the
+// scheduled dates of the tasks the server generated for it.
+type Verification struct {
+	TemplateID int    `json:"template_id"`
+	Timezone   string `json:"timezone"`
+
+	// From and To bound the checked dates: the first and last scheduled
+	// task, or today when there are none
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Dates holds every occurrence and every scheduled task date in range,
+	// in order
+	Dates []DateCheck `json:"dates"`
+
+	// Next is the next occurrence on or after today in the template's
+	// timezone, and NextTaskID its task, if the server generated one
+	Next       *time.Time `json:"next,omitempty"`
+	NextTaskID int        `json:"next_task_id,omitempty"`
+
+	// Unscheduled counts tasks without a scheduled date, which can't be
+	// checked
+	Unscheduled int `json:"unscheduled"`
+}
+
+// MismatchCount returns how many dates disagree.
+func (v *Verification) MismatchCount() int {
+	count := 0
+	for _, d := range v.Dates {
+		if d.Result != VerifyOK {
+			count++
+		}
+	}
+	return count
+}
+
+// Next returns the next count occurrences of a template on or after now's
+// date in the template's timezone, as the start of each day in that
+// timezone: midnight, or later on days that begin with a DST change.
+// Occurrences after the template's end date are excluded, as are dates the
+// timezone skipped, such as 2011-12-30 in Samoa.
+func Next(tmpl *types.RecurringTaskTemplate, now time.Time, count int) ([]time.Time, error) {
+	rule, loc, err := zoneRule(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	today := localDate(now.In(loc))
+	var dates []time.Time
+	for t := rule.After(today, true); !t.IsZero() && len(dates) < count; t = rule.After(t, false) {
+		if tmpl.EndDate != nil && t.After(dateOnly(*tmpl.EndDate)) {
+			break
+		}
+		if start, ok := dayStart(t, loc); ok {
+			dates = append(dates, start)
+		}
+	}
+	return dates, nil
+}
+
+// Verify checks the tasks generated for a template against its occurrences
+// computed in the template's timezone. tasks should be the tasks linked to
+// the template. Occurrences of skip-weekends and skip-holidays templates
+// are expected on the next day cal allows.
+func Verify(tmpl *types.RecurringTaskTemplate, tasks []*types.Task, now time.Time, cal *holidays.Calendar) (*Verification, error) {
+	rule, loc, err := zoneRule(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Verification{TemplateID: tmpl.ID, Timezone: loc.String()}
+	skip := TemplateSkip(tmpl)
+	expected := func(date time.Time) time.Time {
+		if skip.Any() {
+			return cal.Next(date, skip)
+		}
+		return date
+	}
+
+	scheduled := make(map[string][]int)
+	for _, task := range tasks {
+		if task.ScheduledDate == nil {
+			v.Unscheduled++
+			continue
+		}
+		key := dateOnly(*task.ScheduledDate).Format("2006-01-02")
+		scheduled[key] = append(scheduled[key], task.ID)
+	}
+
+	today := localDate(now.In(loc))
+	v.From, v.To = today, today
+	if first := firstScheduled(tasks); first != nil {
+		v.From = *first
+	}
+	if last := LastScheduled(tasks); last != nil {
+		v.To = *last
+	}
+
+	occurrences := make(map[string]bool)
+	for _, date := range rule.Between(v.From, v.To, true) {
+		if tmpl.EndDate != nil && date.After(dateOnly(*tmpl.EndDate)) {
+			break
+		}
+		occurrences[expected(date).Format("2006-01-02")] = true
+	}
+
+	keys := make(map[string]bool)
+	for key := range occurrences {
+		keys[key] = true
+	}
+	for key := range scheduled {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	checks := make(map[string]*DateCheck)
+	for _, key := range sorted {
+		date, _ := time.Parse("2006-01-02", key)
+		check := &DateCheck{Date: date, Occurrence: occurrences[key], TaskIDs: scheduled[key]}
+		switch {
+		case check.Occurrence && len(check.TaskIDs) == 0:
+			check.Result = VerifyMissing
+		case check.Occurrence && len(check.TaskIDs) > 1:
+			check.Result = VerifyDuplicate
+		case check.Occurrence:
+			check.Result = VerifyOK
+		default:
+			check.Result = VerifyUnexpected
+		}
+		checks[key] = check
+	}
+
+	// A task a day off a missing occurrence belongs to it
+	for _, key := range sorted {
+		check := checks[key]
+		if check.Result != VerifyUnexpected {
+			continue
+		}
+		for _, offset := range []int{-1, 1} {
+			neighbor := checks[check.Date.AddDate(0, 0, offset).Format("2006-01-02")]
+			if neighbor != nil && neighbor.Result == VerifyMissing {
+				expected := neighbor.Date
+				check.Result = VerifyShifted
+				check.Expected = &expected
+				neighbor.Result = ""
+				break
+			}
+		}
+	}
+
+	for _, key := range sorted {
+		if checks[key].Result != "" {
+			v.Dates = append(v.Dates, *checks[key])
+		}
+	}
+
+	next, err := Next(tmpl, now, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(next) > 0 {
+		date := expected(localDate(next[0]))
+		v.Next = &date
+		if ids := scheduled[date.Format("2006-01-02")]; len(ids) > 0 {
+			v.NextTaskID = ids[0]
+		}
+	}
+
+	return v, nil
+}
+
+// zoneRule parses a template's recurrence rule and returns the timezone its
+// dates are in. An unknown timezone falls back to UTC. The rule runs on UTC
+// midnights from the start date: occurrences are calendar dates, and
+// computing them in a timezone whose days don't all start at midnight, or
+// that skipped a day, moves them to the wrong date.
+func zoneRule(tmpl *types.RecurringTaskTemplate) (*rrule.RRule, *time.Location, error) {
+	loc, err := time.LoadLocation(tmpl.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	option, err := rrule.StrToROption(tmpl.RecurrenceRule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	option.Dtstart = dateOnly(tmpl.StartDate)
+
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	return rule, loc, nil
+}
+
+// dayStart returns the first whole hour of date, a UTC midnight, in loc,
+// or false if loc skipped the date.
+func dayStart(date time.Time, loc *time.Location) (time.Time, bool) {
+	for hour := 0; hour <= 12; hour++ {
+		start := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, loc)
+		// Times that don't exist are normalized to another hour
+		if start.Hour() == hour && localDate(start).Equal(date) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// localDate returns t's date in its own location as a UTC midnight, the
+// way scheduled dates are stored.
+func localDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// firstScheduled returns the earliest scheduled date among tasks, or nil if
+// none have one.
+func firstScheduled(tasks []*types.Task) *time.Time {
+	var earliest *time.Time
+	for _, task := range tasks {
+		if task.ScheduledDate == nil {
+			continue
+		}
+		date := dateOnly(*task.ScheduledDate)
+		if earliest == nil || date.Before(*earliest) {
+			earliest = &date
+		}
+	}
+	return earliest
+}
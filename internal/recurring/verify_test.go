@@ -0,0 +1,147 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestNextUsesTemplateTimezone(t *testing.T) {
+	tests := []struct {
+		timezone string
+		now      time.Time
+		want     string
+	}{
+		// 21:00 on the 9th in Los Angeles is already the 10th in UTC
+		{"America/Los_Angeles", time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC), "2024-03-09"},
+		{"UTC", time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC), "2024-03-10"},
+		// 05:00 on the 10th in Tokyo is still the 9th in UTC
+		{"Asia/Tokyo", time.Date(2024, 3, 9, 20, 0, 0, 0, time.UTC), "2024-03-10"},
+		{"Not/AZone", time.Date(2024, 3, 9, 20, 0, 0, 0, time.UTC), "2024-03-09"},
+	}
+
+	for _, tt := range tests {
+		tmpl := &types.RecurringTaskTemplate{
+			RecurrenceRule: "FREQ=DAILY",
+			StartDate:      mustDate("2024-01-01"),
+			Timezone:       tt.timezone,
+		}
+		next, err := Next(tmpl, tt.now, 1)
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if len(next) != 1 || localDate(next[0]).Format("2006-01-02") != tt.want {
+			t.Errorf("Next in %s = %v, want %s", tt.timezone, next, tt.want)
+		}
+	}
+}
+
+func TestNextAcrossDSTChange(t *testing.T) {
+	end := mustDate("2024-03-11")
+	tmpl := &types.RecurringTaskTemplate{
+		RecurrenceRule: "FREQ=DAILY",
+		StartDate:      mustDate("2024-03-01"),
+		EndDate:        &end,
+		Timezone:       "America/New_York",
+	}
+
+	next, err := Next(tmpl, time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), 5)
+	if err != nil {
+		t.Fatalf("Next failed: %v", err)
+	}
+
+	// Clocks go forward on the 10th; every occurrence stays at midnight and
+	// the end date stops the list
+	expected := []string{"2024-03-09", "2024-03-10", "2024-03-11"}
+	if len(next) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, next)
+	}
+	for i, occurrence := range next {
+		if occurrence.Hour() != 0 || localDate(occurrence).Format("2006-01-02") != expected[i] {
+			t.Errorf("Occurrence %d = %v, want midnight on %s", i, occurrence, expected[i])
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tmpl := &types.RecurringTaskTemplate{
+		ID:             3,
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO",
+		StartDate:      mustDate("2024-03-04"),
+		Timezone:       "Europe/London",
+	}
+	task := func(id int, date string) *types.Task {
+		d := mustDate(date)
+		return &types.Task{ID: id, ScheduledDate: &d}
+	}
+	tasks := []*types.Task{
+		task(1, "2024-03-04"),
+		task(2, "2024-03-12"), // a day after the 11th
+		task(3, "2024-03-18"),
+		task(4, "2024-03-18"),
+		task(5, "2024-03-20"), // a Wednesday
+		task(6, "2024-04-01"),
+		{ID: 7},
+	}
+
+	v, err := Verify(tmpl, tasks, time.Date(2024, 3, 28, 12, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+
+	expected := []struct {
+		date   string
+		result string
+	}{
+		{"2024-03-04", VerifyOK},
+		{"2024-03-12", VerifyShifted},
+		{"2024-03-18", VerifyDuplicate},
+		{"2024-03-20", VerifyUnexpected},
+		{"2024-03-25", VerifyMissing},
+		{"2024-04-01", VerifyOK},
+	}
+	if len(v.Dates) != len(expected) {
+		t.Fatalf("Expected %d dates, got %+v", len(expected), v.Dates)
+	}
+	for i, want := range expected {
+		got := v.Dates[i]
+		if got.Date.Format("2006-01-02") != want.date || got.Result != want.result {
+			t.Errorf("Dates[%d] = %s %s, want %s %s", i, got.Date.Format("2006-01-02"), got.Result, want.date, want.result)
+		}
+	}
+	if v.Dates[1].Expected == nil || v.Dates[1].Expected.Format("2006-01-02") != "2024-03-11" {
+		t.Errorf("Expected the shifted task to belong to 2024-03-11, got %v", v.Dates[1].Expected)
+	}
+
+	if v.MismatchCount() != 4 {
+		t.Errorf("MismatchCount() = %d, want 4", v.MismatchCount())
+	}
+	if v.Next == nil || v.Next.Format("2006-01-02") != "2024-04-01" || v.NextTaskID != 6 {
+		t.Errorf("Next = %v (task #%d), want 2024-04-01 (task #6)", v.Next, v.NextTaskID)
+	}
+	if v.Unscheduled != 1 {
+		t.Errorf("Unscheduled = %d, want 1", v.Unscheduled)
+	}
+}
+
+func TestVerifySkipWeekends(t *testing.T) {
+	tmpl := &types.RecurringTaskTemplate{
+		RecurrenceRule: "FREQ=MONTHLY;BYMONTHDAY=15",
+		StartDate:      mustDate("2024-05-15"),
+		Labels:         []types.Label{{Name: types.SkipWeekendsLabel}},
+	}
+	may, june := mustDate("2024-05-15"), mustDate("2024-06-17") // June 15 was a Saturday
+	tasks := []*types.Task{{ID: 1, ScheduledDate: &may}, {ID: 2, ScheduledDate: &june}}
+
+	v, err := Verify(tmpl, tasks, mustDate("2024-06-01"), nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if v.MismatchCount() != 0 {
+		t.Errorf("Expected no mismatches, got %+v", v.Dates)
+	}
+	if v.Next == nil || v.Next.Format("2006-01-02") != "2024-06-17" || v.NextTaskID != 2 {
+		t.Errorf("Next = %v (task #%d), want 2024-06-17 (task #2)", v.Next, v.NextTaskID)
+	}
+}
@@ -0,0 +1,47 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Window is a template's completion window: how many days after its
+// scheduled date an occurrence can be done and still count as on time.
+type Window int
+
+// NoWindow lets an occurrence be done any time. Until it's done, it is
+// only still due on its own day.
+const NoWindow Window = -1
+
+// TemplateWindow returns a template's completion window from its grace
+// label, or NoWindow if it has none.
+func TemplateWindow(tmpl *types.RecurringTaskTemplate) Window {
+	if days, ok := tmpl.Grace(); ok {
+		return Window(days)
+	}
+	return NoWindow
+}
+
+// Due returns the last day an occurrence on date can be done on time.
+func (w Window) Due(date time.Time) time.Time {
+	return dateOnly(date).AddDate(0, 0, max(int(w), 0))
+}
+
+// Open reports whether an occurrence on date that isn't done yet can still
+// be done on time as of today.
+func (w Window) Open(date, today time.Time) bool {
+	return !localDate(today).After(w.Due(date))
+}
+
+// OnTime reports whether a done occurrence was done within the window.
+// Tasks are taken to be done when they were last updated.
+func (w Window) OnTime(task *types.Task) bool {
+	if task.Status != "done" {
+		return false
+	}
+	if w == NoWindow {
+		return true
+	}
+	return !localDate(task.UpdatedAt.Local()).After(w.Due(occurrenceDate(task)))
+}
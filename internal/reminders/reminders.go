@@ -0,0 +1,196 @@
+// Package reminders works out when task reminders are due, remembers which
+// were already sent, and sends them as desktop notifications.
+package reminders
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// MaxLate is how late a reminder may still be sent, for when notify or the
+// daemon wasn't running at the time. Later reminders are skipped.
+const MaxLate = 24 * time.Hour
+
+// keepNotified is how long sent reminders are remembered
+const keepNotified = 30 * 24 * time.Hour
+
+// Reminder is a task reminder, Offset before the task is due.
+type Reminder struct {
+	TaskID int
+	Title  string
+	Offset time.Duration
+	Due    time.Time
+	At     time.Time // when the reminder is due to be sent
+}
+
+// key identifies a reminder in the state file. It includes the due time so
+// that moving the due date sends the reminder again.
+func (r Reminder) key() string {
+	return fmt.Sprintf("%d/%s/%s", r.TaskID, types.FormatReminderOffset(r.Offset), r.Due.UTC().Format(time.RFC3339))
+}
+
+// Message says when the task is due, relative to now.
+func (r Reminder) Message(now time.Time) string {
+	when := r.Due.Format("Mon Jan 2 15:04")
+	if left := r.Due.Sub(now); left > 0 {
+		return fmt.Sprintf("due in %s (%s)", formatLeft(left), when)
+	}
+	return fmt.Sprintf("due now (%s)", when)
+}
+
+// formatLeft rounds the time left to minutes, hours, or days.
+func formatLeft(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return strconv.Itoa(max(1, int(d.Round(time.Minute).Minutes()))) + "m"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d.Round(time.Hour).Hours())) + "h"
+	default:
+		return strconv.Itoa(int(d.Round(24*time.Hour).Hours()/24)) + "d"
+	}
+}
+
+// DueTime returns when a task is due. Due dates without a time of day
+// (midnight UTC) are due at the start of that day in loc.
+func DueTime(task *types.Task, loc *time.Location) (time.Time, bool) {
+	if task.DueDate == nil {
+		return time.Time{}, false
+	}
+	due := task.DueDate.UTC()
+	if due.Hour() == 0 && due.Minute() == 0 && due.Second() == 0 {
+		return time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc), true
+	}
+	return due, true
+}
+
+// ForTask returns the task's reminders, earliest first. Tasks without a due
+// date have none.
+func ForTask(task *types.Task, loc *time.Location) []Reminder {
+	due, ok := DueTime(task, loc)
+	if !ok {
+		return nil
+	}
+	var result []Reminder
+	for _, offset := range task.ReminderOffsets() {
+		result = append(result, Reminder{
+			TaskID: task.ID,
+			Title:  task.Title,
+			Offset: offset,
+			Due:    due,
+			At:     due.Add(-offset),
+		})
+	}
+	return result
+}
+
+// Pending returns the reminders of open tasks that are due to be sent at
+// now and weren't sent yet, earliest first. Reminders more than MaxLate
+// late are left out.
+func Pending(tasks []*types.Task, now time.Time, state *State) []Reminder {
+	var result []Reminder
+	for _, task := range tasks {
+		if task.Status == "done" || task.Status == "canceled" {
+			continue
+		}
+		for _, r := range ForTask(task, now.Location()) {
+			if r.At.After(now) || now.Sub(r.At) > MaxLate {
+				continue
+			}
+			if _, sent := state.Notified[r.key()]; sent {
+				continue
+			}
+			result = append(result, r)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b Reminder) int { return a.At.Compare(b.At) })
+	return result
+}
+
+// State records when reminders were sent, so each is only sent once.
+type State struct {
+	Notified map[string]time.Time `json:"notified"`
+}
+
+// MarkNotified records that reminders were sent at now, and forgets
+// reminders sent long ago.
+func (s *State) MarkNotified(reminders []Reminder, now time.Time) {
+	for _, r := range reminders {
+		s.Notified[r.key()] = now
+	}
+	for key, sent := range s.Notified {
+		if now.Sub(sent) > keepNotified {
+			delete(s.Notified, key)
+		}
+	}
+}
+
+// DefaultStatePath returns the default state file
+// (~/.config/todu/reminders.json).
+func DefaultStatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "reminders.json"), nil
+}
+
+// LoadState reads the reminder state from path. A missing file means no
+// reminders were sent yet.
+func LoadState(path string) (*State, error) {
+	state := &State{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read reminder state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("failed to parse reminder state %s: %w", path, err)
+		}
+	}
+	if state.Notified == nil {
+		state.Notified = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the reminder state to path.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write reminder state: %w", err)
+	}
+	return nil
+}
+
+// SendDesktop shows a desktop notification (notify-send on Linux,
+// osascript on macOS).
+func SendDesktop(title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		cmd = exec.Command("notify-send", "todu: "+title, message)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	return cmd.Run()
+}
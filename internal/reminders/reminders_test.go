@@ -0,0 +1,89 @@
+package reminders
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestDueTime(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+
+	dateOnly := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	due, ok := DueTime(&types.Task{DueDate: &dateOnly}, loc)
+	if !ok || !due.Equal(time.Date(2024, 6, 10, 0, 0, 0, 0, loc)) {
+		t.Errorf("Expected a date-only due date at local midnight, got %v", due)
+	}
+
+	withTime := time.Date(2024, 6, 10, 15, 30, 0, 0, time.UTC)
+	if due, _ := DueTime(&types.Task{DueDate: &withTime}, loc); !due.Equal(withTime) {
+		t.Errorf("Expected the due time kept, got %v", due)
+	}
+
+	if _, ok := DueTime(&types.Task{}, loc); ok {
+		t.Error("Expected no due time without a due date")
+	}
+}
+
+func TestPending(t *testing.T) {
+	due := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	labels := []types.Label{{Name: "remind:1d"}, {Name: "remind:1h"}, {Name: "remind:3d"}}
+	tasks := []*types.Task{
+		{ID: 1, Title: "Ship it", Status: "active", DueDate: &due, Labels: labels},
+		{ID: 2, Title: "Done already", Status: "done", DueDate: &due, Labels: labels},
+		{ID: 3, Title: "No due date", Status: "active", Labels: labels},
+	}
+	state := &State{Notified: make(map[string]time.Time)}
+
+	// 3d is more than a day late and 1h isn't due yet
+	now := due.Add(-20 * time.Hour)
+	pending := Pending(tasks, now, state)
+	if len(pending) != 1 || pending[0].TaskID != 1 || pending[0].Offset != 24*time.Hour {
+		t.Fatalf("Expected only task 1's 1d reminder, got %+v", pending)
+	}
+	if got := pending[0].Message(now); got != "due in 20h (Mon Jun 10 12:00)" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	state.MarkNotified(pending, now)
+	later := due.Add(-30 * time.Minute)
+	pending = Pending(tasks, later, state)
+	if len(pending) != 1 || pending[0].Offset != time.Hour {
+		t.Fatalf("Expected only the 1h reminder after the 1d one was sent, got %+v", pending)
+	}
+
+	// Moving the due date sends the reminders again
+	moved := due.Add(24 * time.Hour)
+	tasks[0].DueDate = &moved
+	if pending := Pending(tasks, moved.Add(-23*time.Hour), state); len(pending) != 1 || pending[0].Offset != 24*time.Hour {
+		t.Errorf("Expected the 1d reminder again for the new due date, got %+v", pending)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminders.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	state.Notified["old"] = now.Add(-31 * 24 * time.Hour)
+	state.MarkNotified([]Reminder{{TaskID: 1, Offset: time.Hour, Due: now}}, now)
+	if _, ok := state.Notified["old"]; ok {
+		t.Error("Expected reminders sent long ago to be forgotten")
+	}
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if len(loaded.Notified) != 1 {
+		t.Errorf("Expected one sent reminder, got %v", loaded.Notified)
+	}
+}
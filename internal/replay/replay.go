@@ -0,0 +1,334 @@
+// Package replay records the HTTP traffic of a command to a session file and
+// answers requests from one instead of the network.
+//
+// A Recorder wraps a transport and keeps every request and the response it
+// got. A Player answers each request with the next recorded response for the
+// same method and URL, so a recorded session can be replayed offline, for
+// demos, bug reports, and deterministic tests.
+//
+// Request headers, which usually carry credentials, are not recorded. The
+// values of query parameters and response headers whose names mark them as
+// credentials, such as access_token or X-Api-Key, are recorded as REDACTED,
+// and a Player matches requests on their redacted URL. Request and response
+// bodies are recorded as they are, so a session can still hold private data
+// and secrets a server sends back.
+package replay
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the session file format version.
+const Version = 1
+
+// Session is a recording of a command's HTTP traffic.
+type Session struct {
+	Version      int            `json:"version"`
+	RecordedAt   time.Time      `json:"recorded_at"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response it got.
+type Interaction struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	RequestBody *Body  `json:"request_body,omitempty"`
+
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header,omitempty"`
+	Body    *Body       `json:"body,omitempty"`
+	Elapsed int64       `json:"elapsed_ms"`
+}
+
+// Body is a request or response body: text as it is, anything else base64
+// encoded.
+type Body struct {
+	Text   string `json:"text,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+}
+
+// newBody returns the Body for data, or nil if it is empty.
+func newBody(data []byte) *Body {
+	if len(data) == 0 {
+		return nil
+	}
+	if utf8.Valid(data) {
+		return &Body{Text: string(data)}
+	}
+	return &Body{Base64: base64.StdEncoding.EncodeToString(data)}
+}
+
+// Bytes returns the body's content.
+func (b *Body) Bytes() ([]byte, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if b.Base64 != "" {
+		return base64.StdEncoding.DecodeString(b.Base64)
+	}
+	return []byte(b.Text), nil
+}
+
+// key identifies the requests an interaction answers. Sessions recorded
+// before redaction match on their redacted URL too.
+func (i *Interaction) key() string {
+	return requestKey(i.Method, i.URL)
+}
+
+// requestKey identifies a request by its method and redacted URL.
+func requestKey(method, rawURL string) string {
+	return method + " " + redactURL(rawURL)
+}
+
+// redacted replaces the values of credentials in a recording.
+const redacted = "REDACTED"
+
+// credentialSuffixes end the names of query parameters and headers that
+// carry credentials, compared in lowercase.
+var credentialSuffixes = []string{"token", "key", "secret", "password", "passwd", "signature", "authorization", "cookie"}
+
+// redactedParams are more query parameters that carry credentials, by
+// lowercase name, added by RedactQueryParam.
+var redactedParams sync.Map
+
+// RedactQueryParam marks a query parameter as carrying a credential, for
+// plugins that send their token in one whose name doesn't say so.
+func RedactQueryParam(name string) {
+	if name != "" {
+		redactedParams.Store(strings.ToLower(name), true)
+	}
+}
+
+// isCredential reports whether a query parameter or header name marks its
+// value as a credential.
+func isCredential(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := redactedParams.Load(name); ok {
+		return true
+	}
+	for _, suffix := range credentialSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return name == "auth" || name == "sig"
+}
+
+// redactURL returns rawURL with the values of credential query parameters
+// redacted. The rest of the query is left as it is, in its order.
+func redactURL(rawURL string) string {
+	base, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if isCredential(name) {
+			params[i] = url.QueryEscape(name) + "=" + redacted
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+// redactHeader returns a copy of a response header without cookies and
+// with the values of credential headers redacted.
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	header.Del("Set-Cookie")
+	for name, values := range header {
+		if isCredential(name) {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+	return header
+}
+
+// Load reads a session file.
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	if session.Version != Version {
+		return nil, fmt.Errorf("session %s has unsupported version %d", path, session.Version)
+	}
+	return &session, nil
+}
+
+// Save writes the session to a file, readable only by the user since
+// responses can hold private data.
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that records requests and responses.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	session *Session
+}
+
+// NewRecorder returns a Recorder with an empty session.
+func NewRecorder() *Recorder {
+	return &Recorder{session: &Session{Version: Version, RecordedAt: time.Now().UTC()}}
+}
+
+// Wrap returns a transport that records through r and sends requests with
+// base, or http.DefaultTransport if base is nil.
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, base: base}
+}
+
+// Session returns a copy of what has been recorded so far.
+func (r *Recorder) Session() *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := *r.session
+	session.Interactions = append([]*Interaction(nil), r.session.Interactions...)
+	return &session
+}
+
+func (r *Recorder) add(interaction *Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.session.Interactions = append(r.session.Interactions, interaction)
+}
+
+// recordingTransport records the traffic of one wrapped transport.
+type recordingTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// Failed requests aren't recorded; a replay reports them as missing
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.recorder.add(&Interaction{
+		Method:      req.Method,
+		URL:         redactURL(req.URL.String()),
+		RequestBody: newBody(requestBody),
+		Status:      resp.StatusCode,
+		Header:      redactHeader(resp.Header),
+		Body:        newBody(body),
+		Elapsed:     time.Since(start).Milliseconds(),
+	})
+	return resp, nil
+}
+
+// Player is an http.RoundTripper that answers requests from a session
+// without contacting any server. Requests for the same method and redacted
+// URL get the recorded responses in order, and the last one again once they
+// run out. It is safe for concurrent use.
+type Player struct {
+	mu      sync.Mutex
+	pending map[string][]*Interaction
+	last    map[string]*Interaction
+}
+
+// NewPlayer returns a Player for a session.
+func NewPlayer(session *Session) *Player {
+	p := &Player{pending: make(map[string][]*Interaction), last: make(map[string]*Interaction)}
+	for _, interaction := range session.Interactions {
+		p.pending[interaction.key()] = append(p.pending[interaction.key()], interaction)
+	}
+	return p
+}
+
+// RoundTrip answers req with its recorded response.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	interaction := p.next(requestKey(req.Method, req.URL.String()))
+	if interaction == nil {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+	}
+
+	body, err := interaction.Body.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded response for %s %s: %w", req.Method, req.URL, err)
+	}
+	header := interaction.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(interaction.Status) + " " + http.StatusText(interaction.Status),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// next returns the response for a request, or nil if none was recorded.
+func (p *Player) next(key string) *Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if queue := p.pending[key]; len(queue) > 0 {
+		p.pending[key] = queue[1:]
+		p.last[key] = queue[0]
+		return queue[0]
+	}
+	return p.last[key]
+}
@@ -0,0 +1,151 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Link", `<next>; rel="next"`)
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Path == "/image" {
+			_, _ = w.Write([]byte{0xff, 0x00, 0xfe})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, r.Method+" "+string(body)+" "+strings.Repeat("!", count))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	get := func(client *http.Client, path string) (string, http.Header) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body), resp.Header
+	}
+
+	first, _ := get(client, "/items")
+	second, _ := get(client, "/items")
+	resp, err := client.Post(server.URL+"/items", "application/json", strings.NewReader(`{"title":"x"}`))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	image, _ := get(client, "/image")
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Session().Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "Bearer token") {
+		t.Error("Expected credentials left out of the recording")
+	}
+	session, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(session.Interactions) != 4 {
+		t.Fatalf("Expected 4 interactions, got %d", len(session.Interactions))
+	}
+	if body, _ := session.Interactions[2].RequestBody.Bytes(); string(body) != `{"title":"x"}` {
+		t.Errorf("Expected the request body recorded, got %q", body)
+	}
+	if session.Interactions[0].Header.Get("Set-Cookie") != "" {
+		t.Error("Expected cookies left out of the recording")
+	}
+
+	// Replayed without the server, in the order recorded
+	server.Close()
+	replayed := &http.Client{Transport: NewPlayer(session)}
+	if got, header := get(replayed, "/items"); got != first || header.Get("Link") != `<next>; rel="next"` {
+		t.Errorf("Expected %q with headers, got %q %v", first, got, header)
+	}
+	if got, _ := get(replayed, "/items"); got != second {
+		t.Errorf("Expected %q, got %q", second, got)
+	}
+	if got, _ := get(replayed, "/items"); got != second {
+		t.Errorf("Expected the last response repeated, got %q", got)
+	}
+	if got, _ := get(replayed, "/image"); got != image {
+		t.Errorf("Expected binary body replayed, got %q", got)
+	}
+	if _, err := replayed.Get(server.URL + "/missing"); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("Expected an error for a request not recorded, got %v", err)
+	}
+}
+
+func TestLoadRejectsOtherVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := (&Session{Version: Version + 1}).Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unsupported version")
+	}
+}
+
+func TestRecordRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Api-Key", "secret-header")
+		w.Header().Set("Link", "<next>")
+		_, _ = io.WriteString(w, r.URL.Query().Get("page"))
+	}))
+	defer server.Close()
+
+	RedactQueryParam("k")
+	recorder := NewRecorder()
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+	resp, err := client.Get(server.URL + "/bugs?page=2&api_key=secret-query&k=secret-custom")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Session().Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("Expected credentials redacted, got:\n%s", data)
+	}
+	session, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	interaction := session.Interactions[0]
+	if want := server.URL + "/bugs?page=2&api_key=REDACTED&k=REDACTED"; interaction.URL != want {
+		t.Errorf("Expected URL %s, got %s", want, interaction.URL)
+	}
+	if interaction.Header.Get("Link") != "<next>" {
+		t.Errorf("Expected other headers kept, got %v", interaction.Header)
+	}
+
+	// A replay with another token matches on the redacted URL
+	replayed := &http.Client{Transport: NewPlayer(session)}
+	resp, err = replayed.Get(server.URL + "/bugs?page=2&api_key=other&k=other")
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "2" {
+		t.Errorf("Expected the recorded response, got %q", body)
+	}
+}
@@ -0,0 +1,91 @@
+package repoconfig
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Remote is a parsed git remote URL
+type Remote struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// ExternalID returns the owner/repo form used as a project's external ID
+func (r *Remote) ExternalID() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// isGitRoot reports whether dir is the top of a git repository or worktree
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// GitRoot returns the root of the git repository containing dir
+func GitRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isGitRoot(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not inside a git repository")
+		}
+		dir = parent
+	}
+}
+
+// RemoteURL returns the URL of the named remote in the repository at root
+func RemoteURL(root, name string) (string, error) {
+	out, err := exec.Command("git", "-C", root, "remote", "get-url", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %q: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ParseRemote parses a GitHub or Forgejo remote URL in any of the forms
+// git uses: https://host/owner/repo.git, ssh://git@host:22/owner/repo.git,
+// or git@host:owner/repo.git.
+func ParseRemote(raw string) (*Remote, error) {
+	raw = strings.TrimSpace(raw)
+	var host, path string
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote URL %q: %w", raw, err)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if at := strings.Index(raw, "@"); at >= 0 && strings.Contains(raw[at:], ":") {
+		// scp-like syntax: user@host:owner/repo.git
+		rest := raw[at+1:]
+		colon := strings.Index(rest, ":")
+		host, path = rest[:colon], rest[colon+1:]
+	} else {
+		return nil, fmt.Errorf("unsupported remote URL %q", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if host == "" || len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return nil, fmt.Errorf("remote URL %q does not name an owner/repo", raw)
+	}
+
+	// Forgejo may be served under a subpath; owner/repo are the last two parts
+	return &Remote{
+		Host:  strings.ToLower(host),
+		Owner: parts[len(parts)-2],
+		Repo:  parts[len(parts)-1],
+	}, nil
+}
@@ -0,0 +1,97 @@
+// Package repoconfig reads and writes the .todu.yaml file that maps a git
+// repository to a todu project, and detects a repository's origin remote.
+package repoconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the repository file that pins the project mapping
+const FileName = ".todu.yaml"
+
+// File is the contents of a .todu.yaml file
+type File struct {
+	// Project is the project name
+	Project string `yaml:"project"`
+
+	// System is the identifier of the project's system, e.g. github
+	System string `yaml:"system,omitempty"`
+
+	// ExternalID is the project's ID in its system, e.g. owner/repo
+	ExternalID string `yaml:"external_id,omitempty"`
+
+	// Filters are default task list filters inside the repository
+	Filters Filters `yaml:"filters,omitempty"`
+}
+
+// Filters are default "task list" filters. Flags given on the command
+// line take precedence.
+type Filters struct {
+	Status   string   `yaml:"status,omitempty"`
+	Priority string   `yaml:"priority,omitempty"`
+	Labels   []string `yaml:"labels,omitempty"`
+	Assignee string   `yaml:"assignee,omitempty"`
+}
+
+// Find looks for a .todu.yaml in dir and its parents, stopping at the root
+// of the git repository containing dir. Returns "" if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		if isGitRoot(dir) {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads a .todu.yaml file
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save writes a .todu.yaml file. An existing file is not overwritten.
+func Save(path string, f *File) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", FileName, err)
+	}
+
+	header := "# todu project mapping for this repository; commit it to share with teammates\n"
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package repoconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Remote
+	}{
+		{"https://github.com/evcraddock/todu.sh.git", Remote{"github.com", "evcraddock", "todu.sh"}},
+		{"https://github.com/evcraddock/todu.sh", Remote{"github.com", "evcraddock", "todu.sh"}},
+		{"git@github.com:evcraddock/todu.sh.git", Remote{"github.com", "evcraddock", "todu.sh"}},
+		{"ssh://git@Forgejo.example.com:2222/team/app.git", Remote{"forgejo.example.com", "team", "app"}},
+		{"https://code.example.com/git/team/app/", Remote{"code.example.com", "team", "app"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRemote(tt.url)
+		if err != nil {
+			t.Errorf("ParseRemote(%q) error = %v", tt.url, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseRemote(%q) = %+v, want %+v", tt.url, *got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "/srv/git/app.git", "https://github.com/onlyowner", "git@github.com:"} {
+		if _, err := ParseRemote(bad); err == nil {
+			t.Errorf("ParseRemote(%q) expected error", bad)
+		}
+	}
+}
+
+func TestRemoteExternalID(t *testing.T) {
+	r := Remote{Host: "github.com", Owner: "evcraddock", Repo: "todu.sh"}
+	if got := r.ExternalID(); got != "evcraddock/todu.sh" {
+		t.Errorf("ExternalID() = %q", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "src", "pkg")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file above the repository root is not used
+	if err := os.WriteFile(filepath.Join(root, FileName), []byte("project: outer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Find(sub); err != nil || got != "" {
+		t.Errorf("Find() = %q, %v; want none", got, err)
+	}
+
+	want := filepath.Join(repo, FileName)
+	if err := os.WriteFile(want, []byte("project: inner\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Find(sub); err != nil || got != want {
+		t.Errorf("Find() = %q, %v; want %q", got, err, want)
+	}
+
+	if got, err := GitRoot(sub); err != nil || got != repo {
+		t.Errorf("GitRoot() = %q, %v; want %q", got, err, repo)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	f := &File{Project: "todu.sh", System: "github", ExternalID: "evcraddock/todu.sh"}
+
+	if err := Save(path, f); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, f) {
+		t.Errorf("Load() = %+v, want %+v", *got, *f)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "filters") {
+		t.Errorf("empty filters written:\n%s", data)
+	}
+
+	if err := Save(path, f); err == nil {
+		t.Error("Save() over an existing file expected error")
+	}
+}
+
+func TestLoadFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	content := `project: todu.sh
+filters:
+  status: active
+  labels: [bug, cli]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := Filters{Status: "active", Labels: []string{"bug", "cli"}}
+	if !reflect.DeepEqual(got.Filters, want) {
+		t.Errorf("Filters = %+v, want %+v", got.Filters, want)
+	}
+}
@@ -0,0 +1,317 @@
+// Package reports searches and prunes the local_reports tree. Dated files
+// (journal exports, weekly reviews, and attachments) are kept in month
+// directories under {local_reports}/reviews/YYYY/MM-Monthname/.
+package reports
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// archiveDir holds the yearly archives, under {local_reports}/reviews/
+const archiveDir = "archive"
+
+// Month is a month directory of dated reports.
+type Month struct {
+	Year  int
+	Month time.Month
+	Dir   string
+}
+
+// Name returns the month as YYYY-MM.
+func (m Month) Name() string {
+	return fmt.Sprintf("%04d-%02d", m.Year, int(m.Month))
+}
+
+// end returns the first instant after the month, in local time.
+func (m Month) end() time.Time {
+	return time.Date(m.Year, m.Month+1, 1, 0, 0, 0, 0, time.Local)
+}
+
+// ParseAge parses an age such as 90d, 6w, 6m, or 1y and returns the cutoff
+// that far before now.
+func ParseAge(age string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(age))
+	if len(value) < 2 {
+		return time.Time{}, fmt.Errorf("invalid age %q (use e.g. 90d, 6w, 6m, or 1y)", age)
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 1 {
+		return time.Time{}, fmt.Errorf("invalid age %q (use e.g. 90d, 6w, 6m, or 1y)", age)
+	}
+
+	switch value[len(value)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	case 'y':
+		return now.AddDate(-n, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid age unit in %q (use d, w, m, or y)", age)
+	}
+}
+
+// FindMonths lists the month directories under {localReports}/reviews,
+// oldest first. Directories that don't look like YYYY/MM-Monthname are
+// ignored.
+func FindMonths(localReports string) ([]Month, error) {
+	reviewsDir := filepath.Join(localReports, "reviews")
+	years, err := os.ReadDir(reviewsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", reviewsDir, err)
+	}
+
+	var months []Month
+	for _, yearEntry := range years {
+		year, err := strconv.Atoi(yearEntry.Name())
+		if err != nil || !yearEntry.IsDir() || len(yearEntry.Name()) != 4 {
+			continue
+		}
+
+		yearDir := filepath.Join(reviewsDir, yearEntry.Name())
+		entries, err := os.ReadDir(yearDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", yearDir, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			month, err := time.Parse("01-January", entry.Name())
+			if err != nil {
+				continue
+			}
+			months = append(months, Month{Year: year, Month: month.Month(), Dir: filepath.Join(yearDir, entry.Name())})
+		}
+	}
+
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Name() < months[j].Name()
+	})
+	return months, nil
+}
+
+// OlderThan returns the months that ended before cutoff.
+func OlderThan(months []Month, cutoff time.Time) []Month {
+	var old []Month
+	for _, m := range months {
+		if !m.end().After(cutoff) {
+			old = append(old, m)
+		}
+	}
+	return old
+}
+
+// ArchivePath returns the archive that months of year are rolled up into.
+func ArchivePath(localReports string, year int) string {
+	return filepath.Join(localReports, "reviews", archiveDir, fmt.Sprintf("%d.tar.gz", year))
+}
+
+// Archive rolls each month into its year's archive, then removes the month
+// directories. Files already in an archive are kept, and replaced if a
+// month holds a newer copy. A month is only removed once its archive has
+// been written and read back. Returns the archives written.
+func Archive(localReports string, months []Month) ([]string, error) {
+	byYear := make(map[int][]Month)
+	var years []int
+	for _, m := range months {
+		if _, ok := byYear[m.Year]; !ok {
+			years = append(years, m.Year)
+		}
+		byYear[m.Year] = append(byYear[m.Year], m)
+	}
+	sort.Ints(years)
+
+	var written []string
+	for _, year := range years {
+		path := ArchivePath(localReports, year)
+		if err := writeArchive(filepath.Join(localReports, "reviews"), path, byYear[year]); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+
+		if err := Remove(byYear[year]); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Remove deletes the month directories, and their year directory once it
+// is empty.
+func Remove(months []Month) error {
+	for _, m := range months {
+		if err := os.RemoveAll(m.Dir); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", m.Dir, err)
+		}
+		// Only succeeds if the year directory is now empty
+		_ = os.Remove(filepath.Dir(m.Dir))
+	}
+	return nil
+}
+
+// archiveFile is a file stored in an archive.
+type archiveFile struct {
+	header *tar.Header
+	data   []byte
+}
+
+// writeArchive merges the files of months into the archive at path. Names
+// in the archive are relative to reviewsDir. The archive is written to a
+// temporary file and verified before it replaces the old one.
+func writeArchive(reviewsDir, path string, months []Month) error {
+	files, err := readArchive(path)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range months {
+		err := filepath.WalkDir(m.Dir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return fmt.Errorf("%s is not a regular file", file)
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			name, err := filepath.Rel(reviewsDir, file)
+			if err != nil {
+				return err
+			}
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(name)
+			files[header.Name] = &archiveFile{header: header, data: data}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", m.Dir, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := encodeArchive(tmp, files); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write archive %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write archive %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write archive %s: %w", path, err)
+	}
+
+	written, err := readArchive(tmpPath)
+	if err != nil {
+		return err
+	}
+	if len(written) != len(files) {
+		return fmt.Errorf("archive %s is incomplete: wrote %d of %d files", path, len(written), len(files))
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to save archive %s: %w", path, err)
+	}
+	return nil
+}
+
+// encodeArchive writes files as a gzipped tar, sorted by name.
+func encodeArchive(w io.Writer, files map[string]*archiveFile) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		file := files[name]
+		if err := tw.WriteHeader(file.header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readArchive reads every file in the archive at path. A missing archive
+// is empty.
+func readArchive(path string) (map[string]*archiveFile, error) {
+	files := make(map[string]*archiveFile)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		files[header.Name] = &archiveFile{header: header, data: data}
+	}
+	return files, nil
+}
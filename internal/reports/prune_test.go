@@ -0,0 +1,131 @@
+package reports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	now := time.Date(2025, 12, 16, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		age     string
+		want    time.Time
+		wantErr bool
+	}{
+		{age: "90d", want: now.AddDate(0, 0, -90)},
+		{age: "2w", want: now.AddDate(0, 0, -14)},
+		{age: "6m", want: now.AddDate(0, -6, 0)},
+		{age: "1Y", want: now.AddDate(-1, 0, 0)},
+		{age: "1", wantErr: true},
+		{age: "0y", wantErr: true},
+		{age: "1h", wantErr: true},
+		{age: "ay", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.age, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAge(%q) error = %v, wantErr %v", tt.age, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestFindMonthsAndOlderThan(t *testing.T) {
+	dir := t.TempDir()
+	writeReport(t, dir, "2024/11-November/11-30-2024-journal.md", "november")
+	writeReport(t, dir, "2024/12-December/12-01-2024-weekly-review.md", "december")
+	writeReport(t, dir, "2025/01-January/01-05-2025-journal.md", "january")
+	writeReport(t, dir, "2025/notes/readme.md", "not a month")
+
+	months, err := FindMonths(dir)
+	if err != nil {
+		t.Fatalf("FindMonths() error = %v", err)
+	}
+	if len(months) != 3 || months[0].Name() != "2024-11" || months[2].Name() != "2025-01" {
+		t.Fatalf("FindMonths() = %v, want 2024-11, 2024-12, 2025-01", months)
+	}
+
+	// December ends at the cutoff, so it is old enough
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	old := OlderThan(months, cutoff)
+	if len(old) != 2 || old[1].Name() != "2024-12" {
+		t.Errorf("OlderThan() = %v, want 2024-11 and 2024-12", old)
+	}
+
+	empty, err := FindMonths(filepath.Join(dir, "missing"))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("FindMonths() on a missing tree = %v, %v; want none", empty, err)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	writeReport(t, dir, "2024/11-November/11-30-2024-journal.md", "november")
+	writeReport(t, dir, "2024/11-November/attachments/1-photo.jpg", "photo")
+	writeReport(t, dir, "2024/12-December/12-01-2024-journal.md", "december")
+
+	months, err := FindMonths(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Archive November first, then December into the same yearly archive
+	written, err := Archive(dir, months[:1])
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if len(written) != 1 || written[0] != ArchivePath(dir, 2024) {
+		t.Errorf("Archive() wrote %v, want %s", written, ArchivePath(dir, 2024))
+	}
+	if _, err := os.Stat(months[0].Dir); !os.IsNotExist(err) {
+		t.Error("expected the archived month to be removed")
+	}
+
+	if _, err := Archive(dir, months[1:]); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	files, err := readArchive(ArchivePath(dir, 2024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"2024/11-November/11-30-2024-journal.md":   "november",
+		"2024/11-November/attachments/1-photo.jpg": "photo",
+		"2024/12-December/12-01-2024-journal.md":   "december",
+	} {
+		file, ok := files[name]
+		if !ok {
+			t.Errorf("archive is missing %s", name)
+			continue
+		}
+		if string(file.data) != want {
+			t.Errorf("%s = %q, want %q", name, file.data, want)
+		}
+	}
+	if len(files) != 3 {
+		t.Errorf("archive has %d files, want 3", len(files))
+	}
+
+	// The emptied year directory is removed too
+	if _, err := os.Stat(filepath.Join(dir, "reviews", "2024")); !os.IsNotExist(err) {
+		t.Error("expected the empty year directory to be removed")
+	}
+}
+
+func writeReport(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, "reviews", filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Match is a line of a report that matches a search.
+type Match struct {
+	// Path is the report's path relative to local_reports. Files inside a
+	// yearly archive are shown as the archive path, a colon, and the name.
+	Path string `json:"path"`
+
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// Search finds the lines of the markdown reports under localReports,
+// including those rolled into yearly archives, that contain every word of
+// query, ignoring case. Matches are returned newest report first. Encrypted
+// exports are skipped.
+func Search(localReports, query string) ([]Match, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+
+	var matches []Match
+	err := filepath.WalkDir(localReports, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == localReports {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(localReports, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case strings.HasSuffix(path, ".md"):
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			matches = append(matches, searchLines(rel, data, terms)...)
+		case strings.HasSuffix(path, ".tar.gz") && filepath.Base(filepath.Dir(path)) == archiveDir:
+			files, err := readArchive(path)
+			if err != nil {
+				return err
+			}
+			for name, file := range files {
+				if strings.HasSuffix(name, ".md") {
+					matches = append(matches, searchLines(rel+":"+name, file.data, terms)...)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", localReports, err)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		ki, kj := reportSortKey(matches[i].Path), reportSortKey(matches[j].Path)
+		if ki != kj {
+			return ki > kj
+		}
+		return matches[i].Line < matches[j].Line
+	})
+	return matches, nil
+}
+
+// searchLines returns the lines of data that contain every term.
+func searchLines(path string, data []byte, terms []string) []Match {
+	var matches []Match
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		lower := strings.ToLower(text)
+		found := true
+		for _, term := range terms {
+			if !strings.Contains(lower, term) {
+				found = false
+				break
+			}
+		}
+		if found {
+			matches = append(matches, Match{Path: path, Line: line, Text: strings.TrimSpace(text)})
+		}
+	}
+	return matches
+}
+
+// reportSortKey orders report paths by date. Dated reports are named
+// MM-DD-YYYY-*.md, which doesn't sort by date, so the date is moved to the
+// front as YYYY-MM-DD. Undated reports sort by path.
+func reportSortKey(path string) string {
+	name := path
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if len(name) >= len("01-02-2006") && name[2] == '-' && name[5] == '-' {
+		return name[6:10] + "-" + name[0:5] + " " + path
+	}
+	return path
+}
@@ -0,0 +1,55 @@
+package reports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	dir := t.TempDir()
+	writeReport(t, dir, "2024/11-November/11-30-2024-journal.md", "# Journal\n\nPlanned the Quarterly Report\n")
+	writeReport(t, dir, "2025/01-January/01-05-2025-journal.md", "# Journal\n\nSent the quarterly report\nquarterly sync\n")
+	writeReport(t, dir, "2025/01-January/01-06-2025-journal.md.age", "quarterly report")
+	if err := os.WriteFile(filepath.Join(dir, "daily-review.md"), []byte("nothing here\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Roll November into the yearly archive; it is still searched
+	months, err := FindMonths(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Archive(dir, months[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := Search(dir, "quarterly REPORT")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Search() returned %d matches, want 2: %+v", len(matches), matches)
+	}
+
+	// Newest report first
+	if matches[0].Path != "reviews/2025/01-January/01-05-2025-journal.md" || matches[0].Line != 3 {
+		t.Errorf("first match = %+v", matches[0])
+	}
+	wantArchived := "reviews/archive/2024.tar.gz:2024/11-November/11-30-2024-journal.md"
+	if matches[1].Path != wantArchived || matches[1].Text != "Planned the Quarterly Report" {
+		t.Errorf("second match = %+v, want a line from %s", matches[1], wantArchived)
+	}
+
+	if _, err := Search(dir, "  "); err == nil {
+		t.Error("expected error for an empty query")
+	}
+}
+
+func TestReportSortKey(t *testing.T) {
+	older := reportSortKey("reviews/2024/12-December/12-31-2024-journal.md")
+	newer := reportSortKey("reviews/2025/01-January/01-01-2025-journal.md")
+	if older >= newer {
+		t.Errorf("expected %q to sort before %q", older, newer)
+	}
+}
@@ -0,0 +1,140 @@
+package review
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// generatedPrefix starts the line recording when a daily review was generated
+const generatedPrefix = "Generated: "
+
+// PreviousReportPath returns where SaveDailyReport keeps the prior day's
+// report when it overwrites outputPath, e.g. daily-review.previous.md.
+func PreviousReportPath(outputPath string) string {
+	ext := filepath.Ext(outputPath)
+	return strings.TrimSuffix(outputPath, ext) + ".previous" + ext
+}
+
+// rotatePreviousReport moves an existing report at outputPath aside if it
+// was generated on an earlier day than markdown, so the next review can
+// still see what was planned yesterday.
+func rotatePreviousReport(markdown, outputPath string) error {
+	existing, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil
+	}
+
+	oldDate, ok := reportDate(string(existing))
+	if !ok {
+		return nil
+	}
+	newDate, ok := reportDate(markdown)
+	if !ok || !oldDate.Before(newDate) {
+		return nil
+	}
+
+	return os.Rename(outputPath, PreviousReportPath(outputPath))
+}
+
+// reportDate returns the day a daily review was generated
+func reportDate(markdown string) (time.Time, bool) {
+	for _, line := range strings.Split(markdown, "\n") {
+		if !strings.HasPrefix(line, generatedPrefix) {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, generatedPrefix))
+		if len(value) < len("2006-01-02") {
+			return time.Time{}, false
+		}
+		date, err := time.ParseInLocation("2006-01-02", value[:len("2006-01-02")], time.Local)
+		return date, err == nil
+	}
+	return time.Time{}, false
+}
+
+// readPreviousNext returns the task IDs listed in the Next section of the
+// daily review generated on day, looking at reportPath and its rotated
+// copy. Returns false if neither report is from that day.
+func readPreviousNext(reportPath string, day time.Time) ([]int, bool) {
+	reportPath = expandPath(reportPath)
+	for _, path := range []string{reportPath, PreviousReportPath(reportPath)} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		date, ok := reportDate(string(content))
+		if !ok || !isSameDay(date, day) {
+			continue
+		}
+		return parseSectionTaskIDs(string(content), "Next"), true
+	}
+	return nil, false
+}
+
+// parseSectionTaskIDs returns the task IDs listed under a "## title" heading
+func parseSectionTaskIDs(markdown, title string) []int {
+	var ids []int
+	inSection := false
+
+	scanner := bufio.NewScanner(strings.NewReader(markdown))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "## ") {
+			inSection = strings.TrimPrefix(line, "## ") == title
+			continue
+		}
+		if !inSection || !strings.HasPrefix(line, "- #") {
+			continue
+		}
+		field := strings.Fields(strings.TrimPrefix(line, "- #"))
+		if len(field) == 0 {
+			continue
+		}
+		if id, err := strconv.Atoi(field[0]); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// buildCarryover returns the open tasks that were planned for yesterday and
+// are still not done. plannedIDs are the tasks from yesterday's Next section;
+// if nil, the tasks scheduled for yesterday are used instead.
+func buildCarryover(plannedIDs []int, scheduledYesterday, openTasks []*types.Task, habitTemplateIDs map[int]struct{}) []*types.Task {
+	open := make(map[int]*types.Task, len(openTasks))
+	for _, t := range openTasks {
+		open[t.ID] = t
+	}
+
+	if plannedIDs == nil {
+		for _, t := range scheduledYesterday {
+			plannedIDs = append(plannedIDs, t.ID)
+		}
+	}
+
+	seen := make(map[int]struct{})
+	var carryover []*types.Task
+	for _, id := range plannedIDs {
+		t, ok := open[id]
+		if !ok {
+			continue
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		if t.TemplateID != nil {
+			if _, isHabit := habitTemplateIDs[*t.TemplateID]; isHabit {
+				continue
+			}
+		}
+		seen[id] = struct{}{}
+		carryover = append(carryover, t)
+	}
+	return carryover
+}
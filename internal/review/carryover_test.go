@@ -0,0 +1,149 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+const previousReport = `# Daily Review
+
+Generated: 2025-12-15 08:30
+
+## In Progress
+
+- #1 Working on it (Project A)
+
+1 task
+
+## Next
+
+- #2 Planned task (Project A) - Due: 2025-12-16
+- #3 Another (Project B)
+
+2 tasks
+`
+
+func TestPreviousReportPath(t *testing.T) {
+	got := PreviousReportPath("/reports/daily-review.md")
+	if got != "/reports/daily-review.previous.md" {
+		t.Errorf("PreviousReportPath() = %q", got)
+	}
+}
+
+func TestReportDate(t *testing.T) {
+	date, ok := reportDate(previousReport)
+	if !ok {
+		t.Fatal("Expected report date to be found")
+	}
+	if date.Format("2006-01-02") != "2025-12-15" {
+		t.Errorf("Expected 2025-12-15, got %s", date.Format("2006-01-02"))
+	}
+
+	if _, ok := reportDate("# Daily Review\n"); ok {
+		t.Error("Expected no date for a report without a Generated line")
+	}
+}
+
+func TestParseSectionTaskIDs(t *testing.T) {
+	ids := parseSectionTaskIDs(previousReport, "Next")
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("Expected [2 3], got %v", ids)
+	}
+}
+
+func TestReadPreviousNext(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "daily-review.md")
+	yesterday := time.Date(2025, 12, 15, 0, 0, 0, 0, time.Local)
+
+	if _, ok := readPreviousNext(reportPath, yesterday); ok {
+		t.Error("Expected no previous report")
+	}
+
+	if err := SaveDailyReport(previousReport, reportPath); err != nil {
+		t.Fatalf("SaveDailyReport failed: %v", err)
+	}
+	ids, ok := readPreviousNext(reportPath, yesterday)
+	if !ok || len(ids) != 2 {
+		t.Errorf("Expected yesterday's Next tasks, got %v (found %t)", ids, ok)
+	}
+
+	// Saving today's report keeps yesterday's as the previous report
+	today := strings.Replace(previousReport, "2025-12-15", "2025-12-16", 1)
+	if err := SaveDailyReport(today, reportPath); err != nil {
+		t.Fatalf("SaveDailyReport failed: %v", err)
+	}
+	if _, err := os.Stat(PreviousReportPath(reportPath)); err != nil {
+		t.Fatalf("Expected previous report to be kept: %v", err)
+	}
+	ids, ok = readPreviousNext(reportPath, yesterday)
+	if !ok || len(ids) != 2 {
+		t.Errorf("Expected yesterday's Next tasks from the previous report, got %v (found %t)", ids, ok)
+	}
+
+	// Regenerating today's report doesn't replace yesterday's
+	if err := SaveDailyReport(today, reportPath); err != nil {
+		t.Fatalf("SaveDailyReport failed: %v", err)
+	}
+	if _, ok := readPreviousNext(reportPath, yesterday); !ok {
+		t.Error("Expected previous report to survive regenerating today's report")
+	}
+}
+
+func TestBuildCarryover(t *testing.T) {
+	templateID := 9
+	open := []*types.Task{
+		{ID: 2, Title: "Planned task", Status: "active"},
+		{ID: 4, Title: "Scheduled yesterday", Status: "inprogress"},
+		{ID: 5, Title: "Habit", Status: "active", TemplateID: &templateID},
+	}
+	scheduled := []*types.Task{{ID: 4}, {ID: 5}, {ID: 6}}
+	habits := map[int]struct{}{9: {}}
+
+	result := buildCarryover([]int{2, 3, 2}, scheduled, open, habits)
+	if len(result) != 1 || result[0].ID != 2 {
+		t.Errorf("Expected task 2 from yesterday's report, got %v", taskIDs(result))
+	}
+
+	result = buildCarryover(nil, scheduled, open, habits)
+	if len(result) != 1 || result[0].ID != 4 {
+		t.Errorf("Expected open task 4 scheduled yesterday, got %v", taskIDs(result))
+	}
+}
+
+func TestRenderJournalSection(t *testing.T) {
+	created := time.Date(2025, 12, 16, 9, 5, 0, 0, time.Local)
+	journals := []*types.Comment{
+		{ID: 1, Content: "Morning plan\nSecond line", CreatedAt: created},
+		{ID: 2, Content: "Lunch", CreatedAt: created.Add(3 * time.Hour)},
+	}
+
+	result := renderJournalSection(journals, 0)
+	if !strings.Contains(result, "- 09:05 Morning plan\n  Second line\n") {
+		t.Errorf("Expected indented multi-line entry, got:\n%s", result)
+	}
+	if !strings.HasSuffix(result, "\n2 entries\n") {
+		t.Errorf("Expected entry count, got:\n%s", result)
+	}
+
+	result = renderJournalSection(journals, 1)
+	if strings.Contains(result, "Lunch") || !strings.Contains(result, "- ...and 1 more\n") {
+		t.Errorf("Expected limited entries, got:\n%s", result)
+	}
+
+	journals = append(journals, &types.Comment{ID: 3, Content: "Tired mood:2 energy:1", CreatedAt: created.Add(5 * time.Hour)})
+	journals[1].Content = "Lunch mood:4"
+	result = renderJournalSection(journals, 0)
+	if !strings.HasSuffix(result, "\n3 entries\nMood: 3.0, Energy: 1.0\n") {
+		t.Errorf("Expected mood and energy averages, got:\n%s", result)
+	}
+
+	if result := renderJournalSection(nil, 0); !strings.Contains(result, "0 entries") {
+		t.Errorf("Expected empty journal, got:\n%s", result)
+	}
+}
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/daystart"
+	"github.com/evcraddock/todu.sh/internal/export"
+	"github.com/evcraddock/todu.sh/internal/journal"
+	"github.com/evcraddock/todu.sh/internal/recurring"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"golang.org/x/sync/errgroup"
 )
@@ -21,6 +26,14 @@ const (
 	exportAPITimeout = 30 * time.Second
 )
 
+// DefaultSoonDays is how many days ahead the Coming up Soon section looks
+// when DailyOptions.SoonDays is unset.
+const DefaultSoonDays = 3
+
+// defaultReviewStatuses are the task statuses shown in the Coming up Soon
+// and Next sections when DailyOptions.Statuses is empty.
+var defaultReviewStatuses = []string{"active"}
+
 // dailyData holds all data needed for the daily review
 type dailyData struct {
 	targetDate   time.Time
@@ -30,7 +43,71 @@ type dailyData struct {
 	next         []*types.Task
 	waiting      []*types.Task
 	doneToday    []*types.Task
+	carriedOver  []*types.Task
+	journals     []*types.Comment
 	projectMap   map[int]string
+
+	// custom holds the tasks for each custom section, by section key
+	custom map[string]*customSectionData
+
+	// sections lists the sections to render in order; nil renders all
+	sections []string
+
+	// limits caps the number of items shown per section; 0 is unlimited
+	limits map[string]int
+
+	// milestones are done-today counts to celebrate
+	milestones []int
+}
+
+// customSectionData holds the heading and matching tasks of a custom section
+type customSectionData struct {
+	title string
+	tasks []*types.Task
+}
+
+// DailyOptions customizes the daily review.
+type DailyOptions struct {
+	// DefaultProject is the name of the project whose active tasks are
+	// included in the Next section.
+	DefaultProject string
+
+	// Sections lists the sections to include, in order.
+	// Empty uses DefaultDailySections.
+	Sections []string
+
+	// SoonDays is how many days ahead the Coming up Soon section looks.
+	// Zero uses DefaultSoonDays.
+	SoonDays int
+
+	// Statuses lists the task statuses shown in the Coming up Soon and
+	// Next sections. Empty shows active tasks.
+	Statuses []string
+
+	// Limits caps the number of items shown per section, by section name.
+	// Sections without a limit show everything.
+	Limits map[string]int
+
+	// Custom defines additional sections listing tasks that match a filter.
+	Custom []CustomSection
+
+	// PreviousReport is the path of the saved daily review, used to find
+	// the tasks that were in yesterday's Next section for the carried_over
+	// section. If it's empty or has no report from yesterday, tasks
+	// scheduled for yesterday are used instead.
+	PreviousReport string
+
+	// DayStart is how long after midnight the day starts. Tasks completed
+	// and journal entries written before it count toward the previous day.
+	DayStart time.Duration
+
+	// MaxNext caps the Next section without mentioning the tasks left out,
+	// to keep the day's list short. Zero shows everything.
+	MaxNext int
+
+	// Milestones are counts of tasks done in a day that the Done Today
+	// section celebrates.
+	Milestones []int
 }
 
 // habitStatus represents a habit and its completion status for the day
@@ -38,71 +115,119 @@ type habitStatus struct {
 	taskID    int
 	name      string
 	completed bool
+	skipped   bool
+	reason    string
+	due       time.Time // last day of an earlier occurrence's completion window
 }
 
-// habitTaskInfo holds the task ID and completion status for a habit
+// habitTaskInfo holds the task ID and completion status for a habit, and
+// whether it was skipped and why. due is set for an occurrence from an
+// earlier day that is still in its completion window.
 type habitTaskInfo struct {
 	taskID    int
 	completed bool
+	skipped   bool
+	reason    string
+	due       time.Time
 }
 
 // apiResults holds raw results from all API calls
 type apiResults struct {
 	inProgressTasks []*types.Task
 	scheduledTasks  []*types.Task
-	activeTasks     []*types.Task
+	openTasks       []*types.Task
 	waitingTasks    []*types.Task
 	doneTasks       []*types.Task
-	highPriority    []*types.Task
-	defaultProject  []*types.Task
+	customTasks     [][]*types.Task
+	yesterdayTasks  []*types.Task
+	journals        []*types.Comment
 	habits          []*types.RecurringTaskTemplate
 	projects        []*types.Project
 }
 
 // DailyReport generates a daily review report and returns the markdown content
-func DailyReport(ctx context.Context, client *api.Client, targetDate time.Time, defaultProject string) (string, error) {
+func DailyReport(ctx context.Context, client *api.Client, targetDate time.Time, opts DailyOptions) (string, error) {
 	dateStr := targetDate.Format("2006-01-02")
-	soonDate := targetDate.AddDate(0, 0, 3).Format("2006-01-02")
+	soonDate := opts.soonDate(targetDate)
+	statuses := opts.statuses()
 
-	// Resolve default project ID if configured
-	var defaultProjectID *int
-	if defaultProject != "" {
-		projects, err := client.ListProjects(ctx, nil)
-		if err == nil {
-			lowerName := strings.ToLower(defaultProject)
-			for _, p := range projects {
-				if strings.ToLower(p.Name) == lowerName {
-					defaultProjectID = &p.ID
-					break
-				}
-			}
-		}
+	sections := opts.Sections
+	if sections == nil {
+		sections = DefaultDailySections
 	}
+	wantCarryover := slices.Contains(sections, SectionCarriedOver)
+	wantJournal := slices.Contains(sections, SectionJournal)
 
 	// Fetch all data in parallel
-	results, err := fetchDailyData(ctx, client, dateStr, soonDate, defaultProjectID)
+	yesterday := targetDate.AddDate(0, 0, -1)
+	yesterdayStr := ""
+	if wantCarryover {
+		yesterdayStr = yesterday.Format("2006-01-02")
+	}
+	results, err := fetchDailyData(ctx, client, dateStr, yesterdayStr, wantJournal, statuses, opts.Custom)
+	if err != nil {
+		return "", err
+	}
+	earlierHabitTasks, err := fetchWindowOccurrences(ctx, client, results.habits, targetDate)
 	if err != nil {
 		return "", err
 	}
 
+	// Resolve default project ID if configured
+	defaultProjectID := findProjectID(results.projects, opts.DefaultProject)
+
 	// Build project map
 	projectMap := buildProjectMap(results.projects)
 
 	// Build habit template set and task map
 	habitTemplateIDs := buildHabitTemplateSet(results.habits)
 	habitTasks := buildHabitTaskMap(results.scheduledTasks, habitTemplateIDs)
+	addOpenOccurrences(habitTasks, results.habits, earlierHabitTasks, targetDate)
 
 	// Build daily goals from habits
 	dailyGoals := buildDailyGoals(results.habits, habitTasks)
 
 	// Filter done tasks to only those updated today and exclude habit tasks
-	doneToday := filterDoneToday(results.doneTasks, targetDate, habitTemplateIDs)
+	doneToday := filterDoneToday(results.doneTasks, targetDate, opts.DayStart, habitTemplateIDs)
 
 	// Build "Next" section: high priority + scheduled today + default project (deduplicated)
-	next := buildNextSection(results.highPriority, results.scheduledTasks, results.defaultProject, habitTemplateIDs)
+	next := buildNextFromResults(results, defaultProjectID, statuses, habitTemplateIDs)
+	if opts.MaxNext > 0 && len(next) > opts.MaxNext {
+		next = next[:opts.MaxNext]
+	}
 
 	// Filter coming up soon to exclude habit tasks
-	comingUpSoon := filterComingUpSoon(results.activeTasks, targetDate, soonDate, habitTemplateIDs)
+	comingUpSoon := filterComingUpSoon(results.openTasks, targetDate, soonDate, habitTemplateIDs)
+
+	// Apply the client-side parts of each custom section's filter
+	custom := make(map[string]*customSectionData, len(opts.Custom))
+	for i, c := range opts.Custom {
+		custom[SectionKey(c.Name)] = &customSectionData{
+			title: c.Name,
+			tasks: filterCustomSection(results.customTasks[i], c, results.projects, targetDate),
+		}
+	}
+
+	// Tasks planned yesterday that are still open
+	var carriedOver []*types.Task
+	if wantCarryover {
+		var plannedIDs []int
+		if opts.PreviousReport != "" {
+			plannedIDs, _ = readPreviousNext(opts.PreviousReport, yesterday)
+		}
+		stillOpen := slices.Concat(results.inProgressTasks, results.openTasks, results.waitingTasks)
+		carriedOver = buildCarryover(plannedIDs, results.yesterdayTasks, stillOpen, habitTemplateIDs)
+	}
+
+	var journals []*types.Comment
+	for _, j := range results.journals {
+		if daystart.SameDay(j.CreatedAt.Local(), targetDate, opts.DayStart) {
+			journals = append(journals, j)
+		}
+	}
+	sort.Slice(journals, func(i, j int) bool {
+		return journals[i].CreatedAt.Before(journals[j].CreatedAt)
+	})
 
 	data := &dailyData{
 		targetDate:   targetDate,
@@ -112,40 +237,84 @@ func DailyReport(ctx context.Context, client *api.Client, targetDate time.Time,
 		next:         next,
 		waiting:      results.waitingTasks,
 		doneToday:    doneToday,
+		carriedOver:  carriedOver,
+		journals:     journals,
 		projectMap:   projectMap,
+		custom:       custom,
+		sections:     opts.Sections,
+		limits:       opts.Limits,
+		milestones:   opts.Milestones,
 	}
 
 	return generateDailyMarkdown(data), nil
 }
 
-// SaveDailyReport saves the daily review markdown to a file
-func SaveDailyReport(markdown, outputPath string) error {
-	return SaveReport(markdown, outputPath)
+// soonDate returns the last day covered by the Coming up Soon section
+func (o DailyOptions) soonDate(targetDate time.Time) string {
+	soonDays := o.SoonDays
+	if soonDays <= 0 {
+		soonDays = DefaultSoonDays
+	}
+	return targetDate.AddDate(0, 0, soonDays).Format("2006-01-02")
 }
 
-// SaveReport saves markdown content to a file
-func SaveReport(markdown, outputPath string) error {
-	outputPath = expandPath(outputPath)
-	outputDir := filepath.Dir(outputPath)
+// statuses returns the task statuses shown in Coming up Soon and Next
+func (o DailyOptions) statuses() []string {
+	if len(o.Statuses) == 0 {
+		return defaultReviewStatuses
+	}
+	return o.Statuses
+}
 
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", outputDir, err)
+// buildNextFromResults picks the high priority and default project tasks
+// from the fetched open tasks and builds the Next section from them
+func buildNextFromResults(results *apiResults, defaultProjectID *int, statuses []string, habitTemplateIDs map[int]struct{}) []*types.Task {
+	// Starred tasks always make Next, like high priority ones
+	highPriority := filterTasks(results.openTasks, func(t *types.Task) bool {
+		return t.IsStarred() || types.IsHighPriority(t.Priority)
+	})
+	var defaultProjectTasks []*types.Task
+	if defaultProjectID != nil {
+		defaultProjectTasks = filterTasks(results.openTasks, func(t *types.Task) bool {
+			return t.ProjectID == *defaultProjectID
+		})
 	}
+	return buildNextSection(highPriority, results.scheduledTasks, defaultProjectTasks, statuses, habitTemplateIDs)
+}
 
-	if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", outputPath, err)
+// SaveDailyReport saves the daily review markdown to a file. A report from an
+// earlier day already at outputPath is kept at PreviousReportPath so the
+// carried_over section can still find it.
+func SaveDailyReport(markdown, outputPath string) error {
+	if err := KeepPreviousReport(markdown, outputPath); err != nil {
+		return err
 	}
+	return SaveReport(markdown, outputPath)
+}
 
+// KeepPreviousReport moves a daily review from an earlier day at
+// outputPath to PreviousReportPath, before a new one is written there.
+func KeepPreviousReport(markdown, outputPath string) error {
+	if err := rotatePreviousReport(markdown, expandPath(outputPath)); err != nil {
+		return fmt.Errorf("failed to keep previous daily review: %w", err)
+	}
 	return nil
 }
 
+// SaveReport saves markdown content to a file
+func SaveReport(markdown, outputPath string) error {
+	return export.WriteFile(expandPath(outputPath), []byte(markdown), 0644)
+}
+
 // DefaultDailyReportPath returns the default path for the daily review file
 func DefaultDailyReportPath(localReportsPath string) string {
 	return buildDailyExportPath(expandPath(localReportsPath))
 }
 
-// fetchDailyData fetches all data needed for the daily review in parallel
-func fetchDailyData(ctx context.Context, client *api.Client, dateStr, soonDate string, defaultProjectID *int) (*apiResults, error) {
+// fetchDailyData fetches all data needed for the daily review in parallel.
+// statuses are the task statuses shown in Coming up Soon and Next. Tasks
+// scheduled for yesterdayStr and journals are only fetched when requested.
+func fetchDailyData(ctx context.Context, client *api.Client, dateStr, yesterdayStr string, withJournal bool, statuses []string, custom []CustomSection) (*apiResults, error) {
 	ctx, cancel := context.WithTimeout(ctx, exportAPITimeout)
 	defer cancel()
 
@@ -172,13 +341,11 @@ func fetchDailyData(ctx context.Context, client *api.Client, dateStr, soonDate s
 		return err
 	})
 
-	// 3. Active tasks (for coming up soon - filter by due date client-side)
+	// 3. Open tasks in the review statuses (for coming up soon, high
+	// priority and default project - filtered client-side)
 	g.Go(func() error {
 		var err error
-		results.activeTasks, err = client.ListTasks(ctx, &api.TaskListOptions{
-			Status: "active",
-			Limit:  maxTaskLimit,
-		})
+		results.openTasks, err = listTasksByStatus(ctx, client, statuses, api.TaskListOptions{})
 		return err
 	})
 
@@ -203,32 +370,49 @@ func fetchDailyData(ctx context.Context, client *api.Client, dateStr, soonDate s
 		return err
 	})
 
-	// 6. High priority active tasks
+	// 6. Custom sections (labels, project and due date filtered client-side)
+	results.customTasks = make([][]*types.Task, len(custom))
+	for i, c := range custom {
+		g.Go(func() error {
+			sectionStatuses := c.Statuses
+			if len(sectionStatuses) == 0 {
+				sectionStatuses = defaultReviewStatuses
+			}
+			var err error
+			results.customTasks[i], err = listTasksByStatus(ctx, client, sectionStatuses, api.TaskListOptions{
+				Priority: c.Priority,
+			})
+			return err
+		})
+	}
+
+	// 7. Tasks scheduled yesterday (for carried over)
 	g.Go(func() error {
+		if yesterdayStr == "" {
+			return nil
+		}
 		var err error
-		results.highPriority, err = client.ListTasks(ctx, &api.TaskListOptions{
-			Status:   "active",
-			Priority: "high",
-			Limit:    maxTaskLimit,
+		results.yesterdayTasks, err = client.ListTasks(ctx, &api.TaskListOptions{
+			ScheduledDate: yesterdayStr,
+			Limit:         maxTaskLimit,
 		})
 		return err
 	})
 
-	// 7. Default project tasks (if configured)
+	// 8. Journal entries (filter by created date client-side)
 	g.Go(func() error {
-		if defaultProjectID == nil {
+		if !withJournal {
 			return nil
 		}
-		var err error
-		results.defaultProject, err = client.ListTasks(ctx, &api.TaskListOptions{
-			ProjectID: defaultProjectID,
-			Status:    "active",
-			Limit:     maxTaskLimit,
-		})
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return err
+		}
+		results.journals, err = client.ListCommentsFiltered(ctx, journal.DayListOptions(date))
 		return err
 	})
 
-	// 8. Habit templates
+	// 9. Habit templates
 	g.Go(func() error {
 		var err error
 		results.habits, err = client.ListTemplates(ctx, &api.TemplateListOptions{
@@ -238,7 +422,7 @@ func fetchDailyData(ctx context.Context, client *api.Client, dateStr, soonDate s
 		return err
 	})
 
-	// 9. Projects
+	// 10. Projects
 	g.Go(func() error {
 		var err error
 		results.projects, err = client.ListProjects(ctx, nil)
@@ -249,9 +433,166 @@ func fetchDailyData(ctx context.Context, client *api.Client, dateStr, soonDate s
 		return nil, fmt.Errorf("failed to fetch daily review data: %w", err)
 	}
 
+	results.dropSomeday(custom)
 	return results, nil
 }
 
+// fetchWindowOccurrences fetches the tasks scheduled on the days before
+// targetDate that the longest habit completion window reaches back to, or
+// nothing if no habit has a window reaching before the day.
+func fetchWindowOccurrences(ctx context.Context, client *api.Client, habits []*types.RecurringTaskTemplate, targetDate time.Time) ([]*types.Task, error) {
+	days := 0
+	for _, h := range habits {
+		days = max(days, int(recurring.TemplateWindow(h)))
+	}
+	if days == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportAPITimeout)
+	defer cancel()
+
+	tasks, err := client.ListTasks(ctx, &api.TaskListOptions{
+		ScheduledAfter:  targetDate.AddDate(0, 0, -days).Format("2006-01-02"),
+		ScheduledBefore: targetDate.Format("2006-01-02"),
+		Limit:           maxTaskLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch habit occurrences: %w", err)
+	}
+	return tasks, nil
+}
+
+// dropSomeday removes someday/maybe tasks from the fetched tasks, except in
+// custom sections that ask for the someday label.
+func (r *apiResults) dropSomeday(custom []CustomSection) {
+	active := func(t *types.Task) bool { return !t.IsSomeday() }
+	r.inProgressTasks = filterTasks(r.inProgressTasks, active)
+	r.scheduledTasks = filterTasks(r.scheduledTasks, active)
+	r.openTasks = filterTasks(r.openTasks, active)
+	r.waitingTasks = filterTasks(r.waitingTasks, active)
+	r.yesterdayTasks = filterTasks(r.yesterdayTasks, active)
+	for i, c := range custom {
+		if !containsFold(c.Labels, types.SomedayLabel) {
+			r.customTasks[i] = filterTasks(r.customTasks[i], active)
+		}
+	}
+}
+
+// containsFold reports whether list contains s (case-insensitive)
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// listTasksByStatus lists tasks matching opts for each status in turn and
+// returns them together.
+func listTasksByStatus(ctx context.Context, client *api.Client, statuses []string, opts api.TaskListOptions) ([]*types.Task, error) {
+	var tasks []*types.Task
+	for _, status := range statuses {
+		opts.Status = status
+		opts.Limit = maxTaskLimit
+		statusTasks, err := client.ListTasks(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, statusTasks...)
+	}
+	return tasks, nil
+}
+
+// findProjectID returns the ID of the project with the given name
+// (case-insensitive), or nil if name is empty or not found.
+func findProjectID(projects []*types.Project, name string) *int {
+	if name == "" {
+		return nil
+	}
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return &p.ID
+		}
+	}
+	return nil
+}
+
+// filterTasks returns the tasks for which keep returns true
+func filterTasks(tasks []*types.Task, keep func(*types.Task) bool) []*types.Task {
+	var filtered []*types.Task
+	for _, t := range tasks {
+		if keep(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// filterCustomSection applies a custom section's label, project, and due
+// date filters, sorting the result by due date. Status and priority are
+// filtered by the API.
+func filterCustomSection(tasks []*types.Task, section CustomSection, projects []*types.Project, targetDate time.Time) []*types.Task {
+	var projectID *int
+	if section.Project != "" {
+		projectID = findProjectID(projects, section.Project)
+		if projectID == nil {
+			return nil
+		}
+	}
+
+	var projectIDs map[int]bool
+	if section.Projects != nil {
+		projectIDs = make(map[int]bool)
+		for _, name := range section.Projects {
+			if id := findProjectID(projects, name); id != nil {
+				projectIDs[*id] = true
+			}
+		}
+		if len(projectIDs) == 0 {
+			return nil
+		}
+	}
+
+	var endOfWindow time.Time
+	if section.DueWithinDays > 0 {
+		y, m, d := targetDate.AddDate(0, 0, section.DueWithinDays+1).Date()
+		endOfWindow = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	filtered := filterTasks(tasks, func(t *types.Task) bool {
+		if projectID != nil && t.ProjectID != *projectID {
+			return false
+		}
+		if projectIDs != nil && !projectIDs[t.ProjectID] {
+			return false
+		}
+		if len(section.Labels) > 0 && !hasAnyLabel(t, section.Labels) {
+			return false
+		}
+		if section.DueWithinDays > 0 && (t.DueDate == nil || !t.DueDate.Before(endOfWindow)) {
+			return false
+		}
+		return true
+	})
+
+	sortByDueDate(filtered)
+	return filtered
+}
+
+// hasAnyLabel reports whether a task has any of the labels (case-insensitive)
+func hasAnyLabel(t *types.Task, labels []string) bool {
+	for _, l := range t.Labels {
+		for _, name := range labels {
+			if strings.EqualFold(l.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // buildProjectMap creates a map from project ID to project name
 func buildProjectMap(projects []*types.Project) map[int]string {
 	projectMap := make(map[int]string)
@@ -276,9 +617,12 @@ func buildHabitTaskMap(scheduledTasks []*types.Task, habitTemplateIDs map[int]st
 	for _, t := range scheduledTasks {
 		if t.TemplateID != nil {
 			if _, isHabit := habitTemplateIDs[*t.TemplateID]; isHabit {
+				reason, skipped := t.Skipped()
 				habitTasks[*t.TemplateID] = &habitTaskInfo{
 					taskID:    t.ID,
 					completed: t.Status == "done",
+					skipped:   skipped,
+					reason:    reason,
 				}
 			}
 		}
@@ -286,28 +630,60 @@ func buildHabitTaskMap(scheduledTasks []*types.Task, habitTemplateIDs map[int]st
 	return habitTasks
 }
 
+// addOpenOccurrences adds, for habits without an occurrence on targetDate,
+// their latest earlier occurrence if it's still open and can be done within
+// the habit's completion window.
+func addOpenOccurrences(habitTasks map[int]*habitTaskInfo, habits []*types.RecurringTaskTemplate, earlier []*types.Task, targetDate time.Time) {
+	windows := make(map[int]recurring.Window, len(habits))
+	for _, h := range habits {
+		windows[h.ID] = recurring.TemplateWindow(h)
+	}
+
+	seen := make(map[int]bool)
+	for _, t := range recurring.History(earlier, 0) {
+		if t.TemplateID == nil || t.ScheduledDate == nil {
+			continue
+		}
+		window, isHabit := windows[*t.TemplateID]
+		if !isHabit || seen[*t.TemplateID] || habitTasks[*t.TemplateID] != nil {
+			continue
+		}
+		seen[*t.TemplateID] = true
+		if t.Status == "done" || t.Status == "canceled" || !window.Open(*t.ScheduledDate, targetDate) {
+			continue
+		}
+		habitTasks[*t.TemplateID] = &habitTaskInfo{taskID: t.ID, due: window.Due(*t.ScheduledDate)}
+	}
+}
+
 // buildDailyGoals builds the daily goals section from habits
 func buildDailyGoals(habits []*types.RecurringTaskTemplate, habitTasks map[int]*habitTaskInfo) []*habitStatus {
 	var goals []*habitStatus
 	for _, h := range habits {
-		info := habitTasks[h.ID]
-		var taskID int
-		var completed bool
-		if info != nil {
-			taskID = info.taskID
-			completed = info.completed
-		}
-		goals = append(goals, &habitStatus{
-			taskID:    taskID,
-			name:      h.Title,
-			completed: completed,
-		})
+		goal := &habitStatus{name: h.Title}
+		if info := habitTasks[h.ID]; info != nil {
+			goal.taskID = info.taskID
+			goal.completed = info.completed
+			goal.skipped = info.skipped
+			goal.reason = info.reason
+			goal.due = info.due
+		}
+		goals = append(goals, goal)
 	}
 	return goals
 }
 
+// formatSkipped shows a skipped habit occurrence, an excused miss, as a
+// dash with the reason given for it.
+func formatSkipped(reason string) string {
+	if reason == "" {
+		return "–"
+	}
+	return fmt.Sprintf("– (%s)", reason)
+}
+
 // filterDoneToday filters done tasks to only those updated today, excluding habit tasks
-func filterDoneToday(tasks []*types.Task, targetDate time.Time, habitTemplateIDs map[int]struct{}) []*types.Task {
+func filterDoneToday(tasks []*types.Task, targetDate time.Time, dayStart time.Duration, habitTemplateIDs map[int]struct{}) []*types.Task {
 	var filtered []*types.Task
 	for _, t := range tasks {
 		// Skip habit tasks
@@ -317,7 +693,7 @@ func filterDoneToday(tasks []*types.Task, targetDate time.Time, habitTemplateIDs
 			}
 		}
 		// Check if updated on target date
-		if isSameDay(t.UpdatedAt.Local(), targetDate) {
+		if daystart.SameDay(t.UpdatedAt.Local(), targetDate, dayStart) {
 			filtered = append(filtered, t)
 		}
 	}
@@ -343,22 +719,26 @@ func filterComingUpSoon(tasks []*types.Task, targetDate time.Time, soonDateStr s
 		}
 	}
 
-	// Sort by due date
-	sort.Slice(filtered, func(i, j int) bool {
-		if filtered[i].DueDate == nil {
+	sortByDueDate(filtered)
+	return filtered
+}
+
+// sortByDueDate sorts tasks by due date, earliest first and no due date last
+func sortByDueDate(tasks []*types.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].DueDate == nil {
 			return false
 		}
-		if filtered[j].DueDate == nil {
+		if tasks[j].DueDate == nil {
 			return true
 		}
-		return filtered[i].DueDate.Before(*filtered[j].DueDate)
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
 	})
-
-	return filtered
 }
 
-// buildNextSection builds the Next section from high priority, scheduled, and default project tasks
-func buildNextSection(highPriority, scheduledTasks, defaultProject []*types.Task, habitTemplateIDs map[int]struct{}) []*types.Task {
+// buildNextSection builds the Next section from high priority, scheduled, and default project
+// tasks, keeping only tasks with one of the given statuses
+func buildNextSection(highPriority, scheduledTasks, defaultProject []*types.Task, statuses []string, habitTemplateIDs map[int]struct{}) []*types.Task {
 	seen := make(map[int]struct{})
 	var next []*types.Task
 
@@ -374,8 +754,8 @@ func buildNextSection(highPriority, scheduledTasks, defaultProject []*types.Task
 			if _, exists := seen[t.ID]; exists {
 				continue
 			}
-			// Only include active tasks
-			if t.Status != "active" {
+			// Only include tasks in the review statuses
+			if !slices.Contains(statuses, t.Status) {
 				continue
 			}
 			seen[t.ID] = struct{}{}
@@ -387,8 +767,11 @@ func buildNextSection(highPriority, scheduledTasks, defaultProject []*types.Task
 	addTasks(scheduledTasks)
 	addTasks(defaultProject)
 
-	// Sort by due date (earliest first, no due date last)
+	// Sort starred first, then by due date (earliest first, no due date last)
 	sort.Slice(next, func(i, j int) bool {
+		if si, sj := next[i].IsStarred(), next[j].IsStarred(); si != sj {
+			return si
+		}
 		if next[i].DueDate == nil && next[j].DueDate == nil {
 			return next[i].ID < next[j].ID
 		}
@@ -416,6 +799,11 @@ func buildDailyExportPath(localReports string) string {
 	return filepath.Join(localReports, "daily-review.md")
 }
 
+// ExpandPath expands a leading ~ to the home directory
+func ExpandPath(path string) string {
+	return expandPath(path)
+}
+
 // expandPath expands ~ to home directory
 func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
@@ -436,112 +824,165 @@ func generateDailyMarkdown(data *dailyData) string {
 	sb.WriteString("# Daily Review\n\n")
 	sb.WriteString(fmt.Sprintf("Generated: %s\n\n", now.Format("2006-01-02 15:04")))
 
-	// In Progress section
-	sb.WriteString("## In Progress\n\n")
-	if len(data.inProgress) == 0 {
-		sb.WriteString("0 tasks\n\n")
-	} else {
-		for _, t := range data.inProgress {
-			projectName := data.projectMap[t.ProjectID]
-			sb.WriteString(fmt.Sprintf("- #%d %s (%s)\n", t.ID, t.Title, projectName))
-		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.inProgress)))
-		if len(data.inProgress) != 1 {
-			sb.WriteString("s")
+	sections := data.sections
+	if sections == nil {
+		sections = DefaultDailySections
+	}
+
+	rendered := make([]string, 0, len(sections))
+	for _, name := range sections {
+		if section := renderDailySection(data, name); section != "" {
+			rendered = append(rendered, section)
 		}
-		sb.WriteString("\n\n")
 	}
+	sb.WriteString(strings.Join(rendered, "\n"))
 
-	// Daily Goals section
-	sb.WriteString("## Daily Goals\n\n")
-	if len(data.dailyGoals) == 0 {
-		sb.WriteString("0 tasks\n\n")
-	} else {
-		for _, h := range data.dailyGoals {
+	return sb.String()
+}
+
+// renderDailySection renders a single named section of the daily review,
+// or returns empty for unknown names.
+func renderDailySection(data *dailyData, name string) string {
+	limit := data.limits[name]
+	switch name {
+	case SectionInProgress:
+		return renderTaskSection("In Progress", data.inProgress, data.projectMap, false, limit)
+	case SectionDailyGoals:
+		lines := make([]string, len(data.dailyGoals))
+		for i, h := range data.dailyGoals {
+			state := fmt.Sprintf("%t", h.completed)
+			if h.skipped {
+				state = formatSkipped(h.reason)
+			}
+			if !h.due.IsZero() {
+				state += fmt.Sprintf(" (due by %s)", h.due.Format("Mon 2006-01-02"))
+			}
 			if h.taskID > 0 {
-				sb.WriteString(fmt.Sprintf("- #%d %s : %t\n", h.taskID, h.name, h.completed))
+				lines[i] = fmt.Sprintf("- #%d %s : %s", h.taskID, h.name, state)
 			} else {
-				sb.WriteString(fmt.Sprintf("- %s : %t\n", h.name, h.completed))
+				lines[i] = fmt.Sprintf("- %s : %s", h.name, state)
 			}
 		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.dailyGoals)))
-		if len(data.dailyGoals) != 1 {
-			sb.WriteString("s")
+		return renderSection("Daily Goals", lines, limit)
+	case SectionComingUpSoon:
+		return renderTaskSection("Coming up Soon", data.comingUpSoon, data.projectMap, true, limit)
+	case SectionNext:
+		return renderTaskSection("Next", data.next, data.projectMap, true, limit)
+	case SectionWaiting:
+		return renderTaskSection("Waiting", data.waiting, data.projectMap, false, limit)
+	case SectionDoneToday:
+		section := renderTaskSection("Done Today", data.doneToday, data.projectMap, false, limit)
+		if milestone := reachedMilestone(len(data.doneToday), data.milestones); milestone > 0 {
+			section += fmt.Sprintf("\nMilestone: %d tasks done today. Nice work, and remember to rest.\n", milestone)
+		}
+		return section
+	case SectionCarriedOver:
+		return renderTaskSection("Carried over from Yesterday", data.carriedOver, data.projectMap, true, limit)
+	case SectionJournal:
+		return renderJournalSection(data.journals, limit)
+	default:
+		if custom, ok := data.custom[name]; ok {
+			return renderTaskSection(custom.title, custom.tasks, data.projectMap, true, limit)
 		}
-		sb.WriteString("\n\n")
+		return ""
 	}
+}
 
-	// Coming up Soon section
-	sb.WriteString("## Coming up Soon\n\n")
-	if len(data.comingUpSoon) == 0 {
-		sb.WriteString("0 tasks\n\n")
-	} else {
-		for _, t := range data.comingUpSoon {
-			projectName := data.projectMap[t.ProjectID]
-			dueStr := ""
-			if t.DueDate != nil {
-				dueStr = fmt.Sprintf(" - Due: %s", t.DueDate.Local().Format("2006-01-02"))
-			}
-			sb.WriteString(fmt.Sprintf("- #%d %s (%s)%s\n", t.ID, t.Title, projectName, dueStr))
-		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.comingUpSoon)))
-		if len(data.comingUpSoon) != 1 {
-			sb.WriteString("s")
+// reachedMilestone returns the largest milestone done has reached, or 0
+func reachedMilestone(done int, milestones []int) int {
+	reached := 0
+	for _, m := range milestones {
+		if m > 0 && done >= m && m > reached {
+			reached = m
 		}
-		sb.WriteString("\n\n")
 	}
+	return reached
+}
 
-	// Next section
-	sb.WriteString("## Next\n\n")
-	if len(data.next) == 0 {
-		sb.WriteString("0 tasks\n\n")
-	} else {
-		for _, t := range data.next {
-			projectName := data.projectMap[t.ProjectID]
-			dueStr := ""
-			if t.DueDate != nil {
-				dueStr = fmt.Sprintf(" - Due: %s", t.DueDate.Local().Format("2006-01-02"))
-			}
-			sb.WriteString(fmt.Sprintf("- #%d %s (%s)%s\n", t.ID, t.Title, projectName, dueStr))
-		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.next)))
-		if len(data.next) != 1 {
-			sb.WriteString("s")
-		}
-		sb.WriteString("\n\n")
+// renderJournalSection renders the day's journal entries with their times.
+// Multi-line entries are indented under their first line.
+func renderJournalSection(journals []*types.Comment, limit int) string {
+	var sb strings.Builder
+	sb.WriteString("## Journal\n\n")
+	if len(journals) == 0 {
+		sb.WriteString("0 entries\n")
+		return sb.String()
 	}
 
-	// Waiting section
-	sb.WriteString("## Waiting\n\n")
-	if len(data.waiting) == 0 {
-		sb.WriteString("0 tasks\n\n")
+	shown := journals
+	if limit > 0 && len(journals) > limit {
+		shown = journals[:limit]
+	}
+	for _, j := range shown {
+		content := strings.ReplaceAll(strings.TrimSpace(j.Content), "\n", "\n  ")
+		sb.WriteString(fmt.Sprintf("- %s %s\n", j.CreatedAt.Local().Format("15:04"), content))
+	}
+	if hidden := len(journals) - len(shown); hidden > 0 {
+		sb.WriteString(fmt.Sprintf("- ...and %d more\n", hidden))
+	}
+	sb.WriteString(fmt.Sprintf("\n%d entr", len(journals)))
+	if len(journals) == 1 {
+		sb.WriteString("y\n")
 	} else {
-		for _, t := range data.waiting {
-			projectName := data.projectMap[t.ProjectID]
-			sb.WriteString(fmt.Sprintf("- #%d %s (%s)\n", t.ID, t.Title, projectName))
-		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.waiting)))
-		if len(data.waiting) != 1 {
-			sb.WriteString("s")
-		}
-		sb.WriteString("\n\n")
+		sb.WriteString("ies\n")
+	}
+	if summary := journal.Summarize(journals); summary.HasRatings() {
+		sb.WriteString(formatRatings(summary) + "\n")
 	}
+	return sb.String()
+}
 
-	// Done Today section
-	sb.WriteString("## Done Today\n\n")
-	if len(data.doneToday) == 0 {
-		sb.WriteString("0 tasks\n\n")
-	} else {
-		for _, t := range data.doneToday {
-			projectName := data.projectMap[t.ProjectID]
-			sb.WriteString(fmt.Sprintf("- #%d %s (%s)\n", t.ID, t.Title, projectName))
-		}
-		sb.WriteString(fmt.Sprintf("\n%d task", len(data.doneToday)))
-		if len(data.doneToday) != 1 {
-			sb.WriteString("s")
+// formatRatings describes the average mood and energy of journal entries
+func formatRatings(summary journal.Summary) string {
+	var parts []string
+	if summary.MoodCount > 0 {
+		parts = append(parts, fmt.Sprintf("Mood: %.1f", summary.Mood))
+	}
+	if summary.EnergyCount > 0 {
+		parts = append(parts, fmt.Sprintf("Energy: %.1f", summary.Energy))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderTaskSection renders a section listing tasks with their project and,
+// if showDue is set, their due date.
+func renderTaskSection(title string, tasks []*types.Task, projectMap map[int]string, showDue bool, limit int) string {
+	lines := make([]string, len(tasks))
+	for i, t := range tasks {
+		projectName := projectMap[t.ProjectID]
+		dueStr := ""
+		if showDue && t.DueDate != nil {
+			dueStr = fmt.Sprintf(" - Due: %s", t.DueDate.Local().Format("2006-01-02"))
 		}
-		sb.WriteString("\n")
+		lines[i] = fmt.Sprintf("- #%d %s (%s)%s", t.ID, t.Title, projectName, dueStr)
+	}
+	return renderSection(title, lines, limit)
+}
+
+// renderSection renders a section heading, its lines, and a task count.
+// If limit is positive, only the first limit lines are shown.
+func renderSection(title string, lines []string, limit int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## %s\n\n", title))
+	if len(lines) == 0 {
+		sb.WriteString("0 tasks\n")
+		return sb.String()
 	}
 
+	shown := lines
+	if limit > 0 && len(lines) > limit {
+		shown = lines[:limit]
+	}
+	for _, line := range shown {
+		sb.WriteString(line + "\n")
+	}
+	if hidden := len(lines) - len(shown); hidden > 0 {
+		sb.WriteString(fmt.Sprintf("- ...and %d more\n", hidden))
+	}
+	sb.WriteString(fmt.Sprintf("\n%d task", len(lines)))
+	if len(lines) != 1 {
+		sb.WriteString("s")
+	}
+	sb.WriteString("\n")
 	return sb.String()
 }
@@ -383,7 +383,7 @@ func TestFilterDoneToday(t *testing.T) {
 		1: {},
 	}
 
-	result := filterDoneToday(tasks, targetDate, habitTemplateIDs)
+	result := filterDoneToday(tasks, targetDate, 0, habitTemplateIDs)
 
 	if len(result) != 1 {
 		t.Errorf("Expected 1 task, got %d", len(result))
@@ -393,6 +393,24 @@ func TestFilterDoneToday(t *testing.T) {
 	}
 }
 
+func TestFilterDoneTodayWithDayStart(t *testing.T) {
+	targetDate := time.Date(2025, 12, 16, 0, 0, 0, 0, time.Local)
+	tasks := []*types.Task{
+		{ID: 1, Title: "Before day start", UpdatedAt: time.Date(2025, 12, 16, 2, 30, 0, 0, time.Local)},
+		{ID: 2, Title: "After day start", UpdatedAt: time.Date(2025, 12, 16, 9, 0, 0, 0, time.Local)},
+		{ID: 3, Title: "Late next night", UpdatedAt: time.Date(2025, 12, 17, 1, 0, 0, 0, time.Local)},
+	}
+
+	result := filterDoneToday(tasks, targetDate, 4*time.Hour, map[int]struct{}{})
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(result))
+	}
+	if result[0].ID != 2 || result[1].ID != 3 {
+		t.Errorf("Expected tasks 2 and 3, got %d and %d", result[0].ID, result[1].ID)
+	}
+}
+
 func TestBuildNextSection_Deduplication(t *testing.T) {
 	task1 := &types.Task{ID: 1, Title: "Task 1", Status: "active"}
 	task2 := &types.Task{ID: 2, Title: "Task 2", Status: "active"}
@@ -405,7 +423,7 @@ func TestBuildNextSection_Deduplication(t *testing.T) {
 
 	habitTemplateIDs := make(map[int]struct{})
 
-	result := buildNextSection(highPriority, scheduledTasks, defaultProject, habitTemplateIDs)
+	result := buildNextSection(highPriority, scheduledTasks, defaultProject, []string{"active"}, habitTemplateIDs)
 
 	if len(result) != 3 {
 		t.Errorf("Expected 3 unique tasks, got %d", len(result))
@@ -434,7 +452,7 @@ func TestBuildNextSection_ExcludesHabits(t *testing.T) {
 		1: {},
 	}
 
-	result := buildNextSection(highPriority, nil, nil, habitTemplateIDs)
+	result := buildNextSection(highPriority, nil, nil, []string{"active"}, habitTemplateIDs)
 
 	if len(result) != 1 {
 		t.Errorf("Expected 1 task (excluding habit), got %d", len(result))
@@ -443,3 +461,212 @@ func TestBuildNextSection_ExcludesHabits(t *testing.T) {
 		t.Errorf("Expected task ID 1, got %d", result[0].ID)
 	}
 }
+
+func TestBuildNextSection_Statuses(t *testing.T) {
+	highPriority := []*types.Task{
+		{ID: 1, Title: "Active task", Status: "active"},
+		{ID: 2, Title: "Blocked task", Status: "blocked"},
+		{ID: 3, Title: "Waiting task", Status: "waiting"},
+	}
+
+	result := buildNextSection(highPriority, nil, nil, []string{"active", "blocked"}, map[int]struct{}{})
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(result))
+	}
+	for _, task := range result {
+		if task.Status == "waiting" {
+			t.Errorf("Expected waiting task to be excluded")
+		}
+	}
+}
+
+func TestBuildNextFromResults_StarredFirst(t *testing.T) {
+	due := time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)
+	high, low := "high", "low"
+	results := &apiResults{openTasks: []*types.Task{
+		{ID: 1, Title: "High, due soon", Status: "active", Priority: &high, DueDate: &due},
+		{ID: 2, Title: "Starred, low", Status: "active", Priority: &low, Labels: []types.Label{{Name: types.StarredLabel}}},
+		{ID: 3, Title: "Low", Status: "active", Priority: &low},
+	}}
+
+	result := buildNextFromResults(results, nil, []string{"active"}, map[int]struct{}{})
+
+	if len(result) != 2 {
+		t.Fatalf("Expected starred and high priority tasks, got %d", len(result))
+	}
+	if result[0].ID != 2 || result[1].ID != 1 {
+		t.Errorf("Expected starred task first, got IDs %d, %d", result[0].ID, result[1].ID)
+	}
+}
+
+func TestDropSomeday(t *testing.T) {
+	someday := []types.Label{{Name: types.SomedayLabel}}
+	results := &apiResults{
+		openTasks:   []*types.Task{{ID: 1, Status: "active"}, {ID: 2, Status: "active", Labels: someday}},
+		customTasks: [][]*types.Task{{{ID: 2, Labels: someday}}, {{ID: 2, Labels: someday}}},
+	}
+	custom := []CustomSection{{Name: "Blocked"}, {Name: "Parked", Labels: []string{"Someday"}}}
+
+	results.dropSomeday(custom)
+
+	if len(results.openTasks) != 1 || results.openTasks[0].ID != 1 {
+		t.Errorf("Expected someday task dropped from open tasks, got %v", taskIDs(results.openTasks))
+	}
+	if len(results.customTasks[0]) != 0 {
+		t.Errorf("Expected someday task dropped from a custom section, got %v", taskIDs(results.customTasks[0]))
+	}
+	if len(results.customTasks[1]) != 1 {
+		t.Errorf("Expected someday task kept in a section asking for it, got %v", taskIDs(results.customTasks[1]))
+	}
+}
+
+func TestFilterCustomSection(t *testing.T) {
+	targetDate := time.Date(2025, 12, 15, 0, 0, 0, 0, time.Local)
+	soon := time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC)
+
+	tasks := []*types.Task{
+		{ID: 1, Title: "Blocked soon", ProjectID: 1, DueDate: &later, Labels: []types.Label{{Name: "blocked"}}},
+		{ID: 2, Title: "Blocked later", ProjectID: 1, DueDate: &soon, Labels: []types.Label{{Name: "Blocked"}}},
+		{ID: 3, Title: "Not blocked", ProjectID: 1, DueDate: &soon},
+		{ID: 4, Title: "Other project", ProjectID: 2, DueDate: &soon, Labels: []types.Label{{Name: "blocked"}}},
+	}
+	projects := []*types.Project{{ID: 1, Name: "Work"}, {ID: 2, Name: "Home"}}
+
+	result := filterCustomSection(tasks, CustomSection{Name: "Blocked", Labels: []string{"blocked"}, Project: "work"}, projects, targetDate)
+	if len(result) != 2 || result[0].ID != 2 || result[1].ID != 1 {
+		t.Errorf("Expected tasks 2 and 1 sorted by due date, got %v", taskIDs(result))
+	}
+
+	result = filterCustomSection(tasks, CustomSection{Name: "Blocked", Labels: []string{"blocked"}, DueWithinDays: 3}, projects, targetDate)
+	if len(result) != 2 || result[0].ID != 2 || result[1].ID != 4 {
+		t.Errorf("Expected tasks 2 and 4 due within 3 days, got %v", taskIDs(result))
+	}
+
+	result = filterCustomSection(tasks, CustomSection{Name: "Missing", Project: "Nowhere"}, projects, targetDate)
+	if len(result) != 0 {
+		t.Errorf("Expected no tasks for an unknown project, got %v", taskIDs(result))
+	}
+
+	result = filterCustomSection(tasks, CustomSection{Name: "Area", Projects: []string{"home", "Gone"}}, projects, targetDate)
+	if len(result) != 1 || result[0].ID != 4 {
+		t.Errorf("Expected the task in the area's project, got %v", taskIDs(result))
+	}
+
+	result = filterCustomSection(tasks, CustomSection{Name: "Empty Area", Projects: []string{}}, projects, targetDate)
+	if len(result) != 0 {
+		t.Errorf("Expected no tasks for an area without projects, got %v", taskIDs(result))
+	}
+}
+
+func TestGenerateDailyMarkdown_LimitsAndCustomSections(t *testing.T) {
+	data := &dailyData{
+		targetDate: time.Now(),
+		waiting: []*types.Task{
+			{ID: 1, Title: "First", ProjectID: 1},
+			{ID: 2, Title: "Second", ProjectID: 1},
+			{ID: 3, Title: "Third", ProjectID: 1},
+		},
+		custom: map[string]*customSectionData{
+			"blocked": {title: "Blocked", tasks: []*types.Task{{ID: 5, Title: "Stuck", ProjectID: 1}}},
+		},
+		projectMap: map[int]string{1: "Project A"},
+		sections:   []string{SectionWaiting, "blocked"},
+		limits:     map[string]int{SectionWaiting: 2},
+	}
+
+	result := generateDailyMarkdown(data)
+
+	if strings.Contains(result, "Third") {
+		t.Errorf("Expected waiting section to be limited, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- ...and 1 more\n\n3 tasks\n") {
+		t.Errorf("Expected hidden count and total, got:\n%s", result)
+	}
+	if !strings.Contains(result, "## Blocked\n\n- #5 Stuck (Project A)\n") {
+		t.Errorf("Expected custom section, got:\n%s", result)
+	}
+}
+
+func taskIDs(tasks []*types.Task) []int {
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func TestReachedMilestone(t *testing.T) {
+	tests := []struct {
+		done       int
+		milestones []int
+		want       int
+	}{
+		{3, []int{5, 10}, 0},
+		{5, []int{5, 10}, 5},
+		{12, []int{10, 5}, 10},
+		{4, nil, 0},
+		{0, []int{0}, 0},
+	}
+	for _, tt := range tests {
+		if got := reachedMilestone(tt.done, tt.milestones); got != tt.want {
+			t.Errorf("reachedMilestone(%d, %v) = %d, want %d", tt.done, tt.milestones, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateDailyMarkdown_SkippedHabit(t *testing.T) {
+	templateID := 1
+	scheduled := []*types.Task{
+		{ID: 12, TemplateID: &templateID, Status: "canceled", Labels: []types.Label{{Name: "skipped:injured"}}},
+	}
+	habits := []*types.RecurringTaskTemplate{{ID: 1, Title: "Exercise"}, {ID: 2, Title: "Read"}}
+	goals := buildDailyGoals(habits, buildHabitTaskMap(scheduled, buildHabitTemplateSet(habits)))
+
+	result := generateDailyMarkdown(&dailyData{
+		targetDate: time.Now(),
+		dailyGoals: goals,
+		projectMap: make(map[int]string),
+	})
+
+	if !strings.Contains(result, "- #12 Exercise : – (injured)\n") {
+		t.Errorf("Expected skipped habit to show a dash and the reason, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- Read : false\n") {
+		t.Errorf("Expected unscheduled habit to show false, got:\n%s", result)
+	}
+}
+
+func TestGenerateDailyMarkdown_CompletionWindow(t *testing.T) {
+	review, read, stretch := 1, 2, 3
+	sunday := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	earlier := []*types.Task{
+		{ID: 20, TemplateID: &review, ScheduledDate: &sunday, Status: "active"},
+		{ID: 21, TemplateID: &read, ScheduledDate: &saturday, Status: "active"}, // no window
+		{ID: 22, TemplateID: &stretch, ScheduledDate: &saturday, Status: "active"},
+	}
+	habits := []*types.RecurringTaskTemplate{
+		{ID: 1, Title: "Weekly review", Labels: []types.Label{{Name: "grace:2d"}}},
+		{ID: 2, Title: "Read"},
+		{ID: 3, Title: "Stretch", Labels: []types.Label{{Name: "grace:1d"}}}, // window closed Sunday
+	}
+
+	monday := time.Date(2025, 12, 22, 0, 0, 0, 0, time.Local)
+	habitTasks := buildHabitTaskMap(nil, buildHabitTemplateSet(habits))
+	addOpenOccurrences(habitTasks, habits, earlier, monday)
+
+	result := generateDailyMarkdown(&dailyData{
+		targetDate: monday,
+		dailyGoals: buildDailyGoals(habits, habitTasks),
+		projectMap: make(map[int]string),
+	})
+
+	if !strings.Contains(result, "- #20 Weekly review : false (due by Tue 2025-12-23)\n") {
+		t.Errorf("Expected Sunday's review carried over within its window, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- Read : false\n") || !strings.Contains(result, "- Stretch : false\n") {
+		t.Errorf("Expected occurrences outside a window left out, got:\n%s", result)
+	}
+}
@@ -0,0 +1,99 @@
+package review
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// projectNote is a project's notes document in a weekly review
+type projectNote struct {
+	project string
+	content string
+}
+
+// ProjectNotesPath returns the path of a project's notes document.
+// Format: {local_reports}/projects/project-{id}-notes.md
+func ProjectNotesPath(localReports string, projectID int) string {
+	return filepath.Join(expandPath(localReports), "projects", fmt.Sprintf("project-%d-notes.md", projectID))
+}
+
+// LoadProjectNotes reads a project's notes document. A project without
+// notes has empty notes.
+func LoadProjectNotes(localReports string, projectID int) (string, error) {
+	data, err := os.ReadFile(ProjectNotesPath(localReports, projectID))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read project notes: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// loadWeeklyProjectNotes returns the notes of the projects that are still
+// open, sorted by project name. Projects without notes, and notes that
+// can't be read, are left out.
+func loadWeeklyProjectNotes(localReports string, projects []*types.Project) []projectNote {
+	if localReports == "" {
+		return nil
+	}
+
+	var notes []projectNote
+	for _, p := range projects {
+		if p.Status == "done" || p.Status == "cancelled" || p.Status == "canceled" {
+			continue
+		}
+		content, err := LoadProjectNotes(localReports, p.ID)
+		if err != nil || content == "" {
+			continue
+		}
+		notes = append(notes, projectNote{project: p.Name, content: content})
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].project < notes[j].project
+	})
+	return notes
+}
+
+// writeProjectNotes writes each project's notes under a heading, with the
+// notes' own headings nested below it. Writes nothing without notes.
+func writeProjectNotes(sb *strings.Builder, notes []projectNote) {
+	if len(notes) == 0 {
+		return
+	}
+
+	sb.WriteString("---\n\n")
+	sb.WriteString("## Project Notes\n\n")
+	for _, note := range notes {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", note.project))
+		sb.WriteString(demoteHeadings(note.content, 3))
+		sb.WriteString("\n\n")
+	}
+}
+
+// demoteHeadings adds levels to every markdown heading outside code
+// fences, up to level 6.
+func demoteHeadings(markdown string, levels int) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level > 6 || (len(line) > level && line[level] != ' ') {
+			continue
+		}
+		lines[i] = strings.Repeat("#", min(level+levels, 6)) + line[level:]
+	}
+	return strings.Join(lines, "\n")
+}
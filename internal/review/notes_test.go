@@ -0,0 +1,60 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestLoadWeeklyProjectNotes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(projectID int, content string) {
+		t.Helper()
+		path := ProjectNotesPath(dir, projectID)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(1, "Ship v2 by June\n")
+	write(2, "Old notes")
+	write(3, "  \n")
+
+	projects := []*types.Project{
+		{ID: 1, Name: "website", Status: "active"},
+		{ID: 2, Name: "archive", Status: "done"},
+		{ID: 3, Name: "empty", Status: "active"},
+		{ID: 4, Name: "none", Status: "active"},
+	}
+
+	notes := loadWeeklyProjectNotes(dir, projects)
+	if len(notes) != 1 || notes[0].project != "website" || notes[0].content != "Ship v2 by June" {
+		t.Errorf("Expected only the website notes, got %+v", notes)
+	}
+	if notes := loadWeeklyProjectNotes("", projects); notes != nil {
+		t.Errorf("Expected no notes without local_reports, got %+v", notes)
+	}
+}
+
+func TestGenerateWeeklyReviewMarkdown_ProjectNotes(t *testing.T) {
+	data := &weeklyReviewData{
+		startDate:    time.Date(2025, 12, 21, 0, 0, 0, 0, time.Local),
+		endDate:      time.Date(2025, 12, 27, 0, 0, 0, 0, time.Local),
+		projectMap:   make(map[int]string),
+		habitTasks:   make(map[int]map[string]*weeklyHabitTaskInfo),
+		projectNotes: []projectNote{{project: "website", content: "# Goals\n\nShip v2\n\n```\n# not a heading\n```"}},
+	}
+
+	result := generateWeeklyReviewMarkdown(data)
+	for _, want := range []string{"## Project Notes", "### website", "#### Goals", "\n# not a heading\n"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, result)
+		}
+	}
+}
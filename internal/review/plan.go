@@ -0,0 +1,51 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// PlanCandidates returns the tasks worth considering for the day's plan: the
+// daily review's Coming up Soon tasks followed by its Next tasks (high
+// priority, scheduled, and default project), skipping tasks already
+// scheduled for targetDate. Also returns a map from project ID to name.
+func PlanCandidates(ctx context.Context, client *api.Client, targetDate time.Time, opts DailyOptions) ([]*types.Task, map[int]string, error) {
+	statuses := opts.statuses()
+	results, err := fetchDailyData(ctx, client, targetDate.Format("2006-01-02"), "", false, statuses, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	habitTemplateIDs := buildHabitTemplateSet(results.habits)
+	defaultProjectID := findProjectID(results.projects, opts.DefaultProject)
+
+	comingUpSoon := filterComingUpSoon(results.openTasks, targetDate, opts.soonDate(targetDate), habitTemplateIDs)
+	next := buildNextFromResults(results, defaultProjectID, statuses, habitTemplateIDs)
+
+	return buildPlanCandidates(comingUpSoon, next, targetDate), buildProjectMap(results.projects), nil
+}
+
+// buildPlanCandidates merges the Coming up Soon and Next tasks, dropping
+// duplicates and tasks already scheduled for targetDate
+func buildPlanCandidates(comingUpSoon, next []*types.Task, targetDate time.Time) []*types.Task {
+	today := targetDate.Format("2006-01-02")
+	seen := make(map[int]struct{})
+	var candidates []*types.Task
+
+	for _, tasks := range [][]*types.Task{comingUpSoon, next} {
+		for _, t := range tasks {
+			if _, exists := seen[t.ID]; exists {
+				continue
+			}
+			seen[t.ID] = struct{}{}
+			if t.ScheduledDate != nil && t.ScheduledDate.UTC().Format("2006-01-02") == today {
+				continue
+			}
+			candidates = append(candidates, t)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,28 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestBuildPlanCandidates(t *testing.T) {
+	targetDate := time.Date(2025, 12, 15, 9, 0, 0, 0, time.Local)
+	today := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	soon := []*types.Task{
+		{ID: 1, Title: "Due soon"},
+		{ID: 2, Title: "Already planned", ScheduledDate: &today},
+	}
+	next := []*types.Task{
+		{ID: 1, Title: "Due soon"},
+		{ID: 3, Title: "High priority", ScheduledDate: &tomorrow},
+	}
+
+	result := buildPlanCandidates(soon, next, targetDate)
+	if len(result) != 2 || result[0].ID != 1 || result[1].ID != 3 {
+		t.Errorf("Expected tasks 1 and 3, got %v", taskIDs(result))
+	}
+}
@@ -0,0 +1,167 @@
+package review
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Daily review section names.
+const (
+	SectionInProgress   = "in_progress"
+	SectionDailyGoals   = "daily_goals"
+	SectionComingUpSoon = "coming_up_soon"
+	SectionNext         = "next"
+	SectionWaiting      = "waiting"
+	SectionDoneToday    = "done_today"
+	SectionCarriedOver  = "carried_over"
+	SectionJournal      = "journal"
+)
+
+// DefaultDailySections is the default order of daily review sections.
+var DefaultDailySections = []string{
+	SectionInProgress,
+	SectionDailyGoals,
+	SectionComingUpSoon,
+	SectionNext,
+	SectionWaiting,
+	SectionDoneToday,
+}
+
+// CustomSection is a user-defined daily review section listing the tasks
+// that match a filter.
+type CustomSection struct {
+	// Name is the section heading. Its key (see SectionKey) is used in
+	// section lists, limits, and --include/--exclude.
+	Name string
+
+	// Statuses limits the section to tasks with these statuses.
+	// Empty matches active tasks.
+	Statuses []string
+
+	// Priority limits the section to tasks with this priority.
+	Priority string
+
+	// Labels limits the section to tasks with any of these labels.
+	Labels []string
+
+	// Project limits the section to tasks in this project (by name).
+	Project string
+
+	// Projects, if non-nil, limits the section to tasks in any of these
+	// projects (by name), such as the projects in an area.
+	Projects []string
+
+	// DueWithinDays, if positive, limits the section to tasks due within
+	// this many days of the review date (including overdue tasks).
+	DueWithinDays int
+}
+
+// SectionKey returns the section name used to refer to a custom section,
+// e.g. "Blocked on Others" becomes "blocked_on_others".
+func SectionKey(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// OptionalDailySections are sections that are only shown when listed in the
+// configured order, passed to --include, or enabled with DefaultSectionOrder.
+var OptionalDailySections = []string{
+	SectionCarriedOver,
+	SectionJournal,
+}
+
+// DefaultSectionOrder returns the section order used when none is
+// configured: carried_over (if enabled), the default sections, journal (if
+// enabled), then custom sections.
+func DefaultSectionOrder(custom []CustomSection, carryover, journal bool) []string {
+	var order []string
+	if carryover {
+		order = append(order, SectionCarriedOver)
+	}
+	order = append(order, DefaultDailySections...)
+	if journal {
+		order = append(order, SectionJournal)
+	}
+	for _, c := range custom {
+		order = append(order, SectionKey(c.Name))
+	}
+	return order
+}
+
+// ResolveSections returns the daily review sections to render, in order.
+// order is the configured section order (empty for the default, followed by
+// any custom sections). include, if non-empty, keeps only the listed
+// sections; exclude removes sections. Section names are case-insensitive
+// and accept hyphens for underscores.
+func ResolveSections(order, include, exclude []string, custom []CustomSection) ([]string, error) {
+	available := slices.Concat(DefaultDailySections, OptionalDailySections)
+	for _, c := range custom {
+		key := SectionKey(c.Name)
+		if key == "" {
+			return nil, fmt.Errorf("custom review section is missing a name")
+		}
+		if slices.Contains(available, key) {
+			return nil, fmt.Errorf("duplicate review section %q", key)
+		}
+		available = append(available, key)
+	}
+
+	sections := DefaultSectionOrder(custom, false, false)
+	if len(order) > 0 {
+		normalized, err := normalizeSections(order, available)
+		if err != nil {
+			return nil, err
+		}
+		sections = normalized
+	}
+
+	includeSet, err := normalizeSections(include, available)
+	if err != nil {
+		return nil, err
+	}
+	excludeSet, err := normalizeSections(exclude, available)
+	if err != nil {
+		return nil, err
+	}
+
+	// Included sections missing from the configured order go at the end
+	for _, name := range includeSet {
+		if !slices.Contains(sections, name) {
+			sections = append(slices.Clone(sections), name)
+		}
+	}
+
+	var resolved []string
+	for _, name := range sections {
+		if len(includeSet) > 0 && !slices.Contains(includeSet, name) {
+			continue
+		}
+		if slices.Contains(excludeSet, name) {
+			continue
+		}
+		resolved = append(resolved, name)
+	}
+
+	return resolved, nil
+}
+
+// normalizeSections validates section names against available, splitting
+// comma-separated values and dropping duplicates.
+func normalizeSections(names, available []string) ([]string, error) {
+	var result []string
+	for _, entry := range names {
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+			if name == "" || slices.Contains(result, name) {
+				continue
+			}
+			if !slices.Contains(available, name) {
+				return nil, fmt.Errorf("unknown review section %q (available: %s)", name, strings.Join(available, ", "))
+			}
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
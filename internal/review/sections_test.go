@@ -0,0 +1,127 @@
+package review
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestResolveSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    []string
+		include  []string
+		exclude  []string
+		custom   []CustomSection
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "defaults",
+			expected: DefaultDailySections,
+		},
+		{
+			name:     "configured order",
+			order:    []string{"next", "in-progress", "Waiting"},
+			expected: []string{"next", "in_progress", "waiting"},
+		},
+		{
+			name:     "include keeps configured order",
+			include:  []string{"done_today,next"},
+			expected: []string{"next", "done_today"},
+		},
+		{
+			name:     "include adds sections missing from order",
+			order:    []string{"next"},
+			include:  []string{"next", "waiting"},
+			expected: []string{"next", "waiting"},
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"daily_goals", "waiting"},
+			expected: []string{"in_progress", "coming_up_soon", "next", "done_today"},
+		},
+		{
+			name:    "unknown section",
+			exclude: []string{"blocked"},
+			wantErr: true,
+		},
+		{
+			name:     "custom sections follow defaults",
+			custom:   []CustomSection{{Name: "Blocked"}},
+			exclude:  []string{"daily_goals", "waiting", "done_today"},
+			expected: []string{"in_progress", "coming_up_soon", "next", "blocked"},
+		},
+		{
+			name:     "custom section in configured order",
+			order:    []string{"waiting-on-others", "next"},
+			custom:   []CustomSection{{Name: "Waiting on Others"}},
+			expected: []string{"waiting_on_others", "next"},
+		},
+		{
+			name:     "optional sections via include",
+			include:  []string{"carried_over", "journal"},
+			expected: []string{"carried_over", "journal"},
+		},
+		{
+			name:    "custom section clashes with built-in",
+			custom:  []CustomSection{{Name: "Next"}},
+			wantErr: true,
+		},
+		{
+			name:    "custom section without name",
+			custom:  []CustomSection{{Labels: []string{"blocked"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSections(tt.order, tt.include, tt.exclude, tt.custom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveSections() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("ResolveSections() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDefaultSectionOrder(t *testing.T) {
+	got := DefaultSectionOrder([]CustomSection{{Name: "Blocked"}}, true, true)
+	expected := append(append([]string{SectionCarriedOver}, DefaultDailySections...), SectionJournal, "blocked")
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("DefaultSectionOrder() = %v, expected %v", got, expected)
+	}
+
+	got = DefaultSectionOrder(nil, false, false)
+	if strings.Join(got, ",") != strings.Join(DefaultDailySections, ",") {
+		t.Errorf("DefaultSectionOrder() = %v, expected defaults", got)
+	}
+}
+
+func TestGenerateDailyMarkdown_SectionOrder(t *testing.T) {
+	data := &dailyData{
+		targetDate: time.Now(),
+		waiting:    []*types.Task{{ID: 4, Title: "Waiting for response", ProjectID: 1}},
+		projectMap: map[int]string{1: "Project A"},
+		sections:   []string{SectionWaiting, SectionInProgress},
+	}
+
+	result := generateDailyMarkdown(data)
+
+	waiting := strings.Index(result, "## Waiting")
+	inProgress := strings.Index(result, "## In Progress")
+	if waiting < 0 || inProgress < 0 || waiting > inProgress {
+		t.Errorf("Expected Waiting before In Progress, got:\n%s", result)
+	}
+	if strings.Contains(result, "## Next") || strings.Contains(result, "## Daily Goals") {
+		t.Errorf("Expected only selected sections, got:\n%s", result)
+	}
+	if !strings.HasSuffix(result, "0 tasks\n") {
+		t.Errorf("Expected report to end after the last section, got:\n%q", result)
+	}
+}
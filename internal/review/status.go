@@ -0,0 +1,155 @@
+package review
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Status describes when a review was last generated into the local_reports
+// tree and when the next one is due.
+type Status struct {
+	// Review is "daily" or "weekly"
+	Review string `json:"review"`
+
+	// Path is the newest saved report, empty if none was found
+	Path string `json:"path,omitempty"`
+
+	// LastGenerated is when the newest report was generated, zero if never
+	LastGenerated time.Time `json:"last_generated"`
+
+	// Due is when the next report is expected
+	Due time.Time `json:"due"`
+
+	// Overdue is true once Due has passed without a new report
+	Overdue bool `json:"overdue"`
+}
+
+// Never reports whether no saved report was found.
+func (s *Status) Never() bool {
+	return s.LastGenerated.IsZero()
+}
+
+// DailyStatus reports when the daily review in localReports was last
+// generated. A daily review is due each day at remindAt (HH:MM, local time)
+// and is overdue after that until one is generated that day.
+func DailyStatus(localReports, remindAt string, now time.Time) (*Status, error) {
+	at, err := time.Parse("15:04", remindAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review reminder time %q (use HH:MM)", remindAt)
+	}
+
+	status := &Status{Review: "daily"}
+	path := DefaultDailyReportPath(localReports)
+	if generated, ok := dailyGeneratedAt(path); ok {
+		status.Path = path
+		status.LastGenerated = generated
+	}
+
+	today := truncateToDay(now)
+	due := today.Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)
+	if !status.LastGenerated.Before(today) {
+		due = due.AddDate(0, 0, 1)
+	}
+	status.Due = due
+	status.Overdue = !now.Before(due)
+	return status, nil
+}
+
+// WeeklyStatus reports when a weekly review in localReports was last
+// generated. A weekly review is due remindAfterDays after the last one, and
+// is overdue at once if none was ever saved.
+func WeeklyStatus(localReports string, remindAfterDays int, now time.Time) (*Status, error) {
+	if remindAfterDays < 1 {
+		return nil, fmt.Errorf("invalid weekly review reminder interval %d: must be at least 1 day", remindAfterDays)
+	}
+
+	status := &Status{Review: "weekly", Due: now}
+	path, generated, err := newestWeeklyReport(expandPath(localReports))
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		status.Path = path
+		status.LastGenerated = generated
+		status.Due = generated.AddDate(0, 0, remindAfterDays)
+	}
+	status.Overdue = !now.Before(status.Due)
+	return status, nil
+}
+
+// dailyGeneratedAt returns when the daily review at path was generated,
+// from its Generated line or, failing that, the file's modification time.
+func dailyGeneratedAt(path string) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info.ModTime(), true
+	}
+	if generated, ok := reportGeneratedAt(string(data)); ok {
+		return generated, true
+	}
+	return info.ModTime(), true
+}
+
+// reportGeneratedAt returns the time on a report's Generated line.
+func reportGeneratedAt(markdown string) (time.Time, bool) {
+	for _, line := range strings.Split(markdown, "\n") {
+		if !strings.HasPrefix(line, generatedPrefix) {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, generatedPrefix))
+		generated, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
+		return generated, err == nil
+	}
+	return time.Time{}, false
+}
+
+// newestWeeklyReport finds the most recently written weekly review, either
+// the undated weekly-review.md or a dated file under reviews/. Weekly reviews
+// have no Generated line, so the modification time is used.
+func newestWeeklyReport(localReports string) (string, time.Time, error) {
+	var newest string
+	var newestTime time.Time
+
+	consider := func(path string, info fs.FileInfo) {
+		if info.ModTime().After(newestTime) {
+			newest = path
+			newestTime = info.ModTime()
+		}
+	}
+
+	if info, err := os.Stat(buildWeeklyExportPath(localReports)); err == nil {
+		consider(buildWeeklyExportPath(localReports), info)
+	}
+
+	reviewsDir := filepath.Join(localReports, "reviews")
+	err := filepath.WalkDir(reviewsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == reviewsDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), "-weekly-review.md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		consider(path, info)
+		return nil
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read weekly reviews: %w", err)
+	}
+
+	return newest, newestTime, nil
+}
@@ -0,0 +1,110 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDailyStatus(t *testing.T) {
+	dir := t.TempDir()
+	morning := time.Date(2025, 12, 16, 8, 0, 0, 0, time.Local)
+	afternoon := time.Date(2025, 12, 16, 14, 0, 0, 0, time.Local)
+
+	// Never generated: due at the reminder time today
+	status, err := DailyStatus(dir, "09:00", morning)
+	if err != nil {
+		t.Fatalf("DailyStatus() error = %v", err)
+	}
+	if !status.Never() || status.Overdue {
+		t.Errorf("before 09:00 with no review: never=%t overdue=%t, want never and not overdue", status.Never(), status.Overdue)
+	}
+	if want := time.Date(2025, 12, 16, 9, 0, 0, 0, time.Local); !status.Due.Equal(want) {
+		t.Errorf("Due = %v, want %v", status.Due, want)
+	}
+
+	status, _ = DailyStatus(dir, "09:00", afternoon)
+	if !status.Overdue {
+		t.Error("after 09:00 with no review: expected overdue")
+	}
+
+	// Yesterday's review is still overdue today
+	if err := SaveDailyReport("# Daily Review\n\nGenerated: 2025-12-15 07:30\n", DefaultDailyReportPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	status, _ = DailyStatus(dir, "09:00", afternoon)
+	if !status.Overdue {
+		t.Error("with yesterday's review: expected overdue")
+	}
+	if want := time.Date(2025, 12, 15, 7, 30, 0, 0, time.Local); !status.LastGenerated.Equal(want) {
+		t.Errorf("LastGenerated = %v, want %v", status.LastGenerated, want)
+	}
+
+	// Today's review makes the next one due tomorrow
+	if err := SaveDailyReport("# Daily Review\n\nGenerated: 2025-12-16 10:15\n", DefaultDailyReportPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	status, _ = DailyStatus(dir, "09:00", afternoon)
+	if status.Overdue {
+		t.Error("with today's review: expected not overdue")
+	}
+	if want := time.Date(2025, 12, 17, 9, 0, 0, 0, time.Local); !status.Due.Equal(want) {
+		t.Errorf("Due = %v, want %v", status.Due, want)
+	}
+
+	if _, err := DailyStatus(dir, "9am", afternoon); err == nil {
+		t.Error("expected error for invalid reminder time")
+	}
+}
+
+func TestWeeklyStatus(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.Local)
+
+	status, err := WeeklyStatus(dir, 7, now)
+	if err != nil {
+		t.Fatalf("WeeklyStatus() error = %v", err)
+	}
+	if !status.Never() || !status.Overdue {
+		t.Errorf("with no review: never=%t overdue=%t, want both", status.Never(), status.Overdue)
+	}
+
+	writeWeeklyReview(t, dir, "12-December/12-07-2025-weekly-review.md", now.AddDate(0, 0, -13))
+	newer := writeWeeklyReview(t, dir, "12-December/12-14-2025-weekly-review.md", now.AddDate(0, 0, -6))
+
+	status, err = WeeklyStatus(dir, 7, now)
+	if err != nil {
+		t.Fatalf("WeeklyStatus() error = %v", err)
+	}
+	if status.Path != newer {
+		t.Errorf("Path = %q, want %q", status.Path, newer)
+	}
+	if status.Overdue {
+		t.Error("with a review 6 days ago: expected not overdue")
+	}
+
+	status, _ = WeeklyStatus(dir, 5, now)
+	if !status.Overdue {
+		t.Error("with a review 6 days ago and a 5 day interval: expected overdue")
+	}
+
+	if _, err := WeeklyStatus(dir, 0, now); err == nil {
+		t.Error("expected error for a zero day interval")
+	}
+}
+
+func writeWeeklyReview(t *testing.T, dir, name string, modTime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, "reviews", "2025", name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("# Weekly Review\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,191 @@
+package review
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// SuggestOptions configures the day plan suggestion.
+type SuggestOptions struct {
+	// Capacity is how much estimated work fits in the day
+	Capacity time.Duration
+
+	// DefaultEstimate is assumed for tasks without an estimate label
+	DefaultEstimate time.Duration
+}
+
+// SuggestedTask is a task in a suggested day plan.
+type SuggestedTask struct {
+	Task *types.Task
+
+	// Estimate is the task's estimate, or the default estimate if it has
+	// none (Estimated is then false)
+	Estimate  time.Duration
+	Estimated bool
+
+	// Reason says why the task was picked, such as "overdue" or "high priority"
+	Reason string
+}
+
+// Suggestion is a proposed set of tasks for a day.
+type Suggestion struct {
+	Date     time.Time
+	Capacity time.Duration
+
+	// Scheduled are the tasks already scheduled for the day. They use up
+	// capacity before anything is suggested.
+	Scheduled []SuggestedTask
+
+	// Suggested are the tasks proposed for the day, most important first
+	Suggested []SuggestedTask
+}
+
+// Load returns the total estimate of the scheduled and suggested tasks.
+func (s *Suggestion) Load() time.Duration {
+	return totalEstimate(s.Scheduled) + totalEstimate(s.Suggested)
+}
+
+// SuggestDay proposes tasks for targetDate from the open tasks the daily
+// review considers, filling the capacity left by tasks already scheduled
+// for the day. Also returns a map from project ID to name.
+func SuggestDay(ctx context.Context, client *api.Client, targetDate time.Time, daily DailyOptions, opts SuggestOptions) (*Suggestion, map[int]string, error) {
+	results, err := fetchDailyData(ctx, client, targetDate.Format("2006-01-02"), "", false, daily.statuses(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	habitTemplateIDs := buildHabitTemplateSet(results.habits)
+	open := slices.Concat(results.inProgressTasks, results.openTasks)
+	suggestion := buildSuggestion(open, results.scheduledTasks, targetDate, opts, habitTemplateIDs)
+	return suggestion, buildProjectMap(results.projects), nil
+}
+
+// buildSuggestion fills the day with open tasks. Tasks due on or before the
+// day are always suggested, even past capacity; the rest are taken starred
+// first, then by priority and due date, while they fit. Habit tasks, and
+// tasks scheduled for a later day, are left out.
+func buildSuggestion(open, scheduled []*types.Task, targetDate time.Time, opts SuggestOptions, habitTemplateIDs map[int]struct{}) *Suggestion {
+	day := targetDate.Format("2006-01-02")
+	suggestion := &Suggestion{Date: targetDate, Capacity: opts.Capacity}
+
+	isHabit := func(t *types.Task) bool {
+		if t.TemplateID == nil {
+			return false
+		}
+		_, ok := habitTemplateIDs[*t.TemplateID]
+		return ok
+	}
+
+	seen := make(map[int]struct{})
+	for _, t := range scheduled {
+		if t.Status == "done" || t.Status == "canceled" || t.IsSomeday() || isHabit(t) {
+			continue
+		}
+		seen[t.ID] = struct{}{}
+		suggestion.Scheduled = append(suggestion.Scheduled, suggestTask(t, opts, "scheduled"))
+	}
+
+	var candidates []*types.Task
+	for _, t := range open {
+		if _, exists := seen[t.ID]; exists || isHabit(t) {
+			continue
+		}
+		seen[t.ID] = struct{}{}
+		if t.ScheduledDate != nil && t.ScheduledDate.UTC().Format("2006-01-02") >= day {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+	sortSuggestionCandidates(candidates, day)
+
+	remaining := opts.Capacity - totalEstimate(suggestion.Scheduled)
+	for _, t := range candidates {
+		reason, due := suggestReason(t, day)
+		st := suggestTask(t, opts, reason)
+		if !due && st.Estimate > remaining {
+			continue
+		}
+		suggestion.Suggested = append(suggestion.Suggested, st)
+		remaining -= st.Estimate
+	}
+
+	return suggestion
+}
+
+// sortSuggestionCandidates orders tasks due by day first, then starred
+// tasks, then by priority, due date, and ID.
+func sortSuggestionCandidates(tasks []*types.Task, day string) {
+	dueDate := func(t *types.Task) string {
+		if t.DueDate == nil {
+			return "9999-12-31"
+		}
+		return t.DueDate.UTC().Format("2006-01-02")
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		di, dj := dueDate(tasks[i]), dueDate(tasks[j])
+		if dueI, dueJ := di <= day, dj <= day; dueI != dueJ {
+			return dueI
+		}
+		if si, sj := tasks[i].IsStarred(), tasks[j].IsStarred(); si != sj {
+			return si
+		}
+		if pi, pj := priorityRank(tasks[i].Priority), priorityRank(tasks[j].Priority); pi != pj {
+			return pi > pj
+		}
+		if di != dj {
+			return di < dj
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// suggestReason says why a task is suggested, and whether it is due by day
+func suggestReason(t *types.Task, day string) (string, bool) {
+	if t.DueDate != nil {
+		due := t.DueDate.UTC().Format("2006-01-02")
+		switch {
+		case due < day:
+			return "overdue", true
+		case due == day:
+			return "due", true
+		}
+	}
+	switch {
+	case t.IsStarred():
+		return "starred", false
+	case t.Priority != nil && *t.Priority != "":
+		return *t.Priority + " priority", false
+	case t.DueDate != nil:
+		return "due " + t.DueDate.UTC().Format("2006-01-02"), false
+	}
+	return "open", false
+}
+
+// suggestTask pairs a task with its estimate
+func suggestTask(t *types.Task, opts SuggestOptions, reason string) SuggestedTask {
+	estimate, ok := t.Estimate()
+	if !ok {
+		estimate = opts.DefaultEstimate
+	}
+	return SuggestedTask{Task: t, Estimate: estimate, Estimated: ok, Reason: reason}
+}
+
+// totalEstimate adds up the estimates of tasks
+func totalEstimate(tasks []SuggestedTask) time.Duration {
+	var total time.Duration
+	for _, t := range tasks {
+		total += t.Estimate
+	}
+	return total
+}
+
+// priorityRank orders priorities, higher is more important
+func priorityRank(p *string) int {
+	return types.PriorityRank(p)
+}
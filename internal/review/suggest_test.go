@@ -0,0 +1,75 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestBuildSuggestion(t *testing.T) {
+	targetDate := time.Date(2025, 12, 16, 0, 0, 0, 0, time.Local)
+	day := time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)
+	yesterday := day.AddDate(0, 0, -1)
+	later := day.AddDate(0, 0, 5)
+	high, low := "high", "low"
+	templateID := 9
+
+	estimate := func(label string) []types.Label {
+		return []types.Label{{Name: types.EstimateLabelPrefix + label}}
+	}
+	scheduled := []*types.Task{
+		{ID: 1, Title: "Planned", Status: "active", ScheduledDate: &day, Labels: estimate("2h")},
+		{ID: 2, Title: "Finished", Status: "done", ScheduledDate: &day},
+		{ID: 3, Title: "Habit", Status: "active", ScheduledDate: &day, TemplateID: &templateID},
+	}
+	open := []*types.Task{
+		{ID: 1, Title: "Planned", Status: "active", ScheduledDate: &day},
+		{ID: 4, Title: "Low", Priority: &low, Labels: estimate("30m")},
+		{ID: 5, Title: "Big high", Priority: &high, Labels: estimate("4h")},
+		{ID: 6, Title: "Overdue", Priority: &low, DueDate: &yesterday, Labels: estimate("3h")},
+		{ID: 7, Title: "Deferred", Priority: &high, ScheduledDate: &later},
+		{ID: 8, Title: "High", Priority: &high},
+	}
+
+	opts := SuggestOptions{Capacity: 6 * time.Hour, DefaultEstimate: time.Hour}
+	s := buildSuggestion(open, scheduled, targetDate, opts, map[int]struct{}{9: {}})
+
+	if len(s.Scheduled) != 1 || s.Scheduled[0].Task.ID != 1 {
+		t.Fatalf("Expected only task 1 scheduled, got %+v", s.Scheduled)
+	}
+
+	// 2h scheduled + 3h overdue leaves 1h: the 4h task doesn't fit, the
+	// unestimated high one (1h default) does, and nothing is left for 30m
+	want := []int{6, 8}
+	if len(s.Suggested) != len(want) {
+		t.Fatalf("Expected %d suggested tasks, got %d", len(want), len(s.Suggested))
+	}
+	for i, id := range want {
+		if s.Suggested[i].Task.ID != id {
+			t.Errorf("Suggested[%d] = #%d, want #%d", i, s.Suggested[i].Task.ID, id)
+		}
+	}
+	if s.Suggested[0].Reason != "overdue" || s.Suggested[1].Reason != "high priority" {
+		t.Errorf("Unexpected reasons %q, %q", s.Suggested[0].Reason, s.Suggested[1].Reason)
+	}
+	if s.Suggested[1].Estimated {
+		t.Error("Expected task 8 to use the default estimate")
+	}
+	if s.Load() != 6*time.Hour {
+		t.Errorf("Load() = %v, want 6h", s.Load())
+	}
+}
+
+func TestBuildSuggestionDueOverCapacity(t *testing.T) {
+	targetDate := time.Date(2025, 12, 16, 0, 0, 0, 0, time.Local)
+	day := time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)
+	open := []*types.Task{
+		{ID: 1, Title: "Due today", DueDate: &day, Labels: []types.Label{{Name: "estimate:8h"}}},
+	}
+
+	s := buildSuggestion(open, nil, targetDate, SuggestOptions{Capacity: 6 * time.Hour, DefaultEstimate: time.Hour}, nil)
+	if len(s.Suggested) != 1 || s.Suggested[0].Reason != "due" {
+		t.Fatalf("Expected the due task to be suggested past capacity, got %+v", s.Suggested)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/journal"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/internal/sla"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"golang.org/x/sync/errgroup"
 )
@@ -21,12 +24,18 @@ type weeklyReviewData struct {
 	habits         []*types.RecurringTaskTemplate
 	projectMap     map[int]string
 	habitTasks     map[int]map[string]*weeklyHabitTaskInfo // templateID -> date -> taskInfo
+	ratings        journal.Summary
+	projectNotes   []projectNote
+	sla            *sla.Summary             // nil without SLA rules
+	streaks        map[int]recurring.Streak // templateID -> streak; nil leaves the column out
 }
 
 // weeklyHabitTaskInfo holds the task ID and completion status for a habit on a specific day
 type weeklyHabitTaskInfo struct {
 	taskID    int
 	completed bool
+	late      bool // done, but after the habit's completion window
+	skipped   bool
 }
 
 // weeklyAPIResults holds raw results from all API calls for weekly review
@@ -35,11 +44,31 @@ type weeklyAPIResults struct {
 	scheduledTasks []*types.Task
 	habits         []*types.RecurringTaskTemplate
 	projects       []*types.Project
+	journals       []*types.Comment
+	habitHistory   []*types.Task // scheduled tasks in the streak lookback
 }
 
+// WeeklyOptions configures the weekly review.
+type WeeklyOptions struct {
+	// LocalReports is the reports directory holding project notes, which
+	// are included for open projects. Empty leaves notes out.
+	LocalReports string
+
+	// SLA rules add a section counting open tasks past their SLA and tasks
+	// closed late this week. Empty leaves it out.
+	SLA []sla.Rule
+
+	// StreakFreeze is how many missed occurrences in any 7 days a habit
+	// streak survives (see recurring.Streaks).
+	StreakFreeze int
+}
+
+// streakLookback is how many days back habit streaks are counted
+const streakLookback = 90
+
 // WeeklyReport generates a weekly review report and returns the markdown content
 // startDate is the first day of the 7-day period
-func WeeklyReport(ctx context.Context, client *api.Client, startDate time.Time) (string, error) {
+func WeeklyReport(ctx context.Context, client *api.Client, startDate time.Time, opts WeeklyOptions) (string, error) {
 	start, end := getWeekBoundaries(startDate)
 
 	// Fetch all data in parallel
@@ -55,7 +84,7 @@ func WeeklyReport(ctx context.Context, client *api.Client, startDate time.Time)
 	habitTemplateIDs := buildHabitTemplateSet(results.habits)
 
 	// Build habit task map: templateID -> date -> taskInfo
-	habitTasks := buildWeeklyHabitTaskMap(results.scheduledTasks, habitTemplateIDs)
+	habitTasks := buildWeeklyHabitTaskMap(results.scheduledTasks, results.habits)
 
 	// Filter completed tasks to exclude habit tasks
 	completedTasks := filterNonHabitTasks(results.completedTasks, habitTemplateIDs)
@@ -67,6 +96,18 @@ func WeeklyReport(ctx context.Context, client *api.Client, startDate time.Time)
 		habits:         results.habits,
 		projectMap:     projectMap,
 		habitTasks:     habitTasks,
+		ratings:        journal.Summarize(results.journals),
+		projectNotes:   loadWeeklyProjectNotes(opts.LocalReports, results.projects),
+		streaks:        buildHabitStreaks(results.habits, results.habitHistory, end, opts.StreakFreeze),
+	}
+
+	if len(opts.SLA) > 0 {
+		open, err := sla.ListTasks(ctx, client, opts.SLA)
+		if err != nil {
+			return "", err
+		}
+		summary := sla.Summarize(open, completedTasks, opts.SLA, time.Now())
+		data.sla = &summary
 	}
 
 	return generateWeeklyReviewMarkdown(data), nil
@@ -83,6 +124,12 @@ func BuildWeeklyReportPath(localReportsPath string, startDate time.Time) string
 	return buildDatedWeeklyExportPath(expandPath(localReportsPath), start, end)
 }
 
+// WeekBoundaries returns the first and last days of the 7-day period a
+// weekly review for date covers
+func WeekBoundaries(date time.Time) (start, end time.Time) {
+	return getWeekBoundaries(date)
+}
+
 // buildWeeklyExportPath constructs the output file path for the weekly review (simple path)
 func buildWeeklyExportPath(localReports string) string {
 	return localReports + "/weekly-review.md"
@@ -163,6 +210,27 @@ func fetchWeeklyReviewData(ctx context.Context, client *api.Client, start, end t
 		return err
 	})
 
+	// 5. Journal entries (for mood and energy)
+	g.Go(func() error {
+		var err error
+		results.journals, err = client.ListCommentsFiltered(ctx, &api.CommentListOptions{
+			Type:          "journal",
+			CreatedAfter:  startStr,
+			CreatedBefore: endPlusOne,
+		})
+		return err
+	})
+
+	// 6. Habit occurrences for streaks
+	g.Go(func() error {
+		var err error
+		results.habitHistory, err = client.ListAllTasks(ctx, &api.TaskListOptions{
+			ScheduledAfter:  end.AddDate(0, 0, -streakLookback).Format("2006-01-02"),
+			ScheduledBefore: endPlusOne,
+		})
+		return err
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, fmt.Errorf("failed to fetch weekly review data: %w", err)
 	}
@@ -170,15 +238,22 @@ func fetchWeeklyReviewData(ctx context.Context, client *api.Client, start, end t
 	return results, nil
 }
 
-// buildWeeklyHabitTaskMap creates a map from template ID to date to task info
-func buildWeeklyHabitTaskMap(scheduledTasks []*types.Task, habitTemplateIDs map[int]struct{}) map[int]map[string]*weeklyHabitTaskInfo {
+// buildWeeklyHabitTaskMap creates a map from template ID to date to task info.
+// Occurrences only count as completed when done within their habit's
+// completion window.
+func buildWeeklyHabitTaskMap(scheduledTasks []*types.Task, habits []*types.RecurringTaskTemplate) map[int]map[string]*weeklyHabitTaskInfo {
 	habitTasks := make(map[int]map[string]*weeklyHabitTaskInfo)
+	windows := make(map[int]recurring.Window, len(habits))
+	for _, h := range habits {
+		windows[h.ID] = recurring.TemplateWindow(h)
+	}
 
 	for _, t := range scheduledTasks {
 		if t.TemplateID == nil || t.ScheduledDate == nil {
 			continue
 		}
-		if _, isHabit := habitTemplateIDs[*t.TemplateID]; !isHabit {
+		window, isHabit := windows[*t.TemplateID]
+		if !isHabit {
 			continue
 		}
 
@@ -189,9 +264,13 @@ func buildWeeklyHabitTaskMap(scheduledTasks []*types.Task, habitTemplateIDs map[
 			habitTasks[templateID] = make(map[string]*weeklyHabitTaskInfo)
 		}
 
+		_, skipped := t.Skipped()
+		completed := window.OnTime(t)
 		habitTasks[templateID][dateStr] = &weeklyHabitTaskInfo{
 			taskID:    t.ID,
-			completed: t.Status == "done",
+			completed: completed,
+			late:      t.Status == "done" && !completed,
+			skipped:   skipped,
 		}
 	}
 
@@ -224,12 +303,18 @@ func generateWeeklyReviewMarkdown(data *weeklyReviewData) string {
 	// Projects Worked On section
 	writeProjectSummaries(&sb, data.completedTasks, data.projectMap)
 
+	// Project Notes section
+	writeProjectNotes(&sb, data.projectNotes)
+
 	// Habits Summary section
 	writeHabitsSummary(&sb, data)
 
 	// Weekly Stats section
 	writeWeeklyStats(&sb, data)
 
+	// SLA section
+	writeSLASummary(&sb, data.sla)
+
 	return sb.String()
 }
 
@@ -277,6 +362,36 @@ func writeProjectSummaries(sb *strings.Builder, tasks []*types.Task, projectMap
 	}
 }
 
+// buildHabitStreaks works out each habit's streak as of the end of the week
+func buildHabitStreaks(habits []*types.RecurringTaskTemplate, history []*types.Task, end time.Time, freeze int) map[int]recurring.Streak {
+	byTemplate := make(map[int][]*types.Task)
+	for _, t := range history {
+		if t.TemplateID != nil {
+			byTemplate[*t.TemplateID] = append(byTemplate[*t.TemplateID], t)
+		}
+	}
+	streaks := make(map[int]recurring.Streak, len(habits))
+	for _, habit := range habits {
+		streaks[habit.ID] = recurring.Streaks(byTemplate[habit.ID], end, freeze, recurring.TemplateWindow(habit))
+	}
+	return streaks
+}
+
+// formatStreak describes a streak for the habits table
+func formatStreak(streak recurring.Streak) string {
+	var notes []string
+	if streak.Frozen > 0 {
+		notes = append(notes, fmt.Sprintf("%d frozen", streak.Frozen))
+	}
+	if streak.Skipped > 0 {
+		notes = append(notes, fmt.Sprintf("%d skipped", streak.Skipped))
+	}
+	if len(notes) > 0 {
+		return fmt.Sprintf("%d (%s)", streak.Current, strings.Join(notes, ", "))
+	}
+	return fmt.Sprintf("%d", streak.Current)
+}
+
 // writeHabitsSummary writes the habits summary table
 func writeHabitsSummary(sb *strings.Builder, data *weeklyReviewData) {
 	sb.WriteString("---\n\n")
@@ -298,6 +413,9 @@ func writeHabitsSummary(sb *strings.Builder, data *weeklyReviewData) {
 	for _, day := range days {
 		sb.WriteString(fmt.Sprintf(" %s |", day.Format("Mon")))
 	}
+	if data.streaks != nil {
+		sb.WriteString(" Streak |")
+	}
 	sb.WriteString("\n")
 
 	// Table separator
@@ -305,6 +423,9 @@ func writeHabitsSummary(sb *strings.Builder, data *weeklyReviewData) {
 	for range days {
 		sb.WriteString("-----|")
 	}
+	if data.streaks != nil {
+		sb.WriteString("--------|")
+	}
 	sb.WriteString("\n")
 
 	// Table rows for each habit
@@ -319,6 +440,10 @@ func writeHabitsSummary(sb *strings.Builder, data *weeklyReviewData) {
 				if taskInfo, ok := dayTasks[dateStr]; ok {
 					if taskInfo.completed {
 						symbol = "✓"
+					} else if taskInfo.late {
+						symbol = "(✓)" // done after its completion window
+					} else if taskInfo.skipped {
+						symbol = "–" // skipped for a reason
 					} else {
 						symbol = "○" // scheduled but not done
 					}
@@ -327,6 +452,9 @@ func writeHabitsSummary(sb *strings.Builder, data *weeklyReviewData) {
 
 			sb.WriteString(fmt.Sprintf(" %s |", symbol))
 		}
+		if data.streaks != nil {
+			sb.WriteString(fmt.Sprintf(" %s |", formatStreak(data.streaks[habit.ID])))
+		}
 		sb.WriteString("\n")
 	}
 	sb.WriteString("\n")
@@ -341,12 +469,18 @@ func writeWeeklyStats(sb *strings.Builder, data *weeklyReviewData) {
 	tasksCompleted := len(data.completedTasks)
 
 	// Count habit completions
+	// Skipped occurrences are excused, so they aren't counted as possible
 	habitsCompleted := 0
 	habitsPossible := 0
+	habitsSkipped := 0
 
 	for _, habit := range data.habits {
 		if dayTasks, ok := data.habitTasks[habit.ID]; ok {
 			for _, taskInfo := range dayTasks {
+				if taskInfo.skipped {
+					habitsSkipped++
+					continue
+				}
 				habitsPossible++
 				if taskInfo.completed {
 					habitsCompleted++
@@ -356,9 +490,39 @@ func writeWeeklyStats(sb *strings.Builder, data *weeklyReviewData) {
 	}
 
 	sb.WriteString(fmt.Sprintf("- **Tasks Completed**: %d\n", tasksCompleted))
-	if habitsPossible > 0 {
-		sb.WriteString(fmt.Sprintf("- **Habits Completed**: %d/%d\n", habitsCompleted, habitsPossible))
+	if habitsPossible > 0 || habitsSkipped > 0 {
+		sb.WriteString(fmt.Sprintf("- **Habits Completed**: %d/%d", habitsCompleted, habitsPossible))
+		if habitsSkipped > 0 {
+			sb.WriteString(fmt.Sprintf(" (%d skipped)", habitsSkipped))
+		}
+		sb.WriteString("\n")
 	} else {
 		sb.WriteString("- **Habits Completed**: 0\n")
 	}
+	if data.ratings.MoodCount > 0 {
+		sb.WriteString(fmt.Sprintf("- **Average Mood**: %.1f (%d entries)\n", data.ratings.Mood, data.ratings.MoodCount))
+	}
+	if data.ratings.EnergyCount > 0 {
+		sb.WriteString(fmt.Sprintf("- **Average Energy**: %.1f (%d entries)\n", data.ratings.Energy, data.ratings.EnergyCount))
+	}
+}
+
+// writeSLASummary writes the SLA section with breach counts by label
+func writeSLASummary(sb *strings.Builder, summary *sla.Summary) {
+	if summary == nil {
+		return
+	}
+	sb.WriteString("\n---\n\n")
+	sb.WriteString("## SLA\n\n")
+	sb.WriteString(fmt.Sprintf("- **Open Tasks Breaching**: %d/%d\n", summary.Breaching, summary.Open))
+	sb.WriteString(fmt.Sprintf("- **Closed Late This Week**: %d\n", summary.ClosedLate))
+
+	labels := make([]string, 0, len(summary.ByLabel))
+	for label := range summary.ByLabel {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		sb.WriteString(fmt.Sprintf("  - %s: %d\n", label, summary.ByLabel[label]))
+	}
 }
@@ -5,6 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcraddock/todu.sh/internal/journal"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/internal/sla"
 	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
@@ -82,12 +85,9 @@ func TestBuildWeeklyHabitTaskMap(t *testing.T) {
 		{ID: 105, TemplateID: nil, ScheduledDate: &scheduledDate1, Status: "done"},          // No template
 	}
 
-	habitTemplateIDs := map[int]struct{}{
-		1: {},
-		2: {},
-	}
+	habits := []*types.RecurringTaskTemplate{{ID: 1}, {ID: 2}}
 
-	result := buildWeeklyHabitTaskMap(scheduledTasks, habitTemplateIDs)
+	result := buildWeeklyHabitTaskMap(scheduledTasks, habits)
 
 	// Check template 1 has two days
 	if len(result[1]) != 2 {
@@ -290,6 +290,73 @@ func TestWriteHabitsSummary_DayHeaders(t *testing.T) {
 	}
 }
 
+func TestWeeklyReview_SkippedHabits(t *testing.T) {
+	data := &weeklyReviewData{
+		startDate: time.Date(2025, 12, 21, 0, 0, 0, 0, time.Local),
+		endDate:   time.Date(2025, 12, 27, 0, 0, 0, 0, time.Local),
+		habits: []*types.RecurringTaskTemplate{
+			{ID: 1, Title: "Exercise"},
+		},
+		habitTasks: map[int]map[string]*weeklyHabitTaskInfo{
+			1: {
+				"2025-12-21": {taskID: 100, completed: true},
+				"2025-12-22": {taskID: 101, skipped: true},
+				"2025-12-23": {taskID: 102},
+			},
+		},
+		streaks: map[int]recurring.Streak{1: {Current: 1, Longest: 1, Skipped: 1}},
+	}
+
+	var sb strings.Builder
+	writeHabitsSummary(&sb, data)
+	writeWeeklyStats(&sb, data)
+	result := sb.String()
+
+	if !strings.Contains(result, "| Exercise | ✓ | – | ○ | - |") {
+		t.Errorf("Expected an en dash for the skipped day, got:\n%s", result)
+	}
+	if !strings.Contains(result, " 1 (1 skipped) |") {
+		t.Errorf("Expected the streak to note the skip, got:\n%s", result)
+	}
+	if !strings.Contains(result, "**Habits Completed**: 1/2 (1 skipped)") {
+		t.Errorf("Expected skipped occurrences left out of the possible count, got:\n%s", result)
+	}
+}
+
+func TestWeeklyReview_CompletionWindow(t *testing.T) {
+	templateID := 1
+	sunday := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC)
+	habits := []*types.RecurringTaskTemplate{
+		{ID: 1, Title: "Review", Labels: []types.Label{{Name: "grace:2d"}}},
+	}
+	scheduled := []*types.Task{
+		// Done on Tuesday, within two days
+		{ID: 100, TemplateID: &templateID, ScheduledDate: &sunday, Status: "done", UpdatedAt: time.Date(2025, 12, 23, 18, 0, 0, 0, time.Local)},
+		// Done on Friday, after its window
+		{ID: 101, TemplateID: &templateID, ScheduledDate: &monday, Status: "done", UpdatedAt: time.Date(2025, 12, 26, 9, 0, 0, 0, time.Local)},
+	}
+
+	data := &weeklyReviewData{
+		startDate:  time.Date(2025, 12, 21, 0, 0, 0, 0, time.Local),
+		endDate:    time.Date(2025, 12, 27, 0, 0, 0, 0, time.Local),
+		habits:     habits,
+		habitTasks: buildWeeklyHabitTaskMap(scheduled, habits),
+	}
+
+	var sb strings.Builder
+	writeHabitsSummary(&sb, data)
+	writeWeeklyStats(&sb, data)
+	result := sb.String()
+
+	if !strings.Contains(result, "| Review | ✓ | (✓) | - |") {
+		t.Errorf("Expected the late occurrence marked, got:\n%s", result)
+	}
+	if !strings.Contains(result, "**Habits Completed**: 1/2") {
+		t.Errorf("Expected only the occurrence done in its window to count, got:\n%s", result)
+	}
+}
+
 func TestDefaultWeeklyReportPath(t *testing.T) {
 	result := DefaultWeeklyReportPath("/home/user/reports")
 	expected := "/home/user/reports/weekly-review.md"
@@ -368,3 +435,45 @@ func TestWriteProjectSummaries_SortedByProjectID(t *testing.T) {
 		t.Error("Projects should be sorted by ID (Alpha < Beta < Gamma)")
 	}
 }
+
+func TestWriteWeeklyStats_Ratings(t *testing.T) {
+	data := &weeklyReviewData{
+		ratings: journal.Summarize([]*types.Comment{
+			{Content: "mood:4 energy:3"},
+			{Content: "mood:3"},
+		}),
+	}
+
+	var sb strings.Builder
+	writeWeeklyStats(&sb, data)
+
+	if !strings.Contains(sb.String(), "- **Average Mood**: 3.5 (2 entries)\n") {
+		t.Errorf("Expected average mood, got:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), "- **Average Energy**: 3.0 (1 entries)\n") {
+		t.Errorf("Expected average energy, got:\n%s", sb.String())
+	}
+
+	sb.Reset()
+	writeWeeklyStats(&sb, &weeklyReviewData{})
+	if strings.Contains(sb.String(), "Mood") {
+		t.Errorf("Expected no mood without ratings, got:\n%s", sb.String())
+	}
+}
+
+func TestWriteSLASummary(t *testing.T) {
+	var sb strings.Builder
+	writeSLASummary(&sb, &sla.Summary{Open: 5, Breaching: 3, ClosedLate: 1, ByLabel: map[string]int{"security": 1, "bug": 2}})
+
+	for _, want := range []string{"## SLA\n", "- **Open Tasks Breaching**: 3/5\n", "- **Closed Late This Week**: 1\n", "  - bug: 2\n  - security: 1\n"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("Expected %q, got:\n%s", want, sb.String())
+		}
+	}
+
+	sb.Reset()
+	writeSLASummary(&sb, nil)
+	if sb.Len() != 0 {
+		t.Errorf("Expected no SLA section without rules, got:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// ActionKind identifies what an action does
+type ActionKind string
+
+// Action kinds
+const (
+	ActionAssign      ActionKind = "assign"
+	ActionUnassign    ActionKind = "unassign"
+	ActionAddLabel    ActionKind = "add label"
+	ActionRemoveLabel ActionKind = "remove label"
+	ActionSetPriority ActionKind = "set priority"
+	ActionSetStatus   ActionKind = "set status"
+	ActionComment     ActionKind = "comment"
+)
+
+// Me is the assignee name replaced with the configured author
+const Me = "me"
+
+// Action is a parsed "then" entry
+type Action struct {
+	Kind  ActionKind
+	Value string
+}
+
+// String returns the action as it would be written in a rules file
+func (a Action) String() string {
+	if a.Kind == ActionComment {
+		return fmt.Sprintf("%s %q", a.Kind, a.Value)
+	}
+	return fmt.Sprintf("%s %s", a.Kind, a.Value)
+}
+
+// actionPrefixes lists action kinds longest first, so "add label" is tried
+// before shorter prefixes
+var actionPrefixes = []ActionKind{
+	ActionRemoveLabel,
+	ActionSetPriority,
+	ActionSetStatus,
+	ActionAddLabel,
+	ActionUnassign,
+	ActionComment,
+	ActionAssign,
+}
+
+// ParseAction parses an action such as `assign me`, `add label triaged`,
+// `set priority high`, or `comment "auto-triaged"`. Quotes around the
+// value are optional.
+func ParseAction(text string) (Action, error) {
+	trimmed := strings.TrimSpace(text)
+	lower := strings.ToLower(trimmed)
+
+	for _, kind := range actionPrefixes {
+		prefix := string(kind)
+		if !strings.HasPrefix(lower, prefix+" ") {
+			continue
+		}
+		value := unquote(strings.TrimSpace(trimmed[len(prefix):]))
+		if value == "" {
+			return Action{}, fmt.Errorf("action %q needs a value", kind)
+		}
+		if kind == ActionSetPriority {
+			value = strings.ToLower(value)
+			if err := types.ValidatePriority(value); err != nil {
+				return Action{}, err
+			}
+		}
+		return Action{Kind: kind, Value: value}, nil
+	}
+
+	return Action{}, fmt.Errorf("unknown action %q (valid: assign, unassign, add label, remove label, set priority, set status, comment)", text)
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
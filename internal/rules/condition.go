@@ -0,0 +1,293 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Condition is a parsed "when" expression
+type Condition interface {
+	Match(s Subject) bool
+}
+
+// Subject is what conditions are evaluated against: a task and the name of
+// its project.
+type Subject struct {
+	Task    *types.Task
+	Project string
+}
+
+// Fields that can appear in conditions
+var conditionFields = map[string]struct{}{
+	"label":       {},
+	"assignee":    {},
+	"priority":    {},
+	"status":      {},
+	"project":     {},
+	"title":       {},
+	"description": {},
+	"external_id": {},
+}
+
+// comparison is a single "field op value" test
+type comparison struct {
+	field string
+	op    string
+	value string
+}
+
+func (c *comparison) Match(s Subject) bool {
+	values := fieldValues(s, c.field)
+	// != on a multi-valued field means none of the values are equal
+	if c.op == "!=" {
+		for _, v := range values {
+			if strings.EqualFold(v, c.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compareValue(v, c.op, c.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValue(actual, op, want string) bool {
+	switch op {
+	case "==":
+		return strings.EqualFold(actual, want)
+	case "~=":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(want))
+	}
+	return false
+}
+
+// fieldValues returns the values of a field on the subject. Labels and
+// assignees can have several values; other fields have one.
+func fieldValues(s Subject, field string) []string {
+	t := s.Task
+	switch field {
+	case "label":
+		names := make([]string, len(t.Labels))
+		for i, l := range t.Labels {
+			names[i] = l.Name
+		}
+		return names
+	case "assignee":
+		names := make([]string, len(t.Assignees))
+		for i, a := range t.Assignees {
+			names[i] = a.Name
+		}
+		return names
+	case "priority":
+		return []string{deref(t.Priority)}
+	case "status":
+		return []string{t.Status}
+	case "project":
+		return []string{s.Project}
+	case "title":
+		return []string{t.Title}
+	case "description":
+		return []string{deref(t.Description)}
+	case "external_id":
+		return []string{t.ExternalID}
+	}
+	return nil
+}
+
+type andCondition struct{ left, right Condition }
+
+func (c *andCondition) Match(s Subject) bool { return c.left.Match(s) && c.right.Match(s) }
+
+type orCondition struct{ left, right Condition }
+
+func (c *orCondition) Match(s Subject) bool { return c.left.Match(s) || c.right.Match(s) }
+
+type notCondition struct{ inner Condition }
+
+func (c *notCondition) Match(s Subject) bool { return !c.inner.Match(s) }
+
+// ParseCondition parses a "when" expression such as
+// `label==bug and priority==high`. Comparisons use == (equals), != (not
+// equal), or ~= (contains), and are case-insensitive. They can be combined
+// with and, or, not, and parentheses; and binds tighter than or. Values
+// containing spaces must be quoted.
+func ParseCondition(expr string) (Condition, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty condition")
+	}
+
+	p := &parser{tokens: tokens}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return cond, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string in %q", expr)
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i+1 : end])})
+			i = end + 1
+		case i+1 < len(runes) && (r == '=' || r == '!' || r == '~') && runes[i+1] == '=':
+			tokens = append(tokens, token{tokenOp, string(runes[i : i+2])})
+			i += 2
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()\"'", runes[i]) &&
+				!(i+1 < len(runes) && strings.ContainsRune("=!~", runes[i]) && runes[i+1] == '=') {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected %q in %q", string(r), expr)
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peekKeyword(word string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+func (p *parser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Condition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Condition, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+	if p.peekKeyword("not") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notCondition{inner}, nil
+	}
+	if p.tokens[p.pos].kind == tokenLParen {
+		p.pos++
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenRParen {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return cond, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Condition, error) {
+	if p.pos+3 > len(p.tokens) {
+		return nil, fmt.Errorf("incomplete comparison at %q", p.tokens[p.pos].text)
+	}
+	field, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if field.kind != tokenWord {
+		return nil, fmt.Errorf("expected a field name, got %q", field.text)
+	}
+	name := strings.ToLower(field.text)
+	if _, ok := conditionFields[name]; !ok {
+		return nil, fmt.Errorf("unknown field %q", field.text)
+	}
+	if op.kind != tokenOp {
+		return nil, fmt.Errorf("expected ==, !=, or ~= after %q", field.text)
+	}
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, fmt.Errorf("expected a value after %s%s", field.text, op.text)
+	}
+	p.pos += 3
+	return &comparison{field: name, op: op.text, value: value.text}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
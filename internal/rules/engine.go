@@ -0,0 +1,347 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// CommentAuthor is the author of comments added by rules
+const CommentAuthor = "system"
+
+// Client is the API access needed to apply rules
+type Client interface {
+	ListTasks(ctx context.Context, opts *api.TaskListOptions) ([]*types.Task, error)
+	ListProjects(ctx context.Context, opts *api.ProjectListOptions) ([]*types.Project, error)
+	UpdateTask(ctx context.Context, id int, task *types.TaskUpdate) (*types.Task, error)
+	ListComments(ctx context.Context, taskID int) ([]*types.Comment, error)
+	CreateComment(ctx context.Context, comment *types.CommentCreate) (*types.Comment, error)
+}
+
+// Engine evaluates rules against tasks
+type Engine struct {
+	rules []*Rule
+	me    string
+}
+
+// NewEngine creates an engine for rules. me is the name used for
+// "assign me" and "unassign me".
+func NewEngine(rules []*Rule, me string) *Engine {
+	return &Engine{rules: rules, me: me}
+}
+
+// Rules returns the engine's rules
+func (e *Engine) Rules() []*Rule {
+	return e.rules
+}
+
+// Outcome describes what rules do to one task
+type Outcome struct {
+	TaskID int `json:"task_id"`
+
+	// Rules lists the names of the rules that applied
+	Rules []string `json:"rules"`
+
+	// Update holds the field changes, or nil if there are none
+	Update *types.TaskUpdate `json:"update,omitempty"`
+
+	// Comments lists the comments to add
+	Comments []string `json:"comments,omitempty"`
+}
+
+// Changed reports whether any rule applied
+func (o *Outcome) Changed() bool {
+	return len(o.Rules) > 0
+}
+
+// Evaluate works out what the rules run for trigger would do to the
+// subject's task, without changing anything. An empty trigger evaluates
+// every rule. Rules run in order, and each sees the changes made by the
+// ones before it. comments are the task's existing comments; a comment
+// action whose text is already there does nothing.
+func (e *Engine) Evaluate(s Subject, comments []*types.Comment, trigger Trigger) (*Outcome, error) {
+	state := newTaskState(s.Task, comments)
+	outcome := &Outcome{TaskID: s.Task.ID}
+
+	for _, r := range e.rules {
+		if trigger != "" && !r.RunsOn(trigger) {
+			continue
+		}
+		if !r.Matches(Subject{Task: state.task, Project: s.Project}) {
+			continue
+		}
+
+		next := state.clone()
+		changed := false
+		for _, a := range r.Actions {
+			c, err := next.apply(a, e.me)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+			}
+			changed = changed || c
+		}
+		if changed {
+			state = next
+			outcome.Rules = append(outcome.Rules, r.Name)
+		}
+	}
+
+	outcome.Update = state.update(s.Task)
+	outcome.Comments = state.newComments
+	return outcome, nil
+}
+
+// Preview is like Evaluate, looking up the task's comments when rules
+// would add one.
+func (e *Engine) Preview(ctx context.Context, client Client, s Subject, trigger Trigger) (*Outcome, error) {
+	outcome, err := e.Evaluate(s, nil, trigger)
+	if err != nil || len(outcome.Comments) == 0 {
+		return outcome, err
+	}
+
+	comments, err := client.ListComments(ctx, s.Task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments for task #%d: %w", s.Task.ID, err)
+	}
+	return e.Evaluate(s, comments, trigger)
+}
+
+// Apply evaluates the rules run for trigger against the subject's task and
+// makes the resulting changes.
+func (e *Engine) Apply(ctx context.Context, client Client, s Subject, trigger Trigger) (*Outcome, error) {
+	outcome, err := e.Preview(ctx, client, s, trigger)
+	if err != nil || !outcome.Changed() {
+		return outcome, err
+	}
+
+	if outcome.Update != nil {
+		if _, err := client.UpdateTask(ctx, s.Task.ID, outcome.Update); err != nil {
+			return outcome, fmt.Errorf("failed to update task #%d: %w", s.Task.ID, err)
+		}
+	}
+	for _, text := range outcome.Comments {
+		taskID := s.Task.ID
+		if _, err := client.CreateComment(ctx, &types.CommentCreate{TaskID: &taskID, Content: text, Author: CommentAuthor}); err != nil {
+			return outcome, fmt.Errorf("failed to comment on task #%d: %w", s.Task.ID, err)
+		}
+	}
+	return outcome, nil
+}
+
+// ApplyUpdatedSince applies sync rules to every task updated at or after
+// since, such as the tasks touched by a sync run.
+func (e *Engine) ApplyUpdatedSince(ctx context.Context, client Client, since time.Time) ([]*Outcome, error) {
+	if len(e.rules) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := client.ListTasks(ctx, &api.TaskListOptions{UpdatedAfter: since.UTC().Format(time.RFC3339)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list updated tasks: %w", err)
+	}
+	projectNames, err := ProjectNames(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var outcomes []*Outcome
+	for _, task := range tasks {
+		outcome, err := e.Apply(ctx, client, Subject{Task: task, Project: projectNames[task.ProjectID]}, TriggerSync)
+		if err != nil {
+			return outcomes, err
+		}
+		if outcome.Changed() {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes, nil
+}
+
+// ProjectNames returns project names keyed by ID, for building subjects
+func ProjectNames(ctx context.Context, client Client) (map[int]string, error) {
+	projects, err := client.ListProjects(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	names := make(map[int]string, len(projects))
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	return names, nil
+}
+
+// taskState is a working copy of the fields rules can change
+type taskState struct {
+	task        *types.Task
+	comments    map[string]struct{}
+	newComments []string
+}
+
+func newTaskState(task *types.Task, comments []*types.Comment) *taskState {
+	existing := make(map[string]struct{}, len(comments))
+	for _, c := range comments {
+		existing[strings.TrimSpace(c.Content)] = struct{}{}
+	}
+	copied := *task
+	copied.Labels = append([]types.Label(nil), task.Labels...)
+	copied.Assignees = append([]types.Assignee(nil), task.Assignees...)
+	return &taskState{task: &copied, comments: existing}
+}
+
+func (s *taskState) clone() *taskState {
+	copied := *s.task
+	copied.Labels = append([]types.Label(nil), s.task.Labels...)
+	copied.Assignees = append([]types.Assignee(nil), s.task.Assignees...)
+	comments := make(map[string]struct{}, len(s.comments))
+	for c := range s.comments {
+		comments[c] = struct{}{}
+	}
+	return &taskState{
+		task:        &copied,
+		comments:    comments,
+		newComments: append([]string(nil), s.newComments...),
+	}
+}
+
+// apply applies an action to the working copy, reporting whether it
+// changed anything
+func (s *taskState) apply(a Action, me string) (bool, error) {
+	value := a.Value
+	if (a.Kind == ActionAssign || a.Kind == ActionUnassign) && strings.EqualFold(value, Me) {
+		if me == "" {
+			return false, fmt.Errorf("%q needs author set in config", a.String())
+		}
+		value = me
+	}
+
+	switch a.Kind {
+	case ActionAssign:
+		if hasAssignee(s.task.Assignees, value) {
+			return false, nil
+		}
+		s.task.Assignees = append(s.task.Assignees, types.Assignee{Name: value})
+	case ActionUnassign:
+		if !hasAssignee(s.task.Assignees, value) {
+			return false, nil
+		}
+		var kept []types.Assignee
+		for _, as := range s.task.Assignees {
+			if !strings.EqualFold(as.Name, value) {
+				kept = append(kept, as)
+			}
+		}
+		s.task.Assignees = kept
+	case ActionAddLabel:
+		if hasLabel(s.task.Labels, value) {
+			return false, nil
+		}
+		s.task.Labels = append(s.task.Labels, types.Label{Name: value})
+	case ActionRemoveLabel:
+		if !hasLabel(s.task.Labels, value) {
+			return false, nil
+		}
+		var kept []types.Label
+		for _, l := range s.task.Labels {
+			if !strings.EqualFold(l.Name, value) {
+				kept = append(kept, l)
+			}
+		}
+		s.task.Labels = kept
+	case ActionSetPriority:
+		if strings.EqualFold(deref(s.task.Priority), value) {
+			return false, nil
+		}
+		s.task.Priority = &value
+	case ActionSetStatus:
+		if strings.EqualFold(s.task.Status, value) {
+			return false, nil
+		}
+		s.task.Status = value
+	case ActionComment:
+		text := strings.TrimSpace(value)
+		if _, ok := s.comments[text]; ok {
+			return false, nil
+		}
+		s.comments[text] = struct{}{}
+		s.newComments = append(s.newComments, text)
+	default:
+		return false, fmt.Errorf("unsupported action %q", a.Kind)
+	}
+	return true, nil
+}
+
+// update returns the task update that turns original into the working
+// copy, or nil if no fields changed
+func (s *taskState) update(original *types.Task) *types.TaskUpdate {
+	update := &types.TaskUpdate{}
+	changed := false
+
+	if !sameLabels(original.Labels, s.task.Labels) {
+		update.Labels = labelNames(s.task.Labels)
+		changed = true
+	}
+	if !sameAssignees(original.Assignees, s.task.Assignees) {
+		update.Assignees = assigneeNames(s.task.Assignees)
+		changed = true
+	}
+	if deref(original.Priority) != deref(s.task.Priority) {
+		update.Priority = s.task.Priority
+		changed = true
+	}
+	if original.Status != s.task.Status {
+		status := s.task.Status
+		update.Status = &status
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+func hasLabel(labels []types.Label, name string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAssignee(assignees []types.Assignee, name string) bool {
+	for _, a := range assignees {
+		if strings.EqualFold(a.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func labelNames(labels []types.Label) []string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+func assigneeNames(assignees []types.Assignee) []string {
+	names := make([]string, len(assignees))
+	for i, a := range assignees {
+		names[i] = a.Name
+	}
+	return names
+}
+
+func sameLabels(a, b []types.Label) bool {
+	return strings.Join(labelNames(a), "\x00") == strings.Join(labelNames(b), "\x00")
+}
+
+func sameAssignees(a, b []types.Assignee) bool {
+	return strings.Join(assigneeNames(a), "\x00") == strings.Join(assigneeNames(b), "\x00")
+}
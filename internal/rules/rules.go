@@ -0,0 +1,179 @@
+// Package rules evaluates declarative rules that act on tasks automatically.
+//
+// Rules are read from a YAML file:
+//
+//	rules:
+//	  - name: triage high priority bugs
+//	    when: label==bug and priority==high
+//	    then:
+//	      - assign me
+//	      - add label triaged
+//	      - comment "auto-triaged"
+//
+// A rule applies when its condition matches and at least one of its actions
+// would change the task, so rules are safe to evaluate repeatedly.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Trigger is the event that caused rules to be evaluated
+type Trigger string
+
+// Triggers
+const (
+	TriggerCreate Trigger = "create"
+	TriggerUpdate Trigger = "update"
+	TriggerSync   Trigger = "sync"
+)
+
+var allTriggers = []Trigger{TriggerCreate, TriggerUpdate, TriggerSync}
+
+// ruleFile is the on-disk format of a rules file
+type ruleFile struct {
+	Rules []ruleConfig `yaml:"rules"`
+}
+
+type ruleConfig struct {
+	Name string   `yaml:"name"`
+	When string   `yaml:"when"`
+	Then []string `yaml:"then"`
+	On   []string `yaml:"on"`
+}
+
+// Rule is a parsed rule
+type Rule struct {
+	Name    string
+	When    string
+	Actions []Action
+
+	condition Condition
+	triggers  map[Trigger]struct{}
+}
+
+// Matches reports whether the rule's condition matches the subject
+func (r *Rule) Matches(s Subject) bool {
+	return r.condition.Match(s)
+}
+
+// RunsOn reports whether the rule is evaluated for trigger
+func (r *Rule) RunsOn(trigger Trigger) bool {
+	_, ok := r.triggers[trigger]
+	return ok
+}
+
+// Triggers returns the events the rule is evaluated on
+func (r *Rule) Triggers() []Trigger {
+	var triggers []Trigger
+	for _, t := range allTriggers {
+		if r.RunsOn(t) {
+			triggers = append(triggers, t)
+		}
+	}
+	return triggers
+}
+
+// DefaultPath returns the default rules file location
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "todu", "rules.yaml"), nil
+}
+
+// ResolvePath returns the rules file to use: path with ~ expanded, or the
+// default location if path is empty.
+func ResolvePath(path string) (string, error) {
+	if path == "" {
+		return DefaultPath()
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	return path, nil
+}
+
+// Load reads and parses a rules file. A missing file means no rules.
+func Load(path string) ([]*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	rules, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Parse parses the contents of a rules file
+func Parse(data []byte) ([]*Rule, error) {
+	var file ruleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*Rule, 0, len(file.Rules))
+	for i, rc := range file.Rules {
+		name := strings.TrimSpace(rc.Name)
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		condition, err := ParseCondition(rc.When)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid when: %w", name, err)
+		}
+
+		if len(rc.Then) == 0 {
+			return nil, fmt.Errorf("%s: no actions in then", name)
+		}
+		actions := make([]Action, 0, len(rc.Then))
+		for _, text := range rc.Then {
+			action, err := ParseAction(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			actions = append(actions, action)
+		}
+
+		triggers := make(map[Trigger]struct{})
+		if len(rc.On) == 0 {
+			for _, t := range allTriggers {
+				triggers[t] = struct{}{}
+			}
+		}
+		for _, on := range rc.On {
+			t := Trigger(strings.ToLower(strings.TrimSpace(on)))
+			if t != TriggerCreate && t != TriggerUpdate && t != TriggerSync {
+				return nil, fmt.Errorf("%s: invalid on %q: must be create, update, or sync", name, on)
+			}
+			triggers[t] = struct{}{}
+		}
+
+		rules = append(rules, &Rule{
+			Name:      name,
+			When:      rc.When,
+			Actions:   actions,
+			condition: condition,
+			triggers:  triggers,
+		})
+	}
+	return rules, nil
+}
@@ -0,0 +1,353 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func strPtr(s string) *string { return &s }
+
+func bugTask() *types.Task {
+	return &types.Task{
+		ID:        7,
+		Title:     "Crash on startup",
+		Status:    "active",
+		Priority:  strPtr("high"),
+		ProjectID: 1,
+		Labels:    []types.Label{{Name: "bug"}, {Name: "ui"}},
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	subject := Subject{Task: bugTask(), Project: "Todu"}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"label==bug", true},
+		{"label==BUG", true},
+		{"label==feature", false},
+		{"label!=feature", true},
+		{"label!=ui", false},
+		{"label==bug and priority==high", true},
+		{"label==bug and priority==low", false},
+		{"priority==low or status==active", true},
+		{"not label==bug", false},
+		{"not (label==bug and priority==low)", true},
+		{"label==feature or label==bug and priority==high", true},
+		{"(label==feature or label==bug) and priority==low", false},
+		{`title~="on start"`, true},
+		{"project==todu", true},
+		{"assignee==me", false},
+		{`description==""`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := ParseCondition(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCondition(%q) error = %v", tt.expr, err)
+			}
+			if got := cond.Match(subject); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"color==red",
+		"label bug",
+		"label==",
+		"label==bug and",
+		"(label==bug",
+		`title=="unterminated`,
+		"label==bug priority==high",
+	} {
+		if _, err := ParseCondition(expr); err == nil {
+			t.Errorf("ParseCondition(%q) expected error", expr)
+		}
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		text string
+		want Action
+	}{
+		{"assign me", Action{ActionAssign, "me"}},
+		{"unassign alice", Action{ActionUnassign, "alice"}},
+		{"add label triaged", Action{ActionAddLabel, "triaged"}},
+		{"Remove Label needs-info", Action{ActionRemoveLabel, "needs-info"}},
+		{"set priority High", Action{ActionSetPriority, "high"}},
+		{"set status waiting", Action{ActionSetStatus, "waiting"}},
+		{`comment "auto-triaged"`, Action{ActionComment, "auto-triaged"}},
+		{"comment looked at this", Action{ActionComment, "looked at this"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAction(tt.text)
+		if err != nil {
+			t.Errorf("ParseAction(%q) error = %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAction(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"delete task", "assign", "set priority urgent", `comment ""`} {
+		if _, err := ParseAction(text); err == nil {
+			t.Errorf("ParseAction(%q) expected error", text)
+		}
+	}
+}
+
+const testRules = `
+rules:
+  - name: triage
+    when: label==bug and priority==high
+    then:
+      - assign me
+      - add label triaged
+      - comment "auto-triaged"
+  - name: escalate triaged
+    when: label==triaged
+    then:
+      - set status waiting
+    on: [sync]
+`
+
+func TestParse(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("len(rules) = %d, want 2", len(rules))
+	}
+	if !reflect.DeepEqual(rules[0].Triggers(), []Trigger{TriggerCreate, TriggerUpdate, TriggerSync}) {
+		t.Errorf("default triggers = %v", rules[0].Triggers())
+	}
+	if !reflect.DeepEqual(rules[1].Triggers(), []Trigger{TriggerSync}) {
+		t.Errorf("triggers = %v, want [sync]", rules[1].Triggers())
+	}
+
+	for _, bad := range []string{
+		"rules:\n  - when: label==bug\n",
+		"rules:\n  - when: nope\n    then: [assign me]\n",
+		"rules:\n  - when: label==bug\n    then: [explode]\n",
+		"rules:\n  - when: label==bug\n    then: [assign me]\n    on: [delete]\n",
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	rules, err := Load(filepath.Join(t.TempDir(), "rules.yaml"))
+	if err != nil || rules != nil {
+		t.Errorf("Load(missing) = %v, %v; want nil, nil", rules, err)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n  - when: x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load() error = %v, want error naming the file", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(rules, "alice")
+	subject := Subject{Task: bugTask()}
+
+	t.Run("create runs matching rules", func(t *testing.T) {
+		outcome, err := engine.Evaluate(subject, nil, TriggerCreate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(outcome.Rules, []string{"triage"}) {
+			t.Errorf("Rules = %v", outcome.Rules)
+		}
+		if !reflect.DeepEqual(outcome.Update.Assignees, []string{"alice"}) {
+			t.Errorf("Assignees = %v", outcome.Update.Assignees)
+		}
+		if !reflect.DeepEqual(outcome.Update.Labels, []string{"bug", "ui", "triaged"}) {
+			t.Errorf("Labels = %v", outcome.Update.Labels)
+		}
+		if outcome.Update.Status != nil {
+			t.Errorf("Status = %v, want unchanged on create", *outcome.Update.Status)
+		}
+		if !reflect.DeepEqual(outcome.Comments, []string{"auto-triaged"}) {
+			t.Errorf("Comments = %v", outcome.Comments)
+		}
+	})
+
+	t.Run("later rules see earlier changes", func(t *testing.T) {
+		outcome, err := engine.Evaluate(subject, nil, TriggerSync)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(outcome.Rules, []string{"triage", "escalate triaged"}) {
+			t.Errorf("Rules = %v", outcome.Rules)
+		}
+		if outcome.Update.Status == nil || *outcome.Update.Status != "waiting" {
+			t.Errorf("Status = %v, want waiting", outcome.Update.Status)
+		}
+	})
+
+	t.Run("already applied rule does nothing", func(t *testing.T) {
+		task := bugTask()
+		task.Labels = append(task.Labels, types.Label{Name: "triaged"})
+		task.Assignees = []types.Assignee{{Name: "Alice"}}
+		comments := []*types.Comment{{Content: "auto-triaged"}}
+
+		outcome, err := engine.Evaluate(Subject{Task: task}, comments, TriggerCreate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outcome.Changed() || outcome.Update != nil || len(outcome.Comments) != 0 {
+			t.Errorf("outcome = %+v, want no change", outcome)
+		}
+	})
+
+	t.Run("assign me needs author", func(t *testing.T) {
+		if _, err := NewEngine(rules, "").Evaluate(subject, nil, TriggerCreate); err == nil {
+			t.Error("Evaluate() expected error without author")
+		}
+	})
+
+	t.Run("evaluate does not modify the task", func(t *testing.T) {
+		task := bugTask()
+		if _, err := engine.Evaluate(Subject{Task: task}, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(task, bugTask()) {
+			t.Errorf("task modified: %+v", task)
+		}
+	})
+}
+
+// fakeClient records the changes made by Apply
+type fakeClient struct {
+	tasks        []*types.Task
+	comments     []*types.Comment
+	updates      map[int]*types.TaskUpdate
+	created      []*types.CommentCreate
+	listComments int
+	listOpts     *api.TaskListOptions
+}
+
+func (f *fakeClient) ListTasks(ctx context.Context, opts *api.TaskListOptions) ([]*types.Task, error) {
+	f.listOpts = opts
+	return f.tasks, nil
+}
+
+func (f *fakeClient) ListProjects(ctx context.Context, opts *api.ProjectListOptions) ([]*types.Project, error) {
+	return []*types.Project{{ID: 1, Name: "Todu"}}, nil
+}
+
+func (f *fakeClient) UpdateTask(ctx context.Context, id int, task *types.TaskUpdate) (*types.Task, error) {
+	if f.updates == nil {
+		f.updates = make(map[int]*types.TaskUpdate)
+	}
+	f.updates[id] = task
+	return &types.Task{ID: id}, nil
+}
+
+func (f *fakeClient) ListComments(ctx context.Context, taskID int) ([]*types.Comment, error) {
+	f.listComments++
+	return f.comments, nil
+}
+
+func (f *fakeClient) CreateComment(ctx context.Context, comment *types.CommentCreate) (*types.Comment, error) {
+	f.created = append(f.created, comment)
+	return &types.Comment{Content: comment.Content}, nil
+}
+
+func TestApply(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(rules, "alice")
+
+	client := &fakeClient{comments: []*types.Comment{{Content: "auto-triaged"}}}
+	outcome, err := engine.Apply(context.Background(), client, Subject{Task: bugTask()}, TriggerCreate)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !outcome.Changed() {
+		t.Fatal("Apply() made no changes")
+	}
+	if client.updates[7] == nil {
+		t.Error("task was not updated")
+	}
+	if client.listComments != 1 {
+		t.Errorf("ListComments called %d times, want 1", client.listComments)
+	}
+	if len(client.created) != 0 {
+		t.Errorf("created duplicate comments: %v", client.created)
+	}
+}
+
+func TestApplyNoMatchSkipsComments(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := bugTask()
+	task.Labels = nil
+
+	client := &fakeClient{}
+	outcome, err := NewEngine(rules, "alice").Apply(context.Background(), client, Subject{Task: task}, TriggerCreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Changed() || client.listComments != 0 || client.updates != nil {
+		t.Errorf("unexpected activity: outcome=%+v listComments=%d updates=%v", outcome, client.listComments, client.updates)
+	}
+}
+
+func TestApplyUpdatedSince(t *testing.T) {
+	rules, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := bugTask()
+	other.ID = 8
+	other.Labels = []types.Label{{Name: "docs"}}
+
+	client := &fakeClient{tasks: []*types.Task{bugTask(), other}}
+	outcomes, err := NewEngine(rules, "alice").ApplyUpdatedSince(context.Background(), client, bugTask().CreatedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 || outcomes[0].TaskID != 7 {
+		t.Errorf("outcomes = %+v, want task 7 only", outcomes)
+	}
+	if client.listOpts == nil || client.listOpts.UpdatedAfter == "" {
+		t.Errorf("ListTasks opts = %+v, want UpdatedAfter", client.listOpts)
+	}
+	if len(client.created) != 1 || *client.created[0].TaskID != 7 || client.created[0].Author != CommentAuthor {
+		t.Errorf("created = %+v", client.created)
+	}
+}
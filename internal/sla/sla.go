@@ -0,0 +1,262 @@
+// Package sla tracks how long open tasks have been open against per-label
+// limits, such as bugs being closed within 14 days, and works out which
+// tasks breach them.
+package sla
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// BreachLabel marks open tasks that are past their SLA
+const BreachLabel = "sla-breach"
+
+// Rule is the time a task with Label may stay open.
+type Rule struct {
+	Label  string
+	Within time.Duration
+}
+
+// ParseRules parses the sla config setting, a map of label to the time
+// tasks with it may stay open, such as {bug: 14d, security: 3d}. Rules are
+// returned sorted by label.
+func ParseRules(config map[string]string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(config))
+	for label, value := range config {
+		label = strings.TrimSpace(label)
+		if label == "" || strings.EqualFold(label, BreachLabel) {
+			return nil, fmt.Errorf("invalid SLA label %q", label)
+		}
+		within, err := parseWithin(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLA for %s: %w", label, err)
+		}
+		rules = append(rules, Rule{Label: label, Within: within})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Label < rules[j].Label })
+	return rules, nil
+}
+
+// parseWithin parses a time such as 14d, 2w, or 36h
+func parseWithin(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	invalid := fmt.Errorf("%q is not a time such as 14d, 2w, or 36h", value)
+	for unit, size := range map[string]time.Duration{"w": 7 * 24 * time.Hour, "d": 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, unit); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n <= 0 {
+				return 0, invalid
+			}
+			return time.Duration(n) * size, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, invalid
+	}
+	return d, nil
+}
+
+// FormatDuration formats an age or SLA in whole days, or hours under a
+// day, such as 14d or 5h.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh", d/time.Hour)
+}
+
+// Status is where a task stands against its SLA.
+type Status struct {
+	Task     *types.Task
+	Rule     Rule
+	Age      time.Duration
+	Deadline time.Time
+	Breached bool
+}
+
+// Left returns the time until the deadline, negative once it has passed.
+func (s Status) Left(now time.Time) time.Duration {
+	return s.Deadline.Sub(now)
+}
+
+// RuleFor returns the strictest rule that applies to a task, and false if
+// none does.
+func RuleFor(task *types.Task, rules []Rule) (Rule, bool) {
+	var match Rule
+	found := false
+	for _, rule := range rules {
+		if task.HasLabel(rule.Label) && (!found || rule.Within < match.Within) {
+			match, found = rule, true
+		}
+	}
+	return match, found
+}
+
+// isOpen reports whether a task still counts against its SLA
+func isOpen(task *types.Task) bool {
+	return task.Status != "done" && task.Status != "canceled"
+}
+
+// Evaluate returns the status of each open task with an SLA, breached
+// first and then by deadline. A task's age is the time since it was
+// created.
+func Evaluate(tasks []*types.Task, rules []Rule, now time.Time) []Status {
+	var statuses []Status
+	for _, task := range tasks {
+		if !isOpen(task) {
+			continue
+		}
+		rule, ok := RuleFor(task, rules)
+		if !ok {
+			continue
+		}
+		deadline := task.CreatedAt.Add(rule.Within)
+		statuses = append(statuses, Status{
+			Task:     task,
+			Rule:     rule,
+			Age:      now.Sub(task.CreatedAt),
+			Deadline: deadline,
+			Breached: now.After(deadline),
+		})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Breached != statuses[j].Breached {
+			return statuses[i].Breached
+		}
+		return statuses[i].Deadline.Before(statuses[j].Deadline)
+	})
+	return statuses
+}
+
+// ClosedLate reports whether a closed task was closed after its SLA ran
+// out. Tasks are taken to be closed when they were last updated.
+func ClosedLate(task *types.Task, rules []Rule) bool {
+	if isOpen(task) {
+		return false
+	}
+	rule, ok := RuleFor(task, rules)
+	return ok && task.UpdatedAt.Sub(task.CreatedAt) > rule.Within
+}
+
+// LabelChange is a task whose breach label should be added or removed.
+type LabelChange struct {
+	Task *types.Task
+	Add  bool
+}
+
+// Changes returns the open tasks whose breach label is out of date: those
+// breaching without it and those no longer breaching with it. Closed tasks
+// keep their label as a record. A task whose only label is the breach
+// label is left out, since the API can't remove a task's last label.
+func Changes(tasks []*types.Task, rules []Rule, now time.Time) []LabelChange {
+	breached := make(map[int]bool)
+	for _, status := range Evaluate(tasks, rules, now) {
+		if status.Breached {
+			breached[status.Task.ID] = true
+		}
+	}
+
+	var changes []LabelChange
+	for _, task := range tasks {
+		if !isOpen(task) {
+			continue
+		}
+		labeled := task.HasLabel(BreachLabel)
+		if labeled && len(task.Labels) == 1 {
+			continue
+		}
+		if breached[task.ID] != labeled {
+			changes = append(changes, LabelChange{Task: task, Add: !labeled})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Task.ID < changes[j].Task.ID })
+	return changes
+}
+
+// ListTasks fetches the tasks that SLA rules apply to, and those with the
+// breach label, without duplicates.
+func ListTasks(ctx context.Context, client *api.Client, rules []Rule) ([]*types.Task, error) {
+	seen := make(map[int]bool)
+	var tasks []*types.Task
+	for _, label := range append(ruleLabels(rules), BreachLabel) {
+		found, err := client.ListAllTasks(ctx, &api.TaskListOptions{Labels: []string{label}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks labeled %s: %w", label, err)
+		}
+		for _, task := range found {
+			// Servers without label filters return every task
+			if task.HasLabel(label) && !seen[task.ID] {
+				seen[task.ID] = true
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// ruleLabels returns the labels of rules
+func ruleLabels(rules []Rule) []string {
+	labels := make([]string, len(rules))
+	for i, rule := range rules {
+		labels[i] = rule.Label
+	}
+	return labels
+}
+
+// Apply adds or removes the breach label of each change. It returns the
+// changes made; a failure stops it.
+func Apply(ctx context.Context, client *api.Client, changes []LabelChange) (int, error) {
+	for i, change := range changes {
+		var labels []string
+		for _, label := range change.Task.Labels {
+			if label.Name != BreachLabel {
+				labels = append(labels, label.Name)
+			}
+		}
+		if change.Add {
+			labels = append(labels, BreachLabel)
+		}
+		if _, err := client.UpdateTask(ctx, change.Task.ID, &types.TaskUpdate{Labels: labels}); err != nil {
+			return i, fmt.Errorf("failed to update task #%d: %w", change.Task.ID, err)
+		}
+	}
+	return len(changes), nil
+}
+
+// Summary counts SLA breaches for the weekly review.
+type Summary struct {
+	Open       int            // open tasks with an SLA
+	Breaching  int            // open tasks past their SLA
+	ClosedLate int            // tasks closed after their SLA ran out
+	ByLabel    map[string]int // open tasks past their SLA, by rule label
+}
+
+// Summarize counts the open tasks breaching their SLA at now, and the
+// closed tasks that were closed late.
+func Summarize(open, closed []*types.Task, rules []Rule, now time.Time) Summary {
+	summary := Summary{ByLabel: make(map[string]int)}
+	for _, status := range Evaluate(open, rules, now) {
+		summary.Open++
+		if status.Breached {
+			summary.Breaching++
+			summary.ByLabel[status.Rule.Label]++
+		}
+	}
+	for _, task := range closed {
+		if ClosedLate(task, rules) {
+			summary.ClosedLate++
+		}
+	}
+	return summary
+}
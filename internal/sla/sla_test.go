@@ -0,0 +1,110 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func task(id int, status string, created time.Time, labels ...string) *types.Task {
+	t := &types.Task{ID: id, Status: status, CreatedAt: created, UpdatedAt: created}
+	for _, name := range labels {
+		t.Labels = append(t.Labels, types.Label{Name: name})
+	}
+	return t
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(map[string]string{"bug": "14d", "security": "2w", "outage": "36h"})
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	want := []Rule{{"bug", 14 * 24 * time.Hour}, {"outage", 36 * time.Hour}, {"security", 14 * 24 * time.Hour}}
+	if len(rules) != len(want) {
+		t.Fatalf("ParseRules() = %v, want %v", rules, want)
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rule %d = %v, want %v", i, rules[i], want[i])
+		}
+	}
+
+	for _, bad := range []map[string]string{{"bug": "soon"}, {"bug": "0d"}, {"bug": "-1h"}, {BreachLabel: "1d"}} {
+		if _, err := ParseRules(bad); err == nil {
+			t.Errorf("ParseRules(%v) should fail", bad)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	rules := []Rule{{"bug", 14 * 24 * time.Hour}, {"security", 3 * 24 * time.Hour}}
+	tasks := []*types.Task{
+		task(1, "active", now.AddDate(0, 0, -20), "bug"),
+		task(2, "active", now.AddDate(0, 0, -5), "bug"),
+		task(3, "active", now.AddDate(0, 0, -5), "bug", "security"), // strictest rule applies
+		task(4, "done", now.AddDate(0, 0, -30), "bug"),
+		task(5, "active", now.AddDate(0, 0, -30), "feature"),
+	}
+
+	statuses := Evaluate(tasks, rules, now)
+	if len(statuses) != 3 {
+		t.Fatalf("Expected 3 statuses, got %d", len(statuses))
+	}
+	if statuses[0].Task.ID != 1 || !statuses[0].Breached {
+		t.Errorf("Expected task 1 first, breached longest: %+v", statuses[0])
+	}
+	if statuses[1].Task.ID != 3 || !statuses[1].Breached || statuses[1].Rule.Label != "security" {
+		t.Errorf("Expected task 3 second, breaching security: %+v", statuses[1])
+	}
+	if statuses[2].Task.ID != 2 || statuses[2].Breached || statuses[2].Left(now) != 9*24*time.Hour {
+		t.Errorf("Expected task 2 last with 9d left: %+v", statuses[2])
+	}
+}
+
+func TestChanges(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	rules := []Rule{{"bug", 14 * 24 * time.Hour}}
+	tasks := []*types.Task{
+		task(1, "active", now.AddDate(0, 0, -20), "bug"),                  // needs the label
+		task(2, "active", now.AddDate(0, 0, -20), "bug", BreachLabel),     // already labeled
+		task(3, "active", now.AddDate(0, 0, -2), "bug", BreachLabel),      // no longer breaching
+		task(4, "done", now.AddDate(0, 0, -20), "bug", BreachLabel),       // closed, kept
+		task(5, "active", now.AddDate(0, 0, -2), BreachLabel),             // last label, can't remove
+		task(6, "active", now.AddDate(0, 0, -20), "feature", BreachLabel), // rule gone
+	}
+
+	changes := Changes(tasks, rules, now)
+	got := make(map[int]bool)
+	for _, c := range changes {
+		got[c.Task.ID] = c.Add
+	}
+	want := map[int]bool{1: true, 3: false, 6: false}
+	if len(got) != len(want) {
+		t.Fatalf("Changes() = %v, want %v", got, want)
+	}
+	for id, add := range want {
+		if a, ok := got[id]; !ok || a != add {
+			t.Errorf("task %d: got add=%v (present %v), want add=%v", id, a, ok, add)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	rules := []Rule{{"bug", 14 * 24 * time.Hour}}
+	open := []*types.Task{
+		task(1, "active", now.AddDate(0, 0, -20), "bug"),
+		task(2, "active", now.AddDate(0, 0, -2), "bug"),
+	}
+	late := task(3, "done", now.AddDate(0, 0, -30), "bug")
+	late.UpdatedAt = now.AddDate(0, 0, -1)
+	onTime := task(4, "done", now.AddDate(0, 0, -10), "bug")
+	onTime.UpdatedAt = now.AddDate(0, 0, -1)
+
+	summary := Summarize(open, []*types.Task{late, onTime}, rules, now)
+	if summary.Open != 2 || summary.Breaching != 1 || summary.ClosedLate != 1 || summary.ByLabel["bug"] != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
@@ -0,0 +1,232 @@
+package sync
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Plugin configuration keys for comment attribution and filtering.
+const (
+	// ConfigCommentPrefix is prepended to comments pushed to the external system.
+	ConfigCommentPrefix = "comment_prefix"
+
+	// ConfigCommentFooter is appended to comments pushed to the external system.
+	ConfigCommentFooter = "comment_footer"
+
+	// ConfigSkipBotComments skips pulling comments from bot accounts when "true".
+	ConfigSkipBotComments = "skip_bot_comments"
+
+	// ConfigSkipOwnComments skips pulling comments authored by the plugin's own
+	// credentials when "true". Requires the plugin to implement plugin.Identity.
+	ConfigSkipOwnComments = "skip_own_comments"
+
+	// ConfigSkipCommentAuthors is a comma-separated list of authors whose
+	// comments are not pulled.
+	ConfigSkipCommentAuthors = "skip_comment_authors"
+)
+
+// authorPlaceholder is replaced with the Todu comment author in prefixes and footers.
+const authorPlaceholder = "{author}"
+
+// CommentPolicy controls how comments are attributed when pushed and which
+// comments are skipped when pulled for a plugin.
+type CommentPolicy struct {
+	// Prefix is prepended to pushed comments. "{author}" is replaced with the
+	// Todu comment author.
+	Prefix string
+
+	// Footer is appended to pushed comments. "{author}" is replaced with the
+	// Todu comment author.
+	Footer string
+
+	// SkipBots skips pulled comments from bot accounts (e.g., "dependabot[bot]").
+	SkipBots bool
+
+	// SkipOwn skips pulled comments authored by the plugin's own credentials.
+	SkipOwn bool
+
+	// SkipAuthors skips pulled comments by these authors (case-insensitive).
+	SkipAuthors []string
+}
+
+// CommentPolicyFromConfig reads a CommentPolicy from plugin configuration.
+func CommentPolicyFromConfig(config map[string]string) CommentPolicy {
+	policy := CommentPolicy{
+		Prefix:   config[ConfigCommentPrefix],
+		Footer:   config[ConfigCommentFooter],
+		SkipBots: parseBool(config[ConfigSkipBotComments]),
+		SkipOwn:  parseBool(config[ConfigSkipOwnComments]),
+	}
+
+	for _, author := range strings.Split(config[ConfigSkipCommentAuthors], ",") {
+		if author = strings.TrimSpace(author); author != "" {
+			policy.SkipAuthors = append(policy.SkipAuthors, author)
+		}
+	}
+
+	return policy
+}
+
+// IsEmpty returns true if the policy leaves comments untouched.
+func (c CommentPolicy) IsEmpty() bool {
+	return c.Prefix == "" && c.Footer == "" && !c.SkipBots && !c.SkipOwn && len(c.SkipAuthors) == 0
+}
+
+// Decorate adds the configured prefix and footer to a comment body.
+func (c CommentPolicy) Decorate(content, author string) string {
+	if c.Prefix != "" {
+		content = strings.ReplaceAll(c.Prefix, authorPlaceholder, author) + "\n\n" + content
+	}
+	if c.Footer != "" {
+		content = content + "\n\n" + strings.ReplaceAll(c.Footer, authorPlaceholder, author)
+	}
+	return content
+}
+
+// Skip reports whether a pulled comment should be ignored.
+// self is the plugin's own account name, or empty if unknown.
+func (c CommentPolicy) Skip(comment *types.Comment, self string) bool {
+	if c.SkipBots && isBotAuthor(comment.Author) {
+		return true
+	}
+	if c.SkipOwn && self != "" && strings.EqualFold(comment.Author, self) {
+		return true
+	}
+	for _, author := range c.SkipAuthors {
+		if strings.EqualFold(comment.Author, author) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBotAuthor reports whether an author name looks like a bot account.
+// GitHub and Forgejo suffix app accounts with "[bot]".
+func isBotAuthor(author string) bool {
+	return strings.HasSuffix(strings.ToLower(author), "[bot]")
+}
+
+// parseBool parses a boolean configuration value, treating anything invalid as false.
+func parseBool(value string) bool {
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err == nil && b
+}
+
+// commentPolicyPlugin wraps a plugin to apply a CommentPolicy to its comments.
+type commentPolicyPlugin struct {
+	plugin.Plugin
+	policy CommentPolicy
+
+	selfOnce gosync.Once
+	self     string
+}
+
+// withCommentPolicy wraps p so that comments are attributed and filtered
+// according to policy. Returns p unchanged if the policy is empty.
+func withCommentPolicy(p plugin.Plugin, policy CommentPolicy) plugin.Plugin {
+	if policy.IsEmpty() {
+		return p
+	}
+	return &commentPolicyPlugin{Plugin: p, policy: policy}
+}
+
+// FetchComments fetches comments and drops those the policy skips.
+func (c *commentPolicyPlugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	comments, err := c.Plugin.FetchComments(ctx, projectExternalID, taskExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return c.filter(ctx, comments), nil
+}
+
+// filter drops the comments the policy skips.
+func (c *commentPolicyPlugin) filter(ctx context.Context, comments []*types.Comment) []*types.Comment {
+	self := c.currentUser(ctx)
+	filtered := make([]*types.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !c.policy.Skip(comment, self) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// FetchCommentsSince fetches comments updated since a time and drops those the
+// policy skips. Falls back to fetching all comments if the wrapped plugin
+// can't fetch incrementally.
+func (c *commentPolicyPlugin) FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error) {
+	incremental, ok := c.Plugin.(plugin.IncrementalComments)
+	if !ok {
+		return c.FetchComments(ctx, projectExternalID, taskExternalID)
+	}
+
+	comments, err := incremental.FetchCommentsSince(ctx, projectExternalID, taskExternalID, since)
+	if err != nil {
+		return nil, err
+	}
+	return c.filter(ctx, comments), nil
+}
+
+// CreateComment creates a comment with the policy's prefix and footer applied.
+func (c *commentPolicyPlugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	decorated := *comment
+	decorated.Content = c.policy.Decorate(comment.Content, comment.Author)
+	return c.Plugin.CreateComment(ctx, projectExternalID, taskExternalID, &decorated)
+}
+
+// UpdateComment updates a comment with the policy's prefix and footer applied.
+// Returns plugin.ErrNotSupported if the wrapped plugin can't edit comments.
+func (c *commentPolicyPlugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	updater, ok := c.Plugin.(plugin.CommentUpdater)
+	if !ok {
+		return nil, plugin.ErrNotSupported
+	}
+
+	decorated := *comment
+	decorated.Content = c.policy.Decorate(comment.Content, comment.Author)
+	return updater.UpdateComment(ctx, projectExternalID, taskExternalID, commentExternalID, &decorated)
+}
+
+// AddCommentReaction adds a reaction through the wrapped plugin.
+// Returns plugin.ErrNotSupported if the wrapped plugin can't add reactions.
+func (c *commentPolicyPlugin) AddCommentReaction(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID, emoji string) error {
+	reactor, ok := c.Plugin.(plugin.CommentReactor)
+	if !ok {
+		return plugin.ErrNotSupported
+	}
+	return reactor.AddCommentReaction(ctx, projectExternalID, taskExternalID, commentExternalID, emoji)
+}
+
+// PushLabels creates labels through the wrapped plugin.
+// Returns plugin.ErrNotSupported if the wrapped plugin can't push labels.
+func (c *commentPolicyPlugin) PushLabels(ctx context.Context, projectExternalID *string, labels []string, opts plugin.LabelPushOptions) (*plugin.LabelPushResult, error) {
+	pusher, ok := c.Plugin.(plugin.LabelPusher)
+	if !ok {
+		return nil, plugin.ErrNotSupported
+	}
+	return pusher.PushLabels(ctx, projectExternalID, labels, opts)
+}
+
+// currentUser returns the plugin's own account name, looked up once.
+// Returns empty if the policy doesn't need it or the plugin can't report it.
+func (c *commentPolicyPlugin) currentUser(ctx context.Context) string {
+	if !c.policy.SkipOwn {
+		return ""
+	}
+	c.selfOnce.Do(func() {
+		identity, ok := c.Plugin.(plugin.Identity)
+		if !ok {
+			return
+		}
+		if user, err := identity.CurrentUser(ctx); err == nil {
+			c.self = user
+		}
+	})
+	return c.self
+}
@@ -0,0 +1,118 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestCommentPolicyFromConfig(t *testing.T) {
+	policy := CommentPolicyFromConfig(map[string]string{
+		"token":                  "secret",
+		ConfigCommentFooter:      "— posted via todu on behalf of {author}",
+		ConfigSkipBotComments:    "true",
+		ConfigSkipOwnComments:    "not-a-bool",
+		ConfigSkipCommentAuthors: " ci-user , ,release-bot",
+	})
+
+	if !policy.SkipBots || policy.SkipOwn {
+		t.Errorf("Unexpected skip flags: %+v", policy)
+	}
+	if len(policy.SkipAuthors) != 2 || policy.SkipAuthors[0] != "ci-user" || policy.SkipAuthors[1] != "release-bot" {
+		t.Errorf("Unexpected skip authors: %v", policy.SkipAuthors)
+	}
+
+	if !CommentPolicyFromConfig(map[string]string{"token": "secret"}).IsEmpty() {
+		t.Error("Expected empty policy without comment settings")
+	}
+}
+
+func TestCommentPolicyDecorate(t *testing.T) {
+	policy := CommentPolicy{Prefix: "**{author}:**", Footer: "— via todu"}
+
+	got := policy.Decorate("Looks good", "alice")
+	want := "**alice:**\n\nLooks good\n\n— via todu"
+	if got != want {
+		t.Errorf("Decorate() = %q, want %q", got, want)
+	}
+
+	if got := (CommentPolicy{}).Decorate("Looks good", "alice"); got != "Looks good" {
+		t.Errorf("Expected empty policy to leave content unchanged, got %q", got)
+	}
+}
+
+func TestCommentPolicySkip(t *testing.T) {
+	policy := CommentPolicy{SkipBots: true, SkipOwn: true, SkipAuthors: []string{"CI-User"}}
+
+	tests := []struct {
+		author string
+		want   bool
+	}{
+		{"dependabot[bot]", true},
+		{"sync-account", true},
+		{"ci-user", true},
+		{"alice", false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Skip(&types.Comment{Author: tt.author}, "sync-account"); got != tt.want {
+			t.Errorf("Skip(%q) = %v, want %v", tt.author, got, tt.want)
+		}
+	}
+}
+
+// identityPlugin is a mock plugin that reports its own account name.
+type identityPlugin struct {
+	*plugin.MockPlugin
+	user string
+}
+
+func (p *identityPlugin) CurrentUser(ctx context.Context) (string, error) {
+	return p.user, nil
+}
+
+func TestCommentPolicyPlugin(t *testing.T) {
+	mock := plugin.NewMockPlugin("test")
+	mock.AddTask("1", &types.Task{ExternalID: "1", Title: "Task"})
+	p := withCommentPolicy(&identityPlugin{MockPlugin: mock, user: "sync-account"}, CommentPolicy{
+		Footer:  "— via todu for {author}",
+		SkipOwn: true,
+	})
+
+	ctx := context.Background()
+	project := "repo"
+	if _, err := mock.CreateComment(ctx, &project, "1", &types.CommentCreate{Content: "Human", Author: "alice"}); err != nil {
+		t.Fatalf("CreateComment failed: %v", err)
+	}
+
+	// Pushed comments get the footer and appear as the token owner
+	created, err := p.CreateComment(ctx, &project, "1", &types.CommentCreate{Content: "Pushed", Author: "bob"})
+	if err != nil {
+		t.Fatalf("CreateComment failed: %v", err)
+	}
+	if created.Content != "Pushed\n\n— via todu for bob" {
+		t.Errorf("Expected footer to be added, got %q", created.Content)
+	}
+	if _, err := mock.CreateComment(ctx, &project, "1", &types.CommentCreate{Content: "Echo", Author: "sync-account"}); err != nil {
+		t.Fatalf("CreateComment failed: %v", err)
+	}
+
+	comments, err := p.FetchComments(ctx, &project, "1")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected own comment to be skipped, got %d comments", len(comments))
+	}
+	for _, c := range comments {
+		if c.Author == "sync-account" {
+			t.Errorf("Expected comment by sync-account to be skipped: %+v", c)
+		}
+	}
+
+	if got := withCommentPolicy(mock, CommentPolicy{}); got != plugin.Plugin(mock) {
+		t.Error("Expected empty policy to return the plugin unchanged")
+	}
+}
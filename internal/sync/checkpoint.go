@@ -0,0 +1,185 @@
+package sync
+
+import (
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// checkpointInterval is the number of pulled tasks between checkpoint writes.
+// An interrupted pull redoes at most this many tasks.
+const checkpointInterval = 50
+
+// CheckpointStore persists the progress of a project's initial pull, so an
+// interrupted first sync of a large project resumes where it left off instead
+// of starting over. A SnapshotStore may also implement CheckpointStore.
+//
+// A checkpoint is only kept while the project has never completed a sync, and
+// is cleared once it does.
+type CheckpointStore interface {
+	// Checkpoint returns a project's checkpoint, or nil if there is none.
+	Checkpoint(projectID int) (*Checkpoint, error)
+
+	// SaveCheckpoint replaces a project's checkpoint.
+	SaveCheckpoint(projectID int, checkpoint *Checkpoint) error
+
+	// ClearCheckpoint removes a project's checkpoint.
+	ClearCheckpoint(projectID int) error
+}
+
+// Checkpoint is the progress of an interrupted initial pull.
+type Checkpoint struct {
+	// Cursor is where the next page of external tasks starts, for plugins
+	// that implement plugin.PagedTasks. Empty means the first page.
+	Cursor string `json:"cursor,omitempty"`
+
+	// Pulled is the number of tasks on the pages before Cursor.
+	Pulled int `json:"pulled,omitempty"`
+
+	// Done lists the external tasks already pulled from Cursor on.
+	Done map[string]bool `json:"done"`
+}
+
+// Progress reports how far a project's pull has come. It is passed to
+// Options.Progress after each external task.
+type Progress struct {
+	ProjectID   int
+	ProjectName string
+
+	// Done is the number of external tasks processed, including Resumed.
+	Done int
+
+	// Total is the number of external tasks fetched.
+	Total int
+
+	// Resumed is the number of tasks skipped because an interrupted pull had
+	// already processed them.
+	Resumed int
+
+	// Started is when processing of the fetched tasks began.
+	Started time.Time
+}
+
+// ETA estimates the time left from the rate of tasks processed so far, not
+// counting resumed tasks. Returns 0 until a task has been processed.
+func (p Progress) ETA() time.Duration {
+	processed := p.Done - p.Resumed
+	if processed <= 0 || p.Done >= p.Total {
+		return 0
+	}
+	perTask := time.Since(p.Started) / time.Duration(processed)
+	return perTask * time.Duration(p.Total-p.Done)
+}
+
+// pullCheckpoint tracks the external tasks processed during a project's
+// initial pull, and for a paged fetch the page reached. A nil pullCheckpoint
+// does nothing.
+type pullCheckpoint struct {
+	engine    *Engine
+	store     CheckpointStore
+	projectID int
+	cursor    string
+	pulled    int
+	done      map[string]bool
+	pending   int
+
+	// stuck is set once a page has a task that failed, so the cursor stays
+	// at that page and the task is retried on resume
+	stuck bool
+}
+
+// loadCheckpoint returns the checkpoint for a project's pull, loading the
+// progress of an interrupted pull. Only initial, unfiltered pulls are
+// checkpointed; for any other pull it returns nil.
+func (e *Engine) loadCheckpoint(project *types.Project, options Options) *pullCheckpoint {
+	store, ok := e.snapshots.(CheckpointStore)
+	if !ok || project.LastSyncedAt != nil || options.DryRun || !options.Filter.IsEmpty() {
+		return nil
+	}
+
+	saved, err := store.Checkpoint(project.ID)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("project", project.Name).Msg("Failed to load sync checkpoint")
+	}
+	c := &pullCheckpoint{engine: e, store: store, projectID: project.ID, done: make(map[string]bool)}
+	if saved != nil {
+		c.cursor, c.pulled = saved.Cursor, saved.Pulled
+		if saved.Done != nil {
+			c.done = saved.Done
+		}
+		e.logger.Debug().Str("project", project.Name).Int("done", c.pulled+len(c.done)).Msg("Resuming interrupted initial sync")
+	}
+	return c
+}
+
+// resumeCursor returns where the fetch of a paged pull resumes, and the
+// number of tasks on the pages before it.
+func (c *pullCheckpoint) resumeCursor() (string, int) {
+	if c == nil {
+		return "", 0
+	}
+	return c.cursor, c.pulled
+}
+
+// has returns true if an external task was processed before the pull was
+// interrupted.
+func (c *pullCheckpoint) has(externalID string) bool {
+	return c != nil && c.done[externalID]
+}
+
+// mark records that an external task was processed, writing the checkpoint
+// every checkpointInterval tasks.
+func (c *pullCheckpoint) mark(externalID string) {
+	if c == nil {
+		return
+	}
+	c.done[externalID] = true
+	c.pending++
+	if c.pending >= checkpointInterval {
+		c.flush()
+	}
+}
+
+// pageDone records that the tasks of a page were processed and the fetch
+// moves on to next, writing the checkpoint. The cursor doesn't move past a
+// page with a task that failed.
+func (c *pullCheckpoint) pageDone(next string, page []*types.Task, failed bool) {
+	if c == nil {
+		return
+	}
+	c.stuck = c.stuck || failed
+	if c.stuck {
+		return
+	}
+	c.cursor = next
+	c.pulled += len(page)
+	for _, task := range page {
+		delete(c.done, task.ExternalID)
+	}
+	c.pending++
+	c.flush()
+}
+
+// flush writes any unsaved progress. Failures are logged but do not fail the
+// sync; the worst case is redoing some tasks on resume.
+func (c *pullCheckpoint) flush() {
+	if c == nil || c.pending == 0 {
+		return
+	}
+	if err := c.store.SaveCheckpoint(c.projectID, &Checkpoint{Cursor: c.cursor, Pulled: c.pulled, Done: c.done}); err != nil {
+		c.engine.logger.Warn().Err(err).Int("project", c.projectID).Msg("Failed to save sync checkpoint")
+		return
+	}
+	c.pending = 0
+}
+
+// clearCheckpoint removes a project's checkpoint after a completed sync.
+func (e *Engine) clearCheckpoint(projectID int) {
+	store, ok := e.snapshots.(CheckpointStore)
+	if !ok {
+		return
+	}
+	if err := store.ClearCheckpoint(projectID); err != nil {
+		e.logger.Warn().Err(err).Int("project", projectID).Msg("Failed to clear sync checkpoint")
+	}
+}
@@ -0,0 +1,327 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestSyncResumesInitialPull(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	mockPlugin := plugin.NewMockPlugin("test-system")
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mockPlugin })
+
+	var created []string
+	failTitle := ""
+	projectUpdated := false
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 2, Name: "Big", SystemID: 1, ExternalID: "big"})
+	})
+	mux.HandleFunc("PUT /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		projectUpdated = true
+		_, _ = w.Write([]byte(`{"id": 2}`))
+	})
+	mux.HandleFunc("GET /api/v1/systems/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.System{ID: 1, Identifier: "test-system"})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [], "total": 0}`))
+	})
+	mux.HandleFunc("GET /api/v1/tasks/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		var task types.TaskCreate
+		_ = json.NewDecoder(r.Body).Decode(&task)
+		if task.Title == failTitle {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		created = append(created, task.ExternalID)
+		_ = json.NewEncoder(w).Encode(&types.Task{ID: len(created), ProjectID: 2, ExternalID: task.ExternalID, Title: task.Title})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine := NewEngine(api.NewClient(server.URL, ""), reg).WithSnapshots(store)
+
+	mockPlugin.AddProject("big", &types.Project{ID: 2, ExternalID: "big"})
+	for _, id := range []string{"1", "2", "3"} {
+		mockPlugin.AddTask(id, &types.Task{ExternalID: id, ProjectID: 2, Title: "Task " + id, Status: "active"})
+	}
+
+	pull := StrategyPull
+	var progress []Progress
+	options := Options{
+		ProjectIDs:       []int{2},
+		StrategyOverride: &pull,
+		Progress:         func(p Progress) { progress = append(progress, p) },
+	}
+
+	// The first pull is interrupted by a failure on task 2
+	failTitle = "Task 2"
+	result, err := engine.Sync(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalCreated != 2 || result.TotalErrors != 1 || projectUpdated {
+		t.Fatalf("Expected 2 tasks created and 1 error, got %+v", result)
+	}
+	if len(progress) != 3 || progress[2].Done != 3 || progress[2].Total != 3 {
+		t.Errorf("Expected progress reported for each task, got %+v", progress)
+	}
+	saved, err := store.Checkpoint(2)
+	if err != nil || saved == nil || len(saved.Done) != 2 || saved.Done["2"] {
+		t.Fatalf("Expected checkpoint of the 2 pulled tasks, got %+v (%v)", saved, err)
+	}
+
+	// The next pull resumes with only the failed task
+	failTitle = ""
+	created = nil
+	progress = nil
+	result, err = engine.Sync(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalCreated != 1 || len(created) != 1 || created[0] != "2" {
+		t.Fatalf("Expected only task 2 created on resume, got %+v and %v", result, created)
+	}
+	if result.ProjectResults[0].Resumed != 2 || progress[2].Resumed != 2 {
+		t.Errorf("Expected 2 resumed tasks, got %d", result.ProjectResults[0].Resumed)
+	}
+	if !projectUpdated {
+		t.Error("Expected last_synced_at updated after the completed pull")
+	}
+	if saved, _ := store.Checkpoint(2); saved != nil {
+		t.Errorf("Expected checkpoint cleared after the completed pull, got %+v", saved)
+	}
+}
+
+// pagedPlugin serves the mock plugin's tasks two at a time, in external ID
+// order, recording the cursor of each page requested.
+type pagedPlugin struct {
+	*plugin.MockPlugin
+	cursors []string
+}
+
+func (p *pagedPlugin) FetchTaskPage(ctx context.Context, projectExternalID *string, since *time.Time, cursor string) ([]*types.Task, string, error) {
+	p.cursors = append(p.cursors, cursor)
+	tasks, err := p.FetchTasks(ctx, projectExternalID, since)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ExternalID < tasks[j].ExternalID })
+
+	start, _ := strconv.Atoi(cursor)
+	end := min(start+2, len(tasks))
+	next := ""
+	if end < len(tasks) {
+		next = strconv.Itoa(end)
+	}
+	return tasks[start:end], next, nil
+}
+
+func TestSyncResumesPagedPullFromCursor(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	paged := &pagedPlugin{MockPlugin: plugin.NewMockPlugin("test-system")}
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return paged })
+
+	var created []string
+	failTitle := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 2, Name: "Big", SystemID: 1, ExternalID: "big"})
+	})
+	mux.HandleFunc("PUT /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 2}`))
+	})
+	mux.HandleFunc("GET /api/v1/systems/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.System{ID: 1, Identifier: "test-system"})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [], "total": 0}`))
+	})
+	mux.HandleFunc("GET /api/v1/tasks/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		var task types.TaskCreate
+		_ = json.NewDecoder(r.Body).Decode(&task)
+		if task.Title == failTitle {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		created = append(created, task.ExternalID)
+		_ = json.NewEncoder(w).Encode(&types.Task{ID: len(created), ProjectID: 2, ExternalID: task.ExternalID, Title: task.Title})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine := NewEngine(api.NewClient(server.URL, ""), reg).WithSnapshots(store)
+
+	paged.AddProject("big", &types.Project{ID: 2, ExternalID: "big"})
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		paged.AddTask(id, &types.Task{ExternalID: id, ProjectID: 2, Title: "Task " + id, Status: "active"})
+	}
+
+	pull := StrategyPull
+	var progress []Progress
+	options := Options{
+		ProjectIDs:       []int{2},
+		StrategyOverride: &pull,
+		Progress:         func(p Progress) { progress = append(progress, p) },
+	}
+
+	// The first pull fetches all three pages and fails on task 3, on the
+	// second page
+	failTitle = "Task 3"
+	result, err := engine.Sync(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalCreated != 4 || result.TotalErrors != 1 {
+		t.Fatalf("Expected 4 tasks created and 1 error, got %+v", result)
+	}
+	if strings.Join(paged.cursors, ",") != ",2,4" {
+		t.Errorf("Expected the three pages fetched in turn, got cursors %q", paged.cursors)
+	}
+	saved, err := store.Checkpoint(2)
+	if err != nil || saved == nil || saved.Cursor != "2" || saved.Pulled != 2 || len(saved.Done) != 2 || !saved.Done["4"] || !saved.Done["5"] {
+		t.Fatalf("Expected checkpoint at the second page with tasks 4 and 5 done, got %+v (%v)", saved, err)
+	}
+
+	// The next pull starts at the page with the failed task
+	failTitle = ""
+	created = nil
+	progress = nil
+	paged.cursors = nil
+	result, err = engine.Sync(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if strings.Join(paged.cursors, ",") != "2,4" {
+		t.Errorf("Expected the fetch resumed from the second page, got cursors %q", paged.cursors)
+	}
+	if result.TotalCreated != 1 || len(created) != 1 || created[0] != "3" {
+		t.Fatalf("Expected only task 3 created on resume, got %+v and %v", result, created)
+	}
+	if result.ProjectResults[0].Resumed != 4 {
+		t.Errorf("Expected 4 resumed tasks, got %d", result.ProjectResults[0].Resumed)
+	}
+	if last := progress[len(progress)-1]; last.Done != 5 || last.Total != 5 || last.Resumed != 4 {
+		t.Errorf("Expected progress to count the pages skipped, got %+v", last)
+	}
+	if saved, _ := store.Checkpoint(2); saved != nil {
+		t.Errorf("Expected checkpoint cleared after the completed pull, got %+v", saved)
+	}
+}
+
+func TestSyncStopsWhenCanceled(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	mockPlugin := plugin.NewMockPlugin("test-system")
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mockPlugin })
+
+	var created []string
+	projectUpdated := false
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 2, Name: "Big", SystemID: 1, ExternalID: "big"})
+	})
+	mux.HandleFunc("PUT /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		projectUpdated = true
+		_, _ = w.Write([]byte(`{"id": 2}`))
+	})
+	mux.HandleFunc("GET /api/v1/systems/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.System{ID: 1, Identifier: "test-system"})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [], "total": 0}`))
+	})
+	mux.HandleFunc("GET /api/v1/tasks/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		var task types.TaskCreate
+		_ = json.NewDecoder(r.Body).Decode(&task)
+		created = append(created, task.ExternalID)
+		_ = json.NewEncoder(w).Encode(&types.Task{ID: len(created), ProjectID: 2, ExternalID: task.ExternalID, Title: task.Title})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine := NewEngine(api.NewClient(server.URL, ""), reg).WithSnapshots(store)
+
+	mockPlugin.AddProject("big", &types.Project{ID: 2, ExternalID: "big"})
+	for _, id := range []string{"1", "2", "3"} {
+		mockPlugin.AddTask(id, &types.Task{ExternalID: id, ProjectID: 2, Title: "Task " + id, Status: "active"})
+	}
+
+	// Interrupted, as with Ctrl-C, once the first task is pulled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pull := StrategyPull
+	options := Options{
+		ProjectIDs:       []int{2},
+		StrategyOverride: &pull,
+		Progress:         func(Progress) { cancel() },
+	}
+
+	result, err := engine.Sync(ctx, options)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if !result.Interrupted || !result.ProjectResults[0].Interrupted {
+		t.Errorf("Expected the sync to be reported as interrupted, got %+v", result)
+	}
+	if result.TotalCreated != 1 || len(created) != 1 || result.TotalErrors != 1 {
+		t.Errorf("Expected 1 task created and the interruption as the only error, got %+v", result)
+	}
+	if projectUpdated {
+		t.Error("Expected last_synced_at unchanged after an interrupted pull")
+	}
+	if saved, err := store.Checkpoint(2); err != nil || saved == nil || len(saved.Done) != 1 || len(created) != 1 || !saved.Done[created[0]] {
+		t.Errorf("Expected checkpoint of the pulled task, got %+v (%v)", saved, err)
+	}
+}
+
+func TestProgressETA(t *testing.T) {
+	started := time.Now().Add(-10 * time.Second)
+
+	p := Progress{Done: 30, Resumed: 20, Total: 40, Started: started}
+	eta := p.ETA()
+	// 10 tasks in 10s leaves 10 tasks at 1s each
+	if eta < 9*time.Second || eta > 11*time.Second {
+		t.Errorf("Expected ETA of about 10s, got %s", eta)
+	}
+
+	if eta := (Progress{Done: 20, Resumed: 20, Total: 40, Started: started}).ETA(); eta != 0 {
+		t.Errorf("Expected no ETA before any task is processed, got %s", eta)
+	}
+	if eta := (Progress{Done: 40, Total: 40, Started: started}).ETA(); eta != 0 {
+		t.Errorf("Expected no ETA when done, got %s", eta)
+	}
+}
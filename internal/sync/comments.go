@@ -0,0 +1,257 @@
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// CommentSnapshot records the content of a comment on both sides after it
+// was last synced. Hashes are kept per side because pushed comments may be
+// decorated with a prefix or footer in the external system.
+type CommentSnapshot struct {
+	ExternalID string `json:"external_id"`
+	LocalHash  string `json:"local_hash"`
+	RemoteHash string `json:"remote_hash"`
+}
+
+// CommentSnapshotStore persists comment snapshots between syncs.
+// A SnapshotStore may also implement CommentSnapshotStore to enable comment
+// update propagation.
+type CommentSnapshotStore interface {
+	// GetComment returns the snapshot for a comment, or nil if none exists.
+	GetComment(projectID int, externalID string) (*CommentSnapshot, error)
+
+	// PutComment stores the snapshot for a comment, replacing any previous snapshot.
+	PutComment(projectID int, snapshot *CommentSnapshot) error
+}
+
+// CommentCursorStore persists, per task, the newest external comment
+// timestamp seen by a pull. A SnapshotStore may also implement
+// CommentCursorStore to make comment pulls incremental for plugins that
+// implement plugin.IncrementalComments.
+type CommentCursorStore interface {
+	// CommentCursor returns the cursor for a task, or nil if none exists.
+	CommentCursor(projectID int, externalID string) (*time.Time, error)
+
+	// RecordCommentCursor stores the cursor for a task.
+	RecordCommentCursor(projectID int, externalID string, cursor time.Time) error
+}
+
+// ReactionStore persists, per comment, the reactions already pushed to the
+// external system. A SnapshotStore may also implement ReactionStore to push
+// comment reactions for plugins that implement plugin.CommentReactor.
+type ReactionStore interface {
+	// PushedReactions returns the emoji already pushed for a comment.
+	PushedReactions(projectID int, commentExternalID string) ([]string, error)
+
+	// RecordPushedReactions stores the emoji pushed for a comment.
+	RecordPushedReactions(projectID int, commentExternalID string, emojis []string) error
+}
+
+// fetchPullComments fetches the external comments of a task for a pull.
+// When both the plugin and the snapshot store support it, only comments
+// updated since the task's cursor are fetched. The returned cursor is the
+// one the fetch started from, or nil for a full fetch.
+func (e *Engine) fetchPullComments(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task) ([]*types.Comment, *time.Time, error) {
+	incremental, ok := p.(plugin.IncrementalComments)
+	store, hasStore := e.snapshots.(CommentCursorStore)
+	if !ok || !hasStore {
+		comments, err := p.FetchComments(ctx, &project.ExternalID, toduTask.ExternalID)
+		return comments, nil, err
+	}
+
+	cursor, err := store.CommentCursor(project.ID, toduTask.ExternalID)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("task", toduTask.Title).Msg("Failed to load comment cursor")
+		cursor = nil
+	}
+
+	comments, err := incremental.FetchCommentsSince(ctx, &project.ExternalID, toduTask.ExternalID, cursor)
+	return comments, cursor, err
+}
+
+// advanceCommentCursor records the newest comment timestamp of a pull so the
+// next pull of the task only fetches later comments.
+// Failures are logged but do not fail the sync.
+func (e *Engine) advanceCommentCursor(projectID int, toduTask *types.Task, previous *time.Time, comments []*types.Comment) {
+	store, ok := e.snapshots.(CommentCursorStore)
+	if !ok {
+		return
+	}
+
+	var newest time.Time
+	for _, comment := range comments {
+		if comment.UpdatedAt.After(newest) {
+			newest = comment.UpdatedAt
+		}
+		if comment.CreatedAt.After(newest) {
+			newest = comment.CreatedAt
+		}
+	}
+	if newest.IsZero() || (previous != nil && !newest.After(*previous)) {
+		return
+	}
+
+	if err := store.RecordCommentCursor(projectID, toduTask.ExternalID, newest); err != nil {
+		e.logger.Warn().Err(err).Str("task", toduTask.Title).Msg("Failed to record comment cursor")
+	}
+}
+
+// contentHash returns a hash of comment content.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// commentSnapshots returns the engine's comment snapshot store, or nil if the
+// snapshot store doesn't support comments.
+func (e *Engine) commentSnapshots() CommentSnapshotStore {
+	store, _ := e.snapshots.(CommentSnapshotStore)
+	return store
+}
+
+// saveCommentSnapshot records the content of a comment on both sides.
+// Failures are logged but do not fail the sync.
+func (e *Engine) saveCommentSnapshot(projectID int, externalID, localContent, remoteContent string) {
+	store := e.commentSnapshots()
+	if store == nil || externalID == "" {
+		return
+	}
+	snapshot := &CommentSnapshot{
+		ExternalID: externalID,
+		LocalHash:  contentHash(localContent),
+		RemoteHash: contentHash(remoteContent),
+	}
+	if err := store.PutComment(projectID, snapshot); err != nil {
+		e.logger.Warn().Err(err).Str("comment", externalID).Msg("Failed to save comment snapshot")
+	}
+}
+
+// reconcileComment propagates an edit to a synced comment in one direction.
+//
+// Each side's content is compared with its hash from the last sync. With
+// StrategyPull, an external edit is copied into Todu; with StrategyPush, a
+// Todu edit is copied to the external system. If both sides were edited, the
+// most recently updated comment wins. Edits are only pushed if the plugin
+// implements plugin.CommentUpdater.
+func (e *Engine) reconcileComment(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, local, remote *types.Comment, direction Strategy, dryRun bool, pr *ProjectResult) {
+	store := e.commentSnapshots()
+	if store == nil {
+		return
+	}
+
+	base, err := store.GetComment(project.ID, remote.ExternalID)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("comment", remote.ExternalID).Msg("Failed to load comment snapshot")
+		return
+	}
+	if base == nil {
+		// First time seeing this pair; record it as the base for future edits
+		if !dryRun {
+			e.saveCommentSnapshot(project.ID, remote.ExternalID, local.Content, remote.Content)
+		}
+		return
+	}
+
+	localChanged := contentHash(local.Content) != base.LocalHash
+	remoteChanged := contentHash(remote.Content) != base.RemoteHash
+	if !localChanged && !remoteChanged {
+		return
+	}
+
+	remoteWins := remoteChanged && (!localChanged || !local.UpdatedAt.After(remote.UpdatedAt))
+
+	if direction == StrategyPull {
+		if !remoteWins {
+			return
+		}
+		// Pull the external edit into Todu
+		if !dryRun {
+			commentUpdate := &types.CommentUpdate{Content: &remote.Content}
+			if _, err := e.updateComment(ctx, local.ID, commentUpdate); err != nil {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to update comment on task %s: %w", toduTask.Title, err))
+				return
+			}
+			e.saveCommentSnapshot(project.ID, remote.ExternalID, remote.Content, remote.Content)
+		}
+		e.logger.Debug().Str("task", toduTask.Title).Str("comment", remote.ExternalID).Msg("Pulled comment edit")
+		return
+	}
+
+	if remoteWins {
+		return
+	}
+
+	// Push the Todu edit to the external system
+	updater, ok := p.(plugin.CommentUpdater)
+	if !ok {
+		return
+	}
+	if !dryRun {
+		commentUpdate := &types.CommentCreate{Content: local.Content, Author: local.Author}
+		updated, err := updater.UpdateComment(ctx, &project.ExternalID, toduTask.ExternalID, remote.ExternalID, commentUpdate)
+		if err != nil {
+			if err == plugin.ErrNotSupported {
+				return
+			}
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to push comment edit to task %s: %w", toduTask.Title, err))
+			return
+		}
+		e.saveCommentSnapshot(project.ID, remote.ExternalID, local.Content, updated.Content)
+	}
+	e.logger.Debug().Str("task", toduTask.Title).Str("comment", remote.ExternalID).Msg("Pushed comment edit")
+}
+
+// pushReactions adds the reactions of a synced Todu comment that haven't
+// been pushed yet to the external comment. Each emoji is pushed once,
+// whoever reacted with it. Emoji the external system has no equivalent for
+// are recorded as pushed so they aren't retried. Requires the plugin to
+// implement plugin.CommentReactor and the snapshot store ReactionStore.
+func (e *Engine) pushReactions(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, comment *types.Comment, dryRun bool, pr *ProjectResult) {
+	if len(comment.Reactions) == 0 || comment.ExternalID == "" {
+		return
+	}
+	reactor, ok := p.(plugin.CommentReactor)
+	store, hasStore := e.snapshots.(ReactionStore)
+	if !ok || !hasStore {
+		return
+	}
+
+	pushed, err := store.PushedReactions(project.ID, comment.ExternalID)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("comment", comment.ExternalID).Msg("Failed to load pushed reactions")
+		return
+	}
+
+	changed := false
+	for _, reaction := range comment.Reactions {
+		if slices.Contains(pushed, reaction.Emoji) {
+			continue
+		}
+		if dryRun {
+			e.logger.Debug().Str("task", toduTask.Title).Str("reaction", reaction.Emoji).Msg("Would push reaction")
+			continue
+		}
+		err := reactor.AddCommentReaction(ctx, &project.ExternalID, toduTask.ExternalID, comment.ExternalID, reaction.Emoji)
+		if err != nil && err != plugin.ErrNotSupported {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to push reaction to comment on task %s: %w", toduTask.Title, err))
+			continue
+		}
+		pushed = append(pushed, reaction.Emoji)
+		changed = true
+		e.logger.Debug().Str("task", toduTask.Title).Str("reaction", reaction.Emoji).Msg("Pushed reaction")
+	}
+
+	if changed {
+		if err := store.RecordPushedReactions(project.ID, comment.ExternalID, pushed); err != nil {
+			e.logger.Warn().Err(err).Str("comment", comment.ExternalID).Msg("Failed to record pushed reactions")
+		}
+	}
+}
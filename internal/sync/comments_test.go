@@ -0,0 +1,275 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// commentUpdaterPlugin is a mock plugin that records comment edits.
+type commentUpdaterPlugin struct {
+	*plugin.MockPlugin
+	updates []string
+}
+
+func (p *commentUpdaterPlugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	p.updates = append(p.updates, comment.Content)
+	return &types.Comment{ExternalID: commentExternalID, Content: comment.Content}, nil
+}
+
+// reactorPlugin is a mock plugin that records pushed reactions and doesn't
+// support the "🦄" reaction.
+type reactorPlugin struct {
+	*plugin.MockPlugin
+	reactions []string
+}
+
+func (p *reactorPlugin) AddCommentReaction(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID, emoji string) error {
+	if emoji == "🦄" {
+		return plugin.ErrNotSupported
+	}
+	p.reactions = append(p.reactions, commentExternalID+" "+emoji)
+	return nil
+}
+
+// incrementalCommentsPlugin is a mock plugin that records the since passed
+// to FetchCommentsSince and returns the comments updated since then.
+type incrementalCommentsPlugin struct {
+	*plugin.MockPlugin
+	comments []*types.Comment
+	since    []*time.Time
+}
+
+func (p *incrementalCommentsPlugin) FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error) {
+	p.since = append(p.since, since)
+	var result []*types.Comment
+	for _, comment := range p.comments {
+		if since == nil || !comment.UpdatedAt.Before(*since) {
+			result = append(result, comment)
+		}
+	}
+	return result, nil
+}
+
+func TestFileSnapshotStoreCommentCursors(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	cursor, err := store.CommentCursor(1, "task-1")
+	if err != nil || cursor != nil {
+		t.Fatalf("Expected no cursor, got %v (err %v)", cursor, err)
+	}
+
+	want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := store.RecordCommentCursor(1, "task-1", want); err != nil {
+		t.Fatalf("RecordCommentCursor failed: %v", err)
+	}
+
+	cursor, err = NewFileSnapshotStore(dir).CommentCursor(1, "task-1")
+	if err != nil || cursor == nil || !cursor.Equal(want) {
+		t.Errorf("Expected cursor %v, got %v (err %v)", want, cursor, err)
+	}
+}
+
+func TestSyncPullCommentsIncremental(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	p := &incrementalCommentsPlugin{
+		MockPlugin: mockPlugin,
+		comments: []*types.Comment{
+			{ExternalID: "c1", Content: "one", CreatedAt: first, UpdatedAt: first},
+			{ExternalID: "c2", Content: "two", CreatedAt: second, UpdatedAt: second},
+		},
+	}
+
+	ctx := context.Background()
+	project := &types.Project{ID: 1, ExternalID: "test-repo"}
+	task := &types.Task{ID: 1, ExternalID: "task-1", Title: "Task"}
+
+	pr := &ProjectResult{}
+	engine.syncPullComments(ctx, project, p, task, Options{}, pr)
+	engine.syncPullComments(ctx, project, p, task, Options{}, pr)
+
+	if len(pr.Errors) != 0 {
+		t.Fatalf("Expected no errors, got %v", pr.Errors)
+	}
+	if len(p.since) != 2 || p.since[0] != nil {
+		t.Fatalf("Expected a full fetch then an incremental one, got %v", p.since)
+	}
+	if p.since[1] == nil || !p.since[1].Equal(second) {
+		t.Errorf("Expected second fetch since %v, got %v", second, p.since[1])
+	}
+
+	// Dry runs don't advance the cursor
+	third := second.Add(time.Hour)
+	p.comments = append(p.comments, &types.Comment{ExternalID: "c3", Content: "three", CreatedAt: third, UpdatedAt: third})
+	engine.syncPullComments(ctx, project, p, task, Options{DryRun: true}, pr)
+	cursor, _ := store.CommentCursor(1, "task-1")
+	if cursor == nil || !cursor.Equal(second) {
+		t.Errorf("Expected dry run to keep cursor at %v, got %v", second, cursor)
+	}
+}
+
+func TestFileSnapshotStoreComments(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	if err := store.PutComment(1, &CommentSnapshot{ExternalID: "c1", LocalHash: "l", RemoteHash: "r"}); err != nil {
+		t.Fatalf("PutComment failed: %v", err)
+	}
+
+	snapshot, err := NewFileSnapshotStore(dir).GetComment(1, "c1")
+	if err != nil {
+		t.Fatalf("GetComment failed: %v", err)
+	}
+	if snapshot == nil || snapshot.LocalHash != "l" || snapshot.RemoteHash != "r" {
+		t.Errorf("Unexpected comment snapshot: %+v", snapshot)
+	}
+
+	// Comment snapshots don't mix with task snapshots
+	task, err := store.Get(1, "c1")
+	if err != nil || task != nil {
+		t.Errorf("Expected no task snapshot, got %+v (err %v)", task, err)
+	}
+}
+
+func TestReconcileComment(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+	p := &commentUpdaterPlugin{MockPlugin: mockPlugin}
+
+	ctx := context.Background()
+	project := &types.Project{ID: 1, ExternalID: "test-repo"}
+	task := &types.Task{ID: 1, ExternalID: "task-1", Title: "Task"}
+	now := time.Now()
+	local := &types.Comment{ID: 1, ExternalID: "c1", Content: "Original", Author: "alice", UpdatedAt: now}
+	remote := &types.Comment{ExternalID: "c1", Content: "Original\n\n— via todu", UpdatedAt: now}
+
+	getSnapshot := func() *CommentSnapshot {
+		t.Helper()
+		snapshot, err := store.GetComment(1, "c1")
+		if err != nil || snapshot == nil {
+			t.Fatalf("Expected comment snapshot, got %+v (err %v)", snapshot, err)
+		}
+		return snapshot
+	}
+
+	// First sighting records the base without propagating anything
+	pr := &ProjectResult{}
+	engine.reconcileComment(ctx, project, p, task, local, remote, StrategyPush, false, pr)
+	if len(p.updates) != 0 {
+		t.Errorf("Expected no updates without a base, got %v", p.updates)
+	}
+	getSnapshot()
+
+	// A Todu edit is pushed, but not during the pull pass
+	local.Content = "Edited in todu"
+	local.UpdatedAt = now.Add(time.Minute)
+	engine.reconcileComment(ctx, project, p, task, local, remote, StrategyPull, false, pr)
+	if len(p.updates) != 0 {
+		t.Errorf("Expected pull pass not to push edits, got %v", p.updates)
+	}
+	engine.reconcileComment(ctx, project, p, task, local, remote, StrategyPush, false, pr)
+	if len(p.updates) != 1 || p.updates[0] != "Edited in todu" {
+		t.Fatalf("Expected Todu edit to be pushed, got %v", p.updates)
+	}
+	if getSnapshot().LocalHash != contentHash("Edited in todu") {
+		t.Error("Expected snapshot to record the pushed content")
+	}
+
+	// An external edit is pulled into Todu
+	remote = &types.Comment{ExternalID: "c1", Content: "Edited remotely", UpdatedAt: now.Add(2 * time.Minute)}
+	engine.reconcileComment(ctx, project, p, task, local, remote, StrategyPull, false, pr)
+	snapshot := getSnapshot()
+	if snapshot.LocalHash != contentHash("Edited remotely") || snapshot.RemoteHash != contentHash("Edited remotely") {
+		t.Errorf("Expected snapshot to record the pulled content, got %+v", snapshot)
+	}
+	if len(pr.Errors) != 0 {
+		t.Errorf("Expected no errors, got %v", pr.Errors)
+	}
+}
+
+func TestCommentUpdatesEnabled(t *testing.T) {
+	options := Options{CommentUpdateProjectIDs: []int{2, 5}}
+
+	if !options.commentUpdatesEnabled(5) {
+		t.Error("Expected comment updates enabled for project 5")
+	}
+	if options.commentUpdatesEnabled(1) {
+		t.Error("Expected comment updates disabled for project 1")
+	}
+}
+
+func TestFileSnapshotStoreReactions(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	if err := store.RecordPushedReactions(1, "c1", []string{"👍", "🎉"}); err != nil {
+		t.Fatalf("RecordPushedReactions failed: %v", err)
+	}
+
+	pushed, err := NewFileSnapshotStore(dir).PushedReactions(1, "c1")
+	if err != nil || len(pushed) != 2 || pushed[0] != "👍" || pushed[1] != "🎉" {
+		t.Errorf("Expected [👍 🎉], got %v (err %v)", pushed, err)
+	}
+	if pushed, _ := store.PushedReactions(2, "c1"); len(pushed) != 0 {
+		t.Errorf("Expected no reactions for another project, got %v", pushed)
+	}
+}
+
+func TestPushReactions(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+	p := &reactorPlugin{MockPlugin: mockPlugin}
+
+	ctx := context.Background()
+	project := &types.Project{ID: 1, ExternalID: "test-repo"}
+	task := &types.Task{ID: 1, ExternalID: "task-1", Title: "Task"}
+	comment := &types.Comment{
+		ID:         1,
+		ExternalID: "c1",
+		Reactions: []types.Reaction{
+			{Emoji: "👍", Author: "alice"},
+			{Emoji: "👍", Author: "bob"},
+			{Emoji: "🦄", Author: "alice"},
+		},
+	}
+
+	// Dry runs push nothing
+	pr := &ProjectResult{}
+	engine.pushReactions(ctx, project, p, task, comment, true, pr)
+	if len(p.reactions) != 0 {
+		t.Fatalf("Expected dry run to push nothing, got %v", p.reactions)
+	}
+
+	// Each emoji is pushed once, and unsupported emoji aren't retried
+	engine.pushReactions(ctx, project, p, task, comment, false, pr)
+	engine.pushReactions(ctx, project, p, task, comment, false, pr)
+	if len(p.reactions) != 1 || p.reactions[0] != "c1 👍" {
+		t.Errorf("Expected one 👍 pushed to c1, got %v", p.reactions)
+	}
+
+	comment.Reactions = append(comment.Reactions, types.Reaction{Emoji: "🎉", Author: "bob"})
+	engine.pushReactions(ctx, project, p, task, comment, false, pr)
+	if len(p.reactions) != 2 || p.reactions[1] != "c1 🎉" {
+		t.Errorf("Expected 🎉 pushed next, got %v", p.reactions)
+	}
+	if len(pr.Errors) != 0 {
+		t.Errorf("Expected no errors, got %v", pr.Errors)
+	}
+}
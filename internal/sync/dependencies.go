@@ -0,0 +1,108 @@
+package sync
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// orderByDependencies orders tasks so each comes after its parent and
+// blockers among tasks, and otherwise keeps their order. A dependency cycle
+// is broken at its earliest task, so every task is still returned once.
+func orderByDependencies(tasks []*types.Task) []*types.Task {
+	index := make(map[int]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+
+	// waiting counts the dependencies of each task that aren't placed yet
+	waiting := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i, task := range tasks {
+		for _, id := range task.Dependencies() {
+			if j, ok := index[id]; ok && j != i {
+				waiting[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	placed := make([]bool, len(tasks))
+	ordered := make([]*types.Task, 0, len(tasks))
+	for len(ordered) < len(tasks) {
+		next := -1
+		for i := range tasks {
+			if !placed[i] && waiting[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			next = slices.Index(placed, false)
+		}
+		placed[next] = true
+		ordered = append(ordered, tasks[next])
+		for _, i := range dependents[next] {
+			waiting[i]--
+		}
+	}
+	return ordered
+}
+
+// taskExternalIDs maps the IDs of tasks to their external IDs, leaving out
+// tasks that haven't been synced.
+func taskExternalIDs(tasks []*types.Task) map[int]string {
+	externalIDs := make(map[int]string)
+	for _, task := range tasks {
+		if task.ExternalID != "" {
+			externalIDs[task.ID] = task.ExternalID
+		}
+	}
+	return externalIDs
+}
+
+// dependencyReferences returns a line referring to the task's parent and
+// each of its blockers by external ID, such as "Blocked by #12".
+// Dependencies not in externalIDs, such as tasks in other projects, are
+// left out.
+func dependencyReferences(task *types.Task, externalIDs map[int]string) []string {
+	var refs []string
+	if parent, ok := task.Parent(); ok {
+		if id := externalIDs[parent]; id != "" {
+			refs = append(refs, "Part of #"+id)
+		}
+	}
+	for _, blocker := range task.DependsOn() {
+		if id := externalIDs[blocker]; id != "" {
+			refs = append(refs, "Blocked by #"+id)
+		}
+	}
+	return refs
+}
+
+// withReferences appends the reference lines in refs that description
+// doesn't already have, and reports whether it added any.
+func withReferences(description *string, refs []string) (*string, bool) {
+	var body string
+	if description != nil {
+		body = *description
+	}
+	lines := strings.Split(body, "\n")
+
+	var missing []string
+	for _, ref := range refs {
+		if !slices.Contains(lines, ref) {
+			missing = append(missing, ref)
+		}
+	}
+	if len(missing) == 0 {
+		return description, false
+	}
+
+	if body != "" {
+		body = strings.TrimRight(body, "\n") + "\n\n"
+	}
+	body += strings.Join(missing, "\n")
+	return &body, true
+}
@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func dependentTask(id int, labels ...string) *types.Task {
+	task := &types.Task{ID: id, Title: fmt.Sprintf("Task %d", id)}
+	for _, name := range labels {
+		task.Labels = append(task.Labels, types.Label{Name: name})
+	}
+	return task
+}
+
+func taskIDs(tasks []*types.Task) []int {
+	var ids []int
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []*types.Task
+		want  string
+	}{
+		{"no dependencies", []*types.Task{dependentTask(1), dependentTask(2), dependentTask(3)}, "[1 2 3]"},
+		{"blocker after", []*types.Task{dependentTask(1, "depends-on:3"), dependentTask(2), dependentTask(3)}, "[2 3 1]"},
+		{"parent and blocker", []*types.Task{dependentTask(4, "parent:2", "depends-on:3"), dependentTask(3, "parent:2"), dependentTask(2)}, "[2 3 4]"},
+		{"chain", []*types.Task{dependentTask(1, "depends-on:2"), dependentTask(2, "depends-on:3"), dependentTask(3, "depends-on:4"), dependentTask(4)}, "[4 3 2 1]"},
+		{"outside dependencies are ignored", []*types.Task{dependentTask(1, "depends-on:9"), dependentTask(2, "parent:1")}, "[1 2]"},
+		{"self", []*types.Task{dependentTask(1, "depends-on:1"), dependentTask(2)}, "[1 2]"},
+		{"cycle", []*types.Task{dependentTask(1, "depends-on:2"), dependentTask(2, "depends-on:1"), dependentTask(3)}, "[3 1 2]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(taskIDs(orderByDependencies(tt.tasks))); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWithReferences(t *testing.T) {
+	task := dependentTask(3, "parent:1", "depends-on:2", "depends-on:8")
+	refs := dependencyReferences(task, map[int]string{1: "10", 2: "11"})
+	if fmt.Sprint(refs) != "[Part of #10 Blocked by #11]" {
+		t.Fatalf("Unexpected references %q", refs)
+	}
+
+	description, changed := withReferences(nil, refs)
+	if !changed || *description != "Part of #10\nBlocked by #11" {
+		t.Errorf("Expected references as the description, got %q", *description)
+	}
+
+	body := "Details\n"
+	description, changed = withReferences(&body, refs)
+	if !changed || *description != "Details\n\nPart of #10\nBlocked by #11" {
+		t.Errorf("Expected references after the description, got %q", *description)
+	}
+
+	again, changed := withReferences(description, refs)
+	if changed || again != description {
+		t.Errorf("Expected references already there to be left alone, got %q", *again)
+	}
+
+	if _, changed := withReferences(&body, nil); changed {
+		t.Error("Expected no change without references")
+	}
+}
+
+func TestSyncPushCreatesDependenciesFirst(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Repo", SystemID: system.ID, ExternalID: "test-repo"})
+	child := server.AddTask(types.Task{Title: "Write tests", ProjectID: project.ID, Labels: []types.Label{{Name: "parent:3"}, {Name: "depends-on:2"}}})
+	blocker := server.AddTask(types.Task{Title: "Design API", ProjectID: project.ID, Labels: []types.Label{{Name: "parent:3"}}})
+	parent := server.AddTask(types.Task{Title: "Ship v2", ProjectID: project.ID})
+
+	mock := plugin.NewMockPlugin("test-system")
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Repo"})
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+
+	engine := NewEngine(server.Client(), reg)
+	result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalCreated != 3 || result.TotalErrors != 0 {
+		t.Fatalf("Expected 3 tasks created, got %+v", result.ProjectResults)
+	}
+
+	if got := server.Task(parent.ID).ExternalID; got != "task-1" {
+		t.Errorf("Expected the parent created first, got external ID %q", got)
+	}
+	if got := server.Task(blocker.ID).ExternalID; got != "task-2" {
+		t.Errorf("Expected the blocker created second, got external ID %q", got)
+	}
+
+	pushed := server.Task(child.ID)
+	want := "Part of #task-1\nBlocked by #task-2"
+	if pushed.ExternalID != "task-3" || pushed.Description == nil || *pushed.Description != want {
+		t.Fatalf("Expected the child created last with references kept in Todu, got %+v", pushed)
+	}
+	external, err := mock.FetchTask(context.Background(), &project.ExternalID, "task-3")
+	if err != nil || external.Description == nil || *external.Description != want {
+		t.Errorf("Expected the external task to refer to its parent and blocker, got %+v (%v)", external, err)
+	}
+
+	// The references aren't pulled back as an external edit
+	result, err = engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if result.TotalCreated != 0 || result.TotalErrors != 0 || *server.Task(child.ID).Description != want {
+		t.Errorf("Expected the second sync to change nothing, got %+v", result)
+	}
+}
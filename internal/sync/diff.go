@@ -0,0 +1,184 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// FieldDiff describes a single field that differs between two versions of a task.
+type FieldDiff struct {
+	// Field is the name of the differing field (e.g., "title", "description").
+	Field string `json:"field"`
+
+	// Local is the display value of the field in Todu.
+	Local string `json:"local"`
+
+	// Remote is the display value of the field in the external system.
+	Remote string `json:"remote"`
+
+	// Diff is a unified diff of the values. Only set for multi-line text fields.
+	Diff string `json:"diff,omitempty"`
+}
+
+// DiffTasks compares the synced fields of a Todu task and an external task.
+// Returns one FieldDiff per field that differs, in a stable order.
+func DiffTasks(local, remote *types.Task) []FieldDiff {
+	var diffs []FieldDiff
+
+	if local.Title != remote.Title {
+		diffs = append(diffs, FieldDiff{Field: "title", Local: local.Title, Remote: remote.Title})
+	}
+
+	if local.Status != remote.Status {
+		diffs = append(diffs, FieldDiff{Field: "status", Local: local.Status, Remote: remote.Status})
+	}
+
+	localPriority, remotePriority := derefString(local.Priority), derefString(remote.Priority)
+	if localPriority != remotePriority {
+		diffs = append(diffs, FieldDiff{Field: "priority", Local: localPriority, Remote: remotePriority})
+	}
+
+	localDue, remoteDue := formatDate(local.DueDate), formatDate(remote.DueDate)
+	if localDue != remoteDue {
+		diffs = append(diffs, FieldDiff{Field: "due_date", Local: localDue, Remote: remoteDue})
+	}
+
+	localLabels := sortedNames(extractLabelNames(local.Labels))
+	remoteLabels := sortedNames(extractLabelNames(remote.Labels))
+	if localLabels != remoteLabels {
+		diffs = append(diffs, FieldDiff{Field: "labels", Local: localLabels, Remote: remoteLabels})
+	}
+
+	localAssignees := sortedNames(extractAssigneeNames(local.Assignees))
+	remoteAssignees := sortedNames(extractAssigneeNames(remote.Assignees))
+	if localAssignees != remoteAssignees {
+		diffs = append(diffs, FieldDiff{Field: "assignees", Local: localAssignees, Remote: remoteAssignees})
+	}
+
+	localDesc, remoteDesc := derefString(local.Description), derefString(remote.Description)
+	if localDesc != remoteDesc {
+		diffs = append(diffs, FieldDiff{
+			Field:  "description",
+			Local:  localDesc,
+			Remote: remoteDesc,
+			Diff:   UnifiedDiff(localDesc, remoteDesc, "local", "remote"),
+		})
+	}
+
+	return diffs
+}
+
+// UnifiedDiff returns a line-based unified diff between oldText and newText.
+// Returns an empty string when the texts are identical.
+//
+// The output uses a single hunk covering the whole text, which keeps the
+// implementation simple while remaining readable for task descriptions.
+func UnifiedDiff(oldText, newText, oldName, newName string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n", oldName)
+	fmt.Fprintf(&b, "+++ %s\n", newName)
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(len(oldLines)), hunkRange(len(newLines)))
+
+	for _, op := range diffLines(oldLines, newLines) {
+		b.WriteString(op)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// diffLines computes a line diff using the longest common subsequence and
+// returns each line prefixed with " ", "-", or "+".
+func diffLines(a, b []string) []string {
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, "-"+a[i])
+			i++
+		default:
+			ops = append(ops, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, "+"+b[j])
+	}
+
+	return ops
+}
+
+// splitLines splits text into lines, treating an empty string as no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// hunkRange formats a unified diff hunk range for a hunk starting at line 1.
+func hunkRange(count int) string {
+	if count == 0 {
+		return "0,0"
+	}
+	return fmt.Sprintf("1,%d", count)
+}
+
+// derefString returns the value of a string pointer, or "" if nil.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// formatDate formats a date-only field as YYYY-MM-DD in UTC, or "" if nil.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// sortedNames returns a comma-separated, sorted list of names for comparison.
+func sortedNames(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
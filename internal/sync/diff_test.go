@@ -0,0 +1,150 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/rs/zerolog"
+)
+
+func TestUnifiedDiffIdentical(t *testing.T) {
+	if diff := UnifiedDiff("same\ntext", "same\ntext", "a", "b"); diff != "" {
+		t.Errorf("Expected empty diff for identical text, got %q", diff)
+	}
+}
+
+func TestUnifiedDiffChangedLine(t *testing.T) {
+	diff := UnifiedDiff("line one\nline two\nline three", "line one\nline 2\nline three", "local", "remote")
+
+	expected := strings.Join([]string{
+		"--- local",
+		"+++ remote",
+		"@@ -1,3 +1,3 @@",
+		" line one",
+		"-line two",
+		"+line 2",
+		" line three",
+		"",
+	}, "\n")
+
+	if diff != expected {
+		t.Errorf("Unexpected diff.\nGot:\n%s\nWant:\n%s", diff, expected)
+	}
+}
+
+func TestUnifiedDiffFromEmpty(t *testing.T) {
+	diff := UnifiedDiff("", "new text", "old", "new")
+
+	if !strings.Contains(diff, "@@ -0,0 +1,1 @@") {
+		t.Errorf("Expected empty-source hunk header, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "+new text") {
+		t.Errorf("Expected added line, got:\n%s", diff)
+	}
+}
+
+func TestDiffTasksNoDifferences(t *testing.T) {
+	due := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	task := &types.Task{
+		Title:       "Same",
+		Status:      "active",
+		Priority:    stringPtr("high"),
+		DueDate:     &due,
+		Description: stringPtr("Body"),
+		Labels:      []types.Label{{Name: "b"}, {Name: "a"}},
+	}
+	other := &types.Task{
+		Title:       "Same",
+		Status:      "active",
+		Priority:    stringPtr("high"),
+		DueDate:     &due,
+		Description: stringPtr("Body"),
+		Labels:      []types.Label{{Name: "a"}, {Name: "b"}},
+	}
+
+	if diffs := DiffTasks(task, other); len(diffs) != 0 {
+		t.Errorf("Expected no differences, got %+v", diffs)
+	}
+}
+
+func TestDiffTasksReportsChangedFields(t *testing.T) {
+	local := &types.Task{
+		Title:       "Local title",
+		Status:      "active",
+		Description: stringPtr("old"),
+		Assignees:   []types.Assignee{{Name: "alice"}},
+	}
+	remote := &types.Task{
+		Title:       "Remote title",
+		Status:      "done",
+		Priority:    stringPtr("low"),
+		Description: stringPtr("new"),
+	}
+
+	diffs := DiffTasks(local, remote)
+
+	fields := make([]string, len(diffs))
+	for i, d := range diffs {
+		fields[i] = d.Field
+	}
+	expected := []string{"title", "status", "priority", "assignees", "description"}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected fields %v, got %v", expected, fields)
+	}
+
+	desc := diffs[len(diffs)-1]
+	if !strings.Contains(desc.Diff, "-old") || !strings.Contains(desc.Diff, "+new") {
+		t.Errorf("Expected description diff, got:\n%s", desc.Diff)
+	}
+}
+
+func TestSyncLogsOverwrittenDescriptions(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Repo", SystemID: system.ID, ExternalID: "test-repo"})
+	oldDesc := "Steps:\n1. Sign in"
+	server.AddTask(types.Task{Title: "Fix login", ProjectID: project.ID, ExternalID: "7", Description: &oldDesc})
+
+	mock := plugin.NewMockPlugin("test-system")
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Repo"})
+
+	var logs bytes.Buffer
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+	engine := NewEngine(server.Client(), reg).WithLogger(zerolog.New(&logs))
+
+	sync := func(strategy Strategy, description string, updatedAt time.Time) string {
+		t.Helper()
+		mock.AddTask("7", &types.Task{ExternalID: "7", Title: "Fix login", ProjectID: project.ID, Status: "active", Description: &description, UpdatedAt: updatedAt})
+		logs.Reset()
+		result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}, StrategyOverride: &strategy})
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if result.TotalUpdated != 1 || result.TotalErrors != 0 {
+			t.Fatalf("Expected the task updated, got %+v", result.ProjectResults)
+		}
+		return logs.String()
+	}
+
+	// An external edit pulled into todu logs the todu description's diff
+	pulled := sync(StrategyPull, "Steps:\n1. Sign in\n2. Wait", testsupport.DefaultNow.Add(time.Hour))
+	if !strings.Contains(pulled, `"target":"todu"`) || !strings.Contains(pulled, `+2. Wait`) {
+		t.Errorf("Expected the pulled description change logged, got:\n%s", pulled)
+	}
+
+	// A todu task pushed over an older external edit logs the external diff
+	pushed := sync(StrategyPush, "Steps:\n1. Log in", testsupport.DefaultNow.Add(-time.Hour))
+	if !strings.Contains(pushed, `"target":"external"`) || !strings.Contains(pushed, `-1. Log in`) {
+		t.Errorf("Expected the pushed description change logged, got:\n%s", pushed)
+	}
+}
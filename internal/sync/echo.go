@@ -0,0 +1,46 @@
+package sync
+
+import "github.com/evcraddock/todu.sh/pkg/types"
+
+// EchoStore persists fingerprints of tasks as the external system returned
+// them after a push. A SnapshotStore may also implement EchoStore to enable
+// echo suppression.
+//
+// Without it, the pull following a push sees the pushed change as a newer
+// remote update and rewrites the Todu task with identical content, churning
+// its UpdatedAt.
+type EchoStore interface {
+	// PushedFingerprint returns the fingerprint recorded by the last push of
+	// a task, or an empty string if none exists.
+	PushedFingerprint(projectID int, externalID string) (string, error)
+
+	// RecordPush stores the fingerprint of a task after it was pushed.
+	RecordPush(projectID int, externalID, fingerprint string) error
+}
+
+// recordPush remembers what a push wrote to the external system so the next
+// pull can recognize it. pushed is the task as returned by the plugin.
+// Failures are logged but do not fail the sync.
+func (e *Engine) recordPush(projectID int, pushed *types.Task) {
+	store, ok := e.snapshots.(EchoStore)
+	if !ok || pushed == nil || pushed.ExternalID == "" {
+		return
+	}
+	if err := store.RecordPush(projectID, pushed.ExternalID, Fingerprint(pushed)); err != nil {
+		e.logger.Warn().Err(err).Str("task", pushed.Title).Msg("Failed to record pushed task")
+	}
+}
+
+// isEcho reports whether an external task exactly matches what the last push
+// wrote, meaning its newer UpdatedAt comes from our own write.
+func (e *Engine) isEcho(projectID int, task *types.Task) bool {
+	store, ok := e.snapshots.(EchoStore)
+	if !ok {
+		return false
+	}
+	fingerprint, err := store.PushedFingerprint(projectID, task.ExternalID)
+	if err != nil || fingerprint == "" {
+		return false
+	}
+	return fingerprint == Fingerprint(task)
+}
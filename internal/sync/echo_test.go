@@ -0,0 +1,71 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestFileSnapshotStoreRecordPush(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	if err := store.RecordPush(1, "42", "abc"); err != nil {
+		t.Fatalf("RecordPush failed: %v", err)
+	}
+
+	fingerprint, err := NewFileSnapshotStore(dir).PushedFingerprint(1, "42")
+	if err != nil {
+		t.Fatalf("PushedFingerprint failed: %v", err)
+	}
+	if fingerprint != "abc" {
+		t.Errorf("Expected persisted fingerprint, got %q", fingerprint)
+	}
+
+	if err := store.RecordPush(1, "", "abc"); err == nil {
+		t.Error("Expected error for task without external_id")
+	}
+}
+
+func TestSyncPullSkipsEchoOfPush(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+
+	mockPlugin.AddProject("test-repo", &types.Project{ID: 1, ExternalID: "test-repo", Name: "Test Project"})
+	pushed := &types.Task{
+		ExternalID: "task-1",
+		Title:      "Test Task",
+		ProjectID:  1,
+		Status:     "active",
+		Labels:     []types.Label{{Name: "priority:high"}},
+		UpdatedAt:  time.Now(),
+	}
+	mockPlugin.AddTask("task-1", pushed)
+	engine.recordPush(1, pushed)
+
+	if !engine.isEcho(1, pushed) {
+		t.Fatal("Expected pushed task to be recognized as an echo")
+	}
+
+	// The mock API already has a Todu task for task-1, so without echo
+	// suppression the newer external task would overwrite it
+	result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{1}})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalUpdated != 0 {
+		t.Errorf("Expected echo not to update the Todu task, got %d updates", result.TotalUpdated)
+	}
+
+	// A later change on the external side is no longer an echo
+	changed := *pushed
+	changed.Title = "Renamed remotely"
+	if engine.isEcho(1, &changed) {
+		t.Error("Expected changed task not to be an echo")
+	}
+}
@@ -20,6 +20,9 @@ type Engine struct {
 	apiClient *api.Client
 	registry  *registry.Registry
 	logger    zerolog.Logger
+	snapshots SnapshotStore
+	runs      *RunStore
+	run       *Run // the sync being recorded, if any
 }
 
 // NewEngine creates a new sync engine with the given API client and plugin registry.
@@ -38,11 +41,21 @@ func (e *Engine) WithLogger(logger zerolog.Logger) *Engine {
 	return e
 }
 
+// WithSnapshots sets a snapshot store for the sync engine.
+// When set, bidirectional sync performs field-level three-way merges against
+// the last-synced snapshot instead of overwriting whole tasks.
+func (e *Engine) WithSnapshots(store SnapshotStore) *Engine {
+	e.snapshots = store
+	return e
+}
+
 // Sync performs synchronization based on the provided options.
 // Returns a Result summarizing what was synced and any errors encountered.
 func (e *Engine) Sync(ctx context.Context, options Options) (*Result, error) {
 	startTime := time.Now()
 	result := &Result{}
+	e.startRun(options.DryRun)
+	defer e.finishRun(result)
 
 	// Get projects to sync
 	projects, err := e.getProjectsToSync(ctx, options)
@@ -59,6 +72,11 @@ func (e *Engine) Sync(ctx context.Context, options Options) (*Result, error) {
 
 	// Sync each project
 	for _, project := range projects {
+		if ctx.Err() != nil {
+			// Projects not started are left out of the results
+			result.Interrupted = true
+			break
+		}
 		pr := e.syncProject(ctx, project, options)
 		result.AddProjectResult(pr)
 	}
@@ -67,6 +85,19 @@ func (e *Engine) Sync(ctx context.Context, options Options) (*Result, error) {
 	return result, nil
 }
 
+// interrupted reports whether ctx was canceled, recording it in pr the
+// first time, so syncing stops between tasks instead of failing each one.
+func interrupted(ctx context.Context, pr *ProjectResult) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	if !pr.Interrupted {
+		pr.Interrupted = true
+		pr.Errors = append(pr.Errors, fmt.Errorf("sync interrupted: %w", ctx.Err()))
+	}
+	return true
+}
+
 // getProjectsToSync retrieves the list of projects to sync based on options.
 func (e *Engine) getProjectsToSync(ctx context.Context, options Options) ([]*types.Project, error) {
 	// If specific project IDs are provided, fetch those
@@ -88,12 +119,16 @@ func (e *Engine) getProjectsToSync(ctx context.Context, options Options) ([]*typ
 }
 
 // syncProject synchronizes a single project.
-func (e *Engine) syncProject(ctx context.Context, project *types.Project, options Options) ProjectResult {
-	pr := ProjectResult{
+func (e *Engine) syncProject(ctx context.Context, project *types.Project, options Options) (pr ProjectResult) {
+	startTime := time.Now()
+	pr = ProjectResult{
 		ProjectID:   project.ID,
 		ProjectName: project.Name,
 		Errors:      []error{},
 	}
+	defer func() {
+		pr.Duration = time.Since(startTime)
+	}()
 
 	e.logger.Debug().Str("project", project.Name).Int("id", project.ID).Msg("Syncing project")
 
@@ -101,53 +136,42 @@ func (e *Engine) syncProject(ctx context.Context, project *types.Project, option
 	strategy := e.determineStrategy(project, options)
 	e.logger.Debug().Str("project", project.Name).Str("strategy", string(strategy)).Msg("Using strategy")
 
-	// Get system for project
-	system, err := e.apiClient.GetSystem(ctx, project.SystemID)
+	p, err := e.createPlugin(ctx, project)
 	if err != nil {
-		pr.Errors = append(pr.Errors, fmt.Errorf("failed to get system: %w", err))
-		return pr
-	}
-
-	// Create plugin instance
-	pluginConfig, err := registry.LoadPluginConfig(system.Identifier)
-	if err != nil {
-		pr.Errors = append(pr.Errors, fmt.Errorf("failed to load plugin config: %w", err))
-		return pr
-	}
-	p, err := e.registry.Create(system.Identifier, pluginConfig)
-	if err != nil {
-		pr.Errors = append(pr.Errors, fmt.Errorf("failed to create plugin: %w", err))
-		return pr
-	}
-
-	// Validate plugin configuration
-	if err := p.ValidateConfig(); err != nil {
-		pr.Errors = append(pr.Errors, fmt.Errorf("plugin not configured: %w", err))
+		pr.Errors = append(pr.Errors, err)
 		return pr
 	}
 
 	// Perform sync based on strategy
 	switch strategy {
 	case StrategyPull:
-		e.syncPull(ctx, project, p, options.DryRun, &pr)
+		e.syncPull(ctx, project, p, options, &pr, nil)
 	case StrategyPush:
-		e.syncPush(ctx, project, p, options, &pr)
+		e.syncPush(ctx, project, p, options, &pr, nil)
 	case StrategyBidirectional:
-		e.syncPull(ctx, project, p, options.DryRun, &pr)
-		e.syncPush(ctx, project, p, options, &pr)
+		// Tasks merged during pull are tracked so push doesn't overwrite them
+		merged := make(map[string]bool)
+		e.syncPull(ctx, project, p, options, &pr, merged)
+		if !interrupted(ctx, &pr) {
+			e.syncPush(ctx, project, p, options, &pr, merged)
+		}
 	default:
 		pr.Errors = append(pr.Errors, fmt.Errorf("unknown strategy: %s", strategy))
 	}
 
+	// An interrupted sync isn't complete, so last_synced_at stays as it was
+	interrupted(ctx, &pr)
+
 	if len(pr.Errors) == 0 {
 		e.logger.Debug().
 			Str("project", project.Name).
 			Int("created", pr.Created).
 			Int("updated", pr.Updated).
 			Int("skipped", pr.Skipped).
+			Int("linked", pr.Linked).
 			Msg("Project synced")
-		// Update last_synced_at timestamp on successful sync
-		if !options.DryRun {
+		// Update last_synced_at timestamp on successful full sync
+		if !options.DryRun && options.Filter.IsEmpty() {
 			now := time.Now()
 			projectUpdate := &types.ProjectUpdate{
 				LastSyncedAt: &now,
@@ -155,6 +179,8 @@ func (e *Engine) syncProject(ctx context.Context, project *types.Project, option
 			_, err := e.apiClient.UpdateProject(ctx, project.ID, projectUpdate)
 			if err != nil {
 				e.logger.Warn().Err(err).Str("project", project.Name).Msg("Failed to update last_synced_at")
+			} else {
+				e.clearCheckpoint(project.ID)
 			}
 		}
 	} else {
@@ -170,6 +196,32 @@ func (e *Engine) syncProject(ctx context.Context, project *types.Project, option
 	return pr
 }
 
+// createPlugin creates and validates the plugin for a project's system.
+func (e *Engine) createPlugin(ctx context.Context, project *types.Project) (plugin.Plugin, error) {
+	// Get system for project
+	system, err := e.apiClient.GetSystem(ctx, project.SystemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+
+	// Create plugin instance
+	pluginConfig, err := registry.LoadPluginConfig(system.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugin config: %w", err)
+	}
+	p, err := e.registry.Create(system.Identifier, pluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin: %w", err)
+	}
+
+	// Validate plugin configuration
+	if err := p.ValidateConfig(); err != nil {
+		return nil, fmt.Errorf("plugin not configured: %w", err)
+	}
+
+	return withCommentPolicy(p, CommentPolicyFromConfig(pluginConfig)), nil
+}
+
 // determineStrategy determines which sync strategy to use for a project.
 func (e *Engine) determineStrategy(project *types.Project, options Options) Strategy {
 	// Use override if provided
@@ -182,9 +234,19 @@ func (e *Engine) determineStrategy(project *types.Project, options Options) Stra
 }
 
 // syncPull pulls tasks from external system to Todu.
-func (e *Engine) syncPull(ctx context.Context, project *types.Project, p plugin.Plugin, dryRun bool, pr *ProjectResult) {
+//
+// If merged is non-nil and a snapshot store is configured, existing tasks with
+// a last-synced snapshot are merged field by field in both directions, and
+// their external IDs are added to merged.
+func (e *Engine) syncPull(ctx context.Context, project *types.Project, p plugin.Plugin, options Options, pr *ProjectResult, merged map[string]bool) {
+	// An interrupted initial pull resumes after the tasks it already processed,
+	// and a paged fetch from the page it reached
+	checkpoint := e.loadCheckpoint(project, options)
+	defer checkpoint.flush()
+	cursor, pulled := checkpoint.resumeCursor()
+
 	// Fetch tasks from external system (use LastSyncedAt for incremental sync)
-	externalTasks, err := p.FetchTasks(ctx, &project.ExternalID, project.LastSyncedAt)
+	externalTasks, next, err := fetchTaskPage(ctx, project, p, checkpoint, cursor)
 	if err != nil {
 		pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch external tasks: %w", err))
 		return
@@ -205,75 +267,215 @@ func (e *Engine) syncPull(ctx context.Context, project *types.Project, p plugin.
 		}
 	}
 
-	// Process each external task
-	for _, externalTask := range externalTasks {
-		if externalTask.ExternalID == "" {
-			e.logger.Debug().Msg("External task has no external_id, skipping")
-			pr.Skipped++
-			continue
-		}
+	links := e.projectLinks(project.ID)
+	duplicates := &duplicateFinder{apiClient: e.apiClient, projectID: project.ID, mode: options.DuplicateMatch}
 
-		toduTask, exists := toduTaskMap[externalTask.ExternalID]
+	// Tasks on the pages before the cursor were processed before the pull
+	// was interrupted
+	pr.Skipped += pulled
+	pr.Resumed += pulled
+	progress := Progress{ProjectID: project.ID, ProjectName: project.Name, Done: pulled, Total: pulled + len(externalTasks), Resumed: pulled, Started: time.Now()}
 
-		if !exists {
-			// Task doesn't exist in Todu, create it
-			if !dryRun {
-				taskCreate := &types.TaskCreate{
-					ExternalID:  externalTask.ExternalID,
-					SourceURL:   externalTask.SourceURL,
-					Title:       externalTask.Title,
-					Description: externalTask.Description,
-					ProjectID:   project.ID,
-					Status:      externalTask.Status,
-					Priority:    externalTask.Priority,
-					DueDate:     externalTask.DueDate,
-					Labels:      extractLabelNames(externalTask.Labels),
-					Assignees:   extractAssigneeNames(externalTask.Assignees),
-				}
-				createdTask, err := e.apiClient.CreateTask(ctx, taskCreate)
-				if err != nil {
-					pr.Errors = append(pr.Errors, fmt.Errorf("failed to create task %q: %w", externalTask.Title, err))
-					continue
+	for {
+		if !options.DryRun {
+			e.pullLabelColors(ctx, externalTasks)
+		}
+
+		// Process each external task
+		failed := false
+		for _, externalTask := range externalTasks {
+			if interrupted(ctx, pr) {
+				return
+			}
+			if checkpoint.has(externalTask.ExternalID) {
+				pr.Skipped++
+				pr.Resumed++
+				progress.Resumed++
+			} else {
+				errCount := len(pr.Errors)
+				e.pullTask(ctx, project, p, externalTask, toduTaskMap, links, duplicates, options, pr, merged)
+				if len(pr.Errors) == errCount && externalTask.ExternalID != "" {
+					checkpoint.mark(externalTask.ExternalID)
+				} else if len(pr.Errors) > errCount {
+					failed = true
 				}
-				// Sync comments for newly created task
-				e.syncPullComments(ctx, project, p, createdTask, dryRun, pr)
 			}
-			e.logger.Debug().Str("task", externalTask.Title).Msg("Created task")
-			pr.Created++
-		} else if NeedsUpdate(externalTask, toduTask) {
-			// External task is newer, update Todu task
+
+			progress.Done++
+			if options.Progress != nil {
+				options.Progress(progress)
+			}
+		}
+
+		if next == "" {
+			return
+		}
+		checkpoint.pageDone(next, externalTasks, failed)
+		if interrupted(ctx, pr) {
+			return
+		}
+
+		externalTasks, next, err = fetchTaskPage(ctx, project, p, checkpoint, next)
+		if err != nil {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch external tasks: %w", err))
+			return
+		}
+		progress.Total += len(externalTasks)
+	}
+}
+
+// fetchTaskPage fetches a project's external tasks from cursor on. The
+// initial pull of a plugin that fetches tasks a page at a time gets one page
+// and the cursor of the next, so the checkpoint can record the page reached;
+// any other pull gets every task and an empty cursor.
+func fetchTaskPage(ctx context.Context, project *types.Project, p plugin.Plugin, checkpoint *pullCheckpoint, cursor string) ([]*types.Task, string, error) {
+	if pager, ok := p.(plugin.PagedTasks); ok && checkpoint != nil {
+		return pager.FetchTaskPage(ctx, &project.ExternalID, project.LastSyncedAt, cursor)
+	}
+	tasks, err := p.FetchTasks(ctx, &project.ExternalID, project.LastSyncedAt)
+	return tasks, "", err
+}
+
+// pullTask pulls a single external task into Todu.
+func (e *Engine) pullTask(ctx context.Context, project *types.Project, p plugin.Plugin, externalTask *types.Task, toduTaskMap map[string]*types.Task, links map[string]int, duplicates *duplicateFinder, options Options, pr *ProjectResult, merged map[string]bool) {
+	dryRun := options.DryRun
+
+	if externalTask.ExternalID == "" {
+		e.logger.Debug().Msg("External task has no external_id, skipping")
+		pr.Skipped++
+		return
+	}
+
+	if !options.Filter.Matches(externalTask) {
+		return
+	}
+
+	toduTask, exists := toduTaskMap[externalTask.ExternalID]
+
+	// Skip external tasks that exactly match what we last pushed
+	if exists && e.isEcho(project.ID, externalTask) {
+		e.logger.Debug().Str("task", externalTask.Title).Msg("Skipping echo of pushed change")
+		pr.Skipped++
+		if !dryRun {
+			e.syncPullComments(ctx, project, p, toduTask, options, pr)
+		}
+		return
+	}
+
+	// Bidirectional sync with a known base: merge field by field
+	if exists && merged != nil && e.snapshots != nil {
+		base, err := e.snapshots.Get(project.ID, externalTask.ExternalID)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("task", externalTask.Title).Msg("Failed to load sync snapshot")
+		}
+		if base != nil {
+			e.mergeTask(ctx, project, p, base, toduTask, externalTask, dryRun, pr)
+			merged[externalTask.ExternalID] = true
 			if !dryRun {
-				taskUpdate := &types.TaskUpdate{
-					Title:       &externalTask.Title,
-					Description: externalTask.Description,
-					Status:      &externalTask.Status,
-					Priority:    externalTask.Priority,
-					DueDate:     externalTask.DueDate,
-					Labels:      extractLabelNames(externalTask.Labels),
-					Assignees:   extractAssigneeNames(externalTask.Assignees),
-				}
-				_, err := e.apiClient.UpdateTask(ctx, toduTask.ID, taskUpdate)
-				if err != nil {
-					pr.Errors = append(pr.Errors, fmt.Errorf("failed to update task %q: %w", externalTask.Title, err))
-					continue
-				}
+				e.syncPullComments(ctx, project, p, toduTask, options, pr)
 			}
-			e.logger.Debug().Str("task", externalTask.Title).Msg("Updated task")
-			pr.Updated++
-		} else {
-			// Todu task is up to date
-			pr.Skipped++
+			return
+		}
+	}
+
+	if !exists {
+		// Linked external tasks are synced with a task in another project
+		if taskID := links[externalTask.ExternalID]; taskID != 0 {
+			e.pullLinkedTask(ctx, project, p, taskID, externalTask, dryRun, pr, merged)
+			return
 		}
 
-		// Sync comments for existing tasks
-		if exists && !dryRun {
-			e.syncPullComments(ctx, project, p, toduTask, dryRun, pr)
+		// The same work item may already be synced from another system
+		linked, err := e.linkDuplicate(ctx, project, externalTask, duplicates, dryRun)
+		if err != nil {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to check task %q for duplicates: %w", externalTask.Title, err))
+			return
 		}
+		if linked {
+			if merged != nil {
+				merged[externalTask.ExternalID] = true
+			}
+			pr.Linked++
+			return
+		}
+
+		// Task doesn't exist in Todu, create it
+		if !dryRun {
+			taskCreate := &types.TaskCreate{
+				ExternalID:  externalTask.ExternalID,
+				SourceURL:   externalTask.SourceURL,
+				Title:       externalTask.Title,
+				Description: externalTask.Description,
+				ProjectID:   project.ID,
+				Status:      externalTask.Status,
+				Priority:    externalTask.Priority,
+				DueDate:     externalTask.DueDate,
+				Labels:      extractLabelNames(externalTask.Labels),
+				Assignees:   extractAssigneeNames(externalTask.Assignees),
+			}
+			createdTask, err := e.createTask(ctx, taskCreate)
+			if err != nil {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to create task %q: %w", externalTask.Title, err))
+				return
+			}
+			e.saveSnapshot(project.ID, externalTask)
+			// Sync comments for newly created task
+			e.syncPullComments(ctx, project, p, createdTask, options, pr)
+		}
+		e.logger.Debug().Str("task", externalTask.Title).Msg("Created task")
+		pr.Created++
+	} else if e.unchangedSinceSync(project.ID, externalTask) {
+		// External task content matches the last-synced snapshot, so the
+		// newer UpdatedAt comes from a change that doesn't affect synced fields
+		pr.Skipped++
+	} else if e.externalChanged(project.ID, p, externalTask, toduTask) {
+		// External task is newer, update Todu task
+		if !dryRun {
+			// Fetch full task details to get description (not included in list response)
+			fullTask, err := e.apiClient.GetTask(ctx, toduTask.ID)
+			if err != nil {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err))
+				return
+			}
+			taskUpdate := &types.TaskUpdate{
+				Title:       &externalTask.Title,
+				Description: externalTask.Description,
+				Status:      &externalTask.Status,
+				Priority:    externalTask.Priority,
+				DueDate:     externalTask.DueDate,
+				Labels:      extractLabelNames(externalTask.Labels),
+				Assignees:   extractAssigneeNames(externalTask.Assignees),
+			}
+			keepTaskRefLabels(taskUpdate, fullTask.Labels)
+			_, err = e.updateTask(ctx, toduTask.ID, taskUpdate)
+			if err != nil {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to update task %q: %w", externalTask.Title, err))
+				return
+			}
+			e.logDescriptionChange(externalTask.Title, "todu", fullTask.Description, externalTask.Description)
+			e.saveSnapshot(project.ID, externalTask)
+		}
+		e.logger.Debug().Str("task", externalTask.Title).Msg("Updated task")
+		pr.Updated++
+	} else {
+		// Todu task is up to date. Without a snapshot, untimed tasks can't
+		// show later edits, so the external task becomes their base
+		if !dryRun && tasksUntimed(p) {
+			e.saveSnapshot(project.ID, externalTask)
+		}
+		pr.Skipped++
+	}
+
+	// Sync comments for existing tasks
+	if exists && !dryRun {
+		e.syncPullComments(ctx, project, p, toduTask, options, pr)
 	}
 }
 
-// syncPush pushes tasks from Todu to external system.
-func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.Plugin, options Options, pr *ProjectResult) {
+// syncPush pushes tasks from Todu to external system, including tasks in
+// other projects linked to the project's external tasks.
+// Tasks whose external IDs are in merged were already reconciled during pull and are skipped.
+func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.Plugin, options Options, pr *ProjectResult, merged map[string]bool) {
 	// Fetch tasks from Todu API
 	toduTasks, err := e.apiClient.ListTasks(ctx, &api.TaskListOptions{ProjectID: &project.ID})
 	if err != nil {
@@ -281,8 +483,16 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 		return
 	}
 
-	// Process all tasks: create new ones and update existing ones
+	var pending []*types.Task
 	for _, toduTask := range toduTasks {
+		if toduTask.ExternalID != "" && merged[toduTask.ExternalID] {
+			continue
+		}
+
+		if !options.Filter.Matches(toduTask) {
+			continue
+		}
+
 		// Skip tasks that haven't been modified since last successful push (optimization)
 		// Uses per-task last_pushed_at instead of project-level last_synced_at for accurate tracking
 		// Force flag bypasses this check to allow re-pushing all tasks
@@ -291,6 +501,24 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 			continue
 		}
 
+		pending = append(pending, toduTask)
+	}
+
+	// Labels with a Todu color are created with it before tasks need them
+	if !options.DryRun {
+		e.pushLabelColors(ctx, project, p, pending)
+	}
+
+	// Parents and blockers are created first, so the tasks that depend on
+	// them can refer to them by external ID
+	pending = orderByDependencies(pending)
+	externalIDs := taskExternalIDs(toduTasks)
+
+	// Process all tasks: create new ones and update existing ones
+	for _, toduTask := range pending {
+		if interrupted(ctx, pr) {
+			return
+		}
 		if toduTask.ExternalID == "" {
 			// Task doesn't have external_id, create it in external system
 			if !options.DryRun {
@@ -300,14 +528,16 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err))
 					continue
 				}
+				description, referenced := withReferences(fullTask.Description, dependencyReferences(fullTask, externalIDs))
 				taskCreate := &types.TaskCreate{
-					Title:       fullTask.Title,
-					Description: fullTask.Description,
-					Status:      fullTask.Status,
-					Priority:    fullTask.Priority,
-					DueDate:     fullTask.DueDate,
-					Labels:      extractLabelNames(fullTask.Labels),
-					Assignees:   extractAssigneeNames(fullTask.Assignees),
+					Title:         fullTask.Title,
+					Description:   description,
+					Status:        fullTask.Status,
+					Priority:      fullTask.Priority,
+					DueDate:       fullTask.DueDate,
+					ScheduledDate: fullTask.ScheduledDate,
+					Labels:        extractLabelNames(fullTask.Labels),
+					Assignees:     extractAssigneeNames(fullTask.Assignees),
 				}
 				createdTask, err := p.CreateTask(ctx, &project.ExternalID, taskCreate)
 				if err != nil {
@@ -318,17 +548,21 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to create external task %q: %w", toduTask.Title, err))
 					continue
 				}
+				externalIDs[toduTask.ID] = createdTask.ExternalID
 
 				// If task is already done/canceled in Todu, close it in external system
 				// (GitHub API doesn't support creating issues in closed state)
+				pushedTask := createdTask
 				if fullTask.Status == "done" || fullTask.Status == "canceled" {
 					statusUpdate := &types.TaskUpdate{
 						Status: &fullTask.Status,
 					}
-					_, err = p.UpdateTask(ctx, &project.ExternalID, createdTask.ExternalID, statusUpdate)
+					closedTask, err := p.UpdateTask(ctx, &project.ExternalID, createdTask.ExternalID, statusUpdate)
 					if err != nil && err != plugin.ErrNotSupported {
 						pr.Errors = append(pr.Errors, fmt.Errorf("failed to close external task %q: %w", toduTask.Title, err))
 						// Continue anyway - task was created, just not closed
+					} else if closedTask != nil {
+						pushedTask = closedTask
 					}
 				}
 
@@ -339,11 +573,20 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 					SourceURL:    createdTask.SourceURL,
 					LastPushedAt: &now,
 				}
-				_, err = e.apiClient.UpdateTask(ctx, toduTask.ID, taskUpdate)
+				// Dependency references are kept in Todu too, so they
+				// aren't pulled back as an external edit
+				if referenced {
+					taskUpdate.Description = description
+					fullTask.Description = description
+				}
+				_, err = e.updateTask(ctx, toduTask.ID, taskUpdate)
 				if err != nil {
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to update task with external_id: %w", err))
 					continue
 				}
+				fullTask.ExternalID = createdTask.ExternalID
+				e.saveSnapshot(project.ID, fullTask)
+				e.recordPush(project.ID, pushedTask)
 				e.logger.Debug().Str("task", toduTask.Title).Str("external_id", createdTask.ExternalID).Msg("Created external task")
 			} else {
 				e.logger.Debug().Str("task", toduTask.Title).Msg("Would create external task (dry run)")
@@ -397,15 +640,17 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 					continue
 				}
 				taskUpdate := &types.TaskUpdate{
-					Title:       &fullTask.Title,
-					Description: fullTask.Description,
-					Status:      &fullTask.Status,
-					Priority:    fullTask.Priority,
-					DueDate:     fullTask.DueDate,
-					Labels:      extractLabelNames(fullTask.Labels),
-					Assignees:   extractAssigneeNames(fullTask.Assignees),
+					Title:         &fullTask.Title,
+					Description:   fullTask.Description,
+					Status:        &fullTask.Status,
+					Priority:      fullTask.Priority,
+					DueDate:       fullTask.DueDate,
+					ScheduledDate: fullTask.ScheduledDate,
+					Labels:        extractLabelNames(fullTask.Labels),
+					Assignees:     extractAssigneeNames(fullTask.Assignees),
 				}
-				_, err = p.UpdateTask(ctx, &project.ExternalID, toduTask.ExternalID, taskUpdate)
+				previousDescription := externalTask.Description
+				pushedTask, err := p.UpdateTask(ctx, &project.ExternalID, toduTask.ExternalID, taskUpdate)
 				if err != nil {
 					if err == plugin.ErrNotSupported {
 						pr.Skipped++
@@ -414,13 +659,16 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to push task %q: %w", toduTask.Title, err))
 					continue
 				}
+				e.logDescriptionChange(fullTask.Title, "external", previousDescription, fullTask.Description)
+				e.saveSnapshot(project.ID, fullTask)
+				e.recordPush(project.ID, pushedTask)
 				// Update last_pushed_at after successful push (skip if force to preserve timestamps)
 				if !options.Force {
 					now := time.Now()
 					lastPushedUpdate := &types.TaskUpdate{
 						LastPushedAt: &now,
 					}
-					_, err = e.apiClient.UpdateTask(ctx, toduTask.ID, lastPushedUpdate)
+					_, err = e.updateTask(ctx, toduTask.ID, lastPushedUpdate)
 					if err != nil {
 						e.logger.Warn().Err(err).Str("task", toduTask.Title).Msg("Failed to update last_pushed_at")
 						// Don't fail the sync, just log the warning
@@ -436,20 +684,149 @@ func (e *Engine) syncPush(ctx context.Context, project *types.Project, p plugin.
 
 		// Sync comments for tasks being pushed
 		if toduTask.ExternalID != "" && !options.DryRun {
-			e.syncPushComments(ctx, project, p, toduTask, options.DryRun, pr)
+			e.syncPushComments(ctx, project, p, toduTask, options, pr)
 		}
 	}
+
+	e.pushLinkedTasks(ctx, project, p, options, pr, merged)
+}
+
+// mergeTask reconciles a task changed on both sides using a three-way merge
+// against its last-synced snapshot, then writes the merged fields to whichever
+// side needs them.
+func (e *Engine) mergeTask(ctx context.Context, project *types.Project, p plugin.Plugin, base, toduTask, externalTask *types.Task, dryRun bool, pr *ProjectResult) {
+	// Fetch full task details to get description (not included in list response)
+	fullTask, err := e.apiClient.GetTask(ctx, toduTask.ID)
+	if err != nil {
+		pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err))
+		return
+	}
+
+	result := MergeTask(base, fullTask, externalTask)
+	for _, field := range result.Conflicts {
+		e.logger.Warn().
+			Str("task", fullTask.Title).
+			Str("external_id", externalTask.ExternalID).
+			Str("field", field).
+			Msg("Conflict detected - field changed on both sides, using most recent version")
+	}
+
+	localChanged := len(DiffTasks(fullTask, result.Task)) > 0
+	remoteChanged := len(DiffTasks(externalTask, result.Task)) > 0
+	if !localChanged && !remoteChanged {
+		if !dryRun {
+			e.saveSnapshot(project.ID, result.Task)
+		}
+		pr.Skipped++
+		return
+	}
+
+	if !dryRun {
+		if remoteChanged {
+			previousDescription := externalTask.Description
+			pushedTask, err := p.UpdateTask(ctx, &project.ExternalID, externalTask.ExternalID, taskUpdateFromTask(result.Task))
+			if err != nil && err != plugin.ErrNotSupported {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to push merged task %q: %w", fullTask.Title, err))
+				return
+			}
+			if err == nil {
+				e.recordPush(project.ID, pushedTask)
+				e.logDescriptionChange(fullTask.Title, "external", previousDescription, result.Task.Description)
+			}
+		}
+
+		taskUpdate := &types.TaskUpdate{}
+		if localChanged {
+			taskUpdate = taskUpdateFromTask(result.Task)
+			keepTaskRefLabels(taskUpdate, fullTask.Labels)
+			e.logDescriptionChange(fullTask.Title, "todu", fullTask.Description, result.Task.Description)
+		}
+		// last_pushed_at only tracks the task's own external ID, not links
+		if remoteChanged && fullTask.ExternalID == externalTask.ExternalID {
+			now := time.Now()
+			taskUpdate.LastPushedAt = &now
+		}
+		if localChanged || taskUpdate.LastPushedAt != nil {
+			_, err := e.updateTask(ctx, toduTask.ID, taskUpdate)
+			if err != nil {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to update merged task %q: %w", fullTask.Title, err))
+				return
+			}
+		}
+
+		e.saveSnapshot(project.ID, result.Task)
+	}
+
+	e.logger.Debug().
+		Str("task", result.Task.Title).
+		Bool("todu_changed", localChanged).
+		Bool("external_changed", remoteChanged).
+		Msg("Merged task")
+	pr.Updated++
+}
+
+// unchangedSinceSync reports whether a task's synced fields match its
+// last-synced snapshot. Returns false when no snapshot is available.
+func (e *Engine) unchangedSinceSync(projectID int, task *types.Task) bool {
+	if e.snapshots == nil {
+		return false
+	}
+	base, err := e.snapshots.Get(projectID, task.ExternalID)
+	if err != nil || base == nil {
+		return false
+	}
+	return len(DiffTasks(base, task)) == 0
+}
+
+// externalChanged reports whether an external task has changes to pull:
+// it is newer than its Todu task or, for plugins that can't tell when their
+// tasks changed, it differs from its last-synced snapshot.
+func (e *Engine) externalChanged(projectID int, p plugin.Plugin, externalTask, toduTask *types.Task) bool {
+	if NeedsUpdate(externalTask, toduTask) {
+		return true
+	}
+	if !tasksUntimed(p) || e.snapshots == nil {
+		return false
+	}
+	base, err := e.snapshots.Get(projectID, externalTask.ExternalID)
+	if err != nil || base == nil {
+		return false
+	}
+	return len(DiffTasks(base, externalTask)) > 0
+}
+
+// tasksUntimed reports whether a plugin's tasks don't show when they were
+// last changed.
+func tasksUntimed(p plugin.Plugin) bool {
+	untimed, ok := p.(plugin.UntimedTasks)
+	return ok && untimed.TasksUntimed()
+}
+
+// saveSnapshot records the state of a task after a successful sync.
+// Failures are logged but do not fail the sync.
+func (e *Engine) saveSnapshot(projectID int, task *types.Task) {
+	if e.snapshots == nil || task.ExternalID == "" {
+		return
+	}
+	if err := e.snapshots.Put(projectID, task); err != nil {
+		e.logger.Warn().Err(err).Str("task", task.Title).Msg("Failed to save sync snapshot")
+	}
 }
 
 // syncPullComments pulls comments from external system to Todu for a specific task.
-func (e *Engine) syncPullComments(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, dryRun bool, pr *ProjectResult) {
+// If comment updates are enabled for the project, edits to external comments
+// are pulled into their Todu copies.
+func (e *Engine) syncPullComments(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, options Options, pr *ProjectResult) {
+	dryRun := options.DryRun
+	commentUpdates := options.commentUpdatesEnabled(project.ID)
+
 	// Skip if task has no external_id
 	if toduTask.ExternalID == "" {
 		return
 	}
 
-	// Fetch comments from external system
-	externalComments, err := p.FetchComments(ctx, &project.ExternalID, toduTask.ExternalID)
+	// Fetch comments from external system, incrementally when supported
+	externalComments, cursor, err := e.fetchPullComments(ctx, project, p, toduTask)
 	if err != nil {
 		// If plugin doesn't support comments, silently skip
 		if err == plugin.ErrNotSupported {
@@ -475,13 +852,17 @@ func (e *Engine) syncPullComments(ctx context.Context, project *types.Project, p
 	}
 
 	// Process each external comment
+	errorCount := len(pr.Errors)
 	for _, externalComment := range externalComments {
 		if externalComment.ExternalID == "" {
 			e.logger.Debug().Msg("External comment has no external_id, skipping")
 			continue
 		}
 
-		_, exists := toduCommentMap[externalComment.ExternalID]
+		toduComment, exists := toduCommentMap[externalComment.ExternalID]
+		if exists && commentUpdates {
+			e.reconcileComment(ctx, project, p, toduTask, toduComment, externalComment, StrategyPull, dryRun, pr)
+		}
 		if !exists {
 			// Comment doesn't exist in Todu, create it
 			if !dryRun {
@@ -492,20 +873,32 @@ func (e *Engine) syncPullComments(ctx context.Context, project *types.Project, p
 					Content:    externalComment.Content,
 					Author:     externalComment.Author,
 				}
-				_, err := e.apiClient.CreateComment(ctx, commentCreate)
+				createdComment, err := e.createComment(ctx, commentCreate)
 				if err != nil {
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to create comment on task %s: %w", toduTask.Title, err))
 					continue
 				}
+				if commentUpdates {
+					e.saveCommentSnapshot(project.ID, externalComment.ExternalID, createdComment.Content, externalComment.Content)
+				}
 			}
 			e.logger.Debug().Str("author", externalComment.Author).Msg("Synced comment")
 		}
-		// Note: Comments are typically immutable after creation, so we don't update them
+	}
+
+	// Only skip these comments next time if they were all synced
+	if !dryRun && len(pr.Errors) == errorCount {
+		e.advanceCommentCursor(project.ID, toduTask, cursor, externalComments)
 	}
 }
 
 // syncPushComments pushes comments from Todu to external system for a specific task.
-func (e *Engine) syncPushComments(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, dryRun bool, pr *ProjectResult) {
+// If comment updates are enabled for the project, edits to synced Todu
+// comments are pushed to the external system.
+func (e *Engine) syncPushComments(ctx context.Context, project *types.Project, p plugin.Plugin, toduTask *types.Task, options Options, pr *ProjectResult) {
+	dryRun := options.DryRun
+	commentUpdates := options.commentUpdatesEnabled(project.ID)
+
 	// Skip if task has no external_id
 	if toduTask.ExternalID == "" {
 		return
@@ -518,29 +911,35 @@ func (e *Engine) syncPushComments(ctx context.Context, project *types.Project, p
 		return
 	}
 
-	// Fetch external comments to check what's already synced
-	externalComments, err := p.FetchComments(ctx, &project.ExternalID, toduTask.ExternalID)
-	if err != nil {
-		// If plugin doesn't support comments, silently skip
-		if err == plugin.ErrNotSupported {
+	// Comments with an external_id are already synced, so external comments
+	// are only needed to propagate edits
+	externalCommentMap := make(map[string]*types.Comment)
+	if commentUpdates && hasSyncedComments(toduComments) {
+		externalComments, err := p.FetchComments(ctx, &project.ExternalID, toduTask.ExternalID)
+		if err != nil {
+			// If plugin doesn't support comments, silently skip
+			if err == plugin.ErrNotSupported {
+				return
+			}
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch external comments for task %s: %w", toduTask.Title, err))
 			return
 		}
-		pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch external comments for task %s: %w", toduTask.Title, err))
-		return
-	}
 
-	// Build map of external comments by external_id for deduplication
-	externalCommentMap := make(map[string]*types.Comment)
-	for _, comment := range externalComments {
-		if comment.ExternalID != "" {
-			externalCommentMap[comment.ExternalID] = comment
+		for _, comment := range externalComments {
+			if comment.ExternalID != "" {
+				externalCommentMap[comment.ExternalID] = comment
+			}
 		}
 	}
 
 	// Process each Todu comment
 	for _, toduComment := range toduComments {
-		// Skip comments that already have external_id (already synced)
+		// Comments that already have external_id are synced; only propagate edits
 		if toduComment.ExternalID != "" {
+			if externalComment, ok := externalCommentMap[toduComment.ExternalID]; ok && commentUpdates {
+				e.reconcileComment(ctx, project, p, toduTask, toduComment, externalComment, StrategyPush, dryRun, pr)
+			}
+			e.pushReactions(ctx, project, p, toduTask, toduComment, dryRun, pr)
 			continue
 		}
 
@@ -565,10 +964,17 @@ func (e *Engine) syncPushComments(ctx context.Context, project *types.Project, p
 					Content:    &toduComment.Content,
 					ExternalID: &createdComment.ExternalID,
 				}
-				_, err = e.apiClient.UpdateComment(ctx, toduComment.ID, commentUpdate)
+				_, err = e.updateComment(ctx, toduComment.ID, commentUpdate)
 				if err != nil {
 					pr.Errors = append(pr.Errors, fmt.Errorf("failed to update comment external_id for task %s: %w", toduTask.Title, err))
 					// Continue anyway - comment was created in external system
+				} else {
+					if commentUpdates {
+						e.saveCommentSnapshot(project.ID, createdComment.ExternalID, toduComment.Content, createdComment.Content)
+					}
+					pushed := *toduComment
+					pushed.ExternalID = createdComment.ExternalID
+					e.pushReactions(ctx, project, p, toduTask, &pushed, dryRun, pr)
 				}
 			}
 		}
@@ -576,21 +982,67 @@ func (e *Engine) syncPushComments(ctx context.Context, project *types.Project, p
 	}
 }
 
+// hasSyncedComments reports whether any comment has been synced to the
+// external system.
+func hasSyncedComments(comments []*types.Comment) bool {
+	for _, comment := range comments {
+		if comment.ExternalID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// logDescriptionChange logs a unified diff when a sync overwrites a task description.
+// The target names the side being overwritten ("todu" or "external") so that
+// overwrites can be audited from the sync log. A nil new description isn't
+// written by the update, so nothing is logged for it.
+func (e *Engine) logDescriptionChange(title, target string, oldDesc, newDesc *string) {
+	if newDesc == nil {
+		return
+	}
+	diff := UnifiedDiff(derefString(oldDesc), derefString(newDesc), target+" (before)", target+" (after)")
+	if diff == "" {
+		return
+	}
+	e.logger.Info().
+		Str("task", title).
+		Str("target", target).
+		Str("diff", diff).
+		Msg("Description changed by sync")
+}
+
 // extractLabelNames extracts just the label names as strings from a slice of Label structs.
 // This is needed because the API expects label names as strings, not Label objects.
+// Parent and depends-on labels are left out: they hold Todu task IDs, so they
+// are never pushed, and ones found on external tasks are ignored.
 func extractLabelNames(labels []types.Label) []string {
 	if labels == nil {
 		return nil
 	}
 	result := make([]string, 0, len(labels))
 	for _, label := range labels {
-		if label.Name != "" {
+		if label.Name != "" && !types.IsTaskRefLabel(label.Name) {
 			result = append(result, label.Name)
 		}
 	}
 	return result
 }
 
+// keepTaskRefLabels adds a Todu task's parent and depends-on labels to an
+// update pulled from an external task, which would otherwise remove them.
+// An update without labels leaves the task's labels alone.
+func keepTaskRefLabels(update *types.TaskUpdate, labels []types.Label) {
+	if len(update.Labels) == 0 {
+		return
+	}
+	for _, label := range labels {
+		if types.IsTaskRefLabel(label.Name) {
+			update.Labels = append(update.Labels, label.Name)
+		}
+	}
+}
+
 // extractAssigneeNames extracts just the assignee names as strings from a slice of Assignee structs.
 // This is needed because the API expects assignee names as strings, not Assignee objects.
 func extractAssigneeNames(assignees []types.Assignee) []string {
@@ -201,7 +201,7 @@ func handleMockAPI(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		}
 		_ = json.NewEncoder(w).Encode(comment)
 
-	case r.Method == "PUT" && r.URL.Path == "/api/v1/comments/1":
+	case (r.Method == "PUT" || r.Method == "PATCH") && r.URL.Path == "/api/v1/comments/1":
 		// Update comment
 		var commentUpdate types.CommentUpdate
 		if err := json.NewDecoder(r.Body).Decode(&commentUpdate); err != nil {
@@ -212,12 +212,18 @@ func handleMockAPI(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		comment := &types.Comment{
 			ID:         1,
 			TaskID:     &taskID,
-			ExternalID: *commentUpdate.ExternalID,
+			ExternalID: "",
 			Content:    "Test comment",
 			Author:     "test-user",
 			CreatedAt:  time.Now().Add(-1 * time.Hour),
 			UpdatedAt:  time.Now(),
 		}
+		if commentUpdate.ExternalID != nil {
+			comment.ExternalID = *commentUpdate.ExternalID
+		}
+		if commentUpdate.Content != nil {
+			comment.Content = *commentUpdate.Content
+		}
 		_ = json.NewEncoder(w).Encode(comment)
 
 	default:
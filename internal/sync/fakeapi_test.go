@@ -0,0 +1,56 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestSyncAgainstFakeAPI(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Repo", SystemID: system.ID, ExternalID: "test-repo"})
+	local := server.AddTask(types.Task{Title: "Written locally", ProjectID: project.ID})
+
+	mock := plugin.NewMockPlugin("test-system")
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Repo"})
+	updated := testsupport.DefaultNow.Add(-time.Hour)
+	mock.AddTask("7", &types.Task{ExternalID: "7", Title: "Filed upstream", ProjectID: project.ID, Status: "active", CreatedAt: updated, UpdatedAt: updated})
+
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+	engine := NewEngine(server.Client(), reg)
+
+	result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TotalErrors != 0 {
+		t.Fatalf("Expected no errors, got %+v", result.ProjectResults)
+	}
+
+	tasks := server.Tasks()
+	if len(tasks) != 2 || tasks[1].Title != "Filed upstream" || tasks[1].ExternalID != "7" {
+		t.Fatalf("Expected the upstream task pulled into the API, got %+v", tasks)
+	}
+	pushed := server.Task(local.ID)
+	if pushed.ExternalID == "" {
+		t.Errorf("Expected the local task pushed and given an external ID, got %+v", pushed)
+	}
+
+	// A second sync has nothing to do
+	result, err = engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if result.TotalCreated != 0 || result.TotalErrors != 0 || len(server.Tasks()) != 2 {
+		t.Errorf("Expected the second sync to change nothing, got %+v", result)
+	}
+}
@@ -0,0 +1,70 @@
+package sync
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// TaskFilter restricts which tasks participate in a sync.
+// A zero-value TaskFilter matches every task.
+type TaskFilter struct {
+	// Labels requires tasks to have all of these labels (case-insensitive).
+	Labels []string
+
+	// Status requires tasks to have this status.
+	Status string
+
+	// UpdatedAfter requires tasks to have been updated after this time.
+	UpdatedAfter *time.Time
+
+	// Priority requires tasks to have this priority.
+	Priority string
+
+	// Assignee requires tasks to be assigned to this name (case-insensitive).
+	Assignee string
+}
+
+// IsEmpty returns true if the filter matches every task.
+func (f TaskFilter) IsEmpty() bool {
+	return len(f.Labels) == 0 && f.Status == "" && f.UpdatedAfter == nil &&
+		f.Priority == "" && f.Assignee == ""
+}
+
+// Matches returns true if the task satisfies every condition of the filter.
+func (f TaskFilter) Matches(task *types.Task) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+
+	if f.UpdatedAfter != nil && !task.UpdatedAt.After(*f.UpdatedAfter) {
+		return false
+	}
+
+	if f.Priority != "" && (task.Priority == nil || *task.Priority != f.Priority) {
+		return false
+	}
+
+	if f.Assignee != "" && !slices.ContainsFunc(task.Assignees, func(a types.Assignee) bool {
+		return strings.EqualFold(a.Name, f.Assignee)
+	}) {
+		return false
+	}
+
+	for _, want := range f.Labels {
+		found := false
+		for _, label := range task.Labels {
+			if strings.EqualFold(label.Name, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestTaskFilterMatches(t *testing.T) {
+	now := time.Now()
+	high := "high"
+	task := &types.Task{
+		Status:    "active",
+		Labels:    []types.Label{{Name: "Release-Blocker"}, {Name: "bug"}},
+		Priority:  &high,
+		Assignees: []types.Assignee{{Name: "evcraddock"}},
+		UpdatedAt: now,
+	}
+
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   bool
+	}{
+		{"empty filter", TaskFilter{}, true},
+		{"matching status", TaskFilter{Status: "active"}, true},
+		{"other status", TaskFilter{Status: "done"}, false},
+		{"matching label ignores case", TaskFilter{Labels: []string{"release-blocker"}}, true},
+		{"all labels required", TaskFilter{Labels: []string{"bug", "urgent"}}, false},
+		{"updated after", TaskFilter{UpdatedAfter: &before}, true},
+		{"not updated after", TaskFilter{UpdatedAfter: &after}, false},
+		{"matching priority", TaskFilter{Priority: "high"}, true},
+		{"other priority", TaskFilter{Priority: "low"}, false},
+		{"matching assignee ignores case", TaskFilter{Assignee: "EVCraddock"}, true},
+		{"other assignee", TaskFilter{Assignee: "someone"}, false},
+		{"combined", TaskFilter{Status: "active", Labels: []string{"bug"}, UpdatedAfter: &before}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(task); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+			if tt.filter.IsEmpty() != (tt.name == "empty filter") {
+				t.Errorf("IsEmpty() = %v for %s", tt.filter.IsEmpty(), tt.name)
+			}
+		})
+	}
+}
+
+func TestSyncPullWithFilter(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	mockPlugin.AddProject("test-repo", &types.Project{
+		ID:         1,
+		ExternalID: "test-repo",
+		Name:       "Test Project",
+	})
+
+	now := time.Now()
+	mockPlugin.AddTask("task-1", &types.Task{
+		ExternalID: "task-1",
+		Title:      "Blocker",
+		ProjectID:  1,
+		Status:     "active",
+		Labels:     []types.Label{{Name: "release-blocker"}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	mockPlugin.AddTask("task-2", &types.Task{
+		ExternalID: "task-2",
+		Title:      "Not a blocker",
+		ProjectID:  1,
+		Status:     "active",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+
+	result, err := engine.Sync(context.Background(), Options{
+		ProjectIDs: []int{1},
+		Filter:     TaskFilter{Labels: []string{"release-blocker"}},
+	})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if result.TotalCreated != 1 {
+		t.Errorf("Expected 1 task created, got %d", result.TotalCreated)
+	}
+	if result.TotalErrors != 0 {
+		t.Errorf("Expected 0 errors, got %d", result.TotalErrors)
+		for _, e := range result.ProjectResults[0].Errors {
+			t.Logf("  Error: %v", e)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// pullLabelColors gives Todu labels without a color the color of their
+// label in the external system. Servers without label metadata are skipped.
+func (e *Engine) pullLabelColors(ctx context.Context, externalTasks []*types.Task) {
+	remote := make(map[string]string)
+	for _, task := range externalTasks {
+		for _, label := range task.Labels {
+			if label.Color != "" {
+				remote[strings.ToLower(label.Name)] = label.Color
+			}
+		}
+	}
+	if len(remote) == 0 {
+		return
+	}
+
+	labels, err := e.apiClient.ListLabels(ctx)
+	if err != nil {
+		e.logger.Debug().Err(err).Msg("Skipping label colors")
+		return
+	}
+
+	for id, color := range labelColorUpdates(labels, remote) {
+		if _, err := e.apiClient.UpdateLabel(ctx, id, &types.LabelUpdate{Color: &color}); err != nil {
+			e.logger.Warn().Err(err).Int("label", id).Msg("Failed to update label color")
+		}
+	}
+}
+
+// labelColorUpdates returns the color to set on each Todu label, by ID,
+// that has no color but whose external counterpart (keyed by lowercase
+// name) does.
+func labelColorUpdates(labels []*types.Label, remote map[string]string) map[int]string {
+	updates := make(map[int]string)
+	for _, label := range labels {
+		if color, ok := remote[strings.ToLower(label.Name)]; ok && label.Color == "" {
+			updates[label.ID] = color
+		}
+	}
+	return updates
+}
+
+// pushLabelColors creates the labels of tasks that have a Todu color in the
+// external system with that color, so the plugin doesn't create them with a
+// generated one while pushing the tasks.
+func (e *Engine) pushLabelColors(ctx context.Context, project *types.Project, p plugin.Plugin, tasks []*types.Task) {
+	colors := labelColors(tasks)
+	if len(colors) == 0 {
+		return
+	}
+
+	pusher, ok := p.(plugin.LabelPusher)
+	if !ok {
+		return
+	}
+
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result, err := pusher.PushLabels(ctx, &project.ExternalID, names, plugin.LabelPushOptions{Colors: colors})
+	if err != nil {
+		if !errors.Is(err, plugin.ErrNotSupported) {
+			e.logger.Warn().Err(err).Str("project", project.Name).Msg("Failed to push label colors")
+		}
+		return
+	}
+	for name, failure := range result.Failed {
+		e.logger.Warn().Str("label", name).Str("error", failure).Msg("Failed to create label")
+	}
+}
+
+// labelColors returns the colors of the tasks' labels that have one, by name.
+func labelColors(tasks []*types.Task) map[string]string {
+	colors := make(map[string]string)
+	for _, task := range tasks {
+		for _, label := range task.Labels {
+			if label.Color != "" {
+				colors[label.Name] = label.Color
+			}
+		}
+	}
+	return colors
+}
@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// labelPusherPlugin records the labels pushed through it.
+type labelPusherPlugin struct {
+	*plugin.MockPlugin
+	labels []string
+	opts   plugin.LabelPushOptions
+}
+
+func (p *labelPusherPlugin) PushLabels(ctx context.Context, projectExternalID *string, labels []string, opts plugin.LabelPushOptions) (*plugin.LabelPushResult, error) {
+	p.labels = labels
+	p.opts = opts
+	return &plugin.LabelPushResult{Created: labels}, nil
+}
+
+func TestPushLabelColors(t *testing.T) {
+	engine := NewEngine(nil, nil)
+	project := &types.Project{ExternalID: "owner/repo"}
+	p := &labelPusherPlugin{MockPlugin: plugin.NewMockPlugin("test")}
+
+	engine.pushLabelColors(context.Background(), project, p, []*types.Task{{Labels: []types.Label{{Name: "docs"}}}})
+	if p.labels != nil {
+		t.Errorf("Expected nothing pushed without colored labels, got %v", p.labels)
+	}
+
+	tasks := []*types.Task{
+		{Labels: []types.Label{{Name: "docs"}, {Name: "bug", Color: "d73a4a"}}},
+		{Labels: []types.Label{{Name: "ux", Color: "c5def5"}}},
+	}
+	engine.pushLabelColors(context.Background(), project, p, tasks)
+	if !slices.Equal(p.labels, []string{"bug", "ux"}) || p.opts.Colors["bug"] != "d73a4a" || p.opts.DryRun {
+		t.Errorf("Expected bug and ux pushed with their colors, got %v %+v", p.labels, p.opts)
+	}
+}
+
+func TestPullLabelColors(t *testing.T) {
+	updates := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode([]*types.Label{
+				{ID: 1, Name: "bug"},
+				{ID: 2, Name: "docs", Color: "0075ca"},
+				{ID: 3, Name: "ux"},
+			})
+		case http.MethodPatch:
+			var update types.LabelUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			updates[r.URL.Path] = *update.Color
+			_ = json.NewEncoder(w).Encode(&types.Label{})
+		}
+	}))
+	defer server.Close()
+
+	engine := NewEngine(api.NewClient(server.URL, ""), nil)
+	engine.pullLabelColors(context.Background(), []*types.Task{
+		{Labels: []types.Label{{Name: "Bug", Color: "d73a4a"}, {Name: "docs", Color: "ffffff"}}},
+	})
+
+	if len(updates) != 1 || updates["/api/v1/labels/1"] != "d73a4a" {
+		t.Errorf("Expected only bug to take its remote color, got %v", updates)
+	}
+}
+
+func TestSyncSkipsTaskRefLabels(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Repo", SystemID: system.ID, ExternalID: "test-repo"})
+	parent := server.AddTask(types.Task{Title: "Launch", ProjectID: project.ID})
+	child := server.AddTask(types.Task{Title: "Write docs", ProjectID: project.ID, ExternalID: "7",
+		Labels: []types.Label{{Name: "docs"}, {Name: "parent:1"}, {Name: "depends-on:1"}}})
+
+	mock := plugin.NewMockPlugin("test-system")
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Repo"})
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+	engine := NewEngine(server.Client(), reg)
+
+	sync := func(strategy Strategy) {
+		t.Helper()
+		result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}, StrategyOverride: &strategy})
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if result.TotalErrors != 0 {
+			t.Fatalf("Expected no errors, got %v", result.ProjectResults[0].Errors)
+		}
+	}
+	labelNames := func(task *types.Task) []string {
+		var names []string
+		for _, label := range task.Labels {
+			names = append(names, label.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	// Parent and depends-on labels aren't pushed
+	mock.AddTask("7", &types.Task{ExternalID: "7", Title: "Write docs", ProjectID: project.ID, Status: "active", UpdatedAt: testsupport.DefaultNow.Add(-time.Hour)})
+	sync(StrategyPush)
+	external, _ := mock.FetchTask(context.Background(), nil, "7")
+	if got := labelNames(external); !slices.Equal(got, []string{"docs"}) {
+		t.Errorf("Expected only the docs label pushed, got %v", got)
+	}
+	created, _ := mock.FetchTasks(context.Background(), nil, nil)
+	for _, task := range created {
+		if task.Title == "Launch" && len(task.Labels) != 0 {
+			t.Errorf("Expected the parent created without labels, got %v", labelNames(task))
+		}
+	}
+
+	// Pulled labels keep the task's own and ignore the external ones
+	mock.AddTask("7", &types.Task{ExternalID: "7", Title: "Write docs", ProjectID: project.ID, Status: "active",
+		Labels:    []types.Label{{Name: "docs"}, {Name: "urgent"}, {Name: "parent:99"}},
+		UpdatedAt: time.Now().Add(time.Hour)})
+	sync(StrategyPull)
+	if got := labelNames(server.Task(child.ID)); !slices.Equal(got, []string{"depends-on:1", "docs", "parent:1", "urgent"}) {
+		t.Errorf("Expected the pulled labels with the task's own parent and blocker, got %v", got)
+	}
+	if got := labelNames(server.Task(parent.ID)); len(got) != 0 {
+		t.Errorf("Expected the parent's labels untouched, got %v", got)
+	}
+}
@@ -0,0 +1,324 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// DuplicateMatch selects how the pull looks for an existing Todu task in
+// another project before creating a task for a new external item.
+type DuplicateMatch string
+
+const (
+	// DuplicateMatchURL treats an external task as a duplicate when its
+	// description contains the source URL of a task in another project, or
+	// the other task's description contains its source URL, as mirroring
+	// tools do.
+	DuplicateMatchURL DuplicateMatch = "url"
+
+	// DuplicateMatchTitle also treats an external task as a duplicate when
+	// exactly one synced task in another project has the same title.
+	DuplicateMatchTitle DuplicateMatch = "title"
+
+	// DuplicateMatchOff disables detection. Manual links are still honored.
+	DuplicateMatchOff DuplicateMatch = "off"
+)
+
+// IsValid returns true if the duplicate match mode is valid.
+func (m DuplicateMatch) IsValid() bool {
+	switch m {
+	case DuplicateMatchURL, DuplicateMatchTitle, DuplicateMatchOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// LinkStore persists links from external tasks to Todu tasks in other
+// projects. A SnapshotStore may also implement LinkStore so one Todu task can
+// carry external tasks in several systems, such as an issue mirrored between
+// GitHub and Forgejo.
+//
+// A task's own external ID belongs to its project. Each link adds an external
+// task in another project that is synced with the same Todu task: pulls merge
+// the external task's changes into it and pushes write its changes out.
+// Links are compared against their own snapshots, so a task's UpdatedAt and
+// last_pushed_at only track its own external ID. Comments are only synced
+// with the task's own external ID.
+type LinkStore interface {
+	// LinkedTask returns the Todu task ID an external task is linked to, or
+	// 0 if it isn't linked.
+	LinkedTask(projectID int, externalID string) (int, error)
+
+	// ProjectLinks returns the links of a project, keyed by external ID.
+	ProjectLinks(projectID int) (map[string]int, error)
+
+	// Link links an external task in a project to a Todu task.
+	Link(projectID int, externalID string, taskID int) error
+
+	// Unlink removes the link of an external task in a project.
+	Unlink(projectID int, externalID string) error
+}
+
+// TaskLink identifies an external task linked to a Todu task.
+type TaskLink struct {
+	ProjectID  int    `json:"project_id"`
+	ExternalID string `json:"external_id"`
+}
+
+// duplicateFinder looks up Todu tasks in other projects that an external task
+// duplicates. Candidates are listed on first use, once per project sync.
+type duplicateFinder struct {
+	apiClient *api.Client
+	projectID int
+	mode      DuplicateMatch
+	loaded    bool
+	tasks     []*types.Task
+}
+
+// find returns the Todu task the external task duplicates, or nil if there is
+// none or the match is ambiguous.
+func (f *duplicateFinder) find(ctx context.Context, task *types.Task) (*types.Task, error) {
+	if f.mode != DuplicateMatchURL && f.mode != DuplicateMatchTitle {
+		return nil, nil
+	}
+
+	if !f.loaded {
+		tasks, err := f.apiClient.ListTasks(ctx, &api.TaskListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range tasks {
+			if candidate.ProjectID != f.projectID && candidate.ExternalID != "" {
+				f.tasks = append(f.tasks, candidate)
+			}
+		}
+		f.loaded = true
+	}
+
+	if match := uniqueMatch(f.tasks, func(candidate *types.Task) bool {
+		return mentionsURL(task.Description, candidate.SourceURL) || mentionsURL(candidate.Description, task.SourceURL)
+	}); match != nil || f.mode != DuplicateMatchTitle {
+		return match, nil
+	}
+
+	title := normalizeTitle(task.Title)
+	if title == "" {
+		return nil, nil
+	}
+	return uniqueMatch(f.tasks, func(candidate *types.Task) bool {
+		return normalizeTitle(candidate.Title) == title
+	}), nil
+}
+
+// uniqueMatch returns the only task matching fn, or nil if none or several do.
+func uniqueMatch(tasks []*types.Task, fn func(*types.Task) bool) *types.Task {
+	var found *types.Task
+	for _, task := range tasks {
+		if !fn(task) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = task
+	}
+	return found
+}
+
+// mentionsURL reports whether a description contains a source URL.
+func mentionsURL(description, sourceURL *string) bool {
+	if description == nil || sourceURL == nil || *sourceURL == "" {
+		return false
+	}
+	return strings.Contains(*description, *sourceURL)
+}
+
+// normalizeTitle lowercases a title and collapses its whitespace.
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// linkedTask returns the Todu task ID an external task is linked to, or 0.
+func (e *Engine) linkedTask(projectID int, externalID string) int {
+	store, ok := e.snapshots.(LinkStore)
+	if !ok {
+		return 0
+	}
+	taskID, err := store.LinkedTask(projectID, externalID)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("external_id", externalID).Msg("Failed to load task link")
+		return 0
+	}
+	return taskID
+}
+
+// link records that an external task duplicates a Todu task.
+// Failures are logged but do not fail the sync.
+func (e *Engine) link(projectID int, externalID string, taskID int) {
+	store, ok := e.snapshots.(LinkStore)
+	if !ok {
+		return
+	}
+	if err := store.Link(projectID, externalID, taskID); err != nil {
+		e.logger.Warn().Err(err).Str("external_id", externalID).Msg("Failed to record task link")
+	}
+}
+
+// projectLinks returns the links of a project, or nil if there are none.
+func (e *Engine) projectLinks(projectID int) map[string]int {
+	store, ok := e.snapshots.(LinkStore)
+	if !ok {
+		return nil
+	}
+	links, err := store.ProjectLinks(projectID)
+	if err != nil {
+		e.logger.Warn().Err(err).Int("project", projectID).Msg("Failed to load task links")
+		return nil
+	}
+	return links
+}
+
+// linkDuplicate links a new external task to the Todu task it duplicates, if
+// any, and records the external task as the link's base so later syncs only
+// carry over changes. Returns true if the task was linked.
+func (e *Engine) linkDuplicate(ctx context.Context, project *types.Project, task *types.Task, duplicates *duplicateFinder, dryRun bool) (bool, error) {
+	duplicate, err := duplicates.find(ctx, task)
+	if err != nil || duplicate == nil {
+		return false, err
+	}
+
+	e.logger.Debug().Str("task", task.Title).Int("linked_to", duplicate.ID).Msg("Linked duplicate task")
+	if !dryRun {
+		e.link(project.ID, task.ExternalID, duplicate.ID)
+		e.saveSnapshot(project.ID, task)
+	}
+	return true, nil
+}
+
+// pullLinkedTask pulls an external task into the Todu task it is linked to.
+//
+// Changes are detected against the link's snapshot rather than timestamps.
+// Without a snapshot the external task is recorded as the base and nothing
+// is pulled. If merged is non-nil, the task is merged in both directions and
+// its external ID is added to merged.
+func (e *Engine) pullLinkedTask(ctx context.Context, project *types.Project, p plugin.Plugin, taskID int, externalTask *types.Task, dryRun bool, pr *ProjectResult, merged map[string]bool) {
+	if merged != nil {
+		merged[externalTask.ExternalID] = true
+	}
+
+	if e.isEcho(project.ID, externalTask) {
+		e.logger.Debug().Str("task", externalTask.Title).Msg("Skipping echo of pushed change")
+		pr.Skipped++
+		return
+	}
+
+	var base *types.Task
+	if e.snapshots != nil {
+		var err error
+		base, err = e.snapshots.Get(project.ID, externalTask.ExternalID)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("task", externalTask.Title).Msg("Failed to load sync snapshot")
+		}
+	}
+	if base == nil {
+		if !dryRun {
+			e.saveSnapshot(project.ID, externalTask)
+		}
+		pr.Skipped++
+		return
+	}
+
+	if merged != nil {
+		e.mergeTask(ctx, project, p, base, &types.Task{ID: taskID, Title: externalTask.Title}, externalTask, dryRun, pr)
+		return
+	}
+
+	if len(DiffTasks(base, externalTask)) == 0 {
+		pr.Skipped++
+		return
+	}
+
+	if !dryRun {
+		current, err := e.apiClient.GetTask(ctx, taskID)
+		if err != nil {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch linked task #%d: %w", taskID, err))
+			return
+		}
+		taskUpdate := taskUpdateFromTask(externalTask)
+		keepTaskRefLabels(taskUpdate, current.Labels)
+		if _, err := e.updateTask(ctx, taskID, taskUpdate); err != nil {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to update linked task #%d from %q: %w", taskID, externalTask.ExternalID, err))
+			return
+		}
+		e.saveSnapshot(project.ID, externalTask)
+	}
+	e.logger.Debug().Str("task", externalTask.Title).Int("task_id", taskID).Msg("Updated linked task")
+	pr.Updated++
+}
+
+// pushLinkedTasks pushes the Todu tasks linked to a project's external tasks.
+// A linked task is pushed when it differs from the link's snapshot.
+// Links whose external IDs are in merged were already reconciled during pull.
+func (e *Engine) pushLinkedTasks(ctx context.Context, project *types.Project, p plugin.Plugin, options Options, pr *ProjectResult, merged map[string]bool) {
+	links := e.projectLinks(project.ID)
+	externalIDs := make([]string, 0, len(links))
+	for externalID := range links {
+		if !merged[externalID] {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+	sort.Strings(externalIDs)
+
+	for _, externalID := range externalIDs {
+		if interrupted(ctx, pr) {
+			return
+		}
+		taskID := links[externalID]
+		task, err := e.apiClient.GetTask(ctx, taskID)
+		if err != nil {
+			pr.Errors = append(pr.Errors, fmt.Errorf("failed to fetch task #%d linked to %q: %w", taskID, externalID, err))
+			continue
+		}
+
+		if !options.Filter.Matches(task) {
+			continue
+		}
+
+		synced := snapshotOf(task)
+		synced.ExternalID = externalID
+
+		if !options.Force && e.snapshots != nil {
+			base, err := e.snapshots.Get(project.ID, externalID)
+			if err != nil {
+				e.logger.Warn().Err(err).Str("task", task.Title).Msg("Failed to load sync snapshot")
+			}
+			if base != nil && len(DiffTasks(base, synced)) == 0 {
+				pr.Skipped++
+				continue
+			}
+		}
+
+		if !options.DryRun {
+			pushedTask, err := p.UpdateTask(ctx, &project.ExternalID, externalID, taskUpdateFromTask(task))
+			if err != nil {
+				if err == plugin.ErrNotSupported {
+					pr.Skipped++
+					continue
+				}
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to push task %q to linked %q: %w", task.Title, externalID, err))
+				continue
+			}
+			e.saveSnapshot(project.ID, synced)
+			e.recordPush(project.ID, pushedTask)
+		}
+		e.logger.Debug().Str("task", task.Title).Str("external_id", externalID).Msg("Pushed linked task")
+		pr.Updated++
+	}
+}
@@ -0,0 +1,229 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestFileSnapshotStoreLink(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	if err := store.Link(2, "5", 42); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+
+	taskID, err := NewFileSnapshotStore(dir).LinkedTask(2, "5")
+	if err != nil {
+		t.Fatalf("LinkedTask failed: %v", err)
+	}
+	if taskID != 42 {
+		t.Errorf("Expected persisted link to task 42, got %d", taskID)
+	}
+
+	if taskID, _ := store.LinkedTask(1, "5"); taskID != 0 {
+		t.Errorf("Expected links to be per project, got %d", taskID)
+	}
+
+	if err := store.Link(2, "", 42); err == nil {
+		t.Error("Expected error for task without external_id")
+	}
+
+	if err := store.Link(3, "9", 42); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	links, err := NewFileSnapshotStore(dir).TaskLinks(42)
+	if err != nil {
+		t.Fatalf("TaskLinks failed: %v", err)
+	}
+	if len(links) != 2 || links[0] != (TaskLink{ProjectID: 2, ExternalID: "5"}) || links[1] != (TaskLink{ProjectID: 3, ExternalID: "9"}) {
+		t.Errorf("Unexpected task links: %+v", links)
+	}
+
+	if err := store.Unlink(2, "5"); err != nil {
+		t.Fatalf("Unlink failed: %v", err)
+	}
+	if taskID, _ := NewFileSnapshotStore(dir).LinkedTask(2, "5"); taskID != 0 {
+		t.Errorf("Expected link removed, got %d", taskID)
+	}
+}
+
+func TestDuplicateFinder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("project_id") {
+			t.Errorf("Expected tasks from all projects, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"items": [
+			{"id": 10, "project_id": 1, "external_id": "5", "title": "Fix login", "source_url": "https://github.com/acme/app/issues/5"},
+			{"id": 11, "project_id": 1, "external_id": "6", "title": "Update docs"},
+			{"id": 12, "project_id": 3, "external_id": "9", "title": "Update  Docs"},
+			{"id": 13, "project_id": 1, "external_id": "7", "title": "Add dark mode"},
+			{"id": 14, "project_id": 2, "external_id": "8", "title": "Same project"},
+			{"id": 15, "project_id": 1, "title": "Local only"}
+		], "total": 6}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		mode   DuplicateMatch
+		task   *types.Task
+		wantID int
+	}{
+		{
+			name:   "description mentions url",
+			mode:   DuplicateMatchURL,
+			task:   &types.Task{Title: "Mirror", Description: stringPtr("Mirrored from https://github.com/acme/app/issues/5")},
+			wantID: 10,
+		},
+		{
+			name:   "title ignored in url mode",
+			mode:   DuplicateMatchURL,
+			task:   &types.Task{Title: "add dark mode"},
+			wantID: 0,
+		},
+		{
+			name:   "title match",
+			mode:   DuplicateMatchTitle,
+			task:   &types.Task{Title: "add  dark mode"},
+			wantID: 13,
+		},
+		{
+			name:   "ambiguous title",
+			mode:   DuplicateMatchTitle,
+			task:   &types.Task{Title: "Update docs"},
+			wantID: 0,
+		},
+		{
+			name:   "same project not a candidate",
+			mode:   DuplicateMatchTitle,
+			task:   &types.Task{Title: "Same project"},
+			wantID: 0,
+		},
+		{
+			name:   "unsynced task not a candidate",
+			mode:   DuplicateMatchTitle,
+			task:   &types.Task{Title: "Local only"},
+			wantID: 0,
+		},
+		{
+			name:   "off",
+			mode:   DuplicateMatchOff,
+			task:   &types.Task{Title: "Add dark mode"},
+			wantID: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finder := &duplicateFinder{apiClient: api.NewClient(server.URL, ""), projectID: 2, mode: tt.mode}
+			found, err := finder.find(context.Background(), tt.task)
+			if err != nil {
+				t.Fatalf("find failed: %v", err)
+			}
+			gotID := 0
+			if found != nil {
+				gotID = found.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("Expected task %d, got %d", tt.wantID, gotID)
+			}
+		})
+	}
+}
+
+func TestSyncLinkedTask(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	mockPlugin := plugin.NewMockPlugin("test-system")
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mockPlugin })
+
+	// Task 42 belongs to another project; project 2 mirrors it as issue 5
+	toduTask := &types.Task{ID: 42, ProjectID: 1, ExternalID: "17", Title: "Fix login", Status: "active"}
+	var updates []map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 2, Name: "Mirror", SystemID: 1, ExternalID: "mirror"})
+	})
+	mux.HandleFunc("PUT /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 2}`))
+	})
+	mux.HandleFunc("GET /api/v1/systems/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.System{ID: 1, Identifier: "test-system"})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [], "total": 0}`))
+	})
+	mux.HandleFunc("GET /api/v1/tasks/42", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(toduTask)
+	})
+	mux.HandleFunc("PUT /api/v1/tasks/42", func(w http.ResponseWriter, r *http.Request) {
+		var update map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		updates = append(updates, update)
+		if title, ok := update["title"].(string); ok {
+			toduTask.Title = title
+		}
+		_ = json.NewEncoder(w).Encode(toduTask)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine := NewEngine(api.NewClient(server.URL, ""), reg).WithSnapshots(store)
+	if err := store.Link(2, "5", 42); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+
+	mockPlugin.AddProject("mirror", &types.Project{ID: 2, ExternalID: "mirror"})
+	mockPlugin.AddTask("5", &types.Task{ExternalID: "5", ProjectID: 2, Title: "Fix login", Status: "active"})
+
+	pull, push := StrategyPull, StrategyPush
+	run := func(strategy *Strategy) *Result {
+		t.Helper()
+		result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{2}, StrategyOverride: strategy})
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if result.TotalErrors != 0 {
+			t.Fatalf("Expected no errors, got %v", result.ProjectResults[0].Errors)
+		}
+		return result
+	}
+
+	// The first pull only records the link's base
+	if result := run(&pull); result.TotalCreated != 0 || result.TotalUpdated != 0 || len(updates) != 0 {
+		t.Fatalf("Expected the first pull to change nothing, got %+v and updates %v", result, updates)
+	}
+
+	// An external edit is pulled into the linked task
+	mockPlugin.AddTask("5", &types.Task{ExternalID: "5", ProjectID: 2, Title: "Fix login on mobile", Status: "active"})
+	if result := run(&pull); result.TotalUpdated != 1 || toduTask.Title != "Fix login on mobile" {
+		t.Fatalf("Expected the linked task updated, got %+v and title %q", result, toduTask.Title)
+	}
+	if _, ok := updates[0]["last_pushed_at"]; ok {
+		t.Error("Expected last_pushed_at left to the task's own external ID")
+	}
+
+	// A todu edit is pushed to the linked external task, once
+	toduTask.Title = "Fix login everywhere"
+	if result := run(&push); result.TotalUpdated != 1 {
+		t.Fatalf("Expected the linked task pushed, got %+v", result)
+	}
+	if pushed, _ := mockPlugin.FetchTask(context.Background(), nil, "5"); pushed.Title != "Fix login everywhere" {
+		t.Errorf("Expected external task updated, got %q", pushed.Title)
+	}
+	if result := run(&push); result.TotalUpdated != 0 || result.TotalSkipped != 1 {
+		t.Errorf("Expected unchanged linked task skipped, got %+v", result)
+	}
+}
@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// SnapshotStore persists the state of each task as of its last successful sync.
+//
+// Snapshots provide the common base for three-way merges during bidirectional
+// sync. Without a base, the engine can only compare UpdatedAt timestamps and
+// must overwrite the whole task on one side.
+type SnapshotStore interface {
+	// Get returns the last-synced snapshot for a task, or nil if none exists.
+	Get(projectID int, externalID string) (*types.Task, error)
+
+	// Put stores the snapshot for a task, replacing any previous snapshot.
+	Put(projectID int, task *types.Task) error
+}
+
+// MergeResult holds the outcome of a three-way task merge.
+type MergeResult struct {
+	// Task is the merged task. Only synced fields are populated.
+	Task *types.Task
+
+	// Conflicts lists fields that were changed differently on both sides.
+	// Conflicting fields are resolved with last-write-wins.
+	Conflicts []string
+}
+
+// MergeTask performs a field-level three-way merge of a task.
+//
+// For each synced field:
+//   - If only one side changed the field since base, that change is kept
+//   - If both sides made the same change, it is kept
+//   - If both sides changed the field differently, the side with the most
+//     recent UpdatedAt wins and the field is reported as a conflict
+//
+// This lets a title edited in Todu and labels edited externally both survive
+// the same sync cycle.
+func MergeTask(base, local, remote *types.Task) MergeResult {
+	localWins := !remote.UpdatedAt.After(local.UpdatedAt)
+	merged := &types.Task{
+		ID:         local.ID,
+		ExternalID: remote.ExternalID,
+		SourceURL:  remote.SourceURL,
+		ProjectID:  local.ProjectID,
+		// Scheduled dates are todu's own, pushed but never pulled
+		ScheduledDate: local.ScheduledDate,
+	}
+	var conflicts []string
+
+	// pick decides which side's value to keep for a single field
+	pick := func(field string, baseVal, localVal, remoteVal string) bool {
+		switch {
+		case localVal == remoteVal:
+			return true
+		case localVal == baseVal:
+			return false
+		case remoteVal == baseVal:
+			return true
+		default:
+			conflicts = append(conflicts, field)
+			return localWins
+		}
+	}
+
+	if pick("title", base.Title, local.Title, remote.Title) {
+		merged.Title = local.Title
+	} else {
+		merged.Title = remote.Title
+	}
+
+	if pick("status", base.Status, local.Status, remote.Status) {
+		merged.Status = local.Status
+	} else {
+		merged.Status = remote.Status
+	}
+
+	if pick("priority", derefString(base.Priority), derefString(local.Priority), derefString(remote.Priority)) {
+		merged.Priority = local.Priority
+	} else {
+		merged.Priority = remote.Priority
+	}
+
+	if pick("due_date", formatDate(base.DueDate), formatDate(local.DueDate), formatDate(remote.DueDate)) {
+		merged.DueDate = local.DueDate
+	} else {
+		merged.DueDate = remote.DueDate
+	}
+
+	if pick("description", derefString(base.Description), derefString(local.Description), derefString(remote.Description)) {
+		merged.Description = local.Description
+	} else {
+		merged.Description = remote.Description
+	}
+
+	if pick("labels",
+		sortedNames(extractLabelNames(base.Labels)),
+		sortedNames(extractLabelNames(local.Labels)),
+		sortedNames(extractLabelNames(remote.Labels))) {
+		merged.Labels = local.Labels
+	} else {
+		merged.Labels = remote.Labels
+	}
+
+	if pick("assignees",
+		sortedNames(extractAssigneeNames(base.Assignees)),
+		sortedNames(extractAssigneeNames(local.Assignees)),
+		sortedNames(extractAssigneeNames(remote.Assignees))) {
+		merged.Assignees = local.Assignees
+	} else {
+		merged.Assignees = remote.Assignees
+	}
+
+	return MergeResult{Task: merged, Conflicts: conflicts}
+}
+
+// taskUpdateFromTask builds a TaskUpdate carrying all synced fields of a task.
+func taskUpdateFromTask(task *types.Task) *types.TaskUpdate {
+	return &types.TaskUpdate{
+		Title:         &task.Title,
+		Description:   task.Description,
+		Status:        &task.Status,
+		Priority:      task.Priority,
+		DueDate:       task.DueDate,
+		ScheduledDate: task.ScheduledDate,
+		Labels:        extractLabelNames(task.Labels),
+		Assignees:     extractAssigneeNames(task.Assignees),
+	}
+}
@@ -0,0 +1,144 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/rs/zerolog"
+)
+
+func TestMergeTaskKeepsChangesFromBothSides(t *testing.T) {
+	now := time.Now()
+	base := &types.Task{
+		Title:  "Original title",
+		Status: "active",
+		Labels: []types.Label{{Name: "bug"}},
+	}
+	local := &types.Task{
+		ID:        7,
+		Title:     "Edited locally",
+		Status:    "active",
+		Labels:    []types.Label{{Name: "bug"}},
+		UpdatedAt: now,
+	}
+	remote := &types.Task{
+		ExternalID: "42",
+		Title:      "Original title",
+		Status:     "active",
+		Labels:     []types.Label{{Name: "bug"}, {Name: "urgent"}},
+		UpdatedAt:  now.Add(time.Minute),
+	}
+
+	result := MergeTask(base, local, remote)
+
+	if result.Task.Title != "Edited locally" {
+		t.Errorf("Expected local title to survive, got %q", result.Task.Title)
+	}
+	if got := sortedNames(extractLabelNames(result.Task.Labels)); got != "bug, urgent" {
+		t.Errorf("Expected remote labels to survive, got %q", got)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("Expected no conflicts, got %v", result.Conflicts)
+	}
+	if result.Task.ID != 7 || result.Task.ExternalID != "42" {
+		t.Errorf("Expected IDs to be preserved, got ID=%d ExternalID=%q", result.Task.ID, result.Task.ExternalID)
+	}
+}
+
+func TestMergeTaskConflictUsesMostRecent(t *testing.T) {
+	now := time.Now()
+	base := &types.Task{Title: "Base", Status: "active"}
+	local := &types.Task{Title: "Local", Status: "active", UpdatedAt: now}
+	remote := &types.Task{Title: "Remote", Status: "active", UpdatedAt: now.Add(time.Minute)}
+
+	result := MergeTask(base, local, remote)
+
+	if result.Task.Title != "Remote" {
+		t.Errorf("Expected newer remote title to win, got %q", result.Task.Title)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != "title" {
+		t.Errorf("Expected title conflict, got %v", result.Conflicts)
+	}
+
+	// Same edits, but local is newer
+	local.UpdatedAt = now.Add(2 * time.Minute)
+	result = MergeTask(base, local, remote)
+	if result.Task.Title != "Local" {
+		t.Errorf("Expected newer local title to win, got %q", result.Task.Title)
+	}
+}
+
+func TestMergeTaskSameChangeIsNotConflict(t *testing.T) {
+	base := &types.Task{Title: "Base", Status: "active"}
+	local := &types.Task{Title: "Base", Status: "done"}
+	remote := &types.Task{Title: "Base", Status: "done"}
+
+	result := MergeTask(base, local, remote)
+
+	if result.Task.Status != "done" {
+		t.Errorf("Expected status done, got %q", result.Task.Status)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("Expected no conflicts, got %v", result.Conflicts)
+	}
+}
+
+func TestMergeTaskKeepsLocalScheduledDate(t *testing.T) {
+	now := time.Now()
+	scheduled := now.AddDate(0, 0, 3)
+	base := &types.Task{Title: "Base", Status: "active"}
+	local := &types.Task{Title: "Base", Status: "active", ScheduledDate: &scheduled, UpdatedAt: now}
+	remote := &types.Task{Title: "Remote", Status: "active", UpdatedAt: now.Add(time.Minute)}
+
+	result := MergeTask(base, local, remote)
+
+	if result.Task.ScheduledDate == nil || !result.Task.ScheduledDate.Equal(scheduled) {
+		t.Errorf("Expected local scheduled date to survive, got %v", result.Task.ScheduledDate)
+	}
+	if update := taskUpdateFromTask(result.Task); update.ScheduledDate == nil {
+		t.Error("Expected the scheduled date to be pushed")
+	}
+}
+
+func TestMergeTaskLogsExternalDescriptionOnlyAfterPush(t *testing.T) {
+	server := testsupport.NewServer(t)
+	oldDesc, newDesc := "Old steps", "New steps"
+	toduTask := server.AddTask(types.Task{ExternalID: "7", Title: "Fix login", Description: &newDesc})
+
+	mock := plugin.NewMockPlugin("test-system")
+	project := &types.Project{ID: toduTask.ProjectID, ExternalID: "test-repo"}
+	base := &types.Task{ExternalID: "7", Title: "Fix login", Description: &oldDesc, Status: "active"}
+
+	merge := func(updateErr error) string {
+		t.Helper()
+		mock.Reset()
+		mock.UpdateTaskError = updateErr
+		// The external side renamed the task; todu changed the description
+		externalTask := &types.Task{ExternalID: "7", Title: "Fix login on mobile", Description: &oldDesc, Status: "active"}
+		stored := *externalTask
+		mock.AddTask("7", &stored)
+
+		var logs bytes.Buffer
+		engine := NewEngine(server.Client(), registry.New()).WithLogger(zerolog.New(&logs))
+		pr := &ProjectResult{}
+		engine.mergeTask(context.Background(), project, mock, base, toduTask, externalTask, false, pr)
+		if len(pr.Errors) != 0 {
+			t.Fatalf("Expected no errors, got %v", pr.Errors)
+		}
+		return logs.String()
+	}
+
+	if logs := merge(plugin.ErrNotSupported); strings.Contains(logs, "Description changed by sync") {
+		t.Errorf("Expected no description change logged when the push is not supported, got:\n%s", logs)
+	}
+	if logs := merge(nil); !strings.Contains(logs, `"target":"external"`) {
+		t.Errorf("Expected the pushed description change logged, got:\n%s", logs)
+	}
+}
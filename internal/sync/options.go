@@ -22,4 +22,32 @@ type Options struct {
 	// Force when true will push all tasks regardless of last_pushed_at.
 	// Use this to re-sync tasks that may have been pushed with missing data.
 	Force bool
+
+	// Filter restricts which tasks participate in push and pull.
+	// Tasks that don't match are left untouched on both sides.
+	// A partial sync does not advance the project's last_synced_at, so
+	// excluded tasks are still picked up by the next full sync.
+	Filter TaskFilter
+
+	// CommentUpdateProjectIDs lists projects whose comment edits propagate
+	// between Todu and the external system. Comments in other projects are
+	// only ever created, never updated.
+	CommentUpdateProjectIDs []int
+
+	// DuplicateMatch selects how a pull detects that a new external task is
+	// already synced from another system. Empty disables detection.
+	DuplicateMatch DuplicateMatch
+
+	// Progress, if set, is called after each external task a pull processes.
+	Progress func(Progress)
+}
+
+// commentUpdatesEnabled returns true if comment edits propagate for a project.
+func (o Options) commentUpdatesEnabled(projectID int) bool {
+	for _, id := range o.CommentUpdateProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
 }
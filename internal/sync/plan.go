@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// PlanVersion is the current plan file format version.
+const PlanVersion = 1
+
+// ActionType identifies what a planned sync action does.
+type ActionType string
+
+const (
+	// ActionCreateLocal creates a task in Todu from an external task.
+	ActionCreateLocal ActionType = "create_local"
+
+	// ActionUpdateLocal updates a Todu task with external changes.
+	ActionUpdateLocal ActionType = "update_local"
+
+	// ActionCreateRemote creates a Todu task in the external system.
+	ActionCreateRemote ActionType = "create_remote"
+
+	// ActionUpdateRemote updates an external task with Todu changes.
+	ActionUpdateRemote ActionType = "update_remote"
+
+	// ActionCloseRemote closes an external task that is done in Todu but
+	// no longer appears in the external system's task list.
+	ActionCloseRemote ActionType = "close_remote"
+)
+
+// Plan is a persisted set of sync actions produced by Engine.Plan and
+// executed by Engine.Apply.
+type Plan struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Projects  []ProjectPlan `json:"projects"`
+}
+
+// ProjectPlan holds the planned actions for a single project.
+type ProjectPlan struct {
+	ProjectID   int          `json:"project_id"`
+	ProjectName string       `json:"project_name"`
+	Strategy    Strategy     `json:"strategy"`
+	Actions     []PlanAction `json:"actions"`
+}
+
+// PlanAction is a single create, update, or close operation.
+type PlanAction struct {
+	Type       ActionType `json:"type"`
+	TaskID     int        `json:"task_id,omitempty"`
+	ExternalID string     `json:"external_id,omitempty"`
+	Title      string     `json:"title"`
+
+	// Changes lists the fields that differ on the side being written.
+	Changes []FieldDiff `json:"changes,omitempty"`
+
+	// Task is the state the action writes.
+	Task *types.Task `json:"task"`
+
+	// RemoteFingerprint is the Fingerprint of the external task when the plan
+	// was created. Apply refuses to run if the external task has drifted.
+	RemoteFingerprint string `json:"remote_fingerprint,omitempty"`
+}
+
+// ActionCount returns the total number of actions in the plan.
+func (p *Plan) ActionCount() int {
+	count := 0
+	for _, pp := range p.Projects {
+		count += len(pp.Actions)
+	}
+	return count
+}
+
+// Save writes the plan to path as indented JSON.
+func (p *Plan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync plan: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create plan directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write sync plan: %w", err)
+	}
+	return nil
+}
+
+// LoadPlan reads a plan previously written by Plan.Save.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync plan: %w", err)
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse sync plan: %w", err)
+	}
+
+	if plan.Version != PlanVersion {
+		return nil, fmt.Errorf("unsupported sync plan version %d (expected %d)", plan.Version, PlanVersion)
+	}
+
+	return &plan, nil
+}
+
+// Fingerprint returns a hash of a task's synced fields.
+// Tasks with equal fingerprints have no differences according to DiffTasks.
+func Fingerprint(task *types.Task) string {
+	fields := []string{
+		task.Title,
+		derefString(task.Description),
+		task.Status,
+		derefString(task.Priority),
+		formatDate(task.DueDate),
+		sortedNames(extractLabelNames(task.Labels)),
+		sortedNames(extractAssigneeNames(task.Assignees)),
+	}
+
+	data, _ := json.Marshal(fields)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,193 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestFingerprint(t *testing.T) {
+	task := &types.Task{
+		Title:  "Task",
+		Status: "active",
+		Labels: []types.Label{{Name: "b"}, {Name: "a"}},
+	}
+
+	reordered := *task
+	reordered.Labels = []types.Label{{Name: "a"}, {Name: "b"}}
+	reordered.UpdatedAt = time.Now()
+	if Fingerprint(task) != Fingerprint(&reordered) {
+		t.Error("Expected label order and timestamps not to affect fingerprint")
+	}
+
+	changed := *task
+	changed.Title = "Renamed"
+	if Fingerprint(task) == Fingerprint(&changed) {
+		t.Error("Expected title change to affect fingerprint")
+	}
+}
+
+func TestPlanSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	plan := &Plan{
+		Version:   PlanVersion,
+		CreatedAt: time.Now(),
+		Projects: []ProjectPlan{{
+			ProjectID:   1,
+			ProjectName: "Test Project",
+			Strategy:    StrategyPull,
+			Actions: []PlanAction{{
+				Type:       ActionCreateLocal,
+				ExternalID: "task-1",
+				Title:      "Task",
+				Task:       &types.Task{ExternalID: "task-1", Title: "Task"},
+			}},
+		}},
+	}
+
+	if err := plan.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadPlan(path)
+	if err != nil {
+		t.Fatalf("LoadPlan failed: %v", err)
+	}
+	if loaded.ActionCount() != 1 || loaded.Projects[0].Actions[0].Type != ActionCreateLocal {
+		t.Errorf("Unexpected loaded plan: %+v", loaded)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPlan(path); err == nil {
+		t.Error("Expected error for unsupported plan version")
+	}
+}
+
+func setupPlanTest(t *testing.T) (*Engine, func(), *plugin.MockPlugin) {
+	engine, server, mockPlugin := setupTestEngine(t)
+
+	mockPlugin.AddProject("test-repo", &types.Project{
+		ID:         1,
+		ExternalID: "test-repo",
+		Name:       "Test Project",
+	})
+	now := time.Now()
+	mockPlugin.AddTask("task-1", &types.Task{
+		ExternalID: "task-1",
+		Title:      "Test Task",
+		ProjectID:  1,
+		Status:     "active",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+
+	return engine, server.Close, mockPlugin
+}
+
+func TestPlanAndApply(t *testing.T) {
+	engine, cleanup, _ := setupPlanTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	plan, err := engine.Plan(ctx, Options{ProjectIDs: []int{1}})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	if plan.ActionCount() != 1 {
+		t.Fatalf("Expected 1 planned action, got %d", plan.ActionCount())
+	}
+	action := plan.Projects[0].Actions[0]
+	if action.Type != ActionCreateLocal || action.ExternalID != "task-1" || action.RemoteFingerprint == "" {
+		t.Errorf("Unexpected action: %+v", action)
+	}
+
+	result, err := engine.Apply(ctx, plan)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.TotalCreated != 1 {
+		t.Errorf("Expected 1 task created, got %d", result.TotalCreated)
+	}
+	if result.TotalErrors != 0 {
+		t.Errorf("Expected 0 errors, got %d", result.TotalErrors)
+	}
+}
+
+func TestApplyRefusesDriftedPlan(t *testing.T) {
+	engine, cleanup, mockPlugin := setupPlanTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	plan, err := engine.Plan(ctx, Options{ProjectIDs: []int{1}})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	// Change the external task after the plan was made
+	mockPlugin.AddTask("task-1", &types.Task{
+		ExternalID: "task-1",
+		Title:      "Renamed remotely",
+		ProjectID:  1,
+		Status:     "active",
+		UpdatedAt:  time.Now(),
+	})
+
+	_, err = engine.Apply(ctx, plan)
+	if !errors.Is(err, ErrPlanDrifted) {
+		t.Fatalf("Expected ErrPlanDrifted, got %v", err)
+	}
+}
+
+func TestApplyRefusesCreatesSyncedMeanwhile(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Repo", SystemID: system.ID, ExternalID: "test-repo"})
+	server.AddTask(types.Task{Title: "Written locally", ProjectID: project.ID})
+
+	mock := plugin.NewMockPlugin("test-system")
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Repo"})
+	mock.AddTask("7", &types.Task{ExternalID: "7", Title: "Filed upstream", ProjectID: project.ID, Status: "active", UpdatedAt: testsupport.DefaultNow})
+
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+	engine := NewEngine(server.Client(), reg)
+
+	ctx := context.Background()
+	plan, err := engine.Plan(ctx, Options{ProjectIDs: []int{project.ID}})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if plan.ActionCount() != 2 {
+		t.Fatalf("Expected a create_local and a create_remote action, got %+v", plan.Projects)
+	}
+
+	// A regular sync creates both tasks before the plan is applied
+	if _, err := engine.Sync(ctx, Options{ProjectIDs: []int{project.ID}}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	_, err = engine.Apply(ctx, plan)
+	if !errors.Is(err, ErrPlanDrifted) {
+		t.Fatalf("Expected ErrPlanDrifted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Written locally") || !strings.Contains(err.Error(), "Filed upstream") {
+		t.Errorf("Expected both creates reported, got %v", err)
+	}
+	if tasks, _ := mock.FetchTasks(ctx, nil, nil); len(server.Tasks()) != 2 || len(tasks) != 2 {
+		t.Errorf("Expected no duplicates created, got %d Todu and %d external tasks", len(server.Tasks()), len(tasks))
+	}
+}
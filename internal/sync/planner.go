@@ -0,0 +1,526 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// ErrPlanDrifted is returned by Apply when external tasks changed after the
+// plan was created, or tasks it would create were synced meanwhile.
+var ErrPlanDrifted = errors.New("remote state changed since plan was created")
+
+// Plan computes the actions a sync would take without making any changes.
+// Comments are not part of plans; they are synced by a regular Sync.
+func (e *Engine) Plan(ctx context.Context, options Options) (*Plan, error) {
+	projects, err := e.getProjectsToSync(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	plan := &Plan{
+		Version:   PlanVersion,
+		CreatedAt: time.Now(),
+		Projects:  make([]ProjectPlan, 0, len(projects)),
+	}
+
+	for _, project := range projects {
+		pp, err := e.planProject(ctx, project, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan project %q: %w", project.Name, err)
+		}
+		plan.Projects = append(plan.Projects, *pp)
+	}
+
+	return plan, nil
+}
+
+// planProject computes the actions for a single project.
+func (e *Engine) planProject(ctx context.Context, project *types.Project, options Options) (*ProjectPlan, error) {
+	strategy := e.determineStrategy(project, options)
+	pp := &ProjectPlan{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Strategy:    strategy,
+		Actions:     []PlanAction{},
+	}
+
+	p, err := e.createPlugin(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	toduTasks, err := e.apiClient.ListTasks(ctx, &api.TaskListOptions{ProjectID: &project.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Todu tasks: %w", err)
+	}
+
+	// Tasks planned during pull are not considered again for push
+	planned := make(map[string]bool)
+
+	switch strategy {
+	case StrategyPull:
+		if err := e.planPull(ctx, project, p, toduTasks, options, pp, nil); err != nil {
+			return nil, err
+		}
+	case StrategyPush:
+		if err := e.planPush(ctx, project, p, toduTasks, options, pp, planned); err != nil {
+			return nil, err
+		}
+	case StrategyBidirectional:
+		if err := e.planPull(ctx, project, p, toduTasks, options, pp, planned); err != nil {
+			return nil, err
+		}
+		if err := e.planPush(ctx, project, p, toduTasks, options, pp, planned); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown strategy: %s", strategy)
+	}
+
+	return pp, nil
+}
+
+// planPull plans actions for external tasks. If planned is non-nil, tasks
+// with a last-synced snapshot are merged field by field and their external
+// IDs are added to planned.
+func (e *Engine) planPull(ctx context.Context, project *types.Project, p plugin.Plugin, toduTasks []*types.Task, options Options, pp *ProjectPlan, planned map[string]bool) error {
+	externalTasks, err := p.FetchTasks(ctx, &project.ExternalID, project.LastSyncedAt)
+	if err != nil {
+		return fmt.Errorf("failed to fetch external tasks: %w", err)
+	}
+
+	toduTaskMap := make(map[string]*types.Task)
+	for _, task := range toduTasks {
+		if task.ExternalID != "" {
+			toduTaskMap[task.ExternalID] = task
+		}
+	}
+
+	for _, externalTask := range externalTasks {
+		if externalTask.ExternalID == "" || !options.Filter.Matches(externalTask) {
+			continue
+		}
+
+		toduTask, exists := toduTaskMap[externalTask.ExternalID]
+		if !exists {
+			pp.Actions = append(pp.Actions, PlanAction{
+				Type:              ActionCreateLocal,
+				ExternalID:        externalTask.ExternalID,
+				Title:             externalTask.Title,
+				Task:              externalTask,
+				RemoteFingerprint: Fingerprint(externalTask),
+			})
+			continue
+		}
+
+		if e.isEcho(project.ID, externalTask) {
+			continue
+		}
+
+		var base *types.Task
+		if planned != nil && e.snapshots != nil {
+			base, err = e.snapshots.Get(project.ID, externalTask.ExternalID)
+			if err != nil {
+				e.logger.Warn().Err(err).Str("task", externalTask.Title).Msg("Failed to load sync snapshot")
+			}
+		}
+
+		if base == nil && (e.unchangedSinceSync(project.ID, externalTask) || !e.externalChanged(project.ID, p, externalTask, toduTask)) {
+			continue
+		}
+
+		// Fetch full task details to get description (not included in list response)
+		fullTask, err := e.apiClient.GetTask(ctx, toduTask.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err)
+		}
+
+		target := externalTask
+		if base != nil {
+			target = MergeTask(base, fullTask, externalTask).Task
+			planned[externalTask.ExternalID] = true
+		}
+
+		if changes := DiffTasks(fullTask, target); len(changes) > 0 {
+			pp.Actions = append(pp.Actions, PlanAction{
+				Type:              ActionUpdateLocal,
+				TaskID:            toduTask.ID,
+				ExternalID:        externalTask.ExternalID,
+				Title:             fullTask.Title,
+				Changes:           changes,
+				Task:              target,
+				RemoteFingerprint: Fingerprint(externalTask),
+			})
+		}
+
+		if base != nil {
+			if changes := DiffTasks(target, externalTask); len(changes) > 0 {
+				pp.Actions = append(pp.Actions, PlanAction{
+					Type:              ActionUpdateRemote,
+					TaskID:            toduTask.ID,
+					ExternalID:        externalTask.ExternalID,
+					Title:             fullTask.Title,
+					Changes:           changes,
+					Task:              target,
+					RemoteFingerprint: Fingerprint(externalTask),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// planPush plans actions for Todu tasks, skipping tasks already in planned.
+func (e *Engine) planPush(ctx context.Context, project *types.Project, p plugin.Plugin, toduTasks []*types.Task, options Options, pp *ProjectPlan, planned map[string]bool) error {
+	// Parents and blockers are created first, as in syncPush
+	for _, toduTask := range orderByDependencies(toduTasks) {
+		if toduTask.ExternalID != "" && planned[toduTask.ExternalID] {
+			continue
+		}
+
+		if !options.Filter.Matches(toduTask) {
+			continue
+		}
+
+		if !options.Force && toduTask.ExternalID != "" && toduTask.LastPushedAt != nil && !toduTask.UpdatedAt.After(*toduTask.LastPushedAt) {
+			continue
+		}
+
+		if toduTask.ExternalID == "" {
+			// Fetch full task details to get description (not included in list response)
+			fullTask, err := e.apiClient.GetTask(ctx, toduTask.ID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err)
+			}
+			pp.Actions = append(pp.Actions, PlanAction{
+				Type:   ActionCreateRemote,
+				TaskID: toduTask.ID,
+				Title:  fullTask.Title,
+				Task:   fullTask,
+			})
+			continue
+		}
+
+		externalTask, err := p.FetchTask(ctx, &project.ExternalID, toduTask.ExternalID)
+		if err != nil {
+			if err == plugin.ErrNotSupported {
+				continue
+			}
+			if errors.Is(err, plugin.ErrNotFound) {
+				if toduTask.Status == "done" || toduTask.Status == "canceled" {
+					pp.Actions = append(pp.Actions, PlanAction{
+						Type:       ActionCloseRemote,
+						TaskID:     toduTask.ID,
+						ExternalID: toduTask.ExternalID,
+						Title:      toduTask.Title,
+						Task:       toduTask,
+					})
+				}
+				continue
+			}
+			return fmt.Errorf("failed to fetch external task %q: %w", toduTask.ExternalID, err)
+		}
+
+		if !options.Force && !NeedsUpdate(toduTask, externalTask) {
+			continue
+		}
+
+		// Fetch full task details to get description (not included in list response)
+		fullTask, err := e.apiClient.GetTask(ctx, toduTask.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch full task %q: %w", toduTask.Title, err)
+		}
+
+		if changes := DiffTasks(fullTask, externalTask); len(changes) > 0 {
+			pp.Actions = append(pp.Actions, PlanAction{
+				Type:              ActionUpdateRemote,
+				TaskID:            toduTask.ID,
+				ExternalID:        toduTask.ExternalID,
+				Title:             fullTask.Title,
+				Changes:           changes,
+				Task:              fullTask,
+				RemoteFingerprint: Fingerprint(externalTask),
+			})
+		}
+	}
+
+	return nil
+}
+
+// Apply executes exactly the actions in a plan.
+//
+// Before making any changes, Apply re-fetches every external task the plan
+// depends on, and checks that the tasks the plan creates haven't been synced
+// meanwhile. If any of them changed since the plan was created, nothing is
+// applied and an error wrapping ErrPlanDrifted is returned.
+func (e *Engine) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	startTime := time.Now()
+	result := &Result{}
+	e.startRun(false)
+	defer e.finishRun(result)
+
+	type projectPlugin struct {
+		project *types.Project
+		plugin  plugin.Plugin
+	}
+	plugins := make([]projectPlugin, len(plan.Projects))
+
+	// Phase 1: verify the remote state still matches the plan
+	var drifted []string
+	for i, pp := range plan.Projects {
+		if len(pp.Actions) == 0 {
+			continue
+		}
+
+		project, err := e.apiClient.GetProject(ctx, pp.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project %d: %w", pp.ProjectID, err)
+		}
+		p, err := e.createPlugin(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("project %q: %w", project.Name, err)
+		}
+		plugins[i] = projectPlugin{project: project, plugin: p}
+
+		for _, action := range pp.Actions {
+			if action.RemoteFingerprint == "" {
+				continue
+			}
+			current, err := p.FetchTask(ctx, &project.ExternalID, action.ExternalID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch external task %q: %w", action.ExternalID, err)
+			}
+			if Fingerprint(current) != action.RemoteFingerprint {
+				drifted = append(drifted, fmt.Sprintf("%s (%s)", action.Title, action.ExternalID))
+			}
+		}
+
+		duplicates, err := e.createDrift(ctx, pp)
+		if err != nil {
+			return nil, err
+		}
+		drifted = append(drifted, duplicates...)
+	}
+
+	if len(drifted) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPlanDrifted, strings.Join(drifted, ", "))
+	}
+
+	// Phase 2: execute the actions
+	for i, pp := range plan.Projects {
+		pr := ProjectResult{
+			ProjectID:   pp.ProjectID,
+			ProjectName: pp.ProjectName,
+			Errors:      []error{},
+		}
+		projectStart := time.Now()
+
+		externalIDs, err := e.planExternalIDs(ctx, pp)
+		if err != nil {
+			pr.Errors = append(pr.Errors, err)
+		}
+
+		for _, action := range pp.Actions {
+			if interrupted(ctx, &pr) {
+				break
+			}
+			if err := e.applyAction(ctx, plugins[i].project, plugins[i].plugin, action, externalIDs, &pr); err != nil {
+				pr.Errors = append(pr.Errors, err)
+			}
+		}
+
+		pr.Duration = time.Since(projectStart)
+		result.AddProjectResult(pr)
+		if pr.Interrupted {
+			break
+		}
+	}
+
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// createDrift returns the create actions of a project plan that would now
+// duplicate a task: Todu tasks given an external ID since the plan was made,
+// and external tasks a Todu task has since been created for.
+func (e *Engine) createDrift(ctx context.Context, pp ProjectPlan) ([]string, error) {
+	var creates []PlanAction
+	for _, action := range pp.Actions {
+		if action.Type == ActionCreateLocal || action.Type == ActionCreateRemote {
+			creates = append(creates, action)
+		}
+	}
+	if len(creates) == 0 {
+		return nil, nil
+	}
+
+	toduTasks, err := e.apiClient.ListTasks(ctx, &api.TaskListOptions{ProjectID: &pp.ProjectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Todu tasks of project %d: %w", pp.ProjectID, err)
+	}
+	externalIDs := taskExternalIDs(toduTasks)
+	synced := make(map[string]bool, len(externalIDs))
+	for _, externalID := range externalIDs {
+		synced[externalID] = true
+	}
+
+	var drifted []string
+	for _, action := range creates {
+		switch {
+		case action.Type == ActionCreateRemote && externalIDs[action.TaskID] != "":
+			drifted = append(drifted, fmt.Sprintf("%s (%s)", action.Title, externalIDs[action.TaskID]))
+		case action.Type == ActionCreateLocal && synced[action.ExternalID]:
+			drifted = append(drifted, fmt.Sprintf("%s (%s)", action.Title, action.ExternalID))
+		}
+	}
+	return drifted, nil
+}
+
+// planExternalIDs maps the IDs of the project's Todu tasks to their
+// external IDs when a task the plan creates remotely has dependencies to
+// refer to. It returns an empty map otherwise.
+func (e *Engine) planExternalIDs(ctx context.Context, pp ProjectPlan) (map[int]string, error) {
+	for _, action := range pp.Actions {
+		if action.Type != ActionCreateRemote || action.Task == nil || len(action.Task.Dependencies()) == 0 {
+			continue
+		}
+		toduTasks, err := e.apiClient.ListTasks(ctx, &api.TaskListOptions{ProjectID: &pp.ProjectID})
+		if err != nil {
+			return map[int]string{}, fmt.Errorf("failed to fetch Todu tasks for dependency references: %w", err)
+		}
+		return taskExternalIDs(toduTasks), nil
+	}
+	return map[int]string{}, nil
+}
+
+// applyAction executes a single planned action and records it in pr.
+// externalIDs maps Todu task IDs to external IDs for dependency
+// references, and gains the external IDs of tasks created remotely.
+func (e *Engine) applyAction(ctx context.Context, project *types.Project, p plugin.Plugin, action PlanAction, externalIDs map[int]string, pr *ProjectResult) error {
+	task := action.Task
+	if task == nil {
+		return fmt.Errorf("plan action %s for %q has no task", action.Type, action.Title)
+	}
+
+	switch action.Type {
+	case ActionCreateLocal:
+		taskCreate := &types.TaskCreate{
+			ExternalID:  task.ExternalID,
+			SourceURL:   task.SourceURL,
+			Title:       task.Title,
+			Description: task.Description,
+			ProjectID:   project.ID,
+			Status:      task.Status,
+			Priority:    task.Priority,
+			DueDate:     task.DueDate,
+			Labels:      extractLabelNames(task.Labels),
+			Assignees:   extractAssigneeNames(task.Assignees),
+		}
+		if _, err := e.createTask(ctx, taskCreate); err != nil {
+			return fmt.Errorf("failed to create task %q: %w", task.Title, err)
+		}
+		e.saveSnapshot(project.ID, task)
+		pr.Created++
+
+	case ActionUpdateLocal:
+		current, err := e.apiClient.GetTask(ctx, action.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch task %q: %w", action.Title, err)
+		}
+		taskUpdate := taskUpdateFromTask(task)
+		keepTaskRefLabels(taskUpdate, current.Labels)
+		if _, err := e.updateTask(ctx, action.TaskID, taskUpdate); err != nil {
+			return fmt.Errorf("failed to update task %q: %w", action.Title, err)
+		}
+		e.saveSnapshot(project.ID, withExternalID(task, action.ExternalID))
+		pr.Updated++
+
+	case ActionCreateRemote:
+		description, referenced := withReferences(task.Description, dependencyReferences(task, externalIDs))
+		taskCreate := &types.TaskCreate{
+			Title:         task.Title,
+			Description:   description,
+			Status:        task.Status,
+			Priority:      task.Priority,
+			DueDate:       task.DueDate,
+			ScheduledDate: task.ScheduledDate,
+			Labels:        extractLabelNames(task.Labels),
+			Assignees:     extractAssigneeNames(task.Assignees),
+		}
+		createdTask, err := p.CreateTask(ctx, &project.ExternalID, taskCreate)
+		if err != nil {
+			return fmt.Errorf("failed to create external task %q: %w", task.Title, err)
+		}
+		externalIDs[action.TaskID] = createdTask.ExternalID
+
+		// GitHub API doesn't support creating issues in closed state
+		pushedTask := createdTask
+		if task.Status == "done" || task.Status == "canceled" {
+			statusUpdate := &types.TaskUpdate{Status: &task.Status}
+			closedTask, err := p.UpdateTask(ctx, &project.ExternalID, createdTask.ExternalID, statusUpdate)
+			if err != nil && err != plugin.ErrNotSupported {
+				pr.Errors = append(pr.Errors, fmt.Errorf("failed to close external task %q: %w", task.Title, err))
+			} else if closedTask != nil {
+				pushedTask = closedTask
+			}
+		}
+
+		now := time.Now()
+		taskUpdate := &types.TaskUpdate{
+			ExternalID:   &createdTask.ExternalID,
+			SourceURL:    createdTask.SourceURL,
+			LastPushedAt: &now,
+		}
+		pushed := withExternalID(task, createdTask.ExternalID)
+		if referenced {
+			taskUpdate.Description = description
+			pushed.Description = description
+		}
+		if _, err := e.updateTask(ctx, action.TaskID, taskUpdate); err != nil {
+			return fmt.Errorf("failed to update task with external_id: %w", err)
+		}
+		e.saveSnapshot(project.ID, pushed)
+		e.recordPush(project.ID, pushedTask)
+		pr.Created++
+
+	case ActionUpdateRemote:
+		pushedTask, err := p.UpdateTask(ctx, &project.ExternalID, action.ExternalID, taskUpdateFromTask(task))
+		if err != nil {
+			return fmt.Errorf("failed to push task %q: %w", action.Title, err)
+		}
+		e.recordPush(project.ID, pushedTask)
+		now := time.Now()
+		if _, err := e.updateTask(ctx, action.TaskID, &types.TaskUpdate{LastPushedAt: &now}); err != nil {
+			e.logger.Warn().Err(err).Str("task", action.Title).Msg("Failed to update last_pushed_at")
+		}
+		e.saveSnapshot(project.ID, withExternalID(task, action.ExternalID))
+		pr.Updated++
+
+	case ActionCloseRemote:
+		statusUpdate := &types.TaskUpdate{Status: &task.Status}
+		if _, err := p.UpdateTask(ctx, &project.ExternalID, action.ExternalID, statusUpdate); err != nil {
+			return fmt.Errorf("failed to close external task %q: %w", action.Title, err)
+		}
+		pr.Updated++
+
+	default:
+		return fmt.Errorf("unknown plan action: %s", action.Type)
+	}
+
+	return nil
+}
+
+// withExternalID returns a copy of task with its external ID set.
+func withExternalID(task *types.Task, externalID string) *types.Task {
+	copied := *task
+	copied.ExternalID = externalID
+	return &copied
+}
@@ -0,0 +1,174 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxReportErrors is the number of error messages shown per project in reports.
+// Remaining errors are summarized as a count.
+const MaxReportErrors = 3
+
+// Report is a serializable summary of a sync Result, used for machine-readable
+// and markdown output (e.g., for automation and cron email digests).
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	DryRun      bool            `json:"dry_run"`
+	RunID       string          `json:"run_id,omitempty"`
+	Interrupted bool            `json:"interrupted,omitempty"`
+	DurationMS  int64           `json:"duration_ms"`
+	Totals      ReportTotals    `json:"totals"`
+	Projects    []ProjectReport `json:"projects"`
+}
+
+// ReportTotals holds the aggregate counts for a sync report.
+type ReportTotals struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Linked  int `json:"linked,omitempty"`
+	Errors  int `json:"errors"`
+}
+
+// ProjectReport holds the sync outcome for a single project.
+type ProjectReport struct {
+	ProjectID   int      `json:"project_id"`
+	ProjectName string   `json:"project_name"`
+	Created     int      `json:"created"`
+	Updated     int      `json:"updated"`
+	Skipped     int      `json:"skipped"`
+	Linked      int      `json:"linked,omitempty"`
+	Resumed     int      `json:"resumed,omitempty"`
+	ErrorCount  int      `json:"error_count"`
+	Errors      []string `json:"errors,omitempty"`
+	DurationMS  int64    `json:"duration_ms"`
+}
+
+// NewReport builds a Report from a sync Result.
+func NewReport(result *Result, dryRun bool) *Report {
+	report := &Report{
+		GeneratedAt: time.Now(),
+		DryRun:      dryRun,
+		RunID:       result.RunID,
+		Interrupted: result.Interrupted,
+		DurationMS:  result.Duration.Milliseconds(),
+		Totals: ReportTotals{
+			Created: result.TotalCreated,
+			Updated: result.TotalUpdated,
+			Skipped: result.TotalSkipped,
+			Linked:  result.TotalLinked,
+			Errors:  result.TotalErrors,
+		},
+		Projects: make([]ProjectReport, 0, len(result.ProjectResults)),
+	}
+
+	for _, pr := range result.ProjectResults {
+		errs := make([]string, len(pr.Errors))
+		for i, err := range pr.Errors {
+			errs[i] = err.Error()
+		}
+		report.Projects = append(report.Projects, ProjectReport{
+			ProjectID:   pr.ProjectID,
+			ProjectName: pr.ProjectName,
+			Created:     pr.Created,
+			Updated:     pr.Updated,
+			Skipped:     pr.Skipped,
+			Linked:      pr.Linked,
+			Resumed:     pr.Resumed,
+			ErrorCount:  len(pr.Errors),
+			Errors:      errs,
+			DurationMS:  pr.Duration.Milliseconds(),
+		})
+	}
+
+	return report
+}
+
+// JSON returns the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync report: %w", err)
+	}
+	return data, nil
+}
+
+// Markdown returns the report as a markdown document.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	title := "Sync Report"
+	if r.DryRun {
+		title = "Sync Report (dry run)"
+	}
+	if r.Interrupted {
+		title += " (interrupted)"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "Generated: %s\n\n", r.GeneratedAt.Local().Format("2006-01-02 15:04:05"))
+
+	fmt.Fprintf(&b, "**Total:** %d created, %d updated, %d skipped, %d errors in %s\n\n",
+		r.Totals.Created,
+		r.Totals.Updated,
+		r.Totals.Skipped,
+		r.Totals.Errors,
+		formatMS(r.DurationMS),
+	)
+
+	if len(r.Projects) == 0 {
+		b.WriteString("No projects synced.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Project | Created | Updated | Skipped | Errors | Duration |\n")
+	b.WriteString("| ------- | ------- | ------- | ------- | ------ | -------- |\n")
+	for _, p := range r.Projects {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %s |\n",
+			escapeMarkdownCell(p.ProjectName),
+			p.Created,
+			p.Updated,
+			p.Skipped,
+			p.ErrorCount,
+			formatMS(p.DurationMS),
+		)
+	}
+
+	if r.Totals.Errors > 0 {
+		b.WriteString("\n## Errors\n")
+		for _, p := range r.Projects {
+			if p.ErrorCount == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", p.ProjectName)
+			shown, remaining := TopErrors(p.Errors)
+			for _, msg := range shown {
+				fmt.Fprintf(&b, "- %s\n", msg)
+			}
+			if remaining > 0 {
+				fmt.Fprintf(&b, "- ...and %d more\n", remaining)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// TopErrors returns at most MaxReportErrors messages and the number omitted.
+func TopErrors(messages []string) ([]string, int) {
+	if len(messages) <= MaxReportErrors {
+		return messages, 0
+	}
+	return messages[:MaxReportErrors], len(messages) - MaxReportErrors
+}
+
+// formatMS formats a millisecond duration for display.
+func formatMS(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+// escapeMarkdownCell escapes pipe characters so values don't break table cells.
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
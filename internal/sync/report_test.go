@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestResult() *Result {
+	result := &Result{Duration: 1500 * time.Millisecond}
+	result.AddProjectResult(ProjectResult{
+		ProjectID:   1,
+		ProjectName: "Alpha",
+		Created:     2,
+		Updated:     1,
+		Duration:    time.Second,
+		Errors:      []error{},
+	})
+	errs := make([]error, 5)
+	for i := range errs {
+		errs[i] = fmt.Errorf("failure %d", i+1)
+	}
+	result.AddProjectResult(ProjectResult{
+		ProjectID:   2,
+		ProjectName: "Beta | Gamma",
+		Skipped:     4,
+		Duration:    500 * time.Millisecond,
+		Errors:      errs,
+	})
+	return result
+}
+
+func TestNewReport(t *testing.T) {
+	report := NewReport(newTestResult(), true)
+
+	if !report.DryRun {
+		t.Error("Expected DryRun to be set")
+	}
+	if report.DurationMS != 1500 {
+		t.Errorf("Expected duration 1500ms, got %d", report.DurationMS)
+	}
+	if report.Totals.Created != 2 || report.Totals.Skipped != 4 || report.Totals.Errors != 5 {
+		t.Errorf("Unexpected totals: %+v", report.Totals)
+	}
+	if len(report.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(report.Projects))
+	}
+	if report.Projects[1].ErrorCount != 5 || len(report.Projects[1].Errors) != 5 {
+		t.Errorf("Expected all errors in report, got %+v", report.Projects[1])
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	data, err := NewReport(newTestResult(), false).JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	for _, field := range []string{"generated_at", "dry_run", "duration_ms", "totals", "projects"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("Expected JSON field %s", field)
+		}
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	md := NewReport(newTestResult(), false).Markdown()
+
+	if !strings.HasPrefix(md, "# Sync Report\n") {
+		t.Errorf("Expected report title, got:\n%s", md)
+	}
+	if !strings.Contains(md, "| Alpha | 2 | 1 | 0 | 0 | 1s |") {
+		t.Errorf("Expected Alpha row, got:\n%s", md)
+	}
+	if !strings.Contains(md, `Beta \| Gamma`) {
+		t.Errorf("Expected escaped pipe in project name, got:\n%s", md)
+	}
+	if !strings.Contains(md, "- failure 3\n") || strings.Contains(md, "- failure 4\n") {
+		t.Errorf("Expected only top errors, got:\n%s", md)
+	}
+	if !strings.Contains(md, "...and 2 more") {
+		t.Errorf("Expected remaining error count, got:\n%s", md)
+	}
+}
+
+func TestReportMarkdownNoProjects(t *testing.T) {
+	md := NewReport(&Result{}, true).Markdown()
+
+	if !strings.Contains(md, "(dry run)") {
+		t.Errorf("Expected dry run marker, got:\n%s", md)
+	}
+	if !strings.Contains(md, "No projects synced.") {
+		t.Errorf("Expected empty message, got:\n%s", md)
+	}
+}
+
+func TestTopErrors(t *testing.T) {
+	shown, remaining := TopErrors([]string{"a", "b"})
+	if len(shown) != 2 || remaining != 0 {
+		t.Errorf("Expected all errors shown, got %v (%d remaining)", shown, remaining)
+	}
+
+	shown, remaining = TopErrors([]string{"a", "b", "c", "d"})
+	if len(shown) != MaxReportErrors || remaining != 1 {
+		t.Errorf("Expected %d shown and 1 remaining, got %v (%d remaining)", MaxReportErrors, shown, remaining)
+	}
+}
+
+func TestSyncRecordsProjectDuration(t *testing.T) {
+	engine, server, _ := setupTestEngine(t)
+	defer server.Close()
+
+	result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{1}})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.ProjectResults) != 1 {
+		t.Fatalf("Expected 1 project result, got %d", len(result.ProjectResults))
+	}
+	if result.ProjectResults[0].Duration <= 0 {
+		t.Error("Expected project duration to be recorded")
+	}
+}
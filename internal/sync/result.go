@@ -16,11 +16,23 @@ type Result struct {
 	// TotalSkipped is the total number of tasks skipped across all projects.
 	TotalSkipped int
 
+	// TotalLinked is the total number of external tasks linked to existing
+	// tasks in other projects instead of being created.
+	TotalLinked int
+
 	// TotalErrors is the total number of errors across all projects.
 	TotalErrors int
 
 	// Duration is the total time taken for the sync operation.
 	Duration time.Duration
+
+	// RunID identifies the recorded changes the sync made to Todu, for
+	// rolling them back. Empty if nothing was recorded.
+	RunID string
+
+	// Interrupted is true if the sync was canceled, such as with Ctrl-C,
+	// before it finished. The results cover what was done until then.
+	Interrupted bool
 }
 
 // ProjectResult represents the outcome of syncing a single project.
@@ -40,8 +52,23 @@ type ProjectResult struct {
 	// Skipped is the number of tasks skipped in this project.
 	Skipped int
 
+	// Linked is the number of external tasks linked to existing tasks in
+	// other projects instead of being created.
+	Linked int
+
+	// Resumed is the number of skipped tasks that an interrupted initial
+	// sync had already pulled.
+	Resumed int
+
+	// Duration is the time taken to sync this project.
+	Duration time.Duration
+
 	// Errors contains any errors that occurred during sync.
 	Errors []error
+
+	// Interrupted is true if the project's sync was canceled before it
+	// finished. Errors then includes one saying so.
+	Interrupted bool
 }
 
 // HasErrors returns true if any errors occurred during sync.
@@ -55,5 +82,7 @@ func (r *Result) AddProjectResult(pr ProjectResult) {
 	r.TotalCreated += pr.Created
 	r.TotalUpdated += pr.Updated
 	r.TotalSkipped += pr.Skipped
+	r.TotalLinked += pr.Linked
 	r.TotalErrors += len(pr.Errors)
+	r.Interrupted = r.Interrupted || pr.Interrupted
 }
@@ -0,0 +1,476 @@
+package sync
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// maxRuns is the number of sync runs a RunStore keeps. Older runs are
+// removed when a new one is saved.
+const maxRuns = 100
+
+// Run records the changes one sync made to Todu, so that they can be
+// rolled back after a bad sync, such as one that pulled from the wrong
+// repository. Changes made in external systems are not recorded.
+type Run struct {
+	ID           string           `json:"id"`
+	StartedAt    time.Time        `json:"started_at"`
+	Tasks        []*TaskChange    `json:"tasks"`
+	Comments     []*CommentChange `json:"comments"`
+	RolledBackAt *time.Time       `json:"rolled_back_at,omitempty"`
+}
+
+// TaskChange records a Todu task a sync created or updated. Before is the
+// task before the sync's first update to it; After is the task after its
+// last one.
+type TaskChange struct {
+	TaskID  int         `json:"task_id"`
+	Title   string      `json:"title"`
+	Created bool        `json:"created,omitempty"`
+	Before  *types.Task `json:"before,omitempty"`
+	After   *types.Task `json:"after"`
+}
+
+// CommentChange records a Todu comment a sync created or edited.
+type CommentChange struct {
+	CommentID int    `json:"comment_id"`
+	TaskID    int    `json:"task_id"`
+	Created   bool   `json:"created,omitempty"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after"`
+}
+
+// newRun starts recording a sync run
+func newRun(now time.Time) *Run {
+	suffix := make([]byte, 2)
+	_, _ = rand.Read(suffix)
+	return &Run{
+		ID:        now.Format("20060102-150405") + "-" + hex.EncodeToString(suffix),
+		StartedAt: now,
+	}
+}
+
+// Empty reports whether the run changed nothing in Todu.
+func (r *Run) Empty() bool {
+	return len(r.Tasks) == 0 && len(r.Comments) == 0
+}
+
+// task returns the run's change to a task, or nil
+func (r *Run) task(id int) *TaskChange {
+	for _, change := range r.Tasks {
+		if change.TaskID == id {
+			return change
+		}
+	}
+	return nil
+}
+
+// comment returns the run's change to a comment, or nil
+func (r *Run) comment(id int) *CommentChange {
+	for _, change := range r.Comments {
+		if change.CommentID == id {
+			return change
+		}
+	}
+	return nil
+}
+
+// RunStore keeps sync runs as one JSON file per run in a directory.
+type RunStore struct {
+	dir string
+}
+
+// NewRunStore creates a run store that keeps its files in dir. The
+// directory is created on first write.
+func NewRunStore(dir string) *RunStore {
+	return &RunStore{dir: dir}
+}
+
+// DefaultRunDir returns the default run directory (~/.config/todu/sync-runs).
+func DefaultRunDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "sync-runs"), nil
+}
+
+// path returns the file of a run
+func (s *RunStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// Save writes a run to disk, and removes the oldest runs beyond the
+// number kept.
+func (s *RunStore) Save(run *Run) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync run: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sync run directory: %w", err)
+	}
+	if err := os.WriteFile(s.path(run.ID), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync run: %w", err)
+	}
+	return s.prune()
+}
+
+// Load reads a run by ID.
+func (s *RunStore) Load(id string) (*Run, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid sync run ID %q", id)
+	}
+	data, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("sync run %q not found (see 'todu sync runs')", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync run: %w", err)
+	}
+	var run Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse sync run %s: %w", id, err)
+	}
+	return &run, nil
+}
+
+// List returns the recorded runs, newest first.
+func (s *RunStore) List() ([]*Run, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]*Run, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		run, err := s.Load(ids[i])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// ids returns the IDs of the recorded runs, oldest first. IDs start with
+// the run's start time, so they sort by it.
+func (s *RunStore) ids() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync run directory: %w", err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// prune removes the oldest runs beyond maxRuns
+func (s *RunStore) prune() error {
+	ids, err := s.ids()
+	if err != nil {
+		return err
+	}
+	for len(ids) > maxRuns {
+		if err := os.Remove(s.path(ids[0])); err != nil {
+			return fmt.Errorf("failed to remove old sync run: %w", err)
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+// WithRuns sets a run store for the sync engine. When set, every sync and
+// applied plan that changes Todu records its changes as a Run, whose ID is
+// returned in Result.RunID, so that Rollback can undo them.
+func (e *Engine) WithRuns(store *RunStore) *Engine {
+	e.runs = store
+	return e
+}
+
+// startRun starts recording the changes of a sync, unless it is a dry run
+func (e *Engine) startRun(dryRun bool) {
+	e.run = nil
+	if e.runs != nil && !dryRun {
+		e.run = newRun(time.Now())
+	}
+}
+
+// finishRun stops recording and sets the result's run ID if the sync
+// changed anything
+func (e *Engine) finishRun(result *Result) {
+	if e.run != nil && !e.run.Empty() {
+		result.RunID = e.run.ID
+	}
+	e.run = nil
+}
+
+// saveRun writes the current run after a change, so that the changes of
+// an interrupted sync can still be rolled back. Errors are logged, not
+// returned, so a failing disk doesn't fail the sync.
+func (e *Engine) saveRun() {
+	if err := e.runs.Save(e.run); err != nil {
+		e.logger.Warn().Err(err).Msg("Failed to save sync run")
+	}
+}
+
+// createTask creates a Todu task and records it in the current run.
+func (e *Engine) createTask(ctx context.Context, create *types.TaskCreate) (*types.Task, error) {
+	task, err := e.apiClient.CreateTask(ctx, create)
+	if err != nil || e.run == nil {
+		return task, err
+	}
+	e.run.Tasks = append(e.run.Tasks, &TaskChange{TaskID: task.ID, Title: task.Title, Created: true, After: task})
+	e.saveRun()
+	return task, nil
+}
+
+// updateTask updates a Todu task and records it in the current run. The
+// first time a run updates a task, the task is fetched so that the run
+// knows what to restore.
+func (e *Engine) updateTask(ctx context.Context, id int, update *types.TaskUpdate) (*types.Task, error) {
+	if e.run == nil {
+		return e.apiClient.UpdateTask(ctx, id, update)
+	}
+
+	change := e.run.task(id)
+	if change == nil {
+		before, err := e.apiClient.GetTask(ctx, id)
+		if err != nil {
+			e.logger.Warn().Err(err).Int("task_id", id).Msg("Failed to record task before update; it can't be rolled back")
+		} else {
+			change = &TaskChange{TaskID: id, Title: before.Title, Before: before}
+		}
+	}
+
+	task, err := e.apiClient.UpdateTask(ctx, id, update)
+	if err != nil || change == nil {
+		return task, err
+	}
+	if !slices.Contains(e.run.Tasks, change) {
+		e.run.Tasks = append(e.run.Tasks, change)
+	}
+	change.After = task
+	e.saveRun()
+	return task, nil
+}
+
+// createComment creates a Todu comment and records it in the current run.
+func (e *Engine) createComment(ctx context.Context, create *types.CommentCreate) (*types.Comment, error) {
+	comment, err := e.apiClient.CreateComment(ctx, create)
+	if err != nil || e.run == nil {
+		return comment, err
+	}
+	change := &CommentChange{CommentID: comment.ID, Created: true, After: comment.Content}
+	if create.TaskID != nil {
+		change.TaskID = *create.TaskID
+	}
+	e.run.Comments = append(e.run.Comments, change)
+	e.saveRun()
+	return comment, nil
+}
+
+// updateComment updates a Todu comment and records it in the current run,
+// fetching it first the first time the run edits it.
+func (e *Engine) updateComment(ctx context.Context, id int, update *types.CommentUpdate) (*types.Comment, error) {
+	if e.run == nil || update.Content == nil {
+		return e.apiClient.UpdateComment(ctx, id, update)
+	}
+
+	change := e.run.comment(id)
+	if change == nil {
+		before, err := e.apiClient.GetComment(ctx, id)
+		if err != nil {
+			e.logger.Warn().Err(err).Int("comment_id", id).Msg("Failed to record comment before update; it can't be rolled back")
+		} else {
+			change = &CommentChange{CommentID: id, Before: before.Content}
+			if before.TaskID != nil {
+				change.TaskID = *before.TaskID
+			}
+		}
+	}
+
+	comment, err := e.apiClient.UpdateComment(ctx, id, update)
+	if err != nil || change == nil {
+		return comment, err
+	}
+	if !slices.Contains(e.run.Comments, change) {
+		e.run.Comments = append(e.run.Comments, change)
+	}
+	change.After = comment.Content
+	e.saveRun()
+	return comment, nil
+}
+
+// RollbackOptions controls Rollback.
+type RollbackOptions struct {
+	// DryRun reports what would be rolled back without changing anything.
+	DryRun bool
+
+	// Force rolls back tasks and comments changed since the sync, and
+	// runs that were already rolled back.
+	Force bool
+}
+
+// RollbackResult reports what Rollback did with each change of a run.
+type RollbackResult struct {
+	Restored []string `json:"restored"` // updated tasks and comments put back
+	Deleted  []string `json:"deleted"`  // created tasks and comments removed
+	Skipped  []string `json:"skipped"`  // with the reason
+	Failed   []string `json:"failed"`   // with the error
+}
+
+// Rollback undoes the changes a sync run made to Todu: tasks and comments
+// it created are deleted, and those it updated are put back as they were
+// before. Tasks and comments changed again since the sync are skipped
+// unless opts.Force is set, so later work isn't lost. The run is marked
+// as rolled back.
+//
+// Fields a task didn't have before the sync, such as a due date the sync
+// added, can't be cleared through the API and are left as they are.
+// Changes the sync pushed to external systems are not undone.
+func Rollback(ctx context.Context, client *api.Client, store *RunStore, run *Run, opts RollbackOptions) (*RollbackResult, error) {
+	if run.RolledBackAt != nil && !opts.Force {
+		return nil, fmt.Errorf("sync run %s was already rolled back on %s; use --force to roll it back again",
+			run.ID, run.RolledBackAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	result := &RollbackResult{Restored: []string{}, Deleted: []string{}, Skipped: []string{}, Failed: []string{}}
+
+	// Undo in reverse order, comments before the tasks they belong to
+	for i := len(run.Comments) - 1; i >= 0; i-- {
+		rollbackComment(ctx, client, run.Comments[i], opts, result)
+	}
+	for i := len(run.Tasks) - 1; i >= 0; i-- {
+		rollbackTask(ctx, client, run.Tasks[i], opts, result)
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+	now := time.Now()
+	run.RolledBackAt = &now
+	if err := store.Save(run); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// rollbackTask deletes a task the run created, or restores one it updated
+func rollbackTask(ctx context.Context, client *api.Client, change *TaskChange, opts RollbackOptions, result *RollbackResult) {
+	name := fmt.Sprintf("task #%d %q", change.TaskID, change.Title)
+
+	current, err := client.GetTask(ctx, change.TaskID)
+	if api.IsNotFound(err) {
+		result.Skipped = append(result.Skipped, name+": already deleted")
+		return
+	}
+	if err != nil {
+		result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+
+	if change.Created {
+		if !opts.Force && len(DiffTasks(change.After, current)) > 0 {
+			result.Skipped = append(result.Skipped, name+": changed since the sync")
+			return
+		}
+		if !opts.DryRun {
+			if err := client.DeleteTask(ctx, change.TaskID); err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+				return
+			}
+		}
+		result.Deleted = append(result.Deleted, name)
+		return
+	}
+
+	if len(DiffTasks(change.Before, current)) == 0 && current.ExternalID == change.Before.ExternalID {
+		result.Skipped = append(result.Skipped, name+": unchanged")
+		return
+	}
+	if !opts.Force && len(DiffTasks(change.After, current)) > 0 {
+		result.Skipped = append(result.Skipped, name+": changed since the sync")
+		return
+	}
+	if !opts.DryRun {
+		if _, err := client.UpdateTask(ctx, change.TaskID, restoreUpdate(change.Before)); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+	}
+	result.Restored = append(result.Restored, name)
+}
+
+// restoreUpdate returns the update that puts a task back as it was
+func restoreUpdate(before *types.Task) *types.TaskUpdate {
+	update := taskUpdateFromTask(before)
+	if before.ExternalID != "" {
+		update.ExternalID = &before.ExternalID
+	}
+	update.SourceURL = before.SourceURL
+	update.LastPushedAt = before.LastPushedAt
+	return update
+}
+
+// rollbackComment deletes a comment the run created, or restores the
+// content of one it edited
+func rollbackComment(ctx context.Context, client *api.Client, change *CommentChange, opts RollbackOptions, result *RollbackResult) {
+	name := fmt.Sprintf("comment #%d on task #%d", change.CommentID, change.TaskID)
+
+	current, err := client.GetComment(ctx, change.CommentID)
+	if api.IsNotFound(err) {
+		result.Skipped = append(result.Skipped, name+": already deleted")
+		return
+	}
+	if err != nil {
+		result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+
+	if !change.Created && current.Content == change.Before {
+		result.Skipped = append(result.Skipped, name+": unchanged")
+		return
+	}
+	if !opts.Force && current.Content != change.After {
+		result.Skipped = append(result.Skipped, name+": edited since the sync")
+		return
+	}
+
+	if change.Created {
+		if !opts.DryRun {
+			if err := client.DeleteComment(ctx, change.CommentID); err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+				return
+			}
+		}
+		result.Deleted = append(result.Deleted, name)
+		return
+	}
+
+	if !opts.DryRun {
+		if _, err := client.UpdateComment(ctx, change.CommentID, &types.CommentUpdate{Content: &change.Before}); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+	}
+	result.Restored = append(result.Restored, name)
+}
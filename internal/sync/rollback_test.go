@@ -0,0 +1,207 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestSyncRecordsRun(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+	store := NewRunStore(t.TempDir())
+	engine.WithRuns(store)
+
+	mockPlugin.AddProject("test-repo", &types.Project{ID: 1, ExternalID: "test-repo", Name: "Test Project"})
+	mockPlugin.AddTask("task-1", &types.Task{
+		ExternalID: "task-1",
+		Title:      "Pulled Task",
+		ProjectID:  1,
+		Status:     "active",
+		UpdatedAt:  time.Now(),
+	})
+
+	result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{1}})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.RunID == "" {
+		t.Fatal("Expected the sync to record a run")
+	}
+
+	run, err := store.Load(result.RunID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(run.Tasks) != 1 || !run.Tasks[0].Created || run.Tasks[0].Title != "Pulled Task" {
+		t.Errorf("Unexpected recorded tasks: %+v", run.Tasks)
+	}
+
+	// Dry runs record nothing
+	result, err = engine.Sync(context.Background(), Options{ProjectIDs: []int{1}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.RunID != "" {
+		t.Errorf("Expected a dry run not to record a run, got %s", result.RunID)
+	}
+}
+
+// fakeTodu is an in-memory Todu API for tasks and comments
+type fakeTodu struct {
+	tasks    map[int]*types.Task
+	comments map[int]*types.Comment
+}
+
+func (f *fakeTodu) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var id int
+	switch {
+	case scan(r.URL.Path, "/api/v1/tasks/%d", &id):
+		task, ok := f.tasks[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			delete(f.tasks, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPut, http.MethodPatch:
+			var update types.TaskUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			if update.Title != nil {
+				task.Title = *update.Title
+			}
+			if update.Status != nil {
+				task.Status = *update.Status
+			}
+		}
+		_ = json.NewEncoder(w).Encode(task)
+	case scan(r.URL.Path, "/api/v1/comments/%d", &id):
+		comment, ok := f.comments[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			delete(f.comments, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPut, http.MethodPatch:
+			var update types.CommentUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			if update.Content != nil {
+				comment.Content = *update.Content
+			}
+		}
+		_ = json.NewEncoder(w).Encode(comment)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// scan reports whether path matches format exactly
+func scan(path, format string, id *int) bool {
+	n, err := fmt.Sscanf(path, format, id)
+	return err == nil && n == 1 && fmt.Sprintf(format, *id) == path
+}
+
+func TestRollback(t *testing.T) {
+	taskID := 1
+	fake := &fakeTodu{
+		tasks: map[int]*types.Task{
+			1: {ID: 1, Title: "Renamed by sync", Status: "done"},
+			2: {ID: 2, Title: "Created by sync", Status: "active"},
+			3: {ID: 3, Title: "Edited after sync", Status: "active"},
+		},
+		comments: map[int]*types.Comment{
+			10: {ID: 10, TaskID: &taskID, Content: "pulled comment"},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := NewRunStore(t.TempDir())
+	run := &Run{
+		ID: "20250610-091500-abcd",
+		Tasks: []*TaskChange{
+			{TaskID: 1, Title: "Original", Before: &types.Task{ID: 1, Title: "Original", Status: "active"}, After: &types.Task{ID: 1, Title: "Renamed by sync", Status: "done"}},
+			{TaskID: 2, Title: "Created by sync", Created: true, After: &types.Task{ID: 2, Title: "Created by sync", Status: "active"}},
+			{TaskID: 3, Title: "Other", Before: &types.Task{ID: 3, Title: "Other", Status: "active"}, After: &types.Task{ID: 3, Title: "Synced", Status: "active"}},
+		},
+		Comments: []*CommentChange{
+			{CommentID: 10, TaskID: 1, Created: true, After: "pulled comment"},
+		},
+	}
+	client := api.NewClient(server.URL, "")
+
+	result, err := Rollback(context.Background(), client, store, run, RollbackOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if len(result.Restored) != 1 || len(result.Deleted) != 2 || len(result.Skipped) != 1 {
+		t.Errorf("Unexpected dry run result: %+v", result)
+	}
+	if len(fake.tasks) != 3 || len(fake.comments) != 1 || run.RolledBackAt != nil {
+		t.Fatal("Expected a dry run to change nothing")
+	}
+
+	if _, err := Rollback(context.Background(), client, store, run, RollbackOptions{}); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if fake.tasks[1].Title != "Original" || fake.tasks[1].Status != "active" {
+		t.Errorf("Expected task 1 to be restored, got %+v", fake.tasks[1])
+	}
+	if _, ok := fake.tasks[2]; ok {
+		t.Error("Expected the created task to be deleted")
+	}
+	if fake.tasks[3].Title != "Edited after sync" {
+		t.Error("Expected the task edited after the sync to be left alone")
+	}
+	if len(fake.comments) != 0 {
+		t.Error("Expected the created comment to be deleted")
+	}
+
+	saved, err := store.Load(run.ID)
+	if err != nil || saved.RolledBackAt == nil {
+		t.Fatalf("Expected the run to be marked rolled back, got %+v, %v", saved, err)
+	}
+	if _, err := Rollback(context.Background(), client, store, saved, RollbackOptions{}); err == nil {
+		t.Error("Expected rolling back twice to fail without --force")
+	}
+}
+
+func TestRunStorePrunes(t *testing.T) {
+	store := NewRunStore(t.TempDir())
+	start := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < maxRuns+2; i++ {
+		run := newRun(start.Add(time.Duration(i) * time.Second))
+		if err := store.Save(run); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	runs, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(runs) != maxRuns {
+		t.Fatalf("Expected %d runs kept, got %d", maxRuns, len(runs))
+	}
+	if !runs[0].StartedAt.After(runs[len(runs)-1].StartedAt) {
+		t.Error("Expected runs newest first")
+	}
+	if _, err := store.Load("../secrets"); err == nil {
+		t.Error("Expected an invalid run ID to be rejected")
+	}
+}
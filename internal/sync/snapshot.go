@@ -0,0 +1,576 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	gosync "sync"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// FileSnapshotStore is a SnapshotStore backed by JSON files on disk.
+//
+// Snapshots are stored as one file per project, keyed by task external ID,
+// with comment snapshots, pushed task fingerprints, comment cursors, task
+// links and pushed comment reactions in separate files:
+//
+//	{dir}/project-{id}.json
+//	{dir}/project-{id}-comments.json
+//	{dir}/project-{id}-pushed.json
+//	{dir}/project-{id}-cursors.json
+//	{dir}/project-{id}-links.json
+//	{dir}/project-{id}-reactions.json
+//	{dir}/project-{id}-checkpoint.json
+//
+// Project files are loaded lazily and cached in memory. Every Put writes the
+// project file back to disk so snapshots survive crashes mid-sync.
+// Checkpoints are only read once per pull and are not cached.
+type FileSnapshotStore struct {
+	mu        gosync.Mutex
+	dir       string
+	projects  map[int]map[string]*types.Task
+	comments  map[int]map[string]*CommentSnapshot
+	pushed    map[int]map[string]string
+	cursors   map[int]map[string]time.Time
+	links     map[int]map[string]int
+	reactions map[int]map[string][]string
+}
+
+// NewFileSnapshotStore creates a snapshot store that keeps its files in dir.
+// The directory is created on first write.
+func NewFileSnapshotStore(dir string) *FileSnapshotStore {
+	return &FileSnapshotStore{
+		dir:       dir,
+		projects:  make(map[int]map[string]*types.Task),
+		comments:  make(map[int]map[string]*CommentSnapshot),
+		pushed:    make(map[int]map[string]string),
+		cursors:   make(map[int]map[string]time.Time),
+		links:     make(map[int]map[string]int),
+		reactions: make(map[int]map[string][]string),
+	}
+}
+
+// DefaultSnapshotDir returns the default snapshot directory (~/.config/todu/snapshots).
+func DefaultSnapshotDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "todu", "snapshots"), nil
+}
+
+// Get returns the last-synced snapshot for a task, or nil if none exists.
+func (s *FileSnapshotStore) Get(projectID int, externalID string) (*types.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots[externalID], nil
+}
+
+// Put stores the snapshot for a task and writes the project file to disk.
+func (s *FileSnapshotStore) Put(projectID int, task *types.Task) error {
+	if task.ExternalID == "" {
+		return fmt.Errorf("cannot snapshot task without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load(projectID)
+	if err != nil {
+		return err
+	}
+
+	snapshots[task.ExternalID] = snapshotOf(task)
+	return s.save(projectID, snapshots)
+}
+
+// GetComment returns the snapshot for a comment, or nil if none exists.
+func (s *FileSnapshotStore) GetComment(projectID int, externalID string) (*CommentSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.loadComments(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots[externalID], nil
+}
+
+// PutComment stores the snapshot for a comment and writes the project's
+// comment file to disk.
+func (s *FileSnapshotStore) PutComment(projectID int, snapshot *CommentSnapshot) error {
+	if snapshot.ExternalID == "" {
+		return fmt.Errorf("cannot snapshot comment without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.loadComments(projectID)
+	if err != nil {
+		return err
+	}
+
+	snapshots[snapshot.ExternalID] = snapshot
+	return s.writeFile(s.commentsPath(projectID), snapshots)
+}
+
+// PushedFingerprint returns the fingerprint recorded by the last push of a
+// task, or an empty string if none exists.
+func (s *FileSnapshotStore) PushedFingerprint(projectID int, externalID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pushed, err := s.loadPushed(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return pushed[externalID], nil
+}
+
+// RecordPush stores the fingerprint of a pushed task and writes the
+// project's pushed file to disk.
+func (s *FileSnapshotStore) RecordPush(projectID int, externalID, fingerprint string) error {
+	if externalID == "" {
+		return fmt.Errorf("cannot record push for task without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pushed, err := s.loadPushed(projectID)
+	if err != nil {
+		return err
+	}
+
+	pushed[externalID] = fingerprint
+	return s.writeFile(s.pushedPath(projectID), pushed)
+}
+
+// CommentCursor returns the newest external comment timestamp recorded for a
+// task, or nil if none exists.
+func (s *FileSnapshotStore) CommentCursor(projectID int, externalID string) (*time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.loadCursors(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, ok := cursors[externalID]
+	if !ok {
+		return nil, nil
+	}
+	return &cursor, nil
+}
+
+// RecordCommentCursor stores the comment cursor for a task and writes the
+// project's cursor file to disk.
+func (s *FileSnapshotStore) RecordCommentCursor(projectID int, externalID string, cursor time.Time) error {
+	if externalID == "" {
+		return fmt.Errorf("cannot record comment cursor for task without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.loadCursors(projectID)
+	if err != nil {
+		return err
+	}
+
+	cursors[externalID] = cursor
+	return s.writeFile(s.cursorsPath(projectID), cursors)
+}
+
+// PushedReactions returns the emoji already pushed for a comment.
+func (s *FileSnapshotStore) PushedReactions(projectID int, commentExternalID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reactions, err := s.loadReactions(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), reactions[commentExternalID]...), nil
+}
+
+// RecordPushedReactions stores the emoji pushed for a comment and writes the
+// project's reaction file to disk.
+func (s *FileSnapshotStore) RecordPushedReactions(projectID int, commentExternalID string, emojis []string) error {
+	if commentExternalID == "" {
+		return fmt.Errorf("cannot record reactions for comment without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reactions, err := s.loadReactions(projectID)
+	if err != nil {
+		return err
+	}
+
+	reactions[commentExternalID] = append([]string(nil), emojis...)
+	return s.writeFile(s.reactionsPath(projectID), reactions)
+}
+
+// LinkedTask returns the Todu task ID an external task is linked to, or 0 if
+// it isn't linked.
+func (s *FileSnapshotStore) LinkedTask(projectID int, externalID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links, err := s.loadLinks(projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	return links[externalID], nil
+}
+
+// Link links an external task to a Todu task and writes the project's link
+// file to disk.
+func (s *FileSnapshotStore) Link(projectID int, externalID string, taskID int) error {
+	if externalID == "" {
+		return fmt.Errorf("cannot link task without external_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links, err := s.loadLinks(projectID)
+	if err != nil {
+		return err
+	}
+
+	links[externalID] = taskID
+	return s.writeFile(s.linksPath(projectID), links)
+}
+
+// ProjectLinks returns the task links of a project, keyed by external ID.
+func (s *FileSnapshotStore) ProjectLinks(projectID int) (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links, err := s.loadLinks(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int, len(links))
+	for externalID, taskID := range links {
+		result[externalID] = taskID
+	}
+	return result, nil
+}
+
+// Unlink removes the link of an external task and writes the project's link
+// file to disk. Removing a link that doesn't exist is not an error.
+func (s *FileSnapshotStore) Unlink(projectID int, externalID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links, err := s.loadLinks(projectID)
+	if err != nil {
+		return err
+	}
+	if _, ok := links[externalID]; !ok {
+		return nil
+	}
+
+	delete(links, externalID)
+	return s.writeFile(s.linksPath(projectID), links)
+}
+
+// TaskLinks returns the external tasks linked to a Todu task across all
+// projects, ordered by project ID and external ID.
+func (s *FileSnapshotStore) TaskLinks(taskID int) ([]TaskLink, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "project-*-links.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []TaskLink
+	for _, path := range paths {
+		var projectID int
+		if _, err := fmt.Sscanf(filepath.Base(path), "project-%d-links.json", &projectID); err != nil {
+			continue
+		}
+		links, err := s.loadLinks(projectID)
+		if err != nil {
+			return nil, err
+		}
+		for externalID, linkedID := range links {
+			if linkedID == taskID {
+				result = append(result, TaskLink{ProjectID: projectID, ExternalID: externalID})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ProjectID != result[j].ProjectID {
+			return result[i].ProjectID < result[j].ProjectID
+		}
+		return result[i].ExternalID < result[j].ExternalID
+	})
+	return result, nil
+}
+
+// checkpointFile is a checkpoint as written to disk, with the done external
+// IDs sorted.
+type checkpointFile struct {
+	Cursor string   `json:"cursor,omitempty"`
+	Pulled int      `json:"pulled,omitempty"`
+	Done   []string `json:"done"`
+}
+
+// Checkpoint returns the progress of an interrupted initial sync of a
+// project, or nil if there is no checkpoint.
+func (s *FileSnapshotStore) Checkpoint(projectID int) (*Checkpoint, error) {
+	var raw json.RawMessage
+	if err := s.readFile(s.checkpointPath(projectID), &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	// Checkpoints written before paged fetches are a list of external IDs
+	var file checkpointFile
+	if err := json.Unmarshal(raw, &file.Done); err != nil {
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+		}
+	}
+
+	done := make(map[string]bool, len(file.Done))
+	for _, id := range file.Done {
+		done[id] = true
+	}
+	return &Checkpoint{Cursor: file.Cursor, Pulled: file.Pulled, Done: done}, nil
+}
+
+// SaveCheckpoint writes a project's checkpoint to disk.
+func (s *FileSnapshotStore) SaveCheckpoint(projectID int, checkpoint *Checkpoint) error {
+	file := checkpointFile{Cursor: checkpoint.Cursor, Pulled: checkpoint.Pulled, Done: make([]string, 0, len(checkpoint.Done))}
+	for id := range checkpoint.Done {
+		file.Done = append(file.Done, id)
+	}
+	sort.Strings(file.Done)
+	return s.writeFile(s.checkpointPath(projectID), file)
+}
+
+// ClearCheckpoint removes a project's checkpoint file. Removing a checkpoint
+// that doesn't exist is not an error.
+func (s *FileSnapshotStore) ClearCheckpoint(projectID int) error {
+	if err := os.Remove(s.checkpointPath(projectID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// load returns the cached snapshots for a project, reading them from disk if needed.
+// Must be called with s.mu held.
+func (s *FileSnapshotStore) load(projectID int) (map[string]*types.Task, error) {
+	if snapshots, ok := s.projects[projectID]; ok {
+		return snapshots, nil
+	}
+
+	snapshots := make(map[string]*types.Task)
+	if err := s.readFile(s.projectPath(projectID), &snapshots); err != nil {
+		return nil, err
+	}
+
+	s.projects[projectID] = snapshots
+	return snapshots, nil
+}
+
+// loadComments returns the cached comment snapshots for a project, reading
+// them from disk if needed. Must be called with s.mu held.
+func (s *FileSnapshotStore) loadComments(projectID int) (map[string]*CommentSnapshot, error) {
+	if snapshots, ok := s.comments[projectID]; ok {
+		return snapshots, nil
+	}
+
+	snapshots := make(map[string]*CommentSnapshot)
+	if err := s.readFile(s.commentsPath(projectID), &snapshots); err != nil {
+		return nil, err
+	}
+
+	s.comments[projectID] = snapshots
+	return snapshots, nil
+}
+
+// loadPushed returns the cached pushed fingerprints for a project, reading
+// them from disk if needed. Must be called with s.mu held.
+func (s *FileSnapshotStore) loadPushed(projectID int) (map[string]string, error) {
+	if pushed, ok := s.pushed[projectID]; ok {
+		return pushed, nil
+	}
+
+	pushed := make(map[string]string)
+	if err := s.readFile(s.pushedPath(projectID), &pushed); err != nil {
+		return nil, err
+	}
+
+	s.pushed[projectID] = pushed
+	return pushed, nil
+}
+
+// loadCursors returns the cached comment cursors for a project, reading them
+// from disk if needed. Must be called with s.mu held.
+func (s *FileSnapshotStore) loadCursors(projectID int) (map[string]time.Time, error) {
+	if cursors, ok := s.cursors[projectID]; ok {
+		return cursors, nil
+	}
+
+	cursors := make(map[string]time.Time)
+	if err := s.readFile(s.cursorsPath(projectID), &cursors); err != nil {
+		return nil, err
+	}
+
+	s.cursors[projectID] = cursors
+	return cursors, nil
+}
+
+// loadLinks returns the cached task links for a project, reading them from
+// disk if needed. Must be called with s.mu held.
+func (s *FileSnapshotStore) loadLinks(projectID int) (map[string]int, error) {
+	if links, ok := s.links[projectID]; ok {
+		return links, nil
+	}
+
+	links := make(map[string]int)
+	if err := s.readFile(s.linksPath(projectID), &links); err != nil {
+		return nil, err
+	}
+
+	s.links[projectID] = links
+	return links, nil
+}
+
+// loadReactions returns the cached pushed reactions for a project, reading
+// them from disk if needed. Must be called with s.mu held.
+func (s *FileSnapshotStore) loadReactions(projectID int) (map[string][]string, error) {
+	if reactions, ok := s.reactions[projectID]; ok {
+		return reactions, nil
+	}
+
+	reactions := make(map[string][]string)
+	if err := s.readFile(s.reactionsPath(projectID), &reactions); err != nil {
+		return nil, err
+	}
+
+	s.reactions[projectID] = reactions
+	return reactions, nil
+}
+
+// save writes a project's snapshots to disk atomically.
+// Must be called with s.mu held.
+func (s *FileSnapshotStore) save(projectID int, snapshots map[string]*types.Task) error {
+	return s.writeFile(s.projectPath(projectID), snapshots)
+}
+
+// readFile decodes a snapshot file into v. A missing file leaves v unchanged.
+func (s *FileSnapshotStore) readFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+	return nil
+}
+
+// writeFile encodes v and writes it to path atomically.
+func (s *FileSnapshotStore) writeFile(path string, v interface{}) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+
+	return nil
+}
+
+// projectPath returns the snapshot file path for a project.
+func (s *FileSnapshotStore) projectPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d.json", projectID))
+}
+
+// commentsPath returns the comment snapshot file path for a project.
+func (s *FileSnapshotStore) commentsPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-comments.json", projectID))
+}
+
+// pushedPath returns the pushed fingerprint file path for a project.
+func (s *FileSnapshotStore) pushedPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-pushed.json", projectID))
+}
+
+// cursorsPath returns the comment cursor file path for a project.
+func (s *FileSnapshotStore) cursorsPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-cursors.json", projectID))
+}
+
+// linksPath returns the task link file path for a project.
+func (s *FileSnapshotStore) linksPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-links.json", projectID))
+}
+
+// reactionsPath returns the pushed reaction file path for a project.
+func (s *FileSnapshotStore) reactionsPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-reactions.json", projectID))
+}
+
+// checkpointPath returns the initial pull checkpoint file path for a project.
+func (s *FileSnapshotStore) checkpointPath(projectID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("project-%d-checkpoint.json", projectID))
+}
+
+// snapshotOf copies the synced fields of a task.
+// IDs and timestamps that differ between systems are not part of the snapshot.
+func snapshotOf(task *types.Task) *types.Task {
+	return &types.Task{
+		ExternalID:  task.ExternalID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Labels:      task.Labels,
+		Assignees:   task.Assignees,
+	}
+}
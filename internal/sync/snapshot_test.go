@@ -0,0 +1,222 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/internal/testsupport"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestFileSnapshotStoreGetMissing(t *testing.T) {
+	store := NewFileSnapshotStore(t.TempDir())
+
+	snapshot, err := store.Get(1, "42")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if snapshot != nil {
+		t.Errorf("Expected nil snapshot, got %+v", snapshot)
+	}
+}
+
+func TestFileSnapshotStorePutAndReload(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	task := &types.Task{
+		ID:          5,
+		ExternalID:  "42",
+		Title:       "Snapshot me",
+		Description: stringPtr("Body"),
+		Status:      "active",
+		Labels:      []types.Label{{ID: 3, Name: "bug"}},
+		UpdatedAt:   time.Now(),
+	}
+	if err := store.Put(1, task); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "project-1.json")); err != nil {
+		t.Fatalf("Expected project file to be written: %v", err)
+	}
+
+	// A fresh store must read the snapshot back from disk
+	reloaded, err := NewFileSnapshotStore(dir).Get(1, "42")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if reloaded == nil {
+		t.Fatal("Expected snapshot to be persisted")
+	}
+	if reloaded.Title != "Snapshot me" || derefString(reloaded.Description) != "Body" {
+		t.Errorf("Unexpected snapshot contents: %+v", reloaded)
+	}
+	if reloaded.ID != 0 || !reloaded.UpdatedAt.IsZero() {
+		t.Errorf("Expected IDs and timestamps to be excluded from snapshot, got %+v", reloaded)
+	}
+	if len(DiffTasks(task, reloaded)) != 0 {
+		t.Errorf("Expected snapshot to match synced fields, got %+v", DiffTasks(task, reloaded))
+	}
+
+	// Snapshots are scoped by project
+	other, err := store.Get(2, "42")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if other != nil {
+		t.Errorf("Expected no snapshot for other project, got %+v", other)
+	}
+}
+
+func TestFileSnapshotStorePutRequiresExternalID(t *testing.T) {
+	store := NewFileSnapshotStore(t.TempDir())
+
+	if err := store.Put(1, &types.Task{Title: "No external ID"}); err == nil {
+		t.Error("Expected error for task without external_id")
+	}
+}
+
+func TestFileSnapshotStoreCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileSnapshotStore(dir)
+
+	want := &Checkpoint{Cursor: "page-3", Pulled: 200, Done: map[string]bool{"7": true, "9": true}}
+	if err := store.SaveCheckpoint(4, want); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	got, err := NewFileSnapshotStore(dir).Checkpoint(4)
+	if err != nil || got == nil || got.Cursor != want.Cursor || got.Pulled != want.Pulled || len(got.Done) != 2 || !got.Done["7"] || !got.Done["9"] {
+		t.Errorf("Expected %+v reloaded, got %+v (%v)", want, got, err)
+	}
+
+	// A checkpoint from before paged fetches lists the done external IDs
+	if err := os.WriteFile(filepath.Join(dir, "project-5-checkpoint.json"), []byte(`["1", "2"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.Checkpoint(5)
+	if err != nil || got == nil || got.Cursor != "" || got.Pulled != 0 || len(got.Done) != 2 || !got.Done["1"] {
+		t.Errorf("Expected the old checkpoint read, got %+v (%v)", got, err)
+	}
+}
+
+func TestUnchangedSinceSync(t *testing.T) {
+	engine, server, _ := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+
+	task := &types.Task{ExternalID: "task-1", Title: "Task", Status: "active"}
+	if engine.unchangedSinceSync(1, task) {
+		t.Error("Expected false without a snapshot")
+	}
+
+	if err := store.Put(1, task); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	touched := *task
+	touched.UpdatedAt = time.Now()
+	if !engine.unchangedSinceSync(1, &touched) {
+		t.Error("Expected true when only UpdatedAt changed")
+	}
+
+	touched.Title = "Renamed"
+	if engine.unchangedSinceSync(1, &touched) {
+		t.Error("Expected false when a synced field changed")
+	}
+}
+
+func TestSyncPullSavesSnapshot(t *testing.T) {
+	engine, server, mockPlugin := setupTestEngine(t)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	engine.WithSnapshots(store)
+
+	mockPlugin.AddProject("test-repo", &types.Project{ID: 1, ExternalID: "test-repo", Name: "Test Project"})
+	mockPlugin.AddTask("task-1", &types.Task{
+		ExternalID: "task-1",
+		Title:      "Pulled",
+		ProjectID:  1,
+		Status:     "active",
+		UpdatedAt:  time.Now(),
+	})
+
+	if _, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{1}}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	snapshot, err := store.Get(1, "task-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if snapshot == nil || snapshot.Title != "Pulled" {
+		t.Errorf("Expected snapshot of pulled task, got %+v", snapshot)
+	}
+}
+
+// untimedPlugin is a mock plugin whose tasks' UpdatedAt misses edits. Like
+// Todoist, it returns every task whatever the last sync time.
+type untimedPlugin struct {
+	*plugin.MockPlugin
+}
+
+func (p *untimedPlugin) TasksUntimed() bool { return true }
+
+func (p *untimedPlugin) FetchTasks(ctx context.Context, projectExternalID *string, since *time.Time) ([]*types.Task, error) {
+	return p.MockPlugin.FetchTasks(ctx, projectExternalID, nil)
+}
+
+func TestSyncPullsUntimedEditsBySnapshot(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	server := testsupport.NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "test-system", Name: "Test"})
+	project := server.AddProject(types.Project{Name: "Inbox", SystemID: system.ID, ExternalID: "test-repo"})
+	task := server.AddTask(types.Task{Title: "Buy milk", ProjectID: project.ID, ExternalID: "7"})
+
+	mock := &untimedPlugin{plugin.NewMockPlugin("test-system")}
+	mock.AddProject("test-repo", &types.Project{ID: project.ID, ExternalID: "test-repo", Name: "Inbox"})
+	created := testsupport.DefaultNow.Add(-24 * time.Hour)
+
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mock })
+	engine := NewEngine(server.Client(), reg).WithSnapshots(NewFileSnapshotStore(t.TempDir()))
+
+	strategy := StrategyPull
+	sync := func(title string) *Result {
+		t.Helper()
+		// UpdatedAt stays at the creation time, however the task changes
+		mock.AddTask("7", &types.Task{ExternalID: "7", Title: title, ProjectID: project.ID, Status: "active", CreatedAt: created, UpdatedAt: created})
+		result, err := engine.Sync(context.Background(), Options{ProjectIDs: []int{project.ID}, StrategyOverride: &strategy})
+		if err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		return result
+	}
+
+	// The first sync has no base to compare with, so it records one
+	if result := sync("Buy milk"); result.TotalUpdated != 0 {
+		t.Fatalf("Expected nothing updated, got %+v", result.ProjectResults)
+	}
+
+	// An edit made after creation is pulled though UpdatedAt is older
+	if result := sync("Buy oat milk"); result.TotalUpdated != 1 {
+		t.Fatalf("Expected the edit pulled, got %+v", result.ProjectResults)
+	}
+	if got := server.Task(task.ID).Title; got != "Buy oat milk" {
+		t.Errorf("Expected title %q, got %q", "Buy oat milk", got)
+	}
+
+	// Nothing changed since, so nothing is pulled again
+	if result := sync("Buy oat milk"); result.TotalUpdated != 0 {
+		t.Errorf("Expected nothing updated, got %+v", result.ProjectResults)
+	}
+}
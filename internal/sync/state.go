@@ -0,0 +1,65 @@
+package sync
+
+import "github.com/evcraddock/todu.sh/pkg/types"
+
+// State describes how a Todu task compares with its external counterpart.
+type State string
+
+const (
+	// StateInSync means neither side changed since the last sync.
+	StateInSync State = "in-sync"
+
+	// StateLocalAhead means the Todu task changed since the last sync.
+	StateLocalAhead State = "local-ahead"
+
+	// StateRemoteAhead means the external task changed since the last sync.
+	StateRemoteAhead State = "remote-ahead"
+
+	// StateConflict means both sides changed since the last sync.
+	StateConflict State = "conflict"
+
+	// StateNotSynced means the task has no external counterpart.
+	StateNotSynced State = "not-synced"
+
+	// StateUnknown means there is no snapshot to compare against.
+	StateUnknown State = "unknown"
+)
+
+// TaskState computes the sync state of a task from its last-synced snapshot
+// base and, if it was fetched, the external task remote. Without remote,
+// changes in the external system aren't detected. base and remote may be nil.
+//
+// Without a snapshot, a task edited after its last push is reported as local
+// ahead, and a task matching remote as in sync.
+func TaskState(local, base, remote *types.Task) State {
+	if local.ExternalID == "" {
+		return StateNotSynced
+	}
+
+	if base == nil {
+		switch {
+		case local.LastPushedAt != nil && local.UpdatedAt.After(*local.LastPushedAt):
+			return StateLocalAhead
+		case remote != nil && len(DiffTasks(local, remote)) == 0:
+			return StateInSync
+		default:
+			return StateUnknown
+		}
+	}
+
+	localChanged := len(DiffTasks(base, local)) > 0
+	remoteChanged := remote != nil && len(DiffTasks(base, remote)) > 0
+	switch {
+	case localChanged && remoteChanged:
+		if len(DiffTasks(local, remote)) == 0 {
+			return StateInSync
+		}
+		return StateConflict
+	case localChanged:
+		return StateLocalAhead
+	case remoteChanged:
+		return StateRemoteAhead
+	default:
+		return StateInSync
+	}
+}
@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestTaskState(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	task := func(title string) *types.Task {
+		return &types.Task{ExternalID: "5", Title: title, Status: "active", UpdatedAt: now}
+	}
+	pushedBefore := task("Edited")
+	pushedBefore.LastPushedAt = &earlier
+
+	tests := []struct {
+		name   string
+		local  *types.Task
+		base   *types.Task
+		remote *types.Task
+		want   State
+	}{
+		{name: "not synced", local: &types.Task{Title: "Local"}, want: StateNotSynced},
+		{name: "unchanged", local: task("A"), base: task("A"), want: StateInSync},
+		{name: "local edit", local: task("B"), base: task("A"), want: StateLocalAhead},
+		{name: "remote edit", local: task("A"), base: task("A"), remote: task("B"), want: StateRemoteAhead},
+		{name: "both edited", local: task("B"), base: task("A"), remote: task("C"), want: StateConflict},
+		{name: "same edit on both sides", local: task("B"), base: task("A"), remote: task("B"), want: StateInSync},
+		{name: "local edit with remote unchanged", local: task("B"), base: task("A"), remote: task("A"), want: StateLocalAhead},
+		{name: "no snapshot", local: task("A"), want: StateUnknown},
+		{name: "no snapshot, edited after push", local: pushedBefore, want: StateLocalAhead},
+		{name: "no snapshot, matches remote", local: task("A"), remote: task("A"), want: StateInSync},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaskState(tt.local, tt.base, tt.remote); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// DivergenceKind identifies how a synced task diverges from its external
+// counterpart.
+type DivergenceKind string
+
+const (
+	// DivergenceChanged means one or more synced fields differ.
+	DivergenceChanged DivergenceKind = "changed"
+
+	// DivergenceMissing means the external task no longer exists.
+	DivergenceMissing DivergenceKind = "missing"
+)
+
+// Verification is the result of comparing synced tasks with their external
+// counterparts, produced by Engine.Verify.
+type Verification struct {
+	CheckedAt time.Time             `json:"checked_at"`
+	Projects  []ProjectVerification `json:"projects"`
+}
+
+// ProjectVerification holds the verification results for a single project.
+type ProjectVerification struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+
+	// Checked is the number of synced and linked tasks compared.
+	Checked int `json:"checked"`
+
+	Divergences []Divergence `json:"divergences"`
+
+	// Errors lists tasks, or the whole project, that could not be checked.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Divergence describes a task that differs from its external counterpart.
+type Divergence struct {
+	Kind       DivergenceKind `json:"kind"`
+	TaskID     int            `json:"task_id"`
+	ExternalID string         `json:"external_id"`
+	Title      string         `json:"title"`
+
+	// Linked is true if the external task is linked to the task rather than
+	// being its own external ID.
+	Linked bool `json:"linked,omitempty"`
+
+	// State tells which side changed since the last sync, if known.
+	State State `json:"state,omitempty"`
+
+	// Changes lists the differing fields, with Local being the Todu value.
+	Changes []FieldDiff `json:"changes,omitempty"`
+}
+
+// DivergenceCount returns the number of divergent tasks across all projects.
+func (v *Verification) DivergenceCount() int {
+	count := 0
+	for _, pv := range v.Projects {
+		count += len(pv.Divergences)
+	}
+	return count
+}
+
+// ErrorCount returns the number of tasks and projects that could not be checked.
+func (v *Verification) ErrorCount() int {
+	count := 0
+	for _, pv := range v.Projects {
+		count += len(pv.Errors)
+	}
+	return count
+}
+
+// Verify compares every synced and linked task with its external counterpart
+// and reports divergences. Nothing is changed in Todu, the external systems,
+// or the sync snapshots. Projects are selected like Sync, and options.Filter
+// limits which tasks are checked.
+func (e *Engine) Verify(ctx context.Context, options Options) (*Verification, error) {
+	projects, err := e.getProjectsToSync(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	v := &Verification{
+		CheckedAt: time.Now(),
+		Projects:  make([]ProjectVerification, 0, len(projects)),
+	}
+	for _, project := range projects {
+		v.Projects = append(v.Projects, e.verifyProject(ctx, project, options))
+	}
+
+	return v, nil
+}
+
+// verifyProject compares the tasks synced or linked with a single project.
+func (e *Engine) verifyProject(ctx context.Context, project *types.Project, options Options) ProjectVerification {
+	pv := ProjectVerification{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Divergences: []Divergence{},
+	}
+
+	p, err := e.createPlugin(ctx, project)
+	if err != nil {
+		pv.Errors = append(pv.Errors, err.Error())
+		return pv
+	}
+
+	externalTasks, err := p.FetchTasks(ctx, &project.ExternalID, nil)
+	if err != nil {
+		pv.Errors = append(pv.Errors, fmt.Sprintf("failed to fetch external tasks: %v", err))
+		return pv
+	}
+	remoteByID := make(map[string]*types.Task, len(externalTasks))
+	for _, task := range externalTasks {
+		remoteByID[task.ExternalID] = task
+	}
+
+	toduTasks, err := e.apiClient.ListTasks(ctx, &api.TaskListOptions{ProjectID: &project.ID})
+	if err != nil {
+		pv.Errors = append(pv.Errors, fmt.Sprintf("failed to fetch Todu tasks: %v", err))
+		return pv
+	}
+
+	// Each external ID is checked against the task it is synced with
+	pairs := make(map[string]int)
+	for _, task := range toduTasks {
+		if task.ExternalID != "" {
+			pairs[task.ExternalID] = task.ID
+		}
+	}
+	links := e.projectLinks(project.ID)
+	for externalID, taskID := range links {
+		pairs[externalID] = taskID
+	}
+
+	externalIDs := make([]string, 0, len(pairs))
+	for externalID := range pairs {
+		externalIDs = append(externalIDs, externalID)
+	}
+	sort.Strings(externalIDs)
+
+	for _, externalID := range externalIDs {
+		taskID := pairs[externalID]
+		_, linked := links[externalID]
+
+		// Task lists don't include descriptions, so each task is fetched
+		task, err := e.apiClient.GetTask(ctx, taskID)
+		if err != nil {
+			pv.Errors = append(pv.Errors, fmt.Sprintf("failed to fetch task #%d: %v", taskID, err))
+			continue
+		}
+		if !options.Filter.Matches(task) {
+			continue
+		}
+		pv.Checked++
+
+		divergence := Divergence{TaskID: task.ID, ExternalID: externalID, Title: task.Title, Linked: linked}
+
+		remote, ok := remoteByID[externalID]
+		if !ok {
+			// Some systems leave old or closed tasks out of task lists
+			remote, err = p.FetchTask(ctx, &project.ExternalID, externalID)
+			if errors.Is(err, plugin.ErrNotFound) {
+				divergence.Kind = DivergenceMissing
+				pv.Divergences = append(pv.Divergences, divergence)
+				continue
+			}
+			if err != nil {
+				pv.Errors = append(pv.Errors, fmt.Sprintf("failed to fetch external task %q: %v", externalID, err))
+				continue
+			}
+		}
+
+		if linked {
+			// Link state is tracked by snapshot only, never by push time
+			task = snapshotOf(task)
+			task.ExternalID = externalID
+		}
+
+		changes := DiffTasks(task, remote)
+		if len(changes) == 0 {
+			continue
+		}
+
+		var base *types.Task
+		if e.snapshots != nil {
+			base, _ = e.snapshots.Get(project.ID, externalID)
+		}
+
+		divergence.Kind = DivergenceChanged
+		divergence.State = TaskState(task, base, remote)
+		divergence.Changes = changes
+		pv.Divergences = append(pv.Divergences, divergence)
+	}
+
+	return pv
+}
@@ -0,0 +1,95 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestVerify(t *testing.T) {
+	t.Setenv("TODU_PLUGIN_TEST-SYSTEM_TOKEN", "test-token")
+
+	mockPlugin := plugin.NewMockPlugin("test-system")
+	reg := registry.New()
+	_ = reg.Register("test-system", func() plugin.Plugin { return mockPlugin })
+
+	tasks := map[string]*types.Task{
+		"1":  {ID: 1, ProjectID: 2, ExternalID: "10", Title: "In sync", Status: "active"},
+		"2":  {ID: 2, ProjectID: 2, ExternalID: "20", Title: "Renamed locally", Status: "active"},
+		"3":  {ID: 3, ProjectID: 2, ExternalID: "30", Title: "Deleted remotely", Status: "active"},
+		"4":  {ID: 4, ProjectID: 2, Title: "Never synced", Status: "active"},
+		"42": {ID: 42, ProjectID: 1, ExternalID: "17", Title: "Linked", Status: "done"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.Project{ID: 2, Name: "Repo", SystemID: 1, ExternalID: "repo"})
+	})
+	mux.HandleFunc("GET /api/v1/systems/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&types.System{ID: 1, Identifier: "test-system"})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		items := []*types.Task{tasks["1"], tasks["2"], tasks["3"], tasks["4"]}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total": len(items)})
+	})
+	mux.HandleFunc("GET /api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(tasks[r.PathValue("id")])
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	store := NewFileSnapshotStore(t.TempDir())
+	if err := store.Link(2, "5", 42); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	if err := store.Put(2, &types.Task{ExternalID: "20", Title: "Original", Status: "active"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	mockPlugin.AddProject("repo", &types.Project{ID: 2, ExternalID: "repo"})
+	mockPlugin.AddTask("10", &types.Task{ExternalID: "10", ProjectID: 2, Title: "In sync", Status: "active"})
+	mockPlugin.AddTask("20", &types.Task{ExternalID: "20", ProjectID: 2, Title: "Original", Status: "active"})
+	mockPlugin.AddTask("5", &types.Task{ExternalID: "5", ProjectID: 2, Title: "Linked", Status: "active"})
+
+	engine := NewEngine(api.NewClient(server.URL, ""), reg).WithSnapshots(store)
+	v, err := engine.Verify(context.Background(), Options{ProjectIDs: []int{2}})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if v.ErrorCount() != 0 {
+		t.Fatalf("Expected no errors, got %v", v.Projects[0].Errors)
+	}
+
+	pv := v.Projects[0]
+	if pv.Checked != 4 {
+		t.Errorf("Expected 4 tasks checked, got %d", pv.Checked)
+	}
+	if v.DivergenceCount() != 3 {
+		t.Fatalf("Expected 3 divergences, got %+v", pv.Divergences)
+	}
+
+	renamed, missing, linked := pv.Divergences[0], pv.Divergences[1], pv.Divergences[2]
+	if renamed.TaskID != 2 || renamed.Kind != DivergenceChanged || renamed.State != StateLocalAhead || renamed.Changes[0].Field != "title" {
+		t.Errorf("Unexpected divergence for renamed task: %+v", renamed)
+	}
+	if missing.TaskID != 3 || missing.Kind != DivergenceMissing {
+		t.Errorf("Unexpected divergence for deleted task: %+v", missing)
+	}
+	if linked.TaskID != 42 || !linked.Linked || linked.ExternalID != "5" || linked.Changes[0].Field != "status" {
+		t.Errorf("Unexpected divergence for linked task: %+v", linked)
+	}
+
+	if base, _ := store.Get(2, "5"); base != nil {
+		t.Error("Expected verify not to record snapshots")
+	}
+}
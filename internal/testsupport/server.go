@@ -0,0 +1,1305 @@
+// Package testsupport provides an in-memory fake of the todu API, so
+// commands and sync behaviors can be tested end to end without a live
+// server.
+//
+// A Server answers the endpoints the api package uses for systems,
+// projects, tasks, comments, labels, and recurring templates, with the
+// same filters and pagination as the real API. Tests seed it with the Add
+// methods, point a client or the todu config at its URL, and inspect the
+// result with the accessor methods.
+package testsupport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/internal/recurring"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// DefaultNow is the time a new Server starts its clock at.
+var DefaultNow = time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+// Page sizes, as the real API: a list without a limit gets the default,
+// and larger limits are capped at the maximum.
+const (
+	DefaultPageSize = 100
+	MaxTaskPageSize = 500
+)
+
+// Server is a fake todu API. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL to configure clients with.
+	URL string
+
+	mu        sync.Mutex
+	now       time.Time
+	ids       map[string]int
+	systems   map[int]*types.System
+	projects  map[int]*types.Project
+	tasks     map[int]*types.Task
+	comments  map[int]*types.Comment
+	labels    map[int]*types.Label
+	templates map[int]*types.RecurringTaskTemplate
+	requests  []string
+}
+
+// NewServer starts a Server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{
+		now:       DefaultNow,
+		ids:       make(map[string]int),
+		systems:   make(map[int]*types.System),
+		projects:  make(map[int]*types.Project),
+		tasks:     make(map[int]*types.Task),
+		comments:  make(map[int]*types.Comment),
+		labels:    make(map[int]*types.Label),
+		templates: make(map[int]*types.RecurringTaskTemplate),
+	}
+	srv := httptest.NewServer(s.routes())
+	t.Cleanup(srv.Close)
+	s.URL = srv.URL
+	return s
+}
+
+// Client returns an API client for the server.
+func (s *Server) Client() *api.Client {
+	return api.NewClient(s.URL, "")
+}
+
+// Now returns the server's clock, which stamps created and updated times.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// SetNow sets the server's clock.
+func (s *Server) SetNow(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now.UTC()
+}
+
+// Requests returns the requests the server has answered, as "METHOD
+// /path?query", in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// nextID returns the next ID for a kind of resource. Each kind is numbered
+// from 1, so seeded data has predictable IDs.
+func (s *Server) nextID(kind string) int {
+	s.ids[kind]++
+	return s.ids[kind]
+}
+
+// reserveID makes sure IDs given to later resources of a kind come after id.
+func (s *Server) reserveID(kind string, id int) {
+	if id > s.ids[kind] {
+		s.ids[kind] = id
+	}
+}
+
+// Seeding
+
+// AddSystem adds a system. A zero ID or times are filled in.
+func (s *Server) AddSystem(system types.System) *types.System {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.addSystem(system))
+}
+
+func (s *Server) addSystem(system types.System) *types.System {
+	system.ID = s.assignID("system", system.ID)
+	s.stamp(&system.CreatedAt, &system.UpdatedAt)
+	s.systems[system.ID] = &system
+	return &system
+}
+
+// AddProject adds a project. A zero ID or times are filled in, and the
+// status and sync strategy default to active and bidirectional.
+func (s *Server) AddProject(project types.Project) *types.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.addProject(project))
+}
+
+func (s *Server) addProject(project types.Project) *types.Project {
+	project.ID = s.assignID("project", project.ID)
+	if project.Status == "" {
+		project.Status = "active"
+	}
+	if project.SyncStrategy == "" {
+		project.SyncStrategy = "bidirectional"
+	}
+	s.stamp(&project.CreatedAt, &project.UpdatedAt)
+	s.projects[project.ID] = &project
+	return &project
+}
+
+// AddTask adds a task. A zero ID or times are filled in, the status
+// defaults to active, and labels are given IDs by name.
+func (s *Server) AddTask(task types.Task) *types.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.addTask(task))
+}
+
+func (s *Server) addTask(task types.Task) *types.Task {
+	task.ID = s.assignID("task", task.ID)
+	if task.Status == "" {
+		task.Status = "active"
+	}
+	task.Labels = s.labelsNamed(labelNames(task.Labels))
+	task.Assignees = s.assignees(assigneeNames(task.Assignees))
+	s.stamp(&task.CreatedAt, &task.UpdatedAt)
+	s.tasks[task.ID] = &task
+	return &task
+}
+
+// AddComment adds a comment, or a journal entry if it has no task. A zero
+// ID or times are filled in.
+func (s *Server) AddComment(comment types.Comment) *types.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.addComment(comment))
+}
+
+func (s *Server) addComment(comment types.Comment) *types.Comment {
+	comment.ID = s.assignID("comment", comment.ID)
+	s.stamp(&comment.CreatedAt, &comment.UpdatedAt)
+	s.comments[comment.ID] = &comment
+	return &comment
+}
+
+// AddTemplate adds a recurring template. A zero ID or times are filled in,
+// the timezone defaults to UTC, and the type to task.
+func (s *Server) AddTemplate(template types.RecurringTaskTemplate) *types.RecurringTaskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.addTemplate(template))
+}
+
+func (s *Server) addTemplate(template types.RecurringTaskTemplate) *types.RecurringTaskTemplate {
+	template.ID = s.assignID("template", template.ID)
+	if template.Timezone == "" {
+		template.Timezone = "UTC"
+	}
+	if template.TemplateType == "" {
+		template.TemplateType = "task"
+	}
+	template.Labels = s.labelsNamed(labelNames(template.Labels))
+	template.Assignees = s.assignees(assigneeNames(template.Assignees))
+	s.stamp(&template.CreatedAt, &template.UpdatedAt)
+	s.templates[template.ID] = &template
+	return &template
+}
+
+func (s *Server) assignID(kind string, id int) int {
+	if id == 0 {
+		return s.nextID(kind)
+	}
+	s.reserveID(kind, id)
+	return id
+}
+
+func (s *Server) stamp(created, updated *time.Time) {
+	if created.IsZero() {
+		*created = s.now
+	}
+	if updated.IsZero() {
+		*updated = *created
+	}
+}
+
+// labelsNamed returns the labels with the given names, creating any that
+// don't exist yet, as the API does for task labels.
+func (s *Server) labelsNamed(names []string) []types.Label {
+	var labels []types.Label
+	for _, name := range names {
+		var label *types.Label
+		for _, existing := range s.labels {
+			if existing.Name == name {
+				label = existing
+				break
+			}
+		}
+		if label == nil {
+			label = &types.Label{ID: s.nextID("label"), Name: name}
+			s.labels[label.ID] = label
+		}
+		labels = append(labels, *label)
+	}
+	return labels
+}
+
+func (s *Server) assignees(names []string) []types.Assignee {
+	var assignees []types.Assignee
+	for _, name := range names {
+		assignees = append(assignees, types.Assignee{ID: s.nextID("assignee"), Name: name})
+	}
+	return assignees
+}
+
+// Accessors
+
+// Task returns a task, or nil if it doesn't exist.
+func (s *Server) Task(id int) *types.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.tasks[id])
+}
+
+// Tasks returns every task, by ID.
+func (s *Server) Tasks() []*types.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sorted(s.tasks)
+}
+
+// Projects returns every project, by ID.
+func (s *Server) Projects() []*types.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sorted(s.projects)
+}
+
+// Comments returns every comment and journal entry, by ID.
+func (s *Server) Comments() []*types.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sorted(s.comments)
+}
+
+// Templates returns every recurring template, by ID.
+func (s *Server) Templates() []*types.RecurringTaskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sorted(s.templates)
+}
+
+// Routes
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/systems/{$}", s.listSystems)
+	mux.HandleFunc("POST /api/v1/systems/{$}", s.createSystem)
+	mux.HandleFunc("GET /api/v1/systems/{id}", s.getSystem)
+	mux.HandleFunc("PUT /api/v1/systems/{id}", s.updateSystem)
+	mux.HandleFunc("DELETE /api/v1/systems/{id}", s.deleteSystem)
+
+	mux.HandleFunc("GET /api/v1/projects/{$}", s.listProjects)
+	mux.HandleFunc("POST /api/v1/projects/{$}", s.createProject)
+	mux.HandleFunc("GET /api/v1/projects/{id}", s.getProject)
+	mux.HandleFunc("PUT /api/v1/projects/{id}", s.updateProject)
+	mux.HandleFunc("DELETE /api/v1/projects/{id}", s.deleteProject)
+
+	mux.HandleFunc("GET /api/v1/tasks/{$}", s.listTasks)
+	mux.HandleFunc("POST /api/v1/tasks/{$}", s.createTask)
+	mux.HandleFunc("GET /api/v1/tasks/{id}", s.getTask)
+	mux.HandleFunc("PUT /api/v1/tasks/{id}", s.updateTask)
+	mux.HandleFunc("DELETE /api/v1/tasks/{id}", s.deleteTask)
+	mux.HandleFunc("GET /api/v1/tasks/{id}/comments", s.listTaskComments)
+	mux.HandleFunc("POST /api/v1/tasks/{id}/comments", s.createComment)
+
+	mux.HandleFunc("GET /api/v1/comments", s.listComments)
+	mux.HandleFunc("POST /api/v1/comments", s.createComment)
+	mux.HandleFunc("GET /api/v1/comments/{id}", s.getComment)
+	mux.HandleFunc("PATCH /api/v1/comments/{id}", s.updateComment)
+	mux.HandleFunc("DELETE /api/v1/comments/{id}", s.deleteComment)
+
+	mux.HandleFunc("GET /api/v1/labels/{$}", s.listLabels)
+	mux.HandleFunc("PATCH /api/v1/labels/{id}", s.updateLabel)
+
+	mux.HandleFunc("GET /api/v1/recurring-templates/{$}", s.listTemplates)
+	mux.HandleFunc("POST /api/v1/recurring-templates/{$}", s.createTemplate)
+	mux.HandleFunc("POST /api/v1/recurring-templates/process-due", s.processDue)
+	mux.HandleFunc("GET /api/v1/recurring-templates/{id}", s.getTemplate)
+	mux.HandleFunc("PATCH /api/v1/recurring-templates/{id}", s.updateTemplate)
+	mux.HandleFunc("DELETE /api/v1/recurring-templates/{id}", s.deleteTemplate)
+
+	// Anything else, such as auth/key, is not found, as on servers
+	// without that feature
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not found")
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Systems
+
+func (s *Server) listSystems(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sorted(s.systems))
+}
+
+func (s *Server) getSystem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFound(w, s.systems[pathID(r)])
+}
+
+func (s *Server) createSystem(w http.ResponseWriter, r *http.Request) {
+	var create types.SystemCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, system := range s.systems {
+		if system.Identifier == create.Identifier {
+			writeError(w, http.StatusConflict, "System already exists")
+			return
+		}
+	}
+	system := s.addSystem(types.System{Identifier: create.Identifier, Name: create.Name, URL: create.URL, Metadata: create.Metadata})
+	writeJSON(w, http.StatusCreated, system)
+}
+
+func (s *Server) updateSystem(w http.ResponseWriter, r *http.Request) {
+	var update types.SystemUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	system := s.systems[pathID(r)]
+	if system == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&system.Name, update.Name)
+	if update.URL != nil {
+		system.URL = update.URL
+	}
+	if update.Metadata != nil {
+		system.Metadata = update.Metadata
+	}
+	system.UpdatedAt = s.now
+	writeJSON(w, http.StatusOK, system)
+}
+
+func (s *Server) deleteSystem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.systems[id] == nil {
+		writeNotFound(w)
+		return
+	}
+	delete(s.systems, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Projects
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var projects []*types.Project
+	for _, project := range sorted(s.projects) {
+		if id := query.Get("system_id"); id != "" && strconv.Itoa(project.SystemID) != id {
+			continue
+		}
+		if !matchesAny(query["status"], project.Status) || !matchesAny(query["priority"], deref(project.Priority)) {
+			continue
+		}
+		projects = append(projects, project)
+	}
+	writeJSON(w, http.StatusOK, nonNil(projects))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFound(w, s.projects[pathID(r)])
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var create types.ProjectCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.systems[create.SystemID] == nil {
+		writeError(w, http.StatusBadRequest, "System not found")
+		return
+	}
+	project := s.addProject(types.Project{
+		Name:         create.Name,
+		Description:  create.Description,
+		SystemID:     create.SystemID,
+		ExternalID:   create.ExternalID,
+		Status:       create.Status,
+		Priority:     create.Priority,
+		SyncStrategy: create.SyncStrategy,
+	})
+	writeJSON(w, http.StatusCreated, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var update types.ProjectUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := s.projects[pathID(r)]
+	if project == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&project.Name, update.Name)
+	set(&project.Status, update.Status)
+	set(&project.SyncStrategy, update.SyncStrategy)
+	if update.Description != nil {
+		project.Description = update.Description
+	}
+	if update.Priority != nil {
+		project.Priority = update.Priority
+	}
+	if update.LastSyncedAt != nil {
+		project.LastSyncedAt = update.LastSyncedAt
+	}
+	project.UpdatedAt = s.now
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.projects[id] == nil {
+		writeNotFound(w)
+		return
+	}
+
+	var taskIDs []int
+	for _, task := range s.tasks {
+		if task.ProjectID == id {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+	if len(taskIDs) > 0 && r.URL.Query().Get("cascade") != "true" {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Project has %d tasks; use cascade=true to delete them", len(taskIDs)))
+		return
+	}
+	for _, taskID := range taskIDs {
+		s.removeTask(taskID)
+	}
+	delete(s.projects, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Tasks
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	skip, limit, ok := pageParams(w, query, MaxTaskPageSize)
+	if !ok {
+		return
+	}
+	filter, err := s.taskFilter(query)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []*types.Task
+	for _, task := range sorted(s.tasks) {
+		if filter(task) {
+			matched = append(matched, task)
+		}
+	}
+	writeJSON(w, http.StatusOK, &api.TasksResponse{
+		Items: nonNil(page(matched, skip, limit)),
+		Total: len(matched),
+		Skip:  skip,
+		Limit: limit,
+	})
+}
+
+// taskFilter returns a function reporting whether a task matches the
+// filters in query. It is called with s.mu held.
+func (s *Server) taskFilter(query map[string][]string) (func(*types.Task) bool, error) {
+	get := func(key string) string {
+		if values := query[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	var times []func(*types.Task) bool
+	for _, f := range []struct {
+		key    string
+		field  func(*types.Task) *time.Time
+		before bool
+	}{
+		{"updated_after", func(t *types.Task) *time.Time { return &t.UpdatedAt }, false},
+		{"updated_before", func(t *types.Task) *time.Time { return &t.UpdatedAt }, true},
+		{"due_after", func(t *types.Task) *time.Time { return t.DueDate }, false},
+		{"due_before", func(t *types.Task) *time.Time { return t.DueDate }, true},
+		{"scheduled_before", func(t *types.Task) *time.Time { return t.ScheduledDate }, true},
+	} {
+		value := get(f.key)
+		if value == "" {
+			continue
+		}
+		bound, err := parseTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", f.key, err)
+		}
+		times = append(times, func(t *types.Task) bool {
+			at := f.field(t)
+			if at == nil {
+				return false
+			}
+			if f.before {
+				return !at.After(bound)
+			}
+			return !at.Before(bound)
+		})
+	}
+
+	for _, key := range []string{"scheduled_date", "scheduled_after"} {
+		if value := get(key); value != "" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+		}
+	}
+
+	return func(t *types.Task) bool {
+		if id := get("project_id"); id != "" && strconv.Itoa(t.ProjectID) != id {
+			return false
+		}
+		if id := get("template_id"); id != "" && (t.TemplateID == nil || strconv.Itoa(*t.TemplateID) != id) {
+			return false
+		}
+		if !matchesAny(splitList(get("status")), t.Status) || !matchesAny(splitList(get("priority")), deref(t.Priority)) {
+			return false
+		}
+		if len(query["project_status"]) > 0 || len(query["project_priority"]) > 0 {
+			project := s.projects[t.ProjectID]
+			if project == nil || !matchesAny(query["project_status"], project.Status) || !matchesAny(query["project_priority"], deref(project.Priority)) {
+				return false
+			}
+		}
+
+		scheduled := ""
+		if t.ScheduledDate != nil {
+			scheduled = t.ScheduledDate.UTC().Format("2006-01-02")
+		}
+		if date := get("scheduled_date"); date != "" && scheduled != date {
+			return false
+		}
+		if date := get("scheduled_after"); date != "" && (scheduled == "" || scheduled < date) {
+			return false
+		}
+		for _, match := range times {
+			if !match(t) {
+				return false
+			}
+		}
+
+		for _, label := range query["label"] {
+			if !t.HasLabel(label) {
+				return false
+			}
+		}
+		if assignee := get("assignee"); assignee != "" && !slices.ContainsFunc(t.Assignees, func(a types.Assignee) bool {
+			return strings.EqualFold(a.Name, assignee)
+		}) {
+			return false
+		}
+		if search := get("search"); search != "" && !containsFold(t.Title, search) && !containsFold(deref(t.Description), search) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFound(w, s.tasks[pathID(r)])
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var create types.TaskCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.projects[create.ProjectID] == nil {
+		writeError(w, http.StatusBadRequest, "Project not found")
+		return
+	}
+	if create.Title == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Title is required")
+		return
+	}
+	task := s.addTask(types.Task{
+		ExternalID:    create.ExternalID,
+		SourceURL:     create.SourceURL,
+		Title:         create.Title,
+		Description:   create.Description,
+		ProjectID:     create.ProjectID,
+		Status:        create.Status,
+		Priority:      create.Priority,
+		DueDate:       create.DueDate,
+		TemplateID:    create.TemplateID,
+		ScheduledDate: create.ScheduledDate,
+		Labels:        namedLabels(create.Labels),
+		Assignees:     namedAssignees(create.Assignees),
+	})
+	writeJSON(w, http.StatusCreated, task)
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	var update types.TaskUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := s.tasks[pathID(r)]
+	if task == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&task.ExternalID, update.ExternalID)
+	set(&task.Title, update.Title)
+	set(&task.Status, update.Status)
+	for _, field := range []struct {
+		dst **string
+		src *string
+	}{{&task.SourceURL, update.SourceURL}, {&task.Description, update.Description}, {&task.Priority, update.Priority}} {
+		if field.src != nil {
+			*field.dst = field.src
+		}
+	}
+	for _, field := range []struct {
+		dst **time.Time
+		src *time.Time
+	}{{&task.DueDate, update.DueDate}, {&task.ScheduledDate, update.ScheduledDate}, {&task.LastPushedAt, update.LastPushedAt}} {
+		if field.src != nil {
+			*field.dst = field.src
+		}
+	}
+	if update.TemplateID != nil {
+		task.TemplateID = update.TemplateID
+	}
+	// Omitted lists are left alone; empty ones clear them
+	if update.Labels != nil {
+		task.Labels = s.labelsNamed(update.Labels)
+	}
+	if update.Assignees != nil {
+		task.Assignees = s.assignees(update.Assignees)
+	}
+	task.UpdatedAt = s.now
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.tasks[id] == nil {
+		writeNotFound(w)
+		return
+	}
+	s.removeTask(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeTask deletes a task and its comments.
+func (s *Server) removeTask(id int) {
+	for commentID, comment := range s.comments {
+		if comment.TaskID != nil && *comment.TaskID == id {
+			delete(s.comments, commentID)
+		}
+	}
+	delete(s.tasks, id)
+}
+
+// Comments
+
+func (s *Server) listTaskComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.tasks[id] == nil {
+		writeNotFound(w)
+		return
+	}
+	var comments []*types.Comment
+	for _, comment := range s.sortedComments() {
+		if comment.TaskID != nil && *comment.TaskID == id {
+			comments = append(comments, comment)
+		}
+	}
+	writeJSON(w, http.StatusOK, nonNil(comments))
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	skip, limit, ok := pageParams(w, query, DefaultPageSize)
+	if !ok {
+		return
+	}
+	var after, before time.Time
+	for _, bound := range []struct {
+		key string
+		dst *time.Time
+	}{{"created_after", &after}, {"created_before", &before}} {
+		if value := query.Get(bound.key); value != "" {
+			t, err := parseTime(value)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid %s: %v", bound.key, err))
+				return
+			}
+			*bound.dst = t
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []*types.Comment
+	for _, comment := range s.sortedComments() {
+		switch query.Get("type") {
+		case "journal":
+			if comment.TaskID != nil {
+				continue
+			}
+		case "comment":
+			if comment.TaskID == nil {
+				continue
+			}
+		}
+		if !after.IsZero() && comment.CreatedAt.Before(after) {
+			continue
+		}
+		if !before.IsZero() && comment.CreatedAt.After(before) {
+			continue
+		}
+		if search := query.Get("search"); search != "" && !containsFold(comment.Content, search) {
+			continue
+		}
+		matched = append(matched, comment)
+	}
+	writeJSON(w, http.StatusOK, nonNil(page(matched, skip, limit)))
+}
+
+// sortedComments returns the comments oldest first, as the API lists them.
+func (s *Server) sortedComments() []*types.Comment {
+	comments := sorted(s.comments)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments
+}
+
+func (s *Server) getComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFound(w, s.comments[pathID(r)])
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	var create types.CommentCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if r.PathValue("id") != "" {
+		id := pathID(r)
+		create.TaskID = &id
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if create.TaskID != nil && s.tasks[*create.TaskID] == nil {
+		writeNotFound(w)
+		return
+	}
+	comment := s.addComment(types.Comment{
+		TaskID:     create.TaskID,
+		ExternalID: create.ExternalID,
+		Content:    create.Content,
+		Author:     create.Author,
+	})
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request) {
+	var update types.CommentUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comment := s.comments[pathID(r)]
+	if comment == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&comment.ExternalID, update.ExternalID)
+	set(&comment.Content, update.Content)
+	if update.Reactions != nil {
+		comment.Reactions = *update.Reactions
+	}
+	comment.UpdatedAt = s.now
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.comments[id] == nil {
+		writeNotFound(w)
+		return
+	}
+	delete(s.comments, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Labels
+
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, sorted(s.labels))
+}
+
+func (s *Server) updateLabel(w http.ResponseWriter, r *http.Request) {
+	var update types.LabelUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	label := s.labels[pathID(r)]
+	if label == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&label.Color, update.Color)
+	set(&label.Emoji, update.Emoji)
+
+	// Tasks and templates carry copies of their labels
+	for _, task := range s.tasks {
+		for i := range task.Labels {
+			if task.Labels[i].ID == label.ID {
+				task.Labels[i] = *label
+			}
+		}
+	}
+	for _, template := range s.templates {
+		for i := range template.Labels {
+			if template.Labels[i].ID == label.ID {
+				template.Labels[i] = *label
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, label)
+}
+
+// Recurring templates
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	skip, limit, ok := pageParams(w, query, DefaultPageSize)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []*types.RecurringTaskTemplate
+	for _, template := range sorted(s.templates) {
+		if id := query.Get("project_id"); id != "" && strconv.Itoa(template.ProjectID) != id {
+			continue
+		}
+		if active := query.Get("is_active"); active != "" && strconv.FormatBool(template.IsActive) != active {
+			continue
+		}
+		if kind := query.Get("template_type"); kind != "" && template.TemplateType != kind {
+			continue
+		}
+		matched = append(matched, template)
+	}
+	writeJSON(w, http.StatusOK, nonNil(page(matched, skip, limit)))
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFound(w, s.templates[pathID(r)])
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request) {
+	var create types.RecurringTaskTemplateCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	start, err := parseTime(create.StartDate)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid start_date: %v", err))
+		return
+	}
+	var end *time.Time
+	if create.EndDate != nil {
+		t, err := parseTime(*create.EndDate)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid end_date: %v", err))
+			return
+		}
+		end = &t
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.projects[create.ProjectID] == nil {
+		writeError(w, http.StatusBadRequest, "Project not found")
+		return
+	}
+	template := s.addTemplate(types.RecurringTaskTemplate{
+		ProjectID:      create.ProjectID,
+		Title:          create.Title,
+		Description:    create.Description,
+		Priority:       create.Priority,
+		RecurrenceRule: create.RecurrenceRule,
+		StartDate:      start,
+		EndDate:        end,
+		Timezone:       create.Timezone,
+		TemplateType:   create.TemplateType,
+		IsActive:       create.IsActive,
+		Labels:         namedLabels(create.Labels),
+		Assignees:      namedAssignees(create.Assignees),
+	})
+	writeJSON(w, http.StatusCreated, template)
+}
+
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request) {
+	var update types.RecurringTaskTemplateUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	template := s.templates[pathID(r)]
+	if template == nil {
+		writeNotFound(w)
+		return
+	}
+	set(&template.Title, update.Title)
+	set(&template.RecurrenceRule, update.RecurrenceRule)
+	set(&template.Timezone, update.Timezone)
+	set(&template.IsActive, update.IsActive)
+	if update.Description != nil {
+		template.Description = update.Description
+	}
+	if update.Priority != nil {
+		template.Priority = update.Priority
+	}
+	if update.EndDate != nil {
+		end, err := parseTime(*update.EndDate)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid end_date: %v", err))
+			return
+		}
+		template.EndDate = &end
+	}
+	if update.Labels != nil {
+		template.Labels = s.labelsNamed(update.Labels)
+	}
+	if update.Assignees != nil {
+		template.Assignees = s.assignees(update.Assignees)
+	}
+	template.UpdatedAt = s.now
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) deleteTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathID(r)
+	if s.templates[id] == nil {
+		writeNotFound(w)
+		return
+	}
+	delete(s.templates, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// processDue creates a task for each active task template whose latest
+// occurrence up to today has none yet, as the API's scheduler does.
+func (s *Server) processDue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &api.ProcessDueTemplatesResponse{Details: []api.TemplateProcessDetail{}}
+	for _, template := range sorted(s.templates) {
+		if !template.IsActive || template.TemplateType != "task" {
+			continue
+		}
+		result.Processed++
+		detail := api.TemplateProcessDetail{TemplateID: template.ID}
+
+		occurrences, err := recurring.Occurrences(template, template.StartDate, s.now)
+		switch {
+		case err != nil:
+			detail.Action, detail.Error = "failed", err.Error()
+			result.Failed++
+		case len(occurrences) == 0:
+			detail.Action, detail.Reason = "skipped", "not due"
+			result.Skipped++
+		case s.hasTemplateTask(template.ID, occurrences[len(occurrences)-1]):
+			detail.Action, detail.Reason = "skipped", "task already exists"
+			result.Skipped++
+		default:
+			create := recurring.TaskCreate(template, occurrences[len(occurrences)-1])
+			task := s.addTask(types.Task{
+				Title:         create.Title,
+				Description:   create.Description,
+				ProjectID:     create.ProjectID,
+				Status:        create.Status,
+				Priority:      create.Priority,
+				TemplateID:    create.TemplateID,
+				ScheduledDate: create.ScheduledDate,
+				Labels:        namedLabels(create.Labels),
+				Assignees:     namedAssignees(create.Assignees),
+			})
+			detail.Action, detail.TaskID = "created", &task.ID
+			result.TasksCreated++
+		}
+		result.Details = append(result.Details, detail)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) hasTemplateTask(templateID int, date time.Time) bool {
+	for _, task := range s.tasks {
+		if task.TemplateID != nil && *task.TemplateID == templateID &&
+			task.ScheduledDate != nil && task.ScheduledDate.UTC().Format("2006-01-02") == date.Format("2006-01-02") {
+			return true
+		}
+	}
+	return false
+}
+
+// Helpers
+
+// pageParams reads skip and limit, defaulting the limit to DefaultPageSize
+// and capping it at max.
+func pageParams(w http.ResponseWriter, query map[string][]string, max int) (skip, limit int, ok bool) {
+	limit = DefaultPageSize
+	for _, param := range []struct {
+		key string
+		dst *int
+	}{{"skip", &skip}, {"limit", &limit}} {
+		values := query[param.key]
+		if len(values) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(values[0])
+		if err != nil || n < 0 {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid %s %q", param.key, values[0]))
+			return 0, 0, false
+		}
+		*param.dst = n
+	}
+	if limit > max {
+		limit = max
+	}
+	return skip, limit, true
+}
+
+func page[T any](items []T, skip, limit int) []T {
+	if skip >= len(items) {
+		return nil
+	}
+	items = items[skip:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// parseTime parses an RFC3339 timestamp or a YYYY-MM-DD date.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected an RFC3339 time or YYYY-MM-DD date, got %q", value)
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// matchesAny reports whether value is one of allowed, or allowed is empty.
+func matchesAny(allowed []string, value string) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, value)
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func set[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func labelNames(labels []types.Label) []string {
+	var names []string
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+func namedLabels(names []string) []types.Label {
+	var labels []types.Label
+	for _, name := range names {
+		labels = append(labels, types.Label{Name: name})
+	}
+	return labels
+}
+
+func assigneeNames(assignees []types.Assignee) []string {
+	var names []string
+	for _, assignee := range assignees {
+		names = append(names, assignee.Name)
+	}
+	return names
+}
+
+func namedAssignees(names []string) []types.Assignee {
+	var assignees []types.Assignee
+	for _, name := range names {
+		assignees = append(assignees, types.Assignee{Name: name})
+	}
+	return assignees
+}
+
+// sorted returns the values of m by ID, as the API lists them.
+func sorted[T any](m map[int]*T) []*T {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	values := make([]*T, len(ids))
+	for i, id := range ids {
+		values[i] = clone(m[id])
+	}
+	return values
+}
+
+// clone returns a deep copy of v, so callers can't change the server's
+// data, or nil if v is nil.
+func clone[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		panic(err)
+	}
+	return &copied
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func pathID(r *http.Request) int {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	return id
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, dest interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dest); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeFound[T any](w http.ResponseWriter, v *T) {
+	if v == nil {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
@@ -0,0 +1,162 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/api"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestServerPaginatesTasks(t *testing.T) {
+	server := NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "local", Name: "Local"})
+	project := server.AddProject(types.Project{Name: "Inbox", SystemID: system.ID})
+	for i := 0; i < 1203; i++ {
+		server.AddTask(types.Task{Title: fmt.Sprintf("Task %d", i), ProjectID: project.ID})
+	}
+
+	client := server.Client()
+	ctx := context.Background()
+
+	tasks, err := client.ListTasks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(tasks) != MaxTaskPageSize {
+		t.Errorf("Expected one page of %d tasks, got %d", MaxTaskPageSize, len(tasks))
+	}
+
+	all, err := client.ListAllTasks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListAllTasks failed: %v", err)
+	}
+	if len(all) != 1203 || all[1202].Title != "Task 1202" {
+		t.Errorf("Expected all 1203 tasks in order, got %d", len(all))
+	}
+	pages := 0
+	for _, request := range server.Requests() {
+		if request == "GET /api/v1/tasks/?limit=500&skip=1000" {
+			pages++
+		}
+	}
+	if pages != 1 {
+		t.Errorf("Expected the last page requested with skip, got %v", server.Requests())
+	}
+}
+
+func TestServerFiltersTasks(t *testing.T) {
+	server := NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "local", Name: "Local"})
+	high := "high"
+	work := server.AddProject(types.Project{Name: "Work", SystemID: system.ID, Priority: &high})
+	home := server.AddProject(types.Project{Name: "Home", SystemID: system.ID, Status: "archived"})
+
+	due := time.Date(2025, 1, 20, 17, 0, 0, 0, time.UTC)
+	scheduled := time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)
+	description := "Quarterly numbers"
+	server.AddTask(types.Task{Title: "Write report", ProjectID: work.ID, Description: &description, DueDate: &due, Labels: []types.Label{{Name: "writing"}, {Name: "q1"}}})
+	server.AddTask(types.Task{Title: "Review PR", ProjectID: work.ID, Status: "inprogress", Priority: &high, Assignees: []types.Assignee{{Name: "sam"}}})
+	server.AddTask(types.Task{Title: "Mow lawn", ProjectID: home.ID, Status: "done", ScheduledDate: &scheduled, Labels: []types.Label{{Name: "q1"}}})
+
+	workID := work.ID
+	tests := []struct {
+		name string
+		opts *api.TaskListOptions
+		want []string
+	}{
+		{"project", &api.TaskListOptions{ProjectID: &workID}, []string{"Write report", "Review PR"}},
+		{"statuses", &api.TaskListOptions{Status: "inprogress,done"}, []string{"Review PR", "Mow lawn"}},
+		{"priority", &api.TaskListOptions{Priority: "high"}, []string{"Review PR"}},
+		{"project status", &api.TaskListOptions{ProjectStatus: []string{"archived"}}, []string{"Mow lawn"}},
+		{"project priority", &api.TaskListOptions{ProjectPriority: []string{"high"}}, []string{"Write report", "Review PR"}},
+		{"labels", &api.TaskListOptions{Labels: []string{"q1", "writing"}}, []string{"Write report"}},
+		{"assignee", &api.TaskListOptions{Assignee: "Sam"}, []string{"Review PR"}},
+		{"search", &api.TaskListOptions{Search: "quarterly"}, []string{"Write report"}},
+		{"due", &api.TaskListOptions{DueAfter: "2025-01-20T00:00:00Z", DueBefore: "2025-01-20T23:59:59Z"}, []string{"Write report"}},
+		{"scheduled", &api.TaskListOptions{ScheduledAfter: "2025-01-16", ScheduledBefore: "2025-01-16T23:59:59Z"}, []string{"Mow lawn"}},
+		{"scheduled before the day", &api.TaskListOptions{ScheduledBefore: "2025-01-15T23:59:59Z"}, nil},
+		{"page", &api.TaskListOptions{Skip: 1, Limit: 1}, []string{"Review PR"}},
+	}
+	for _, tt := range tests {
+		tasks, err := server.Client().ListTasks(context.Background(), tt.opts)
+		if err != nil {
+			t.Fatalf("%s: ListTasks failed: %v", tt.name, err)
+		}
+		var titles []string
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+		if fmt.Sprint(titles) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, titles)
+		}
+	}
+}
+
+func TestServerComments(t *testing.T) {
+	server := NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "local", Name: "Local"})
+	project := server.AddProject(types.Project{Name: "Inbox", SystemID: system.ID})
+	task := server.AddTask(types.Task{Title: "Plan trip", ProjectID: project.ID})
+
+	client := server.Client()
+	ctx := context.Background()
+	for i := 0; i < 150; i++ {
+		server.SetNow(DefaultNow.Add(time.Duration(i) * time.Hour))
+		if _, err := client.CreateComment(ctx, &types.CommentCreate{Content: fmt.Sprintf("Entry %d", i), Author: "me"}); err != nil {
+			t.Fatalf("CreateComment failed: %v", err)
+		}
+	}
+	if _, err := client.CreateComment(ctx, &types.CommentCreate{TaskID: &task.ID, Content: "Booked flights", Author: "me"}); err != nil {
+		t.Fatalf("CreateComment failed: %v", err)
+	}
+
+	journals, err := client.ListJournals(ctx, 0, 0)
+	if err != nil || len(journals) != 150 {
+		t.Fatalf("Expected 150 journal entries over two pages, got %d (%v)", len(journals), err)
+	}
+	comments, err := client.ListComments(ctx, task.ID)
+	if err != nil || len(comments) != 1 || comments[0].Content != "Booked flights" {
+		t.Errorf("Expected the task's comment, got %+v (%v)", comments, err)
+	}
+	filtered, err := client.ListCommentsFiltered(ctx, &api.CommentListOptions{Type: "journal", CreatedAfter: "2025-01-20", Search: "entry 14"})
+	if err != nil {
+		t.Fatalf("ListCommentsFiltered failed: %v", err)
+	}
+	// Entries 14 and 140-149; only 140-149 are from the 20th on
+	if len(filtered) != 10 {
+		t.Errorf("Expected 10 filtered entries, got %d", len(filtered))
+	}
+}
+
+func TestServerProcessesDueTemplates(t *testing.T) {
+	server := NewServer(t)
+	system := server.AddSystem(types.System{Identifier: "local", Name: "Local"})
+	project := server.AddProject(types.Project{Name: "Home", SystemID: system.ID})
+	template := server.AddTemplate(types.RecurringTaskTemplate{
+		ProjectID:      project.ID,
+		Title:          "Water plants",
+		RecurrenceRule: "FREQ=WEEKLY;BYDAY=MO",
+		StartDate:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsActive:       true,
+		Labels:         []types.Label{{Name: "chores"}},
+	})
+
+	client := server.Client()
+	for i, want := range []int{1, 0} {
+		result, err := client.ProcessDueTemplates(context.Background())
+		if err != nil {
+			t.Fatalf("ProcessDueTemplates failed: %v", err)
+		}
+		if result.TasksCreated != want {
+			t.Errorf("Run %d: expected %d tasks created, got %+v", i+1, want, result)
+		}
+	}
+
+	tasks := server.Tasks()
+	if len(tasks) != 1 || *tasks[0].TemplateID != template.ID || tasks[0].ScheduledDate.Format("2006-01-02") != "2025-01-13" || !tasks[0].HasLabel("chores") {
+		t.Errorf("Expected a task for Monday the 13th, got %+v", tasks)
+	}
+}
@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// ParseCustomFields parses a custom_fields plugin setting: "labels" (the
+// default) syncs custom fields as "field:<key>=<value>" labels, and
+// "frontmatter" moves them into front matter at the top of the issue body.
+func ParseCustomFields(value string) (frontMatter bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "labels":
+		return false, nil
+	case "frontmatter":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid custom_fields %q: use labels or frontmatter", value)
+	}
+}
+
+// FieldsToFrontMatter moves the field labels in labels into front matter at
+// the top of description, for systems without native custom fields. When
+// description is nil the body isn't being updated, so labels are returned
+// as they are.
+func FieldsToFrontMatter(labels []string, description *string) ([]string, *string) {
+	if description == nil {
+		return labels, nil
+	}
+
+	var rest []string
+	var lines []string
+	for _, name := range labels {
+		if key, value, ok := types.ParseFieldLabel(name); ok {
+			lines = append(lines, key+": "+value)
+			continue
+		}
+		rest = append(rest, name)
+	}
+	if len(lines) == 0 {
+		return labels, description
+	}
+
+	body := "---\n" + strings.Join(lines, "\n") + "\n---\n"
+	if *description != "" {
+		body += "\n" + *description
+	}
+	return rest, &body
+}
+
+// FieldsFromFrontMatter moves the fields in front matter at the top of the
+// task's description into field labels, undoing FieldsToFrontMatter.
+// Descriptions that don't start with front matter of key: value lines are
+// left alone.
+func FieldsFromFrontMatter(task *types.Task) {
+	if task.Description == nil {
+		return
+	}
+	body := strings.ReplaceAll(*task.Description, "\r\n", "\n")
+	block, ok := strings.CutPrefix(body, "---\n")
+	if !ok {
+		return
+	}
+	end := strings.Index(block, "\n---")
+	if end < 0 {
+		return
+	}
+	rest := block[end+len("\n---"):]
+	if rest != "" && rest[0] != '\n' {
+		return
+	}
+
+	var labels []types.Label
+	for _, line := range strings.Split(block[:end], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !ok || types.ValidateFieldKey(key) != nil || value == "" {
+			return
+		}
+		labels = append(labels, types.Label{Name: types.FieldLabel(key, value)})
+	}
+
+	for _, label := range labels {
+		if !hasLabel(task.Labels, label.Name) {
+			task.Labels = append(task.Labels, label)
+		}
+	}
+	rest = strings.TrimPrefix(strings.TrimPrefix(rest, "\n"), "\n")
+	if rest == "" {
+		task.Description = nil
+	} else {
+		task.Description = &rest
+	}
+}
+
+// hasLabel reports whether labels has one named name
+func hasLabel(labels []types.Label, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
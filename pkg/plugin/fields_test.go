@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestParseCustomFields(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "labels": false, "FrontMatter": true} {
+		if got, err := ParseCustomFields(value); err != nil || got != want {
+			t.Errorf("ParseCustomFields(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseCustomFields("body"); err == nil {
+		t.Error("Expected an error for an unknown setting")
+	}
+}
+
+func TestFieldsFrontMatterRoundTrip(t *testing.T) {
+	description := "Fix the login page"
+	labels, body := FieldsToFrontMatter([]string{"bug", "field:customer=acme", "field:story-points=3"}, &description)
+	if !slices.Equal(labels, []string{"bug"}) {
+		t.Errorf("Expected field labels to be moved out, got %v", labels)
+	}
+	want := "---\ncustomer: acme\nstory-points: 3\n---\n\nFix the login page"
+	if body == nil || *body != want {
+		t.Fatalf("Unexpected body %q", *body)
+	}
+
+	task := &types.Task{Description: body, Labels: []types.Label{{Name: "bug"}}}
+	FieldsFromFrontMatter(task)
+	if task.Description == nil || *task.Description != description {
+		t.Errorf("Expected the front matter to be stripped, got %v", task.Description)
+	}
+	fields := task.Fields()
+	if len(fields) != 2 || fields["customer"] != "acme" || fields["story-points"] != "3" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+}
+
+func TestFieldsToFrontMatterWithoutDescription(t *testing.T) {
+	labels, body := FieldsToFrontMatter([]string{"field:customer=acme"}, nil)
+	if body != nil || !slices.Equal(labels, []string{"field:customer=acme"}) {
+		t.Errorf("Expected labels unchanged without a description, got %v, %v", labels, body)
+	}
+
+	empty := ""
+	_, body = FieldsToFrontMatter([]string{"field:customer=acme"}, &empty)
+	if body == nil || *body != "---\ncustomer: acme\n---\n" {
+		t.Errorf("Unexpected body %v", body)
+	}
+	task := &types.Task{Description: body}
+	FieldsFromFrontMatter(task)
+	if task.Description != nil || task.Fields()["customer"] != "acme" {
+		t.Errorf("Expected only the field, got %v, %v", task.Description, task.Labels)
+	}
+}
+
+func TestFieldsFromFrontMatterIgnoresOtherBodies(t *testing.T) {
+	for _, body := range []string{
+		"No front matter",
+		"---\ntitle: Some Post\nTags: [a, b]\n---\n\nBody",
+		"---\nunterminated: yes\n",
+		"---\n\n---",
+	} {
+		description := body
+		task := &types.Task{Description: &description}
+		FieldsFromFrontMatter(task)
+		if *task.Description != body || len(task.Labels) != 0 {
+			t.Errorf("Expected %q to be left alone, got %q and %v", body, *task.Description, task.Labels)
+		}
+	}
+}
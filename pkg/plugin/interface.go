@@ -145,3 +145,126 @@ type Plugin interface {
 	// Returns ErrNotFound if the task doesn't exist.
 	CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error)
 }
+
+// Identity is an optional interface for plugins that can report which account
+// their credentials belong to.
+//
+// The sync engine uses it to recognize comments it posted itself, so they
+// aren't pulled back into Todu as new comments.
+type Identity interface {
+	// CurrentUser returns the account name of the configured credentials,
+	// matching the Author of comments created by this plugin.
+	CurrentUser(ctx context.Context) (string, error)
+}
+
+// CommentUpdater is an optional interface for plugins that can edit existing
+// comments in the external system.
+//
+// The sync engine uses it to propagate comment edits made in Todu.
+type CommentUpdater interface {
+	// UpdateComment replaces the content of an existing comment.
+	//
+	// Parameters:
+	//   - projectExternalID: Optional project identifier. Required by some systems.
+	//   - taskExternalID: The external identifier for the task.
+	//   - commentExternalID: The external identifier for the comment.
+	//   - comment: The comment as it should now read. Content replaces the
+	//     existing body; Author is the Todu author, for attribution only.
+	//
+	// Returns the updated comment.
+	// Returns ErrNotFound if the comment doesn't exist.
+	UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error)
+}
+
+// CommentReactor is an optional interface for plugins that can add emoji
+// reactions to comments in the external system.
+//
+// The sync engine uses it to push reactions added in Todu. Removing a
+// reaction in Todu is not propagated.
+type CommentReactor interface {
+	// AddCommentReaction adds a reaction to an existing comment.
+	//
+	// Parameters:
+	//   - projectExternalID: Optional project identifier. Required by some systems.
+	//   - taskExternalID: The external identifier for the task.
+	//   - commentExternalID: The external identifier for the comment.
+	//   - emoji: The reaction, as an emoji such as "👍".
+	//
+	// Adding a reaction that already exists is not an error.
+	// Returns ErrNotSupported if the system has no equivalent for the emoji.
+	// Returns ErrNotFound if the comment doesn't exist.
+	AddCommentReaction(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID, emoji string) error
+}
+
+// IncrementalComments is an optional interface for plugins that can fetch
+// only the comments of a task that changed after a point in time.
+//
+// The sync engine uses it to avoid refetching every comment of every task on
+// each pull.
+type IncrementalComments interface {
+	// FetchCommentsSince retrieves comments created or updated at or after since.
+	//
+	// Parameters:
+	//   - projectExternalID: Optional project identifier. Required by some systems.
+	//   - taskExternalID: The external identifier for the task.
+	//   - since: Only return comments updated at or after this time.
+	//     If nil, fetch all comments like FetchComments.
+	//
+	// Returns ErrNotSupported if the plugin doesn't support comments.
+	// Returns ErrNotFound if the task doesn't exist.
+	FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error)
+}
+
+// UntimedTasks is an optional interface for plugins whose system doesn't
+// report when a task last changed, so their tasks' UpdatedAt can't show an
+// edit.
+//
+// The sync engine detects edits to their tasks by comparing them with the
+// last-synced snapshot instead of by UpdatedAt.
+type UntimedTasks interface {
+	// TasksUntimed reports whether the UpdatedAt of fetched tasks misses
+	// edits made in the external system.
+	TasksUntimed() bool
+}
+
+// PagedTasks is an optional interface for plugins that fetch a project's
+// tasks a page at a time.
+//
+// The sync engine uses it on a project's initial pull to record how far the
+// fetch got, so an interrupted pull resumes from the page it reached instead
+// of fetching every page again.
+type PagedTasks interface {
+	// FetchTaskPage retrieves one page of the tasks FetchTasks returns.
+	//
+	// Parameters:
+	//   - projectExternalID: Optional project identifier. Required by some systems.
+	//   - since: As for FetchTasks.
+	//   - cursor: Where the page starts, as returned for the previous page.
+	//     Empty for the first page.
+	//
+	// Returns the page's tasks and the cursor of the next page, which is
+	// empty after the last page.
+	FetchTaskPage(ctx context.Context, projectExternalID *string, since *time.Time, cursor string) ([]*types.Task, string, error)
+}
+
+// SearchResult is a task found by a search, together with the external ID of
+// the project it belongs to.
+type SearchResult struct {
+	ProjectExternalID string
+	Task              *types.Task
+}
+
+// Searcher is an optional interface for plugins that can search the external
+// system with its own query syntax.
+//
+// Commands use it to discover or import a curated subset of projects and
+// tasks instead of everything the credentials can access.
+type Searcher interface {
+	// SearchProjects returns the projects matching a system-specific query
+	// (e.g., "org:myorg topic:active" for GitHub).
+	SearchProjects(ctx context.Context, query string) ([]*types.Project, error)
+
+	// SearchTasks returns the tasks matching a system-specific query
+	// (e.g., "is:open label:help-wanted repo:owner/name" for GitHub).
+	SearchTasks(ctx context.Context, query string) ([]*SearchResult, error)
+}
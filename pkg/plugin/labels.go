@@ -0,0 +1,165 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RemoteLabel is a label as it exists in an external system.
+type RemoteLabel struct {
+	Name  string
+	Color string // hex, with or without a leading "#"
+}
+
+// LabelDrift describes a Todu label whose counterpart in the external system
+// differs in name case or color from what the plugin would create it with.
+type LabelDrift struct {
+	Name          string `json:"name"`                     // the Todu label
+	RemoteName    string `json:"remote_name"`              // the label in the external system
+	Color         string `json:"color,omitempty"`          // the external label's color
+	ExpectedColor string `json:"expected_color,omitempty"` // the color the plugin would create it with
+}
+
+// LabelPushOptions controls how a LabelPusher creates labels.
+type LabelPushOptions struct {
+	// DryRun reports what would be created without creating anything.
+	DryRun bool
+
+	// Interval is the pause between label creations, to stay under the
+	// external system's rate limits.
+	Interval time.Duration
+
+	// Colors maps label names to the colors (six hex digits) Todu has for
+	// them. Other labels are created with LabelColor.
+	Colors map[string]string
+}
+
+// ColorFor returns the color to create a label with: its Todu color if it
+// has one, and LabelColor otherwise.
+func (o LabelPushOptions) ColorFor(name string) string {
+	if color := o.Colors[name]; color != "" {
+		return color
+	}
+	return LabelColor(name)
+}
+
+// LabelPushResult reports what a LabelPusher did with each label.
+type LabelPushResult struct {
+	Created  []string          `json:"created"`  // created, or would be on a dry run
+	Existing []string          `json:"existing"` // already in the external system
+	Drift    []LabelDrift      `json:"drift,omitempty"`
+	Failed   map[string]string `json:"failed,omitempty"` // error by label name
+}
+
+// LabelPusher is an optional interface for plugins that can create labels
+// in the external system ahead of a sync.
+//
+// "todu labels push" uses it so that a large sync doesn't stop partway
+// through on a label that can't be created. The sync engine uses it to
+// create labels that have a Todu color with that color.
+type LabelPusher interface {
+	// PushLabels creates each of labels missing from the external project
+	// and reports labels whose existing counterpart has drifted.
+	//
+	// Parameters:
+	//   - projectExternalID: Optional project identifier. Required by some systems.
+	//   - labels: The Todu label names to ensure exist.
+	//   - opts: Dry run, pacing, and color options.
+	//
+	// A label that fails to be created is recorded in the result's Failed
+	// map rather than stopping the push.
+	// Returns ErrNotFound if the project doesn't exist.
+	PushLabels(ctx context.Context, projectExternalID *string, labels []string, opts LabelPushOptions) (*LabelPushResult, error)
+}
+
+// LabelColor returns the color, as six hex digits, that plugins create a
+// label with: a pastel color derived from its name, so the same label gets
+// the same color everywhere.
+func LabelColor(name string) string {
+	var hash uint32
+	for _, c := range name {
+		hash = hash*31 + uint32(c)
+	}
+
+	r := 128 + (hash&0xFF)%128
+	g := 128 + ((hash>>8)&0xFF)%128
+	b := 128 + ((hash>>16)&0xFF)%128
+
+	return fmt.Sprintf("%02x%02x%02x", r, g, b)
+}
+
+// PlanLabelPush compares labels with the labels in an external system. It
+// returns a result with Existing and Drift filled in, and the labels that
+// need to be created. Names match case-insensitively, as they do in GitHub;
+// a match that differs in case, or in color from opts.ColorFor, is drift.
+func PlanLabelPush(labels []string, remote []RemoteLabel, opts LabelPushOptions) (*LabelPushResult, []string) {
+	byName := make(map[string]RemoteLabel, len(remote))
+	for _, label := range remote {
+		byName[strings.ToLower(label.Name)] = label
+	}
+
+	result := &LabelPushResult{Created: []string{}, Existing: []string{}}
+	var missing []string
+	seen := make(map[string]bool)
+	for _, name := range labels {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		existing, ok := byName[key]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		result.Existing = append(result.Existing, name)
+
+		color := strings.ToLower(strings.TrimPrefix(existing.Color, "#"))
+		expected := opts.ColorFor(name)
+		if existing.Name != name || color != expected {
+			result.Drift = append(result.Drift, LabelDrift{
+				Name:          name,
+				RemoteName:    existing.Name,
+				Color:         color,
+				ExpectedColor: expected,
+			})
+		}
+	}
+	return result, missing
+}
+
+// CreateLabels creates each of missing with create, waiting opts.Interval
+// between creations, and records them in result. Failures are recorded in
+// result.Failed; only a cancelled context stops it early.
+func CreateLabels(ctx context.Context, result *LabelPushResult, missing []string, opts LabelPushOptions, create func(name string) error) error {
+	for i, name := range missing {
+		if opts.DryRun {
+			result.Created = append(result.Created, name)
+			continue
+		}
+
+		if i > 0 && opts.Interval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.Interval):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := create(name); err != nil {
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[name] = err.Error()
+			continue
+		}
+		result.Created = append(result.Created, name)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLabelColor(t *testing.T) {
+	color := LabelColor("bug")
+	if len(color) != 6 || color != LabelColor("bug") || color == LabelColor("feature") {
+		t.Errorf("Expected a stable six digit color per name, got %q", color)
+	}
+}
+
+func TestPlanLabelPush(t *testing.T) {
+	remote := []RemoteLabel{
+		{Name: "bug", Color: "#" + LabelColor("bug")},
+		{Name: "Docs", Color: LabelColor("docs")},
+		{Name: "feature", Color: "ededed"},
+	}
+
+	result, missing := PlanLabelPush([]string{"bug", "docs", "feature", "urgent", "Urgent", ""}, remote, LabelPushOptions{})
+	if !slices.Equal(missing, []string{"urgent"}) {
+		t.Errorf("Expected urgent to be missing, got %v", missing)
+	}
+	if !slices.Equal(result.Existing, []string{"bug", "docs", "feature"}) {
+		t.Errorf("Unexpected existing labels: %v", result.Existing)
+	}
+	if len(result.Drift) != 2 || result.Drift[0].RemoteName != "Docs" || result.Drift[1].Color != "ededed" {
+		t.Errorf("Expected drift in docs' name and feature's color, got %+v", result.Drift)
+	}
+
+	opts := LabelPushOptions{Colors: map[string]string{"feature": "ededed", "bug": "d73a4a"}}
+	result, _ = PlanLabelPush([]string{"bug", "feature"}, remote, opts)
+	if len(result.Drift) != 1 || result.Drift[0].Name != "bug" || result.Drift[0].ExpectedColor != "d73a4a" {
+		t.Errorf("Expected drift against Todu's colors, got %+v", result.Drift)
+	}
+	if opts.ColorFor("urgent") != LabelColor("urgent") {
+		t.Errorf("Expected labels without a Todu color to use LabelColor")
+	}
+}
+
+func TestCreateLabels(t *testing.T) {
+	var created []string
+	create := func(name string) error {
+		if name == "bad" {
+			return errors.New("invalid name")
+		}
+		created = append(created, name)
+		return nil
+	}
+
+	result := &LabelPushResult{}
+	if err := CreateLabels(context.Background(), result, []string{"a", "bad", "b"}, LabelPushOptions{}, create); err != nil {
+		t.Fatalf("CreateLabels failed: %v", err)
+	}
+	if !slices.Equal(result.Created, []string{"a", "b"}) || result.Failed["bad"] != "invalid name" {
+		t.Errorf("Expected a and b created and bad failed, got %+v", result)
+	}
+
+	created = nil
+	result = &LabelPushResult{}
+	if err := CreateLabels(context.Background(), result, []string{"c"}, LabelPushOptions{DryRun: true}, create); err != nil {
+		t.Fatalf("CreateLabels failed: %v", err)
+	}
+	if created != nil || !slices.Equal(result.Created, []string{"c"}) {
+		t.Errorf("Expected a dry run to report c without creating it, got %v created", created)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := CreateLabels(ctx, &LabelPushResult{}, []string{"d"}, LabelPushOptions{}, create); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -300,6 +300,22 @@ func (m *MockPlugin) FetchComments(ctx context.Context, projectExternalID *strin
 	return comments, nil
 }
 
+// FetchCommentsSince returns the comments for a task updated at or after since.
+func (m *MockPlugin) FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error) {
+	comments, err := m.FetchComments(ctx, projectExternalID, taskExternalID)
+	if err != nil || since == nil {
+		return comments, err
+	}
+
+	result := make([]*types.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.UpdatedAt.Before(*since) {
+			result = append(result, comment)
+		}
+	}
+	return result, nil
+}
+
 // CreateComment creates a new comment in memory.
 func (m *MockPlugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
 	if m.CreateCommentError != nil {
@@ -344,7 +360,7 @@ func (m *MockPlugin) AddTask(externalID string, task *types.Task) {
 	m.tasks[externalID] = task
 }
 
-// Reset clears all stored data.
+// Reset clears all stored data and injected errors.
 // This is a test helper method not part of the Plugin interface.
 func (m *MockPlugin) Reset() {
 	m.mu.Lock()
@@ -353,4 +369,15 @@ func (m *MockPlugin) Reset() {
 	m.projects = make(map[string]*types.Project)
 	m.tasks = make(map[string]*types.Task)
 	m.comments = make(map[string][]*types.Comment)
+
+	m.FetchProjectsError = nil
+	m.FetchProjectError = nil
+	m.FetchTasksError = nil
+	m.FetchTaskError = nil
+	m.CreateTaskError = nil
+	m.UpdateTaskError = nil
+	m.FetchCommentsError = nil
+	m.CreateCommentError = nil
+	m.ConfigureError = nil
+	m.ValidateConfigError = nil
 }
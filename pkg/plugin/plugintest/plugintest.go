@@ -0,0 +1,33 @@
+// Package plugintest helps test plugins against a fake of their external
+// system.
+package plugintest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+)
+
+// Serve configures a plugin against a fake of its external system.
+// It starts a server for handler that is closed when the test ends, and
+// configures p with config and the server's URL followed by urlPath as
+// "url". HOME is pointed at a temporary directory, so the plugin's caches
+// stay out of the user's.
+func Serve(t testing.TB, p plugin.Plugin, handler http.Handler, urlPath string, config map[string]string) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := map[string]string{"url": server.URL + urlPath}
+	for key, value := range config {
+		cfg[key] = value
+	}
+	if err := p.Configure(cfg); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+}
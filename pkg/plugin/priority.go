@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// PriorityLabelPrefix starts the labels that carry a task's priority in
+// label-based systems such as GitHub and Forgejo, unless the plugin's
+// priority_labels setting maps the level to another label.
+const PriorityLabelPrefix = "priority:"
+
+// PriorityLabels maps Todu priority levels to the labels that carry them
+// in an external system. A nil *PriorityLabels maps every level to
+// "priority:<level>".
+type PriorityLabels struct {
+	labels map[string]string // label by level
+	levels map[string]string // level by lowercase label
+}
+
+// ParsePriorityLabels parses a priority_labels plugin setting, a
+// comma-separated list of level=label pairs such as "urgent=P0,high=P1".
+// Levels must be configured priorities; levels left out keep their
+// "priority:<level>" label. An empty spec returns nil.
+func ParsePriorityLabels(spec string) (*PriorityLabels, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	p := &PriorityLabels{labels: make(map[string]string), levels: make(map[string]string)}
+	for _, pair := range strings.Split(spec, ",") {
+		level, label, ok := strings.Cut(pair, "=")
+		level = strings.ToLower(strings.TrimSpace(level))
+		label = strings.TrimSpace(label)
+		if !ok || level == "" || label == "" {
+			return nil, fmt.Errorf("invalid priority label %q: use level=label", strings.TrimSpace(pair))
+		}
+		if err := types.ValidatePriority(level); err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(label)
+		if other, ok := p.levels[key]; ok && other != level {
+			return nil, fmt.Errorf("label %q is mapped to both %s and %s", label, other, level)
+		}
+		p.labels[level] = label
+		p.levels[key] = level
+	}
+	return p, nil
+}
+
+// Label returns the label that carries a priority level.
+func (p *PriorityLabels) Label(level string) string {
+	if p != nil {
+		if label, ok := p.labels[level]; ok {
+			return label
+		}
+	}
+	return PriorityLabelPrefix + level
+}
+
+// Priority returns the level a label carries, and false if it is not a
+// priority label. Labels match case-insensitively. A "priority:" label
+// with an unknown level, such as priority:critical, maps to "high" (or the
+// highest level if "high" isn't one) so it isn't quietly deprioritized,
+// and so it stands out to be corrected.
+func (p *PriorityLabels) Priority(label string) (string, bool) {
+	key := strings.ToLower(label)
+	if p != nil {
+		if level, ok := p.levels[key]; ok {
+			return level, true
+		}
+	}
+
+	level, ok := strings.CutPrefix(key, PriorityLabelPrefix)
+	if !ok {
+		return "", false
+	}
+	levels := types.Priorities()
+	if slices.Contains(levels, level) {
+		return level, true
+	}
+	if slices.Contains(levels, "high") {
+		return "high", true
+	}
+	return levels[len(levels)-1], true
+}
+
+// Relabel returns label, with a "priority:<level>" label replaced by the
+// label configured for the level. Plugins use it to create the priority
+// labels "todu labels push" lists under the names they sync with.
+func (p *PriorityLabels) Relabel(label string) string {
+	if level, ok := strings.CutPrefix(label, PriorityLabelPrefix); ok {
+		return p.Label(level)
+	}
+	return label
+}
@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+func TestPriorityLabels(t *testing.T) {
+	t.Cleanup(func() { _ = types.SetPriorities(nil) })
+	if err := types.SetPriorities([]string{"none", "low", "medium", "high", "urgent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ParsePriorityLabels("urgent=P0, high=P1")
+	if err != nil {
+		t.Fatalf("ParsePriorityLabels failed: %v", err)
+	}
+	if p.Label("urgent") != "P0" || p.Label("low") != "priority:low" {
+		t.Errorf("Unexpected labels: %q, %q", p.Label("urgent"), p.Label("low"))
+	}
+	if p.Relabel("priority:high") != "P1" || p.Relabel("bug") != "bug" {
+		t.Error("Expected Relabel to map priority labels only")
+	}
+
+	tests := []struct {
+		label  string
+		want   string
+		wantOK bool
+	}{
+		{"p0", "urgent", true},
+		{"priority:none", "none", true},
+		{"priority:URGENT", "urgent", true},
+		{"priority:critical", "high", true},
+		{"P2", "", false},
+		{"bug", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := p.Priority(tt.label); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Priority(%q) = %q, %v, want %q, %v", tt.label, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	var defaults *PriorityLabels
+	if defaults.Label("high") != "priority:high" {
+		t.Error("Expected nil table to use priority:<level>")
+	}
+
+	for _, spec := range []string{"critical=P0", "urgent", "urgent=P0,high=p0"} {
+		if _, err := ParsePriorityLabels(spec); err == nil {
+			t.Errorf("ParsePriorityLabels(%q): expected error", spec)
+		}
+	}
+	if p, err := ParsePriorityLabels(""); p != nil || err != nil {
+		t.Errorf("Expected empty spec to give nil table, got %v, %v", p, err)
+	}
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ChecklistItem is an item of a task's checklist
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// checklistPattern matches a markdown task list item such as "- [ ] text"
+// or "* [x] text", capturing the indent, bullet, mark, and text.
+var checklistPattern = regexp.MustCompile(`^(\s*)([-*+]) \[([ xX])\] (.*)$`)
+
+// checklistLines returns the index of each checklist line in lines,
+// skipping code fences.
+func checklistLines(lines []string) []int {
+	var indexes []int
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && checklistPattern.MatchString(line) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// ParseChecklist returns the checklist in a task description: its markdown
+// task list items ("- [ ] text" or "- [x] text"), the format GitHub and
+// Forgejo render as checkboxes in issue bodies.
+func ParseChecklist(description string) []ChecklistItem {
+	lines := strings.Split(description, "\n")
+	var items []ChecklistItem
+	for _, i := range checklistLines(lines) {
+		m := checklistPattern.FindStringSubmatch(lines[i])
+		items = append(items, ChecklistItem{Text: strings.TrimSpace(m[4]), Done: m[3] != " "})
+	}
+	return items
+}
+
+// ChecklistProgress returns how many of items are done.
+func ChecklistProgress(items []ChecklistItem) (done, total int) {
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(items)
+}
+
+// AddChecklistItem returns description with an unchecked item added after
+// the last checklist item, or at the end if there is no checklist yet.
+func AddChecklistItem(description, text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "\n") {
+		return "", fmt.Errorf("checklist item must be a single non-empty line")
+	}
+	item := "- [ ] " + text
+
+	lines := strings.Split(description, "\n")
+	indexes := checklistLines(lines)
+	if len(indexes) == 0 {
+		description = strings.TrimRight(description, "\n")
+		if description == "" {
+			return item, nil
+		}
+		return description + "\n\n" + item, nil
+	}
+
+	last := indexes[len(indexes)-1]
+	m := checklistPattern.FindStringSubmatch(lines[last])
+	item = m[1] + m[2] + " [ ] " + text
+	lines = append(lines[:last+1], append([]string{item}, lines[last+1:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// ToggleChecklistItem returns description with checklist item n (1-based)
+// checked if it was unchecked, or unchecked if it was checked.
+func ToggleChecklistItem(description string, n int) (string, error) {
+	return editChecklistItem(description, n, func(m []string) string {
+		mark := "x"
+		if m[3] != " " {
+			mark = " "
+		}
+		return m[1] + m[2] + " [" + mark + "] " + m[4]
+	})
+}
+
+// RemoveChecklistItem returns description without checklist item n
+// (1-based).
+func RemoveChecklistItem(description string, n int) (string, error) {
+	return editChecklistItem(description, n, nil)
+}
+
+// editChecklistItem replaces checklist item n (1-based) with the line
+// replace returns for its pattern match, or removes it if replace is nil.
+func editChecklistItem(description string, n int, replace func(m []string) string) (string, error) {
+	lines := strings.Split(description, "\n")
+	indexes := checklistLines(lines)
+	if n < 1 || n > len(indexes) {
+		return "", fmt.Errorf("checklist item %d not found (the checklist has %d items)", n, len(indexes))
+	}
+
+	i := indexes[n-1]
+	if replace == nil {
+		lines = append(lines[:i], lines[i+1:]...)
+	} else {
+		lines[i] = replace(checklistPattern.FindStringSubmatch(lines[i]))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Checklist returns the checklist in the task's description.
+func (t *Task) Checklist() []ChecklistItem {
+	if t.Description == nil {
+		return nil
+	}
+	return ParseChecklist(*t.Description)
+}
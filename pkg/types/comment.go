@@ -1,16 +1,92 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
 
 // Comment represents a task comment or journal entry with full fields
 type Comment struct {
-	ID         int       `json:"id"`
-	TaskID     *int      `json:"task_id"`               // Nullable - nil for journal entries
-	ExternalID string    `json:"external_id,omitempty"` // External system's comment ID for sync
-	Content    string    `json:"content"`
-	Author     string    `json:"author"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         int        `json:"id"`
+	TaskID     *int       `json:"task_id"`               // Nullable - nil for journal entries
+	ExternalID string     `json:"external_id,omitempty"` // External system's comment ID for sync
+	Content    string     `json:"content"`
+	Author     string     `json:"author"`
+	Reactions  []Reaction `json:"reactions,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Reaction is an emoji reaction to a comment
+type Reaction struct {
+	Emoji  string `json:"emoji"`
+	Author string `json:"author"`
+}
+
+// reactionNames maps reaction names to their emoji, so reactions can be
+// typed without an emoji keyboard. The names are GitHub's reaction names.
+var reactionNames = map[string]string{
+	"+1":       "👍",
+	"thumbsup": "👍",
+	"-1":       "👎",
+	"laugh":    "😄",
+	"confused": "😕",
+	"heart":    "❤️",
+	"hooray":   "🎉",
+	"tada":     "🎉",
+	"rocket":   "🚀",
+	"eyes":     "👀",
+}
+
+// ParseReaction returns the emoji for a reaction given as an emoji or a
+// name such as +1, heart, or rocket.
+func ParseReaction(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if emoji, ok := reactionNames[strings.ToLower(value)]; ok {
+		return emoji, nil
+	}
+	for _, r := range value {
+		if r > 0x7f {
+			return value, nil
+		}
+	}
+	names := make([]string, 0, len(reactionNames))
+	for name := range reactionNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("invalid reaction %q (use an emoji or one of: %s)", value, strings.Join(names, ", "))
+}
+
+// HasReaction reports whether author reacted to the comment with emoji.
+func (c *Comment) HasReaction(emoji, author string) bool {
+	for _, r := range c.Reactions {
+		if r.Emoji == emoji && r.Author == author {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionSummary counts the comment's reactions by emoji, in the order
+// each emoji was first used, such as "👍 2  🎉 1". Returns "" if there are
+// none.
+func (c *Comment) ReactionSummary() string {
+	var emojis []string
+	counts := make(map[string]int)
+	for _, r := range c.Reactions {
+		if counts[r.Emoji] == 0 {
+			emojis = append(emojis, r.Emoji)
+		}
+		counts[r.Emoji]++
+	}
+	parts := make([]string, len(emojis))
+	for i, emoji := range emojis {
+		parts[i] = fmt.Sprintf("%s %d", emoji, counts[emoji])
+	}
+	return strings.Join(parts, "  ")
 }
 
 // CommentCreate represents data for creating a comment or journal entry
@@ -23,6 +99,7 @@ type CommentCreate struct {
 
 // CommentUpdate represents data for updating a comment
 type CommentUpdate struct {
-	ExternalID *string `json:"external_id,omitempty"` // Update external system's comment ID
-	Content    *string `json:"content,omitempty"`
+	ExternalID *string     `json:"external_id,omitempty"` // Update external system's comment ID
+	Content    *string     `json:"content,omitempty"`
+	Reactions  *[]Reaction `json:"reactions,omitempty"` // Replaces all reactions; empty clears them
 }
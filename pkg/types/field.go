@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldLabelPrefix starts the labels that hold a task's custom fields, each
+// "field:<key>=<value>" such as "field:customer=acme".
+const FieldLabelPrefix = "field:"
+
+// ValidateFieldKey checks that key is a custom field name: lowercase
+// letters, digits, "-", and "_", starting with a letter or digit.
+func ValidateFieldKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("empty field name")
+	}
+	for i, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case (r == '-' || r == '_') && i > 0:
+		default:
+			return fmt.Errorf("invalid field name %q: use lowercase letters, digits, - and _", key)
+		}
+	}
+	return nil
+}
+
+// ParseField parses a custom field assignment such as "customer=acme" or
+// "story-points=3". An empty value, as in "customer=", clears the field.
+func ParseField(assignment string) (key, value string, err error) {
+	key, value, ok := strings.Cut(assignment, "=")
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if !ok {
+		return "", "", fmt.Errorf("invalid field %q: use key=value", assignment)
+	}
+	if err := ValidateFieldKey(key); err != nil {
+		return "", "", err
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", fmt.Errorf("invalid field %q: values are a single line", assignment)
+	}
+	return key, value, nil
+}
+
+// FieldLabel returns the label that records a custom field.
+func FieldLabel(key, value string) string {
+	return FieldLabelPrefix + key + "=" + value
+}
+
+// ParseFieldLabel returns the key and value of a custom field label, and
+// false if name isn't one.
+func ParseFieldLabel(name string) (key, value string, ok bool) {
+	rest, ok := strings.CutPrefix(name, FieldLabelPrefix)
+	if !ok {
+		return "", "", false
+	}
+	key, value, ok = strings.Cut(rest, "=")
+	if !ok || ValidateFieldKey(key) != nil || value == "" {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// Fields returns the task's custom fields from its field labels. A key with
+// several labels keeps the last.
+func (t *Task) Fields() map[string]string {
+	fields := make(map[string]string)
+	for _, label := range t.Labels {
+		if key, value, ok := ParseFieldLabel(label.Name); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// FieldKeys returns the keys of fields, sorted.
+func FieldKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,7 +1,46 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Label represents a task label
 type Label struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"` // six hex digits, without "#"
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// LabelUpdate represents data for updating a label's metadata. An empty
+// string clears the field.
+type LabelUpdate struct {
+	Color *string `json:"color,omitempty"`
+	Emoji *string `json:"emoji,omitempty"`
+}
+
+// DisplayName returns the label's name, prefixed with its emoji if it has
+// one.
+func (l Label) DisplayName() string {
+	if l.Emoji == "" {
+		return l.Name
+	}
+	return l.Emoji + " " + l.Name
+}
+
+// NormalizeLabelColor returns color as six lowercase hex digits without a
+// leading "#". Three-digit colors are expanded, and empty stays empty.
+func NormalizeLabelColor(color string) (string, error) {
+	hex := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+	if hex == "" {
+		return "", nil
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 || strings.Trim(hex, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid color %q: use hex such as #d73a4a", color)
+	}
+	return hex, nil
 }
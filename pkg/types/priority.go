@@ -0,0 +1,98 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultPriorities are the priority levels used unless the config sets
+// others, lowest first.
+var DefaultPriorities = []string{"low", "medium", "high"}
+
+// priorityLevels are the valid priority levels, lowest first
+var priorityLevels = DefaultPriorities
+
+// SetPriorities sets the valid priority levels, lowest first, such as
+// none, low, medium, high, urgent. Empty restores DefaultPriorities.
+// Levels are lowercase names without spaces or commas, and may not repeat.
+func SetPriorities(levels []string) error {
+	if len(levels) == 0 {
+		priorityLevels = DefaultPriorities
+		return nil
+	}
+
+	normalized := make([]string, 0, len(levels))
+	for _, level := range levels {
+		level = strings.ToLower(strings.TrimSpace(level))
+		if level == "" || strings.ContainsAny(level, " \t,=:") {
+			return fmt.Errorf("invalid priority level %q", level)
+		}
+		if slices.Contains(normalized, level) {
+			return fmt.Errorf("duplicate priority level %q", level)
+		}
+		normalized = append(normalized, level)
+	}
+	priorityLevels = normalized
+	return nil
+}
+
+// Priorities returns the valid priority levels, lowest first.
+func Priorities() []string {
+	return slices.Clone(priorityLevels)
+}
+
+// DefaultPriority returns the priority new tasks get: "medium" if it is a
+// level, and the middle level otherwise.
+func DefaultPriority() string {
+	if slices.Contains(priorityLevels, "medium") {
+		return "medium"
+	}
+	return priorityLevels[(len(priorityLevels)-1)/2]
+}
+
+// ValidatePriority returns an error naming the valid levels if p is not
+// one of them.
+func ValidatePriority(p string) error {
+	if slices.Contains(priorityLevels, p) {
+		return nil
+	}
+	return fmt.Errorf("invalid priority %q: must be %s", p, joinOr(priorityLevels))
+}
+
+// PriorityRank orders priorities for sorting: 1 for the lowest level up
+// to the number of levels for the highest, and 0 for nil or an unknown
+// priority.
+func PriorityRank(p *string) int {
+	if p == nil {
+		return 0
+	}
+	return slices.Index(priorityLevels, *p) + 1
+}
+
+// IsHighPriority reports whether p is "high" or a level above it. If
+// "high" is not a level, only the highest level counts.
+func IsHighPriority(p *string) bool {
+	rank := PriorityRank(p)
+	if rank == 0 {
+		return false
+	}
+	high := slices.Index(priorityLevels, "high") + 1
+	if high == 0 {
+		high = len(priorityLevels)
+	}
+	return rank >= high
+}
+
+// joinOr joins words as "a, b, or c"
+func joinOr(words []string) string {
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return words[0]
+	case 2:
+		return words[0] + " or " + words[1]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + ", or " + words[len(words)-1]
+}
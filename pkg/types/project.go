@@ -10,7 +10,7 @@ type Project struct {
 	SystemID     int        `json:"system_id"`
 	ExternalID   string     `json:"external_id"`
 	Status       string     `json:"status"`
-	Priority     *string    `json:"priority,omitempty"` // "low", "medium", or "high"
+	Priority     *string    `json:"priority,omitempty"` // a configured level, such as "high"
 	SyncStrategy string     `json:"sync_strategy"`      // "pull", "push", or "bidirectional"
 	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
@@ -24,7 +24,7 @@ type ProjectCreate struct {
 	SystemID     int     `json:"system_id"`
 	ExternalID   string  `json:"external_id"`
 	Status       string  `json:"status"`
-	Priority     *string `json:"priority,omitempty"` // "low", "medium", or "high"
+	Priority     *string `json:"priority,omitempty"` // a configured level, such as "high"
 	SyncStrategy string  `json:"sync_strategy"`      // "pull", "push", or "bidirectional"
 }
 
@@ -33,7 +33,7 @@ type ProjectUpdate struct {
 	Name         *string    `json:"name,omitempty"`
 	Description  *string    `json:"description,omitempty"`
 	Status       *string    `json:"status,omitempty"`
-	Priority     *string    `json:"priority,omitempty"`      // "low", "medium", or "high"
+	Priority     *string    `json:"priority,omitempty"`      // a configured level, such as "high"
 	SyncStrategy *string    `json:"sync_strategy,omitempty"` // "pull", "push", or "bidirectional"
 	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
 }
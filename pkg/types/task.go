@@ -1,6 +1,14 @@
 package types
 
-import "time"
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Task represents a full task with all fields
 type Task struct {
@@ -22,6 +30,246 @@ type Task struct {
 	Assignees     []Assignee `json:"assignees,omitempty"`
 }
 
+// StarredLabel is the label that marks a task as starred. Starred tasks are
+// listed before other tasks regardless of sorting.
+const StarredLabel = "starred"
+
+// SomedayLabel is the label that parks a task in the someday/maybe backlog.
+// Someday tasks are left out of default task lists and reviews.
+const SomedayLabel = "someday"
+
+// SkipWeekendsLabel marks a recurring template, and the tasks it generates,
+// whose occurrences move off weekends to the next weekday.
+const SkipWeekendsLabel = "skip-weekends"
+
+// SkipHolidaysLabel marks a recurring template, and the tasks it generates,
+// whose occurrences move off holidays in the configured holiday calendar.
+const SkipHolidaysLabel = "skip-holidays"
+
+// EstimateLabelPrefix starts the label that holds a task's time estimate,
+// such as "estimate:45m" or "estimate:1h30m".
+const EstimateLabelPrefix = "estimate:"
+
+// ParseEstimate parses a time estimate such as 45m, 2h, or 1h30m.
+func ParseEstimate(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid estimate %q (use a duration such as 45m, 2h, or 1h30m)", value)
+	}
+	return d, nil
+}
+
+// EstimateLabel returns the label that records estimate, rounded to the
+// minute.
+func EstimateLabel(estimate time.Duration) string {
+	return EstimateLabelPrefix + FormatEstimate(estimate)
+}
+
+// FormatEstimate formats an estimate in hours and minutes, such as 1h30m.
+func FormatEstimate(estimate time.Duration) string {
+	minutes := int(estimate.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	}
+}
+
+// Estimate returns the task's time estimate from its estimate label, and
+// false if it has none or the label doesn't parse.
+func (t *Task) Estimate() (time.Duration, bool) {
+	for _, label := range t.Labels {
+		if value, ok := strings.CutPrefix(label.Name, EstimateLabelPrefix); ok {
+			if d, err := ParseEstimate(value); err == nil {
+				return d, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ReminderLabelPrefix starts the labels that hold a task's reminders, each
+// an offset before its due date such as "remind:1d" or "remind:1h".
+const ReminderLabelPrefix = "remind:"
+
+// ParseReminderOffset parses a reminder offset: a whole number of weeks or
+// days such as 1w or 2d, or a duration such as 1h or 1h30m. 0 reminds at
+// the due time.
+func ParseReminderOffset(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	invalid := fmt.Errorf("invalid reminder %q (use an offset such as 1w, 2d, 1h, or 30m)", value)
+	for unit, size := range map[string]time.Duration{"w": 7 * 24 * time.Hour, "d": 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, unit); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n < 0 {
+				return 0, invalid
+			}
+			return time.Duration(n) * size, nil
+		}
+	}
+	if value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, invalid
+	}
+	return d, nil
+}
+
+// FormatReminderOffset formats a reminder offset in the largest whole unit
+// of days, hours, or minutes, such as 2d, 1h, or 1h30m.
+func FormatReminderOffset(offset time.Duration) string {
+	if offset > 0 && offset%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", offset/(24*time.Hour))
+	}
+	return FormatEstimate(offset)
+}
+
+// ReminderLabel returns the label that records a reminder offset.
+func ReminderLabel(offset time.Duration) string {
+	return ReminderLabelPrefix + FormatReminderOffset(offset)
+}
+
+// ReminderOffsets returns the offsets before the due date of the task's
+// reminders, from its reminder labels, largest first. Labels that don't
+// parse are skipped.
+func (t *Task) ReminderOffsets() []time.Duration {
+	var offsets []time.Duration
+	for _, label := range t.Labels {
+		if value, ok := strings.CutPrefix(label.Name, ReminderLabelPrefix); ok {
+			if d, err := ParseReminderOffset(value); err == nil && !slices.Contains(offsets, d) {
+				offsets = append(offsets, d)
+			}
+		}
+	}
+	slices.SortFunc(offsets, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+	return offsets
+}
+
+// ParentLabelPrefix starts the label that holds the ID of a task's parent
+// task, such as "parent:12".
+const ParentLabelPrefix = "parent:"
+
+// DependsOnLabelPrefix starts the labels that hold the IDs of the tasks
+// blocking a task, such as "depends-on:12". The Forgejo plugin's
+// "blocked-by:#<number>" labels are different: they refer to issue
+// numbers in the external system.
+const DependsOnLabelPrefix = "depends-on:"
+
+// IsTaskRefLabel reports whether a label is a parent or depends-on label.
+// The task IDs they hold only mean something in Todu.
+func IsTaskRefLabel(name string) bool {
+	return strings.HasPrefix(name, ParentLabelPrefix) || strings.HasPrefix(name, DependsOnLabelPrefix)
+}
+
+// ParseTaskRef parses a task ID, as used in parent and depends-on labels.
+func ParseTaskRef(value string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid task ID %q", value)
+	}
+	return id, nil
+}
+
+// Parent returns the ID of the task's parent from its parent label, and
+// false if it has none or the label doesn't parse.
+func (t *Task) Parent() (int, bool) {
+	for _, label := range t.Labels {
+		if value, ok := strings.CutPrefix(label.Name, ParentLabelPrefix); ok {
+			if id, err := ParseTaskRef(value); err == nil {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// DependsOn returns the IDs of the tasks blocking the task, from its
+// depends-on labels, in label order. Labels that don't parse are skipped.
+func (t *Task) DependsOn() []int {
+	var ids []int
+	for _, label := range t.Labels {
+		if value, ok := strings.CutPrefix(label.Name, DependsOnLabelPrefix); ok {
+			if id, err := ParseTaskRef(value); err == nil && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// Dependencies returns the IDs of the tasks the task depends on: its parent
+// first, then its blockers.
+func (t *Task) Dependencies() []int {
+	var ids []int
+	if parent, ok := t.Parent(); ok {
+		ids = append(ids, parent)
+	}
+	for _, id := range t.DependsOn() {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// HasLabel reports whether the task has a label with the given name.
+func (t *Task) HasLabel(name string) bool {
+	for _, label := range t.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStarred reports whether the task has the starred label.
+func (t *Task) IsStarred() bool {
+	return t.HasLabel(StarredLabel)
+}
+
+// IsSomeday reports whether the task has the someday label.
+func (t *Task) IsSomeday() bool {
+	return t.HasLabel(SomedayLabel)
+}
+
+// SkippedLabel marks a canceled habit occurrence as skipped for a reason: an
+// excused miss that neither counts toward nor breaks a streak. The reason,
+// when given, follows SkippedLabelPrefix, as in "skipped:injured".
+const (
+	SkippedLabel       = "skipped"
+	SkippedLabelPrefix = SkippedLabel + ":"
+)
+
+// SkippedLabelFor returns the label that marks a task as skipped for reason.
+func SkippedLabelFor(reason string) string {
+	if reason = strings.TrimSpace(reason); reason == "" {
+		return SkippedLabel
+	}
+	return SkippedLabelPrefix + reason
+}
+
+// Skipped reports whether the task was skipped, and the reason given. Only
+// canceled tasks are skipped, so reopening a task takes back its skip.
+func (t *Task) Skipped() (reason string, ok bool) {
+	if t.Status != "canceled" {
+		return "", false
+	}
+	for _, label := range t.Labels {
+		if label.Name == SkippedLabel {
+			return "", true
+		}
+		if value, found := strings.CutPrefix(label.Name, SkippedLabelPrefix); found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
 // TaskCreate represents data for creating a new task
 type TaskCreate struct {
 	ExternalID    string     `json:"external_id"`
@@ -40,14 +288,33 @@ type TaskCreate struct {
 
 // TaskUpdate represents data for updating an existing task
 type TaskUpdate struct {
-	ExternalID   *string    `json:"external_id,omitempty"`
-	SourceURL    *string    `json:"source_url,omitempty"`
-	Title        *string    `json:"title,omitempty"`
-	Description  *string    `json:"description,omitempty"`
-	Status       *string    `json:"status,omitempty"`
-	Priority     *string    `json:"priority,omitempty"`
-	DueDate      *time.Time `json:"due_date,omitempty"`
-	LastPushedAt *time.Time `json:"last_pushed_at,omitempty"`
-	Labels       []string   `json:"labels,omitempty"`
-	Assignees    []string   `json:"assignees,omitempty"`
+	ExternalID    *string    `json:"external_id,omitempty"`
+	SourceURL     *string    `json:"source_url,omitempty"`
+	Title         *string    `json:"title,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	Status        *string    `json:"status,omitempty"`
+	Priority      *string    `json:"priority,omitempty"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	TemplateID    *int       `json:"template_id,omitempty"`
+	ScheduledDate *time.Time `json:"scheduled_date,omitempty"`
+	LastPushedAt  *time.Time `json:"last_pushed_at,omitempty"`
+	Labels        []string   `json:"labels,omitempty"`
+	Assignees     []string   `json:"assignees,omitempty"`
+
+	// ClearLabels sends an empty label set when Labels is empty, removing
+	// every label. Otherwise an empty Labels leaves the labels alone.
+	ClearLabels bool `json:"-"`
+}
+
+// MarshalJSON encodes the update, with an empty label set when
+// ClearLabels is set and there are no labels.
+func (u TaskUpdate) MarshalJSON() ([]byte, error) {
+	type update TaskUpdate
+	if !u.ClearLabels || len(u.Labels) > 0 {
+		return json.Marshal(update(u))
+	}
+	return json.Marshal(struct {
+		update
+		Labels []string `json:"labels"`
+	}{update(u), []string{}})
 }
@@ -1,6 +1,11 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // RecurringTaskTemplate represents a recurring task template with all fields
 type RecurringTaskTemplate struct {
@@ -21,6 +26,49 @@ type RecurringTaskTemplate struct {
 	Assignees      []Assignee `json:"assignees,omitempty"`
 }
 
+// HasLabel reports whether the template has a label with the given name.
+func (t *RecurringTaskTemplate) HasLabel(name string) bool {
+	for _, label := range t.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GraceLabelPrefix starts the label that holds a recurring template's
+// completion window: how many days after its scheduled date an occurrence
+// still counts as done on time, such as "grace:2d".
+const GraceLabelPrefix = "grace:"
+
+// ParseGrace parses a completion window in whole days, such as 2d or 2.
+func ParseGrace(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid completion window %q (use a number of days such as 2d)", value)
+	}
+	return days, nil
+}
+
+// GraceLabel returns the label that records a completion window of days.
+func GraceLabel(days int) string {
+	return fmt.Sprintf("%s%dd", GraceLabelPrefix, days)
+}
+
+// Grace returns the template's completion window in days from its grace
+// label, and false if it has none or the label doesn't parse.
+func (t *RecurringTaskTemplate) Grace() (int, bool) {
+	for _, label := range t.Labels {
+		if value, ok := strings.CutPrefix(label.Name, GraceLabelPrefix); ok {
+			if days, err := ParseGrace(value); err == nil {
+				return days, true
+			}
+		}
+	}
+	return 0, false
+}
+
 // RecurringTaskTemplateCreate represents data for creating a new recurring task template
 type RecurringTaskTemplateCreate struct {
 	ProjectID      int      `json:"project_id"`
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -139,6 +141,27 @@ func TestTaskUpdateMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestTaskUpdateClearLabels(t *testing.T) {
+	tests := []struct {
+		update TaskUpdate
+		want   string
+	}{
+		{TaskUpdate{}, `{}`},
+		{TaskUpdate{ClearLabels: true}, `{"labels":[]}`},
+		{TaskUpdate{Labels: []string{"bug"}, ClearLabels: true}, `{"labels":["bug"]}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(&tt.update)
+		if err != nil {
+			t.Fatalf("Failed to marshal task update: %v", err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, data)
+		}
+	}
+}
+
 func TestProjectMarshalJSON(t *testing.T) {
 	now := time.Now()
 	desc := "Test project description"
@@ -318,3 +341,324 @@ func TestJSONTagCorrectness(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskEstimate(t *testing.T) {
+	task := &Task{Labels: []Label{{Name: "work"}, {Name: "estimate:1h30m"}}}
+	if d, ok := task.Estimate(); !ok || d != 90*time.Minute {
+		t.Errorf("Estimate() = %v, %v, want 1h30m", d, ok)
+	}
+
+	for _, labels := range [][]Label{nil, {{Name: "estimate:soon"}}, {{Name: "estimate:-1h"}}} {
+		task := &Task{Labels: labels}
+		if d, ok := task.Estimate(); ok {
+			t.Errorf("Estimate() with labels %v = %v, want none", labels, d)
+		}
+	}
+
+	tests := map[time.Duration]string{
+		45 * time.Minute:  "estimate:45m",
+		2 * time.Hour:     "estimate:2h",
+		100 * time.Minute: "estimate:1h40m",
+	}
+	for d, want := range tests {
+		if got := EstimateLabel(d); got != want {
+			t.Errorf("EstimateLabel(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseReaction(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"👍", "👍", false},
+		{"+1", "👍", false},
+		{"Heart", "❤️", false},
+		{" rocket ", "🚀", false},
+		{"🦄", "🦄", false},
+		{"wow", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseReaction(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseReaction(%q) = %q, %v; want %q (error %v)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCommentReactionSummary(t *testing.T) {
+	comment := &Comment{Reactions: []Reaction{
+		{Emoji: "🎉", Author: "alice"},
+		{Emoji: "👍", Author: "alice"},
+		{Emoji: "🎉", Author: "bob"},
+	}}
+
+	if got := comment.ReactionSummary(); got != "🎉 2  👍 1" {
+		t.Errorf("ReactionSummary() = %q, want %q", got, "🎉 2  👍 1")
+	}
+	if !comment.HasReaction("👍", "alice") || comment.HasReaction("👍", "bob") {
+		t.Error("HasReaction did not match the reactions")
+	}
+	if got := (&Comment{}).ReactionSummary(); got != "" {
+		t.Errorf("Expected empty summary without reactions, got %q", got)
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	description := "Release steps:\n\n- [ ] write tests\n- [x] update docs\n\n```\n- [ ] not an item\n```\nNotes"
+
+	items := ParseChecklist(description)
+	if len(items) != 2 || items[0] != (ChecklistItem{Text: "write tests"}) || !items[1].Done {
+		t.Fatalf("ParseChecklist() = %+v", items)
+	}
+	if done, total := ChecklistProgress(items); done != 1 || total != 2 {
+		t.Errorf("ChecklistProgress() = %d/%d, want 1/2", done, total)
+	}
+
+	added, err := AddChecklistItem(description, "tag release")
+	if err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if !strings.Contains(added, "- [x] update docs\n- [ ] tag release\n") {
+		t.Errorf("Expected item after the checklist, got %q", added)
+	}
+
+	toggled, err := ToggleChecklistItem(added, 1)
+	if err != nil {
+		t.Fatalf("ToggleChecklistItem failed: %v", err)
+	}
+	toggled, _ = ToggleChecklistItem(toggled, 2)
+	items = ParseChecklist(toggled)
+	if !items[0].Done || items[1].Done || items[2].Done {
+		t.Errorf("Expected only the first item checked, got %+v", items)
+	}
+
+	removed, err := RemoveChecklistItem(toggled, 3)
+	if err != nil {
+		t.Fatalf("RemoveChecklistItem failed: %v", err)
+	}
+	if len(ParseChecklist(removed)) != 2 || strings.Contains(removed, "tag release") {
+		t.Errorf("Expected the third item removed, got %q", removed)
+	}
+
+	if _, err := ToggleChecklistItem(description, 3); err == nil {
+		t.Error("Expected error for a missing item")
+	}
+	if got, _ := AddChecklistItem("", "first"); got != "- [ ] first" {
+		t.Errorf("AddChecklistItem on empty description = %q", got)
+	}
+	if _, err := AddChecklistItem("", "two\nlines"); err == nil {
+		t.Error("Expected error for a multi-line item")
+	}
+}
+
+func TestLabelColorAndEmoji(t *testing.T) {
+	tests := map[string]string{"#D73A4A": "d73a4a", "fc0": "ffcc00", "": ""}
+	for input, want := range tests {
+		if got, err := NormalizeLabelColor(input); err != nil || got != want {
+			t.Errorf("NormalizeLabelColor(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"red", "#12345", "#gggggg"} {
+		if _, err := NormalizeLabelColor(input); err == nil {
+			t.Errorf("NormalizeLabelColor(%q): expected error", input)
+		}
+	}
+
+	if got := (Label{Name: "bug", Emoji: "🐛"}).DisplayName(); got != "🐛 bug" {
+		t.Errorf("Expected emoji before name, got %q", got)
+	}
+	if got := (Label{Name: "bug"}).DisplayName(); got != "bug" {
+		t.Errorf("Expected plain name, got %q", got)
+	}
+}
+
+func TestPriorities(t *testing.T) {
+	t.Cleanup(func() { _ = SetPriorities(nil) })
+	ptr := func(s string) *string { return &s }
+
+	if err := ValidatePriority("urgent"); err == nil || err.Error() != `invalid priority "urgent": must be low, medium, or high` {
+		t.Errorf("Expected urgent to be invalid by default, got %v", err)
+	}
+	if DefaultPriority() != "medium" || !IsHighPriority(ptr("high")) {
+		t.Error("Expected default levels")
+	}
+
+	if err := SetPriorities([]string{"none", "Low", "medium", "high", "urgent"}); err != nil {
+		t.Fatalf("SetPriorities failed: %v", err)
+	}
+	if err := ValidatePriority("urgent"); err != nil {
+		t.Errorf("Expected urgent to be valid: %v", err)
+	}
+	if PriorityRank(ptr("urgent")) != 5 || PriorityRank(ptr("none")) != 1 || PriorityRank(ptr("low")) != 2 || PriorityRank(nil) != 0 {
+		t.Error("Expected ranks to follow the configured order")
+	}
+	if !IsHighPriority(ptr("urgent")) || IsHighPriority(ptr("medium")) {
+		t.Error("Expected urgent to count as high priority")
+	}
+
+	if err := SetPriorities([]string{"p3", "p2", "p1"}); err != nil {
+		t.Fatalf("SetPriorities failed: %v", err)
+	}
+	if DefaultPriority() != "p2" || !IsHighPriority(ptr("p1")) || IsHighPriority(ptr("p2")) {
+		t.Error("Expected the middle level as default and only the top level as high")
+	}
+
+	for _, levels := range [][]string{{"low", "LOW"}, {"low", ""}, {"very high"}} {
+		if err := SetPriorities(levels); err == nil {
+			t.Errorf("SetPriorities(%q): expected error", levels)
+		}
+	}
+}
+
+func TestReminderOffsets(t *testing.T) {
+	tests := map[string]time.Duration{"1w": 7 * 24 * time.Hour, "2d": 48 * time.Hour, "1h30m": 90 * time.Minute, "0": 0}
+	for input, want := range tests {
+		if got, err := ParseReminderOffset(input); err != nil || got != want {
+			t.Errorf("ParseReminderOffset(%q) = %v, %v, want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "soon", "-1h", "1.5d"} {
+		if _, err := ParseReminderOffset(input); err == nil {
+			t.Errorf("ParseReminderOffset(%q): expected error", input)
+		}
+	}
+
+	if got := ReminderLabel(48 * time.Hour); got != "remind:2d" {
+		t.Errorf("ReminderLabel(48h) = %q", got)
+	}
+	if got := ReminderLabel(90 * time.Minute); got != "remind:1h30m" {
+		t.Errorf("ReminderLabel(90m) = %q", got)
+	}
+
+	task := &Task{Labels: []Label{{Name: "remind:1h"}, {Name: "bug"}, {Name: "remind:1d"}, {Name: "remind:later"}, {Name: "remind:60m"}}}
+	offsets := task.ReminderOffsets()
+	if len(offsets) != 2 || offsets[0] != 24*time.Hour || offsets[1] != time.Hour {
+		t.Errorf("Expected 1d and 1h, largest first, got %v", offsets)
+	}
+}
+
+func TestFields(t *testing.T) {
+	key, value, err := ParseField("story-points = 3")
+	if err != nil || key != "story-points" || value != "3" {
+		t.Errorf("ParseField = %q, %q, %v", key, value, err)
+	}
+	if key, value, err := ParseField("customer="); err != nil || key != "customer" || value != "" {
+		t.Errorf("Expected an empty value to parse, got %q, %q, %v", key, value, err)
+	}
+	for _, input := range []string{"customer", "=acme", "Customer=acme", "-x=1", "a b=1", "notes=one\ntwo"} {
+		if _, _, err := ParseField(input); err == nil {
+			t.Errorf("ParseField(%q): expected error", input)
+		}
+	}
+
+	if got := FieldLabel("customer", "acme"); got != "field:customer=acme" {
+		t.Errorf("FieldLabel = %q", got)
+	}
+
+	task := &Task{Labels: []Label{
+		{Name: "field:customer=acme"},
+		{Name: "bug"},
+		{Name: "field:story-points=3"},
+		{Name: "field:url=https://example.com/?a=b"},
+		{Name: "field:broken"},
+		{Name: "field:empty="},
+	}}
+	fields := task.Fields()
+	if len(fields) != 3 || fields["customer"] != "acme" || fields["story-points"] != "3" || fields["url"] != "https://example.com/?a=b" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if keys := FieldKeys(fields); strings.Join(keys, ",") != "customer,story-points,url" {
+		t.Errorf("FieldKeys = %v", keys)
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	for input, want := range map[string]int{"12": 12, " 3 ": 3} {
+		if got, err := ParseTaskRef(input); err != nil || got != want {
+			t.Errorf("ParseTaskRef(%q) = %d, %v, want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "0", "-2", "#7", "abc"} {
+		if _, err := ParseTaskRef(input); err == nil {
+			t.Errorf("ParseTaskRef(%q): expected error", input)
+		}
+	}
+
+	task := &Task{Labels: []Label{{Name: "depends-on:4"}, {Name: "parent:x"}, {Name: "depends-on:2"}, {Name: "parent:2"}, {Name: "depends-on:4"}}}
+	if parent, ok := task.Parent(); !ok || parent != 2 {
+		t.Errorf("Parent() = %d, %v, want 2", parent, ok)
+	}
+	if got := task.DependsOn(); fmt.Sprint(got) != "[4 2]" {
+		t.Errorf("DependsOn() = %v, want [4 2]", got)
+	}
+	if got := task.Dependencies(); fmt.Sprint(got) != "[2 4]" {
+		t.Errorf("Dependencies() = %v, want [2 4]", got)
+	}
+	if _, ok := (&Task{}).Parent(); ok {
+		t.Error("Expected no parent for a task without labels")
+	}
+
+	for name, want := range map[string]bool{"parent:2": true, "depends-on:4": true, "blocked-by:#3": false, "parents": false} {
+		if got := IsTaskRefLabel(name); got != want {
+			t.Errorf("IsTaskRefLabel(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSkipped(t *testing.T) {
+	if got := SkippedLabelFor(" injured "); got != "skipped:injured" {
+		t.Errorf("SkippedLabelFor() = %q, want skipped:injured", got)
+	}
+	if got := SkippedLabelFor(""); got != "skipped" {
+		t.Errorf("SkippedLabelFor(\"\") = %q, want skipped", got)
+	}
+
+	tests := []struct {
+		status string
+		labels []Label
+		reason string
+		ok     bool
+	}{
+		{"canceled", []Label{{Name: "health"}, {Name: "skipped:injured"}}, "injured", true},
+		{"canceled", []Label{{Name: "skipped"}}, "", true},
+		{"canceled", []Label{{Name: "skipped-weekends"}}, "", false},
+		{"canceled", nil, "", false},
+		{"active", []Label{{Name: "skipped:injured"}}, "", false},
+	}
+	for _, tt := range tests {
+		reason, ok := (&Task{Status: tt.status, Labels: tt.labels}).Skipped()
+		if reason != tt.reason || ok != tt.ok {
+			t.Errorf("Skipped() of a %s task with %v = %q, %v, want %q, %v", tt.status, tt.labels, reason, ok, tt.reason, tt.ok)
+		}
+	}
+}
+
+func TestTemplateGrace(t *testing.T) {
+	for value, want := range map[string]int{"2d": 2, "2": 2, " 0d ": 0} {
+		if got, err := ParseGrace(value); err != nil || got != want {
+			t.Errorf("ParseGrace(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "-1d", "2h", "two"} {
+		if _, err := ParseGrace(value); err == nil {
+			t.Errorf("ParseGrace(%q) expected an error", value)
+		}
+	}
+	if got := GraceLabel(2); got != "grace:2d" {
+		t.Errorf("GraceLabel(2) = %q, want grace:2d", got)
+	}
+
+	tmpl := &RecurringTaskTemplate{Labels: []Label{{Name: "review"}, {Name: "grace:3d"}}}
+	if got, ok := tmpl.Grace(); got != 3 || !ok {
+		t.Errorf("Grace() = %d, %v, want 3, true", got, ok)
+	}
+	tmpl.Labels = []Label{{Name: "grace:soon"}}
+	if got, ok := tmpl.Grace(); ok {
+		t.Errorf("Grace() with a bad label = %d, want none", got)
+	}
+}
@@ -0,0 +1,456 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/httpcache"
+)
+
+// defaultHost is the Azure DevOps Services host; the organization follows it.
+const defaultHost = "https://dev.azure.com"
+
+// apiVersion is the REST API version sent with every request. The comments
+// API is still in preview, so it has its own version.
+const (
+	apiVersion         = "7.1"
+	commentsAPIVersion = "7.1-preview.4"
+)
+
+// projectsPerPage is the number of projects requested per page.
+const projectsPerPage = 100
+
+// workItemsPerBatch is the most work items Azure DevOps returns per batch.
+const workItemsPerBatch = 200
+
+// commentsPerPage is the number of comments requested per page.
+const commentsPerPage = 200
+
+// workItemFields are the fields fetched for every work item.
+var workItemFields = []string{
+	fieldID, fieldTitle, fieldDescription, fieldState, fieldWorkItemType,
+	fieldAreaPath, fieldAssignedTo, fieldTags, fieldCreatedDate,
+	fieldChangedDate, fieldPriority, fieldTeamProject,
+}
+
+// Work item field reference names
+const (
+	fieldID           = "System.Id"
+	fieldTitle        = "System.Title"
+	fieldDescription  = "System.Description"
+	fieldState        = "System.State"
+	fieldWorkItemType = "System.WorkItemType"
+	fieldAreaPath     = "System.AreaPath"
+	fieldAssignedTo   = "System.AssignedTo"
+	fieldTags         = "System.Tags"
+	fieldCreatedDate  = "System.CreatedDate"
+	fieldChangedDate  = "System.ChangedDate"
+	fieldPriority     = "Microsoft.VSTS.Common.Priority"
+	fieldTeamProject  = "System.TeamProject"
+)
+
+// Azure DevOps API response types
+
+// Project represents an Azure DevOps project.
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// AreaNode represents a node of a project's area path tree.
+type AreaNode struct {
+	Name     string      `json:"name"`
+	Path     string      `json:"path"`
+	Children []*AreaNode `json:"children"`
+}
+
+// WorkItem represents an Azure DevOps work item.
+type WorkItem struct {
+	ID     int            `json:"id"`
+	Rev    int            `json:"rev"`
+	Fields WorkItemFields `json:"fields"`
+}
+
+// WorkItemFields holds the work item fields todu uses.
+type WorkItemFields struct {
+	Title        string       `json:"System.Title"`
+	Description  string       `json:"System.Description"`
+	State        string       `json:"System.State"`
+	WorkItemType string       `json:"System.WorkItemType"`
+	AreaPath     string       `json:"System.AreaPath"`
+	AssignedTo   *IdentityRef `json:"System.AssignedTo"`
+	Tags         string       `json:"System.Tags"`
+	CreatedDate  time.Time    `json:"System.CreatedDate"`
+	ChangedDate  time.Time    `json:"System.ChangedDate"`
+	Priority     *json.Number `json:"Microsoft.VSTS.Common.Priority"`
+	TeamProject  string       `json:"System.TeamProject"`
+}
+
+// IdentityRef represents an Azure DevOps user.
+type IdentityRef struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// State represents one of a work item type's states.
+type State struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// Comment represents a comment in a work item's discussion.
+type Comment struct {
+	ID           int          `json:"id"`
+	Text         string       `json:"text"`
+	CreatedBy    *IdentityRef `json:"createdBy"`
+	CreatedDate  time.Time    `json:"createdDate"`
+	ModifiedDate time.Time    `json:"modifiedDate"`
+}
+
+// PatchOperation is one operation of a JSON Patch document, which is how
+// work items are created and updated.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// commentRequest represents the request body for creating or editing a
+// comment.
+type commentRequest struct {
+	Text string `json:"text"`
+}
+
+// client wraps the Azure DevOps REST API with an HTTP client and a cache
+// of work item type states.
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	mu sync.Mutex
+
+	// states caches each work item type's states, by "project/type"
+	states map[string][]*State
+}
+
+// newClient creates a new Azure DevOps API client. The base URL is the
+// url setting, such as an Azure DevOps Server collection URL, or else the
+// organization's URL on dev.azure.com.
+func newClient(config map[string]string) (*client, error) {
+	token := strings.TrimSpace(config["token"])
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	baseURL := strings.TrimSpace(config["url"])
+	if baseURL == "" {
+		organization := strings.TrimSpace(config["organization"])
+		if organization == "" {
+			return nil, fmt.Errorf("organization or url is required")
+		}
+		baseURL = defaultHost + "/" + url.PathEscape(organization)
+	}
+
+	// Normalize base URL (remove trailing slash)
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: httpcache.NewTransport(nil)},
+		states:     make(map[string][]*State),
+	}, nil
+}
+
+// doRequest performs an HTTP request with authentication and decodes the
+// JSON response into result, unless result is nil. path is relative to
+// the base URL and may include a query; version is added to it as the
+// api-version.
+func (c *client) doRequest(ctx context.Context, method, path, version string, body, result interface{}) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	fullURL := c.baseURL + path + separator + "api-version=" + version
+
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Personal access tokens are sent as the password of basic auth, with
+	// an empty username
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+c.token)))
+	req.Header.Set("Accept", "application/json")
+	if _, ok := body.([]PatchOperation); ok {
+		req.Header.Set("Content-Type", "application/json-patch+json")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors. A bad token can get a 203 with a sign-in page
+	// rather than a 401.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return fmt.Errorf("API error %d: the token was not accepted", http.StatusUnauthorized)
+	}
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// getCurrentUser retrieves the account name of the user the token belongs
+// to, falling back to the display name.
+func (c *client) getCurrentUser(ctx context.Context) (string, error) {
+	var result struct {
+		AuthenticatedUser struct {
+			ProviderDisplayName string `json:"providerDisplayName"`
+			Properties          struct {
+				Account struct {
+					Value string `json:"$value"`
+				} `json:"Account"`
+			} `json:"properties"`
+		} `json:"authenticatedUser"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, "/_apis/connectionData", apiVersion, nil, &result); err != nil {
+		return "", err
+	}
+	user := result.AuthenticatedUser
+	if user.Properties.Account.Value != "" {
+		return user.Properties.Account.Value, nil
+	}
+	if user.ProviderDisplayName == "" {
+		return "", fmt.Errorf("no authenticated user in response")
+	}
+	return user.ProviderDisplayName, nil
+}
+
+// listProjects retrieves every project in the organization.
+func (c *client) listProjects(ctx context.Context) ([]*Project, error) {
+	var allProjects []*Project
+	for skip := 0; ; skip += projectsPerPage {
+		var result struct {
+			Value []*Project `json:"value"`
+		}
+		path := fmt.Sprintf("/_apis/projects?$top=%d&$skip=%d", projectsPerPage, skip)
+		if err := c.doRequest(ctx, http.MethodGet, path, apiVersion, nil, &result); err != nil {
+			return nil, err
+		}
+		allProjects = append(allProjects, result.Value...)
+
+		if len(result.Value) < projectsPerPage {
+			break
+		}
+	}
+	return allProjects, nil
+}
+
+// getProject retrieves a single project by name or ID.
+func (c *client) getProject(ctx context.Context, project string) (*Project, error) {
+	var result Project
+	if err := c.doRequest(ctx, http.MethodGet, "/_apis/projects/"+url.PathEscape(project), apiVersion, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// getAreas retrieves a project's area path tree.
+func (c *client) getAreas(ctx context.Context, project string, depth int) (*AreaNode, error) {
+	var result AreaNode
+	path := fmt.Sprintf("/%s/_apis/wit/classificationnodes/areas?$depth=%d", url.PathEscape(project), depth)
+	if err := c.doRequest(ctx, http.MethodGet, path, apiVersion, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// queryWorkItemIDs runs a WIQL query in a project and returns the IDs of
+// the work items it matches.
+func (c *client) queryWorkItemIDs(ctx context.Context, project, query string) ([]int, error) {
+	var result struct {
+		WorkItems []struct {
+			ID int `json:"id"`
+		} `json:"workItems"`
+	}
+	// timePrecision compares dates to the second rather than the day
+	path := "/" + url.PathEscape(project) + "/_apis/wit/wiql?timePrecision=true"
+	if err := c.doRequest(ctx, http.MethodPost, path, apiVersion, map[string]string{"query": query}, &result); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(result.WorkItems))
+	for i, item := range result.WorkItems {
+		ids[i] = item.ID
+	}
+	return ids, nil
+}
+
+// getWorkItems retrieves work items by ID, a batch at a time, in the order
+// of ids.
+func (c *client) getWorkItems(ctx context.Context, project string, ids []int) ([]*WorkItem, error) {
+	var allItems []*WorkItem
+	for start := 0; start < len(ids); start += workItemsPerBatch {
+		batch := ids[start:min(start+workItemsPerBatch, len(ids))]
+
+		var result struct {
+			Value []*WorkItem `json:"value"`
+		}
+		body := map[string]interface{}{
+			"ids":    batch,
+			"fields": workItemFields,
+		}
+		path := "/" + url.PathEscape(project) + "/_apis/wit/workitemsbatch"
+		if err := c.doRequest(ctx, http.MethodPost, path, apiVersion, body, &result); err != nil {
+			return nil, err
+		}
+		allItems = append(allItems, result.Value...)
+	}
+	return allItems, nil
+}
+
+// workItemPath returns the path of a work item, in project when it's known.
+func workItemPath(project string, id string) string {
+	if project == "" {
+		return "/_apis/wit/workitems/" + url.PathEscape(id)
+	}
+	return "/" + url.PathEscape(project) + "/_apis/wit/workitems/" + url.PathEscape(id)
+}
+
+// getWorkItem retrieves a single work item.
+func (c *client) getWorkItem(ctx context.Context, project, id string) (*WorkItem, error) {
+	var item WorkItem
+	path := workItemPath(project, id) + "?fields=" + url.QueryEscape(strings.Join(workItemFields, ","))
+	if err := c.doRequest(ctx, http.MethodGet, path, apiVersion, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// createWorkItem creates a work item of the given type.
+func (c *client) createWorkItem(ctx context.Context, project, workItemType string, ops []PatchOperation) (*WorkItem, error) {
+	var item WorkItem
+	path := "/" + url.PathEscape(project) + "/_apis/wit/workitems/$" + url.PathEscape(workItemType)
+	if err := c.doRequest(ctx, http.MethodPost, path, apiVersion, ops, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// updateWorkItem applies patch operations to a work item.
+func (c *client) updateWorkItem(ctx context.Context, project, id string, ops []PatchOperation) (*WorkItem, error) {
+	var item WorkItem
+	if err := c.doRequest(ctx, http.MethodPatch, workItemPath(project, id), apiVersion, ops, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// getStates retrieves a work item type's states, cached per project.
+func (c *client) getStates(ctx context.Context, project, workItemType string) ([]*State, error) {
+	key := project + "/" + workItemType
+	c.mu.Lock()
+	states, ok := c.states[key]
+	c.mu.Unlock()
+	if ok {
+		return states, nil
+	}
+
+	var result struct {
+		Value []*State `json:"value"`
+	}
+	path := fmt.Sprintf("/%s/_apis/wit/workitemtypes/%s/states", url.PathEscape(project), url.PathEscape(workItemType))
+	if err := c.doRequest(ctx, http.MethodGet, path, apiVersion, nil, &result); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.states[key] = result.Value
+	c.mu.Unlock()
+	return result.Value, nil
+}
+
+// commentsPath returns the path of a work item's comments.
+func commentsPath(project, id string) string {
+	return "/" + url.PathEscape(project) + "/_apis/wit/workItems/" + url.PathEscape(id) + "/comments"
+}
+
+// listComments retrieves every comment on a work item, oldest first.
+func (c *client) listComments(ctx context.Context, project, id string) ([]*Comment, error) {
+	var allComments []*Comment
+	params := url.Values{}
+	params.Set("$top", strconv.Itoa(commentsPerPage))
+	params.Set("order", "asc")
+
+	for {
+		var result struct {
+			Comments          []*Comment `json:"comments"`
+			ContinuationToken string     `json:"continuationToken"`
+		}
+		path := commentsPath(project, id) + "?" + params.Encode()
+		if err := c.doRequest(ctx, http.MethodGet, path, commentsAPIVersion, nil, &result); err != nil {
+			return nil, err
+		}
+		allComments = append(allComments, result.Comments...)
+
+		if result.ContinuationToken == "" {
+			break
+		}
+		params.Set("continuationToken", result.ContinuationToken)
+	}
+	return allComments, nil
+}
+
+// createComment adds a comment to a work item's discussion.
+func (c *client) createComment(ctx context.Context, project, id, text string) (*Comment, error) {
+	var comment Comment
+	if err := c.doRequest(ctx, http.MethodPost, commentsPath(project, id), commentsAPIVersion, &commentRequest{Text: text}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// editComment replaces the text of a comment.
+func (c *client) editComment(ctx context.Context, project, id, commentID, text string) (*Comment, error) {
+	var comment Comment
+	path := commentsPath(project, id) + "/" + url.PathEscape(commentID)
+	if err := c.doRequest(ctx, http.MethodPatch, path, commentsAPIVersion, &commentRequest{Text: text}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
@@ -0,0 +1,7 @@
+module github.com/evcraddock/todu.sh/plugins/azuredevops
+
+go 1.24.6
+
+require github.com/evcraddock/todu.sh v0.1.0
+
+replace github.com/evcraddock/todu.sh => ../..
@@ -0,0 +1,443 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// mapper.go contains functions for converting between Azure DevOps API types and Todu types.
+//
+// Mappings:
+//   - Azure DevOps Project → Todu Project (external_id = project name)
+//   - Area Path → Todu Project (external_id = area path, such as "Project\Team"),
+//     covering the work items under it
+//   - Work Item (bug, user story, task, ...) → Todu Task (external_id = work item ID)
+//   - Work Item Type → Todu Label ("type:<type>", such as "type:Bug")
+//   - State → Todu Status (by state category; see below)
+//   - Priority (1-4) → Todu Priority
+//   - Tags → Todu Labels
+//   - Assigned To → Todu Assignee (by unique name)
+//   - Discussion Comments → Todu Comments (1:1 mapping)
+//
+// Status Mapping (Azure DevOps → Todu):
+// Each work item type's states belong to a category, whatever they're named:
+//   - Proposed   → active
+//   - InProgress → inprogress
+//   - Resolved   → waiting
+//   - Completed  → done
+//   - Removed    → canceled
+//
+// Status Mapping (Todu → Azure DevOps):
+// The work item type's first state in the category is used:
+//   - active     → Proposed
+//   - inprogress → InProgress
+//   - waiting    → Resolved, else InProgress
+//   - done       → Completed
+//   - canceled   → Removed, else Completed
+//
+// Priority Mapping:
+//   - 1 ↔ "high" if it is a priority level, else the highest level
+//   - 2 ↔ "medium" or "normal" if it is a level, else the default priority
+//   - 3 ↔ "low" if it is a level, else the lowest level
+//   - 4 ↔ the lowest level, when that isn't "low"
+//
+// Descriptions and comments are HTML in Azure DevOps and plain text in Todu.
+
+// typeLabelPrefix starts the reserved label holding a work item's type. It
+// is never pushed as a tag; on a new task it picks the type to create.
+const typeLabelPrefix = "type:"
+
+// State categories
+const (
+	categoryProposed   = "Proposed"
+	categoryInProgress = "InProgress"
+	categoryResolved   = "Resolved"
+	categoryCompleted  = "Completed"
+	categoryRemoved    = "Removed"
+)
+
+// parseProjectExternalID parses a project external ID, which is a project
+// name or an area path under it such as "Project\Team", into the project
+// and the area path, which is empty for a whole project.
+func parseProjectExternalID(externalID string) (project, areaPath string, err error) {
+	externalID = strings.Trim(strings.TrimSpace(externalID), `\`)
+	project, _, nested := strings.Cut(externalID, `\`)
+	if project == "" || strings.ContainsAny(project, "/") {
+		return "", "", fmt.Errorf(`invalid project external_id format: expected 'project' or 'project\area', got %q`, externalID)
+	}
+	if nested {
+		areaPath = externalID
+	}
+	return project, areaPath, nil
+}
+
+// projectToProject converts an Azure DevOps project to a Todu project.
+func projectToProject(project *Project) *types.Project {
+	result := &types.Project{
+		ExternalID: project.Name,
+		Name:       project.Name,
+		Status:     "active",
+	}
+	if project.Description != "" {
+		description := project.Description
+		result.Description = &description
+	}
+	return result
+}
+
+// areaPath converts a classification node path, such as
+// "\Project\Area\Team", to the area path work items use, "Project\Team".
+func areaPath(nodePath string) string {
+	segments := strings.Split(strings.Trim(nodePath, `\`), `\`)
+	if len(segments) >= 2 && segments[1] == "Area" {
+		segments = append(segments[:1], segments[2:]...)
+	}
+	return strings.Join(segments, `\`)
+}
+
+// areaToProject converts an area path to a Todu project named after it.
+func areaToProject(path string) *types.Project {
+	name := strings.ReplaceAll(path, `\`, " / ")
+	return &types.Project{
+		ExternalID: path,
+		Name:       name,
+		Status:     "active",
+	}
+}
+
+// workItemURL returns the web page of a work item.
+func workItemURL(baseURL, project string, id int) string {
+	return fmt.Sprintf("%s/%s/_workitems/edit/%d", baseURL, strings.ReplaceAll(project, " ", "%20"), id)
+}
+
+// workItemToTask converts a work item to a Todu task, given its type's
+// states.
+func workItemToTask(item *WorkItem, states []*State, baseURL string) *types.Task {
+	fields := item.Fields
+
+	var description *string
+	if text := htmlToText(fields.Description); text != "" {
+		description = &text
+	}
+
+	var sourceURL *string
+	if baseURL != "" && fields.TeamProject != "" {
+		url := workItemURL(baseURL, fields.TeamProject, item.ID)
+		sourceURL = &url
+	}
+
+	labels := extractTags(fields.Tags)
+	if fields.WorkItemType != "" {
+		labels = append(labels, types.Label{Name: typeLabelPrefix + fields.WorkItemType})
+	}
+
+	var assignees []types.Assignee
+	if fields.AssignedTo != nil && fields.AssignedTo.UniqueName != "" {
+		assignees = []types.Assignee{{Name: fields.AssignedTo.UniqueName}}
+	}
+
+	return &types.Task{
+		ExternalID:  fmt.Sprint(item.ID),
+		SourceURL:   sourceURL,
+		Title:       fields.Title,
+		Description: description,
+		Status:      mapStateToTodu(fields.State, states),
+		Priority:    mapPriorityToTodu(fields.Priority),
+		CreatedAt:   fields.CreatedDate,
+		UpdatedAt:   fields.ChangedDate,
+		Labels:      labels,
+		Assignees:   assignees,
+	}
+}
+
+// extractTags splits a work item's tags, which Azure DevOps separates with
+// semicolons.
+func extractTags(tags string) []types.Label {
+	var labels []types.Label
+	for _, tag := range strings.Split(tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			labels = append(labels, types.Label{Name: tag})
+		}
+	}
+	return labels
+}
+
+// splitReservedLabels separates the work item type label from the labels
+// that are tags.
+func splitReservedLabels(labels []string) (tags []string, workItemType string) {
+	for _, label := range labels {
+		if value, ok := strings.CutPrefix(label, typeLabelPrefix); ok {
+			workItemType = strings.TrimSpace(value)
+			continue
+		}
+		tags = append(tags, label)
+	}
+	return tags, workItemType
+}
+
+// sameTags reports whether a work item's tags are the labels given,
+// ignoring order and case as Azure DevOps does.
+func sameTags(current string, tags []string) bool {
+	var existing []string
+	for _, label := range extractTags(current) {
+		existing = append(existing, strings.ToLower(label.Name))
+	}
+	var wanted []string
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" && !slices.Contains(wanted, tag) {
+			wanted = append(wanted, tag)
+		}
+	}
+	slices.Sort(existing)
+	slices.Sort(wanted)
+	return slices.Equal(existing, wanted)
+}
+
+// mapStateToTodu maps a work item state to a todu status by the state's
+// category, falling back to the state names of the standard processes.
+func mapStateToTodu(state string, states []*State) string {
+	category := ""
+	for _, s := range states {
+		if strings.EqualFold(s.Name, state) {
+			category = s.Category
+			break
+		}
+	}
+
+	switch category {
+	case categoryProposed:
+		return "active"
+	case categoryInProgress:
+		return "inprogress"
+	case categoryResolved:
+		return "waiting"
+	case categoryCompleted:
+		return "done"
+	case categoryRemoved:
+		return "canceled"
+	}
+
+	switch strings.ToLower(state) {
+	case "active", "committed", "in progress", "doing", "open":
+		return "inprogress"
+	case "resolved":
+		return "waiting"
+	case "closed", "done":
+		return "done"
+	case "removed":
+		return "canceled"
+	default:
+		return "active"
+	}
+}
+
+// mapToduStatusToState maps a todu status to the first of the work item
+// type's states in the matching category, or "" if none fits.
+func mapToduStatusToState(status string, states []*State) string {
+	inCategory := func(category string) string {
+		for _, s := range states {
+			if s.Category == category {
+				return s.Name
+			}
+		}
+		return ""
+	}
+	firstOf := func(categories ...string) string {
+		for _, category := range categories {
+			if name := inCategory(category); name != "" {
+				return name
+			}
+		}
+		return ""
+	}
+
+	switch status {
+	case "inprogress":
+		return firstOf(categoryInProgress)
+	case "waiting":
+		return firstOf(categoryResolved, categoryInProgress)
+	case "done":
+		return firstOf(categoryCompleted)
+	case "canceled":
+		return firstOf(categoryRemoved, categoryCompleted)
+	default:
+		return firstOf(categoryProposed)
+	}
+}
+
+// mapPriorityToTodu maps an Azure DevOps priority to a todu priority level.
+func mapPriorityToTodu(priority *json.Number) *string {
+	if priority == nil {
+		return nil
+	}
+
+	levels := types.Priorities()
+	pick := func(names ...string) string {
+		for _, name := range names {
+			if slices.Contains(levels, name) {
+				return name
+			}
+		}
+		return ""
+	}
+
+	var level string
+	switch priority.String() {
+	case "1":
+		if level = pick("high"); level == "" {
+			level = levels[len(levels)-1]
+		}
+	case "2":
+		if level = pick("medium", "normal"); level == "" {
+			level = types.DefaultPriority()
+		}
+	case "3":
+		if level = pick("low"); level == "" {
+			level = levels[0]
+		}
+	case "4":
+		level = levels[0]
+	default:
+		return nil
+	}
+	return &level
+}
+
+// mapToduPriorityToADO maps a todu priority level to an Azure DevOps
+// priority.
+func mapToduPriorityToADO(priority string) int {
+	switch strings.ToLower(priority) {
+	case "urgent", "critical", "high":
+		return 1
+	case "medium", "normal":
+		return 2
+	case "low":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// blockTags matches the HTML tags that end a line.
+var blockTags = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`)
+
+// anyTag matches any HTML tag.
+var anyTag = regexp.MustCompile(`<[^>]*>`)
+
+// htmlToText converts the HTML of a description or comment to plain text.
+func htmlToText(body string) string {
+	text := blockTags.ReplaceAllString(body, "\n")
+	text = anyTag.ReplaceAllString(text, "")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
+
+// textToHTML converts plain text to HTML that htmlToText turns back into
+// the same text.
+func textToHTML(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// fieldOp returns the patch operation that sets a field.
+func fieldOp(field string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "add", Path: "/fields/" + field, Value: value}
+}
+
+// taskCreateToOps converts a Todu TaskCreate to the patch operations that
+// create a work item, in areaPath when it isn't empty. The state is left
+// to the work item type's initial state.
+func taskCreateToOps(task *types.TaskCreate, areaPath string) []PatchOperation {
+	tags, _ := splitReservedLabels(task.Labels)
+
+	ops := []PatchOperation{fieldOp(fieldTitle, task.Title)}
+	if task.Description != nil && *task.Description != "" {
+		ops = append(ops, fieldOp(fieldDescription, textToHTML(*task.Description)))
+	}
+	if areaPath != "" {
+		ops = append(ops, fieldOp(fieldAreaPath, areaPath))
+	}
+	if task.Priority != nil {
+		ops = append(ops, fieldOp(fieldPriority, mapToduPriorityToADO(*task.Priority)))
+	}
+	if len(tags) > 0 {
+		ops = append(ops, fieldOp(fieldTags, strings.Join(tags, "; ")))
+	}
+	if len(task.Assignees) > 0 {
+		ops = append(ops, fieldOp(fieldAssignedTo, task.Assignees[0]))
+	}
+	return ops
+}
+
+// taskUpdateToOps converts a Todu TaskUpdate to the patch operations that
+// update a work item, given its current fields and its type's states.
+// Fields whose current value already maps to the update are left alone, so
+// a state or priority with no exact Todu equivalent isn't rewritten.
+func taskUpdateToOps(task *types.TaskUpdate, current *WorkItem, states []*State) []PatchOperation {
+	fields := current.Fields
+	var ops []PatchOperation
+
+	if task.Title != nil && *task.Title != fields.Title {
+		ops = append(ops, fieldOp(fieldTitle, *task.Title))
+	}
+
+	if task.Description != nil && *task.Description != htmlToText(fields.Description) {
+		ops = append(ops, fieldOp(fieldDescription, textToHTML(*task.Description)))
+	}
+
+	if task.Status != nil && *task.Status != mapStateToTodu(fields.State, states) {
+		if state := mapToduStatusToState(*task.Status, states); state != "" && state != fields.State {
+			ops = append(ops, fieldOp(fieldState, state))
+		}
+	}
+
+	if task.Priority != nil {
+		if current := mapPriorityToTodu(fields.Priority); current == nil || *current != *task.Priority {
+			ops = append(ops, fieldOp(fieldPriority, mapToduPriorityToADO(*task.Priority)))
+		}
+	}
+
+	// Update tags when a full label set was provided
+	if len(task.Labels) > 0 {
+		tags, _ := splitReservedLabels(task.Labels)
+		if !sameTags(fields.Tags, tags) {
+			ops = append(ops, fieldOp(fieldTags, strings.Join(tags, "; ")))
+		}
+	}
+
+	if len(task.Assignees) > 0 {
+		if fields.AssignedTo == nil || !strings.EqualFold(fields.AssignedTo.UniqueName, task.Assignees[0]) {
+			ops = append(ops, fieldOp(fieldAssignedTo, task.Assignees[0]))
+		}
+	}
+
+	return ops
+}
+
+// commentToComment converts a work item comment to a Todu comment.
+func commentToComment(comment *Comment) *types.Comment {
+	var author string
+	if comment.CreatedBy != nil {
+		author = comment.CreatedBy.UniqueName
+		if author == "" {
+			author = comment.CreatedBy.DisplayName
+		}
+	}
+
+	updated := comment.ModifiedDate
+	if updated.IsZero() {
+		updated = comment.CreatedDate
+	}
+
+	return &types.Comment{
+		ExternalID: fmt.Sprint(comment.ID),
+		Content:    htmlToText(comment.Text),
+		Author:     author,
+		CreatedAt:  comment.CreatedDate,
+		UpdatedAt:  updated,
+	}
+}
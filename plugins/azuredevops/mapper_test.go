@@ -0,0 +1,291 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// agileTaskStates are the states of a Task in the Agile process.
+var agileTaskStates = []*State{
+	{Name: "New", Category: "Proposed"},
+	{Name: "Active", Category: "InProgress"},
+	{Name: "Closed", Category: "Completed"},
+	{Name: "Removed", Category: "Removed"},
+}
+
+// agileBugStates are the states of a Bug in the Agile process.
+var agileBugStates = []*State{
+	{Name: "New", Category: "Proposed"},
+	{Name: "Active", Category: "InProgress"},
+	{Name: "Resolved", Category: "Resolved"},
+	{Name: "Closed", Category: "Completed"},
+	{Name: "Removed", Category: "Removed"},
+}
+
+// TestMapStateToTodu tests the mapping from work item states to todu statuses.
+func TestMapStateToTodu(t *testing.T) {
+	scrumStates := []*State{
+		{Name: "To Do", Category: "Proposed"},
+		{Name: "In Progress", Category: "InProgress"},
+		{Name: "Done", Category: "Completed"},
+	}
+
+	tests := []struct {
+		name   string
+		state  string
+		states []*State
+		want   string
+	}{
+		{"proposed", "New", agileBugStates, "active"},
+		{"in progress", "Active", agileBugStates, "inprogress"},
+		{"resolved", "Resolved", agileBugStates, "waiting"},
+		{"completed", "Closed", agileBugStates, "done"},
+		{"removed", "Removed", agileBugStates, "canceled"},
+		{"by category, not name", "In Progress", scrumStates, "inprogress"},
+		{"custom state", "Ready for QA", []*State{{Name: "Ready for QA", Category: "Resolved"}}, "waiting"},
+		{"fallback by name", "Committed", nil, "inprogress"},
+		{"fallback unknown", "Triage", nil, "active"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapStateToTodu(tt.state, tt.states); got != tt.want {
+				t.Errorf("mapStateToTodu(%q) = %q, want %q", tt.state, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMapToduStatusToState tests that each todu status picks the type's
+// first state in the matching category.
+func TestMapToduStatusToState(t *testing.T) {
+	tests := []struct {
+		status string
+		states []*State
+		want   string
+	}{
+		{"active", agileBugStates, "New"},
+		{"inprogress", agileBugStates, "Active"},
+		{"waiting", agileBugStates, "Resolved"},
+		{"waiting", agileTaskStates, "Active"},
+		{"done", agileBugStates, "Closed"},
+		{"canceled", agileBugStates, "Removed"},
+		{"canceled", []*State{{Name: "Done", Category: "Completed"}}, "Done"},
+		{"inprogress", nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := mapToduStatusToState(tt.status, tt.states); got != tt.want {
+			t.Errorf("mapToduStatusToState(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+// TestPriorityMapping tests that priorities map to the default priority
+// levels and back.
+func TestPriorityMapping(t *testing.T) {
+	for priority, want := range map[string]string{"1": "high", "2": "medium", "3": "low", "4": "low"} {
+		number := json.Number(priority)
+		if got := mapPriorityToTodu(&number); got == nil || *got != want {
+			t.Errorf("mapPriorityToTodu(%s) = %v, want %q", priority, got, want)
+		}
+	}
+	if got := mapPriorityToTodu(nil); got != nil {
+		t.Errorf("mapPriorityToTodu(nil) = %q, want nil", *got)
+	}
+
+	for level, want := range map[string]int{"urgent": 1, "high": 1, "medium": 2, "low": 3, "none": 4} {
+		if got := mapToduPriorityToADO(level); got != want {
+			t.Errorf("mapToduPriorityToADO(%q) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+// TestParseProjectExternalID tests parsing project and area path IDs.
+func TestParseProjectExternalID(t *testing.T) {
+	tests := []struct {
+		externalID  string
+		wantProject string
+		wantArea    string
+		wantErr     bool
+	}{
+		{"Fabrikam", "Fabrikam", "", false},
+		{`Fabrikam\Web Team`, "Fabrikam", `Fabrikam\Web Team`, false},
+		{`\Fabrikam\Web Team\UI`, "Fabrikam", `Fabrikam\Web Team\UI`, false},
+		{"owner/repo", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		project, area, err := parseProjectExternalID(tt.externalID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseProjectExternalID(%q) error = %v, wantErr %v", tt.externalID, err, tt.wantErr)
+			continue
+		}
+		if project != tt.wantProject || area != tt.wantArea {
+			t.Errorf("parseProjectExternalID(%q) = %q, %q, want %q, %q", tt.externalID, project, area, tt.wantProject, tt.wantArea)
+		}
+	}
+}
+
+func TestAreaPath(t *testing.T) {
+	if got := areaPath(`\Fabrikam\Area\Web Team`); got != `Fabrikam\Web Team` {
+		t.Errorf("areaPath() = %q, want Fabrikam\\Web Team", got)
+	}
+	if project := areaToProject(`Fabrikam\Web Team`); project.Name != "Fabrikam / Web Team" {
+		t.Errorf("areaToProject() name = %q, want Fabrikam / Web Team", project.Name)
+	}
+}
+
+// TestWorkItemToTask tests converting a work item.
+func TestWorkItemToTask(t *testing.T) {
+	priority := json.Number("1")
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	task := workItemToTask(&WorkItem{
+		ID: 42,
+		Fields: WorkItemFields{
+			Title:        "Login fails",
+			Description:  "<div>Steps:</div><div>1. Sign in &amp; wait<br></div>",
+			State:        "Resolved",
+			WorkItemType: "Bug",
+			AssignedTo:   &IdentityRef{DisplayName: "Alice", UniqueName: "alice@example.com"},
+			Tags:         "backend; urgent",
+			CreatedDate:  created,
+			ChangedDate:  created.Add(time.Hour),
+			Priority:     &priority,
+			TeamProject:  "Fabrikam Web",
+		},
+	}, agileBugStates, "https://dev.azure.com/acme")
+
+	if task.ExternalID != "42" || task.Title != "Login fails" || task.Status != "waiting" {
+		t.Errorf("task = %q %q %q", task.ExternalID, task.Title, task.Status)
+	}
+	if task.Description == nil || *task.Description != "Steps:\n1. Sign in & wait" {
+		t.Errorf("Description = %q, want the text of the HTML", *task.Description)
+	}
+	if task.Priority == nil || *task.Priority != "high" {
+		t.Errorf("Priority = %v, want high", task.Priority)
+	}
+	var labels []string
+	for _, label := range task.Labels {
+		labels = append(labels, label.Name)
+	}
+	if !slices.Equal(labels, []string{"backend", "urgent", "type:Bug"}) {
+		t.Errorf("Labels = %v, want [backend urgent type:Bug]", labels)
+	}
+	if len(task.Assignees) != 1 || task.Assignees[0].Name != "alice@example.com" {
+		t.Errorf("Assignees = %v, want [alice@example.com]", task.Assignees)
+	}
+	if task.SourceURL == nil || *task.SourceURL != "https://dev.azure.com/acme/Fabrikam%20Web/_workitems/edit/42" {
+		t.Errorf("SourceURL = %v", task.SourceURL)
+	}
+}
+
+// TestTextToHTML tests that plain text survives a round trip through HTML.
+func TestTextToHTML(t *testing.T) {
+	for _, text := range []string{"one line", "a < b & c\n\nnext paragraph", "line 1\nline 2"} {
+		if got := htmlToText(textToHTML(text)); got != text {
+			t.Errorf("htmlToText(textToHTML(%q)) = %q", text, got)
+		}
+	}
+}
+
+// TestTaskCreateToOps tests that the type label isn't pushed as a tag.
+func TestTaskCreateToOps(t *testing.T) {
+	description := "Details"
+	priority := "low"
+	ops := taskCreateToOps(&types.TaskCreate{
+		Title:       "New bug",
+		Description: &description,
+		Priority:    &priority,
+		Labels:      []string{"backend", "type:Bug", "ui"},
+		Assignees:   []string{"alice@example.com"},
+	}, `Fabrikam\Web Team`)
+
+	got := make(map[string]interface{})
+	for _, op := range ops {
+		if op.Op != "add" {
+			t.Errorf("op %s on %s, want add", op.Op, op.Path)
+		}
+		got[op.Path] = op.Value
+	}
+	want := map[string]interface{}{
+		"/fields/System.Title":                   "New bug",
+		"/fields/System.Description":             "Details",
+		"/fields/System.AreaPath":                `Fabrikam\Web Team`,
+		"/fields/Microsoft.VSTS.Common.Priority": 3,
+		"/fields/System.Tags":                    "backend; ui",
+		"/fields/System.AssignedTo":              "alice@example.com",
+	}
+	for path, value := range want {
+		if got[path] != value {
+			t.Errorf("%s = %v, want %v", path, got[path], value)
+		}
+	}
+}
+
+// TestTaskUpdateToOps tests that only fields that differ are updated, and
+// that a state with the same todu status isn't rewritten.
+func TestTaskUpdateToOps(t *testing.T) {
+	priority := json.Number("4")
+	current := &WorkItem{ID: 42, Fields: WorkItemFields{
+		Title:        "Login fails",
+		Description:  "Steps",
+		State:        "Resolved",
+		WorkItemType: "Bug",
+		Tags:         "Backend; urgent",
+		Priority:     &priority,
+		AssignedTo:   &IdentityRef{UniqueName: "alice@example.com"},
+	}}
+
+	title := "Login fails"
+	description := "Steps"
+	waiting := "waiting"
+	low := "low"
+	ops := taskUpdateToOps(&types.TaskUpdate{
+		Title:       &title,
+		Description: &description,
+		Status:      &waiting,
+		Priority:    &low,
+		Labels:      []string{"urgent", "backend", "type:Bug"},
+		Assignees:   []string{"Alice@example.com"},
+	}, current, agileBugStates)
+	if len(ops) != 0 {
+		t.Errorf("ops = %+v, want none", ops)
+	}
+
+	done := "done"
+	ops = taskUpdateToOps(&types.TaskUpdate{
+		Status: &done,
+		Labels: []string{"urgent"},
+	}, current, agileBugStates)
+	got := make(map[string]interface{})
+	for _, op := range ops {
+		got[op.Path] = op.Value
+	}
+	if got["/fields/System.State"] != "Closed" || got["/fields/System.Tags"] != "urgent" || len(got) != 2 {
+		t.Errorf("ops = %v, want state Closed and tags urgent", got)
+	}
+}
+
+// TestCommentToComment tests converting a discussion comment.
+func TestCommentToComment(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	comment := commentToComment(&Comment{
+		ID:           7,
+		Text:         "<p>Looks good</p>",
+		CreatedBy:    &IdentityRef{DisplayName: "Bob", UniqueName: "bob@example.com"},
+		CreatedDate:  created,
+		ModifiedDate: created.Add(time.Minute),
+	})
+	if comment.ExternalID != "7" || comment.Content != "Looks good" || comment.Author != "bob@example.com" {
+		t.Errorf("comment = %+v", comment)
+	}
+	if !comment.UpdatedAt.Equal(created.Add(time.Minute)) {
+		t.Errorf("UpdatedAt = %v, want the modified date", comment.UpdatedAt)
+	}
+}
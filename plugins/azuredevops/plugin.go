@@ -0,0 +1,452 @@
+// Package azuredevops provides a plugin for syncing tasks with Azure DevOps
+// Boards work items.
+//
+// Azure DevOps projects and their area paths are projects. An area path
+// project syncs the work items under that area and creates new ones in it.
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// defaultWorkItemTypes are the work item types synced unless the
+// work_item_types setting is set: the bugs, backlog items, and tasks of
+// the Agile, Scrum, Basic, and CMMI processes.
+const defaultWorkItemTypes = "Bug,User Story,Product Backlog Item,Issue,Requirement,Task"
+
+// defaultWorkItemType is the type new work items are created as, unless
+// the default_type setting or a type label says otherwise.
+const defaultWorkItemType = "Task"
+
+// areaDepth is how deep FetchProjects lists area paths below each project:
+// only the areas directly below it.
+const areaDepth = 1
+
+// Plugin implements the plugin.Plugin interface for Azure DevOps.
+type Plugin struct {
+	client *client
+	config map[string]string
+}
+
+// init registers the Azure DevOps plugin with the global registry.
+func init() {
+	registry.Register("azuredevops", func() plugin.Plugin {
+		return &Plugin{}
+	})
+}
+
+// Name returns the unique identifier for this plugin.
+func (p *Plugin) Name() string {
+	return "azuredevops"
+}
+
+// Version returns the version of this plugin implementation.
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// Configure provides configuration to the plugin.
+// Required configuration keys:
+//   - token: Azure DevOps personal access token (Work Items read & write)
+//   - organization: Organization name on dev.azure.com, unless url is set
+//
+// Optional configuration keys:
+//   - url: Organization or collection URL, for Azure DevOps Server
+//   - work_item_types: Comma-separated work item types to sync
+//     (default "Bug,User Story,Product Backlog Item,Issue,Requirement,Task")
+//   - default_type: Work item type new tasks are created as (default "Task")
+func (p *Plugin) Configure(config map[string]string) error {
+	p.config = config
+
+	// Validate required configuration
+	if err := p.ValidateConfig(); err != nil {
+		return err
+	}
+
+	// Create Azure DevOps API client
+	var err error
+	p.client, err = newClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to create Azure DevOps client: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks that the plugin has been properly configured.
+func (p *Plugin) ValidateConfig() error {
+	if p.config == nil {
+		return plugin.ErrNotConfigured
+	}
+
+	// Check required fields
+	if p.config["token"] == "" {
+		return fmt.Errorf("%w: missing required field 'token'", plugin.ErrNotConfigured)
+	}
+	if p.config["organization"] == "" && p.config["url"] == "" {
+		return fmt.Errorf("%w: missing required field 'organization' (or 'url')", plugin.ErrNotConfigured)
+	}
+
+	return nil
+}
+
+// workItemTypes returns the work item types to sync.
+func (p *Plugin) workItemTypes() []string {
+	setting := p.config["work_item_types"]
+	if strings.TrimSpace(setting) == "" {
+		setting = defaultWorkItemTypes
+	}
+
+	var workItemTypes []string
+	for _, name := range strings.Split(setting, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			workItemTypes = append(workItemTypes, name)
+		}
+	}
+	return workItemTypes
+}
+
+// FetchProjects retrieves every project in the organization, and the area
+// paths directly below each.
+func (p *Plugin) FetchProjects(ctx context.Context) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	projects, err := p.client.listProjects(ctx)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, "failed to list projects")
+	}
+
+	var result []*types.Project
+	for _, project := range projects {
+		result = append(result, projectToProject(project))
+
+		root, err := p.client.getAreas(ctx, project.Name, areaDepth)
+		if err != nil {
+			return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to list area paths in %s", project.Name))
+		}
+		var walk func(nodes []*AreaNode)
+		walk = func(nodes []*AreaNode) {
+			for _, node := range nodes {
+				result = append(result, areaToProject(areaPath(node.Path)))
+				walk(node.Children)
+			}
+		}
+		walk(root.Children)
+	}
+
+	return result, nil
+}
+
+// FetchProject retrieves a single project or area path by its external ID.
+func (p *Plugin) FetchProject(ctx context.Context, externalID string) (*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	projectName, area, err := parseProjectExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := p.client.getProject(ctx, projectName)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to fetch project %s", projectName))
+	}
+
+	if area != "" {
+		return areaToProject(area), nil
+	}
+	return projectToProject(project), nil
+}
+
+// FetchTasks retrieves the work items in a project or under an area path.
+func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, since *time.Time) ([]*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for Azure DevOps")
+	}
+
+	project, area, err := parseProjectExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := p.client.queryWorkItemIDs(ctx, project, buildWorkItemQuery(p.workItemTypes(), area, since))
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to query work items in %s", *projectExternalID))
+	}
+
+	items, err := p.client.getWorkItems(ctx, project, ids)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to fetch work items in %s", *projectExternalID))
+	}
+
+	result := make([]*types.Task, len(items))
+	for i, item := range items {
+		result[i] = workItemToTask(item, p.states(ctx, item), p.client.baseURL)
+	}
+
+	return result, nil
+}
+
+// buildWorkItemQuery returns the WIQL query for the work items of the given
+// types, under area when it isn't empty, changed after since when it isn't
+// nil.
+func buildWorkItemQuery(workItemTypes []string, area string, since *time.Time) string {
+	quote := func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+
+	quoted := make([]string, len(workItemTypes))
+	for i, name := range workItemTypes {
+		quoted[i] = quote(name)
+	}
+
+	query := "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project" +
+		" AND [System.WorkItemType] IN (" + strings.Join(quoted, ", ") + ")"
+	if area != "" {
+		query += " AND [System.AreaPath] UNDER " + quote(area)
+	}
+	if since != nil {
+		query += " AND [System.ChangedDate] > " + quote(since.UTC().Format(time.RFC3339))
+	}
+	return query + " ORDER BY [System.Id]"
+}
+
+// states returns the states of a work item's type. States that can't be
+// fetched are left to the mapper's fallback by state name.
+func (p *Plugin) states(ctx context.Context, item *WorkItem) []*State {
+	if item.Fields.TeamProject == "" || item.Fields.WorkItemType == "" {
+		return nil
+	}
+	states, err := p.client.getStates(ctx, item.Fields.TeamProject, item.Fields.WorkItemType)
+	if err != nil {
+		return nil
+	}
+	return states
+}
+
+// projectName returns the Azure DevOps project of an optional project
+// external ID, or "" if there is none.
+func projectName(projectExternalID *string) (string, error) {
+	if projectExternalID == nil {
+		return "", nil
+	}
+	project, _, err := parseProjectExternalID(*projectExternalID)
+	return project, err
+}
+
+// FetchTask retrieves a single work item by its ID.
+func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskExternalID string) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := projectName(projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := p.client.getWorkItem(ctx, project, taskExternalID)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to fetch work item %s", taskExternalID))
+	}
+
+	return workItemToTask(item, p.states(ctx, item), p.client.baseURL), nil
+}
+
+// CreateTask creates a new work item, of the type in its type label or
+// else the default type, and then moves it to the task's state.
+func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task *types.TaskCreate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for Azure DevOps")
+	}
+
+	project, area, err := parseProjectExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, workItemType := splitReservedLabels(task.Labels)
+	if workItemType == "" {
+		workItemType = strings.TrimSpace(p.config["default_type"])
+	}
+	if workItemType == "" {
+		workItemType = defaultWorkItemType
+	}
+
+	item, err := p.client.createWorkItem(ctx, project, workItemType, taskCreateToOps(task, area))
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to create %s in %s", workItemType, *projectExternalID))
+	}
+
+	// New work items start in their type's initial state
+	states := p.states(ctx, item)
+	if mapStateToTodu(item.Fields.State, states) != task.Status {
+		if state := mapToduStatusToState(task.Status, states); state != "" && state != item.Fields.State {
+			id := fmt.Sprint(item.ID)
+			item, err = p.client.updateWorkItem(ctx, project, id, []PatchOperation{fieldOp(fieldState, state)})
+			if err != nil {
+				return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to set the state of work item %s", id))
+			}
+		}
+	}
+
+	return workItemToTask(item, states, p.client.baseURL), nil
+}
+
+// UpdateTask updates an existing work item, changing only the fields that
+// differ from the update.
+func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, taskExternalID string, task *types.TaskUpdate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := projectName(projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := p.client.getWorkItem(ctx, project, taskExternalID)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to fetch work item %s", taskExternalID))
+	}
+	states := p.states(ctx, current)
+
+	ops := taskUpdateToOps(task, current, states)
+	if len(ops) == 0 {
+		return workItemToTask(current, states, p.client.baseURL), nil
+	}
+
+	updated, err := p.client.updateWorkItem(ctx, project, taskExternalID, ops)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to update work item %s", taskExternalID))
+	}
+
+	return workItemToTask(updated, states, p.client.baseURL), nil
+}
+
+// commentProject returns the project a work item's comments are in, which
+// the comments API requires.
+func commentProject(projectExternalID *string) (string, error) {
+	if projectExternalID == nil {
+		return "", fmt.Errorf("projectExternalID is required for Azure DevOps comments")
+	}
+	return projectName(projectExternalID)
+}
+
+// FetchComments retrieves all comments in a work item's discussion, oldest
+// first.
+func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := commentProject(projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := p.client.listComments(ctx, project, taskExternalID)
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to list comments for work item %s", taskExternalID))
+	}
+
+	result := make([]*types.Comment, len(comments))
+	for i, comment := range comments {
+		result[i] = commentToComment(comment)
+	}
+
+	return result, nil
+}
+
+// CreateComment adds a comment to a work item's discussion.
+func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := commentProject(projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := p.client.createComment(ctx, project, taskExternalID, textToHTML(comment.Content))
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to create comment on work item %s", taskExternalID))
+	}
+
+	return commentToComment(created), nil
+}
+
+// UpdateComment replaces the text of an existing comment.
+func (p *Plugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := commentProject(projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := p.client.editComment(ctx, project, taskExternalID, commentExternalID, textToHTML(comment.Content))
+	if err != nil {
+		return nil, handleAzureDevOpsError(err, fmt.Sprintf("failed to update comment %s on work item %s", commentExternalID, taskExternalID))
+	}
+
+	return commentToComment(updated), nil
+}
+
+// CurrentUser returns the account name of the user the configured token
+// belongs to.
+func (p *Plugin) CurrentUser(ctx context.Context) (string, error) {
+	if p.client == nil {
+		return "", plugin.ErrNotConfigured
+	}
+
+	user, err := p.client.getCurrentUser(ctx)
+	if err != nil {
+		return "", handleAzureDevOpsError(err, "failed to get authenticated user")
+	}
+
+	return user, nil
+}
+
+// handleAzureDevOpsError converts Azure DevOps API errors to plugin errors.
+func handleAzureDevOpsError(err error, context string) error {
+	if err == nil {
+		return nil
+	}
+
+	errMsg := err.Error()
+
+	// Check for 404 Not Found
+	if strings.Contains(errMsg, "API error 404") {
+		return plugin.NewErrNotFound(context)
+	}
+
+	// Check for 401/403 Unauthorized/Forbidden
+	if strings.Contains(errMsg, "API error 401") || strings.Contains(errMsg, "API error 403") {
+		return plugin.NewErrUnauthorized(context)
+	}
+
+	// Return generic error with context
+	return fmt.Errorf("%s: %w", context, err)
+}
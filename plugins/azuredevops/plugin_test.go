@@ -0,0 +1,263 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/plugin/plugintest"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// newTestPlugin configures a plugin against a test server.
+func newTestPlugin(t *testing.T, mux *http.ServeMux) *Plugin {
+	t.Helper()
+
+	p := &Plugin{}
+	plugintest.Serve(t, p, mux, "/acme", map[string]string{"token": "pat"})
+	return p
+}
+
+// writeStates serves the Agile Bug states.
+func writeStates(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{"value": agileBugStates})
+}
+
+func TestConfigure_RequiresOrganization(t *testing.T) {
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{"token": "pat"}); !errors.Is(err, plugin.ErrNotConfigured) {
+		t.Errorf("Configure() error = %v, want ErrNotConfigured", err)
+	}
+}
+
+// TestFetchProjects tests that each project and the area paths directly
+// below it are projects.
+func TestFetchProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /acme/_apis/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte(":pat")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") != apiVersion {
+			t.Errorf("api-version = %q, want %s", r.URL.Query().Get("api-version"), apiVersion)
+		}
+		w.Write([]byte(`{"count": 1, "value": [{"id": "p1", "name": "Fabrikam", "description": "Web site"}]}`))
+	})
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/classificationnodes/areas", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "Fabrikam", "path": "\\Fabrikam\\Area", "children": [{"name": "Web Team", "path": "\\Fabrikam\\Area\\Web Team"}]}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	projects, err := p.FetchProjects(context.Background())
+	if err != nil {
+		t.Fatalf("FetchProjects failed: %v", err)
+	}
+
+	if len(projects) != 2 {
+		t.Fatalf("got %d projects, want 2", len(projects))
+	}
+	if projects[0].ExternalID != "Fabrikam" || projects[0].Description == nil || *projects[0].Description != "Web site" {
+		t.Errorf("projects[0] = %+v", projects[0])
+	}
+	if projects[1].ExternalID != `Fabrikam\Web Team` {
+		t.Errorf("projects[1].ExternalID = %q, want Fabrikam\\Web Team", projects[1].ExternalID)
+	}
+}
+
+// TestFetchTasks tests that work items are queried under the area path,
+// changed since the last sync, and fetched in batches.
+func TestFetchTasks(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var query string
+	var batches [][]int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /acme/Fabrikam/_apis/wit/wiql", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("timePrecision") != "true" {
+			t.Error("expected timePrecision=true")
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		query = body["query"]
+
+		var items []map[string]int
+		for id := 1; id <= workItemsPerBatch+1; id++ {
+			items = append(items, map[string]int{"id": id})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"workItems": items})
+	})
+	mux.HandleFunc("POST /acme/Fabrikam/_apis/wit/workitemsbatch", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			IDs []int `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batches = append(batches, body.IDs)
+
+		var items []*WorkItem
+		for _, id := range body.IDs {
+			items = append(items, &WorkItem{ID: id, Fields: WorkItemFields{Title: "Item", State: "Resolved", WorkItemType: "Bug", TeamProject: "Fabrikam"}})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"value": items})
+	})
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/workitemtypes/Bug/states", writeStates)
+
+	p := newTestPlugin(t, mux)
+	project := `Fabrikam\Web Team`
+	tasks, err := p.FetchTasks(context.Background(), &project, &since)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"[System.WorkItemType] IN ('Bug', 'User Story', ",
+		`[System.AreaPath] UNDER 'Fabrikam\Web Team'`,
+		"[System.ChangedDate] > '2026-01-01T00:00:00Z'",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query %q doesn't contain %q", query, want)
+		}
+	}
+	if len(batches) != 2 || len(batches[0]) != workItemsPerBatch || len(batches[1]) != 1 {
+		t.Errorf("got batches of %d, want %d and 1", len(batches), workItemsPerBatch)
+	}
+	if len(tasks) != workItemsPerBatch+1 || tasks[0].Status != "waiting" {
+		t.Errorf("got %d tasks, first %q, want %d waiting", len(tasks), tasks[0].Status, workItemsPerBatch+1)
+	}
+}
+
+// TestCreateTask tests that the type label picks the work item type and
+// that the new work item is moved to the task's state.
+func TestCreateTask(t *testing.T) {
+	var created, updated []PatchOperation
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /acme/Fabrikam/_apis/wit/workitems/{type}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("type") != "$Bug" {
+			t.Errorf("type = %q, want $Bug", r.PathValue("type"))
+		}
+		if r.Header.Get("Content-Type") != "application/json-patch+json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&created)
+		json.NewEncoder(w).Encode(&WorkItem{ID: 42, Fields: WorkItemFields{Title: "New bug", State: "New", WorkItemType: "Bug", TeamProject: "Fabrikam"}})
+	})
+	mux.HandleFunc("PATCH /acme/Fabrikam/_apis/wit/workitems/42", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&updated)
+		json.NewEncoder(w).Encode(&WorkItem{ID: 42, Fields: WorkItemFields{Title: "New bug", State: "Active", WorkItemType: "Bug", TeamProject: "Fabrikam"}})
+	})
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/workitemtypes/Bug/states", writeStates)
+
+	p := newTestPlugin(t, mux)
+	project := "Fabrikam"
+	task, err := p.CreateTask(context.Background(), &project, &types.TaskCreate{
+		Title:  "New bug",
+		Status: "inprogress",
+		Labels: []string{"type:Bug"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	for _, op := range created {
+		if op.Path == "/fields/System.Tags" || op.Path == "/fields/System.State" {
+			t.Errorf("unexpected create op %+v", op)
+		}
+	}
+	if len(updated) != 1 || updated[0].Path != "/fields/System.State" || updated[0].Value != "Active" {
+		t.Errorf("update = %+v, want state Active", updated)
+	}
+	if task.ExternalID != "42" || task.Status != "inprogress" {
+		t.Errorf("task = %q %q, want 42 inprogress", task.ExternalID, task.Status)
+	}
+}
+
+// TestUpdateTask_NoChanges tests that an update matching the work item
+// sends nothing.
+func TestUpdateTask_NoChanges(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/workitems/42", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&WorkItem{ID: 42, Fields: WorkItemFields{Title: "Bug", State: "Resolved", WorkItemType: "Bug", TeamProject: "Fabrikam"}})
+	})
+	mux.HandleFunc("PATCH /acme/Fabrikam/_apis/wit/workitems/42", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected update")
+	})
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/workitemtypes/Bug/states", writeStates)
+
+	p := newTestPlugin(t, mux)
+	project := "Fabrikam"
+	status := "waiting"
+	task, err := p.UpdateTask(context.Background(), &project, "42", &types.TaskUpdate{Status: &status})
+	if err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if task.Status != "waiting" {
+		t.Errorf("Status = %q, want waiting", task.Status)
+	}
+}
+
+// TestComments tests paging through the discussion and editing a comment.
+func TestComments(t *testing.T) {
+	var edited map[string]string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /acme/Fabrikam/_apis/wit/workItems/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api-version") != commentsAPIVersion {
+			t.Errorf("api-version = %q, want %s", r.URL.Query().Get("api-version"), commentsAPIVersion)
+		}
+		if r.URL.Query().Get("continuationToken") == "" {
+			w.Write([]byte(`{"comments": [{"id": 1, "text": "<p>First</p>", "createdBy": {"uniqueName": "alice@example.com"}, "createdDate": "2026-01-01T09:00:00Z"}], "continuationToken": "next"}`))
+			return
+		}
+		w.Write([]byte(`{"comments": [{"id": 2, "text": "Second", "createdBy": {"uniqueName": "bob@example.com"}, "createdDate": "2026-01-02T09:00:00Z"}]}`))
+	})
+	mux.HandleFunc("PATCH /acme/Fabrikam/_apis/wit/workItems/42/comments/2", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&edited)
+		w.Write([]byte(`{"id": 2, "text": "Edited", "createdBy": {"uniqueName": "bob@example.com"}, "createdDate": "2026-01-02T09:00:00Z", "modifiedDate": "2026-01-03T09:00:00Z"}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	project := `Fabrikam\Web Team`
+	comments, err := p.FetchComments(context.Background(), &project, "42")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Content != "First" || comments[1].Author != "bob@example.com" {
+		t.Errorf("comments = %+v", comments)
+	}
+
+	comment, err := p.UpdateComment(context.Background(), &project, "42", "2", &types.CommentCreate{Content: "a < b"})
+	if err != nil {
+		t.Fatalf("UpdateComment failed: %v", err)
+	}
+	if edited["text"] != "a &lt; b" {
+		t.Errorf("text = %q, want the content as HTML", edited["text"])
+	}
+	if comment.Content != "Edited" {
+		t.Errorf("Content = %q, want Edited", comment.Content)
+	}
+
+	if _, err := p.FetchComments(context.Background(), nil, "42"); err == nil {
+		t.Error("expected an error without a project")
+	}
+}
+
+// TestHandleAzureDevOpsError tests that a rejected token is unauthorized.
+func TestHandleAzureDevOpsError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /acme/_apis/connectionData", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		w.Write([]byte("<html>Sign in</html>"))
+	})
+
+	p := newTestPlugin(t, mux)
+	if _, err := p.CurrentUser(context.Background()); !errors.Is(err, plugin.ErrUnauthorized) {
+		t.Errorf("CurrentUser() error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,556 @@
+package clickup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/httpcache"
+)
+
+// defaultBaseURL is the ClickUp API host used unless the url setting is set.
+const defaultBaseURL = "https://api.clickup.com"
+
+// tasksPerPage is the number of tasks ClickUp returns per page.
+const tasksPerPage = 100
+
+// commentsPerPage is the number of comments ClickUp returns per page.
+const commentsPerPage = 25
+
+// ClickUp API response types
+
+// Team represents a ClickUp workspace (called a team in the API).
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Space represents a ClickUp space.
+type Space struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Statuses []*Status `json:"statuses"`
+}
+
+// Folder represents a ClickUp folder and the lists in it.
+type Folder struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Lists []*List `json:"lists"`
+}
+
+// List represents a ClickUp list.
+type List struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Content  string    `json:"content"`
+	Space    *Ref      `json:"space"`
+	Folder   *Ref      `json:"folder"`
+	Statuses []*Status `json:"statuses"`
+}
+
+// Ref is the short reference to a list, folder, or space embedded in other
+// objects.
+type Ref struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Status represents a task status. Type is "open", "custom", "done", or
+// "closed".
+type Status struct {
+	Status string `json:"status"`
+	Type   string `json:"type"`
+}
+
+// User represents a ClickUp user.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// Tag represents a ClickUp tag.
+type Tag struct {
+	Name  string `json:"name"`
+	TagBg string `json:"tag_bg"`
+}
+
+// Priority represents a task priority: 1 urgent, 2 high, 3 normal, 4 low.
+type Priority struct {
+	ID       string `json:"id"`
+	Priority string `json:"priority"`
+}
+
+// Task represents a ClickUp task. Dates are Unix times in milliseconds,
+// sent as strings.
+type Task struct {
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Description         string    `json:"description"`
+	MarkdownDescription string    `json:"markdown_description"`
+	Status              *Status   `json:"status"`
+	Priority            *Priority `json:"priority"`
+	Assignees           []*User   `json:"assignees"`
+	Tags                []*Tag    `json:"tags"`
+	DueDate             *string   `json:"due_date"`
+	DateCreated         string    `json:"date_created"`
+	DateUpdated         string    `json:"date_updated"`
+	URL                 string    `json:"url"`
+	List                *Ref      `json:"list"`
+	Space               *Ref      `json:"space"`
+}
+
+// Comment represents a comment on a ClickUp task. The API sends IDs and
+// dates as strings in some responses and numbers in others.
+type Comment struct {
+	ID          json.Number `json:"id"`
+	CommentText string      `json:"comment_text"`
+	User        *User       `json:"user"`
+	Date        json.Number `json:"date"`
+}
+
+// API request types
+
+// CreateTaskRequest represents the request body for creating a task.
+type CreateTaskRequest struct {
+	Name                string   `json:"name"`
+	MarkdownDescription string   `json:"markdown_description,omitempty"`
+	Status              string   `json:"status,omitempty"`
+	Priority            *int     `json:"priority,omitempty"`
+	DueDate             *int64   `json:"due_date,omitempty"`
+	Assignees           []int64  `json:"assignees,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+}
+
+// UpdateTaskRequest represents the request body for updating a task.
+// Priorities and due dates are removed with clearTaskFields instead.
+type UpdateTaskRequest struct {
+	Name                *string          `json:"name,omitempty"`
+	MarkdownDescription *string          `json:"markdown_description,omitempty"`
+	Status              *string          `json:"status,omitempty"`
+	Priority            *int             `json:"priority,omitempty"`
+	DueDate             *int64           `json:"due_date,omitempty"`
+	Assignees           *AssigneesUpdate `json:"assignees,omitempty"`
+}
+
+// AssigneesUpdate adds and removes task assignees by user ID.
+type AssigneesUpdate struct {
+	Add []int64 `json:"add"`
+	Rem []int64 `json:"rem"`
+}
+
+// CommentRequest represents the request body for creating or editing a
+// comment.
+type CommentRequest struct {
+	CommentText string `json:"comment_text"`
+	NotifyAll   bool   `json:"notify_all"`
+}
+
+// client wraps the ClickUp API with an HTTP client and caches of the lookups
+// every push needs.
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	mu sync.Mutex
+
+	// lists caches lists, with their statuses, by ID
+	lists map[string]*List
+
+	// members caches the users who can be assigned tasks in a list, by list ID
+	members map[string][]*User
+
+	// spaceTeams caches the workspace each space belongs to, by space ID
+	spaceTeams map[string]string
+}
+
+// newClient creates a new ClickUp API client.
+func newClient(config map[string]string) (*client, error) {
+	token := strings.TrimSpace(config["token"])
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	baseURL := strings.TrimSpace(config["url"])
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	// Normalize base URL (remove trailing slash)
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: httpcache.NewTransport(nil)},
+		lists:      make(map[string]*List),
+		members:    make(map[string][]*User),
+		spaceTeams: make(map[string]string),
+	}, nil
+}
+
+// doRequest performs an HTTP request with authentication and decodes the
+// JSON response into result, unless result is nil.
+func (c *client) doRequest(ctx context.Context, method, path string, body, result interface{}) error {
+	fullURL := c.baseURL + "/api/v2" + path
+
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// ClickUp personal tokens are sent as they are, without a scheme
+	req.Header.Set("Authorization", c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// getCurrentUser retrieves the user the token belongs to.
+func (c *client) getCurrentUser(ctx context.Context) (*User, error) {
+	var result struct {
+		User *User `json:"user"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, "/user", nil, &result); err != nil {
+		return nil, err
+	}
+	if result.User == nil {
+		return nil, fmt.Errorf("no user in response")
+	}
+	return result.User, nil
+}
+
+// listTeams retrieves the workspaces the user belongs to.
+func (c *client) listTeams(ctx context.Context) ([]*Team, error) {
+	var result struct {
+		Teams []*Team `json:"teams"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, "/team", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Teams, nil
+}
+
+// listSpaces retrieves the spaces in a workspace that aren't archived.
+func (c *client) listSpaces(ctx context.Context, teamID string) ([]*Space, error) {
+	var result struct {
+		Spaces []*Space `json:"spaces"`
+	}
+	path := fmt.Sprintf("/team/%s/space?archived=false", url.PathEscape(teamID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	for _, space := range result.Spaces {
+		c.spaceTeams[space.ID] = teamID
+	}
+	c.mu.Unlock()
+
+	return result.Spaces, nil
+}
+
+// getSpace retrieves a single space.
+func (c *client) getSpace(ctx context.Context, spaceID string) (*Space, error) {
+	var space Space
+	if err := c.doRequest(ctx, http.MethodGet, "/space/"+url.PathEscape(spaceID), nil, &space); err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+// listSpaceLists retrieves the lists in a space that aren't archived:
+// lists outside folders first, then the lists in each folder.
+func (c *client) listSpaceLists(ctx context.Context, spaceID string) ([]*List, error) {
+	var folderless struct {
+		Lists []*List `json:"lists"`
+	}
+	path := fmt.Sprintf("/space/%s/list?archived=false", url.PathEscape(spaceID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &folderless); err != nil {
+		return nil, err
+	}
+
+	var folders struct {
+		Folders []*Folder `json:"folders"`
+	}
+	path = fmt.Sprintf("/space/%s/folder?archived=false", url.PathEscape(spaceID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &folders); err != nil {
+		return nil, err
+	}
+
+	lists := folderless.Lists
+	for _, folder := range folders.Folders {
+		for _, list := range folder.Lists {
+			if list.Folder == nil {
+				list.Folder = &Ref{ID: folder.ID, Name: folder.Name}
+			}
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+
+// getList retrieves a single list with its statuses, caching it for the
+// life of the client.
+func (c *client) getList(ctx context.Context, listID string) (*List, error) {
+	c.mu.Lock()
+	list, ok := c.lists[listID]
+	c.mu.Unlock()
+	if ok {
+		return list, nil
+	}
+
+	list = &List{}
+	if err := c.doRequest(ctx, http.MethodGet, "/list/"+url.PathEscape(listID), nil, list); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.lists[listID] = list
+	c.mu.Unlock()
+	return list, nil
+}
+
+// listMembers retrieves the users who can be assigned tasks in a list,
+// caching them for the life of the client.
+func (c *client) listMembers(ctx context.Context, listID string) ([]*User, error) {
+	c.mu.Lock()
+	members, ok := c.members[listID]
+	c.mu.Unlock()
+	if ok {
+		return members, nil
+	}
+
+	var result struct {
+		Members []*User `json:"members"`
+	}
+	path := fmt.Sprintf("/list/%s/member", url.PathEscape(listID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.members[listID] = result.Members
+	c.mu.Unlock()
+	return result.Members, nil
+}
+
+// spaceTeam returns the ID of the workspace a space belongs to, looking
+// through the user's workspaces the first time.
+func (c *client) spaceTeam(ctx context.Context, spaceID string) (string, error) {
+	c.mu.Lock()
+	teamID, ok := c.spaceTeams[spaceID]
+	c.mu.Unlock()
+	if ok {
+		return teamID, nil
+	}
+
+	teams, err := c.listTeams(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, team := range teams {
+		spaces, err := c.listSpaces(ctx, team.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, space := range spaces {
+			if space.ID == spaceID {
+				return team.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("API error 404: space %s not found in any workspace", spaceID)
+}
+
+// listTasks retrieves the tasks in a list, including closed tasks and
+// subtasks. If since is set, only tasks updated after it are returned.
+func (c *client) listTasks(ctx context.Context, listID string, since *time.Time) ([]*Task, error) {
+	path := fmt.Sprintf("/list/%s/task", url.PathEscape(listID))
+	return c.pageTasks(ctx, path, url.Values{}, since)
+}
+
+// listSpaceTasks retrieves the tasks in every list of a space, including
+// closed tasks and subtasks. If since is set, only tasks updated after it
+// are returned.
+func (c *client) listSpaceTasks(ctx context.Context, spaceID string, since *time.Time) ([]*Task, error) {
+	teamID, err := c.spaceTeam(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("space_ids[]", spaceID)
+	return c.pageTasks(ctx, fmt.Sprintf("/team/%s/task", url.PathEscape(teamID)), params, since)
+}
+
+// pageTasks retrieves every page of a task listing.
+func (c *client) pageTasks(ctx context.Context, path string, params url.Values, since *time.Time) ([]*Task, error) {
+	params.Set("include_closed", "true")
+	params.Set("subtasks", "true")
+	params.Set("include_markdown_description", "true")
+	if since != nil {
+		params.Set("date_updated_gt", strconv.FormatInt(since.UnixMilli(), 10))
+	}
+
+	var allTasks []*Task
+	for page := 0; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+
+		var result struct {
+			Tasks    []*Task `json:"tasks"`
+			LastPage bool    `json:"last_page"`
+		}
+		if err := c.doRequest(ctx, http.MethodGet, path+"?"+params.Encode(), nil, &result); err != nil {
+			return nil, err
+		}
+		allTasks = append(allTasks, result.Tasks...)
+
+		if result.LastPage || len(result.Tasks) < tasksPerPage {
+			break
+		}
+	}
+
+	return allTasks, nil
+}
+
+// getTask retrieves a single task.
+func (c *client) getTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/task/%s?include_markdown_description=true", url.PathEscape(taskID))
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// createTask creates a task in a list.
+func (c *client) createTask(ctx context.Context, listID string, req *CreateTaskRequest) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/list/%s/task", url.PathEscape(listID))
+	if err := c.doRequest(ctx, http.MethodPost, path, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// updateTask updates a task's fields.
+func (c *client) updateTask(ctx context.Context, taskID string, req *UpdateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.doRequest(ctx, http.MethodPut, "/task/"+url.PathEscape(taskID), req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// clearTaskFields removes a task's priority or due date, which ClickUp
+// only does when they are sent as null.
+func (c *client) clearTaskFields(ctx context.Context, taskID string, fields ...string) error {
+	body := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		body[field] = nil
+	}
+	return c.doRequest(ctx, http.MethodPut, "/task/"+url.PathEscape(taskID), body, nil)
+}
+
+// addTag adds a tag to a task.
+func (c *client) addTag(ctx context.Context, taskID, name string) error {
+	path := fmt.Sprintf("/task/%s/tag/%s", url.PathEscape(taskID), url.PathEscape(name))
+	return c.doRequest(ctx, http.MethodPost, path, nil, nil)
+}
+
+// removeTag removes a tag from a task.
+func (c *client) removeTag(ctx context.Context, taskID, name string) error {
+	path := fmt.Sprintf("/task/%s/tag/%s", url.PathEscape(taskID), url.PathEscape(name))
+	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// listComments retrieves every comment on a task, oldest first. ClickUp
+// returns comments newest first, a page at a time; each further page
+// starts after the oldest comment of the page before.
+func (c *client) listComments(ctx context.Context, taskID string) ([]*Comment, error) {
+	var allComments []*Comment
+	params := url.Values{}
+
+	for {
+		path := fmt.Sprintf("/task/%s/comment", url.PathEscape(taskID))
+		if len(params) > 0 {
+			path += "?" + params.Encode()
+		}
+
+		var result struct {
+			Comments []*Comment `json:"comments"`
+		}
+		if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+			return nil, err
+		}
+		allComments = append(allComments, result.Comments...)
+
+		if len(result.Comments) < commentsPerPage {
+			break
+		}
+		last := result.Comments[len(result.Comments)-1]
+		params.Set("start", last.Date.String())
+		params.Set("start_id", last.ID.String())
+	}
+
+	// Reverse into oldest first
+	for i, j := 0, len(allComments)-1; i < j; i, j = i+1, j-1 {
+		allComments[i], allComments[j] = allComments[j], allComments[i]
+	}
+	return allComments, nil
+}
+
+// createComment adds a comment to a task. ClickUp only returns the new
+// comment's ID and date.
+func (c *client) createComment(ctx context.Context, taskID, text string) (*Comment, error) {
+	var comment Comment
+	path := fmt.Sprintf("/task/%s/comment", url.PathEscape(taskID))
+	if err := c.doRequest(ctx, http.MethodPost, path, &CommentRequest{CommentText: text}, &comment); err != nil {
+		return nil, err
+	}
+	comment.CommentText = text
+	return &comment, nil
+}
+
+// editComment replaces the text of a comment.
+func (c *client) editComment(ctx context.Context, commentID, text string) error {
+	return c.doRequest(ctx, http.MethodPut, "/comment/"+url.PathEscape(commentID), &CommentRequest{CommentText: text}, nil)
+}
@@ -0,0 +1,456 @@
+package clickup
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// mapper.go contains functions for converting between ClickUp API types and Todu types.
+//
+// Mappings:
+//   - ClickUp List → Todu Project (external_id = "list:<id>")
+//   - ClickUp Space → Todu Project (external_id = "space:<id>"), covering every list in it
+//   - ClickUp Task → Todu Task (external_id = task ID)
+//   - ClickUp Status → Todu Status (by status type and name; see below)
+//   - ClickUp Priority → Todu Priority (urgent, high, normal, low)
+//   - ClickUp Tags → Todu Labels
+//   - ClickUp Assignees → Todu Assignees (by username)
+//   - ClickUp Comments → Todu Comments (1:1 mapping)
+//
+// Status Mapping (ClickUp → Todu):
+// Each list has its own statuses, each of type "open", "custom", "done", or
+// "closed".
+//   - type "done" or "closed" → done, or canceled if the name mentions cancel
+//   - type "open"             → active
+//   - type "custom"           → waiting if the name mentions waiting, blocked,
+//     or on hold; canceled if it mentions cancel; otherwise inprogress
+//
+// Status Mapping (Todu → ClickUp):
+// The list's first status that fits is used:
+//   - active     → the first "open" status
+//   - inprogress → a custom status named "in progress", else the first custom status
+//   - waiting    → a custom status for waiting, blocked, or on hold, else as inprogress
+//   - done       → the first "closed" status, else the first "done" status
+//   - canceled   → a status mentioning cancel, else as done
+//
+// Priority Mapping:
+//   - urgent ↔ "urgent" if it is a priority level, else the highest level
+//   - high   ↔ "high" if it is a level, else the highest level
+//   - normal ↔ "medium" or "normal" if it is a level, else the default priority
+//   - low    ↔ "low" if it is a level, else the lowest level
+//
+// Other Todu levels, such as "none", clear the ClickUp priority.
+
+const (
+	listPrefix  = "list:"
+	spacePrefix = "space:"
+)
+
+// ClickUp priority IDs
+const (
+	priorityUrgent = 1
+	priorityHigh   = 2
+	priorityNormal = 3
+	priorityLow    = 4
+)
+
+// parseProjectExternalID parses "list:<id>" or "space:<id>" into the kind
+// of container and its ID. A bare ID is a list.
+func parseProjectExternalID(externalID string) (kind, id string, err error) {
+	externalID = strings.TrimSpace(externalID)
+	switch {
+	case strings.HasPrefix(externalID, listPrefix):
+		kind, id = "list", strings.TrimPrefix(externalID, listPrefix)
+	case strings.HasPrefix(externalID, spacePrefix):
+		kind, id = "space", strings.TrimPrefix(externalID, spacePrefix)
+	default:
+		kind, id = "list", externalID
+	}
+	if id == "" || strings.ContainsAny(id, "/: ") {
+		return "", "", fmt.Errorf("invalid project external_id format: expected 'list:<id>' or 'space:<id>', got %q", externalID)
+	}
+	return kind, id, nil
+}
+
+// spaceToProject converts a ClickUp space to a Todu project.
+func spaceToProject(space *Space) *types.Project {
+	return &types.Project{
+		ExternalID: spacePrefix + space.ID,
+		Name:       space.Name,
+		Status:     "active",
+	}
+}
+
+// listToProject converts a ClickUp list to a Todu project. Its description
+// is the list's description, or else where the list is.
+func listToProject(list *List) *types.Project {
+	description := list.Content
+	if description == "" {
+		var path []string
+		if list.Space != nil && list.Space.Name != "" {
+			path = append(path, list.Space.Name)
+		}
+		if list.Folder != nil && list.Folder.Name != "" && !strings.EqualFold(list.Folder.Name, "hidden") {
+			path = append(path, list.Folder.Name)
+		}
+		description = strings.Join(path, " / ")
+	}
+
+	project := &types.Project{
+		ExternalID: listPrefix + list.ID,
+		Name:       list.Name,
+		Status:     "active",
+	}
+	if description != "" {
+		project.Description = &description
+	}
+	return project
+}
+
+// taskToTask converts a ClickUp task to a Todu task.
+func taskToTask(task *Task) *types.Task {
+	var description *string
+	if body := task.MarkdownDescription; body != "" {
+		description = &body
+	} else if body := task.Description; body != "" {
+		description = &body
+	}
+
+	var sourceURL *string
+	if task.URL != "" {
+		sourceURL = &task.URL
+	}
+
+	var dueDate *time.Time
+	if task.DueDate != nil {
+		if due, ok := parseMillis(*task.DueDate); ok {
+			dueDate = &due
+		}
+	}
+
+	created, _ := parseMillis(task.DateCreated)
+	updated, _ := parseMillis(task.DateUpdated)
+
+	return &types.Task{
+		ExternalID:  task.ID,
+		SourceURL:   sourceURL,
+		Title:       task.Name,
+		Description: description,
+		Status:      mapClickUpStatusToTodu(task.Status),
+		Priority:    mapClickUpPriorityToTodu(task.Priority),
+		DueDate:     dueDate,
+		CreatedAt:   created,
+		UpdatedAt:   updated,
+		Labels:      extractTags(task.Tags),
+		Assignees:   extractAssignees(task.Assignees),
+	}
+}
+
+// extractTags converts ClickUp tags to Todu labels.
+func extractTags(tags []*Tag) []types.Label {
+	var result []types.Label
+	for _, tag := range tags {
+		result = append(result, types.Label{
+			Name:  tag.Name,
+			Color: strings.ToLower(strings.TrimPrefix(tag.TagBg, "#")),
+		})
+	}
+	return result
+}
+
+// extractAssignees converts ClickUp assignees to Todu assignees.
+func extractAssignees(assignees []*User) []types.Assignee {
+	var result []types.Assignee
+	for _, assignee := range assignees {
+		result = append(result, types.Assignee{
+			Name: assignee.Username,
+		})
+	}
+	return result
+}
+
+// commentToComment converts a ClickUp comment to a Todu comment. ClickUp
+// doesn't report when a comment was edited, so UpdatedAt is its creation
+// time.
+func commentToComment(comment *Comment) *types.Comment {
+	author := ""
+	if comment.User != nil {
+		author = comment.User.Username
+	}
+
+	created, _ := parseMillis(comment.Date.String())
+
+	return &types.Comment{
+		ExternalID: comment.ID.String(),
+		Content:    comment.CommentText,
+		Author:     author,
+		CreatedAt:  created,
+		UpdatedAt:  created,
+	}
+}
+
+// mapClickUpStatusToTodu maps a ClickUp status to a todu status by its
+// type and name.
+func mapClickUpStatusToTodu(status *Status) string {
+	if status == nil {
+		return "active"
+	}
+
+	name := strings.ToLower(status.Status)
+	switch strings.ToLower(status.Type) {
+	case "done", "closed":
+		if strings.Contains(name, "cancel") {
+			return "canceled"
+		}
+		return "done"
+	case "custom":
+		switch {
+		case isWaitingStatus(name):
+			return "waiting"
+		case strings.Contains(name, "cancel"):
+			return "canceled"
+		default:
+			return "inprogress"
+		}
+	default:
+		return "active"
+	}
+}
+
+// isWaitingStatus reports whether a lowercase status name means the task is
+// waiting on something.
+func isWaitingStatus(name string) bool {
+	for _, word := range []string{"wait", "block", "hold"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapToduStatusToClickUp returns the name of the list status that best
+// matches a todu status, or "" if the list has none that fits.
+func mapToduStatusToClickUp(status string, statuses []*Status) string {
+	find := func(match func(name, kind string) bool) string {
+		for _, s := range statuses {
+			if match(strings.ToLower(s.Status), strings.ToLower(s.Type)) {
+				return s.Status
+			}
+		}
+		return ""
+	}
+	ofType := func(kind string) string {
+		return find(func(_, k string) bool { return k == kind })
+	}
+	inProgress := func() string {
+		if name := find(func(n, k string) bool { return k == "custom" && n == "in progress" }); name != "" {
+			return name
+		}
+		return find(func(n, k string) bool { return k == "custom" && !isWaitingStatus(n) && !strings.Contains(n, "cancel") })
+	}
+	done := func() string {
+		if name := find(func(n, k string) bool { return k == "closed" && !strings.Contains(n, "cancel") }); name != "" {
+			return name
+		}
+		return find(func(n, k string) bool { return k == "done" && !strings.Contains(n, "cancel") })
+	}
+
+	switch status {
+	case "inprogress":
+		return inProgress()
+	case "waiting":
+		if name := find(func(n, k string) bool { return k == "custom" && isWaitingStatus(n) }); name != "" {
+			return name
+		}
+		return inProgress()
+	case "done":
+		return done()
+	case "canceled":
+		if name := find(func(n, _ string) bool { return strings.Contains(n, "cancel") }); name != "" {
+			return name
+		}
+		return done()
+	default:
+		return ofType("open")
+	}
+}
+
+// mapClickUpPriorityToTodu maps a ClickUp priority to the closest
+// configured priority level, or nil if the task has none.
+func mapClickUpPriorityToTodu(priority *Priority) *string {
+	if priority == nil {
+		return nil
+	}
+
+	id, err := strconv.Atoi(priority.ID)
+	if err != nil {
+		switch strings.ToLower(priority.Priority) {
+		case "urgent":
+			id = priorityUrgent
+		case "high":
+			id = priorityHigh
+		case "normal":
+			id = priorityNormal
+		case "low":
+			id = priorityLow
+		default:
+			return nil
+		}
+	}
+
+	levels := types.Priorities()
+	pick := func(names ...string) string {
+		for _, name := range names {
+			if slices.Contains(levels, name) {
+				return name
+			}
+		}
+		return ""
+	}
+
+	var level string
+	switch id {
+	case priorityUrgent:
+		if level = pick("urgent"); level == "" {
+			level = levels[len(levels)-1]
+		}
+	case priorityHigh:
+		if level = pick("high"); level == "" {
+			level = levels[len(levels)-1]
+		}
+	case priorityNormal:
+		if level = pick("medium", "normal"); level == "" {
+			level = types.DefaultPriority()
+		}
+	case priorityLow:
+		if level = pick("low"); level == "" {
+			level = levels[0]
+		}
+	default:
+		return nil
+	}
+	return &level
+}
+
+// mapToduPriorityToClickUp maps a todu priority level to a ClickUp priority
+// ID, and false for levels with no equivalent, which clear the priority.
+func mapToduPriorityToClickUp(priority string) (int, bool) {
+	switch strings.ToLower(priority) {
+	case "urgent", "critical":
+		return priorityUrgent, true
+	case "high":
+		return priorityHigh, true
+	case "medium", "normal":
+		return priorityNormal, true
+	case "low":
+		return priorityLow, true
+	default:
+		return 0, false
+	}
+}
+
+// parseMillis parses a Unix time in milliseconds as ClickUp sends it.
+func parseMillis(value string) (time.Time, bool) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
+
+// assigneeIDs returns the IDs of the members whose username or email matches
+// each name. Names that aren't members of the list are skipped, since ClickUp
+// can't assign them.
+func assigneeIDs(names []string, members []*User) []int64 {
+	var ids []int64
+	for _, name := range names {
+		for _, member := range members {
+			if strings.EqualFold(member.Username, name) || (member.Email != "" && strings.EqualFold(member.Email, name)) {
+				if !slices.Contains(ids, member.ID) {
+					ids = append(ids, member.ID)
+				}
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// taskCreateToRequest converts a Todu TaskCreate to a ClickUp
+// CreateTaskRequest, given the statuses and members of the list the task is
+// created in.
+func taskCreateToRequest(task *types.TaskCreate, statuses []*Status, members []*User) *CreateTaskRequest {
+	req := &CreateTaskRequest{
+		Name:      task.Title,
+		Status:    mapToduStatusToClickUp(task.Status, statuses),
+		Assignees: assigneeIDs(task.Assignees, members),
+		Tags:      task.Labels,
+	}
+
+	if task.Description != nil {
+		req.MarkdownDescription = *task.Description
+	}
+
+	if task.Priority != nil {
+		if priority, ok := mapToduPriorityToClickUp(*task.Priority); ok {
+			req.Priority = &priority
+		}
+	}
+
+	if task.DueDate != nil {
+		due := task.DueDate.UnixMilli()
+		req.DueDate = &due
+	}
+
+	return req
+}
+
+// tagChanges returns the tags to add and remove to turn current into labels,
+// compared case-insensitively as ClickUp does.
+func tagChanges(current []*Tag, labels []string) (add, remove []string) {
+	has := func(names []string, name string) bool {
+		return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) })
+	}
+
+	var existing []string
+	for _, tag := range current {
+		existing = append(existing, tag.Name)
+	}
+	for _, label := range labels {
+		if !has(existing, label) && !has(add, label) {
+			add = append(add, label)
+		}
+	}
+	for _, name := range existing {
+		if !has(labels, name) {
+			remove = append(remove, name)
+		}
+	}
+	return add, remove
+}
+
+// assigneeChanges returns the IDs of the members to add and remove to turn
+// current into the assignees named in names.
+func assigneeChanges(current []*User, names []string, members []*User) *AssigneesUpdate {
+	desired := assigneeIDs(names, members)
+
+	update := &AssigneesUpdate{Add: []int64{}, Rem: []int64{}}
+	var existing []int64
+	for _, user := range current {
+		existing = append(existing, user.ID)
+		if !slices.Contains(desired, user.ID) {
+			update.Rem = append(update.Rem, user.ID)
+		}
+	}
+	for _, id := range desired {
+		if !slices.Contains(existing, id) {
+			update.Add = append(update.Add, id)
+		}
+	}
+	return update
+}
@@ -0,0 +1,292 @@
+package clickup
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// listStatuses is a list's statuses as ClickUp sets them up by default,
+// plus a blocked status and a canceled one.
+var listStatuses = []*Status{
+	{Status: "to do", Type: "open"},
+	{Status: "in progress", Type: "custom"},
+	{Status: "blocked", Type: "custom"},
+	{Status: "review", Type: "custom"},
+	{Status: "complete", Type: "done"},
+	{Status: "canceled", Type: "done"},
+	{Status: "Closed", Type: "closed"},
+}
+
+// TestMapClickUpStatusToTodu tests the mapping from ClickUp statuses to todu statuses.
+func TestMapClickUpStatusToTodu(t *testing.T) {
+	tests := []struct {
+		status *Status
+		want   string
+	}{
+		{nil, "active"},
+		{&Status{Status: "to do", Type: "open"}, "active"},
+		{&Status{Status: "in progress", Type: "custom"}, "inprogress"},
+		{&Status{Status: "review", Type: "custom"}, "inprogress"},
+		{&Status{Status: "Blocked", Type: "custom"}, "waiting"},
+		{&Status{Status: "on hold", Type: "custom"}, "waiting"},
+		{&Status{Status: "waiting on customer", Type: "custom"}, "waiting"},
+		{&Status{Status: "cancelled", Type: "custom"}, "canceled"},
+		{&Status{Status: "complete", Type: "done"}, "done"},
+		{&Status{Status: "canceled", Type: "done"}, "canceled"},
+		{&Status{Status: "Closed", Type: "closed"}, "done"},
+	}
+
+	for _, tt := range tests {
+		got := mapClickUpStatusToTodu(tt.status)
+		if got != tt.want {
+			t.Errorf("mapClickUpStatusToTodu(%+v) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+// TestMapToduStatusToClickUp tests that each todu status picks the list's
+// status that fits it best.
+func TestMapToduStatusToClickUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		statuses []*Status
+		want     string
+	}{
+		{"active", "active", listStatuses, "to do"},
+		{"inprogress", "inprogress", listStatuses, "in progress"},
+		{"waiting", "waiting", listStatuses, "blocked"},
+		{"done prefers closed", "done", listStatuses, "Closed"},
+		{"canceled", "canceled", listStatuses, "canceled"},
+		{
+			name:   "inprogress falls back to first custom status",
+			status: "inprogress",
+			statuses: []*Status{
+				{Status: "open", Type: "open"},
+				{Status: "doing", Type: "custom"},
+				{Status: "done", Type: "done"},
+			},
+			want: "doing",
+		},
+		{
+			name:   "waiting without a waiting status is in progress",
+			status: "waiting",
+			statuses: []*Status{
+				{Status: "open", Type: "open"},
+				{Status: "doing", Type: "custom"},
+			},
+			want: "doing",
+		},
+		{
+			name:   "canceled without a canceled status is done",
+			status: "canceled",
+			statuses: []*Status{
+				{Status: "open", Type: "open"},
+				{Status: "finished", Type: "done"},
+			},
+			want: "finished",
+		},
+		{
+			name:     "no fitting status",
+			status:   "inprogress",
+			statuses: []*Status{{Status: "open", Type: "open"}, {Status: "closed", Type: "closed"}},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapToduStatusToClickUp(tt.status, tt.statuses)
+			if got != tt.want {
+				t.Errorf("mapToduStatusToClickUp(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPriorityMapping tests that ClickUp priorities map to the default
+// priority levels and back.
+func TestPriorityMapping(t *testing.T) {
+	tests := []struct {
+		priority *Priority
+		want     string
+	}{
+		{&Priority{ID: "1", Priority: "urgent"}, "high"},
+		{&Priority{ID: "2", Priority: "high"}, "high"},
+		{&Priority{ID: "3", Priority: "normal"}, "medium"},
+		{&Priority{ID: "4", Priority: "low"}, "low"},
+		{&Priority{Priority: "low"}, "low"},
+	}
+
+	for _, tt := range tests {
+		got := mapClickUpPriorityToTodu(tt.priority)
+		if got == nil || *got != tt.want {
+			t.Errorf("mapClickUpPriorityToTodu(%+v) = %v, want %q", tt.priority, got, tt.want)
+		}
+	}
+
+	if got := mapClickUpPriorityToTodu(nil); got != nil {
+		t.Errorf("mapClickUpPriorityToTodu(nil) = %q, want nil", *got)
+	}
+
+	for level, want := range map[string]int{"urgent": priorityUrgent, "high": priorityHigh, "medium": priorityNormal, "low": priorityLow} {
+		if got, ok := mapToduPriorityToClickUp(level); !ok || got != want {
+			t.Errorf("mapToduPriorityToClickUp(%q) = %d, %v, want %d", level, got, ok, want)
+		}
+	}
+	if _, ok := mapToduPriorityToClickUp("none"); ok {
+		t.Error("mapToduPriorityToClickUp(\"none\") should have no equivalent")
+	}
+}
+
+// TestParseProjectExternalID tests parsing list and space project IDs.
+func TestParseProjectExternalID(t *testing.T) {
+	tests := []struct {
+		externalID string
+		wantKind   string
+		wantID     string
+		wantErr    bool
+	}{
+		{"list:901", "list", "901", false},
+		{"space:42", "space", "42", false},
+		{"901", "list", "901", false},
+		{"list:", "", "", true},
+		{"owner/repo", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		kind, id, err := parseProjectExternalID(tt.externalID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseProjectExternalID(%q) error = %v, wantErr %v", tt.externalID, err, tt.wantErr)
+			continue
+		}
+		if kind != tt.wantKind || id != tt.wantID {
+			t.Errorf("parseProjectExternalID(%q) = %q, %q, want %q, %q", tt.externalID, kind, id, tt.wantKind, tt.wantID)
+		}
+	}
+}
+
+// TestListToProject tests that a list without a description is described
+// by where it is.
+func TestListToProject(t *testing.T) {
+	project := listToProject(&List{
+		ID:     "901",
+		Name:   "Backlog",
+		Space:  &Ref{ID: "42", Name: "Engineering"},
+		Folder: &Ref{ID: "7", Name: "Platform"},
+	})
+	if project.ExternalID != "list:901" || project.Name != "Backlog" {
+		t.Errorf("listToProject() = %q %q, want list:901 Backlog", project.ExternalID, project.Name)
+	}
+	if project.Description == nil || *project.Description != "Engineering / Platform" {
+		t.Errorf("Description = %v, want Engineering / Platform", project.Description)
+	}
+
+	folderless := listToProject(&List{ID: "902", Name: "Inbox", Space: &Ref{Name: "Engineering"}, Folder: &Ref{Name: "hidden"}})
+	if folderless.Description == nil || *folderless.Description != "Engineering" {
+		t.Errorf("Description = %v, want Engineering", folderless.Description)
+	}
+}
+
+// TestTaskToTask tests converting a ClickUp task.
+func TestTaskToTask(t *testing.T) {
+	due := "1767268800000"
+	task := taskToTask(&Task{
+		ID:                  "86abc",
+		Name:                "Fix login",
+		Description:         "Plain text",
+		MarkdownDescription: "**Markdown**",
+		Status:              &Status{Status: "in progress", Type: "custom"},
+		Priority:            &Priority{ID: "2", Priority: "high"},
+		Assignees:           []*User{{ID: 1, Username: "alice"}},
+		Tags:                []*Tag{{Name: "bug"}},
+		DueDate:             &due,
+		DateCreated:         "1767225600000",
+		DateUpdated:         "1767229200000",
+		URL:                 "https://app.clickup.com/t/86abc",
+	})
+
+	if task.ExternalID != "86abc" || task.Title != "Fix login" {
+		t.Errorf("ExternalID, Title = %q, %q", task.ExternalID, task.Title)
+	}
+	if task.Description == nil || *task.Description != "**Markdown**" {
+		t.Errorf("Description = %v, want the markdown description", task.Description)
+	}
+	if task.Status != "inprogress" {
+		t.Errorf("Status = %q, want inprogress", task.Status)
+	}
+	if task.Priority == nil || *task.Priority != "high" {
+		t.Errorf("Priority = %v, want high", task.Priority)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("DueDate = %v, want 2026-01-01 12:00 UTC", task.DueDate)
+	}
+	if !task.UpdatedAt.Equal(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt = %v, want 2026-01-01 01:00 UTC", task.UpdatedAt)
+	}
+	if len(task.Labels) != 1 || task.Labels[0].Name != "bug" {
+		t.Errorf("Labels = %v, want [bug]", task.Labels)
+	}
+	if len(task.Assignees) != 1 || task.Assignees[0].Name != "alice" {
+		t.Errorf("Assignees = %v, want [alice]", task.Assignees)
+	}
+	if task.SourceURL == nil || *task.SourceURL != "https://app.clickup.com/t/86abc" {
+		t.Errorf("SourceURL = %v", task.SourceURL)
+	}
+}
+
+// TestTaskCreateToRequest tests that assignees are matched to list members
+// and unknown names are skipped.
+func TestTaskCreateToRequest(t *testing.T) {
+	priority := "urgent"
+	description := "Details"
+	req := taskCreateToRequest(&types.TaskCreate{
+		Title:       "New task",
+		Description: &description,
+		Status:      "inprogress",
+		Priority:    &priority,
+		Labels:      []string{"bug"},
+		Assignees:   []string{"Alice", "bob@example.com", "nobody"},
+	}, listStatuses, []*User{
+		{ID: 1, Username: "alice"},
+		{ID: 2, Username: "bob", Email: "bob@example.com"},
+	})
+
+	if req.Name != "New task" || req.MarkdownDescription != "Details" || req.Status != "in progress" {
+		t.Errorf("request = %+v", req)
+	}
+	if req.Priority == nil || *req.Priority != priorityUrgent {
+		t.Errorf("Priority = %v, want %d", req.Priority, priorityUrgent)
+	}
+	if !slices.Equal(req.Assignees, []int64{1, 2}) {
+		t.Errorf("Assignees = %v, want [1 2]", req.Assignees)
+	}
+}
+
+// TestTagChanges tests the tags added and removed to match a label set.
+func TestTagChanges(t *testing.T) {
+	add, remove := tagChanges(
+		[]*Tag{{Name: "bug"}, {Name: "backend"}},
+		[]string{"Bug", "urgent", "urgent"},
+	)
+	if !slices.Equal(add, []string{"urgent"}) {
+		t.Errorf("add = %v, want [urgent]", add)
+	}
+	if !slices.Equal(remove, []string{"backend"}) {
+		t.Errorf("remove = %v, want [backend]", remove)
+	}
+}
+
+// TestAssigneeChanges tests the assignees added and removed to match a set
+// of names.
+func TestAssigneeChanges(t *testing.T) {
+	members := []*User{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}, {ID: 3, Username: "carol"}}
+	update := assigneeChanges(members[:2], []string{"bob", "carol"}, members)
+	if !slices.Equal(update.Add, []int64{3}) || !slices.Equal(update.Rem, []int64{1}) {
+		t.Errorf("assigneeChanges() = add %v rem %v, want add [3] rem [1]", update.Add, update.Rem)
+	}
+}
@@ -0,0 +1,413 @@
+// Package clickup provides a plugin for syncing tasks with ClickUp.
+//
+// ClickUp lists and spaces are projects. A list project syncs the tasks in
+// that list; a space project syncs the tasks in every list of the space and
+// creates new tasks in its first list.
+package clickup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Plugin implements the plugin.Plugin interface for ClickUp.
+type Plugin struct {
+	client *client
+	config map[string]string
+}
+
+// init registers the ClickUp plugin with the global registry.
+func init() {
+	registry.Register("clickup", func() plugin.Plugin {
+		return &Plugin{}
+	})
+}
+
+// Name returns the unique identifier for this plugin.
+func (p *Plugin) Name() string {
+	return "clickup"
+}
+
+// Version returns the version of this plugin implementation.
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// Configure provides configuration to the plugin.
+// Required configuration keys:
+//   - token: ClickUp personal API token
+//
+// Optional configuration keys:
+//   - url: ClickUp API base URL (default "https://api.clickup.com")
+func (p *Plugin) Configure(config map[string]string) error {
+	p.config = config
+
+	// Validate required configuration
+	if err := p.ValidateConfig(); err != nil {
+		return err
+	}
+
+	// Create ClickUp API client
+	var err error
+	p.client, err = newClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to create ClickUp client: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks that the plugin has been properly configured.
+func (p *Plugin) ValidateConfig() error {
+	if p.config == nil {
+		return plugin.ErrNotConfigured
+	}
+
+	// Check required fields
+	if p.config["token"] == "" {
+		return fmt.Errorf("%w: missing required field 'token'", plugin.ErrNotConfigured)
+	}
+
+	return nil
+}
+
+// FetchProjects retrieves every space, and every list in each space, in
+// the workspaces the token can access.
+func (p *Plugin) FetchProjects(ctx context.Context) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	teams, err := p.client.listTeams(ctx)
+	if err != nil {
+		return nil, handleClickUpError(err, "failed to list workspaces")
+	}
+
+	var projects []*types.Project
+	for _, team := range teams {
+		spaces, err := p.client.listSpaces(ctx, team.ID)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to list spaces in workspace %s", team.Name))
+		}
+		for _, space := range spaces {
+			projects = append(projects, spaceToProject(space))
+
+			lists, err := p.client.listSpaceLists(ctx, space.ID)
+			if err != nil {
+				return nil, handleClickUpError(err, fmt.Sprintf("failed to list lists in space %s", space.Name))
+			}
+			for _, list := range lists {
+				if list.Space == nil {
+					list.Space = &Ref{ID: space.ID, Name: space.Name}
+				}
+				projects = append(projects, listToProject(list))
+			}
+		}
+	}
+
+	return projects, nil
+}
+
+// FetchProject retrieves a single list or space by its external ID.
+func (p *Plugin) FetchProject(ctx context.Context, externalID string) (*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	kind, id, err := parseProjectExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == "space" {
+		space, err := p.client.getSpace(ctx, id)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch space %s", id))
+		}
+		return spaceToProject(space), nil
+	}
+
+	list, err := p.client.getList(ctx, id)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch list %s", id))
+	}
+	return listToProject(list), nil
+}
+
+// FetchTasks retrieves the tasks in a list or space.
+func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, since *time.Time) ([]*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for ClickUp")
+	}
+
+	kind, id, err := parseProjectExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*Task
+	if kind == "space" {
+		tasks, err = p.client.listSpaceTasks(ctx, id, since)
+	} else {
+		tasks, err = p.client.listTasks(ctx, id, since)
+	}
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to list tasks for %s", *projectExternalID))
+	}
+
+	result := make([]*types.Task, len(tasks))
+	for i, task := range tasks {
+		result[i] = taskToTask(task)
+	}
+
+	return result, nil
+}
+
+// FetchTask retrieves a single task by its external ID.
+func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskExternalID string) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	task, err := p.client.getTask(ctx, taskExternalID)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch task %s", taskExternalID))
+	}
+
+	return taskToTask(task), nil
+}
+
+// CreateTask creates a new task in a list, or in the first list of a space.
+func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task *types.TaskCreate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for ClickUp")
+	}
+
+	kind, id, err := parseProjectExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	listID := id
+	if kind == "space" {
+		lists, err := p.client.listSpaceLists(ctx, id)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to list lists in space %s", id))
+		}
+		if len(lists) == 0 {
+			return nil, fmt.Errorf("space %s has no list to create tasks in", id)
+		}
+		listID = lists[0].ID
+	}
+
+	list, err := p.client.getList(ctx, listID)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch list %s", listID))
+	}
+
+	var members []*User
+	if len(task.Assignees) > 0 {
+		members, err = p.client.listMembers(ctx, listID)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to list members of list %s", listID))
+		}
+	}
+
+	created, err := p.client.createTask(ctx, listID, taskCreateToRequest(task, list.Statuses, members))
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to create task in %s", *projectExternalID))
+	}
+
+	return taskToTask(created), nil
+}
+
+// UpdateTask updates an existing task. Tags are added and removed one at a
+// time, since ClickUp doesn't set them with the task's other fields.
+func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, taskExternalID string, task *types.TaskUpdate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	// The current task has the list whose statuses and members apply, and
+	// the tags and assignees to change
+	current, err := p.client.getTask(ctx, taskExternalID)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch task %s", taskExternalID))
+	}
+
+	req := &UpdateTaskRequest{
+		Name:                task.Title,
+		MarkdownDescription: task.Description,
+	}
+
+	if task.Status != nil && current.List != nil {
+		list, err := p.client.getList(ctx, current.List.ID)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch list %s", current.List.ID))
+		}
+		if status := mapToduStatusToClickUp(*task.Status, list.Statuses); status != "" {
+			req.Status = &status
+		}
+	}
+
+	var clear []string
+	if task.Priority != nil {
+		if priority, ok := mapToduPriorityToClickUp(*task.Priority); ok {
+			req.Priority = &priority
+		} else if current.Priority != nil {
+			clear = append(clear, "priority")
+		}
+	}
+
+	if task.DueDate != nil {
+		due := task.DueDate.UnixMilli()
+		req.DueDate = &due
+	}
+
+	if task.Assignees != nil && current.List != nil {
+		members, err := p.client.listMembers(ctx, current.List.ID)
+		if err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to list members of list %s", current.List.ID))
+		}
+		if changes := assigneeChanges(current.Assignees, task.Assignees, members); len(changes.Add) > 0 || len(changes.Rem) > 0 {
+			req.Assignees = changes
+		}
+	}
+
+	updated, err := p.client.updateTask(ctx, taskExternalID, req)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to update task %s", taskExternalID))
+	}
+
+	if len(clear) > 0 {
+		if err := p.client.clearTaskFields(ctx, taskExternalID, clear...); err != nil {
+			return nil, handleClickUpError(err, fmt.Sprintf("failed to update task %s", taskExternalID))
+		}
+		updated.Priority = nil
+	}
+
+	// Update tags when a full label set was provided
+	if len(task.Labels) > 0 {
+		add, remove := tagChanges(current.Tags, task.Labels)
+		for _, name := range add {
+			if err := p.client.addTag(ctx, taskExternalID, name); err != nil {
+				return nil, handleClickUpError(err, fmt.Sprintf("failed to add tag %q to task %s", name, taskExternalID))
+			}
+		}
+		for _, name := range remove {
+			if err := p.client.removeTag(ctx, taskExternalID, name); err != nil {
+				return nil, handleClickUpError(err, fmt.Sprintf("failed to remove tag %q from task %s", name, taskExternalID))
+			}
+		}
+		if len(add) > 0 || len(remove) > 0 {
+			updated, err = p.client.getTask(ctx, taskExternalID)
+			if err != nil {
+				return nil, handleClickUpError(err, fmt.Sprintf("failed to fetch task %s", taskExternalID))
+			}
+		}
+	}
+
+	return taskToTask(updated), nil
+}
+
+// FetchComments retrieves all comments for a task, oldest first.
+func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	comments, err := p.client.listComments(ctx, taskExternalID)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to list comments for task %s", taskExternalID))
+	}
+
+	result := make([]*types.Comment, len(comments))
+	for i, comment := range comments {
+		result[i] = commentToComment(comment)
+	}
+
+	return result, nil
+}
+
+// CreateComment creates a new comment on a task.
+func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	created, err := p.client.createComment(ctx, taskExternalID, comment.Content)
+	if err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to create comment on task %s", taskExternalID))
+	}
+
+	return commentToComment(created), nil
+}
+
+// UpdateComment replaces the text of an existing comment.
+func (p *Plugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if err := p.client.editComment(ctx, commentExternalID, comment.Content); err != nil {
+		return nil, handleClickUpError(err, fmt.Sprintf("failed to update comment %s on task %s", commentExternalID, taskExternalID))
+	}
+
+	// ClickUp doesn't return the edited comment
+	return &types.Comment{
+		ExternalID: commentExternalID,
+		Content:    comment.Content,
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
+// CurrentUser returns the username of the user the configured token
+// belongs to.
+func (p *Plugin) CurrentUser(ctx context.Context) (string, error) {
+	if p.client == nil {
+		return "", plugin.ErrNotConfigured
+	}
+
+	user, err := p.client.getCurrentUser(ctx)
+	if err != nil {
+		return "", handleClickUpError(err, "failed to get authenticated user")
+	}
+
+	return user.Username, nil
+}
+
+// handleClickUpError converts ClickUp API errors to plugin errors.
+func handleClickUpError(err error, context string) error {
+	if err == nil {
+		return nil
+	}
+
+	errMsg := err.Error()
+
+	// Check for 404 Not Found
+	if strings.Contains(errMsg, "API error 404") {
+		return plugin.NewErrNotFound(context)
+	}
+
+	// Check for 401/403 Unauthorized/Forbidden
+	if strings.Contains(errMsg, "API error 401") || strings.Contains(errMsg, "API error 403") {
+		return plugin.NewErrUnauthorized(context)
+	}
+
+	// Return generic error with context
+	return fmt.Errorf("%s: %w", context, err)
+}
@@ -0,0 +1,313 @@
+package clickup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/plugin/plugintest"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// newTestPlugin configures a plugin against a test server.
+func newTestPlugin(t *testing.T, mux *http.ServeMux) *Plugin {
+	t.Helper()
+
+	p := &Plugin{}
+	plugintest.Serve(t, p, mux, "", map[string]string{"token": "pk_test"})
+	return p
+}
+
+func TestConfigure_RequiresToken(t *testing.T) {
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{}); !errors.Is(err, plugin.ErrNotConfigured) {
+		t.Errorf("Configure() error = %v, want ErrNotConfigured", err)
+	}
+}
+
+// TestFetchProjects tests that each space and each of its lists is a project.
+func TestFetchProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/team", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "pk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"teams": [{"id": "1", "name": "Acme"}]}`))
+	})
+	mux.HandleFunc("GET /api/v2/team/1/space", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"spaces": [{"id": "42", "name": "Engineering"}]}`))
+	})
+	mux.HandleFunc("GET /api/v2/space/42/list", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lists": [{"id": "901", "name": "Inbox"}]}`))
+	})
+	mux.HandleFunc("GET /api/v2/space/42/folder", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"folders": [{"id": "7", "name": "Platform", "lists": [{"id": "902", "name": "Backlog"}]}]}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	projects, err := p.FetchProjects(context.Background())
+	if err != nil {
+		t.Fatalf("FetchProjects failed: %v", err)
+	}
+
+	var got []string
+	for _, project := range projects {
+		got = append(got, project.ExternalID+" "+project.Name)
+	}
+	want := []string{"space:42 Engineering", "list:901 Inbox", "list:902 Backlog"}
+	if len(got) != len(want) {
+		t.Fatalf("projects = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("projects[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if projects[2].Description == nil || *projects[2].Description != "Engineering / Platform" {
+		t.Errorf("Description = %v, want Engineering / Platform", projects[2].Description)
+	}
+}
+
+// TestFetchTasks_PagesAndSince tests that every page is fetched and that
+// since is sent as date_updated_gt in milliseconds.
+func TestFetchTasks_PagesAndSince(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var pages []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/list/901/task", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("date_updated_gt") != strconv.FormatInt(since.UnixMilli(), 10) {
+			t.Errorf("date_updated_gt = %q", query.Get("date_updated_gt"))
+		}
+		if query.Get("include_closed") != "true" {
+			t.Errorf("include_closed = %q, want true", query.Get("include_closed"))
+		}
+		pages = append(pages, query.Get("page"))
+
+		var tasks []*Task
+		if query.Get("page") == "0" {
+			for i := range tasksPerPage {
+				tasks = append(tasks, &Task{ID: "a" + strconv.Itoa(i), Name: "Task"})
+			}
+		} else {
+			tasks = []*Task{{ID: "last", Name: "Last", Status: &Status{Status: "complete", Type: "done"}}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"tasks": tasks, "last_page": query.Get("page") != "0"})
+	})
+
+	p := newTestPlugin(t, mux)
+	project := "list:901"
+	tasks, err := p.FetchTasks(context.Background(), &project, &since)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	if len(tasks) != tasksPerPage+1 {
+		t.Fatalf("got %d tasks, want %d", len(tasks), tasksPerPage+1)
+	}
+	if len(pages) != 2 || pages[0] != "0" || pages[1] != "1" {
+		t.Errorf("pages = %v, want [0 1]", pages)
+	}
+	if last := tasks[len(tasks)-1]; last.ExternalID != "last" || last.Status != "done" {
+		t.Errorf("last task = %q %q, want last done", last.ExternalID, last.Status)
+	}
+}
+
+// TestCreateTask_InSpace tests that a task in a space project is created in
+// the space's first list with that list's statuses and members.
+func TestCreateTask_InSpace(t *testing.T) {
+	var body CreateTaskRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/space/42/list", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lists": [{"id": "901", "name": "Inbox"}]}`))
+	})
+	mux.HandleFunc("GET /api/v2/space/42/folder", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"folders": []}`))
+	})
+	mux.HandleFunc("GET /api/v2/list/901", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&List{ID: "901", Name: "Inbox", Statuses: listStatuses})
+	})
+	mux.HandleFunc("GET /api/v2/list/901/member", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"members": [{"id": 1, "username": "alice"}]}`))
+	})
+	mux.HandleFunc("POST /api/v2/list/901/task", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(&Task{
+			ID:        "86abc",
+			Name:      body.Name,
+			Status:    &Status{Status: body.Status, Type: "custom"},
+			Assignees: []*User{{ID: 1, Username: "alice"}},
+		})
+	})
+
+	p := newTestPlugin(t, mux)
+	project := "space:42"
+	task, err := p.CreateTask(context.Background(), &project, &types.TaskCreate{
+		Title:     "New task",
+		Status:    "inprogress",
+		Assignees: []string{"alice"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if body.Status != "in progress" || len(body.Assignees) != 1 || body.Assignees[0] != 1 {
+		t.Errorf("request = %+v, want status in progress and assignee 1", body)
+	}
+	if task.ExternalID != "86abc" || task.Status != "inprogress" {
+		t.Errorf("task = %q %q, want 86abc inprogress", task.ExternalID, task.Status)
+	}
+}
+
+// TestUpdateTask tests that status, priority, assignees, and tags are
+// updated, and that a level with no ClickUp priority clears it.
+func TestUpdateTask(t *testing.T) {
+	var (
+		updates []map[string]any
+		added   []string
+		removed []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/task/86abc", func(w http.ResponseWriter, r *http.Request) {
+		tags := []*Tag{{Name: "bug"}, {Name: "backend"}}
+		if len(added) > 0 {
+			tags = []*Tag{{Name: "bug"}, {Name: "urgent"}}
+		}
+		json.NewEncoder(w).Encode(&Task{
+			ID:        "86abc",
+			Name:      "Fix login",
+			Status:    &Status{Status: "to do", Type: "open"},
+			Priority:  &Priority{ID: "3", Priority: "normal"},
+			Assignees: []*User{{ID: 1, Username: "alice"}},
+			Tags:      tags,
+			List:      &Ref{ID: "901"},
+		})
+	})
+	mux.HandleFunc("GET /api/v2/list/901", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&List{ID: "901", Statuses: listStatuses})
+	})
+	mux.HandleFunc("GET /api/v2/list/901/member", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"members": [{"id": 1, "username": "alice"}, {"id": 2, "username": "bob"}]}`))
+	})
+	mux.HandleFunc("PUT /api/v2/task/86abc", func(w http.ResponseWriter, r *http.Request) {
+		var update map[string]any
+		json.NewDecoder(r.Body).Decode(&update)
+		updates = append(updates, update)
+		json.NewEncoder(w).Encode(&Task{ID: "86abc", Name: "Fix login", Status: &Status{Status: "blocked", Type: "custom"}})
+	})
+	mux.HandleFunc("POST /api/v2/task/86abc/tag/{name}", func(w http.ResponseWriter, r *http.Request) {
+		added = append(added, r.PathValue("name"))
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("DELETE /api/v2/task/86abc/tag/{name}", func(w http.ResponseWriter, r *http.Request) {
+		removed = append(removed, r.PathValue("name"))
+		w.Write([]byte(`{}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	status := "waiting"
+	priority := "none"
+	task, err := p.UpdateTask(context.Background(), nil, "86abc", &types.TaskUpdate{
+		Status:    &status,
+		Priority:  &priority,
+		Labels:    []string{"bug", "urgent"},
+		Assignees: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+
+	if len(updates) != 2 {
+		t.Fatalf("got %d updates, want the update and the priority clear", len(updates))
+	}
+	if updates[0]["status"] != "blocked" {
+		t.Errorf("status = %v, want blocked", updates[0]["status"])
+	}
+	assignees, _ := updates[0]["assignees"].(map[string]any)
+	if add, _ := assignees["add"].([]any); len(add) != 1 || add[0] != float64(2) {
+		t.Errorf("assignees = %v, want add [2] rem [1]", updates[0]["assignees"])
+	}
+	if priority, ok := updates[1]["priority"]; !ok || priority != nil {
+		t.Errorf("second update = %v, want priority cleared", updates[1])
+	}
+	if len(added) != 1 || added[0] != "urgent" || len(removed) != 1 || removed[0] != "backend" {
+		t.Errorf("added %v removed %v, want added [urgent] removed [backend]", added, removed)
+	}
+	if len(task.Labels) != 2 || task.Labels[1].Name != "urgent" {
+		t.Errorf("Labels = %v, want the tags after the update", task.Labels)
+	}
+}
+
+// TestComments tests that comments are paged oldest first and that
+// UpdateComment edits the comment.
+func TestComments(t *testing.T) {
+	var edited CommentRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/task/86abc/comment", func(w http.ResponseWriter, r *http.Request) {
+		var comments []map[string]any
+		if r.URL.Query().Get("start_id") == "" {
+			for i := commentsPerPage; i > 0; i-- {
+				comments = append(comments, map[string]any{
+					"id":           strconv.Itoa(100 + i),
+					"comment_text": "comment " + strconv.Itoa(i),
+					"user":         map[string]any{"id": 1, "username": "alice"},
+					"date":         strconv.Itoa(1767225600000 + i*1000),
+				})
+			}
+		} else if r.URL.Query().Get("start_id") == "101" {
+			comments = []map[string]any{{"id": "100", "comment_text": "first", "user": map[string]any{"id": 1, "username": "alice"}, "date": "1767225600000"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"comments": comments})
+	})
+	mux.HandleFunc("PUT /api/v2/comment/101", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&edited)
+		w.Write([]byte(`{}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	comments, err := p.FetchComments(context.Background(), nil, "86abc")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if len(comments) != commentsPerPage+1 {
+		t.Fatalf("got %d comments, want %d", len(comments), commentsPerPage+1)
+	}
+	if comments[0].Content != "first" || comments[0].Author != "alice" {
+		t.Errorf("first comment = %q by %q, want first by alice", comments[0].Content, comments[0].Author)
+	}
+	if comments[len(comments)-1].ExternalID != strconv.Itoa(100+commentsPerPage) {
+		t.Errorf("last comment = %q, want the newest", comments[len(comments)-1].ExternalID)
+	}
+
+	if _, err := p.UpdateComment(context.Background(), nil, "86abc", "101", &types.CommentCreate{Content: "edited"}); err != nil {
+		t.Fatalf("UpdateComment failed: %v", err)
+	}
+	if edited.CommentText != "edited" {
+		t.Errorf("comment_text = %q, want edited", edited.CommentText)
+	}
+}
+
+// TestHandleClickUpError tests that API errors map to plugin errors.
+func TestHandleClickUpError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/task/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"err": "Task not found"}`))
+	})
+
+	p := newTestPlugin(t, mux)
+	_, err := p.FetchTask(context.Background(), nil, "missing")
+	if !errors.Is(err, plugin.ErrNotFound) {
+		t.Errorf("FetchTask() error = %v, want ErrNotFound", err)
+	}
+}
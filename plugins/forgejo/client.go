@@ -12,6 +12,10 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/evcraddock/todu.sh/internal/httpcache"
+	"github.com/evcraddock/todu.sh/internal/plugincache"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 )
 
 // Forgejo API response types
@@ -46,12 +50,36 @@ type Issue struct {
 	HTMLURL     string     `json:"html_url"`
 	Labels      []*Label   `json:"labels"`
 	Assignees   []*User    `json:"assignees"`
+	Milestone   *Milestone `json:"milestone"`
 	PullRequest *struct{}  `json:"pull_request"`
+	Repository  *RepoMeta  `json:"repository"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	ClosedAt    *time.Time `json:"closed_at"`
 }
 
+// RepoMeta is the short repository reference embedded in issues.
+type RepoMeta struct {
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	FullName string `json:"full_name"`
+}
+
+// Milestone represents a Forgejo milestone.
+type Milestone struct {
+	ID    int64      `json:"id"`
+	Title string     `json:"title"`
+	State string     `json:"state"`
+	DueOn *time.Time `json:"due_on"`
+}
+
+// IssueRef identifies an issue in a dependency, possibly in another repository.
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
 // Label represents a Forgejo label.
 type Label struct {
 	ID    int64  `json:"id"`
@@ -73,17 +101,20 @@ type Comment struct {
 
 // CreateIssueRequest represents the request body for creating an issue.
 type CreateIssueRequest struct {
-	Title    string  `json:"title"`
-	Body     string  `json:"body,omitempty"`
-	Labels   []int64 `json:"labels,omitempty"`
-	Assignee string  `json:"assignee,omitempty"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body,omitempty"`
+	Labels    []int64 `json:"labels,omitempty"`
+	Assignee  string  `json:"assignee,omitempty"`
+	Milestone int64   `json:"milestone,omitempty"`
 }
 
 // UpdateIssueRequest represents the request body for updating an issue.
+// A Milestone of 0 removes the issue from its milestone.
 type UpdateIssueRequest struct {
-	Title *string `json:"title,omitempty"`
-	Body  *string `json:"body,omitempty"`
-	State *string `json:"state,omitempty"`
+	Title     *string `json:"title,omitempty"`
+	Body      *string `json:"body,omitempty"`
+	State     *string `json:"state,omitempty"`
+	Milestone *int64  `json:"milestone,omitempty"`
 }
 
 // UpdateLabelsRequest represents the request body for updating issue labels.
@@ -96,6 +127,18 @@ type CreateCommentRequest struct {
 	Body string `json:"body"`
 }
 
+// CreateMilestoneRequest represents the request body for creating a milestone.
+type CreateMilestoneRequest struct {
+	Title string `json:"title"`
+}
+
+// IssueMetaRequest identifies the issue in a dependency request.
+type IssueMetaRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Index int64  `json:"index"`
+}
+
 // CreateLabelRequest represents the request body for creating a label.
 type CreateLabelRequest struct {
 	Name  string `json:"name"`
@@ -111,6 +154,13 @@ type client struct {
 	// Label cache: map[owner/repo]map[labelName]labelID
 	labelCache map[string]map[string]int64
 	labelMu    sync.RWMutex
+
+	// Milestone cache: map[owner/repo]map[milestoneTitle]milestoneID
+	milestoneCache map[string]map[string]int64
+	milestoneMu    sync.RWMutex
+
+	// diskCache keeps label IDs between runs; nil disables it
+	diskCache *plugincache.Cache
 }
 
 // newClient creates a new Forgejo API client.
@@ -129,10 +179,12 @@ func newClient(config map[string]string) (*client, error) {
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
 	return &client{
-		baseURL:    baseURL,
-		token:      token,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-		labelCache: make(map[string]map[string]int64),
+		baseURL:        baseURL,
+		token:          token,
+		httpClient:     &http.Client{Timeout: 30 * time.Second, Transport: httpcache.NewTransport(nil)},
+		labelCache:     make(map[string]map[string]int64),
+		milestoneCache: make(map[string]map[string]int64),
+		diskCache:      plugincache.ForPlugin(config),
 	}, nil
 }
 
@@ -204,6 +256,47 @@ func (c *client) listRepositories(ctx context.Context) ([]*Repository, error) {
 	return allRepos, nil
 }
 
+// searchRepositories retrieves all repositories matching a keyword search.
+// If topic is true, keywords match repository topics instead of names.
+func (c *client) searchRepositories(ctx context.Context, keywords string, topic bool) ([]*Repository, error) {
+	var allRepos []*Repository
+	page := 1
+	limit := 50
+
+	for {
+		params := url.Values{}
+		params.Set("q", keywords)
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(limit))
+		if topic {
+			params.Set("topic", "true")
+		}
+
+		resp, err := c.doRequest(ctx, http.MethodGet, "/repos/search?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Data []*Repository `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		resp.Body.Close()
+
+		allRepos = append(allRepos, result.Data...)
+
+		if len(result.Data) < limit {
+			break
+		}
+		page++
+	}
+
+	return allRepos, nil
+}
+
 // getRepository retrieves a single repository by owner and name.
 func (c *client) getRepository(ctx context.Context, owner, repo string) (*Repository, error) {
 	path := fmt.Sprintf("/repos/%s/%s", owner, repo)
@@ -221,6 +314,22 @@ func (c *client) getRepository(ctx context.Context, owner, repo string) (*Reposi
 	return &repository, nil
 }
 
+// getCurrentUser retrieves the authenticated user.
+func (c *client) getCurrentUser(ctx context.Context) (*User, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &user, nil
+}
+
 // listIssues retrieves issues for a repository.
 func (c *client) listIssues(ctx context.Context, owner, repo string, since *time.Time) ([]*Issue, error) {
 	var allIssues []*Issue
@@ -261,6 +370,56 @@ func (c *client) listIssues(ctx context.Context, owner, repo string, since *time
 	return allIssues, nil
 }
 
+// searchIssues retrieves all issues matching a search across the
+// repositories the user can access.
+func (c *client) searchIssues(ctx context.Context, search *issueSearch) ([]*Issue, error) {
+	var allIssues []*Issue
+	page := 1
+	limit := 50
+
+	for {
+		params := url.Values{}
+		params.Set("type", "issues")
+		params.Set("state", search.State)
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(limit))
+		if search.Keywords != "" {
+			params.Set("q", search.Keywords)
+		}
+		if len(search.Labels) > 0 {
+			params.Set("labels", strings.Join(search.Labels, ","))
+		}
+		if search.Owner != "" {
+			params.Set("owner", search.Owner)
+		}
+
+		resp, err := c.doRequest(ctx, http.MethodGet, "/repos/issues/search?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var issues []*Issue
+		if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		resp.Body.Close()
+
+		for _, issue := range issues {
+			if issue.PullRequest == nil && search.matchesRepo(issue.Repository) {
+				allIssues = append(allIssues, issue)
+			}
+		}
+
+		if len(issues) < limit {
+			break
+		}
+		page++
+	}
+
+	return allIssues, nil
+}
+
 // getIssue retrieves a single issue by number.
 func (c *client) getIssue(ctx context.Context, owner, repo string, number int) (*Issue, error) {
 	path := fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number)
@@ -329,13 +488,17 @@ func (c *client) updateIssueLabels(ctx context.Context, owner, repo string, numb
 }
 
 // listComments retrieves all comments for an issue.
-func (c *client) listComments(ctx context.Context, owner, repo string, number int) ([]*Comment, error) {
+// If since is provided, only comments updated since that time are returned.
+func (c *client) listComments(ctx context.Context, owner, repo string, number int, since *time.Time) ([]*Comment, error) {
 	var allComments []*Comment
 	page := 1
 	limit := 100
 
 	for {
 		path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments?page=%d&limit=%d", owner, repo, number, page, limit)
+		if since != nil {
+			path += "&since=" + url.QueryEscape(since.Format(time.RFC3339))
+		}
 		resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 		if err != nil {
 			return nil, err
@@ -378,6 +541,25 @@ func (c *client) createComment(ctx context.Context, owner, repo string, number i
 	return &comment, nil
 }
 
+// editComment replaces the body of an existing issue comment.
+func (c *client) editComment(ctx context.Context, owner, repo string, commentID int64, body string) (*Comment, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/comments/%d", owner, repo, commentID)
+	req := &CreateCommentRequest{Body: body}
+
+	resp, err := c.doRequest(ctx, http.MethodPatch, path, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var comment Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &comment, nil
+}
+
 // Label management methods
 
 // listLabels retrieves all labels for a repository.
@@ -411,12 +593,12 @@ func (c *client) listLabels(ctx context.Context, owner, repo string) ([]*Label,
 	return allLabels, nil
 }
 
-// createLabel creates a new label in a repository.
-func (c *client) createLabel(ctx context.Context, owner, repo, name string) (*Label, error) {
+// createLabel creates a new label with a color (six hex digits) in a repository.
+func (c *client) createLabel(ctx context.Context, owner, repo, name, color string) (*Label, error) {
 	path := fmt.Sprintf("/repos/%s/%s/labels", owner, repo)
 	req := &CreateLabelRequest{
 		Name:  name,
-		Color: generateLabelColor(name),
+		Color: color,
 	}
 
 	resp, err := c.doRequest(ctx, http.MethodPost, path, req)
@@ -457,7 +639,7 @@ func (c *client) resolveLabelIDs(ctx context.Context, owner, repo string, labelN
 			labelIDs = append(labelIDs, id)
 		} else {
 			// Create the label
-			label, err := c.createLabel(ctx, owner, repo, name)
+			label, err := c.createLabel(ctx, owner, repo, name, plugin.LabelColor(name))
 			if err != nil {
 				return nil, fmt.Errorf("failed to create label %q: %w", name, err)
 			}
@@ -468,6 +650,7 @@ func (c *client) resolveLabelIDs(ctx context.Context, owner, repo string, labelN
 				c.labelCache[repoKey] = make(map[string]int64)
 			}
 			c.labelCache[repoKey][name] = label.ID
+			_ = c.diskCache.Put(c.labelCacheKey(owner, repo), c.labelCache[repoKey])
 			c.labelMu.Unlock()
 
 			labelIDs = append(labelIDs, label.ID)
@@ -478,6 +661,7 @@ func (c *client) resolveLabelIDs(ctx context.Context, owner, repo string, labelN
 }
 
 // populateLabelCache fetches all labels for a repository and caches them.
+// Labels cached on disk by an earlier run are used until they expire.
 func (c *client) populateLabelCache(ctx context.Context, owner, repo string) error {
 	repoKey := owner + "/" + repo
 
@@ -489,35 +673,200 @@ func (c *client) populateLabelCache(ctx context.Context, owner, repo string) err
 		return nil
 	}
 
+	var cached map[string]int64
+	if c.diskCache.Get(c.labelCacheKey(owner, repo), &cached) && cached != nil {
+		c.labelMu.Lock()
+		c.labelCache[repoKey] = cached
+		c.labelMu.Unlock()
+		return nil
+	}
+
 	labels, err := c.listLabels(ctx, owner, repo)
 	if err != nil {
 		return err
 	}
 
+	c.cacheLabels(owner, repo, labels)
+	return nil
+}
+
+// cacheLabels replaces the cached labels for a repository with labels.
+func (c *client) cacheLabels(owner, repo string, labels []*Label) {
 	c.labelMu.Lock()
-	c.labelCache[repoKey] = make(map[string]int64)
+	defer c.labelMu.Unlock()
+
+	repoKey := owner + "/" + repo
+	c.labelCache[repoKey] = make(map[string]int64, len(labels))
 	for _, label := range labels {
 		c.labelCache[repoKey][label.Name] = label.ID
 	}
+	_ = c.diskCache.Put(c.labelCacheKey(owner, repo), c.labelCache[repoKey])
+}
+
+// invalidateLabelCache drops the cached labels for a repository, e.g. after a
+// request failed because a cached label was deleted in Forgejo.
+func (c *client) invalidateLabelCache(owner, repo string) {
+	c.labelMu.Lock()
+	delete(c.labelCache, owner+"/"+repo)
 	c.labelMu.Unlock()
 
-	return nil
+	c.diskCache.Delete(c.labelCacheKey(owner, repo))
+}
+
+// labelCacheKey returns the disk cache key for a repository's labels.
+func (c *client) labelCacheKey(owner, repo string) string {
+	return plugincache.Key("forgejo", c.baseURL, "labels", owner+"/"+repo)
 }
 
-// generateLabelColor generates a color for a label based on its name.
-func generateLabelColor(name string) string {
-	// Use a simple hash to generate consistent colors
-	var hash uint32
-	for _, c := range name {
-		hash = hash*31 + uint32(c)
+// Milestone management methods
+
+// listMilestones retrieves all open and closed milestones for a repository.
+func (c *client) listMilestones(ctx context.Context, owner, repo string) ([]*Milestone, error) {
+	var allMilestones []*Milestone
+	page := 1
+	limit := 100
+
+	for {
+		path := fmt.Sprintf("/repos/%s/%s/milestones?state=all&page=%d&limit=%d", owner, repo, page, limit)
+		resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var milestones []*Milestone
+		if err := json.NewDecoder(resp.Body).Decode(&milestones); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		resp.Body.Close()
+
+		allMilestones = append(allMilestones, milestones...)
+
+		if len(milestones) < limit {
+			break
+		}
+		page++
 	}
 
-	// Generate a pastel color
-	r := 128 + (hash&0xFF)%128
-	g := 128 + ((hash>>8)&0xFF)%128
-	b := 128 + ((hash>>16)&0xFF)%128
+	return allMilestones, nil
+}
+
+// createMilestone creates a new milestone in a repository.
+func (c *client) createMilestone(ctx context.Context, owner, repo, title string) (*Milestone, error) {
+	path := fmt.Sprintf("/repos/%s/%s/milestones", owner, repo)
+	resp, err := c.doRequest(ctx, http.MethodPost, path, &CreateMilestoneRequest{Title: title})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	return fmt.Sprintf("%02x%02x%02x", r, g, b)
+	var milestone Milestone
+	if err := json.NewDecoder(resp.Body).Decode(&milestone); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &milestone, nil
+}
+
+// resolveMilestoneID converts a milestone title to its ID, creating the
+// milestone if it doesn't exist.
+func (c *client) resolveMilestoneID(ctx context.Context, owner, repo, title string) (int64, error) {
+	repoKey := owner + "/" + repo
+
+	c.milestoneMu.RLock()
+	cache, cached := c.milestoneCache[repoKey]
+	c.milestoneMu.RUnlock()
+
+	if !cached {
+		milestones, err := c.listMilestones(ctx, owner, repo)
+		if err != nil {
+			return 0, err
+		}
+		cache = make(map[string]int64, len(milestones))
+		for _, m := range milestones {
+			cache[m.Title] = m.ID
+		}
+		c.milestoneMu.Lock()
+		c.milestoneCache[repoKey] = cache
+		c.milestoneMu.Unlock()
+	}
+
+	c.milestoneMu.RLock()
+	id, ok := cache[title]
+	c.milestoneMu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	milestone, err := c.createMilestone(ctx, owner, repo, title)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create milestone %q: %w", title, err)
+	}
+
+	c.milestoneMu.Lock()
+	c.milestoneCache[repoKey][title] = milestone.ID
+	c.milestoneMu.Unlock()
+
+	return milestone.ID, nil
+}
+
+// Dependency management methods
+
+// listDependencies retrieves the issues that block an issue.
+func (c *client) listDependencies(ctx context.Context, owner, repo string, number int) ([]*Issue, error) {
+	var allIssues []*Issue
+	page := 1
+	limit := 100
+
+	for {
+		path := fmt.Sprintf("/repos/%s/%s/issues/%d/dependencies?page=%d&limit=%d", owner, repo, number, page, limit)
+		resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var issues []*Issue
+		if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		resp.Body.Close()
+
+		allIssues = append(allIssues, issues...)
+
+		if len(issues) < limit {
+			break
+		}
+		page++
+	}
+
+	return allIssues, nil
+}
+
+// addDependency makes an issue blocked by another issue.
+func (c *client) addDependency(ctx context.Context, owner, repo string, number int, blocker IssueRef) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/dependencies", owner, repo, number)
+	req := &IssueMetaRequest{Owner: blocker.Owner, Repo: blocker.Repo, Index: int64(blocker.Number)}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// removeDependency removes an issue's dependency on another issue.
+func (c *client) removeDependency(ctx context.Context, owner, repo string, number int, blocker IssueRef) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/dependencies", owner, repo, number)
+	req := &IssueMetaRequest{Owner: blocker.Owner, Repo: blocker.Repo, Index: int64(blocker.Number)}
+
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
 }
 
 // Helper to convert int64 to string
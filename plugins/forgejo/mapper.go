@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
@@ -14,8 +16,18 @@ import (
 //   - Forgejo Repository → Todu Project (external_id = "owner/repo")
 //   - Forgejo Issue → Todu Task (external_id = issue number as string)
 //   - Forgejo Issue State + StateReason → Todu Status (bidirectional with semantic preservation)
-//   - Forgejo Labels → Todu Labels (priority extracted from priority:* labels)
+//   - Forgejo Labels → Todu Labels (priority extracted from priority labels)
 //   - Forgejo Issue Comments → Todu Comments (1:1 mapping)
+//   - Forgejo Milestone → Todu Label "milestone:<title>" (due date → task DueDate)
+//   - Forgejo Issue Dependencies → Todu Labels "blocked-by:#<number>" (opt-in)
+//
+// Milestone and Dependency Labels:
+// Todu has no milestone or dependency model, so both are carried as reserved
+// labels. They are never created as real Forgejo labels; on push they set the
+// issue's milestone (creating it if needed) and add or remove dependencies.
+// Dependencies on issues in another repository use "blocked-by:owner/repo#<number>".
+// Dependencies cost one request per issue, so they are only synced when the
+// plugin's "dependencies" option is "true".
 //
 // Status Mapping (Todu → Forgejo):
 //   - done       → state: "closed", state_reason: "completed"
@@ -31,13 +43,12 @@ import (
 //   - state: "open"                                → active
 //
 // Priority Mapping:
-//   - Labels matching "priority:high" → high priority
-//   - Labels matching "priority:medium" → medium priority
-//   - Labels matching "priority:low" → low priority
-//   - Labels matching "priority:*" (invalid values) → high priority (e.g., urgent, critical, p0)
+//   - Labels in the priority_labels table (e.g., "urgent=P0,high=P1") → their level
+//   - Labels matching "priority:<level>" for a configured level → that level
+//   - Labels matching "priority:*" (other values) → high priority (e.g., critical, p0)
 //   - No priority label → no priority set
 //
-// Note: Invalid priority values are normalized to "high" to prevent accidental deprioritization
+// Note: Unknown priority values are normalized to "high" to prevent accidental deprioritization
 // and to increase visibility so users are more likely to correct the labels in Forgejo.
 
 // repoToProject converts a Forgejo repository to a Todu project.
@@ -61,7 +72,8 @@ func repoToProject(repo *Repository) *types.Project {
 }
 
 // issueToTask converts a Forgejo issue to a Todu task.
-func issueToTask(issue *Issue, repoOwner, repoName string) *types.Task {
+// priorities maps priority levels to labels; nil uses priority:<level>.
+func issueToTask(issue *Issue, repoOwner, repoName string, priorities *plugin.PriorityLabels) *types.Task {
 	externalID := strconv.Itoa(issue.Number)
 
 	var description *string
@@ -73,10 +85,17 @@ func issueToTask(issue *Issue, repoOwner, repoName string) *types.Task {
 	status := mapForgejoStatusToTodu(issue.State, issue.StateReason)
 
 	// Extract priority from labels
-	priority := extractPriority(issue.Labels)
+	priority := extractPriority(issue.Labels, priorities)
 
 	// Extract non-priority labels
-	labels := extractLabels(issue.Labels)
+	labels := extractLabels(issue.Labels, priorities)
+
+	// Map milestone to a reserved label and its due date
+	var dueDate *time.Time
+	if issue.Milestone != nil {
+		labels = append(labels, types.Label{Name: milestoneLabelPrefix + issue.Milestone.Title})
+		dueDate = issue.Milestone.DueOn
+	}
 
 	// Extract assignees
 	assignees := extractAssignees(issue.Assignees)
@@ -91,7 +110,7 @@ func issueToTask(issue *Issue, repoOwner, repoName string) *types.Task {
 		Description: description,
 		Status:      status,
 		Priority:    priority,
-		DueDate:     nil, // Forgejo doesn't have milestones with due dates in the same way
+		DueDate:     dueDate,
 		CreatedAt:   issue.CreatedAt,
 		UpdatedAt:   issue.UpdatedAt,
 		Labels:      labels,
@@ -100,40 +119,32 @@ func issueToTask(issue *Issue, repoOwner, repoName string) *types.Task {
 }
 
 // extractPriority extracts priority from Forgejo labels and normalizes to valid values.
-// Valid priorities are: high, medium, low
-// Any other priority value is mapped to "high" to avoid accidental deprioritization.
-func extractPriority(labels []*Label) *string {
+// Valid priorities are the configured levels. Any other priority:* value is
+// mapped to "high" to avoid accidental deprioritization.
+func extractPriority(labels []*Label, priorities *plugin.PriorityLabels) *string {
 	for _, label := range labels {
-		name := strings.ToLower(label.Name)
-		if strings.HasPrefix(name, "priority:") {
-			priority := strings.TrimPrefix(name, "priority:")
-
-			// Normalize to valid priority values
-			switch priority {
-			case "high", "medium", "low":
-				return &priority
-			default:
-				// Map any invalid priority to "high" to avoid deprioritization
-				// This includes values like: urgent, critical, p0, p1, etc.
-				high := "high"
-				return &high
-			}
+		if priority, ok := priorities.Priority(label.Name); ok {
+			return &priority
 		}
 	}
 	return nil
 }
 
 // extractLabels extracts non-priority labels.
-func extractLabels(labels []*Label) []types.Label {
+func extractLabels(labels []*Label, priorities *plugin.PriorityLabels) []types.Label {
 	var result []types.Label
 	for _, label := range labels {
-		name := strings.ToLower(label.Name)
 		// Skip priority labels
-		if strings.HasPrefix(name, "priority:") {
+		if _, ok := priorities.Priority(label.Name); ok {
+			continue
+		}
+		// Skip labels that would be mistaken for milestone or dependency labels
+		if isReservedLabel(label.Name) {
 			continue
 		}
 		result = append(result, types.Label{
-			Name: label.Name,
+			Name:  label.Name,
+			Color: strings.ToLower(strings.TrimPrefix(label.Color, "#")),
 		})
 	}
 	return result
@@ -259,11 +270,221 @@ func parseRepoExternalID(externalID string) (owner, repo string, err error) {
 
 // buildLabelsWithPriority creates a list of label names including priority.
 // This is used before resolving to IDs.
-func buildLabelsWithPriority(labels []string, priority *string) []string {
+func buildLabelsWithPriority(labels []string, priority *string, priorities *plugin.PriorityLabels) []string {
 	var result []string
 	if priority != nil {
-		result = append(result, fmt.Sprintf("priority:%s", *priority))
+		result = append(result, priorities.Label(*priority))
 	}
 	result = append(result, labels...)
 	return result
 }
+
+const (
+	milestoneLabelPrefix = "milestone:"
+	blockedByLabelPrefix = "blocked-by:"
+)
+
+// isReservedLabel reports whether a label name carries a milestone or dependency.
+func isReservedLabel(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasPrefix(lower, milestoneLabelPrefix) || strings.HasPrefix(lower, blockedByLabelPrefix)
+}
+
+// splitReservedLabels separates milestone and dependency labels from real labels.
+// The milestone is nil when no milestone label is present. Dependency labels
+// that can't be parsed are returned as errors rather than pushed as labels.
+func splitReservedLabels(labels []string, owner, repo string) (real []string, milestone *string, blockers []IssueRef, err error) {
+	for _, label := range labels {
+		lower := strings.ToLower(label)
+		switch {
+		case strings.HasPrefix(lower, milestoneLabelPrefix):
+			title := strings.TrimSpace(label[len(milestoneLabelPrefix):])
+			if title != "" {
+				milestone = &title
+			}
+		case strings.HasPrefix(lower, blockedByLabelPrefix):
+			ref, parseErr := parseIssueRef(label[len(blockedByLabelPrefix):], owner, repo)
+			if parseErr != nil {
+				return nil, nil, nil, fmt.Errorf("invalid dependency label %q: %w", label, parseErr)
+			}
+			blockers = append(blockers, ref)
+		default:
+			real = append(real, label)
+		}
+	}
+	return real, milestone, blockers, nil
+}
+
+// parseIssueRef parses "#12" or "owner/repo#12", defaulting to the given repository.
+func parseIssueRef(s, owner, repo string) (IssueRef, error) {
+	s = strings.TrimSpace(s)
+	idx := strings.LastIndex(s, "#")
+	if idx < 0 {
+		return IssueRef{}, fmt.Errorf("expected #<number> or owner/repo#<number>")
+	}
+
+	ref := IssueRef{Owner: owner, Repo: repo}
+	if prefix := s[:idx]; prefix != "" {
+		var err error
+		ref.Owner, ref.Repo, err = parseRepoExternalID(prefix)
+		if err != nil {
+			return IssueRef{}, err
+		}
+	}
+
+	number, err := strconv.Atoi(s[idx+1:])
+	if err != nil || number <= 0 {
+		return IssueRef{}, fmt.Errorf("invalid issue number %q", s[idx+1:])
+	}
+	ref.Number = number
+
+	return ref, nil
+}
+
+// issueRefOf returns the reference for an issue returned by the dependencies API.
+func issueRefOf(issue *Issue, owner, repo string) IssueRef {
+	ref := IssueRef{Owner: owner, Repo: repo, Number: issue.Number}
+	if issue.Repository != nil {
+		if issue.Repository.Owner != "" && issue.Repository.Name != "" {
+			ref.Owner, ref.Repo = issue.Repository.Owner, issue.Repository.Name
+		} else if o, r, err := parseRepoExternalID(issue.Repository.FullName); err == nil {
+			ref.Owner, ref.Repo = o, r
+		}
+	}
+	return ref
+}
+
+// dependencyLabel formats a dependency as a reserved label, omitting the
+// repository when it matches the issue's own.
+func dependencyLabel(ref IssueRef, owner, repo string) string {
+	if strings.EqualFold(ref.Owner, owner) && strings.EqualFold(ref.Repo, repo) {
+		return fmt.Sprintf("%s#%d", blockedByLabelPrefix, ref.Number)
+	}
+	return fmt.Sprintf("%s%s/%s#%d", blockedByLabelPrefix, ref.Owner, ref.Repo, ref.Number)
+}
+
+// sameIssueRef reports whether two references point at the same issue.
+func sameIssueRef(a, b IssueRef) bool {
+	return a.Number == b.Number && strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Repo, b.Repo)
+}
+
+// repoSearch is a repository search parsed from a GitHub-style query.
+type repoSearch struct {
+	Keywords string
+	Topic    bool
+	Owner    string
+}
+
+// parseRepoSearch parses a repository query such as "org:myorg topic:active".
+// Forgejo searches either names or topics, so "topic:" terms can't be mixed
+// with plain keywords. Owners are matched after the search.
+func parseRepoSearch(query string) (*repoSearch, error) {
+	search := &repoSearch{}
+	var keywords, topics []string
+
+	for _, term := range strings.Fields(query) {
+		qualifier, value, ok := strings.Cut(term, ":")
+		switch {
+		case ok && strings.EqualFold(qualifier, "topic"):
+			topics = append(topics, value)
+		case ok && isOwnerQualifier(qualifier):
+			search.Owner = value
+		default:
+			keywords = append(keywords, term)
+		}
+	}
+
+	switch {
+	case len(topics) > 1:
+		return nil, fmt.Errorf("only one topic: term is supported")
+	case len(topics) == 1 && len(keywords) > 0:
+		return nil, fmt.Errorf("topic: can't be combined with keywords")
+	case len(topics) == 1:
+		search.Keywords = topics[0]
+		search.Topic = true
+	default:
+		search.Keywords = strings.Join(keywords, " ")
+	}
+
+	return search, nil
+}
+
+// matchesOwner reports whether a repository belongs to the searched owner.
+func (s *repoSearch) matchesOwner(repo *Repository) bool {
+	if s.Owner == "" {
+		return true
+	}
+	return repo.Owner != nil && strings.EqualFold(repo.Owner.Login, s.Owner)
+}
+
+// issueSearch is an issue search parsed from a GitHub-style query.
+type issueSearch struct {
+	Keywords string
+	State    string
+	Labels   []string
+	Owner    string
+	Repo     string
+}
+
+// parseIssueSearch parses an issue query such as
+// "is:open label:help-wanted repo:owner/name". Supported qualifiers are
+// is:open, is:closed, state:, label:, org:/owner:/user: and repo:; other
+// terms are searched as keywords. Like GitHub, all states are searched
+// unless one is given.
+func parseIssueSearch(query string) (*issueSearch, error) {
+	search := &issueSearch{State: "all"}
+	var keywords []string
+
+	for _, term := range strings.Fields(query) {
+		qualifier, value, ok := strings.Cut(term, ":")
+		if !ok {
+			keywords = append(keywords, term)
+			continue
+		}
+
+		switch strings.ToLower(qualifier) {
+		case "is", "state":
+			switch strings.ToLower(value) {
+			case "open", "closed":
+				search.State = strings.ToLower(value)
+			case "issue":
+			default:
+				return nil, fmt.Errorf("unsupported search term %q", term)
+			}
+		case "label":
+			search.Labels = append(search.Labels, value)
+		case "org", "owner", "user":
+			search.Owner = value
+		case "repo":
+			owner, repo, err := parseRepoExternalID(value)
+			if err != nil {
+				return nil, err
+			}
+			search.Owner = owner
+			search.Repo = repo
+		default:
+			keywords = append(keywords, term)
+		}
+	}
+
+	search.Keywords = strings.Join(keywords, " ")
+	return search, nil
+}
+
+// matchesRepo reports whether an issue's repository is the searched one.
+// Forgejo filters by owner but not by repository, so repo: is applied here.
+func (s *issueSearch) matchesRepo(repo *RepoMeta) bool {
+	if s.Repo == "" {
+		return true
+	}
+	return repo != nil && strings.EqualFold(repo.Name, s.Repo) && strings.EqualFold(repo.Owner, s.Owner)
+}
+
+// isOwnerQualifier reports whether a search qualifier names an owner.
+func isOwnerQualifier(qualifier string) bool {
+	switch strings.ToLower(qualifier) {
+	case "org", "owner", "user":
+		return true
+	}
+	return false
+}
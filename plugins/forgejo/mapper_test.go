@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 )
 
@@ -173,7 +174,7 @@ func TestIssueToTask_StatusMapping(t *testing.T) {
 				UpdatedAt:   now,
 			}
 
-			task := issueToTask(issue, owner, repo)
+			task := issueToTask(issue, owner, repo, nil)
 
 			if task.Status != tt.expectedStatus {
 				t.Errorf("Expected status %s, got %s", tt.expectedStatus, task.Status)
@@ -342,7 +343,7 @@ func TestExtractPriority(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			priority := extractPriority(tt.labels)
+			priority := extractPriority(tt.labels, nil)
 
 			if tt.expectedPriority == nil {
 				if priority != nil {
@@ -404,11 +405,20 @@ func TestExtractLabels(t *testing.T) {
 			},
 			expectedLabels: []string{"bug"},
 		},
+		{
+			name: "filters out reserved milestone and dependency labels",
+			labels: []*Label{
+				{Name: "milestone:v1"},
+				{Name: "blocked-by:#3"},
+				{Name: "bug"},
+			},
+			expectedLabels: []string{"bug"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			labels := extractLabels(tt.labels)
+			labels := extractLabels(tt.labels, nil)
 
 			if len(labels) != len(tt.expectedLabels) {
 				t.Fatalf("Expected %d labels, got %d", len(tt.expectedLabels), len(labels))
@@ -533,6 +543,34 @@ func TestParseRepoExternalID(t *testing.T) {
 	}
 }
 
+// TestPriorityLabelsMapping tests that a priority_labels table maps
+// configured levels to custom labels in both directions.
+func TestPriorityLabelsMapping(t *testing.T) {
+	t.Cleanup(func() { _ = types.SetPriorities(nil) })
+	if err := types.SetPriorities([]string{"none", "low", "medium", "high", "urgent"}); err != nil {
+		t.Fatal(err)
+	}
+	priorities, err := plugin.ParsePriorityLabels("urgent=P0,none=triage")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := &Issue{Number: 1, State: "open", Labels: []*Label{{Name: "Triage"}, {Name: "bug"}}}
+	task := issueToTask(issue, "owner", "repo", priorities)
+	if task.Priority == nil || *task.Priority != "none" {
+		t.Errorf("Expected Triage to map to none, got %v", task.Priority)
+	}
+	if len(task.Labels) != 1 || task.Labels[0].Name != "bug" {
+		t.Errorf("Expected Triage to be left out of labels, got %v", task.Labels)
+	}
+
+	urgent := "urgent"
+	labels := buildLabelsWithPriority([]string{"bug"}, &urgent, priorities)
+	if len(labels) != 2 || labels[0] != "P0" {
+		t.Errorf("Expected urgent to be written as P0, got %v", labels)
+	}
+}
+
 // TestBuildLabelsWithPriority tests building label list with priority included.
 func TestBuildLabelsWithPriority(t *testing.T) {
 	tests := []struct {
@@ -569,7 +607,7 @@ func TestBuildLabelsWithPriority(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildLabelsWithPriority(tt.labels, tt.priority)
+			result := buildLabelsWithPriority(tt.labels, tt.priority, nil)
 
 			if len(result) != len(tt.expectedLabels) {
 				t.Fatalf("Expected %d labels, got %d: %v", len(tt.expectedLabels), len(result), result)
@@ -804,3 +842,143 @@ func TestCommentToComment(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+// TestIssueToTask_Milestone tests that a milestone becomes a label and due date.
+func TestIssueToTask_Milestone(t *testing.T) {
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issue := &Issue{
+		Number:    7,
+		Title:     "Ship it",
+		State:     "open",
+		Labels:    []*Label{{Name: "bug"}},
+		Milestone: &Milestone{ID: 2, Title: "v1.0", DueOn: &due},
+	}
+
+	task := issueToTask(issue, "owner", "repo", nil)
+
+	if len(task.Labels) != 2 || task.Labels[1].Name != "milestone:v1.0" {
+		t.Errorf("Expected labels [bug milestone:v1.0], got %v", task.Labels)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(due) {
+		t.Errorf("Expected due date %v, got %v", due, task.DueDate)
+	}
+
+	issue.Milestone = nil
+	task = issueToTask(issue, "owner", "repo", nil)
+	if len(task.Labels) != 1 || task.DueDate != nil {
+		t.Errorf("Expected no milestone label or due date, got %v, %v", task.Labels, task.DueDate)
+	}
+}
+
+// TestSplitReservedLabels tests separating milestone and dependency labels.
+func TestSplitReservedLabels(t *testing.T) {
+	real, milestone, blockers, err := splitReservedLabels(
+		[]string{"bug", "Milestone:v2", "blocked-by:#4", "blocked-by:other/lib#9"},
+		"owner", "repo",
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(real) != 1 || real[0] != "bug" {
+		t.Errorf("Expected real labels [bug], got %v", real)
+	}
+	if milestone == nil || *milestone != "v2" {
+		t.Errorf("Expected milestone v2, got %v", milestone)
+	}
+
+	expected := []IssueRef{
+		{Owner: "owner", Repo: "repo", Number: 4},
+		{Owner: "other", Repo: "lib", Number: 9},
+	}
+	if len(blockers) != len(expected) {
+		t.Fatalf("Expected %d blockers, got %d", len(expected), len(blockers))
+	}
+	for i, ref := range expected {
+		if blockers[i] != ref {
+			t.Errorf("Expected blocker %v at position %d, got %v", ref, i, blockers[i])
+		}
+	}
+
+	for _, label := range []string{"blocked-by:4", "blocked-by:#x", "blocked-by:bad#1"} {
+		if _, _, _, err := splitReservedLabels([]string{label}, "owner", "repo"); err == nil {
+			t.Errorf("Expected error for %q", label)
+		}
+	}
+}
+
+// TestDependencyLabel tests formatting dependencies as labels.
+func TestDependencyLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      IssueRef
+		expected string
+	}{
+		{
+			name:     "same repository omits owner and repo",
+			ref:      IssueRef{Owner: "Owner", Repo: "repo", Number: 3},
+			expected: "blocked-by:#3",
+		},
+		{
+			name:     "other repository is qualified",
+			ref:      IssueRef{Owner: "other", Repo: "lib", Number: 9},
+			expected: "blocked-by:other/lib#9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependencyLabel(tt.ref, "owner", "repo"); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseRepoSearch tests parsing repository search queries.
+func TestParseRepoSearch(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected *repoSearch
+		wantErr  bool
+	}{
+		{"org:myorg topic:active", &repoSearch{Keywords: "active", Topic: true, Owner: "myorg"}, false},
+		{"tools cli", &repoSearch{Keywords: "tools cli"}, false},
+		{"topic:a topic:b", nil, true},
+		{"topic:a tools", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRepoSearch(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRepoSearch(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if tt.expected != nil && *got != *tt.expected {
+			t.Errorf("parseRepoSearch(%q) = %+v, want %+v", tt.query, got, tt.expected)
+		}
+	}
+}
+
+// TestParseIssueSearch tests parsing issue search queries.
+func TestParseIssueSearch(t *testing.T) {
+	search, err := parseIssueSearch("is:closed label:bug label:ui repo:acme/web is:issue login fails")
+	if err != nil {
+		t.Fatalf("parseIssueSearch failed: %v", err)
+	}
+	if search.State != "closed" || search.Owner != "acme" || search.Repo != "web" || search.Keywords != "login fails" {
+		t.Errorf("Unexpected search: %+v", search)
+	}
+	if len(search.Labels) != 2 || search.Labels[0] != "bug" || search.Labels[1] != "ui" {
+		t.Errorf("Expected labels [bug ui], got %v", search.Labels)
+	}
+
+	search, err = parseIssueSearch("crash")
+	if err != nil || search.State != "all" {
+		t.Errorf("Expected all states by default, got %+v (%v)", search, err)
+	}
+
+	if _, err := parseIssueSearch("is:pr"); err == nil {
+		t.Error("Expected error for is:pr")
+	}
+}
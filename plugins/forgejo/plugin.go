@@ -16,6 +16,16 @@ import (
 type Plugin struct {
 	client *client
 	config map[string]string
+
+	// syncDependencies enables fetching and pushing issue dependencies.
+	syncDependencies bool
+
+	// priorities maps priority levels to labels, from priority_labels.
+	priorities *plugin.PriorityLabels
+
+	// fieldsFrontMatter syncs custom fields as front matter in issue bodies
+	// rather than as labels, from custom_fields.
+	fieldsFrontMatter bool
 }
 
 // init registers the Forgejo plugin with the global registry.
@@ -39,6 +49,10 @@ func (p *Plugin) Version() string {
 // Required configuration keys:
 //   - token: Forgejo personal access token
 //   - url: Forgejo instance base URL (e.g., "https://forgejo.example.com")
+//
+// Optional configuration keys:
+//   - dependencies: "true" to sync issue dependencies as blocked-by labels
+//   - custom_fields: "frontmatter" to sync custom fields in issue bodies
 func (p *Plugin) Configure(config map[string]string) error {
 	p.config = config
 
@@ -47,8 +61,20 @@ func (p *Plugin) Configure(config map[string]string) error {
 		return err
 	}
 
-	// Create Forgejo API client
+	p.syncDependencies = strings.EqualFold(config["dependencies"], "true")
+
 	var err error
+	p.priorities, err = plugin.ParsePriorityLabels(config["priority_labels"])
+	if err != nil {
+		return fmt.Errorf("invalid priority_labels: %w", err)
+	}
+
+	p.fieldsFrontMatter, err = plugin.ParseCustomFields(config["custom_fields"])
+	if err != nil {
+		return err
+	}
+
+	// Create Forgejo API client
 	p.client, err = newClient(config)
 	if err != nil {
 		return fmt.Errorf("failed to create Forgejo client: %w", err)
@@ -137,7 +163,10 @@ func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, sinc
 
 	tasks := make([]*types.Task, len(issues))
 	for i, issue := range issues {
-		tasks[i] = issueToTask(issue, owner, repo)
+		tasks[i] = p.toTask(issue, owner, repo)
+		if err := p.addDependencyLabels(ctx, tasks[i], owner, repo, issue.Number); err != nil {
+			return nil, handleForgejoError(err, fmt.Sprintf("failed to list dependencies for %s#%d", *projectExternalID, issue.Number))
+		}
 	}
 
 	return tasks, nil
@@ -168,7 +197,22 @@ func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskE
 		return nil, handleForgejoError(err, fmt.Sprintf("failed to fetch issue %s#%s", *projectExternalID, taskExternalID))
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	result := p.toTask(issue, owner, repo)
+	if err := p.addDependencyLabels(ctx, result, owner, repo, issueNumber); err != nil {
+		return nil, handleForgejoError(err, fmt.Sprintf("failed to list dependencies for %s#%s", *projectExternalID, taskExternalID))
+	}
+
+	return result, nil
+}
+
+// toTask converts an issue to a task, reading custom fields out of its body
+// when they're synced as front matter.
+func (p *Plugin) toTask(issue *Issue, owner, repo string) *types.Task {
+	task := issueToTask(issue, owner, repo, p.priorities)
+	if p.fieldsFrontMatter {
+		plugin.FieldsFromFrontMatter(task)
+	}
+	return task
 }
 
 // CreateTask creates a new issue in Forgejo.
@@ -186,8 +230,20 @@ func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task
 		return nil, err
 	}
 
+	if p.fieldsFrontMatter {
+		created := *task
+		created.Labels, created.Description = plugin.FieldsToFrontMatter(task.Labels, task.Description)
+		task = &created
+	}
+
+	// Separate milestone and dependency labels from real labels
+	realLabels, milestone, blockers, err := splitReservedLabels(task.Labels, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build label names including priority
-	labelNames := buildLabelsWithPriority(task.Labels, task.Priority)
+	labelNames := buildLabelsWithPriority(realLabels, task.Priority, p.priorities)
 
 	// Resolve label names to IDs (auto-creating if necessary)
 	labelIDs, err := p.client.resolveLabelIDs(ctx, owner, repo, labelNames)
@@ -196,12 +252,32 @@ func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task
 	}
 
 	req := taskCreateToIssueRequest(task, labelIDs)
+	if milestone != nil {
+		req.Milestone, err = p.client.resolveMilestoneID(ctx, owner, repo, *milestone)
+		if err != nil {
+			return nil, handleForgejoError(err, fmt.Sprintf("failed to resolve milestone for %s", *projectExternalID))
+		}
+	}
+
 	issue, err := p.client.createIssue(ctx, owner, repo, req)
 	if err != nil {
+		if len(labelIDs) > 0 {
+			p.client.invalidateLabelCache(owner, repo)
+		}
 		return nil, handleForgejoError(err, fmt.Sprintf("failed to create issue in %s", *projectExternalID))
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	result := p.toTask(issue, owner, repo)
+	if p.syncDependencies && len(blockers) > 0 {
+		if err := p.syncIssueDependencies(ctx, owner, repo, issue.Number, blockers); err != nil {
+			return nil, handleForgejoError(err, fmt.Sprintf("failed to add dependencies for %s#%d", *projectExternalID, issue.Number))
+		}
+		for _, ref := range blockers {
+			result.Labels = append(result.Labels, types.Label{Name: dependencyLabel(ref, owner, repo)})
+		}
+	}
+
+	return result, nil
 }
 
 // UpdateTask updates an existing issue in Forgejo.
@@ -224,8 +300,32 @@ func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, task
 		return nil, fmt.Errorf("invalid task external_id: %w", err)
 	}
 
+	if p.fieldsFrontMatter {
+		updated := *task
+		updated.Labels, updated.Description = plugin.FieldsToFrontMatter(task.Labels, task.Description)
+		task = &updated
+	}
+
+	// Separate milestone and dependency labels from real labels. A full label
+	// set without a milestone label clears the issue's milestone.
+	realLabels, milestone, blockers, err := splitReservedLabels(task.Labels, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update issue metadata first
 	req := taskUpdateToIssueRequest(task)
+	if len(task.Labels) > 0 {
+		var milestoneID int64
+		if milestone != nil {
+			milestoneID, err = p.client.resolveMilestoneID(ctx, owner, repo, *milestone)
+			if err != nil {
+				return nil, handleForgejoError(err, fmt.Sprintf("failed to resolve milestone for %s#%s", *projectExternalID, taskExternalID))
+			}
+		}
+		req.Milestone = &milestoneID
+	}
+
 	issue, err := p.client.updateIssue(ctx, owner, repo, issueNumber, req)
 	if err != nil {
 		return nil, handleForgejoError(err, fmt.Sprintf("failed to update issue %s#%s", *projectExternalID, taskExternalID))
@@ -235,14 +335,14 @@ func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, task
 	if len(task.Labels) > 0 || task.Priority != nil {
 		var labelNames []string
 		if len(task.Labels) > 0 {
-			labelNames = buildLabelsWithPriority(task.Labels, task.Priority)
+			labelNames = buildLabelsWithPriority(realLabels, task.Priority, p.priorities)
 		} else if task.Priority != nil {
 			// Only priority is being updated, need to preserve existing non-priority labels
-			existingLabels := extractLabels(issue.Labels)
+			existingLabels := extractLabels(issue.Labels, p.priorities)
 			for _, l := range existingLabels {
 				labelNames = append(labelNames, l.Name)
 			}
-			labelNames = buildLabelsWithPriority(labelNames, task.Priority)
+			labelNames = buildLabelsWithPriority(labelNames, task.Priority, p.priorities)
 		}
 
 		labelIDs, err := p.client.resolveLabelIDs(ctx, owner, repo, labelNames)
@@ -252,15 +352,35 @@ func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, task
 
 		issue, err = p.client.updateIssueLabels(ctx, owner, repo, issueNumber, labelIDs)
 		if err != nil {
+			p.client.invalidateLabelCache(owner, repo)
 			return nil, handleForgejoError(err, fmt.Sprintf("failed to update labels for %s#%s", *projectExternalID, taskExternalID))
 		}
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	result := p.toTask(issue, owner, repo)
+
+	// Update dependencies when a full label set was provided
+	if p.syncDependencies && len(task.Labels) > 0 {
+		if err := p.syncIssueDependencies(ctx, owner, repo, issueNumber, blockers); err != nil {
+			return nil, handleForgejoError(err, fmt.Sprintf("failed to update dependencies for %s#%s", *projectExternalID, taskExternalID))
+		}
+		for _, ref := range blockers {
+			result.Labels = append(result.Labels, types.Label{Name: dependencyLabel(ref, owner, repo)})
+		}
+	} else if err := p.addDependencyLabels(ctx, result, owner, repo, issueNumber); err != nil {
+		return nil, handleForgejoError(err, fmt.Sprintf("failed to list dependencies for %s#%s", *projectExternalID, taskExternalID))
+	}
+
+	return result, nil
 }
 
 // FetchComments retrieves all comments for an issue.
 func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	return p.FetchCommentsSince(ctx, projectExternalID, taskExternalID, nil)
+}
+
+// FetchCommentsSince retrieves the comments of an issue updated at or after since.
+func (p *Plugin) FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error) {
 	if p.client == nil {
 		return nil, plugin.ErrNotConfigured
 	}
@@ -279,7 +399,7 @@ func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, t
 		return nil, fmt.Errorf("invalid task external_id: %w", err)
 	}
 
-	comments, err := p.client.listComments(ctx, owner, repo, issueNumber)
+	comments, err := p.client.listComments(ctx, owner, repo, issueNumber, since)
 	if err != nil {
 		return nil, handleForgejoError(err, fmt.Sprintf("failed to list comments for %s#%s", *projectExternalID, taskExternalID))
 	}
@@ -320,6 +440,226 @@ func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, t
 	return commentToComment(fgComment), nil
 }
 
+// UpdateComment replaces the body of an existing comment.
+func (p *Plugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for Forgejo")
+	}
+
+	owner, repo, err := parseRepoExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := strconv.ParseInt(commentExternalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment external_id: %w", err)
+	}
+
+	fgComment, err := p.client.editComment(ctx, owner, repo, commentID, comment.Content)
+	if err != nil {
+		return nil, handleForgejoError(err, fmt.Sprintf("failed to update comment %s on %s#%s", commentExternalID, *projectExternalID, taskExternalID))
+	}
+
+	return commentToComment(fgComment), nil
+}
+
+// PushLabels creates the labels missing from a repository, refreshing the
+// label cache so the next sync finds them. Milestone and dependency labels
+// are skipped, since sync maps them to milestones and dependencies.
+func (p *Plugin) PushLabels(ctx context.Context, projectExternalID *string, labels []string, opts plugin.LabelPushOptions) (*plugin.LabelPushResult, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for Forgejo")
+	}
+
+	owner, repo, err := parseRepoExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.client.listLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, handleForgejoError(err, fmt.Sprintf("failed to list labels for %s", *projectExternalID))
+	}
+
+	var names []string
+	for _, name := range labels {
+		if !isReservedLabel(name) {
+			names = append(names, p.priorities.Relabel(name))
+		}
+	}
+	remote := make([]plugin.RemoteLabel, len(existing))
+	for i, label := range existing {
+		remote[i] = plugin.RemoteLabel{Name: label.Name, Color: label.Color}
+	}
+
+	result, missing := plugin.PlanLabelPush(names, remote, opts)
+	err = plugin.CreateLabels(ctx, result, missing, opts, func(name string) error {
+		label, err := p.client.createLabel(ctx, owner, repo, name, opts.ColorFor(name))
+		if err != nil {
+			return handleForgejoError(err, fmt.Sprintf("failed to create label %q", name))
+		}
+		existing = append(existing, label)
+		return nil
+	})
+	if !opts.DryRun {
+		p.client.cacheLabels(owner, repo, existing)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SearchProjects returns the repositories matching a query such as
+// "org:myorg topic:active". See parseRepoSearch for the supported terms.
+func (p *Plugin) SearchProjects(ctx context.Context, query string) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	search, err := parseRepoSearch(query)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := p.client.searchRepositories(ctx, search.Keywords, search.Topic)
+	if err != nil {
+		return nil, handleForgejoError(err, "failed to search repositories")
+	}
+
+	var projects []*types.Project
+	for _, repo := range repos {
+		if search.matchesOwner(repo) {
+			projects = append(projects, repoToProject(repo))
+		}
+	}
+
+	return projects, nil
+}
+
+// SearchTasks returns the issues matching a query such as
+// "is:open label:help-wanted repo:owner/name". See parseIssueSearch for the
+// supported terms.
+func (p *Plugin) SearchTasks(ctx context.Context, query string) ([]*plugin.SearchResult, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	search, err := parseIssueSearch(query)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := p.client.searchIssues(ctx, search)
+	if err != nil {
+		return nil, handleForgejoError(err, "failed to search issues")
+	}
+
+	results := make([]*plugin.SearchResult, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Repository == nil {
+			return nil, fmt.Errorf("search result #%d has no repository", issue.Number)
+		}
+		owner, repo := issue.Repository.Owner, issue.Repository.Name
+		task := p.toTask(issue, owner, repo)
+		if err := p.addDependencyLabels(ctx, task, owner, repo, issue.Number); err != nil {
+			return nil, handleForgejoError(err, fmt.Sprintf("failed to list dependencies for %s/%s#%d", owner, repo, issue.Number))
+		}
+		results = append(results, &plugin.SearchResult{
+			ProjectExternalID: owner + "/" + repo,
+			Task:              task,
+		})
+	}
+
+	return results, nil
+}
+
+// CurrentUser returns the login of the user the configured token belongs to.
+func (p *Plugin) CurrentUser(ctx context.Context) (string, error) {
+	if p.client == nil {
+		return "", plugin.ErrNotConfigured
+	}
+
+	user, err := p.client.getCurrentUser(ctx)
+	if err != nil {
+		return "", handleForgejoError(err, "failed to get authenticated user")
+	}
+
+	return user.Login, nil
+}
+
+// addDependencyLabels appends a blocked-by label for each issue blocking the
+// given issue. It does nothing unless dependency sync is enabled.
+func (p *Plugin) addDependencyLabels(ctx context.Context, task *types.Task, owner, repo string, number int) error {
+	if !p.syncDependencies {
+		return nil
+	}
+
+	blockers, err := p.client.listDependencies(ctx, owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	for _, blocker := range blockers {
+		ref := issueRefOf(blocker, owner, repo)
+		task.Labels = append(task.Labels, types.Label{Name: dependencyLabel(ref, owner, repo)})
+	}
+
+	return nil
+}
+
+// syncIssueDependencies makes an issue's dependencies match the desired set,
+// adding missing dependencies and removing ones no longer listed.
+func (p *Plugin) syncIssueDependencies(ctx context.Context, owner, repo string, number int, desired []IssueRef) error {
+	existing, err := p.client.listDependencies(ctx, owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	current := make([]IssueRef, len(existing))
+	for i, issue := range existing {
+		current[i] = issueRefOf(issue, owner, repo)
+	}
+
+	for _, ref := range desired {
+		if !containsIssueRef(current, ref) {
+			if err := p.client.addDependency(ctx, owner, repo, number, ref); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ref := range current {
+		if !containsIssueRef(desired, ref) {
+			if err := p.client.removeDependency(ctx, owner, repo, number, ref); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// containsIssueRef reports whether refs contains ref.
+func containsIssueRef(refs []IssueRef, ref IssueRef) bool {
+	for _, r := range refs {
+		if sameIssueRef(r, ref) {
+			return true
+		}
+	}
+	return false
+}
+
 // handleForgejoError converts Forgejo API errors to plugin errors.
 func handleForgejoError(err error, context string) error {
 	if err == nil {
@@ -0,0 +1,267 @@
+package forgejo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// TestUpdateTask_SyncsMilestoneAndDependencies tests that reserved labels set
+// the milestone and add or remove dependencies instead of creating labels.
+func TestUpdateTask_SyncsMilestoneAndDependencies(t *testing.T) {
+	var (
+		updateBody map[string]any
+		added      []int64
+		removed    []int64
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*Milestone{{ID: 5, Title: "v1.0"}})
+	})
+	mux.HandleFunc("PATCH /api/v1/repos/owner/repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&updateBody)
+		json.NewEncoder(w).Encode(&Issue{Number: 1, State: "open", Milestone: &Milestone{ID: 5, Title: "v1.0"}})
+	})
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*Label{{ID: 10, Name: "bug"}})
+	})
+	mux.HandleFunc("PUT /api/v1/repos/owner/repo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*Label{{ID: 10, Name: "bug"}})
+	})
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Issue{Number: 1, State: "open", Labels: []*Label{{ID: 10, Name: "bug"}}, Milestone: &Milestone{ID: 5, Title: "v1.0"}})
+	})
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/issues/1/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*Issue{
+			{Number: 2, Repository: &RepoMeta{Owner: "owner", Name: "repo"}},
+			{Number: 3, Repository: &RepoMeta{Owner: "owner", Name: "repo"}},
+		})
+	})
+	mux.HandleFunc("POST /api/v1/repos/owner/repo/issues/1/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		var meta IssueMetaRequest
+		json.NewDecoder(r.Body).Decode(&meta)
+		added = append(added, meta.Index)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("{}"))
+	})
+	mux.HandleFunc("DELETE /api/v1/repos/owner/repo/issues/1/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		var meta IssueMetaRequest
+		json.NewDecoder(r.Body).Decode(&meta)
+		removed = append(removed, meta.Index)
+		w.Write([]byte("{}"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{"token": "t", "url": server.URL, "dependencies": "true"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	project := "owner/repo"
+	task, err := p.UpdateTask(context.Background(), &project, "1", &types.TaskUpdate{
+		Labels: []string{"bug", "milestone:v1.0", "blocked-by:#2", "blocked-by:#4"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+
+	if updateBody["milestone"] != float64(5) {
+		t.Errorf("Expected milestone 5 in update request, got %v", updateBody["milestone"])
+	}
+	if len(added) != 1 || added[0] != 4 {
+		t.Errorf("Expected dependency #4 to be added, got %v", added)
+	}
+	if len(removed) != 1 || removed[0] != 3 {
+		t.Errorf("Expected dependency #3 to be removed, got %v", removed)
+	}
+
+	var names []string
+	for _, l := range task.Labels {
+		names = append(names, l.Name)
+	}
+	sort.Strings(names)
+	expected := []string{"blocked-by:#2", "blocked-by:#4", "bug", "milestone:v1.0"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected labels %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected labels %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+// TestFetchCommentsSince tests that the since filter is sent with comment requests.
+func TestFetchCommentsSince(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("since")
+		json.NewEncoder(w).Encode([]*Comment{{ID: 9, Body: "new"}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{"token": "t", "url": server.URL}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	project := "owner/repo"
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	comments, err := p.FetchCommentsSince(context.Background(), &project, "1", &since)
+	if err != nil {
+		t.Fatalf("FetchCommentsSince failed: %v", err)
+	}
+	if query != "2025-01-02T03:04:05Z" {
+		t.Errorf("Expected since query 2025-01-02T03:04:05Z, got %q", query)
+	}
+	if len(comments) != 1 || comments[0].ExternalID != "9" {
+		t.Errorf("Unexpected comments: %+v", comments)
+	}
+}
+
+// TestResolveLabelIDs_DiskCache tests that label IDs are reused across clients
+// until the cache is invalidated.
+func TestResolveLabelIDs_DiskCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	listed := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		json.NewEncoder(w).Encode([]*Label{{ID: 10, Name: "bug"}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := map[string]string{"token": "t", "url": server.URL}
+	for i := 0; i < 2; i++ {
+		c, err := newClient(config)
+		if err != nil {
+			t.Fatalf("newClient failed: %v", err)
+		}
+		ids, err := c.resolveLabelIDs(context.Background(), "owner", "repo", []string{"bug"})
+		if err != nil || len(ids) != 1 || ids[0] != 10 {
+			t.Fatalf("Expected label ID 10, got %v (err %v)", ids, err)
+		}
+		if i == 1 {
+			c.invalidateLabelCache("owner", "repo")
+		}
+	}
+	if listed != 1 {
+		t.Errorf("Expected labels to be listed once, got %d", listed)
+	}
+
+	c, _ := newClient(config)
+	if _, err := c.resolveLabelIDs(context.Background(), "owner", "repo", []string{"bug"}); err != nil {
+		t.Fatalf("resolveLabelIDs failed: %v", err)
+	}
+	if listed != 2 {
+		t.Errorf("Expected labels to be listed again after invalidation, got %d", listed)
+	}
+}
+
+// TestSearchTasks tests that qualifiers are sent as search parameters and that
+// repo: and pull requests are filtered from the results.
+func TestSearchTasks(t *testing.T) {
+	var params map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/issues/search", func(w http.ResponseWriter, r *http.Request) {
+		params = map[string]string{}
+		for key := range r.URL.Query() {
+			params[key] = r.URL.Query().Get(key)
+		}
+		w.Write([]byte(`[
+			{"number":1,"title":"Wanted","state":"open","repository":{"name":"repo","owner":"owner","full_name":"owner/repo"}},
+			{"number":2,"title":"Other repo","state":"open","repository":{"name":"other","owner":"owner","full_name":"owner/other"}},
+			{"number":3,"title":"PR","state":"open","pull_request":{},"repository":{"name":"repo","owner":"owner","full_name":"owner/repo"}}
+		]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{"token": "t", "url": server.URL}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	results, err := p.SearchTasks(context.Background(), "is:open label:help-wanted repo:owner/repo crash")
+	if err != nil {
+		t.Fatalf("SearchTasks failed: %v", err)
+	}
+
+	expected := map[string]string{"state": "open", "labels": "help-wanted", "owner": "owner", "q": "crash", "type": "issues"}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, params[key])
+		}
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].ProjectExternalID != "owner/repo" || results[0].Task.ExternalID != "1" {
+		t.Errorf("Unexpected result: %s %+v", results[0].ProjectExternalID, results[0].Task)
+	}
+}
+
+// TestPushLabels tests that missing labels are created, reserved labels are
+// skipped, and created labels are cached for the next sync.
+func TestPushLabels(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var created []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*Label{{ID: 10, Name: "bug", Color: "ee0701"}})
+	})
+	mux.HandleFunc("POST /api/v1/repos/owner/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		var req CreateLabelRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		created = append(created, req.Name)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&Label{ID: 11, Name: req.Name, Color: req.Color})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{"token": "t", "url": server.URL}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	project := "owner/repo"
+	result, err := p.PushLabels(context.Background(), &project, []string{"bug", "docs", "milestone:v1.0"}, plugin.LabelPushOptions{})
+	if err != nil {
+		t.Fatalf("PushLabels failed: %v", err)
+	}
+	if len(created) != 1 || created[0] != "docs" || len(result.Created) != 1 {
+		t.Errorf("Expected only docs to be created, got %v", created)
+	}
+	if len(result.Drift) != 1 || result.Drift[0].Color != "ee0701" {
+		t.Errorf("Expected bug's color to be reported as drift, got %+v", result.Drift)
+	}
+
+	ids, err := p.client.resolveLabelIDs(context.Background(), "owner", "repo", []string{"docs"})
+	if err != nil || len(ids) != 1 || ids[0] != 11 {
+		t.Errorf("Expected docs to be cached as label 11, got %v (err %v)", ids, err)
+	}
+}
@@ -0,0 +1,251 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/httpcache"
+	"github.com/evcraddock/todu.sh/internal/replay"
+)
+
+// client is an HTTP client for a tracker described by a mapping.
+type client struct {
+	baseURL    string
+	token      string
+	mapping    *Mapping
+	httpClient *http.Client
+}
+
+// newClient creates a client for a mapping. The configured url overrides the
+// mapping's base URL and is substituted for {base} in the task URL template.
+func newClient(mapping *Mapping, config map[string]string) (*client, error) {
+	baseURL := strings.TrimSpace(config["url"])
+	if baseURL == "" {
+		baseURL = mapping.URL
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("url is required (set it in the mapping file or the plugin configuration)")
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	mapping.Tasks.URL = strings.ReplaceAll(mapping.Tasks.URL, "{base}", baseURL)
+
+	// Keep a token sent in the query string out of --record sessions
+	replay.RedactQueryParam(mapping.Auth.Query)
+
+	return &client{
+		baseURL:    baseURL,
+		token:      strings.TrimSpace(config["token"]),
+		mapping:    mapping,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: httpcache.NewTransport(nil)},
+	}, nil
+}
+
+// doRequest performs a request and decodes the JSON response. JSON numbers
+// are decoded as json.Number so IDs keep their exact form. An empty response
+// body decodes to nil.
+func (c *client) doRequest(ctx context.Context, method, path string, body any) (any, error) {
+	fullURL := c.baseURL + path
+	if c.token != "" && c.mapping.Auth.Query != "" {
+		fullURL = addQuery(fullURL, url.QueryEscape(c.mapping.Auth.Query)+"="+url.QueryEscape(c.token))
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range c.mapping.Headers {
+		req.Header.Set(key, value)
+	}
+	if c.token != "" && c.mapping.Auth.Query == "" {
+		if c.mapping.Auth.Header != "" {
+			req.Header.Set(c.mapping.Auth.Header, c.mapping.Auth.Prefix+c.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(data))
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result, nil
+}
+
+// list fetches every page of a list endpoint and returns the items.
+// extraQuery is added to the first request's query string.
+func (c *client) list(ctx context.Context, endpoint *Endpoint, vars map[string]string, extraQuery string) ([]any, error) {
+	var all []any
+	for page := 0; ; page++ {
+		items, more, err := c.listPage(ctx, endpoint, vars, extraQuery, page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if !more {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// listPage fetches one page of a list endpoint, counting pages from 0, and
+// returns its items and whether another page may follow. An endpoint without
+// pagination has a single page.
+func (c *client) listPage(ctx context.Context, endpoint *Endpoint, vars map[string]string, extraQuery string, page int) ([]any, bool, error) {
+	pagePath := addQuery(expandPath(endpoint.Path, vars), extraQuery)
+	paging := endpoint.Pagination
+
+	size := paging.Size
+	if size <= 0 {
+		size = 100
+	}
+
+	if paging.Page != "" {
+		pagePath = addQuery(pagePath, fmt.Sprintf("%s=%d", paging.Page, page+1))
+	}
+	if paging.Offset != "" {
+		pagePath = addQuery(pagePath, fmt.Sprintf("%s=%d", paging.Offset, page*size))
+	}
+	if paging.Limit != "" {
+		pagePath = addQuery(pagePath, fmt.Sprintf("%s=%d", paging.Limit, size))
+	}
+
+	result, err := c.doRequest(ctx, methodOr(endpoint.Method, http.MethodGet), pagePath, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	items, err := itemsAt(result, expandPath(endpoint.Items, vars))
+	if err != nil {
+		return nil, false, err
+	}
+
+	more := (paging.Page != "" || paging.Offset != "") && len(items) >= size
+	return items, more, nil
+}
+
+// get fetches a single object from an endpoint.
+func (c *client) get(ctx context.Context, endpoint *Endpoint, vars map[string]string) (map[string]any, error) {
+	result, err := c.doRequest(ctx, methodOr(endpoint.Method, http.MethodGet), expandPath(endpoint.Path, vars), nil)
+	if err != nil {
+		return nil, err
+	}
+	return objectAt(result, endpoint.Item)
+}
+
+// send writes body to an endpoint, adding the endpoint's fixed fields and
+// nesting it under the endpoint's wrap key. Returns the object in the
+// response, or nil if the response is empty.
+func (c *client) send(ctx context.Context, endpoint *Endpoint, defaultMethod string, vars map[string]string, body map[string]any) (map[string]any, error) {
+	for key, value := range endpoint.Body {
+		if _, ok := body[key]; !ok {
+			body[key] = value
+		}
+	}
+
+	var payload any = body
+	if endpoint.Wrap != "" {
+		payload = map[string]any{endpoint.Wrap: body}
+	}
+
+	result, err := c.doRequest(ctx, methodOr(endpoint.Method, defaultMethod), expandPath(endpoint.Path, vars), payload)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return objectAt(result, endpoint.Item)
+}
+
+// expandPath substitutes {name} placeholders with URL-escaped values.
+func expandPath(template string, vars map[string]string) string {
+	pairs := make([]string, 0, len(vars)*2)
+	for name, value := range vars {
+		pairs = append(pairs, "{"+name+"}", url.PathEscape(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// sinceQuery expands a since template with an RFC 3339 timestamp.
+func sinceQuery(template string, since time.Time) string {
+	return strings.ReplaceAll(template, "{since}", url.QueryEscape(since.UTC().Format(time.RFC3339)))
+}
+
+// addQuery appends a query-string fragment to a path.
+func addQuery(path, query string) string {
+	if query == "" {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + query
+	}
+	return path + "?" + query
+}
+
+// methodOr returns method in upper case, or fallback if it is empty.
+func methodOr(method, fallback string) string {
+	if method == "" {
+		return fallback
+	}
+	return strings.ToUpper(method)
+}
+
+// formatID converts an ID value from a response to a string.
+func formatID(v any) string {
+	switch id := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return id
+	case json.Number:
+		return id.String()
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	default:
+		return fmt.Sprint(id)
+	}
+}
@@ -0,0 +1,275 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// mapper.go converts between decoded JSON responses and todu types using
+// the field paths of a mapping.
+//
+// Mappings:
+//   - Tracker project → Todu Project (external_id = projects.fields.id)
+//   - Tracker task → Todu Task (external_id = tasks.fields.id)
+//   - Tracker status → Todu Status via tasks.statuses (unmapped → active)
+//   - Tracker priority → Todu Priority via tasks.priorities (unmapped → none)
+//   - Tracker comment → Todu Comment (1:1 mapping)
+
+// lookup returns the value at a dotted path. When the path crosses an array,
+// the rest of the path is applied to each element and the results are
+// flattened into one array. Returns nil if the path doesn't exist.
+func lookup(value any, path string) any {
+	if path == "" {
+		return value
+	}
+
+	segment, rest, _ := strings.Cut(path, ".")
+	switch v := value.(type) {
+	case map[string]any:
+		return lookup(v[segment], rest)
+	case []any:
+		if index, err := strconv.Atoi(segment); err == nil {
+			if index < 0 || index >= len(v) {
+				return nil
+			}
+			return lookup(v[index], rest)
+		}
+		var results []any
+		for _, element := range v {
+			switch found := lookup(element, path).(type) {
+			case nil:
+			case []any:
+				results = append(results, found...)
+			default:
+				results = append(results, found)
+			}
+		}
+		return results
+	default:
+		return nil
+	}
+}
+
+// lookupString returns the value at a path as a string, or "" if it is
+// missing or not a scalar.
+func lookupString(value any, path string) string {
+	if path == "" {
+		return ""
+	}
+	switch v := lookup(value, path).(type) {
+	case string:
+		return v
+	case json.Number, float64, bool:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+// lookupStrings returns the values at a path as strings. A single value
+// becomes a one-element slice.
+func lookupStrings(value any, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	found := lookup(value, path)
+	elements, ok := found.([]any)
+	if !ok {
+		elements = []any{found}
+	}
+
+	var result []string
+	for _, element := range elements {
+		switch v := element.(type) {
+		case string:
+			if v != "" {
+				result = append(result, v)
+			}
+		case json.Number, float64, bool:
+			result = append(result, fmt.Sprint(v))
+		}
+	}
+	return result
+}
+
+// lookupTime returns the value at a path as a time, or nil if it is missing
+// or not a recognized date format.
+func lookupTime(value any, path string) *time.Time {
+	s := lookupString(value, path)
+	if s == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// itemsAt returns the array at a path in a list response.
+func itemsAt(response any, path string) ([]any, error) {
+	found := lookup(response, path)
+	if found == nil {
+		return nil, nil
+	}
+	items, ok := found.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array at %q in response", path)
+	}
+	return items, nil
+}
+
+// objectAt returns the object at a path in a single-item response.
+func objectAt(response any, path string) (map[string]any, error) {
+	object, ok := lookup(response, path).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object at %q in response", path)
+	}
+	return object, nil
+}
+
+// setPath sets a value at a dotted path, creating nested objects as needed.
+func setPath(object map[string]any, path string, value any) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := object[segment].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			object[segment] = next
+		}
+		object = next
+	}
+	object[segments[len(segments)-1]] = value
+}
+
+// objectToProject converts a tracker project to a Todu project.
+func objectToProject(object map[string]any, fields ProjectFields) *types.Project {
+	var description *string
+	if desc := lookupString(object, fields.Description); desc != "" {
+		description = &desc
+	}
+
+	return &types.Project{
+		ExternalID:  formatID(lookup(object, fields.ID)),
+		Name:        lookupString(object, fields.Name),
+		Description: description,
+		Status:      "active",
+	}
+}
+
+// objectToTask converts a tracker task to a Todu task.
+func objectToTask(object map[string]any, tasks *TasksMapping) *types.Task {
+	fields := tasks.Fields
+	task := &types.Task{
+		ExternalID: formatID(lookup(object, fields.ID)),
+		Title:      lookupString(object, fields.Title),
+		Status:     mapStatus(lookupString(object, fields.Status), tasks.Statuses),
+		DueDate:    lookupTime(object, fields.DueDate),
+	}
+
+	if desc := lookupString(object, fields.Description); desc != "" {
+		task.Description = &desc
+	}
+
+	if priority, ok := tasks.Priorities[lookupString(object, fields.Priority)]; ok && priority != "" {
+		task.Priority = &priority
+	}
+
+	sourceURL := lookupString(object, fields.URL)
+	if sourceURL == "" && tasks.URL != "" {
+		sourceURL = strings.ReplaceAll(tasks.URL, "{id}", task.ExternalID)
+	}
+	if sourceURL != "" {
+		task.SourceURL = &sourceURL
+	}
+
+	if created := lookupTime(object, fields.CreatedAt); created != nil {
+		task.CreatedAt = *created
+	}
+	if updated := lookupTime(object, fields.UpdatedAt); updated != nil {
+		task.UpdatedAt = *updated
+	}
+
+	for _, name := range lookupStrings(object, fields.Labels) {
+		task.Labels = append(task.Labels, types.Label{Name: name})
+	}
+	for _, name := range lookupStrings(object, fields.Assignees) {
+		task.Assignees = append(task.Assignees, types.Assignee{Name: name})
+	}
+
+	return task
+}
+
+// objectToComment converts a tracker comment to a Todu comment.
+func objectToComment(object map[string]any, fields CommentFields) *types.Comment {
+	comment := &types.Comment{
+		ExternalID: formatID(lookup(object, fields.ID)),
+		Content:    lookupString(object, fields.Body),
+		Author:     lookupString(object, fields.Author),
+	}
+	if created := lookupTime(object, fields.CreatedAt); created != nil {
+		comment.CreatedAt = *created
+	}
+	if updated := lookupTime(object, fields.UpdatedAt); updated != nil {
+		comment.UpdatedAt = *updated
+	} else {
+		comment.UpdatedAt = comment.CreatedAt
+	}
+	return comment
+}
+
+// mapStatus maps a tracker status to a todu status.
+func mapStatus(status string, statuses map[string]string) string {
+	if mapped, ok := statuses[status]; ok {
+		return mapped
+	}
+	return "active"
+}
+
+// taskBody builds the request body for creating or updating a task.
+// Nil fields are left out, so updates only send what changed.
+func taskBody(tasks *TasksMapping, title, description, status, priority *string, dueDate *time.Time, labels, assignees []string) map[string]any {
+	body := make(map[string]any)
+	fields := tasks.Fields
+
+	set := func(field, readPath string, value any) {
+		if path := tasks.writePath(field, readPath); path != "" {
+			setPath(body, path, value)
+		}
+	}
+
+	if title != nil {
+		set("title", fields.Title, *title)
+	}
+	if description != nil {
+		set("description", fields.Description, *description)
+	}
+	if status != nil {
+		if value, ok := tasks.PushStatuses[*status]; ok {
+			set("status", fields.Status, value)
+		}
+	}
+	if priority != nil {
+		if value, ok := tasks.PushPriorities[*priority]; ok {
+			set("priority", fields.Priority, value)
+		}
+	}
+	if dueDate != nil {
+		set("due_date", fields.DueDate, dueDate.UTC().Format("2006-01-02"))
+	}
+	if labels != nil {
+		set("labels", fields.Labels, labels)
+	}
+	if assignees != nil {
+		set("assignees", fields.Assignees, assignees)
+	}
+
+	return body
+}
@@ -0,0 +1,120 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// decode decodes JSON the way the client does.
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		t.Fatalf("failed to decode %s: %v", s, err)
+	}
+	return v
+}
+
+func TestLookup(t *testing.T) {
+	object := decode(t, `{"id": 12, "status": {"name": "Closed"}, "tags": [{"name": "a"}, {"name": "b"}], "keywords": ["x", "y"], "bugs": [{"id": 7}]}`)
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"id", []string{"12"}},
+		{"status.name", []string{"Closed"}},
+		{"tags.name", []string{"a", "b"}},
+		{"keywords", []string{"x", "y"}},
+		{"bugs.0.id", []string{"7"}},
+		{"bugs.1.id", nil},
+		{"missing.field", nil},
+	}
+
+	for _, tt := range tests {
+		if got := lookupStrings(object, tt.path); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("lookupStrings(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+
+	if got := formatID(lookup(object, "id")); got != "12" {
+		t.Errorf("formatID = %q, want 12", got)
+	}
+}
+
+func TestObjectToTask(t *testing.T) {
+	tasks := &TasksMapping{
+		Fields: TaskFields{
+			ID: "id", Title: "subject", Description: "description", Status: "status.name",
+			Priority: "priority.name", DueDate: "due_date", Assignees: "assigned_to.name",
+			UpdatedAt: "updated_on",
+		},
+		Statuses:   map[string]string{"Resolved": "done"},
+		Priorities: map[string]string{"Urgent": "high"},
+		URL:        "https://tracker.example.com/issues/{id}",
+	}
+	object := decode(t, `{"id": 5, "subject": "Fix it", "description": "", "status": {"name": "Resolved"},
+		"priority": {"name": "Urgent"}, "due_date": "2025-03-01", "assigned_to": {"name": "Ann"},
+		"updated_on": "2025-02-01T10:00:00Z"}`).(map[string]any)
+
+	task := objectToTask(object, tasks)
+	if task.ExternalID != "5" || task.Title != "Fix it" || task.Status != "done" {
+		t.Errorf("Unexpected task: %+v", task)
+	}
+	if task.Description != nil {
+		t.Errorf("Expected empty description to be nil, got %q", *task.Description)
+	}
+	if task.Priority == nil || *task.Priority != "high" {
+		t.Errorf("Expected high priority, got %v", task.Priority)
+	}
+	if task.DueDate == nil || task.DueDate.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("Unexpected due date: %v", task.DueDate)
+	}
+	if task.SourceURL == nil || *task.SourceURL != "https://tracker.example.com/issues/5" {
+		t.Errorf("Unexpected source URL: %v", task.SourceURL)
+	}
+	if len(task.Assignees) != 1 || task.Assignees[0].Name != "Ann" {
+		t.Errorf("Unexpected assignees: %v", task.Assignees)
+	}
+	if !task.UpdatedAt.Equal(time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected updated time: %v", task.UpdatedAt)
+	}
+
+	object["status"] = map[string]any{"name": "Unknown"}
+	if got := objectToTask(object, tasks).Status; got != "active" {
+		t.Errorf("Expected unmapped status to be active, got %s", got)
+	}
+}
+
+func TestTaskBody(t *testing.T) {
+	tasks := &TasksMapping{
+		Fields:       TaskFields{Title: "subject", Status: "status.name", Priority: "priority.name", Assignees: "assigned_to.name", DueDate: "due.date"},
+		WriteFields:  map[string]string{"status": "status_id", "assignees": ""},
+		PushStatuses: map[string]any{"done": 5},
+	}
+
+	title := "New title"
+	done := "done"
+	waiting := "waiting"
+	priority := "high"
+	due := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	body := taskBody(tasks, &title, nil, &done, &priority, &due, nil, []string{"ann"})
+	expected := map[string]any{
+		"subject":   "New title",
+		"status_id": 5,
+		"due":       map[string]any{"date": "2025-04-01"},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("taskBody() = %v, want %v", body, expected)
+	}
+
+	if body := taskBody(tasks, nil, nil, &waiting, nil, nil, nil, nil); len(body) != 0 {
+		t.Errorf("Expected unmapped status to be left out, got %v", body)
+	}
+}
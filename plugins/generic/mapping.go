@@ -0,0 +1,320 @@
+package generic
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// mapping.go describes the YAML mapping file that tells the generic plugin
+// how to talk to a REST issue tracker.
+//
+// A mapping names the endpoints for listing and fetching projects and tasks
+// (and optionally creating and updating tasks and reading and writing
+// comments), and for each todu field the dotted JSON path that holds it in
+// the tracker's responses:
+//
+//	url: https://redmine.example.com
+//	auth:
+//	  header: X-Redmine-API-Key
+//	projects:
+//	  list: {path: /projects.json, items: projects, pagination: {offset: offset, limit: limit, size: 100}}
+//	  fields: {id: identifier, name: name, description: description}
+//	tasks:
+//	  list: {path: "/issues.json?project_id={project}&status_id=*", items: issues, since: "updated_on=%3E%3D{since}"}
+//	  get: {path: "/issues/{id}.json", item: issue}
+//	  fields: {id: id, title: subject, status: status.name, labels: tags.name}
+//	  statuses: {New: active, Closed: done}
+//
+// Paths:
+//   - "a.b" reads field b of object a
+//   - "a.0" reads the first element of array a
+//   - When a path crosses an array, the rest of the path is applied to every
+//     element, so "tags.name" reads the names of all tags
+//
+// Path templates:
+//   - {project} is the project's external ID
+//   - {id} is the task's external ID
+//   - {since} is the last sync time in RFC 3339 (only in tasks.list.since)
+//
+// Values substituted into templates are URL-escaped.
+
+// Mapping describes a REST issue tracker.
+type Mapping struct {
+	// Name is a human-readable name for the tracker (e.g., "Redmine").
+	Name string `yaml:"name"`
+
+	// URL is the base URL endpoint paths are relative to. The plugin's "url"
+	// configuration overrides it.
+	URL string `yaml:"url"`
+
+	// Auth describes how the plugin's "token" is sent.
+	Auth Auth `yaml:"auth"`
+
+	// Headers are sent with every request.
+	Headers map[string]string `yaml:"headers"`
+
+	Projects ProjectsMapping `yaml:"projects"`
+	Tasks    TasksMapping    `yaml:"tasks"`
+
+	// Comments is optional; without it comments aren't synced.
+	Comments *CommentsMapping `yaml:"comments"`
+}
+
+// Auth describes how the token is sent. Without a header or query parameter,
+// the token is sent as "Authorization: Bearer <token>".
+type Auth struct {
+	// Header is the request header carrying the token (e.g., "X-Redmine-API-Key").
+	Header string `yaml:"header"`
+
+	// Prefix is prepended to the token in the header (e.g., "Bearer ").
+	Prefix string `yaml:"prefix"`
+
+	// Query is a query parameter carrying the token instead of a header
+	// (e.g., "api_key" for Bugzilla).
+	Query string `yaml:"query"`
+}
+
+// Endpoint describes one REST call.
+type Endpoint struct {
+	// Method defaults to GET for reads, POST for creates and PUT for updates.
+	Method string `yaml:"method"`
+
+	// Path is a template relative to the base URL and may include a query string.
+	Path string `yaml:"path"`
+
+	// Items is the path to the array of results in a list response and may
+	// use the same placeholders as Path (e.g., "bugs.{id}.comments").
+	// Empty means the response itself is the array.
+	Items string `yaml:"items"`
+
+	// Item is the path to the object in a single-item response.
+	// Empty means the response itself is the object.
+	Item string `yaml:"item"`
+
+	// Wrap nests the request body under this key (e.g., "issue" sends
+	// {"issue": {...}}).
+	Wrap string `yaml:"wrap"`
+
+	// Body holds fixed fields sent with every request to this endpoint, such
+	// as a component Bugzilla requires when filing a bug. Mapped fields
+	// override them.
+	Body map[string]any `yaml:"body"`
+
+	// Since is a query-string template added when fetching tasks changed
+	// after the last sync (e.g., "last_change_time={since}").
+	Since string `yaml:"since"`
+
+	Pagination Pagination `yaml:"pagination"`
+}
+
+// Pagination describes how a list endpoint is paged. Without a page or
+// offset parameter, the first response is taken as the complete list.
+// Paging stops at the first page with fewer than Size results.
+type Pagination struct {
+	// Page is the page-number parameter, starting at 1.
+	Page string `yaml:"page"`
+
+	// Offset is the result-offset parameter, starting at 0.
+	Offset string `yaml:"offset"`
+
+	// Limit is the page-size parameter.
+	Limit string `yaml:"limit"`
+
+	// Size is the page size. Defaults to 100.
+	Size int `yaml:"size"`
+}
+
+// ProjectsMapping describes the tracker's projects.
+type ProjectsMapping struct {
+	List Endpoint `yaml:"list"`
+
+	// Get is optional; without it a project is found by listing all projects.
+	Get *Endpoint `yaml:"get"`
+
+	Fields ProjectFields `yaml:"fields"`
+}
+
+// ProjectFields are the paths of project fields.
+type ProjectFields struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// TasksMapping describes the tracker's tasks.
+type TasksMapping struct {
+	List Endpoint `yaml:"list"`
+	Get  Endpoint `yaml:"get"`
+
+	// Create and Update are optional; without them the tracker is read-only.
+	// A task is fetched again after it is written, so Create's Item only
+	// needs to locate the new task's ID and Update needs no Item.
+	Create *Endpoint `yaml:"create"`
+	Update *Endpoint `yaml:"update"`
+
+	Fields TaskFields `yaml:"fields"`
+
+	// WriteFields overrides the path a field is written to on create and
+	// update (e.g., status: status_id). An empty path skips the field.
+	// The "project" key names the path the project's external ID is written
+	// to on create, for trackers that don't take it from the URL.
+	WriteFields map[string]string `yaml:"write_fields"`
+
+	// Statuses maps tracker status values to todu statuses. Unmapped values
+	// become "active".
+	Statuses map[string]string `yaml:"statuses"`
+
+	// PushStatuses maps todu statuses to the values written to the tracker.
+	// Statuses without a value aren't pushed.
+	PushStatuses map[string]any `yaml:"push_statuses"`
+
+	// Priorities maps tracker priority values to todu priorities.
+	Priorities map[string]string `yaml:"priorities"`
+
+	// PushPriorities maps todu priorities to the values written to the tracker.
+	PushPriorities map[string]any `yaml:"push_priorities"`
+
+	// URL is a template for a task's web URL (e.g., "{base}/issues/{id}",
+	// where {base} is the base URL), used when Fields.URL is unset.
+	URL string `yaml:"url"`
+}
+
+// TaskFields are the paths of task fields.
+type TaskFields struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Status      string `yaml:"status"`
+	Priority    string `yaml:"priority"`
+	DueDate     string `yaml:"due_date"`
+	Labels      string `yaml:"labels"`
+	Assignees   string `yaml:"assignees"`
+	URL         string `yaml:"url"`
+	CreatedAt   string `yaml:"created_at"`
+	UpdatedAt   string `yaml:"updated_at"`
+}
+
+// CommentsMapping describes a task's comments.
+type CommentsMapping struct {
+	List Endpoint `yaml:"list"`
+
+	// Create is optional; without it comments are only pulled.
+	Create *Endpoint `yaml:"create"`
+
+	Fields CommentFields `yaml:"fields"`
+
+	// WriteBody is the path a new comment's content is written to.
+	// Defaults to Fields.Body.
+	WriteBody string `yaml:"write_body"`
+}
+
+// CommentFields are the paths of comment fields.
+type CommentFields struct {
+	ID        string `yaml:"id"`
+	Body      string `yaml:"body"`
+	Author    string `yaml:"author"`
+	CreatedAt string `yaml:"created_at"`
+	UpdatedAt string `yaml:"updated_at"`
+}
+
+// LoadMapping reads and validates a mapping file.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m Mapping
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+
+	return &m, nil
+}
+
+// Validate checks that the mapping has the endpoints and fields every
+// tracker needs.
+func (m *Mapping) Validate() error {
+	var missing []string
+	require := func(value, name string) {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	require(m.Projects.List.Path, "projects.list.path")
+	require(m.Projects.Fields.ID, "projects.fields.id")
+	require(m.Projects.Fields.Name, "projects.fields.name")
+	require(m.Tasks.List.Path, "tasks.list.path")
+	require(m.Tasks.Get.Path, "tasks.get.path")
+	require(m.Tasks.Fields.ID, "tasks.fields.id")
+	require(m.Tasks.Fields.Title, "tasks.fields.title")
+	if m.Tasks.Create != nil {
+		require(m.Tasks.Create.Path, "tasks.create.path")
+	}
+	if m.Tasks.Update != nil {
+		require(m.Tasks.Update.Path, "tasks.update.path")
+	}
+	if m.Comments != nil {
+		require(m.Comments.List.Path, "comments.list.path")
+		require(m.Comments.Fields.Body, "comments.fields.body")
+		if m.Comments.Create != nil {
+			require(m.Comments.Create.Path, "comments.create.path")
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+
+	for tracker, status := range m.Tasks.Statuses {
+		if !isToduStatus(status) {
+			return fmt.Errorf("tasks.statuses: %q maps to unknown status %q", tracker, status)
+		}
+	}
+	for status := range m.Tasks.PushStatuses {
+		if !isToduStatus(status) {
+			return fmt.Errorf("tasks.push_statuses: unknown status %q", status)
+		}
+	}
+	for tracker, priority := range m.Tasks.Priorities {
+		if priority == "" {
+			continue
+		}
+		if err := types.ValidatePriority(priority); err != nil {
+			return fmt.Errorf("tasks.priorities: %q: %w", tracker, err)
+		}
+	}
+	for priority := range m.Tasks.PushPriorities {
+		if err := types.ValidatePriority(priority); err != nil {
+			return fmt.Errorf("tasks.push_priorities: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writePath returns the path a task field is written to, or "" to skip it.
+func (t *TasksMapping) writePath(field, readPath string) string {
+	if path, ok := t.WriteFields[field]; ok {
+		return path
+	}
+	return readPath
+}
+
+// isToduStatus reports whether s is a todu task status.
+func isToduStatus(s string) bool {
+	switch s {
+	case "active", "inprogress", "waiting", "done", "canceled":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoadMapping_Examples verifies that the bundled example mappings are valid.
+func TestLoadMapping_Examples(t *testing.T) {
+	for _, name := range []string{"redmine.yaml", "bugzilla.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadMapping(filepath.Join("examples", name)); err != nil {
+				t.Errorf("LoadMapping failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "missing endpoints",
+			yaml:    "name: Empty\n",
+			wantErr: "missing projects.list.path",
+		},
+		{
+			name: "unknown status",
+			yaml: `projects: {list: {path: /p}, fields: {id: id, name: name}}
+tasks:
+  list: {path: /t}
+  get: {path: "/t/{id}"}
+  fields: {id: id, title: title}
+  statuses: {Open: open}
+`,
+			wantErr: `unknown status "open"`,
+		},
+		{
+			name: "unknown priority",
+			yaml: `projects: {list: {path: /p}, fields: {id: id, name: name}}
+tasks:
+  list: {path: /t}
+  get: {path: "/t/{id}"}
+  fields: {id: id, title: title}
+  priorities: {Immediate: urgent}
+`,
+			wantErr: `invalid priority "urgent"`,
+		},
+		{
+			name: "comment create without path",
+			yaml: `projects: {list: {path: /p}, fields: {id: id, name: name}}
+tasks: {list: {path: /t}, get: {path: "/t/{id}"}, fields: {id: id, title: title}}
+comments: {list: {path: "/t/{id}/c"}, create: {wrap: c}, fields: {body: text}}
+`,
+			wantErr: "missing comments.create.path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mapping.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadMapping(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadMapping() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+// Package generic provides a plugin for simple REST issue trackers such as
+// Bugzilla and Redmine.
+//
+// Instead of Go code, the plugin reads a YAML mapping file describing the
+// tracker's endpoints and where each todu field lives in its JSON (see
+// mapping.go), and interprets it at runtime.
+package generic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Plugin implements the plugin.Plugin interface for a mapped REST tracker.
+type Plugin struct {
+	client *client
+	config map[string]string
+}
+
+// init registers the generic plugin with the global registry.
+func init() {
+	err := registry.Register("generic", func() plugin.Plugin {
+		return &Plugin{}
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Name returns the unique identifier for this plugin.
+func (p *Plugin) Name() string {
+	return "generic"
+}
+
+// Version returns the version of this plugin implementation.
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// Configure provides configuration to the plugin.
+// Required configuration keys:
+//   - mapping: Path to the YAML mapping file describing the tracker
+//
+// Optional configuration keys:
+//   - url: Base URL of the tracker, overriding the mapping's url
+//   - token: API token, sent as described by the mapping's auth section
+func (p *Plugin) Configure(config map[string]string) error {
+	p.config = config
+
+	if err := p.ValidateConfig(); err != nil {
+		return err
+	}
+
+	mapping, err := LoadMapping(expandHome(config["mapping"]))
+	if err != nil {
+		return err
+	}
+
+	p.client, err = newClient(mapping, config)
+	if err != nil {
+		return fmt.Errorf("failed to create generic client: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks that the plugin has been properly configured.
+func (p *Plugin) ValidateConfig() error {
+	if p.config == nil {
+		return plugin.ErrNotConfigured
+	}
+
+	if strings.TrimSpace(p.config["mapping"]) == "" {
+		return fmt.Errorf("%w: missing required field 'mapping'", plugin.ErrNotConfigured)
+	}
+
+	return nil
+}
+
+// FetchProjects retrieves all projects listed by the tracker.
+func (p *Plugin) FetchProjects(ctx context.Context) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	items, err := p.client.list(ctx, &mapping.Projects.List, nil, "")
+	if err != nil {
+		return nil, handleError(err, "failed to list projects")
+	}
+
+	projects := make([]*types.Project, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]any); ok {
+			projects = append(projects, objectToProject(object, mapping.Projects.Fields))
+		}
+	}
+
+	return projects, nil
+}
+
+// FetchProject retrieves a single project by its external ID.
+func (p *Plugin) FetchProject(ctx context.Context, externalID string) (*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	if mapping.Projects.Get == nil {
+		projects, err := p.FetchProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			if project.ExternalID == externalID {
+				return project, nil
+			}
+		}
+		return nil, plugin.NewErrNotFound(fmt.Sprintf("project %s", externalID))
+	}
+
+	object, err := p.client.get(ctx, mapping.Projects.Get, map[string]string{"project": externalID})
+	if err != nil {
+		return nil, handleError(err, fmt.Sprintf("failed to fetch project %s", externalID))
+	}
+
+	return objectToProject(object, mapping.Projects.Fields), nil
+}
+
+// FetchTasks retrieves a project's tasks, only those changed since the given
+// time if the mapping's tasks.list.since is set.
+func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, since *time.Time) ([]*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for the generic plugin")
+	}
+
+	mapping := p.client.mapping
+	items, err := p.client.list(ctx, &mapping.Tasks.List, map[string]string{"project": *projectExternalID}, taskSinceQuery(mapping, since))
+	if err != nil {
+		return nil, handleError(err, fmt.Sprintf("failed to list tasks for %s", *projectExternalID))
+	}
+
+	return itemsToTasks(items, mapping), nil
+}
+
+// FetchTaskPage retrieves one page of a project's tasks. The cursor is the
+// number of the page, counting from 0.
+func (p *Plugin) FetchTaskPage(ctx context.Context, projectExternalID *string, since *time.Time, cursor string) ([]*types.Task, string, error) {
+	if p.client == nil {
+		return nil, "", plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, "", fmt.Errorf("projectExternalID is required for the generic plugin")
+	}
+
+	page := 0
+	if cursor != "" {
+		var err error
+		if page, err = strconv.Atoi(cursor); err != nil || page < 0 {
+			return nil, "", fmt.Errorf("invalid page cursor %q", cursor)
+		}
+	}
+
+	mapping := p.client.mapping
+	items, more, err := p.client.listPage(ctx, &mapping.Tasks.List, map[string]string{"project": *projectExternalID}, taskSinceQuery(mapping, since), page)
+	if err != nil {
+		return nil, "", handleError(err, fmt.Sprintf("failed to list tasks for %s", *projectExternalID))
+	}
+
+	next := ""
+	if more {
+		next = strconv.Itoa(page + 1)
+	}
+	return itemsToTasks(items, mapping), next, nil
+}
+
+// taskSinceQuery returns the query that limits the task list to tasks
+// updated since a time, or "" if the mapping has no such filter.
+func taskSinceQuery(mapping *Mapping, since *time.Time) string {
+	if since == nil || mapping.Tasks.List.Since == "" {
+		return ""
+	}
+	return sinceQuery(mapping.Tasks.List.Since, *since)
+}
+
+// itemsToTasks maps the objects of a task list to tasks.
+func itemsToTasks(items []any, mapping *Mapping) []*types.Task {
+	tasks := make([]*types.Task, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]any); ok {
+			tasks = append(tasks, objectToTask(object, &mapping.Tasks))
+		}
+	}
+	return tasks
+}
+
+// FetchTask retrieves a single task by its external ID.
+func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskExternalID string) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	object, err := p.client.get(ctx, &mapping.Tasks.Get, taskVars(projectExternalID, taskExternalID))
+	if err != nil {
+		return nil, handleError(err, fmt.Sprintf("failed to fetch task %s", taskExternalID))
+	}
+
+	return objectToTask(object, &mapping.Tasks), nil
+}
+
+// CreateTask creates a task in the tracker.
+// Returns ErrNotSupported if the mapping has no tasks.create endpoint.
+func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task *types.TaskCreate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	if mapping.Tasks.Create == nil {
+		return nil, plugin.ErrNotSupported
+	}
+
+	status := task.Status
+	body := taskBody(&mapping.Tasks, &task.Title, task.Description, &status, task.Priority, task.DueDate, task.Labels, task.Assignees)
+	if path := mapping.Tasks.WriteFields["project"]; path != "" && projectExternalID != nil {
+		setPath(body, path, *projectExternalID)
+	}
+
+	object, err := p.client.send(ctx, mapping.Tasks.Create, http.MethodPost, taskVars(projectExternalID, ""), body)
+	if err != nil {
+		return nil, handleError(err, "failed to create task")
+	}
+
+	// Trackers often reply with just the new ID, so fetch the full task
+	externalID := ""
+	if object != nil {
+		externalID = formatID(lookup(object, mapping.Tasks.Fields.ID))
+	}
+	if externalID == "" {
+		return nil, fmt.Errorf("failed to create task: the response didn't include the new task's ID")
+	}
+
+	return p.FetchTask(ctx, projectExternalID, externalID)
+}
+
+// UpdateTask updates a task in the tracker and returns it as fetched
+// afterwards.
+// Returns ErrNotSupported if the mapping has no tasks.update endpoint.
+func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, taskExternalID string, task *types.TaskUpdate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	if mapping.Tasks.Update == nil {
+		return nil, plugin.ErrNotSupported
+	}
+
+	body := taskBody(&mapping.Tasks, task.Title, task.Description, task.Status, task.Priority, task.DueDate, task.Labels, task.Assignees)
+	if len(body) > 0 {
+		if _, err := p.client.send(ctx, mapping.Tasks.Update, http.MethodPut, taskVars(projectExternalID, taskExternalID), body); err != nil {
+			return nil, handleError(err, fmt.Sprintf("failed to update task %s", taskExternalID))
+		}
+	}
+
+	return p.FetchTask(ctx, projectExternalID, taskExternalID)
+}
+
+// FetchComments retrieves all comments for a task. Comments with no body
+// (such as change-log entries) are skipped.
+// Returns ErrNotSupported if the mapping has no comments section.
+func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	if mapping.Comments == nil {
+		return nil, plugin.ErrNotSupported
+	}
+
+	items, err := p.client.list(ctx, &mapping.Comments.List, taskVars(projectExternalID, taskExternalID), "")
+	if err != nil {
+		return nil, handleError(err, fmt.Sprintf("failed to list comments for task %s", taskExternalID))
+	}
+
+	comments := make([]*types.Comment, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		comment := objectToComment(object, mapping.Comments.Fields)
+		if comment.Content != "" {
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// CreateComment adds a comment to a task.
+// Returns ErrNotSupported if the mapping has no comments.create endpoint.
+func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	mapping := p.client.mapping
+	if mapping.Comments == nil || mapping.Comments.Create == nil {
+		return nil, plugin.ErrNotSupported
+	}
+
+	bodyPath := mapping.Comments.WriteBody
+	if bodyPath == "" {
+		bodyPath = mapping.Comments.Fields.Body
+	}
+	body := make(map[string]any)
+	setPath(body, bodyPath, comment.Content)
+
+	object, err := p.client.send(ctx, mapping.Comments.Create, http.MethodPost, taskVars(projectExternalID, taskExternalID), body)
+	if err != nil {
+		return nil, handleError(err, fmt.Sprintf("failed to create comment on task %s", taskExternalID))
+	}
+
+	// Some trackers (e.g., Redmine) reply with no content, so the comment's
+	// ID is found by listing the task's comments
+	if object == nil {
+		comments, err := p.FetchComments(ctx, projectExternalID, taskExternalID)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(comments) - 1; i >= 0; i-- {
+			if comments[i].Content == comment.Content {
+				return comments[i], nil
+			}
+		}
+		return nil, fmt.Errorf("failed to find the created comment on task %s", taskExternalID)
+	}
+
+	created := objectToComment(object, mapping.Comments.Fields)
+	if created.Content == "" {
+		created.Content = comment.Content
+	}
+	if created.CreatedAt.IsZero() {
+		created.CreatedAt = time.Now()
+		created.UpdatedAt = created.CreatedAt
+	}
+
+	return created, nil
+}
+
+// taskVars returns the path template variables for a task.
+func taskVars(projectExternalID *string, taskExternalID string) map[string]string {
+	vars := map[string]string{"id": taskExternalID}
+	if projectExternalID != nil {
+		vars["project"] = *projectExternalID
+	}
+	return vars
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+// handleError converts tracker API errors to plugin errors.
+func handleError(err error, context string) error {
+	if err == nil {
+		return nil
+	}
+
+	errMsg := err.Error()
+
+	// Check for 404 Not Found
+	if strings.Contains(errMsg, "API error 404") {
+		return plugin.NewErrNotFound(context)
+	}
+
+	// Check for 401/403 Unauthorized/Forbidden
+	if strings.Contains(errMsg, "API error 401") || strings.Contains(errMsg, "API error 403") {
+		return plugin.NewErrUnauthorized(context)
+	}
+
+	return fmt.Errorf("%s: %w", context, err)
+}
@@ -0,0 +1,219 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// newRedmineServer returns a fake Redmine server and the bodies written to it.
+func newRedmineServer(t *testing.T) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+
+	var written []map[string]any
+	record := func(r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		written = append(written, body)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Redmine-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("project_id") != "web" {
+			t.Errorf("Expected project_id=web, got %q", query.Get("project_id"))
+		}
+		if query.Get("offset") == "0" {
+			w.Write([]byte(`{"issues": [{"id": 1, "subject": "First", "status": {"name": "New"}}, {"id": 2, "subject": "Second", "status": {"name": "Closed"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"issues": [{"id": 3, "subject": "Third", "status": {"name": "Rejected"}, "updated_on": "` + strings.TrimPrefix(query.Get("updated_on"), ">=") + `"}]}`))
+	})
+	mux.HandleFunc("GET /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "9.json":
+			if r.URL.Query().Get("include") == "journals" {
+				w.Write([]byte(`{"issue": {"id": 9, "journals": [{"id": 90, "notes": ""}, {"id": 91, "notes": "Looks good", "user": {"name": "Ann"}}]}}`))
+				return
+			}
+			w.Write([]byte(`{"issue": {"id": 9, "subject": "Created", "status": {"name": "New"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"issue": {"id": 9}}`))
+	})
+	mux.HandleFunc("PUT /issues/9.json", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &written
+}
+
+// newRedminePlugin configures a plugin with the example Redmine mapping.
+func newRedminePlugin(t *testing.T, serverURL string) *Plugin {
+	t.Helper()
+
+	p := &Plugin{}
+	err := p.Configure(map[string]string{
+		"mapping": filepath.Join("examples", "redmine.yaml"),
+		"url":     serverURL,
+		"token":   "secret",
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	// Two results per page so pagination is exercised
+	p.client.mapping.Tasks.List.Pagination.Size = 2
+	return p
+}
+
+func TestConfigure_RequiresMapping(t *testing.T) {
+	p := &Plugin{}
+	err := p.Configure(map[string]string{"url": "https://tracker.example.com"})
+	if !errors.Is(err, plugin.ErrNotConfigured) {
+		t.Errorf("Configure() error = %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestFetchTasks(t *testing.T) {
+	server, _ := newRedmineServer(t)
+	p := newRedminePlugin(t, server.URL)
+
+	project := "web"
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tasks, err := p.FetchTasks(context.Background(), &project, &since)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	if len(tasks) != 3 {
+		t.Fatalf("Expected 3 tasks across two pages, got %d", len(tasks))
+	}
+	statuses := []string{"active", "done", "canceled"}
+	for i, task := range tasks {
+		if task.Status != statuses[i] {
+			t.Errorf("Task %s: expected status %s, got %s", task.ExternalID, statuses[i], task.Status)
+		}
+	}
+	if tasks[0].SourceURL == nil || *tasks[0].SourceURL != server.URL+"/issues/1" {
+		t.Errorf("Unexpected source URL: %v", tasks[0].SourceURL)
+	}
+	if !tasks[2].UpdatedAt.Equal(since) {
+		t.Errorf("Expected since filter >=%s to be sent, got %v", since.Format(time.RFC3339), tasks[2].UpdatedAt)
+	}
+}
+
+func TestFetchTaskPage(t *testing.T) {
+	server, _ := newRedmineServer(t)
+	p := newRedminePlugin(t, server.URL)
+	project := "web"
+
+	first, next, err := p.FetchTaskPage(context.Background(), &project, nil, "")
+	if err != nil {
+		t.Fatalf("FetchTaskPage failed: %v", err)
+	}
+	if len(first) != 2 || first[0].ExternalID != "1" || next != "1" {
+		t.Errorf("Expected the first page and cursor 1, got %d tasks and %q", len(first), next)
+	}
+
+	last, next, err := p.FetchTaskPage(context.Background(), &project, nil, next)
+	if err != nil {
+		t.Fatalf("FetchTaskPage failed: %v", err)
+	}
+	if len(last) != 1 || last[0].ExternalID != "3" || next != "" {
+		t.Errorf("Expected the last page and no cursor, got %d tasks and %q", len(last), next)
+	}
+
+	if _, _, err := p.FetchTaskPage(context.Background(), &project, nil, "page-2"); err == nil {
+		t.Error("Expected an invalid cursor to be rejected")
+	}
+}
+
+func TestFetchTask_NotFound(t *testing.T) {
+	server, _ := newRedmineServer(t)
+	p := newRedminePlugin(t, server.URL)
+
+	_, err := p.FetchTask(context.Background(), nil, "404")
+	if !errors.Is(err, plugin.ErrNotFound) {
+		t.Errorf("FetchTask() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCreateAndUpdateTask(t *testing.T) {
+	server, written := newRedmineServer(t)
+	p := newRedminePlugin(t, server.URL)
+
+	project := "web"
+	priority := "high"
+	created, err := p.CreateTask(context.Background(), &project, &types.TaskCreate{Title: "Created", Status: "active", Priority: &priority})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if created.ExternalID != "9" || created.Title != "Created" {
+		t.Errorf("Expected the created task to be fetched, got %+v", created)
+	}
+
+	status := "done"
+	if _, err := p.UpdateTask(context.Background(), &project, "9", &types.TaskUpdate{Status: &status}); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+
+	if len(*written) != 2 {
+		t.Fatalf("Expected 2 writes, got %d", len(*written))
+	}
+	create := (*written)[0]["issue"].(map[string]any)
+	if create["project_id"] != "web" || create["subject"] != "Created" || create["status_id"] != float64(1) || create["priority_id"] != float64(3) {
+		t.Errorf("Unexpected create body: %v", create)
+	}
+	update := (*written)[1]["issue"].(map[string]any)
+	if len(update) != 1 || update["status_id"] != float64(5) {
+		t.Errorf("Unexpected update body: %v", update)
+	}
+}
+
+func TestCreateComment_EmptyResponse(t *testing.T) {
+	server, written := newRedmineServer(t)
+	p := newRedminePlugin(t, server.URL)
+
+	comment, err := p.CreateComment(context.Background(), nil, "9", &types.CommentCreate{Content: "Looks good"})
+	if err != nil {
+		t.Fatalf("CreateComment failed: %v", err)
+	}
+	if comment.ExternalID != "91" || comment.Author != "Ann" {
+		t.Errorf("Expected the comment to be found by listing, got %+v", comment)
+	}
+	if notes := (*written)[0]["issue"].(map[string]any)["notes"]; notes != "Looks good" {
+		t.Errorf("Expected notes to be written, got %v", notes)
+	}
+
+	comments, err := p.FetchComments(context.Background(), nil, "9")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if len(comments) != 1 {
+		t.Errorf("Expected journal entries without notes to be skipped, got %d comments", len(comments))
+	}
+}
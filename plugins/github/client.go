@@ -2,9 +2,12 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/evcraddock/todu.sh/internal/httpcache"
 	"github.com/google/go-github/v56/github"
 	"golang.org/x/oauth2"
 )
@@ -13,6 +16,10 @@ import (
 type client struct {
 	gh  *github.Client
 	ctx context.Context
+
+	// httpClient and graphQLURL are used for GraphQL queries.
+	httpClient *http.Client
+	graphQLURL string
 }
 
 // newClient creates a new GitHub API client.
@@ -33,6 +40,10 @@ func newClient(config map[string]string) (*client, error) {
 	)
 	tc := oauth2.NewClient(ctx, ts)
 
+	// Revalidate repeated GETs with ETags; 304 replies don't count against
+	// the rate limit
+	tc.Transport = httpcache.NewTransport(tc.Transport)
+
 	// Create GitHub client
 	var gh *github.Client
 	if url := config["url"]; url != "" && url != "https://api.github.com" {
@@ -48,8 +59,10 @@ func newClient(config map[string]string) (*client, error) {
 	}
 
 	return &client{
-		gh:  gh,
-		ctx: ctx,
+		gh:         gh,
+		ctx:        ctx,
+		httpClient: tc,
+		graphQLURL: graphQLEndpoint(config["url"]),
 	}, nil
 }
 
@@ -129,10 +142,12 @@ func (c *client) updateIssue(ctx context.Context, owner, repo string, number int
 }
 
 // listComments retrieves all comments for an issue.
-func (c *client) listComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+// If since is provided, only comments updated since that time are returned.
+func (c *client) listComments(ctx context.Context, owner, repo string, number int, since *time.Time) ([]*github.IssueComment, error) {
 	var allComments []*github.IssueComment
 	opts := &github.IssueListCommentsOptions{
 		ListOptions: github.ListOptions{PerPage: 100},
+		Since:       since,
 	}
 
 	for {
@@ -158,3 +173,115 @@ func (c *client) createComment(ctx context.Context, owner, repo string, number i
 	})
 	return comment, err
 }
+
+// editComment replaces the body of an existing issue comment.
+func (c *client) editComment(ctx context.Context, owner, repo string, commentID int64, body string) (*github.IssueComment, error) {
+	comment, _, err := c.gh.Issues.EditComment(ctx, owner, repo, commentID, &github.IssueComment{
+		Body: &body,
+	})
+	return comment, err
+}
+
+// addReaction adds a reaction to an issue comment. GitHub returns the
+// existing reaction if the user already reacted with the same content.
+func (c *client) addReaction(ctx context.Context, owner, repo string, commentID int64, content string) error {
+	_, _, err := c.gh.Reactions.CreateIssueCommentReaction(ctx, owner, repo, commentID, content)
+	return err
+}
+
+// listLabels retrieves all labels for a repository.
+func (c *client) listLabels(ctx context.Context, owner, repo string) ([]*github.Label, error) {
+	var allLabels []*github.Label
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		labels, resp, err := c.gh.Issues.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		allLabels = append(allLabels, labels...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allLabels, nil
+}
+
+// createLabel creates a label in a repository. If GitHub's secondary rate
+// limit rejects the request, it waits as long as GitHub asks and tries once
+// more.
+func (c *client) createLabel(ctx context.Context, owner, repo, name, color string) (*github.Label, error) {
+	label := &github.Label{Name: github.String(name), Color: github.String(color)}
+
+	created, _, err := c.gh.Issues.CreateLabel(ctx, owner, repo, label)
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) && abuseErr.RetryAfter != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(*abuseErr.RetryAfter):
+		}
+		created, _, err = c.gh.Issues.CreateLabel(ctx, owner, repo, label)
+	}
+	return created, err
+}
+
+// searchRepositories retrieves all repositories matching a search query.
+// GitHub returns at most 1000 results per search.
+func (c *client) searchRepositories(ctx context.Context, query string) ([]*github.Repository, error) {
+	var allRepos []*github.Repository
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		result, resp, err := c.gh.Search.Repositories(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		allRepos = append(allRepos, result.Repositories...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allRepos, nil
+}
+
+// searchIssues retrieves all issues matching a search query.
+// GitHub returns at most 1000 results per search.
+func (c *client) searchIssues(ctx context.Context, query string) ([]*github.Issue, error) {
+	var allIssues []*github.Issue
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		result, resp, err := c.gh.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		allIssues = append(allIssues, result.Issues...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// currentUser retrieves the login of the authenticated user.
+func (c *client) currentUser(ctx context.Context) (string, error) {
+	user, _, err := c.gh.Users.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
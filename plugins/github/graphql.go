@@ -0,0 +1,281 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+)
+
+// graphql.go fetches issues through the GitHub GraphQL API.
+//
+// A single paginated query returns issues together with their labels,
+// assignees, milestone and comments, replacing one REST request per page of
+// issues plus one per issue for comments. Comments are only used when the
+// query returned all of them; issues with more comments than fit in one page
+// are left to the REST comment endpoint.
+
+// issuesQuery lists a repository's issues updated since a timestamp, oldest
+// first. Connections request 100 nodes, the most GitHub allows per page.
+const issuesQuery = `query($owner: String!, $repo: String!, $cursor: String, $since: DateTime) {
+  repository(owner: $owner, name: $repo) {
+    issues(first: 100, after: $cursor, filterBy: {since: $since}, orderBy: {field: UPDATED_AT, direction: ASC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        body
+        state
+        stateReason
+        url
+        createdAt
+        updatedAt
+        closedAt
+        labels(first: 100) { nodes { name color } }
+        assignees(first: 100) { nodes { login } }
+        milestone { dueOn }
+        comments(first: 100) {
+          totalCount
+          nodes {
+            databaseId
+            body
+            createdAt
+            updatedAt
+            author { __typename login }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQLError is an error reported in a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphQLIssuesResponse is the response to issuesQuery.
+type graphQLIssuesResponse struct {
+	Data struct {
+		Repository *struct {
+			Issues struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []graphQLIssue `json:"nodes"`
+			} `json:"issues"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+// graphQLIssue is an issue node returned by issuesQuery.
+type graphQLIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	StateReason *string    `json:"stateReason"`
+	URL         string     `json:"url"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	ClosedAt    *time.Time `json:"closedAt"`
+	Labels      struct {
+		Nodes []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Milestone *struct {
+		DueOn *time.Time `json:"dueOn"`
+	} `json:"milestone"`
+	Comments struct {
+		TotalCount int              `json:"totalCount"`
+		Nodes      []graphQLComment `json:"nodes"`
+	} `json:"comments"`
+}
+
+// graphQLComment is a comment node returned by issuesQuery.
+type graphQLComment struct {
+	DatabaseID int64     `json:"databaseId"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Author     *struct {
+		Typename string `json:"__typename"`
+		Login    string `json:"login"`
+	} `json:"author"`
+}
+
+// listIssuesGraphQL retrieves issues for a repository with a single paginated
+// GraphQL query. The returned map holds the complete comment list for each
+// issue number whose comments all fit in the query.
+func (c *client) listIssuesGraphQL(ctx context.Context, owner, repo string, since *time.Time) ([]*github.Issue, map[int][]*github.IssueComment, error) {
+	var issues []*github.Issue
+	comments := make(map[int][]*github.IssueComment)
+
+	variables := map[string]any{
+		"owner": owner,
+		"repo":  repo,
+	}
+	if since != nil {
+		variables["since"] = since.UTC().Format(time.RFC3339)
+	}
+
+	for {
+		var resp graphQLIssuesResponse
+		if err := c.doGraphQL(ctx, issuesQuery, variables, &resp); err != nil {
+			return nil, nil, err
+		}
+		if resp.Data.Repository == nil {
+			return nil, nil, fmt.Errorf("404 repository %s/%s not found", owner, repo)
+		}
+
+		page := resp.Data.Repository.Issues
+		for _, node := range page.Nodes {
+			issues = append(issues, node.toIssue())
+			if node.Comments.TotalCount <= len(node.Comments.Nodes) {
+				comments[node.Number] = node.toComments()
+			}
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = page.PageInfo.EndCursor
+	}
+
+	return issues, comments, nil
+}
+
+// doGraphQL posts a GraphQL query and decodes the response into out.
+func (c *client) doGraphQL(ctx context.Context, query string, variables map[string]any, out *graphQLIssuesResponse) error {
+	body, err := json.Marshal(&graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GraphQL API error %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(out.Errors) > 0 {
+		messages := make([]string, len(out.Errors))
+		for i, e := range out.Errors {
+			messages[i] = e.Message
+			if e.Type == "NOT_FOUND" {
+				messages[i] = "404 " + messages[i]
+			}
+		}
+		return fmt.Errorf("GraphQL error: %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
+
+// toIssue converts a GraphQL issue node to the REST issue type used by the mapper.
+func (n *graphQLIssue) toIssue() *github.Issue {
+	issue := &github.Issue{
+		Number:    github.Int(n.Number),
+		Title:     github.String(n.Title),
+		Body:      github.String(n.Body),
+		State:     github.String(strings.ToLower(n.State)),
+		HTMLURL:   github.String(n.URL),
+		CreatedAt: &github.Timestamp{Time: n.CreatedAt},
+		UpdatedAt: &github.Timestamp{Time: n.UpdatedAt},
+	}
+
+	if n.StateReason != nil {
+		issue.StateReason = github.String(strings.ToLower(*n.StateReason))
+	}
+	if n.ClosedAt != nil {
+		issue.ClosedAt = &github.Timestamp{Time: *n.ClosedAt}
+	}
+
+	for _, label := range n.Labels.Nodes {
+		issue.Labels = append(issue.Labels, &github.Label{Name: github.String(label.Name), Color: github.String(label.Color)})
+	}
+	for _, assignee := range n.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, &github.User{Login: github.String(assignee.Login)})
+	}
+
+	if n.Milestone != nil {
+		issue.Milestone = &github.Milestone{}
+		if n.Milestone.DueOn != nil {
+			issue.Milestone.DueOn = &github.Timestamp{Time: *n.Milestone.DueOn}
+		}
+	}
+
+	return issue
+}
+
+// toComments converts the comment nodes of a GraphQL issue to REST comments.
+// GraphQL reports app accounts without the "[bot]" suffix the REST API uses,
+// so it is added back to keep bot detection consistent.
+func (n *graphQLIssue) toComments() []*github.IssueComment {
+	comments := make([]*github.IssueComment, len(n.Comments.Nodes))
+	for i, node := range n.Comments.Nodes {
+		login := ""
+		if node.Author != nil {
+			login = node.Author.Login
+			if node.Author.Typename == "Bot" && !strings.HasSuffix(login, "[bot]") {
+				login += "[bot]"
+			}
+		}
+		comments[i] = &github.IssueComment{
+			ID:        github.Int64(node.DatabaseID),
+			Body:      github.String(node.Body),
+			User:      &github.User{Login: github.String(login)},
+			CreatedAt: &github.Timestamp{Time: node.CreatedAt},
+			UpdatedAt: &github.Timestamp{Time: node.UpdatedAt},
+		}
+	}
+	return comments
+}
+
+// graphQLEndpoint returns the GraphQL endpoint for a configured REST API URL.
+// GitHub Enterprise serves REST under /api/v3 and GraphQL under /api/graphql.
+func graphQLEndpoint(apiURL string) string {
+	apiURL = strings.TrimSuffix(strings.TrimSpace(apiURL), "/")
+	if apiURL == "" || apiURL == "https://api.github.com" {
+		return "https://api.github.com/graphql"
+	}
+	apiURL = strings.TrimSuffix(apiURL, "/api/v3")
+	return apiURL + "/api/graphql"
+}
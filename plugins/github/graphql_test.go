@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+)
+
+// newTestPlugin returns a plugin whose REST and GraphQL clients talk to server.
+func newTestPlugin(t *testing.T, server *httptest.Server) *Plugin {
+	t.Helper()
+
+	gh, err := github.NewClient(server.Client()).WithEnterpriseURLs(server.URL, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return &Plugin{
+		client: &client{
+			gh:         gh,
+			httpClient: server.Client(),
+			graphQLURL: server.URL + "/api/graphql",
+		},
+		config:     map[string]string{"token": "t"},
+		useGraphQL: true,
+	}
+}
+
+// TestFetchTasks_GraphQL verifies that issues and comments are read from a
+// paginated GraphQL query and that comments are served without REST calls.
+func TestFetchTasks_GraphQL(t *testing.T) {
+	pages := []string{
+		`{"data":{"repository":{"issues":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[
+			{"number":1,"title":"First","body":"","state":"OPEN","url":"https://github.com/o/r/issues/1",
+			 "createdAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-02T00:00:00Z",
+			 "labels":{"nodes":[{"name":"priority:low"},{"name":"bug"}]},
+			 "assignees":{"nodes":[{"login":"alice"}]},
+			 "milestone":{"dueOn":"2025-02-01T00:00:00Z"},
+			 "comments":{"totalCount":1,"nodes":[{"databaseId":11,"body":"hi","createdAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-01T00:00:00Z","author":{"__typename":"Bot","login":"dependabot"}}]}}
+		]}}}}`,
+		`{"data":{"repository":{"issues":{"pageInfo":{"hasNextPage":false,"endCursor":"c2"},"nodes":[
+			{"number":2,"title":"Second","body":"done","state":"CLOSED","stateReason":"NOT_PLANNED","url":"https://github.com/o/r/issues/2",
+			 "createdAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-03T00:00:00Z",
+			 "labels":{"nodes":[]},"assignees":{"nodes":[]},
+			 "comments":{"totalCount":150,"nodes":[]}}
+		]}}}}`,
+	}
+
+	var cursors []any
+	restComments := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		cursors = append(cursors, req.Variables["cursor"])
+		w.Write([]byte(pages[len(cursors)-1]))
+	})
+	mux.HandleFunc("GET /api/v3/repos/o/r/issues/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		restComments++
+		w.Write([]byte(`[{"id":21,"body":"rest","user":{"login":"bob"}}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := newTestPlugin(t, server)
+	project := "o/r"
+
+	tasks, err := p.FetchTasks(context.Background(), &project, nil)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	if len(cursors) != 2 || cursors[0] != nil || cursors[1] != "c1" {
+		t.Errorf("Expected cursors [nil c1], got %v", cursors)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	first := tasks[0]
+	if first.Status != "active" || first.Priority == nil || *first.Priority != "low" {
+		t.Errorf("Unexpected first task status/priority: %s %v", first.Status, first.Priority)
+	}
+	if len(first.Labels) != 1 || first.Labels[0].Name != "bug" {
+		t.Errorf("Expected labels [bug], got %v", first.Labels)
+	}
+	if len(first.Assignees) != 1 || first.Assignees[0].Name != "alice" {
+		t.Errorf("Expected assignee alice, got %v", first.Assignees)
+	}
+	if first.DueDate == nil {
+		t.Error("Expected due date from milestone")
+	}
+	if tasks[1].Status != "canceled" {
+		t.Errorf("Expected second task canceled, got %s", tasks[1].Status)
+	}
+
+	comments, err := p.FetchComments(context.Background(), &project, "1")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ExternalID != "11" || comments[0].Author != "dependabot[bot]" {
+		t.Errorf("Unexpected cached comments: %+v", comments)
+	}
+	if restComments != 0 {
+		t.Errorf("Expected no REST comment requests for issue 1, got %d", restComments)
+	}
+
+	// Issue 2 had more comments than the query returned, so REST is used.
+	comments, err = p.FetchComments(context.Background(), &project, "2")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+	if restComments != 1 || len(comments) != 1 || comments[0].Content != "rest" {
+		t.Errorf("Expected REST comments for issue 2, got %d requests and %+v", restComments, comments)
+	}
+}
+
+// TestFetchTasks_GraphQLFallback verifies that REST is used when GraphQL fails.
+func TestFetchTasks_GraphQLFallback(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not available", http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/v3/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"number":3,"title":"From REST","state":"open"},{"number":4,"title":"PR","state":"open","pull_request":{}}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := newTestPlugin(t, server)
+	project := "o/r"
+
+	tasks, err := p.FetchTasks(context.Background(), &project, nil)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "From REST" {
+		t.Errorf("Expected REST task, got %+v", tasks)
+	}
+}
+
+// TestGraphQLEndpoint tests deriving the GraphQL URL from the REST API URL.
+func TestGraphQLEndpoint(t *testing.T) {
+	tests := []struct {
+		apiURL   string
+		expected string
+	}{
+		{"", "https://api.github.com/graphql"},
+		{"https://api.github.com/", "https://api.github.com/graphql"},
+		{"https://github.example.com/api/v3/", "https://github.example.com/api/graphql"},
+		{"https://github.example.com", "https://github.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		if got := graphQLEndpoint(tt.apiURL); got != tt.expected {
+			t.Errorf("graphQLEndpoint(%q) = %q, want %q", tt.apiURL, got, tt.expected)
+		}
+	}
+}
+
+// TestTakeComments_Since verifies that cached comments are consumed once and
+// filtered by since.
+func TestTakeComments_Since(t *testing.T) {
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := old.Add(time.Hour)
+
+	p := &Plugin{}
+	p.storeComments("o", "r", map[int][]*github.IssueComment{
+		1: {
+			{ID: github.Int64(1), UpdatedAt: &github.Timestamp{Time: old}},
+			{ID: github.Int64(2), UpdatedAt: &github.Timestamp{Time: recent}},
+		},
+	})
+
+	comments, ok := p.takeComments("o", "r", 1, &recent)
+	if !ok || len(comments) != 1 || comments[0].GetID() != 2 {
+		t.Errorf("Expected only comment 2, got %v (ok %v)", comments, ok)
+	}
+
+	if _, ok := p.takeComments("o", "r", 1, nil); ok {
+		t.Error("Expected cached comments to be consumed")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/google/go-github/v56/github"
 )
@@ -16,7 +17,7 @@ import (
 //   - GitHub Repository → Todu Project (external_id = "owner/repo")
 //   - GitHub Issue → Todu Task (external_id = issue number as string)
 //   - GitHub Issue State + StateReason → Todu Status (bidirectional with semantic preservation)
-//   - GitHub Labels → Todu Labels (priority extracted from priority:* labels)
+//   - GitHub Labels → Todu Labels (priority extracted from priority labels)
 //   - GitHub Issue Comments → Todu Comments (1:1 mapping)
 //
 // Status Mapping (Todu → GitHub):
@@ -33,13 +34,12 @@ import (
 //   - state: "open"                                → active
 //
 // Priority Mapping:
-//   - Labels matching "priority:high" → high priority
-//   - Labels matching "priority:medium" → medium priority
-//   - Labels matching "priority:low" → low priority
-//   - Labels matching "priority:*" (invalid values) → high priority (e.g., urgent, critical, p0)
+//   - Labels in the priority_labels table (e.g., "urgent=P0,high=P1") → their level
+//   - Labels matching "priority:<level>" for a configured level → that level
+//   - Labels matching "priority:*" (other values) → high priority (e.g., critical, p0)
 //   - No priority label → no priority set
 //
-// Note: Invalid priority values are normalized to "high" to prevent accidental deprioritization
+// Note: Unknown priority values are normalized to "high" to prevent accidental deprioritization
 // and to increase visibility so users are more likely to correct the labels in GitHub.
 
 // repoToProject converts a GitHub repository to a Todu project.
@@ -60,7 +60,8 @@ func repoToProject(repo *github.Repository) *types.Project {
 }
 
 // issueToTask converts a GitHub issue to a Todu task.
-func issueToTask(issue *github.Issue, repoOwner, repoName string) *types.Task {
+// priorities maps priority levels to labels; nil uses priority:<level>.
+func issueToTask(issue *github.Issue, repoOwner, repoName string, priorities *plugin.PriorityLabels) *types.Task {
 	externalID := strconv.Itoa(issue.GetNumber())
 
 	var description *string
@@ -72,10 +73,10 @@ func issueToTask(issue *github.Issue, repoOwner, repoName string) *types.Task {
 	status := mapGitHubStatusToTodu(issue.GetState(), issue.GetStateReason())
 
 	// Extract priority from labels
-	priority := extractPriority(issue.Labels)
+	priority := extractPriority(issue.Labels, priorities)
 
 	// Extract non-priority labels
-	labels := extractLabels(issue.Labels)
+	labels := extractLabels(issue.Labels, priorities)
 
 	// Extract assignees
 	assignees := extractAssignees(issue.Assignees)
@@ -105,40 +106,28 @@ func issueToTask(issue *github.Issue, repoOwner, repoName string) *types.Task {
 }
 
 // extractPriority extracts priority from GitHub labels and normalizes to valid values.
-// Valid priorities are: high, medium, low
-// Any other priority value is mapped to "high" to avoid accidental deprioritization.
-func extractPriority(labels []*github.Label) *string {
+// Valid priorities are the configured levels. Any other priority:* value is
+// mapped to "high" to avoid accidental deprioritization.
+func extractPriority(labels []*github.Label, priorities *plugin.PriorityLabels) *string {
 	for _, label := range labels {
-		name := strings.ToLower(label.GetName())
-		if strings.HasPrefix(name, "priority:") {
-			priority := strings.TrimPrefix(name, "priority:")
-
-			// Normalize to valid priority values
-			switch priority {
-			case "high", "medium", "low":
-				return &priority
-			default:
-				// Map any invalid priority to "high" to avoid deprioritization
-				// This includes values like: urgent, critical, p0, p1, etc.
-				high := "high"
-				return &high
-			}
+		if priority, ok := priorities.Priority(label.GetName()); ok {
+			return &priority
 		}
 	}
 	return nil
 }
 
 // extractLabels extracts non-priority labels.
-func extractLabels(labels []*github.Label) []types.Label {
+func extractLabels(labels []*github.Label, priorities *plugin.PriorityLabels) []types.Label {
 	var result []types.Label
 	for _, label := range labels {
-		name := strings.ToLower(label.GetName())
 		// Skip priority labels
-		if strings.HasPrefix(name, "priority:") {
+		if _, ok := priorities.Priority(label.GetName()); ok {
 			continue
 		}
 		result = append(result, types.Label{
-			Name: label.GetName(),
+			Name:  label.GetName(),
+			Color: strings.ToLower(label.GetColor()),
 		})
 	}
 	return result
@@ -166,6 +155,30 @@ func commentToComment(comment *github.IssueComment) *types.Comment {
 	}
 }
 
+// reactionContent maps a Todu reaction emoji to a GitHub reaction content
+// name. Reports false for emoji GitHub has no reaction for.
+func reactionContent(emoji string) (string, bool) {
+	switch emoji {
+	case "👍":
+		return "+1", true
+	case "👎":
+		return "-1", true
+	case "😄":
+		return "laugh", true
+	case "😕":
+		return "confused", true
+	case "❤️", "❤":
+		return "heart", true
+	case "🎉":
+		return "hooray", true
+	case "🚀":
+		return "rocket", true
+	case "👀":
+		return "eyes", true
+	}
+	return "", false
+}
+
 // mapToduStatusToGitHub maps todu status to GitHub state and state_reason.
 //
 // Mappings:
@@ -205,7 +218,7 @@ func mapGitHubStatusToTodu(state string, stateReason string) string {
 }
 
 // taskCreateToIssueRequest converts a Todu TaskCreate to a GitHub IssueRequest.
-func taskCreateToIssueRequest(task *types.TaskCreate) *github.IssueRequest {
+func taskCreateToIssueRequest(task *types.TaskCreate, priorities *plugin.PriorityLabels) *github.IssueRequest {
 	req := &github.IssueRequest{
 		Title: &task.Title,
 	}
@@ -217,7 +230,7 @@ func taskCreateToIssueRequest(task *types.TaskCreate) *github.IssueRequest {
 	// Build labels including priority
 	var labels []string
 	if task.Priority != nil {
-		labels = append(labels, fmt.Sprintf("priority:%s", *task.Priority))
+		labels = append(labels, priorities.Label(*task.Priority))
 	}
 	labels = append(labels, task.Labels...)
 	if len(labels) > 0 {
@@ -233,7 +246,7 @@ func taskCreateToIssueRequest(task *types.TaskCreate) *github.IssueRequest {
 }
 
 // taskUpdateToIssueRequest converts a Todu TaskUpdate to a GitHub IssueRequest.
-func taskUpdateToIssueRequest(task *types.TaskUpdate) *github.IssueRequest {
+func taskUpdateToIssueRequest(task *types.TaskUpdate, priorities *plugin.PriorityLabels) *github.IssueRequest {
 	req := &github.IssueRequest{}
 
 	if task.Title != nil {
@@ -257,7 +270,7 @@ func taskUpdateToIssueRequest(task *types.TaskUpdate) *github.IssueRequest {
 	if task.Priority != nil || len(task.Labels) > 0 {
 		var labels []string
 		if task.Priority != nil {
-			labels = append(labels, fmt.Sprintf("priority:%s", *task.Priority))
+			labels = append(labels, priorities.Label(*task.Priority))
 		}
 		labels = append(labels, task.Labels...)
 		req.Labels = &labels
@@ -279,3 +292,29 @@ func parseRepoExternalID(externalID string) (owner, repo string, err error) {
 	}
 	return parts[0], parts[1], nil
 }
+
+// issueSearchQuery restricts a search query to issues unless it already
+// chooses between issues and pull requests.
+func issueSearchQuery(query string) string {
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		switch term {
+		case "is:issue", "is:pr", "is:pull-request", "type:issue", "type:pr":
+			return query
+		}
+	}
+	return strings.TrimSpace(query + " is:issue")
+}
+
+// repoFromIssueURL extracts "owner/repo" from an issue's repository API URL
+// (e.g., "https://api.github.com/repos/owner/repo").
+func repoFromIssueURL(repositoryURL string) (string, error) {
+	idx := strings.LastIndex(repositoryURL, "/repos/")
+	if idx < 0 {
+		return "", fmt.Errorf("unexpected repository URL %q", repositoryURL)
+	}
+	externalID := strings.Trim(repositoryURL[idx+len("/repos/"):], "/")
+	if _, _, err := parseRepoExternalID(externalID); err != nil {
+		return "", err
+	}
+	return externalID, nil
+}
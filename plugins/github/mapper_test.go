@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
 	"github.com/google/go-github/v56/github"
 )
@@ -174,7 +175,7 @@ func TestIssueToTask_StatusMapping(t *testing.T) {
 				UpdatedAt:   &github.Timestamp{Time: now},
 			}
 
-			task := issueToTask(issue, owner, repo)
+			task := issueToTask(issue, owner, repo, nil)
 
 			if task.Status != tt.expectedStatus {
 				t.Errorf("Expected status %s, got %s", tt.expectedStatus, task.Status)
@@ -223,7 +224,7 @@ func TestTaskUpdateToIssueRequest_StatusMapping(t *testing.T) {
 				Status: &tt.toduStatus,
 			}
 
-			req := taskUpdateToIssueRequest(taskUpdate)
+			req := taskUpdateToIssueRequest(taskUpdate, nil)
 
 			if req.State == nil {
 				t.Fatal("Expected State to be set")
@@ -256,7 +257,7 @@ func TestTaskUpdateToIssueRequest_NoStatusChange(t *testing.T) {
 		Status: nil,
 	}
 
-	req := taskUpdateToIssueRequest(taskUpdate)
+	req := taskUpdateToIssueRequest(taskUpdate, nil)
 
 	if req.State != nil {
 		t.Errorf("Expected State to be nil when status is not updated, got %s", *req.State)
@@ -364,7 +365,7 @@ func TestExtractPriority(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			priority := extractPriority(tt.labels)
+			priority := extractPriority(tt.labels, nil)
 
 			if tt.expectedPriority == nil {
 				if priority != nil {
@@ -381,3 +382,101 @@ func TestExtractPriority(t *testing.T) {
 		})
 	}
 }
+
+// TestPriorityLabelsMapping tests that a priority_labels table maps
+// configured levels to custom labels in both directions.
+func TestPriorityLabelsMapping(t *testing.T) {
+	t.Cleanup(func() { _ = types.SetPriorities(nil) })
+	if err := types.SetPriorities([]string{"low", "medium", "high", "urgent"}); err != nil {
+		t.Fatal(err)
+	}
+	priorities, err := plugin.ParsePriorityLabels("urgent=P0,high=P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := &github.Issue{
+		Number: github.Int(1),
+		State:  github.String("open"),
+		Labels: []*github.Label{{Name: github.String("P0")}, {Name: github.String("bug")}},
+	}
+	task := issueToTask(issue, "owner", "repo", priorities)
+	if task.Priority == nil || *task.Priority != "urgent" {
+		t.Errorf("Expected P0 to map to urgent, got %v", task.Priority)
+	}
+	if len(task.Labels) != 1 || task.Labels[0].Name != "bug" {
+		t.Errorf("Expected P0 to be left out of labels, got %v", task.Labels)
+	}
+
+	urgent, medium := "urgent", "medium"
+	req := taskCreateToIssueRequest(&types.TaskCreate{Title: "t", Priority: &urgent}, priorities)
+	if req.Labels == nil || (*req.Labels)[0] != "P0" {
+		t.Errorf("Expected urgent to be written as P0, got %v", req.Labels)
+	}
+	update := taskUpdateToIssueRequest(&types.TaskUpdate{Priority: &medium}, priorities)
+	if update.Labels == nil || (*update.Labels)[0] != "priority:medium" {
+		t.Errorf("Expected unmapped level to use priority:medium, got %v", update.Labels)
+	}
+}
+
+// TestIssueSearchQuery tests that searches are restricted to issues by default.
+func TestIssueSearchQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"is:open label:help-wanted", "is:open label:help-wanted is:issue"},
+		{"repo:x/y is:PR", "repo:x/y is:PR"},
+		{"type:issue org:acme", "type:issue org:acme"},
+		{"", "is:issue"},
+	}
+
+	for _, tt := range tests {
+		if got := issueSearchQuery(tt.query); got != tt.expected {
+			t.Errorf("issueSearchQuery(%q) = %q, want %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+// TestRepoFromIssueURL tests extracting owner/repo from repository API URLs.
+func TestRepoFromIssueURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+		wantErr  bool
+	}{
+		{"https://api.github.com/repos/octocat/hello", "octocat/hello", false},
+		{"https://github.example.com/api/v3/repos/acme/tools/", "acme/tools", false},
+		{"https://api.github.com/users/octocat", "", true},
+		{"https://api.github.com/repos/octocat", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := repoFromIssueURL(tt.url)
+		if (err != nil) != tt.wantErr || got != tt.expected {
+			t.Errorf("repoFromIssueURL(%q) = %q, %v; want %q (error %v)", tt.url, got, err, tt.expected, tt.wantErr)
+		}
+	}
+}
+
+// TestReactionContent tests mapping reaction emoji to GitHub reactions.
+func TestReactionContent(t *testing.T) {
+	tests := []struct {
+		emoji    string
+		expected string
+		ok       bool
+	}{
+		{"👍", "+1", true},
+		{"❤️", "heart", true},
+		{"🎉", "hooray", true},
+		{"👀", "eyes", true},
+		{"🦄", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := reactionContent(tt.emoji)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("reactionContent(%q) = %q, %v; want %q, %v", tt.emoji, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
@@ -5,17 +5,34 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/evcraddock/todu.sh/internal/registry"
 	"github.com/evcraddock/todu.sh/pkg/plugin"
 	"github.com/evcraddock/todu.sh/pkg/types"
+	"github.com/google/go-github/v56/github"
 )
 
 // Plugin implements the plugin.Plugin interface for GitHub.
 type Plugin struct {
 	client *client
 	config map[string]string
+
+	// useGraphQL fetches issues through the GraphQL API, falling back to REST.
+	useGraphQL bool
+
+	// priorities maps priority levels to labels, from priority_labels.
+	priorities *plugin.PriorityLabels
+
+	// fieldsFrontMatter syncs custom fields as front matter in issue bodies
+	// rather than as labels, from custom_fields.
+	fieldsFrontMatter bool
+
+	// comments holds comments returned alongside issues by the GraphQL query,
+	// keyed by "owner/repo#number", until FetchComments consumes them.
+	comments   map[string][]*github.IssueComment
+	commentsMu sync.Mutex
 }
 
 // init registers the GitHub plugin with the global registry.
@@ -41,6 +58,8 @@ func (p *Plugin) Version() string {
 //
 // Optional configuration keys:
 //   - url: GitHub API URL (defaults to "https://api.github.com")
+//   - graphql: "false" to fetch issues with the REST API only
+//   - custom_fields: "frontmatter" to sync custom fields in issue bodies
 func (p *Plugin) Configure(config map[string]string) error {
 	p.config = config
 
@@ -49,8 +68,20 @@ func (p *Plugin) Configure(config map[string]string) error {
 		return err
 	}
 
-	// Create GitHub API client
+	p.useGraphQL = !strings.EqualFold(config["graphql"], "false")
+
 	var err error
+	p.priorities, err = plugin.ParsePriorityLabels(config["priority_labels"])
+	if err != nil {
+		return fmt.Errorf("invalid priority_labels: %w", err)
+	}
+
+	p.fieldsFrontMatter, err = plugin.ParseCustomFields(config["custom_fields"])
+	if err != nil {
+		return err
+	}
+
+	// Create GitHub API client
 	p.client, err = newClient(config)
 	if err != nil {
 		return fmt.Errorf("failed to create GitHub client: %w", err)
@@ -136,6 +167,23 @@ func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, sinc
 		return nil, err
 	}
 
+	if p.useGraphQL {
+		issues, comments, err := p.client.listIssuesGraphQL(ctx, owner, repo, since)
+		if err == nil {
+			p.storeComments(owner, repo, comments)
+			tasks := make([]*types.Task, len(issues))
+			for i, issue := range issues {
+				tasks[i] = p.toTask(issue, owner, repo)
+			}
+			return tasks, nil
+		}
+		if ctx.Err() != nil {
+			return nil, handleGitHubError(err, fmt.Sprintf("failed to list issues for %s", *projectExternalID))
+		}
+		// Fall back to REST, e.g. for GitHub Enterprise servers without GraphQL
+		// or tokens lacking GraphQL access.
+	}
+
 	issues, err := p.client.listIssues(ctx, owner, repo, since)
 	if err != nil {
 		return nil, handleGitHubError(err, fmt.Sprintf("failed to list issues for %s", *projectExternalID))
@@ -147,7 +195,7 @@ func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, sinc
 		if issue.PullRequestLinks != nil {
 			continue
 		}
-		tasks = append(tasks, issueToTask(issue, owner, repo))
+		tasks = append(tasks, p.toTask(issue, owner, repo))
 	}
 
 	return tasks, nil
@@ -178,7 +226,7 @@ func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskE
 		return nil, handleGitHubError(err, fmt.Sprintf("failed to fetch issue %s#%s", *projectExternalID, taskExternalID))
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	return p.toTask(issue, owner, repo), nil
 }
 
 // CreateTask creates a new issue in GitHub.
@@ -196,13 +244,19 @@ func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task
 		return nil, err
 	}
 
-	req := taskCreateToIssueRequest(task)
+	if p.fieldsFrontMatter {
+		created := *task
+		created.Labels, created.Description = plugin.FieldsToFrontMatter(task.Labels, task.Description)
+		task = &created
+	}
+
+	req := taskCreateToIssueRequest(task, p.priorities)
 	issue, err := p.client.createIssue(ctx, owner, repo, req)
 	if err != nil {
 		return nil, handleGitHubError(err, fmt.Sprintf("failed to create issue in %s", *projectExternalID))
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	return p.toTask(issue, owner, repo), nil
 }
 
 // UpdateTask updates an existing issue in GitHub.
@@ -225,17 +279,38 @@ func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, task
 		return nil, fmt.Errorf("invalid task external_id: %w", err)
 	}
 
-	req := taskUpdateToIssueRequest(task)
+	if p.fieldsFrontMatter {
+		updated := *task
+		updated.Labels, updated.Description = plugin.FieldsToFrontMatter(task.Labels, task.Description)
+		task = &updated
+	}
+
+	req := taskUpdateToIssueRequest(task, p.priorities)
 	issue, err := p.client.updateIssue(ctx, owner, repo, issueNumber, req)
 	if err != nil {
 		return nil, handleGitHubError(err, fmt.Sprintf("failed to update issue %s#%s", *projectExternalID, taskExternalID))
 	}
 
-	return issueToTask(issue, owner, repo), nil
+	return p.toTask(issue, owner, repo), nil
+}
+
+// toTask converts an issue to a task, reading custom fields out of its body
+// when they're synced as front matter.
+func (p *Plugin) toTask(issue *github.Issue, owner, repo string) *types.Task {
+	task := issueToTask(issue, owner, repo, p.priorities)
+	if p.fieldsFrontMatter {
+		plugin.FieldsFromFrontMatter(task)
+	}
+	return task
 }
 
 // FetchComments retrieves all comments for an issue.
 func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	return p.FetchCommentsSince(ctx, projectExternalID, taskExternalID, nil)
+}
+
+// FetchCommentsSince retrieves the comments of an issue updated at or after since.
+func (p *Plugin) FetchCommentsSince(ctx context.Context, projectExternalID *string, taskExternalID string, since *time.Time) ([]*types.Comment, error) {
 	if p.client == nil {
 		return nil, plugin.ErrNotConfigured
 	}
@@ -254,9 +329,12 @@ func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, t
 		return nil, fmt.Errorf("invalid task external_id: %w", err)
 	}
 
-	comments, err := p.client.listComments(ctx, owner, repo, issueNumber)
-	if err != nil {
-		return nil, handleGitHubError(err, fmt.Sprintf("failed to list comments for %s#%s", *projectExternalID, taskExternalID))
+	comments, ok := p.takeComments(owner, repo, issueNumber, since)
+	if !ok {
+		comments, err = p.client.listComments(ctx, owner, repo, issueNumber, since)
+		if err != nil {
+			return nil, handleGitHubError(err, fmt.Sprintf("failed to list comments for %s#%s", *projectExternalID, taskExternalID))
+		}
 	}
 
 	result := make([]*types.Comment, len(comments))
@@ -287,6 +365,8 @@ func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, t
 		return nil, fmt.Errorf("invalid task external_id: %w", err)
 	}
 
+	p.forgetComments(owner, repo, issueNumber)
+
 	ghComment, err := p.client.createComment(ctx, owner, repo, issueNumber, comment.Content)
 	if err != nil {
 		return nil, handleGitHubError(err, fmt.Sprintf("failed to create comment on %s#%s", *projectExternalID, taskExternalID))
@@ -295,6 +375,229 @@ func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, t
 	return commentToComment(ghComment), nil
 }
 
+// UpdateComment replaces the body of an existing comment.
+func (p *Plugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for GitHub")
+	}
+
+	owner, repo, err := parseRepoExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := strconv.ParseInt(commentExternalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment external_id: %w", err)
+	}
+
+	if issueNumber, err := strconv.Atoi(taskExternalID); err == nil {
+		p.forgetComments(owner, repo, issueNumber)
+	}
+
+	ghComment, err := p.client.editComment(ctx, owner, repo, commentID, comment.Content)
+	if err != nil {
+		return nil, handleGitHubError(err, fmt.Sprintf("failed to update comment %s on %s#%s", commentExternalID, *projectExternalID, taskExternalID))
+	}
+
+	return commentToComment(ghComment), nil
+}
+
+// AddCommentReaction adds a reaction to a GitHub issue comment.
+// Returns plugin.ErrNotSupported for emoji GitHub has no reaction for.
+func (p *Plugin) AddCommentReaction(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID, emoji string) error {
+	if p.client == nil {
+		return plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return fmt.Errorf("projectExternalID is required for GitHub")
+	}
+
+	content, ok := reactionContent(emoji)
+	if !ok {
+		return plugin.ErrNotSupported
+	}
+
+	owner, repo, err := parseRepoExternalID(*projectExternalID)
+	if err != nil {
+		return err
+	}
+
+	commentID, err := strconv.ParseInt(commentExternalID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment external_id: %w", err)
+	}
+
+	if err := p.client.addReaction(ctx, owner, repo, commentID, content); err != nil {
+		return handleGitHubError(err, fmt.Sprintf("failed to add reaction to comment %s on %s#%s", commentExternalID, *projectExternalID, taskExternalID))
+	}
+	return nil
+}
+
+// PushLabels creates the labels missing from a repository. GitHub label
+// names are case-insensitive, so a label differing only in case is reported
+// as drift rather than created.
+func (p *Plugin) PushLabels(ctx context.Context, projectExternalID *string, labels []string, opts plugin.LabelPushOptions) (*plugin.LabelPushResult, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for GitHub")
+	}
+
+	owner, repo, err := parseRepoExternalID(*projectExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.client.listLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, handleGitHubError(err, fmt.Sprintf("failed to list labels for %s", *projectExternalID))
+	}
+
+	remote := make([]plugin.RemoteLabel, len(existing))
+	for i, label := range existing {
+		remote[i] = plugin.RemoteLabel{Name: label.GetName(), Color: label.GetColor()}
+	}
+
+	names := make([]string, len(labels))
+	for i, name := range labels {
+		names[i] = p.priorities.Relabel(name)
+	}
+	result, missing := plugin.PlanLabelPush(names, remote, opts)
+	err = plugin.CreateLabels(ctx, result, missing, opts, func(name string) error {
+		if _, err := p.client.createLabel(ctx, owner, repo, name, opts.ColorFor(name)); err != nil {
+			return handleGitHubError(err, fmt.Sprintf("failed to create label %q", name))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SearchProjects returns the repositories matching a GitHub search query
+// (e.g., "org:myorg topic:active").
+func (p *Plugin) SearchProjects(ctx context.Context, query string) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	repos, err := p.client.searchRepositories(ctx, query)
+	if err != nil {
+		return nil, handleGitHubError(err, "failed to search repositories")
+	}
+
+	projects := make([]*types.Project, len(repos))
+	for i, repo := range repos {
+		projects[i] = repoToProject(repo)
+	}
+
+	return projects, nil
+}
+
+// SearchTasks returns the issues matching a GitHub search query
+// (e.g., "is:open label:help-wanted repo:owner/name"). Pull requests are
+// excluded unless the query asks for them with is:pr.
+func (p *Plugin) SearchTasks(ctx context.Context, query string) ([]*plugin.SearchResult, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	issues, err := p.client.searchIssues(ctx, issueSearchQuery(query))
+	if err != nil {
+		return nil, handleGitHubError(err, "failed to search issues")
+	}
+
+	results := make([]*plugin.SearchResult, 0, len(issues))
+	for _, issue := range issues {
+		externalID, err := repoFromIssueURL(issue.GetRepositoryURL())
+		if err != nil {
+			return nil, err
+		}
+		owner, repo, _ := parseRepoExternalID(externalID)
+		results = append(results, &plugin.SearchResult{
+			ProjectExternalID: externalID,
+			Task:              p.toTask(issue, owner, repo),
+		})
+	}
+
+	return results, nil
+}
+
+// CurrentUser returns the login of the user the configured token belongs to.
+func (p *Plugin) CurrentUser(ctx context.Context) (string, error) {
+	if p.client == nil {
+		return "", plugin.ErrNotConfigured
+	}
+
+	login, err := p.client.currentUser(ctx)
+	if err != nil {
+		return "", handleGitHubError(err, "failed to get authenticated user")
+	}
+
+	return login, nil
+}
+
+// commentKey identifies an issue in the comment cache.
+func commentKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+// storeComments replaces the cached comments for a repository's issues.
+func (p *Plugin) storeComments(owner, repo string, comments map[int][]*github.IssueComment) {
+	p.commentsMu.Lock()
+	defer p.commentsMu.Unlock()
+
+	if p.comments == nil {
+		p.comments = make(map[string][]*github.IssueComment)
+	}
+	for number, list := range comments {
+		p.comments[commentKey(owner, repo, number)] = list
+	}
+}
+
+// takeComments returns and removes the cached comments for an issue,
+// keeping only those updated at or after since.
+func (p *Plugin) takeComments(owner, repo string, number int, since *time.Time) ([]*github.IssueComment, bool) {
+	p.commentsMu.Lock()
+	defer p.commentsMu.Unlock()
+
+	key := commentKey(owner, repo, number)
+	comments, ok := p.comments[key]
+	if !ok {
+		return nil, false
+	}
+	delete(p.comments, key)
+
+	if since == nil {
+		return comments, true
+	}
+	var result []*github.IssueComment
+	for _, comment := range comments {
+		if !comment.GetUpdatedAt().Time.Before(*since) {
+			result = append(result, comment)
+		}
+	}
+	return result, true
+}
+
+// forgetComments drops the cached comments for an issue that is being changed.
+func (p *Plugin) forgetComments(owner, repo string, number int) {
+	p.commentsMu.Lock()
+	defer p.commentsMu.Unlock()
+
+	delete(p.comments, commentKey(owner, repo, number))
+}
+
 // handleGitHubError converts GitHub API errors to plugin errors.
 func handleGitHubError(err error, context string) error {
 	if err == nil {
@@ -61,7 +61,7 @@ func TestFetchTasks_FiltersPullRequests(t *testing.T) {
 		if issue.PullRequestLinks != nil {
 			continue
 		}
-		task := issueToTask(issue, owner, repo)
+		task := issueToTask(issue, owner, repo, nil)
 		filteredTasks = append(filteredTasks, task.Title)
 	}
 
@@ -151,7 +151,7 @@ func TestFetchTasks_NoPullRequests(t *testing.T) {
 		if issue.PullRequestLinks != nil {
 			continue
 		}
-		task := issueToTask(issue, owner, repo)
+		task := issueToTask(issue, owner, repo, nil)
 		filteredTasks = append(filteredTasks, task.Title)
 	}
 
@@ -159,3 +159,29 @@ func TestFetchTasks_NoPullRequests(t *testing.T) {
 		t.Errorf("Expected 2 tasks when no PRs present, got %d", len(filteredTasks))
 	}
 }
+
+// TestToTask_FieldsFrontMatter verifies that custom fields in an issue body's
+// front matter become field labels when custom_fields is frontmatter.
+func TestToTask_FieldsFrontMatter(t *testing.T) {
+	issue := &github.Issue{
+		Number:  github.Int(7),
+		Title:   github.String("Invoice export"),
+		State:   github.String("open"),
+		Body:    github.String("---\ncustomer: acme\n---\n\nExport invoices as CSV"),
+		HTMLURL: github.String("https://github.com/test/test/issues/7"),
+	}
+
+	p := &Plugin{fieldsFrontMatter: true}
+	task := p.toTask(issue, "test", "test")
+	if task.Description == nil || *task.Description != "Export invoices as CSV" {
+		t.Errorf("Expected the front matter to be stripped, got %v", task.Description)
+	}
+	if task.Fields()["customer"] != "acme" {
+		t.Errorf("Expected the customer field, got labels %v", task.Labels)
+	}
+
+	p = &Plugin{}
+	if task := p.toTask(issue, "test", "test"); len(task.Fields()) != 0 {
+		t.Errorf("Expected the body left alone without custom_fields, got labels %v", task.Labels)
+	}
+}
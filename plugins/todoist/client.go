@@ -0,0 +1,337 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/httpcache"
+)
+
+// defaultBaseURL is the Todoist API host used unless the url setting is set.
+const defaultBaseURL = "https://api.todoist.com"
+
+// Todoist API response types
+
+// Project represents a Todoist project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Section represents a section of a Todoist project.
+type Section struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+}
+
+// Due represents a task's due date. Date is "YYYY-MM-DD"; Datetime is set,
+// as RFC 3339, only when the task is due at a time of day.
+type Due struct {
+	Date        string `json:"date"`
+	Datetime    string `json:"datetime"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+}
+
+// Task represents a Todoist task. Priority runs from 1 (normal) to 4
+// (urgent).
+type Task struct {
+	ID          string   `json:"id"`
+	ProjectID   string   `json:"project_id"`
+	SectionID   string   `json:"section_id"`
+	Content     string   `json:"content"`
+	Description string   `json:"description"`
+	IsCompleted bool     `json:"is_completed"`
+	Labels      []string `json:"labels"`
+	Priority    int      `json:"priority"`
+	Due         *Due     `json:"due"`
+	URL         string   `json:"url"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// Comment represents a comment on a Todoist task.
+type Comment struct {
+	ID       string `json:"id"`
+	TaskID   string `json:"task_id"`
+	Content  string `json:"content"`
+	PostedAt string `json:"posted_at"`
+}
+
+// API request types
+
+// CreateTaskRequest represents the request body for creating a task.
+type CreateTaskRequest struct {
+	Content     string   `json:"content"`
+	Description string   `json:"description,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+	SectionID   string   `json:"section_id,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Priority    int      `json:"priority,omitempty"`
+	DueDate     string   `json:"due_date,omitempty"`
+	DueString   string   `json:"due_string,omitempty"`
+}
+
+// UpdateTaskRequest represents the request body for updating a task.
+// Completion is changed with closeTask and reopenTask instead.
+type UpdateTaskRequest struct {
+	Content     *string   `json:"content,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Priority    *int      `json:"priority,omitempty"`
+	DueDate     *string   `json:"due_date,omitempty"`
+	DueString   *string   `json:"due_string,omitempty"`
+}
+
+// CommentRequest represents the request body for creating or editing a
+// comment.
+type CommentRequest struct {
+	TaskID  string `json:"task_id,omitempty"`
+	Content string `json:"content"`
+}
+
+// client wraps the Todoist REST API with an HTTP client and a cache of
+// project sections.
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	mu sync.Mutex
+
+	// sections caches the sections of each project, by project ID
+	sections map[string][]*Section
+}
+
+// newClient creates a new Todoist API client.
+func newClient(config map[string]string) (*client, error) {
+	token := strings.TrimSpace(config["token"])
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	baseURL := strings.TrimSpace(config["url"])
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	// Normalize base URL (remove trailing slash)
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: httpcache.NewTransport(nil)},
+		sections:   make(map[string][]*Section),
+	}, nil
+}
+
+// doRequest performs an HTTP request with authentication and decodes the
+// JSON response into result, unless result is nil.
+func (c *client) doRequest(ctx context.Context, method, path string, body, result interface{}) error {
+	fullURL := c.baseURL + "/rest/v2" + path
+
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	// Close, reopen, and edits without a body answer 204 No Content
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// listProjects retrieves every project of the user.
+func (c *client) listProjects(ctx context.Context) ([]*Project, error) {
+	var projects []*Project
+	if err := c.doRequest(ctx, http.MethodGet, "/projects", nil, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// getProject retrieves a single project.
+func (c *client) getProject(ctx context.Context, projectID string) (*Project, error) {
+	var project Project
+	if err := c.doRequest(ctx, http.MethodGet, "/projects/"+url.PathEscape(projectID), nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// listSections retrieves the sections of a project, caching them for the
+// life of the client.
+func (c *client) listSections(ctx context.Context, projectID string) ([]*Section, error) {
+	c.mu.Lock()
+	sections, ok := c.sections[projectID]
+	c.mu.Unlock()
+	if ok {
+		return sections, nil
+	}
+
+	params := url.Values{}
+	params.Set("project_id", projectID)
+	if err := c.doRequest(ctx, http.MethodGet, "/sections?"+params.Encode(), nil, &sections); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.sections[projectID] = sections
+	c.mu.Unlock()
+	return sections, nil
+}
+
+// createSection creates a section at the end of a project.
+func (c *client) createSection(ctx context.Context, projectID, name string) (*Section, error) {
+	body := map[string]string{"project_id": projectID, "name": name}
+	var section Section
+	if err := c.doRequest(ctx, http.MethodPost, "/sections", body, &section); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if sections, ok := c.sections[projectID]; ok {
+		c.sections[projectID] = append(sections, &section)
+	}
+	c.mu.Unlock()
+	return &section, nil
+}
+
+// listTasks retrieves the active tasks in a project, or the active tasks
+// matching filter when it is set. Todoist ignores project_id alongside a
+// filter, so filtered tasks from other projects are dropped here.
+func (c *client) listTasks(ctx context.Context, projectID, filter string) ([]*Task, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Set("filter", filter)
+	} else if projectID != "" {
+		params.Set("project_id", projectID)
+	}
+
+	path := "/tasks"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var tasks []*Task
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &tasks); err != nil {
+		return nil, err
+	}
+
+	if filter == "" || projectID == "" {
+		return tasks, nil
+	}
+	var result []*Task
+	for _, task := range tasks {
+		if task.ProjectID == projectID {
+			result = append(result, task)
+		}
+	}
+	return result, nil
+}
+
+// getTask retrieves a single task, active or completed.
+func (c *client) getTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	if err := c.doRequest(ctx, http.MethodGet, "/tasks/"+url.PathEscape(taskID), nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// createTask creates a new task.
+func (c *client) createTask(ctx context.Context, req *CreateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.doRequest(ctx, http.MethodPost, "/tasks", req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// updateTask updates the fields of a task set in req.
+func (c *client) updateTask(ctx context.Context, taskID string, req *UpdateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.doRequest(ctx, http.MethodPost, "/tasks/"+url.PathEscape(taskID), req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// closeTask completes a task.
+func (c *client) closeTask(ctx context.Context, taskID string) error {
+	return c.doRequest(ctx, http.MethodPost, "/tasks/"+url.PathEscape(taskID)+"/close", nil, nil)
+}
+
+// reopenTask uncompletes a task.
+func (c *client) reopenTask(ctx context.Context, taskID string) error {
+	return c.doRequest(ctx, http.MethodPost, "/tasks/"+url.PathEscape(taskID)+"/reopen", nil, nil)
+}
+
+// listComments retrieves every comment on a task, oldest first.
+func (c *client) listComments(ctx context.Context, taskID string) ([]*Comment, error) {
+	params := url.Values{}
+	params.Set("task_id", taskID)
+	var comments []*Comment
+	if err := c.doRequest(ctx, http.MethodGet, "/comments?"+params.Encode(), nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// createComment adds a comment to a task.
+func (c *client) createComment(ctx context.Context, taskID, content string) (*Comment, error) {
+	var comment Comment
+	if err := c.doRequest(ctx, http.MethodPost, "/comments", &CommentRequest{TaskID: taskID, Content: content}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// updateComment replaces the text of a comment.
+func (c *client) updateComment(ctx context.Context, commentID, content string) (*Comment, error) {
+	var comment Comment
+	if err := c.doRequest(ctx, http.MethodPost, "/comments/"+url.PathEscape(commentID), &CommentRequest{Content: content}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
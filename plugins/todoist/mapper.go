@@ -0,0 +1,351 @@
+package todoist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// mapper.go contains functions for converting between Todoist API types and Todu types.
+//
+// Mappings:
+//   - Todoist Project → Todu Project (external_id = project ID)
+//   - Todoist Task → Todu Task (external_id = task ID)
+//   - Todoist Completion → Todu Status (completed → done, else active)
+//   - Todoist Priority → Todu Priority (inverted; see below)
+//   - Todoist Labels → Todu Labels
+//   - Todoist Section → Todu Label "section:<name>"
+//   - Todoist Due Date → Todu Due Date
+//   - Todoist Comments → Todu Comments (1:1 mapping)
+//
+// Priority Mapping (the API runs from 1, normal, to 4, urgent, which the
+// Todoist app shows as p4 to p1):
+//   - 4 (p1) ↔ "high" if it is a priority level, else the highest level
+//     (urgent and critical push as 4 too)
+//   - 3 (p2) ↔ "medium" or "normal" if it is a level, else the default priority
+//   - 2 (p3) ↔ "low" if it is a level, else the lowest level
+//   - 1 (p4) ↔ no priority
+//
+// The priority_labels setting replaces this with a table of levels and
+// p1–p4 names, such as "urgent=p1,high=p2,medium=p3,low=p4". Levels left
+// out of the table push as p4, and p1–p4 left out pull as no priority.
+//
+// Dates: a todu due date is pushed as the Todoist due date. A task with no
+// due date but a scheduled date gets the scheduled date as a due string
+// instead, since Todoist has a single date per task.
+
+// sectionLabelPrefix starts the label that carries a task's section.
+const sectionLabelPrefix = "section:"
+
+// Todoist priority values
+const (
+	priorityNormal = 1
+	priorityMedium = 2
+	priorityHigh   = 3
+	priorityUrgent = 4
+)
+
+// dateLayout is the layout of Todoist due dates.
+const dateLayout = "2006-01-02"
+
+// projectToProject converts a Todoist project to a Todu project.
+func projectToProject(project *Project) *types.Project {
+	return &types.Project{
+		ExternalID: project.ID,
+		Name:       project.Name,
+		Status:     "active",
+	}
+}
+
+// taskToTask converts a Todoist task to a Todu task. sections are the
+// sections of the task's project, used to name its section label.
+func taskToTask(task *Task, sections []*Section, priorities *plugin.PriorityLabels) *types.Task {
+	var description *string
+	if task.Description != "" {
+		description = &task.Description
+	}
+
+	var sourceURL *string
+	if task.URL != "" {
+		sourceURL = &task.URL
+	}
+
+	status := "active"
+	if task.IsCompleted {
+		status = "done"
+	}
+
+	// Todoist doesn't report when a task was last changed
+	created, _ := time.Parse(time.RFC3339, task.CreatedAt)
+
+	labels := make([]types.Label, 0, len(task.Labels)+1)
+	for _, name := range task.Labels {
+		if isSectionLabel(name) {
+			continue
+		}
+		labels = append(labels, types.Label{Name: name})
+	}
+	if name := sectionName(task.SectionID, sections); name != "" {
+		labels = append(labels, types.Label{Name: sectionLabelPrefix + name})
+	}
+
+	return &types.Task{
+		ExternalID:  task.ID,
+		SourceURL:   sourceURL,
+		Title:       task.Content,
+		Description: description,
+		Status:      status,
+		Priority:    mapTodoistPriorityToTodu(task.Priority, priorities),
+		DueDate:     parseDue(task.Due),
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		Labels:      labels,
+	}
+}
+
+// sectionName returns the name of the section with the given ID, or "" if
+// the task isn't in a known section.
+func sectionName(id string, sections []*Section) string {
+	if id == "" {
+		return ""
+	}
+	for _, section := range sections {
+		if section.ID == id {
+			return section.Name
+		}
+	}
+	return ""
+}
+
+// parseDue returns the date a task is due, or nil if it has none.
+func parseDue(due *Due) *time.Time {
+	if due == nil {
+		return nil
+	}
+	if due.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			return &t
+		}
+	}
+	if t, err := time.Parse(dateLayout, due.Date); err == nil {
+		return &t
+	}
+	return nil
+}
+
+// commentToComment converts a Todoist comment to a Todu comment. Todoist
+// doesn't report a comment's author or when it was edited.
+func commentToComment(comment *Comment) *types.Comment {
+	posted, _ := time.Parse(time.RFC3339, comment.PostedAt)
+
+	return &types.Comment{
+		ExternalID: comment.ID,
+		Content:    comment.Content,
+		CreatedAt:  posted,
+		UpdatedAt:  posted,
+	}
+}
+
+// priorityName returns the p1–p4 name the Todoist app shows for an API
+// priority.
+func priorityName(priority int) string {
+	return fmt.Sprintf("p%d", priorityUrgent+1-priority)
+}
+
+// parsePriorityName returns the API priority of a p1–p4 name, matched
+// case-insensitively, and false if name isn't one.
+func parsePriorityName(name string) (int, bool) {
+	for priority := priorityNormal; priority <= priorityUrgent; priority++ {
+		if strings.EqualFold(name, priorityName(priority)) {
+			return priority, true
+		}
+	}
+	return 0, false
+}
+
+// parsePriorityTable parses a priority_labels setting, whose labels must be
+// p1–p4 names. An empty spec returns nil.
+func parsePriorityTable(spec string) (*plugin.PriorityLabels, error) {
+	priorities, err := plugin.ParsePriorityLabels(spec)
+	if err != nil || priorities == nil {
+		return priorities, err
+	}
+	for _, level := range types.Priorities() {
+		label := priorities.Label(level)
+		if label == plugin.PriorityLabelPrefix+level {
+			continue
+		}
+		if _, ok := parsePriorityName(label); !ok {
+			return nil, fmt.Errorf("%s is mapped to %q, not p1, p2, p3 or p4", level, label)
+		}
+	}
+	return priorities, nil
+}
+
+// mapTodoistPriorityToTodu maps a Todoist priority to a todu priority
+// level, or nil for normal priority. priorities is the priority_labels
+// table, or nil for the default mapping.
+func mapTodoistPriorityToTodu(priority int, priorities *plugin.PriorityLabels) *string {
+	if priority <= priorityNormal || priority > priorityUrgent {
+		return nil
+	}
+	if priorities != nil {
+		level, ok := priorities.Priority(priorityName(priority))
+		if !ok {
+			return nil
+		}
+		return &level
+	}
+
+	levels := types.Priorities()
+	pick := func(names ...string) string {
+		for _, name := range names {
+			if slices.Contains(levels, name) {
+				return name
+			}
+		}
+		return ""
+	}
+
+	var level string
+	switch priority {
+	case priorityUrgent:
+		if level = pick("high"); level == "" {
+			level = levels[len(levels)-1]
+		}
+	case priorityHigh:
+		if level = pick("medium", "normal"); level == "" {
+			level = types.DefaultPriority()
+		}
+	case priorityMedium:
+		if level = pick("low"); level == "" {
+			level = levels[0]
+		}
+	}
+	return &level
+}
+
+// mapToduPriorityToTodoist maps a todu priority level to a Todoist
+// priority. Levels with no equivalent, such as "none", are normal priority.
+// priorities is the priority_labels table, or nil for the default mapping.
+func mapToduPriorityToTodoist(priority string, priorities *plugin.PriorityLabels) int {
+	if priorities != nil {
+		if value, ok := parsePriorityName(priorities.Label(strings.ToLower(priority))); ok {
+			return value
+		}
+		return priorityNormal
+	}
+
+	switch strings.ToLower(priority) {
+	case "urgent", "critical", "high":
+		return priorityUrgent
+	case "medium", "normal":
+		return priorityHigh
+	case "low":
+		return priorityMedium
+	default:
+		return priorityNormal
+	}
+}
+
+// isSectionLabel reports whether a label name carries a section.
+func isSectionLabel(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), sectionLabelPrefix)
+}
+
+// splitSectionLabel separates the section label from real labels. The
+// section is "" when no section label is present; if there are several,
+// the last one wins.
+func splitSectionLabel(labels []string) (real []string, section string) {
+	real = []string{}
+	for _, label := range labels {
+		if isSectionLabel(label) {
+			section = strings.TrimSpace(label[len(sectionLabelPrefix):])
+			continue
+		}
+		if !slices.Contains(real, label) {
+			real = append(real, label)
+		}
+	}
+	return real, section
+}
+
+// findSection returns the section with the given name, compared
+// case-insensitively, or nil if the project has none.
+func findSection(name string, sections []*Section) *Section {
+	for _, section := range sections {
+		if strings.EqualFold(section.Name, name) {
+			return section
+		}
+	}
+	return nil
+}
+
+// dueFields returns the due date or due string to push for a task: its due
+// date if it has one, else its scheduled date as a due string.
+func dueFields(dueDate, scheduledDate *time.Time) (date, dueString string) {
+	if dueDate != nil {
+		return dueDate.Format(dateLayout), ""
+	}
+	if scheduledDate != nil {
+		return "", scheduledDate.Format(dateLayout)
+	}
+	return "", ""
+}
+
+// taskCreateToRequest converts a Todu TaskCreate to a Todoist
+// CreateTaskRequest in a project. The section label is left for the caller
+// to resolve to a section ID.
+func taskCreateToRequest(task *types.TaskCreate, projectID string, priorities *plugin.PriorityLabels) *CreateTaskRequest {
+	labels, _ := splitSectionLabel(task.Labels)
+	req := &CreateTaskRequest{
+		Content:   task.Title,
+		ProjectID: projectID,
+		Labels:    labels,
+	}
+
+	if task.Description != nil {
+		req.Description = *task.Description
+	}
+
+	if task.Priority != nil {
+		req.Priority = mapToduPriorityToTodoist(*task.Priority, priorities)
+	}
+
+	req.DueDate, req.DueString = dueFields(task.DueDate, task.ScheduledDate)
+
+	return req
+}
+
+// taskUpdateToRequest converts a Todu TaskUpdate to a Todoist
+// UpdateTaskRequest. Section labels are dropped, since a task's section
+// can't be changed through an update.
+func taskUpdateToRequest(task *types.TaskUpdate, priorities *plugin.PriorityLabels) *UpdateTaskRequest {
+	req := &UpdateTaskRequest{
+		Content:     task.Title,
+		Description: task.Description,
+	}
+
+	if task.Priority != nil {
+		priority := mapToduPriorityToTodoist(*task.Priority, priorities)
+		req.Priority = &priority
+	}
+
+	// Update labels when a full label set was provided
+	if len(task.Labels) > 0 {
+		labels, _ := splitSectionLabel(task.Labels)
+		req.Labels = &labels
+	}
+
+	if date, dueString := dueFields(task.DueDate, task.ScheduledDate); date != "" {
+		req.DueDate = &date
+	} else if dueString != "" {
+		req.DueString = &dueString
+	}
+
+	return req
+}
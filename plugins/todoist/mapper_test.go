@@ -0,0 +1,215 @@
+package todoist
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// TestMapTodoistPriorityToTodu tests that Todoist's inverted priorities map
+// to todu levels and back.
+func TestMapTodoistPriorityToTodu(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{priorityUrgent, "high"},
+		{priorityHigh, "medium"},
+		{priorityMedium, "low"},
+		{priorityNormal, ""},
+		{0, ""},
+	}
+
+	for _, tt := range tests {
+		got := ""
+		if level := mapTodoistPriorityToTodu(tt.priority, nil); level != nil {
+			got = *level
+		}
+		if got != tt.want {
+			t.Errorf("mapTodoistPriorityToTodu(%d) = %q, want %q", tt.priority, got, tt.want)
+		}
+		if tt.want != "" && mapToduPriorityToTodoist(tt.want, nil) != tt.priority {
+			t.Errorf("mapToduPriorityToTodoist(%q) = %d, want %d", tt.want, mapToduPriorityToTodoist(tt.want, nil), tt.priority)
+		}
+	}
+
+	if got := mapToduPriorityToTodoist("urgent", nil); got != priorityUrgent {
+		t.Errorf("mapToduPriorityToTodoist(urgent) = %d, want %d", got, priorityUrgent)
+	}
+	if got := mapToduPriorityToTodoist("none", nil); got != priorityNormal {
+		t.Errorf("mapToduPriorityToTodoist(none) = %d, want %d", got, priorityNormal)
+	}
+}
+
+// TestMapTodoistPriorityConfiguredLevels tests that the default mapping
+// picks from the configured levels.
+func TestMapTodoistPriorityConfiguredLevels(t *testing.T) {
+	t.Cleanup(func() { _ = types.SetPriorities(nil) })
+	if err := types.SetPriorities([]string{"minor", "normal", "major"}); err != nil {
+		t.Fatalf("SetPriorities failed: %v", err)
+	}
+
+	want := map[int]string{priorityUrgent: "major", priorityHigh: "normal", priorityMedium: "minor"}
+	for priority, level := range want {
+		if got := mapTodoistPriorityToTodu(priority, nil); got == nil || *got != level {
+			t.Errorf("mapTodoistPriorityToTodu(%d) = %v, want %s", priority, got, level)
+		}
+	}
+}
+
+// TestMapTodoistPriorityTable tests that a priority_labels table replaces
+// the default mapping in both directions.
+func TestMapTodoistPriorityTable(t *testing.T) {
+	t.Cleanup(func() { _ = types.SetPriorities(nil) })
+	if err := types.SetPriorities([]string{"none", "low", "medium", "high", "urgent"}); err != nil {
+		t.Fatalf("SetPriorities failed: %v", err)
+	}
+
+	priorities, err := parsePriorityTable("urgent=p1,high=P2,medium=p3")
+	if err != nil {
+		t.Fatalf("parsePriorityTable failed: %v", err)
+	}
+
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{priorityUrgent, "urgent"},
+		{priorityHigh, "high"},
+		{priorityMedium, "medium"},
+		{priorityNormal, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if level := mapTodoistPriorityToTodu(tt.priority, priorities); level != nil {
+			got = *level
+		}
+		if got != tt.want {
+			t.Errorf("mapTodoistPriorityToTodu(%d) = %q, want %q", tt.priority, got, tt.want)
+		}
+		if tt.want != "" && mapToduPriorityToTodoist(tt.want, priorities) != tt.priority {
+			t.Errorf("mapToduPriorityToTodoist(%q) = %d, want %d", tt.want, mapToduPriorityToTodoist(tt.want, priorities), tt.priority)
+		}
+	}
+
+	// Levels left out of the table push as p4
+	if got := mapToduPriorityToTodoist("low", priorities); got != priorityNormal {
+		t.Errorf("mapToduPriorityToTodoist(low) = %d, want %d", got, priorityNormal)
+	}
+
+	if _, err := parsePriorityTable("urgent=P0"); err == nil {
+		t.Error("Expected an error for a label that isn't p1–p4")
+	}
+}
+
+// TestTaskToTask tests the conversion of a Todoist task, including its
+// section label and due date.
+func TestTaskToTask(t *testing.T) {
+	sections := []*Section{{ID: "s1", ProjectID: "p1", Name: "Errands"}}
+	task := &Task{
+		ID:          "t1",
+		ProjectID:   "p1",
+		SectionID:   "s1",
+		Content:     "Buy milk",
+		Description: "2 liters",
+		IsCompleted: true,
+		Labels:      []string{"home", "section:stale"},
+		Priority:    priorityUrgent,
+		Due:         &Due{Date: "2025-03-08", String: "Mar 8"},
+		URL:         "https://app.todoist.com/app/task/t1",
+		CreatedAt:   "2025-03-01T10:00:00Z",
+	}
+
+	got := taskToTask(task, sections, nil)
+
+	if got.ExternalID != "t1" || got.Title != "Buy milk" || got.Status != "done" {
+		t.Errorf("task = %+v", got)
+	}
+	if got.Description == nil || *got.Description != "2 liters" {
+		t.Errorf("Description = %v, want 2 liters", got.Description)
+	}
+	if got.Priority == nil || *got.Priority != "high" {
+		t.Errorf("Priority = %v, want high", got.Priority)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DueDate = %v, want 2025-03-08", got.DueDate)
+	}
+	if got.SourceURL == nil || *got.SourceURL != task.URL {
+		t.Errorf("SourceURL = %v, want %s", got.SourceURL, task.URL)
+	}
+
+	var labels []string
+	for _, label := range got.Labels {
+		labels = append(labels, label.Name)
+	}
+	if want := []string{"home", "section:Errands"}; !slices.Equal(labels, want) {
+		t.Errorf("Labels = %v, want %v", labels, want)
+	}
+}
+
+// TestParseDue tests that timed due dates keep their time.
+func TestParseDue(t *testing.T) {
+	if got := parseDue(nil); got != nil {
+		t.Errorf("parseDue(nil) = %v, want nil", got)
+	}
+
+	got := parseDue(&Due{Date: "2025-03-08", Datetime: "2025-03-08T14:30:00Z"})
+	if got == nil || !got.Equal(time.Date(2025, 3, 8, 14, 30, 0, 0, time.UTC)) {
+		t.Errorf("parseDue(datetime) = %v, want 2025-03-08 14:30", got)
+	}
+}
+
+// TestTaskCreateToRequest tests that the section label is kept out of the
+// task's labels and a scheduled date becomes a due string.
+func TestTaskCreateToRequest(t *testing.T) {
+	priority := "medium"
+	scheduled := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	task := &types.TaskCreate{
+		Title:         "Buy milk",
+		Priority:      &priority,
+		ScheduledDate: &scheduled,
+		Labels:        []string{"home", "section:Errands"},
+	}
+
+	req := taskCreateToRequest(task, "p1", nil)
+
+	if req.Content != "Buy milk" || req.ProjectID != "p1" || req.Priority != priorityHigh {
+		t.Errorf("request = %+v", req)
+	}
+	if !slices.Equal(req.Labels, []string{"home"}) {
+		t.Errorf("Labels = %v, want [home]", req.Labels)
+	}
+	if req.DueDate != "" || req.DueString != "2025-03-10" {
+		t.Errorf("DueDate = %q, DueString = %q, want due string 2025-03-10", req.DueDate, req.DueString)
+	}
+
+	due := time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC)
+	task.DueDate = &due
+	req = taskCreateToRequest(task, "p1", nil)
+	if req.DueDate != "2025-03-12" || req.DueString != "" {
+		t.Errorf("DueDate = %q, DueString = %q, want due date 2025-03-12", req.DueDate, req.DueString)
+	}
+}
+
+// TestTaskUpdateToRequest tests that a label set of only a section label
+// clears the task's labels, and unset fields are left alone.
+func TestTaskUpdateToRequest(t *testing.T) {
+	req := taskUpdateToRequest(&types.TaskUpdate{Labels: []string{"section:Errands"}}, nil)
+	if req.Labels == nil || len(*req.Labels) != 0 {
+		t.Errorf("Labels = %v, want empty", req.Labels)
+	}
+	if req.Content != nil || req.Priority != nil || req.DueDate != nil || req.DueString != nil {
+		t.Errorf("request = %+v, want only labels", req)
+	}
+
+	scheduled := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	req = taskUpdateToRequest(&types.TaskUpdate{ScheduledDate: &scheduled}, nil)
+	if req.Labels != nil {
+		t.Errorf("Labels = %v, want unchanged", *req.Labels)
+	}
+	if req.DueString == nil || *req.DueString != "2025-03-10" {
+		t.Errorf("DueString = %v, want 2025-03-10", req.DueString)
+	}
+}
@@ -0,0 +1,341 @@
+// Package todoist provides a plugin for syncing tasks with Todoist.
+//
+// Todoist projects are projects. A task's section is carried by a
+// "section:<name>" label, and the filter setting limits which tasks are
+// pulled to those matching a Todoist filter query.
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcraddock/todu.sh/internal/registry"
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// Plugin implements the plugin.Plugin interface for Todoist.
+type Plugin struct {
+	client     *client
+	config     map[string]string
+	priorities *plugin.PriorityLabels
+}
+
+// init registers the Todoist plugin with the global registry.
+func init() {
+	registry.Register("todoist", func() plugin.Plugin {
+		return &Plugin{}
+	})
+}
+
+// Name returns the unique identifier for this plugin.
+func (p *Plugin) Name() string {
+	return "todoist"
+}
+
+// Version returns the version of this plugin implementation.
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// Configure provides configuration to the plugin.
+// Required configuration keys:
+//   - token: Todoist API token
+//
+// Optional configuration keys:
+//   - url: Todoist API base URL (default "https://api.todoist.com")
+//   - filter: Todoist filter query, such as "today | overdue", that limits
+//     which tasks are pulled
+//   - priority_labels: level=p1–p4 pairs, such as "urgent=p1,high=p2",
+//     replacing the default priority mapping
+func (p *Plugin) Configure(config map[string]string) error {
+	p.config = config
+
+	// Validate required configuration
+	if err := p.ValidateConfig(); err != nil {
+		return err
+	}
+
+	var err error
+	p.priorities, err = parsePriorityTable(config["priority_labels"])
+	if err != nil {
+		return fmt.Errorf("invalid priority_labels: %w", err)
+	}
+
+	// Create Todoist API client
+	p.client, err = newClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to create Todoist client: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks that the plugin has been properly configured.
+func (p *Plugin) ValidateConfig() error {
+	if p.config == nil {
+		return plugin.ErrNotConfigured
+	}
+
+	// Check required fields
+	if p.config["token"] == "" {
+		return fmt.Errorf("%w: missing required field 'token'", plugin.ErrNotConfigured)
+	}
+
+	return nil
+}
+
+// TasksUntimed reports that Todoist tasks' UpdatedAt is their creation
+// time, since the API doesn't report when a task last changed.
+func (p *Plugin) TasksUntimed() bool {
+	return true
+}
+
+// FetchProjects retrieves every project of the user.
+func (p *Plugin) FetchProjects(ctx context.Context) ([]*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	projects, err := p.client.listProjects(ctx)
+	if err != nil {
+		return nil, handleTodoistError(err, "failed to list projects")
+	}
+
+	result := make([]*types.Project, len(projects))
+	for i, project := range projects {
+		result[i] = projectToProject(project)
+	}
+
+	return result, nil
+}
+
+// FetchProject retrieves a single project by its ID.
+func (p *Plugin) FetchProject(ctx context.Context, externalID string) (*types.Project, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	project, err := p.client.getProject(ctx, strings.TrimSpace(externalID))
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to fetch project %s", externalID))
+	}
+
+	return projectToProject(project), nil
+}
+
+// FetchTasks retrieves the active tasks in a project, or in every project
+// when projectExternalID is nil. When the filter setting is set, only tasks
+// matching it are returned. Todoist doesn't report when tasks change, so
+// since is ignored.
+func (p *Plugin) FetchTasks(ctx context.Context, projectExternalID *string, since *time.Time) ([]*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	projectID := ""
+	if projectExternalID != nil {
+		projectID = strings.TrimSpace(*projectExternalID)
+	}
+
+	tasks, err := p.client.listTasks(ctx, projectID, strings.TrimSpace(p.config["filter"]))
+	if err != nil {
+		return nil, handleTodoistError(err, "failed to list tasks")
+	}
+
+	result := make([]*types.Task, len(tasks))
+	for i, task := range tasks {
+		sections, err := p.sections(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = taskToTask(task, sections, p.priorities)
+	}
+
+	return result, nil
+}
+
+// FetchTask retrieves a single task by its ID.
+func (p *Plugin) FetchTask(ctx context.Context, projectExternalID *string, taskExternalID string) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	task, err := p.client.getTask(ctx, taskExternalID)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to fetch task %s", taskExternalID))
+	}
+
+	sections, err := p.sections(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return taskToTask(task, sections, p.priorities), nil
+}
+
+// CreateTask creates a new task in a project. A "section:<name>" label
+// puts it in that section, which is created if the project doesn't have it
+// yet. A completed todu task is closed right after it is created.
+func (p *Plugin) CreateTask(ctx context.Context, projectExternalID *string, task *types.TaskCreate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	if projectExternalID == nil {
+		return nil, fmt.Errorf("projectExternalID is required for Todoist")
+	}
+	projectID := strings.TrimSpace(*projectExternalID)
+
+	req := taskCreateToRequest(task, projectID, p.priorities)
+
+	var sections []*Section
+	if _, name := splitSectionLabel(task.Labels); name != "" {
+		var err error
+		sections, err = p.client.listSections(ctx, projectID)
+		if err != nil {
+			return nil, handleTodoistError(err, fmt.Sprintf("failed to list sections of project %s", projectID))
+		}
+		section := findSection(name, sections)
+		if section == nil {
+			section, err = p.client.createSection(ctx, projectID, name)
+			if err != nil {
+				return nil, handleTodoistError(err, fmt.Sprintf("failed to create section %q in project %s", name, projectID))
+			}
+			sections = append(sections, section)
+		}
+		req.SectionID = section.ID
+	}
+
+	created, err := p.client.createTask(ctx, req)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to create task in project %s", projectID))
+	}
+
+	if task.Status == "done" || task.Status == "canceled" {
+		if err := p.client.closeTask(ctx, created.ID); err != nil {
+			return nil, handleTodoistError(err, fmt.Sprintf("failed to close task %s", created.ID))
+		}
+		created.IsCompleted = true
+	}
+
+	return taskToTask(created, sections, p.priorities), nil
+}
+
+// UpdateTask updates an existing task. Completion uses Todoist's separate
+// close and reopen endpoints; "done" and "canceled" close the task and
+// every other status reopens it.
+func (p *Plugin) UpdateTask(ctx context.Context, projectExternalID *string, taskExternalID string, task *types.TaskUpdate) (*types.Task, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	updated, err := p.client.updateTask(ctx, taskExternalID, taskUpdateToRequest(task, p.priorities))
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to update task %s", taskExternalID))
+	}
+
+	if task.Status != nil {
+		closed := *task.Status == "done" || *task.Status == "canceled"
+		if closed && !updated.IsCompleted {
+			if err := p.client.closeTask(ctx, taskExternalID); err != nil {
+				return nil, handleTodoistError(err, fmt.Sprintf("failed to close task %s", taskExternalID))
+			}
+		} else if !closed && updated.IsCompleted {
+			if err := p.client.reopenTask(ctx, taskExternalID); err != nil {
+				return nil, handleTodoistError(err, fmt.Sprintf("failed to reopen task %s", taskExternalID))
+			}
+		}
+		updated.IsCompleted = closed
+	}
+
+	sections, err := p.sections(ctx, updated)
+	if err != nil {
+		return nil, err
+	}
+	return taskToTask(updated, sections, p.priorities), nil
+}
+
+// FetchComments retrieves all comments for a task, oldest first.
+func (p *Plugin) FetchComments(ctx context.Context, projectExternalID *string, taskExternalID string) ([]*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	comments, err := p.client.listComments(ctx, taskExternalID)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to list comments for task %s", taskExternalID))
+	}
+
+	result := make([]*types.Comment, len(comments))
+	for i, comment := range comments {
+		result[i] = commentToComment(comment)
+	}
+
+	return result, nil
+}
+
+// CreateComment creates a new comment on a task.
+func (p *Plugin) CreateComment(ctx context.Context, projectExternalID *string, taskExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	created, err := p.client.createComment(ctx, taskExternalID, comment.Content)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to create comment on task %s", taskExternalID))
+	}
+
+	return commentToComment(created), nil
+}
+
+// UpdateComment replaces the text of an existing comment.
+func (p *Plugin) UpdateComment(ctx context.Context, projectExternalID *string, taskExternalID, commentExternalID string, comment *types.CommentCreate) (*types.Comment, error) {
+	if p.client == nil {
+		return nil, plugin.ErrNotConfigured
+	}
+
+	updated, err := p.client.updateComment(ctx, commentExternalID, comment.Content)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to update comment %s on task %s", commentExternalID, taskExternalID))
+	}
+
+	return commentToComment(updated), nil
+}
+
+// sections returns the sections of a task's project, or nil if the task
+// isn't in a section.
+func (p *Plugin) sections(ctx context.Context, task *Task) ([]*Section, error) {
+	if task.SectionID == "" || task.ProjectID == "" {
+		return nil, nil
+	}
+
+	sections, err := p.client.listSections(ctx, task.ProjectID)
+	if err != nil {
+		return nil, handleTodoistError(err, fmt.Sprintf("failed to list sections of project %s", task.ProjectID))
+	}
+	return sections, nil
+}
+
+// handleTodoistError converts Todoist API errors to plugin errors.
+func handleTodoistError(err error, context string) error {
+	if err == nil {
+		return nil
+	}
+
+	errMsg := err.Error()
+
+	// Check for 404 Not Found
+	if strings.Contains(errMsg, "API error 404") {
+		return plugin.NewErrNotFound(context)
+	}
+
+	// Check for 401/403 Unauthorized/Forbidden
+	if strings.Contains(errMsg, "API error 401") || strings.Contains(errMsg, "API error 403") {
+		return plugin.NewErrUnauthorized(context)
+	}
+
+	// Return generic error with context
+	return fmt.Errorf("%s: %w", context, err)
+}
@@ -0,0 +1,216 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/evcraddock/todu.sh/pkg/plugin"
+	"github.com/evcraddock/todu.sh/pkg/plugin/plugintest"
+	"github.com/evcraddock/todu.sh/pkg/types"
+)
+
+// newTestPlugin configures a plugin against a test server.
+func newTestPlugin(t *testing.T, mux *http.ServeMux, config map[string]string) *Plugin {
+	t.Helper()
+
+	cfg := map[string]string{"token": "test-token"}
+	for key, value := range config {
+		cfg[key] = value
+	}
+
+	p := &Plugin{}
+	plugintest.Serve(t, p, mux, "", cfg)
+	return p
+}
+
+func TestConfigure_RequiresToken(t *testing.T) {
+	p := &Plugin{}
+	if err := p.Configure(map[string]string{}); !errors.Is(err, plugin.ErrNotConfigured) {
+		t.Errorf("Configure() error = %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestConfigure_PriorityLabels(t *testing.T) {
+	p := &Plugin{}
+	err := p.Configure(map[string]string{"token": "test-token", "priority_labels": "high=urgent"})
+	if err == nil {
+		t.Error("Configure() succeeded, want an error for a label that isn't p1–p4")
+	}
+}
+
+// TestTasksUntimed tests that the sync engine is told to find Todoist
+// edits by snapshot, since tasks report only when they were created.
+func TestTasksUntimed(t *testing.T) {
+	var p plugin.Plugin = &Plugin{}
+	if untimed, ok := p.(plugin.UntimedTasks); !ok || !untimed.TasksUntimed() {
+		t.Error("Expected Todoist tasks to be untimed")
+	}
+}
+
+// TestFetchTasks tests that a project's tasks carry their section labels.
+func TestFetchTasks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got := r.URL.Query().Get("project_id"); got != "p1" {
+			t.Errorf("project_id = %q, want p1", got)
+		}
+		w.Write([]byte(`[
+			{"id": "t1", "project_id": "p1", "section_id": "s1", "content": "Buy milk", "priority": 4, "labels": ["home"]},
+			{"id": "t2", "project_id": "p1", "content": "Call mom", "priority": 1}
+		]`))
+	})
+	mux.HandleFunc("GET /rest/v2/sections", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "s1", "project_id": "p1", "name": "Errands"}]`))
+	})
+
+	p := newTestPlugin(t, mux, nil)
+	projectID := "p1"
+	tasks, err := p.FetchTasks(context.Background(), &projectID, nil)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	if len(tasks[0].Labels) != 2 || tasks[0].Labels[1].Name != "section:Errands" {
+		t.Errorf("Labels = %v, want home and section:Errands", tasks[0].Labels)
+	}
+	if len(tasks[1].Labels) != 0 {
+		t.Errorf("Labels = %v, want none", tasks[1].Labels)
+	}
+}
+
+// TestFetchTasksFilter tests that the filter setting selects tasks, and
+// matches from other projects are dropped.
+func TestFetchTasksFilter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != "today | overdue" {
+			t.Errorf("filter = %q, want today | overdue", got)
+		}
+		w.Write([]byte(`[
+			{"id": "t1", "project_id": "p1", "content": "Buy milk"},
+			{"id": "t3", "project_id": "p2", "content": "Ship release"}
+		]`))
+	})
+
+	p := newTestPlugin(t, mux, map[string]string{"filter": "today | overdue"})
+	projectID := "p1"
+	tasks, err := p.FetchTasks(context.Background(), &projectID, nil)
+	if err != nil {
+		t.Fatalf("FetchTasks failed: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ExternalID != "t1" {
+		t.Errorf("tasks = %v, want only t1", tasks)
+	}
+}
+
+// TestCreateTaskInSection tests that a section label puts a new task in
+// that section, creating it when the project doesn't have it.
+func TestCreateTaskInSection(t *testing.T) {
+	var created CreateTaskRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/sections", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "s1", "project_id": "p1", "name": "Errands"}]`))
+	})
+	mux.HandleFunc("POST /rest/v2/sections", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Later" || body["project_id"] != "p1" {
+			t.Errorf("section = %v, want Later in p1", body)
+		}
+		w.Write([]byte(`{"id": "s2", "project_id": "p1", "name": "Later"}`))
+	})
+	mux.HandleFunc("POST /rest/v2/tasks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		task := Task{ID: "t9", ProjectID: created.ProjectID, SectionID: created.SectionID, Content: created.Content, Labels: created.Labels}
+		json.NewEncoder(w).Encode(task)
+	})
+
+	p := newTestPlugin(t, mux, nil)
+	projectID := "p1"
+
+	task, err := p.CreateTask(context.Background(), &projectID, &types.TaskCreate{Title: "Buy milk", Labels: []string{"home", "section:errands"}})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if created.SectionID != "s1" {
+		t.Errorf("SectionID = %q, want s1", created.SectionID)
+	}
+	if len(task.Labels) != 2 || task.Labels[1].Name != "section:Errands" {
+		t.Errorf("Labels = %v, want home and section:Errands", task.Labels)
+	}
+
+	if _, err := p.CreateTask(context.Background(), &projectID, &types.TaskCreate{Title: "Paint fence", Labels: []string{"section:Later"}}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if created.SectionID != "s2" {
+		t.Errorf("SectionID = %q, want s2", created.SectionID)
+	}
+}
+
+// TestUpdateTaskStatus tests that completion goes through the close and
+// reopen endpoints.
+func TestUpdateTaskStatus(t *testing.T) {
+	completed := false
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/v2/tasks/t1", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "update")
+		json.NewEncoder(w).Encode(Task{ID: "t1", ProjectID: "p1", Content: "Buy milk", IsCompleted: completed})
+	})
+	mux.HandleFunc("POST /rest/v2/tasks/t1/close", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "close")
+		completed = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /rest/v2/tasks/t1/reopen", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "reopen")
+		completed = false
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	p := newTestPlugin(t, mux, nil)
+	for _, status := range []string{"done", "done", "active"} {
+		task, err := p.UpdateTask(context.Background(), nil, "t1", &types.TaskUpdate{Status: &status})
+		if err != nil {
+			t.Fatalf("UpdateTask(%s) failed: %v", status, err)
+		}
+		if task.Status != status {
+			t.Errorf("Status = %q, want %q", task.Status, status)
+		}
+	}
+
+	want := []string{"update", "close", "update", "update", "reopen"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("calls = %v, want %v", calls, want)
+			break
+		}
+	}
+}
+
+// TestFetchTaskNotFound tests that a missing task is reported as not found.
+func TestFetchTaskNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/tasks/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Task not found", http.StatusNotFound)
+	})
+
+	p := newTestPlugin(t, mux, nil)
+	if _, err := p.FetchTask(context.Background(), nil, "missing"); !errors.Is(err, plugin.ErrNotFound) {
+		t.Errorf("FetchTask() error = %v, want ErrNotFound", err)
+	}
+}